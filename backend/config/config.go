@@ -9,11 +9,21 @@ import (
 )
 
 type Config struct {
-	AppConfig       AppConfig       `env:"APPCONFIG"`
-	DBConfig        DBConfig
-	OIDCConfig      OIDCConfig      `env:"OIDC"`
-	EmailConfig     EmailConfig     `env:"EMAIL"`
-	TypesenseConfig TypesenseConfig `env:"TYPESENSE"`
+	AppConfig        AppConfig       `env:"APPCONFIG"`
+	ServerConfig     ServerConfig    `env:"SERVER"`
+	RateLimitConfig  RateLimitConfig `env:"RATE_LIMIT"`
+	DBConfig         DBConfig
+	OIDCConfig       OIDCConfig       `env:"OIDC"`
+	EmailConfig      EmailConfig      `env:"EMAIL"`
+	TypesenseConfig  TypesenseConfig  `env:"TYPESENSE"`
+	EmbeddingsConfig EmbeddingsConfig `env:"EMBEDDINGS"`
+	LLMConfig        LLMConfig        `env:"LLM"`
+	ContentConfig    ContentConfig    `env:"CONTENT"`
+	MembershipConfig MembershipConfig `env:"MEMBERSHIP"`
+	WarehouseConfig  WarehouseConfig  `env:"WAREHOUSE"`
+	BackupConfig     BackupConfig     `env:"BACKUP"`
+	StorageConfig    StorageConfig    `env:"STORAGE"`
+	InstanceConfig   InstanceConfig   `env:"INSTANCE"`
 }
 
 type OIDCConfig struct {
@@ -23,15 +33,25 @@ type OIDCConfig struct {
 	Providers              []OIDCProvider `env:"-"` // Loaded separately from OIDC_PROVIDERS env var
 }
 
+// OIDC provider types
+const (
+	// OIDCProviderTypeOIDC is an OpenID Connect provider, e.g. Google, Okta or Dex
+	OIDCProviderTypeOIDC = "oidc"
+	// OIDCProviderTypeGitHub is GitHub or GitHub Enterprise, which only speak OAuth2 and are
+	// asked for the user's profile through their API
+	OIDCProviderTypeGitHub = "github"
+)
+
 // OIDCProvider represents an OIDC provider configuration
 type OIDCProvider struct {
 	Name         string `json:"name"`          // Display name (e.g., "Google", "Okta")
 	Slug         string `json:"slug"`          // URL-safe identifier (e.g., "google", "okta")
-	IssuerURL    string `json:"issuer_url"`    // OIDC issuer URL
+	Type         string `json:"type"`          // OIDCProviderTypeOIDC (default) or OIDCProviderTypeGitHub
+	IssuerURL    string `json:"issuer_url"`    // OIDC issuer URL; for GitHub the web URL, defaults to https://github.com
 	DiscoveryURL string `json:"discovery_url"` // Optional: different URL for discovery (Docker networking)
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
-	Scopes       string `json:"scopes"` // Space-separated scopes, defaults to "openid email profile"
+	Scopes       string `json:"scopes"` // Space-separated scopes, defaults to "openid email profile" ("read:user user:email" for GitHub)
 }
 
 type AppConfig struct {
@@ -40,12 +60,45 @@ type AppConfig struct {
 	Version                      string `default:"x.x.x" env:"VERSION"`
 	Env                          string `default:"development" env:"ENV"`
 	JWTSecret                    string `env:"JWT_SECRET" default:"dev-secret-change-in-production"`
-	JWTExpirationHours           int    `env:"JWT_EXPIRATION_HOURS" default:"24"`          // Deprecated: use AccessTokenExpirationMinutes
-	AccessTokenExpirationMinutes int    `env:"JWT_ACCESS_EXPIRATION_MINUTES" default:"5"`  // Access token expiry (short-lived)
-	RefreshTokenExpirationDays   int    `env:"JWT_REFRESH_EXPIRATION_DAYS" default:"7"`    // Refresh token expiry
+	JWTExpirationHours           int    `env:"JWT_EXPIRATION_HOURS" default:"24"`                                  // Deprecated: use AccessTokenExpirationMinutes
+	AccessTokenExpirationMinutes int    `env:"JWT_ACCESS_EXPIRATION_MINUTES" default:"5"`                          // Access token expiry (short-lived)
+	RefreshTokenExpirationDays   int    `env:"JWT_REFRESH_EXPIRATION_DAYS" default:"7"`                            // Refresh token expiry
 	CORSOrigins                  string `env:"CORS_ORIGINS" default:"http://localhost:4321,http://localhost:3000"` // Comma-separated allowed origins
-	CookieDomain                 string `env:"COOKIE_DOMAIN" default:""`                   // Cookie domain (empty = current domain only)
-	CookieSecure                 bool   `env:"COOKIE_SECURE" default:"false"`              // Use Secure flag on cookies (requires HTTPS)
+	CookieDomain                 string `env:"COOKIE_DOMAIN" default:""`                                           // Cookie domain (empty = current domain only)
+	CookieSecure                 bool   `env:"COOKIE_SECURE" default:"false"`                                      // Use Secure flag on cookies (requires HTTPS)
+	WebSocketKeepAliveSeconds    int    `env:"WS_KEEPALIVE_SECONDS" default:"15"`                                  // Interval between GraphQL websocket keepalive messages
+	WebSocketMaxConnsPerUser     int    `env:"WS_MAX_CONNECTIONS_PER_USER" default:"10"`                           // Open GraphQL websocket connections allowed per user
+	TrustedProxies               string `env:"TRUSTED_PROXIES" default:"127.0.0.0/8,::1"`                          // Comma-separated CIDRs or IPs of reverse proxies whose X-Forwarded-For is believed
+}
+
+// ServerConfig tunes the HTTP server. GraphQL subscriptions (websockets and server-sent
+// events) are exempt from the read, write and request timeouts, so these can stay short for
+// queries and mutations.
+type ServerConfig struct {
+	ReadHeaderTimeoutSeconds  int  `env:"SERVER_READ_HEADER_TIMEOUT_SECONDS" default:"10"`   // Time to read a request's headers
+	ReadTimeoutSeconds        int  `env:"SERVER_READ_TIMEOUT_SECONDS" default:"60"`          // Time to read a whole request, body included; 0 for no limit
+	WriteTimeoutSeconds       int  `env:"SERVER_WRITE_TIMEOUT_SECONDS" default:"60"`         // Time from the end of the request headers to the end of the response; 0 for no limit
+	IdleTimeoutSeconds        int  `env:"SERVER_IDLE_TIMEOUT_SECONDS" default:"120"`         // How long a keep-alive connection waits for its next request
+	RequestTimeoutSeconds     int  `env:"SERVER_REQUEST_TIMEOUT_SECONDS" default:"30"`       // Deadline for handling a query or mutation, database calls included; 0 for none
+	MaxHeaderBytes            int  `env:"SERVER_MAX_HEADER_BYTES" default:"1048576"`         // Largest request headers accepted
+	DisableKeepAlives         bool `env:"SERVER_DISABLE_KEEP_ALIVES" default:"false"`        // Close each connection after one request
+	H2C                       bool `env:"SERVER_H2C" default:"false"`                        // Also serve HTTP/2 without TLS (prior knowledge h2c), for proxies that speak it to the backend
+	HTTP2MaxConcurrentStreams int  `env:"SERVER_HTTP2_MAX_CONCURRENT_STREAMS" default:"250"` // Requests one HTTP/2 connection may have in flight
+}
+
+// RateLimitConfig limits GraphQL requests with token buckets: each request takes a token
+// from its bucket, which refills at its per-minute rate up to its burst. Requests are
+// counted per user, or per client IP when anonymous, and login and register attempts also
+// per client IP against the stricter auth bucket.
+type RateLimitConfig struct {
+	Disabled      bool   `env:"RATE_LIMIT_DISABLED" default:"false"`
+	RedisURL      string `env:"RATE_LIMIT_REDIS_URL" default:""`          // Redis holding the buckets for all API instances, e.g. redis://localhost:6379/0; each instance keeps its own when empty or unreachable
+	UserPerMinute int    `env:"RATE_LIMIT_USER_PER_MINUTE" default:"600"` // Requests a signed-in user (or API token) may send a minute
+	UserBurst     int    `env:"RATE_LIMIT_USER_BURST" default:"120"`      // Requests a signed-in user may send at once
+	IPPerMinute   int    `env:"RATE_LIMIT_IP_PER_MINUTE" default:"120"`   // Anonymous requests a client IP may send a minute
+	IPBurst       int    `env:"RATE_LIMIT_IP_BURST" default:"60"`         // Anonymous requests a client IP may send at once
+	AuthPerMinute int    `env:"RATE_LIMIT_AUTH_PER_MINUTE" default:"1"`   // Login and register attempts a client IP may make a minute
+	AuthBurst     int    `env:"RATE_LIMIT_AUTH_BURST" default:"10"`       // Login and register attempts a client IP may make at once
 }
 
 type DBConfig struct {
@@ -65,8 +118,94 @@ type EmailConfig struct {
 	FromEmail       string `env:"EMAIL_FROM" default:"noreply@kaimu.local"`
 	FromName        string `env:"EMAIL_FROM_NAME" default:"Kaimu"`
 	SSLType         string `env:"EMAIL_SSL_TYPE" default:"none"` // none, tls, ssl
+	Provider        string `env:"EMAIL_PROVIDER" default:"smtp"` // smtp, sendgrid or postmark
+	APIKey          string `env:"EMAIL_API_KEY"`                 // API key (Postmark: server token) of the sendgrid and postmark providers
+	APIURL          string `env:"EMAIL_API_URL"`                 // Base URL of the provider's API; empty uses the provider's own, e.g. set it for a regional endpoint
+	TemplateDir     string `env:"EMAIL_TEMPLATE_DIR"`            // Directory of .mjml templates that replace the built-in ones of the same name
 	VerificationURL string `env:"EMAIL_VERIFICATION_URL" default:"http://localhost:4321/verify"`
 	InvitationURL   string `env:"EMAIL_INVITATION_URL" default:"http://localhost:4321/invite"`
+	SPFInclude      string `env:"EMAIL_SPF_INCLUDE" default:"_spf.kaimu.local"` // SPF include organizations add to send from their own domain
+	DKIMSelector    string `env:"EMAIL_DKIM_SELECTOR" default:"kaimu"`          // Selector of the DKIM key organizations publish for their domain
+	DKIMPublicKey   string `env:"EMAIL_DKIM_PUBLIC_KEY"`                        // Base64 public key of the signing key; without it any DKIM record passes
+}
+
+// ContentConfig holds the limits and moderation scanners applied to user-written text
+type ContentConfig struct {
+	MaxTitleLength        int    `env:"CONTENT_MAX_TITLE_LENGTH" default:"500"`          // Card title length limit, in characters
+	MaxDescriptionLength  int    `env:"CONTENT_MAX_DESCRIPTION_LENGTH" default:"100000"` // Card description length limit, in characters of stored HTML
+	MaxCommentLength      int    `env:"CONTENT_MAX_COMMENT_LENGTH" default:"10000"`      // Comment length limit, in characters
+	PIIScanner            bool   `env:"CONTENT_PII_SCANNER" default:"true"`              // Offer the PII scanner to organizations that enable moderation
+	BlockedWords          string `env:"CONTENT_BLOCKED_WORDS" default:""`                // Comma-separated words rejected for organizations that enable moderation
+	MaxIdenticalPerMinute int    `env:"CONTENT_MAX_IDENTICAL_PER_MINUTE" default:"5"`    // Identical cards or comments a user may submit to a board per minute, 0 to allow any
+}
+
+// GetBlockedWords returns the blocked words as a slice
+func (c *ContentConfig) GetBlockedWords() []string {
+	var words []string
+	for _, w := range strings.Split(c.BlockedWords, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// InstanceConfig holds who administers the instance itself
+type InstanceConfig struct {
+	AdminEmails string `env:"INSTANCE_ADMIN_EMAILS" default:""` // Comma-separated verified emails of the platform admins, who manage the instance settings
+}
+
+// GetAdminEmails returns the platform admins' emails, lowercased
+func (c *InstanceConfig) GetAdminEmails() []string {
+	var emails []string
+	for _, e := range strings.Split(c.AdminEmails, ",") {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			emails = append(emails, e)
+		}
+	}
+	return emails
+}
+
+// MembershipConfig holds the plan limits on who can join an organization
+type MembershipConfig struct {
+	MaxGuestsPerOrg int `env:"MAX_GUESTS_PER_ORG" default:"10"` // Guests plus pending guest invitations per organization, 0 for no limit
+}
+
+// WarehouseConfig configures the optional sync of card, sprint and audit aggregates to a
+// data warehouse. The sync is off unless a provider is set.
+type WarehouseConfig struct {
+	Provider                string `env:"WAREHOUSE_PROVIDER" default:""`               // bigquery or snowflake; empty disables the sync
+	IntervalMinutes         int    `env:"WAREHOUSE_INTERVAL_MINUTES" default:"60"`     // Time between syncs
+	BatchSize               int    `env:"WAREHOUSE_BATCH_SIZE" default:"500"`          // Rows sent per insert
+	TablePrefix             string `env:"WAREHOUSE_TABLE_PREFIX" default:"kaimu_"`     // Prepended to the cards, sprints and audit_daily table names
+	BigQueryProject         string `env:"WAREHOUSE_BIGQUERY_PROJECT"`                  // Google Cloud project holding the dataset
+	BigQueryDataset         string `env:"WAREHOUSE_BIGQUERY_DATASET"`                  // Dataset holding the tables
+	BigQueryCredentialsFile string `env:"WAREHOUSE_BIGQUERY_CREDENTIALS_FILE"`         // Service account key (JSON) allowed to insert into the dataset
+	SnowflakeAccount        string `env:"WAREHOUSE_SNOWFLAKE_ACCOUNT"`                 // Account identifier, e.g. myorg-myaccount
+	SnowflakeUser           string `env:"WAREHOUSE_SNOWFLAKE_USER"`                    // User the key pair is registered for
+	SnowflakePrivateKeyFile string `env:"WAREHOUSE_SNOWFLAKE_PRIVATE_KEY_FILE"`        // PKCS#8 PEM RSA private key of the user
+	SnowflakeDatabase       string `env:"WAREHOUSE_SNOWFLAKE_DATABASE"`                // Database holding the tables
+	SnowflakeSchema         string `env:"WAREHOUSE_SNOWFLAKE_SCHEMA" default:"PUBLIC"` // Schema holding the tables
+	SnowflakeWarehouse      string `env:"WAREHOUSE_SNOWFLAKE_WAREHOUSE"`               // Virtual warehouse running the inserts
+}
+
+// BackupConfig configures where organization and instance backups are stored
+type BackupConfig struct {
+	Dir string `env:"BACKUP_DIR" default:"backups"` // Directory backups are written to; mount durable storage here
+}
+
+// StorageConfig configures the S3-compatible object store card attachments are kept in.
+// Attachments are unavailable unless an endpoint is set.
+type StorageConfig struct {
+	Endpoint             string `env:"STORAGE_ENDPOINT" default:""`                     // e.g. https://s3.eu-west-1.amazonaws.com or http://localhost:9000 for MinIO; empty disables attachments
+	Region               string `env:"STORAGE_REGION" default:"us-east-1"`              // Region requests are signed for
+	Bucket               string `env:"STORAGE_BUCKET" default:""`                       // Bucket holding the attachments
+	AccessKeyID          string `env:"STORAGE_ACCESS_KEY_ID"`                           // Access key allowed to read, write and delete objects in the bucket
+	SecretAccessKey      string `env:"STORAGE_SECRET_ACCESS_KEY"`                       // Secret of the access key
+	PathStyle            bool   `env:"STORAGE_PATH_STYLE" default:"true"`               // Address the bucket in the path (MinIO) rather than the host name (AWS)
+	PresignExpiryMinutes int    `env:"STORAGE_PRESIGN_EXPIRY_MINUTES" default:"15"`     // How long upload and download URLs stay valid
+	MaxAttachmentBytes   int64  `env:"STORAGE_MAX_ATTACHMENT_BYTES" default:"26214400"` // Largest file an organization may attach, unless it sets its own limit
+	OrgQuotaBytes        int64  `env:"STORAGE_ORG_QUOTA_BYTES" default:"0"`             // Total attachment size per organization, unless it sets its own; 0 for no limit
 }
 
 type TypesenseConfig struct {
@@ -75,6 +214,26 @@ type TypesenseConfig struct {
 	APIKey string `env:"TYPESENSE_API_KEY" default:"dev_api_key"`
 }
 
+// EmbeddingsConfig configures the optional embeddings provider behind semantic search. Cards
+// are only embedded when a provider is set.
+type EmbeddingsConfig struct {
+	Provider   string `env:"EMBEDDINGS_PROVIDER" default:""`                     // openai; empty disables embeddings
+	URL        string `env:"EMBEDDINGS_URL" default:"https://api.openai.com/v1"` // Base URL of an OpenAI-compatible API, e.g. http://localhost:11434/v1 for Ollama
+	APIKey     string `env:"EMBEDDINGS_API_KEY"`                                 // Sent as a bearer token; may be empty for local servers
+	Model      string `env:"EMBEDDINGS_MODEL" default:"text-embedding-3-small"`  // Embedding model
+	Dimensions int    `env:"EMBEDDINGS_DIMENSIONS" default:"1536"`               // Length of the model's vectors
+}
+
+// LLMConfig configures the optional language model behind AI-assisted features such as card
+// drafting. They are unavailable unless a provider is set.
+type LLMConfig struct {
+	Provider  string `env:"LLM_PROVIDER" default:""`       // openai or anthropic; empty disables AI features
+	URL       string `env:"LLM_URL" default:""`            // Base URL of the API; empty uses the provider's, set it for OpenAI-compatible servers such as Ollama
+	APIKey    string `env:"LLM_API_KEY"`                   // Provider API key
+	Model     string `env:"LLM_MODEL"`                     // Model name, e.g. gpt-4o-mini or claude-3-5-haiku-latest
+	MaxTokens int    `env:"LLM_MAX_TOKENS" default:"1024"` // Longest completion requested
+}
+
 func LoadConfigOrPanic() Config {
 	var config = Config{}
 	configor.Load(&config, "config/config.dev.json")
@@ -98,6 +257,17 @@ func (c *AppConfig) GetCORSOrigins() []string {
 	return origins
 }
 
+// GetTrustedProxies returns the CIDRs and IPs of the trusted reverse proxies as a slice
+func (c *AppConfig) GetTrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(c.TrustedProxies, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
 // loadOIDCProviders loads OIDC provider configurations from the OIDC_PROVIDERS environment variable.
 // The variable should be a JSON array of provider objects.
 //
@@ -109,7 +279,8 @@ func (c *AppConfig) GetCORSOrigins() []string {
 //
 //	OIDC_PROVIDERS='[
 //	  {"name":"Google","slug":"google","issuer_url":"https://accounts.google.com","client_id":"...","client_secret":"..."},
-//	  {"name":"Okta","slug":"okta","issuer_url":"https://dev-123.okta.com","client_id":"...","client_secret":"..."}
+//	  {"name":"Okta","slug":"okta","issuer_url":"https://dev-123.okta.com","client_id":"...","client_secret":"..."},
+//	  {"name":"GitHub","slug":"github","type":"github","client_id":"...","client_secret":"..."}
 //	]'
 func loadOIDCProviders() []OIDCProvider {
 	providersJSON := os.Getenv("OIDC_PROVIDERS")
@@ -126,8 +297,19 @@ func loadOIDCProviders() []OIDCProvider {
 		return nil
 	}
 
-	// Set default scopes if not specified
+	// Set the default type, issuer and scopes if not specified
 	for i := range providers {
+		if providers[i].Type == "" {
+			providers[i].Type = OIDCProviderTypeOIDC
+		}
+		if providers[i].Type == OIDCProviderTypeGitHub {
+			if providers[i].IssuerURL == "" {
+				providers[i].IssuerURL = "https://github.com"
+			}
+			if providers[i].Scopes == "" {
+				providers[i].Scopes = "read:user user:email"
+			}
+		}
 		if providers[i].Scopes == "" {
 			providers[i].Scopes = "openid email profile"
 		}
@@ -1,9 +1,11 @@
 package db
 
 import (
+	"database/sql"
 	"embed"
 	"log"
 	"net/http"
+	"sync"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -18,6 +20,8 @@ import (
 var (
 	//go:embed migrations/*.sql
 	migrations embed.FS
+
+	registerSourceOnce sync.Once
 )
 
 type driver struct {
@@ -40,10 +44,6 @@ func getMigration() (*migrate.Migrate, error) {
 	if err != nil {
 		return nil, err
 	}
-	dbdriver, err := postgres.WithInstance(sqldb, &postgres.Config{})
-	if err != nil {
-		return nil, err
-	}
 
 	// log files in migrations folder
 	files, err := migrations.ReadDir("migrations")
@@ -54,9 +54,35 @@ func getMigration() (*migrate.Migrate, error) {
 		println(file.Name())
 	}
 
-	source.Register("embed", &driver{})
+	return newMigration(sqldb, cfg.DBConfig.DataBase)
+}
+
+func newMigration(sqldb *sql.DB, databaseName string) (*migrate.Migrate, error) {
+	dbdriver, err := postgres.WithInstance(sqldb, &postgres.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	registerSourceOnce.Do(func() {
+		source.Register("embed", &driver{})
+	})
 
-	return migrate.NewWithDatabaseInstance("embed://", cfg.DBConfig.DataBase, dbdriver)
+	return migrate.NewWithDatabaseInstance("embed://", databaseName, dbdriver)
+}
+
+// MigrateUpWithDB applies all pending migrations to an already open database connection
+func MigrateUpWithDB(sqldb *sql.DB, databaseName string) error {
+	m, err := newMigration(sqldb, databaseName)
+	if err != nil {
+		return err
+	}
+
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+
+	return nil
 }
 
 func MigrateUp() error {
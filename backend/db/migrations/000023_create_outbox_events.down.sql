@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_outbox_events_dispatched_at;
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,20 @@
+-- Transactional outbox: domain events are written in the same transaction as the state
+-- change and delivered to subscribers by the outbox dispatcher
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    actor_id UUID,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Index for the dispatcher's claim query (pending events that are due)
+CREATE INDEX idx_outbox_events_pending ON outbox_events(available_at) WHERE dispatched_at IS NULL;
+
+-- Index for cleanup of dispatched events
+CREATE INDEX idx_outbox_events_dispatched_at ON outbox_events(dispatched_at) WHERE dispatched_at IS NOT NULL;
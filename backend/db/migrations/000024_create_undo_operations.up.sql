@@ -0,0 +1,17 @@
+-- Command log of undoable bulk operations: each row stores the inverse steps needed to
+-- revert the operation while it is inside its undo window
+CREATE TABLE undo_operations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(50) NOT NULL,
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    entity_id UUID NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    inverse JSONB NOT NULL DEFAULT '[]',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    undone_at TIMESTAMP WITH TIME ZONE,
+    undone_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Index for listing a board's operations that can still be undone
+CREATE INDEX idx_undo_operations_board_id ON undo_operations(board_id, created_at DESC) WHERE undone_at IS NULL;
@@ -0,0 +1,8 @@
+-- Remove the bypass permission from roles
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code = 'board:bypass_workflow'
+);
+
+DELETE FROM permissions WHERE code = 'board:bypass_workflow';
+
+DROP TABLE IF EXISTS column_transitions;
@@ -0,0 +1,24 @@
+-- Board workflow: the column-to-column moves a board allows. A board without any
+-- transitions allows every move; once one is defined, only listed moves are allowed.
+CREATE TABLE column_transitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    from_column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    to_column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT unique_column_transition UNIQUE (from_column_id, to_column_id),
+    CONSTRAINT column_transition_distinct_columns CHECK (from_column_id <> to_column_id)
+);
+
+CREATE INDEX idx_column_transitions_board_id ON column_transitions(board_id);
+
+-- Permission to move cards regardless of the board workflow
+INSERT INTO permissions (code, name, description, resource_type) VALUES
+    ('board:bypass_workflow', 'Bypass Workflow', 'Can move cards between any columns regardless of the board workflow', 'board')
+ON CONFLICT (code) DO NOTHING;
+
+-- Owner and Admin roles can bypass the workflow
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name IN ('Owner', 'Admin') AND r.is_system = TRUE AND p.code = 'board:bypass_workflow'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
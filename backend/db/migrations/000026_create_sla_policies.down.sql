@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS sla_breaches;
+DROP TABLE IF EXISTS sla_policies;
+
+ALTER TABLE cards DROP COLUMN IF EXISTS column_entered_at;
@@ -0,0 +1,37 @@
+-- When a card arrived in its current column, so time spent in a column can be measured.
+-- Existing cards use their last update as the best available approximation.
+ALTER TABLE cards ADD COLUMN column_entered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+UPDATE cards SET column_entered_at = updated_at;
+
+-- SLA policies: cards (optionally of one priority) must leave a column within a time limit
+CREATE TABLE sla_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    priority card_priority,
+    max_duration_minutes INTEGER NOT NULL CHECK (max_duration_minutes > 0),
+    escalate_to_priority card_priority,
+    breach_tag_id UUID REFERENCES tags(id) ON DELETE SET NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_sla_policies_project_id ON sla_policies(project_id);
+
+-- One breach per policy and stay of a card in the policy's column
+CREATE TABLE sla_breaches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    policy_id UUID NOT NULL REFERENCES sla_policies(id) ON DELETE CASCADE,
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    column_entered_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    breached_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT unique_sla_breach UNIQUE (policy_id, card_id, column_entered_at)
+);
+
+CREATE INDEX idx_sla_breaches_card_id ON sla_breaches(card_id);
+CREATE INDEX idx_sla_breaches_open ON sla_breaches(policy_id) WHERE resolved_at IS NULL;
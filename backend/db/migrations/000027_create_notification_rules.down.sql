@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_rule_deliveries;
+DROP TABLE IF EXISTS notification_rules;
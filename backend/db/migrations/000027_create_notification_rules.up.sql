@@ -0,0 +1,26 @@
+-- User-defined notification rules: notify the rule's owner when a card event in the
+-- project matches the rule's optional tag, column and priority conditions
+CREATE TABLE notification_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    tag_id UUID REFERENCES tags(id) ON DELETE CASCADE,
+    column_id UUID REFERENCES board_columns(id) ON DELETE CASCADE,
+    priority card_priority,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_rules_user_id ON notification_rules(user_id);
+CREATE INDEX idx_notification_rules_project_event ON notification_rules(project_id, event) WHERE enabled;
+
+-- Events already delivered for a rule, so redelivered events don't notify twice
+CREATE TABLE notification_rule_deliveries (
+    rule_id UUID NOT NULL REFERENCES notification_rules(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rule_id, event_id)
+);
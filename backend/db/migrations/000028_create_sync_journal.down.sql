@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sync_mutations;
+DROP TABLE IF EXISTS sync_changes;
@@ -0,0 +1,24 @@
+-- Change journal for offline sync: one row per card or board change on a board. Clients
+-- pass the seq of the last change they saw to fetch what changed since.
+CREATE TABLE sync_changes (
+    seq BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL,
+    board_id UUID NOT NULL,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, board_id, entity_id)
+);
+
+CREATE INDEX idx_sync_changes_board_seq ON sync_changes(board_id, seq);
+
+-- Offline mutations already applied, so a client replaying its queue doesn't apply one
+-- twice and later mutations can refer to cards it created offline
+CREATE TABLE sync_mutations (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_mutation_id VARCHAR(100) NOT NULL,
+    card_id UUID,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_mutation_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE organizations ADD COLUMN content_moderation_enabled BOOLEAN NOT NULL DEFAULT false;
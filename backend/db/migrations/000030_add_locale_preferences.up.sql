@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN locale VARCHAR(16);
+ALTER TABLE organizations ADD COLUMN default_locale VARCHAR(16) NOT NULL DEFAULT 'en';
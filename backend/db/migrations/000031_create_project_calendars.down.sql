@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_holidays;
+DROP TABLE IF EXISTS project_calendars;
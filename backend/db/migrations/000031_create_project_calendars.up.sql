@@ -0,0 +1,18 @@
+-- Working days and pace of a project, used to suggest due dates. Projects without a row
+-- work Monday to Friday at one story point per day.
+CREATE TABLE project_calendars (
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    -- Bit n set means time.Weekday n (0 = Sunday) is a working day
+    working_days SMALLINT NOT NULL DEFAULT 62,
+    points_per_day DOUBLE PRECISION NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE project_holidays (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, date)
+);
@@ -0,0 +1,15 @@
+-- Links between cards of a project. A 'blocks' link means the from card has to be done
+-- before the to card; 'relates' links are informational and symmetric in meaning.
+CREATE TABLE card_dependencies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    to_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_card_dependency UNIQUE (from_card_id, to_card_id, kind),
+    CONSTRAINT card_dependency_distinct_cards CHECK (from_card_id <> to_card_id),
+    CONSTRAINT card_dependency_kind CHECK (kind IN ('blocks', 'relates'))
+);
+
+CREATE INDEX idx_card_dependencies_to_card_id ON card_dependencies(to_card_id);
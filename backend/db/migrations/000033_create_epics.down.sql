@@ -0,0 +1,3 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS epic_id;
+
+DROP TABLE IF EXISTS epics;
@@ -0,0 +1,16 @@
+-- Epics group the cards of a project that deliver a larger piece of work
+CREATE TABLE epics (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_epics_project_id ON epics(project_id);
+
+ALTER TABLE cards ADD COLUMN epic_id UUID REFERENCES epics(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_cards_epic_id ON cards(epic_id);
@@ -0,0 +1,16 @@
+-- A mirror is a card on another project's board that follows a source card. Its title and
+-- column stay in step with the source; 'two_way' mirrors also push their changes back.
+CREATE TABLE card_mirrors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    mirror_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    direction VARCHAR(20) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_card_mirror_card UNIQUE (mirror_card_id),
+    CONSTRAINT card_mirror_distinct_cards CHECK (source_card_id <> mirror_card_id),
+    CONSTRAINT card_mirror_direction CHECK (direction IN ('one_way', 'two_way'))
+);
+
+CREATE INDEX idx_card_mirrors_source_card_id ON card_mirrors(source_card_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS column_default_tags;
+DROP TABLE IF EXISTS column_card_defaults;
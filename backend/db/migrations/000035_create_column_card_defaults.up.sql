@@ -0,0 +1,17 @@
+-- Defaults applied to cards created in a column. Fields left NULL are not defaulted;
+-- the checklist is appended to the description of every new card.
+CREATE TABLE column_card_defaults (
+    column_id UUID PRIMARY KEY REFERENCES board_columns(id) ON DELETE CASCADE,
+    priority card_priority,
+    assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    story_points INTEGER,
+    description TEXT NOT NULL DEFAULT '',
+    checklist JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE column_default_tags (
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (column_id, tag_id)
+);
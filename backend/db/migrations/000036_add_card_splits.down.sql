@@ -0,0 +1,4 @@
+-- Enum values cannot be dropped, so 'card_split' stays in audit_action
+DELETE FROM card_dependencies WHERE kind = 'split_from';
+ALTER TABLE card_dependencies DROP CONSTRAINT card_dependency_kind;
+ALTER TABLE card_dependencies ADD CONSTRAINT card_dependency_kind CHECK (kind IN ('blocks', 'relates'));
@@ -0,0 +1,6 @@
+-- A split card is linked to each card split off it with a 'split_from' link from the new
+-- card to the original, and the split itself is recorded in the audit log.
+ALTER TABLE card_dependencies DROP CONSTRAINT card_dependency_kind;
+ALTER TABLE card_dependencies ADD CONSTRAINT card_dependency_kind CHECK (kind IN ('blocks', 'relates', 'split_from'));
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'card_split';
@@ -0,0 +1,3 @@
+ALTER TABLE invitations
+    DROP COLUMN IF EXISTS project_role_id,
+    DROP COLUMN IF EXISTS project_id;
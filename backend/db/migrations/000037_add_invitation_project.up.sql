@@ -0,0 +1,5 @@
+-- An invitation can also grant membership of one project, created together with the
+-- organization membership when it is accepted. A NULL project role inherits the org role.
+ALTER TABLE invitations
+    ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    ADD COLUMN project_role_id UUID REFERENCES roles(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_organization_members_guests;
+
+ALTER TABLE invitations
+    DROP COLUMN IF EXISTS is_guest;
+
+ALTER TABLE organization_members
+    DROP COLUMN IF EXISTS is_guest;
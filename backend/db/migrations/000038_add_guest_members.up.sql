@@ -0,0 +1,10 @@
+-- Guests are external collaborators: members of an organization who only reach the
+-- projects they were explicitly added to, and who are left out of member lists and
+-- organization-wide search.
+ALTER TABLE organization_members
+    ADD COLUMN is_guest BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE invitations
+    ADD COLUMN is_guest BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_organization_members_guests ON organization_members(organization_id) WHERE is_guest;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_slack_deliveries;
+DROP TABLE IF EXISTS notification_channel_settings;
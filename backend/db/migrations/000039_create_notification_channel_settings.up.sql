@@ -0,0 +1,28 @@
+-- Where card events are delivered: an organization's defaults (project_id NULL) and
+-- per-project overrides, one row per event. Email goes to the owners of matching
+-- notification rules; a Slack webhook gets every such event in the project. With
+-- neither, the event stays in-app only.
+CREATE TABLE notification_channel_settings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    email BOOLEAN NOT NULL DEFAULT TRUE,
+    slack_webhook_url TEXT,
+    slack_channel VARCHAR(80),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_notification_channel_settings_org_event
+    ON notification_channel_settings(organization_id, event) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX idx_notification_channel_settings_project_event
+    ON notification_channel_settings(project_id, event) WHERE project_id IS NOT NULL;
+
+-- Events already posted to Slack for a setting, so redelivered events don't post twice
+CREATE TABLE notification_slack_deliveries (
+    setting_id UUID NOT NULL REFERENCES notification_channel_settings(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (setting_id, event_id)
+);
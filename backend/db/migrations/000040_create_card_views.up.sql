@@ -0,0 +1,10 @@
+-- When each user last viewed each card. A card has unread activity for a user when it
+-- changed after their last view, or they never viewed it.
+CREATE TABLE card_views (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, card_id)
+);
+
+CREATE INDEX idx_card_views_card_id ON card_views(card_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS column_watch_deliveries;
+DROP TABLE IF EXISTS column_watches;
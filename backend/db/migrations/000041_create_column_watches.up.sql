@@ -0,0 +1,19 @@
+-- Users watching a board column are emailed when cards enter or leave it
+CREATE TABLE column_watches (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, column_id)
+);
+
+CREATE INDEX idx_column_watches_column_id ON column_watches(column_id);
+
+-- Events already delivered to a watcher, so redelivered events don't notify twice
+CREATE TABLE column_watch_deliveries (
+    user_id UUID NOT NULL,
+    column_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, column_id, event_id),
+    FOREIGN KEY (user_id, column_id) REFERENCES column_watches(user_id, column_id) ON DELETE CASCADE
+);
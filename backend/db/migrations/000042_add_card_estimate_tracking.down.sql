@@ -0,0 +1,2 @@
+ALTER TABLE cards DROP COLUMN IF EXISTS started_at;
+ALTER TABLE cards DROP COLUMN IF EXISTS original_story_points;
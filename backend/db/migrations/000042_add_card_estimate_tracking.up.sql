@@ -0,0 +1,6 @@
+-- The first story points a card was given, kept when it is re-estimated
+ALTER TABLE cards ADD COLUMN original_story_points INTEGER;
+UPDATE cards SET original_story_points = story_points;
+
+-- When the card first left the column it was created in; cycle time runs from here
+ALTER TABLE cards ADD COLUMN started_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,8 @@
+-- How far each stream has been synced to the data warehouse. Row streams resume after
+-- (cursor_at, cursor_id); daily streams resume at the day cursor_at starts.
+CREATE TABLE warehouse_sync_cursors (
+    stream VARCHAR(64) PRIMARY KEY,
+    cursor_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    cursor_id UUID,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,22 @@
+-- Matches of users from an external source (e.g. a Jira or Trello import, or a bulk invite)
+-- to existing users, so migrating doesn't create duplicate accounts. Uncertain matches wait
+-- in a queue for someone to resolve them.
+CREATE TABLE user_matches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    source VARCHAR(50) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    display_name VARCHAR(255),
+    email VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    confidence DOUBLE PRECISION NOT NULL DEFAULT 0,
+    candidates JSONB NOT NULL DEFAULT '[]',
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (organization_id, source, external_id)
+);
+
+CREATE INDEX idx_user_matches_pending ON user_matches(organization_id) WHERE status = 'pending';
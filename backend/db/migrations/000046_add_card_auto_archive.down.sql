@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS auto_archive_runs;
+ALTER TABLE boards DROP COLUMN IF EXISTS auto_archive_days;
+DROP INDEX IF EXISTS idx_cards_archived;
+ALTER TABLE cards DROP COLUMN IF EXISTS archived_at;
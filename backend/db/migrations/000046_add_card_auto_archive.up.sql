@@ -0,0 +1,18 @@
+-- Archived cards are kept, but left off their board's columns
+ALTER TABLE cards ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_cards_archived ON cards(board_id, archived_at) WHERE archived_at IS NOT NULL;
+
+-- Days a card may stay in a done column before it is archived; NULL never archives
+ALTER TABLE boards ADD COLUMN auto_archive_days INTEGER;
+
+-- Each time the auto-archival job archived cards of a board, for the summary email
+CREATE TABLE auto_archive_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    card_count INTEGER NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_auto_archive_runs_board_id ON auto_archive_runs(board_id);
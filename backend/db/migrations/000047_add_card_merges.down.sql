@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped, so 'card_merged' stays in audit_action
+ALTER TABLE cards DROP COLUMN IF EXISTS merged_into_id;
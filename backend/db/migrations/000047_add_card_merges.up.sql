@@ -0,0 +1,5 @@
+-- A card merged into another is archived with a pointer to it, and the merge itself is
+-- recorded in the audit log
+ALTER TABLE cards ADD COLUMN merged_into_id UUID REFERENCES cards(id) ON DELETE SET NULL;
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'card_merged';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_anomalies;
+DROP TABLE IF EXISTS audit_anomaly_settings;
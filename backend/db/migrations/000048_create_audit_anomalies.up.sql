@@ -0,0 +1,35 @@
+-- Per organization thresholds of the audit anomaly detector; organizations without a row
+-- use the defaults
+CREATE TABLE audit_anomaly_settings (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Deletions by one actor within the window that count as a mass deletion
+    mass_deletion_threshold INTEGER NOT NULL DEFAULT 25,
+    mass_deletion_window_minutes INTEGER NOT NULL DEFAULT 10,
+    -- Role changes outside [business_hours_start, business_hours_end) on weekdays, in the
+    -- timezone, are flagged
+    business_hours_start INTEGER NOT NULL DEFAULT 7,
+    business_hours_end INTEGER NOT NULL DEFAULT 19,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT audit_anomaly_business_hours CHECK (business_hours_start >= 0 AND business_hours_start < business_hours_end AND business_hours_end <= 24)
+);
+
+-- Unusual audit activity, emailed to the organization's admins
+CREATE TABLE audit_anomalies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- The flagged event, for anomalies about a single event
+    audit_event_id UUID,
+    event_count INTEGER NOT NULL,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT audit_anomaly_kind CHECK (kind IN ('mass_deletion', 'off_hours_role_change'))
+);
+
+CREATE INDEX idx_audit_anomalies_org ON audit_anomalies(organization_id, detected_at DESC);
+CREATE UNIQUE INDEX idx_audit_anomalies_event ON audit_anomalies(audit_event_id) WHERE audit_event_id IS NOT NULL;
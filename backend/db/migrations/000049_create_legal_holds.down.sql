@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped, so 'legal_hold_placed' and 'legal_hold_lifted' stay in audit_action
+DROP TABLE IF EXISTS legal_holds;
@@ -0,0 +1,19 @@
+-- Legal holds on organizations: while one is active (not lifted), permanent deletions in the
+-- organization are refused. Lifted holds stay as the record of who held what, when and why.
+CREATE TABLE legal_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    placed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    placed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    lift_reason TEXT,
+    lifted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    lifted_at TIMESTAMPTZ
+);
+
+-- At most one active hold per organization
+CREATE UNIQUE INDEX idx_legal_holds_active ON legal_holds(organization_id) WHERE lifted_at IS NULL;
+CREATE INDEX idx_legal_holds_organization ON legal_holds(organization_id, placed_at DESC);
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'legal_hold_placed';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'legal_hold_lifted';
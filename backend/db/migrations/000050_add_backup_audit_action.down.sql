@@ -0,0 +1 @@
+-- Enum values cannot be dropped, so 'backup_created' stays in audit_action
@@ -0,0 +1 @@
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'backup_created';
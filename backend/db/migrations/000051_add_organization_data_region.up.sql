@@ -0,0 +1,2 @@
+-- The data region an organization's data must stay in; NULL is the primary region
+ALTER TABLE organizations ADD COLUMN data_region VARCHAR(32);
@@ -0,0 +1,3 @@
+ALTER TABLE organizations DROP COLUMN IF EXISTS search_analytics_anonymized;
+
+DROP TABLE IF EXISTS search_queries;
@@ -0,0 +1,16 @@
+-- Searches run in each organization, for search analytics. user_id is NULL for
+-- organizations that anonymize their search analytics.
+CREATE TABLE search_queries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Lowercased with whitespace collapsed, so spellings of a query are counted together
+    query VARCHAR(255) NOT NULL,
+    -- Results found in the organization
+    result_count INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_search_queries_org_created ON search_queries(organization_id, created_at);
+
+ALTER TABLE organizations ADD COLUMN search_analytics_anonymized BOOLEAN NOT NULL DEFAULT FALSE;
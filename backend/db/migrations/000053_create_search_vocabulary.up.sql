@@ -0,0 +1,18 @@
+-- Per organization search vocabulary: sets of words searched as one another, and words left
+-- out of queries
+CREATE TABLE search_synonym_sets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    -- Lowercased words or phrases, at least two
+    words JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_search_synonym_sets_organization_id ON search_synonym_sets(organization_id);
+
+CREATE TABLE search_stop_words (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    word VARCHAR(64) NOT NULL,
+    PRIMARY KEY (organization_id, word)
+);
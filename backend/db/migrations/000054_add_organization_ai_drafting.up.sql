@@ -0,0 +1,3 @@
+-- Card drafting sends prompts and project tag names to the configured language model, so
+-- organizations opt in
+ALTER TABLE organizations ADD COLUMN ai_drafting_enabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE sprints DROP COLUMN IF EXISTS summary_generated_at;
+ALTER TABLE sprints DROP COLUMN IF EXISTS summary;
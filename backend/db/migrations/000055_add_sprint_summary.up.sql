@@ -0,0 +1,4 @@
+-- The last language-model summary generated for the sprint, kept so it can be read again
+-- without another request to the model
+ALTER TABLE sprints ADD COLUMN summary TEXT;
+ALTER TABLE sprints ADD COLUMN summary_generated_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,3 @@
+ALTER TABLE boards DROP COLUMN IF EXISTS column_palette;
+ALTER TABLE boards DROP COLUMN IF EXISTS background_image_url;
+ALTER TABLE boards DROP COLUMN IF EXISTS background_color;
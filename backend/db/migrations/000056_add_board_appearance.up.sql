@@ -0,0 +1,6 @@
+-- How the board looks, kept on the server so it follows the board across clients. The
+-- image is a reference (an https URL), not the image itself; the palette is the name of one
+-- of the backend's column palette presets.
+ALTER TABLE boards ADD COLUMN background_color VARCHAR(7) NOT NULL DEFAULT '';
+ALTER TABLE boards ADD COLUMN background_image_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE boards ADD COLUMN column_palette VARCHAR(32) NOT NULL DEFAULT '';
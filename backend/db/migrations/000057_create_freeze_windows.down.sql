@@ -0,0 +1,9 @@
+-- Enum values cannot be dropped, so 'freeze_overridden' stays in audit_action
+DELETE FROM role_permissions WHERE permission_id IN (
+    SELECT id FROM permissions WHERE code = 'board:override_freeze'
+);
+
+DELETE FROM permissions WHERE code = 'board:override_freeze';
+
+DROP TABLE IF EXISTS freeze_window_columns;
+DROP TABLE IF EXISTS freeze_windows;
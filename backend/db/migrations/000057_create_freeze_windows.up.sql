@@ -0,0 +1,38 @@
+-- Freeze windows: scheduled periods (a code freeze week, a release day) during which cards
+-- may only be moved into the listed columns of a board by roles with the override
+-- permission, who have to give a justification that is kept in the audit log
+CREATE TABLE freeze_windows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT freeze_window_period CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_freeze_windows_board ON freeze_windows(board_id, starts_at);
+
+-- The columns a window freezes
+CREATE TABLE freeze_window_columns (
+    freeze_window_id UUID NOT NULL REFERENCES freeze_windows(id) ON DELETE CASCADE,
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    PRIMARY KEY (freeze_window_id, column_id)
+);
+
+CREATE INDEX idx_freeze_window_columns_column ON freeze_window_columns(column_id);
+
+-- Permission to move cards into frozen columns
+INSERT INTO permissions (code, name, description, resource_type) VALUES
+    ('board:override_freeze', 'Override Freeze', 'Can move cards into columns frozen by a freeze window, with a justification', 'board')
+ON CONFLICT (code) DO NOTHING;
+
+-- Owner and Admin roles can override freezes
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name IN ('Owner', 'Admin') AND r.is_system = TRUE AND p.code = 'board:override_freeze'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'freeze_overridden';
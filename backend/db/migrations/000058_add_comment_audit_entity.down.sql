@@ -0,0 +1 @@
+-- Enum values cannot be dropped, so 'comment' stays in audit_entity_type
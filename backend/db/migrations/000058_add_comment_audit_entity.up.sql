@@ -0,0 +1,2 @@
+-- Comments are audited like cards, so contributor activity can count them
+ALTER TYPE audit_entity_type ADD VALUE IF NOT EXISTS 'comment';
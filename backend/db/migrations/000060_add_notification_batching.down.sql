@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_batch_items;
+ALTER TABLE boards DROP COLUMN IF EXISTS notification_batch_minutes;
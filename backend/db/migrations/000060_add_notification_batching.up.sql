@@ -0,0 +1,21 @@
+-- Quiet mode: minutes a board's card notifications are collected before one summary is
+-- sent per recipient; NULL notifies per event
+ALTER TABLE boards ADD COLUMN notification_batch_minutes INTEGER;
+
+-- Notifications waiting for their board's batch window to close. A row is either an email
+-- for a notification rule's owner or a Slack post; the message is rendered when queued.
+CREATE TABLE notification_batch_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    rule_id UUID REFERENCES notification_rules(id) ON DELETE CASCADE,
+    slack_webhook_url TEXT,
+    slack_channel VARCHAR(80) NOT NULL DEFAULT '',
+    event_id UUID NOT NULL,
+    message TEXT NOT NULL,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT notification_batch_items_one_recipient CHECK ((rule_id IS NULL) <> (slack_webhook_url IS NULL))
+);
+
+CREATE INDEX idx_notification_batch_items_board ON notification_batch_items(board_id, queued_at);
+CREATE UNIQUE INDEX idx_notification_batch_items_rule_event ON notification_batch_items(rule_id, event_id) WHERE rule_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_notification_batch_items_slack_event ON notification_batch_items(slack_webhook_url, slack_channel, event_id) WHERE slack_webhook_url IS NOT NULL;
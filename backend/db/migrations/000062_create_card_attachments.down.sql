@@ -0,0 +1,4 @@
+-- Enum values cannot be dropped, so 'attachment' stays in audit_entity_type
+ALTER TABLE organizations DROP COLUMN IF EXISTS attachment_quota_bytes;
+ALTER TABLE organizations DROP COLUMN IF EXISTS attachment_max_bytes;
+DROP TABLE IF EXISTS card_attachments;
@@ -0,0 +1,25 @@
+-- Files attached to cards, stored in the object store under object_key. Deleting a card (or
+-- its board, project or organization) detaches its attachments, and the attachment sweeper
+-- then deletes their objects and rows. uploaded_at stays NULL until the upload is confirmed.
+CREATE TABLE card_attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID REFERENCES cards(id) ON DELETE SET NULL,
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    object_key VARCHAR(255) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    uploaded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_attachments_card ON card_attachments(card_id, created_at);
+CREATE INDEX idx_card_attachments_organization ON card_attachments(organization_id);
+CREATE INDEX idx_card_attachments_detached ON card_attachments(created_at) WHERE card_id IS NULL;
+
+-- Per-organization overrides of the configured attachment limits
+ALTER TABLE organizations ADD COLUMN attachment_max_bytes BIGINT CHECK (attachment_max_bytes > 0);
+ALTER TABLE organizations ADD COLUMN attachment_quota_bytes BIGINT CHECK (attachment_quota_bytes > 0);
+
+ALTER TYPE audit_entity_type ADD VALUE IF NOT EXISTS 'attachment';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS column_alerts;
+DROP TABLE IF EXISTS column_alert_settings;
@@ -0,0 +1,37 @@
+-- Per board thresholds of the column alert check; boards without a row are not checked
+CREATE TABLE column_alert_settings (
+    board_id UUID PRIMARY KEY REFERENCES boards(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Minutes a column may stay over its WIP limit before alerting; NULL disables the alert
+    wip_exceeded_minutes INTEGER,
+    -- Hours the cards of a column may be old on average before alerting; NULL disables the alert
+    average_age_hours INTEGER,
+    -- Optional Slack incoming webhook alerts are also posted to
+    slack_webhook_url TEXT,
+    slack_channel VARCHAR(80) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT column_alert_wip_exceeded_minutes CHECK (wip_exceeded_minutes > 0),
+    CONSTRAINT column_alert_average_age_hours CHECK (average_age_hours > 0)
+);
+
+-- A column over its WIP limit or holding old cards. A row is opened when the condition is
+-- first seen, raised (and the board's admins alerted) once it has lasted long enough, and
+-- resolved when the condition clears.
+CREATE TABLE column_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    raised_at TIMESTAMP WITH TIME ZONE,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    -- The column's state when the alert was raised
+    card_count INTEGER,
+    wip_limit INTEGER,
+    average_age_seconds DOUBLE PRECISION,
+    CONSTRAINT column_alert_kind CHECK (kind IN ('wip_exceeded', 'aging'))
+);
+
+CREATE INDEX idx_column_alerts_board ON column_alerts(board_id, started_at DESC);
+CREATE UNIQUE INDEX idx_column_alerts_open ON column_alerts(column_id, kind) WHERE resolved_at IS NULL;
@@ -0,0 +1,4 @@
+-- Card activity feeds include the events of the card's comments and attachments, which
+-- record the card in their metadata
+CREATE INDEX idx_audit_events_metadata_card ON audit_events((metadata->>'card_id'), occurred_at DESC)
+    WHERE entity_type IN ('comment', 'attachment');
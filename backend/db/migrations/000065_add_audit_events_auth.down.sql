@@ -0,0 +1,3 @@
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS impersonator_id,
+    DROP COLUMN IF EXISTS auth_method;
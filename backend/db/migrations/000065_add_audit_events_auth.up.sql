@@ -0,0 +1,4 @@
+-- How the actor authenticated, and who acted as them when impersonated
+ALTER TABLE audit_events
+    ADD COLUMN auth_method TEXT CHECK (auth_method IN ('session', 'token')),
+    ADD COLUMN impersonator_id UUID REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped, so 'checklist_item' stays in audit_entity_type
+DROP TABLE IF EXISTS card_checklist_items;
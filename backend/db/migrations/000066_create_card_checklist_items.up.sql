@@ -0,0 +1,17 @@
+-- Checklist items on cards, ordered by position. The assignee is kept NULL once their
+-- account is deleted.
+CREATE TABLE card_checklist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    title VARCHAR(500) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_checklist_items_card ON card_checklist_items(card_id, position);
+CREATE INDEX idx_card_checklist_items_assignee ON card_checklist_items(assignee_id) WHERE assignee_id IS NOT NULL;
+
+ALTER TYPE audit_entity_type ADD VALUE IF NOT EXISTS 'checklist_item';
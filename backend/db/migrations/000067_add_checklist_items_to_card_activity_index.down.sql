@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_events_metadata_card;
+CREATE INDEX idx_audit_events_metadata_card ON audit_events((metadata->>'card_id'), occurred_at DESC)
+    WHERE entity_type IN ('comment', 'attachment');
@@ -0,0 +1,5 @@
+-- Checklist item events record their card in their metadata too. This can't share a
+-- migration with the enum value it uses.
+DROP INDEX IF EXISTS idx_audit_events_metadata_card;
+CREATE INDEX idx_audit_events_metadata_card ON audit_events((metadata->>'card_id'), occurred_at DESC)
+    WHERE entity_type IN ('comment', 'attachment', 'checklist_item');
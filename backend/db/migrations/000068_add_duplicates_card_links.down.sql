@@ -0,0 +1,3 @@
+DELETE FROM card_dependencies WHERE kind = 'duplicates';
+ALTER TABLE card_dependencies DROP CONSTRAINT card_dependency_kind;
+ALTER TABLE card_dependencies ADD CONSTRAINT card_dependency_kind CHECK (kind IN ('blocks', 'relates', 'split_from'));
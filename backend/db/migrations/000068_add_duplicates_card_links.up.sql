@@ -0,0 +1,3 @@
+-- A 'duplicates' link marks the from card as a duplicate of the to card
+ALTER TABLE card_dependencies DROP CONSTRAINT card_dependency_kind;
+ALTER TABLE card_dependencies ADD CONSTRAINT card_dependency_kind CHECK (kind IN ('blocks', 'relates', 'split_from', 'duplicates'));
@@ -0,0 +1,18 @@
+-- How an organization's outbound mail looks and who it comes from; organizations without
+-- a row use the platform sender and look
+CREATE TABLE organization_branding (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    from_name VARCHAR(100) NOT NULL DEFAULT '',
+    -- Only used once its domain passed the ownership, SPF and DKIM checks
+    from_email VARCHAR(255) NOT NULL DEFAULT '',
+    logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    accent_color VARCHAR(7) NOT NULL DEFAULT '',
+    -- Published by the organization in a TXT record to prove it owns the domain
+    verification_token VARCHAR(64) NOT NULL DEFAULT '',
+    ownership_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    spf_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    dkim_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    domain_verified_at TIMESTAMP WITH TIME ZONE,
+    domain_checked_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,11 @@
+-- Whitelabel settings of the whole instance, kept in a single row; an instance without the
+-- row uses the built-in product name and look
+CREATE TABLE instance_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    product_name VARCHAR(100) NOT NULL DEFAULT '',
+    logo_url VARCHAR(2048) NOT NULL DEFAULT '',
+    support_email VARCHAR(255) NOT NULL DEFAULT '',
+    terms_url VARCHAR(2048) NOT NULL DEFAULT '',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
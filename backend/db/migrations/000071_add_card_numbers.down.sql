@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cards_board_number;
+ALTER TABLE cards DROP COLUMN IF EXISTS number;
+ALTER TABLE projects DROP COLUMN IF EXISTS last_card_number;
@@ -0,0 +1,25 @@
+-- Cards are numbered per project from 1, giving them keys such as API-42. The project keeps
+-- the last number it handed out, so numbers of deleted cards are never reused
+ALTER TABLE projects ADD COLUMN last_card_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE cards ADD COLUMN number INTEGER;
+
+-- Existing cards are numbered in the order they were created
+UPDATE cards SET number = numbered.n
+FROM (
+    SELECT cards.id, ROW_NUMBER() OVER (PARTITION BY boards.project_id ORDER BY cards.created_at, cards.id) AS n
+    FROM cards
+    JOIN boards ON boards.id = cards.board_id
+) AS numbered
+WHERE cards.id = numbered.id;
+
+UPDATE projects SET last_card_number = COALESCE((
+    SELECT MAX(cards.number)
+    FROM cards
+    JOIN boards ON boards.id = cards.board_id
+    WHERE boards.project_id = projects.id
+), 0);
+
+ALTER TABLE cards ALTER COLUMN number SET NOT NULL;
+
+-- Keys are looked up through the boards of the key's project
+CREATE INDEX idx_cards_board_number ON cards(board_id, number);
@@ -0,0 +1 @@
+-- Enum values cannot be dropped, so 'cards_imported' stays in audit_action
@@ -0,0 +1 @@
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'cards_imported';
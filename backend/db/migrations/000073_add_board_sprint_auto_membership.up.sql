@@ -0,0 +1,3 @@
+-- Column policy: moving a card out of the backlog adds it to the board's active sprint, and
+-- moving it back removes it. Off by default, so sprint scope changes only when asked
+ALTER TABLE boards ADD COLUMN sprint_auto_membership BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+-- Enum values cannot be dropped, so 'project_export_requested' stays in audit_action
+DROP TABLE IF EXISTS project_exports;
+DROP TYPE IF EXISTS project_export_status;
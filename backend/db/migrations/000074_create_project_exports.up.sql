@@ -0,0 +1,25 @@
+-- Project exports: JSON archives of a project, built in the background and stored in the
+-- object store until they expire
+CREATE TYPE project_export_status AS ENUM ('pending', 'running', 'completed', 'failed');
+
+CREATE TABLE project_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status project_export_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- When a running export counts as abandoned by its worker and may be claimed again
+    lease_until TIMESTAMPTZ,
+    storage_key VARCHAR(500) NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    card_count INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_project_exports_project ON project_exports(project_id, created_at DESC);
+CREATE INDEX idx_project_exports_queued ON project_exports(created_at) WHERE status IN ('pending', 'running');
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'project_export_requested';
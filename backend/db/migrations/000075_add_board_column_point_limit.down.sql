@@ -0,0 +1 @@
+ALTER TABLE board_columns DROP COLUMN IF EXISTS point_limit;
@@ -0,0 +1,3 @@
+-- Capacity by story points: a column with a point limit refuses moves that would take the
+-- sum of its cards' points over it. NULL leaves the column unlimited
+ALTER TABLE board_columns ADD COLUMN point_limit INTEGER;
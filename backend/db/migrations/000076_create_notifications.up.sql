@@ -0,0 +1,21 @@
+-- The in-app notification center: one row per user and event that concerns them. What it
+-- is about is referenced rather than copied, so it disappears with the card, comment,
+-- sprint or invitation
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    -- The domain event that caused it, so redelivered events notify once
+    event_id UUID NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    card_id UUID REFERENCES cards(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES card_comments(id) ON DELETE CASCADE,
+    sprint_id UUID REFERENCES sprints(id) ON DELETE CASCADE,
+    invitation_id UUID REFERENCES invitations(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_invitations_open;
+ALTER TABLE invitations DROP COLUMN IF EXISTS expired_at;
+ALTER TABLE invitations DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE organizations DROP COLUMN IF EXISTS invitation_reminder_days;
+ALTER TABLE organizations DROP COLUMN IF EXISTS invitation_ttl_days;
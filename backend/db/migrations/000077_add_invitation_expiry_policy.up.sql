@@ -0,0 +1,10 @@
+-- An organization's invitation policy: how many days invitations stay valid (NULL uses the
+-- default of 7) and how many days before expiry invitees get a reminder (NULL sends none)
+ALTER TABLE organizations ADD COLUMN invitation_ttl_days INTEGER CHECK (invitation_ttl_days > 0);
+ALTER TABLE organizations ADD COLUMN invitation_reminder_days INTEGER CHECK (invitation_reminder_days > 0);
+
+-- Set by the invitation expirer, so each invitation is reminded and expired once
+ALTER TABLE invitations ADD COLUMN reminded_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE invitations ADD COLUMN expired_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_invitations_open ON invitations (expires_at) WHERE accepted_at IS NULL AND expired_at IS NULL;
@@ -0,0 +1,13 @@
+-- Which notifications a member wants from an organization, and where. A category without a
+-- row uses the defaults: in the app, not by email
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, organization_id, category)
+);
+
+CREATE INDEX idx_notification_preferences_org ON notification_preferences(organization_id, category);
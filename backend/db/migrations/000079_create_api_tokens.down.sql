@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped, so 'api_token_created' and 'api_token_revoked' stay in audit_action
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,24 @@
+-- Tokens for programmatic access, sent as "Authorization: Bearer". A personal token acts as
+-- its user; a service token acts as a service account that is a member of one organization
+-- only. Only a hash of each token is stored.
+CREATE TABLE api_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    token_hash VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('personal', 'service')),
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('read_only', 'card_write', 'admin')),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    CHECK ((kind = 'service') = (organization_id IS NOT NULL))
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
+CREATE INDEX idx_api_tokens_organization_id ON api_tokens(organization_id) WHERE organization_id IS NOT NULL;
+
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'api_token_created';
+ALTER TYPE audit_action ADD VALUE IF NOT EXISTS 'api_token_revoked';
@@ -0,0 +1,5 @@
+UPDATE audit_events SET auth_method = 'token' WHERE auth_method = 'api_token';
+ALTER TABLE audit_events DROP CONSTRAINT IF EXISTS audit_events_auth_method_check;
+ALTER TABLE audit_events
+    ADD CONSTRAINT audit_events_auth_method_check CHECK (auth_method IN ('session', 'token')),
+    DROP COLUMN IF EXISTS api_token_id;
@@ -0,0 +1,7 @@
+-- Requests authenticated with an API token get their own auth method, and record the token.
+-- The token is not a foreign key: personal tokens are left out of organization backups, and
+-- the record should outlive the token.
+ALTER TABLE audit_events DROP CONSTRAINT IF EXISTS audit_events_auth_method_check;
+ALTER TABLE audit_events
+    ADD CONSTRAINT audit_events_auth_method_check CHECK (auth_method IN ('session', 'token', 'api_token')),
+    ADD COLUMN api_token_id UUID;
@@ -0,0 +1,3 @@
+-- Response bodies of webhook endpoints are no longer logged: read by organization managers,
+-- they exposed whatever the URL returned
+ALTER TABLE webhook_deliveries DROP COLUMN response_body;
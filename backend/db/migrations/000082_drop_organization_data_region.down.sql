@@ -0,0 +1 @@
+ALTER TABLE organizations ADD COLUMN data_region VARCHAR(32);
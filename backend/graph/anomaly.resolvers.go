@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// UpdateAuditAnomalySettings is the resolver for the updateAuditAnomalySettings field.
+func (r *mutationResolver) UpdateAuditAnomalySettings(ctx context.Context, input model.UpdateAuditAnomalySettingsInput) (*model.AuditAnomalySettings, error) {
+	return resolvers.UpdateAuditAnomalySettings(ctx, r.RBACService, r.AnomalyService, input)
+}
+
+// AuditAnomalySettings is the resolver for the auditAnomalySettings field.
+func (r *queryResolver) AuditAnomalySettings(ctx context.Context, organizationID string) (*model.AuditAnomalySettings, error) {
+	return resolvers.AuditAnomalySettings(ctx, r.RBACService, r.AnomalyService, organizationID)
+}
+
+// AuditAnomalies is the resolver for the auditAnomalies field.
+func (r *queryResolver) AuditAnomalies(ctx context.Context, organizationID string, limit *int) ([]*model.AuditAnomaly, error) {
+	return resolvers.AuditAnomalies(ctx, r.RBACService, r.AnomalyService, r.UserService, organizationID, limit)
+}
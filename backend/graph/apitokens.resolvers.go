@@ -0,0 +1,128 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// CreatePersonalAccessToken is the resolver for the createPersonalAccessToken field.
+func (r *mutationResolver) CreatePersonalAccessToken(ctx context.Context, input model.CreateAPITokenInput) (*model.CreatedAPIToken, error) {
+	result, err := resolvers.CreatePersonalAccessToken(ctx, r.UserService, r.APITokenService, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit event against the user the token acts as
+	if r.AuditService != nil && result.APIToken.User != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		entityID, _ := uuid.Parse(result.APIToken.User.ID)
+		var orgID *uuid.UUID
+		if result.APIToken.OrganizationID != nil {
+			id, _ := uuid.Parse(*result.APIToken.OrganizationID)
+			orgID = &id
+		}
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionAPITokenCreated,
+			EntityType:     auditrepo.EntityUser,
+			EntityID:       entityID,
+			OrganizationID: orgID,
+			Metadata: map[string]interface{}{
+				"token_id": result.APIToken.ID,
+				"name":     result.APIToken.Name,
+				"kind":     result.APIToken.Kind,
+				"scope":    result.APIToken.Scope,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// CreateServiceToken is the resolver for the createServiceToken field.
+func (r *mutationResolver) CreateServiceToken(ctx context.Context, organizationID string, input model.CreateAPITokenInput) (*model.CreatedAPIToken, error) {
+	result, err := resolvers.CreateServiceToken(ctx, r.RBACService, r.UserService, r.APITokenService, organizationID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit event against the user the token acts as
+	if r.AuditService != nil && result.APIToken.User != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		entityID, _ := uuid.Parse(result.APIToken.User.ID)
+		var orgID *uuid.UUID
+		if result.APIToken.OrganizationID != nil {
+			id, _ := uuid.Parse(*result.APIToken.OrganizationID)
+			orgID = &id
+		}
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionAPITokenCreated,
+			EntityType:     auditrepo.EntityUser,
+			EntityID:       entityID,
+			OrganizationID: orgID,
+			Metadata: map[string]interface{}{
+				"token_id": result.APIToken.ID,
+				"name":     result.APIToken.Name,
+				"kind":     result.APIToken.Kind,
+				"scope":    result.APIToken.Scope,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// RevokeAPIToken is the resolver for the revokeApiToken field.
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, id string) (*model.APIToken, error) {
+	result, err := resolvers.RevokeAPIToken(ctx, r.RBACService, r.UserService, r.APITokenService, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit event against the user the token acts as
+	if r.AuditService != nil && result.User != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		entityID, _ := uuid.Parse(result.User.ID)
+		var orgID *uuid.UUID
+		if result.OrganizationID != nil {
+			id, _ := uuid.Parse(*result.OrganizationID)
+			orgID = &id
+		}
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionAPITokenRevoked,
+			EntityType:     auditrepo.EntityUser,
+			EntityID:       entityID,
+			OrganizationID: orgID,
+			Metadata: map[string]interface{}{
+				"token_id": result.ID,
+				"name":     result.Name,
+				"kind":     result.Kind,
+				"scope":    result.Scope,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// MyAPITokens is the resolver for the myApiTokens field.
+func (r *queryResolver) MyAPITokens(ctx context.Context) ([]*model.APIToken, error) {
+	return resolvers.MyAPITokens(ctx, r.UserService, r.APITokenService)
+}
+
+// OrganizationServiceTokens is the resolver for the organizationServiceTokens field.
+func (r *queryResolver) OrganizationServiceTokens(ctx context.Context, organizationID string) ([]*model.APIToken, error) {
+	return resolvers.OrganizationServiceTokens(ctx, r.RBACService, r.UserService, r.APITokenService, organizationID)
+}
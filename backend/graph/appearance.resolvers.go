@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SetBoardAppearance is the resolver for the setBoardAppearance field.
+func (r *mutationResolver) SetBoardAppearance(ctx context.Context, boardID string, input model.BoardAppearanceInput) (*model.Board, error) {
+	return resolvers.SetBoardAppearance(ctx, r.RBACService, r.AppearanceService, boardID, input)
+}
+
+// ColumnPalettes is the resolver for the columnPalettes field.
+func (r *queryResolver) ColumnPalettes(ctx context.Context) ([]*model.ColumnPalette, error) {
+	return resolvers.ColumnPalettes(ctx)
+}
@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SetBoardAutoArchive is the resolver for the setBoardAutoArchive field.
+func (r *mutationResolver) SetBoardAutoArchive(ctx context.Context, boardID string, days *int) (*model.Board, error) {
+	return resolvers.SetBoardAutoArchive(ctx, r.RBACService, r.ArchiveService, boardID, days)
+}
+
+// UnarchiveCard is the resolver for the unarchiveCard field.
+func (r *mutationResolver) UnarchiveCard(ctx context.Context, id string) (*model.Card, error) {
+	return resolvers.UnarchiveCard(ctx, r.RBACService, r.CardService, r.ArchiveService, id)
+}
+
+// ArchivedCards is the resolver for the archivedCards field.
+func (r *queryResolver) ArchivedCards(ctx context.Context, boardID string) ([]*model.Card, error) {
+	return resolvers.ArchivedCards(ctx, r.RBACService, r.ArchiveService, boardID)
+}
@@ -0,0 +1,153 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// Attachments is the resolver for the attachments field.
+func (r *cardResolver) Attachments(ctx context.Context, obj *model.Card) ([]*model.CardAttachment, error) {
+	return resolvers.CardAttachments(ctx, r.AttachmentService, obj)
+}
+
+// UploadedBy is the resolver for the uploadedBy field.
+func (r *cardAttachmentResolver) UploadedBy(ctx context.Context, obj *model.CardAttachment) (*model.User, error) {
+	return resolvers.CardAttachmentUploadedBy(ctx, r.AttachmentService, r.UserService, obj)
+}
+
+// DownloadURL is the resolver for the downloadUrl field.
+func (r *cardAttachmentResolver) DownloadURL(ctx context.Context, obj *model.CardAttachment) (string, error) {
+	return resolvers.CardAttachmentDownloadURL(ctx, r.AttachmentService, obj)
+}
+
+// RequestAttachmentUpload is the resolver for the requestAttachmentUpload field.
+func (r *mutationResolver) RequestAttachmentUpload(ctx context.Context, input model.RequestAttachmentUploadInput) (*model.AttachmentUpload, error) {
+	return resolvers.RequestAttachmentUpload(ctx, r.RBACService, r.CardService, r.AttachmentService, input)
+}
+
+// CompleteAttachmentUpload is the resolver for the completeAttachmentUpload field.
+func (r *mutationResolver) CompleteAttachmentUpload(ctx context.Context, id string) (*model.CardAttachment, error) {
+	attachment, err := resolvers.CompleteAttachmentUpload(ctx, r.RBACService, r.CardService, r.AttachmentService, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		attachmentID, _ := uuid.Parse(attachment.ID)
+		cardID, _ := uuid.Parse(attachment.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCreated,
+			EntityType:     auditrepo.EntityAttachment,
+			EntityID:       attachmentID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateAfter:     attachment,
+			Metadata: map[string]interface{}{
+				"card_id": attachment.CardID,
+			},
+		})
+	}
+	return attachment, nil
+}
+
+// DeleteCardAttachment is the resolver for the deleteCardAttachment field.
+func (r *mutationResolver) DeleteCardAttachment(ctx context.Context, id string) (bool, error) {
+	attachment, err := resolvers.DeleteCardAttachment(ctx, r.RBACService, r.CardService, r.AttachmentService, r.LegalHoldService, id)
+	if err != nil {
+		return false, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		attachmentID, _ := uuid.Parse(attachment.ID)
+		cardID, _ := uuid.Parse(attachment.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionDeleted,
+			EntityType:     auditrepo.EntityAttachment,
+			EntityID:       attachmentID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateBefore:    attachment,
+			Metadata: map[string]interface{}{
+				"card_id": attachment.CardID,
+			},
+		})
+	}
+	return true, nil
+}
+
+// SetOrganizationAttachmentLimits is the resolver for the setOrganizationAttachmentLimits field.
+func (r *mutationResolver) SetOrganizationAttachmentLimits(ctx context.Context, organizationID string, maxBytes *int, quotaBytes *int) (*model.Organization, error) {
+	org, err := resolvers.SetOrganizationAttachmentLimits(ctx, r.RBACService, r.AttachmentService, organizationID, maxBytes, quotaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"attachment_max_bytes":   maxBytes,
+				"attachment_quota_bytes": quotaBytes,
+			},
+		})
+	}
+
+	return org, nil
+}
+
+// CardAttachment returns generated.CardAttachmentResolver implementation.
+func (r *Resolver) CardAttachment() generated.CardAttachmentResolver {
+	return &cardAttachmentResolver{r}
+}
+
+type cardAttachmentResolver struct{ *Resolver }
@@ -26,6 +26,11 @@ func (r *queryResolver) BoardActivity(ctx context.Context, boardID string, first
 	return resolvers.BoardActivity(ctx, r.RBACService, r.AuditService, r.getAuditServices(), boardID, first, after)
 }
 
+// CardActivity is the resolver for the cardActivity field.
+func (r *queryResolver) CardActivity(ctx context.Context, cardID string, first *int, after *string) (*model.AuditEventConnection, error) {
+	return resolvers.CardActivity(ctx, r.RBACService, r.CardService, r.AuditService, r.getAuditServices(), cardID, first, after)
+}
+
 // EntityHistory is the resolver for the entityHistory field.
 func (r *queryResolver) EntityHistory(ctx context.Context, entityType model.AuditEntityType, entityID string, first *int, after *string) (*model.AuditEventConnection, error) {
 	return resolvers.EntityHistory(ctx, r.RBACService, r.AuditService, r.getAuditServices(), entityType, entityID, first, after)
@@ -0,0 +1,48 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// CreateOrganizationBackup is the resolver for the createOrganizationBackup field.
+func (r *mutationResolver) CreateOrganizationBackup(ctx context.Context, organizationID string) (*model.OrganizationBackup, error) {
+	backup, err := resolvers.CreateOrganizationBackup(ctx, r.RBACService, r.BackupService, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionBackupCreated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"key":        backup.Key,
+				"size_bytes": backup.SizeBytes,
+			},
+		})
+	}
+
+	return backup, nil
+}
+
+// OrganizationBackups is the resolver for the organizationBackups field.
+func (r *queryResolver) OrganizationBackups(ctx context.Context, organizationID string) ([]*model.OrganizationBackup, error) {
+	return resolvers.OrganizationBackups(ctx, r.RBACService, r.BackupService, organizationID)
+}
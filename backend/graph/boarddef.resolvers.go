@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// ImportBoardDefinition is the resolver for the importBoardDefinition field.
+func (r *mutationResolver) ImportBoardDefinition(ctx context.Context, projectID string, definition string, name *string) (*model.Board, error) {
+	return resolvers.ImportBoardDefinition(ctx, r.RBACService, r.BoardDefinitionService, projectID, definition, name)
+}
+
+// ExportBoardDefinition is the resolver for the exportBoardDefinition field.
+func (r *queryResolver) ExportBoardDefinition(ctx context.Context, boardID string) (string, error) {
+	return resolvers.ExportBoardDefinition(ctx, r.RBACService, r.BoardService, r.BoardDefinitionService, boardID)
+}
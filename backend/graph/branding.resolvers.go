@@ -0,0 +1,76 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// UpdateOrganizationBranding is the resolver for the updateOrganizationBranding field.
+func (r *mutationResolver) UpdateOrganizationBranding(ctx context.Context, input model.UpdateOrganizationBrandingInput) (*model.OrganizationBranding, error) {
+	branding, err := resolvers.UpdateOrganizationBranding(ctx, r.RBACService, r.BrandingService, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(input.OrganizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"branding_from_email":   branding.FromEmail,
+				"branding_logo_url":     branding.LogoURL,
+				"branding_accent_color": branding.AccentColor,
+			},
+		})
+	}
+
+	return branding, nil
+}
+
+// VerifyBrandingDomain is the resolver for the verifyBrandingDomain field.
+func (r *mutationResolver) VerifyBrandingDomain(ctx context.Context, organizationID string) (*model.OrganizationBranding, error) {
+	branding, err := resolvers.VerifyBrandingDomain(ctx, r.RBACService, r.BrandingService, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"branding_domain":          branding.Domain,
+				"branding_domain_verified": branding.DomainVerifiedAt != nil,
+			},
+		})
+	}
+
+	return branding, nil
+}
+
+// OrganizationBranding is the resolver for the organizationBranding field.
+func (r *queryResolver) OrganizationBranding(ctx context.Context, organizationID string) (*model.OrganizationBranding, error) {
+	return resolvers.OrganizationBranding(ctx, r.RBACService, r.BrandingService, organizationID)
+}
@@ -0,0 +1,51 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// BulkChangeMemberRoles is the resolver for the bulkChangeMemberRoles field.
+func (r *mutationResolver) BulkChangeMemberRoles(ctx context.Context, organizationID string, userIds []string, roleID string) (*model.BulkMemberRoleChangeResult, error) {
+	result, err := resolvers.BulkChangeMemberRoles(ctx, r.RBACService, organizationID, userIds, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit events, one per member whose role changed
+	if r.AuditService != nil && result.Applied {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+
+		for _, change := range result.Results {
+			if change.Status != model.MemberRoleChangeStatusChanged {
+				continue
+			}
+			memberID, _ := uuid.Parse(change.UserID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionMemberRoleChanged,
+				EntityType:     auditrepo.EntityUser,
+				EntityID:       memberID,
+				OrganizationID: &orgID,
+				Metadata: map[string]interface{}{
+					"previous_role_id": change.PreviousRoleID,
+					"role_id":          roleID,
+					"bulk":             true,
+				},
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// UpdateProjectCalendar is the resolver for the updateProjectCalendar field.
+func (r *mutationResolver) UpdateProjectCalendar(ctx context.Context, projectID string, input model.UpdateProjectCalendarInput) (*model.ProjectCalendar, error) {
+	return resolvers.UpdateProjectCalendar(ctx, r.RBACService, r.CalendarService, projectID, input)
+}
+
+// AddProjectHoliday is the resolver for the addProjectHoliday field.
+func (r *mutationResolver) AddProjectHoliday(ctx context.Context, projectID string, date string, name string) (*model.ProjectHoliday, error) {
+	return resolvers.AddProjectHoliday(ctx, r.RBACService, r.CalendarService, projectID, date, name)
+}
+
+// RemoveProjectHoliday is the resolver for the removeProjectHoliday field.
+func (r *mutationResolver) RemoveProjectHoliday(ctx context.Context, id string) (bool, error) {
+	return resolvers.RemoveProjectHoliday(ctx, r.RBACService, r.CalendarService, id)
+}
+
+// ProjectCalendar is the resolver for the projectCalendar field.
+func (r *queryResolver) ProjectCalendar(ctx context.Context, projectID string) (*model.ProjectCalendar, error) {
+	return resolvers.ProjectCalendar(ctx, r.RBACService, r.CalendarService, projectID)
+}
+
+// SuggestDueDate is the resolver for the suggestDueDate field.
+func (r *queryResolver) SuggestDueDate(ctx context.Context, input model.SuggestDueDateInput) (*model.DueDateSuggestion, error) {
+	return resolvers.SuggestDueDate(ctx, r.RBACService, r.CardService, input)
+}
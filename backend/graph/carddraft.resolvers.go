@@ -0,0 +1,47 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// DraftCard is the resolver for the draftCard field.
+func (r *mutationResolver) DraftCard(ctx context.Context, input model.DraftCardInput) (*model.CardDraft, error) {
+	return resolvers.DraftCard(ctx, r.RBACService, r.CardDraftService, input)
+}
+
+// SetAIDraftingEnabled is the resolver for the setAIDraftingEnabled field.
+func (r *mutationResolver) SetAIDraftingEnabled(ctx context.Context, organizationID string, enabled bool) (*model.Organization, error) {
+	org, err := resolvers.SetAIDraftingEnabled(ctx, r.RBACService, r.CardDraftService, organizationID, enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"ai_drafting_enabled": enabled,
+			},
+		})
+	}
+
+	return org, nil
+}
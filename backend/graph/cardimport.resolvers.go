@@ -0,0 +1,131 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// ImportCards is the resolver for the importCards field.
+func (r *mutationResolver) ImportCards(ctx context.Context, boardID string, csv string, columnMapping []*model.CardImportColumnMappingInput, columnID *string, dryRun *bool) (*model.CardImportResult, error) {
+	result, err := resolvers.ImportCards(ctx, r.RBACService, r.BoardService, r.CardImportService, boardID, csv, columnMapping, columnID, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit events, one per created card
+	if r.AuditService != nil && len(result.Cards) > 0 {
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		bID, _ := uuid.Parse(boardID)
+		var projectID, orgID *uuid.UUID
+		if proj, err := r.BoardService.GetProject(ctx, bID); err == nil {
+			projectID = &proj.ID
+			orgID = &proj.OrganizationID
+		}
+
+		for _, card := range result.Cards {
+			cardID, _ := uuid.Parse(card.ID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionCreated,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       cardID,
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				BoardID:        &bID,
+				StateAfter:     card,
+				Metadata: map[string]interface{}{
+					"column_id": result.ColumnID,
+					"title":     card.Title,
+					"source":    "csv",
+				},
+			})
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCardsImported,
+			EntityType:     auditrepo.EntityBoard,
+			EntityID:       bID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        &bID,
+			Metadata: map[string]interface{}{
+				"format":   "csv",
+				"cards":    len(result.Cards),
+				"new_tags": result.NewTags,
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// ImportProjectCards is the resolver for the importProjectCards field.
+func (r *mutationResolver) ImportProjectCards(ctx context.Context, projectID string, format model.CardImportFormat, payload string, boardID *string, statusMapping []*model.CardImportStatusMappingInput, dryRun *bool) (*model.ProjectCardImportResult, error) {
+	result, err := resolvers.ImportProjectCards(ctx, r.RBACService, r.CardImportService, projectID, format, payload, boardID, statusMapping, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit events, one per created card and one for the import
+	if r.AuditService != nil && len(result.Cards) > 0 {
+		userID := middleware.GetUserIDFromContext(ctx)
+		source := strings.ToLower(string(format))
+
+		pID, _ := uuid.Parse(projectID)
+		bID, _ := uuid.Parse(result.BoardID)
+		var orgID *uuid.UUID
+		if proj, err := r.ProjectService.GetProject(ctx, pID); err == nil {
+			orgID = &proj.OrganizationID
+		}
+
+		// Cards are created in row order
+		for i, card := range result.Cards {
+			cardID, _ := uuid.Parse(card.ID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionCreated,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       cardID,
+				OrganizationID: orgID,
+				ProjectID:      &pID,
+				BoardID:        &bID,
+				StateAfter:     card,
+				Metadata: map[string]interface{}{
+					"column_id": result.Rows[i].ColumnID,
+					"title":     card.Title,
+					"source":    source,
+				},
+			})
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCardsImported,
+			EntityType:     auditrepo.EntityBoard,
+			EntityID:       bID,
+			OrganizationID: orgID,
+			ProjectID:      &pID,
+			BoardID:        &bID,
+			Metadata: map[string]interface{}{
+				"format":   source,
+				"cards":    len(result.Cards),
+				"new_tags": result.NewTags,
+			},
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SprintHistory is the resolver for the sprintHistory field.
+func (r *cardResolver) SprintHistory(ctx context.Context, obj *model.Card) ([]*model.CardSprintStay, error) {
+	return resolvers.CardSprintHistory(ctx, r.CarryoverService, obj)
+}
+
+// CarryoverReport is the resolver for the carryoverReport field.
+func (r *queryResolver) CarryoverReport(ctx context.Context, boardID string, lastN *int) (*model.CarryoverReport, error) {
+	return resolvers.CarryoverReport(ctx, r.RBACService, r.CarryoverService, boardID, lastN)
+}
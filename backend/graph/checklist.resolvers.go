@@ -0,0 +1,175 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// Checklist is the resolver for the checklist field.
+func (r *cardResolver) Checklist(ctx context.Context, obj *model.Card) ([]*model.ChecklistItem, error) {
+	return resolvers.CardChecklist(ctx, r.ChecklistService, obj)
+}
+
+// ChecklistCompletion is the resolver for the checklistCompletion field.
+func (r *cardResolver) ChecklistCompletion(ctx context.Context, obj *model.Card) (*int, error) {
+	return resolvers.CardChecklistCompletion(ctx, r.ChecklistService, obj)
+}
+
+// Assignee is the resolver for the assignee field.
+func (r *checklistItemResolver) Assignee(ctx context.Context, obj *model.ChecklistItem) (*model.User, error) {
+	return resolvers.ChecklistItemAssignee(ctx, r.ChecklistService, r.UserService, obj)
+}
+
+// CreateChecklistItem is the resolver for the createChecklistItem field.
+func (r *mutationResolver) CreateChecklistItem(ctx context.Context, input model.CreateChecklistItemInput) (*model.ChecklistItem, error) {
+	item, err := resolvers.CreateChecklistItem(ctx, r.RBACService, r.CardService, r.ChecklistService, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		itemID, _ := uuid.Parse(item.ID)
+		cardID, _ := uuid.Parse(item.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCreated,
+			EntityType:     auditrepo.EntityChecklistItem,
+			EntityID:       itemID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateAfter:     item,
+			Metadata: map[string]interface{}{
+				"card_id": item.CardID,
+			},
+		})
+	}
+	return item, nil
+}
+
+// UpdateChecklistItem is the resolver for the updateChecklistItem field.
+func (r *mutationResolver) UpdateChecklistItem(ctx context.Context, input model.UpdateChecklistItemInput) (*model.ChecklistItem, error) {
+	// Get item before update for audit
+	var itemBefore *model.ChecklistItem
+	if r.AuditService != nil {
+		itemID, _ := uuid.Parse(input.ID)
+		if existing, err := r.ChecklistService.GetItem(ctx, itemID); err == nil {
+			itemBefore = resolvers.ChecklistItemToModel(existing)
+		}
+	}
+
+	item, err := resolvers.UpdateChecklistItem(ctx, r.RBACService, r.CardService, r.ChecklistService, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		itemID, _ := uuid.Parse(item.ID)
+		cardID, _ := uuid.Parse(item.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityChecklistItem,
+			EntityID:       itemID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateBefore:    itemBefore,
+			StateAfter:     item,
+			Metadata: map[string]interface{}{
+				"card_id": item.CardID,
+			},
+		})
+	}
+	return item, nil
+}
+
+// ReorderChecklistItems is the resolver for the reorderChecklistItems field.
+func (r *mutationResolver) ReorderChecklistItems(ctx context.Context, cardID string, itemIds []string) ([]*model.ChecklistItem, error) {
+	return resolvers.ReorderChecklistItems(ctx, r.RBACService, r.CardService, r.ChecklistService, cardID, itemIds)
+}
+
+// DeleteChecklistItem is the resolver for the deleteChecklistItem field.
+func (r *mutationResolver) DeleteChecklistItem(ctx context.Context, id string) (bool, error) {
+	item, err := resolvers.DeleteChecklistItem(ctx, r.RBACService, r.CardService, r.ChecklistService, id)
+	if err != nil {
+		return false, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		itemID, _ := uuid.Parse(item.ID)
+		cardID, _ := uuid.Parse(item.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionDeleted,
+			EntityType:     auditrepo.EntityChecklistItem,
+			EntityID:       itemID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateBefore:    item,
+			Metadata: map[string]interface{}{
+				"card_id": item.CardID,
+			},
+		})
+	}
+	return true, nil
+}
+
+// ChecklistItem returns generated.ChecklistItemResolver implementation.
+func (r *Resolver) ChecklistItem() generated.ChecklistItemResolver { return &checklistItemResolver{r} }
+
+type checklistItemResolver struct{ *Resolver }
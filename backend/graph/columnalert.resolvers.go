@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// UpdateColumnAlertSettings is the resolver for the updateColumnAlertSettings field.
+func (r *mutationResolver) UpdateColumnAlertSettings(ctx context.Context, input model.UpdateColumnAlertSettingsInput) (*model.ColumnAlertSettings, error) {
+	return resolvers.UpdateColumnAlertSettings(ctx, r.RBACService, r.ColumnAlertService, input)
+}
+
+// ColumnAlertSettings is the resolver for the columnAlertSettings field.
+func (r *queryResolver) ColumnAlertSettings(ctx context.Context, boardID string) (*model.ColumnAlertSettings, error) {
+	return resolvers.ColumnAlertSettings(ctx, r.RBACService, r.ColumnAlertService, boardID)
+}
+
+// ColumnAlerts is the resolver for the columnAlerts field.
+func (r *queryResolver) ColumnAlerts(ctx context.Context, boardID string, limit *int) ([]*model.ColumnAlert, error) {
+	return resolvers.ColumnAlerts(ctx, r.RBACService, r.ColumnAlertService, boardID, limit)
+}
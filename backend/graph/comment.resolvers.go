@@ -0,0 +1,180 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// Comments is the resolver for the comments field.
+func (r *cardResolver) Comments(ctx context.Context, obj *model.Card) ([]*model.CardComment, error) {
+	return resolvers.CardComments(ctx, r.CommentService, obj)
+}
+
+// Author is the resolver for the author field.
+func (r *cardCommentResolver) Author(ctx context.Context, obj *model.CardComment) (*model.User, error) {
+	return resolvers.CardCommentAuthor(ctx, r.CommentService, r.UserService, obj)
+}
+
+// Mentions is the resolver for the mentions field.
+func (r *cardCommentResolver) Mentions(ctx context.Context, obj *model.CardComment) ([]*model.User, error) {
+	return resolvers.CardCommentMentions(ctx, r.CommentService, r.UserService, obj)
+}
+
+// CreateCardComment is the resolver for the createCardComment field.
+func (r *mutationResolver) CreateCardComment(ctx context.Context, cardID string, body string) (*model.CardComment, error) {
+	comment, err := resolvers.CreateCardComment(ctx, r.RBACService, r.CardService, r.CommentService, cardID, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		commentID, _ := uuid.Parse(comment.ID)
+		cardID, _ := uuid.Parse(comment.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCreated,
+			EntityType:     auditrepo.EntityComment,
+			EntityID:       commentID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateAfter:     comment,
+			Metadata: map[string]interface{}{
+				"card_id": comment.CardID,
+			},
+		})
+	}
+	return comment, nil
+}
+
+// UpdateCardComment is the resolver for the updateCardComment field.
+func (r *mutationResolver) UpdateCardComment(ctx context.Context, id string, body string) (*model.CardComment, error) {
+	// Get comment before update for audit
+	var commentBefore *model.CardComment
+	if r.AuditService != nil {
+		commentID, _ := uuid.Parse(id)
+		if existing, err := r.CommentService.GetComment(ctx, commentID); err == nil {
+			commentBefore = resolvers.CommentToModel(existing)
+		}
+	}
+
+	comment, err := resolvers.UpdateCardComment(ctx, r.RBACService, r.CardService, r.CommentService, id, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		commentID, _ := uuid.Parse(comment.ID)
+		cardID, _ := uuid.Parse(comment.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityComment,
+			EntityID:       commentID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateBefore:    commentBefore,
+			StateAfter:     comment,
+			Metadata: map[string]interface{}{
+				"card_id": comment.CardID,
+			},
+		})
+	}
+	return comment, nil
+}
+
+// DeleteCardComment is the resolver for the deleteCardComment field.
+func (r *mutationResolver) DeleteCardComment(ctx context.Context, id string) (bool, error) {
+	comment, err := resolvers.DeleteCardComment(ctx, r.RBACService, r.CardService, r.CommentService, id)
+	if err != nil {
+		return false, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		commentID, _ := uuid.Parse(comment.ID)
+		cardID, _ := uuid.Parse(comment.CardID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// Get board and project info for audit context
+		board, _ := r.CardService.GetBoardByCardID(ctx, cardID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionDeleted,
+			EntityType:     auditrepo.EntityComment,
+			EntityID:       commentID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateBefore:    comment,
+			Metadata: map[string]interface{}{
+				"card_id": comment.CardID,
+			},
+		})
+	}
+	return true, nil
+}
+
+// MarkMentionsRead is the resolver for the markMentionsRead field.
+func (r *mutationResolver) MarkMentionsRead(ctx context.Context, ids []string) (int, error) {
+	return resolvers.MarkMentionsRead(ctx, r.CommentService, ids)
+}
+
+// MyMentions is the resolver for the myMentions field.
+func (r *queryResolver) MyMentions(ctx context.Context, unreadOnly *bool, first *int) ([]*model.CommentMention, error) {
+	return resolvers.MyMentions(ctx, r.CardService, r.CommentService, r.UserService, unreadOnly, first)
+}
+
+// CardComment returns generated.CardCommentResolver implementation.
+func (r *Resolver) CardComment() generated.CardCommentResolver { return &cardCommentResolver{r} }
+
+type cardCommentResolver struct{ *Resolver }
@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SetOrganizationContentModeration is the resolver for the setOrganizationContentModeration field.
+func (r *mutationResolver) SetOrganizationContentModeration(ctx context.Context, organizationID string, enabled bool) (*model.Organization, error) {
+	return resolvers.SetOrganizationContentModeration(ctx, r.RBACService, r.ContentService, organizationID, enabled)
+}
+
+// ContentLimits is the resolver for the contentLimits field.
+func (r *queryResolver) ContentLimits(ctx context.Context) (*model.ContentLimits, error) {
+	return resolvers.ContentLimits(ctx, r.ContentService)
+}
@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SeedDemoData is the resolver for the seedDemoData field.
+func (r *mutationResolver) SeedDemoData(ctx context.Context) (*model.Organization, error) {
+	return resolvers.SeedDemoData(ctx, r.DemoService, r.OrganizationService, r.SearchIndexer)
+}
@@ -0,0 +1,32 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// Links is the resolver for the links field.
+func (r *cardResolver) Links(ctx context.Context, obj *model.Card) ([]*model.CardLink, error) {
+	return resolvers.CardLinks(ctx, r.CardService, r.DependencyService, obj)
+}
+
+// AddCardDependency is the resolver for the addCardDependency field.
+func (r *mutationResolver) AddCardDependency(ctx context.Context, input model.AddCardDependencyInput) (*model.CardDependency, error) {
+	return resolvers.AddCardDependency(ctx, r.RBACService, r.CardService, r.DependencyService, input)
+}
+
+// RemoveCardDependency is the resolver for the removeCardDependency field.
+func (r *mutationResolver) RemoveCardDependency(ctx context.Context, id string) (bool, error) {
+	return resolvers.RemoveCardDependency(ctx, r.RBACService, r.CardService, r.DependencyService, id)
+}
+
+// ProjectDependencyGraph is the resolver for the projectDependencyGraph field.
+func (r *queryResolver) ProjectDependencyGraph(ctx context.Context, projectID string) (*model.DependencyGraph, error) {
+	return resolvers.ProjectDependencyGraph(ctx, r.RBACService, r.DependencyService, projectID)
+}
@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// OrganizationDirectory is the resolver for the organizationDirectory field.
+func (r *queryResolver) OrganizationDirectory(ctx context.Context, organizationID string, filter *model.OrganizationDirectoryFilter, sort *model.OrganizationDirectorySort, descending *bool, first *int, after *string) (*model.OrganizationMemberConnection, error) {
+	return resolvers.OrganizationDirectory(ctx, r.RBACService, organizationID, filter, sort, descending, first, after)
+}
@@ -0,0 +1,33 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// GenerateMetricsEmbedToken is the resolver for the generateMetricsEmbedToken field.
+func (r *mutationResolver) GenerateMetricsEmbedToken(ctx context.Context, boardID string, charts []model.MetricsEmbedChart, expiresAt time.Time) (*model.GeneratedMetricsEmbedToken, error) {
+	return resolvers.GenerateMetricsEmbedToken(ctx, r.RBACService, r.EmbedService, boardID, charts, expiresAt)
+}
+
+// RevokeMetricsEmbedToken is the resolver for the revokeMetricsEmbedToken field.
+func (r *mutationResolver) RevokeMetricsEmbedToken(ctx context.Context, id string) (*model.MetricsEmbedToken, error) {
+	return resolvers.RevokeMetricsEmbedToken(ctx, r.RBACService, r.EmbedService, id)
+}
+
+// EmbeddedMetrics is the resolver for the embeddedMetrics field.
+func (r *queryResolver) EmbeddedMetrics(ctx context.Context, token string, sprintID *string, mode model.MetricMode, sprintCount *int) (*model.EmbeddedMetrics, error) {
+	return resolvers.EmbeddedMetrics(ctx, r.EmbedService, r.BoardService, token, sprintID, mode, sprintCount)
+}
+
+// MetricsEmbedTokens is the resolver for the metricsEmbedTokens field.
+func (r *queryResolver) MetricsEmbedTokens(ctx context.Context, boardID string) ([]*model.MetricsEmbedToken, error) {
+	return resolvers.MetricsEmbedTokens(ctx, r.RBACService, r.EmbedService, boardID)
+}
@@ -0,0 +1,68 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// Cards is the resolver for the cards field.
+func (r *epicResolver) Cards(ctx context.Context, obj *model.Epic) ([]*model.Card, error) {
+	return resolvers.EpicCards(ctx, r.EpicService, obj)
+}
+
+// Progress is the resolver for the progress field.
+func (r *epicResolver) Progress(ctx context.Context, obj *model.Epic) (*model.EpicProgress, error) {
+	return resolvers.EpicProgress(ctx, r.EpicService, obj)
+}
+
+// CreateEpic is the resolver for the createEpic field.
+func (r *mutationResolver) CreateEpic(ctx context.Context, input model.CreateEpicInput) (*model.Epic, error) {
+	return resolvers.CreateEpic(ctx, r.RBACService, r.EpicService, input)
+}
+
+// UpdateEpic is the resolver for the updateEpic field.
+func (r *mutationResolver) UpdateEpic(ctx context.Context, input model.UpdateEpicInput) (*model.Epic, error) {
+	return resolvers.UpdateEpic(ctx, r.RBACService, r.EpicService, input)
+}
+
+// DeleteEpic is the resolver for the deleteEpic field.
+func (r *mutationResolver) DeleteEpic(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteEpic(ctx, r.RBACService, r.EpicService, id)
+}
+
+// SetCardEpic is the resolver for the setCardEpic field.
+func (r *mutationResolver) SetCardEpic(ctx context.Context, cardID string, epicID *string) (*model.Card, error) {
+	return resolvers.SetCardEpic(ctx, r.RBACService, r.CardService, r.EpicService, cardID, epicID)
+}
+
+// Epics is the resolver for the epics field.
+func (r *queryResolver) Epics(ctx context.Context, projectID string) ([]*model.Epic, error) {
+	return resolvers.Epics(ctx, r.RBACService, r.EpicService, projectID)
+}
+
+// Epic is the resolver for the epic field.
+func (r *queryResolver) Epic(ctx context.Context, id string) (*model.Epic, error) {
+	return resolvers.Epic(ctx, r.RBACService, r.EpicService, id)
+}
+
+// CriticalPath is the resolver for the criticalPath field.
+func (r *queryResolver) CriticalPath(ctx context.Context, epicID string) (*model.CriticalPath, error) {
+	return resolvers.CriticalPath(ctx, r.RBACService, r.EpicService, epicID)
+}
+
+// EpicBurnUpData is the resolver for the epicBurnUpData field.
+func (r *queryResolver) EpicBurnUpData(ctx context.Context, epicID string, mode model.MetricMode) (*model.EpicBurnUpData, error) {
+	return resolvers.EpicBurnUpData(ctx, r.RBACService, r.EpicService, r.MetricsService, epicID, mode)
+}
+
+// Epic returns generated.EpicResolver implementation.
+func (r *Resolver) Epic() generated.EpicResolver { return &epicResolver{r} }
+
+type epicResolver struct{ *Resolver }
@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// ExportCardsPDF is the resolver for the exportCardsPdf field.
+func (r *mutationResolver) ExportCardsPDF(ctx context.Context, input model.ExportCardsPDFInput) (*model.CardPDFExport, error) {
+	return resolvers.ExportCardsPDF(ctx, r.RBACService, r.CardService, r.ExportService, input)
+}
@@ -0,0 +1,32 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// CreateFreezeWindow is the resolver for the createFreezeWindow field.
+func (r *mutationResolver) CreateFreezeWindow(ctx context.Context, boardID string, input model.FreezeWindowInput) (*model.FreezeWindow, error) {
+	return resolvers.CreateFreezeWindow(ctx, r.RBACService, r.FreezeService, boardID, input)
+}
+
+// UpdateFreezeWindow is the resolver for the updateFreezeWindow field.
+func (r *mutationResolver) UpdateFreezeWindow(ctx context.Context, id string, input model.FreezeWindowInput) (*model.FreezeWindow, error) {
+	return resolvers.UpdateFreezeWindow(ctx, r.RBACService, r.FreezeService, id, input)
+}
+
+// DeleteFreezeWindow is the resolver for the deleteFreezeWindow field.
+func (r *mutationResolver) DeleteFreezeWindow(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteFreezeWindow(ctx, r.RBACService, r.FreezeService, id)
+}
+
+// FreezeWindows is the resolver for the freezeWindows field.
+func (r *queryResolver) FreezeWindows(ctx context.Context, boardID string) ([]*model.FreezeWindow, error) {
+	return resolvers.FreezeWindows(ctx, r.RBACService, r.FreezeService, boardID)
+}
@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// Health is the resolver for the health field.
+func (r *projectResolver) Health(ctx context.Context, obj *model.Project) (*model.ProjectHealth, error) {
+	return resolvers.ProjectHealth(ctx, r.HealthService, obj)
+}
+
+// ProjectHealthBreakdown is the resolver for the projectHealthBreakdown field.
+func (r *queryResolver) ProjectHealthBreakdown(ctx context.Context, projectID string) (*model.ProjectHealthBreakdown, error) {
+	return resolvers.ProjectHealthBreakdown(ctx, r.RBACService, r.HealthService, projectID)
+}
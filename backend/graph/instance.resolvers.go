@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// UpdateInstanceSettings is the resolver for the updateInstanceSettings field.
+func (r *mutationResolver) UpdateInstanceSettings(ctx context.Context, input model.UpdateInstanceSettingsInput) (*model.InstanceInfo, error) {
+	return resolvers.UpdateInstanceSettings(ctx, r.InstanceService, input)
+}
+
+// InstanceInfo is the resolver for the instanceInfo field.
+func (r *queryResolver) InstanceInfo(ctx context.Context) (*model.InstanceInfo, error) {
+	return resolvers.InstanceInfo(ctx, r.InstanceService)
+}
+
+// IsPlatformAdmin is the resolver for the isPlatformAdmin field.
+func (r *queryResolver) IsPlatformAdmin(ctx context.Context) (bool, error) {
+	return resolvers.IsPlatformAdmin(ctx, r.InstanceService)
+}
@@ -0,0 +1,43 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// SetInvitationExpiryPolicy is the resolver for the setInvitationExpiryPolicy field.
+func (r *mutationResolver) SetInvitationExpiryPolicy(ctx context.Context, organizationID string, ttlDays *int, reminderDays *int) (*model.Organization, error) {
+	org, err := resolvers.SetInvitationExpiryPolicy(ctx, r.InvitationService, r.RBACService, organizationID, ttlDays, reminderDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"invitation_ttl_days":      ttlDays,
+				"invitation_reminder_days": reminderDays,
+			},
+		})
+	}
+
+	return org, nil
+}
@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// ExportProjectAsJira is the resolver for the exportProjectAsJira field.
+func (r *queryResolver) ExportProjectAsJira(ctx context.Context, projectID string) (*model.JiraExport, error) {
+	return resolvers.ExportProjectAsJira(ctx, r.RBACService, r.ProjectService, r.JiraService, projectID)
+}
@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// LabelSuggestions is the resolver for the labelSuggestions field.
+func (r *cardResolver) LabelSuggestions(ctx context.Context, obj *model.Card) (*model.LabelSuggestions, error) {
+	return resolvers.CardLabelSuggestions(ctx, r.LabelSuggestService, obj)
+}
+
+// AcceptLabelSuggestions is the resolver for the acceptLabelSuggestions field.
+func (r *mutationResolver) AcceptLabelSuggestions(ctx context.Context, input model.AcceptLabelSuggestionsInput) (*model.Card, error) {
+	return resolvers.AcceptLabelSuggestions(ctx, r.CardService, r.Mutation(), input)
+}
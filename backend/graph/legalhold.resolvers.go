@@ -0,0 +1,82 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// PlaceLegalHold is the resolver for the placeLegalHold field.
+func (r *mutationResolver) PlaceLegalHold(ctx context.Context, organizationID string, reason string) (*model.LegalHold, error) {
+	hold, err := resolvers.PlaceLegalHold(ctx, r.RBACService, r.LegalHoldService, r.UserService, organizationID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionLegalHoldPlaced,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			StateAfter:     hold,
+			Metadata: map[string]interface{}{
+				"hold_id": hold.ID,
+				"reason":  hold.Reason,
+			},
+		})
+	}
+
+	return hold, nil
+}
+
+// LiftLegalHold is the resolver for the liftLegalHold field.
+func (r *mutationResolver) LiftLegalHold(ctx context.Context, organizationID string, reason string) (*model.LegalHold, error) {
+	hold, err := resolvers.LiftLegalHold(ctx, r.RBACService, r.LegalHoldService, r.UserService, organizationID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionLegalHoldLifted,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			StateAfter:     hold,
+			Metadata: map[string]interface{}{
+				"hold_id": hold.ID,
+				"reason":  reason,
+			},
+		})
+	}
+
+	return hold, nil
+}
+
+// LegalHold is the resolver for the legalHold field.
+func (r *queryResolver) LegalHold(ctx context.Context, organizationID string) (*model.LegalHold, error) {
+	return resolvers.LegalHold(ctx, r.RBACService, r.LegalHoldService, r.UserService, organizationID)
+}
+
+// LegalHolds is the resolver for the legalHolds field.
+func (r *queryResolver) LegalHolds(ctx context.Context, organizationID string) ([]*model.LegalHold, error) {
+	return resolvers.LegalHolds(ctx, r.RBACService, r.LegalHoldService, r.UserService, organizationID)
+}
@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SetMyLocale is the resolver for the setMyLocale field.
+func (r *mutationResolver) SetMyLocale(ctx context.Context, locale *string) (*model.User, error) {
+	return resolvers.SetMyLocale(ctx, r.LocaleService, locale)
+}
+
+// SetOrganizationDefaultLocale is the resolver for the setOrganizationDefaultLocale field.
+func (r *mutationResolver) SetOrganizationDefaultLocale(ctx context.Context, organizationID string, locale string) (*model.Organization, error) {
+	return resolvers.SetOrganizationDefaultLocale(ctx, r.RBACService, r.LocaleService, organizationID, locale)
+}
+
+// SupportedLocales is the resolver for the supportedLocales field.
+func (r *queryResolver) SupportedLocales(ctx context.Context) ([]string, error) {
+	return resolvers.SupportedLocales(ctx)
+}
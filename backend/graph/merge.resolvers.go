@@ -0,0 +1,78 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// MergeCards is the resolver for the mergeCards field.
+func (r *mutationResolver) MergeCards(ctx context.Context, primaryID string, duplicateIds []string) (*model.MergeCardsResult, error) {
+	result, err := resolvers.MergeCards(ctx, r.RBACService, r.CardService, r.MergeService, primaryID, duplicateIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging: the merge on the primary card, and on each duplicate pointing at it
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		primaryCardID, _ := uuid.Parse(result.Card.ID)
+
+		var boardID, projectID, orgID *uuid.UUID
+		if board, err := r.CardService.GetBoardByCardID(ctx, primaryCardID); err == nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		duplicateCardIDs := make([]string, len(result.Duplicates))
+		for i, card := range result.Duplicates {
+			duplicateCardIDs[i] = card.ID
+			duplicateCardID, _ := uuid.Parse(card.ID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionCardMerged,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       duplicateCardID,
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				BoardID:        boardID,
+				StateAfter:     card,
+				Metadata: map[string]interface{}{
+					"title":       card.Title,
+					"merged_into": result.Card.ID,
+				},
+			})
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCardMerged,
+			EntityType:     auditrepo.EntityCard,
+			EntityID:       primaryCardID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateAfter:     result.Card,
+			Metadata: map[string]interface{}{
+				"title":       result.Card.Title,
+				"card_ids":    duplicateCardIDs,
+				"tags_moved":  result.TagsMoved,
+				"links_moved": result.LinksMoved,
+			},
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// MirrorCard is the resolver for the mirrorCard field.
+func (r *mutationResolver) MirrorCard(ctx context.Context, cardID string, targetProjectID string, direction model.CardMirrorDirection) (*model.CardMirror, error) {
+	return resolvers.MirrorCard(ctx, r.RBACService, r.CardService, r.MirrorService, cardID, targetProjectID, direction)
+}
+
+// SetCardMirrorDirection is the resolver for the setCardMirrorDirection field.
+func (r *mutationResolver) SetCardMirrorDirection(ctx context.Context, id string, direction model.CardMirrorDirection) (*model.CardMirror, error) {
+	return resolvers.SetCardMirrorDirection(ctx, r.RBACService, r.CardService, r.MirrorService, id, direction)
+}
+
+// RemoveCardMirror is the resolver for the removeCardMirror field.
+func (r *mutationResolver) RemoveCardMirror(ctx context.Context, id string) (bool, error) {
+	return resolvers.RemoveCardMirror(ctx, r.RBACService, r.CardService, r.MirrorService, id)
+}
+
+// CardMirrors is the resolver for the cardMirrors field.
+func (r *queryResolver) CardMirrors(ctx context.Context, cardID string) ([]*model.CardMirror, error) {
+	return resolvers.CardMirrors(ctx, r.RBACService, r.CardService, r.MirrorService, cardID)
+}
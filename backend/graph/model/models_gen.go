@@ -9,12 +9,93 @@ import (
 	"time"
 )
 
+type AcceptLabelSuggestionsInput struct {
+	CardID string `json:"cardId"`
+	// Suggested tags to add to the card's tags
+	TagIds []string `json:"tagIds"`
+	// The suggested priority, when accepted
+	Priority *CardPriority `json:"priority,omitempty"`
+}
+
+type AddCardDependencyInput struct {
+	FromCardID string             `json:"fromCardId"`
+	ToCardID   string             `json:"toCardId"`
+	Kind       CardDependencyKind `json:"kind"`
+}
+
+type APIToken struct {
+	ID    string        `json:"id"`
+	Name  string        `json:"name"`
+	Kind  APITokenKind  `json:"kind"`
+	Scope APITokenScope `json:"scope"`
+	// Who the token acts as: its creator, or the service account of a service token
+	User *User `json:"user,omitempty"`
+	// The organization of a service token; null for personal tokens
+	OrganizationID *string `json:"organizationId,omitempty"`
+	CreatedBy      *User   `json:"createdBy,omitempty"`
+	// Null for tokens that don't expire
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// When the token was last used, to the minute
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
 type AssignProjectRoleInput struct {
 	ProjectID string  `json:"projectId"`
 	UserID    string  `json:"userId"`
 	RoleID    *string `json:"roleId,omitempty"`
 }
 
+type AssigneeEstimationAccuracy struct {
+	// Null for unassigned cards
+	AssigneeID           *string  `json:"assigneeId,omitempty"`
+	CardCount            int      `json:"cardCount"`
+	Points               int      `json:"points"`
+	AverageCycleTimeDays float64  `json:"averageCycleTimeDays"`
+	DaysPerPoint         *float64 `json:"daysPerPoint,omitempty"`
+	// Mean distance of the cards' ratios from 1; 0 means every estimate was on pace
+	MeanDeviation    *float64 `json:"meanDeviation,omitempty"`
+	ReestimatedCount int      `json:"reestimatedCount"`
+}
+
+// Where to upload a file. PUT exactly sizeBytes bytes to uploadUrl with uploadHeaders before expiresAt, then call completeAttachmentUpload
+type AttachmentUpload struct {
+	// The attachment, not shown on the card until the upload is completed
+	Attachment    *CardAttachment `json:"attachment"`
+	UploadURL     string          `json:"uploadUrl"`
+	UploadHeaders []*UploadHeader `json:"uploadHeaders"`
+	ExpiresAt     time.Time       `json:"expiresAt"`
+}
+
+type AuditAnomaly struct {
+	ID   string           `json:"id"`
+	Kind AuditAnomalyKind `json:"kind"`
+	// The member whose activity was flagged
+	Actor *User `json:"actor,omitempty"`
+	// The flagged audit event, for role changes
+	AuditEventID *string    `json:"auditEventId,omitempty"`
+	EventCount   int        `json:"eventCount"`
+	WindowStart  time.Time  `json:"windowStart"`
+	WindowEnd    time.Time  `json:"windowEnd"`
+	DetectedAt   time.Time  `json:"detectedAt"`
+	NotifiedAt   *time.Time `json:"notifiedAt,omitempty"`
+}
+
+type AuditAnomalySettings struct {
+	OrganizationID string `json:"organizationId"`
+	Enabled        bool   `json:"enabled"`
+	// How many deletions by one member within the window count as a mass deletion
+	MassDeletionThreshold     int `json:"massDeletionThreshold"`
+	MassDeletionWindowMinutes int `json:"massDeletionWindowMinutes"`
+	// Hour of the day business hours start, in the timezone
+	BusinessHoursStart int `json:"businessHoursStart"`
+	// Hour of the day business hours end, in the timezone
+	BusinessHoursEnd int `json:"businessHoursEnd"`
+	// IANA timezone, e.g. Europe/Berlin
+	Timezone string `json:"timezone"`
+}
+
 type AuditEvent struct {
 	ID           string          `json:"id"`
 	OccurredAt   time.Time       `json:"occurredAt"`
@@ -31,6 +112,16 @@ type AuditEvent struct {
 	IPAddress    *string         `json:"ipAddress,omitempty"`
 	UserAgent    *string         `json:"userAgent,omitempty"`
 	TraceID      *string         `json:"traceId,omitempty"`
+	// Null for events not caused by an authenticated request
+	AuthMethod *AuditAuthMethod `json:"authMethod,omitempty"`
+	// The API token the request was authenticated with; null for other auth methods
+	APITokenID *string `json:"apiTokenId,omitempty"`
+	// Whether a staff member acted as the actor
+	Impersonated bool `json:"impersonated"`
+	// The staff member who acted as the actor; null when not impersonated or their account was deleted
+	Impersonator *User `json:"impersonator,omitempty"`
+	// What happened, as a sentence in the requester's language, e.g. 'Ana moved a card from To Do to Doing'
+	Summary string `json:"summary"`
 }
 
 type AuditEventConnection struct {
@@ -50,6 +141,9 @@ type AuditFilters struct {
 	ActorID     *string           `json:"actorId,omitempty"`
 	StartDate   *time.Time        `json:"startDate,omitempty"`
 	EndDate     *time.Time        `json:"endDate,omitempty"`
+	IPAddress   *string           `json:"ipAddress,omitempty"`
+	// Only impersonated events when true, only events not impersonated when false
+	Impersonated *bool `json:"impersonated,omitempty"`
 }
 
 type AuthPayload struct {
@@ -65,23 +159,113 @@ type Board struct {
 	Columns      []*BoardColumn `json:"columns"`
 	Sprints      []*Sprint      `json:"sprints"`
 	ActiveSprint *Sprint        `json:"activeSprint,omitempty"`
-	CreatedAt    time.Time      `json:"createdAt"`
-	UpdatedAt    time.Time      `json:"updatedAt"`
+	// Allowed column-to-column moves. Empty means cards may move between any columns.
+	ColumnTransitions []*ColumnTransition `json:"columnTransitions"`
+	CreatedAt         time.Time           `json:"createdAt"`
+	UpdatedAt         time.Time           `json:"updatedAt"`
+	Appearance        *BoardAppearance    `json:"appearance"`
+	// Days a card may stay in a done column before it's archived; null never archives
+	AutoArchiveDays *int `json:"autoArchiveDays,omitempty"`
+	// Aggregates of every column, in column order, computed by the database
+	ColumnStats []*ColumnStats `json:"columnStats"`
+	// Quiet mode: minutes card notifications are collected before each recipient gets one summary; null notifies per event
+	NotificationBatchMinutes *int `json:"notificationBatchMinutes,omitempty"`
+	// Moving a card out of a backlog column adds it to the active sprint, and moving it back removes it
+	SprintAutoMembership bool `json:"sprintAutoMembership"`
+	// How many of the board's cards have unread activity for the current user
+	UnreadCount int `json:"unreadCount"`
+}
+
+// How a board looks; null fields are left to the client's defaults
+type BoardAppearance struct {
+	// #RRGGBB
+	BackgroundColor *string `json:"backgroundColor,omitempty"`
+	// An https URL of the background image
+	BackgroundImageURL *string `json:"backgroundImageUrl,omitempty"`
+	// The name of one of the columnPalettes presets
+	ColumnPalette *string `json:"columnPalette,omitempty"`
+}
+
+type BoardAppearanceInput struct {
+	// #RRGGBB
+	BackgroundColor *string `json:"backgroundColor,omitempty"`
+	// An https URL of at most 2048 characters
+	BackgroundImageURL *string `json:"backgroundImageUrl,omitempty"`
+	// The name of one of the columnPalettes presets
+	ColumnPalette *string `json:"columnPalette,omitempty"`
+	// Give the board's columns the palette's colors
+	RecolorColumns *bool `json:"recolorColumns,omitempty"`
+}
+
+// What changed on a board since a sync cursor
+type BoardChangeSet struct {
+	// Set on a full sync or when the board itself changed
+	Board *Board `json:"board,omitempty"`
+	// Every column of the board
+	Columns []*BoardColumn `json:"columns"`
+	// Cards created or changed since the cursor, in their current state
+	Cards      []*Card          `json:"cards"`
+	Tombstones []*SyncTombstone `json:"tombstones"`
+	// Pass to the next boardChanges call
+	Cursor string `json:"cursor"`
+	// More changes are waiting; call boardChanges again with the new cursor
+	HasMore bool `json:"hasMore"`
 }
 
 type BoardColumn struct {
-	ID        string    `json:"id"`
-	Board     *Board    `json:"board"`
-	Name      string    `json:"name"`
-	Position  int       `json:"position"`
-	IsBacklog bool      `json:"isBacklog"`
-	IsHidden  bool      `json:"isHidden"`
-	IsDone    bool      `json:"isDone"`
-	Color     *string   `json:"color,omitempty"`
-	WipLimit  *int      `json:"wipLimit,omitempty"`
-	Cards     []*Card   `json:"cards"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        string  `json:"id"`
+	Board     *Board  `json:"board"`
+	Name      string  `json:"name"`
+	Position  int     `json:"position"`
+	IsBacklog bool    `json:"isBacklog"`
+	IsHidden  bool    `json:"isHidden"`
+	IsDone    bool    `json:"isDone"`
+	Color     *string `json:"color,omitempty"`
+	WipLimit  *int    `json:"wipLimit,omitempty"`
+	// Caps the story points of the column's cards; moves that would exceed it are refused
+	PointLimit *int `json:"pointLimit,omitempty"`
+	// Sum of the story points of the column's cards
+	PointsInColumn int                 `json:"pointsInColumn"`
+	Cards          []*Card             `json:"cards"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+	CardDefaults   *ColumnCardDefaults `json:"cardDefaults"`
+	// How many users watch the column
+	WatcherCount int `json:"watcherCount"`
+	// Whether the current user watches the column
+	IsWatching bool `json:"isWatching"`
+}
+
+type BoardSnapshotImage struct {
+	// Presigned URL the image can be embedded from until expiresAt
+	URL       string              `json:"url"`
+	ExpiresAt time.Time           `json:"expiresAt"`
+	Format    BoardSnapshotFormat `json:"format"`
+	Width     int                 `json:"width"`
+	Height    int                 `json:"height"`
+}
+
+// A user currently looking at a board
+type BoardViewer struct {
+	User       *User            `json:"user"`
+	Activity   PresenceActivity `json:"activity"`
+	LastSeenAt time.Time        `json:"lastSeenAt"`
+}
+
+// A TXT record the organization publishes to send mail from its domain
+type BrandingDNSRecord struct {
+	Purpose BrandingDNSRecordPurpose `json:"purpose"`
+	Name    string                   `json:"name"`
+	Value   string                   `json:"value"`
+	// Whether the last verifyBrandingDomain found the record
+	Verified bool `json:"verified"`
+}
+
+type BulkMemberRoleChangeResult struct {
+	// Set when every member took the role; otherwise none of them changed
+	Applied bool `json:"applied"`
+	// One result per user, in the order they were given, without repeats
+	Results []*MemberRoleChangeResult `json:"results"`
 }
 
 type BurnDownData struct {
@@ -103,7 +287,11 @@ type BurnUpData struct {
 }
 
 type Card struct {
-	ID          string       `json:"id"`
+	ID string `json:"id"`
+	// Counts the cards of the project from 1; a card moved to another project's board is renumbered there
+	Number int `json:"number"`
+	// The project key and number, such as API-42
+	Key         string       `json:"key"`
 	Column      *BoardColumn `json:"column"`
 	Board       *Board       `json:"board"`
 	Sprints     []*Sprint    `json:"sprints"`
@@ -118,6 +306,278 @@ type Card struct {
 	CreatedAt   time.Time    `json:"createdAt"`
 	UpdatedAt   time.Time    `json:"updatedAt"`
 	CreatedBy   *User        `json:"createdBy,omitempty"`
+	// When the card was archived; archived cards are hidden from the board
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+	// Uploaded attachments, oldest first
+	Attachments []*CardAttachment `json:"attachments"`
+	// Every sprint the card has been in, oldest first, from its sprints and their audit history. A card taken out of a sprint and put back has a stay for each time
+	SprintHistory []*CardSprintStay `json:"sprintHistory"`
+	// In order
+	Checklist []*ChecklistItem `json:"checklist"`
+	// Percentage of checklist items done, rounded down; null for cards without a checklist
+	ChecklistCompletion *int `json:"checklistCompletion,omitempty"`
+	// Oldest first
+	Comments []*CardComment `json:"comments"`
+	// Links from or to the card, oldest first
+	Links  []*CardLink `json:"links"`
+	EpicID *string     `json:"epicId,omitempty"`
+	// Tags and a priority suggested from the labels of the project's most similarly worded cards, or by the configured language model while the project has fewer than 10 labelled cards and its organization has enabled AI features. Computed on request; select it in createCard's response to suggest labels for a new card
+	LabelSuggestions *LabelSuggestions `json:"labelSuggestions"`
+	// The card this card was merged into as a duplicate; merged cards are archived
+	MergedIntoID *string `json:"mergedIntoId,omitempty"`
+	// Whether the card changed since the current user last viewed it, or they never viewed it
+	HasUnreadActivity bool `json:"hasUnreadActivity"`
+}
+
+// Narrows the cards aggregated; omitted or empty lists do not filter
+type CardAggregateFilter struct {
+	BoardIds    []string `json:"boardIds,omitempty"`
+	AssigneeIds []string `json:"assigneeIds,omitempty"`
+	// Cards with any of the tags
+	TagIds     []string       `json:"tagIds,omitempty"`
+	Priorities []CardPriority `json:"priorities,omitempty"`
+	// True for cards in done columns only, false for cards outside them
+	Done *bool `json:"done,omitempty"`
+}
+
+type CardAggregateGroup struct {
+	// The group's value per grouped field, in groupBy order
+	Keys               []*CardAggregateKey `json:"keys"`
+	CardCount          int                 `json:"cardCount"`
+	EstimatedCardCount int                 `json:"estimatedCardCount"`
+	StoryPoints        int                 `json:"storyPoints"`
+}
+
+type CardAggregateKey struct {
+	Field CardAggregateField `json:"field"`
+	// The id of the assignee, tag, column or epic, or the priority; null for cards without one
+	Value *string `json:"value,omitempty"`
+	// The display name of the value
+	Label *string `json:"label,omitempty"`
+}
+
+type CardAttachment struct {
+	ID          string `json:"id"`
+	CardID      string `json:"cardId"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	SizeBytes   int    `json:"sizeBytes"`
+	// Null once the uploader's account is deleted
+	UploadedBy *User     `json:"uploadedBy,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// Presigned URL the file can be downloaded from for a limited time
+	DownloadURL string `json:"downloadUrl"`
+}
+
+type CardComment struct {
+	ID     string `json:"id"`
+	CardID string `json:"cardId"`
+	// Null once the author's account is deleted
+	Author *User `json:"author,omitempty"`
+	// Sanitized HTML
+	Body string `json:"body"`
+	// When the body last changed; null for comments never edited
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	// Organization members notified because the comment @mentions them
+	Mentions []*User `json:"mentions"`
+}
+
+type CardDependency struct {
+	ID        string             `json:"id"`
+	Kind      CardDependencyKind `json:"kind"`
+	FromCard  *Card              `json:"fromCard"`
+	ToCard    *Card              `json:"toCard"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+// A card drafted from a prompt by the configured language model. Drafts aren't saved; create the card from it.
+type CardDraft struct {
+	Title string `json:"title"`
+	// HTML: the drafted description followed by the acceptance criteria as an unticked checklist
+	Description        string          `json:"description"`
+	AcceptanceCriteria []string        `json:"acceptanceCriteria"`
+	SuggestedTags      []*CardDraftTag `json:"suggestedTags"`
+}
+
+// A tag proposed for a drafted card
+type CardDraftTag struct {
+	Name string `json:"name"`
+	// The project's existing tag of that name; null when the tag would be new
+	Tag *Tag `json:"tag,omitempty"`
+}
+
+type CardDragInput struct {
+	CardID string `json:"cardId"`
+	// The column the card hovers over; omit when the drag ends or is cancelled
+	ColumnID    *string `json:"columnId,omitempty"`
+	AfterCardID *string `json:"afterCardId,omitempty"`
+}
+
+// An in-progress card drag by another viewer, used to show a ghost card
+type CardDragPreview struct {
+	User   *User  `json:"user"`
+	CardID string `json:"cardId"`
+	// The column the card hovers over; null when the drag ended or was cancelled
+	ColumnID *string `json:"columnId,omitempty"`
+	// The card the dragged card would be dropped after; null for the top of the column
+	AfterCardID *string   `json:"afterCardId,omitempty"`
+	SentAt      time.Time `json:"sentAt"`
+}
+
+// A completed card's original estimate against how long it took
+type CardEstimationAccuracy struct {
+	CardID     string  `json:"cardId"`
+	Title      string  `json:"title"`
+	AssigneeID *string `json:"assigneeId,omitempty"`
+	// The first story points the card was given
+	OriginalStoryPoints int `json:"originalStoryPoints"`
+	// The story points the card ended with
+	StoryPoints *int `json:"storyPoints,omitempty"`
+	// Days from the card first leaving its starting column to entering a done column
+	CycleTimeDays float64 `json:"cycleTimeDays"`
+	// The original estimate at the project's days per point over the range
+	ExpectedDays float64 `json:"expectedDays"`
+	// Cycle time over expected days: above 1 took longer than estimated, below 1 went faster
+	Ratio       *float64  `json:"ratio,omitempty"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// A Jira or Trello user cards are assigned to
+type CardImportAssignee struct {
+	// The user's match; resolve pending ones with resolveUserMatch and import again to assign their cards
+	Match *UserMatch `json:"match"`
+	// Who the user's cards are assigned to: the matched user, when they can view the project. Null leaves the cards unassigned.
+	AssigneeID *string `json:"assigneeId,omitempty"`
+	CardCount  int     `json:"cardCount"`
+}
+
+type CardImportColumnMappingInput struct {
+	// The CSV header, matched ignoring case
+	Header string          `json:"header"`
+	Field  CardImportField `json:"field"`
+}
+
+type CardImportResult struct {
+	// Whether every row can be imported
+	Valid bool `json:"valid"`
+	// The column the cards are created in
+	ColumnID string           `json:"columnId"`
+	Rows     []*CardImportRow `json:"rows"`
+	// Tags the import creates in the project
+	NewTags []string `json:"newTags"`
+	// The created cards in row order; empty for dry runs and imports with row errors
+	Cards []*Card `json:"cards"`
+}
+
+// One CSV row, or one card of a Jira or Trello export, read into card fields
+type CardImportRow struct {
+	// The row's line in the CSV, counting the header as line 1, or the card's position in a Trello export
+	Line          int                   `json:"line"`
+	Title         string                `json:"title"`
+	Description   *string               `json:"description,omitempty"`
+	AssigneeEmail *string               `json:"assigneeEmail,omitempty"`
+	Tags          []string              `json:"tags"`
+	StoryPoints   *int                  `json:"storyPoints,omitempty"`
+	DueDate       *time.Time            `json:"dueDate,omitempty"`
+	Errors        []*CardImportRowError `json:"errors"`
+	// The Jira issue key or Trello short link
+	SourceKey *string `json:"sourceKey,omitempty"`
+	// The Jira status or Trello list
+	Status *string `json:"status,omitempty"`
+	// Who the card is assigned to in Jira or Trello
+	Assignee *string `json:"assignee,omitempty"`
+	// The column the card is created in
+	ColumnID string `json:"columnId"`
+	// Who the card is assigned to
+	AssigneeID *string `json:"assigneeId,omitempty"`
+}
+
+type CardImportRowError struct {
+	Field CardImportField `json:"field"`
+	// One of required, invalid_number, invalid_date, tag_too_long, unknown_assignee or content_rejected
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Where the cards with a Jira status or in a Trello list go
+type CardImportStatus struct {
+	Status   string `json:"status"`
+	ColumnID string `json:"columnId"`
+	// False when no column matched the status, so its cards go to the default column
+	Matched   bool `json:"matched"`
+	CardCount int  `json:"cardCount"`
+}
+
+type CardImportStatusMappingInput struct {
+	// A Jira status or Trello list, matched ignoring case
+	Status   string `json:"status"`
+	ColumnID string `json:"columnId"`
+}
+
+// A card dependency seen from one of its cards
+type CardLink struct {
+	// ID of the dependency, for removeCardDependency
+	ID       string           `json:"id"`
+	Relation CardLinkRelation `json:"relation"`
+	// The other card of the link
+	Card      *Card     `json:"card"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// A card shown on another project's board, whose title and column follow its source card
+type CardMirror struct {
+	ID         string              `json:"id"`
+	Direction  CardMirrorDirection `json:"direction"`
+	SourceCard *Card               `json:"sourceCard"`
+	MirrorCard *Card               `json:"mirrorCard"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+type CardPDFExport struct {
+	// Presigned URL the PDF can be downloaded from until expiresAt
+	DownloadURL string    `json:"downloadUrl"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	CardCount   int       `json:"cardCount"`
+	PageCount   int       `json:"pageCount"`
+}
+
+// One stretch of a card being in a sprint
+type CardSprintStay struct {
+	Sprint *Sprint `json:"sprint"`
+	// Null when the card joined the sprint before its history was recorded
+	AddedAt *time.Time `json:"addedAt,omitempty"`
+	// Null while the card is still in the sprint, or when it left without an audit event
+	RemovedAt *time.Time `json:"removedAt,omitempty"`
+	// Whether the card is in the sprint now; cards stay in the closed sprints they were carried out of
+	InSprint bool `json:"inSprint"`
+}
+
+// A card carried out of at least one of the report's sprints
+type CarriedCard struct {
+	CardID      string `json:"cardId"`
+	Title       string `json:"title"`
+	StoryPoints *int   `json:"storyPoints,omitempty"`
+	// The report's sprints the card was in, oldest first
+	Sprints []*Sprint `json:"sprints"`
+	// How many of the report's sprints the card was in without being delivered
+	TimesCarried int `json:"timesCarried"`
+	// The card's story points for each time it was carried
+	UndeliveredPoints int `json:"undeliveredPoints"`
+	// Whether the card has since been delivered
+	Delivered bool `json:"delivered"`
+}
+
+type CarryoverReport struct {
+	BoardID string `json:"boardId"`
+	// The closed sprints covered, oldest first
+	Sprints []*SprintCarryover `json:"sprints"`
+	// Most carried first
+	Cards           []*CarriedCard `json:"cards"`
+	TotalCarryovers int            `json:"totalCarryovers"`
+	// Story points committed to a sprint and not delivered in it, summed over every carry
+	UndeliveredPoints int `json:"undeliveredPoints"`
 }
 
 type ChangeMemberRoleInput struct {
@@ -125,6 +585,69 @@ type ChangeMemberRoleInput struct {
 	RoleID string `json:"roleId"`
 }
 
+type ChecklistItem struct {
+	ID     string `json:"id"`
+	CardID string `json:"cardId"`
+	Title  string `json:"title"`
+	Done   bool   `json:"done"`
+	// Null for unassigned items and once the assignee's account is deleted
+	Assignee *User `json:"assignee,omitempty"`
+	// Zero-based place in the card's checklist
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ColumnAlert struct {
+	ID       string          `json:"id"`
+	ColumnID string          `json:"columnId"`
+	Kind     ColumnAlertKind `json:"kind"`
+	// When the condition was first seen
+	StartedAt time.Time `json:"startedAt"`
+	// When the board's admins were alerted
+	RaisedAt time.Time `json:"raisedAt"`
+	// When the condition cleared, null while it lasts
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	// The column's card count when the alert was raised
+	CardCount      *int     `json:"cardCount,omitempty"`
+	WipLimit       *int     `json:"wipLimit,omitempty"`
+	AverageAgeDays *float64 `json:"averageAgeDays,omitempty"`
+}
+
+type ColumnAlertSettings struct {
+	BoardID string `json:"boardId"`
+	Enabled bool   `json:"enabled"`
+	// Minutes a column may stay over its WIP limit before its board's admins are alerted; null turns WIP alerts off
+	WipExceededMinutes *int `json:"wipExceededMinutes,omitempty"`
+	// Average card age in hours that alerts the board's admins; null turns aging alerts off
+	AverageAgeHours *int `json:"averageAgeHours,omitempty"`
+	// Slack incoming webhook alerts are also posted to
+	SlackWebhookURL *string `json:"slackWebhookUrl,omitempty"`
+	SlackChannel    string  `json:"slackChannel"`
+}
+
+// Values applied to cards created in a column when the card does not set them
+type ColumnCardDefaults struct {
+	Priority *CardPriority `json:"priority,omitempty"`
+	Assignee *User         `json:"assignee,omitempty"`
+	// Added to the tags a new card is created with
+	Tags        []*Tag `json:"tags"`
+	StoryPoints *int   `json:"storyPoints,omitempty"`
+	// Description of cards created without one
+	Description *string `json:"description,omitempty"`
+	// Items appended to the description of every new card as an unticked list
+	Checklist []string `json:"checklist"`
+}
+
+type ColumnCardDefaultsInput struct {
+	Priority    *CardPriority `json:"priority,omitempty"`
+	AssigneeID  *string       `json:"assigneeId,omitempty"`
+	TagIds      []string      `json:"tagIds,omitempty"`
+	StoryPoints *int          `json:"storyPoints,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Checklist   []string      `json:"checklist,omitempty"`
+}
+
 type ColumnFlowData struct {
 	ColumnID   string `json:"columnId"`
 	ColumnName string `json:"columnName"`
@@ -132,6 +655,104 @@ type ColumnFlowData struct {
 	Values     []int  `json:"values"`
 }
 
+// A preset of column colors, applied to a board's columns in order
+type ColumnPalette struct {
+	Name string `json:"name"`
+	// #RRGGBB colors, repeated from the start for boards with more columns
+	Colors []string `json:"colors"`
+}
+
+// The aggregates of the cards in a board column
+type ColumnStats struct {
+	ColumnID  string `json:"columnId"`
+	CardCount int    `json:"cardCount"`
+	// Sum of the story points of the estimated cards
+	StoryPoints        int  `json:"storyPoints"`
+	EstimatedCardCount int  `json:"estimatedCardCount"`
+	WipLimit           *int `json:"wipLimit,omitempty"`
+	// Set when the column holds more cards than its WIP limit
+	OverWipLimit bool `json:"overWipLimit"`
+	PointLimit   *int `json:"pointLimit,omitempty"`
+	// Set when the column holds more story points than its point limit
+	OverPointLimit bool `json:"overPointLimit"`
+	// Mean days since the cards were created; null for an empty column
+	AverageAgeDays *float64 `json:"averageAgeDays,omitempty"`
+	// Mean days since the cards entered the column; null for an empty column
+	AverageDaysInColumn *float64 `json:"averageDaysInColumn,omitempty"`
+}
+
+// A move between two columns that the board workflow allows
+type ColumnTransition struct {
+	FromColumnID string `json:"fromColumnId"`
+	ToColumnID   string `json:"toColumnId"`
+}
+
+type ColumnTransitionInput struct {
+	FromColumnID string `json:"fromColumnId"`
+	ToColumnID   string `json:"toColumnId"`
+}
+
+// A notification that someone @mentioned the user in a comment
+type CommentMention struct {
+	ID      string       `json:"id"`
+	Comment *CardComment `json:"comment"`
+	Card    *Card        `json:"card"`
+	// Who wrote the comment; null once their account is deleted
+	MentionedBy *User      `json:"mentionedBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ReadAt      *time.Time `json:"readAt,omitempty"`
+}
+
+// Maximum lengths of user-written text, in characters
+type ContentLimits struct {
+	CardTitle int `json:"cardTitle"`
+	// Measured on the description's HTML
+	CardDescription int `json:"cardDescription"`
+	Comment         int `json:"comment"`
+}
+
+// Per-user daily activity in a project over whole UTC days
+type ContributorActivity struct {
+	ProjectID string `json:"projectId"`
+	// Start of the first day
+	From time.Time `json:"from"`
+	// End of the last day, exclusive
+	To time.Time `json:"to"`
+	// The highest total of any contributor on any day
+	MaxDailyCount int `json:"maxDailyCount"`
+	// Most active first; only the requester unless they can manage the project
+	Contributors []*ContributorActivityRow `json:"contributors"`
+}
+
+// A contributor's activity on one UTC day
+type ContributorActivityDay struct {
+	// Start of the day
+	Date         time.Time `json:"date"`
+	CardsCreated int       `json:"cardsCreated"`
+	CardsMoved   int       `json:"cardsMoved"`
+	Comments     int       `json:"comments"`
+	Total        int       `json:"total"`
+	// From 0 (no activity) to 4, against the busiest day of the heatmap
+	Level int `json:"level"`
+}
+
+type ContributorActivityRow struct {
+	User         *User `json:"user"`
+	CardsCreated int   `json:"cardsCreated"`
+	CardsMoved   int   `json:"cardsMoved"`
+	Comments     int   `json:"comments"`
+	Total        int   `json:"total"`
+	// Every day of the range, oldest first
+	Days []*ContributorActivityDay `json:"days"`
+}
+
+type CreateAPITokenInput struct {
+	Name  string        `json:"name"`
+	Scope APITokenScope `json:"scope"`
+	// Leave out for a token that doesn't expire
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
 type CreateBoardInput struct {
 	ProjectID   string  `json:"projectId"`
 	Name        string  `json:"name"`
@@ -149,12 +770,26 @@ type CreateCardInput struct {
 	StoryPoints *int          `json:"storyPoints,omitempty"`
 }
 
+type CreateChecklistItemInput struct {
+	CardID string `json:"cardId"`
+	// At most 500 characters
+	Title string `json:"title"`
+	// Must be a member of the card's organization
+	AssigneeID *string `json:"assigneeId,omitempty"`
+}
+
 type CreateColumnInput struct {
 	BoardID   string `json:"boardId"`
 	Name      string `json:"name"`
 	IsBacklog *bool  `json:"isBacklog,omitempty"`
 }
 
+type CreateEpicInput struct {
+	ProjectID   string  `json:"projectId"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
 type CreateOrganizationInput struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description,omitempty"`
@@ -189,6 +824,45 @@ type CreateTagInput struct {
 	Description *string `json:"description,omitempty"`
 }
 
+type CreateWebhookInput struct {
+	// An absolute http or https URL
+	URL         string   `json:"url"`
+	Description *string  `json:"description,omitempty"`
+	Events      []string `json:"events"`
+}
+
+type CreatedAPIToken struct {
+	// The secret to send as a bearer token. It is only returned here.
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"apiToken"`
+}
+
+type CriticalPath struct {
+	EpicID string `json:"epicId"`
+	// Remaining story points along the longest chain of blocking cards
+	Length int `json:"length"`
+	// The cards of that chain, first card first
+	Path []*Card `json:"path"`
+	// Every card of the epic, in topological order
+	Cards []*CriticalPathCard `json:"cards"`
+}
+
+// A card's place in its epic's schedule. Times are story points of remaining work from now
+type CriticalPathCard struct {
+	Card *Card `json:"card"`
+	// The card's estimate, or 0 once it is done or when it has none
+	RemainingPoints int `json:"remainingPoints"`
+	// False for unfinished cards without story points
+	Estimated      bool `json:"estimated"`
+	EarliestStart  int  `json:"earliestStart"`
+	EarliestFinish int  `json:"earliestFinish"`
+	LatestStart    int  `json:"latestStart"`
+	LatestFinish   int  `json:"latestFinish"`
+	// How far the card can slip without moving the epic's end
+	Slack    int  `json:"slack"`
+	Critical bool `json:"critical"`
+}
+
 type CumulativeFlowData struct {
 	SprintID   string            `json:"sprintId"`
 	SprintName string            `json:"sprintName"`
@@ -201,6 +875,190 @@ type DataPoint struct {
 	Value float64   `json:"value"`
 }
 
+type DateRangeInput struct {
+	// Start of the range, inclusive; defaults to 90 days before the end
+	From *time.Time `json:"from,omitempty"`
+	// End of the range, exclusive; defaults to now
+	To *time.Time `json:"to,omitempty"`
+}
+
+// The cards of a project that have dependencies, with the links between them
+type DependencyGraph struct {
+	// Nodes in topological order: a card comes after every card blocking it, except along cycles
+	Nodes     []*DependencyGraphNode `json:"nodes"`
+	Edges     []*DependencyGraphEdge `json:"edges"`
+	HasCycles bool                   `json:"hasCycles"`
+}
+
+type DependencyGraphEdge struct {
+	ID         string             `json:"id"`
+	Kind       CardDependencyKind `json:"kind"`
+	FromCardID string             `json:"fromCardId"`
+	ToCardID   string             `json:"toCardId"`
+	// Set for BLOCKS edges that are part of a cycle
+	InCycle bool `json:"inCycle"`
+}
+
+type DependencyGraphNode struct {
+	Card *Card `json:"card"`
+	// Length of the longest chain of blocking cards leading to the card
+	Level int `json:"level"`
+	// Position of the card in topological order, starting at 0
+	Order int `json:"order"`
+	// Set when the card (transitively) blocks itself
+	InCycle bool `json:"inCycle"`
+}
+
+type DraftCardInput struct {
+	ProjectID string `json:"projectId"`
+	// One line describing the card, up to 500 characters
+	Prompt string `json:"prompt"`
+}
+
+// A proposed due date and how it was reached
+type DueDateSuggestion struct {
+	DueDate time.Time `json:"dueDate"`
+	// Working days needed for the assignee's workload plus the estimate
+	WorkingDays    int `json:"workingDays"`
+	EstimatePoints int `json:"estimatePoints"`
+	// Story points on the assignee's other unfinished cards, assumed to be done first
+	WorkloadPoints int `json:"workloadPoints"`
+	WorkloadCards  int `json:"workloadCards"`
+	// Holidays between the start and the due date
+	SkippedHolidays []*ProjectHoliday `json:"skippedHolidays"`
+}
+
+// A board's charts read with an embed token. Charts the token doesn't allow are null.
+type EmbeddedMetrics struct {
+	BoardID   string `json:"boardId"`
+	BoardName string `json:"boardName"`
+	// The sprint of the sprint charts; null when the board has no active sprint and none was given
+	Sprint         *Sprint             `json:"sprint,omitempty"`
+	BurnDown       *BurnDownData       `json:"burnDown,omitempty"`
+	BurnUp         *BurnUpData         `json:"burnUp,omitempty"`
+	Velocity       *VelocityData       `json:"velocity,omitempty"`
+	CumulativeFlow *CumulativeFlowData `json:"cumulativeFlow,omitempty"`
+}
+
+// A larger piece of work grouping cards of a project
+type Epic struct {
+	ID          string        `json:"id"`
+	ProjectID   string        `json:"projectId"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Cards       []*Card       `json:"cards"`
+	Progress    *EpicProgress `json:"progress"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// An epic's scope and done work per day, from its creation until today
+type EpicBurnUpData struct {
+	EpicID    string       `json:"epicId"`
+	EpicName  string       `json:"epicName"`
+	StartDate time.Time    `json:"startDate"`
+	EndDate   time.Time    `json:"endDate"`
+	ScopeLine []*DataPoint `json:"scopeLine"`
+	DoneLine  []*DataPoint `json:"doneLine"`
+}
+
+// How much of an epic's work is in done columns
+type EpicProgress struct {
+	TotalCards           int `json:"totalCards"`
+	CompletedCards       int `json:"completedCards"`
+	TotalStoryPoints     int `json:"totalStoryPoints"`
+	CompletedStoryPoints int `json:"completedStoryPoints"`
+}
+
+type EstimationAccuracy struct {
+	ProjectID string    `json:"projectId"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	// Estimated cards completed in the range
+	CardCount int `json:"cardCount"`
+	// Sum of the original estimates
+	Points               int     `json:"points"`
+	AverageCycleTimeDays float64 `json:"averageCycleTimeDays"`
+	// Cycle time per original point; null without estimated cards
+	DaysPerPoint  *float64 `json:"daysPerPoint,omitempty"`
+	MeanDeviation *float64 `json:"meanDeviation,omitempty"`
+	// Cards that ended with a different estimate than they started with
+	ReestimatedCount int `json:"reestimatedCount"`
+	// Completed cards that were never estimated, left out of the report
+	UnestimatedCount int `json:"unestimatedCount"`
+	// In completion order
+	Cards []*CardEstimationAccuracy `json:"cards"`
+	// Most cards first, unassigned last
+	Assignees []*AssigneeEstimationAccuracy `json:"assignees"`
+	// Every week overlapping the range, oldest first
+	Periods []*EstimationPeriod `json:"periods"`
+	// By story points, smallest first
+	Calibration []*StoryPointCalibration `json:"calibration"`
+}
+
+// The cards completed in the week starting on Monday at start (UTC)
+type EstimationPeriod struct {
+	Start                time.Time `json:"start"`
+	CardCount            int       `json:"cardCount"`
+	Points               int       `json:"points"`
+	AverageCycleTimeDays float64   `json:"averageCycleTimeDays"`
+	DaysPerPoint         *float64  `json:"daysPerPoint,omitempty"`
+	MeanDeviation        *float64  `json:"meanDeviation,omitempty"`
+	ReestimatedCount     int       `json:"reestimatedCount"`
+}
+
+// Cards to print: either cardIds, printed in that order, or boardId, printing the board's cards column by column
+type ExportCardsPDFInput struct {
+	CardIds []string        `json:"cardIds,omitempty"`
+	BoardID *string         `json:"boardId,omitempty"`
+	Layout  CardPrintLayout `json:"layout"`
+}
+
+type ExternalUserInput struct {
+	// Identifies the user in the source; defaults to the email
+	ExternalID  *string `json:"externalId,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	Email       *string `json:"email,omitempty"`
+}
+
+// A scheduled period during which cards may only be moved into its columns with an override
+type FreezeWindow struct {
+	ID       string    `json:"id"`
+	BoardID  string    `json:"boardId"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"startsAt"`
+	// Exclusive; the window is over from this moment
+	EndsAt time.Time `json:"endsAt"`
+	// The columns cards may not be moved into while the window is active
+	ColumnIds []string `json:"columnIds"`
+	// Whether the window is active now
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type FreezeWindowInput struct {
+	Name      string    `json:"name"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	ColumnIds []string  `json:"columnIds"`
+}
+
+type GeneratedMetricsEmbedToken struct {
+	// The secret to pass to embeddedMetrics. It is only returned here.
+	Token      string             `json:"token"`
+	EmbedToken *MetricsEmbedToken `json:"embedToken"`
+}
+
+// How the instance presents itself, e.g. on the login page and in emails
+type InstanceInfo struct {
+	// The configured product name, or Kaimu
+	ProductName  string  `json:"productName"`
+	LogoURL      *string `json:"logoUrl,omitempty"`
+	SupportEmail *string `json:"supportEmail,omitempty"`
+	TermsURL     *string `json:"termsUrl,omitempty"`
+}
+
 type Invitation struct {
 	ID           string        `json:"id"`
 	Email        string        `json:"email"`
@@ -208,14 +1066,81 @@ type Invitation struct {
 	Role         *Role         `json:"role"`
 	Organization *Organization `json:"organization"`
 	InvitedBy    *User         `json:"invitedBy"`
-	ExpiresAt    time.Time     `json:"expiresAt"`
-	CreatedAt    time.Time     `json:"createdAt"`
+	// The project the invitee joins on acceptance, if any
+	Project *Project `json:"project,omitempty"`
+	// The invitee's role in project; null inherits the organization role
+	ProjectRole *Role `json:"projectRole,omitempty"`
+	// The invitee joins as a guest, reaching only project
+	IsGuest   bool      `json:"isGuest"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	// When the invitee was reminded that the invitation is about to expire
+	RemindedAt *time.Time `json:"remindedAt,omitempty"`
 }
 
 type InviteMemberInput struct {
 	OrganizationID string `json:"organizationId"`
 	Email          string `json:"email"`
-	RoleID         string `json:"roleId"`
+	// Organization role; defaults to Viewer
+	RoleID *string `json:"roleId,omitempty"`
+	// Also add the invitee to this project of the organization on acceptance
+	ProjectID *string `json:"projectId,omitempty"`
+	// Project role for projectId; omitted, the invitee inherits the organization role
+	ProjectRoleID *string `json:"projectRoleId,omitempty"`
+	// Invite as a guest, who only reaches projectId; needs projectId and counts toward the guest limit
+	Guest *bool `json:"guest,omitempty"`
+}
+
+type JiraExport struct {
+	// The key issues are numbered under, e.g. KAI-1 for the card created first
+	ProjectKey string `json:"projectKey"`
+	// Document for Jira's JSON importer: the users issues reference and the project with its issues
+	JSON string `json:"json"`
+	// Document for Jira's CSV importer, one row per issue. Labels and sprints repeat their
+	// column for multiple values; dates are UTC in the format yyyy-MM-dd HH:mm.
+	CSV string `json:"csv"`
+	// Statuses to set up in the Jira workflow before importing
+	Statuses []*JiraStatus `json:"statuses"`
+	// The sprints of the project's boards; issues name theirs in the Sprint field
+	Sprints    []*JiraSprint `json:"sprints"`
+	IssueCount int           `json:"issueCount"`
+}
+
+type JiraSprint struct {
+	Name string `json:"name"`
+	// FUTURE, ACTIVE or CLOSED
+	State     string     `json:"state"`
+	Goal      *string    `json:"goal,omitempty"`
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+}
+
+// A Jira status issues are exported with: the name of the column their card is in
+type JiraStatus struct {
+	Name string `json:"name"`
+	// Done columns are DONE, backlog columns and the first other column TO_DO, the rest IN_PROGRESS
+	Category JiraStatusCategory `json:"category"`
+}
+
+type LabelSuggestions struct {
+	// Up to 3 project tags the card doesn't have, most confident first
+	Tags []*TagSuggestion `json:"tags"`
+	// Null when the card has a priority or none is suggested
+	Priority *PrioritySuggestion `json:"priority,omitempty"`
+	// Null when nothing is suggested
+	Source *LabelSuggestionSource `json:"source,omitempty"`
+}
+
+type LegalHold struct {
+	ID       string    `json:"id"`
+	Reason   string    `json:"reason"`
+	PlacedBy *User     `json:"placedBy,omitempty"`
+	PlacedAt time.Time `json:"placedAt"`
+	// Why the hold was lifted; null while it is active
+	LiftReason *string    `json:"liftReason,omitempty"`
+	LiftedBy   *User      `json:"liftedBy,omitempty"`
+	LiftedAt   *time.Time `json:"liftedAt,omitempty"`
+	Active     bool       `json:"active"`
 }
 
 type LoginInput struct {
@@ -223,10 +1148,87 @@ type LoginInput struct {
 	Password string `json:"password"`
 }
 
+type MemberPermissionAudit struct {
+	User *User `json:"user"`
+	// The member's effective organization role
+	OrgRole *Role `json:"orgRole"`
+	IsGuest bool  `json:"isGuest"`
+	// The projects the member was added to
+	Projects []*ProjectPermissionAudit `json:"projects"`
+	// The only boards a guest can reach; empty for members, who reach every board of the organization
+	RestrictedBoards []*Board `json:"restrictedBoards"`
+	// Usable metrics embed tokens the member created
+	EmbedTokens []*MetricsEmbedToken `json:"embedTokens"`
+	// Usable API tokens acting as the member: their personal access tokens, or the organization's service tokens when the member is their service account
+	APITokens []*APIToken `json:"apiTokens"`
+}
+
+// What a bulk role change did to one user
+type MemberRoleChangeResult struct {
+	UserID string                 `json:"userId"`
+	Status MemberRoleChangeStatus `json:"status"`
+	// The membership as it is after the change; null for NOT_A_MEMBER
+	Member *OrganizationMember `json:"member,omitempty"`
+	// The role the member had before the change
+	PreviousRoleID *string `json:"previousRoleId,omitempty"`
+}
+
+type MergeCardsResult struct {
+	// The primary card, which is kept
+	Card *Card `json:"card"`
+	// The duplicates, now archived and pointing at the primary card
+	Duplicates []*Card `json:"duplicates"`
+	// How many tags the primary card gained from the duplicates
+	TagsMoved int `json:"tagsMoved"`
+	// How many dependencies of the duplicates now link the primary card
+	LinksMoved int `json:"linksMoved"`
+}
+
+type MergedInvitation struct {
+	InvitationID string                `json:"invitationId"`
+	Email        string                `json:"email"`
+	Action       MergeInvitationAction `json:"action"`
+}
+
+type MergedMember struct {
+	User   *User             `json:"user"`
+	Action MergeMemberAction `json:"action"`
+	// The member's role in the target organization
+	RoleID *string `json:"roleId,omitempty"`
+}
+
+type MergedProject struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	OldKey    string `json:"oldKey"`
+	// Differs from oldKey when the key was taken in the target organization
+	NewKey string `json:"newKey"`
+}
+
+type MergedRole struct {
+	RoleID  string `json:"roleId"`
+	OldName string `json:"oldName"`
+	// Differs from oldName when the name was taken in the target organization
+	NewName string `json:"newName"`
+}
+
+type MetricsEmbedToken struct {
+	ID        string              `json:"id"`
+	BoardID   string              `json:"boardId"`
+	Charts    []MetricsEmbedChart `json:"charts"`
+	ExpiresAt time.Time           `json:"expiresAt"`
+	CreatedAt time.Time           `json:"createdAt"`
+	RevokedAt *time.Time          `json:"revokedAt,omitempty"`
+}
+
 type MoveCardInput struct {
 	CardID         string  `json:"cardId"`
 	TargetColumnID string  `json:"targetColumnId"`
 	AfterCardID    *string `json:"afterCardId,omitempty"`
+	// Why the move has to happen during a freeze window; required to move a card into a frozen column
+	FreezeOverrideReason *string `json:"freezeOverrideReason,omitempty"`
+	// Applies when the target column is a done column and open cards block the card; defaults to IGNORE
+	OpenBlockers *OpenBlockerPolicy `json:"openBlockers,omitempty"`
 }
 
 type MoveCardToSprintInput struct {
@@ -234,11 +1236,111 @@ type MoveCardToSprintInput struct {
 	SprintID string `json:"sprintId"`
 }
 
+type MySprintWork struct {
+	// The board's active sprint; null when there is none
+	Sprint *Sprint `json:"sprint,omitempty"`
+	// In board column order, leaving out columns without the user's cards
+	Columns []*SprintWorkColumn `json:"columns"`
+	Totals  *SprintWorkTotals   `json:"totals"`
+}
+
+// Something that happened to or for the current user. Which of card, comment, sprint and invitation are set depends on the kind
+type Notification struct {
+	ID   string           `json:"id"`
+	Kind NotificationKind `json:"kind"`
+	// Who caused it; null for system actions and deleted accounts
+	Actor      *User        `json:"actor,omitempty"`
+	Card       *Card        `json:"card,omitempty"`
+	Comment    *CardComment `json:"comment,omitempty"`
+	Sprint     *Sprint      `json:"sprint,omitempty"`
+	Invitation *Invitation  `json:"invitation,omitempty"`
+	ReadAt     *time.Time   `json:"readAt,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+// Where one card event is delivered. With no channels the event stays in-app only.
+type NotificationChannelSetting struct {
+	Event           NotificationRuleEvent `json:"event"`
+	Channels        []NotificationChannel `json:"channels"`
+	SlackWebhookURL *string               `json:"slackWebhookUrl,omitempty"`
+	// Slack channel posted to instead of the webhook's own
+	SlackChannel *string `json:"slackChannel,omitempty"`
+	// Whether the setting comes from the organization default or the built-in default (email only) rather than being set here
+	Inherited bool `json:"inherited"`
+}
+
+type NotificationChannelSettingInput struct {
+	Event    NotificationRuleEvent `json:"event"`
+	Channels []NotificationChannel `json:"channels"`
+	// Required for SLACK
+	SlackWebhookURL *string `json:"slackWebhookUrl,omitempty"`
+	SlackChannel    *string `json:"slackChannel,omitempty"`
+}
+
+// How you get one category of notifications from an organization
+type NotificationPreference struct {
+	Category NotificationCategory `json:"category"`
+	// Shown in the notification center; on by default
+	InApp bool `json:"inApp"`
+	// Sent by email; off by default
+	Email bool `json:"email"`
+}
+
+// Notifies its owner when a card event in a project matches every condition that is set
+type NotificationRule struct {
+	ID        string                `json:"id"`
+	ProjectID string                `json:"projectId"`
+	Name      string                `json:"name"`
+	Event     NotificationRuleEvent `json:"event"`
+	// Only cards with this tag match
+	TagID *string `json:"tagId,omitempty"`
+	// Only cards in this column match; for CARD_MOVED, the column the card was moved to
+	ColumnID *string `json:"columnId,omitempty"`
+	// Only cards with this priority match
+	Priority  *CardPriority `json:"priority,omitempty"`
+	Enabled   bool          `json:"enabled"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+type NotificationRuleInput struct {
+	ProjectID string                `json:"projectId"`
+	Name      string                `json:"name"`
+	Event     NotificationRuleEvent `json:"event"`
+	TagID     *string               `json:"tagId,omitempty"`
+	ColumnID  *string               `json:"columnId,omitempty"`
+	Priority  *CardPriority         `json:"priority,omitempty"`
+	Enabled   *bool                 `json:"enabled,omitempty"`
+}
+
 type OIDCProvider struct {
 	Slug string `json:"slug"`
 	Name string `json:"name"`
 }
 
+// A mutation queued while offline. Set exactly one of createCard, updateCard, moveCard and
+// deleteCardId. Card IDs may be the clientMutationId of an earlier createCard, applied in this
+// batch or a previous one.
+type OfflineMutationInput struct {
+	// Client-generated ID; replaying a mutation with the same ID does not apply it twice
+	ClientMutationID string `json:"clientMutationId"`
+	// The card's updatedAt when the client last synced it; omit to skip conflict checks
+	BaseUpdatedAt *time.Time        `json:"baseUpdatedAt,omitempty"`
+	OnConflict    *ConflictStrategy `json:"onConflict,omitempty"`
+	CreateCard    *CreateCardInput  `json:"createCard,omitempty"`
+	UpdateCard    *UpdateCardInput  `json:"updateCard,omitempty"`
+	MoveCard      *MoveCardInput    `json:"moveCard,omitempty"`
+	DeleteCardID  *string           `json:"deleteCardId,omitempty"`
+}
+
+type OfflineMutationResult struct {
+	ClientMutationID string                `json:"clientMutationId"`
+	Status           OfflineMutationStatus `json:"status"`
+	// The card after the mutation, or the server's card on a conflict; null once deleted
+	Card    *Card   `json:"card,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
 type Organization struct {
 	ID          string                `json:"id"`
 	Name        string                `json:"name"`
@@ -249,25 +1351,101 @@ type Organization struct {
 	Projects    []*Project            `json:"projects"`
 	CreatedAt   time.Time             `json:"createdAt"`
 	UpdatedAt   time.Time             `json:"updatedAt"`
+	// Largest file members may attach, overriding the server's limit; null uses the server's
+	AttachmentMaxBytes *int `json:"attachmentMaxBytes,omitempty"`
+	// Total size of the organization's attachments, overriding the server's quota; null uses the server's
+	AttachmentQuotaBytes *int `json:"attachmentQuotaBytes,omitempty"`
+	// Whether members may draft cards, summarize sprints and get label suggestions with the configured language model
+	AiDraftingEnabled bool `json:"aiDraftingEnabled"`
+	// Whether the configured moderation scanners check card text and comments
+	ContentModerationEnabled bool `json:"contentModerationEnabled"`
+	// Days invitations stay valid; null uses the default of 7
+	InvitationTTLDays *int `json:"invitationTtlDays,omitempty"`
+	// Days before expiry invitees are reminded by email; null sends no reminders
+	InvitationReminderDays *int `json:"invitationReminderDays,omitempty"`
+	// Language for members who haven't chosen one
+	DefaultLocale string `json:"defaultLocale"`
+	// Whether the organization's searches are recorded without who ran them
+	SearchAnalyticsAnonymized bool `json:"searchAnalyticsAnonymized"`
 }
 
-type OrganizationMember struct {
-	ID         string    `json:"id"`
-	User       *User     `json:"user"`
-	Role       *Role     `json:"role"`
-	LegacyRole string    `json:"legacyRole"`
-	CreatedAt  time.Time `json:"createdAt"`
+type OrganizationBackup struct {
+	// Where the backup is stored; pass it to `backup restore --key`
+	Key       string    `json:"key"`
+	SizeBytes int       `json:"sizeBytes"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Rows the backup holds; only set on a backup that was just taken
+	RowCount *int `json:"rowCount,omitempty"`
 }
 
-type PageInfo struct {
-	HasNextPage     bool    `json:"hasNextPage"`
-	HasPreviousPage bool    `json:"hasPreviousPage"`
-	StartCursor     *string `json:"startCursor,omitempty"`
-	EndCursor       *string `json:"endCursor,omitempty"`
-	TotalCount      int     `json:"totalCount"`
+type OrganizationBranding struct {
+	OrganizationID string  `json:"organizationId"`
+	FromName       *string `json:"fromName,omitempty"`
+	// Mail only comes from this address while the domain is verified
+	FromEmail *string `json:"fromEmail,omitempty"`
+	LogoURL   *string `json:"logoUrl,omitempty"`
+	// #RRGGBB
+	AccentColor *string `json:"accentColor,omitempty"`
+	// Domain of fromEmail
+	Domain *string `json:"domain,omitempty"`
+	// Set while the ownership, SPF and DKIM records all check out
+	DomainVerifiedAt *time.Time `json:"domainVerifiedAt,omitempty"`
+	// When verifyBrandingDomain last looked up the records
+	DomainCheckedAt *time.Time `json:"domainCheckedAt,omitempty"`
+	// The records to publish for the domain; empty without fromEmail
+	DNSRecords []*BrandingDNSRecord `json:"dnsRecords"`
 }
 
-type Permission struct {
+type OrganizationDirectoryFilter struct {
+	// Matches part of the username, display name or email, case-insensitively
+	Search *string `json:"search,omitempty"`
+	RoleID *string `json:"roleId,omitempty"`
+}
+
+type OrganizationMember struct {
+	ID         string `json:"id"`
+	User       *User  `json:"user"`
+	Role       *Role  `json:"role"`
+	LegacyRole string `json:"legacyRole"`
+	// Guests only reach the projects they were added to
+	IsGuest   bool      `json:"isGuest"`
+	CreatedAt time.Time `json:"createdAt"`
+	// When the member last signed in or refreshed a session; loaded by organizationDirectory, null elsewhere
+	LastActiveAt *time.Time `json:"lastActiveAt,omitempty"`
+}
+
+type OrganizationMemberConnection struct {
+	Edges    []*OrganizationMemberEdge `json:"edges"`
+	PageInfo *PageInfo                 `json:"pageInfo"`
+}
+
+type OrganizationMemberEdge struct {
+	Node   *OrganizationMember `json:"node"`
+	Cursor string              `json:"cursor"`
+}
+
+// What merging one organization into another does, or did
+type OrganizationMergeReport struct {
+	SourceID   string        `json:"sourceId"`
+	SourceName string        `json:"sourceName"`
+	Target     *Organization `json:"target"`
+	// Set when nothing was changed
+	DryRun      bool                `json:"dryRun"`
+	Members     []*MergedMember     `json:"members"`
+	Projects    []*MergedProject    `json:"projects"`
+	Roles       []*MergedRole       `json:"roles"`
+	Invitations []*MergedInvitation `json:"invitations"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+	TotalCount      int     `json:"totalCount"`
+}
+
+type Permission struct {
 	ID           string  `json:"id"`
 	Code         string  `json:"code"`
 	Name         string  `json:"name"`
@@ -275,6 +1453,64 @@ type Permission struct {
 	ResourceType string  `json:"resourceType"`
 }
 
+type PermissionAuditReport struct {
+	OrganizationID string    `json:"organizationId"`
+	GeneratedAt    time.Time `json:"generatedAt"`
+	// The organization's members and guests
+	Members []*MemberPermissionAudit `json:"members"`
+	// The report as CSV, one row per member
+	CSV string `json:"csv"`
+}
+
+type Person struct {
+	Member *OrganizationMember `json:"member"`
+	// By project name
+	Projects []*PersonProject `json:"projects"`
+	// Active sprints, ending soonest first
+	SprintCommitments []*PersonSprintCommitment `json:"sprintCommitments"`
+	// Totals over all projects
+	OpenCardCount    int `json:"openCardCount"`
+	OpenStoryPoints  int `json:"openStoryPoints"`
+	OverdueCardCount int `json:"overdueCardCount"`
+	// Totals over the active sprint commitments
+	SprintStoryPoints     int `json:"sprintStoryPoints"`
+	SprintDoneStoryPoints int `json:"sprintDoneStoryPoints"`
+}
+
+// A person's project membership, or a project they have open cards in
+type PersonProject struct {
+	ProjectID   string `json:"projectId"`
+	ProjectName string `json:"projectName"`
+	// False for projects the person only has cards assigned in
+	IsMember bool `json:"isMember"`
+	// The person's project role; null when they inherit their organization role
+	RoleID *string `json:"roleId,omitempty"`
+	// Assigned cards that are neither archived nor in a done column
+	OpenCardCount    int `json:"openCardCount"`
+	OpenStoryPoints  int `json:"openStoryPoints"`
+	OverdueCardCount int `json:"overdueCardCount"`
+}
+
+// The cards assigned to a person in an active sprint
+type PersonSprintCommitment struct {
+	SprintID    string     `json:"sprintId"`
+	SprintName  string     `json:"sprintName"`
+	BoardID     string     `json:"boardId"`
+	ProjectID   string     `json:"projectId"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	CardCount   int        `json:"cardCount"`
+	StoryPoints int        `json:"storyPoints"`
+	// Cards in done columns
+	DoneCardCount   int `json:"doneCardCount"`
+	DoneStoryPoints int `json:"doneStoryPoints"`
+}
+
+// A priority suggested for a card
+type PrioritySuggestion struct {
+	Priority   CardPriority `json:"priority"`
+	Confidence float64      `json:"confidence"`
+}
+
 type Project struct {
 	ID           string        `json:"id"`
 	Organization *Organization `json:"organization"`
@@ -286,6 +1522,84 @@ type Project struct {
 	Tags         []*Tag        `json:"tags"`
 	CreatedAt    time.Time     `json:"createdAt"`
 	UpdatedAt    time.Time     `json:"updatedAt"`
+	// The project's computed health
+	Health *ProjectHealth `json:"health"`
+}
+
+// The days a project's team works and its pace, used to suggest due dates
+type ProjectCalendar struct {
+	ProjectID   string    `json:"projectId"`
+	WorkingDays []Weekday `json:"workingDays"`
+	// Story points the team completes per working day
+	PointsPerDay float64 `json:"pointsPerDay"`
+	// Holidays from today on
+	Holidays []*ProjectHoliday `json:"holidays"`
+}
+
+type ProjectCardImportResult struct {
+	// Whether every card can be imported
+	Valid   bool   `json:"valid"`
+	BoardID string `json:"boardId"`
+	// The column cards go to when their status matches no column
+	DefaultColumnID string                `json:"defaultColumnId"`
+	Rows            []*CardImportRow      `json:"rows"`
+	Statuses        []*CardImportStatus   `json:"statuses"`
+	Assignees       []*CardImportAssignee `json:"assignees"`
+	// Tags the import creates in the project
+	NewTags []string `json:"newTags"`
+	// The created cards in row order; empty for dry runs and imports with row errors
+	Cards []*Card `json:"cards"`
+}
+
+type ProjectExport struct {
+	ID          string              `json:"id"`
+	Status      ProjectExportStatus `json:"status"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	StartedAt   *time.Time          `json:"startedAt,omitempty"`
+	CompletedAt *time.Time          `json:"completedAt,omitempty"`
+	// Cards in the archive, once completed
+	CardCount *int `json:"cardCount,omitempty"`
+	SizeBytes *int `json:"sizeBytes,omitempty"`
+	// Why the last attempt failed; kept while a retry is pending
+	Error *string `json:"error,omitempty"`
+	// Presigned URL the archive can be downloaded from until downloadUrlExpiresAt; only set once completed
+	DownloadURL          *string    `json:"downloadUrl,omitempty"`
+	DownloadURLExpiresAt *time.Time `json:"downloadUrlExpiresAt,omitempty"`
+}
+
+type ProjectHealth struct {
+	// The worst status of the health signals
+	Status ProjectHealthStatus `json:"status"`
+	// 100 when every signal is on track, less 15 per signal at risk and 35 per signal off track
+	Score int `json:"score"`
+}
+
+type ProjectHealthBreakdown struct {
+	ProjectID  string                 `json:"projectId"`
+	Status     ProjectHealthStatus    `json:"status"`
+	Score      int                    `json:"score"`
+	Signals    []*ProjectHealthSignal `json:"signals"`
+	ComputedAt time.Time              `json:"computedAt"`
+}
+
+type ProjectHealthSignal struct {
+	Kind   ProjectHealthSignalKind `json:"kind"`
+	Status ProjectHealthStatus     `json:"status"`
+	// The measure the status is judged on; null when there is nothing to measure yet
+	Value *float64 `json:"value,omitempty"`
+	// The number measured, e.g. overdue cards, or completed points in the recent sprints
+	Count int `json:"count"`
+	// What the count is measured against, e.g. open cards, or completed points in the earlier sprints
+	Total int `json:"total"`
+	// Set on the velocity signal once there are enough closed sprints
+	Trend *VelocityTrend `json:"trend,omitempty"`
+}
+
+type ProjectHoliday struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Date      string `json:"date"`
+	Name      string `json:"name"`
 }
 
 type ProjectMember struct {
@@ -296,6 +1610,14 @@ type ProjectMember struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+type ProjectPermissionAudit struct {
+	Project *Project `json:"project"`
+	// The member's project-specific role; null when their organization role applies
+	Role *Role `json:"role,omitempty"`
+	// The role that applies to the member in the project
+	EffectiveRole *Role `json:"effectiveRole"`
+}
+
 type RefreshTokenPayload struct {
 	Success   bool `json:"success"`
 	ExpiresIn int  `json:"expiresIn"`
@@ -312,6 +1634,14 @@ type ReorderColumnsInput struct {
 	ColumnIds []string `json:"columnIds"`
 }
 
+type RequestAttachmentUploadInput struct {
+	CardID   string `json:"cardId"`
+	Filename string `json:"filename"`
+	// Media type of the file; anything that doesn't parse is stored as application/octet-stream
+	ContentType string `json:"contentType"`
+	SizeBytes   int    `json:"sizeBytes"`
+}
+
 type Role struct {
 	ID          string        `json:"id"`
 	Name        string        `json:"name"`
@@ -323,6 +1653,78 @@ type Role struct {
 	UpdatedAt   time.Time     `json:"updatedAt"`
 }
 
+// Cards (optionally of one priority) must leave a column within a time limit
+type SLAPolicy struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	// The column cards must leave in time
+	ColumnID string `json:"columnId"`
+	// Only cards with this priority are covered; null covers every card
+	Priority           *CardPriority `json:"priority,omitempty"`
+	MaxDurationMinutes int           `json:"maxDurationMinutes"`
+	// Breaching cards are raised to this priority
+	EscalateToPriority *CardPriority `json:"escalateToPriority,omitempty"`
+	// Breaching cards are given this tag
+	BreachTagID *string   `json:"breachTagId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// How a sprint's cards fared against one SLA policy
+type SLAPolicyCompliance struct {
+	Policy *SLAPolicy `json:"policy"`
+	// Sprint cards the policy covers
+	TrackedCards  int `json:"trackedCards"`
+	BreachedCards int `json:"breachedCards"`
+	// Breaches whose card is still in the policy's column
+	OpenBreaches int `json:"openBreaches"`
+	// Share of tracked cards that never breached the policy, from 0 to 1
+	ComplianceRate float64 `json:"complianceRate"`
+}
+
+type SLAPolicyInput struct {
+	Name               string        `json:"name"`
+	ColumnID           string        `json:"columnId"`
+	Priority           *CardPriority `json:"priority,omitempty"`
+	MaxDurationMinutes int           `json:"maxDurationMinutes"`
+	EscalateToPriority *CardPriority `json:"escalateToPriority,omitempty"`
+	BreachTagID        *string       `json:"breachTagId,omitempty"`
+}
+
+// SLA compliance of the cards in a sprint
+type SLAReport struct {
+	SprintID string `json:"sprintId"`
+	// Sprint cards covered by at least one policy
+	TrackedCards   int                    `json:"trackedCards"`
+	BreachedCards  int                    `json:"breachedCards"`
+	ComplianceRate float64                `json:"complianceRate"`
+	Policies       []*SLAPolicyCompliance `json:"policies"`
+}
+
+type SearchAnalytics struct {
+	OrganizationID     string    `json:"organizationId"`
+	Since              time.Time `json:"since"`
+	Searches           int       `json:"searches"`
+	ZeroResultSearches int       `json:"zeroResultSearches"`
+	// Distinct members who searched; anonymized searches aren't counted
+	Searchers          int                        `json:"searchers"`
+	PopularQueries     []*SearchQueryStats        `json:"popularQueries"`
+	ZeroResultQueries  []*SearchQueryStats        `json:"zeroResultQueries"`
+	SynonymSuggestions []*SearchSynonymSuggestion `json:"synonymSuggestions"`
+}
+
+// How often a query was searched in an organization
+type SearchQueryStats struct {
+	Query    string `json:"query"`
+	Searches int    `json:"searches"`
+	// Distinct members who searched it; anonymized searches aren't counted
+	Searchers int `json:"searchers"`
+	// Mean number of results found in the organization
+	AverageResults float64   `json:"averageResults"`
+	LastSearchedAt time.Time `json:"lastSearchedAt"`
+}
+
 type SearchResult struct {
 	Type             SearchEntityType `json:"type"`
 	ID               string           `json:"id"`
@@ -343,6 +1745,8 @@ type SearchResults struct {
 	Results    []*SearchResult `json:"results"`
 	TotalCount int             `json:"totalCount"`
 	Query      string          `json:"query"`
+	// True when searches were sent too fast and the results come from recent searches, so they may be incomplete
+	Throttled bool `json:"throttled"`
 }
 
 type SearchScope struct {
@@ -350,6 +1754,42 @@ type SearchScope struct {
 	ProjectID      *string `json:"projectId,omitempty"`
 }
 
+// Words or phrases an organization's searches treat as one another, e.g. bug and defect
+type SearchSynonymSet struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organizationId"`
+	// Lowercased, at least two
+	Words     []string  `json:"words"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// A proposed synonym for a query that found nothing
+type SearchSynonymSuggestion struct {
+	// The query that found nothing
+	Term string `json:"term"`
+	// A similarly spelled query that found results
+	Synonym string `json:"synonym"`
+	// How often the term was searched
+	Searches int `json:"searches"`
+}
+
+type SplitCardOptions struct {
+	// Give every new card the card's tags
+	CopyTags *bool `json:"copyTags,omitempty"`
+	// Give every new card the card's assignee
+	CopyAssignee *bool `json:"copyAssignee,omitempty"`
+	// Divide the card's story points among the new cards and clear them on the card
+	SplitStoryPoints *bool `json:"splitStoryPoints,omitempty"`
+}
+
+type SplitCardResult struct {
+	// The split card, which is kept
+	Card *Card `json:"card"`
+	// The new cards, in title order, each linked to the card with a SPLIT_FROM dependency
+	Cards []*Card `json:"cards"`
+}
+
 type Sprint struct {
 	ID        string       `json:"id"`
 	Board     *Board       `json:"board"`
@@ -363,6 +1803,19 @@ type Sprint struct {
 	CreatedAt time.Time    `json:"createdAt"`
 	UpdatedAt time.Time    `json:"updatedAt"`
 	CreatedBy *User        `json:"createdBy,omitempty"`
+	// The last generated summary; null until one is generated
+	Summary *SprintSummary `json:"summary,omitempty"`
+}
+
+type SprintCarryover struct {
+	Sprint          *Sprint `json:"sprint"`
+	CommittedCards  int     `json:"committedCards"`
+	CommittedPoints int     `json:"committedPoints"`
+	// Cards that were in an earlier sprint too
+	CarriedInCards int `json:"carriedInCards"`
+	// Cards that were in a later sprint too, so were not delivered in this one
+	CarriedOutCards  int `json:"carriedOutCards"`
+	CarriedOutPoints int `json:"carriedOutPoints"`
 }
 
 type SprintConnection struct {
@@ -382,6 +1835,16 @@ type SprintStats struct {
 	CompletedStoryPoints int `json:"completedStoryPoints"`
 	DaysRemaining        int `json:"daysRemaining"`
 	DaysElapsed          int `json:"daysElapsed"`
+	// Checklist items on the sprint's cards
+	TotalChecklistItems     int `json:"totalChecklistItems"`
+	CompletedChecklistItems int `json:"completedChecklistItems"`
+}
+
+// A language-model summary of a sprint's completed, in-progress and blocked cards
+type SprintSummary struct {
+	// Plain text, ready to paste into a stakeholder update
+	Text        string    `json:"text"`
+	GeneratedAt time.Time `json:"generatedAt"`
 }
 
 type SprintVelocity struct {
@@ -391,6 +1854,48 @@ type SprintVelocity struct {
 	CompletedPoints int    `json:"completedPoints"`
 }
 
+// A board column with the user's sprint cards in it
+type SprintWorkColumn struct {
+	Column *BoardColumn `json:"column"`
+	// By position in the column
+	Cards  []*Card           `json:"cards"`
+	Totals *SprintWorkTotals `json:"totals"`
+}
+
+// Aggregates over a set of sprint cards
+type SprintWorkTotals struct {
+	CardCount int `json:"cardCount"`
+	// Sum over the estimated cards
+	StoryPoints int `json:"storyPoints"`
+	// Cards in done columns
+	DoneCardCount   int `json:"doneCardCount"`
+	DoneStoryPoints int `json:"doneStoryPoints"`
+}
+
+// How long cards of one original estimate took
+type StoryPointCalibration struct {
+	StoryPoints          int     `json:"storyPoints"`
+	CardCount            int     `json:"cardCount"`
+	AverageCycleTimeDays float64 `json:"averageCycleTimeDays"`
+}
+
+type SuggestDueDateInput struct {
+	BoardID string `json:"boardId"`
+	// The card being edited, left out of the assignee's workload
+	CardID      *string `json:"cardId,omitempty"`
+	AssigneeID  *string `json:"assigneeId,omitempty"`
+	StoryPoints *int    `json:"storyPoints,omitempty"`
+	// Defaults to today
+	StartDate *string `json:"startDate,omitempty"`
+}
+
+// An entity the client should drop: it was deleted or left the board
+type SyncTombstone struct {
+	EntityType SyncEntityType `json:"entityType"`
+	ID         string         `json:"id"`
+	DeletedAt  time.Time      `json:"deletedAt"`
+}
+
 type Tag struct {
 	ID          string    `json:"id"`
 	Project     *Project  `json:"project"`
@@ -400,6 +1905,39 @@ type Tag struct {
 	CreatedAt   time.Time `json:"createdAt"`
 }
 
+// A project tag suggested for a card
+type TagSuggestion struct {
+	Tag *Tag `json:"tag"`
+	// The share of the similar cards' votes for the tag, from 0 to 1; 1 for language model suggestions
+	Confidence float64 `json:"confidence"`
+}
+
+// A bulk operation recorded with the steps needed to revert it
+type UndoableOperation struct {
+	ID      string            `json:"id"`
+	Kind    UndoOperationKind `json:"kind"`
+	BoardID string            `json:"boardId"`
+	// The sprint or other entity the operation acted on
+	EntityID  string    `json:"entityId"`
+	Actor     *User     `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// The operation can no longer be undone after this time
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UndoneAt  *time.Time `json:"undoneAt,omitempty"`
+}
+
+type UpdateAuditAnomalySettingsInput struct {
+	OrganizationID string `json:"organizationId"`
+	Enabled        bool   `json:"enabled"`
+	// At least 2
+	MassDeletionThreshold int `json:"massDeletionThreshold"`
+	// Between 1 and 1440
+	MassDeletionWindowMinutes int    `json:"massDeletionWindowMinutes"`
+	BusinessHoursStart        int    `json:"businessHoursStart"`
+	BusinessHoursEnd          int    `json:"businessHoursEnd"`
+	Timezone                  string `json:"timezone"`
+}
+
 type UpdateBoardInput struct {
 	ID          string  `json:"id"`
 	Name        *string `json:"name,omitempty"`
@@ -420,13 +1958,56 @@ type UpdateCardInput struct {
 	ClearStoryPoints *bool         `json:"clearStoryPoints,omitempty"`
 }
 
+type UpdateChecklistItemInput struct {
+	ID            string  `json:"id"`
+	Title         *string `json:"title,omitempty"`
+	Done          *bool   `json:"done,omitempty"`
+	AssigneeID    *string `json:"assigneeId,omitempty"`
+	ClearAssignee *bool   `json:"clearAssignee,omitempty"`
+}
+
+type UpdateColumnAlertSettingsInput struct {
+	BoardID string `json:"boardId"`
+	Enabled bool   `json:"enabled"`
+	// Between 1 and 10080, or null to not alert
+	WipExceededMinutes *int `json:"wipExceededMinutes,omitempty"`
+	// Between 1 and 8760, or null to not alert
+	AverageAgeHours *int `json:"averageAgeHours,omitempty"`
+	// An https://hooks.slack.com/ URL, or null to only email
+	SlackWebhookURL *string `json:"slackWebhookUrl,omitempty"`
+	SlackChannel    *string `json:"slackChannel,omitempty"`
+}
+
 type UpdateColumnInput struct {
 	ID            string  `json:"id"`
 	Name          *string `json:"name,omitempty"`
 	Color         *string `json:"color,omitempty"`
 	WipLimit      *int    `json:"wipLimit,omitempty"`
 	ClearWipLimit *bool   `json:"clearWipLimit,omitempty"`
-	IsDone        *bool   `json:"isDone,omitempty"`
+	// At least 1
+	PointLimit      *int  `json:"pointLimit,omitempty"`
+	ClearPointLimit *bool `json:"clearPointLimit,omitempty"`
+	IsDone          *bool `json:"isDone,omitempty"`
+	// Replaces the defaults applied to cards created in the column; {} removes them
+	CardDefaults *ColumnCardDefaultsInput `json:"cardDefaults,omitempty"`
+}
+
+// Fields left out are not changed
+type UpdateEpicInput struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// Empty or missing fields fall back to the built-in branding
+type UpdateInstanceSettingsInput struct {
+	// At most 100 characters
+	ProductName *string `json:"productName,omitempty"`
+	// An https URL of at most 2048 characters
+	LogoURL      *string `json:"logoUrl,omitempty"`
+	SupportEmail *string `json:"supportEmail,omitempty"`
+	// An http or https URL of at most 2048 characters
+	TermsURL *string `json:"termsUrl,omitempty"`
 }
 
 type UpdateMeInput struct {
@@ -434,12 +2015,30 @@ type UpdateMeInput struct {
 	Email       *string `json:"email,omitempty"`
 }
 
+// Replaces the organization's branding; omitted fields fall back to Kaimu's
+type UpdateOrganizationBrandingInput struct {
+	OrganizationID string `json:"organizationId"`
+	// At most 100 characters
+	FromName *string `json:"fromName,omitempty"`
+	// Changing its domain restarts verification
+	FromEmail *string `json:"fromEmail,omitempty"`
+	// An https URL of at most 2048 characters
+	LogoURL *string `json:"logoUrl,omitempty"`
+	// #RRGGBB
+	AccentColor *string `json:"accentColor,omitempty"`
+}
+
 type UpdateOrganizationInput struct {
 	ID          string  `json:"id"`
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
 }
 
+type UpdateProjectCalendarInput struct {
+	WorkingDays  []Weekday `json:"workingDays,omitempty"`
+	PointsPerDay *float64  `json:"pointsPerDay,omitempty"`
+}
+
 type UpdateProjectInput struct {
 	ID          string  `json:"id"`
 	Name        *string `json:"name,omitempty"`
@@ -468,6 +2067,20 @@ type UpdateTagInput struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// Fields left out are unchanged
+type UpdateWebhookInput struct {
+	URL         *string  `json:"url,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+// A header the upload request must send
+type UploadHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 type User struct {
 	ID            string    `json:"id"`
 	Username      string    `json:"username"`
@@ -476,12 +2089,186 @@ type User struct {
 	DisplayName   *string   `json:"displayName,omitempty"`
 	AvatarURL     *string   `json:"avatarUrl,omitempty"`
 	CreatedAt     time.Time `json:"createdAt"`
+	// Language for emails and notifications, e.g. "es"; null uses the organization's default
+	Locale *string `json:"locale,omitempty"`
+	// How many unread notifications the user has; only set for the current user, as on me
+	UnreadNotificationCount *int `json:"unreadNotificationCount,omitempty"`
+}
+
+type UserMatch struct {
+	ID             string          `json:"id"`
+	OrganizationID string          `json:"organizationId"`
+	Source         string          `json:"source"`
+	ExternalID     string          `json:"externalId"`
+	DisplayName    *string         `json:"displayName,omitempty"`
+	Email          *string         `json:"email,omitempty"`
+	Status         UserMatchStatus `json:"status"`
+	UserID         *string         `json:"userId,omitempty"`
+	User           *User           `json:"user,omitempty"`
+	// How sure the match to user is, from 0 to 1; the best candidate's confidence while pending
+	Confidence float64 `json:"confidence"`
+	// Users who may be the external user, most likely first
+	Candidates []*UserMatchCandidate `json:"candidates"`
+	// Set when the match was resolved by hand
+	ResolvedBy *string    `json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type UserMatchCandidate struct {
+	UserID string `json:"userId"`
+	User   *User  `json:"user"`
+	// How likely the user is the external user, from 0 to 1
+	Confidence float64         `json:"confidence"`
+	Reason     UserMatchReason `json:"reason"`
 }
 
 type VelocityData struct {
 	Sprints []*SprintVelocity `json:"sprints"`
 }
 
+// An HTTP endpoint that receives the organization's events as signed JSON POST requests.
+// Each request has the headers X-Kaimu-Event, X-Kaimu-Delivery, X-Kaimu-Timestamp and
+// X-Kaimu-Signature; the signature is "sha256=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>", keyed with the webhook's secret.
+type Webhook struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	// Event names, as listed by webhookEvents
+	Events []string `json:"events"`
+	// Disabled webhooks get no new deliveries and their pending ones fail
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// One event sent to a webhook, with the outcome of its last attempt
+type WebhookDelivery struct {
+	ID string `json:"id"`
+	// The same for every webhook and attempt, for dropping duplicates
+	EventID string `json:"eventId"`
+	Event   string `json:"event"`
+	// The JSON request body
+	Body     string                `json:"body"`
+	Status   WebhookDeliveryStatus `json:"status"`
+	Attempts int                   `json:"attempts"`
+	// When the next attempt is due, for pending deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+	// Null when the last attempt got no response
+	ResponseStatus *int       `json:"responseStatus,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type WebhookDeliveryConnection struct {
+	Edges    []*WebhookDeliveryEdge `json:"edges"`
+	PageInfo *PageInfo              `json:"pageInfo"`
+}
+
+type WebhookDeliveryEdge struct {
+	Node   *WebhookDelivery `json:"node"`
+	Cursor string           `json:"cursor"`
+}
+
+// A new webhook with its secret, which is only shown when created or rotated
+type WebhookWithSecret struct {
+	Webhook *Webhook `json:"webhook"`
+	Secret  string   `json:"secret"`
+}
+
+type APITokenKind string
+
+const (
+	// Acts as the user who created it
+	APITokenKindPersonal APITokenKind = "PERSONAL"
+	// Acts as a service account that is a member of one organization
+	APITokenKindService APITokenKind = "SERVICE"
+)
+
+var AllAPITokenKind = []APITokenKind{
+	APITokenKindPersonal,
+	APITokenKindService,
+}
+
+func (e APITokenKind) IsValid() bool {
+	switch e {
+	case APITokenKindPersonal, APITokenKindService:
+		return true
+	}
+	return false
+}
+
+func (e APITokenKind) String() string {
+	return string(e)
+}
+
+func (e *APITokenKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ApiTokenKind", str)
+	}
+	return nil
+}
+
+func (e APITokenKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// What a token may do, within what its user may do
+type APITokenScope string
+
+const (
+	// View only; no mutations
+	APITokenScopeReadOnly APITokenScope = "READ_ONLY"
+	// View, and create, edit, move, assign and delete cards
+	APITokenScopeCardWrite APITokenScope = "CARD_WRITE"
+	// Everything its user may do, except managing sign-in and API tokens
+	APITokenScopeAdmin APITokenScope = "ADMIN"
+)
+
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopeReadOnly,
+	APITokenScopeCardWrite,
+	APITokenScopeAdmin,
+}
+
+func (e APITokenScope) IsValid() bool {
+	switch e {
+	case APITokenScopeReadOnly, APITokenScopeCardWrite, APITokenScopeAdmin:
+		return true
+	}
+	return false
+}
+
+func (e APITokenScope) String() string {
+	return string(e)
+}
+
+func (e *APITokenScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ApiTokenScope", str)
+	}
+	return nil
+}
+
+func (e APITokenScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type AuditAction string
 
 const (
@@ -503,6 +2290,16 @@ const (
 	AuditActionColumnVisibilityToggled AuditAction = "COLUMN_VISIBILITY_TOGGLED"
 	AuditActionUserLoggedIn            AuditAction = "USER_LOGGED_IN"
 	AuditActionUserLoggedOut           AuditAction = "USER_LOGGED_OUT"
+	AuditActionCardSplit               AuditAction = "CARD_SPLIT"
+	AuditActionCardMerged              AuditAction = "CARD_MERGED"
+	AuditActionLegalHoldPlaced         AuditAction = "LEGAL_HOLD_PLACED"
+	AuditActionLegalHoldLifted         AuditAction = "LEGAL_HOLD_LIFTED"
+	AuditActionBackupCreated           AuditAction = "BACKUP_CREATED"
+	AuditActionFreezeOverridden        AuditAction = "FREEZE_OVERRIDDEN"
+	AuditActionCardsImported           AuditAction = "CARDS_IMPORTED"
+	AuditActionProjectExportRequested  AuditAction = "PROJECT_EXPORT_REQUESTED"
+	AuditActionAPITokenCreated         AuditAction = "API_TOKEN_CREATED"
+	AuditActionAPITokenRevoked         AuditAction = "API_TOKEN_REVOKED"
 )
 
 var AllAuditAction = []AuditAction{
@@ -524,11 +2321,21 @@ var AllAuditAction = []AuditAction{
 	AuditActionColumnVisibilityToggled,
 	AuditActionUserLoggedIn,
 	AuditActionUserLoggedOut,
+	AuditActionCardSplit,
+	AuditActionCardMerged,
+	AuditActionLegalHoldPlaced,
+	AuditActionLegalHoldLifted,
+	AuditActionBackupCreated,
+	AuditActionFreezeOverridden,
+	AuditActionCardsImported,
+	AuditActionProjectExportRequested,
+	AuditActionAPITokenCreated,
+	AuditActionAPITokenRevoked,
 }
 
 func (e AuditAction) IsValid() bool {
 	switch e {
-	case AuditActionCreated, AuditActionUpdated, AuditActionDeleted, AuditActionCardMoved, AuditActionCardAssigned, AuditActionCardUnassigned, AuditActionSprintStarted, AuditActionSprintCompleted, AuditActionCardAddedToSprint, AuditActionCardRemovedFromSprint, AuditActionMemberInvited, AuditActionMemberJoined, AuditActionMemberRemoved, AuditActionMemberRoleChanged, AuditActionColumnReordered, AuditActionColumnVisibilityToggled, AuditActionUserLoggedIn, AuditActionUserLoggedOut:
+	case AuditActionCreated, AuditActionUpdated, AuditActionDeleted, AuditActionCardMoved, AuditActionCardAssigned, AuditActionCardUnassigned, AuditActionSprintStarted, AuditActionSprintCompleted, AuditActionCardAddedToSprint, AuditActionCardRemovedFromSprint, AuditActionMemberInvited, AuditActionMemberJoined, AuditActionMemberRemoved, AuditActionMemberRoleChanged, AuditActionColumnReordered, AuditActionColumnVisibilityToggled, AuditActionUserLoggedIn, AuditActionUserLoggedOut, AuditActionCardSplit, AuditActionCardMerged, AuditActionLegalHoldPlaced, AuditActionLegalHoldLifted, AuditActionBackupCreated, AuditActionFreezeOverridden, AuditActionCardsImported, AuditActionProjectExportRequested, AuditActionAPITokenCreated, AuditActionAPITokenRevoked:
 		return true
 	}
 	return false
@@ -555,148 +2362,1564 @@ func (e AuditAction) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-type AuditEntityType string
+type AuditAnomalyKind string
 
 const (
-	AuditEntityTypeUser         AuditEntityType = "USER"
-	AuditEntityTypeOrganization AuditEntityType = "ORGANIZATION"
-	AuditEntityTypeProject      AuditEntityType = "PROJECT"
-	AuditEntityTypeBoard        AuditEntityType = "BOARD"
-	AuditEntityTypeBoardColumn  AuditEntityType = "BOARD_COLUMN"
-	AuditEntityTypeCard         AuditEntityType = "CARD"
-	AuditEntityTypeSprint       AuditEntityType = "SPRINT"
-	AuditEntityTypeTag          AuditEntityType = "TAG"
-	AuditEntityTypeRole         AuditEntityType = "ROLE"
-	AuditEntityTypeInvitation   AuditEntityType = "INVITATION"
+	AuditAnomalyKindMassDeletion       AuditAnomalyKind = "MASS_DELETION"
+	AuditAnomalyKindOffHoursRoleChange AuditAnomalyKind = "OFF_HOURS_ROLE_CHANGE"
 )
 
-var AllAuditEntityType = []AuditEntityType{
-	AuditEntityTypeUser,
-	AuditEntityTypeOrganization,
-	AuditEntityTypeProject,
-	AuditEntityTypeBoard,
-	AuditEntityTypeBoardColumn,
-	AuditEntityTypeCard,
-	AuditEntityTypeSprint,
-	AuditEntityTypeTag,
-	AuditEntityTypeRole,
-	AuditEntityTypeInvitation,
+var AllAuditAnomalyKind = []AuditAnomalyKind{
+	AuditAnomalyKindMassDeletion,
+	AuditAnomalyKindOffHoursRoleChange,
 }
 
-func (e AuditEntityType) IsValid() bool {
+func (e AuditAnomalyKind) IsValid() bool {
 	switch e {
-	case AuditEntityTypeUser, AuditEntityTypeOrganization, AuditEntityTypeProject, AuditEntityTypeBoard, AuditEntityTypeBoardColumn, AuditEntityTypeCard, AuditEntityTypeSprint, AuditEntityTypeTag, AuditEntityTypeRole, AuditEntityTypeInvitation:
+	case AuditAnomalyKindMassDeletion, AuditAnomalyKindOffHoursRoleChange:
 		return true
 	}
 	return false
 }
 
-func (e AuditEntityType) String() string {
+func (e AuditAnomalyKind) String() string {
 	return string(e)
 }
 
-func (e *AuditEntityType) UnmarshalGQL(v interface{}) error {
+func (e *AuditAnomalyKind) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = AuditEntityType(str)
+	*e = AuditAnomalyKind(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid AuditEntityType", str)
+		return fmt.Errorf("%s is not a valid AuditAnomalyKind", str)
 	}
 	return nil
 }
 
-func (e AuditEntityType) MarshalGQL(w io.Writer) {
+func (e AuditAnomalyKind) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-type CardPriority string
+// How the user behind an event authenticated
+type AuditAuthMethod string
 
 const (
-	CardPriorityNone   CardPriority = "NONE"
-	CardPriorityLow    CardPriority = "LOW"
-	CardPriorityMedium CardPriority = "MEDIUM"
-	CardPriorityHigh   CardPriority = "HIGH"
-	CardPriorityUrgent CardPriority = "URGENT"
+	// The session cookie set at login
+	AuditAuthMethodSession AuditAuthMethod = "SESSION"
+	// An access token the client sent itself, e.g. when opening a websocket
+	AuditAuthMethodToken AuditAuthMethod = "TOKEN"
+	// A personal access or service token sent as a bearer token
+	AuditAuthMethodAPIToken AuditAuthMethod = "API_TOKEN"
 )
 
-var AllCardPriority = []CardPriority{
-	CardPriorityNone,
-	CardPriorityLow,
-	CardPriorityMedium,
-	CardPriorityHigh,
-	CardPriorityUrgent,
+var AllAuditAuthMethod = []AuditAuthMethod{
+	AuditAuthMethodSession,
+	AuditAuthMethodToken,
+	AuditAuthMethodAPIToken,
 }
 
-func (e CardPriority) IsValid() bool {
+func (e AuditAuthMethod) IsValid() bool {
 	switch e {
-	case CardPriorityNone, CardPriorityLow, CardPriorityMedium, CardPriorityHigh, CardPriorityUrgent:
+	case AuditAuthMethodSession, AuditAuthMethodToken, AuditAuthMethodAPIToken:
 		return true
 	}
 	return false
 }
 
-func (e CardPriority) String() string {
+func (e AuditAuthMethod) String() string {
 	return string(e)
 }
 
-func (e *CardPriority) UnmarshalGQL(v interface{}) error {
+func (e *AuditAuthMethod) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = CardPriority(str)
+	*e = AuditAuthMethod(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid CardPriority", str)
+		return fmt.Errorf("%s is not a valid AuditAuthMethod", str)
 	}
 	return nil
 }
 
-func (e CardPriority) MarshalGQL(w io.Writer) {
+func (e AuditAuthMethod) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-type MetricMode string
+type AuditEntityType string
 
 const (
-	MetricModeCardCount   MetricMode = "CARD_COUNT"
-	MetricModeStoryPoints MetricMode = "STORY_POINTS"
+	AuditEntityTypeUser          AuditEntityType = "USER"
+	AuditEntityTypeOrganization  AuditEntityType = "ORGANIZATION"
+	AuditEntityTypeProject       AuditEntityType = "PROJECT"
+	AuditEntityTypeBoard         AuditEntityType = "BOARD"
+	AuditEntityTypeBoardColumn   AuditEntityType = "BOARD_COLUMN"
+	AuditEntityTypeCard          AuditEntityType = "CARD"
+	AuditEntityTypeSprint        AuditEntityType = "SPRINT"
+	AuditEntityTypeTag           AuditEntityType = "TAG"
+	AuditEntityTypeRole          AuditEntityType = "ROLE"
+	AuditEntityTypeInvitation    AuditEntityType = "INVITATION"
+	AuditEntityTypeComment       AuditEntityType = "COMMENT"
+	AuditEntityTypeAttachment    AuditEntityType = "ATTACHMENT"
+	AuditEntityTypeChecklistItem AuditEntityType = "CHECKLIST_ITEM"
 )
 
-var AllMetricMode = []MetricMode{
-	MetricModeCardCount,
-	MetricModeStoryPoints,
+var AllAuditEntityType = []AuditEntityType{
+	AuditEntityTypeUser,
+	AuditEntityTypeOrganization,
+	AuditEntityTypeProject,
+	AuditEntityTypeBoard,
+	AuditEntityTypeBoardColumn,
+	AuditEntityTypeCard,
+	AuditEntityTypeSprint,
+	AuditEntityTypeTag,
+	AuditEntityTypeRole,
+	AuditEntityTypeInvitation,
+	AuditEntityTypeComment,
+	AuditEntityTypeAttachment,
+	AuditEntityTypeChecklistItem,
 }
 
-func (e MetricMode) IsValid() bool {
+func (e AuditEntityType) IsValid() bool {
 	switch e {
-	case MetricModeCardCount, MetricModeStoryPoints:
+	case AuditEntityTypeUser, AuditEntityTypeOrganization, AuditEntityTypeProject, AuditEntityTypeBoard, AuditEntityTypeBoardColumn, AuditEntityTypeCard, AuditEntityTypeSprint, AuditEntityTypeTag, AuditEntityTypeRole, AuditEntityTypeInvitation, AuditEntityTypeComment, AuditEntityTypeAttachment, AuditEntityTypeChecklistItem:
 		return true
 	}
 	return false
 }
 
-func (e MetricMode) String() string {
+func (e AuditEntityType) String() string {
 	return string(e)
 }
 
-func (e *MetricMode) UnmarshalGQL(v interface{}) error {
+func (e *AuditEntityType) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = MetricMode(str)
+	*e = AuditEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditEntityType", str)
+	}
+	return nil
+}
+
+func (e AuditEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BoardSnapshotFormat string
+
+const (
+	// For Slack and email clients, which don't show SVG
+	BoardSnapshotFormatPng BoardSnapshotFormat = "PNG"
+	BoardSnapshotFormatSVG BoardSnapshotFormat = "SVG"
+)
+
+var AllBoardSnapshotFormat = []BoardSnapshotFormat{
+	BoardSnapshotFormatPng,
+	BoardSnapshotFormatSVG,
+}
+
+func (e BoardSnapshotFormat) IsValid() bool {
+	switch e {
+	case BoardSnapshotFormatPng, BoardSnapshotFormatSVG:
+		return true
+	}
+	return false
+}
+
+func (e BoardSnapshotFormat) String() string {
+	return string(e)
+}
+
+func (e *BoardSnapshotFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BoardSnapshotFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BoardSnapshotFormat", str)
+	}
+	return nil
+}
+
+func (e BoardSnapshotFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BrandingDNSRecordPurpose string
+
+const (
+	// Proves the organization owns the domain
+	BrandingDNSRecordPurposeOwnership BrandingDNSRecordPurpose = "OWNERSHIP"
+	// Allows Kaimu's mail servers to send for the domain
+	BrandingDNSRecordPurposeSpf BrandingDNSRecordPurpose = "SPF"
+	// Publishes the key Kaimu signs the domain's mail with
+	BrandingDNSRecordPurposeDkim BrandingDNSRecordPurpose = "DKIM"
+)
+
+var AllBrandingDNSRecordPurpose = []BrandingDNSRecordPurpose{
+	BrandingDNSRecordPurposeOwnership,
+	BrandingDNSRecordPurposeSpf,
+	BrandingDNSRecordPurposeDkim,
+}
+
+func (e BrandingDNSRecordPurpose) IsValid() bool {
+	switch e {
+	case BrandingDNSRecordPurposeOwnership, BrandingDNSRecordPurposeSpf, BrandingDNSRecordPurposeDkim:
+		return true
+	}
+	return false
+}
+
+func (e BrandingDNSRecordPurpose) String() string {
+	return string(e)
+}
+
+func (e *BrandingDNSRecordPurpose) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BrandingDNSRecordPurpose(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BrandingDnsRecordPurpose", str)
+	}
+	return nil
+}
+
+func (e BrandingDNSRecordPurpose) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CardAggregateField string
+
+const (
+	CardAggregateFieldAssignee CardAggregateField = "ASSIGNEE"
+	// A card counts once in each of its tags' groups
+	CardAggregateFieldTag      CardAggregateField = "TAG"
+	CardAggregateFieldPriority CardAggregateField = "PRIORITY"
+	CardAggregateFieldColumn   CardAggregateField = "COLUMN"
+	CardAggregateFieldEpic     CardAggregateField = "EPIC"
+)
+
+var AllCardAggregateField = []CardAggregateField{
+	CardAggregateFieldAssignee,
+	CardAggregateFieldTag,
+	CardAggregateFieldPriority,
+	CardAggregateFieldColumn,
+	CardAggregateFieldEpic,
+}
+
+func (e CardAggregateField) IsValid() bool {
+	switch e {
+	case CardAggregateFieldAssignee, CardAggregateFieldTag, CardAggregateFieldPriority, CardAggregateFieldColumn, CardAggregateFieldEpic:
+		return true
+	}
+	return false
+}
+
+func (e CardAggregateField) String() string {
+	return string(e)
+}
+
+func (e *CardAggregateField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardAggregateField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardAggregateField", str)
+	}
+	return nil
+}
+
+func (e CardAggregateField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CardDependencyKind string
+
+const (
+	// The from card has to be done before the to card
+	CardDependencyKindBlocks CardDependencyKind = "BLOCKS"
+	// The cards are related without being ordered
+	CardDependencyKindRelates CardDependencyKind = "RELATES"
+	// The from card was split off the to card (see splitCard); not set by addCardDependency
+	CardDependencyKindSplitFrom CardDependencyKind = "SPLIT_FROM"
+	// The from card duplicates the to card
+	CardDependencyKindDuplicates CardDependencyKind = "DUPLICATES"
+)
+
+var AllCardDependencyKind = []CardDependencyKind{
+	CardDependencyKindBlocks,
+	CardDependencyKindRelates,
+	CardDependencyKindSplitFrom,
+	CardDependencyKindDuplicates,
+}
+
+func (e CardDependencyKind) IsValid() bool {
+	switch e {
+	case CardDependencyKindBlocks, CardDependencyKindRelates, CardDependencyKindSplitFrom, CardDependencyKindDuplicates:
+		return true
+	}
+	return false
+}
+
+func (e CardDependencyKind) String() string {
+	return string(e)
+}
+
+func (e *CardDependencyKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardDependencyKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardDependencyKind", str)
+	}
+	return nil
+}
+
+func (e CardDependencyKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A card field a CSV column can be mapped to
+type CardImportField string
+
+const (
+	CardImportFieldTitle       CardImportField = "TITLE"
+	CardImportFieldDescription CardImportField = "DESCRIPTION"
+	// Matched to a user who can view the board's project
+	CardImportFieldAssigneeEmail CardImportField = "ASSIGNEE_EMAIL"
+	// Tag names separated by commas or semicolons; unknown tags are created
+	CardImportFieldTags        CardImportField = "TAGS"
+	CardImportFieldStoryPoints CardImportField = "STORY_POINTS"
+	// A date such as 2024-05-31, or an RFC 3339 timestamp
+	CardImportFieldDueDate CardImportField = "DUE_DATE"
+)
+
+var AllCardImportField = []CardImportField{
+	CardImportFieldTitle,
+	CardImportFieldDescription,
+	CardImportFieldAssigneeEmail,
+	CardImportFieldTags,
+	CardImportFieldStoryPoints,
+	CardImportFieldDueDate,
+}
+
+func (e CardImportField) IsValid() bool {
+	switch e {
+	case CardImportFieldTitle, CardImportFieldDescription, CardImportFieldAssigneeEmail, CardImportFieldTags, CardImportFieldStoryPoints, CardImportFieldDueDate:
+		return true
+	}
+	return false
+}
+
+func (e CardImportField) String() string {
+	return string(e)
+}
+
+func (e *CardImportField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardImportField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardImportField", str)
+	}
+	return nil
+}
+
+func (e CardImportField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A tracker export importProjectCards reads
+type CardImportFormat string
+
+const (
+	// A Jira issue search exported as CSV with all fields
+	CardImportFormatJiraCSV CardImportFormat = "JIRA_CSV"
+	// A Trello board exported as JSON
+	CardImportFormatTrelloJSON CardImportFormat = "TRELLO_JSON"
+)
+
+var AllCardImportFormat = []CardImportFormat{
+	CardImportFormatJiraCSV,
+	CardImportFormatTrelloJSON,
+}
+
+func (e CardImportFormat) IsValid() bool {
+	switch e {
+	case CardImportFormatJiraCSV, CardImportFormatTrelloJSON:
+		return true
+	}
+	return false
+}
+
+func (e CardImportFormat) String() string {
+	return string(e)
+}
+
+func (e *CardImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardImportFormat", str)
+	}
+	return nil
+}
+
+func (e CardImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// How a card relates to the other card of a link
+type CardLinkRelation string
+
+const (
+	CardLinkRelationBlocks       CardLinkRelation = "BLOCKS"
+	CardLinkRelationBlockedBy    CardLinkRelation = "BLOCKED_BY"
+	CardLinkRelationRelatesTo    CardLinkRelation = "RELATES_TO"
+	CardLinkRelationDuplicates   CardLinkRelation = "DUPLICATES"
+	CardLinkRelationDuplicatedBy CardLinkRelation = "DUPLICATED_BY"
+	// The card was split off the other card
+	CardLinkRelationSplitFrom CardLinkRelation = "SPLIT_FROM"
+	// The other card was split off the card
+	CardLinkRelationSplitInto CardLinkRelation = "SPLIT_INTO"
+)
+
+var AllCardLinkRelation = []CardLinkRelation{
+	CardLinkRelationBlocks,
+	CardLinkRelationBlockedBy,
+	CardLinkRelationRelatesTo,
+	CardLinkRelationDuplicates,
+	CardLinkRelationDuplicatedBy,
+	CardLinkRelationSplitFrom,
+	CardLinkRelationSplitInto,
+}
+
+func (e CardLinkRelation) IsValid() bool {
+	switch e {
+	case CardLinkRelationBlocks, CardLinkRelationBlockedBy, CardLinkRelationRelatesTo, CardLinkRelationDuplicates, CardLinkRelationDuplicatedBy, CardLinkRelationSplitFrom, CardLinkRelationSplitInto:
+		return true
+	}
+	return false
+}
+
+func (e CardLinkRelation) String() string {
+	return string(e)
+}
+
+func (e *CardLinkRelation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardLinkRelation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardLinkRelation", str)
+	}
+	return nil
+}
+
+func (e CardLinkRelation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CardMirrorDirection string
+
+const (
+	// Changes to the source card are copied to the mirror
+	CardMirrorDirectionOneWay CardMirrorDirection = "ONE_WAY"
+	// Changes to either card are copied to the other
+	CardMirrorDirectionTwoWay CardMirrorDirection = "TWO_WAY"
+)
+
+var AllCardMirrorDirection = []CardMirrorDirection{
+	CardMirrorDirectionOneWay,
+	CardMirrorDirectionTwoWay,
+}
+
+func (e CardMirrorDirection) IsValid() bool {
+	switch e {
+	case CardMirrorDirectionOneWay, CardMirrorDirectionTwoWay:
+		return true
+	}
+	return false
+}
+
+func (e CardMirrorDirection) String() string {
+	return string(e)
+}
+
+func (e *CardMirrorDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardMirrorDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardMirrorDirection", str)
+	}
+	return nil
+}
+
+func (e CardMirrorDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CardPrintLayout string
+
+const (
+	// Eight index cards a page with their descriptions, to cut out for a physical board
+	CardPrintLayoutCards CardPrintLayout = "CARDS"
+	// Twelve square notes a page showing the title, estimate, priority and assignee
+	CardPrintLayoutStickyNotes CardPrintLayout = "STICKY_NOTES"
+	// A table of the cards, for reviews
+	CardPrintLayoutList CardPrintLayout = "LIST"
+)
+
+var AllCardPrintLayout = []CardPrintLayout{
+	CardPrintLayoutCards,
+	CardPrintLayoutStickyNotes,
+	CardPrintLayoutList,
+}
+
+func (e CardPrintLayout) IsValid() bool {
+	switch e {
+	case CardPrintLayoutCards, CardPrintLayoutStickyNotes, CardPrintLayoutList:
+		return true
+	}
+	return false
+}
+
+func (e CardPrintLayout) String() string {
+	return string(e)
+}
+
+func (e *CardPrintLayout) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardPrintLayout(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardPrintLayout", str)
+	}
+	return nil
+}
+
+func (e CardPrintLayout) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CardPriority string
+
+const (
+	CardPriorityNone   CardPriority = "NONE"
+	CardPriorityLow    CardPriority = "LOW"
+	CardPriorityMedium CardPriority = "MEDIUM"
+	CardPriorityHigh   CardPriority = "HIGH"
+	CardPriorityUrgent CardPriority = "URGENT"
+)
+
+var AllCardPriority = []CardPriority{
+	CardPriorityNone,
+	CardPriorityLow,
+	CardPriorityMedium,
+	CardPriorityHigh,
+	CardPriorityUrgent,
+}
+
+func (e CardPriority) IsValid() bool {
+	switch e {
+	case CardPriorityNone, CardPriorityLow, CardPriorityMedium, CardPriorityHigh, CardPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+func (e CardPriority) String() string {
+	return string(e)
+}
+
+func (e *CardPriority) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CardPriority(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CardPriority", str)
+	}
+	return nil
+}
+
+func (e CardPriority) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ColumnAlertKind string
+
+const (
+	ColumnAlertKindWipExceeded ColumnAlertKind = "WIP_EXCEEDED"
+	ColumnAlertKindAging       ColumnAlertKind = "AGING"
+)
+
+var AllColumnAlertKind = []ColumnAlertKind{
+	ColumnAlertKindWipExceeded,
+	ColumnAlertKindAging,
+}
+
+func (e ColumnAlertKind) IsValid() bool {
+	switch e {
+	case ColumnAlertKindWipExceeded, ColumnAlertKindAging:
+		return true
+	}
+	return false
+}
+
+func (e ColumnAlertKind) String() string {
+	return string(e)
+}
+
+func (e *ColumnAlertKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ColumnAlertKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ColumnAlertKind", str)
+	}
+	return nil
+}
+
+func (e ColumnAlertKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ConflictStrategy string
+
+const (
+	// Reject the mutation when the card changed on the server since baseUpdatedAt
+	ConflictStrategyServerWins ConflictStrategy = "SERVER_WINS"
+	// Apply the mutation over any server changes
+	ConflictStrategyClientWins ConflictStrategy = "CLIENT_WINS"
+)
+
+var AllConflictStrategy = []ConflictStrategy{
+	ConflictStrategyServerWins,
+	ConflictStrategyClientWins,
+}
+
+func (e ConflictStrategy) IsValid() bool {
+	switch e {
+	case ConflictStrategyServerWins, ConflictStrategyClientWins:
+		return true
+	}
+	return false
+}
+
+func (e ConflictStrategy) String() string {
+	return string(e)
+}
+
+func (e *ConflictStrategy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConflictStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConflictStrategy", str)
+	}
+	return nil
+}
+
+func (e ConflictStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Invitations that have not been accepted, by whether they have expired
+type InvitationStatus string
+
+const (
+	InvitationStatusPending InvitationStatus = "PENDING"
+	InvitationStatusExpired InvitationStatus = "EXPIRED"
+)
+
+var AllInvitationStatus = []InvitationStatus{
+	InvitationStatusPending,
+	InvitationStatusExpired,
+}
+
+func (e InvitationStatus) IsValid() bool {
+	switch e {
+	case InvitationStatusPending, InvitationStatusExpired:
+		return true
+	}
+	return false
+}
+
+func (e InvitationStatus) String() string {
+	return string(e)
+}
+
+func (e *InvitationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InvitationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InvitationStatus", str)
+	}
+	return nil
+}
+
+func (e InvitationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type JiraStatusCategory string
+
+const (
+	JiraStatusCategoryToDo       JiraStatusCategory = "TO_DO"
+	JiraStatusCategoryInProgress JiraStatusCategory = "IN_PROGRESS"
+	JiraStatusCategoryDone       JiraStatusCategory = "DONE"
+)
+
+var AllJiraStatusCategory = []JiraStatusCategory{
+	JiraStatusCategoryToDo,
+	JiraStatusCategoryInProgress,
+	JiraStatusCategoryDone,
+}
+
+func (e JiraStatusCategory) IsValid() bool {
+	switch e {
+	case JiraStatusCategoryToDo, JiraStatusCategoryInProgress, JiraStatusCategoryDone:
+		return true
+	}
+	return false
+}
+
+func (e JiraStatusCategory) String() string {
+	return string(e)
+}
+
+func (e *JiraStatusCategory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JiraStatusCategory(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JiraStatusCategory", str)
+	}
+	return nil
+}
+
+func (e JiraStatusCategory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LabelSuggestionSource string
+
+const (
+	// The labels of the project's similarly worded cards
+	LabelSuggestionSourceHistory LabelSuggestionSource = "HISTORY"
+	// Picked from the project's tags by the configured language model
+	LabelSuggestionSourceLanguageModel LabelSuggestionSource = "LANGUAGE_MODEL"
+)
+
+var AllLabelSuggestionSource = []LabelSuggestionSource{
+	LabelSuggestionSourceHistory,
+	LabelSuggestionSourceLanguageModel,
+}
+
+func (e LabelSuggestionSource) IsValid() bool {
+	switch e {
+	case LabelSuggestionSourceHistory, LabelSuggestionSourceLanguageModel:
+		return true
+	}
+	return false
+}
+
+func (e LabelSuggestionSource) String() string {
+	return string(e)
+}
+
+func (e *LabelSuggestionSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LabelSuggestionSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LabelSuggestionSource", str)
+	}
+	return nil
+}
+
+func (e LabelSuggestionSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MemberRoleChangeStatus string
+
+const (
+	// The member now has the role
+	MemberRoleChangeStatusChanged MemberRoleChangeStatus = "CHANGED"
+	// The member already had the role
+	MemberRoleChangeStatusUnchanged MemberRoleChangeStatus = "UNCHANGED"
+	// The user is not a member of the organization
+	MemberRoleChangeStatusNotAMember MemberRoleChangeStatus = "NOT_A_MEMBER"
+	// The member is an owner, and the change would leave the organization without one
+	MemberRoleChangeStatusLastOwner MemberRoleChangeStatus = "LAST_OWNER"
+	// The member could take the role but kept their own, as another member could not
+	MemberRoleChangeStatusRolledBack MemberRoleChangeStatus = "ROLLED_BACK"
+)
+
+var AllMemberRoleChangeStatus = []MemberRoleChangeStatus{
+	MemberRoleChangeStatusChanged,
+	MemberRoleChangeStatusUnchanged,
+	MemberRoleChangeStatusNotAMember,
+	MemberRoleChangeStatusLastOwner,
+	MemberRoleChangeStatusRolledBack,
+}
+
+func (e MemberRoleChangeStatus) IsValid() bool {
+	switch e {
+	case MemberRoleChangeStatusChanged, MemberRoleChangeStatusUnchanged, MemberRoleChangeStatusNotAMember, MemberRoleChangeStatusLastOwner, MemberRoleChangeStatusRolledBack:
+		return true
+	}
+	return false
+}
+
+func (e MemberRoleChangeStatus) String() string {
+	return string(e)
+}
+
+func (e *MemberRoleChangeStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MemberRoleChangeStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MemberRoleChangeStatus", str)
+	}
+	return nil
+}
+
+func (e MemberRoleChangeStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MergeInvitationAction string
+
+const (
+	MergeInvitationActionMoved MergeInvitationAction = "MOVED"
+	// Dropped because the target organization already invited the address
+	MergeInvitationActionSkipped MergeInvitationAction = "SKIPPED"
+)
+
+var AllMergeInvitationAction = []MergeInvitationAction{
+	MergeInvitationActionMoved,
+	MergeInvitationActionSkipped,
+}
+
+func (e MergeInvitationAction) IsValid() bool {
+	switch e {
+	case MergeInvitationActionMoved, MergeInvitationActionSkipped:
+		return true
+	}
+	return false
+}
+
+func (e MergeInvitationAction) String() string {
+	return string(e)
+}
+
+func (e *MergeInvitationAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MergeInvitationAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MergeInvitationAction", str)
+	}
+	return nil
+}
+
+func (e MergeInvitationAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MergeMemberAction string
+
+const (
+	// Joins the target organization with their source role (the source owner joins as admin)
+	MergeMemberActionMoved MergeMemberAction = "MOVED"
+	// Already a member of the target organization, whose role is kept
+	MergeMemberActionAlreadyMember MergeMemberAction = "ALREADY_MEMBER"
+)
+
+var AllMergeMemberAction = []MergeMemberAction{
+	MergeMemberActionMoved,
+	MergeMemberActionAlreadyMember,
+}
+
+func (e MergeMemberAction) IsValid() bool {
+	switch e {
+	case MergeMemberActionMoved, MergeMemberActionAlreadyMember:
+		return true
+	}
+	return false
+}
+
+func (e MergeMemberAction) String() string {
+	return string(e)
+}
+
+func (e *MergeMemberAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MergeMemberAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MergeMemberAction", str)
+	}
+	return nil
+}
+
+func (e MergeMemberAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MetricMode string
+
+const (
+	MetricModeCardCount   MetricMode = "CARD_COUNT"
+	MetricModeStoryPoints MetricMode = "STORY_POINTS"
+)
+
+var AllMetricMode = []MetricMode{
+	MetricModeCardCount,
+	MetricModeStoryPoints,
+}
+
+func (e MetricMode) IsValid() bool {
+	switch e {
+	case MetricModeCardCount, MetricModeStoryPoints:
+		return true
+	}
+	return false
+}
+
+func (e MetricMode) String() string {
+	return string(e)
+}
+
+func (e *MetricMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MetricMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MetricMode", str)
+	}
+	return nil
+}
+
+func (e MetricMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MetricsEmbedChart string
+
+const (
+	MetricsEmbedChartBurnDown       MetricsEmbedChart = "BURN_DOWN"
+	MetricsEmbedChartBurnUp         MetricsEmbedChart = "BURN_UP"
+	MetricsEmbedChartVelocity       MetricsEmbedChart = "VELOCITY"
+	MetricsEmbedChartCumulativeFlow MetricsEmbedChart = "CUMULATIVE_FLOW"
+)
+
+var AllMetricsEmbedChart = []MetricsEmbedChart{
+	MetricsEmbedChartBurnDown,
+	MetricsEmbedChartBurnUp,
+	MetricsEmbedChartVelocity,
+	MetricsEmbedChartCumulativeFlow,
+}
+
+func (e MetricsEmbedChart) IsValid() bool {
+	switch e {
+	case MetricsEmbedChartBurnDown, MetricsEmbedChartBurnUp, MetricsEmbedChartVelocity, MetricsEmbedChartCumulativeFlow:
+		return true
+	}
+	return false
+}
+
+func (e MetricsEmbedChart) String() string {
+	return string(e)
+}
+
+func (e *MetricsEmbedChart) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MetricsEmbedChart(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MetricsEmbedChart", str)
+	}
+	return nil
+}
+
+func (e MetricsEmbedChart) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Notifications that can be turned on or off together; invitations are always shown
+type NotificationCategory string
+
+const (
+	// Cards assigned to you
+	NotificationCategoryAssignments NotificationCategory = "ASSIGNMENTS"
+	// @mentions of you in comments
+	NotificationCategoryMentions NotificationCategory = "MENTIONS"
+	// Sprints starting with cards assigned to you
+	NotificationCategorySprints NotificationCategory = "SPRINTS"
+)
+
+var AllNotificationCategory = []NotificationCategory{
+	NotificationCategoryAssignments,
+	NotificationCategoryMentions,
+	NotificationCategorySprints,
+}
+
+func (e NotificationCategory) IsValid() bool {
+	switch e {
+	case NotificationCategoryAssignments, NotificationCategoryMentions, NotificationCategorySprints:
+		return true
+	}
+	return false
+}
+
+func (e NotificationCategory) String() string {
+	return string(e)
+}
+
+func (e *NotificationCategory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationCategory(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationCategory", str)
+	}
+	return nil
+}
+
+func (e NotificationCategory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Channels a card event can be delivered through outside the app
+type NotificationChannel string
+
+const (
+	// Email the owners of matching notification rules
+	NotificationChannelEmail NotificationChannel = "EMAIL"
+	// Post every such event to a Slack channel
+	NotificationChannelSLACk NotificationChannel = "SLACK"
+)
+
+var AllNotificationChannel = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelSLACk,
+}
+
+func (e NotificationChannel) IsValid() bool {
+	switch e {
+	case NotificationChannelEmail, NotificationChannelSLACk:
+		return true
+	}
+	return false
+}
+
+func (e NotificationChannel) String() string {
+	return string(e)
+}
+
+func (e *NotificationChannel) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationChannel(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationChannel", str)
+	}
+	return nil
+}
+
+func (e NotificationChannel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationKind string
+
+const (
+	// A card was assigned to you
+	NotificationKindCardAssigned NotificationKind = "CARD_ASSIGNED"
+	// You were @mentioned in a comment
+	NotificationKindMentioned NotificationKind = "MENTIONED"
+	// You were invited into an organization
+	NotificationKindInvitationReceived NotificationKind = "INVITATION_RECEIVED"
+	// A sprint holding cards assigned to you started
+	NotificationKindSprintStarted NotificationKind = "SPRINT_STARTED"
+)
+
+var AllNotificationKind = []NotificationKind{
+	NotificationKindCardAssigned,
+	NotificationKindMentioned,
+	NotificationKindInvitationReceived,
+	NotificationKindSprintStarted,
+}
+
+func (e NotificationKind) IsValid() bool {
+	switch e {
+	case NotificationKindCardAssigned, NotificationKindMentioned, NotificationKindInvitationReceived, NotificationKindSprintStarted:
+		return true
+	}
+	return false
+}
+
+func (e NotificationKind) String() string {
+	return string(e)
+}
+
+func (e *NotificationKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationKind", str)
+	}
+	return nil
+}
+
+func (e NotificationKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Card events a notification rule can watch
+type NotificationRuleEvent string
+
+const (
+	NotificationRuleEventCardCreated     NotificationRuleEvent = "CARD_CREATED"
+	NotificationRuleEventCardUpdated     NotificationRuleEvent = "CARD_UPDATED"
+	NotificationRuleEventCardMoved       NotificationRuleEvent = "CARD_MOVED"
+	NotificationRuleEventCardDeleted     NotificationRuleEvent = "CARD_DELETED"
+	NotificationRuleEventCardSLABreached NotificationRuleEvent = "CARD_SLA_BREACHED"
+)
+
+var AllNotificationRuleEvent = []NotificationRuleEvent{
+	NotificationRuleEventCardCreated,
+	NotificationRuleEventCardUpdated,
+	NotificationRuleEventCardMoved,
+	NotificationRuleEventCardDeleted,
+	NotificationRuleEventCardSLABreached,
+}
+
+func (e NotificationRuleEvent) IsValid() bool {
+	switch e {
+	case NotificationRuleEventCardCreated, NotificationRuleEventCardUpdated, NotificationRuleEventCardMoved, NotificationRuleEventCardDeleted, NotificationRuleEventCardSLABreached:
+		return true
+	}
+	return false
+}
+
+func (e NotificationRuleEvent) String() string {
+	return string(e)
+}
+
+func (e *NotificationRuleEvent) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationRuleEvent(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationRuleEvent", str)
+	}
+	return nil
+}
+
+func (e NotificationRuleEvent) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OfflineMutationStatus string
+
+const (
+	OfflineMutationStatusApplied OfflineMutationStatus = "APPLIED"
+	// The card changed on the server or was deleted; card holds the server's version
+	OfflineMutationStatusConflict OfflineMutationStatus = "CONFLICT"
+	// The mutation failed, for example on permissions or validation; see message
+	OfflineMutationStatusRejected OfflineMutationStatus = "REJECTED"
+)
+
+var AllOfflineMutationStatus = []OfflineMutationStatus{
+	OfflineMutationStatusApplied,
+	OfflineMutationStatusConflict,
+	OfflineMutationStatusRejected,
+}
+
+func (e OfflineMutationStatus) IsValid() bool {
+	switch e {
+	case OfflineMutationStatusApplied, OfflineMutationStatusConflict, OfflineMutationStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e OfflineMutationStatus) String() string {
+	return string(e)
+}
+
+func (e *OfflineMutationStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OfflineMutationStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OfflineMutationStatus", str)
+	}
+	return nil
+}
+
+func (e OfflineMutationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// What moveCard does when a card moved into a done column is blocked by open cards
+type OpenBlockerPolicy string
+
+const (
+	// Move the card anyway
+	OpenBlockerPolicyIgnore OpenBlockerPolicy = "IGNORE"
+	// Move the card and report a CARD_BLOCKED error alongside the result
+	OpenBlockerPolicyWarn OpenBlockerPolicy = "WARN"
+	// Refuse the move with a CARD_BLOCKED error
+	OpenBlockerPolicyFail OpenBlockerPolicy = "FAIL"
+)
+
+var AllOpenBlockerPolicy = []OpenBlockerPolicy{
+	OpenBlockerPolicyIgnore,
+	OpenBlockerPolicyWarn,
+	OpenBlockerPolicyFail,
+}
+
+func (e OpenBlockerPolicy) IsValid() bool {
+	switch e {
+	case OpenBlockerPolicyIgnore, OpenBlockerPolicyWarn, OpenBlockerPolicyFail:
+		return true
+	}
+	return false
+}
+
+func (e OpenBlockerPolicy) String() string {
+	return string(e)
+}
+
+func (e *OpenBlockerPolicy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OpenBlockerPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OpenBlockerPolicy", str)
+	}
+	return nil
+}
+
+func (e OpenBlockerPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrganizationDirectorySort string
+
+const (
+	// Display name, else username
+	OrganizationDirectorySortName     OrganizationDirectorySort = "NAME"
+	OrganizationDirectorySortJoinedAt OrganizationDirectorySort = "JOINED_AT"
+	// Members who were never active come last
+	OrganizationDirectorySortLastActiveAt OrganizationDirectorySort = "LAST_ACTIVE_AT"
+)
+
+var AllOrganizationDirectorySort = []OrganizationDirectorySort{
+	OrganizationDirectorySortName,
+	OrganizationDirectorySortJoinedAt,
+	OrganizationDirectorySortLastActiveAt,
+}
+
+func (e OrganizationDirectorySort) IsValid() bool {
+	switch e {
+	case OrganizationDirectorySortName, OrganizationDirectorySortJoinedAt, OrganizationDirectorySortLastActiveAt:
+		return true
+	}
+	return false
+}
+
+func (e OrganizationDirectorySort) String() string {
+	return string(e)
+}
+
+func (e *OrganizationDirectorySort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrganizationDirectorySort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrganizationDirectorySort", str)
+	}
+	return nil
+}
+
+func (e OrganizationDirectorySort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PresenceActivity string
+
+const (
+	PresenceActivityViewing PresenceActivity = "VIEWING"
+	PresenceActivityEditing PresenceActivity = "EDITING"
+)
+
+var AllPresenceActivity = []PresenceActivity{
+	PresenceActivityViewing,
+	PresenceActivityEditing,
+}
+
+func (e PresenceActivity) IsValid() bool {
+	switch e {
+	case PresenceActivityViewing, PresenceActivityEditing:
+		return true
+	}
+	return false
+}
+
+func (e PresenceActivity) String() string {
+	return string(e)
+}
+
+func (e *PresenceActivity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PresenceActivity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PresenceActivity", str)
+	}
+	return nil
+}
+
+func (e PresenceActivity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProjectExportStatus string
+
+const (
+	ProjectExportStatusPending   ProjectExportStatus = "PENDING"
+	ProjectExportStatusRunning   ProjectExportStatus = "RUNNING"
+	ProjectExportStatusCompleted ProjectExportStatus = "COMPLETED"
+	ProjectExportStatusFailed    ProjectExportStatus = "FAILED"
+)
+
+var AllProjectExportStatus = []ProjectExportStatus{
+	ProjectExportStatusPending,
+	ProjectExportStatusRunning,
+	ProjectExportStatusCompleted,
+	ProjectExportStatusFailed,
+}
+
+func (e ProjectExportStatus) IsValid() bool {
+	switch e {
+	case ProjectExportStatusPending, ProjectExportStatusRunning, ProjectExportStatusCompleted, ProjectExportStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e ProjectExportStatus) String() string {
+	return string(e)
+}
+
+func (e *ProjectExportStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProjectExportStatus(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid MetricMode", str)
+		return fmt.Errorf("%s is not a valid ProjectExportStatus", str)
 	}
 	return nil
 }
 
-func (e MetricMode) MarshalGQL(w io.Writer) {
+func (e ProjectExportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProjectHealthSignalKind string
+
+const (
+	// Share of open cards past their due date
+	ProjectHealthSignalKindOverdue ProjectHealthSignalKind = "OVERDUE"
+	// Share of active sprint cards added after the sprint started
+	ProjectHealthSignalKindScopeChurn ProjectHealthSignalKind = "SCOPE_CHURN"
+	// Mean days open cards have been blocked by open blockers
+	ProjectHealthSignalKindBlocked ProjectHealthSignalKind = "BLOCKED"
+	// Change in completed points between the earlier and recent half of the last closed sprints
+	ProjectHealthSignalKindVelocityTrend ProjectHealthSignalKind = "VELOCITY_TREND"
+)
+
+var AllProjectHealthSignalKind = []ProjectHealthSignalKind{
+	ProjectHealthSignalKindOverdue,
+	ProjectHealthSignalKindScopeChurn,
+	ProjectHealthSignalKindBlocked,
+	ProjectHealthSignalKindVelocityTrend,
+}
+
+func (e ProjectHealthSignalKind) IsValid() bool {
+	switch e {
+	case ProjectHealthSignalKindOverdue, ProjectHealthSignalKindScopeChurn, ProjectHealthSignalKindBlocked, ProjectHealthSignalKindVelocityTrend:
+		return true
+	}
+	return false
+}
+
+func (e ProjectHealthSignalKind) String() string {
+	return string(e)
+}
+
+func (e *ProjectHealthSignalKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProjectHealthSignalKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProjectHealthSignalKind", str)
+	}
+	return nil
+}
+
+func (e ProjectHealthSignalKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProjectHealthStatus string
+
+const (
+	ProjectHealthStatusOnTrack  ProjectHealthStatus = "ON_TRACK"
+	ProjectHealthStatusAtRisk   ProjectHealthStatus = "AT_RISK"
+	ProjectHealthStatusOffTrack ProjectHealthStatus = "OFF_TRACK"
+)
+
+var AllProjectHealthStatus = []ProjectHealthStatus{
+	ProjectHealthStatusOnTrack,
+	ProjectHealthStatusAtRisk,
+	ProjectHealthStatusOffTrack,
+}
+
+func (e ProjectHealthStatus) IsValid() bool {
+	switch e {
+	case ProjectHealthStatusOnTrack, ProjectHealthStatusAtRisk, ProjectHealthStatusOffTrack:
+		return true
+	}
+	return false
+}
+
+func (e ProjectHealthStatus) String() string {
+	return string(e)
+}
+
+func (e *ProjectHealthStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProjectHealthStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProjectHealthStatus", str)
+	}
+	return nil
+}
+
+func (e ProjectHealthStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
@@ -789,3 +4012,314 @@ func (e *SprintStatus) UnmarshalGQL(v interface{}) error {
 func (e SprintStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type SyncEntityType string
+
+const (
+	SyncEntityTypeBoard SyncEntityType = "BOARD"
+	SyncEntityTypeCard  SyncEntityType = "CARD"
+)
+
+var AllSyncEntityType = []SyncEntityType{
+	SyncEntityTypeBoard,
+	SyncEntityTypeCard,
+}
+
+func (e SyncEntityType) IsValid() bool {
+	switch e {
+	case SyncEntityTypeBoard, SyncEntityTypeCard:
+		return true
+	}
+	return false
+}
+
+func (e SyncEntityType) String() string {
+	return string(e)
+}
+
+func (e *SyncEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SyncEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SyncEntityType", str)
+	}
+	return nil
+}
+
+func (e SyncEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UndoOperationKind string
+
+const (
+	UndoOperationKindCompleteSprint UndoOperationKind = "COMPLETE_SPRINT"
+)
+
+var AllUndoOperationKind = []UndoOperationKind{
+	UndoOperationKindCompleteSprint,
+}
+
+func (e UndoOperationKind) IsValid() bool {
+	switch e {
+	case UndoOperationKindCompleteSprint:
+		return true
+	}
+	return false
+}
+
+func (e UndoOperationKind) String() string {
+	return string(e)
+}
+
+func (e *UndoOperationKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UndoOperationKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UndoOperationKind", str)
+	}
+	return nil
+}
+
+func (e UndoOperationKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UserMatchReason string
+
+const (
+	UserMatchReasonEmail          UserMatchReason = "EMAIL"
+	UserMatchReasonName           UserMatchReason = "NAME"
+	UserMatchReasonSimilarName    UserMatchReason = "SIMILAR_NAME"
+	UserMatchReasonUsername       UserMatchReason = "USERNAME"
+	UserMatchReasonEmailLocalPart UserMatchReason = "EMAIL_LOCAL_PART"
+)
+
+var AllUserMatchReason = []UserMatchReason{
+	UserMatchReasonEmail,
+	UserMatchReasonName,
+	UserMatchReasonSimilarName,
+	UserMatchReasonUsername,
+	UserMatchReasonEmailLocalPart,
+}
+
+func (e UserMatchReason) IsValid() bool {
+	switch e {
+	case UserMatchReasonEmail, UserMatchReasonName, UserMatchReasonSimilarName, UserMatchReasonUsername, UserMatchReasonEmailLocalPart:
+		return true
+	}
+	return false
+}
+
+func (e UserMatchReason) String() string {
+	return string(e)
+}
+
+func (e *UserMatchReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserMatchReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserMatchReason", str)
+	}
+	return nil
+}
+
+func (e UserMatchReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UserMatchStatus string
+
+const (
+	// Mapped to an existing user
+	UserMatchStatusMatched UserMatchStatus = "MATCHED"
+	// Awaiting someone picking the user, as no candidate was certain enough
+	UserMatchStatusPending UserMatchStatus = "PENDING"
+	// No existing user; the importer creates or invites one
+	UserMatchStatusNew UserMatchStatus = "NEW"
+)
+
+var AllUserMatchStatus = []UserMatchStatus{
+	UserMatchStatusMatched,
+	UserMatchStatusPending,
+	UserMatchStatusNew,
+}
+
+func (e UserMatchStatus) IsValid() bool {
+	switch e {
+	case UserMatchStatusMatched, UserMatchStatusPending, UserMatchStatusNew:
+		return true
+	}
+	return false
+}
+
+func (e UserMatchStatus) String() string {
+	return string(e)
+}
+
+func (e *UserMatchStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserMatchStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserMatchStatus", str)
+	}
+	return nil
+}
+
+func (e UserMatchStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type VelocityTrend string
+
+const (
+	VelocityTrendImproving VelocityTrend = "IMPROVING"
+	VelocityTrendStable    VelocityTrend = "STABLE"
+	VelocityTrendDeclining VelocityTrend = "DECLINING"
+)
+
+var AllVelocityTrend = []VelocityTrend{
+	VelocityTrendImproving,
+	VelocityTrendStable,
+	VelocityTrendDeclining,
+}
+
+func (e VelocityTrend) IsValid() bool {
+	switch e {
+	case VelocityTrendImproving, VelocityTrendStable, VelocityTrendDeclining:
+		return true
+	}
+	return false
+}
+
+func (e VelocityTrend) String() string {
+	return string(e)
+}
+
+func (e *VelocityTrend) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VelocityTrend(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VelocityTrend", str)
+	}
+	return nil
+}
+
+func (e VelocityTrend) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// Every attempt failed
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Weekday string
+
+const (
+	WeekdaySunday    Weekday = "SUNDAY"
+	WeekdayMonday    Weekday = "MONDAY"
+	WeekdayTuesday   Weekday = "TUESDAY"
+	WeekdayWednesday Weekday = "WEDNESDAY"
+	WeekdayThursday  Weekday = "THURSDAY"
+	WeekdayFriday    Weekday = "FRIDAY"
+	WeekdaySaturday  Weekday = "SATURDAY"
+)
+
+var AllWeekday = []Weekday{
+	WeekdaySunday,
+	WeekdayMonday,
+	WeekdayTuesday,
+	WeekdayWednesday,
+	WeekdayThursday,
+	WeekdayFriday,
+	WeekdaySaturday,
+}
+
+func (e Weekday) IsValid() bool {
+	switch e {
+	case WeekdaySunday, WeekdayMonday, WeekdayTuesday, WeekdayWednesday, WeekdayThursday, WeekdayFriday, WeekdaySaturday:
+		return true
+	}
+	return false
+}
+
+func (e Weekday) String() string {
+	return string(e)
+}
+
+func (e *Weekday) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Weekday(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Weekday", str)
+	}
+	return nil
+}
+
+func (e Weekday) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
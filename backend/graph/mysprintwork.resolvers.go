@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// MySprintWork is the resolver for the mySprintWork field.
+func (r *queryResolver) MySprintWork(ctx context.Context, boardID string) (*model.MySprintWork, error) {
+	return resolvers.MySprintWork(ctx, r.RBACService, r.SprintService, boardID)
+}
@@ -0,0 +1,62 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// CreateNotificationRule is the resolver for the createNotificationRule field.
+func (r *mutationResolver) CreateNotificationRule(ctx context.Context, input model.NotificationRuleInput) (*model.NotificationRule, error) {
+	return resolvers.CreateNotificationRule(ctx, r.RBACService, r.NotificationService, input)
+}
+
+// UpdateNotificationRule is the resolver for the updateNotificationRule field.
+func (r *mutationResolver) UpdateNotificationRule(ctx context.Context, id string, input model.NotificationRuleInput) (*model.NotificationRule, error) {
+	return resolvers.UpdateNotificationRule(ctx, r.RBACService, r.NotificationService, id, input)
+}
+
+// DeleteNotificationRule is the resolver for the deleteNotificationRule field.
+func (r *mutationResolver) DeleteNotificationRule(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteNotificationRule(ctx, r.NotificationService, id)
+}
+
+// TestNotificationRule is the resolver for the testNotificationRule field.
+func (r *mutationResolver) TestNotificationRule(ctx context.Context, id string) (bool, error) {
+	return resolvers.TestNotificationRule(ctx, r.NotificationService, id)
+}
+
+// UpdateProjectNotificationSettings is the resolver for the updateProjectNotificationSettings field.
+func (r *mutationResolver) UpdateProjectNotificationSettings(ctx context.Context, projectID string, settings []*model.NotificationChannelSettingInput) ([]*model.NotificationChannelSetting, error) {
+	return resolvers.UpdateProjectNotificationSettings(ctx, r.RBACService, r.NotificationService, projectID, settings)
+}
+
+// UpdateOrganizationNotificationSettings is the resolver for the updateOrganizationNotificationSettings field.
+func (r *mutationResolver) UpdateOrganizationNotificationSettings(ctx context.Context, organizationID string, settings []*model.NotificationChannelSettingInput) ([]*model.NotificationChannelSetting, error) {
+	return resolvers.UpdateOrganizationNotificationSettings(ctx, r.RBACService, r.NotificationService, organizationID, settings)
+}
+
+// SetBoardQuietMode is the resolver for the setBoardQuietMode field.
+func (r *mutationResolver) SetBoardQuietMode(ctx context.Context, boardID string, minutes *int) (*model.Board, error) {
+	return resolvers.SetBoardQuietMode(ctx, r.RBACService, r.NotificationService, boardID, minutes)
+}
+
+// MyNotificationRules is the resolver for the myNotificationRules field.
+func (r *queryResolver) MyNotificationRules(ctx context.Context) ([]*model.NotificationRule, error) {
+	return resolvers.MyNotificationRules(ctx, r.NotificationService)
+}
+
+// ProjectNotificationSettings is the resolver for the projectNotificationSettings field.
+func (r *queryResolver) ProjectNotificationSettings(ctx context.Context, projectID string) ([]*model.NotificationChannelSetting, error) {
+	return resolvers.ProjectNotificationSettings(ctx, r.RBACService, r.NotificationService, projectID)
+}
+
+// OrganizationNotificationSettings is the resolver for the organizationNotificationSettings field.
+func (r *queryResolver) OrganizationNotificationSettings(ctx context.Context, organizationID string) ([]*model.NotificationChannelSetting, error) {
+	return resolvers.OrganizationNotificationSettings(ctx, r.RBACService, r.NotificationService, organizationID)
+}
@@ -0,0 +1,42 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// MarkNotificationRead is the resolver for the markNotificationRead field.
+func (r *mutationResolver) MarkNotificationRead(ctx context.Context, id string) (*model.Notification, error) {
+	return resolvers.MarkNotificationRead(ctx, r.NotificationInboxService, r.CardService, r.CommentService, r.SprintService, r.InvitationService, r.UserService, id)
+}
+
+// MarkAllNotificationsRead is the resolver for the markAllNotificationsRead field.
+func (r *mutationResolver) MarkAllNotificationsRead(ctx context.Context) (int, error) {
+	return resolvers.MarkAllNotificationsRead(ctx, r.NotificationInboxService)
+}
+
+// SetNotificationPreference is the resolver for the setNotificationPreference field.
+func (r *mutationResolver) SetNotificationPreference(ctx context.Context, organizationID string, category model.NotificationCategory, inApp *bool, email *bool) (*model.NotificationPreference, error) {
+	return resolvers.SetNotificationPreference(ctx, r.RBACService, r.NotificationInboxService, organizationID, category, inApp, email)
+}
+
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, unreadOnly *bool, first *int) ([]*model.Notification, error) {
+	return resolvers.Notifications(ctx, r.NotificationInboxService, r.CardService, r.CommentService, r.SprintService, r.InvitationService, r.UserService, unreadOnly, first)
+}
+
+// NotificationPreferences is the resolver for the notificationPreferences field.
+func (r *queryResolver) NotificationPreferences(ctx context.Context, organizationID string) ([]*model.NotificationPreference, error) {
+	return resolvers.NotificationPreferences(ctx, r.RBACService, r.NotificationInboxService, organizationID)
+}
+
+// UnreadNotificationCount is the resolver for the unreadNotificationCount field.
+func (r *userResolver) UnreadNotificationCount(ctx context.Context, obj *model.User) (*int, error) {
+	return resolvers.UserUnreadNotificationCount(ctx, r.NotificationInboxService, obj)
+}
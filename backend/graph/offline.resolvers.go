@@ -0,0 +1,22 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SubmitOfflineMutations is the resolver for the submitOfflineMutations field.
+func (r *mutationResolver) SubmitOfflineMutations(ctx context.Context, mutations []*model.OfflineMutationInput) ([]*model.OfflineMutationResult, error) {
+	return resolvers.SubmitOfflineMutations(ctx, r.RBACService, r.OfflineService, r.Mutation(), mutations)
+}
+
+// BoardChanges is the resolver for the boardChanges field.
+func (r *queryResolver) BoardChanges(ctx context.Context, boardID string, cursor *string, limit *int) (*model.BoardChangeSet, error) {
+	return resolvers.BoardChanges(ctx, r.RBACService, r.OfflineService, boardID, cursor, limit)
+}
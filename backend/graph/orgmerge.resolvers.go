@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// MergeOrganizations is the resolver for the mergeOrganizations field.
+func (r *mutationResolver) MergeOrganizations(ctx context.Context, sourceID string, targetID string, dryRun bool) (*model.OrganizationMergeReport, error) {
+	return resolvers.MergeOrganizations(ctx, r.RBACService, r.OrgMergeService, r.LegalHoldService, r.UserService, sourceID, targetID, dryRun)
+}
@@ -0,0 +1,48 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// BoardHeartbeat is the resolver for the boardHeartbeat field.
+func (r *mutationResolver) BoardHeartbeat(ctx context.Context, boardID string, activity model.PresenceActivity) (bool, error) {
+	return resolvers.BoardHeartbeat(ctx, r.RBACService, r.PresenceService, boardID, activity)
+}
+
+// LeaveBoard is the resolver for the leaveBoard field.
+func (r *mutationResolver) LeaveBoard(ctx context.Context, boardID string) (bool, error) {
+	return resolvers.LeaveBoard(ctx, r.PresenceService, boardID)
+}
+
+// BroadcastCardDrag is the resolver for the broadcastCardDrag field.
+func (r *mutationResolver) BroadcastCardDrag(ctx context.Context, input model.CardDragInput) (bool, error) {
+	return resolvers.BroadcastCardDrag(ctx, r.RBACService, r.PresenceService, r.CardService, input)
+}
+
+// BoardViewers is the resolver for the boardViewers field.
+func (r *queryResolver) BoardViewers(ctx context.Context, boardID string) ([]*model.BoardViewer, error) {
+	return resolvers.BoardViewers(ctx, r.RBACService, r.PresenceService, r.UserService, boardID)
+}
+
+// BoardPresence is the resolver for the boardPresence field.
+func (r *subscriptionResolver) BoardPresence(ctx context.Context, boardID string) (<-chan []*model.BoardViewer, error) {
+	return resolvers.BoardPresence(ctx, r.RBACService, r.PresenceService, r.UserService, boardID)
+}
+
+// CardDragPreviews is the resolver for the cardDragPreviews field.
+func (r *subscriptionResolver) CardDragPreviews(ctx context.Context, boardID string) (<-chan *model.CardDragPreview, error) {
+	return resolvers.CardDragPreviews(ctx, r.RBACService, r.PresenceService, r.UserService, boardID)
+}
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,57 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// RequestProjectExport is the resolver for the requestProjectExport field.
+func (r *mutationResolver) RequestProjectExport(ctx context.Context, projectID string) (*model.ProjectExport, error) {
+	export, err := resolvers.RequestProjectExport(ctx, r.RBACService, r.ProjectExportService, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		pID, _ := uuid.Parse(projectID)
+		var orgID *uuid.UUID
+		if proj, err := r.ProjectService.GetProject(ctx, pID); err == nil {
+			orgID = &proj.OrganizationID
+		}
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionProjectExportRequested,
+			EntityType:     auditrepo.EntityProject,
+			EntityID:       pID,
+			OrganizationID: orgID,
+			ProjectID:      &pID,
+			Metadata: map[string]interface{}{
+				"export_id": export.ID,
+			},
+		})
+	}
+
+	return export, nil
+}
+
+// ProjectExport is the resolver for the projectExport field.
+func (r *queryResolver) ProjectExport(ctx context.Context, id string) (*model.ProjectExport, error) {
+	return resolvers.ProjectExport(ctx, r.RBACService, r.ProjectExportService, id)
+}
+
+// ProjectExports is the resolver for the projectExports field.
+func (r *queryResolver) ProjectExports(ctx context.Context, projectID string) ([]*model.ProjectExport, error) {
+	return resolvers.ProjectExports(ctx, r.RBACService, r.ProjectExportService, projectID)
+}
@@ -3,21 +3,74 @@ package graph
 import (
 	"github.com/thatcatdev/kaimu/backend/config"
 	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/activity"
+	"github.com/thatcatdev/kaimu/backend/internal/services/aggregate"
+	"github.com/thatcatdev/kaimu/backend/internal/services/anomaly"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	"github.com/thatcatdev/kaimu/backend/internal/services/appearance"
+	"github.com/thatcatdev/kaimu/backend/internal/services/archive"
+	"github.com/thatcatdev/kaimu/backend/internal/services/attachment"
 	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
+	"github.com/thatcatdev/kaimu/backend/internal/services/backup"
 	"github.com/thatcatdev/kaimu/backend/internal/services/board"
+	"github.com/thatcatdev/kaimu/backend/internal/services/boarddef"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	"github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	"github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/carddraft"
+	"github.com/thatcatdev/kaimu/backend/internal/services/cardimport"
+	"github.com/thatcatdev/kaimu/backend/internal/services/carryover"
+	"github.com/thatcatdev/kaimu/backend/internal/services/checklist"
+	"github.com/thatcatdev/kaimu/backend/internal/services/columnalert"
+	"github.com/thatcatdev/kaimu/backend/internal/services/comment"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/internal/services/demo"
+	"github.com/thatcatdev/kaimu/backend/internal/services/dependency"
 	"github.com/thatcatdev/kaimu/backend/internal/services/email"
+	"github.com/thatcatdev/kaimu/backend/internal/services/embed"
+	"github.com/thatcatdev/kaimu/backend/internal/services/epic"
+	"github.com/thatcatdev/kaimu/backend/internal/services/estimation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/export"
+	"github.com/thatcatdev/kaimu/backend/internal/services/freeze"
+	"github.com/thatcatdev/kaimu/backend/internal/services/health"
+	"github.com/thatcatdev/kaimu/backend/internal/services/instance"
 	"github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/jira"
+	"github.com/thatcatdev/kaimu/backend/internal/services/labelsuggest"
+	"github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/merge"
 	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mirror"
+	"github.com/thatcatdev/kaimu/backend/internal/services/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/services/notificationcenter"
+	"github.com/thatcatdev/kaimu/backend/internal/services/offline"
 	"github.com/thatcatdev/kaimu/backend/internal/services/oidc"
 	"github.com/thatcatdev/kaimu/backend/internal/services/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/services/orgmerge"
+	"github.com/thatcatdev/kaimu/backend/internal/services/people"
+	"github.com/thatcatdev/kaimu/backend/internal/services/permissionaudit"
+	"github.com/thatcatdev/kaimu/backend/internal/services/presence"
 	"github.com/thatcatdev/kaimu/backend/internal/services/project"
+	"github.com/thatcatdev/kaimu/backend/internal/services/projectexport"
 	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchanalytics"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchvocabulary"
+	"github.com/thatcatdev/kaimu/backend/internal/services/sla"
+	"github.com/thatcatdev/kaimu/backend/internal/services/snapshot"
+	"github.com/thatcatdev/kaimu/backend/internal/services/split"
 	"github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/services/sprintsummary"
 	"github.com/thatcatdev/kaimu/backend/internal/services/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/services/undo"
+	"github.com/thatcatdev/kaimu/backend/internal/services/unread"
 	"github.com/thatcatdev/kaimu/backend/internal/services/user"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	"github.com/thatcatdev/kaimu/backend/internal/services/watch"
+	"github.com/thatcatdev/kaimu/backend/internal/services/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/services/workflow"
 )
 
 // This file will not be regenerated automatically.
@@ -32,9 +85,18 @@ type Resolver struct {
 	AuditService             audit.Service
 	OIDCService              oidc.Service
 	OrganizationService      organization.Service
+	OrgMergeService          orgmerge.Service
 	ProjectService           project.Service
 	BoardService             board.Service
 	CardService              card.Service
+	ContentService           content.Service
+	CalendarService          calendar.Service
+	DependencyService        dependency.Service
+	EpicService              epic.Service
+	MirrorService            mirror.Service
+	SplitService             split.Service
+	LocaleService            locale.Service
+	WorkflowService          workflow.Service
 	TagService               tag.Service
 	RBACService              rbac.Service
 	InvitationService        invitation.Service
@@ -43,5 +105,49 @@ type Resolver struct {
 	SearchService            search.Service
 	SearchIndexer            *resolvers.SearchIndexer
 	SprintService            sprint.Service
+	UndoService              undo.Service
+	SLAService               sla.Service
+	NotificationService      notification.Service
+	PresenceService          presence.Service
+	OfflineService           offline.Service
 	MetricsService           metrics.Service
+	DemoService              demo.Service
+	UnreadService            unread.Service
+	WatchService             watch.Service
+	HealthService            health.Service
+	EstimationService        estimation.Service
+	CarryoverService         carryover.Service
+	AggregateService         aggregate.Service
+	EmbedService             embed.Service
+	APITokenService          apitoken.Service
+	UserMatchService         usermatch.Service
+	ArchiveService           archive.Service
+	MergeService             merge.Service
+	PermissionAuditService   permissionaudit.Service
+	AnomalyService           anomaly.Service
+	LegalHoldService         legalhold.Service
+	BackupService            backup.Service
+	SearchAnalyticsService   searchanalytics.Service
+	SearchVocabularyService  searchvocabulary.Service
+	CardDraftService         carddraft.Service
+	SprintSummaryService     sprintsummary.Service
+	LabelSuggestService      labelsuggest.Service
+	BoardDefinitionService   boarddef.Service
+	AppearanceService        appearance.Service
+	FreezeService            freeze.Service
+	ActivityService          activity.Service
+	CardImportService        cardimport.Service
+	PeopleService            people.Service
+	WebhookService           webhook.Service
+	CommentService           comment.Service
+	AttachmentService        attachment.Service
+	ColumnAlertService       columnalert.Service
+	ChecklistService         checklist.Service
+	JiraService              jira.Service
+	BrandingService          branding.Service
+	InstanceService          instance.Service
+	ExportService            export.Service
+	SnapshotService          snapshot.Service
+	ProjectExportService     projectexport.Service
+	NotificationInboxService notificationcenter.Service
 }
@@ -106,101 +106,37 @@ func (r *mutationResolver) UpdateOrganization(ctx context.Context, input model.U
 
 // DeleteOrganization is the resolver for the deleteOrganization field.
 func (r *mutationResolver) DeleteOrganization(ctx context.Context, id string) (bool, error) {
-	return resolvers.DeleteOrganization(ctx, r.OrganizationService, id)
+	return resolvers.DeleteOrganization(ctx, r.OrganizationService, r.LegalHoldService, id)
 }
 
 // CreateProject is the resolver for the createProject field.
 func (r *mutationResolver) CreateProject(ctx context.Context, input model.CreateProjectInput) (*model.Project, error) {
-	project, err := resolvers.CreateProject(ctx, r.RBACService, r.OrganizationService, r.ProjectService, r.BoardService, input)
-	if err != nil {
-		return nil, err
-	}
-
-	// Index for search
-	if r.SearchIndexer != nil {
-		projectID, _ := uuid.Parse(project.ID)
-		r.SearchIndexer.IndexProjectAsync(ctx, projectID)
-	}
-
-	return project, nil
+	return resolvers.CreateProject(ctx, r.RBACService, r.OrganizationService, r.ProjectService, input)
 }
 
 // UpdateProject is the resolver for the updateProject field.
 func (r *mutationResolver) UpdateProject(ctx context.Context, input model.UpdateProjectInput) (*model.Project, error) {
-	project, err := resolvers.UpdateProject(ctx, r.RBACService, r.ProjectService, input)
-	if err != nil {
-		return nil, err
-	}
-
-	// Index for search
-	if r.SearchIndexer != nil {
-		projectID, _ := uuid.Parse(project.ID)
-		r.SearchIndexer.IndexProjectAsync(ctx, projectID)
-	}
-
-	return project, nil
+	return resolvers.UpdateProject(ctx, r.RBACService, r.ProjectService, input)
 }
 
 // DeleteProject is the resolver for the deleteProject field.
 func (r *mutationResolver) DeleteProject(ctx context.Context, id string) (bool, error) {
-	result, err := resolvers.DeleteProject(ctx, r.RBACService, r.ProjectService, id)
-	if err != nil {
-		return false, err
-	}
-
-	// Remove from search index
-	if r.SearchIndexer != nil {
-		r.SearchIndexer.DeleteProjectAsync(ctx, id)
-	}
-
-	return result, nil
+	return resolvers.DeleteProject(ctx, r.RBACService, r.ProjectService, r.LegalHoldService, id)
 }
 
 // CreateBoard is the resolver for the createBoard field.
 func (r *mutationResolver) CreateBoard(ctx context.Context, input model.CreateBoardInput) (*model.Board, error) {
-	board, err := resolvers.CreateBoard(ctx, r.RBACService, r.BoardService, r.ProjectService, input)
-	if err != nil {
-		return nil, err
-	}
-
-	// Index for search
-	if r.SearchIndexer != nil {
-		boardID, _ := uuid.Parse(board.ID)
-		r.SearchIndexer.IndexBoardAsync(ctx, boardID)
-	}
-
-	return board, nil
+	return resolvers.CreateBoard(ctx, r.RBACService, r.BoardService, r.ProjectService, input)
 }
 
 // UpdateBoard is the resolver for the updateBoard field.
 func (r *mutationResolver) UpdateBoard(ctx context.Context, input model.UpdateBoardInput) (*model.Board, error) {
-	board, err := resolvers.UpdateBoard(ctx, r.RBACService, r.BoardService, input)
-	if err != nil {
-		return nil, err
-	}
-
-	// Index for search
-	if r.SearchIndexer != nil {
-		boardID, _ := uuid.Parse(board.ID)
-		r.SearchIndexer.IndexBoardAsync(ctx, boardID)
-	}
-
-	return board, nil
+	return resolvers.UpdateBoard(ctx, r.RBACService, r.BoardService, input)
 }
 
 // DeleteBoard is the resolver for the deleteBoard field.
 func (r *mutationResolver) DeleteBoard(ctx context.Context, id string) (bool, error) {
-	result, err := resolvers.DeleteBoard(ctx, r.RBACService, r.BoardService, id)
-	if err != nil {
-		return false, err
-	}
-
-	// Remove from search index
-	if r.SearchIndexer != nil {
-		r.SearchIndexer.DeleteBoardAsync(ctx, id)
-	}
-
-	return result, nil
+	return resolvers.DeleteBoard(ctx, r.RBACService, r.BoardService, r.LegalHoldService, id)
 }
 
 // CreateColumn is the resolver for the createColumn field.
@@ -210,7 +146,7 @@ func (r *mutationResolver) CreateColumn(ctx context.Context, input model.CreateC
 
 // UpdateColumn is the resolver for the updateColumn field.
 func (r *mutationResolver) UpdateColumn(ctx context.Context, input model.UpdateColumnInput) (*model.BoardColumn, error) {
-	return resolvers.UpdateColumn(ctx, r.RBACService, r.BoardService, input)
+	return resolvers.UpdateColumn(ctx, r.RBACService, r.BoardService, r.CardService, input)
 }
 
 // ReorderColumns is the resolver for the reorderColumns field.
@@ -225,7 +161,12 @@ func (r *mutationResolver) ToggleColumnVisibility(ctx context.Context, id string
 
 // DeleteColumn is the resolver for the deleteColumn field.
 func (r *mutationResolver) DeleteColumn(ctx context.Context, id string) (bool, error) {
-	return resolvers.DeleteColumn(ctx, r.RBACService, r.BoardService, id)
+	return resolvers.DeleteColumn(ctx, r.RBACService, r.BoardService, r.LegalHoldService, id)
+}
+
+// SetColumnTransitions is the resolver for the setColumnTransitions field.
+func (r *mutationResolver) SetColumnTransitions(ctx context.Context, boardID string, transitions []*model.ColumnTransitionInput) ([]*model.ColumnTransition, error) {
+	return resolvers.SetColumnTransitions(ctx, r.RBACService, r.BoardService, r.WorkflowService, boardID, transitions)
 }
 
 // CreateCard is the resolver for the createCard field.
@@ -235,12 +176,6 @@ func (r *mutationResolver) CreateCard(ctx context.Context, input model.CreateCar
 		return nil, err
 	}
 
-	// Index for search
-	if r.SearchIndexer != nil {
-		cardID, _ := uuid.Parse(card.ID)
-		r.SearchIndexer.IndexCardAsync(ctx, cardID)
-	}
-
 	// Audit logging
 	if r.AuditService != nil {
 		cardID, _ := uuid.Parse(card.ID)
@@ -276,14 +211,62 @@ func (r *mutationResolver) CreateCard(ctx context.Context, input model.CreateCar
 	return card, nil
 }
 
+// CreateCardsFromText is the resolver for the createCardsFromText field.
+func (r *mutationResolver) CreateCardsFromText(ctx context.Context, columnID string, text string) ([]*model.Card, error) {
+	cards, err := resolvers.CreateCardsFromText(ctx, r.RBACService, r.CardService, r.BoardService, columnID, text)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit events, one per created card
+	if r.AuditService != nil && len(cards) > 0 {
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		// All cards share a column, so the board and project are looked up once
+		colID, _ := uuid.Parse(columnID)
+		board, _ := r.BoardService.GetBoardByColumnID(ctx, colID)
+		var boardID, projectID, orgID *uuid.UUID
+		if board != nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		for _, card := range cards {
+			cardID, _ := uuid.Parse(card.ID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionCreated,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       cardID,
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				BoardID:        boardID,
+				StateAfter:     card,
+				Metadata: map[string]interface{}{
+					"column_id": columnID,
+					"title":     card.Title,
+					"source":    "text",
+				},
+			})
+		}
+	}
+
+	return cards, nil
+}
+
 // UpdateCard is the resolver for the updateCard field.
 func (r *mutationResolver) UpdateCard(ctx context.Context, input model.UpdateCardInput) (*model.Card, error) {
 	// Get card before update for audit
 	var cardBefore *model.Card
+	var assigneeBefore *uuid.UUID
 	if r.AuditService != nil {
 		cardID, _ := uuid.Parse(input.ID)
 		if existingCard, err := r.CardService.GetCard(ctx, cardID); err == nil {
 			cardBefore = resolvers.CardToModel(existingCard)
+			assigneeBefore = existingCard.AssigneeID
 		}
 	}
 
@@ -292,12 +275,6 @@ func (r *mutationResolver) UpdateCard(ctx context.Context, input model.UpdateCar
 		return nil, err
 	}
 
-	// Index for search
-	if r.SearchIndexer != nil {
-		cardID, _ := uuid.Parse(card.ID)
-		r.SearchIndexer.IndexCardAsync(ctx, cardID)
-	}
-
 	// Audit logging
 	if r.AuditService != nil {
 		cardID, _ := uuid.Parse(card.ID)
@@ -325,6 +302,50 @@ func (r *mutationResolver) UpdateCard(ctx context.Context, input model.UpdateCar
 			StateBefore:    cardBefore,
 			StateAfter:     card,
 		})
+
+		// Card snapshots leave out the assignee, so assignments are logged separately for
+		// the activity feeds
+		if cardBefore != nil && (input.AssigneeID != nil || (input.ClearAssignee != nil && *input.ClearAssignee)) {
+			if updated, err := r.CardService.GetCard(ctx, cardID); err == nil {
+				switch {
+				case updated.AssigneeID != nil && (assigneeBefore == nil || *assigneeBefore != *updated.AssigneeID):
+					metadata := map[string]interface{}{
+						"assignee_id": updated.AssigneeID.String(),
+					}
+					if assignee, err := r.UserService.GetByID(ctx, *updated.AssigneeID); err == nil {
+						metadata["assignee_name"] = assignee.Username
+						if assignee.DisplayName != nil {
+							metadata["assignee_name"] = *assignee.DisplayName
+						}
+					}
+					r.AuditService.LogEventAsync(ctx, audit.EventInput{
+						ActorID:        userID,
+						Action:         auditrepo.ActionCardAssigned,
+						EntityType:     auditrepo.EntityCard,
+						EntityID:       cardID,
+						OrganizationID: orgID,
+						ProjectID:      projectID,
+						BoardID:        boardID,
+						StateAfter:     card,
+						Metadata:       metadata,
+					})
+				case updated.AssigneeID == nil && assigneeBefore != nil:
+					r.AuditService.LogEventAsync(ctx, audit.EventInput{
+						ActorID:        userID,
+						Action:         auditrepo.ActionCardUnassigned,
+						EntityType:     auditrepo.EntityCard,
+						EntityID:       cardID,
+						OrganizationID: orgID,
+						ProjectID:      projectID,
+						BoardID:        boardID,
+						StateAfter:     card,
+						Metadata: map[string]interface{}{
+							"previous_assignee_id": assigneeBefore.String(),
+						},
+					})
+				}
+			}
+		}
 	}
 
 	return card, nil
@@ -348,17 +369,11 @@ func (r *mutationResolver) MoveCard(ctx context.Context, input model.MoveCardInp
 		}
 	}
 
-	card, err := resolvers.MoveCard(ctx, r.RBACService, r.CardService, r.BoardService, input)
+	card, override, err := resolvers.MoveCard(ctx, r.RBACService, r.CardService, r.BoardService, r.FreezeService, r.DependencyService, input)
 	if err != nil {
 		return nil, err
 	}
 
-	// Index for search
-	if r.SearchIndexer != nil {
-		cardID, _ := uuid.Parse(card.ID)
-		r.SearchIndexer.IndexCardAsync(ctx, cardID)
-	}
-
 	// Audit logging
 	if r.AuditService != nil {
 		cardID, _ := uuid.Parse(card.ID)
@@ -390,6 +405,28 @@ func (r *mutationResolver) MoveCard(ctx context.Context, input model.MoveCardInp
 			metadata["from_column_id"] = fromColumnID.String()
 			metadata["from_column_name"] = fromColumnName
 		}
+		if override != nil {
+			metadata["freeze_window_id"] = override.Window.ID.String()
+			metadata["freeze_override_reason"] = override.Reason
+
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionFreezeOverridden,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       cardID,
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				BoardID:        boardID,
+				Metadata: map[string]interface{}{
+					"freeze_window_id":   override.Window.ID.String(),
+					"freeze_window_name": override.Window.Name,
+					"freeze_window_ends": override.Window.EndsAt,
+					"reason":             override.Reason,
+					"to_column_id":       targetColID.String(),
+					"to_column_name":     toColumnName,
+				},
+			})
+		}
 
 		r.AuditService.LogEventAsync(ctx, audit.EventInput{
 			ActorID:        userID,
@@ -428,16 +465,11 @@ func (r *mutationResolver) DeleteCard(ctx context.Context, id string) (bool, err
 		}
 	}
 
-	result, err := resolvers.DeleteCard(ctx, r.RBACService, r.CardService, r.BoardService, id)
+	result, err := resolvers.DeleteCard(ctx, r.RBACService, r.CardService, r.BoardService, r.LegalHoldService, id)
 	if err != nil {
 		return false, err
 	}
 
-	// Remove from search index
-	if r.SearchIndexer != nil {
-		r.SearchIndexer.DeleteCardAsync(ctx, id)
-	}
-
 	// Audit logging
 	if r.AuditService != nil {
 		userID := middleware.GetUserIDFromContext(ctx)
@@ -469,7 +501,7 @@ func (r *mutationResolver) UpdateTag(ctx context.Context, input model.UpdateTagI
 
 // DeleteTag is the resolver for the deleteTag field.
 func (r *mutationResolver) DeleteTag(ctx context.Context, id string) (bool, error) {
-	return resolvers.DeleteTag(ctx, r.OrganizationService, r.TagService, id)
+	return resolvers.DeleteTag(ctx, r.OrganizationService, r.TagService, r.LegalHoldService, id)
 }
 
 // CreateRole is the resolver for the createRole field.
@@ -615,7 +647,7 @@ func (r *mutationResolver) DeleteSprint(ctx context.Context, id string) (bool, e
 		}
 	}
 
-	result, err := resolvers.DeleteSprint(ctx, r.RBACService, r.SprintService, id)
+	result, err := resolvers.DeleteSprint(ctx, r.RBACService, r.SprintService, r.LegalHoldService, id)
 	if err != nil {
 		return false, err
 	}
@@ -920,12 +952,12 @@ func (r *queryResolver) OidcProviders(ctx context.Context) ([]*model.OIDCProvide
 
 // Organizations is the resolver for the organizations field.
 func (r *queryResolver) Organizations(ctx context.Context) ([]*model.Organization, error) {
-	return resolvers.Organizations(ctx, r.OrganizationService, r.ProjectService, r.BoardService)
+	return resolvers.Organizations(ctx, r.OrganizationService, r.ProjectService, r.BoardService, r.RBACService)
 }
 
 // Organization is the resolver for the organization field.
 func (r *queryResolver) Organization(ctx context.Context, id string) (*model.Organization, error) {
-	return resolvers.Organization(ctx, r.OrganizationService, r.ProjectService, id)
+	return resolvers.Organization(ctx, r.OrganizationService, r.ProjectService, r.RBACService, id)
 }
 
 // Project is the resolver for the project field.
@@ -948,6 +980,11 @@ func (r *queryResolver) Card(ctx context.Context, id string) (*model.Card, error
 	return resolvers.Card(ctx, r.RBACService, r.CardService, r.BoardService, id)
 }
 
+// CardByKey is the resolver for the cardByKey field.
+func (r *queryResolver) CardByKey(ctx context.Context, key string, organizationID *string) (*model.Card, error) {
+	return resolvers.CardByKey(ctx, r.RBACService, r.OrganizationService, r.ProjectService, r.CardService, key, organizationID)
+}
+
 // MyCards is the resolver for the myCards field.
 func (r *queryResolver) MyCards(ctx context.Context) ([]*model.Card, error) {
 	return resolvers.MyCards(ctx, r.CardService)
@@ -978,14 +1015,19 @@ func (r *queryResolver) OrganizationMembers(ctx context.Context, organizationID
 	return resolvers.GetOrganizationMembersRBAC(ctx, r.RBACService, organizationID)
 }
 
+// OrganizationGuests is the resolver for the organizationGuests field.
+func (r *queryResolver) OrganizationGuests(ctx context.Context, organizationID string) ([]*model.OrganizationMember, error) {
+	return resolvers.OrganizationGuests(ctx, r.RBACService, organizationID)
+}
+
 // ProjectMembers is the resolver for the projectMembers field.
 func (r *queryResolver) ProjectMembers(ctx context.Context, projectID string) ([]*model.ProjectMember, error) {
 	return resolvers.ProjectMembers(ctx, r.RBACService, projectID)
 }
 
 // Invitations is the resolver for the invitations field.
-func (r *queryResolver) Invitations(ctx context.Context, organizationID string) ([]*model.Invitation, error) {
-	return resolvers.Invitations(ctx, r.InvitationService, r.RBACService, organizationID)
+func (r *queryResolver) Invitations(ctx context.Context, organizationID string, status *model.InvitationStatus) ([]*model.Invitation, error) {
+	return resolvers.Invitations(ctx, r.InvitationService, r.RBACService, organizationID, status)
 }
 
 // HasPermission is the resolver for the hasPermission field.
@@ -1003,7 +1045,7 @@ func (r *queryResolver) Search(ctx context.Context, query string, scope *model.S
 	if r.SearchService == nil {
 		return nil, errors.New("search service is not configured")
 	}
-	return resolvers.Search(ctx, r.SearchService, query, scope, limit)
+	return resolvers.Search(ctx, r.SearchService, r.SearchAnalyticsService, query, scope, limit)
 }
 
 // Sprint is the resolver for the sprint field.
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/internal/schemacompat"
+)
+
+// schemaSnapshotPath holds the last released schema. Regenerate it with
+// UPDATE_SCHEMA_SNAPSHOT=1 go test ./graph -run TestSchemaCompatibility
+var schemaSnapshotPath = filepath.Join("testdata", "schema.snapshot.graphql")
+
+func TestSchemaCompatibility(t *testing.T) {
+	current := schemacompat.Print(generated.NewExecutableSchema(generated.Config{}).Schema())
+
+	if os.Getenv("UPDATE_SCHEMA_SNAPSHOT") != "" {
+		snapshot, err := os.ReadFile(schemaSnapshotPath)
+		if err == nil {
+			assertNoBreakingChanges(t, string(snapshot), current)
+		}
+		if err := os.WriteFile(schemaSnapshotPath, []byte(current), 0o644); err != nil {
+			t.Fatalf("failed to write schema snapshot: %v", err)
+		}
+		return
+	}
+
+	snapshot, err := os.ReadFile(schemaSnapshotPath)
+	if err != nil {
+		t.Fatalf("failed to read schema snapshot %s: %v", schemaSnapshotPath, err)
+	}
+
+	assertNoBreakingChanges(t, string(snapshot), current)
+
+	if string(snapshot) != current {
+		t.Errorf("schema snapshot is out of date; run UPDATE_SCHEMA_SNAPSHOT=1 go test ./graph -run TestSchemaCompatibility")
+	}
+}
+
+func assertNoBreakingChanges(t *testing.T, snapshot, current string) {
+	t.Helper()
+
+	prev, err := schemacompat.Parse(schemaSnapshotPath, snapshot)
+	if err != nil {
+		t.Fatalf("failed to parse schema snapshot: %v", err)
+	}
+	next, err := schemacompat.Parse("current", current)
+	if err != nil {
+		t.Fatalf("failed to parse current schema: %v", err)
+	}
+
+	for _, change := range schemacompat.Check(prev, next) {
+		t.Errorf("breaking schema change: %s", change)
+	}
+}
@@ -0,0 +1,48 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// SetSearchAnalyticsAnonymized is the resolver for the setSearchAnalyticsAnonymized field.
+func (r *mutationResolver) SetSearchAnalyticsAnonymized(ctx context.Context, organizationID string, anonymized bool) (*model.Organization, error) {
+	org, err := resolvers.SetSearchAnalyticsAnonymized(ctx, r.RBACService, r.SearchAnalyticsService, organizationID, anonymized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		orgID, _ := uuid.Parse(organizationID)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"search_analytics_anonymized": anonymized,
+			},
+		})
+	}
+
+	return org, nil
+}
+
+// SearchAnalytics is the resolver for the searchAnalytics field.
+func (r *queryResolver) SearchAnalytics(ctx context.Context, organizationID string, since *time.Time) (*model.SearchAnalytics, error) {
+	return resolvers.SearchAnalytics(ctx, r.RBACService, r.SearchAnalyticsService, organizationID, since)
+}
@@ -0,0 +1,133 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// CreateSearchSynonymSet is the resolver for the createSearchSynonymSet field.
+func (r *mutationResolver) CreateSearchSynonymSet(ctx context.Context, organizationID string, words []string) (*model.SearchSynonymSet, error) {
+	set, err := resolvers.CreateSearchSynonymSet(ctx, r.RBACService, r.SearchVocabularyService, organizationID, words)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		orgID, _ := uuid.Parse(set.OrganizationID)
+		userID := middleware.GetUserIDFromContext(ctx)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"search_synonym_set_created": set.ID,
+				"words":                      set.Words,
+			},
+		})
+	}
+
+	return set, nil
+}
+
+// UpdateSearchSynonymSet is the resolver for the updateSearchSynonymSet field.
+func (r *mutationResolver) UpdateSearchSynonymSet(ctx context.Context, id string, words []string) (*model.SearchSynonymSet, error) {
+	set, err := resolvers.UpdateSearchSynonymSet(ctx, r.RBACService, r.SearchVocabularyService, id, words)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		orgID, _ := uuid.Parse(set.OrganizationID)
+		userID := middleware.GetUserIDFromContext(ctx)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"search_synonym_set_updated": set.ID,
+				"words":                      set.Words,
+			},
+		})
+	}
+
+	return set, nil
+}
+
+// DeleteSearchSynonymSet is the resolver for the deleteSearchSynonymSet field.
+func (r *mutationResolver) DeleteSearchSynonymSet(ctx context.Context, id string) (bool, error) {
+	set, err := resolvers.DeleteSearchSynonymSet(ctx, r.RBACService, r.SearchVocabularyService, id)
+	if err != nil {
+		return false, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		orgID, _ := uuid.Parse(set.OrganizationID)
+		userID := middleware.GetUserIDFromContext(ctx)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"search_synonym_set_deleted": set.ID,
+				"words":                      set.Words,
+			},
+		})
+	}
+
+	return true, nil
+}
+
+// SetSearchStopWords is the resolver for the setSearchStopWords field.
+func (r *mutationResolver) SetSearchStopWords(ctx context.Context, organizationID string, words []string) ([]string, error) {
+	stopWords, err := resolvers.SetSearchStopWords(ctx, r.RBACService, r.SearchVocabularyService, organizationID, words)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		orgID, _ := uuid.Parse(organizationID)
+		userID := middleware.GetUserIDFromContext(ctx)
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntityOrganization,
+			EntityID:       orgID,
+			OrganizationID: &orgID,
+			Metadata: map[string]interface{}{
+				"search_stop_words": stopWords,
+			},
+		})
+	}
+
+	return stopWords, nil
+}
+
+// SearchSynonymSets is the resolver for the searchSynonymSets field.
+func (r *queryResolver) SearchSynonymSets(ctx context.Context, organizationID string) ([]*model.SearchSynonymSet, error) {
+	return resolvers.SearchSynonymSets(ctx, r.RBACService, r.SearchVocabularyService, organizationID)
+}
+
+// SearchStopWords is the resolver for the searchStopWords field.
+func (r *queryResolver) SearchStopWords(ctx context.Context, organizationID string) ([]string, error) {
+	return resolvers.SearchStopWords(ctx, r.RBACService, r.SearchVocabularyService, organizationID)
+}
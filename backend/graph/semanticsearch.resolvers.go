@@ -0,0 +1,21 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SearchSemantic is the resolver for the searchSemantic field.
+func (r *queryResolver) SearchSemantic(ctx context.Context, query string, scope *model.SearchScope, limit *int) (*model.SearchResults, error) {
+	if r.SearchService == nil {
+		return nil, errors.New("search service is not configured")
+	}
+	return resolvers.SearchSemantic(ctx, r.SearchService, r.SearchAnalyticsService, query, scope, limit)
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// fakeCardService returns one card on one board
+type fakeCardService struct {
+	cardService.Service
+	card  *card.Card
+	board *board.Board
+}
+
+func (f *fakeCardService) GetCard(ctx context.Context, id uuid.UUID) (*card.Card, error) {
+	return f.card, nil
+}
+
+func (f *fakeCardService) GetBoardByCardID(ctx context.Context, cardID uuid.UUID) (*board.Board, error) {
+	return f.board, nil
+}
+
+// fakeBoardService returns one project for every board
+type fakeBoardService struct {
+	boardService.Service
+	project *project.Project
+}
+
+func (f *fakeBoardService) GetProject(ctx context.Context, boardID uuid.UUID) (*project.Project, error) {
+	return f.project, nil
+}
+
+// fakeUserService returns the users it holds
+type fakeUserService struct {
+	userService.Service
+	users map[uuid.UUID]*user.User
+}
+
+func (f *fakeUserService) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	return f.users[id], nil
+}
+
+// fakeRBACService lets everyone view cards; admins can manage every user
+type fakeRBACService struct {
+	rbacService.Service
+	admins map[uuid.UUID]bool
+}
+
+func (f *fakeRBACService) HasProjectPermission(ctx context.Context, userID, projectID uuid.UUID, permission string) (bool, error) {
+	return permission == "card:view", nil
+}
+
+func (f *fakeRBACService) CanManageUser(ctx context.Context, viewerID, userID uuid.UUID) (bool, error) {
+	return f.admins[viewerID], nil
+}
+
+// TestSensitiveFieldsThroughNestedPaths checks that @sensitive fields stay hidden when
+// reached through another object rather than queried directly
+func TestSensitiveFieldsThroughNestedPaths(t *testing.T) {
+	assigneeID := uuid.New()
+	memberID := uuid.New()
+	adminID := uuid.New()
+	email := "assignee@example.com"
+
+	proj := &project.Project{ID: uuid.New(), OrganizationID: uuid.New()}
+	b := &board.Board{ID: uuid.New(), ProjectID: proj.ID}
+	c := &card.Card{ID: uuid.New(), BoardID: b.ID, Title: "Fix login", AssigneeID: &assigneeID}
+	rbacSvc := &fakeRBACService{admins: map[uuid.UUID]bool{adminID: true}}
+
+	resolver := &Resolver{
+		CardService:  &fakeCardService{card: c, board: b},
+		BoardService: &fakeBoardService{project: proj},
+		UserService: &fakeUserService{users: map[uuid.UUID]*user.User{
+			assigneeID: {ID: assigneeID, Username: "assignee", Email: &email},
+		}},
+		RBACService: rbacSvc,
+	}
+	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{
+		Resolvers:  resolver,
+		Directives: directives.GetDirectives(rbacSvc, nil),
+	}))
+
+	query := fmt.Sprintf(`{ card(id: %q) { assignee { username email } } }`, c.ID)
+	var resp struct {
+		Card struct {
+			Assignee struct {
+				Username string
+				Email    *string
+			}
+		}
+	}
+
+	tests := []struct {
+		name      string
+		viewer    uuid.UUID
+		wantEmail *string
+	}{
+		{name: "other members", viewer: memberID, wantEmail: nil},
+		{name: "organization admins", viewer: adminID, wantEmail: &email},
+		{name: "the assignee", viewer: assigneeID, wantEmail: &email},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewer := tt.viewer
+			gql := client.New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				srv.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, viewer)))
+			}))
+
+			require.NoError(t, gql.Post(query, &resp))
+			assert.Equal(t, "assignee", resp.Card.Assignee.Username)
+			assert.Equal(t, tt.wantEmail, resp.Card.Assignee.Email)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// CreateSLAPolicy is the resolver for the createSLAPolicy field.
+func (r *mutationResolver) CreateSLAPolicy(ctx context.Context, projectID string, input model.SLAPolicyInput) (*model.SLAPolicy, error) {
+	return resolvers.CreateSLAPolicy(ctx, r.RBACService, r.SLAService, projectID, input)
+}
+
+// UpdateSLAPolicy is the resolver for the updateSLAPolicy field.
+func (r *mutationResolver) UpdateSLAPolicy(ctx context.Context, id string, input model.SLAPolicyInput) (*model.SLAPolicy, error) {
+	return resolvers.UpdateSLAPolicy(ctx, r.RBACService, r.SLAService, id, input)
+}
+
+// DeleteSLAPolicy is the resolver for the deleteSLAPolicy field.
+func (r *mutationResolver) DeleteSLAPolicy(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteSLAPolicy(ctx, r.RBACService, r.SLAService, id)
+}
+
+// SLAPolicies is the resolver for the slaPolicies field.
+func (r *queryResolver) SLAPolicies(ctx context.Context, projectID string) ([]*model.SLAPolicy, error) {
+	return resolvers.SLAPolicies(ctx, r.RBACService, r.SLAService, projectID)
+}
+
+// SLAReport is the resolver for the slaReport field.
+func (r *queryResolver) SLAReport(ctx context.Context, sprintID string) (*model.SLAReport, error) {
+	return resolvers.SLAReport(ctx, r.RBACService, r.SprintService, r.SLAService, sprintID)
+}
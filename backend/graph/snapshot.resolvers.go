@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// GenerateBoardSnapshotImage is the resolver for the generateBoardSnapshotImage field.
+func (r *mutationResolver) GenerateBoardSnapshotImage(ctx context.Context, boardID string, format *model.BoardSnapshotFormat) (*model.BoardSnapshotImage, error) {
+	return resolvers.GenerateBoardSnapshotImage(ctx, r.RBACService, r.SnapshotService, boardID, format)
+}
@@ -0,0 +1,76 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// SplitCard is the resolver for the splitCard field.
+func (r *mutationResolver) SplitCard(ctx context.Context, cardID string, titles []string, options *model.SplitCardOptions) (*model.SplitCardResult, error) {
+	result, err := resolvers.SplitCard(ctx, r.RBACService, r.CardService, r.SplitService, cardID, titles, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging: the split on the card, and a creation per new card
+	if r.AuditService != nil {
+		userID := middleware.GetUserIDFromContext(ctx)
+		originalID, _ := uuid.Parse(result.Card.ID)
+
+		var boardID, projectID, orgID *uuid.UUID
+		if board, err := r.CardService.GetBoardByCardID(ctx, originalID); err == nil {
+			boardID = &board.ID
+			if proj, err := r.BoardService.GetProject(ctx, board.ID); err == nil {
+				projectID = &proj.ID
+				orgID = &proj.OrganizationID
+			}
+		}
+
+		newCardIDs := make([]string, len(result.Cards))
+		for i, card := range result.Cards {
+			newCardIDs[i] = card.ID
+			newCardID, _ := uuid.Parse(card.ID)
+			r.AuditService.LogEventAsync(ctx, audit.EventInput{
+				ActorID:        userID,
+				Action:         auditrepo.ActionCreated,
+				EntityType:     auditrepo.EntityCard,
+				EntityID:       newCardID,
+				OrganizationID: orgID,
+				ProjectID:      projectID,
+				BoardID:        boardID,
+				StateAfter:     card,
+				Metadata: map[string]interface{}{
+					"title":      card.Title,
+					"split_from": result.Card.ID,
+				},
+			})
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionCardSplit,
+			EntityType:     auditrepo.EntityCard,
+			EntityID:       originalID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        boardID,
+			StateAfter:     result.Card,
+			Metadata: map[string]interface{}{
+				"title":    result.Card.Title,
+				"card_ids": newCardIDs,
+			},
+		})
+	}
+
+	return result, nil
+}
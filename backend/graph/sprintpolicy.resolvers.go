@@ -0,0 +1,17 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// SetBoardSprintAutoMembership is the resolver for the setBoardSprintAutoMembership field.
+func (r *mutationResolver) SetBoardSprintAutoMembership(ctx context.Context, boardID string, enabled bool) (*model.Board, error) {
+	return resolvers.SetBoardSprintAutoMembership(ctx, r.RBACService, r.SprintService, boardID, enabled)
+}
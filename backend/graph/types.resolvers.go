@@ -32,16 +32,31 @@ func (r *boardResolver) ActiveSprint(ctx context.Context, obj *model.Board) (*mo
 	return resolvers.BoardActiveSprint(ctx, r.SprintService, obj)
 }
 
+// ColumnTransitions is the resolver for the columnTransitions field.
+func (r *boardResolver) ColumnTransitions(ctx context.Context, obj *model.Board) ([]*model.ColumnTransition, error) {
+	return resolvers.BoardColumnTransitions(ctx, r.WorkflowService, obj)
+}
+
 // Board is the resolver for the board field.
 func (r *boardColumnResolver) Board(ctx context.Context, obj *model.BoardColumn) (*model.Board, error) {
 	return resolvers.ColumnBoard(ctx, r.BoardService, obj)
 }
 
+// PointsInColumn is the resolver for the pointsInColumn field.
+func (r *boardColumnResolver) PointsInColumn(ctx context.Context, obj *model.BoardColumn) (int, error) {
+	return resolvers.ColumnPointsInColumn(ctx, r.CardService, obj)
+}
+
 // Cards is the resolver for the cards field.
 func (r *boardColumnResolver) Cards(ctx context.Context, obj *model.BoardColumn) ([]*model.Card, error) {
 	return resolvers.ColumnCards(ctx, r.CardService, obj)
 }
 
+// Key is the resolver for the key field.
+func (r *cardResolver) Key(ctx context.Context, obj *model.Card) (string, error) {
+	return resolvers.CardKey(ctx, r.CardService, r.BoardService, obj)
+}
+
 // Column is the resolver for the column field.
 func (r *cardResolver) Column(ctx context.Context, obj *model.Card) (*model.BoardColumn, error) {
 	return resolvers.CardColumn(ctx, r.CardService, obj)
@@ -87,6 +102,16 @@ func (r *invitationResolver) InvitedBy(ctx context.Context, obj *model.Invitatio
 	return resolvers.InvitationInvitedBy(ctx, r.InvitationService, obj)
 }
 
+// Project is the resolver for the project field.
+func (r *invitationResolver) Project(ctx context.Context, obj *model.Invitation) (*model.Project, error) {
+	return resolvers.InvitationProject(ctx, r.InvitationService, obj)
+}
+
+// ProjectRole is the resolver for the projectRole field.
+func (r *invitationResolver) ProjectRole(ctx context.Context, obj *model.Invitation) (*model.Role, error) {
+	return resolvers.InvitationProjectRole(ctx, r.InvitationService, obj)
+}
+
 // User is the resolver for the user field.
 func (r *organizationMemberResolver) User(ctx context.Context, obj *model.OrganizationMember) (*model.User, error) {
 	return resolvers.OrgMemberUser(ctx, r.RBACService, obj)
@@ -184,6 +209,9 @@ func (r *Resolver) Sprint() generated.SprintResolver { return &sprintResolver{r}
 // Tag returns generated.TagResolver implementation.
 func (r *Resolver) Tag() generated.TagResolver { return &tagResolver{r} }
 
+// User returns generated.UserResolver implementation.
+func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
+
 type boardResolver struct{ *Resolver }
 type boardColumnResolver struct{ *Resolver }
 type cardResolver struct{ *Resolver }
@@ -194,3 +222,4 @@ type projectMemberResolver struct{ *Resolver }
 type roleResolver struct{ *Resolver }
 type sprintResolver struct{ *Resolver }
 type tagResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
@@ -0,0 +1,58 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+)
+
+// UndoOperation is the resolver for the undoOperation field.
+func (r *mutationResolver) UndoOperation(ctx context.Context, operationID string) (*model.UndoableOperation, error) {
+	op, err := resolvers.UndoOperation(ctx, r.RBACService, r.UndoService, r.UserService, operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Audit logging
+	if r.AuditService != nil {
+		boardID, _ := uuid.Parse(op.BoardID)
+		entityID, _ := uuid.Parse(op.EntityID)
+		userID := middleware.GetUserIDFromContext(ctx)
+
+		var projectID, orgID *uuid.UUID
+		if proj, err := r.BoardService.GetProject(ctx, boardID); err == nil {
+			projectID = &proj.ID
+			orgID = &proj.OrganizationID
+		}
+
+		r.AuditService.LogEventAsync(ctx, audit.EventInput{
+			ActorID:        userID,
+			Action:         auditrepo.ActionUpdated,
+			EntityType:     auditrepo.EntitySprint,
+			EntityID:       entityID,
+			OrganizationID: orgID,
+			ProjectID:      projectID,
+			BoardID:        &boardID,
+			Metadata: map[string]interface{}{
+				"undo_operation_id": op.ID,
+				"undone_operation":  op.Kind,
+			},
+		})
+	}
+
+	return op, nil
+}
+
+// UndoableOperations is the resolver for the undoableOperations field.
+func (r *queryResolver) UndoableOperations(ctx context.Context, boardID string) ([]*model.UndoableOperation, error) {
+	return resolvers.UndoableOperations(ctx, r.RBACService, r.UndoService, r.UserService, boardID)
+}
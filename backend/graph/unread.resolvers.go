@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// UnreadCount is the resolver for the unreadCount field.
+func (r *boardResolver) UnreadCount(ctx context.Context, obj *model.Board) (int, error) {
+	return resolvers.BoardUnreadCount(ctx, r.UnreadService, obj)
+}
+
+// HasUnreadActivity is the resolver for the hasUnreadActivity field.
+func (r *cardResolver) HasUnreadActivity(ctx context.Context, obj *model.Card) (bool, error) {
+	return resolvers.CardHasUnreadActivity(ctx, r.UnreadService, obj)
+}
+
+// MarkCardViewed is the resolver for the markCardViewed field.
+func (r *mutationResolver) MarkCardViewed(ctx context.Context, cardID string) (*model.Card, error) {
+	return resolvers.MarkCardViewed(ctx, r.RBACService, r.CardService, r.UnreadService, cardID)
+}
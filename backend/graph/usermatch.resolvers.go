@@ -0,0 +1,49 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// MatchExternalUsers is the resolver for the matchExternalUsers field.
+func (r *mutationResolver) MatchExternalUsers(ctx context.Context, organizationID string, source string, users []*model.ExternalUserInput) ([]*model.UserMatch, error) {
+	return resolvers.MatchExternalUsers(ctx, r.RBACService, r.UserMatchService, organizationID, source, users)
+}
+
+// ResolveUserMatch is the resolver for the resolveUserMatch field.
+func (r *mutationResolver) ResolveUserMatch(ctx context.Context, id string, userID *string) (*model.UserMatch, error) {
+	return resolvers.ResolveUserMatch(ctx, r.RBACService, r.UserMatchService, id, userID)
+}
+
+// UserMatchQueue is the resolver for the userMatchQueue field.
+func (r *queryResolver) UserMatchQueue(ctx context.Context, organizationID string) ([]*model.UserMatch, error) {
+	return resolvers.UserMatchQueue(ctx, r.RBACService, r.UserMatchService, organizationID)
+}
+
+// User is the resolver for the user field.
+func (r *userMatchResolver) User(ctx context.Context, obj *model.UserMatch) (*model.User, error) {
+	return resolvers.UserMatchUser(ctx, r.UserService, obj)
+}
+
+// User is the resolver for the user field.
+func (r *userMatchCandidateResolver) User(ctx context.Context, obj *model.UserMatchCandidate) (*model.User, error) {
+	return resolvers.UserMatchCandidateUser(ctx, r.UserService, obj)
+}
+
+// UserMatch returns generated.UserMatchResolver implementation.
+func (r *Resolver) UserMatch() generated.UserMatchResolver { return &userMatchResolver{r} }
+
+// UserMatchCandidate returns generated.UserMatchCandidateResolver implementation.
+func (r *Resolver) UserMatchCandidate() generated.UserMatchCandidateResolver {
+	return &userMatchCandidateResolver{r}
+}
+
+type userMatchResolver struct{ *Resolver }
+type userMatchCandidateResolver struct{ *Resolver }
@@ -0,0 +1,32 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// WatcherCount is the resolver for the watcherCount field.
+func (r *boardColumnResolver) WatcherCount(ctx context.Context, obj *model.BoardColumn) (int, error) {
+	return resolvers.ColumnWatcherCount(ctx, r.WatchService, obj)
+}
+
+// IsWatching is the resolver for the isWatching field.
+func (r *boardColumnResolver) IsWatching(ctx context.Context, obj *model.BoardColumn) (bool, error) {
+	return resolvers.ColumnIsWatching(ctx, r.WatchService, obj)
+}
+
+// WatchColumn is the resolver for the watchColumn field.
+func (r *mutationResolver) WatchColumn(ctx context.Context, columnID string) (*model.BoardColumn, error) {
+	return resolvers.WatchColumn(ctx, r.RBACService, r.BoardService, r.WatchService, columnID)
+}
+
+// UnwatchColumn is the resolver for the unwatchColumn field.
+func (r *mutationResolver) UnwatchColumn(ctx context.Context, columnID string) (*model.BoardColumn, error) {
+	return resolvers.UnwatchColumn(ctx, r.RBACService, r.BoardService, r.WatchService, columnID)
+}
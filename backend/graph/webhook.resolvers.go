@@ -0,0 +1,52 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.37
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+)
+
+// CreateWebhook is the resolver for the createWebhook field.
+func (r *mutationResolver) CreateWebhook(ctx context.Context, organizationID string, input model.CreateWebhookInput) (*model.WebhookWithSecret, error) {
+	return resolvers.CreateWebhook(ctx, r.RBACService, r.WebhookService, organizationID, input)
+}
+
+// UpdateWebhook is the resolver for the updateWebhook field.
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id string, input model.UpdateWebhookInput) (*model.Webhook, error) {
+	return resolvers.UpdateWebhook(ctx, r.RBACService, r.WebhookService, id, input)
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	return resolvers.DeleteWebhook(ctx, r.RBACService, r.WebhookService, id)
+}
+
+// RotateWebhookSecret is the resolver for the rotateWebhookSecret field.
+func (r *mutationResolver) RotateWebhookSecret(ctx context.Context, id string) (*model.WebhookWithSecret, error) {
+	return resolvers.RotateWebhookSecret(ctx, r.RBACService, r.WebhookService, id)
+}
+
+// RedeliverWebhookDelivery is the resolver for the redeliverWebhookDelivery field.
+func (r *mutationResolver) RedeliverWebhookDelivery(ctx context.Context, id string) (*model.WebhookDelivery, error) {
+	return resolvers.RedeliverWebhookDelivery(ctx, r.RBACService, r.WebhookService, id)
+}
+
+// WebhookEvents is the resolver for the webhookEvents field.
+func (r *queryResolver) WebhookEvents(ctx context.Context) ([]string, error) {
+	return resolvers.WebhookEvents(ctx)
+}
+
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context, organizationID string) ([]*model.Webhook, error) {
+	return resolvers.Webhooks(ctx, r.RBACService, r.WebhookService, organizationID)
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID string, first *int, after *string) (*model.WebhookDeliveryConnection, error) {
+	return resolvers.WebhookDeliveries(ctx, r.RBACService, r.WebhookService, webhookID, first, after)
+}
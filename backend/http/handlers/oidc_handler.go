@@ -115,7 +115,7 @@ func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case oidc.ErrInvalidState, oidc.ErrStateExpired:
 			h.redirectWithError(w, r, "Authentication session expired. Please try again.")
-		case oidc.ErrTokenExchangeFailed:
+		case oidc.ErrTokenExchangeFailed, oidc.ErrProfileFetchFailed:
 			h.redirectWithError(w, r, "Failed to complete authentication. Please try again.")
 		case oidc.ErrInvalidIDToken, oidc.ErrNonceMismatch:
 			h.redirectWithError(w, r, "Invalid authentication response. Please try again.")
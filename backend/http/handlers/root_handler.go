@@ -2,53 +2,168 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
 	"github.com/thatcatdev/kaimu/backend/config"
 	"github.com/thatcatdev/kaimu/backend/graph"
 	"github.com/thatcatdev/kaimu/backend/graph/generated"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	backupEngine "github.com/thatcatdev/kaimu/backend/internal/backup"
 	"github.com/thatcatdev/kaimu/backend/internal/db"
+	apiTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	attachmentRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	auditRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	auditAnomalyRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	auditAnomalySettingRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	autoArchiveRunRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run"
 	boardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
 	boardColumnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardAggregateRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate"
+	cardDependencyRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	cardMirrorRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardViewRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view"
+	columnAlertRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	columnAlertSettingRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	checklistRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	commentRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	columnWatchRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
 	emailVerificationTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/email_verification_token"
+	epicRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
+	estimationAccuracyRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/estimation_accuracy"
+	freezeWindowRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window"
+	instanceSettingRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting"
 	invitationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	legalHoldRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	metricsEmbedTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
 	metricsHistoryRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history"
+	notificationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	notificationBatchRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	notificationChannelRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	notificationPreferenceRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	notificationRuleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
 	oidcIdentityRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/oidc_identity"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgBrandingRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding"
 	orgMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	outboxEventRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/outbox_event"
+	peopleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/people"
 	permissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	projectExportRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export"
+	projectHealthRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_health"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
+	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
-	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
+	searchQueryRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query"
+	searchStopWordRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_stop_word"
+	searchSynonymSetRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set"
+	slaBreachRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	slaPolicyRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
 	sprintRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMembershipRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint_membership"
+	syncChangeRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	syncMutationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_mutation"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	undoOperationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
-	auditRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	userMatchRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	warehouseSyncRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/warehouse_sync"
+	webhookRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/outbox"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
+	"github.com/thatcatdev/kaimu/backend/internal/services/activity"
+	"github.com/thatcatdev/kaimu/backend/internal/services/aggregate"
+	"github.com/thatcatdev/kaimu/backend/internal/services/anomaly"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	"github.com/thatcatdev/kaimu/backend/internal/services/appearance"
+	"github.com/thatcatdev/kaimu/backend/internal/services/archive"
+	"github.com/thatcatdev/kaimu/backend/internal/services/attachment"
 	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
+	"github.com/thatcatdev/kaimu/backend/internal/services/backup"
 	"github.com/thatcatdev/kaimu/backend/internal/services/board"
+	"github.com/thatcatdev/kaimu/backend/internal/services/boarddef"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
 	"github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/carddraft"
+	"github.com/thatcatdev/kaimu/backend/internal/services/cardimport"
+	"github.com/thatcatdev/kaimu/backend/internal/services/carryover"
+	"github.com/thatcatdev/kaimu/backend/internal/services/calendar"
+	"github.com/thatcatdev/kaimu/backend/internal/services/checklist"
+	"github.com/thatcatdev/kaimu/backend/internal/services/columnalert"
+	"github.com/thatcatdev/kaimu/backend/internal/services/comment"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/internal/services/demo"
+	"github.com/thatcatdev/kaimu/backend/internal/services/dependency"
 	"github.com/thatcatdev/kaimu/backend/internal/services/email"
+	"github.com/thatcatdev/kaimu/backend/internal/services/embed"
+	"github.com/thatcatdev/kaimu/backend/internal/services/embeddings"
+	"github.com/thatcatdev/kaimu/backend/internal/services/epic"
+	"github.com/thatcatdev/kaimu/backend/internal/services/estimation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/export"
+	"github.com/thatcatdev/kaimu/backend/internal/services/freeze"
+	"github.com/thatcatdev/kaimu/backend/internal/services/health"
+	"github.com/thatcatdev/kaimu/backend/internal/services/instance"
 	"github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/jira"
+	"github.com/thatcatdev/kaimu/backend/internal/services/labelsuggest"
+	"github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	"github.com/thatcatdev/kaimu/backend/internal/services/llm"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
 	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/merge"
 	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mirror"
 	"github.com/thatcatdev/kaimu/backend/internal/services/mjml"
+	"github.com/thatcatdev/kaimu/backend/internal/services/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/services/notificationcenter"
+	"github.com/thatcatdev/kaimu/backend/internal/services/offline"
 	"github.com/thatcatdev/kaimu/backend/internal/services/oidc"
 	"github.com/thatcatdev/kaimu/backend/internal/services/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/services/orgmerge"
+	"github.com/thatcatdev/kaimu/backend/internal/services/people"
+	"github.com/thatcatdev/kaimu/backend/internal/services/permissionaudit"
+	"github.com/thatcatdev/kaimu/backend/internal/services/presence"
 	"github.com/thatcatdev/kaimu/backend/internal/services/project"
+	"github.com/thatcatdev/kaimu/backend/internal/services/projectexport"
+	"github.com/thatcatdev/kaimu/backend/internal/services/ratelimit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
-	"github.com/thatcatdev/kaimu/backend/internal/resolvers"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchanalytics"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchvocabulary"
+	"github.com/thatcatdev/kaimu/backend/internal/services/sla"
+	"github.com/thatcatdev/kaimu/backend/internal/services/snapshot"
+	"github.com/thatcatdev/kaimu/backend/internal/services/split"
 	"github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/services/sprintsummary"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
 	"github.com/thatcatdev/kaimu/backend/internal/services/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/services/undo"
+	"github.com/thatcatdev/kaimu/backend/internal/services/unread"
 	"github.com/thatcatdev/kaimu/backend/internal/services/user"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	"github.com/thatcatdev/kaimu/backend/internal/services/warehouse"
+	"github.com/thatcatdev/kaimu/backend/internal/services/watch"
+	"github.com/thatcatdev/kaimu/backend/internal/services/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/services/workflow"
 )
 
 // Dependencies holds all initialized dependencies for the application
@@ -57,9 +172,18 @@ type Dependencies struct {
 	AuditService             audit.Service
 	OIDCService              oidc.Service
 	OrganizationService      organization.Service
+	OrgMergeService          orgmerge.Service
 	ProjectService           project.Service
 	BoardService             board.Service
 	CardService              card.Service
+	ContentService           content.Service
+	CalendarService          calendar.Service
+	DependencyService        dependency.Service
+	EpicService              epic.Service
+	MirrorService            mirror.Service
+	SplitService             split.Service
+	LocaleService            locale.Service
+	WorkflowService          workflow.Service
 	TagService               tag.Service
 	RBACService              rbac.Service
 	InvitationService        invitation.Service
@@ -68,8 +192,65 @@ type Dependencies struct {
 	SearchService            search.Service
 	SearchIndexer            *resolvers.SearchIndexer
 	SprintService            sprint.Service
+	UndoService              undo.Service
+	SLAService               sla.Service
+	NotificationService      notification.Service
+	PresenceService          presence.Service
+	OfflineService           offline.Service
 	MetricsService           metrics.Service
+	DemoService              demo.Service
+	UnreadService            unread.Service
+	WatchService             watch.Service
+	HealthService            health.Service
+	EstimationService        estimation.Service
+	CarryoverService         carryover.Service
+	AggregateService         aggregate.Service
+	EmbedService             embed.Service
+	APITokenService          apitoken.Service
+	RateLimitService         ratelimit.Service // nil when rate limiting is disabled
+	UserMatchService         usermatch.Service
+	ArchiveService           archive.Service
+	MergeService             merge.Service
+	PermissionAuditService   permissionaudit.Service
+	AnomalyService           anomaly.Service
+	LegalHoldService         legalhold.Service
+	BackupService            backup.Service
+	SearchAnalyticsService   searchanalytics.Service
+	SearchVocabularyService  searchvocabulary.Service
+	CardDraftService         carddraft.Service
+	SprintSummaryService     sprintsummary.Service
+	LabelSuggestService      labelsuggest.Service
+	BoardDefinitionService   boarddef.Service
+	AppearanceService        appearance.Service
+	FreezeService            freeze.Service
+	ActivityService          activity.Service
+	CardImportService        cardimport.Service
+	PeopleService            people.Service
+	WebhookService           webhook.Service
+	CommentService           comment.Service
+	AttachmentService        attachment.Service
+	ColumnAlertService       columnalert.Service
+	ChecklistService         checklist.Service
+	JiraService              jira.Service
+	BrandingService          branding.Service
+	InstanceService          instance.Service
+	ExportService            export.Service
+	SnapshotService          snapshot.Service
+	ProjectExportService     projectexport.Service
+	NotificationInboxService notificationcenter.Service
 	OIDCHandler              *OIDCHandler
+	OutboxDispatcher         *outbox.Dispatcher
+	SLAEvaluator             *sla.Evaluator
+	PresenceSweeper          *presence.Sweeper
+	AutoArchiver             *archive.Archiver
+	AnomalyDetector          *anomaly.Detector
+	WarehouseWorker          *warehouse.Worker // nil unless a warehouse provider is configured
+	WebhookSender            *webhook.Sender
+	NotificationBatchFlusher *notification.BatchFlusher
+	AttachmentSweeper        *attachment.Sweeper
+	ColumnAlertChecker       *columnalert.Checker
+	ProjectExportWorker      *projectexport.Worker
+	InvitationExpirer        *invitation.Expirer
 }
 
 // InitializeDependencies creates all application dependencies
@@ -87,16 +268,29 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 	cardRepository := cardRepo.NewRepository(database.DB)
 	tagRepository := tagRepo.NewRepository(database.DB)
 	cardTagRepository := cardTagRepo.NewRepository(database.DB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(database.DB)
 	oidcIdentityRepository := oidcIdentityRepo.NewRepository(database.DB)
 	permissionRepository := permissionRepo.NewRepository(database.DB)
 	roleRepository := roleRepo.NewRepository(database.DB)
 	rolePermissionRepository := rolePermissionRepo.NewRepository(database.DB)
 	projectMemberRepository := projectMemberRepo.NewRepository(database.DB)
 	invitationRepository := invitationRepo.NewRepository(database.DB)
+	attachmentRepository := attachmentRepo.NewRepository(database.DB)
+	checklistRepository := checklistRepo.NewRepository(database.DB)
 
 	// Initialize refresh token repository
 	refreshTokenRepository := refreshTokenRepo.NewRepository(database.DB)
 
+	// Unit of work shared by services that span several repositories
+	txManager := transaction.NewManager(database.DB)
+
+	// Domain events: services record them in the outbox inside their transactions and the
+	// dispatcher delivers them to the subscribers registered on eventBus
+	eventBus := events.NewBus()
+	outboxRepository := outboxEventRepo.NewRepository(database.DB)
+	outboxDispatcher := outbox.NewDispatcher(outboxRepository, eventBus, outbox.DefaultConfig())
+	eventPublisher := outbox.NewPublisher(outboxRepository, eventBus, outboxDispatcher.Notify)
+
 	// Initialize services
 	authService := auth.NewService(
 		userRepository,
@@ -110,27 +304,94 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		orgRepository,
 		orgMemberRepository,
 		userRepository,
+		txManager,
+		eventPublisher,
+	)
+
+	boardService := board.NewService(
+		boardRepository,
+		boardColumnRepository,
+		projectRepository,
+		txManager,
+		eventPublisher,
 	)
 
 	projectService := project.NewService(
 		projectRepository,
 		orgRepository,
+		boardService,
+		txManager,
+		eventPublisher,
 	)
 
-	boardService := board.NewService(
+	workflowService := workflow.NewService(
+		columnTransitionRepository,
 		boardRepository,
 		boardColumnRepository,
+	)
+
+	// Initialize content limits and the moderation scanners organizations can enable
+	contentScanners := []content.Scanner{}
+	if cfg.ContentConfig.PIIScanner {
+		contentScanners = append(contentScanners, content.NewPIIScanner())
+	}
+	if words := cfg.ContentConfig.GetBlockedWords(); len(words) > 0 {
+		contentScanners = append(contentScanners, content.NewWordListScanner(words))
+	}
+	contentService := content.NewService(
+		boardRepository,
 		projectRepository,
+		orgRepository,
+		content.LimitsFromConfig(cfg.ContentConfig),
+		contentScanners...,
 	)
 
+	// Initialize project calendars, used to suggest due dates on working days
+	calendarService := calendar.NewService(projectCalendarRepo.NewRepository(database.DB), projectRepository)
+
 	cardService := card.NewService(
 		cardRepository,
 		boardColumnRepository,
 		boardRepository,
 		tagRepository,
 		cardTagRepository,
+		columnDefaultsRepo.NewRepository(database.DB),
+		workflowService,
+		contentService,
+		calendarService,
+		txManager,
+		eventPublisher,
 	)
 
+	// Initialize card dependencies (blocks/relates links and the project dependency graph)
+	cardDependencyRepository := cardDependencyRepo.NewRepository(database.DB)
+	dependencyService := dependency.NewService(cardDependencyRepository, cardRepository, boardRepository)
+
+	// Initialize epics, scheduled along their cards' blocking links
+	epicRepository := epicRepo.NewRepository(database.DB)
+	epicService := epic.NewService(
+		epicRepository,
+		projectRepository,
+		cardRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardDependencyRepository,
+	)
+
+	// Initialize card mirroring, keeping mirrors on other projects' boards in step
+	mirrorService := mirror.NewService(
+		cardMirrorRepo.NewRepository(database.DB),
+		cardRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardService,
+		txManager,
+	)
+	mirror.NewSyncer(mirrorService).Subscribe(eventBus)
+
+	splitService := split.NewService(cardDependencyRepository, cardService, txManager)
+	mergeService := merge.NewService(cardRepository, cardDependencyRepository, cardService, txManager, eventPublisher)
+
 	tagService := tag.NewService(
 		tagRepository,
 		projectRepository,
@@ -147,10 +408,21 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		userRepository,
 	)
 
+	// Initialize locale preferences, used to pick the language of emails
+	localeService := locale.NewService(userRepository, projectRepository, orgRepository)
+
 	// Initialize email services first (needed by invitation service)
 	emailVerificationTokenRepository := emailVerificationTokenRepo.NewEmailVerificationTokenRepository(database.DB)
-	mjmlService := mjml.NewMJMLService()
-	mailService := mail.NewMailService(cfg.EmailConfig, mjmlService)
+	mjmlService := mjml.NewMJMLService(cfg.EmailConfig.TemplateDir)
+
+	// Platform admins can whitelabel the instance; its mail uses the product name and look
+	instanceService := instance.NewService(instanceSettingRepo.NewRepository(database.DB), userRepository, cfg.InstanceConfig)
+	mailService := mail.NewMailService(cfg.EmailConfig, mjmlService, instanceService)
+
+	// Organizations can brand their mail and, once its domain is verified, send it from
+	// their own address
+	orgBrandingRepository := orgBrandingRepo.NewRepository(database.DB)
+	brandingService := branding.NewService(orgBrandingRepository, projectRepository, net.DefaultResolver, cfg.EmailConfig)
 
 	invitationService := invitation.NewService(
 		invitationRepository,
@@ -158,25 +430,160 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		orgMemberRepository,
 		userRepository,
 		roleRepository,
+		projectRepository,
+		projectMemberRepository,
 		mailService,
+		brandingService,
 		cfg.EmailConfig,
+		cfg.MembershipConfig,
+		txManager,
+		eventPublisher,
 	)
+	// Remind invitees before their invitations expire, and expire the stale ones
+	invitationExpirer := invitation.NewExpirer(invitationService, invitation.DefaultExpiryInterval)
 
 	userService := user.NewService(userRepository)
 
 	// Initialize sprint repository and service
 	sprintRepository := sprintRepo.NewRepository(database.DB)
+	undoOperationRepository := undoOperationRepo.NewRepository(database.DB)
+	undoService := undo.NewService(undoOperationRepository, sprintRepository, cardRepository, txManager, eventPublisher)
 	sprintService := sprint.NewService(
 		sprintRepository,
 		cardRepository,
 		boardRepository,
 		boardColumnRepository,
+		undoService,
+		txManager,
+		eventPublisher,
+	)
+	// Boards with the backlog column policy on keep their active sprint in step with moves
+	sprint.NewMembershipSyncer(sprintService).Subscribe(eventBus)
+
+	// Initialize SLA policies; breaches are flagged by the evaluator started with the server
+	// and their owners are emailed by the notifier
+	slaPolicyRepository := slaPolicyRepo.NewRepository(database.DB)
+	slaBreachRepository := slaBreachRepo.NewRepository(database.DB)
+	slaService := sla.NewService(
+		slaPolicyRepository,
+		slaBreachRepository,
+		projectRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardRepository,
+		cardTagRepository,
+		tagRepository,
+		sprintRepository,
+		txManager,
+		eventPublisher,
+	)
+	slaEvaluator := sla.NewEvaluator(slaService, sla.DefaultEvaluationInterval)
+	sla.NewBreachNotifier(slaBreachRepository, slaPolicyRepository, cardRepository, userRepository, mailService, localeService).Subscribe(eventBus)
+
+	// Initialize user-defined notification rules, evaluated against card events
+	notificationRuleRepository := notificationRuleRepo.NewRepository(database.DB)
+	notificationChannelRepository := notificationChannelRepo.NewRepository(database.DB)
+	notificationBatchRepository := notificationBatchRepo.NewRepository(database.DB)
+	notificationService := notification.NewService(
+		notificationRuleRepository,
+		notificationChannelRepository,
+		projectRepository,
+		boardRepository,
+		boardColumnRepository,
+		tagRepository,
+		userRepository,
+		mailService,
+		localeService,
+		brandingService,
+		txManager,
+	)
+	slackPoster := notification.NewSlackPoster()
+	notification.NewRuleNotifier(
+		notificationRuleRepository,
+		notificationChannelRepository,
+		notificationBatchRepository,
+		boardRepository,
+		boardColumnRepository,
+		projectRepository,
+		cardRepository,
+		cardTagRepository,
+		userRepository,
+		rbacService,
+		mailService,
+		localeService,
+		brandingService,
+		slackPoster,
+	).Subscribe(eventBus)
+	// Send the notifications boards in quiet mode held back, one summary per recipient
+	notificationBatchFlusher := notification.NewBatchFlusher(
+		notificationBatchRepository,
+		notificationRuleRepository,
+		boardRepository,
+		projectRepository,
+		userRepository,
+		rbacService,
+		mailService,
+		localeService,
+		brandingService,
+		slackPoster,
+		txManager,
+		notification.DefaultBatchFlushInterval,
+	)
+
+	// Initialize column watches, emailing watchers as cards enter and leave their columns
+	columnWatchRepository := columnWatchRepo.NewRepository(database.DB)
+	watchService := watch.NewService(columnWatchRepository, boardColumnRepository)
+	watch.NewNotifier(
+		columnWatchRepository,
+		notificationChannelRepository,
+		boardColumnRepository,
+		boardRepository,
+		projectRepository,
+		cardRepository,
+		userRepository,
+		rbacService,
+		mailService,
+		localeService,
+	).Subscribe(eventBus)
+
+	// Initialize read/unread tracking, marking cards viewed by the users who change them
+	cardViewRepository := cardViewRepo.NewRepository(database.DB)
+	unreadService := unread.NewService(cardViewRepository)
+	unread.NewTracker(cardViewRepository).Subscribe(eventBus)
+
+	// Initialize board presence, kept in memory and swept of expired viewers
+	presenceService := presence.NewService()
+	presenceSweeper := presence.NewSweeper(presenceService, presence.DefaultSweepInterval)
+
+	// Initialize offline sync, journaling card and board changes for delta pulls
+	syncChangeRepository := syncChangeRepo.NewRepository(database.DB)
+	syncMutationRepository := syncMutationRepo.NewRepository(database.DB)
+	offlineService := offline.NewService(
+		syncChangeRepository,
+		syncMutationRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardRepository,
 	)
+	offline.NewJournal(syncChangeRepository, boardColumnRepository).Subscribe(eventBus)
 
 	// Initialize audit repository and service (needed by metrics service)
 	auditRepository := auditRepo.NewRepository(database.DB)
 	auditService := audit.NewService(auditRepository)
 
+	// Initialize organization merges (consolidating an acquired organization into another)
+	orgMergeService := orgmerge.NewService(
+		orgRepository,
+		orgMemberRepository,
+		projectRepository,
+		roleRepository,
+		invitationRepository,
+		auditRepository,
+		attachmentRepository,
+		txManager,
+		eventPublisher,
+	)
+
 	// Initialize metrics repository and service
 	metricsHistoryRepository := metricsHistoryRepo.NewRepository(database.DB)
 	metricsService := metrics.NewService(
@@ -185,6 +592,120 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		boardColumnRepository,
 		metricsHistoryRepository,
 		auditRepository,
+		checklistRepository,
+		epicRepository,
+	)
+	metrics.NewSnapshotSubscriber(metricsService, sprintRepository, cardRepository).Subscribe(eventBus)
+
+	// Initialize project health, computed from overdue cards, sprint churn, blocked time and velocity
+	healthService := health.NewService(projectHealthRepo.NewRepository(database.DB), boardRepository, metricsService)
+
+	// Initialize estimation accuracy reports, comparing original estimates with cycle time
+	estimationService := estimation.NewService(estimationAccuracyRepo.NewRepository(database.DB))
+
+	// Initialize carryover reports across sprints
+	carryoverService := carryover.NewService(sprintRepository, sprintMembershipRepo.NewRepository(database.DB))
+
+	// Initialize card aggregates for reporting tools
+	aggregateService := aggregate.NewService(cardAggregateRepo.NewRepository(database.DB))
+
+	// Initialize embed tokens, letting external dashboards read a board's charts
+	metricsEmbedTokenRepository := metricsEmbedTokenRepo.NewRepository(database.DB)
+	embedService := embed.NewService(metricsEmbedTokenRepository, sprintRepository, metricsService)
+
+	// Initialize API tokens for programmatic access
+	apiTokenRepository := apiTokenRepo.NewRepository(database.DB)
+	apiTokenService := apitoken.NewService(apiTokenRepository, userRepository, orgMemberRepository, txManager)
+
+	// Initialize rate limiting (nil when disabled), sharing the buckets through Redis when configured
+	var rateLimitService ratelimit.Service
+	if rc := cfg.RateLimitConfig; !rc.Disabled {
+		for _, n := range []int{rc.UserPerMinute, rc.UserBurst, rc.IPPerMinute, rc.IPBurst, rc.AuthPerMinute, rc.AuthBurst} {
+			if n <= 0 {
+				panic("RATE_LIMIT_* rates and bursts must be positive")
+			}
+		}
+		var redisClient redis.UniversalClient
+		if rc.RedisURL != "" {
+			client, err := ratelimit.NewRedisClient(rc.RedisURL)
+			if err != nil {
+				panic(fmt.Sprintf("invalid RATE_LIMIT_REDIS_URL: %v", err))
+			}
+			redisClient = client
+		}
+		rateLimitService = ratelimit.NewService(redisClient)
+	}
+
+	// Initialize user matching for imports and bulk invites
+	userMatchService := usermatch.NewService(userMatchRepo.NewRepository(database.DB), orgMemberRepository, userRepository)
+
+	// Initialize the permission audit report for security reviews
+	permissionAuditService := permissionaudit.NewService(
+		orgMemberRepository,
+		projectRepository,
+		projectMemberRepository,
+		boardRepository,
+		metricsEmbedTokenRepository,
+		apiTokenRepository,
+		userRepository,
+		rbacService,
+	)
+
+	// Initialize auto-archival of cards left in done columns, with a summary email per run
+	autoArchiveRunRepository := autoArchiveRunRepo.NewRepository(database.DB)
+	archiveService := archive.NewService(boardRepository, boardColumnRepository, cardRepository, autoArchiveRunRepository, txManager, eventPublisher)
+	autoArchiver := archive.NewArchiver(archiveService, archive.DefaultArchiveInterval)
+	archive.NewSummaryNotifier(autoArchiveRunRepository, boardRepository, userRepository, mailService, localeService).Subscribe(eventBus)
+
+	// Initialize anomaly detection over the audit log, emailing organization admins
+	auditAnomalyRepository := auditAnomalyRepo.NewRepository(database.DB)
+	auditAnomalySettingRepository := auditAnomalySettingRepo.NewRepository(database.DB)
+	anomalyService := anomaly.NewService(auditRepository, auditAnomalyRepository, auditAnomalySettingRepository, eventPublisher)
+	anomalyDetector := anomaly.NewDetector(anomalyService, anomaly.DefaultDetectionInterval)
+	anomaly.NewAnomalyNotifier(
+		auditAnomalyRepository,
+		auditAnomalySettingRepository,
+		orgRepository,
+		orgMemberRepository,
+		userRepository,
+		rbacService,
+		mailService,
+		localeService,
+	).Subscribe(eventBus)
+
+	// Initialize legal holds, which block permanent deletions in an organization
+	legalHoldService := legalhold.NewService(legalHoldRepo.NewRepository(database.DB), projectRepository, boardRepository)
+
+	// Initialize backups, stored in the configured directory
+	backupService := backup.NewService(backupEngine.NewEngine(database.DB), backupEngine.NewDirStore(cfg.BackupConfig.Dir))
+
+	// Initialize the optional warehouse sync of card, sprint and audit aggregates
+	var warehouseWorker *warehouse.Worker
+	warehouseSink, err := warehouse.NewSink(cfg.WarehouseConfig)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure warehouse sync: %v", err))
+	}
+	if warehouseSink != nil {
+		warehouseService := warehouse.NewService(
+			warehouseSyncRepo.NewRepository(database.DB),
+			warehouseSink,
+			cfg.WarehouseConfig.TablePrefix,
+			cfg.WarehouseConfig.BatchSize,
+		)
+		warehouseWorker = warehouse.NewWorker(warehouseService, time.Duration(cfg.WarehouseConfig.IntervalMinutes)*time.Minute)
+	}
+
+	// Initialize demo data service (seeding is never allowed in production)
+	demoService := demo.NewService(
+		cfg.AppConfig.Env != "production",
+		organizationService,
+		projectService,
+		boardService,
+		cardService,
+		sprintService,
+		tagService,
+		auditRepository,
+		metricsHistoryRepository,
 	)
 
 	// Initialize email verification service (uses same mail service)
@@ -209,13 +730,203 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 	isSecure := cfg.AppConfig.Env != "development"
 	oidcHandler := NewOIDCHandler(oidcService, authService, cfg.OIDCConfig.FrontendURL, isSecure)
 
+	// Card drafting and sprint summaries are unavailable unless a language model is
+	// configured; label suggestions then only come from history
+	llmProvider, err := llm.NewProvider(cfg.LLMConfig)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure language model: %v", err))
+	}
+	cardDraftService := carddraft.NewService(llmProvider, projectRepository, orgRepository, tagRepository)
+	sprintSummaryService := sprintsummary.NewService(llmProvider, sprintRepository, cardRepository, boardColumnRepository, cardDependencyRepository, boardRepository, projectRepository, orgRepository)
+	labelSuggestService := labelsuggest.NewService(cardRepository, cardTagRepository, tagRepository, boardRepository, projectRepository, orgRepository, llmProvider)
+
+	// Initialize board definitions, the versioned JSON format boards are exported and imported in
+	boardDefinitionService := boarddef.NewService(
+		boardRepository,
+		boardColumnRepository,
+		columnDefaultsRepo.NewRepository(database.DB),
+		columnTransitionRepository,
+		slaPolicyRepository,
+		tagRepository,
+		projectRepository,
+		contentService,
+		txManager,
+		eventPublisher,
+	)
+
+	// Initialize board appearance settings
+	appearanceService := appearance.NewService(boardRepository, boardColumnRepository, txManager, eventPublisher)
+
+	// Initialize freeze windows, checked by moveCard
+	freezeService := freeze.NewService(freezeWindowRepo.NewRepository(database.DB), boardRepository, boardColumnRepository)
+
+	// Initialize contributor activity heatmaps, counted from the audit log
+	activityService := activity.NewService(auditRepository)
+
+	// Initialize card imports from CSV, Jira and Trello
+	cardImportService := cardimport.NewService(projectRepository, boardRepository, boardColumnRepository, tagRepository, userRepository, cardService, contentService, rbacService, userMatchService, txManager)
+
+	// Initialize the organization people view
+	peopleService := people.NewService(orgMemberRepository, peopleRepo.NewRepository(database.DB))
+
+	// Initialize webhooks: events are queued per webhook and posted by the sender started
+	// with the server
+	webhookRepository := webhookRepo.NewRepository(database.DB)
+	webhookSender := webhook.NewSender(webhookRepository, webhook.DefaultSenderConfig())
+	webhookService := webhook.NewService(webhookRepository, webhookSender.Notify)
+	webhook.NewEnqueuer(webhookRepository, boardRepository, projectRepository, webhookSender.Notify).Subscribe(eventBus)
+
+	// Initialize card comments; @mentions are recorded for the mentioned members
+	commentRepository := commentRepo.NewRepository(database.DB)
+	commentService := comment.NewService(
+		commentRepository,
+		cardRepository,
+		boardRepository,
+		projectRepository,
+		userRepository,
+		orgMemberRepository,
+		rbacService,
+		contentService,
+		txManager,
+		eventPublisher,
+	)
+
+	// Initialize the notification center: the recorder turns assignments, mentions,
+	// invitations and sprint starts into in-app notifications and emails, as each member's
+	// preferences say
+	notificationRepository := notificationRepo.NewRepository(database.DB)
+	notificationPreferenceRepository := notificationPreferenceRepo.NewRepository(database.DB)
+	notificationCenterService := notificationcenter.NewService(notificationRepository, notificationPreferenceRepository)
+	notificationcenter.NewRecorder(
+		notificationRepository,
+		notificationPreferenceRepository,
+		cardRepository,
+		commentRepository,
+		invitationRepository,
+		sprintRepository,
+		boardRepository,
+		projectRepository,
+		userRepository,
+		mailService,
+		localeService,
+		brandingService,
+	).Subscribe(eventBus)
+
+	// Initialize card checklists
+	checklistService := checklist.NewService(
+		checklistRepository,
+		cardRepository,
+		boardRepository,
+		projectRepository,
+		orgMemberRepository,
+		txManager,
+	)
+
+	// Initialize project exports for Jira's importers
+	jiraService := jira.NewService(
+		projectRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardRepository,
+		cardTagRepository,
+		tagRepository,
+		sprintRepository,
+		userRepository,
+	)
+
+	// Initialize card attachments (uploads are refused unless an object store is configured);
+	// the sweeper deletes the files of deleted cards
+	attachmentStore, err := storage.NewStore(cfg.StorageConfig)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure attachment storage: %v", err))
+	}
+	attachmentService := attachment.NewService(
+		attachmentRepository,
+		cardRepository,
+		boardRepository,
+		projectRepository,
+		orgRepository,
+		legalHoldService,
+		attachmentStore,
+		attachment.LimitsFromConfig(cfg.StorageConfig),
+	)
+	attachmentSweeper := attachment.NewSweeper(attachmentService, attachment.DefaultSweepInterval)
+
+	// Initialize printable card exports, stored alongside attachments
+	exportService := export.NewService(
+		cardRepository,
+		boardRepository,
+		boardColumnRepository,
+		projectRepository,
+		cardTagRepository,
+		tagRepository,
+		userRepository,
+		attachmentStore,
+	)
+
+	// Initialize board snapshot images for Slack messages and digest emails, stored alongside
+	// attachments
+	snapshotService := snapshot.NewService(
+		boardRepository,
+		boardColumnRepository,
+		cardRepository,
+		projectRepository,
+		sprintRepository,
+		userRepository,
+		attachmentStore,
+	)
+
+	// Initialize project exports: JSON archives of a project built by the worker and stored
+	// alongside attachments
+	projectExportService := projectexport.NewService(
+		projectExportRepo.NewRepository(database.DB),
+		projectRepository,
+		boardRepository,
+		boardColumnRepository,
+		cardRepository,
+		cardTagRepository,
+		tagRepository,
+		sprintRepository,
+		commentRepository,
+		userRepository,
+		attachmentStore,
+	)
+	projectExportWorker := projectexport.NewWorker(projectExportService, projectexport.DefaultPollInterval)
+
+	// Initialize column alerts: the checker raises alerts for columns over their WIP limit or
+	// holding old cards, and the notifier emails the board's admins and posts to Slack
+	columnAlertRepository := columnAlertRepo.NewRepository(database.DB)
+	columnAlertSettingRepository := columnAlertSettingRepo.NewRepository(database.DB)
+	columnAlertService := columnalert.NewService(columnAlertSettingRepository, columnAlertRepository, boardRepository, boardColumnRepository, eventPublisher)
+	columnAlertChecker := columnalert.NewChecker(columnAlertService, columnalert.DefaultCheckInterval)
+	columnalert.NewAlertNotifier(
+		columnAlertRepository,
+		columnAlertSettingRepository,
+		boardRepository,
+		boardColumnRepository,
+		projectRepository,
+		orgMemberRepository,
+		userRepository,
+		rbacService,
+		mailService,
+		localeService,
+		slackPoster,
+	).Subscribe(eventBus)
+
 	// Initialize search service (optional - nil if Typesense is not configured)
 	var searchService search.Service
+	searchAnalyticsService := searchanalytics.NewService(searchQueryRepo.NewRepository(database.DB), orgRepository)
+	searchVocabularyService := searchvocabulary.NewService(searchSynonymSetRepo.NewRepository(database.DB), searchStopWordRepo.NewRepository(database.DB))
 	var searchIndexer *resolvers.SearchIndexer
 	if cfg.TypesenseConfig.Host != "" && cfg.TypesenseConfig.APIKey != "" {
+		// Cards are embedded for semantic search when an embeddings provider is configured
+		embeddingsProvider, err := embeddings.NewProvider(cfg.EmbeddingsConfig)
+		if err != nil {
+			panic(fmt.Sprintf("failed to configure embeddings: %v", err))
+		}
 		typesenseClient, err := search.NewTypesenseClient(cfg.TypesenseConfig)
 		if err == nil {
-			searchService = search.NewService(typesenseClient, orgMemberRepository)
+			searchService = search.NewService(typesenseClient, orgMemberRepository, searchVocabularyService, embeddingsProvider)
 			// Initialize collections on startup (create if not exists)
 			_ = searchService.InitializeCollections(context.Background())
 
@@ -228,6 +939,7 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 				cardService,
 				userService,
 			)
+			searchIndexer.Subscribe(eventBus)
 		}
 	}
 
@@ -236,9 +948,18 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		AuditService:             auditService,
 		OIDCService:              oidcService,
 		OrganizationService:      organizationService,
+		OrgMergeService:          orgMergeService,
 		ProjectService:           projectService,
 		BoardService:             boardService,
 		CardService:              cardService,
+		ContentService:           contentService,
+		CalendarService:          calendarService,
+		DependencyService:        dependencyService,
+		EpicService:              epicService,
+		MirrorService:            mirrorService,
+		SplitService:             splitService,
+		LocaleService:            localeService,
+		WorkflowService:          workflowService,
 		TagService:               tagService,
 		RBACService:              rbacService,
 		InvitationService:        invitationService,
@@ -247,8 +968,65 @@ func InitializeDependencies(cfg config.Config) *Dependencies {
 		SearchService:            searchService,
 		SearchIndexer:            searchIndexer,
 		SprintService:            sprintService,
+		UndoService:              undoService,
+		SLAService:               slaService,
+		NotificationService:      notificationService,
+		PresenceService:          presenceService,
+		OfflineService:           offlineService,
 		MetricsService:           metricsService,
+		DemoService:              demoService,
+		UnreadService:            unreadService,
+		WatchService:             watchService,
+		HealthService:            healthService,
+		EstimationService:        estimationService,
+		CarryoverService:         carryoverService,
+		AggregateService:         aggregateService,
+		EmbedService:             embedService,
+		APITokenService:          apiTokenService,
+		RateLimitService:         rateLimitService,
+		UserMatchService:         userMatchService,
+		ArchiveService:           archiveService,
+		MergeService:             mergeService,
+		PermissionAuditService:   permissionAuditService,
+		AnomalyService:           anomalyService,
+		LegalHoldService:         legalHoldService,
+		BackupService:            backupService,
+		SearchAnalyticsService:   searchAnalyticsService,
+		SearchVocabularyService:  searchVocabularyService,
+		CardDraftService:         cardDraftService,
+		SprintSummaryService:     sprintSummaryService,
+		LabelSuggestService:      labelSuggestService,
+		BoardDefinitionService:   boardDefinitionService,
+		AppearanceService:        appearanceService,
+		FreezeService:            freezeService,
+		ActivityService:          activityService,
+		CardImportService:        cardImportService,
+		PeopleService:            peopleService,
+		WebhookService:           webhookService,
+		CommentService:           commentService,
+		AttachmentService:        attachmentService,
+		ColumnAlertService:       columnAlertService,
+		ChecklistService:         checklistService,
+		JiraService:              jiraService,
+		BrandingService:          brandingService,
+		InstanceService:          instanceService,
+		ExportService:            exportService,
+		SnapshotService:          snapshotService,
+		ProjectExportService:     projectExportService,
+		NotificationInboxService: notificationCenterService,
 		OIDCHandler:              oidcHandler,
+		OutboxDispatcher:         outboxDispatcher,
+		SLAEvaluator:             slaEvaluator,
+		PresenceSweeper:          presenceSweeper,
+		AutoArchiver:             autoArchiver,
+		AnomalyDetector:          anomalyDetector,
+		WarehouseWorker:          warehouseWorker,
+		WebhookSender:            webhookSender,
+		NotificationBatchFlusher: notificationBatchFlusher,
+		AttachmentSweeper:        attachmentSweeper,
+		ColumnAlertChecker:       columnAlertChecker,
+		ProjectExportWorker:      projectExportWorker,
+		InvitationExpirer:        invitationExpirer,
 	}
 }
 
@@ -257,7 +1035,7 @@ func BuildRootHandler(conf config.Config) http.Handler {
 		Config: conf,
 	}
 
-	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives()}
+	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives(nil, nil)}
 
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
 
@@ -274,9 +1052,18 @@ func BuildRootHandlerWithContext(ctx context.Context, conf config.Config, deps *
 		AuditService:             deps.AuditService,
 		OIDCService:              deps.OIDCService,
 		OrganizationService:      deps.OrganizationService,
+		OrgMergeService:          deps.OrgMergeService,
 		ProjectService:           deps.ProjectService,
 		BoardService:             deps.BoardService,
 		CardService:              deps.CardService,
+		ContentService:           deps.ContentService,
+		CalendarService:          deps.CalendarService,
+		DependencyService:        deps.DependencyService,
+		EpicService:              deps.EpicService,
+		MirrorService:            deps.MirrorService,
+		SplitService:             deps.SplitService,
+		LocaleService:            deps.LocaleService,
+		WorkflowService:          deps.WorkflowService,
 		TagService:               deps.TagService,
 		RBACService:              deps.RBACService,
 		InvitationService:        deps.InvitationService,
@@ -285,12 +1072,97 @@ func BuildRootHandlerWithContext(ctx context.Context, conf config.Config, deps *
 		SearchService:            deps.SearchService,
 		SearchIndexer:            deps.SearchIndexer,
 		SprintService:            deps.SprintService,
+		UndoService:              deps.UndoService,
+		SLAService:               deps.SLAService,
+		NotificationService:      deps.NotificationService,
+		PresenceService:          deps.PresenceService,
+		OfflineService:           deps.OfflineService,
 		MetricsService:           deps.MetricsService,
+		DemoService:              deps.DemoService,
+		UnreadService:            deps.UnreadService,
+		WatchService:             deps.WatchService,
+		HealthService:            deps.HealthService,
+		EstimationService:        deps.EstimationService,
+		CarryoverService:         deps.CarryoverService,
+		AggregateService:         deps.AggregateService,
+		EmbedService:             deps.EmbedService,
+		APITokenService:          deps.APITokenService,
+		UserMatchService:         deps.UserMatchService,
+		ArchiveService:           deps.ArchiveService,
+		MergeService:             deps.MergeService,
+		PermissionAuditService:   deps.PermissionAuditService,
+		AnomalyService:           deps.AnomalyService,
+		LegalHoldService:         deps.LegalHoldService,
+		BackupService:            deps.BackupService,
+		SearchAnalyticsService:   deps.SearchAnalyticsService,
+		SearchVocabularyService:  deps.SearchVocabularyService,
+		CardDraftService:         deps.CardDraftService,
+		SprintSummaryService:     deps.SprintSummaryService,
+		LabelSuggestService:      deps.LabelSuggestService,
+		BoardDefinitionService:   deps.BoardDefinitionService,
+		AppearanceService:        deps.AppearanceService,
+		FreezeService:            deps.FreezeService,
+		ActivityService:          deps.ActivityService,
+		CardImportService:        deps.CardImportService,
+		PeopleService:            deps.PeopleService,
+		WebhookService:           deps.WebhookService,
+		CommentService:           deps.CommentService,
+		AttachmentService:        deps.AttachmentService,
+		ColumnAlertService:       deps.ColumnAlertService,
+		ChecklistService:         deps.ChecklistService,
+		JiraService:              deps.JiraService,
+		BrandingService:          deps.BrandingService,
+		InstanceService:          deps.InstanceService,
+		ExportService:            deps.ExportService,
+		SnapshotService:          deps.SnapshotService,
+		ProjectExportService:     deps.ProjectExportService,
+		NotificationInboxService: deps.NotificationInboxService,
 	}
 
-	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives()}
+	cfg := generated.Config{Resolvers: resolvers, Directives: directives.GetDirectives(deps.RBACService, deps.InvitationService)}
 
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+	wsAuth := middleware.NewWebSocketAuth(deps.AuthService, conf.AppConfig.WebSocketMaxConnsPerUser)
+
+	// Same transports and extensions as handler.NewDefaultServer, with an authenticated
+	// graphql-ws transport and server-sent events for incremental (@defer) delivery
+	srv := handler.New(generated.NewExecutableSchema(cfg))
+	srv.AddTransport(transport.Websocket{
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: middleware.WebSocketOriginChecker(conf.AppConfig.GetCORSOrigins()),
+		},
+		InitFunc:              wsAuth.InitFunc,
+		CloseFunc:             wsAuth.CloseFunc,
+		InitTimeout:           10 * time.Second,
+		KeepAlivePingInterval: time.Duration(conf.AppConfig.WebSocketKeepAliveSeconds) * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	// SSE must come before POST: both accept JSON POSTs, and only SSE sends deferred payloads
+	srv.AddTransport(transport.SSE{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New(1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
+
+	// Let subscriptions and @defer responses stream past the request timeouts
+	srv.Use(middleware.StreamTimeouts{})
+
+	// Keep API tokens to their scope where permission checks don't
+	srv.Use(middleware.APITokenGuard{})
+
+	// Limit login and register attempts per client IP
+	if deps.RateLimitService != nil {
+		rc := conf.RateLimitConfig
+		srv.Use(middleware.AuthRateLimit{
+			Limiter: deps.RateLimitService,
+			Limit:   ratelimit.PerMinute(rc.AuthPerMinute, rc.AuthBurst),
+		})
+	}
 
 	// Add GraphQL tracing extension
 	srv.Use(&middleware.GraphQLTracingExtension{})
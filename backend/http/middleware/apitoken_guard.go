@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+)
+
+var ErrAPITokenScope = errors.New("the API token's scope does not allow this operation")
+
+// sessionMutations manage sign-in, the account and API tokens themselves, so they need a
+// session: a leaked token must not be able to mint more tokens or take over the account
+var sessionMutations = map[string]bool{
+	"register":                  true,
+	"login":                     true,
+	"logout":                    true,
+	"refreshToken":              true,
+	"verifyEmail":               true,
+	"resendVerificationEmail":   true,
+	"updateMe":                  true,
+	"createPersonalAccessToken": true,
+	"createServiceToken":        true,
+	"revokeApiToken":            true,
+}
+
+// mutationPermissions maps every mutation an API token may run to the permission its scope
+// must allow. Mutations no permission check covers, such as the caller's own settings or
+// instance administration, map to a permission no role grants, so only admin tokens, which
+// do everything their user may, run them. Mutations missing here are denied to every token
+var mutationPermissions = map[string]string{
+	// Organizations
+	"createOrganization":                     "org:create",
+	"updateOrganization":                     "org:manage",
+	"deleteOrganization":                     "org:delete",
+	"mergeOrganizations":                     "org:delete",
+	"updateAuditAnomalySettings":             "org:manage",
+	"setOrganizationAttachmentLimits":        "org:manage",
+	"createOrganizationBackup":               "org:manage",
+	"updateOrganizationBranding":             "org:manage",
+	"verifyBrandingDomain":                   "org:manage",
+	"setAIDraftingEnabled":                   "org:manage",
+	"setOrganizationContentModeration":       "org:manage",
+	"setInvitationExpiryPolicy":              "org:manage",
+	"placeLegalHold":                         "org:manage",
+	"liftLegalHold":                          "org:manage",
+	"setOrganizationDefaultLocale":           "org:manage",
+	"updateOrganizationNotificationSettings": "org:manage",
+	"setSearchAnalyticsAnonymized":           "org:manage",
+	"createSearchSynonymSet":                 "org:manage",
+	"updateSearchSynonymSet":                 "org:manage",
+	"deleteSearchSynonymSet":                 "org:manage",
+	"setSearchStopWords":                     "org:manage",
+	"createWebhook":                          "org:manage",
+	"updateWebhook":                          "org:manage",
+	"deleteWebhook":                          "org:manage",
+	"rotateWebhookSecret":                    "org:manage",
+	"redeliverWebhookDelivery":               "org:manage",
+	"setNotificationPreference":              "org:view",
+
+	// Roles, members and invitations
+	"createRole":            "org:manage_roles",
+	"updateRole":            "org:manage_roles",
+	"deleteRole":            "org:manage_roles",
+	"changeMemberRole":      "org:manage_roles",
+	"bulkChangeMemberRoles": "org:manage_roles",
+	"inviteMember":          "org:invite",
+	"cancelInvitation":      "org:invite",
+	"resendInvitation":      "org:invite",
+	"matchExternalUsers":    "org:invite",
+	"resolveUserMatch":      "org:invite",
+	"removeMember":          "org:remove_members",
+	"assignProjectRole":     "project:manage_members",
+	"removeProjectMember":   "project:manage_members",
+
+	// Projects
+	"createProject":                     "project:create",
+	"updateProject":                     "project:manage",
+	"deleteProject":                     "project:delete",
+	"updateProjectCalendar":             "project:manage",
+	"addProjectHoliday":                 "project:manage",
+	"removeProjectHoliday":              "project:manage",
+	"updateProjectNotificationSettings": "project:manage",
+	"requestProjectExport":              "project:manage",
+	"createSLAPolicy":                   "project:manage",
+	"updateSLAPolicy":                   "project:manage",
+	"deleteSLAPolicy":                   "project:manage",
+	"createTag":                         "project:manage",
+	"updateTag":                         "project:manage",
+	"deleteTag":                         "project:manage",
+	"createNotificationRule":            "project:view",
+	"updateNotificationRule":            "project:view",
+	"deleteNotificationRule":            "project:view",
+	"testNotificationRule":              "project:view",
+
+	// Boards and columns
+	"createBoard":                  "board:create",
+	"importBoardDefinition":        "board:create",
+	"updateBoard":                  "board:manage",
+	"deleteBoard":                  "board:delete",
+	"setBoardAppearance":           "board:manage",
+	"setBoardAutoArchive":          "board:manage",
+	"setBoardQuietMode":            "board:manage",
+	"setBoardSprintAutoMembership": "board:manage",
+	"generateMetricsEmbedToken":    "board:manage",
+	"revokeMetricsEmbedToken":      "board:manage",
+	"createFreezeWindow":           "board:manage",
+	"updateFreezeWindow":           "board:manage",
+	"deleteFreezeWindow":           "board:manage",
+	"createColumn":                 "board:manage",
+	"updateColumn":                 "board:manage",
+	"reorderColumns":               "board:manage",
+	"toggleColumnVisibility":       "board:manage",
+	"deleteColumn":                 "board:manage",
+	"setColumnTransitions":         "board:manage",
+	"updateColumnAlertSettings":    "board:manage",
+	"boardHeartbeat":               "board:view",
+	"leaveBoard":                   "board:view",
+	"generateBoardSnapshotImage":   "board:view",
+	"watchColumn":                  "board:view",
+	"unwatchColumn":                "board:view",
+
+	// Sprints
+	"createSprint":          "sprint:manage",
+	"updateSprint":          "sprint:manage",
+	"deleteSprint":          "sprint:manage",
+	"startSprint":           "sprint:manage",
+	"completeSprint":        "sprint:manage",
+	"reopenSprint":          "sprint:manage",
+	"generateSprintSummary": "sprint:manage",
+	"undoOperation":         "sprint:manage",
+
+	// Cards
+	"createCard":               "card:create",
+	"createCardsFromText":      "card:create",
+	"draftCard":                "card:create",
+	"importCards":              "card:create",
+	"importProjectCards":       "card:create",
+	"createEpic":               "card:create",
+	"mirrorCard":               "card:create",
+	"splitCard":                "card:create",
+	"updateCard":               "card:edit",
+	"unarchiveCard":            "card:edit",
+	"requestAttachmentUpload":  "card:edit",
+	"completeAttachmentUpload": "card:edit",
+	"deleteCardAttachment":     "card:edit",
+	"createChecklistItem":      "card:edit",
+	"updateChecklistItem":      "card:edit",
+	"reorderChecklistItems":    "card:edit",
+	"deleteChecklistItem":      "card:edit",
+	"addCardDependency":        "card:edit",
+	"removeCardDependency":     "card:edit",
+	"updateEpic":               "card:edit",
+	"setCardEpic":              "card:edit",
+	"acceptLabelSuggestions":   "card:edit",
+	"mergeCards":               "card:edit",
+	"setCardMirrorDirection":   "card:edit",
+	"removeCardMirror":         "card:edit",
+	"submitOfflineMutations":   "card:edit",
+	"deleteCard":               "card:delete",
+	"deleteEpic":               "card:delete",
+	"moveCard":                 "card:move",
+	"broadcastCardDrag":        "card:move",
+	"addCardToSprint":          "card:move",
+	"removeCardFromSprint":     "card:move",
+	"setCardSprints":           "card:move",
+	"moveCardToBacklog":        "card:move",
+	"createCardComment":        "card:view",
+	"updateCardComment":        "card:view",
+	"deleteCardComment":        "card:view",
+	"markCardViewed":           "card:view",
+	"exportCardsPdf":           "card:view",
+
+	// The caller's own account and the instance
+	"acceptInvitation":         "account:manage",
+	"setMyLocale":              "account:manage",
+	"markMentionsRead":         "account:manage",
+	"markNotificationRead":     "account:manage",
+	"markAllNotificationsRead": "account:manage",
+	"seedDemoData":             "account:manage",
+	"updateInstanceSettings":   "instance:manage",
+}
+
+// APITokenGuard keeps requests authenticated with an API token to their token's scope where
+// permission checks don't: read-only tokens run no mutations, no token runs the mutations
+// that need a session, and any other mutation runs only if the token's scope allows the
+// permission it maps to
+type APITokenGuard struct{}
+
+// ExtensionName returns the name of the extension
+func (APITokenGuard) ExtensionName() string {
+	return "APITokenGuard"
+}
+
+// Validate validates the extension configuration
+func (APITokenGuard) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField rejects the mutations the request's API token may not run
+func (APITokenGuard) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc.Field.ObjectDefinition.Name != "Mutation" {
+		return next(ctx)
+	}
+	token := apitoken.FromContext(ctx)
+	if token == nil {
+		return next(ctx)
+	}
+
+	permission, ok := mutationPermissions[fc.Field.Name]
+	if !ok || sessionMutations[fc.Field.Name] || token.Scope == api_token.ScopeReadOnly || !token.Scope.Allows(permission) {
+		return nil, ErrAPITokenScope
+	}
+	return next(ctx)
+}
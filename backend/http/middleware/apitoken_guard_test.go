@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestAPITokenGuard(t *testing.T) {
+	resolve := func(ctx context.Context, object, field string, scope api_token.Scope) error {
+		if scope != "" {
+			ctx = apitoken.WithToken(ctx, &api_token.APIToken{Scope: scope})
+		}
+		ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Field: graphql.CollectedField{Field: &ast.Field{
+				Name:             field,
+				ObjectDefinition: &ast.Definition{Name: object},
+			}},
+		})
+		_, err := APITokenGuard{}.InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+			return true, nil
+		})
+		return err
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		object string
+		field  string
+		scope  api_token.Scope
+		err    error
+	}{
+		{"session runs any mutation", "Mutation", "createPersonalAccessToken", "", nil},
+		{"read-only token queries", "Query", "board", api_token.ScopeReadOnly, nil},
+		{"read-only token mutates", "Mutation", "moveCard", api_token.ScopeReadOnly, ErrAPITokenScope},
+		{"card write token mutates", "Mutation", "moveCard", api_token.ScopeCardWrite, nil},
+		{"card write token creates an organization", "Mutation", "createOrganization", api_token.ScopeCardWrite, ErrAPITokenScope},
+		{"admin token creates an organization", "Mutation", "createOrganization", api_token.ScopeAdmin, nil},
+		{"admin token mints a token", "Mutation", "createPersonalAccessToken", api_token.ScopeAdmin, ErrAPITokenScope},
+		{"admin token updates the account", "Mutation", "updateMe", api_token.ScopeAdmin, ErrAPITokenScope},
+		{"card write token updates instance settings", "Mutation", "updateInstanceSettings", api_token.ScopeCardWrite, ErrAPITokenScope},
+		{"card write token accepts an invitation", "Mutation", "acceptInvitation", api_token.ScopeCardWrite, ErrAPITokenScope},
+		{"card write token sets the locale", "Mutation", "setMyLocale", api_token.ScopeCardWrite, ErrAPITokenScope},
+		{"card write token comments", "Mutation", "createCardComment", api_token.ScopeCardWrite, nil},
+		{"admin token runs an unknown mutation", "Mutation", "noSuchMutation", api_token.ScopeAdmin, ErrAPITokenScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, resolve(ctx, tt.object, tt.field, tt.scope), tt.err)
+		})
+	}
+}
+
+func TestAPITokenGuardCoversSchema(t *testing.T) {
+	scopes := []api_token.Scope{api_token.ScopeReadOnly, api_token.ScopeCardWrite, api_token.ScopeAdmin}
+	schema := generated.NewExecutableSchema(generated.Config{}).Schema()
+
+	for _, field := range schema.Mutation.Fields {
+		permission, mapped := mutationPermissions[field.Name]
+		assert.True(t, mapped != sessionMutations[field.Name],
+			"%s must either map to a permission or need a session", field.Name)
+
+		for _, scope := range scopes {
+			ctx := apitoken.WithToken(context.Background(), &api_token.APIToken{Scope: scope})
+			ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+				Field: graphql.CollectedField{Field: &ast.Field{Name: field.Name, ObjectDefinition: schema.Mutation}},
+			})
+			_, err := APITokenGuard{}.InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+				return true, nil
+			})
+
+			allowed := mapped && scope != api_token.ScopeReadOnly && scope.Allows(permission)
+			if allowed {
+				assert.NoError(t, err, "%s token running %s", scope, field.Name)
+			} else {
+				assert.ErrorIs(t, err, ErrAPITokenScope, "%s token running %s", scope, field.Name)
+			}
+			if scope == api_token.ScopeCardWrite && allowed {
+				assert.Regexp(t, `^card:|:view$`, permission, "card write token running %s", field.Name)
+			}
+		}
+	}
+
+	for name := range mutationPermissions {
+		assert.NotNil(t, schema.Mutation.Fields.ForName(name), "%s is not a mutation", name)
+	}
+}
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	"go.opentelemetry.io/otel/trace"
@@ -15,7 +14,7 @@ func AuditContextMiddleware() func(http.Handler) http.Handler {
 			ctx := r.Context()
 
 			// Extract IP address
-			ipAddress := extractIPAddress(r)
+			ipAddress := GetClientIP(r)
 
 			// Extract user agent
 			userAgent := r.UserAgent()
@@ -38,28 +37,3 @@ func AuditContextMiddleware() func(http.Handler) http.Handler {
 		})
 	}
 }
-
-// extractIPAddress extracts the client IP address from the request
-func extractIPAddress(r *http.Request) string {
-	// Check X-Forwarded-For header (for proxies/load balancers)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		// X-Forwarded-For can contain multiple IPs, take the first one (client IP)
-		if idx := strings.Index(xff, ","); idx != -1 {
-			return strings.TrimSpace(xff[:idx])
-		}
-		return strings.TrimSpace(xff)
-	}
-
-	// Check X-Real-IP header
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return strings.TrimSpace(xri)
-	}
-
-	// Fall back to RemoteAddr
-	// RemoteAddr is in the form "IP:port", extract just the IP
-	addr := r.RemoteAddr
-	if idx := strings.LastIndex(addr, ":"); idx != -1 {
-		return addr[:idx]
-	}
-	return addr
-}
@@ -6,6 +6,10 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 )
 
@@ -45,7 +49,10 @@ func SetCookieConfig(domain string, secure bool) {
 	}
 }
 
-func AuthMiddleware(authService auth.Service) func(http.Handler) http.Handler {
+// AuthMiddleware puts the request's user on its context. An API token sent as
+// "Authorization: Bearer" takes the place of the session cookie, and narrows what the
+// request may do to the token's scope.
+func AuthMiddleware(authService auth.Service, apiTokenService apitoken.Service) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Add response writer to context for cookie setting
@@ -55,12 +62,22 @@ func AuthMiddleware(authService auth.Service) func(http.Handler) http.Handler {
 			ctx = context.WithValue(ctx, UserAgentKey, r.Header.Get("User-Agent"))
 			ctx = context.WithValue(ctx, IPAddressKey, GetClientIP(r))
 
-			// Try to get access token from cookie
-			cookie, err := r.Cookie(AccessTokenCookie)
-			if err == nil && cookie.Value != "" {
+			bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if apiTokenService != nil && apitoken.IsAPIToken(bearer) {
+				token, err := apiTokenService.Authenticate(ctx, bearer)
+				if err == nil {
+					ctx = context.WithValue(ctx, UserIDKey, token.UserID)
+					ctx = events.WithActor(ctx, token.UserID)
+					ctx = audit.WithAPITokenAuthentication(ctx, token.ID)
+					ctx = apitoken.WithToken(ctx, token)
+				}
+			} else if cookie, err := r.Cookie(AccessTokenCookie); err == nil && cookie.Value != "" {
+				// Try to get access token from cookie
 				claims, err := authService.ValidateToken(cookie.Value)
 				if err == nil {
 					ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+					ctx = events.WithActor(ctx, claims.UserID)
+					ctx = audit.WithAuthentication(ctx, auditrepo.AuthMethodSession, claims.ImpersonatorID)
 				}
 			}
 
@@ -184,30 +201,3 @@ func ClearAuthCookies(w http.ResponseWriter) {
 func ClearAuthCookie(w http.ResponseWriter) {
 	ClearAuthCookies(w)
 }
-
-// GetClientIP extracts the client IP address from the request
-func GetClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (for proxied requests)
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		// X-Forwarded-For can contain multiple IPs, the first one is the client
-		ips := strings.Split(xff, ",")
-		if len(ips) > 0 {
-			return strings.TrimSpace(ips[0])
-		}
-	}
-
-	// Check X-Real-IP header
-	xri := r.Header.Get("X-Real-IP")
-	if xri != "" {
-		return xri
-	}
-
-	// Fall back to RemoteAddr
-	// RemoteAddr is in the format "IP:port" or "[IPv6]:port"
-	addr := r.RemoteAddr
-	if idx := strings.LastIndex(addr, ":"); idx != -1 {
-		return addr[:idx]
-	}
-	return addr
-}
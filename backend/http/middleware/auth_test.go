@@ -10,6 +10,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	apitokenMocks "github.com/thatcatdev/kaimu/backend/internal/services/apitoken/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth/mocks"
 	"go.uber.org/mock/gomock"
@@ -30,7 +35,7 @@ func TestAuthMiddleware_WithValidCookie(t *testing.T) {
 
 	mockAuth.EXPECT().ValidateToken("valid-token").Return(claims, nil)
 
-	middleware := AuthMiddleware(mockAuth)
+	middleware := AuthMiddleware(mockAuth, nil)
 
 	var capturedUserID *uuid.UUID
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -49,6 +54,29 @@ func TestAuthMiddleware_WithValidCookie(t *testing.T) {
 	assert.Equal(t, userID, *capturedUserID)
 }
 
+func TestAuthMiddleware_RecordsSessionForAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuth := mocks.NewMockService(ctrl)
+	mockAuth.EXPECT().ValidateToken("valid-token").Return(&auth.Claims{UserID: uuid.New()}, nil)
+
+	var reqCtx *audit.RequestContext
+	handler := AuditContextMiddleware()(AuthMiddleware(mockAuth, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCtx = audit.GetRequestContext(r.Context())
+	})))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: "valid-token"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotNil(t, reqCtx)
+	assert.Equal(t, "test-agent", reqCtx.UserAgent)
+	assert.Equal(t, auditrepo.AuthMethodSession, reqCtx.AuthMethod)
+	assert.Nil(t, reqCtx.ImpersonatorID)
+}
+
 func TestAuthMiddleware_WithInvalidCookie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -57,7 +85,7 @@ func TestAuthMiddleware_WithInvalidCookie(t *testing.T) {
 
 	mockAuth.EXPECT().ValidateToken("invalid-token").Return(nil, auth.ErrInvalidToken)
 
-	middleware := AuthMiddleware(mockAuth)
+	middleware := AuthMiddleware(mockAuth, nil)
 
 	var capturedUserID *uuid.UUID
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -82,7 +110,7 @@ func TestAuthMiddleware_WithNoCookie(t *testing.T) {
 
 	mockAuth := mocks.NewMockService(ctrl)
 
-	middleware := AuthMiddleware(mockAuth)
+	middleware := AuthMiddleware(mockAuth, nil)
 
 	var capturedUserID *uuid.UUID
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -179,3 +207,55 @@ func TestGetResponseWriter_NoWriter(t *testing.T) {
 
 	assert.Nil(t, w)
 }
+
+func TestAuthMiddleware_WithAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuth := mocks.NewMockService(ctrl)
+	mockTokens := apitokenMocks.NewMockService(ctrl)
+	token := &api_token.APIToken{ID: uuid.New(), UserID: uuid.New(), Scope: api_token.ScopeReadOnly}
+	mockTokens.EXPECT().Authenticate(gomock.Any(), "kaimu_pat_secret").Return(token, nil)
+
+	var capturedUserID *uuid.UUID
+	var capturedToken *api_token.APIToken
+	var reqCtx *audit.RequestContext
+	handler := AuditContextMiddleware()(AuthMiddleware(mockAuth, mockTokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedUserID = GetUserIDFromContext(r.Context())
+		capturedToken = apitoken.FromContext(r.Context())
+		reqCtx = audit.GetRequestContext(r.Context())
+	})))
+
+	// The session cookie is not consulted when an API token is sent
+	req := httptest.NewRequest("POST", "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer kaimu_pat_secret")
+	req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: "valid-token"})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, &token.UserID, capturedUserID)
+	assert.Same(t, token, capturedToken)
+	assert.Equal(t, auditrepo.AuthMethodAPIToken, reqCtx.AuthMethod)
+	assert.Equal(t, &token.ID, reqCtx.APITokenID)
+}
+
+func TestAuthMiddleware_WithInvalidAPIToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuth := mocks.NewMockService(ctrl)
+	mockTokens := apitokenMocks.NewMockService(ctrl)
+	mockTokens.EXPECT().Authenticate(gomock.Any(), "kaimu_svc_revoked").Return(nil, apitoken.ErrInvalidToken)
+
+	var capturedUserID *uuid.UUID
+	handler := AuthMiddleware(mockAuth, mockTokens)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedUserID = GetUserIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("POST", "/graphql", nil)
+	req.Header.Set("Authorization", "Bearer kaimu_svc_revoked")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, capturedUserID)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Trusted reverse proxies (set at startup)
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the reverse proxies whose X-Forwarded-For and X-Real-IP headers
+// are believed. Each entry is a CIDR such as 10.0.0.0/8 or a single IP.
+func SetTrustedProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return fmt.Errorf("invalid trusted proxy %q", p)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientIP returns the IP address of the client that made the request. Forwarding
+// headers are only believed from trusted proxies: X-Forwarded-For is read from the nearest
+// hop back, and the first address that is not a trusted proxy is the client, so addresses
+// a client puts in the header itself are never used.
+func GetClientIP(r *http.Request) string {
+	// RemoteAddr is in the format "IP:port" or "[IPv6]:port"
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	client := net.ParseIP(host)
+	if client == nil {
+		return host
+	}
+	if !isTrustedProxy(client) {
+		return client.String()
+	}
+
+	// Each proxy appends the address it got the request from, across any number of headers
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	if len(hops) == 0 {
+		if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+			return ip.String()
+		}
+		return client.String()
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// A malformed hop was not written by a trusted proxy; the hop after it is the
+			// last address known to be real
+			break
+		}
+		client = ip
+		if !isTrustedProxy(ip) {
+			break
+		}
+	}
+	return client.String()
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClientIP(t *testing.T) {
+	require.NoError(t, SetTrustedProxies([]string{"10.0.0.0/8", "192.0.2.7", "fd00::/8"}))
+	t.Cleanup(func() { trustedProxies = nil })
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		xRealIP    string
+		want       string
+	}{
+		{"direct client", "203.0.113.5:5123", nil, "", "203.0.113.5"},
+		{"direct client forging headers", "203.0.113.5:5123", []string{"198.51.100.1"}, "198.51.100.2", "203.0.113.5"},
+		{"direct IPv6 client", "[2001:db8::1]:443", nil, "", "2001:db8::1"},
+		{"behind a load balancer", "10.1.2.3:40000", []string{"198.51.100.1"}, "", "198.51.100.1"},
+		{"behind a chain of proxies", "10.1.2.3:40000", []string{"198.51.100.1, 192.0.2.7, 10.9.9.9"}, "", "198.51.100.1"},
+		{"client prepends a forged hop", "10.1.2.3:40000", []string{"1.1.1.1, 198.51.100.1"}, "", "198.51.100.1"},
+		{"hops across several headers", "10.1.2.3:40000", []string{"198.51.100.1", "10.9.9.9"}, "", "198.51.100.1"},
+		{"malformed hop", "10.1.2.3:40000", []string{"198.51.100.1, not-an-ip, 10.9.9.9"}, "", "10.9.9.9"},
+		{"only proxies", "10.1.2.3:40000", []string{"10.9.9.9, 10.8.8.8"}, "", "10.9.9.9"},
+		{"X-Real-IP from a proxy", "10.1.2.3:40000", nil, "198.51.100.3", "198.51.100.3"},
+		{"IPv6 proxy", "[fd00::2]:40000", []string{"2001:db8::9"}, "", "2001:db8::9"},
+		{"proxy without headers", "192.0.2.7:40000", nil, "", "192.0.2.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, xff := range tt.xff {
+				req.Header.Add("X-Forwarded-For", xff)
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+
+			assert.Equal(t, tt.want, GetClientIP(req))
+		})
+	}
+}
+
+func TestGetClientIPWithoutTrustedProxies(t *testing.T) {
+	require.NoError(t, SetTrustedProxies(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.RemoteAddr = "10.1.2.3:40000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	assert.Equal(t, "10.1.2.3", GetClientIP(req))
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	t.Cleanup(func() { trustedProxies = nil })
+
+	assert.NoError(t, SetTrustedProxies([]string{"127.0.0.0/8", "::1", "172.16.0.1"}))
+	assert.Error(t, SetTrustedProxies([]string{"10.0.0.0/33"}))
+	assert.Error(t, SetTrustedProxies([]string{"load-balancer"}))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                     "",
+		"gzip":                 "gzip",
+		"gzip, deflate, br":    "br",
+		"br;q=0, gzip":         "gzip",
+		"gzip;q=0.0, deflate":  "",
+		"BR;q=0.5, gzip;q=1.0": "br",
+		"identity":             "",
+	}
+	for acceptEncoding, want := range tests {
+		assert.Equal(t, want, negotiateEncoding(acceptEncoding), acceptEncoding)
+	}
+}
+
+func TestGzipMiddleware_Brotli(t *testing.T) {
+	body := "Hello, World! This is a test response that should be compressed."
+
+	t.Run("compresses with brotli when accepted", func(t *testing.T) {
+		handler := GzipMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+		req.Header.Set("Accept-Encoding", "gzip, br")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		decompressed, err := io.ReadAll(brotli.NewReader(rec.Body))
+		require.NoError(t, err)
+		assert.Equal(t, body, string(decompressed))
+	})
+
+	t.Run("leaves 304 responses empty", func(t *testing.T) {
+		handler := GzipMiddleware()(ETagMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		})))
+
+		req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+		req.Header.Set("Accept-Encoding", "br")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		etag := rec.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		req = httptest.NewRequest(http.MethodGet, "/graphql", nil)
+		req.Header.Set("Accept-Encoding", "br")
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Zero(t, rec.Body.Len())
+	})
+}
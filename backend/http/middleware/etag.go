@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ETagMiddleware makes GET responses revalidatable. It buffers successful responses, tags
+// them with a hash of the body and answers a matching If-None-Match with 304 Not Modified,
+// so clients and CDNs re-download a query result only when it changed. GraphQL only
+// accepts queries over GET, so only idempotent results are tagged.
+func ETagMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || isWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buffered := &etagResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(buffered, r)
+
+			if buffered.statusCode != http.StatusOK {
+				w.WriteHeader(buffered.statusCode)
+				_, _ = w.Write(buffered.body.Bytes())
+				return
+			}
+
+			sum := sha256.Sum256(buffered.body.Bytes())
+			// Weak, because compression changes the bytes on the wire but not the result
+			etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+			w.Header().Set("ETag", etag)
+			if w.Header().Get("Cache-Control") == "" {
+				// Results depend on the caller's permissions, so shared caches must keep them
+				// per user and everyone must revalidate before reuse
+				if GetUserIDFromContext(r.Context()) != nil {
+					w.Header().Set("Cache-Control", "private, no-cache")
+				} else {
+					w.Header().Set("Cache-Control", "no-cache")
+				}
+			}
+			w.Header().Add("Vary", "Authorization, Cookie, Accept-Language")
+
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(buffered.body.Bytes())
+		})
+	}
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using the weak
+// comparison RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// etagResponseWriter holds the response back until its ETag is known
+type etagResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *etagResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = code
+}
+
+func (w *etagResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagMiddleware(t *testing.T) {
+	body := `{"data":{"board":{"id":"1"}}}`
+	handler := ETagMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/graphql?query={board{id}}", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("tags GET responses", func(t *testing.T) {
+		rec := get("")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, body, rec.Body.String())
+		assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, rec.Header().Get("ETag"))
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("same result, same tag", func(t *testing.T) {
+		assert.Equal(t, get("").Header().Get("ETag"), get("").Header().Get("ETag"))
+	})
+
+	t.Run("matching If-None-Match returns 304", func(t *testing.T) {
+		etag := get("").Header().Get("ETag")
+
+		for _, ifNoneMatch := range []string{etag, `"other", ` + etag, etag[2:], "*"} {
+			rec := get(ifNoneMatch)
+			assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+			assert.Empty(t, rec.Body.String())
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+		}
+	})
+
+	t.Run("stale If-None-Match returns the body", func(t *testing.T) {
+		rec := get(`W/"stale"`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, body, rec.Body.String())
+	})
+
+	t.Run("authenticated results are private", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/graphql?query={board{id}}", nil)
+		req = req.WithContext(context.WithValue(req.Context(), UserIDKey, uuid.New()))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("POST is passed through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("ETag"))
+		assert.Equal(t, body, rec.Body.String())
+	})
+
+	t.Run("errors are not tagged", func(t *testing.T) {
+		failing := ETagMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+		rec := httptest.NewRecorder()
+		failing.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get("ETag"))
+		assert.Equal(t, "boom\n", rec.Body.String())
+	})
+}
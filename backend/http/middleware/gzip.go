@@ -4,21 +4,25 @@ import (
 	"compress/gzip"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/andybalholm/brotli"
 	"github.com/thatcatdev/kaimu/backend/internal/logger"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// GzipMiddleware handles both request decompression and response compression
+// GzipMiddleware handles both gzip request decompression and response compression,
+// preferring brotli over gzip when the client accepts both
 // Excludes certain endpoints like /metrics that should not be compressed
 func GzipMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip compression for metrics endpoint and other endpoints that shouldn't be compressed
-			if shouldSkipCompression(r.URL.Path) {
+			// Websocket upgrades need the raw connection, which the gzip writer can't hijack
+			if shouldSkipCompression(r.URL.Path) || isWebSocketUpgrade(r) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -57,8 +61,9 @@ func GzipMiddleware() func(http.Handler) http.Handler {
 				log.Debug().Msg("Decompressed gzip request body")
 			}
 
-			// Check if client accepts gzip encoding for response
-			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			// Pick the best response encoding the client accepts
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
 				span.SetAttributes(
 					attribute.Bool("compression.request_compressed", requestCompressed),
 					attribute.Bool("compression.response_compressed", false),
@@ -68,31 +73,31 @@ func GzipMiddleware() func(http.Handler) http.Handler {
 				return
 			}
 
-			// Wrap response writer with gzip compression
-			w.Header().Set("Content-Encoding", "gzip")
-			w.Header().Set("Vary", "Accept-Encoding")
+			// Wrap response writer with compression. The encoder is only created once the
+			// handler writes a status with a body, so 304 and 204 responses stay empty.
+			w.Header().Add("Vary", "Accept-Encoding")
 			responseCompressed = true
 
-			gzipWriter := gzip.NewWriter(w)
-			defer gzipWriter.Close()
-
-			gzipResponseWriter := &gzipResponseWriter{
+			compressResponseWriter := &gzipResponseWriter{
 				ResponseWriter: w,
-				Writer:         gzipWriter,
+				encoding:       encoding,
 			}
+			defer compressResponseWriter.Close()
 
 			span.SetAttributes(
 				attribute.Bool("compression.request_compressed", requestCompressed),
 				attribute.Bool("compression.response_compressed", responseCompressed),
+				attribute.String("compression.response_encoding", encoding),
 			)
 
 			log.Debug().
 				Bool("request_compressed", requestCompressed).
 				Bool("response_compressed", responseCompressed).
-				Msg("Gzip compression applied")
+				Str("response_encoding", encoding).
+				Msg("Response compression applied")
 
 			r = r.WithContext(ctx)
-			next.ServeHTTP(gzipResponseWriter, r)
+			next.ServeHTTP(compressResponseWriter, r)
 		})
 	}
 }
@@ -113,21 +118,92 @@ func shouldSkipCompression(path string) bool {
 	return false
 }
 
-// gzipResponseWriter wraps http.ResponseWriter to provide gzip compression
+// isWebSocketUpgrade reports whether the request asks to switch to the websocket protocol
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// negotiateEncoding returns the response encoding to use for an Accept-Encoding header:
+// "br" or "gzip", or "" when the client accepts neither
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		// q=0 means the client refuses the encoding
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	switch {
+	case accepted["br"]:
+		return "br"
+	case accepted["gzip"]:
+		return "gzip"
+	}
+	return ""
+}
+
+// compressWriter is an encoder that can flush buffered output, as gzip and brotli writers do
+type compressWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// gzipResponseWriter wraps http.ResponseWriter to provide gzip or brotli compression
 type gzipResponseWriter struct {
 	http.ResponseWriter
-	Writer io.Writer
+	encoding    string
+	Writer      compressWriter
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if code != http.StatusNotModified && code != http.StatusNoContent {
+		w.Header().Set("Content-Encoding", w.encoding)
+		// The length of the uncompressed body no longer applies
+		w.Header().Del("Content-Length")
+		if w.encoding == "br" {
+			w.Writer = brotli.NewWriter(w.ResponseWriter)
+		} else {
+			w.Writer = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
 }
 
 func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.Writer == nil {
+		return w.ResponseWriter.Write(b)
+	}
 	return w.Writer.Write(b)
 }
 
 func (w *gzipResponseWriter) Flush() {
-	if flusher, ok := w.Writer.(*gzip.Writer); ok {
-		flusher.Flush()
+	if w.Writer != nil {
+		_ = w.Writer.Flush()
 	}
 	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
 		flusher.Flush()
 	}
 }
+
+// Close finishes the compressed stream. Responses the handler never wrote to are left
+// empty rather than sent as an empty compressed stream.
+func (w *gzipResponseWriter) Close() error {
+	if w.Writer == nil {
+		return nil
+	}
+	return w.Writer.Close()
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+)
+
+// LocaleMiddleware writes request-scoped messages, such as validation errors, in the
+// language the client asked for with Accept-Language. Emails and other messages sent
+// outside a request use the recipient's stored preference instead.
+func LocaleMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if locale := i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language")); locale != "" {
+				r = r.WithContext(i18n.WithLocale(r.Context(), locale))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
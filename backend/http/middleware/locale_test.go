@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	var got string
+	handler := LocaleMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = i18n.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{"regional tag", "es-MX,es;q=0.9", "es"},
+		{"weighted preference", "fr;q=0.9,de;q=0.8", "de"},
+		{"unsupported language", "ja", i18n.DefaultLocale},
+		{"no header", "", i18n.DefaultLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
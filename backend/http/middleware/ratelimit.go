@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/ratelimit"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// authMutations can be used to guess passwords or mass-create accounts, so they are limited
+// per client IP on top of the request limits
+var authMutations = map[string]bool{
+	"login":    true,
+	"register": true,
+}
+
+// RateLimitMiddleware limits requests per user, or per client IP for anonymous requests, and
+// reports the caller's bucket in RateLimit headers. Requests over the limit get a 429 with
+// Retry-After. It must run after AuthMiddleware.
+func RateLimitMiddleware(limiter ratelimit.Service, userLimit, ipLimit ratelimit.Limit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, limit := "ip:"+GetClientIP(r), ipLimit
+			if userID := GetUserIDFromContext(r.Context()); userID != nil {
+				key, limit = "user:"+userID.String(), userLimit
+			}
+
+			result := limiter.Allow(r.Context(), key, limit)
+			setRateLimitHeaders(w, limit, result)
+			if !result.Allowed {
+				writeRateLimited(w, r, result)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthRateLimit limits login and register attempts per client IP, replacing the request's
+// RateLimit headers with those of the stricter bucket
+type AuthRateLimit struct {
+	Limiter ratelimit.Service
+	Limit   ratelimit.Limit
+}
+
+// ExtensionName returns the name of the extension
+func (AuthRateLimit) ExtensionName() string {
+	return "AuthRateLimit"
+}
+
+// Validate validates the extension configuration
+func (AuthRateLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField rejects login and register attempts over the limit
+func (a AuthRateLimit) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc.Field.ObjectDefinition.Name != "Mutation" || !authMutations[fc.Field.Name] {
+		return next(ctx)
+	}
+
+	result := a.Limiter.Allow(ctx, "auth:"+GetIPAddressFromContext(ctx), a.Limit)
+	if w := GetResponseWriter(ctx); w != nil {
+		setRateLimitHeaders(w, a.Limit, result)
+	}
+	if !result.Allowed {
+		return nil, rateLimitedError(ctx, result)
+	}
+	return next(ctx)
+}
+
+// setRateLimitHeaders describes the bucket in the RateLimit headers of the IETF draft: the
+// burst, the requests left, and the seconds until the bucket is full again
+func setRateLimitHeaders(w http.ResponseWriter, limit ratelimit.Limit, result ratelimit.Result) {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	// The policy's window is how long an empty bucket takes to refill its burst
+	window := time.Duration(limit.Burst) * time.Minute / time.Duration(limit.PerMinute)
+	h.Set("RateLimit-Policy", strconv.Itoa(limit.Burst)+";w="+strconv.Itoa(ceilSeconds(window)))
+	if !result.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+	}
+}
+
+// writeRateLimited answers a request over the limit with a GraphQL error
+func writeRateLimited(w http.ResponseWriter, r *http.Request, result ratelimit.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(graphql.Response{
+		Errors: gqlerror.List{rateLimitedError(r.Context(), result)},
+	})
+}
+
+// rateLimitedError exposes a rate limit with a machine-readable code and when to retry
+func rateLimitedError(ctx context.Context, result ratelimit.Result) *gqlerror.Error {
+	seconds := ceilSeconds(result.RetryAfter)
+	return &gqlerror.Error{
+		Message: i18n.Tc(ctx, "errors.rate_limited", map[string]string{
+			"seconds": strconv.Itoa(seconds),
+		}),
+		Extensions: map[string]interface{}{
+			"code":       "RATE_LIMITED",
+			"retryAfter": seconds,
+		},
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
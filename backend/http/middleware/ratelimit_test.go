@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/services/ratelimit"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter := ratelimit.NewService(nil)
+	handler := RateLimitMiddleware(limiter, ratelimit.PerMinute(60, 2), ratelimit.PerMinute(60, 1))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	send := func(remoteAddr string, userID *uuid.UUID) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.RemoteAddr = remoteAddr
+		if userID != nil {
+			req = req.WithContext(context.WithValue(req.Context(), UserIDKey, *userID))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("anonymous requests are limited per IP", func(t *testing.T) {
+		rec := send("203.0.113.1:1234", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "1;w=1", rec.Header().Get("RateLimit-Policy"))
+
+		rec = send("203.0.113.1:5678", nil)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		var body graphql.Response
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "RATE_LIMITED", body.Errors[0].Extensions["code"])
+
+		assert.Equal(t, http.StatusOK, send("203.0.113.2:1234", nil).Code)
+	})
+
+	t.Run("signed-in requests are limited per user", func(t *testing.T) {
+		userID := uuid.New()
+		rec := send("203.0.113.1:1234", &userID)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, http.StatusOK, send("203.0.113.3:1234", &userID).Code)
+		assert.Equal(t, http.StatusTooManyRequests, send("203.0.113.4:1234", &userID).Code)
+	})
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	guard := AuthRateLimit{Limiter: ratelimit.NewService(nil), Limit: ratelimit.PerMinute(1, 2)}
+	resolve := func(field, ip string) (*httptest.ResponseRecorder, error) {
+		rec := httptest.NewRecorder()
+		ctx := context.WithValue(context.Background(), ResponseKey, http.ResponseWriter(rec))
+		ctx = context.WithValue(ctx, IPAddressKey, ip)
+		ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Field: graphql.CollectedField{Field: &ast.Field{
+				Name:             field,
+				ObjectDefinition: &ast.Definition{Name: "Mutation"},
+			}},
+		})
+		_, err := guard.InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+			return true, nil
+		})
+		return rec, err
+	}
+
+	for range 2 {
+		_, err := resolve("login", "203.0.113.1")
+		require.NoError(t, err)
+	}
+
+	t.Run("register shares the bucket", func(t *testing.T) {
+		rec, err := resolve("register", "203.0.113.1")
+		var gqlErr *gqlerror.Error
+		require.ErrorAs(t, err, &gqlErr)
+		assert.Equal(t, "RATE_LIMITED", gqlErr.Extensions["code"])
+		assert.Equal(t, 60, gqlErr.Extensions["retryAfter"])
+		assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("other mutations aren't limited", func(t *testing.T) {
+		rec, err := resolve("createCard", "203.0.113.1")
+		assert.NoError(t, err)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("other IPs have their own bucket", func(t *testing.T) {
+		_, err := resolve("login", "203.0.113.2")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// streamKey holds the streamLifter TimeoutMiddleware leaves for LiftForStream
+const streamKey contextKey = "stream"
+
+// streamLifter lifts a request's timeouts once the server knows its response streams
+type streamLifter struct {
+	rc      *http.ResponseController
+	untimed context.Context
+}
+
+// TimeoutMiddleware gives each request a context deadline of timeout (none when 0), except
+// websocket upgrades, which hijack the connection and so already shed the server's deadlines.
+// Responses that stream, which only the server can tell once it has read and parsed the
+// operation, lift the deadlines with LiftForStream.
+//
+// It must wrap the router rather than be added to it, as deadlines can only be lifted on
+// the server's own ResponseWriter.
+func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			untimed := r.Context()
+			ctx := context.WithValue(untimed, streamKey, &streamLifter{
+				rc:      http.NewResponseController(w),
+				untimed: untimed,
+			})
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// LiftForStream lifts the server's read and write deadlines and the request's context
+// deadline for a response that streams, returning a context that keeps ctx's values without
+// its deadline. Call it only once the request body has been read, so the read deadline still
+// bounds the body; it's then lifted too, as the server's background read would otherwise
+// cancel the request when it passes. Outside TimeoutMiddleware it returns ctx unchanged.
+func LiftForStream(ctx context.Context) context.Context {
+	lifter, ok := ctx.Value(streamKey).(*streamLifter)
+	if !ok {
+		return ctx
+	}
+	// Writers that don't support deadlines have none to lift
+	_ = lifter.rc.SetReadDeadline(time.Time{})
+	_ = lifter.rc.SetWriteDeadline(time.Time{})
+	return untimedContext{Context: lifter.untimed, values: ctx}
+}
+
+// untimedContext is cancelled with the request but not by TimeoutMiddleware's deadline,
+// while still carrying the values added after it
+type untimedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c untimedContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+// StreamTimeouts lifts the request's timeouts for subscriptions and operations using @defer,
+// whose responses stream for as long as they last. It decides from the parsed operation,
+// not from request headers a client controls.
+type StreamTimeouts struct{}
+
+// ExtensionName returns the name of the extension
+func (StreamTimeouts) ExtensionName() string {
+	return "StreamTimeouts"
+}
+
+// Validate validates the extension configuration
+func (StreamTimeouts) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation lifts the timeouts of operations that stream
+func (StreamTimeouts) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if op := graphql.GetOperationContext(ctx).Operation; op != nil &&
+		(op.Operation == ast.Subscription || defers(op.SelectionSet)) {
+		ctx = LiftForStream(ctx)
+	}
+	return next(ctx)
+}
+
+// defers reports whether a selection set, fragments included, uses @defer
+func defers(set ast.SelectionSet) bool {
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Directives.ForName("defer") != nil || defers(s.SelectionSet) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if s.Directives.ForName("defer") != nil || defers(s.SelectionSet) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if s.Directives.ForName("defer") != nil ||
+				(s.Definition != nil && defers(s.Definition.SelectionSet)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func TestTimeoutMiddleware_Deadline(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		headers     map[string]string
+		hasDeadline bool
+	}{
+		{"query", time.Minute, nil, true},
+		{"no timeout", 0, nil, false},
+		{"websocket upgrade", time.Minute, map[string]string{"Connection": "Upgrade", "Upgrade": "websocket"}, false},
+		{"event stream accept header", time.Minute, map[string]string{"Accept": "text/event-stream"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hasDeadline bool
+			handler := TimeoutMiddleware(tt.timeout)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, hasDeadline = r.Context().Deadline()
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.hasDeadline, hasDeadline)
+		})
+	}
+}
+
+func TestLiftForStream(t *testing.T) {
+	var lifted context.Context
+	handler := TimeoutMiddleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), UserIDKey, "user")
+		lifted = LiftForStream(ctx)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+
+	_, hasDeadline := lifted.Deadline()
+	assert.False(t, hasDeadline)
+	assert.Equal(t, "user", lifted.Value(UserIDKey), "values added after the middleware are kept")
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, LiftForStream(ctx), "unchanged outside the middleware")
+}
+
+func TestTimeoutMiddleware_LiftsDeadlinesForStreams(t *testing.T) {
+	server := httptest.NewUnstartedServer(TimeoutMiddleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		if r.URL.Path == "/stream" {
+			LiftForStream(r.Context())
+		}
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("data: done\n\n"))
+	})))
+	server.Config.ReadTimeout = 50 * time.Millisecond
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	post := func(path string) (string, error) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	body, err := post("/stream")
+	require.NoError(t, err)
+	assert.Equal(t, "data: done\n\n", body)
+
+	_, err = post("/graphql")
+	assert.Error(t, err, "an event stream accept header alone doesn't lift the write timeout")
+}
+
+func TestStreamTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		streams bool
+	}{
+		{"query", `query { me { id } }`, false},
+		{"mutation", `mutation { logout }`, false},
+		{"subscription", `subscription { boardUpdated(boardId: "1") { id } }`, true},
+		{"deferred fragment", `query { me { id ... @defer { email } } }`, true},
+		{"deferred nested fragment", `query { board(id: "1") { columns { ... @defer { name } } } }`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseQuery(&ast.Source{Input: tt.query})
+			require.NoError(t, err)
+
+			var hasDeadline bool
+			handler := TimeoutMiddleware(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := graphql.WithOperationContext(r.Context(), &graphql.OperationContext{Operation: doc.Operations[0]})
+				StreamTimeouts{}.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+					_, hasDeadline = ctx.Deadline()
+					return nil
+				})
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+
+			assert.Equal(t, !tt.streams, hasDeadline)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/google/uuid"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
+)
+
+var (
+	ErrWebSocketUnauthorized = errors.New("unauthorized")
+	ErrTooManyConnections    = errors.New("too many open connections")
+)
+
+// wsSlotKey holds the connection slot a websocket acquired at connection init
+const wsSlotKey contextKey = "wsSlot"
+
+// WebSocketAuth authenticates GraphQL websocket connections when the client sends
+// connection_init and limits how many connections each user may hold open.
+//
+// The user comes from an access token in the init payload ("authToken", or an
+// "Authorization: Bearer" entry), falling back to the cookie AuthMiddleware read from
+// the upgrade request.
+type WebSocketAuth struct {
+	authService auth.Service
+	maxPerUser  int
+
+	mu   sync.Mutex
+	open map[uuid.UUID]int
+}
+
+// NewWebSocketAuth creates a WebSocketAuth; maxPerUser <= 0 disables the connection limit
+func NewWebSocketAuth(authService auth.Service, maxPerUser int) *WebSocketAuth {
+	return &WebSocketAuth{
+		authService: authService,
+		maxPerUser:  maxPerUser,
+		open:        make(map[uuid.UUID]int),
+	}
+}
+
+// wsSlot identifies a websocket connection and releases its user's slot exactly once
+type wsSlot struct {
+	id     uuid.UUID
+	userID uuid.UUID
+	once   sync.Once
+}
+
+// InitFunc is the websocket transport's connection_init hook. It returns the per-connection
+// context every operation on the connection runs with.
+func (a *WebSocketAuth) InitFunc(ctx context.Context, payload transport.InitPayload) (context.Context, error) {
+	ctx, userID, err := a.authenticate(ctx, payload)
+	if err != nil {
+		return ctx, err
+	}
+
+	if !a.acquire(userID) {
+		return ctx, ErrTooManyConnections
+	}
+
+	ctx = context.WithValue(ctx, UserIDKey, userID)
+	ctx = events.WithActor(ctx, userID)
+	// The upgrade request's response writer is useless once the connection is hijacked
+	ctx = context.WithValue(ctx, ResponseKey, nil)
+	return context.WithValue(ctx, wsSlotKey, &wsSlot{id: uuid.New(), userID: userID}), nil
+}
+
+// CloseFunc is the websocket transport's close hook; it frees the connection's slot
+func (a *WebSocketAuth) CloseFunc(ctx context.Context, _ int) {
+	slot, ok := ctx.Value(wsSlotKey).(*wsSlot)
+	if !ok {
+		return
+	}
+	slot.once.Do(func() {
+		a.release(slot.userID)
+	})
+}
+
+// GetConnectionID returns the ID of the websocket connection an operation runs on, or nil
+// for plain HTTP requests
+func GetConnectionID(ctx context.Context) *uuid.UUID {
+	slot, ok := ctx.Value(wsSlotKey).(*wsSlot)
+	if !ok {
+		return nil
+	}
+	return &slot.id
+}
+
+// OpenConnections returns how many websocket connections the user holds open
+func (a *WebSocketAuth) OpenConnections(userID uuid.UUID) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.open[userID]
+}
+
+// authenticate returns the connection's user, recording on the context when they
+// authenticated with a token from the payload
+func (a *WebSocketAuth) authenticate(ctx context.Context, payload transport.InitPayload) (context.Context, uuid.UUID, error) {
+	token := payload.GetString("authToken")
+	if token == "" {
+		token = strings.TrimPrefix(payload.Authorization(), "Bearer ")
+	}
+
+	if token != "" {
+		claims, err := a.authService.ValidateToken(token)
+		if err != nil {
+			return ctx, uuid.Nil, ErrWebSocketUnauthorized
+		}
+		return audit.WithAuthentication(ctx, auditrepo.AuthMethodToken, claims.ImpersonatorID), claims.UserID, nil
+	}
+
+	if userID := GetUserIDFromContext(ctx); userID != nil {
+		return ctx, *userID, nil
+	}
+	return ctx, uuid.Nil, ErrWebSocketUnauthorized
+}
+
+func (a *WebSocketAuth) acquire(userID uuid.UUID) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.maxPerUser > 0 && a.open[userID] >= a.maxPerUser {
+		return false
+	}
+	a.open[userID]++
+	return true
+}
+
+func (a *WebSocketAuth) release(userID uuid.UUID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.open[userID] <= 1 {
+		delete(a.open, userID)
+		return
+	}
+	a.open[userID]--
+}
+
+// WebSocketOriginChecker allows upgrades from the CORS origins, and from clients that send
+// no Origin header (non-browser clients can't be driven by another site's cookies)
+func WebSocketOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || originAllowed(allowedOrigins, origin)
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
+	"github.com/thatcatdev/kaimu/backend/internal/services/auth/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebSocketAuth_InitFunc(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("authenticates with a token in the init payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockAuth := mocks.NewMockService(ctrl)
+		mockAuth.EXPECT().ValidateToken("payload-token").Return(&auth.Claims{UserID: userID}, nil)
+
+		wsAuth := NewWebSocketAuth(mockAuth, 0)
+		ctx, err := wsAuth.InitFunc(context.Background(), transport.InitPayload{"authToken": "payload-token"})
+		require.NoError(t, err)
+
+		assert.Equal(t, userID, *GetUserIDFromContext(ctx))
+		assert.Equal(t, userID, *events.ActorFromContext(ctx))
+		assert.NotNil(t, GetConnectionID(ctx))
+		assert.Nil(t, GetConnectionID(context.Background()))
+	})
+
+	t.Run("records token authentication for audit events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockAuth := mocks.NewMockService(ctrl)
+		staffID := uuid.New()
+		mockAuth.EXPECT().ValidateToken("payload-token").Return(&auth.Claims{UserID: userID, ImpersonatorID: &staffID}, nil)
+
+		upgradeCtx := audit.WithRequestContext(context.Background(), &audit.RequestContext{IPAddress: "10.0.0.1"})
+		wsAuth := NewWebSocketAuth(mockAuth, 0)
+		ctx, err := wsAuth.InitFunc(upgradeCtx, transport.InitPayload{"authToken": "payload-token"})
+		require.NoError(t, err)
+
+		reqCtx := audit.GetRequestContext(ctx)
+		require.NotNil(t, reqCtx)
+		assert.Equal(t, "10.0.0.1", reqCtx.IPAddress)
+		assert.Equal(t, auditrepo.AuthMethodToken, reqCtx.AuthMethod)
+		assert.Equal(t, &staffID, reqCtx.ImpersonatorID)
+		assert.Empty(t, audit.GetRequestContext(upgradeCtx).AuthMethod)
+	})
+
+	t.Run("accepts a bearer Authorization entry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockAuth := mocks.NewMockService(ctrl)
+		mockAuth.EXPECT().ValidateToken("bearer-token").Return(&auth.Claims{UserID: userID}, nil)
+
+		wsAuth := NewWebSocketAuth(mockAuth, 0)
+		ctx, err := wsAuth.InitFunc(context.Background(), transport.InitPayload{"Authorization": "Bearer bearer-token"})
+		require.NoError(t, err)
+		assert.Equal(t, userID, *GetUserIDFromContext(ctx))
+	})
+
+	t.Run("falls back to the cookie user of the upgrade request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		wsAuth := NewWebSocketAuth(mocks.NewMockService(ctrl), 0)
+
+		cookieCtx := context.WithValue(context.Background(), UserIDKey, userID)
+		ctx, err := wsAuth.InitFunc(cookieCtx, transport.InitPayload{})
+		require.NoError(t, err)
+		assert.Equal(t, userID, *GetUserIDFromContext(ctx))
+	})
+
+	t.Run("rejects an invalid token even with a cookie user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockAuth := mocks.NewMockService(ctrl)
+		mockAuth.EXPECT().ValidateToken("bad-token").Return(nil, auth.ErrInvalidToken)
+
+		wsAuth := NewWebSocketAuth(mockAuth, 0)
+		cookieCtx := context.WithValue(context.Background(), UserIDKey, userID)
+		_, err := wsAuth.InitFunc(cookieCtx, transport.InitPayload{"authToken": "bad-token"})
+		assert.ErrorIs(t, err, ErrWebSocketUnauthorized)
+	})
+
+	t.Run("rejects anonymous connections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		wsAuth := NewWebSocketAuth(mocks.NewMockService(ctrl), 0)
+
+		_, err := wsAuth.InitFunc(context.Background(), transport.InitPayload{})
+		assert.ErrorIs(t, err, ErrWebSocketUnauthorized)
+	})
+}
+
+func TestWebSocketAuth_ConnectionLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	wsAuth := NewWebSocketAuth(mocks.NewMockService(ctrl), 2)
+
+	userID := uuid.New()
+	cookieCtx := context.WithValue(context.Background(), UserIDKey, userID)
+
+	first, err := wsAuth.InitFunc(cookieCtx, transport.InitPayload{})
+	require.NoError(t, err)
+	_, err = wsAuth.InitFunc(cookieCtx, transport.InitPayload{})
+	require.NoError(t, err)
+
+	_, err = wsAuth.InitFunc(cookieCtx, transport.InitPayload{})
+	assert.ErrorIs(t, err, ErrTooManyConnections)
+	assert.Equal(t, 2, wsAuth.OpenConnections(userID))
+
+	// Other users are unaffected
+	otherCtx := context.WithValue(context.Background(), UserIDKey, uuid.New())
+	_, err = wsAuth.InitFunc(otherCtx, transport.InitPayload{})
+	require.NoError(t, err)
+
+	// Closing frees the slot, and closing twice doesn't free another
+	wsAuth.CloseFunc(first, 1000)
+	wsAuth.CloseFunc(first, 1000)
+	assert.Equal(t, 1, wsAuth.OpenConnections(userID))
+
+	// A connection rejected at init holds no slot
+	wsAuth.CloseFunc(cookieCtx, 1000)
+	assert.Equal(t, 1, wsAuth.OpenConnections(userID))
+
+	_, err = wsAuth.InitFunc(cookieCtx, transport.InitPayload{})
+	assert.NoError(t, err)
+}
+
+func TestWebSocketOriginChecker(t *testing.T) {
+	check := WebSocketOriginChecker([]string{"http://localhost:4321"})
+
+	allowed := httptest.NewRequest("GET", "/graphql", nil)
+	allowed.Header.Set("Origin", "http://localhost:4321")
+	assert.True(t, check(allowed))
+
+	foreign := httptest.NewRequest("GET", "/graphql", nil)
+	foreign.Header.Set("Origin", "https://evil.example.com")
+	assert.False(t, check(foreign))
+
+	assert.True(t, check(httptest.NewRequest("GET", "/graphql", nil)))
+}
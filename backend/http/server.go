@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/thatcatdev/kaimu/backend/config"
 	"github.com/thatcatdev/kaimu/backend/http/handlers"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/ratelimit"
 	"github.com/thatcatdev/kaimu/backend/metrics"
 	muxtrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorilla/mux"
 )
@@ -36,15 +38,31 @@ func SetupServerWithContext(ctx context.Context, cfg config.Config, deps *handle
 	// Configure cookie settings
 	middleware.SetCookieConfig(cfg.AppConfig.CookieDomain, cfg.AppConfig.CookieSecure)
 
+	// Configure the proxies allowed to tell the client IP
+	if err := middleware.SetTrustedProxies(cfg.AppConfig.GetTrustedProxies()); err != nil {
+		panic(fmt.Sprintf("invalid TRUSTED_PROXIES: %v", err))
+	}
+
 	// Add middleware to all routes - CORS must be first to handle preflight requests
 	router.Use(middleware.CORSMiddleware(cfg.AppConfig.GetCORSOrigins()))
 	router.Use(middleware.GzipMiddleware())
 	router.Use(middleware.TracingMiddleware())
 	router.Use(middleware.AuditContextMiddleware())
-	router.Use(middleware.AuthMiddleware(deps.AuthService))
+	router.Use(middleware.LocaleMiddleware())
+	router.Use(middleware.AuthMiddleware(deps.AuthService, deps.APITokenService))
 
 	router.Handle("/ui/playground", playground.Handler("GraphQL playground", "/graphql")).Methods("GET")
-	router.Handle("/graphql", handlers.BuildRootHandlerWithContext(ctx, cfg, deps)).Methods("POST", "OPTIONS")
+	// GET carries graphql-ws upgrades as well as GET queries
+	graphqlHandler := middleware.ETagMiddleware()(handlers.BuildRootHandlerWithContext(ctx, cfg, deps))
+	if deps.RateLimitService != nil {
+		rc := cfg.RateLimitConfig
+		graphqlHandler = middleware.RateLimitMiddleware(
+			deps.RateLimitService,
+			ratelimit.PerMinute(rc.UserPerMinute, rc.UserBurst),
+			ratelimit.PerMinute(rc.IPPerMinute, rc.IPBurst),
+		)(graphqlHandler)
+	}
+	router.Handle("/graphql", graphqlHandler).Methods("GET", "POST", "OPTIONS")
 	router.Handle("/healthcheck", handlers.HealthCheckHandler()).Methods("GET")
 	router.Handle("/metrics", metrics.NewPrometheusInstance().Handler()).Methods("GET")
 
@@ -56,6 +74,37 @@ func SetupServerWithContext(ctx context.Context, cfg config.Config, deps *handle
 	return router
 }
 
+// newHTTPServer serves the router with the configured timeouts, keep-alives and HTTP/2
+// settings
+func newHTTPServer(cfg config.Config, router http.Handler) *http.Server {
+	sc := cfg.ServerConfig
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(sc.H2C)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.AppConfig.Port),
+		Handler:           middleware.TimeoutMiddleware(seconds(sc.RequestTimeoutSeconds))(router),
+		ReadHeaderTimeout: seconds(sc.ReadHeaderTimeoutSeconds),
+		ReadTimeout:       seconds(sc.ReadTimeoutSeconds),
+		WriteTimeout:      seconds(sc.WriteTimeoutSeconds),
+		IdleTimeout:       seconds(sc.IdleTimeoutSeconds),
+		MaxHeaderBytes:    sc.MaxHeaderBytes,
+		Protocols:         protocols,
+		HTTP2: &http.HTTP2Config{
+			MaxConcurrentStreams: sc.HTTP2MaxConcurrentStreams,
+		},
+	}
+	server.SetKeepAlivesEnabled(!sc.DisableKeepAlives)
+	return server
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
 func StartServer() error {
 	cfg := config.LoadConfigOrPanic()
 	router := SetupServer(cfg)
@@ -66,7 +115,7 @@ func StartServer() error {
 		Str("playground_url", fmt.Sprintf("http://localhost:%d/", cfg.AppConfig.Port)).
 		Msg("Starting GraphQL server")
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", cfg.AppConfig.Port), router)
+	return newHTTPServer(cfg, router).ListenAndServe()
 }
 
 func StartServerWithContext(ctx context.Context, deps *handlers.Dependencies) error {
@@ -79,5 +128,5 @@ func StartServerWithContext(ctx context.Context, deps *handlers.Dependencies) er
 		Str("playground_url", fmt.Sprintf("http://localhost:%d/ui/playground", cfg.AppConfig.Port)).
 		Msg("Starting GraphQL server")
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", cfg.AppConfig.Port), router)
+	return newHTTPServer(cfg, router).ListenAndServe()
 }
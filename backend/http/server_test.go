@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+func TestNewHTTPServer(t *testing.T) {
+	cfg := config.Config{
+		AppConfig: config.AppConfig{Port: 3000},
+		ServerConfig: config.ServerConfig{
+			ReadHeaderTimeoutSeconds:  10,
+			ReadTimeoutSeconds:        60,
+			WriteTimeoutSeconds:       45,
+			IdleTimeoutSeconds:        120,
+			RequestTimeoutSeconds:     30,
+			MaxHeaderBytes:            1 << 16,
+			H2C:                       true,
+			HTTP2MaxConcurrentStreams: 100,
+		},
+	}
+
+	server := newHTTPServer(cfg, http.NotFoundHandler())
+	assert.Equal(t, ":3000", server.Addr)
+	assert.Equal(t, 10*time.Second, server.ReadHeaderTimeout)
+	assert.Equal(t, time.Minute, server.ReadTimeout)
+	assert.Equal(t, 45*time.Second, server.WriteTimeout)
+	assert.Equal(t, 2*time.Minute, server.IdleTimeout)
+	assert.Equal(t, 1<<16, server.MaxHeaderBytes)
+	assert.True(t, server.Protocols.HTTP1())
+	assert.True(t, server.Protocols.UnencryptedHTTP2())
+	assert.Equal(t, 100, server.HTTP2.MaxConcurrentStreams)
+
+	cfg.ServerConfig.H2C = false
+	assert.False(t, newHTTPServer(cfg, http.NotFoundHandler()).Protocols.UnencryptedHTTP2())
+}
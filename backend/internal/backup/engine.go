@@ -0,0 +1,291 @@
+package backup
+
+//go:generate mockgen -source=engine.go -destination=mocks/engine_mock.go -package=mocks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// restoreBatchSize is how many rows of a table are inserted at once
+const restoreBatchSize = 500
+
+var (
+	ErrSchemaMismatch      = errors.New("backup was taken at a different schema version")
+	ErrDirtySchema         = errors.New("database has a failed migration")
+	ErrOrganizationExists  = errors.New("organization of the backup already exists")
+	ErrInstanceNotEmpty    = errors.New("an instance backup only restores into an instance without organizations")
+	ErrUnknownTable        = errors.New("backup holds a table this version does not know")
+	ErrOrganizationMissing = errors.New("organization backup has no organization ID")
+)
+
+// Engine takes and restores backups of the database
+type Engine interface {
+	// Snapshot writes a consistent backup of the organization to w, or of the whole instance
+	// when orgID is nil
+	Snapshot(ctx context.Context, w io.Writer, orgID *uuid.UUID) (*Manifest, *Summary, error)
+	// Restore loads a backup into the database in one transaction. The database must be
+	// migrated to the backup's schema version and must not hold what the backup restores.
+	Restore(ctx context.Context, r io.Reader) (*Manifest, *Summary, error)
+}
+
+type engine struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewEngine(db *gorm.DB) Engine {
+	return &engine{db: db, now: time.Now}
+}
+
+func (e *engine) Snapshot(ctx context.Context, w io.Writer, orgID *uuid.UUID) (*Manifest, *Summary, error) {
+	manifest := Manifest{Scope: ScopeInstance, OrganizationID: orgID, CreatedAt: e.now().UTC()}
+	if orgID != nil {
+		manifest.Scope = ScopeOrganization
+	}
+
+	var summary *Summary
+	// Repeatable read makes every table come from the same snapshot of the database
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := schemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		manifest.SchemaVersion = version
+
+		bw, err := NewWriter(w, manifest)
+		if err != nil {
+			return err
+		}
+		for _, t := range tables {
+			if err := snapshotTable(tx, bw, t, orgID); err != nil {
+				return fmt.Errorf("backing up %s: %w", t.name, err)
+			}
+		}
+		summary, err = bw.Close()
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &manifest, summary, nil
+}
+
+func snapshotTable(tx *gorm.DB, bw *Writer, t table, orgID *uuid.UUID) error {
+	query := "SELECT row_to_json(t) FROM " + t.name + " t"
+	var args []interface{}
+	if orgID != nil {
+		query += " WHERE " + t.orgFilter
+		args = append(args, sql.Named("org", *orgID))
+	} else if t.instanceFilter != "" {
+		query += " WHERE " + t.instanceFilter
+	}
+
+	rows, err := tx.Raw(query, args...).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+		if err := bw.WriteRow(t.name, row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (e *engine) Restore(ctx context.Context, r io.Reader) (*Manifest, *Summary, error) {
+	br, err := NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := br.Manifest()
+
+	err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := schemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		if version != manifest.SchemaVersion {
+			return fmt.Errorf("%w: backup is at %d, database at %d", ErrSchemaMismatch, manifest.SchemaVersion, version)
+		}
+		if err := checkRestoreTarget(tx, manifest); err != nil {
+			return err
+		}
+
+		var (
+			current  *table
+			batch    []json.RawMessage
+			deferred = map[string][]json.RawMessage{}
+		)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			err := insertRows(tx, current, batch)
+			batch = batch[:0]
+			return err
+		}
+
+		for {
+			name, row, err := br.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			if current == nil || current.name != name {
+				if err := flush(); err != nil {
+					return err
+				}
+				if current = tableByName(name); current == nil {
+					return fmt.Errorf("%w: %s", ErrUnknownTable, name)
+				}
+			}
+			if len(current.deferred) > 0 {
+				var update json.RawMessage
+				if row, update, err = splitDeferred(row, current.deferred); err != nil {
+					return err
+				}
+				deferred[current.name] = append(deferred[current.name], update)
+			}
+			if batch = append(batch, row); len(batch) >= restoreBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		for name, updates := range deferred {
+			if err := applyDeferred(tx, tableByName(name), updates); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, br.Summary(), nil
+}
+
+// checkRestoreTarget refuses to restore over existing data
+func checkRestoreTarget(tx *gorm.DB, manifest *Manifest) error {
+	var count int64
+	if manifest.Scope == ScopeOrganization {
+		if manifest.OrganizationID == nil {
+			return ErrOrganizationMissing
+		}
+		if err := tx.Raw("SELECT count(*) FROM organizations WHERE id = ?", *manifest.OrganizationID).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrOrganizationExists
+		}
+		return nil
+	}
+
+	if err := tx.Raw("SELECT count(*) FROM organizations").Scan(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrInstanceNotEmpty
+	}
+	return nil
+}
+
+// insertRows inserts rows of a table as returned by row_to_json
+func insertRows(tx *gorm.DB, t *table, rows []json.RawMessage) error {
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO " + t.name + " SELECT * FROM json_populate_recordset(NULL::" + t.name + ", ?::json)"
+	if t.shared {
+		query += " ON CONFLICT DO NOTHING"
+	}
+	if err := tx.Exec(query, string(data)).Error; err != nil {
+		return fmt.Errorf("restoring %s: %w", t.name, err)
+	}
+	return nil
+}
+
+// splitDeferred nulls the deferred columns of a row, returning the row to insert and the
+// update that sets them afterwards
+func splitDeferred(row json.RawMessage, columns []string) (json.RawMessage, json.RawMessage, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(row, &values); err != nil {
+		return nil, nil, err
+	}
+	update := map[string]json.RawMessage{"id": values["id"]}
+	for _, column := range columns {
+		update[column] = values[column]
+		values[column] = json.RawMessage("null")
+	}
+
+	insert, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	updateData, err := json.Marshal(update)
+	if err != nil {
+		return nil, nil, err
+	}
+	return insert, updateData, nil
+}
+
+// applyDeferred sets the deferred columns of a table's restored rows
+func applyDeferred(tx *gorm.DB, t *table, updates []json.RawMessage) error {
+	for start := 0; start < len(updates); start += restoreBatchSize {
+		end := min(start+restoreBatchSize, len(updates))
+		data, err := json.Marshal(updates[start:end])
+		if err != nil {
+			return err
+		}
+
+		query := "UPDATE " + t.name + " t SET "
+		for i, column := range t.deferred {
+			if i > 0 {
+				query += ", "
+			}
+			query += column + " = d." + column
+		}
+		query += " FROM json_populate_recordset(NULL::" + t.name + ", ?::json) d WHERE t.id = d.id"
+		if err := tx.Exec(query, string(data)).Error; err != nil {
+			return fmt.Errorf("restoring %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the migration the database is at
+func schemaVersion(tx *gorm.DB) (uint, error) {
+	var migration struct {
+		Version uint
+		Dirty   bool
+	}
+	if err := tx.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&migration).Error; err != nil {
+		return 0, err
+	}
+	if migration.Dirty {
+		return 0, ErrDirtySchema
+	}
+	return migration.Version, nil
+}
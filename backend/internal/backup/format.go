@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FormatVersion is the version of the backup format written by Writer
+const FormatVersion = 1
+
+// maxLineSize bounds one line of a backup, which holds a single row
+const maxLineSize = 64 << 20
+
+var (
+	ErrInvalidBackup      = errors.New("not a kaimu backup")
+	ErrUnsupportedFormat  = errors.New("unsupported backup format version")
+	ErrTruncated          = errors.New("backup is truncated")
+	ErrRowCountMismatch   = errors.New("backup row counts do not match its summary")
+	ErrTrailingData       = errors.New("backup has data after its summary")
+	ErrMissingTableOrRow  = errors.New("backup line has no table or row")
+	ErrManifestNotAtStart = errors.New("backup manifest must be the first line")
+)
+
+// Scope is what a backup covers
+type Scope string
+
+const (
+	ScopeOrganization Scope = "organization"
+	ScopeInstance     Scope = "instance"
+)
+
+// Manifest is the first line of a backup
+type Manifest struct {
+	FormatVersion int `json:"format_version"`
+	// SchemaVersion is the database migration the backup was taken at; a backup only
+	// restores into an instance migrated to the same version
+	SchemaVersion  uint       `json:"schema_version"`
+	Scope          Scope      `json:"scope"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Summary is the last line of a backup. It marks the backup as complete.
+type Summary struct {
+	// Rows is how many rows the backup holds per table
+	Rows map[string]int `json:"rows"`
+}
+
+// Total returns how many rows the backup holds
+func (s *Summary) Total() int {
+	total := 0
+	for _, n := range s.Rows {
+		total += n
+	}
+	return total
+}
+
+// line is one line of a backup; exactly one of its parts is set
+type line struct {
+	Manifest *Manifest       `json:"manifest,omitempty"`
+	Table    string          `json:"table,omitempty"`
+	Row      json.RawMessage `json:"row,omitempty"`
+	Summary  *Summary        `json:"summary,omitempty"`
+}
+
+// Writer writes a backup: a gzip-compressed stream of JSON lines holding the manifest, one
+// line per row and the summary
+type Writer struct {
+	gz      *gzip.Writer
+	enc     *json.Encoder
+	summary Summary
+}
+
+// NewWriter starts a backup on w by writing its manifest
+func NewWriter(w io.Writer, manifest Manifest) (*Writer, error) {
+	manifest.FormatVersion = FormatVersion
+	gz := gzip.NewWriter(w)
+	bw := &Writer{gz: gz, enc: json.NewEncoder(gz), summary: Summary{Rows: map[string]int{}}}
+	if err := bw.enc.Encode(line{Manifest: &manifest}); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+// WriteRow adds a row of a table, as returned by row_to_json
+func (w *Writer) WriteRow(table string, row json.RawMessage) error {
+	if err := w.enc.Encode(line{Table: table, Row: row}); err != nil {
+		return err
+	}
+	w.summary.Rows[table]++
+	return nil
+}
+
+// Close writes the summary and flushes the backup. A backup without its summary is
+// rejected as truncated when read.
+func (w *Writer) Close() (*Summary, error) {
+	if err := w.enc.Encode(line{Summary: &w.summary}); err != nil {
+		return nil, err
+	}
+	if err := w.gz.Close(); err != nil {
+		return nil, err
+	}
+	return &w.summary, nil
+}
+
+// Reader reads a backup written by Writer
+type Reader struct {
+	scanner  *bufio.Scanner
+	manifest *Manifest
+	counts   map[string]int
+	summary  *Summary
+}
+
+// NewReader reads the manifest of the backup in r
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	br := &Reader{scanner: scanner, counts: map[string]int{}}
+	first, err := br.next()
+	if err != nil {
+		if errors.Is(err, ErrTruncated) {
+			return nil, ErrInvalidBackup
+		}
+		return nil, err
+	}
+	if first.Manifest == nil {
+		return nil, ErrInvalidBackup
+	}
+	if first.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, first.Manifest.FormatVersion)
+	}
+	br.manifest = first.Manifest
+	return br, nil
+}
+
+// Manifest returns the backup's manifest
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+// Summary returns the backup's summary once Next has returned io.EOF
+func (r *Reader) Summary() *Summary {
+	return r.summary
+}
+
+// Next returns the next row of the backup. It returns io.EOF after the summary, once the
+// rows read match it, and ErrTruncated when the backup ends without one.
+func (r *Reader) Next() (string, json.RawMessage, error) {
+	if r.summary != nil {
+		return "", nil, io.EOF
+	}
+	l, err := r.next()
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case l.Manifest != nil:
+		return "", nil, ErrManifestNotAtStart
+	case l.Summary != nil:
+		if !maps.Equal(l.Summary.Rows, r.counts) {
+			return "", nil, ErrRowCountMismatch
+		}
+		if r.scanner.Scan() {
+			return "", nil, ErrTrailingData
+		}
+		if err := r.scanner.Err(); err != nil {
+			return "", nil, err
+		}
+		r.summary = l.Summary
+		return "", nil, io.EOF
+	case l.Table == "" || len(l.Row) == 0:
+		return "", nil, ErrMissingTableOrRow
+	}
+	r.counts[l.Table]++
+	return l.Table, l.Row, nil
+}
+
+func (r *Reader) next() (*line, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+		return nil, ErrTruncated
+	}
+	var l line
+	if err := json.Unmarshal(r.scanner.Bytes(), &l); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	return &l, nil
+}
@@ -0,0 +1,115 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBackup(t *testing.T, rows map[string][]string) []byte {
+	t.Helper()
+	orgID := uuid.New()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Manifest{SchemaVersion: 49, Scope: ScopeOrganization, OrganizationID: &orgID, CreatedAt: time.Now()})
+	require.NoError(t, err)
+	for _, name := range []string{"users", "cards"} {
+		for _, row := range rows[name] {
+			require.NoError(t, w.WriteRow(name, json.RawMessage(row)))
+		}
+	}
+	_, err = w.Close()
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func gzipLines(t *testing.T, lines ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	for _, l := range lines {
+		_, err := gz.Write([]byte(l + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestFormat(t *testing.T) {
+	t.Run("success - round trip", func(t *testing.T) {
+		data := writeBackup(t, map[string][]string{
+			"users": {`{"id":"u1"}`},
+			"cards": {`{"id":"c1"}`, `{"id":"c2"}`},
+		})
+
+		r, err := NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, FormatVersion, r.Manifest().FormatVersion)
+		assert.Equal(t, uint(49), r.Manifest().SchemaVersion)
+
+		var tables []string
+		for {
+			name, row, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, row)
+			tables = append(tables, name)
+		}
+		assert.Equal(t, []string{"users", "cards", "cards"}, tables)
+		assert.Equal(t, map[string]int{"users": 1, "cards": 2}, r.Summary().Rows)
+		assert.Equal(t, 3, r.Summary().Total())
+	})
+
+	t.Run("fail - not a backup", func(t *testing.T) {
+		_, err := NewReader(bytes.NewReader([]byte("plain text")))
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+
+		_, err = NewReader(bytes.NewReader(gzipLines(t, `{"table":"users","row":{}}`)))
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+	})
+
+	t.Run("fail - unsupported version", func(t *testing.T) {
+		_, err := NewReader(bytes.NewReader(gzipLines(t, `{"manifest":{"format_version":99}}`)))
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("fail - missing summary", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(gzipLines(t, `{"manifest":{"format_version":1}}`, `{"table":"users","row":{"id":"u1"}}`)))
+		require.NoError(t, err)
+		_, _, err = r.Next()
+		require.NoError(t, err)
+		_, _, err = r.Next()
+		assert.ErrorIs(t, err, ErrTruncated)
+	})
+
+	t.Run("fail - cut off stream", func(t *testing.T) {
+		data := writeBackup(t, map[string][]string{"cards": {`{"id":"c1"}`, `{"id":"c2"}`}})
+		r, err := NewReader(bytes.NewReader(data[:len(data)-12]))
+		require.NoError(t, err)
+		for err == nil {
+			_, _, err = r.Next()
+		}
+		assert.NotErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("fail - counts do not match the summary", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(gzipLines(t,
+			`{"manifest":{"format_version":1}}`,
+			`{"table":"users","row":{"id":"u1"}}`,
+			`{"summary":{"rows":{"users":2}}}`,
+		)))
+		require.NoError(t, err)
+		_, _, err = r.Next()
+		require.NoError(t, err)
+		_, _, err = r.Next()
+		assert.ErrorIs(t, err, ErrRowCountMismatch)
+	})
+}
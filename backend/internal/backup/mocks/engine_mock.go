@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: engine.go
+//
+// Generated by this command:
+//
+//	mockgen -source=engine.go -destination=mocks/engine_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	backup "github.com/thatcatdev/kaimu/backend/internal/backup"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEngine is a mock of Engine interface.
+type MockEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockEngineMockRecorder
+	isgomock struct{}
+}
+
+// MockEngineMockRecorder is the mock recorder for MockEngine.
+type MockEngineMockRecorder struct {
+	mock *MockEngine
+}
+
+// NewMockEngine creates a new mock instance.
+func NewMockEngine(ctrl *gomock.Controller) *MockEngine {
+	mock := &MockEngine{ctrl: ctrl}
+	mock.recorder = &MockEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
+	return m.recorder
+}
+
+// Restore mocks base method.
+func (m *MockEngine) Restore(ctx context.Context, r io.Reader) (*backup.Manifest, *backup.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, r)
+	ret0, _ := ret[0].(*backup.Manifest)
+	ret1, _ := ret[1].(*backup.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockEngineMockRecorder) Restore(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockEngine)(nil).Restore), ctx, r)
+}
+
+// Snapshot mocks base method.
+func (m *MockEngine) Snapshot(ctx context.Context, w io.Writer, orgID *uuid.UUID) (*backup.Manifest, *backup.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, w, orgID)
+	ret0, _ := ret[0].(*backup.Manifest)
+	ret1, _ := ret[1].(*backup.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockEngineMockRecorder) Snapshot(ctx, w, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockEngine)(nil).Snapshot), ctx, w, orgID)
+}
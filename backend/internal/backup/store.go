@@ -0,0 +1,139 @@
+package backup
+
+//go:generate mockgen -source=store.go -destination=mocks/store_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidKey     = errors.New("invalid backup key")
+	ErrBackupNotFound = errors.New("backup not found")
+)
+
+// Object is a backup file in a Store
+type Object struct {
+	Key        string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+// Store keeps backup files by key. Keys are slash-separated paths.
+type Store interface {
+	// Put stores the contents of r under key. Nothing is stored when reading r fails.
+	Put(ctx context.Context, key string, r io.Reader) (*Object, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// List returns the objects whose key starts with prefix, newest first
+	List(ctx context.Context, prefix string) ([]*Object, error)
+}
+
+// DirStore is a Store in a local directory, typically a mounted volume that is itself
+// replicated off the host
+type DirStore struct {
+	dir string
+}
+
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+func (s *DirStore) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *DirStore) Put(ctx context.Context, key string, r io.Reader) (*Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+
+	// Write to a temporary file so a failed backup never shows up under its key
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".backup-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Key: key, Size: size, ModifiedAt: info.ModTime()}, nil
+}
+
+func (s *DirStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBackupNotFound
+	}
+	return f, err
+}
+
+func (s *DirStore) List(ctx context.Context, prefix string) ([]*Object, error) {
+	var objects []*Object
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == s.dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &Object{Key: key, Size: info.Size(), ModifiedAt: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ModifiedAt.After(objects[j].ModifiedAt)
+	})
+	return objects, nil
+}
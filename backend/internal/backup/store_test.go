@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewDirStore(t.TempDir())
+
+	obj, err := store.Put(ctx, "organizations/a/1.jsonl.gz", strings.NewReader("backup"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), obj.Size)
+
+	f, err := store.Open(ctx, obj.Key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "backup", string(data))
+
+	// A failed write leaves nothing behind
+	_, err = store.Put(ctx, "organizations/a/2.jsonl.gz", io.MultiReader(strings.NewReader("part"), failingReader{}))
+	assert.Error(t, err)
+	_, err = store.Open(ctx, "organizations/a/2.jsonl.gz")
+	assert.ErrorIs(t, err, ErrBackupNotFound)
+
+	_, err = store.Put(ctx, "instance/1.jsonl.gz", strings.NewReader("backup"))
+	require.NoError(t, err)
+	objects, err := store.List(ctx, "organizations/a/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "organizations/a/1.jsonl.gz", objects[0].Key)
+
+	_, err = store.Put(ctx, "../escape", strings.NewReader("backup"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestDirStoreListMissingDir(t *testing.T) {
+	objects, err := NewDirStore(t.TempDir()+"/missing").List(context.Background(), "")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("snapshot failed")
+}
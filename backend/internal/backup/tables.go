@@ -0,0 +1,144 @@
+package backup
+
+import "strings"
+
+// table is a table a backup covers
+type table struct {
+	name string
+	// orgFilter selects the table's rows of the organization named @org
+	orgFilter string
+	// instanceFilter selects the table's rows in an instance backup; empty for every row
+	instanceFilter string
+	// userColumns reference users; the users they reference are part of an organization
+	// backup
+	userColumns []string
+	// shared rows may already exist in the instance a backup is restored into, and are then
+	// kept as they are
+	shared bool
+	// deferred columns reference rows of the same table, and are set once all of them exist
+	deferred []string
+}
+
+const (
+	orgProjects = "SELECT id FROM projects WHERE organization_id = @org"
+	orgBoards   = "SELECT id FROM boards WHERE project_id IN (" + orgProjects + ")"
+	orgColumns  = "SELECT id FROM board_columns WHERE board_id IN (" + orgBoards + ")"
+	orgCards    = "SELECT id FROM cards WHERE board_id IN (" + orgBoards + ")"
+	orgSprints  = "SELECT id FROM sprints WHERE project_id IN (" + orgProjects + ")"
+	customRoles = "SELECT id FROM roles WHERE is_system = false"
+)
+
+// tables lists the tables a backup covers, in the order they are restored so every
+// reference points at a row restored before it.
+//
+// Left out are the seeded permissions and system roles, which every instance has, and
+// state that is rebuilt or short-lived: sessions, verification tokens, the outbox, the sync
+// journal, undo history, warehouse cursors, project export jobs and in-app notifications.
+var tables = []table{
+	// users' orgFilter is generated from the userColumns of the other tables
+	{name: "users", shared: true},
+	// The instance's own settings are only part of instance backups
+	{name: "instance_settings", orgFilter: "FALSE", shared: true},
+	{name: "organizations", orgFilter: "id = @org", userColumns: []string{"owner_id"}},
+	{
+		name:           "roles",
+		orgFilter:      "is_system = false AND organization_id = @org",
+		instanceFilter: "is_system = false",
+	},
+	{
+		name:           "role_permissions",
+		orgFilter:      "role_id IN (" + customRoles + " AND organization_id = @org)",
+		instanceFilter: "role_id IN (" + customRoles + ")",
+	},
+	{name: "organization_members", orgFilter: "organization_id = @org", userColumns: []string{"user_id"}},
+	// oidc_identities' orgFilter is that of users
+	{name: "oidc_identities", shared: true},
+	{name: "projects", orgFilter: "organization_id = @org"},
+	{name: "project_members", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"user_id"}},
+	{name: "invitations", orgFilter: "organization_id = @org", userColumns: []string{"invited_by"}},
+	{name: "boards", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"created_by"}},
+	{name: "board_columns", orgFilter: "board_id IN (" + orgBoards + ")"},
+	{name: "tags", orgFilter: "project_id IN (" + orgProjects + ")"},
+	{name: "epics", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"created_by"}},
+	{name: "sprints", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"created_by"}},
+	{
+		name:        "cards",
+		orgFilter:   "board_id IN (" + orgBoards + ")",
+		userColumns: []string{"assignee_id", "created_by"},
+		deferred:    []string{"merged_into_id"},
+	},
+	{name: "card_tags", orgFilter: "card_id IN (" + orgCards + ")"},
+	{name: "card_sprints", orgFilter: "sprint_id IN (" + orgSprints + ")"},
+	{
+		name:        "card_dependencies",
+		orgFilter:   "from_card_id IN (" + orgCards + ") AND to_card_id IN (" + orgCards + ")",
+		userColumns: []string{"created_by"},
+	},
+	{
+		name:        "card_mirrors",
+		orgFilter:   "source_card_id IN (" + orgCards + ") AND mirror_card_id IN (" + orgCards + ")",
+		userColumns: []string{"created_by"},
+	},
+	{name: "card_views", orgFilter: "card_id IN (" + orgCards + ")", userColumns: []string{"user_id"}},
+	{name: "card_attachments", orgFilter: "card_id IN (" + orgCards + ")", userColumns: []string{"uploaded_by"}},
+	{name: "column_transitions", orgFilter: "board_id IN (" + orgBoards + ")"},
+	{name: "column_card_defaults", orgFilter: "column_id IN (" + orgColumns + ")", userColumns: []string{"assignee_id"}},
+	{name: "column_default_tags", orgFilter: "column_id IN (" + orgColumns + ")"},
+	{name: "column_watches", orgFilter: "column_id IN (" + orgColumns + ")", userColumns: []string{"user_id"}},
+	{name: "column_watch_deliveries", orgFilter: "column_id IN (" + orgColumns + ")"},
+	{name: "sla_policies", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"created_by"}},
+	{name: "sla_breaches", orgFilter: "policy_id IN (SELECT id FROM sla_policies WHERE project_id IN (" + orgProjects + "))"},
+	{name: "notification_rules", orgFilter: "project_id IN (" + orgProjects + ")", userColumns: []string{"user_id"}},
+	{name: "notification_rule_deliveries", orgFilter: "rule_id IN (SELECT id FROM notification_rules WHERE project_id IN (" + orgProjects + "))"},
+	{name: "notification_channel_settings", orgFilter: "organization_id = @org"},
+	{name: "notification_preferences", orgFilter: "organization_id = @org", userColumns: []string{"user_id"}},
+	{name: "notification_slack_deliveries", orgFilter: "setting_id IN (SELECT id FROM notification_channel_settings WHERE organization_id = @org)"},
+	{name: "project_calendars", orgFilter: "project_id IN (" + orgProjects + ")"},
+	{name: "project_holidays", orgFilter: "project_id IN (" + orgProjects + ")"},
+	{name: "metrics_history", orgFilter: "sprint_id IN (" + orgSprints + ")"},
+	{name: "metrics_embed_tokens", orgFilter: "board_id IN (" + orgBoards + ")", userColumns: []string{"created_by"}},
+	// Personal tokens belong to no organization, so only instance backups hold them
+	{name: "api_tokens", orgFilter: "organization_id = @org", userColumns: []string{"user_id", "created_by"}},
+	{name: "audit_events", orgFilter: "organization_id = @org", userColumns: []string{"actor_id", "impersonator_id"}},
+	{name: "user_matches", orgFilter: "organization_id = @org", userColumns: []string{"user_id", "resolved_by"}},
+	{name: "auto_archive_runs", orgFilter: "board_id IN (" + orgBoards + ")"},
+	{name: "audit_anomaly_settings", orgFilter: "organization_id = @org"},
+	{name: "organization_branding", orgFilter: "organization_id = @org"},
+	{name: "audit_anomalies", orgFilter: "organization_id = @org", userColumns: []string{"actor_id"}},
+	{name: "legal_holds", orgFilter: "organization_id = @org", userColumns: []string{"placed_by", "lifted_by"}},
+	{name: "search_queries", orgFilter: "organization_id = @org", userColumns: []string{"user_id"}},
+	{name: "search_synonym_sets", orgFilter: "organization_id = @org"},
+	{name: "search_stop_words", orgFilter: "organization_id = @org"},
+	{name: "freeze_windows", orgFilter: "board_id IN (" + orgBoards + ")", userColumns: []string{"created_by"}},
+	{name: "freeze_window_columns", orgFilter: "column_id IN (" + orgColumns + ")"},
+	{name: "webhooks", orgFilter: "organization_id = @org", userColumns: []string{"created_by"}},
+	{name: "webhook_deliveries", orgFilter: "webhook_id IN (SELECT id FROM webhooks WHERE organization_id = @org)"},
+	{name: "card_comments", orgFilter: "card_id IN (" + orgCards + ")", userColumns: []string{"author_id"}},
+	{name: "comment_mentions", orgFilter: "card_id IN (" + orgCards + ")", userColumns: []string{"user_id", "actor_id"}},
+	{name: "card_checklist_items", orgFilter: "card_id IN (" + orgCards + ")", userColumns: []string{"assignee_id"}},
+	{name: "column_alert_settings", orgFilter: "board_id IN (" + orgBoards + ")"},
+	{name: "column_alerts", orgFilter: "board_id IN (" + orgBoards + ")"},
+}
+
+func init() {
+	// An organization backup holds every user its rows reference, and their identities
+	var refs []string
+	for _, t := range tables {
+		for _, column := range t.userColumns {
+			refs = append(refs, "SELECT "+column+" FROM "+t.name+" WHERE "+t.orgFilter)
+		}
+	}
+	orgUsers := "id IN (" + strings.Join(refs, " UNION ") + ")"
+	tableByName("users").orgFilter = orgUsers
+	tableByName("oidc_identities").orgFilter = "user_id IN (SELECT id FROM users WHERE " + orgUsers + ")"
+}
+
+// tableByName returns the table a backup covers by its name, or nil
+func tableByName(name string) *table {
+	for i := range tables {
+		if tables[i].name == name {
+			return &tables[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/thatcatdev/kaimu/backend/config"
+	backupEngine "github.com/thatcatdev/kaimu/backend/internal/backup"
+	"github.com/thatcatdev/kaimu/backend/internal/db"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/backup"
+)
+
+var (
+	backupOrgID  string
+	backupOutput string
+	restoreKey   string
+	restoreFile  string
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Take and restore backups of an organization or the whole instance",
+	Long: `Backups are consistent snapshots in a documented format (see CLAUDE.md, "Backups").
+They are written to BACKUP_DIR unless an output file is given.`,
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Back up an organization, or the whole instance when --org is not set",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, engine, svc := backupSetup(cmd, "kaimu-backup")
+		log := logger.FromCtx(ctx)
+
+		var orgID *uuid.UUID
+		if backupOrgID != "" {
+			id, err := uuid.Parse(backupOrgID)
+			if err != nil {
+				return fmt.Errorf("invalid organization ID: %w", err)
+			}
+			orgID = &id
+		}
+
+		if backupOutput != "" {
+			f, err := os.Create(backupOutput)
+			if err != nil {
+				return err
+			}
+			_, summary, err := engine.Snapshot(ctx, f, orgID)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(backupOutput)
+				return err
+			}
+			log.Info().Str("file", backupOutput).Int("rows", summary.Total()).Msg("Backup written")
+			return nil
+		}
+
+		var (
+			b   *backup.Backup
+			err error
+		)
+		if orgID != nil {
+			b, err = svc.CreateOrganizationBackup(ctx, *orgID)
+		} else {
+			b, err = svc.CreateInstanceBackup(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		log.Info().Str("key", b.Key).Int64("size_bytes", b.SizeBytes).Int("rows", b.Summary.Total()).Msg("Backup stored")
+		fmt.Fprintln(os.Stdout, b.Key)
+		return nil
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup into this instance",
+	Long: `Restores a backup in one transaction. Run "migrate up" first: the database must be at the
+backup's schema version. An organization backup is refused when its organization exists, an
+instance backup when any organization exists.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (restoreKey == "") == (restoreFile == "") {
+			return fmt.Errorf("set exactly one of --key and --file")
+		}
+		ctx, engine, svc := backupSetup(cmd, "kaimu-restore")
+		log := logger.FromCtx(ctx)
+
+		var (
+			manifest *backupEngine.Manifest
+			summary  *backupEngine.Summary
+			err      error
+		)
+		if restoreKey != "" {
+			manifest, summary, err = svc.Restore(ctx, restoreKey)
+		} else {
+			f, openErr := os.Open(restoreFile)
+			if openErr != nil {
+				return openErr
+			}
+			defer f.Close()
+			manifest, summary, err = engine.Restore(ctx, f)
+		}
+		if err != nil {
+			return fmt.Errorf("restore failed, nothing was restored: %w", err)
+		}
+
+		event := log.Info().Str("scope", string(manifest.Scope)).Time("taken_at", manifest.CreatedAt).Int("rows", summary.Total())
+		if manifest.OrganizationID != nil {
+			event = event.Str("organization_id", manifest.OrganizationID.String())
+		}
+		event.Msg("Backup restored")
+		return nil
+	},
+}
+
+func backupSetup(cmd *cobra.Command, serverName string) (context.Context, backupEngine.Engine, backup.Service) {
+	cfg := config.LoadConfigOrPanic()
+
+	logger.Logger(
+		logger.WithServerName(serverName),
+		logger.WithVersion("1.0.0"),
+		logger.WithEnvironment(cfg.AppConfig.Env),
+	)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	database := db.NewDatabase(cfg.DBConfig)
+	engine := backupEngine.NewEngine(database.DB)
+	return ctx, engine, backup.NewService(engine, backupEngine.NewDirStore(cfg.BackupConfig.Dir))
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupCreateCmd, backupRestoreCmd)
+
+	backupCreateCmd.Flags().StringVar(&backupOrgID, "org", "", "Organization to back up (default the whole instance)")
+	backupCreateCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Write the backup to this file instead of BACKUP_DIR")
+	backupRestoreCmd.Flags().StringVar(&restoreKey, "key", "", "Key of a backup in BACKUP_DIR")
+	backupRestoreCmd.Flags().StringVar(&restoreFile, "file", "", "Path of a backup file")
+}
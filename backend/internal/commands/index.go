@@ -19,6 +19,8 @@ import (
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
 	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/embeddings"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search"
 )
 
@@ -71,8 +73,14 @@ var indexCmd = &cobra.Command{
 		boardRepository := boardRepo.NewRepository(database.DB)
 		cardRepository := cardRepo.NewRepository(database.DB)
 
+		// Cards are embedded while indexing when an embeddings provider is configured
+		embeddingsProvider, err := embeddings.NewProvider(cfg.EmbeddingsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to configure embeddings: %w", err)
+		}
+
 		// Initialize search service
-		searchService := search.NewService(typesenseClient, orgMemberRepository)
+		searchService := search.NewService(typesenseClient, orgMemberRepository, nil, embeddingsProvider)
 
 		// Initialize collections
 		log.Info().Msg("Initializing Typesense collections...")
@@ -80,15 +88,22 @@ var indexCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize collections: %w", err)
 		}
 		log.Info().Msg("Collections initialized")
+		if pending := searchService.PendingMigrations(); len(pending) > 0 {
+			log.Info().Strs("collections", pending).Msg("Backfilling new search schema versions")
+		}
+
+		// Failures while indexing; new schema versions are only swapped in after a clean run
+		failed := 0
 
 		// Index organizations
 		log.Info().Msg("Indexing organizations...")
 		orgs, err := orgRepository.GetAll(ctx)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to get organizations")
+			failed++
 		} else {
 			for _, org := range orgs {
-				members, _ := orgMemberRepository.GetByOrgID(ctx, org.ID)
+				members, _ := orgMemberRepository.GetMembersByOrgID(ctx, org.ID)
 				memberIDs := make([]string, len(members))
 				for i, m := range members {
 					memberIDs[i] = m.UserID.String()
@@ -105,6 +120,7 @@ var indexCmd = &cobra.Command{
 				}
 				if err := searchService.IndexOrganization(ctx, doc); err != nil {
 					log.Warn().Err(err).Str("org_id", org.ID.String()).Msg("Failed to index organization")
+					failed++
 				}
 			}
 			log.Info().Int("count", len(orgs)).Msg("Organizations indexed")
@@ -115,13 +131,17 @@ var indexCmd = &cobra.Command{
 		users, err := userRepository.GetAll(ctx)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to get users")
+			failed++
 		} else {
 			for _, user := range users {
 				// Get user's organization memberships
 				memberships, _ := orgMemberRepository.GetByUserID(ctx, user.ID)
-				orgIDs := make([]string, len(memberships))
-				for i, m := range memberships {
-					orgIDs[i] = m.OrganizationID.String()
+				orgIDs := make([]string, 0, len(memberships))
+				for _, m := range memberships {
+					if m.IsGuest {
+						continue // Guests cannot be found by the organizations they are a guest of
+					}
+					orgIDs = append(orgIDs, m.OrganizationID.String())
 				}
 
 				email := ""
@@ -143,6 +163,7 @@ var indexCmd = &cobra.Command{
 				}
 				if err := searchService.IndexUser(ctx, doc); err != nil {
 					log.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to index user")
+					failed++
 				}
 			}
 			log.Info().Int("count", len(users)).Msg("Users indexed")
@@ -153,6 +174,7 @@ var indexCmd = &cobra.Command{
 		projects, err := projectRepository.GetAll(ctx)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to get projects")
+			failed++
 		} else {
 			// Build org maps for names and slugs
 			orgNameMap := make(map[string]string)
@@ -176,6 +198,7 @@ var indexCmd = &cobra.Command{
 				}
 				if err := searchService.IndexProject(ctx, doc); err != nil {
 					log.Warn().Err(err).Str("project_id", proj.ID.String()).Msg("Failed to index project")
+					failed++
 				}
 			}
 			log.Info().Int("count", len(projects)).Msg("Projects indexed")
@@ -186,6 +209,7 @@ var indexCmd = &cobra.Command{
 		boards, err := boardRepository.GetAll(ctx)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to get boards")
+			failed++
 		} else {
 			// Build project map
 			projectMap := make(map[string]*projectRepo.Project)
@@ -223,6 +247,7 @@ var indexCmd = &cobra.Command{
 				}
 				if err := searchService.IndexBoard(ctx, doc); err != nil {
 					log.Warn().Err(err).Str("board_id", board.ID.String()).Msg("Failed to index board")
+					failed++
 				}
 			}
 			log.Info().Int("count", len(boards)).Msg("Boards indexed")
@@ -233,6 +258,7 @@ var indexCmd = &cobra.Command{
 		cards, err := cardRepository.GetAll(ctx)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to get cards")
+			failed++
 		} else {
 			// Build board map
 			boardMap := make(map[string]*boardRepo.Board)
@@ -266,6 +292,7 @@ var indexCmd = &cobra.Command{
 
 				doc := &search.CardDocument{
 					ID:               card.ID.String(),
+					Key:              cardService.Key(proj.Key, card.Number),
 					Title:            card.Title,
 					Description:      stripHTML(card.Description),
 					Priority:         string(card.Priority),
@@ -291,11 +318,23 @@ var indexCmd = &cobra.Command{
 
 				if err := searchService.IndexCard(ctx, doc); err != nil {
 					log.Warn().Err(err).Str("card_id", card.ID.String()).Msg("Failed to index card")
+					failed++
 				}
 			}
 			log.Info().Int("count", len(cards)).Msg("Cards indexed")
 		}
 
+		// Swap the aliases of backfilled collections over to their new schema versions
+		if pending := searchService.PendingMigrations(); len(pending) > 0 {
+			if failed > 0 {
+				return fmt.Errorf("indexing failed %d times, so the new schema versions of %s were not swapped in; rerun the indexer", failed, strings.Join(pending, ", "))
+			}
+			if err := searchService.CompleteMigrations(ctx); err != nil {
+				return fmt.Errorf("failed to swap in new search schema versions: %w", err)
+			}
+			log.Info().Strs("collections", pending).Msg("New search schema versions swapped in")
+		}
+
 		log.Info().Msg("Indexing complete!")
 		return nil
 	},
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db"
+	"github.com/thatcatdev/kaimu/backend/internal/loadtest"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+var (
+	loadtestURL       string
+	loadtestScenarios []string
+	loadtestDuration  time.Duration
+	loadtestRate      int
+	loadtestSpec      = loadtest.DefaultDatasetSpec
+)
+
+// loadtestCmd represents the loadtest command
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Run load test scenarios against a running API",
+	Long: `Seeds a large dataset into the configured database, then drives the board-load,
+card-moves and search scenarios against the API and fails when a latency budget is exceeded.
+Run "index" after seeding to make the generated cards searchable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.LoadConfigOrPanic()
+
+		logger.Logger(
+			logger.WithServerName("kaimu-loadtest"),
+			logger.WithVersion("1.0.0"),
+			logger.WithEnvironment(cfg.AppConfig.Env),
+		)
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		log := logger.FromCtx(ctx)
+
+		if cfg.AppConfig.Env == "production" {
+			return fmt.Errorf("refusing to seed load test data in production")
+		}
+
+		scenarios, err := selectScenarios(loadtestScenarios)
+		if err != nil {
+			return err
+		}
+
+		database := db.NewDatabase(cfg.DBConfig)
+		log.Info().
+			Int("projects", loadtestSpec.Projects).
+			Int("boards_per_project", loadtestSpec.BoardsPerProject).
+			Int("cards_per_board", loadtestSpec.CardsPerBoard).
+			Msg("Generating dataset")
+		dataset, err := loadtest.GenerateDataset(ctx, database.DB, loadtestSpec)
+		if err != nil {
+			return fmt.Errorf("failed to generate dataset: %w", err)
+		}
+
+		url := loadtestURL
+		if url == "" {
+			url = fmt.Sprintf("http://localhost:%d/graphql", cfg.AppConfig.Port)
+		}
+		cookie, err := loadtest.Login(ctx, http.DefaultClient, url, dataset.Username, dataset.Password)
+		if err != nil {
+			return err
+		}
+
+		target := &loadtest.Target{GraphQLURL: url, Cookie: cookie, Dataset: dataset}
+
+		failed := 0
+		for _, scenario := range scenarios {
+			if loadtestDuration > 0 {
+				scenario.Duration = loadtestDuration
+			}
+			if loadtestRate > 0 {
+				scenario.Rate = vegeta.Rate{Freq: loadtestRate, Per: time.Second}
+			}
+
+			log.Info().Str("scenario", scenario.Name).Str("rate", scenario.Rate.String()).Dur("duration", scenario.Duration).Msg("Running scenario")
+			result := loadtest.Run(ctx, target, scenario)
+
+			fmt.Fprintf(os.Stdout, "== %s ==\n", scenario.Name)
+			if err := vegeta.NewTextReporter(&result.Metrics).Report(os.Stdout); err != nil {
+				return err
+			}
+			for _, v := range result.Violations {
+				fmt.Fprintf(os.Stdout, "BUDGET EXCEEDED: %s\n", v)
+			}
+			if !result.Passed() {
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d scenarios exceeded their budget", failed, len(scenarios))
+		}
+		return nil
+	},
+}
+
+func selectScenarios(names []string) ([]loadtest.Scenario, error) {
+	if len(names) == 0 {
+		return loadtest.Scenarios(), nil
+	}
+	scenarios := make([]loadtest.Scenario, 0, len(names))
+	for _, name := range names {
+		s, ok := loadtest.ScenarioByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q", name)
+		}
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, nil
+}
+
+func init() {
+	rootCmd.AddCommand(loadtestCmd)
+
+	loadtestCmd.Flags().StringVar(&loadtestURL, "url", "", "GraphQL endpoint (default http://localhost:$PORT/graphql)")
+	loadtestCmd.Flags().StringSliceVar(&loadtestScenarios, "scenario", nil, "Scenarios to run: board-load, card-moves, search (default all)")
+	loadtestCmd.Flags().DurationVar(&loadtestDuration, "duration", 0, "Override the duration of every scenario")
+	loadtestCmd.Flags().IntVar(&loadtestRate, "rate", 0, "Override the requests per second of every scenario")
+	loadtestCmd.Flags().IntVar(&loadtestSpec.Projects, "projects", loadtestSpec.Projects, "Projects to generate")
+	loadtestCmd.Flags().IntVar(&loadtestSpec.BoardsPerProject, "boards-per-project", loadtestSpec.BoardsPerProject, "Boards to generate per project")
+	loadtestCmd.Flags().IntVar(&loadtestSpec.CardsPerBoard, "cards-per-board", loadtestSpec.CardsPerBoard, "Cards to generate per board")
+}
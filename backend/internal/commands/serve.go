@@ -55,6 +55,46 @@ var serveCmd = &cobra.Command{
 		log := logger.FromCtx(tracedCtx)
 		log.Info().Msg("Dependencies initialized successfully")
 
+		// Deliver domain events recorded in the outbox
+		dispatcherCtx, stopDispatcher := context.WithCancel(tracedCtx)
+		defer stopDispatcher()
+		go deps.OutboxDispatcher.Run(dispatcherCtx)
+
+		// Flag cards that breach their project's SLA policies
+		go deps.SLAEvaluator.Run(dispatcherCtx)
+
+		// Drop board viewers whose heartbeats have expired
+		go deps.PresenceSweeper.Run(dispatcherCtx)
+
+		// Archive cards left in done columns past their board's auto-archive period
+		go deps.AutoArchiver.Run(dispatcherCtx)
+
+		// Flag mass deletions and off-hours role changes in the audit log
+		go deps.AnomalyDetector.Run(dispatcherCtx)
+
+		// Post queued webhook deliveries, retrying failures with backoff
+		go deps.WebhookSender.Run(dispatcherCtx)
+
+		// Send the summaries of boards in quiet mode once their batch windows close
+		go deps.NotificationBatchFlusher.Run(dispatcherCtx)
+
+		// Delete the files of deleted cards and of uploads never completed
+		go deps.AttachmentSweeper.Run(dispatcherCtx)
+
+		// Alert board admins about columns over their WIP limit or holding old cards
+		go deps.ColumnAlertChecker.Run(dispatcherCtx)
+
+		// Build queued project exports and delete the ones past their retention
+		go deps.ProjectExportWorker.Run(dispatcherCtx)
+
+		// Remind invitees of invitations about to expire and expire the stale ones
+		go deps.InvitationExpirer.Run(dispatcherCtx)
+
+		// Sync card, sprint and audit aggregates to the data warehouse, when one is configured
+		if deps.WarehouseWorker != nil {
+			go deps.WarehouseWorker.Run(dispatcherCtx)
+		}
+
 		// Start the server with traced context
 		return http.StartServerWithContext(tracedCtx, deps)
 	},
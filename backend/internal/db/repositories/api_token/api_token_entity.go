@@ -0,0 +1,84 @@
+package api_token
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind tells who a token acts as
+type Kind string
+
+const (
+	// KindPersonal tokens act as the user who created them
+	KindPersonal Kind = "personal"
+	// KindService tokens act as a service account that is a member of one organization
+	KindService Kind = "service"
+)
+
+// Scope narrows what a token may do to part of what its user may do
+type Scope string
+
+const (
+	// ScopeReadOnly only views, and runs no mutations
+	ScopeReadOnly Scope = "read_only"
+	// ScopeCardWrite views, and creates, edits, moves, assigns and deletes cards
+	ScopeCardWrite Scope = "card_write"
+	// ScopeAdmin does everything its user may do
+	ScopeAdmin Scope = "admin"
+)
+
+// IsValid reports whether the scope is one of the known scopes
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeReadOnly, ScopeCardWrite, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// Allows reports whether a token of the scope may use the permission
+func (s Scope) Allows(permission string) bool {
+	switch s {
+	case ScopeAdmin:
+		return true
+	case ScopeCardWrite:
+		if strings.HasPrefix(permission, "card:") {
+			return true
+		}
+		return strings.HasSuffix(permission, ":view")
+	case ScopeReadOnly:
+		return strings.HasSuffix(permission, ":view")
+	}
+	return false
+}
+
+// APIToken lets a program call the API without a user session
+type APIToken struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	TokenHash string    `gorm:"type:varchar(255);not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Kind      Kind      `gorm:"type:varchar(20);not null"`
+	Scope     Scope     `gorm:"type:varchar(20);not null"`
+	// UserID is who the token acts as: its creator for a personal token, the service
+	// account for a service token
+	UserID uuid.UUID `gorm:"type:uuid;not null"`
+	// OrganizationID is the organization of a service token; nil for personal tokens
+	OrganizationID *uuid.UUID `gorm:"type:uuid"`
+	CreatedBy      *uuid.UUID `gorm:"type:uuid"`
+	// ExpiresAt is nil for tokens that don't expire
+	ExpiresAt  *time.Time `gorm:"type:timestamp with time zone"`
+	LastUsedAt *time.Time `gorm:"type:timestamp with time zone"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	RevokedAt  *time.Time `gorm:"type:timestamp with time zone"`
+}
+
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// IsValid checks if the token is not expired and not revoked
+func (t *APIToken) IsValid(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
@@ -0,0 +1,91 @@
+package api_token
+
+//go:generate mockgen -source=api_token_repository.go -destination=mocks/api_token_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, token *APIToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*APIToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*APIToken, error)
+	// GetPersonalByUserID returns the user's personal tokens that are neither revoked nor
+	// expired at now, newest first
+	GetPersonalByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*APIToken, error)
+	// GetServiceByOrgID returns the organization's service tokens that are neither revoked
+	// nor expired at now, newest first
+	GetServiceByOrgID(ctx context.Context, orgID uuid.UUID, now time.Time) ([]*APIToken, error)
+	// MarkUsed records that the token was used at, unless that was already recorded less
+	// than a minute before
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, token *APIToken) error {
+	return transaction.DB(ctx, r.db).Create(token).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*APIToken, error) {
+	var token APIToken
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*APIToken, error) {
+	var token APIToken
+	err := transaction.DB(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) GetPersonalByUserID(ctx context.Context, userID uuid.UUID, now time.Time) ([]*APIToken, error) {
+	return r.findUsable(ctx, now, "user_id = ? AND kind = ?", userID, KindPersonal)
+}
+
+func (r *repository) GetServiceByOrgID(ctx context.Context, orgID uuid.UUID, now time.Time) ([]*APIToken, error) {
+	return r.findUsable(ctx, now, "organization_id = ? AND kind = ?", orgID, KindService)
+}
+
+func (r *repository) findUsable(ctx context.Context, now time.Time, query string, args ...any) ([]*APIToken, error) {
+	var tokens []*APIToken
+	err := transaction.DB(ctx, r.db).
+		Where(query, args...).
+		Where("revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *repository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&APIToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-time.Minute)).
+		Update("last_used_at", at).Error
+}
+
+func (r *repository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
@@ -0,0 +1,28 @@
+package attachment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file attached to a card, kept in the object store under ObjectKey
+type Attachment struct {
+	ID uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	// CardID is nil once the card is deleted; the sweeper then deletes the attachment
+	CardID *uuid.UUID `gorm:"type:uuid"`
+	// OrganizationID is the organization whose quota the file counts against
+	OrganizationID *uuid.UUID `gorm:"type:uuid"`
+	ObjectKey      string     `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Filename       string     `gorm:"type:varchar(255);not null"`
+	ContentType    string     `gorm:"type:varchar(255);not null"`
+	SizeBytes      int64      `gorm:"type:bigint;not null"`
+	UploadedBy     *uuid.UUID `gorm:"type:uuid"`
+	// UploadedAt is nil until the upload to the object store is confirmed
+	UploadedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+}
+
+func (Attachment) TableName() string {
+	return "card_attachments"
+}
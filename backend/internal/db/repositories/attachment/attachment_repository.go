@@ -0,0 +1,100 @@
+package attachment
+
+//go:generate mockgen -source=attachment_repository.go -destination=mocks/attachment_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	// GetByCardID returns the card's uploaded attachments, oldest first
+	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Attachment, error)
+	Update(ctx context.Context, attachment *Attachment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SumBytesByOrganization returns the size of the organization's attachments on cards,
+	// counting uploads not yet confirmed
+	SumBytesByOrganization(ctx context.Context, orgID uuid.UUID) (int64, error)
+	// GetAbandoned returns up to limit attachments whose card was deleted or whose upload
+	// was started before pendingBefore and never confirmed
+	GetAbandoned(ctx context.Context, pendingBefore time.Time, limit int) ([]*Attachment, error)
+	// ReassignOrganization counts one organization's attachments against another's quota
+	ReassignOrganization(ctx context.Context, fromOrgID, toOrgID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, attachment *Attachment) error {
+	return transaction.DB(ctx, r.db).Create(attachment).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error) {
+	var attachment Attachment
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&attachment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attachment, nil
+}
+
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Attachment, error) {
+	var attachments []*Attachment
+	result := transaction.DB(ctx, r.db).
+		Where("card_id = ? AND uploaded_at IS NOT NULL", cardID).
+		Order("created_at ASC").
+		Find(&attachments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return attachments, nil
+}
+
+func (r *repository) Update(ctx context.Context, attachment *Attachment) error {
+	return transaction.DB(ctx, r.db).Save(attachment).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Attachment{}, "id = ?", id).Error
+}
+
+func (r *repository) SumBytesByOrganization(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var total int64
+	result := transaction.DB(ctx, r.db).
+		Model(&Attachment{}).
+		Where("organization_id = ? AND card_id IS NOT NULL", orgID).
+		Select("COALESCE(SUM(size_bytes), 0)").
+		Scan(&total)
+	return total, result.Error
+}
+
+func (r *repository) GetAbandoned(ctx context.Context, pendingBefore time.Time, limit int) ([]*Attachment, error) {
+	var attachments []*Attachment
+	result := transaction.DB(ctx, r.db).
+		Where("card_id IS NULL OR (uploaded_at IS NULL AND created_at < ?)", pendingBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&attachments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return attachments, nil
+}
+
+func (r *repository) ReassignOrganization(ctx context.Context, fromOrgID, toOrgID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Model(&Attachment{}).
+		Where("organization_id = ?", fromOrgID).
+		Update("organization_id", toOrgID).Error
+}
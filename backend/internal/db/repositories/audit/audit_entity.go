@@ -29,22 +29,48 @@ const (
 	ActionColumnVisibilityToggled AuditAction = "column_visibility_toggled"
 	ActionUserLoggedIn          AuditAction = "user_logged_in"
 	ActionUserLoggedOut         AuditAction = "user_logged_out"
+	ActionCardSplit             AuditAction = "card_split"
+	ActionCardMerged            AuditAction = "card_merged"
+	ActionLegalHoldPlaced       AuditAction = "legal_hold_placed"
+	ActionLegalHoldLifted       AuditAction = "legal_hold_lifted"
+	ActionBackupCreated         AuditAction = "backup_created"
+	ActionFreezeOverridden      AuditAction = "freeze_overridden"
+	ActionCardsImported         AuditAction = "cards_imported"
+	ActionProjectExportRequested AuditAction = "project_export_requested"
+	ActionAPITokenCreated       AuditAction = "api_token_created"
+	ActionAPITokenRevoked       AuditAction = "api_token_revoked"
 )
 
 // EntityType represents the type of entity being audited
 type EntityType string
 
 const (
-	EntityUser         EntityType = "user"
-	EntityOrganization EntityType = "organization"
-	EntityProject      EntityType = "project"
-	EntityBoard        EntityType = "board"
-	EntityBoardColumn  EntityType = "board_column"
-	EntityCard         EntityType = "card"
-	EntitySprint       EntityType = "sprint"
-	EntityTag          EntityType = "tag"
-	EntityRole         EntityType = "role"
-	EntityInvitation   EntityType = "invitation"
+	EntityUser          EntityType = "user"
+	EntityOrganization  EntityType = "organization"
+	EntityProject       EntityType = "project"
+	EntityBoard         EntityType = "board"
+	EntityBoardColumn   EntityType = "board_column"
+	EntityCard          EntityType = "card"
+	EntitySprint        EntityType = "sprint"
+	EntityTag           EntityType = "tag"
+	EntityRole          EntityType = "role"
+	EntityInvitation    EntityType = "invitation"
+	EntityComment       EntityType = "comment"
+	EntityAttachment    EntityType = "attachment"
+	EntityChecklistItem EntityType = "checklist_item"
+)
+
+// AuthMethod is how the user behind an event authenticated the request
+type AuthMethod string
+
+const (
+	// AuthMethodSession is the access token cookie set at login
+	AuthMethodSession AuthMethod = "session"
+	// AuthMethodToken is an access token the client sent itself, e.g. in a websocket's
+	// connection_init payload
+	AuthMethodToken AuthMethod = "token"
+	// AuthMethodAPIToken is a personal access or service token sent as a bearer token
+	AuthMethodAPIToken AuthMethod = "api_token"
 )
 
 // AuditEvent represents a single audit log entry
@@ -64,6 +90,12 @@ type AuditEvent struct {
 	IPAddress      *string         `gorm:"type:inet"`
 	UserAgent      *string         `gorm:"type:text"`
 	TraceID        *string         `gorm:"type:text"`
+	AuthMethod     *AuthMethod     `gorm:"type:text"`
+	// ImpersonatorID is the staff member who acted as the actor, nil when the actor acted
+	// themselves
+	ImpersonatorID *uuid.UUID      `gorm:"type:uuid"`
+	// APITokenID is the API token the request was authenticated with
+	APITokenID     *uuid.UUID      `gorm:"type:uuid"`
 	CreatedAt      time.Time       `gorm:"autoCreateTime"`
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +18,26 @@ type QueryFilters struct {
 	ActorID     *uuid.UUID
 	StartDate   *time.Time
 	EndDate     *time.Time
+	IPAddress   *string
+	// Impersonated, when set, keeps only events that were (true) or were not (false)
+	// impersonated
+	Impersonated *bool
+}
+
+// ActorCount is how many matching events an actor has
+type ActorCount struct {
+	ActorID uuid.UUID
+	Count   int
+}
+
+// DailyActorCount is how many events of one action and entity type an actor has on one day
+type DailyActorCount struct {
+	ActorID uuid.UUID
+	// Day is the start of the UTC day
+	Day        time.Time
+	Action     AuditAction
+	EntityType EntityType
+	Count      int
 }
 
 type Repository interface {
@@ -37,12 +58,35 @@ type Repository interface {
 	// Query by entity (entity history)
 	GetByEntity(ctx context.Context, entityType EntityType, entityID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error)
 
+	// Query by card (card activity): the card's own events and those of its comments,
+	// attachments and checklist items
+	GetByCardID(ctx context.Context, cardID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error)
+
 	// Query by actor (user activity)
 	GetByActorID(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error)
 
 	// Metrics queries for burn charts
 	GetCardMovementsByBoardAndDateRange(ctx context.Context, boardID uuid.UUID, startDate, endDate time.Time) ([]*AuditEvent, error)
 	GetSprintCardEvents(ctx context.Context, sprintID uuid.UUID, startDate, endDate time.Time) ([]*AuditEvent, error)
+
+	// Anomaly detection queries
+	// GetActiveOrganizationIDs returns the organizations with events recorded in [since, until)
+	GetActiveOrganizationIDs(ctx context.Context, since, until time.Time) ([]uuid.UUID, error)
+	// CountByActor counts each actor's events with the action in the organization that
+	// occurred in [since, until)
+	CountByActor(ctx context.Context, orgID uuid.UUID, action AuditAction, since, until time.Time) ([]*ActorCount, error)
+	// GetRoleChanges returns the organization's role assignments and role edits recorded
+	// in [since, until)
+	GetRoleChanges(ctx context.Context, orgID uuid.UUID, since, until time.Time) ([]*AuditEvent, error)
+
+	// Contributor activity queries
+	// CountDailyByActor counts the project's events with one of the actions and entity types
+	// per actor and UTC day in [since, until). A non-nil actorID counts only that actor.
+	CountDailyByActor(ctx context.Context, projectID uuid.UUID, actorID *uuid.UUID, actions []AuditAction, entityTypes []EntityType, since, until time.Time) ([]*DailyActorCount, error)
+
+	// ReassignOrganization moves an organization's events to another organization, so
+	// merged organizations keep their history
+	ReassignOrganization(ctx context.Context, fromOrgID, toOrgID uuid.UUID) error
 }
 
 type repository struct {
@@ -54,21 +98,21 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, event *AuditEvent) error {
-	return r.db.WithContext(ctx).Create(event).Error
+	return transaction.DB(ctx, r.db).Create(event).Error
 }
 
 func (r *repository) CreateBatch(ctx context.Context, events []*AuditEvent) error {
 	if len(events) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Create(events).Error
+	return transaction.DB(ctx, r.db).Create(events).Error
 }
 
 func (r *repository) GetByOrganizationID(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error) {
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).Where("organization_id = ?", orgID)
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).Where("organization_id = ?", orgID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -90,7 +134,7 @@ func (r *repository) GetByOrganizationIDWithFilters(ctx context.Context, orgID u
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).Where("organization_id = ?", orgID)
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).Where("organization_id = ?", orgID)
 
 	// Apply filters
 	if len(filters.Actions) > 0 {
@@ -108,6 +152,16 @@ func (r *repository) GetByOrganizationIDWithFilters(ctx context.Context, orgID u
 	if filters.EndDate != nil {
 		query = query.Where("occurred_at <= ?", *filters.EndDate)
 	}
+	if filters.IPAddress != nil {
+		query = query.Where("ip_address = ?", *filters.IPAddress)
+	}
+	if filters.Impersonated != nil {
+		if *filters.Impersonated {
+			query = query.Where("impersonator_id IS NOT NULL")
+		} else {
+			query = query.Where("impersonator_id IS NULL")
+		}
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -129,7 +183,7 @@ func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID, li
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).Where("project_id = ?", projectID)
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).Where("project_id = ?", projectID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -151,7 +205,7 @@ func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID, limit,
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).Where("board_id = ?", boardID)
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).Where("board_id = ?", boardID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -173,7 +227,7 @@ func (r *repository) GetByEntity(ctx context.Context, entityType EntityType, ent
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).
 		Where("entity_type = ? AND entity_id = ?", entityType, entityID)
 
 	if err := query.Count(&total).Error; err != nil {
@@ -192,11 +246,35 @@ func (r *repository) GetByEntity(ctx context.Context, entityType EntityType, ent
 	return events, total, nil
 }
 
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error) {
+	var events []*AuditEvent
+	var total int64
+
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).
+		Where("(entity_type = ? AND entity_id = ?) OR (entity_type IN ? AND metadata->>'card_id' = ?)",
+			EntityCard, cardID, []EntityType{EntityComment, EntityAttachment, EntityChecklistItem}, cardID.String())
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("occurred_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
 func (r *repository) GetByActorID(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]*AuditEvent, int64, error) {
 	var events []*AuditEvent
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&AuditEvent{}).Where("actor_id = ?", actorID)
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).Where("actor_id = ?", actorID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -218,7 +296,7 @@ func (r *repository) GetByActorID(ctx context.Context, actorID uuid.UUID, limit,
 func (r *repository) GetCardMovementsByBoardAndDateRange(ctx context.Context, boardID uuid.UUID, startDate, endDate time.Time) ([]*AuditEvent, error) {
 	var events []*AuditEvent
 
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ?", boardID).
 		Where("entity_type = ?", EntityCard).
 		Where("action IN ?", []AuditAction{
@@ -243,7 +321,7 @@ func (r *repository) GetSprintCardEvents(ctx context.Context, sprintID uuid.UUID
 	var events []*AuditEvent
 
 	// Query events where the sprint_id is in metadata
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("entity_type = ?", EntityCard).
 		Where("action IN ?", []AuditAction{
 			ActionCreated,
@@ -262,3 +340,73 @@ func (r *repository) GetSprintCardEvents(ctx context.Context, sprintID uuid.UUID
 
 	return events, nil
 }
+
+func (r *repository) ReassignOrganization(ctx context.Context, fromOrgID, toOrgID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Model(&AuditEvent{}).
+		Where("organization_id = ?", fromOrgID).
+		Update("organization_id", toOrgID).Error
+}
+
+func (r *repository) GetActiveOrganizationIDs(ctx context.Context, since, until time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := transaction.DB(ctx, r.db).Model(&AuditEvent{}).
+		Distinct("organization_id").
+		Where("organization_id IS NOT NULL AND created_at >= ? AND created_at < ?", since, until).
+		Pluck("organization_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *repository) CountByActor(ctx context.Context, orgID uuid.UUID, action AuditAction, since, until time.Time) ([]*ActorCount, error) {
+	var counts []*ActorCount
+	err := transaction.DB(ctx, r.db).Model(&AuditEvent{}).
+		Select("actor_id, COUNT(*) AS count").
+		Where("organization_id = ? AND action = ? AND actor_id IS NOT NULL", orgID, action).
+		Where("occurred_at >= ? AND occurred_at < ?", since, until).
+		Group("actor_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *repository) GetRoleChanges(ctx context.Context, orgID uuid.UUID, since, until time.Time) ([]*AuditEvent, error) {
+	var events []*AuditEvent
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND created_at >= ? AND created_at < ?", orgID, since, until).
+		Where("action = ? OR entity_type = ?", ActionMemberRoleChanged, EntityRole).
+		Order("occurred_at ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *repository) CountDailyByActor(ctx context.Context, projectID uuid.UUID, actorID *uuid.UUID, actions []AuditAction, entityTypes []EntityType, since, until time.Time) ([]*DailyActorCount, error) {
+	query := transaction.DB(ctx, r.db).Model(&AuditEvent{}).
+		Select("actor_id, date_trunc('day', occurred_at AT TIME ZONE 'UTC') AS day, action, entity_type, COUNT(*) AS count").
+		Where("project_id = ? AND actor_id IS NOT NULL", projectID).
+		Where("action IN ? AND entity_type IN ?", actions, entityTypes).
+		Where("occurred_at >= ? AND occurred_at < ?", since, until)
+	if actorID != nil {
+		query = query.Where("actor_id = ?", *actorID)
+	}
+
+	var counts []*DailyActorCount
+	err := query.
+		Group("actor_id, day, action, entity_type").
+		Order("day").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		c.Day = time.Date(c.Day.Year(), c.Day.Month(), c.Day.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return counts, nil
+}
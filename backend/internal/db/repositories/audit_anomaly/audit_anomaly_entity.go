@@ -0,0 +1,37 @@
+package audit_anomaly
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindMassDeletion is one actor deleting more than the organization's threshold within
+	// its window
+	KindMassDeletion Kind = "mass_deletion"
+	// KindOffHoursRoleChange is a role being changed outside the organization's business hours
+	KindOffHoursRoleChange Kind = "off_hours_role_change"
+)
+
+// AuditAnomaly is unusual activity found in an organization's audit log
+type AuditAnomaly struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	Kind           Kind       `gorm:"type:varchar(30);not null"`
+	ActorID        *uuid.UUID `gorm:"type:uuid"`
+	// AuditEventID is the flagged event of anomalies about a single event
+	AuditEventID *uuid.UUID `gorm:"type:uuid"`
+	EventCount   int        `gorm:"not null"`
+	WindowStart  time.Time  `gorm:"not null"`
+	WindowEnd    time.Time  `gorm:"not null"`
+	DetectedAt   time.Time  `gorm:"not null"`
+	// NotifiedAt is set once the organization's admins have been emailed
+	NotifiedAt *time.Time
+}
+
+func (AuditAnomaly) TableName() string {
+	return "audit_anomalies"
+}
@@ -0,0 +1,86 @@
+package audit_anomaly
+
+//go:generate mockgen -source=audit_anomaly_repository.go -destination=mocks/audit_anomaly_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// CreateIfAbsent records the anomaly unless its audit event was already flagged. It
+	// reports whether a new anomaly was recorded.
+	CreateIfAbsent(ctx context.Context, anomaly *AuditAnomaly) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*AuditAnomaly, error)
+	// GetByOrgID returns the organization's latest anomalies, newest first
+	GetByOrgID(ctx context.Context, orgID uuid.UUID, limit int) ([]*AuditAnomaly, error)
+	// ExistsSince reports whether the actor has an anomaly of the kind whose window ends
+	// after since
+	ExistsSince(ctx context.Context, orgID uuid.UUID, kind Kind, actorID uuid.UUID, since time.Time) (bool, error)
+	// MarkNotified claims the anomaly's notification. It reports false when it was already sent.
+	MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateIfAbsent(ctx context.Context, anomaly *AuditAnomaly) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(anomaly)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*AuditAnomaly, error) {
+	var anomaly AuditAnomaly
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&anomaly)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &anomaly, nil
+}
+
+func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID, limit int) ([]*AuditAnomaly, error) {
+	var anomalies []*AuditAnomaly
+	result := transaction.DB(ctx, r.db).
+		Where("organization_id = ?", orgID).
+		Order("detected_at DESC").
+		Limit(limit).
+		Find(&anomalies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return anomalies, nil
+}
+
+func (r *repository) ExistsSince(ctx context.Context, orgID uuid.UUID, kind Kind, actorID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&AuditAnomaly{}).
+		Where("organization_id = ? AND kind = ? AND actor_id = ? AND window_end > ?", orgID, kind, actorID, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&AuditAnomaly{}).
+		Where("id = ? AND notified_at IS NULL", id).
+		Update("notified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_anomaly_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit_anomaly_repository.go -destination=mocks/audit_anomaly_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	audit_anomaly "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIfAbsent mocks base method.
+func (m *MockRepository) CreateIfAbsent(ctx context.Context, anomaly *audit_anomaly.AuditAnomaly) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfAbsent", ctx, anomaly)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIfAbsent indicates an expected call of CreateIfAbsent.
+func (mr *MockRepositoryMockRecorder) CreateIfAbsent(ctx, anomaly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfAbsent", reflect.TypeOf((*MockRepository)(nil).CreateIfAbsent), ctx, anomaly)
+}
+
+// ExistsSince mocks base method.
+func (m *MockRepository) ExistsSince(ctx context.Context, orgID uuid.UUID, kind audit_anomaly.Kind, actorID uuid.UUID, since time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsSince", ctx, orgID, kind, actorID, since)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsSince indicates an expected call of ExistsSince.
+func (mr *MockRepositoryMockRecorder) ExistsSince(ctx, orgID, kind, actorID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsSince", reflect.TypeOf((*MockRepository)(nil).ExistsSince), ctx, orgID, kind, actorID, since)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*audit_anomaly.AuditAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*audit_anomaly.AuditAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockRepository) GetByOrgID(ctx context.Context, orgID uuid.UUID, limit int) ([]*audit_anomaly.AuditAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", ctx, orgID, limit)
+	ret0, _ := ret[0].([]*audit_anomaly.AuditAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockRepositoryMockRecorder) GetByOrgID(ctx, orgID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockRepository)(nil).GetByOrgID), ctx, orgID, limit)
+}
+
+// MarkNotified mocks base method.
+func (m *MockRepository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockRepositoryMockRecorder) MarkNotified(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockRepository)(nil).MarkNotified), ctx, id, at)
+}
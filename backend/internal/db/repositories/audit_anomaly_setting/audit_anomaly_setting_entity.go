@@ -0,0 +1,53 @@
+package audit_anomaly_setting
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMassDeletionThreshold     = 25
+	DefaultMassDeletionWindowMinutes = 10
+	DefaultBusinessHoursStart        = 7
+	DefaultBusinessHoursEnd          = 19
+	DefaultTimezone                  = "UTC"
+)
+
+// AuditAnomalySetting holds an organization's audit anomaly thresholds
+type AuditAnomalySetting struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Enabled        bool      `gorm:"not null;default:true"`
+	// MassDeletionThreshold deletions by one actor within MassDeletionWindowMinutes are a
+	// mass deletion
+	MassDeletionThreshold     int `gorm:"not null"`
+	MassDeletionWindowMinutes int `gorm:"not null"`
+	// Role changes outside [BusinessHoursStart, BusinessHoursEnd) on weekdays in Timezone
+	// are flagged
+	BusinessHoursStart int       `gorm:"not null"`
+	BusinessHoursEnd   int       `gorm:"not null"`
+	Timezone           string    `gorm:"type:varchar(64);not null"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+func (AuditAnomalySetting) TableName() string {
+	return "audit_anomaly_settings"
+}
+
+// Default returns the settings of an organization that hasn't configured any
+func Default(orgID uuid.UUID) *AuditAnomalySetting {
+	return &AuditAnomalySetting{
+		OrganizationID:            orgID,
+		Enabled:                   true,
+		MassDeletionThreshold:     DefaultMassDeletionThreshold,
+		MassDeletionWindowMinutes: DefaultMassDeletionWindowMinutes,
+		BusinessHoursStart:        DefaultBusinessHoursStart,
+		BusinessHoursEnd:          DefaultBusinessHoursEnd,
+		Timezone:                  DefaultTimezone,
+	}
+}
+
+// MassDeletionWindow returns the window deletions are counted over
+func (s *AuditAnomalySetting) MassDeletionWindow() time.Duration {
+	return time.Duration(s.MassDeletionWindowMinutes) * time.Minute
+}
@@ -0,0 +1,43 @@
+package audit_anomaly_setting
+
+//go:generate mockgen -source=audit_anomaly_setting_repository.go -destination=mocks/audit_anomaly_setting_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// GetByOrgID returns the organization's settings, or the defaults when it has none
+	GetByOrgID(ctx context.Context, orgID uuid.UUID) (*AuditAnomalySetting, error)
+	// Save creates or replaces the organization's settings
+	Save(ctx context.Context, setting *AuditAnomalySetting) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) (*AuditAnomalySetting, error) {
+	var setting AuditAnomalySetting
+	err := transaction.DB(ctx, r.db).Where("organization_id = ?", orgID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Default(orgID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *repository) Save(ctx context.Context, setting *AuditAnomalySetting) error {
+	return transaction.DB(ctx, r.db).Save(setting).Error
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_anomaly_setting_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit_anomaly_setting_repository.go -destination=mocks/audit_anomaly_setting_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	audit_anomaly_setting "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByOrgID mocks base method.
+func (m *MockRepository) GetByOrgID(ctx context.Context, orgID uuid.UUID) (*audit_anomaly_setting.AuditAnomalySetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", ctx, orgID)
+	ret0, _ := ret[0].(*audit_anomaly_setting.AuditAnomalySetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockRepositoryMockRecorder) GetByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockRepository)(nil).GetByOrgID), ctx, orgID)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, setting *audit_anomaly_setting.AuditAnomalySetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, setting)
+}
@@ -0,0 +1,21 @@
+package auto_archive_run
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutoArchiveRun records that the auto-archival job archived cards of a board, so one
+// summary is sent per run
+type AutoArchiveRun struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BoardID    uuid.UUID  `gorm:"type:uuid;not null"`
+	CardCount  int        `gorm:"not null"`
+	ArchivedAt time.Time  `gorm:"type:timestamp with time zone;not null"`
+	NotifiedAt *time.Time `gorm:"type:timestamp with time zone"`
+}
+
+func (AutoArchiveRun) TableName() string {
+	return "auto_archive_runs"
+}
@@ -0,0 +1,51 @@
+package auto_archive_run
+
+//go:generate mockgen -source=auto_archive_run_repository.go -destination=mocks/auto_archive_run_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, run *AutoArchiveRun) error
+	GetByID(ctx context.Context, id uuid.UUID) (*AutoArchiveRun, error)
+	// MarkNotified claims the run's summary. It reports false when it was already sent.
+	MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, run *AutoArchiveRun) error {
+	return transaction.DB(ctx, r.db).Create(run).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*AutoArchiveRun, error) {
+	var run AutoArchiveRun
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *repository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&AutoArchiveRun{}).
+		Where("id = ? AND notified_at IS NULL", id).
+		Update("notified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
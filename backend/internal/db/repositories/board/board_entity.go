@@ -15,6 +15,20 @@ type Board struct {
 	CreatedAt   time.Time  `gorm:"autoCreateTime"`
 	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
 	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	// AutoArchiveDays is how many days cards may stay in a done column before they are
+	// archived; nil never archives them
+	AutoArchiveDays *int `gorm:"type:integer"`
+	// NotificationBatchMinutes is quiet mode: card notifications are collected for this many
+	// minutes and sent as one summary per recipient; nil notifies per event
+	NotificationBatchMinutes *int `gorm:"type:integer"`
+	// SprintAutoMembership makes moves in and out of the backlog columns change the active
+	// sprint: cards leaving the backlog join it and cards returning leave it
+	SprintAutoMembership bool `gorm:"type:boolean;not null;default:false"`
+	// BackgroundColor, BackgroundImageURL and ColumnPalette are the board's appearance; empty
+	// leaves it to the client's defaults
+	BackgroundColor    string `gorm:"type:varchar(7);not null;default:''"`
+	BackgroundImageURL string `gorm:"type:text;not null;default:''"`
+	ColumnPalette      string `gorm:"type:varchar(32);not null;default:''"`
 }
 
 func (Board) TableName() string {
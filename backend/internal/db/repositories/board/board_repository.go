@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +16,8 @@ type Repository interface {
 	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Board, error)
 	GetDefaultByProjectID(ctx context.Context, projectID uuid.UUID) (*Board, error)
 	GetAll(ctx context.Context) ([]*Board, error)
+	// GetAutoArchiving returns the boards that archive cards left in done columns
+	GetAutoArchiving(ctx context.Context) ([]*Board, error)
 	Update(ctx context.Context, board *Board) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -28,12 +31,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, board *Board) error {
-	return r.db.WithContext(ctx).Create(board).Error
+	return transaction.DB(ctx, r.db).Create(board).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Board, error) {
 	var board Board
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&board).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&board).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +45,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Board, error)
 
 func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Board, error) {
 	var boards []*Board
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("project_id = ?", projectID).
 		Order("created_at ASC").
 		Find(&boards).Error
@@ -54,7 +57,7 @@ func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([
 
 func (r *repository) GetDefaultByProjectID(ctx context.Context, projectID uuid.UUID) (*Board, error) {
 	var board Board
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("project_id = ? AND is_default = TRUE", projectID).
 		First(&board).Error
 	if err != nil {
@@ -65,7 +68,16 @@ func (r *repository) GetDefaultByProjectID(ctx context.Context, projectID uuid.U
 
 func (r *repository) GetAll(ctx context.Context) ([]*Board, error) {
 	var boards []*Board
-	err := r.db.WithContext(ctx).Find(&boards).Error
+	err := transaction.DB(ctx, r.db).Find(&boards).Error
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+func (r *repository) GetAutoArchiving(ctx context.Context) ([]*Board, error) {
+	var boards []*Board
+	err := transaction.DB(ctx, r.db).Where("auto_archive_days IS NOT NULL").Find(&boards).Error
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +85,9 @@ func (r *repository) GetAll(ctx context.Context) ([]*Board, error) {
 }
 
 func (r *repository) Update(ctx context.Context, board *Board) error {
-	return r.db.WithContext(ctx).Save(board).Error
+	return transaction.DB(ctx, r.db).Save(board).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Board{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Board{}, "id = ?", id).Error
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/db/repositories/board/board_repository.go
+// Source: board_repository.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/db/repositories/board/board_repository.go -destination=internal/db/repositories/board/mocks/board_repository_mock.go -package=mocks
+//	mockgen -source=board_repository.go -destination=mocks/board_repository_mock.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -70,6 +70,36 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
 }
 
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context) ([]*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
+}
+
+// GetAutoArchiving mocks base method.
+func (m *MockRepository) GetAutoArchiving(ctx context.Context) ([]*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutoArchiving", ctx)
+	ret0, _ := ret[0].([]*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutoArchiving indicates an expected call of GetAutoArchiving.
+func (mr *MockRepositoryMockRecorder) GetAutoArchiving(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutoArchiving", reflect.TypeOf((*MockRepository)(nil).GetAutoArchiving), ctx)
+}
+
 // GetByID mocks base method.
 func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*board.Board, error) {
 	m.ctrl.T.Helper()
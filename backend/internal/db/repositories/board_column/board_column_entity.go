@@ -16,10 +16,37 @@ type BoardColumn struct {
 	IsDone    bool      `gorm:"type:boolean;not null;default:false"`
 	Color     string    `gorm:"type:varchar(7);default:'#6B7280'"`
 	WipLimit  *int      `gorm:"type:integer"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	// PointLimit caps the story points of the column's cards; moves over it are refused
+	PointLimit *int      `gorm:"type:integer"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 }
 
 func (BoardColumn) TableName() string {
 	return "board_columns"
 }
+
+// ColumnStats are the aggregates of the cards in a column
+type ColumnStats struct {
+	ColumnID  uuid.UUID
+	CardCount int
+	// StoryPoints is the sum over the estimated cards, EstimatedCardCount their number
+	StoryPoints        int
+	EstimatedCardCount int
+	WipLimit           *int
+	PointLimit         *int
+	// AverageAgeSeconds is the mean time since the cards were created, nil for an empty column
+	AverageAgeSeconds *float64
+	// AverageTimeInColumnSeconds is the mean time since the cards entered the column
+	AverageTimeInColumnSeconds *float64
+}
+
+// OverWipLimit reports whether the column holds more cards than its WIP limit
+func (s *ColumnStats) OverWipLimit() bool {
+	return s.WipLimit != nil && s.CardCount > *s.WipLimit
+}
+
+// OverPointLimit reports whether the column holds more story points than its point limit
+func (s *ColumnStats) OverPointLimit() bool {
+	return s.PointLimit != nil && s.StoryPoints > *s.PointLimit
+}
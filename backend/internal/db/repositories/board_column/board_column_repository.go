@@ -4,8 +4,10 @@ package board_column
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +16,9 @@ type Repository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*BoardColumn, error)
 	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*BoardColumn, error)
 	GetVisibleByBoardID(ctx context.Context, boardID uuid.UUID) ([]*BoardColumn, error)
+	// GetStatsByBoardID aggregates the unarchived cards of each column of the board in one query, in
+	// column order. Ages are measured up to now.
+	GetStatsByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*ColumnStats, error)
 	GetMaxPosition(ctx context.Context, boardID uuid.UUID) (int, error)
 	Update(ctx context.Context, column *BoardColumn) error
 	UpdatePositions(ctx context.Context, columns []*BoardColumn) error
@@ -29,12 +34,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, column *BoardColumn) error {
-	return r.db.WithContext(ctx).Create(column).Error
+	return transaction.DB(ctx, r.db).Create(column).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*BoardColumn, error) {
 	var column BoardColumn
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&column).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&column).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +48,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*BoardColumn, e
 
 func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*BoardColumn, error) {
 	var columns []*BoardColumn
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ?", boardID).
 		Order("position ASC").
 		Find(&columns).Error
@@ -55,7 +60,7 @@ func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Bo
 
 func (r *repository) GetVisibleByBoardID(ctx context.Context, boardID uuid.UUID) ([]*BoardColumn, error) {
 	var columns []*BoardColumn
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ? AND is_hidden = FALSE", boardID).
 		Order("position ASC").
 		Find(&columns).Error
@@ -65,9 +70,33 @@ func (r *repository) GetVisibleByBoardID(ctx context.Context, boardID uuid.UUID)
 	return columns, nil
 }
 
+func (r *repository) GetStatsByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*ColumnStats, error) {
+	var stats []*ColumnStats
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			bc.id AS column_id,
+			COUNT(c.id) AS card_count,
+			COALESCE(SUM(c.story_points), 0) AS story_points,
+			COUNT(c.story_points) AS estimated_card_count,
+			bc.wip_limit AS wip_limit,
+			bc.point_limit AS point_limit,
+			AVG(EXTRACT(EPOCH FROM (?::timestamptz - c.created_at))) AS average_age_seconds,
+			AVG(EXTRACT(EPOCH FROM (?::timestamptz - c.column_entered_at))) AS average_time_in_column_seconds
+		FROM board_columns bc
+		LEFT JOIN cards c ON c.column_id = bc.id AND c.archived_at IS NULL
+		WHERE bc.board_id = ?
+		GROUP BY bc.id
+		ORDER BY bc.position ASC`, now, now, boardID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 func (r *repository) GetMaxPosition(ctx context.Context, boardID uuid.UUID) (int, error) {
 	var maxPos *int
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&BoardColumn{}).
 		Where("board_id = ?", boardID).
 		Select("COALESCE(MAX(position), -1)").
@@ -82,11 +111,11 @@ func (r *repository) GetMaxPosition(ctx context.Context, boardID uuid.UUID) (int
 }
 
 func (r *repository) Update(ctx context.Context, column *BoardColumn) error {
-	return r.db.WithContext(ctx).Save(column).Error
+	return transaction.DB(ctx, r.db).Save(column).Error
 }
 
 func (r *repository) UpdatePositions(ctx context.Context, columns []*BoardColumn) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		for _, col := range columns {
 			if err := tx.Model(&BoardColumn{}).
 				Where("id = ?", col.ID).
@@ -99,5 +128,5 @@ func (r *repository) UpdatePositions(ctx context.Context, columns []*BoardColumn
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&BoardColumn{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&BoardColumn{}, "id = ?", id).Error
 }
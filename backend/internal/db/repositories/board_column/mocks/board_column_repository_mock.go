@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/db/repositories/board_column/board_column_repository.go
+// Source: board_column_repository.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/db/repositories/board_column/board_column_repository.go -destination=internal/db/repositories/board_column/mocks/board_column_repository_mock.go -package=mocks
+//	mockgen -source=board_column_repository.go -destination=mocks/board_column_repository_mock.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	board_column "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
@@ -115,6 +116,21 @@ func (mr *MockRepositoryMockRecorder) GetMaxPosition(ctx, boardID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPosition", reflect.TypeOf((*MockRepository)(nil).GetMaxPosition), ctx, boardID)
 }
 
+// GetStatsByBoardID mocks base method.
+func (m *MockRepository) GetStatsByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*board_column.ColumnStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatsByBoardID", ctx, boardID, now)
+	ret0, _ := ret[0].([]*board_column.ColumnStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatsByBoardID indicates an expected call of GetStatsByBoardID.
+func (mr *MockRepositoryMockRecorder) GetStatsByBoardID(ctx, boardID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsByBoardID", reflect.TypeOf((*MockRepository)(nil).GetStatsByBoardID), ctx, boardID, now)
+}
+
 // GetVisibleByBoardID mocks base method.
 func (m *MockRepository) GetVisibleByBoardID(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error) {
 	m.ctrl.T.Helper()
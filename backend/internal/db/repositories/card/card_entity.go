@@ -27,9 +27,23 @@ type Card struct {
 	AssigneeID  *uuid.UUID   `gorm:"type:uuid"`
 	DueDate     *time.Time   `gorm:"type:timestamptz"`
 	StoryPoints *int         `gorm:"type:integer"`
-	CreatedAt   time.Time    `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time    `gorm:"autoUpdateTime"`
-	CreatedBy   *uuid.UUID   `gorm:"type:uuid"`
+	// OriginalStoryPoints is the first estimate the card was given
+	OriginalStoryPoints *int       `gorm:"type:integer"`
+	EpicID              *uuid.UUID `gorm:"type:uuid"`
+	// Number counts the cards of the board's project from 1; with the project key it makes the
+	// card's key, such as API-42. Create assigns it
+	Number int `gorm:"type:integer;not null"`
+	// ColumnEnteredAt is when the card arrived in its current column
+	ColumnEnteredAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+	// StartedAt is when the card first left the column it was created in
+	StartedAt *time.Time `gorm:"type:timestamptz"`
+	// ArchivedAt is when the card was archived; archived cards are left off their board
+	ArchivedAt *time.Time `gorm:"type:timestamptz"`
+	// MergedIntoID is the card this card was merged into as a duplicate
+	MergedIntoID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+	CreatedBy    *uuid.UUID `gorm:"type:uuid"`
 }
 
 // CardSprint represents the many-to-many relationship between cards and sprints
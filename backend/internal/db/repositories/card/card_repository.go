@@ -4,23 +4,54 @@ package card
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
+	// Create numbers the card in its board's project unless it already has a number
 	Create(ctx context.Context, card *Card) error
+	// ReserveNumbers hands out count consecutive card numbers of the board's project, returning
+	// the first, for cards inserted without Create
+	ReserveNumbers(ctx context.Context, boardID uuid.UUID, count int) (int, error)
+	// GetByID returns the card even when it is archived
 	GetByID(ctx context.Context, id uuid.UUID) (*Card, error)
+	// GetByProjectNumber returns the project's card with the number, even when it is archived
+	GetByProjectNumber(ctx context.Context, projectID uuid.UUID, number int) (*Card, error)
+	// GetByColumnID, GetByColumnEnteredBefore, GetByBoardID, GetByAssigneeID and
+	// GetBacklogByBoardID leave archived cards out
 	GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*Card, error)
+	// GetByColumnEnteredBefore returns the column's cards that arrived there before the given time
+	GetByColumnEnteredBefore(ctx context.Context, columnID uuid.UUID, before time.Time) ([]*Card, error)
 	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error)
 	GetByAssigneeID(ctx context.Context, assigneeID uuid.UUID) ([]*Card, error)
+	// GetBySprintID includes archived cards, so sprint history stays whole
 	GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*Card, error)
 	GetBacklogByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error)
+	// GetArchivedByBoardID returns the board's archived cards, most recently archived first
+	GetArchivedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error)
+	// Archive archives those of the cards that aren't archived yet, returning how many it archived
+	Archive(ctx context.Context, ids []uuid.UUID, at time.Time) (int64, error)
+	// Unarchive puts the card back on its board, restarting its stay in its column at
+	// columnEnteredAt so auto-archival doesn't take it again right away
+	Unarchive(ctx context.Context, id uuid.UUID, columnEnteredAt time.Time) error
+	// Merge archives the cards as duplicates of the card intoID, pointing them at it
+	Merge(ctx context.Context, ids []uuid.UUID, intoID uuid.UUID, at time.Time) error
 	GetAll(ctx context.Context) ([]*Card, error)
 	GetMaxPosition(ctx context.Context, columnID uuid.UUID) (float64, error)
 	GetPositionBetween(ctx context.Context, columnID uuid.UUID, afterCardID *uuid.UUID) (float64, error)
+	// GetStoryPointsByColumnID sums the story points of the column's unarchived cards
+	GetStoryPointsByColumnID(ctx context.Context, columnID uuid.UUID) (int, error)
+	// LockColumnStoryPoints locks the column row until the transaction ends and sums the
+	// story points of its unarchived cards, so a check against the sum holds until commit
+	LockColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error)
+	// MoveCard moves the card to the column; a card moved to another project's board is given
+	// a number of that project
+	MoveCard(ctx context.Context, cardID, targetColumnID, targetBoardID uuid.UUID, afterCardID *uuid.UUID) (*Card, error)
 	Update(ctx context.Context, card *Card) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -32,6 +63,13 @@ type Repository interface {
 	RemoveCardFromAllSprints(ctx context.Context, cardID uuid.UUID) error
 }
 
+const (
+	// positionStep is the gap between neighbouring cards after a column is renumbered
+	positionStep = 1000
+	// minPositionGap is the smallest gap that can still be split before float precision runs out
+	minPositionGap = 1e-6
+)
+
 type repository struct {
 	db *gorm.DB
 }
@@ -41,12 +79,57 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, card *Card) error {
-	return r.db.WithContext(ctx).Create(card).Error
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if card.Number == 0 {
+			number, err := reserveNumbers(tx, card.BoardID, 1)
+			if err != nil {
+				return err
+			}
+			card.Number = number
+		}
+		return tx.Create(card).Error
+	})
+}
+
+func (r *repository) ReserveNumbers(ctx context.Context, boardID uuid.UUID, count int) (int, error) {
+	return reserveNumbers(transaction.DB(ctx, r.db), boardID, count)
+}
+
+// reserveNumbers advances the counter of the board's project by count. The counter is not on
+// the project entity, so saving a project never winds it back; its row stays locked until
+// the transaction ends, so concurrent cards get distinct numbers.
+func reserveNumbers(tx *gorm.DB, boardID uuid.UUID, count int) (int, error) {
+	var last []int
+	err := tx.Raw(`
+		UPDATE projects SET last_card_number = last_card_number + ?
+		FROM boards
+		WHERE boards.id = ? AND projects.id = boards.project_id
+		RETURNING projects.last_card_number
+	`, count, boardID).Scan(&last).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(last) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return last[0] - count + 1, nil
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Card, error) {
 	var card Card
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&card).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&card).Error
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *repository) GetByProjectNumber(ctx context.Context, projectID uuid.UUID, number int) (*Card, error) {
+	var card Card
+	err := transaction.DB(ctx, r.db).
+		Where("number = ? AND board_id IN (?)", number,
+			r.db.Table("boards").Select("id").Where("project_id = ?", projectID)).
+		First(&card).Error
 	if err != nil {
 		return nil, err
 	}
@@ -55,8 +138,8 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Card, error) {
 
 func (r *repository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*Card, error) {
 	var cards []*Card
-	err := r.db.WithContext(ctx).
-		Where("column_id = ?", columnID).
+	err := transaction.DB(ctx, r.db).
+		Where("column_id = ? AND archived_at IS NULL", columnID).
 		Order("position ASC").
 		Find(&cards).Error
 	if err != nil {
@@ -65,10 +148,22 @@ func (r *repository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*
 	return cards, nil
 }
 
+func (r *repository) GetByColumnEnteredBefore(ctx context.Context, columnID uuid.UUID, before time.Time) ([]*Card, error) {
+	var cards []*Card
+	err := transaction.DB(ctx, r.db).
+		Where("column_id = ? AND column_entered_at < ? AND archived_at IS NULL", columnID, before).
+		Order("column_entered_at ASC").
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
 func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error) {
 	var cards []*Card
-	err := r.db.WithContext(ctx).
-		Where("board_id = ?", boardID).
+	err := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND archived_at IS NULL", boardID).
 		Order("position ASC").
 		Find(&cards).Error
 	if err != nil {
@@ -79,8 +174,8 @@ func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Ca
 
 func (r *repository) GetByAssigneeID(ctx context.Context, assigneeID uuid.UUID) ([]*Card, error) {
 	var cards []*Card
-	err := r.db.WithContext(ctx).
-		Where("assignee_id = ?", assigneeID).
+	err := transaction.DB(ctx, r.db).
+		Where("assignee_id = ? AND archived_at IS NULL", assigneeID).
 		Order("due_date ASC NULLS LAST, created_at DESC").
 		Find(&cards).Error
 	if err != nil {
@@ -91,7 +186,7 @@ func (r *repository) GetByAssigneeID(ctx context.Context, assigneeID uuid.UUID)
 
 func (r *repository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*Card, error) {
 	var cards []*Card
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Joins("JOIN card_sprints ON card_sprints.card_id = cards.id").
 		Where("card_sprints.sprint_id = ?", sprintID).
 		Order("cards.position ASC").
@@ -105,8 +200,8 @@ func (r *repository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*
 func (r *repository) GetBacklogByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error) {
 	var cards []*Card
 	// Cards in backlog are those not assigned to any sprint
-	err := r.db.WithContext(ctx).
-		Where("board_id = ? AND id NOT IN (SELECT card_id FROM card_sprints)", boardID).
+	err := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND archived_at IS NULL AND id NOT IN (SELECT card_id FROM card_sprints)", boardID).
 		Order("position ASC").
 		Find(&cards).Error
 	if err != nil {
@@ -115,9 +210,49 @@ func (r *repository) GetBacklogByBoardID(ctx context.Context, boardID uuid.UUID)
 	return cards, nil
 }
 
+func (r *repository) GetArchivedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Card, error) {
+	var cards []*Card
+	err := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND archived_at IS NOT NULL", boardID).
+		Order("archived_at DESC, position ASC").
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (r *repository) Archive(ctx context.Context, ids []uuid.UUID, at time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := transaction.DB(ctx, r.db).Model(&Card{}).
+		Where("id IN ? AND archived_at IS NULL", ids).
+		Update("archived_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) Merge(ctx context.Context, ids []uuid.UUID, intoID uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return transaction.DB(ctx, r.db).Model(&Card{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"merged_into_id": intoID,
+			"archived_at":    gorm.Expr("COALESCE(archived_at, ?)", at),
+		}).Error
+}
+
+func (r *repository) Unarchive(ctx context.Context, id uuid.UUID, columnEnteredAt time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&Card{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"archived_at": nil, "column_entered_at": columnEnteredAt}).Error
+}
+
 func (r *repository) GetAll(ctx context.Context) ([]*Card, error) {
 	var cards []*Card
-	err := r.db.WithContext(ctx).Find(&cards).Error
+	err := transaction.DB(ctx, r.db).Find(&cards).Error
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +261,7 @@ func (r *repository) GetAll(ctx context.Context) ([]*Card, error) {
 
 func (r *repository) GetMaxPosition(ctx context.Context, columnID uuid.UUID) (float64, error) {
 	var maxPos *float64
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&Card{}).
 		Where("column_id = ?", columnID).
 		Select("COALESCE(MAX(position), 0)").
@@ -144,7 +279,7 @@ func (r *repository) GetPositionBetween(ctx context.Context, columnID uuid.UUID,
 	// If afterCardID is nil, insert at the beginning
 	if afterCardID == nil {
 		var minPos *float64
-		err := r.db.WithContext(ctx).
+		err := transaction.DB(ctx, r.db).
 			Model(&Card{}).
 			Where("column_id = ?", columnID).
 			Select("MIN(position)").
@@ -160,14 +295,14 @@ func (r *repository) GetPositionBetween(ctx context.Context, columnID uuid.UUID,
 
 	// Get the card we're inserting after
 	var afterCard Card
-	err := r.db.WithContext(ctx).Where("id = ?", *afterCardID).First(&afterCard).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", *afterCardID).First(&afterCard).Error
 	if err != nil {
 		return 0, err
 	}
 
 	// Get the next card
 	var nextCard Card
-	err = r.db.WithContext(ctx).
+	err = transaction.DB(ctx, r.db).
 		Where("column_id = ? AND position > ?", columnID, afterCard.Position).
 		Order("position ASC").
 		First(&nextCard).Error
@@ -183,12 +318,174 @@ func (r *repository) GetPositionBetween(ctx context.Context, columnID uuid.UUID,
 	return (afterCard.Position + nextCard.Position) / 2, nil
 }
 
+func (r *repository) GetStoryPointsByColumnID(ctx context.Context, columnID uuid.UUID) (int, error) {
+	return sumStoryPoints(transaction.DB(ctx, r.db), columnID)
+}
+
+func (r *repository) LockColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error) {
+	db := transaction.DB(ctx, r.db)
+	var locked struct{ ID uuid.UUID }
+	if err := db.Table("board_columns").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", columnID).
+		Take(&locked).Error; err != nil {
+		return 0, err
+	}
+	return sumStoryPoints(db, columnID)
+}
+
+func sumStoryPoints(db *gorm.DB, columnID uuid.UUID) (int, error) {
+	var points int
+	err := db.Model(&Card{}).
+		Where("column_id = ? AND archived_at IS NULL", columnID).
+		Select("COALESCE(SUM(story_points), 0)").
+		Scan(&points).Error
+	if err != nil {
+		return 0, err
+	}
+	return points, nil
+}
+
+// MoveCard moves a card into a column after afterCardID (or to the top when nil).
+// The target column row is locked for the duration of the transaction, so concurrent
+// moves into the same column are serialized and never compute colliding positions.
+// When the gap around the insertion point is exhausted, the column is renumbered first.
+func (r *repository) MoveCard(ctx context.Context, cardID, targetColumnID, targetBoardID uuid.UUID, afterCardID *uuid.UUID) (*Card, error) {
+	var moved Card
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var locked struct{ ID uuid.UUID }
+		if err := tx.Table("board_columns").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", targetColumnID).
+			Take(&locked).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", cardID).
+			First(&moved).Error; err != nil {
+			return err
+		}
+
+		position, ok, err := positionAfter(tx, targetColumnID, cardID, afterCardID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := renumberColumn(tx, targetColumnID, cardID); err != nil {
+				return err
+			}
+			if position, _, err = positionAfter(tx, targetColumnID, cardID, afterCardID); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"column_id": targetColumnID,
+			"board_id":  targetBoardID,
+			"position":  position,
+		}
+		if targetBoardID != moved.BoardID {
+			var projectIDs []uuid.UUID
+			if err := tx.Table("boards").Distinct("project_id").
+				Where("id IN ?", []uuid.UUID{moved.BoardID, targetBoardID}).
+				Pluck("project_id", &projectIDs).Error; err != nil {
+				return err
+			}
+			if len(projectIDs) > 1 {
+				number, err := reserveNumbers(tx, targetBoardID, 1)
+				if err != nil {
+					return err
+				}
+				updates["number"] = number
+			}
+		}
+		// Reordering within a column keeps the time the card arrived there
+		if moved.ColumnID != targetColumnID {
+			now := time.Now()
+			updates["column_entered_at"] = now
+			updates["started_at"] = gorm.Expr("COALESCE(started_at, ?)", now)
+		}
+		return tx.Model(&moved).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &moved, nil
+}
+
+// positionAfter computes the position for placing movingID after afterCardID in the column,
+// ignoring the moving card itself. ok is false when there is no room left between neighbours.
+func positionAfter(tx *gorm.DB, columnID, movingID uuid.UUID, afterCardID *uuid.UUID) (float64, bool, error) {
+	siblings := tx.Model(&Card{}).Where("column_id = ? AND id <> ?", columnID, movingID).Session(&gorm.Session{})
+
+	var afterCard Card
+	if afterCardID != nil {
+		err := tx.Where("id = ? AND column_id = ? AND id <> ?", *afterCardID, columnID, movingID).First(&afterCard).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return 0, false, err
+		}
+		if err == gorm.ErrRecordNotFound {
+			// The anchor left the column in a concurrent move; append to the end instead
+			var maxPos *float64
+			if err := siblings.Select("MAX(position)").Scan(&maxPos).Error; err != nil {
+				return 0, false, err
+			}
+			if maxPos == nil {
+				return positionStep, true, nil
+			}
+			return *maxPos + positionStep, true, nil
+		}
+	}
+
+	if afterCardID == nil {
+		var minPos *float64
+		if err := siblings.Select("MIN(position)").Scan(&minPos).Error; err != nil {
+			return 0, false, err
+		}
+		if minPos == nil || *minPos >= positionStep {
+			return positionStep / 2, true, nil
+		}
+		return *minPos / 2, *minPos > minPositionGap, nil
+	}
+
+	var nextCard Card
+	err := siblings.
+		Where("position > ?", afterCard.Position).
+		Order("position ASC").
+		First(&nextCard).Error
+	if err == gorm.ErrRecordNotFound {
+		return afterCard.Position + positionStep, true, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return (afterCard.Position + nextCard.Position) / 2, nextCard.Position-afterCard.Position > minPositionGap, nil
+}
+
+// renumberColumn spreads the column's cards (except movingID) evenly, keeping their order
+func renumberColumn(tx *gorm.DB, columnID, movingID uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE cards SET position = ordered.rn * ?
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY position ASC, id ASC) AS rn
+			FROM cards
+			WHERE column_id = ? AND id <> ?
+		) AS ordered
+		WHERE cards.id = ordered.id
+	`, positionStep, columnID, movingID).Error
+}
+
 func (r *repository) Update(ctx context.Context, card *Card) error {
-	return r.db.WithContext(ctx).Save(card).Error
+	return transaction.DB(ctx, r.db).Save(card).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Card{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Card{}, "id = ?", id).Error
 }
 
 // AddCardToSprint adds a card to a sprint (many-to-many)
@@ -198,14 +495,14 @@ func (r *repository) AddCardToSprint(ctx context.Context, cardID, sprintID uuid.
 		SprintID: sprintID,
 	}
 	// Use ON CONFLICT DO NOTHING to handle duplicate entries gracefully
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Clauses(clause.OnConflict{DoNothing: true}).
 		Create(cardSprint).Error
 }
 
 // RemoveCardFromSprint removes a card from a sprint
 func (r *repository) RemoveCardFromSprint(ctx context.Context, cardID, sprintID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("card_id = ? AND sprint_id = ?", cardID, sprintID).
 		Delete(&CardSprint{}).Error
 }
@@ -213,7 +510,7 @@ func (r *repository) RemoveCardFromSprint(ctx context.Context, cardID, sprintID
 // GetSprintIDsForCard returns all sprint IDs that a card belongs to
 func (r *repository) GetSprintIDsForCard(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
 	var cardSprints []CardSprint
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("card_id = ?", cardID).
 		Order("added_at ASC").
 		Find(&cardSprints).Error
@@ -230,7 +527,7 @@ func (r *repository) GetSprintIDsForCard(ctx context.Context, cardID uuid.UUID)
 
 // SetCardSprints replaces all sprint assignments for a card
 func (r *repository) SetCardSprints(ctx context.Context, cardID uuid.UUID, sprintIDs []uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Remove all existing sprint assignments
 		if err := tx.Where("card_id = ?", cardID).Delete(&CardSprint{}).Error; err != nil {
 			return err
@@ -252,7 +549,7 @@ func (r *repository) SetCardSprints(ctx context.Context, cardID uuid.UUID, sprin
 
 // RemoveCardFromAllSprints removes a card from all sprints (moves to backlog)
 func (r *repository) RemoveCardFromAllSprints(ctx context.Context, cardID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("card_id = ?", cardID).
 		Delete(&CardSprint{}).Error
 }
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
@@ -56,6 +57,21 @@ func (mr *MockRepositoryMockRecorder) AddCardToSprint(ctx, cardID, sprintID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCardToSprint", reflect.TypeOf((*MockRepository)(nil).AddCardToSprint), ctx, cardID, sprintID)
 }
 
+// Archive mocks base method.
+func (m *MockRepository) Archive(ctx context.Context, ids []uuid.UUID, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, ids, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockRepositoryMockRecorder) Archive(ctx, ids, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockRepository)(nil).Archive), ctx, ids, at)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, arg1 *card.Card) error {
 	m.ctrl.T.Helper()
@@ -99,6 +115,21 @@ func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
 }
 
+// GetArchivedByBoardID mocks base method.
+func (m *MockRepository) GetArchivedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedByBoardID", ctx, boardID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedByBoardID indicates an expected call of GetArchivedByBoardID.
+func (mr *MockRepositoryMockRecorder) GetArchivedByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedByBoardID", reflect.TypeOf((*MockRepository)(nil).GetArchivedByBoardID), ctx, boardID)
+}
+
 // GetBacklogByBoardID mocks base method.
 func (m *MockRepository) GetBacklogByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +175,21 @@ func (mr *MockRepositoryMockRecorder) GetByBoardID(ctx, boardID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoardID", reflect.TypeOf((*MockRepository)(nil).GetByBoardID), ctx, boardID)
 }
 
+// GetByColumnEnteredBefore mocks base method.
+func (m *MockRepository) GetByColumnEnteredBefore(ctx context.Context, columnID uuid.UUID, before time.Time) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByColumnEnteredBefore", ctx, columnID, before)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByColumnEnteredBefore indicates an expected call of GetByColumnEnteredBefore.
+func (mr *MockRepositoryMockRecorder) GetByColumnEnteredBefore(ctx, columnID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByColumnEnteredBefore", reflect.TypeOf((*MockRepository)(nil).GetByColumnEnteredBefore), ctx, columnID, before)
+}
+
 // GetByColumnID mocks base method.
 func (m *MockRepository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*card.Card, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +220,21 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByProjectNumber mocks base method.
+func (m *MockRepository) GetByProjectNumber(ctx context.Context, projectID uuid.UUID, number int) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProjectNumber", ctx, projectID, number)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProjectNumber indicates an expected call of GetByProjectNumber.
+func (mr *MockRepositoryMockRecorder) GetByProjectNumber(ctx, projectID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectNumber", reflect.TypeOf((*MockRepository)(nil).GetByProjectNumber), ctx, projectID, number)
+}
+
 // GetBySprintID mocks base method.
 func (m *MockRepository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*card.Card, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +295,65 @@ func (mr *MockRepositoryMockRecorder) GetSprintIDsForCard(ctx, cardID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintIDsForCard", reflect.TypeOf((*MockRepository)(nil).GetSprintIDsForCard), ctx, cardID)
 }
 
+// GetStoryPointsByColumnID mocks base method.
+func (m *MockRepository) GetStoryPointsByColumnID(ctx context.Context, columnID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoryPointsByColumnID", ctx, columnID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoryPointsByColumnID indicates an expected call of GetStoryPointsByColumnID.
+func (mr *MockRepositoryMockRecorder) GetStoryPointsByColumnID(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoryPointsByColumnID", reflect.TypeOf((*MockRepository)(nil).GetStoryPointsByColumnID), ctx, columnID)
+}
+
+// LockColumnStoryPoints mocks base method.
+func (m *MockRepository) LockColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockColumnStoryPoints", ctx, columnID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockColumnStoryPoints indicates an expected call of LockColumnStoryPoints.
+func (mr *MockRepositoryMockRecorder) LockColumnStoryPoints(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockColumnStoryPoints", reflect.TypeOf((*MockRepository)(nil).LockColumnStoryPoints), ctx, columnID)
+}
+
+// Merge mocks base method.
+func (m *MockRepository) Merge(ctx context.Context, ids []uuid.UUID, intoID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, ids, intoID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockRepositoryMockRecorder) Merge(ctx, ids, intoID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockRepository)(nil).Merge), ctx, ids, intoID, at)
+}
+
+// MoveCard mocks base method.
+func (m *MockRepository) MoveCard(ctx context.Context, cardID, targetColumnID, targetBoardID uuid.UUID, afterCardID *uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCard", ctx, cardID, targetColumnID, targetBoardID, afterCardID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCard indicates an expected call of MoveCard.
+func (mr *MockRepositoryMockRecorder) MoveCard(ctx, cardID, targetColumnID, targetBoardID, afterCardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCard", reflect.TypeOf((*MockRepository)(nil).MoveCard), ctx, cardID, targetColumnID, targetBoardID, afterCardID)
+}
+
 // RemoveCardFromAllSprints mocks base method.
 func (m *MockRepository) RemoveCardFromAllSprints(ctx context.Context, cardID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -262,6 +382,21 @@ func (mr *MockRepositoryMockRecorder) RemoveCardFromSprint(ctx, cardID, sprintID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCardFromSprint", reflect.TypeOf((*MockRepository)(nil).RemoveCardFromSprint), ctx, cardID, sprintID)
 }
 
+// ReserveNumbers mocks base method.
+func (m *MockRepository) ReserveNumbers(ctx context.Context, boardID uuid.UUID, count int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveNumbers", ctx, boardID, count)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveNumbers indicates an expected call of ReserveNumbers.
+func (mr *MockRepositoryMockRecorder) ReserveNumbers(ctx, boardID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveNumbers", reflect.TypeOf((*MockRepository)(nil).ReserveNumbers), ctx, boardID, count)
+}
+
 // SetCardSprints mocks base method.
 func (m *MockRepository) SetCardSprints(ctx context.Context, cardID uuid.UUID, sprintIDs []uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -276,6 +411,20 @@ func (mr *MockRepositoryMockRecorder) SetCardSprints(ctx, cardID, sprintIDs any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardSprints", reflect.TypeOf((*MockRepository)(nil).SetCardSprints), ctx, cardID, sprintIDs)
 }
 
+// Unarchive mocks base method.
+func (m *MockRepository) Unarchive(ctx context.Context, id uuid.UUID, columnEnteredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", ctx, id, columnEnteredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockRepositoryMockRecorder) Unarchive(ctx, id, columnEnteredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockRepository)(nil).Unarchive), ctx, id, columnEnteredAt)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, arg1 *card.Card) error {
 	m.ctrl.T.Helper()
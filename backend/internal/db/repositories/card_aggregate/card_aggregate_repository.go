@@ -0,0 +1,154 @@
+package card_aggregate
+
+//go:generate mockgen -source=card_aggregate_repository.go -destination=mocks/card_aggregate_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+// Field is a card attribute cards can be grouped by
+type Field string
+
+const (
+	FieldAssignee Field = "assignee"
+	// FieldTag puts a card in one group per tag, so a card with two tags counts twice
+	FieldTag      Field = "tag"
+	FieldPriority Field = "priority"
+	FieldColumn   Field = "column"
+	FieldEpic     Field = "epic"
+)
+
+// grouping is how a field is selected and joined in the aggregate query
+type grouping struct {
+	key   string
+	label string
+	join  string
+}
+
+var groupings = map[Field]grouping{
+	FieldAssignee: {key: "c.assignee_id::text", label: "COALESCE(u.display_name, u.username)", join: "LEFT JOIN users u ON u.id = c.assignee_id"},
+	FieldTag:      {key: "t.id::text", label: "t.name", join: "LEFT JOIN card_tags ct ON ct.card_id = c.id LEFT JOIN tags t ON t.id = ct.tag_id"},
+	FieldPriority: {key: "c.priority::text", label: "c.priority::text"},
+	FieldColumn:   {key: "col.id::text", label: "col.name"},
+	FieldEpic:     {key: "e.id::text", label: "e.name", join: "LEFT JOIN epics e ON e.id = c.epic_id"},
+}
+
+// IsValid reports whether cards can be grouped by the field
+func (f Field) IsValid() bool {
+	_, ok := groupings[f]
+	return ok
+}
+
+// Filter narrows the cards aggregated; empty lists do not filter
+type Filter struct {
+	BoardIDs    []uuid.UUID
+	AssigneeIDs []uuid.UUID
+	// TagIDs keeps cards with any of the tags
+	TagIDs     []uuid.UUID
+	Priorities []card.CardPriority
+	// Done keeps only cards in done columns when true, and only cards outside them when false
+	Done *bool
+}
+
+// Group is the aggregate of the cards sharing a value for every grouped field
+type Group struct {
+	// Keys and Labels hold the group's id (or priority) and display name per grouped field,
+	// in grouping order; nil for cards without one, e.g. unassigned or untagged cards
+	Keys               []*string
+	Labels             []*string
+	CardCount          int
+	EstimatedCardCount int
+	StoryPoints        int
+}
+
+type Repository interface {
+	// Aggregate counts the project's unarchived cards and sums their story points per group,
+	// largest groups first. The fields must be valid and distinct.
+	Aggregate(ctx context.Context, projectID uuid.UUID, groupBy []Field, filter Filter) ([]*Group, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Aggregate(ctx context.Context, projectID uuid.UUID, groupBy []Field, filter Filter) ([]*Group, error) {
+	var selects, joins, groups []string
+	for _, field := range groupBy {
+		g := groupings[field]
+		selects = append(selects, g.key, g.label)
+		groups = append(groups, g.key, g.label)
+		if g.join != "" {
+			joins = append(joins, g.join)
+		}
+	}
+
+	where := []string{"b.project_id = ?", "c.archived_at IS NULL"}
+	args := []interface{}{projectID}
+	if len(filter.BoardIDs) > 0 {
+		where = append(where, "c.board_id IN ?")
+		args = append(args, filter.BoardIDs)
+	}
+	if len(filter.AssigneeIDs) > 0 {
+		where = append(where, "c.assignee_id IN ?")
+		args = append(args, filter.AssigneeIDs)
+	}
+	if len(filter.TagIDs) > 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM card_tags ft WHERE ft.card_id = c.id AND ft.tag_id IN ?)")
+		args = append(args, filter.TagIDs)
+	}
+	if len(filter.Priorities) > 0 {
+		where = append(where, "c.priority IN ?")
+		args = append(args, filter.Priorities)
+	}
+	if filter.Done != nil {
+		where = append(where, "col.is_done = ?")
+		args = append(args, *filter.Done)
+	}
+
+	query := `
+		SELECT ` + strings.Join(selects, ", ") + `,
+			COUNT(*) AS card_count,
+			COUNT(c.story_points) AS estimated_card_count,
+			COALESCE(SUM(c.story_points), 0) AS story_points
+		FROM cards c
+		JOIN boards b ON b.id = c.board_id
+		JOIN board_columns col ON col.id = c.column_id
+		` + strings.Join(joins, "\n\t\t") + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		GROUP BY ` + strings.Join(groups, ", ") + `
+		ORDER BY card_count DESC, ` + strings.Join(groups, ", ")
+
+	rows, err := transaction.DB(ctx, r.db).Raw(query, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Group
+	for rows.Next() {
+		group := &Group{Keys: make([]*string, len(groupBy)), Labels: make([]*string, len(groupBy))}
+		dest := make([]interface{}, 0, 2*len(groupBy)+3)
+		for i := range groupBy {
+			dest = append(dest, &group.Keys[i], &group.Labels[i])
+		}
+		dest = append(dest, &group.CardCount, &group.EstimatedCardCount, &group.StoryPoints)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package card_aggregate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fixtures"
+)
+
+func TestCardAggregateRepository_Aggregate(t *testing.T) {
+	db := testsupport.NewTestDB(t)
+	repos := fixtures.DBRepositories(db)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	org := fixtures.NewTestOrg(t, repos)
+	proj := fixtures.NewTestProject(t, repos, org.Organization.ID)
+	tb := fixtures.NewTestBoardWithCards(t, repos, proj.ID, 3)
+
+	points := 3
+	for _, c := range tb.Cards {
+		c.StoryPoints = &points
+		require.NoError(t, repos.Cards.Update(ctx, c))
+	}
+
+	// Archived cards are left out of every aggregate
+	archived, err := repos.Cards.Archive(ctx, []uuid.UUID{tb.Cards[2].ID}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), archived)
+
+	groups, err := repo.Aggregate(ctx, proj.ID, []Field{FieldColumn}, Filter{})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, tb.Todo.ID.String(), *groups[0].Keys[0])
+	assert.Equal(t, 2, groups[0].CardCount)
+	assert.Equal(t, 2, groups[0].EstimatedCardCount)
+	assert.Equal(t, 6, groups[0].StoryPoints)
+
+	notDone := false
+	groups, err = repo.Aggregate(ctx, proj.ID, []Field{FieldPriority}, Filter{BoardIDs: []uuid.UUID{tb.Board.ID}, Done: &notDone})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, 2, groups[0].CardCount)
+}
@@ -0,0 +1,46 @@
+package card_dependency
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindBlocks means the from card has to be done before the to card
+	KindBlocks Kind = "blocks"
+	// KindRelates links related cards without ordering them
+	KindRelates Kind = "relates"
+	// KindSplitFrom links a card split off another card (the from card) to it
+	KindSplitFrom Kind = "split_from"
+	// KindDuplicates marks the from card as a duplicate of the to card
+	KindDuplicates Kind = "duplicates"
+)
+
+// CardDependency links two cards of the same project
+type CardDependency struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FromCardID uuid.UUID  `gorm:"type:uuid;not null"`
+	ToCardID   uuid.UUID  `gorm:"type:uuid;not null"`
+	Kind       Kind       `gorm:"type:varchar(20);not null"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+}
+
+func (CardDependency) TableName() string {
+	return "card_dependencies"
+}
+
+// GraphNode is a card taking part in a project's dependency graph
+type GraphNode struct {
+	CardID uuid.UUID
+	// Level is the length of the longest chain of blocking cards leading to the card, so
+	// ordering by level is a topological order of the acyclic part of the graph
+	Level int
+	// InCycle is set when the card (transitively) blocks itself
+	InCycle bool
+	// CycleSuccessors are the cards this card blocks along a cycle back to itself
+	CycleSuccessors []uuid.UUID
+}
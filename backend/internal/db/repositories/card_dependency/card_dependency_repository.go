@@ -0,0 +1,172 @@
+package card_dependency
+
+//go:generate mockgen -source=card_dependency_repository.go -destination=mocks/card_dependency_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, dependency *CardDependency) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CardDependency, error)
+	// GetByCardID returns the dependencies from or to the card
+	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardDependency, error)
+	// GetByProjectID returns the dependencies between cards of the project
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*CardDependency, error)
+	// GetGraphNodes returns every card of the project with a dependency, with its level
+	// and cycle membership along 'blocks' links
+	GetGraphNodes(ctx context.Context, projectID uuid.UUID) ([]*GraphNode, error)
+	// GetOpenBlockerIDs returns the cards blocking the card that are neither archived nor
+	// in a done column, oldest link first
+	GetOpenBlockerIDs(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error)
+	// Update saves the dependency's cards and kind
+	Update(ctx context.Context, dependency *CardDependency) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, dependency *CardDependency) error {
+	return transaction.DB(ctx, r.db).Create(dependency).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*CardDependency, error) {
+	var dependency CardDependency
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&dependency)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &dependency, nil
+}
+
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardDependency, error) {
+	var dependencies []*CardDependency
+	err := transaction.DB(ctx, r.db).
+		Where("from_card_id = ? OR to_card_id = ?", cardID, cardID).
+		Order("created_at ASC").
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*CardDependency, error) {
+	var dependencies []*CardDependency
+	err := transaction.DB(ctx, r.db).
+		Joins("JOIN cards fc ON fc.id = card_dependencies.from_card_id").
+		Joins("JOIN boards fb ON fb.id = fc.board_id").
+		Joins("JOIN cards tc ON tc.id = card_dependencies.to_card_id").
+		Joins("JOIN boards tb ON tb.id = tc.board_id").
+		Where("fb.project_id = ? AND tb.project_id = ?", projectID, projectID).
+		Order("card_dependencies.created_at ASC").
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// graphNodesQuery walks every simple path along 'blocks' links, starting from each card
+// with a dependency. A path stops once it reaches a card it already visited; when that
+// card is the path's start, the card lies on a cycle and path[2] is its next card on it.
+// The longest cycle-free path ending at a card is its level.
+const graphNodesQuery = `
+WITH RECURSIVE
+project_cards AS (
+    SELECT c.id FROM cards c JOIN boards b ON b.id = c.board_id WHERE b.project_id = @project
+),
+links AS (
+    SELECT d.from_card_id, d.to_card_id, d.kind
+    FROM card_dependencies d
+    JOIN project_cards f ON f.id = d.from_card_id
+    JOIN project_cards t ON t.id = d.to_card_id
+),
+nodes AS (
+    SELECT from_card_id AS card_id FROM links
+    UNION
+    SELECT to_card_id FROM links
+),
+walk (card_id, depth, path, is_cycle) AS (
+    SELECT card_id, 0, ARRAY[card_id], FALSE FROM nodes
+    UNION ALL
+    SELECT l.to_card_id, w.depth + 1, w.path || l.to_card_id, l.to_card_id = ANY(w.path)
+    FROM walk w
+    JOIN links l ON l.from_card_id = w.card_id AND l.kind = 'blocks'
+    WHERE NOT w.is_cycle
+)
+SELECT
+    card_id,
+    COALESCE(MAX(depth) FILTER (WHERE NOT is_cycle), 0) AS level,
+    BOOL_OR(is_cycle AND path[1] = card_id) AS in_cycle,
+    COALESCE(STRING_AGG(DISTINCT path[2]::text, ',') FILTER (WHERE is_cycle AND path[1] = card_id), '') AS cycle_successors
+FROM walk
+GROUP BY card_id`
+
+type graphNodeRow struct {
+	CardID          uuid.UUID
+	Level           int
+	InCycle         bool
+	CycleSuccessors string
+}
+
+func (r *repository) GetGraphNodes(ctx context.Context, projectID uuid.UUID) ([]*GraphNode, error) {
+	var rows []graphNodeRow
+	err := transaction.DB(ctx, r.db).
+		Raw(graphNodesQuery, map[string]interface{}{"project": projectID}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*GraphNode, len(rows))
+	for i, row := range rows {
+		node := &GraphNode{CardID: row.CardID, Level: row.Level, InCycle: row.InCycle}
+		if row.CycleSuccessors != "" {
+			for _, id := range strings.Split(row.CycleSuccessors, ",") {
+				successor, err := uuid.Parse(id)
+				if err != nil {
+					return nil, err
+				}
+				node.CycleSuccessors = append(node.CycleSuccessors, successor)
+			}
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+func (r *repository) GetOpenBlockerIDs(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := transaction.DB(ctx, r.db).
+		Table("card_dependencies").
+		Joins("JOIN cards blocker ON blocker.id = card_dependencies.from_card_id").
+		Joins("JOIN board_columns blocker_col ON blocker_col.id = blocker.column_id").
+		Where("card_dependencies.to_card_id = ? AND card_dependencies.kind = ?", cardID, KindBlocks).
+		Where("blocker.archived_at IS NULL AND NOT blocker_col.is_done").
+		Order("card_dependencies.created_at ASC").
+		Pluck("blocker.id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *repository) Update(ctx context.Context, dependency *CardDependency) error {
+	return transaction.DB(ctx, r.db).Save(dependency).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&CardDependency{}, "id = ?", id).Error
+}
@@ -0,0 +1,67 @@
+package card_dependency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fixtures"
+)
+
+func TestCardDependencyRepository_GetGraphNodes(t *testing.T) {
+	db := testsupport.NewTestDB(t)
+	repos := fixtures.DBRepositories(db)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	org := fixtures.NewTestOrg(t, repos)
+	proj := fixtures.NewTestProject(t, repos, org.Organization.ID)
+	tb := fixtures.NewTestBoardWithCards(t, repos, proj.ID, 6)
+	a, b, c, d, e, f := tb.Cards[0], tb.Cards[1], tb.Cards[2], tb.Cards[3], tb.Cards[4], tb.Cards[5]
+
+	// a -> b -> c and a -> c, d <-> e, c relates to f
+	link := func(from, to uuid.UUID, kind Kind) {
+		require.NoError(t, repo.Create(ctx, &CardDependency{FromCardID: from, ToCardID: to, Kind: kind}))
+	}
+	link(a.ID, b.ID, KindBlocks)
+	link(b.ID, c.ID, KindBlocks)
+	link(a.ID, c.ID, KindBlocks)
+	link(d.ID, e.ID, KindBlocks)
+	link(e.ID, d.ID, KindBlocks)
+	link(c.ID, f.ID, KindRelates)
+
+	// Links to cards of other projects are not part of the graph
+	other := fixtures.NewTestProject(t, repos, org.Organization.ID)
+	otherBoard := fixtures.NewTestBoardWithCards(t, repos, other.ID, 1)
+	link(f.ID, otherBoard.Cards[0].ID, KindBlocks)
+
+	nodes, err := repo.GetGraphNodes(ctx, proj.ID)
+	require.NoError(t, err)
+
+	byCard := make(map[uuid.UUID]*GraphNode, len(nodes))
+	for _, node := range nodes {
+		byCard[node.CardID] = node
+	}
+	require.Len(t, byCard, 6)
+
+	assert.Equal(t, 0, byCard[a.ID].Level)
+	assert.Equal(t, 1, byCard[b.ID].Level)
+	assert.Equal(t, 2, byCard[c.ID].Level)
+	assert.Equal(t, 0, byCard[f.ID].Level)
+	for _, id := range []uuid.UUID{a.ID, b.ID, c.ID, f.ID} {
+		assert.False(t, byCard[id].InCycle)
+		assert.Empty(t, byCard[id].CycleSuccessors)
+	}
+
+	assert.True(t, byCard[d.ID].InCycle)
+	assert.Equal(t, []uuid.UUID{e.ID}, byCard[d.ID].CycleSuccessors)
+	assert.True(t, byCard[e.ID].InCycle)
+	assert.Equal(t, []uuid.UUID{d.ID}, byCard[e.ID].CycleSuccessors)
+
+	dependencies, err := repo.GetByProjectID(ctx, proj.ID)
+	require.NoError(t, err)
+	assert.Len(t, dependencies, 6)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: card_dependency_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=card_dependency_repository.go -destination=mocks/card_dependency_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	card_dependency "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, dependency *card_dependency.CardDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, dependency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, dependency)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// GetByCardID mocks base method.
+func (m *MockRepository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCardID", ctx, cardID)
+	ret0, _ := ret[0].([]*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCardID indicates an expected call of GetByCardID.
+func (mr *MockRepositoryMockRecorder) GetByCardID(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCardID", reflect.TypeOf((*MockRepository)(nil).GetByCardID), ctx, cardID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByProjectID mocks base method.
+func (m *MockRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProjectID indicates an expected call of GetByProjectID.
+func (mr *MockRepositoryMockRecorder) GetByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectID", reflect.TypeOf((*MockRepository)(nil).GetByProjectID), ctx, projectID)
+}
+
+// GetGraphNodes mocks base method.
+func (m *MockRepository) GetGraphNodes(ctx context.Context, projectID uuid.UUID) ([]*card_dependency.GraphNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphNodes", ctx, projectID)
+	ret0, _ := ret[0].([]*card_dependency.GraphNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphNodes indicates an expected call of GetGraphNodes.
+func (mr *MockRepositoryMockRecorder) GetGraphNodes(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphNodes", reflect.TypeOf((*MockRepository)(nil).GetGraphNodes), ctx, projectID)
+}
+
+// GetOpenBlockerIDs mocks base method.
+func (m *MockRepository) GetOpenBlockerIDs(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenBlockerIDs", ctx, cardID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenBlockerIDs indicates an expected call of GetOpenBlockerIDs.
+func (mr *MockRepositoryMockRecorder) GetOpenBlockerIDs(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenBlockerIDs", reflect.TypeOf((*MockRepository)(nil).GetOpenBlockerIDs), ctx, cardID)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, dependency *card_dependency.CardDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, dependency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, dependency)
+}
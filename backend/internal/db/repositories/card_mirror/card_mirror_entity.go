@@ -0,0 +1,31 @@
+package card_mirror
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Direction string
+
+const (
+	// DirectionOneWay copies changes of the source card to the mirror only
+	DirectionOneWay Direction = "one_way"
+	// DirectionTwoWay also copies changes of the mirror back to the source card
+	DirectionTwoWay Direction = "two_way"
+)
+
+// CardMirror links a card to its mirror on another project's board
+type CardMirror struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SourceCardID uuid.UUID  `gorm:"type:uuid;not null"`
+	MirrorCardID uuid.UUID  `gorm:"type:uuid;not null"`
+	Direction    Direction  `gorm:"type:varchar(20);not null"`
+	CreatedBy    *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (CardMirror) TableName() string {
+	return "card_mirrors"
+}
@@ -0,0 +1,61 @@
+package card_mirror
+
+//go:generate mockgen -source=card_mirror_repository.go -destination=mocks/card_mirror_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, mirror *CardMirror) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CardMirror, error)
+	// GetByCardID returns the mirrors of the card and the link making it a mirror, if any
+	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardMirror, error)
+	Update(ctx context.Context, mirror *CardMirror) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, mirror *CardMirror) error {
+	return transaction.DB(ctx, r.db).Create(mirror).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*CardMirror, error) {
+	var mirror CardMirror
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&mirror)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &mirror, nil
+}
+
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardMirror, error) {
+	var mirrors []*CardMirror
+	err := transaction.DB(ctx, r.db).
+		Where("source_card_id = ? OR mirror_card_id = ?", cardID, cardID).
+		Order("created_at ASC").
+		Find(&mirrors).Error
+	if err != nil {
+		return nil, err
+	}
+	return mirrors, nil
+}
+
+func (r *repository) Update(ctx context.Context, mirror *CardMirror) error {
+	return transaction.DB(ctx, r.db).Save(mirror).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&CardMirror{}, "id = ?", id).Error
+}
@@ -6,12 +6,15 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
 type Repository interface {
 	Create(ctx context.Context, cardTag *CardTag) error
 	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardTag, error)
+	// GetByCardIDs returns the tags of all the cards in one query
+	GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*CardTag, error)
 	GetByTagID(ctx context.Context, tagID uuid.UUID) ([]*CardTag, error)
 	DeleteByCardID(ctx context.Context, cardID uuid.UUID) error
 	DeleteByCardAndTag(ctx context.Context, cardID, tagID uuid.UUID) error
@@ -27,12 +30,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, cardTag *CardTag) error {
-	return r.db.WithContext(ctx).Create(cardTag).Error
+	return transaction.DB(ctx, r.db).Create(cardTag).Error
 }
 
 func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*CardTag, error) {
 	var cardTags []*CardTag
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("card_id = ?", cardID).
 		Find(&cardTags).Error
 	if err != nil {
@@ -41,9 +44,23 @@ func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Card
 	return cardTags, nil
 }
 
+func (r *repository) GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*CardTag, error) {
+	var cardTags []*CardTag
+	if len(cardIDs) == 0 {
+		return cardTags, nil
+	}
+	err := transaction.DB(ctx, r.db).
+		Where("card_id IN ?", cardIDs).
+		Find(&cardTags).Error
+	if err != nil {
+		return nil, err
+	}
+	return cardTags, nil
+}
+
 func (r *repository) GetByTagID(ctx context.Context, tagID uuid.UUID) ([]*CardTag, error) {
 	var cardTags []*CardTag
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("tag_id = ?", tagID).
 		Find(&cardTags).Error
 	if err != nil {
@@ -53,19 +70,19 @@ func (r *repository) GetByTagID(ctx context.Context, tagID uuid.UUID) ([]*CardTa
 }
 
 func (r *repository) DeleteByCardID(ctx context.Context, cardID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("card_id = ?", cardID).
 		Delete(&CardTag{}).Error
 }
 
 func (r *repository) DeleteByCardAndTag(ctx context.Context, cardID, tagID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("card_id = ? AND tag_id = ?", cardID, tagID).
 		Delete(&CardTag{}).Error
 }
 
 func (r *repository) SetTagsForCard(ctx context.Context, cardID uuid.UUID, tagIDs []uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Delete existing tags for this card
 		if err := tx.Where("card_id = ?", cardID).Delete(&CardTag{}).Error; err != nil {
 			return err
@@ -99,6 +99,21 @@ func (mr *MockRepositoryMockRecorder) GetByCardID(ctx, cardID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCardID", reflect.TypeOf((*MockRepository)(nil).GetByCardID), ctx, cardID)
 }
 
+// GetByCardIDs mocks base method.
+func (m *MockRepository) GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*card_tag.CardTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCardIDs", ctx, cardIDs)
+	ret0, _ := ret[0].([]*card_tag.CardTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCardIDs indicates an expected call of GetByCardIDs.
+func (mr *MockRepositoryMockRecorder) GetByCardIDs(ctx, cardIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCardIDs", reflect.TypeOf((*MockRepository)(nil).GetByCardIDs), ctx, cardIDs)
+}
+
 // GetByTagID mocks base method.
 func (m *MockRepository) GetByTagID(ctx context.Context, tagID uuid.UUID) ([]*card_tag.CardTag, error) {
 	m.ctrl.T.Helper()
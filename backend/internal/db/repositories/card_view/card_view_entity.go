@@ -0,0 +1,18 @@
+package card_view
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardView is when a user last viewed a card
+type CardView struct {
+	UserID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CardID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ViewedAt time.Time `gorm:"type:timestamptz;not null"`
+}
+
+func (CardView) TableName() string {
+	return "card_views"
+}
@@ -0,0 +1,64 @@
+package card_view
+
+//go:generate mockgen -source=card_view_repository.go -destination=mocks/card_view_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// MarkViewed records a view of the card at viewedAt, never moving an existing view
+	// back in time. A card that no longer exists is ignored.
+	MarkViewed(ctx context.Context, userID, cardID uuid.UUID, viewedAt time.Time) error
+	GetByUserAndCard(ctx context.Context, userID, cardID uuid.UUID) (*CardView, error)
+	// CountUnreadByBoardID counts the board's cards that changed since the user last
+	// viewed them, or that they never viewed
+	CountUnreadByBoardID(ctx context.Context, userID, boardID uuid.UUID) (int, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) MarkViewed(ctx context.Context, userID, cardID uuid.UUID, viewedAt time.Time) error {
+	return transaction.DB(ctx, r.db).Exec(`
+		INSERT INTO card_views (user_id, card_id, viewed_at)
+		SELECT ?, id, ? FROM cards WHERE id = ?
+		ON CONFLICT (user_id, card_id)
+		DO UPDATE SET viewed_at = GREATEST(card_views.viewed_at, EXCLUDED.viewed_at)
+	`, userID, viewedAt, cardID).Error
+}
+
+func (r *repository) GetByUserAndCard(ctx context.Context, userID, cardID uuid.UUID) (*CardView, error) {
+	var view CardView
+	result := transaction.DB(ctx, r.db).
+		Where("user_id = ? AND card_id = ?", userID, cardID).
+		First(&view)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &view, nil
+}
+
+func (r *repository) CountUnreadByBoardID(ctx context.Context, userID, boardID uuid.UUID) (int, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT COUNT(*)
+		FROM cards c
+		LEFT JOIN card_views v ON v.card_id = c.id AND v.user_id = ?
+		WHERE c.board_id = ? AND c.archived_at IS NULL AND (v.viewed_at IS NULL OR c.updated_at > v.viewed_at)
+	`, userID, boardID).Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: card_view_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=card_view_repository.go -destination=mocks/card_view_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	card_view "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadByBoardID mocks base method.
+func (m *MockRepository) CountUnreadByBoardID(ctx context.Context, userID, boardID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadByBoardID", ctx, userID, boardID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadByBoardID indicates an expected call of CountUnreadByBoardID.
+func (mr *MockRepositoryMockRecorder) CountUnreadByBoardID(ctx, userID, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadByBoardID", reflect.TypeOf((*MockRepository)(nil).CountUnreadByBoardID), ctx, userID, boardID)
+}
+
+// GetByUserAndCard mocks base method.
+func (m *MockRepository) GetByUserAndCard(ctx context.Context, userID, cardID uuid.UUID) (*card_view.CardView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserAndCard", ctx, userID, cardID)
+	ret0, _ := ret[0].(*card_view.CardView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserAndCard indicates an expected call of GetByUserAndCard.
+func (mr *MockRepositoryMockRecorder) GetByUserAndCard(ctx, userID, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserAndCard", reflect.TypeOf((*MockRepository)(nil).GetByUserAndCard), ctx, userID, cardID)
+}
+
+// MarkViewed mocks base method.
+func (m *MockRepository) MarkViewed(ctx context.Context, userID, cardID uuid.UUID, viewedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkViewed", ctx, userID, cardID, viewedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkViewed indicates an expected call of MarkViewed.
+func (mr *MockRepositoryMockRecorder) MarkViewed(ctx, userID, cardID, viewedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkViewed", reflect.TypeOf((*MockRepository)(nil).MarkViewed), ctx, userID, cardID, viewedAt)
+}
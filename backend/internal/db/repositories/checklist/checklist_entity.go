@@ -0,0 +1,31 @@
+package checklist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is one entry of a card's checklist
+type Item struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CardID uuid.UUID `gorm:"type:uuid;not null"`
+	Title  string    `gorm:"type:varchar(500);not null"`
+	Done   bool      `gorm:"not null;default:false"`
+	// AssigneeID is nil for unassigned items and once the assignee's account is deleted
+	AssigneeID *uuid.UUID `gorm:"type:uuid"`
+	Position   int        `gorm:"not null;default:0"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (Item) TableName() string {
+	return "card_checklist_items"
+}
+
+// Progress counts the items of a card's checklist and how many of them are done
+type Progress struct {
+	CardID uuid.UUID
+	Total  int
+	Done   int
+}
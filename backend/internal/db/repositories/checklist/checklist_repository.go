@@ -0,0 +1,112 @@
+package checklist
+
+//go:generate mockgen -source=checklist_repository.go -destination=mocks/checklist_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, item *Item) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Item, error)
+	// GetByCardID returns the card's checklist items in order
+	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Item, error)
+	Update(ctx context.Context, item *Item) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// UpdatePositions sets the position of each item to its index in ids
+	UpdatePositions(ctx context.Context, ids []uuid.UUID) error
+	// GetMaxPosition returns the highest position on the card's checklist, -1 when it is empty
+	GetMaxPosition(ctx context.Context, cardID uuid.UUID) (int, error)
+	// GetProgressByCardIDs counts the checklist items of each card; cards without items are
+	// left out
+	GetProgressByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*Progress, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, item *Item) error {
+	return transaction.DB(ctx, r.db).Create(item).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Item, error) {
+	var item Item
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &item, nil
+}
+
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Item, error) {
+	var items []*Item
+	result := transaction.DB(ctx, r.db).
+		Where("card_id = ?", cardID).
+		Order("position ASC, created_at ASC").
+		Find(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return items, nil
+}
+
+func (r *repository) Update(ctx context.Context, item *Item) error {
+	return transaction.DB(ctx, r.db).Save(item).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Item{}, "id = ?", id).Error
+}
+
+func (r *repository) UpdatePositions(ctx context.Context, ids []uuid.UUID) error {
+	db := transaction.DB(ctx, r.db)
+	for i, id := range ids {
+		if err := db.Model(&Item{}).Where("id = ?", id).Update("position", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) GetMaxPosition(ctx context.Context, cardID uuid.UUID) (int, error) {
+	var maxPos *int
+	result := transaction.DB(ctx, r.db).
+		Model(&Item{}).
+		Where("card_id = ?", cardID).
+		Select("MAX(position)").
+		Scan(&maxPos)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if maxPos == nil {
+		return -1, nil
+	}
+	return *maxPos, nil
+}
+
+func (r *repository) GetProgressByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*Progress, error) {
+	if len(cardIDs) == 0 {
+		return nil, nil
+	}
+
+	var progress []*Progress
+	result := transaction.DB(ctx, r.db).
+		Model(&Item{}).
+		Select("card_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE done) AS done").
+		Where("card_id IN ?", cardIDs).
+		Group("card_id").
+		Scan(&progress)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return progress, nil
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: checklist_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=checklist_repository.go -destination=mocks/checklist_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	checklist "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, item *checklist.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, item)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// GetByCardID mocks base method.
+func (m *MockRepository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCardID", ctx, cardID)
+	ret0, _ := ret[0].([]*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCardID indicates an expected call of GetByCardID.
+func (mr *MockRepositoryMockRecorder) GetByCardID(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCardID", reflect.TypeOf((*MockRepository)(nil).GetByCardID), ctx, cardID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetMaxPosition mocks base method.
+func (m *MockRepository) GetMaxPosition(ctx context.Context, cardID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxPosition", ctx, cardID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaxPosition indicates an expected call of GetMaxPosition.
+func (mr *MockRepositoryMockRecorder) GetMaxPosition(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxPosition", reflect.TypeOf((*MockRepository)(nil).GetMaxPosition), ctx, cardID)
+}
+
+// GetProgressByCardIDs mocks base method.
+func (m *MockRepository) GetProgressByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*checklist.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgressByCardIDs", ctx, cardIDs)
+	ret0, _ := ret[0].([]*checklist.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgressByCardIDs indicates an expected call of GetProgressByCardIDs.
+func (mr *MockRepositoryMockRecorder) GetProgressByCardIDs(ctx, cardIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgressByCardIDs", reflect.TypeOf((*MockRepository)(nil).GetProgressByCardIDs), ctx, cardIDs)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, item *checklist.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, item)
+}
+
+// UpdatePositions mocks base method.
+func (m *MockRepository) UpdatePositions(ctx context.Context, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePositions", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePositions indicates an expected call of UpdatePositions.
+func (mr *MockRepositoryMockRecorder) UpdatePositions(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePositions", reflect.TypeOf((*MockRepository)(nil).UpdatePositions), ctx, ids)
+}
@@ -0,0 +1,40 @@
+package column_alert
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindWIPExceeded is a column holding more cards than its WIP limit for longer than its
+	// board allows
+	KindWIPExceeded Kind = "wip_exceeded"
+	// KindAging is a column whose cards are older on average than its board allows
+	KindAging Kind = "aging"
+)
+
+// ColumnAlert is a column over its WIP limit or holding old cards, from when the condition
+// was first seen until it cleared
+type ColumnAlert struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BoardID   uuid.UUID `gorm:"type:uuid;not null"`
+	ColumnID  uuid.UUID `gorm:"type:uuid;not null"`
+	Kind      Kind      `gorm:"type:varchar(20);not null"`
+	StartedAt time.Time `gorm:"not null"`
+	// RaisedAt is set once the condition has lasted long enough to alert the board's admins
+	RaisedAt *time.Time
+	// NotifiedAt is set once the board's admins have been alerted
+	NotifiedAt *time.Time
+	ResolvedAt *time.Time
+	// CardCount, WipLimit and AverageAgeSeconds are the column's state when the alert was raised
+	CardCount         *int
+	WipLimit          *int
+	AverageAgeSeconds *float64
+}
+
+func (ColumnAlert) TableName() string {
+	return "column_alerts"
+}
@@ -0,0 +1,129 @@
+package column_alert
+
+//go:generate mockgen -source=column_alert_repository.go -destination=mocks/column_alert_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// CreateIfAbsent opens the alert unless its column already has an open alert of the
+	// kind. It reports whether a new alert was opened.
+	CreateIfAbsent(ctx context.Context, alert *ColumnAlert) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*ColumnAlert, error)
+	// GetOpenByBoardID returns the board's unresolved alerts
+	GetOpenByBoardID(ctx context.Context, boardID uuid.UUID) ([]*ColumnAlert, error)
+	// GetByBoardID returns the board's latest alerts that were raised, newest first
+	GetByBoardID(ctx context.Context, boardID uuid.UUID, limit int) ([]*ColumnAlert, error)
+	// Raise records the column's state on the alert and marks it raised. It reports false
+	// when it was already raised.
+	Raise(ctx context.Context, alert *ColumnAlert, at time.Time) (bool, error)
+	// Resolve closes the alert
+	Resolve(ctx context.Context, id uuid.UUID, at time.Time) error
+	// ResolveByBoardID closes all of the board's open alerts
+	ResolveByBoardID(ctx context.Context, boardID uuid.UUID, at time.Time) error
+	// MarkNotified claims the alert's notification. It reports false when it was already sent.
+	MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateIfAbsent(ctx context.Context, alert *ColumnAlert) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*ColumnAlert, error) {
+	var alert ColumnAlert
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&alert)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &alert, nil
+}
+
+func (r *repository) GetOpenByBoardID(ctx context.Context, boardID uuid.UUID) ([]*ColumnAlert, error) {
+	var alerts []*ColumnAlert
+	result := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND resolved_at IS NULL", boardID).
+		Find(&alerts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return alerts, nil
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID, limit int) ([]*ColumnAlert, error) {
+	var alerts []*ColumnAlert
+	result := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND raised_at IS NOT NULL", boardID).
+		Order("raised_at DESC").
+		Limit(limit).
+		Find(&alerts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return alerts, nil
+}
+
+func (r *repository) Raise(ctx context.Context, alert *ColumnAlert, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&ColumnAlert{}).
+		Where("id = ? AND raised_at IS NULL", alert.ID).
+		Updates(map[string]interface{}{
+			"raised_at":           at,
+			"card_count":          alert.CardCount,
+			"wip_limit":           alert.WipLimit,
+			"average_age_seconds": alert.AverageAgeSeconds,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	alert.RaisedAt = &at
+	return true, nil
+}
+
+func (r *repository) Resolve(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).
+		Model(&ColumnAlert{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Update("resolved_at", at).Error
+}
+
+func (r *repository) ResolveByBoardID(ctx context.Context, boardID uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).
+		Model(&ColumnAlert{}).
+		Where("board_id = ? AND resolved_at IS NULL", boardID).
+		Update("resolved_at", at).Error
+}
+
+func (r *repository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&ColumnAlert{}).
+		Where("id = ? AND notified_at IS NULL", id).
+		Update("notified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
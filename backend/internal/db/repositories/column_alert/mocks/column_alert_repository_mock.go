@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: column_alert_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=column_alert_repository.go -destination=mocks/column_alert_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	column_alert "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIfAbsent mocks base method.
+func (m *MockRepository) CreateIfAbsent(ctx context.Context, alert *column_alert.ColumnAlert) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfAbsent", ctx, alert)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIfAbsent indicates an expected call of CreateIfAbsent.
+func (mr *MockRepositoryMockRecorder) CreateIfAbsent(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfAbsent", reflect.TypeOf((*MockRepository)(nil).CreateIfAbsent), ctx, alert)
+}
+
+// GetByBoardID mocks base method.
+func (m *MockRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID, limit int) ([]*column_alert.ColumnAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBoardID", ctx, boardID, limit)
+	ret0, _ := ret[0].([]*column_alert.ColumnAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBoardID indicates an expected call of GetByBoardID.
+func (mr *MockRepositoryMockRecorder) GetByBoardID(ctx, boardID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoardID", reflect.TypeOf((*MockRepository)(nil).GetByBoardID), ctx, boardID, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*column_alert.ColumnAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*column_alert.ColumnAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetOpenByBoardID mocks base method.
+func (m *MockRepository) GetOpenByBoardID(ctx context.Context, boardID uuid.UUID) ([]*column_alert.ColumnAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenByBoardID", ctx, boardID)
+	ret0, _ := ret[0].([]*column_alert.ColumnAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenByBoardID indicates an expected call of GetOpenByBoardID.
+func (mr *MockRepositoryMockRecorder) GetOpenByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenByBoardID", reflect.TypeOf((*MockRepository)(nil).GetOpenByBoardID), ctx, boardID)
+}
+
+// MarkNotified mocks base method.
+func (m *MockRepository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockRepositoryMockRecorder) MarkNotified(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockRepository)(nil).MarkNotified), ctx, id, at)
+}
+
+// Raise mocks base method.
+func (m *MockRepository) Raise(ctx context.Context, alert *column_alert.ColumnAlert, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Raise", ctx, alert, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Raise indicates an expected call of Raise.
+func (mr *MockRepositoryMockRecorder) Raise(ctx, alert, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Raise", reflect.TypeOf((*MockRepository)(nil).Raise), ctx, alert, at)
+}
+
+// Resolve mocks base method.
+func (m *MockRepository) Resolve(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockRepositoryMockRecorder) Resolve(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRepository)(nil).Resolve), ctx, id, at)
+}
+
+// ResolveByBoardID mocks base method.
+func (m *MockRepository) ResolveByBoardID(ctx context.Context, boardID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByBoardID", ctx, boardID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveByBoardID indicates an expected call of ResolveByBoardID.
+func (mr *MockRepositoryMockRecorder) ResolveByBoardID(ctx, boardID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByBoardID", reflect.TypeOf((*MockRepository)(nil).ResolveByBoardID), ctx, boardID, at)
+}
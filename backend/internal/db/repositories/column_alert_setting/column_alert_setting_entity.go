@@ -0,0 +1,59 @@
+package column_alert_setting
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWIPExceededMinutes is how long a column may stay over its WIP limit on boards
+// that haven't configured their alerts
+const DefaultWIPExceededMinutes = 60
+
+// ColumnAlertSetting holds a board's column alert thresholds
+type ColumnAlertSetting struct {
+	BoardID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Enabled bool      `gorm:"not null;default:true"`
+	// WIPExceededMinutes is how long a column may hold more cards than its WIP limit before
+	// alerting, nil to not alert
+	WIPExceededMinutes *int
+	// AverageAgeHours is how old the cards of a column may be on average before alerting,
+	// nil to not alert
+	AverageAgeHours *int
+	// SlackWebhookURL is an incoming webhook alerts are also posted to, in SlackChannel when
+	// not empty
+	SlackWebhookURL *string
+	SlackChannel    string    `gorm:"type:varchar(80);not null;default:''"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ColumnAlertSetting) TableName() string {
+	return "column_alert_settings"
+}
+
+// Default returns the settings of a board that hasn't configured any: alerts are off
+func Default(boardID uuid.UUID) *ColumnAlertSetting {
+	minutes := DefaultWIPExceededMinutes
+	return &ColumnAlertSetting{
+		BoardID:            boardID,
+		WIPExceededMinutes: &minutes,
+	}
+}
+
+// WIPExceededDuration returns how long a column may stay over its WIP limit, or zero when
+// WIP alerts are off
+func (s *ColumnAlertSetting) WIPExceededDuration() time.Duration {
+	if s.WIPExceededMinutes == nil {
+		return 0
+	}
+	return time.Duration(*s.WIPExceededMinutes) * time.Minute
+}
+
+// AverageAge returns the average card age that raises an aging alert, or zero when aging
+// alerts are off
+func (s *ColumnAlertSetting) AverageAge() time.Duration {
+	if s.AverageAgeHours == nil {
+		return 0
+	}
+	return time.Duration(*s.AverageAgeHours) * time.Hour
+}
@@ -0,0 +1,56 @@
+package column_alert_setting
+
+//go:generate mockgen -source=column_alert_setting_repository.go -destination=mocks/column_alert_setting_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// GetByBoardID returns the board's settings, or the defaults when it has none
+	GetByBoardID(ctx context.Context, boardID uuid.UUID) (*ColumnAlertSetting, error)
+	// GetEnabled returns the settings of every board with alerts turned on
+	GetEnabled(ctx context.Context) ([]*ColumnAlertSetting, error)
+	// Save creates or replaces the board's settings
+	Save(ctx context.Context, setting *ColumnAlertSetting) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) (*ColumnAlertSetting, error) {
+	var setting ColumnAlertSetting
+	err := transaction.DB(ctx, r.db).Where("board_id = ?", boardID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Default(boardID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *repository) GetEnabled(ctx context.Context) ([]*ColumnAlertSetting, error) {
+	var settings []*ColumnAlertSetting
+	err := transaction.DB(ctx, r.db).
+		Where("enabled = TRUE AND (wip_exceeded_minutes IS NOT NULL OR average_age_hours IS NOT NULL)").
+		Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *repository) Save(ctx context.Context, setting *ColumnAlertSetting) error {
+	return transaction.DB(ctx, r.db).Save(setting).Error
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: column_alert_setting_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=column_alert_setting_repository.go -destination=mocks/column_alert_setting_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_alert_setting "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByBoardID mocks base method.
+func (m *MockRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) (*column_alert_setting.ColumnAlertSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBoardID", ctx, boardID)
+	ret0, _ := ret[0].(*column_alert_setting.ColumnAlertSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBoardID indicates an expected call of GetByBoardID.
+func (mr *MockRepositoryMockRecorder) GetByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoardID", reflect.TypeOf((*MockRepository)(nil).GetByBoardID), ctx, boardID)
+}
+
+// GetEnabled mocks base method.
+func (m *MockRepository) GetEnabled(ctx context.Context) ([]*column_alert_setting.ColumnAlertSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled", ctx)
+	ret0, _ := ret[0].([]*column_alert_setting.ColumnAlertSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockRepositoryMockRecorder) GetEnabled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockRepository)(nil).GetEnabled), ctx)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, setting *column_alert_setting.ColumnAlertSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, setting)
+}
@@ -0,0 +1,74 @@
+package column_defaults
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+)
+
+// ColumnDefaults are applied to cards created in a column. Nil fields are not defaulted.
+type ColumnDefaults struct {
+	ColumnID    uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	Priority    *card.CardPriority `gorm:"type:card_priority"`
+	AssigneeID  *uuid.UUID         `gorm:"type:uuid"`
+	StoryPoints *int               `gorm:"type:integer"`
+	// Description is used for cards created without one
+	Description string `gorm:"type:text;not null;default:''"`
+	// Checklist items are appended to the description of every new card
+	Checklist Checklist `gorm:"type:jsonb;not null;default:'[]'"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	// TagIDs are stored in column_default_tags
+	TagIDs []uuid.UUID `gorm:"-"`
+}
+
+func (ColumnDefaults) TableName() string {
+	return "column_card_defaults"
+}
+
+// IsEmpty reports whether the defaults would leave a new card unchanged
+func (d *ColumnDefaults) IsEmpty() bool {
+	return d.Priority == nil && d.AssigneeID == nil && d.StoryPoints == nil &&
+		d.Description == "" && len(d.Checklist) == 0 && len(d.TagIDs) == 0
+}
+
+// ColumnDefaultTag is a tag added to cards created in a column
+type ColumnDefaultTag struct {
+	ColumnID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TagID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+func (ColumnDefaultTag) TableName() string {
+	return "column_default_tags"
+}
+
+// Checklist is a list of item texts stored as a JSON array
+type Checklist []string
+
+func (c Checklist) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *Checklist) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into Checklist", value)
+	}
+}
@@ -0,0 +1,80 @@
+package column_defaults
+
+//go:generate mockgen -source=column_defaults_repository.go -destination=mocks/column_defaults_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// GetByColumnID returns the column's defaults with their tags, or gorm.ErrRecordNotFound
+	GetByColumnID(ctx context.Context, columnID uuid.UUID) (*ColumnDefaults, error)
+	// Save creates or replaces the column's defaults, tags included
+	Save(ctx context.Context, defaults *ColumnDefaults) error
+	// Delete removes the column's defaults and default tags
+	Delete(ctx context.Context, columnID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByColumnID(ctx context.Context, columnID uuid.UUID) (*ColumnDefaults, error) {
+	db := transaction.DB(ctx, r.db)
+
+	var defaults ColumnDefaults
+	if err := db.Where("column_id = ?", columnID).First(&defaults).Error; err != nil {
+		return nil, err
+	}
+
+	err := db.Model(&ColumnDefaultTag{}).
+		Where("column_id = ?", columnID).
+		Order("tag_id").
+		Pluck("tag_id", &defaults.TagIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return &defaults, nil
+}
+
+func (r *repository) Save(ctx context.Context, defaults *ColumnDefaults) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "column_id"}},
+			UpdateAll: true,
+		}).Create(defaults).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("column_id = ?", defaults.ColumnID).Delete(&ColumnDefaultTag{}).Error; err != nil {
+			return err
+		}
+		if len(defaults.TagIDs) == 0 {
+			return nil
+		}
+		tags := make([]*ColumnDefaultTag, len(defaults.TagIDs))
+		for i, tagID := range defaults.TagIDs {
+			tags[i] = &ColumnDefaultTag{ColumnID: defaults.ColumnID, TagID: tagID}
+		}
+		return tx.Create(&tags).Error
+	})
+}
+
+func (r *repository) Delete(ctx context.Context, columnID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&ColumnDefaultTag{}, "column_id = ?", columnID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ColumnDefaults{}, "column_id = ?", columnID).Error
+	})
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: column_defaults_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=column_defaults_repository.go -destination=mocks/column_defaults_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_defaults "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, columnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, columnID)
+}
+
+// GetByColumnID mocks base method.
+func (m *MockRepository) GetByColumnID(ctx context.Context, columnID uuid.UUID) (*column_defaults.ColumnDefaults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByColumnID", ctx, columnID)
+	ret0, _ := ret[0].(*column_defaults.ColumnDefaults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByColumnID indicates an expected call of GetByColumnID.
+func (mr *MockRepositoryMockRecorder) GetByColumnID(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByColumnID", reflect.TypeOf((*MockRepository)(nil).GetByColumnID), ctx, columnID)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, defaults *column_defaults.ColumnDefaults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, defaults)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, defaults any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, defaults)
+}
@@ -0,0 +1,20 @@
+package column_transition
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ColumnTransition allows cards on a board to move from one column to another
+type ColumnTransition struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BoardID      uuid.UUID `gorm:"type:uuid;not null"`
+	FromColumnID uuid.UUID `gorm:"type:uuid;not null"`
+	ToColumnID   uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (ColumnTransition) TableName() string {
+	return "column_transitions"
+}
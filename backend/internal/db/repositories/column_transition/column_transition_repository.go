@@ -0,0 +1,49 @@
+package column_transition
+
+//go:generate mockgen -source=column_transition_repository.go -destination=mocks/column_transition_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*ColumnTransition, error)
+	// ReplaceForBoard swaps the board's transitions for the given ones
+	ReplaceForBoard(ctx context.Context, boardID uuid.UUID, transitions []*ColumnTransition) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*ColumnTransition, error) {
+	var transitions []*ColumnTransition
+	err := transaction.DB(ctx, r.db).
+		Where("board_id = ?", boardID).
+		Order("created_at ASC").
+		Find(&transitions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+func (r *repository) ReplaceForBoard(ctx context.Context, boardID uuid.UUID, transitions []*ColumnTransition) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("board_id = ?", boardID).Delete(&ColumnTransition{}).Error; err != nil {
+			return err
+		}
+		if len(transitions) == 0 {
+			return nil
+		}
+		return tx.Create(&transitions).Error
+	})
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: column_transition_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=column_transition_repository.go -destination=mocks/column_transition_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_transition "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByBoardID mocks base method.
+func (m *MockRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*column_transition.ColumnTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBoardID", ctx, boardID)
+	ret0, _ := ret[0].([]*column_transition.ColumnTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBoardID indicates an expected call of GetByBoardID.
+func (mr *MockRepositoryMockRecorder) GetByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoardID", reflect.TypeOf((*MockRepository)(nil).GetByBoardID), ctx, boardID)
+}
+
+// ReplaceForBoard mocks base method.
+func (m *MockRepository) ReplaceForBoard(ctx context.Context, boardID uuid.UUID, transitions []*column_transition.ColumnTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceForBoard", ctx, boardID, transitions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceForBoard indicates an expected call of ReplaceForBoard.
+func (mr *MockRepositoryMockRecorder) ReplaceForBoard(ctx, boardID, transitions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceForBoard", reflect.TypeOf((*MockRepository)(nil).ReplaceForBoard), ctx, boardID, transitions)
+}
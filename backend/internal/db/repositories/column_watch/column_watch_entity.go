@@ -0,0 +1,30 @@
+package column_watch
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ColumnWatch subscribes UserID to cards entering and leaving ColumnID
+type ColumnWatch struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ColumnID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (ColumnWatch) TableName() string {
+	return "column_watches"
+}
+
+// Delivery records that a watcher has been notified about an event
+type Delivery struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ColumnID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DeliveredAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Delivery) TableName() string {
+	return "column_watch_deliveries"
+}
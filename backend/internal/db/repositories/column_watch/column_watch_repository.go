@@ -0,0 +1,98 @@
+package column_watch
+
+//go:generate mockgen -source=column_watch_repository.go -destination=mocks/column_watch_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Create adds the watch; watching a column twice is a no-op
+	Create(ctx context.Context, watch *ColumnWatch) error
+	Delete(ctx context.Context, userID, columnID uuid.UUID) error
+	Exists(ctx context.Context, userID, columnID uuid.UUID) (bool, error)
+	GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*ColumnWatch, error)
+	CountByColumnID(ctx context.Context, columnID uuid.UUID) (int, error)
+	// IsDelivered reports whether the watcher has already been notified about the event
+	IsDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) (bool, error)
+	MarkDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, watch *ColumnWatch) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(watch).Error
+}
+
+func (r *repository) Delete(ctx context.Context, userID, columnID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Delete(&ColumnWatch{}, "user_id = ? AND column_id = ?", userID, columnID).Error
+}
+
+func (r *repository) Exists(ctx context.Context, userID, columnID uuid.UUID) (bool, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&ColumnWatch{}).
+		Where("user_id = ? AND column_id = ?", userID, columnID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*ColumnWatch, error) {
+	var watches []*ColumnWatch
+	result := transaction.DB(ctx, r.db).
+		Where("column_id = ?", columnID).
+		Order("created_at ASC").
+		Find(&watches)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return watches, nil
+}
+
+func (r *repository) CountByColumnID(ctx context.Context, columnID uuid.UUID) (int, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&ColumnWatch{}).
+		Where("column_id = ?", columnID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *repository) IsDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) (bool, error) {
+	var delivery Delivery
+	err := transaction.DB(ctx, r.db).
+		Where("user_id = ? AND column_id = ? AND event_id = ?", userID, columnID, eventID).
+		First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) MarkDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Delivery{UserID: userID, ColumnID: columnID, EventID: eventID}).Error
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: column_watch_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=column_watch_repository.go -destination=mocks/column_watch_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_watch "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByColumnID mocks base method.
+func (m *MockRepository) CountByColumnID(ctx context.Context, columnID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByColumnID", ctx, columnID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByColumnID indicates an expected call of CountByColumnID.
+func (mr *MockRepositoryMockRecorder) CountByColumnID(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByColumnID", reflect.TypeOf((*MockRepository)(nil).CountByColumnID), ctx, columnID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, watch *column_watch.ColumnWatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, watch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, watch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, watch)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, userID, columnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, userID, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, userID, columnID)
+}
+
+// Exists mocks base method.
+func (m *MockRepository) Exists(ctx context.Context, userID, columnID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, userID, columnID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRepositoryMockRecorder) Exists(ctx, userID, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRepository)(nil).Exists), ctx, userID, columnID)
+}
+
+// GetByColumnID mocks base method.
+func (m *MockRepository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*column_watch.ColumnWatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByColumnID", ctx, columnID)
+	ret0, _ := ret[0].([]*column_watch.ColumnWatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByColumnID indicates an expected call of GetByColumnID.
+func (mr *MockRepositoryMockRecorder) GetByColumnID(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByColumnID", reflect.TypeOf((*MockRepository)(nil).GetByColumnID), ctx, columnID)
+}
+
+// IsDelivered mocks base method.
+func (m *MockRepository) IsDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDelivered", ctx, userID, columnID, eventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDelivered indicates an expected call of IsDelivered.
+func (mr *MockRepositoryMockRecorder) IsDelivered(ctx, userID, columnID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDelivered", reflect.TypeOf((*MockRepository)(nil).IsDelivered), ctx, userID, columnID, eventID)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockRepository) MarkDelivered(ctx context.Context, userID, columnID, eventID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, userID, columnID, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockRepositoryMockRecorder) MarkDelivered(ctx, userID, columnID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockRepository)(nil).MarkDelivered), ctx, userID, columnID, eventID)
+}
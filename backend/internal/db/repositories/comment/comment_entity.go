@@ -0,0 +1,39 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a user's comment on a card
+type Comment struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CardID uuid.UUID `gorm:"type:uuid;not null"`
+	// AuthorID is nil once the author's account is deleted
+	AuthorID *uuid.UUID `gorm:"type:uuid"`
+	Body     string     `gorm:"type:text;not null"`
+	// EditedAt is when the body last changed, nil for comments never edited
+	EditedAt  *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (Comment) TableName() string {
+	return "card_comments"
+}
+
+// Mention notifies UserID that ActorID @mentioned them in a comment
+type Mention struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CommentID uuid.UUID  `gorm:"type:uuid;not null"`
+	CardID    uuid.UUID  `gorm:"type:uuid;not null"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null"`
+	ActorID   *uuid.UUID `gorm:"type:uuid"`
+	ReadAt    *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+func (Mention) TableName() string {
+	return "comment_mentions"
+}
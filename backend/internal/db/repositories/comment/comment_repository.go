@@ -0,0 +1,137 @@
+package comment
+
+//go:generate mockgen -source=comment_repository.go -destination=mocks/comment_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, comment *Comment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Comment, error)
+	// GetByCardID returns the card's comments, oldest first
+	GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Comment, error)
+	// GetByCardIDs returns the comments of all the cards, oldest first
+	GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*Comment, error)
+	Update(ctx context.Context, comment *Comment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateMentions records mentions, skipping users already mentioned in the comment
+	CreateMentions(ctx context.Context, mentions []*Mention) error
+	// GetMentionedUserIDs returns the users mentioned in the comment
+	GetMentionedUserIDs(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error)
+	// GetMentionsByUserID returns the user's mentions, newest first
+	GetMentionsByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*Mention, error)
+	// MarkMentionsRead marks the user's unread mentions among ids read, or all of them when
+	// ids is empty, and returns how many changed
+	MarkMentionsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, comment *Comment) error {
+	return transaction.DB(ctx, r.db).Create(comment).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Comment, error) {
+	var comment Comment
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&comment)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &comment, nil
+}
+
+func (r *repository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*Comment, error) {
+	var comments []*Comment
+	result := transaction.DB(ctx, r.db).
+		Where("card_id = ?", cardID).
+		Order("created_at ASC").
+		Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return comments, nil
+}
+
+func (r *repository) GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*Comment, error) {
+	if len(cardIDs) == 0 {
+		return []*Comment{}, nil
+	}
+	var comments []*Comment
+	result := transaction.DB(ctx, r.db).
+		Where("card_id IN ?", cardIDs).
+		Order("created_at ASC").
+		Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return comments, nil
+}
+
+func (r *repository) Update(ctx context.Context, comment *Comment) error {
+	return transaction.DB(ctx, r.db).Save(comment).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Comment{}, "id = ?", id).Error
+}
+
+func (r *repository) CreateMentions(ctx context.Context, mentions []*Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&mentions).Error
+}
+
+func (r *repository) GetMentionedUserIDs(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	result := transaction.DB(ctx, r.db).
+		Model(&Mention{}).
+		Where("comment_id = ?", commentID).
+		Order("created_at ASC").
+		Pluck("user_id", &userIDs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userIDs, nil
+}
+
+func (r *repository) GetMentionsByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*Mention, error) {
+	query := transaction.DB(ctx, r.db).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var mentions []*Mention
+	result := query.Order("created_at DESC").Limit(limit).Find(&mentions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return mentions, nil
+}
+
+func (r *repository) MarkMentionsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error) {
+	query := transaction.DB(ctx, r.db).
+		Model(&Mention{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -41,7 +42,7 @@ func (r *emailVerificationTokenRepository) Create(ctx context.Context, userID uu
 		ExpiresAt: time.Now().Add(expiresIn),
 	}
 
-	if err := r.db.WithContext(ctx).Create(verificationToken).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Create(verificationToken).Error; err != nil {
 		return nil, err
 	}
 
@@ -50,7 +51,7 @@ func (r *emailVerificationTokenRepository) Create(ctx context.Context, userID uu
 
 func (r *emailVerificationTokenRepository) FindByToken(ctx context.Context, token string) (*EmailVerificationToken, error) {
 	var verificationToken EmailVerificationToken
-	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&verificationToken).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Where("token = ?", token).First(&verificationToken).Error; err != nil {
 		return nil, err
 	}
 	return &verificationToken, nil
@@ -58,19 +59,19 @@ func (r *emailVerificationTokenRepository) FindByToken(ctx context.Context, toke
 
 func (r *emailVerificationTokenRepository) MarkAsUsed(ctx context.Context, token string) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&EmailVerificationToken{}).
+	return transaction.DB(ctx, r.db).Model(&EmailVerificationToken{}).
 		Where("token = ?", token).
 		Update("used_at", &now).Error
 }
 
 func (r *emailVerificationTokenRepository) DeleteExpiredTokens(ctx context.Context) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("expires_at < ? OR used_at IS NOT NULL", time.Now()).
 		Delete(&EmailVerificationToken{}).Error
 }
 
 func (r *emailVerificationTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("user_id = ?", userID).
 		Delete(&EmailVerificationToken{}).Error
 }
@@ -0,0 +1,22 @@
+package epic
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Epic groups the cards of a project that deliver a larger piece of work
+type Epic struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID   uuid.UUID  `gorm:"type:uuid;not null"`
+	Name        string     `gorm:"type:varchar(255);not null"`
+	Description string     `gorm:"type:text;not null;default:''"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (Epic) TableName() string {
+	return "epics"
+}
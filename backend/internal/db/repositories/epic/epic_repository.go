@@ -0,0 +1,85 @@
+package epic
+
+//go:generate mockgen -source=epic_repository.go -destination=mocks/epic_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, epic *Epic) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Epic, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Epic, error)
+	Update(ctx context.Context, epic *Epic) error
+	// Delete removes the epic; its cards stay in the project without an epic
+	Delete(ctx context.Context, id uuid.UUID) error
+	// GetCards returns the cards assigned to the epic
+	GetCards(ctx context.Context, epicID uuid.UUID) ([]*card.Card, error)
+	// SetCardEpic assigns the card to the epic, or removes it from its epic when epicID is nil
+	SetCardEpic(ctx context.Context, cardID uuid.UUID, epicID *uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, epic *Epic) error {
+	return transaction.DB(ctx, r.db).Create(epic).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Epic, error) {
+	var epic Epic
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&epic)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &epic, nil
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Epic, error) {
+	var epics []*Epic
+	err := transaction.DB(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("created_at ASC").
+		Find(&epics).Error
+	if err != nil {
+		return nil, err
+	}
+	return epics, nil
+}
+
+func (r *repository) Update(ctx context.Context, epic *Epic) error {
+	return transaction.DB(ctx, r.db).Save(epic).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Where("id = ?", id).Delete(&Epic{}).Error
+}
+
+func (r *repository) GetCards(ctx context.Context, epicID uuid.UUID) ([]*card.Card, error) {
+	var cards []*card.Card
+	err := transaction.DB(ctx, r.db).
+		Where("epic_id = ?", epicID).
+		Order("created_at ASC").
+		Find(&cards).Error
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func (r *repository) SetCardEpic(ctx context.Context, cardID uuid.UUID, epicID *uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Model(&card.Card{}).
+		Where("id = ?", cardID).
+		Update("epic_id", epicID).Error
+}
@@ -0,0 +1,63 @@
+package estimation_accuracy
+
+//go:generate mockgen -source=estimation_accuracy_repository.go -destination=mocks/estimation_accuracy_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+// Sample is a completed card with its estimates and cycle time. A card is completed
+// when it sits in a done column, as of the time it entered that column.
+type Sample struct {
+	CardID              uuid.UUID
+	Title               string
+	AssigneeID          *uuid.UUID
+	OriginalStoryPoints *int
+	StoryPoints         *int
+	// StartedAt is when the card first left the column it was created in, or when it
+	// was created for cards that went straight to done
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+type Repository interface {
+	// GetCompletedSamples returns the project's cards completed in [from, to), oldest first
+	GetCompletedSamples(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]*Sample, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCompletedSamples(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]*Sample, error) {
+	var samples []*Sample
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			c.id AS card_id,
+			c.title,
+			c.assignee_id,
+			c.original_story_points,
+			c.story_points,
+			COALESCE(c.started_at, c.created_at) AS started_at,
+			c.column_entered_at AS completed_at
+		FROM cards c
+		JOIN boards b ON b.id = c.board_id
+		JOIN board_columns col ON col.id = c.column_id
+		WHERE b.project_id = ? AND col.is_done
+			AND c.column_entered_at >= ? AND c.column_entered_at < ?
+		ORDER BY c.column_entered_at ASC
+	`, projectID, from, to).Scan(&samples).Error
+	if err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: estimation_accuracy_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=estimation_accuracy_repository.go -destination=mocks/estimation_accuracy_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	estimation_accuracy "github.com/thatcatdev/kaimu/backend/internal/db/repositories/estimation_accuracy"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCompletedSamples mocks base method.
+func (m *MockRepository) GetCompletedSamples(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]*estimation_accuracy.Sample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedSamples", ctx, projectID, from, to)
+	ret0, _ := ret[0].([]*estimation_accuracy.Sample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedSamples indicates an expected call of GetCompletedSamples.
+func (mr *MockRepositoryMockRecorder) GetCompletedSamples(ctx, projectID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedSamples", reflect.TypeOf((*MockRepository)(nil).GetCompletedSamples), ctx, projectID, from, to)
+}
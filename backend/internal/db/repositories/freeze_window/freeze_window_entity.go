@@ -0,0 +1,42 @@
+package freeze_window
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FreezeWindow is a period during which cards may only be moved into its columns with an
+// override
+type FreezeWindow struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BoardID  uuid.UUID `gorm:"type:uuid;not null"`
+	Name     string    `gorm:"type:varchar(255);not null"`
+	StartsAt time.Time `gorm:"not null"`
+	// EndsAt is exclusive
+	EndsAt    time.Time  `gorm:"not null"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime"`
+	// ColumnIDs are stored in freeze_window_columns
+	ColumnIDs []uuid.UUID `gorm:"-"`
+}
+
+func (FreezeWindow) TableName() string {
+	return "freeze_windows"
+}
+
+// IsActive reports whether the window freezes its columns at the given time
+func (w *FreezeWindow) IsActive(at time.Time) bool {
+	return !at.Before(w.StartsAt) && at.Before(w.EndsAt)
+}
+
+// FreezeWindowColumn is a column a window freezes
+type FreezeWindowColumn struct {
+	FreezeWindowID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ColumnID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+func (FreezeWindowColumn) TableName() string {
+	return "freeze_window_columns"
+}
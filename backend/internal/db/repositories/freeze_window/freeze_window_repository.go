@@ -0,0 +1,138 @@
+package freeze_window
+
+//go:generate mockgen -source=freeze_window_repository.go -destination=mocks/freeze_window_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Create stores the window with its columns
+	Create(ctx context.Context, window *FreezeWindow) error
+	// GetByID returns the window with its columns, or gorm.ErrRecordNotFound
+	GetByID(ctx context.Context, id uuid.UUID) (*FreezeWindow, error)
+	// GetByBoardID returns the board's windows with their columns, earliest start first
+	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*FreezeWindow, error)
+	// GetActiveByColumnID returns the windows freezing the column at the given time, with
+	// their columns, earliest end first
+	GetActiveByColumnID(ctx context.Context, columnID uuid.UUID, at time.Time) ([]*FreezeWindow, error)
+	// Update saves the window and replaces its columns
+	Update(ctx context.Context, window *FreezeWindow) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, window *FreezeWindow) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(window).Error; err != nil {
+			return err
+		}
+		return createColumns(tx, window)
+	})
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*FreezeWindow, error) {
+	db := transaction.DB(ctx, r.db)
+
+	var window FreezeWindow
+	if err := db.Where("id = ?", id).First(&window).Error; err != nil {
+		return nil, err
+	}
+	if err := loadColumns(db, []*FreezeWindow{&window}); err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*FreezeWindow, error) {
+	db := transaction.DB(ctx, r.db)
+
+	var windows []*FreezeWindow
+	if err := db.Where("board_id = ?", boardID).Order("starts_at, created_at").Find(&windows).Error; err != nil {
+		return nil, err
+	}
+	if err := loadColumns(db, windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (r *repository) GetActiveByColumnID(ctx context.Context, columnID uuid.UUID, at time.Time) ([]*FreezeWindow, error) {
+	db := transaction.DB(ctx, r.db)
+
+	var windows []*FreezeWindow
+	err := db.
+		Joins("JOIN freeze_window_columns ON freeze_window_columns.freeze_window_id = freeze_windows.id").
+		Where("freeze_window_columns.column_id = ? AND freeze_windows.starts_at <= ? AND freeze_windows.ends_at > ?", columnID, at, at).
+		Order("freeze_windows.ends_at").
+		Find(&windows).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := loadColumns(db, windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (r *repository) Update(ctx context.Context, window *FreezeWindow) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(window).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("freeze_window_id = ?", window.ID).Delete(&FreezeWindowColumn{}).Error; err != nil {
+			return err
+		}
+		return createColumns(tx, window)
+	})
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&FreezeWindow{}, "id = ?", id).Error
+}
+
+func createColumns(tx *gorm.DB, window *FreezeWindow) error {
+	if len(window.ColumnIDs) == 0 {
+		return nil
+	}
+	columns := make([]*FreezeWindowColumn, len(window.ColumnIDs))
+	for i, columnID := range window.ColumnIDs {
+		columns[i] = &FreezeWindowColumn{FreezeWindowID: window.ID, ColumnID: columnID}
+	}
+	return tx.Create(&columns).Error
+}
+
+// loadColumns fills in the windows' column IDs with one query
+func loadColumns(db *gorm.DB, windows []*FreezeWindow) error {
+	if len(windows) == 0 {
+		return nil
+	}
+	byID := make(map[uuid.UUID]*FreezeWindow, len(windows))
+	ids := make([]uuid.UUID, len(windows))
+	for i, w := range windows {
+		byID[w.ID] = w
+		ids[i] = w.ID
+	}
+
+	var columns []*FreezeWindowColumn
+	if err := db.Where("freeze_window_id IN ?", ids).Order("column_id").Find(&columns).Error; err != nil {
+		return err
+	}
+	for _, c := range columns {
+		w := byID[c.FreezeWindowID]
+		w.ColumnIDs = append(w.ColumnIDs, c.ColumnID)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package instance_setting
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstanceSetting is the whitelabel branding of the whole instance. There is at most one row.
+type InstanceSetting struct {
+	ID           bool       `gorm:"primary_key;default:true"`
+	ProductName  string     `gorm:"type:varchar(100);not null"`
+	LogoURL      string     `gorm:"type:varchar(2048);not null"`
+	SupportEmail string     `gorm:"type:varchar(255);not null"`
+	TermsURL     string     `gorm:"type:varchar(2048);not null"`
+	UpdatedBy    *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (InstanceSetting) TableName() string {
+	return "instance_settings"
+}
+
+// Default returns the settings of an instance that hasn't been branded
+func Default() *InstanceSetting {
+	return &InstanceSetting{ID: true}
+}
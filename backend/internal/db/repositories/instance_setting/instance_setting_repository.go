@@ -0,0 +1,43 @@
+package instance_setting
+
+//go:generate mockgen -source=instance_setting_repository.go -destination=mocks/instance_setting_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Get returns the instance's settings, or the defaults when there are none
+	Get(ctx context.Context) (*InstanceSetting, error)
+	// Save creates or replaces the instance's settings
+	Save(ctx context.Context, setting *InstanceSetting) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Get(ctx context.Context) (*InstanceSetting, error) {
+	var setting InstanceSetting
+	err := transaction.DB(ctx, r.db).Where("id = ?", true).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *repository) Save(ctx context.Context, setting *InstanceSetting) error {
+	setting.ID = true
+	return transaction.DB(ctx, r.db).Save(setting).Error
+}
@@ -6,18 +6,36 @@ import (
 	"github.com/google/uuid"
 )
 
+// Invitation invites an email address into an organization. With a ProjectID the invitee
+// also joins that project, with ProjectRoleID as project role (nil inherits the org role).
+// A guest invitation always has a ProjectID, and makes the invitee a guest of the org.
 type Invitation struct {
 	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
 	Email          string     `gorm:"type:varchar(255);not null"`
 	RoleID         *uuid.UUID `gorm:"type:uuid"`
+	ProjectID      *uuid.UUID `gorm:"type:uuid"`
+	ProjectRoleID  *uuid.UUID `gorm:"type:uuid"`
+	IsGuest        bool       `gorm:"not null;default:false"`
 	InvitedBy      uuid.UUID  `gorm:"type:uuid;not null"`
 	Token          string     `gorm:"type:varchar(255);uniqueIndex;not null"`
 	ExpiresAt      time.Time  `gorm:"not null"`
 	AcceptedAt     *time.Time
-	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	// RemindedAt is when the invitee was reminded that the invitation is about to expire
+	RemindedAt *time.Time
+	// ExpiredAt is when the expirer found the invitation past ExpiresAt
+	ExpiredAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
 
+// Status selects invitations that are not accepted by whether they have expired
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusExpired Status = "expired"
+)
+
 func (Invitation) TableName() string {
 	return "invitations"
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -16,10 +17,23 @@ type Repository interface {
 	GetByToken(ctx context.Context, token string) (*Invitation, error)
 	GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error)
 	GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error)
+	// GetExpiredByOrgID returns the organization's invitations that expired before being
+	// accepted, most recently expired first
+	GetExpiredByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error)
 	GetByOrgAndEmail(ctx context.Context, orgID uuid.UUID, email string) (*Invitation, error)
 	Update(ctx context.Context, inv *Invitation) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteExpired(ctx context.Context) error
+
+	// GetDueReminders returns up to limit pending invitations not yet reminded that expire
+	// within their organization's reminder period
+	GetDueReminders(ctx context.Context, now time.Time, limit int) ([]*Invitation, error)
+	// MarkReminded records the reminder of an invitation, and reports false when it was
+	// already recorded
+	MarkReminded(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	// ExpireStale marks the invitations past their expiry as expired and returns how many
+	// changed
+	ExpireStale(ctx context.Context, now time.Time) (int64, error)
 }
 
 type repository struct {
@@ -31,12 +45,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, inv *Invitation) error {
-	return r.db.WithContext(ctx).Create(inv).Error
+	return transaction.DB(ctx, r.db).Create(inv).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error) {
 	var inv Invitation
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&inv).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&inv).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +59,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Invitation, er
 
 func (r *repository) GetByToken(ctx context.Context, token string) (*Invitation, error) {
 	var inv Invitation
-	err := r.db.WithContext(ctx).Where("token = ?", token).First(&inv).Error
+	err := transaction.DB(ctx, r.db).Where("token = ?", token).First(&inv).Error
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +68,7 @@ func (r *repository) GetByToken(ctx context.Context, token string) (*Invitation,
 
 func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error) {
 	var invs []*Invitation
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("organization_id = ?", orgID).
 		Order("created_at DESC").
 		Find(&invs).Error
@@ -66,7 +80,7 @@ func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invita
 
 func (r *repository) GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error) {
 	var invs []*Invitation
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("organization_id = ? AND accepted_at IS NULL AND expires_at > ?", orgID, time.Now()).
 		Order("created_at DESC").
 		Find(&invs).Error
@@ -76,9 +90,21 @@ func (r *repository) GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]
 	return invs, nil
 }
 
+func (r *repository) GetExpiredByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Invitation, error) {
+	var invs []*Invitation
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND accepted_at IS NULL AND expires_at <= ?", orgID, time.Now()).
+		Order("expires_at DESC").
+		Find(&invs).Error
+	if err != nil {
+		return nil, err
+	}
+	return invs, nil
+}
+
 func (r *repository) GetByOrgAndEmail(ctx context.Context, orgID uuid.UUID, email string) (*Invitation, error) {
 	var inv Invitation
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("organization_id = ? AND email = ?", orgID, email).
 		First(&inv).Error
 	if err != nil {
@@ -88,14 +114,47 @@ func (r *repository) GetByOrgAndEmail(ctx context.Context, orgID uuid.UUID, emai
 }
 
 func (r *repository) Update(ctx context.Context, inv *Invitation) error {
-	return r.db.WithContext(ctx).Save(inv).Error
+	return transaction.DB(ctx, r.db).Save(inv).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Invitation{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Invitation{}, "id = ?", id).Error
 }
 
 func (r *repository) DeleteExpired(ctx context.Context) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Delete(&Invitation{}, "expires_at < ? AND accepted_at IS NULL", time.Now()).Error
 }
+
+func (r *repository) GetDueReminders(ctx context.Context, now time.Time, limit int) ([]*Invitation, error) {
+	var invs []*Invitation
+	err := transaction.DB(ctx, r.db).
+		Joins("JOIN organizations ON organizations.id = invitations.organization_id").
+		Where("invitations.accepted_at IS NULL AND invitations.expired_at IS NULL AND invitations.reminded_at IS NULL").
+		Where("invitations.expires_at > ?", now).
+		Where("organizations.invitation_reminder_days IS NOT NULL").
+		Where("invitations.expires_at <= ? + make_interval(days => organizations.invitation_reminder_days)", now).
+		Order("invitations.expires_at ASC").
+		Limit(limit).
+		Find(&invs).Error
+	if err != nil {
+		return nil, err
+	}
+	return invs, nil
+}
+
+func (r *repository) MarkReminded(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&Invitation{}).
+		Where("id = ? AND reminded_at IS NULL", id).
+		Update("reminded_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) ExpireStale(ctx context.Context, now time.Time) (int64, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&Invitation{}).
+		Where("accepted_at IS NULL AND expired_at IS NULL AND expires_at <= ?", now).
+		Update("expired_at", now)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,29 @@
+package legal_hold
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LegalHold suspends permanent deletions in an organization until it is lifted
+type LegalHold struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	Reason         string     `gorm:"type:text;not null"`
+	PlacedBy       *uuid.UUID `gorm:"type:uuid"`
+	PlacedAt       time.Time  `gorm:"not null"`
+	LiftReason     *string    `gorm:"type:text"`
+	LiftedBy       *uuid.UUID `gorm:"type:uuid"`
+	// LiftedAt is nil while the hold is active
+	LiftedAt *time.Time
+}
+
+func (LegalHold) TableName() string {
+	return "legal_holds"
+}
+
+// IsActive reports whether the hold still blocks deletions
+func (h *LegalHold) IsActive() bool {
+	return h.LiftedAt == nil
+}
@@ -0,0 +1,82 @@
+package legal_hold
+
+//go:generate mockgen -source=legal_hold_repository.go -destination=mocks/legal_hold_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// CreateIfAbsent places the hold unless the organization already has an active one. It
+	// reports whether the hold was placed.
+	CreateIfAbsent(ctx context.Context, hold *LegalHold) (bool, error)
+	// GetActiveByOrgID returns the organization's active hold, or gorm.ErrRecordNotFound
+	GetActiveByOrgID(ctx context.Context, orgID uuid.UUID) (*LegalHold, error)
+	// GetByOrgID returns the organization's holds, active and lifted, newest first
+	GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*LegalHold, error)
+	// Lift records who lifted the hold, when and why. It reports false when the hold was
+	// already lifted.
+	Lift(ctx context.Context, id uuid.UUID, liftedBy uuid.UUID, at time.Time, reason string) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateIfAbsent(ctx context.Context, hold *LegalHold) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(hold)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) GetActiveByOrgID(ctx context.Context, orgID uuid.UUID) (*LegalHold, error) {
+	var hold LegalHold
+	result := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND lifted_at IS NULL", orgID).
+		First(&hold)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &hold, nil
+}
+
+func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*LegalHold, error) {
+	var holds []*LegalHold
+	result := transaction.DB(ctx, r.db).
+		Where("organization_id = ?", orgID).
+		Order("placed_at DESC").
+		Find(&holds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return holds, nil
+}
+
+func (r *repository) Lift(ctx context.Context, id uuid.UUID, liftedBy uuid.UUID, at time.Time, reason string) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&LegalHold{}).
+		Where("id = ? AND lifted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"lifted_by":   liftedBy,
+			"lifted_at":   at,
+			"lift_reason": reason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
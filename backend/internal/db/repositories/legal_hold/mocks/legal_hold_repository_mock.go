@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: legal_hold_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=legal_hold_repository.go -destination=mocks/legal_hold_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	legal_hold "github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIfAbsent mocks base method.
+func (m *MockRepository) CreateIfAbsent(ctx context.Context, hold *legal_hold.LegalHold) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfAbsent", ctx, hold)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIfAbsent indicates an expected call of CreateIfAbsent.
+func (mr *MockRepositoryMockRecorder) CreateIfAbsent(ctx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfAbsent", reflect.TypeOf((*MockRepository)(nil).CreateIfAbsent), ctx, hold)
+}
+
+// GetActiveByOrgID mocks base method.
+func (m *MockRepository) GetActiveByOrgID(ctx context.Context, orgID uuid.UUID) (*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByOrgID", ctx, orgID)
+	ret0, _ := ret[0].(*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByOrgID indicates an expected call of GetActiveByOrgID.
+func (mr *MockRepositoryMockRecorder) GetActiveByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByOrgID", reflect.TypeOf((*MockRepository)(nil).GetActiveByOrgID), ctx, orgID)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockRepository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", ctx, orgID)
+	ret0, _ := ret[0].([]*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockRepositoryMockRecorder) GetByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockRepository)(nil).GetByOrgID), ctx, orgID)
+}
+
+// Lift mocks base method.
+func (m *MockRepository) Lift(ctx context.Context, id, liftedBy uuid.UUID, at time.Time, reason string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lift", ctx, id, liftedBy, at, reason)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lift indicates an expected call of Lift.
+func (mr *MockRepositoryMockRecorder) Lift(ctx, id, liftedBy, at, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lift", reflect.TypeOf((*MockRepository)(nil).Lift), ctx, id, liftedBy, at, reason)
+}
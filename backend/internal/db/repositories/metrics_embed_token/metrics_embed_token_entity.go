@@ -0,0 +1,76 @@
+package metrics_embed_token
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Chart is a board chart an embed token can read
+type Chart string
+
+const (
+	ChartBurnDown       Chart = "burn_down"
+	ChartBurnUp         Chart = "burn_up"
+	ChartVelocity       Chart = "velocity"
+	ChartCumulativeFlow Chart = "cumulative_flow"
+)
+
+// MetricsEmbedToken lets an external dashboard read some of a board's charts without a
+// user session
+type MetricsEmbedToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BoardID   uuid.UUID  `gorm:"type:uuid;not null"`
+	TokenHash string     `gorm:"type:varchar(255);not null"`
+	Charts    Charts     `gorm:"type:jsonb;not null;default:'[]'"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	RevokedAt *time.Time `gorm:"type:timestamp with time zone"`
+}
+
+func (MetricsEmbedToken) TableName() string {
+	return "metrics_embed_tokens"
+}
+
+// IsValid checks if the token is not expired and not revoked
+func (t *MetricsEmbedToken) IsValid(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// Allows reports whether the token can read the chart
+func (t *MetricsEmbedToken) Allows(chart Chart) bool {
+	return slices.Contains(t.Charts, chart)
+}
+
+// Charts is a list of charts stored as a JSON array
+type Charts []Chart
+
+func (c Charts) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *Charts) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into Charts", value)
+	}
+}
@@ -0,0 +1,70 @@
+package metrics_embed_token
+
+//go:generate mockgen -source=metrics_embed_token_repository.go -destination=mocks/metrics_embed_token_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, token *MetricsEmbedToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*MetricsEmbedToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*MetricsEmbedToken, error)
+	// GetByBoardID returns the board's tokens that are neither revoked nor expired at now,
+	// newest first
+	GetByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*MetricsEmbedToken, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, token *MetricsEmbedToken) error {
+	return transaction.DB(ctx, r.db).Create(token).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*MetricsEmbedToken, error) {
+	var token MetricsEmbedToken
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*MetricsEmbedToken, error) {
+	var token MetricsEmbedToken
+	err := transaction.DB(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*MetricsEmbedToken, error) {
+	var tokens []*MetricsEmbedToken
+	err := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND revoked_at IS NULL AND expires_at > ?", boardID, now).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *repository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&MetricsEmbedToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
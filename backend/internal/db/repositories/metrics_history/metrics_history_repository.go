@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -28,12 +29,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, history *MetricsHistory) error {
-	return r.db.WithContext(ctx).Create(history).Error
+	return transaction.DB(ctx, r.db).Create(history).Error
 }
 
 // Upsert inserts or updates a metrics history record based on sprint_id and recorded_date
 func (r *repository) Upsert(ctx context.Context, history *MetricsHistory) error {
-	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+	return transaction.DB(ctx, r.db).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "sprint_id"}, {Name: "recorded_date"}},
 		UpdateAll: true,
 	}).Create(history).Error
@@ -41,7 +42,7 @@ func (r *repository) Upsert(ctx context.Context, history *MetricsHistory) error
 
 func (r *repository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*MetricsHistory, error) {
 	var histories []*MetricsHistory
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("sprint_id = ?", sprintID).
 		Order("recorded_date ASC").
 		Find(&histories).Error
@@ -53,7 +54,7 @@ func (r *repository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*
 
 func (r *repository) GetBySprintIDAndDateRange(ctx context.Context, sprintID uuid.UUID, startDate, endDate time.Time) ([]*MetricsHistory, error) {
 	var histories []*MetricsHistory
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("sprint_id = ? AND recorded_date >= ? AND recorded_date <= ?", sprintID, startDate, endDate).
 		Order("recorded_date ASC").
 		Find(&histories).Error
@@ -65,7 +66,7 @@ func (r *repository) GetBySprintIDAndDateRange(ctx context.Context, sprintID uui
 
 func (r *repository) GetLatestBySprintID(ctx context.Context, sprintID uuid.UUID) (*MetricsHistory, error) {
 	var history MetricsHistory
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("sprint_id = ?", sprintID).
 		Order("recorded_date DESC").
 		First(&history).Error
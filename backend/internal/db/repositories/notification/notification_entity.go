@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind is what a notification tells its user about
+type Kind string
+
+const (
+	// KindCardAssigned is a card assigned to the user by someone else
+	KindCardAssigned Kind = "card_assigned"
+	// KindMentioned is an @mention of the user in a comment
+	KindMentioned Kind = "mentioned"
+	// KindInvitationReceived is an invitation into an organization sent to the user's email
+	KindInvitationReceived Kind = "invitation_received"
+	// KindSprintStarted is the start of a sprint holding cards assigned to the user
+	KindSprintStarted Kind = "sprint_started"
+)
+
+// Notification tells UserID about something that concerns them. Which of the references
+// are set depends on the Kind.
+type Notification struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID uuid.UUID `gorm:"type:uuid;not null"`
+	Kind   Kind      `gorm:"type:varchar(50);not null"`
+	// EventID is the domain event that caused the notification
+	EventID uuid.UUID `gorm:"type:uuid;not null"`
+	// ActorID is who caused it, nil for system actions and deleted users
+	ActorID      *uuid.UUID `gorm:"type:uuid"`
+	CardID       *uuid.UUID `gorm:"type:uuid"`
+	CommentID    *uuid.UUID `gorm:"type:uuid"`
+	SprintID     *uuid.UUID `gorm:"type:uuid"`
+	InvitationID *uuid.UUID `gorm:"type:uuid"`
+	ReadAt       *time.Time `gorm:"type:timestamptz"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
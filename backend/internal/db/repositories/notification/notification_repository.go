@@ -0,0 +1,89 @@
+package notification
+
+//go:generate mockgen -source=notification_repository.go -destination=mocks/notification_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// CreateMany records notifications, skipping users already notified about the event
+	CreateMany(ctx context.Context, notifications []*Notification) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Notification, error)
+	// GetByUserID returns the user's notifications, newest first
+	GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*Notification, error)
+	// CountUnread counts the user's unread notifications
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead marks the user's unread notifications among ids read, or all of them when ids
+	// is empty, and returns how many changed
+	MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateMany(ctx context.Context, notifications []*Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&notifications).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Notification, error) {
+	var notification Notification
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&notification)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &notification, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*Notification, error) {
+	query := transaction.DB(ctx, r.db).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []*Notification
+	result := query.Order("created_at DESC").Limit(limit).Find(&notifications)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return notifications, nil
+}
+
+func (r *repository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).
+		Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *repository) MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID, at time.Time) (int64, error) {
+	query := transaction.DB(ctx, r.db).
+		Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_batch_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_batch_repository.go -destination=mocks/notification_batch_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	notification_batch "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockRepository) Enqueue(ctx context.Context, item *notification_batch.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockRepositoryMockRecorder) Enqueue(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockRepository)(nil).Enqueue), ctx, item)
+}
+
+// GetDueBatches mocks base method.
+func (m *MockRepository) GetDueBatches(ctx context.Context, now time.Time) ([]*notification_batch.Batch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueBatches", ctx, now)
+	ret0, _ := ret[0].([]*notification_batch.Batch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueBatches indicates an expected call of GetDueBatches.
+func (mr *MockRepositoryMockRecorder) GetDueBatches(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueBatches", reflect.TypeOf((*MockRepository)(nil).GetDueBatches), ctx, now)
+}
+
+// TakeBatch mocks base method.
+func (m *MockRepository) TakeBatch(ctx context.Context, batch *notification_batch.Batch) ([]*notification_batch.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeBatch", ctx, batch)
+	ret0, _ := ret[0].([]*notification_batch.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeBatch indicates an expected call of TakeBatch.
+func (mr *MockRepositoryMockRecorder) TakeBatch(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeBatch", reflect.TypeOf((*MockRepository)(nil).TakeBatch), ctx, batch)
+}
@@ -0,0 +1,32 @@
+package notification_batch
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is a notification held back by a board's quiet mode. It goes to the owner of RuleID
+// by email, or to SlackWebhookURL; Message is the event rendered in the recipient's locale.
+type Item struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BoardID         uuid.UUID  `gorm:"type:uuid;not null"`
+	RuleID          *uuid.UUID `gorm:"type:uuid"`
+	SlackWebhookURL *string    `gorm:"type:text"`
+	SlackChannel    string     `gorm:"type:varchar(80);not null;default:''"`
+	EventID         uuid.UUID  `gorm:"type:uuid;not null"`
+	Message         string     `gorm:"type:text;not null"`
+	QueuedAt        time.Time  `gorm:"not null"`
+}
+
+func (Item) TableName() string {
+	return "notification_batch_items"
+}
+
+// Batch identifies the items of one board that are summarized into one notification
+type Batch struct {
+	BoardID         uuid.UUID
+	RuleID          *uuid.UUID
+	SlackWebhookURL *string
+	SlackChannel    string
+}
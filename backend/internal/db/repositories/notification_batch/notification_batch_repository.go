@@ -0,0 +1,79 @@
+package notification_batch
+
+//go:generate mockgen -source=notification_batch_repository.go -destination=mocks/notification_batch_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Enqueue holds an item back; an item for the same recipient and event is kept once
+	Enqueue(ctx context.Context, item *Item) error
+	// GetDueBatches returns the batches whose board's window has passed since their oldest
+	// item was queued. Items of boards that left quiet mode are due at once.
+	GetDueBatches(ctx context.Context, now time.Time) ([]*Batch, error)
+	// TakeBatch deletes the batch's items and returns them, oldest first
+	TakeBatch(ctx context.Context, batch *Batch) ([]*Item, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Enqueue(ctx context.Context, item *Item) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(item).Error
+}
+
+func (r *repository) GetDueBatches(ctx context.Context, now time.Time) ([]*Batch, error) {
+	var batches []*Batch
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT i.board_id, i.rule_id, i.slack_webhook_url, i.slack_channel
+		FROM notification_batch_items i
+		JOIN boards b ON b.id = i.board_id
+		GROUP BY i.board_id, i.rule_id, i.slack_webhook_url, i.slack_channel, b.notification_batch_minutes
+		HAVING b.notification_batch_minutes IS NULL
+			OR MIN(i.queued_at) + make_interval(mins => b.notification_batch_minutes) <= ?
+		ORDER BY MIN(i.queued_at)
+	`, now).Scan(&batches).Error
+	if err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+func (r *repository) TakeBatch(ctx context.Context, batch *Batch) ([]*Item, error) {
+	query := transaction.DB(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("board_id = ? AND slack_channel = ?", batch.BoardID, batch.SlackChannel)
+	if batch.RuleID != nil {
+		query = query.Where("rule_id = ?", *batch.RuleID)
+	} else {
+		query = query.Where("rule_id IS NULL")
+	}
+	if batch.SlackWebhookURL != nil {
+		query = query.Where("slack_webhook_url = ?", *batch.SlackWebhookURL)
+	} else {
+		query = query.Where("slack_webhook_url IS NULL")
+	}
+
+	var items []*Item
+	if err := query.Delete(&items).Error; err != nil {
+		return nil, err
+	}
+	slices.SortFunc(items, func(a, b *Item) int {
+		return a.QueuedAt.Compare(b.QueuedAt)
+	})
+	return items, nil
+}
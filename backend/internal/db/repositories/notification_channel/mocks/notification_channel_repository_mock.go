@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_channel_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_channel_repository.go -destination=mocks/notification_channel_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	notification_channel "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, setting *notification_channel.Setting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, setting)
+}
+
+// DeleteByProjectID mocks base method.
+func (m *MockRepository) DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByProjectID", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByProjectID indicates an expected call of DeleteByProjectID.
+func (mr *MockRepositoryMockRecorder) DeleteByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByProjectID", reflect.TypeOf((*MockRepository)(nil).DeleteByProjectID), ctx, projectID)
+}
+
+// DeleteOrgDefaults mocks base method.
+func (m *MockRepository) DeleteOrgDefaults(ctx context.Context, orgID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrgDefaults", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrgDefaults indicates an expected call of DeleteOrgDefaults.
+func (mr *MockRepositoryMockRecorder) DeleteOrgDefaults(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrgDefaults", reflect.TypeOf((*MockRepository)(nil).DeleteOrgDefaults), ctx, orgID)
+}
+
+// GetByProjectID mocks base method.
+func (m *MockRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*notification_channel.Setting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*notification_channel.Setting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProjectID indicates an expected call of GetByProjectID.
+func (mr *MockRepositoryMockRecorder) GetByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectID", reflect.TypeOf((*MockRepository)(nil).GetByProjectID), ctx, projectID)
+}
+
+// GetEffective mocks base method.
+func (m *MockRepository) GetEffective(ctx context.Context, projectID uuid.UUID, event string) (*notification_channel.Setting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffective", ctx, projectID, event)
+	ret0, _ := ret[0].(*notification_channel.Setting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffective indicates an expected call of GetEffective.
+func (mr *MockRepositoryMockRecorder) GetEffective(ctx, projectID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffective", reflect.TypeOf((*MockRepository)(nil).GetEffective), ctx, projectID, event)
+}
+
+// GetOrgDefaults mocks base method.
+func (m *MockRepository) GetOrgDefaults(ctx context.Context, orgID uuid.UUID) ([]*notification_channel.Setting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgDefaults", ctx, orgID)
+	ret0, _ := ret[0].([]*notification_channel.Setting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgDefaults indicates an expected call of GetOrgDefaults.
+func (mr *MockRepositoryMockRecorder) GetOrgDefaults(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgDefaults", reflect.TypeOf((*MockRepository)(nil).GetOrgDefaults), ctx, orgID)
+}
+
+// IsSlackDelivered mocks base method.
+func (m *MockRepository) IsSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlackDelivered", ctx, settingID, eventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSlackDelivered indicates an expected call of IsSlackDelivered.
+func (mr *MockRepositoryMockRecorder) IsSlackDelivered(ctx, settingID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlackDelivered", reflect.TypeOf((*MockRepository)(nil).IsSlackDelivered), ctx, settingID, eventID)
+}
+
+// MarkSlackDelivered mocks base method.
+func (m *MockRepository) MarkSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSlackDelivered", ctx, settingID, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSlackDelivered indicates an expected call of MarkSlackDelivered.
+func (mr *MockRepositoryMockRecorder) MarkSlackDelivered(ctx, settingID, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSlackDelivered", reflect.TypeOf((*MockRepository)(nil).MarkSlackDelivered), ctx, settingID, eventID)
+}
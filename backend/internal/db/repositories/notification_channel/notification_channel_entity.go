@@ -0,0 +1,38 @@
+package notification_channel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Setting routes one card event to delivery channels: an organization default when
+// ProjectID is nil, otherwise a project override. Slack is enabled when SlackWebhookURL
+// is set; with neither email nor Slack the event stays in-app only.
+type Setting struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID  uuid.UUID  `gorm:"type:uuid;not null"`
+	ProjectID       *uuid.UUID `gorm:"type:uuid"`
+	Event           string     `gorm:"type:varchar(50);not null"`
+	Email           bool       `gorm:"not null;default:true"`
+	SlackWebhookURL *string    `gorm:"type:text"`
+	// SlackChannel overrides the webhook's default channel
+	SlackChannel *string   `gorm:"type:varchar(80)"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+func (Setting) TableName() string {
+	return "notification_channel_settings"
+}
+
+// SlackDelivery records that a setting has posted an event to Slack
+type SlackDelivery struct {
+	SettingID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DeliveredAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (SlackDelivery) TableName() string {
+	return "notification_slack_deliveries"
+}
@@ -0,0 +1,112 @@
+package notification_channel
+
+//go:generate mockgen -source=notification_channel_repository.go -destination=mocks/notification_channel_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, setting *Setting) error
+	// GetByProjectID returns the project's overrides
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Setting, error)
+	// GetOrgDefaults returns the organization's default settings
+	GetOrgDefaults(ctx context.Context, orgID uuid.UUID) ([]*Setting, error)
+	// GetEffective returns the project's override for the event, falling back to its
+	// organization's default. It returns gorm.ErrRecordNotFound when neither is set.
+	GetEffective(ctx context.Context, projectID uuid.UUID, event string) (*Setting, error)
+	DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error
+	DeleteOrgDefaults(ctx context.Context, orgID uuid.UUID) error
+	// IsSlackDelivered reports whether the setting has already posted the event to Slack
+	IsSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) (bool, error)
+	MarkSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, setting *Setting) error {
+	return transaction.DB(ctx, r.db).Create(setting).Error
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Setting, error) {
+	var settings []*Setting
+	result := transaction.DB(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("event ASC").
+		Find(&settings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return settings, nil
+}
+
+func (r *repository) GetOrgDefaults(ctx context.Context, orgID uuid.UUID) ([]*Setting, error) {
+	var settings []*Setting
+	result := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND project_id IS NULL", orgID).
+		Order("event ASC").
+		Find(&settings)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return settings, nil
+}
+
+func (r *repository) GetEffective(ctx context.Context, projectID uuid.UUID, event string) (*Setting, error) {
+	var settings []*Setting
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT s.*
+		FROM notification_channel_settings s
+		JOIN projects p ON p.organization_id = s.organization_id
+		WHERE p.id = ? AND s.event = ? AND (s.project_id = p.id OR s.project_id IS NULL)
+		ORDER BY s.project_id NULLS LAST
+		LIMIT 1
+	`, projectID, event).Scan(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return settings[0], nil
+}
+
+func (r *repository) DeleteByProjectID(ctx context.Context, projectID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Setting{}, "project_id = ?", projectID).Error
+}
+
+func (r *repository) DeleteOrgDefaults(ctx context.Context, orgID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Setting{}, "organization_id = ? AND project_id IS NULL", orgID).Error
+}
+
+func (r *repository) IsSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) (bool, error) {
+	var delivery SlackDelivery
+	err := transaction.DB(ctx, r.db).
+		Where("setting_id = ? AND event_id = ?", settingID, eventID).
+		First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) MarkSlackDelivered(ctx context.Context, settingID, eventID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&SlackDelivery{SettingID: settingID, EventID: eventID}).Error
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_preference_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_preference_repository.go -destination=mocks/notification_preference_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	notification_preference "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUser mocks base method.
+func (m *MockRepository) GetByUser(ctx context.Context, userID, orgID uuid.UUID) ([]*notification_preference.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID, orgID)
+	ret0, _ := ret[0].([]*notification_preference.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockRepositoryMockRecorder) GetByUser(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockRepository)(nil).GetByUser), ctx, userID, orgID)
+}
+
+// GetByUsers mocks base method.
+func (m *MockRepository) GetByUsers(ctx context.Context, orgID uuid.UUID, category notification_preference.Category, userIDs []uuid.UUID) ([]*notification_preference.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsers", ctx, orgID, category, userIDs)
+	ret0, _ := ret[0].([]*notification_preference.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsers indicates an expected call of GetByUsers.
+func (mr *MockRepositoryMockRecorder) GetByUsers(ctx, orgID, category, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsers", reflect.TypeOf((*MockRepository)(nil).GetByUsers), ctx, orgID, category, userIDs)
+}
+
+// Upsert mocks base method.
+func (m *MockRepository) Upsert(ctx context.Context, preference *notification_preference.Preference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRepositoryMockRecorder) Upsert(ctx, preference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRepository)(nil).Upsert), ctx, preference)
+}
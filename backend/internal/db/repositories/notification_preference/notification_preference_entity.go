@@ -0,0 +1,43 @@
+package notification_preference
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category groups the notifications a user can turn on or off together
+type Category string
+
+const (
+	// CategoryAssignments is cards assigned to the user
+	CategoryAssignments Category = "assignments"
+	// CategoryMentions is @mentions of the user in comments
+	CategoryMentions Category = "mentions"
+	// CategorySprints is sprints starting with cards assigned to the user
+	CategorySprints Category = "sprints"
+)
+
+// Categories lists every category, in the order they are shown
+var Categories = []Category{CategoryAssignments, CategoryMentions, CategorySprints}
+
+// Preference is where a user wants the notifications of a category from one organization
+type Preference struct {
+	UserID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Category       Category  `gorm:"type:varchar(50);primaryKey"`
+	// InApp has no gorm default so that false is written rather than the column's default
+	InApp     bool      `gorm:"not null"`
+	Email     bool      `gorm:"not null;default:false"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (Preference) TableName() string {
+	return "notification_preferences"
+}
+
+// Default is the preference of a user who never changed the category: in the app, not by
+// email
+func Default(userID, orgID uuid.UUID, category Category) *Preference {
+	return &Preference{UserID: userID, OrganizationID: orgID, Category: category, InApp: true}
+}
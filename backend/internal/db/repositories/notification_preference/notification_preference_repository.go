@@ -0,0 +1,64 @@
+package notification_preference
+
+//go:generate mockgen -source=notification_preference_repository.go -destination=mocks/notification_preference_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// GetByUser returns the preferences the user changed in the organization
+	GetByUser(ctx context.Context, userID, orgID uuid.UUID) ([]*Preference, error)
+	// GetByUsers returns the preferences of the users that changed the category in the
+	// organization
+	GetByUsers(ctx context.Context, orgID uuid.UUID, category Category, userIDs []uuid.UUID) ([]*Preference, error)
+	// Upsert creates or replaces the preference
+	Upsert(ctx context.Context, preference *Preference) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByUser(ctx context.Context, userID, orgID uuid.UUID) ([]*Preference, error) {
+	var preferences []*Preference
+	err := transaction.DB(ctx, r.db).
+		Where("user_id = ? AND organization_id = ?", userID, orgID).
+		Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *repository) GetByUsers(ctx context.Context, orgID uuid.UUID, category Category, userIDs []uuid.UUID) ([]*Preference, error) {
+	if len(userIDs) == 0 {
+		return []*Preference{}, nil
+	}
+	var preferences []*Preference
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND category = ? AND user_id IN ?", orgID, category, userIDs).
+		Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *repository) Upsert(ctx context.Context, preference *Preference) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "organization_id"}, {Name: "category"}},
+			DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "updated_at"}),
+		}).
+		Create(preference).Error
+}
@@ -0,0 +1,39 @@
+package notification_rule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+)
+
+// NotificationRule notifies UserID when Event happens to a card in ProjectID. The nil
+// conditions (TagID, ColumnID, Priority) match every card.
+type NotificationRule struct {
+	ID        uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID          `gorm:"type:uuid;not null"`
+	ProjectID uuid.UUID          `gorm:"type:uuid;not null"`
+	Name      string             `gorm:"type:varchar(255);not null"`
+	Event     string             `gorm:"type:varchar(50);not null"`
+	TagID     *uuid.UUID         `gorm:"type:uuid"`
+	ColumnID  *uuid.UUID         `gorm:"type:uuid"`
+	Priority  *card.CardPriority `gorm:"type:card_priority"`
+	Enabled   bool               `gorm:"not null;default:true"`
+	CreatedAt time.Time          `gorm:"autoCreateTime"`
+	UpdatedAt time.Time          `gorm:"autoUpdateTime"`
+}
+
+func (NotificationRule) TableName() string {
+	return "notification_rules"
+}
+
+// Delivery records that a rule has notified its owner about an event
+type Delivery struct {
+	RuleID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DeliveredAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Delivery) TableName() string {
+	return "notification_rule_deliveries"
+}
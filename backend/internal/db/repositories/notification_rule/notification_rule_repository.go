@@ -0,0 +1,98 @@
+package notification_rule
+
+//go:generate mockgen -source=notification_rule_repository.go -destination=mocks/notification_rule_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, rule *NotificationRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*NotificationRule, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*NotificationRule, error)
+	// GetEnabledByProjectAndEvent returns the enabled rules watching event in the project
+	GetEnabledByProjectAndEvent(ctx context.Context, projectID uuid.UUID, event string) ([]*NotificationRule, error)
+	Update(ctx context.Context, rule *NotificationRule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsDelivered reports whether the rule has already notified about the event
+	IsDelivered(ctx context.Context, ruleID, eventID uuid.UUID) (bool, error)
+	MarkDelivered(ctx context.Context, ruleID, eventID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, rule *NotificationRule) error {
+	return transaction.DB(ctx, r.db).Create(rule).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*NotificationRule, error) {
+	var rule NotificationRule
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &rule, nil
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*NotificationRule, error) {
+	var rules []*NotificationRule
+	result := transaction.DB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+func (r *repository) GetEnabledByProjectAndEvent(ctx context.Context, projectID uuid.UUID, event string) ([]*NotificationRule, error) {
+	var rules []*NotificationRule
+	result := transaction.DB(ctx, r.db).
+		Where("project_id = ? AND event = ? AND enabled", projectID, event).
+		Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return rules, nil
+}
+
+func (r *repository) Update(ctx context.Context, rule *NotificationRule) error {
+	return transaction.DB(ctx, r.db).Save(rule).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&NotificationRule{}, "id = ?", id).Error
+}
+
+func (r *repository) IsDelivered(ctx context.Context, ruleID, eventID uuid.UUID) (bool, error) {
+	var delivery Delivery
+	err := transaction.DB(ctx, r.db).
+		Where("rule_id = ? AND event_id = ?", ruleID, eventID).
+		First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *repository) MarkDelivered(ctx context.Context, ruleID, eventID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Delivery{RuleID: ruleID, EventID: eventID}).Error
+}
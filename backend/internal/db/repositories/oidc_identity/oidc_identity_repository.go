@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -26,12 +27,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, identity *OIDCIdentity) error {
-	return r.db.WithContext(ctx).Create(identity).Error
+	return transaction.DB(ctx, r.db).Create(identity).Error
 }
 
 func (r *repository) GetByIssuerAndSubject(ctx context.Context, issuer, subject string) (*OIDCIdentity, error) {
 	var identity OIDCIdentity
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("issuer = ? AND subject = ?", issuer, subject).
 		First(&identity).Error
 	if err != nil {
@@ -42,7 +43,7 @@ func (r *repository) GetByIssuerAndSubject(ctx context.Context, issuer, subject
 
 func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*OIDCIdentity, error) {
 	var identities []*OIDCIdentity
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("user_id = ?", userID).
 		Find(&identities).Error
 	if err != nil {
@@ -52,10 +53,10 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*OIDC
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&OIDCIdentity{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&OIDCIdentity{}, "id = ?", id).Error
 }
 
 func (r *repository) DeleteByUserIDAndIssuer(ctx context.Context, userID uuid.UUID, issuer string) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Delete(&OIDCIdentity{}, "user_id = ? AND issuer = ?", userID, issuer).Error
 }
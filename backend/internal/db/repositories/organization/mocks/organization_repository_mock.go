@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/db/repositories/organization/organization_repository.go
+// Source: organization_repository.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/db/repositories/organization/organization_repository.go -destination=internal/db/repositories/organization/mocks/organization_repository_mock.go -package=mocks
+//	mockgen -source=organization_repository.go -destination=mocks/organization_repository_mock.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -70,6 +70,21 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
 }
 
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context) ([]*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
+}
+
 // GetByID mocks base method.
 func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
 	m.ctrl.T.Helper()
@@ -12,8 +12,25 @@ type Organization struct {
 	Slug        string    `gorm:"type:varchar(255);uniqueIndex;not null"`
 	Description string    `gorm:"type:text"`
 	OwnerID     uuid.UUID `gorm:"type:uuid;not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	// ContentModerationEnabled runs the configured moderation scanners on card text and comments
+	ContentModerationEnabled bool `gorm:"not null;default:false"`
+	// DefaultLocale is the language used for members who haven't chosen one
+	DefaultLocale string `gorm:"type:varchar(16);not null;default:'en'"`
+	// SearchAnalyticsAnonymized records the organization's searches without who ran them
+	SearchAnalyticsAnonymized bool `gorm:"not null;default:false"`
+	// AIDraftingEnabled lets members draft cards with the configured language model
+	AIDraftingEnabled bool `gorm:"not null;default:false"`
+	// AttachmentMaxBytes and AttachmentQuotaBytes override the configured limits on one
+	// attached file and on all of the organization's attachments; nil uses the configured one
+	AttachmentMaxBytes   *int64 `gorm:"type:bigint"`
+	AttachmentQuotaBytes *int64 `gorm:"type:bigint"`
+	// InvitationTTLDays is how long invitations stay valid; nil uses the default
+	InvitationTTLDays *int `gorm:"type:integer"`
+	// InvitationReminderDays is how many days before expiry invitees are reminded; nil sends
+	// no reminders
+	InvitationReminderDays *int      `gorm:"type:integer"`
+	CreatedAt              time.Time `gorm:"autoCreateTime"`
+	UpdatedAt              time.Time `gorm:"autoUpdateTime"`
 }
 
 func (Organization) TableName() string {
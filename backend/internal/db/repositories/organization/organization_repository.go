@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -29,12 +30,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, org *Organization) error {
-	return r.db.WithContext(ctx).Create(org).Error
+	return transaction.DB(ctx, r.db).Create(org).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Organization, error) {
 	var org Organization
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&org).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&org).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +44,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Organization,
 
 func (r *repository) GetBySlug(ctx context.Context, slug string) (*Organization, error) {
 	var org Organization
-	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&org).Error
+	err := transaction.DB(ctx, r.db).Where("slug = ?", slug).First(&org).Error
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +53,7 @@ func (r *repository) GetBySlug(ctx context.Context, slug string) (*Organization,
 
 func (r *repository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*Organization, error) {
 	var orgs []*Organization
-	err := r.db.WithContext(ctx).Where("owner_id = ?", ownerID).Find(&orgs).Error
+	err := transaction.DB(ctx, r.db).Where("owner_id = ?", ownerID).Find(&orgs).Error
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +63,7 @@ func (r *repository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*Or
 // GetByUserID returns all organizations the user is a member of (including owned)
 func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Organization, error) {
 	var orgs []*Organization
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Joins("LEFT JOIN organization_members ON organizations.id = organization_members.organization_id").
 		Where("organizations.owner_id = ? OR organization_members.user_id = ?", userID, userID).
 		Distinct().
@@ -75,7 +76,7 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Orga
 
 func (r *repository) GetAll(ctx context.Context) ([]*Organization, error) {
 	var orgs []*Organization
-	err := r.db.WithContext(ctx).Find(&orgs).Error
+	err := transaction.DB(ctx, r.db).Find(&orgs).Error
 	if err != nil {
 		return nil, err
 	}
@@ -83,9 +84,9 @@ func (r *repository) GetAll(ctx context.Context) ([]*Organization, error) {
 }
 
 func (r *repository) Update(ctx context.Context, org *Organization) error {
-	return r.db.WithContext(ctx).Save(org).Error
+	return transaction.DB(ctx, r.db).Save(org).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Organization{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Organization{}, "id = ?", id).Error
 }
@@ -2,84 +2,15 @@ package organization
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/postgres"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping test: could not connect to test database: %v", err)
-	}
-
-	// Setup schema
-	db.Exec(`
-		CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-		CREATE TABLE IF NOT EXISTS users (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			username VARCHAR(255) NOT NULL UNIQUE,
-			password_hash VARCHAR(255) NOT NULL,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		);
-		CREATE TABLE IF NOT EXISTS organizations (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			name VARCHAR(255) NOT NULL,
-			slug VARCHAR(255) NOT NULL UNIQUE,
-			description TEXT,
-			owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		);
-		CREATE TABLE IF NOT EXISTS organization_members (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
-			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			role VARCHAR(50) NOT NULL DEFAULT 'member',
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			UNIQUE(organization_id, user_id)
-		);
-	`)
-
-	// Clean up
-	db.Exec("DELETE FROM organization_members")
-	db.Exec("DELETE FROM organizations")
-	db.Exec("DELETE FROM users")
-
-	return db
-}
-
 func createTestUser(t *testing.T, db *gorm.DB, username string) uuid.UUID {
 	userID := uuid.New()
 	err := db.Exec("INSERT INTO users (id, username, password_hash) VALUES (?, ?, ?)",
@@ -89,7 +20,7 @@ func createTestUser(t *testing.T, db *gorm.DB, username string) uuid.UUID {
 }
 
 func TestOrganizationRepository_Create(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -116,7 +47,7 @@ func TestOrganizationRepository_Create(t *testing.T) {
 }
 
 func TestOrganizationRepository_Create_DuplicateSlug(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -145,7 +76,7 @@ func TestOrganizationRepository_Create_DuplicateSlug(t *testing.T) {
 }
 
 func TestOrganizationRepository_GetByID(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -171,7 +102,7 @@ func TestOrganizationRepository_GetByID(t *testing.T) {
 }
 
 func TestOrganizationRepository_GetByID_NotFound(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -188,7 +119,7 @@ func TestOrganizationRepository_GetByID_NotFound(t *testing.T) {
 }
 
 func TestOrganizationRepository_GetBySlug(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -213,7 +144,7 @@ func TestOrganizationRepository_GetBySlug(t *testing.T) {
 }
 
 func TestOrganizationRepository_GetByOwnerID(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -237,7 +168,7 @@ func TestOrganizationRepository_GetByOwnerID(t *testing.T) {
 }
 
 func TestOrganizationRepository_Update(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -266,7 +197,7 @@ func TestOrganizationRepository_Update(t *testing.T) {
 }
 
 func TestOrganizationRepository_Delete(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM organization_members")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
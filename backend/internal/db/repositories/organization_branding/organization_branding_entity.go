@@ -0,0 +1,50 @@
+package organization_branding
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationBranding is how an organization's outbound mail looks and who it comes from
+type OrganizationBranding struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primary_key"`
+	FromName       string    `gorm:"type:varchar(100);not null"`
+	// FromEmail is only used as the sender once its domain is verified
+	FromEmail   string `gorm:"type:varchar(255);not null"`
+	LogoURL     string `gorm:"type:varchar(2048);not null"`
+	AccentColor string `gorm:"type:varchar(7);not null"`
+	// VerificationToken is published in a TXT record to prove the organization owns the domain
+	VerificationToken string `gorm:"type:varchar(64);not null"`
+	OwnershipVerified bool   `gorm:"not null;default:false"`
+	SPFVerified       bool   `gorm:"column:spf_verified;not null;default:false"`
+	DKIMVerified      bool   `gorm:"column:dkim_verified;not null;default:false"`
+	// DomainVerifiedAt is set while all three checks pass
+	DomainVerifiedAt *time.Time `gorm:"type:timestamptz"`
+	DomainCheckedAt  *time.Time `gorm:"type:timestamptz"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (OrganizationBranding) TableName() string {
+	return "organization_branding"
+}
+
+// Default returns the branding of an organization that hasn't configured any
+func Default(orgID uuid.UUID) *OrganizationBranding {
+	return &OrganizationBranding{OrganizationID: orgID}
+}
+
+// Domain returns the domain of the from address, or "" without one
+func (b *OrganizationBranding) Domain() string {
+	at := strings.LastIndex(b.FromEmail, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(b.FromEmail[at+1:])
+}
+
+// DomainVerified reports whether mail may be sent from the from address
+func (b *OrganizationBranding) DomainVerified() bool {
+	return b.FromEmail != "" && b.DomainVerifiedAt != nil
+}
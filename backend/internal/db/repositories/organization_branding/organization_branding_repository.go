@@ -0,0 +1,43 @@
+package organization_branding
+
+//go:generate mockgen -source=organization_branding_repository.go -destination=mocks/organization_branding_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// GetByOrgID returns the organization's branding, or the defaults when it has none
+	GetByOrgID(ctx context.Context, orgID uuid.UUID) (*OrganizationBranding, error)
+	// Save creates or replaces the organization's branding
+	Save(ctx context.Context, branding *OrganizationBranding) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) (*OrganizationBranding, error) {
+	var branding OrganizationBranding
+	err := transaction.DB(ctx, r.db).Where("organization_id = ?", orgID).First(&branding).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Default(orgID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &branding, nil
+}
+
+func (r *repository) Save(ctx context.Context, branding *OrganizationBranding) error {
+	return transaction.DB(ctx, r.db).Save(branding).Error
+}
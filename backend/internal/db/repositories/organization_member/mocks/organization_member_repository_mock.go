@@ -130,6 +130,67 @@ func (mr *MockRepositoryMockRecorder) GetByUserID(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockRepository)(nil).GetByUserID), ctx, userID)
 }
 
+// GetDirectory mocks base method.
+func (m *MockRepository) GetDirectory(ctx context.Context, orgID uuid.UUID, query organization_member.DirectoryQuery) ([]*organization_member.DirectoryEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectory", ctx, orgID, query)
+	ret0, _ := ret[0].([]*organization_member.DirectoryEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDirectory indicates an expected call of GetDirectory.
+func (mr *MockRepositoryMockRecorder) GetDirectory(ctx, orgID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectory", reflect.TypeOf((*MockRepository)(nil).GetDirectory), ctx, orgID, query)
+}
+
+// GetGuestProjectIDs mocks base method.
+func (m *MockRepository) GetGuestProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestProjectIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestProjectIDs indicates an expected call of GetGuestProjectIDs.
+func (mr *MockRepositoryMockRecorder) GetGuestProjectIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestProjectIDs", reflect.TypeOf((*MockRepository)(nil).GetGuestProjectIDs), ctx, userID)
+}
+
+// GetGuestsByOrgID mocks base method.
+func (m *MockRepository) GetGuestsByOrgID(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestsByOrgID", ctx, orgID)
+	ret0, _ := ret[0].([]*organization_member.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestsByOrgID indicates an expected call of GetGuestsByOrgID.
+func (mr *MockRepositoryMockRecorder) GetGuestsByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestsByOrgID", reflect.TypeOf((*MockRepository)(nil).GetGuestsByOrgID), ctx, orgID)
+}
+
+// GetMembersByOrgID mocks base method.
+func (m *MockRepository) GetMembersByOrgID(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersByOrgID", ctx, orgID)
+	ret0, _ := ret[0].([]*organization_member.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembersByOrgID indicates an expected call of GetMembersByOrgID.
+func (mr *MockRepositoryMockRecorder) GetMembersByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByOrgID", reflect.TypeOf((*MockRepository)(nil).GetMembersByOrgID), ctx, orgID)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, member *organization_member.OrganizationMember) error {
 	m.ctrl.T.Helper()
@@ -143,3 +204,18 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, member any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, member)
 }
+
+// UpdateRoles mocks base method.
+func (m *MockRepository) UpdateRoles(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoles", ctx, orgID, userIDs, roleID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRoles indicates an expected call of UpdateRoles.
+func (mr *MockRepositoryMockRecorder) UpdateRoles(ctx, orgID, userIDs, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoles", reflect.TypeOf((*MockRepository)(nil).UpdateRoles), ctx, orgID, userIDs, roleID)
+}
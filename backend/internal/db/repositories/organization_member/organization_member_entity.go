@@ -6,12 +6,15 @@ import (
 	"github.com/google/uuid"
 )
 
+// OrganizationMember is a user's membership of an organization. A guest only reaches the
+// projects they are a project member of, and is left out of the organization's member list.
 type OrganizationMember struct {
 	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
 	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
 	Role           string     `gorm:"type:varchar(50);not null;default:'member'"` // Deprecated: use RoleID
 	RoleID         *uuid.UUID `gorm:"type:uuid"`
+	IsGuest        bool       `gorm:"not null;default:false"`
 	CreatedAt      time.Time  `gorm:"autoCreateTime"`
 }
 
@@ -4,18 +4,63 @@ package organization_member
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
+// DirectorySort is what an organization directory is ordered by
+type DirectorySort string
+
+const (
+	DirectorySortName         DirectorySort = "name" // Display name, else username
+	DirectorySortJoinedAt     DirectorySort = "joined_at"
+	DirectorySortLastActiveAt DirectorySort = "last_active_at" // Never active members last
+)
+
+// DirectoryQuery filters, orders and pages the members of an organization
+type DirectoryQuery struct {
+	// Search matches part of the username or display name, case-insensitively
+	Search string
+	// SearchEmail also matches Search against the email, for callers allowed to see it
+	SearchEmail bool
+	RoleID      *uuid.UUID
+	Sort        DirectorySort
+	Descending  bool
+	Limit       int
+	Offset      int
+}
+
+// DirectoryEntry is a member with the last time they started or refreshed a session, nil
+// when they have no session on record
+type DirectoryEntry struct {
+	OrganizationMember
+	LastActiveAt *time.Time
+}
+
 type Repository interface {
 	Create(ctx context.Context, member *OrganizationMember) error
 	GetByID(ctx context.Context, id uuid.UUID) (*OrganizationMember, error)
 	GetByOrgAndUser(ctx context.Context, orgID, userID uuid.UUID) (*OrganizationMember, error)
 	GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error)
+	// GetMembersByOrgID returns the organization's members without its guests
+	GetMembersByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error)
+	GetGuestsByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error)
+	// GetDirectory returns one page of the organization's members, without its guests, and
+	// how many members match the query in total
+	GetDirectory(ctx context.Context, orgID uuid.UUID, query DirectoryQuery) ([]*DirectoryEntry, int64, error)
+	// GetGuestProjectIDs returns the projects the user was added to in the organizations
+	// they are a guest of
+	GetGuestProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*OrganizationMember, error)
 	Update(ctx context.Context, member *OrganizationMember) error
+	// UpdateRoles gives the users' memberships of the organization the role in one
+	// statement, and returns how many changed
+	UpdateRoles(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (int64, error)
 	Delete(ctx context.Context, orgID, userID uuid.UUID) error
 }
 
@@ -28,12 +73,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, member *OrganizationMember) error {
-	return r.db.WithContext(ctx).Create(member).Error
+	return transaction.DB(ctx, r.db).Create(member).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*OrganizationMember, error) {
 	var member OrganizationMember
-	err := r.db.WithContext(ctx).First(&member, id).Error
+	err := transaction.DB(ctx, r.db).First(&member, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +87,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*OrganizationMe
 
 func (r *repository) GetByOrgAndUser(ctx context.Context, orgID, userID uuid.UUID) (*OrganizationMember, error) {
 	var member OrganizationMember
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("organization_id = ? AND user_id = ?", orgID, userID).
 		First(&member).Error
 	if err != nil {
@@ -53,16 +98,104 @@ func (r *repository) GetByOrgAndUser(ctx context.Context, orgID, userID uuid.UUI
 
 func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error) {
 	var members []*OrganizationMember
-	err := r.db.WithContext(ctx).Where("organization_id = ?", orgID).Find(&members).Error
+	err := transaction.DB(ctx, r.db).Where("organization_id = ?", orgID).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *repository) GetMembersByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error) {
+	var members []*OrganizationMember
+	err := transaction.DB(ctx, r.db).Where("organization_id = ? AND NOT is_guest", orgID).Find(&members).Error
 	if err != nil {
 		return nil, err
 	}
 	return members, nil
 }
 
+func (r *repository) GetGuestsByOrgID(ctx context.Context, orgID uuid.UUID) ([]*OrganizationMember, error) {
+	var members []*OrganizationMember
+	err := transaction.DB(ctx, r.db).Where("organization_id = ? AND is_guest", orgID).Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *repository) GetDirectory(ctx context.Context, orgID uuid.UUID, query DirectoryQuery) ([]*DirectoryEntry, int64, error) {
+	// Sessions rotate their refresh token on every refresh, so the newest token tells when
+	// the user was last active
+	db := transaction.DB(ctx, r.db).
+		Table("organization_members om").
+		Joins("JOIN users u ON u.id = om.user_id").
+		Joins("LEFT JOIN (SELECT user_id, MAX(created_at) AS last_active_at FROM refresh_tokens GROUP BY user_id) s ON s.user_id = om.user_id").
+		Where("om.organization_id = ? AND NOT om.is_guest", orgID)
+
+	if search := strings.TrimSpace(query.Search); search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		if query.SearchEmail {
+			db = db.Where("(u.username ILIKE ? OR u.display_name ILIKE ? OR u.email ILIKE ?)", pattern, pattern, pattern)
+		} else {
+			db = db.Where("(u.username ILIKE ? OR u.display_name ILIKE ?)", pattern, pattern)
+		}
+	}
+	if query.RoleID != nil {
+		db = db.Where("om.role_id = ?", *query.RoleID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := "ASC"
+	if query.Descending {
+		direction = "DESC"
+	}
+	var order string
+	switch query.Sort {
+	case DirectorySortJoinedAt:
+		order = fmt.Sprintf("om.created_at %s", direction)
+	case DirectorySortLastActiveAt:
+		order = fmt.Sprintf("s.last_active_at %s NULLS LAST", direction)
+	default:
+		order = fmt.Sprintf("LOWER(COALESCE(NULLIF(u.display_name, ''), u.username)) %s", direction)
+	}
+
+	var entries []*DirectoryEntry
+	err := db.Select("om.*, s.last_active_at").
+		Order(order).
+		Order("om.id").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+func (r *repository) GetGuestProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var projectIDs []uuid.UUID
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT pm.project_id
+		FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id
+		JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = pm.user_id
+		WHERE pm.user_id = ? AND om.is_guest`, userID).Scan(&projectIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return projectIDs, nil
+}
+
 func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*OrganizationMember, error) {
 	var members []*OrganizationMember
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&members).Error
+	err := transaction.DB(ctx, r.db).Where("user_id = ?", userID).Find(&members).Error
 	if err != nil {
 		return nil, err
 	}
@@ -70,10 +203,21 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Orga
 }
 
 func (r *repository) Update(ctx context.Context, member *OrganizationMember) error {
-	return r.db.WithContext(ctx).Save(member).Error
+	return transaction.DB(ctx, r.db).Save(member).Error
+}
+
+func (r *repository) UpdateRoles(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	result := transaction.DB(ctx, r.db).
+		Model(&OrganizationMember{}).
+		Where("organization_id = ? AND user_id IN ?", orgID, userIDs).
+		Updates(map[string]interface{}{"role_id": roleID, "role": ""})
+	return result.RowsAffected, result.Error
 }
 
 func (r *repository) Delete(ctx context.Context, orgID, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Delete(&OrganizationMember{}, "organization_id = ? AND user_id = ?", orgID, userID).Error
 }
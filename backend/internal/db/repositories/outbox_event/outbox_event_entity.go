@@ -0,0 +1,27 @@
+package outbox_event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a domain event waiting to be delivered to subscribers. The ID is the
+// event ID, so consumers can recognise a redelivery.
+type OutboxEvent struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primary_key"`
+	EventName    string          `gorm:"type:varchar(100);not null"`
+	Payload      json.RawMessage `gorm:"type:jsonb;not null;default:'{}'"`
+	ActorID      *uuid.UUID      `gorm:"type:uuid"`
+	OccurredAt   time.Time       `gorm:"not null"`
+	Attempts     int             `gorm:"type:integer;not null;default:0"`
+	AvailableAt  time.Time       `gorm:"not null"`
+	DispatchedAt *time.Time
+	LastError    *string   `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,104 @@
+package outbox_event
+
+//go:generate mockgen -source=outbox_event_repository.go -destination=mocks/outbox_event_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, event *OutboxEvent) error
+	// ClaimDue leases up to limit pending events that are due and have been attempted fewer
+	// than maxAttempts times. Claimed events count an attempt and stay invisible to other
+	// dispatchers until the lease expires, so a crashed dispatcher's events are retried.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration, maxAttempts int) ([]*OutboxEvent, error)
+	MarkDispatched(ctx context.Context, id uuid.UUID) error
+	// MarkFailed records the delivery error and schedules the next attempt
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt time.Time) error
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, event *OutboxEvent) error {
+	return transaction.DB(ctx, r.db).Create(event).Error
+}
+
+func (r *repository) ClaimDue(ctx context.Context, limit int, lease time.Duration, maxAttempts int) ([]*OutboxEvent, error) {
+	var claimed []*OutboxEvent
+
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// SKIP LOCKED lets several dispatchers claim disjoint batches concurrently
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL AND available_at <= ? AND attempts < ?", now, maxAttempts).
+			Order("available_at ASC, created_at ASC").
+			Limit(limit).
+			Find(&claimed).Error
+		if err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(claimed))
+		leaseUntil := now.Add(lease)
+		for i, e := range claimed {
+			ids[i] = e.ID
+			e.Attempts++
+			e.AvailableAt = leaseUntil
+		}
+
+		return tx.Model(&OutboxEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"attempts":     gorm.Expr("attempts + 1"),
+				"available_at": leaseUntil,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *repository) MarkDispatched(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Model(&OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"dispatched_at": time.Now(),
+			"last_error":    nil,
+		}).Error
+}
+
+func (r *repository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt time.Time) error {
+	return transaction.DB(ctx, r.db).
+		Model(&OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_error":   lastError,
+			"available_at": retryAt,
+		}).Error
+}
+
+func (r *repository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := transaction.DB(ctx, r.db).
+		Where("dispatched_at IS NOT NULL AND dispatched_at < ?", before).
+		Delete(&OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,76 @@
+package outbox_event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
+)
+
+func newTestEvent(availableAt time.Time) *OutboxEvent {
+	return &OutboxEvent{
+		ID:          uuid.New(),
+		EventName:   "card.created",
+		Payload:     []byte(`{"card_id":"00000000-0000-0000-0000-000000000000"}`),
+		OccurredAt:  availableAt,
+		AvailableAt: availableAt,
+	}
+}
+
+func TestOutboxEventRepository_ClaimDue(t *testing.T) {
+	db := testsupport.NewTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	due := newTestEvent(time.Now().Add(-time.Minute))
+	later := newTestEvent(time.Now().Add(time.Hour))
+	require.NoError(t, repo.Create(ctx, due))
+	require.NoError(t, repo.Create(ctx, later))
+
+	claimed, err := repo.ClaimDue(ctx, 10, time.Minute, 5)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, due.ID, claimed[0].ID)
+	assert.Equal(t, 1, claimed[0].Attempts)
+
+	t.Run("leased events are not claimed again", func(t *testing.T) {
+		again, err := repo.ClaimDue(ctx, 10, time.Minute, 5)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("failed events become due at their retry time", func(t *testing.T) {
+		require.NoError(t, repo.MarkFailed(ctx, due.ID, "boom", time.Now().Add(-time.Second)))
+
+		retried, err := repo.ClaimDue(ctx, 10, time.Minute, 5)
+		require.NoError(t, err)
+		require.Len(t, retried, 1)
+		assert.Equal(t, 2, retried[0].Attempts)
+		require.NotNil(t, retried[0].LastError)
+		assert.Equal(t, "boom", *retried[0].LastError)
+	})
+
+	t.Run("events past max attempts are left for inspection", func(t *testing.T) {
+		require.NoError(t, repo.MarkFailed(ctx, due.ID, "boom", time.Now().Add(-time.Second)))
+
+		exhausted, err := repo.ClaimDue(ctx, 10, time.Minute, 2)
+		require.NoError(t, err)
+		assert.Empty(t, exhausted)
+	})
+
+	t.Run("dispatched events are never claimed and are cleaned up", func(t *testing.T) {
+		require.NoError(t, repo.MarkDispatched(ctx, due.ID))
+
+		pending, err := repo.ClaimDue(ctx, 10, time.Minute, 5)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		deleted, err := repo.DeleteDispatchedBefore(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+}
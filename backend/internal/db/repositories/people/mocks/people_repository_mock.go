@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: people_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=people_repository.go -destination=mocks/people_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	people "github.com/thatcatdev/kaimu/backend/internal/db/repositories/people"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOpenCards mocks base method.
+func (m *MockRepository) CountOpenCards(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*people.OpenCardCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenCards", ctx, orgID, at)
+	ret0, _ := ret[0].([]*people.OpenCardCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenCards indicates an expected call of CountOpenCards.
+func (mr *MockRepositoryMockRecorder) CountOpenCards(ctx, orgID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenCards", reflect.TypeOf((*MockRepository)(nil).CountOpenCards), ctx, orgID, at)
+}
+
+// GetProjectAssignments mocks base method.
+func (m *MockRepository) GetProjectAssignments(ctx context.Context, orgID uuid.UUID) ([]*people.ProjectAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectAssignments", ctx, orgID)
+	ret0, _ := ret[0].([]*people.ProjectAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectAssignments indicates an expected call of GetProjectAssignments.
+func (mr *MockRepositoryMockRecorder) GetProjectAssignments(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectAssignments", reflect.TypeOf((*MockRepository)(nil).GetProjectAssignments), ctx, orgID)
+}
+
+// GetSprintCommitments mocks base method.
+func (m *MockRepository) GetSprintCommitments(ctx context.Context, orgID uuid.UUID) ([]*people.SprintCommitment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprintCommitments", ctx, orgID)
+	ret0, _ := ret[0].([]*people.SprintCommitment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprintCommitments indicates an expected call of GetSprintCommitments.
+func (mr *MockRepositoryMockRecorder) GetSprintCommitments(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintCommitments", reflect.TypeOf((*MockRepository)(nil).GetSprintCommitments), ctx, orgID)
+}
@@ -0,0 +1,129 @@
+package people
+
+//go:generate mockgen -source=people_repository.go -destination=mocks/people_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+// ProjectAssignment is a user's project membership in an organization
+type ProjectAssignment struct {
+	UserID      uuid.UUID
+	ProjectID   uuid.UUID
+	ProjectName string
+	// RoleID is nil when the member inherits their organization role
+	RoleID *uuid.UUID
+}
+
+// OpenCardCount is what one user has assigned in one project, counting cards that are
+// neither archived nor in a done column
+type OpenCardCount struct {
+	UserID          uuid.UUID
+	ProjectID       uuid.UUID
+	ProjectName     string
+	OpenCards       int
+	OpenStoryPoints int
+	// OverdueCards are the open cards due before the time the counts were taken at
+	OverdueCards int
+}
+
+// SprintCommitment is what one user has assigned in one active sprint
+type SprintCommitment struct {
+	UserID          uuid.UUID
+	SprintID        uuid.UUID
+	SprintName      string
+	BoardID         uuid.UUID
+	ProjectID       uuid.UUID
+	EndDate         *time.Time
+	CardCount       int
+	StoryPoints     int
+	DoneCardCount   int
+	DoneStoryPoints int
+}
+
+// Repository reads per-user workload of an organization with one grouped query each, for
+// staffing views
+type Repository interface {
+	GetProjectAssignments(ctx context.Context, orgID uuid.UUID) ([]*ProjectAssignment, error)
+	// CountOpenCards groups the organization's open assigned cards by assignee and project
+	CountOpenCards(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*OpenCardCount, error)
+	// GetSprintCommitments groups the assigned cards of the organization's active sprints by
+	// assignee and sprint; archived cards are left out
+	GetSprintCommitments(ctx context.Context, orgID uuid.UUID) ([]*SprintCommitment, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetProjectAssignments(ctx context.Context, orgID uuid.UUID) ([]*ProjectAssignment, error) {
+	var assignments []*ProjectAssignment
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT pm.user_id, p.id AS project_id, p.name AS project_name, pm.role_id
+		FROM project_members pm
+		JOIN projects p ON p.id = pm.project_id
+		WHERE p.organization_id = ?
+		ORDER BY p.name, p.id
+	`, orgID).Scan(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *repository) CountOpenCards(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*OpenCardCount, error) {
+	var counts []*OpenCardCount
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT c.assignee_id AS user_id, p.id AS project_id, p.name AS project_name,
+			COUNT(*) AS open_cards,
+			COALESCE(SUM(c.story_points), 0) AS open_story_points,
+			COUNT(*) FILTER (WHERE c.due_date < ?) AS overdue_cards
+		FROM cards c
+		JOIN boards b ON b.id = c.board_id
+		JOIN projects p ON p.id = b.project_id
+		JOIN board_columns col ON col.id = c.column_id
+		WHERE p.organization_id = ? AND c.assignee_id IS NOT NULL
+			AND c.archived_at IS NULL AND NOT col.is_done
+		GROUP BY c.assignee_id, p.id, p.name
+		ORDER BY p.name, p.id
+	`, at, orgID).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *repository) GetSprintCommitments(ctx context.Context, orgID uuid.UUID) ([]*SprintCommitment, error) {
+	var commitments []*SprintCommitment
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT c.assignee_id AS user_id, s.id AS sprint_id, s.name AS sprint_name,
+			s.board_id, b.project_id, s.end_date,
+			COUNT(*) AS card_count,
+			COALESCE(SUM(c.story_points), 0) AS story_points,
+			COUNT(*) FILTER (WHERE col.is_done) AS done_card_count,
+			COALESCE(SUM(c.story_points) FILTER (WHERE col.is_done), 0) AS done_story_points
+		FROM card_sprints cs
+		JOIN sprints s ON s.id = cs.sprint_id
+		JOIN cards c ON c.id = cs.card_id
+		JOIN boards b ON b.id = s.board_id
+		JOIN projects p ON p.id = b.project_id
+		JOIN board_columns col ON col.id = c.column_id
+		WHERE p.organization_id = ? AND s.status = 'active'
+			AND c.assignee_id IS NOT NULL AND c.archived_at IS NULL
+		GROUP BY c.assignee_id, s.id, s.name, s.board_id, b.project_id, s.end_date
+		ORDER BY s.end_date NULLS LAST, s.name, s.id
+	`, orgID).Scan(&commitments).Error
+	if err != nil {
+		return nil, err
+	}
+	return commitments, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -27,7 +28,7 @@ func NewRepository(db *gorm.DB) Repository {
 
 func (r *repository) GetAll(ctx context.Context) ([]*Permission, error) {
 	var permissions []*Permission
-	err := r.db.WithContext(ctx).Find(&permissions).Error
+	err := transaction.DB(ctx, r.db).Find(&permissions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func (r *repository) GetAll(ctx context.Context) ([]*Permission, error) {
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Permission, error) {
 	var permission Permission
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&permission).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&permission).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +46,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Permission, er
 
 func (r *repository) GetByCode(ctx context.Context, code string) (*Permission, error) {
 	var permission Permission
-	err := r.db.WithContext(ctx).Where("code = ?", code).First(&permission).Error
+	err := transaction.DB(ctx, r.db).Where("code = ?", code).First(&permission).Error
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +55,7 @@ func (r *repository) GetByCode(ctx context.Context, code string) (*Permission, e
 
 func (r *repository) GetByCodes(ctx context.Context, codes []string) ([]*Permission, error) {
 	var permissions []*Permission
-	err := r.db.WithContext(ctx).Where("code IN ?", codes).Find(&permissions).Error
+	err := transaction.DB(ctx, r.db).Where("code IN ?", codes).Find(&permissions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ func (r *repository) GetByCodes(ctx context.Context, codes []string) ([]*Permiss
 
 func (r *repository) GetByResourceType(ctx context.Context, resourceType string) ([]*Permission, error) {
 	var permissions []*Permission
-	err := r.db.WithContext(ctx).Where("resource_type = ?", resourceType).Find(&permissions).Error
+	err := transaction.DB(ctx, r.db).Where("resource_type = ?", resourceType).Find(&permissions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -28,12 +29,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, project *Project) error {
-	return r.db.WithContext(ctx).Create(project).Error
+	return transaction.DB(ctx, r.db).Create(project).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Project, error) {
 	var project Project
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&project).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&project).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +43,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Project, error
 
 func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Project, error) {
 	var projects []*Project
-	err := r.db.WithContext(ctx).Where("organization_id = ?", orgID).Find(&projects).Error
+	err := transaction.DB(ctx, r.db).Where("organization_id = ?", orgID).Find(&projects).Error
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +52,7 @@ func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Projec
 
 func (r *repository) GetByKey(ctx context.Context, orgID uuid.UUID, key string) (*Project, error) {
 	var project Project
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("organization_id = ? AND key = ?", orgID, key).
 		First(&project).Error
 	if err != nil {
@@ -62,7 +63,7 @@ func (r *repository) GetByKey(ctx context.Context, orgID uuid.UUID, key string)
 
 func (r *repository) GetAll(ctx context.Context) ([]*Project, error) {
 	var projects []*Project
-	err := r.db.WithContext(ctx).Find(&projects).Error
+	err := transaction.DB(ctx, r.db).Find(&projects).Error
 	if err != nil {
 		return nil, err
 	}
@@ -70,9 +71,9 @@ func (r *repository) GetAll(ctx context.Context) ([]*Project, error) {
 }
 
 func (r *repository) Update(ctx context.Context, project *Project) error {
-	return r.db.WithContext(ctx).Save(project).Error
+	return transaction.DB(ctx, r.db).Save(project).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Project{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Project{}, "id = ?", id).Error
 }
@@ -2,86 +2,15 @@ package project
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/postgres"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping test: could not connect to test database: %v", err)
-	}
-
-	// Setup schema
-	db.Exec(`
-		CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-		CREATE TABLE IF NOT EXISTS users (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			username VARCHAR(255) NOT NULL UNIQUE,
-			password_hash VARCHAR(255) NOT NULL,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		);
-		CREATE TABLE IF NOT EXISTS organizations (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			name VARCHAR(255) NOT NULL,
-			slug VARCHAR(255) NOT NULL UNIQUE,
-			description TEXT,
-			owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		);
-		CREATE TABLE IF NOT EXISTS projects (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
-			name VARCHAR(255) NOT NULL,
-			key VARCHAR(10) NOT NULL,
-			description TEXT,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			UNIQUE(organization_id, key)
-		);
-	`)
-
-	// Clean up
-	db.Exec("DELETE FROM projects")
-	db.Exec("DELETE FROM organizations")
-	db.Exec("DELETE FROM users")
-
-	return db
-}
-
 func createTestUser(t *testing.T, db *gorm.DB, username string) uuid.UUID {
 	userID := uuid.New()
 	err := db.Exec("INSERT INTO users (id, username, password_hash) VALUES (?, ?, ?)",
@@ -99,7 +28,7 @@ func createTestOrganization(t *testing.T, db *gorm.DB, name, slug string, ownerI
 }
 
 func TestProjectRepository_Create(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -127,7 +56,7 @@ func TestProjectRepository_Create(t *testing.T) {
 }
 
 func TestProjectRepository_Create_DuplicateKey(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -157,7 +86,7 @@ func TestProjectRepository_Create_DuplicateKey(t *testing.T) {
 }
 
 func TestProjectRepository_Create_SameKeyDifferentOrg(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -188,7 +117,7 @@ func TestProjectRepository_Create_SameKeyDifferentOrg(t *testing.T) {
 }
 
 func TestProjectRepository_GetByID(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -215,7 +144,7 @@ func TestProjectRepository_GetByID(t *testing.T) {
 }
 
 func TestProjectRepository_GetByID_NotFound(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -232,7 +161,7 @@ func TestProjectRepository_GetByID_NotFound(t *testing.T) {
 }
 
 func TestProjectRepository_GetByOrgID(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -257,7 +186,7 @@ func TestProjectRepository_GetByOrgID(t *testing.T) {
 }
 
 func TestProjectRepository_GetByKey(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -283,7 +212,7 @@ func TestProjectRepository_GetByKey(t *testing.T) {
 }
 
 func TestProjectRepository_GetByKey_NotFound(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -302,7 +231,7 @@ func TestProjectRepository_GetByKey_NotFound(t *testing.T) {
 }
 
 func TestProjectRepository_Update(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -332,7 +261,7 @@ func TestProjectRepository_Update(t *testing.T) {
 }
 
 func TestProjectRepository_Delete(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM projects")
 	defer db.Exec("DELETE FROM organizations")
 	defer db.Exec("DELETE FROM users")
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: project_calendar_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=project_calendar_repository.go -destination=mocks/project_calendar_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	project_calendar "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHoliday mocks base method.
+func (m *MockRepository) CreateHoliday(ctx context.Context, holiday *project_calendar.ProjectHoliday) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHoliday", ctx, holiday)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHoliday indicates an expected call of CreateHoliday.
+func (mr *MockRepositoryMockRecorder) CreateHoliday(ctx, holiday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHoliday", reflect.TypeOf((*MockRepository)(nil).CreateHoliday), ctx, holiday)
+}
+
+// DeleteHoliday mocks base method.
+func (m *MockRepository) DeleteHoliday(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHoliday", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHoliday indicates an expected call of DeleteHoliday.
+func (mr *MockRepositoryMockRecorder) DeleteHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHoliday", reflect.TypeOf((*MockRepository)(nil).DeleteHoliday), ctx, id)
+}
+
+// GetByProjectID mocks base method.
+func (m *MockRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*project_calendar.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProjectID", ctx, projectID)
+	ret0, _ := ret[0].(*project_calendar.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProjectID indicates an expected call of GetByProjectID.
+func (mr *MockRepositoryMockRecorder) GetByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectID", reflect.TypeOf((*MockRepository)(nil).GetByProjectID), ctx, projectID)
+}
+
+// GetHolidayByID mocks base method.
+func (m *MockRepository) GetHolidayByID(ctx context.Context, id uuid.UUID) (*project_calendar.ProjectHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolidayByID", ctx, id)
+	ret0, _ := ret[0].(*project_calendar.ProjectHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolidayByID indicates an expected call of GetHolidayByID.
+func (mr *MockRepositoryMockRecorder) GetHolidayByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolidayByID", reflect.TypeOf((*MockRepository)(nil).GetHolidayByID), ctx, id)
+}
+
+// GetHolidays mocks base method.
+func (m *MockRepository) GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*project_calendar.ProjectHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolidays", ctx, projectID, from)
+	ret0, _ := ret[0].([]*project_calendar.ProjectHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolidays indicates an expected call of GetHolidays.
+func (mr *MockRepositoryMockRecorder) GetHolidays(ctx, projectID, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolidays", reflect.TypeOf((*MockRepository)(nil).GetHolidays), ctx, projectID, from)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, calendar *project_calendar.ProjectCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, calendar any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, calendar)
+}
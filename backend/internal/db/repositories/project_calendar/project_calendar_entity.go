@@ -0,0 +1,45 @@
+package project_calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWorkingDays is Monday to Friday
+const DefaultWorkingDays = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+
+// DefaultPointsPerDay is the pace assumed for projects that haven't set one
+const DefaultPointsPerDay = 1.0
+
+// ProjectCalendar holds the days a project's team works and how many story points they
+// complete per working day
+type ProjectCalendar struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	// WorkingDays has bit n set when time.Weekday n is a working day
+	WorkingDays  int16     `gorm:"type:smallint;not null;default:62"`
+	PointsPerDay float64   `gorm:"type:double precision;not null;default:1"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ProjectCalendar) TableName() string {
+	return "project_calendars"
+}
+
+// IsWorkingWeekday reports whether the team works on the weekday
+func (c *ProjectCalendar) IsWorkingWeekday(day time.Weekday) bool {
+	return c.WorkingDays&(1<<day) != 0
+}
+
+// ProjectHoliday is a day off for a project, stored as a calendar date at UTC midnight
+type ProjectHoliday struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProjectID uuid.UUID `gorm:"type:uuid;not null"`
+	Date      time.Time `gorm:"type:date;not null"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (ProjectHoliday) TableName() string {
+	return "project_holidays"
+}
@@ -0,0 +1,74 @@
+package project_calendar
+
+//go:generate mockgen -source=project_calendar_repository.go -destination=mocks/project_calendar_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// GetByProjectID returns the project's calendar, or gorm.ErrRecordNotFound when the
+	// project uses the defaults
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) (*ProjectCalendar, error)
+	Save(ctx context.Context, calendar *ProjectCalendar) error
+	CreateHoliday(ctx context.Context, holiday *ProjectHoliday) error
+	GetHolidayByID(ctx context.Context, id uuid.UUID) (*ProjectHoliday, error)
+	// GetHolidays returns the project's holidays on or after from, by date
+	GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*ProjectHoliday, error)
+	DeleteHoliday(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (*ProjectCalendar, error) {
+	var calendar ProjectCalendar
+	result := transaction.DB(ctx, r.db).Where("project_id = ?", projectID).First(&calendar)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &calendar, nil
+}
+
+func (r *repository) Save(ctx context.Context, calendar *ProjectCalendar) error {
+	return transaction.DB(ctx, r.db).Save(calendar).Error
+}
+
+func (r *repository) CreateHoliday(ctx context.Context, holiday *ProjectHoliday) error {
+	return transaction.DB(ctx, r.db).Create(holiday).Error
+}
+
+func (r *repository) GetHolidayByID(ctx context.Context, id uuid.UUID) (*ProjectHoliday, error) {
+	var holiday ProjectHoliday
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&holiday)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &holiday, nil
+}
+
+func (r *repository) GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*ProjectHoliday, error) {
+	var holidays []*ProjectHoliday
+	result := transaction.DB(ctx, r.db).
+		Where("project_id = ? AND date >= ?", projectID, from.Format(time.DateOnly)).
+		Order("date ASC").
+		Find(&holidays)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return holidays, nil
+}
+
+func (r *repository) DeleteHoliday(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&ProjectHoliday{}, "id = ?", id).Error
+}
@@ -0,0 +1,40 @@
+package project_export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	// StatusFailed exports ran out of attempts
+	StatusFailed Status = "failed"
+)
+
+// ProjectExport is a requested JSON archive of a project and the state of building it
+type ProjectExport struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProjectID   uuid.UUID  `gorm:"type:uuid;not null"`
+	RequestedBy *uuid.UUID `gorm:"type:uuid"`
+	Status      Status     `gorm:"type:project_export_status;not null;default:'pending'"`
+	Attempts    int        `gorm:"type:integer;not null;default:0"`
+	// LeaseUntil is when a running export counts as abandoned and may be claimed again
+	LeaseUntil *time.Time `gorm:"type:timestamptz"`
+	// StorageKey, SizeBytes and CardCount describe the archive of a completed export
+	StorageKey  string     `gorm:"type:varchar(500);not null;default:''"`
+	SizeBytes   int64      `gorm:"type:bigint;not null;default:0"`
+	CardCount   int        `gorm:"type:integer;not null;default:0"`
+	Error       *string    `gorm:"type:text"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	StartedAt   *time.Time `gorm:"type:timestamptz"`
+	CompletedAt *time.Time `gorm:"type:timestamptz"`
+}
+
+func (ProjectExport) TableName() string {
+	return "project_exports"
+}
@@ -0,0 +1,136 @@
+package project_export
+
+//go:generate mockgen -source=project_export_repository.go -destination=mocks/project_export_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, export *ProjectExport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ProjectExport, error)
+	// GetByProjectID returns the project's exports, newest first
+	GetByProjectID(ctx context.Context, projectID uuid.UUID, limit int) ([]*ProjectExport, error)
+	// GetQueuedByProjectID returns the project's pending or running export, or
+	// gorm.ErrRecordNotFound
+	GetQueuedByProjectID(ctx context.Context, projectID uuid.UUID) (*ProjectExport, error)
+	// ClaimNext marks the oldest pending export, or a running one whose lease has run out, as
+	// running until lease from now and counts the attempt. It returns nil when none is due.
+	ClaimNext(ctx context.Context, lease time.Duration) (*ProjectExport, error)
+	Update(ctx context.Context, export *ProjectExport) error
+	// GetFinishedBefore returns up to limit completed or failed exports created before t
+	GetFinishedBefore(ctx context.Context, t time.Time, limit int) ([]*ProjectExport, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, export *ProjectExport) error {
+	return transaction.DB(ctx, r.db).Create(export).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*ProjectExport, error) {
+	var export ProjectExport
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID, limit int) ([]*ProjectExport, error) {
+	var exports []*ProjectExport
+	err := transaction.DB(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *repository) GetQueuedByProjectID(ctx context.Context, projectID uuid.UUID) (*ProjectExport, error) {
+	var export ProjectExport
+	err := transaction.DB(ctx, r.db).
+		Where("project_id = ? AND status IN ?", projectID, []Status{StatusPending, StatusRunning}).
+		Order("created_at ASC").
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *repository) ClaimNext(ctx context.Context, lease time.Duration) (*ProjectExport, error) {
+	var claimed []*ProjectExport
+
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// SKIP LOCKED lets several workers claim different exports concurrently
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND lease_until <= ?)", StatusPending, StatusRunning, now).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&claimed).Error
+		if err != nil || len(claimed) == 0 {
+			return err
+		}
+
+		export := claimed[0]
+		leaseUntil := now.Add(lease)
+		export.Status = StatusRunning
+		export.Attempts++
+		export.LeaseUntil = &leaseUntil
+		if export.StartedAt == nil {
+			export.StartedAt = &now
+		}
+		return tx.Model(&ProjectExport{}).
+			Where("id = ?", export.ID).
+			Updates(map[string]interface{}{
+				"status":      StatusRunning,
+				"attempts":    gorm.Expr("attempts + 1"),
+				"lease_until": leaseUntil,
+				"started_at":  export.StartedAt,
+			}).Error
+	})
+	if err != nil || len(claimed) == 0 {
+		return nil, err
+	}
+	return claimed[0], nil
+}
+
+func (r *repository) Update(ctx context.Context, export *ProjectExport) error {
+	return transaction.DB(ctx, r.db).Save(export).Error
+}
+
+func (r *repository) GetFinishedBefore(ctx context.Context, t time.Time, limit int) ([]*ProjectExport, error) {
+	var exports []*ProjectExport
+	err := transaction.DB(ctx, r.db).
+		Where("status IN ? AND created_at < ?", []Status{StatusCompleted, StatusFailed}, t).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&ProjectExport{}, "id = ?", id).Error
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: project_health_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=project_health_repository.go -destination=mocks/project_health_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	project_health "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_health"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetIndicators mocks base method.
+func (m *MockRepository) GetIndicators(ctx context.Context, projectID uuid.UUID, now time.Time) (*project_health.Indicators, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndicators", ctx, projectID, now)
+	ret0, _ := ret[0].(*project_health.Indicators)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndicators indicates an expected call of GetIndicators.
+func (mr *MockRepositoryMockRecorder) GetIndicators(ctx, projectID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndicators", reflect.TypeOf((*MockRepository)(nil).GetIndicators), ctx, projectID, now)
+}
@@ -0,0 +1,108 @@
+package project_health
+
+//go:generate mockgen -source=project_health_repository.go -destination=mocks/project_health_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+// Indicators are the raw counts a project's health is judged on. Open cards are the
+// cards outside done columns.
+type Indicators struct {
+	OpenCards int
+	// OverdueCards are open cards whose due date has passed
+	OverdueCards int
+	// SprintCards are the cards in the project's active sprints
+	SprintCards int
+	// AddedSprintCards are the sprint cards added after their sprint started
+	AddedSprintCards int
+	// BlockedCards are open cards with at least one open card blocking them
+	BlockedCards int
+	// BlockedSeconds is the mean time the blocked cards have been blocked, counted from
+	// their oldest open blocker
+	BlockedSeconds float64
+}
+
+type Repository interface {
+	// GetIndicators computes the project's indicators as of now
+	GetIndicators(ctx context.Context, projectID uuid.UUID, now time.Time) (*Indicators, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetIndicators(ctx context.Context, projectID uuid.UUID, now time.Time) (*Indicators, error) {
+	db := transaction.DB(ctx, r.db)
+	var indicators Indicators
+
+	err := db.Raw(`
+		SELECT
+			COUNT(*) AS open_cards,
+			COUNT(*) FILTER (WHERE c.due_date < ?) AS overdue_cards
+		FROM cards c
+		JOIN boards b ON b.id = c.board_id
+		JOIN board_columns col ON col.id = c.column_id
+		WHERE b.project_id = ? AND NOT col.is_done
+	`, now, projectID).Scan(&indicators).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var churn struct {
+		SprintCards      int
+		AddedSprintCards int
+	}
+	err = db.Raw(`
+		SELECT
+			COUNT(*) AS sprint_cards,
+			COUNT(*) FILTER (WHERE cs.added_at > s.start_date) AS added_sprint_cards
+		FROM card_sprints cs
+		JOIN sprints s ON s.id = cs.sprint_id
+		JOIN boards b ON b.id = s.board_id
+		WHERE b.project_id = ? AND s.status = 'active'
+	`, projectID).Scan(&churn).Error
+	if err != nil {
+		return nil, err
+	}
+	indicators.SprintCards = churn.SprintCards
+	indicators.AddedSprintCards = churn.AddedSprintCards
+
+	var blocked struct {
+		BlockedCards   int
+		BlockedSeconds float64
+	}
+	err = db.Raw(`
+		SELECT
+			COUNT(*) AS blocked_cards,
+			COALESCE(AVG(EXTRACT(EPOCH FROM (? - blocked_since))), 0) AS blocked_seconds
+		FROM (
+			SELECT d.to_card_id, MIN(d.created_at) AS blocked_since
+			FROM card_dependencies d
+			JOIN cards blocked ON blocked.id = d.to_card_id
+			JOIN board_columns blocked_col ON blocked_col.id = blocked.column_id
+			JOIN cards blocker ON blocker.id = d.from_card_id
+			JOIN board_columns blocker_col ON blocker_col.id = blocker.column_id
+			JOIN boards b ON b.id = blocked.board_id
+			WHERE b.project_id = ? AND d.kind = 'blocks'
+				AND NOT blocked_col.is_done AND NOT blocker_col.is_done
+			GROUP BY d.to_card_id
+		) blocked
+	`, now, projectID).Scan(&blocked).Error
+	if err != nil {
+		return nil, err
+	}
+	indicators.BlockedCards = blocked.BlockedCards
+	indicators.BlockedSeconds = blocked.BlockedSeconds
+
+	return &indicators, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -28,12 +29,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, pm *ProjectMember) error {
-	return r.db.WithContext(ctx).Create(pm).Error
+	return transaction.DB(ctx, r.db).Create(pm).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*ProjectMember, error) {
 	var pm ProjectMember
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&pm).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&pm).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +43,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*ProjectMember,
 
 func (r *repository) GetByProjectAndUser(ctx context.Context, projectID, userID uuid.UUID) (*ProjectMember, error) {
 	var pm ProjectMember
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("project_id = ? AND user_id = ?", projectID, userID).
 		First(&pm).Error
 	if err != nil {
@@ -53,7 +54,7 @@ func (r *repository) GetByProjectAndUser(ctx context.Context, projectID, userID
 
 func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*ProjectMember, error) {
 	var pms []*ProjectMember
-	err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Find(&pms).Error
+	err := transaction.DB(ctx, r.db).Where("project_id = ?", projectID).Find(&pms).Error
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +63,7 @@ func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([
 
 func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*ProjectMember, error) {
 	var pms []*ProjectMember
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&pms).Error
+	err := transaction.DB(ctx, r.db).Where("user_id = ?", userID).Find(&pms).Error
 	if err != nil {
 		return nil, err
 	}
@@ -70,10 +71,10 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Proj
 }
 
 func (r *repository) Update(ctx context.Context, pm *ProjectMember) error {
-	return r.db.WithContext(ctx).Save(pm).Error
+	return transaction.DB(ctx, r.db).Save(pm).Error
 }
 
 func (r *repository) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Delete(&ProjectMember{}, "project_id = ? AND user_id = ?", projectID, userID).Error
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -29,12 +30,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, token *RefreshToken) error {
-	return r.db.WithContext(ctx).Create(token).Error
+	return transaction.DB(ctx, r.db).Create(token).Error
 }
 
 func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
 	var token RefreshToken
-	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	err := transaction.DB(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +44,7 @@ func (r *repository) GetByTokenHash(ctx context.Context, tokenHash string) (*Ref
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*RefreshToken, error) {
 	var token RefreshToken
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&token).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&token).Error
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +53,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*RefreshToken,
 
 func (r *repository) Revoke(ctx context.Context, id uuid.UUID, replacedBy *uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&RefreshToken{}).
+	return transaction.DB(ctx, r.db).Model(&RefreshToken{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"revoked_at":  now,
@@ -62,13 +63,13 @@ func (r *repository) Revoke(ctx context.Context, id uuid.UUID, replacedBy *uuid.
 
 func (r *repository) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&RefreshToken{}).
+	return transaction.DB(ctx, r.db).Model(&RefreshToken{}).
 		Where("user_id = ? AND revoked_at IS NULL", userID).
 		Update("revoked_at", now).Error
 }
 
 func (r *repository) DeleteExpired(ctx context.Context) (int64, error) {
-	result := r.db.WithContext(ctx).
+	result := transaction.DB(ctx, r.db).
 		Where("expires_at < ?", time.Now()).
 		Delete(&RefreshToken{})
 	return result.RowsAffected, result.Error
@@ -76,7 +77,7 @@ func (r *repository) DeleteExpired(ctx context.Context) (int64, error) {
 
 func (r *repository) GetActiveTokensForUser(ctx context.Context, userID uuid.UUID) ([]*RefreshToken, error) {
 	var tokens []*RefreshToken
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
 		Order("created_at DESC").
 		Find(&tokens).Error
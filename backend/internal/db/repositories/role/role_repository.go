@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -28,12 +29,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, role *Role) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return transaction.DB(ctx, r.db).Create(role).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Role, error) {
 	var role Role
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&role).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&role).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +43,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Role, error) {
 
 func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Role, error) {
 	var roles []*Role
-	err := r.db.WithContext(ctx).Where("organization_id = ?", orgID).Find(&roles).Error
+	err := transaction.DB(ctx, r.db).Where("organization_id = ?", orgID).Find(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +52,7 @@ func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Role,
 
 func (r *repository) GetSystemRoles(ctx context.Context) ([]*Role, error) {
 	var roles []*Role
-	err := r.db.WithContext(ctx).Where("is_system = ?", true).Find(&roles).Error
+	err := transaction.DB(ctx, r.db).Where("is_system = ?", true).Find(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +62,7 @@ func (r *repository) GetSystemRoles(ctx context.Context) ([]*Role, error) {
 // GetAllForOrg returns system roles + organization custom roles
 func (r *repository) GetAllForOrg(ctx context.Context, orgID uuid.UUID) ([]*Role, error) {
 	var roles []*Role
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("is_system = ? OR organization_id = ?", true, orgID).
 		Order("is_system DESC, name ASC").
 		Find(&roles).Error
@@ -72,9 +73,9 @@ func (r *repository) GetAllForOrg(ctx context.Context, orgID uuid.UUID) ([]*Role
 }
 
 func (r *repository) Update(ctx context.Context, role *Role) error {
-	return r.db.WithContext(ctx).Save(role).Error
+	return transaction.DB(ctx, r.db).Save(role).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Role{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Role{}, "id = ?", id).Error
 }
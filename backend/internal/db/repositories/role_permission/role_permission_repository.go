@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +31,7 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, rp *RolePermission) error {
-	return r.db.WithContext(ctx).Create(rp).Error
+	return transaction.DB(ctx, r.db).Create(rp).Error
 }
 
 func (r *repository) CreateBatch(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
@@ -45,12 +46,12 @@ func (r *repository) CreateBatch(ctx context.Context, roleID uuid.UUID, permissi
 			PermissionID: permID,
 		}
 	}
-	return r.db.WithContext(ctx).Create(&rolePermissions).Error
+	return transaction.DB(ctx, r.db).Create(&rolePermissions).Error
 }
 
 func (r *repository) GetByRoleID(ctx context.Context, roleID uuid.UUID) ([]*RolePermission, error) {
 	var rps []*RolePermission
-	err := r.db.WithContext(ctx).Where("role_id = ?", roleID).Find(&rps).Error
+	err := transaction.DB(ctx, r.db).Where("role_id = ?", roleID).Find(&rps).Error
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +60,7 @@ func (r *repository) GetByRoleID(ctx context.Context, roleID uuid.UUID) ([]*Role
 
 func (r *repository) GetPermissionsByRoleID(ctx context.Context, roleID uuid.UUID) ([]*permission.Permission, error) {
 	var permissions []*permission.Permission
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Table("permissions").
 		Joins("INNER JOIN role_permissions ON permissions.id = role_permissions.permission_id").
 		Where("role_permissions.role_id = ?", roleID).
@@ -72,7 +73,7 @@ func (r *repository) GetPermissionsByRoleID(ctx context.Context, roleID uuid.UUI
 
 func (r *repository) GetPermissionCodesByRoleID(ctx context.Context, roleID uuid.UUID) ([]string, error) {
 	var codes []string
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Table("permissions").
 		Select("permissions.code").
 		Joins("INNER JOIN role_permissions ON permissions.id = role_permissions.permission_id").
@@ -85,16 +86,16 @@ func (r *repository) GetPermissionCodesByRoleID(ctx context.Context, roleID uuid
 }
 
 func (r *repository) DeleteByRoleID(ctx context.Context, roleID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&RolePermission{}, "role_id = ?", roleID).Error
+	return transaction.DB(ctx, r.db).Delete(&RolePermission{}, "role_id = ?", roleID).Error
 }
 
 func (r *repository) Delete(ctx context.Context, roleID, permissionID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&RolePermission{}, "role_id = ? AND permission_id = ?", roleID, permissionID).Error
+	return transaction.DB(ctx, r.db).Delete(&RolePermission{}, "role_id = ? AND permission_id = ?", roleID, permissionID).Error
 }
 
 // ReplaceForRole deletes all existing permissions for a role and creates new ones
 func (r *repository) ReplaceForRole(ctx context.Context, roleID uuid.UUID, permissionIDs []uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Delete existing
 		if err := tx.Delete(&RolePermission{}, "role_id = ?", roleID).Error; err != nil {
 			return err
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_query_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=search_query_repository.go -destination=mocks/search_query_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	search_query "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeByOrgID mocks base method.
+func (m *MockRepository) AnonymizeByOrgID(ctx context.Context, orgID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeByOrgID", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymizeByOrgID indicates an expected call of AnonymizeByOrgID.
+func (mr *MockRepositoryMockRecorder) AnonymizeByOrgID(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeByOrgID", reflect.TypeOf((*MockRepository)(nil).AnonymizeByOrgID), ctx, orgID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, query *search_query.SearchQuery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, query)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, query)
+}
+
+// GetPopular mocks base method.
+func (m *MockRepository) GetPopular(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*search_query.QueryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopular", ctx, orgID, since, limit)
+	ret0, _ := ret[0].([]*search_query.QueryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopular indicates an expected call of GetPopular.
+func (mr *MockRepositoryMockRecorder) GetPopular(ctx, orgID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopular", reflect.TypeOf((*MockRepository)(nil).GetPopular), ctx, orgID, since, limit)
+}
+
+// GetTotals mocks base method.
+func (m *MockRepository) GetTotals(ctx context.Context, orgID uuid.UUID, since time.Time) (*search_query.Totals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", ctx, orgID, since)
+	ret0, _ := ret[0].(*search_query.Totals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockRepositoryMockRecorder) GetTotals(ctx, orgID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockRepository)(nil).GetTotals), ctx, orgID, since)
+}
+
+// GetZeroResult mocks base method.
+func (m *MockRepository) GetZeroResult(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*search_query.QueryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZeroResult", ctx, orgID, since, limit)
+	ret0, _ := ret[0].([]*search_query.QueryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZeroResult indicates an expected call of GetZeroResult.
+func (mr *MockRepositoryMockRecorder) GetZeroResult(ctx, orgID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZeroResult", reflect.TypeOf((*MockRepository)(nil).GetZeroResult), ctx, orgID, since, limit)
+}
+
+// UpdateQuery mocks base method.
+func (m *MockRepository) UpdateQuery(ctx context.Context, id uuid.UUID, query string, resultCount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuery", ctx, id, query, resultCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuery indicates an expected call of UpdateQuery.
+func (mr *MockRepositoryMockRecorder) UpdateQuery(ctx, id, query, resultCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuery", reflect.TypeOf((*MockRepository)(nil).UpdateQuery), ctx, id, query, resultCount)
+}
@@ -0,0 +1,42 @@
+package search_query
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchQuery is a search run in an organization
+type SearchQuery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	// UserID is nil when the organization anonymizes its search analytics
+	UserID *uuid.UUID `gorm:"type:uuid"`
+	// Query is normalized: lowercased, with whitespace collapsed
+	Query string `gorm:"type:varchar(255);not null"`
+	// ResultCount is how many results were found in the organization
+	ResultCount int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (SearchQuery) TableName() string {
+	return "search_queries"
+}
+
+// QueryStats is how often a query was searched in an organization
+type QueryStats struct {
+	Query     string
+	Searches  int
+	Searchers int
+	// AvgResults is the mean result count of the query's searches
+	AvgResults     float64
+	LastSearchedAt time.Time
+}
+
+// Totals sums an organization's searches
+type Totals struct {
+	Searches           int
+	ZeroResultSearches int
+	// Searchers counts distinct users; anonymized searches aren't counted
+	Searchers int
+}
@@ -0,0 +1,92 @@
+package search_query
+
+//go:generate mockgen -source=search_query_repository.go -destination=mocks/search_query_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, query *SearchQuery) error
+	// UpdateQuery replaces the query and result count of a recorded search
+	UpdateQuery(ctx context.Context, id uuid.UUID, query string, resultCount int) error
+	// GetPopular returns the organization's most searched queries since a time, most searched first
+	GetPopular(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*QueryStats, error)
+	// GetZeroResult returns the organization's most searched queries since a time that found
+	// nothing, most searched first
+	GetZeroResult(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*QueryStats, error)
+	GetTotals(ctx context.Context, orgID uuid.UUID, since time.Time) (*Totals, error)
+	// AnonymizeByOrgID removes the users from the organization's recorded searches
+	AnonymizeByOrgID(ctx context.Context, orgID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, query *SearchQuery) error {
+	return transaction.DB(ctx, r.db).Create(query).Error
+}
+
+func (r *repository) UpdateQuery(ctx context.Context, id uuid.UUID, query string, resultCount int) error {
+	return transaction.DB(ctx, r.db).
+		Model(&SearchQuery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"query": query, "result_count": resultCount}).Error
+}
+
+func (r *repository) GetPopular(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*QueryStats, error) {
+	return r.getStats(ctx, orgID, since, limit, false)
+}
+
+func (r *repository) GetZeroResult(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]*QueryStats, error) {
+	return r.getStats(ctx, orgID, since, limit, true)
+}
+
+func (r *repository) getStats(ctx context.Context, orgID uuid.UUID, since time.Time, limit int, zeroResult bool) ([]*QueryStats, error) {
+	var stats []*QueryStats
+	q := transaction.DB(ctx, r.db).
+		Model(&SearchQuery{}).
+		Select("query, COUNT(*) AS searches, COUNT(DISTINCT user_id) AS searchers, AVG(result_count) AS avg_results, MAX(created_at) AS last_searched_at").
+		Where("organization_id = ? AND created_at >= ?", orgID, since)
+	if zeroResult {
+		q = q.Where("result_count = 0")
+	}
+	err := q.Group("query").
+		Order("searches DESC, last_searched_at DESC").
+		Limit(limit).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *repository) GetTotals(ctx context.Context, orgID uuid.UUID, since time.Time) (*Totals, error) {
+	var totals Totals
+	err := transaction.DB(ctx, r.db).
+		Model(&SearchQuery{}).
+		Select("COUNT(*) AS searches, COUNT(*) FILTER (WHERE result_count = 0) AS zero_result_searches, COUNT(DISTINCT user_id) AS searchers").
+		Where("organization_id = ? AND created_at >= ?", orgID, since).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+func (r *repository) AnonymizeByOrgID(ctx context.Context, orgID uuid.UUID) error {
+	return transaction.DB(ctx, r.db).
+		Model(&SearchQuery{}).
+		Where("organization_id = ? AND user_id IS NOT NULL", orgID).
+		Update("user_id", nil).Error
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_stop_word_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=search_stop_word_repository.go -destination=mocks/search_stop_word_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	search_stop_word "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_stop_word"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByOrgIDs mocks base method.
+func (m *MockRepository) GetByOrgIDs(ctx context.Context, orgIDs []uuid.UUID) ([]*search_stop_word.SearchStopWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgIDs", ctx, orgIDs)
+	ret0, _ := ret[0].([]*search_stop_word.SearchStopWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgIDs indicates an expected call of GetByOrgIDs.
+func (mr *MockRepositoryMockRecorder) GetByOrgIDs(ctx, orgIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgIDs", reflect.TypeOf((*MockRepository)(nil).GetByOrgIDs), ctx, orgIDs)
+}
+
+// ReplaceByOrgID mocks base method.
+func (m *MockRepository) ReplaceByOrgID(ctx context.Context, orgID uuid.UUID, words []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByOrgID", ctx, orgID, words)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByOrgID indicates an expected call of ReplaceByOrgID.
+func (mr *MockRepositoryMockRecorder) ReplaceByOrgID(ctx, orgID, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByOrgID", reflect.TypeOf((*MockRepository)(nil).ReplaceByOrgID), ctx, orgID, words)
+}
@@ -0,0 +1,15 @@
+package search_stop_word
+
+import (
+	"github.com/google/uuid"
+)
+
+// SearchStopWord is a word left out of an organization's search queries
+type SearchStopWord struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Word           string    `gorm:"type:varchar(64);primaryKey"`
+}
+
+func (SearchStopWord) TableName() string {
+	return "search_stop_words"
+}
@@ -0,0 +1,57 @@
+package search_stop_word
+
+//go:generate mockgen -source=search_stop_word_repository.go -destination=mocks/search_stop_word_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// GetByOrgIDs returns the stop words of the organizations, alphabetically
+	GetByOrgIDs(ctx context.Context, orgIDs []uuid.UUID) ([]*SearchStopWord, error)
+	// ReplaceByOrgID replaces the organization's stop words
+	ReplaceByOrgID(ctx context.Context, orgID uuid.UUID, words []string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByOrgIDs(ctx context.Context, orgIDs []uuid.UUID) ([]*SearchStopWord, error) {
+	var words []*SearchStopWord
+	if len(orgIDs) == 0 {
+		return words, nil
+	}
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id IN ?", orgIDs).
+		Order("word ASC").
+		Find(&words).Error
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+func (r *repository) ReplaceByOrgID(ctx context.Context, orgID uuid.UUID, words []string) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&SearchStopWord{}, "organization_id = ?", orgID).Error; err != nil {
+			return err
+		}
+		if len(words) == 0 {
+			return nil
+		}
+		rows := make([]*SearchStopWord, len(words))
+		for i, word := range words {
+			rows[i] = &SearchStopWord{OrganizationID: orgID, Word: word}
+		}
+		return tx.Create(&rows).Error
+	})
+}
@@ -0,0 +1,52 @@
+package search_synonym_set
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchSynonymSet is a set of words or phrases an organization's searches treat as one
+// another, e.g. "bug" and "defect"
+type SearchSynonymSet struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	Words          Words     `gorm:"type:jsonb;not null;default:'[]'"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+func (SearchSynonymSet) TableName() string {
+	return "search_synonym_sets"
+}
+
+// Words is a list of words stored as a JSON array
+type Words []string
+
+func (w Words) Value() (driver.Value, error) {
+	if w == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (w *Words) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*w = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, w)
+	case string:
+		return json.Unmarshal([]byte(v), w)
+	default:
+		return fmt.Errorf("cannot scan %T into Words", value)
+	}
+}
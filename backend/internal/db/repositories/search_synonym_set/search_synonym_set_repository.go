@@ -0,0 +1,71 @@
+package search_synonym_set
+
+//go:generate mockgen -source=search_synonym_set_repository.go -destination=mocks/search_synonym_set_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, set *SearchSynonymSet) error
+	GetByID(ctx context.Context, id uuid.UUID) (*SearchSynonymSet, error)
+	// GetByOrgIDs returns the synonym sets of the organizations, oldest first
+	GetByOrgIDs(ctx context.Context, orgIDs []uuid.UUID) ([]*SearchSynonymSet, error)
+	CountByOrgID(ctx context.Context, orgID uuid.UUID) (int64, error)
+	Update(ctx context.Context, set *SearchSynonymSet) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, set *SearchSynonymSet) error {
+	return transaction.DB(ctx, r.db).Create(set).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*SearchSynonymSet, error) {
+	var set SearchSynonymSet
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&set).Error
+	if err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (r *repository) GetByOrgIDs(ctx context.Context, orgIDs []uuid.UUID) ([]*SearchSynonymSet, error) {
+	var sets []*SearchSynonymSet
+	if len(orgIDs) == 0 {
+		return sets, nil
+	}
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id IN ?", orgIDs).
+		Order("created_at ASC").
+		Find(&sets).Error
+	if err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+func (r *repository) CountByOrgID(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&SearchSynonymSet{}).Where("organization_id = ?", orgID).Count(&count).Error
+	return count, err
+}
+
+func (r *repository) Update(ctx context.Context, set *SearchSynonymSet) error {
+	return transaction.DB(ctx, r.db).Save(set).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&SearchSynonymSet{}, "id = ?", id).Error
+}
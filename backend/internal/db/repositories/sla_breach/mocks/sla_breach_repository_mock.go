@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sla_breach_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sla_breach_repository.go -destination=mocks/sla_breach_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	sla_breach "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIfAbsent mocks base method.
+func (m *MockRepository) CreateIfAbsent(ctx context.Context, breach *sla_breach.SLABreach) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfAbsent", ctx, breach)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIfAbsent indicates an expected call of CreateIfAbsent.
+func (mr *MockRepositoryMockRecorder) CreateIfAbsent(ctx, breach any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfAbsent", reflect.TypeOf((*MockRepository)(nil).CreateIfAbsent), ctx, breach)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*sla_breach.SLABreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*sla_breach.SLABreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetBySprintID mocks base method.
+func (m *MockRepository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*sla_breach.SLABreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySprintID", ctx, sprintID)
+	ret0, _ := ret[0].([]*sla_breach.SLABreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySprintID indicates an expected call of GetBySprintID.
+func (mr *MockRepositoryMockRecorder) GetBySprintID(ctx, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySprintID", reflect.TypeOf((*MockRepository)(nil).GetBySprintID), ctx, sprintID)
+}
+
+// GetOpenByPolicyID mocks base method.
+func (m *MockRepository) GetOpenByPolicyID(ctx context.Context, policyID uuid.UUID) ([]*sla_breach.SLABreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenByPolicyID", ctx, policyID)
+	ret0, _ := ret[0].([]*sla_breach.SLABreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenByPolicyID indicates an expected call of GetOpenByPolicyID.
+func (mr *MockRepositoryMockRecorder) GetOpenByPolicyID(ctx, policyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenByPolicyID", reflect.TypeOf((*MockRepository)(nil).GetOpenByPolicyID), ctx, policyID)
+}
+
+// MarkNotified mocks base method.
+func (m *MockRepository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockRepositoryMockRecorder) MarkNotified(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockRepository)(nil).MarkNotified), ctx, id, at)
+}
+
+// Resolve mocks base method.
+func (m *MockRepository) Resolve(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockRepositoryMockRecorder) Resolve(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRepository)(nil).Resolve), ctx, id, at)
+}
@@ -0,0 +1,26 @@
+package sla_breach
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLABreach records a card overstaying its policy's limit in the policy's column. A card
+// breaches a policy at most once per stay, identified by ColumnEnteredAt.
+type SLABreach struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PolicyID        uuid.UUID `gorm:"type:uuid;not null"`
+	CardID          uuid.UUID `gorm:"type:uuid;not null"`
+	ColumnEnteredAt time.Time `gorm:"not null"`
+	BreachedAt      time.Time `gorm:"not null"`
+	// ResolvedAt is set once the card has left the column
+	ResolvedAt *time.Time
+	// NotifiedAt is set once the card's owner has been told about the breach
+	NotifiedAt *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (SLABreach) TableName() string {
+	return "sla_breaches"
+}
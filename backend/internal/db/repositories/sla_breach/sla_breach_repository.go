@@ -0,0 +1,95 @@
+package sla_breach
+
+//go:generate mockgen -source=sla_breach_repository.go -destination=mocks/sla_breach_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// CreateIfAbsent records the breach unless the card's stay already breached the policy.
+	// It reports whether a new breach was recorded, so concurrent evaluators flag it once.
+	CreateIfAbsent(ctx context.Context, breach *SLABreach) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*SLABreach, error)
+	GetOpenByPolicyID(ctx context.Context, policyID uuid.UUID) ([]*SLABreach, error)
+	// GetBySprintID returns the breaches of cards currently planned in the sprint
+	GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*SLABreach, error)
+	Resolve(ctx context.Context, id uuid.UUID, at time.Time) error
+	// MarkNotified claims the breach's notification. It reports false when it was already sent.
+	MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateIfAbsent(ctx context.Context, breach *SLABreach) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(breach)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*SLABreach, error) {
+	var breach SLABreach
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&breach)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &breach, nil
+}
+
+func (r *repository) GetOpenByPolicyID(ctx context.Context, policyID uuid.UUID) ([]*SLABreach, error) {
+	var breaches []*SLABreach
+	result := transaction.DB(ctx, r.db).
+		Where("policy_id = ? AND resolved_at IS NULL", policyID).
+		Find(&breaches)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return breaches, nil
+}
+
+func (r *repository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*SLABreach, error) {
+	var breaches []*SLABreach
+	result := transaction.DB(ctx, r.db).
+		Joins("JOIN card_sprints ON card_sprints.card_id = sla_breaches.card_id").
+		Where("card_sprints.sprint_id = ?", sprintID).
+		Order("sla_breaches.breached_at ASC").
+		Find(&breaches)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return breaches, nil
+}
+
+func (r *repository) Resolve(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).
+		Model(&SLABreach{}).
+		Where("id = ? AND resolved_at IS NULL", id).
+		Update("resolved_at", at).Error
+}
+
+func (r *repository) MarkNotified(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&SLABreach{}).
+		Where("id = ? AND notified_at IS NULL", id).
+		Update("notified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,40 @@
+package sla_policy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+)
+
+// SLAPolicy requires cards in a column to leave it within MaxDurationMinutes. A nil
+// Priority applies the policy to cards of every priority.
+type SLAPolicy struct {
+	ID                 uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProjectID          uuid.UUID          `gorm:"type:uuid;not null"`
+	Name               string             `gorm:"type:varchar(255);not null"`
+	ColumnID           uuid.UUID          `gorm:"type:uuid;not null"`
+	Priority           *card.CardPriority `gorm:"type:card_priority"`
+	MaxDurationMinutes int                `gorm:"not null"`
+	// EscalateToPriority raises a breaching card's priority to this level
+	EscalateToPriority *card.CardPriority `gorm:"type:card_priority"`
+	// BreachTagID is added to a breaching card
+	BreachTagID *uuid.UUID `gorm:"type:uuid"`
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (SLAPolicy) TableName() string {
+	return "sla_policies"
+}
+
+// MaxDuration returns how long a card may stay in the column
+func (p *SLAPolicy) MaxDuration() time.Duration {
+	return time.Duration(p.MaxDurationMinutes) * time.Minute
+}
+
+// Applies reports whether the policy covers cards of the given priority
+func (p *SLAPolicy) Applies(priority card.CardPriority) bool {
+	return p.Priority == nil || *p.Priority == priority
+}
@@ -0,0 +1,70 @@
+package sla_policy
+
+//go:generate mockgen -source=sla_policy_repository.go -destination=mocks/sla_policy_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, policy *SLAPolicy) error
+	GetByID(ctx context.Context, id uuid.UUID) (*SLAPolicy, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*SLAPolicy, error)
+	GetAll(ctx context.Context) ([]*SLAPolicy, error)
+	Update(ctx context.Context, policy *SLAPolicy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, policy *SLAPolicy) error {
+	return transaction.DB(ctx, r.db).Create(policy).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*SLAPolicy, error) {
+	var policy SLAPolicy
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&policy)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &policy, nil
+}
+
+func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*SLAPolicy, error) {
+	var policies []*SLAPolicy
+	result := transaction.DB(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("created_at ASC").
+		Find(&policies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return policies, nil
+}
+
+func (r *repository) GetAll(ctx context.Context) ([]*SLAPolicy, error) {
+	var policies []*SLAPolicy
+	result := transaction.DB(ctx, r.db).Order("created_at ASC").Find(&policies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return policies, nil
+}
+
+func (r *repository) Update(ctx context.Context, policy *SLAPolicy) error {
+	return transaction.DB(ctx, r.db).Save(policy).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&SLAPolicy{}, "id = ?", id).Error
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	sprint "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
@@ -189,3 +190,17 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, arg1)
 }
+
+// UpdateSummary mocks base method.
+func (m *MockRepository) UpdateSummary(ctx context.Context, id uuid.UUID, summary string, generatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSummary", ctx, id, summary, generatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSummary indicates an expected call of UpdateSummary.
+func (mr *MockRepositoryMockRecorder) UpdateSummary(ctx, id, summary, generatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSummary", reflect.TypeOf((*MockRepository)(nil).UpdateSummary), ctx, id, summary, generatedAt)
+}
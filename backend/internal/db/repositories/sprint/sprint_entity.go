@@ -26,6 +26,9 @@ type Sprint struct {
 	CreatedAt time.Time    `gorm:"autoCreateTime"`
 	UpdatedAt time.Time    `gorm:"autoUpdateTime"`
 	CreatedBy *uuid.UUID   `gorm:"type:uuid"`
+	// Summary is the last generated summary of the sprint, empty until one is generated
+	Summary            string     `gorm:"type:text"`
+	SummaryGeneratedAt *time.Time `gorm:"type:timestamp with time zone"`
 }
 
 func (Sprint) TableName() string {
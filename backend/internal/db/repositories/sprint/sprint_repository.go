@@ -4,8 +4,10 @@ package sprint
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +20,8 @@ type Repository interface {
 	GetClosedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Sprint, error)
 	GetClosedByBoardIDPaginated(ctx context.Context, boardID uuid.UUID, limit, offset int) ([]*Sprint, int, error)
 	Update(ctx context.Context, sprint *Sprint) error
+	// UpdateSummary saves only the sprint's summary, leaving updated_at and concurrent edits alone
+	UpdateSummary(ctx context.Context, id uuid.UUID, summary string, generatedAt time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetNextPosition(ctx context.Context, boardID uuid.UUID) (int, error)
 }
@@ -31,12 +35,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, sprint *Sprint) error {
-	return r.db.WithContext(ctx).Create(sprint).Error
+	return transaction.DB(ctx, r.db).Create(sprint).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Sprint, error) {
 	var sprint Sprint
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&sprint).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&sprint).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +49,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Sprint, error)
 
 func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Sprint, error) {
 	var sprints []*Sprint
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ?", boardID).
 		Order("position ASC, created_at ASC").
 		Find(&sprints).Error
@@ -57,7 +61,7 @@ func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Sp
 
 func (r *repository) GetActiveByBoardID(ctx context.Context, boardID uuid.UUID) (*Sprint, error) {
 	var sprint Sprint
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ? AND status = ?", boardID, SprintStatusActive).
 		First(&sprint).Error
 	if err != nil {
@@ -68,7 +72,7 @@ func (r *repository) GetActiveByBoardID(ctx context.Context, boardID uuid.UUID)
 
 func (r *repository) GetFutureByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Sprint, error) {
 	var sprints []*Sprint
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ? AND status = ?", boardID, SprintStatusFuture).
 		Order("position ASC, created_at ASC").
 		Find(&sprints).Error
@@ -80,7 +84,7 @@ func (r *repository) GetFutureByBoardID(ctx context.Context, boardID uuid.UUID)
 
 func (r *repository) GetClosedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Sprint, error) {
 	var sprints []*Sprint
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("board_id = ? AND status = ?", boardID, SprintStatusClosed).
 		Order("end_date DESC, created_at DESC").
 		Find(&sprints).Error
@@ -95,7 +99,7 @@ func (r *repository) GetClosedByBoardIDPaginated(ctx context.Context, boardID uu
 	var totalCount int64
 
 	// Get total count
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&Sprint{}).
 		Where("board_id = ? AND status = ?", boardID, SprintStatusClosed).
 		Count(&totalCount).Error
@@ -104,7 +108,7 @@ func (r *repository) GetClosedByBoardIDPaginated(ctx context.Context, boardID uu
 	}
 
 	// Get paginated results
-	err = r.db.WithContext(ctx).
+	err = transaction.DB(ctx, r.db).
 		Where("board_id = ? AND status = ?", boardID, SprintStatusClosed).
 		Order("end_date DESC, created_at DESC").
 		Limit(limit).
@@ -118,16 +122,23 @@ func (r *repository) GetClosedByBoardIDPaginated(ctx context.Context, boardID uu
 }
 
 func (r *repository) Update(ctx context.Context, sprint *Sprint) error {
-	return r.db.WithContext(ctx).Save(sprint).Error
+	return transaction.DB(ctx, r.db).Save(sprint).Error
+}
+
+func (r *repository) UpdateSummary(ctx context.Context, id uuid.UUID, summary string, generatedAt time.Time) error {
+	return transaction.DB(ctx, r.db).
+		Model(&Sprint{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"summary": summary, "summary_generated_at": generatedAt}).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Sprint{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Sprint{}, "id = ?", id).Error
 }
 
 func (r *repository) GetNextPosition(ctx context.Context, boardID uuid.UUID) (int, error) {
 	var maxPosition int
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&Sprint{}).
 		Where("board_id = ?", boardID).
 		Select("COALESCE(MAX(position), -1)").
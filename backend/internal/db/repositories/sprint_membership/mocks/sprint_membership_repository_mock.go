@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sprint_membership_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sprint_membership_repository.go -destination=mocks/sprint_membership_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	sprint_membership "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint_membership"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByBoardID mocks base method.
+func (m *MockRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*sprint_membership.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBoardID", ctx, boardID)
+	ret0, _ := ret[0].([]*sprint_membership.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBoardID indicates an expected call of GetByBoardID.
+func (mr *MockRepositoryMockRecorder) GetByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBoardID", reflect.TypeOf((*MockRepository)(nil).GetByBoardID), ctx, boardID)
+}
+
+// GetCardChanges mocks base method.
+func (m *MockRepository) GetCardChanges(ctx context.Context, cardID uuid.UUID) ([]*sprint_membership.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardChanges", ctx, cardID)
+	ret0, _ := ret[0].([]*sprint_membership.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardChanges indicates an expected call of GetCardChanges.
+func (mr *MockRepositoryMockRecorder) GetCardChanges(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardChanges", reflect.TypeOf((*MockRepository)(nil).GetCardChanges), ctx, cardID)
+}
+
+// GetCardSprints mocks base method.
+func (m *MockRepository) GetCardSprints(ctx context.Context, cardID uuid.UUID) ([]*sprint_membership.CardSprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardSprints", ctx, cardID)
+	ret0, _ := ret[0].([]*sprint_membership.CardSprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardSprints indicates an expected call of GetCardSprints.
+func (mr *MockRepositoryMockRecorder) GetCardSprints(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardSprints", reflect.TypeOf((*MockRepository)(nil).GetCardSprints), ctx, cardID)
+}
@@ -0,0 +1,156 @@
+package sprint_membership
+
+//go:generate mockgen -source=sprint_membership_repository.go -destination=mocks/sprint_membership_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+// Membership records that a card was in a sprint at some point, with the card as it is now
+type Membership struct {
+	CardID      uuid.UUID
+	SprintID    uuid.UUID
+	Title       string
+	StoryPoints *int
+	// Done is set when the card is in a done column now
+	Done bool
+}
+
+// CardSprint is a sprint a card is in now
+type CardSprint struct {
+	SprintID uuid.UUID
+	AddedAt  time.Time
+}
+
+// ChangeKind is how an audit event changed a card's sprints
+type ChangeKind string
+
+const (
+	// ChangeAdded adds the card to the change's sprint
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved takes the card out of the change's sprint, or out of every sprint when
+	// the change has none (moving the card to the backlog)
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeSet replaces the card's sprints with the change's
+	ChangeSet ChangeKind = "set"
+)
+
+// Change is an audit event that changed a card's sprints
+type Change struct {
+	Kind       ChangeKind
+	SprintIDs  []uuid.UUID
+	OccurredAt time.Time
+}
+
+type Repository interface {
+	// GetByBoardID returns every card each of the board's sprints ever held: the sprint's
+	// current cards plus the cards audit events show were added to it and later removed.
+	// Deleted cards are left out.
+	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Membership, error)
+	// GetCardSprints returns the sprints the card is in now, including closed sprints it
+	// was carried out of
+	GetCardSprints(ctx context.Context, cardID uuid.UUID) ([]*CardSprint, error)
+	// GetCardChanges returns the audit events that changed the card's sprints, oldest first
+	GetCardChanges(ctx context.Context, cardID uuid.UUID) ([]*Change, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Membership, error) {
+	var memberships []*Membership
+	err := transaction.DB(ctx, r.db).Raw(`
+		WITH board_sprints AS (
+			SELECT id FROM sprints WHERE board_id = ?
+		), members AS (
+			SELECT cs.card_id, cs.sprint_id
+			FROM card_sprints cs
+			JOIN board_sprints s ON s.id = cs.sprint_id
+			UNION
+			SELECT a.entity_id, s.id
+			FROM audit_events a
+			JOIN board_sprints s ON s.id::text = a.metadata->>'sprint_id'
+			WHERE a.entity_type = 'card' AND a.action = 'card_added_to_sprint'
+			UNION
+			SELECT a.entity_id, s.id
+			FROM audit_events a
+			CROSS JOIN LATERAL jsonb_array_elements_text(a.metadata->'sprint_ids') AS sprint_ids(sprint_id)
+			JOIN board_sprints s ON s.id::text = sprint_ids.sprint_id
+			WHERE a.entity_type = 'card' AND a.action = 'updated'
+				AND jsonb_typeof(a.metadata->'sprint_ids') = 'array'
+		)
+		SELECT m.card_id, m.sprint_id, c.title, c.story_points, col.is_done AS done
+		FROM members m
+		JOIN cards c ON c.id = m.card_id
+		JOIN board_columns col ON col.id = c.column_id
+	`, boardID).Scan(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *repository) GetCardSprints(ctx context.Context, cardID uuid.UUID) ([]*CardSprint, error) {
+	var sprints []*CardSprint
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT sprint_id, added_at FROM card_sprints WHERE card_id = ? ORDER BY added_at ASC
+	`, cardID).Scan(&sprints).Error
+	if err != nil {
+		return nil, err
+	}
+	return sprints, nil
+}
+
+func (r *repository) GetCardChanges(ctx context.Context, cardID uuid.UUID) ([]*Change, error) {
+	var rows []struct {
+		Kind       ChangeKind
+		SprintID   *uuid.UUID
+		SprintIDs  *string
+		OccurredAt time.Time
+	}
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			CASE action
+				WHEN 'card_added_to_sprint' THEN 'added'
+				WHEN 'card_removed_from_sprint' THEN 'removed'
+				ELSE 'set'
+			END AS kind,
+			(metadata->>'sprint_id')::uuid AS sprint_id,
+			metadata->'sprint_ids' AS sprint_ids,
+			occurred_at
+		FROM audit_events
+		WHERE entity_type = 'card' AND entity_id = ?
+			AND (action IN ('card_added_to_sprint', 'card_removed_from_sprint')
+				OR (action = 'updated' AND jsonb_typeof(metadata->'sprint_ids') = 'array'))
+		ORDER BY occurred_at ASC, created_at ASC
+	`, cardID).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*Change, 0, len(rows))
+	for _, row := range rows {
+		change := &Change{Kind: row.Kind, SprintIDs: []uuid.UUID{}, OccurredAt: row.OccurredAt}
+		switch {
+		case row.SprintID != nil:
+			change.SprintIDs = append(change.SprintIDs, *row.SprintID)
+		case row.SprintIDs != nil:
+			if err := json.Unmarshal([]byte(*row.SprintIDs), &change.SprintIDs); err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sync_change_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sync_change_repository.go -destination=mocks/sync_change_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	sync_change "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLatestSeq mocks base method.
+func (m *MockRepository) GetLatestSeq(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSeq", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSeq indicates an expected call of GetLatestSeq.
+func (mr *MockRepositoryMockRecorder) GetLatestSeq(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSeq", reflect.TypeOf((*MockRepository)(nil).GetLatestSeq), ctx)
+}
+
+// GetSince mocks base method.
+func (m *MockRepository) GetSince(ctx context.Context, boardID uuid.UUID, seq int64, before time.Time, limit int) ([]*sync_change.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSince", ctx, boardID, seq, before, limit)
+	ret0, _ := ret[0].([]*sync_change.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSince indicates an expected call of GetSince.
+func (mr *MockRepositoryMockRecorder) GetSince(ctx, boardID, seq, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSince", reflect.TypeOf((*MockRepository)(nil).GetSince), ctx, boardID, seq, before, limit)
+}
+
+// Record mocks base method.
+func (m *MockRepository) Record(ctx context.Context, change *sync_change.Change) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRepositoryMockRecorder) Record(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRepository)(nil).Record), ctx, change)
+}
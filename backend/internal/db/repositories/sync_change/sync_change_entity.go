@@ -0,0 +1,32 @@
+package sync_change
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntityType is the kind of entity a change is about
+type EntityType string
+
+const (
+	EntityCard  EntityType = "card"
+	EntityBoard EntityType = "board"
+)
+
+// Change records that an entity on a board changed or was removed from it. Seq orders
+// changes and serves as the sync cursor.
+type Change struct {
+	Seq        int64      `gorm:"primaryKey;autoIncrement"`
+	EventID    uuid.UUID  `gorm:"type:uuid;not null"`
+	BoardID    uuid.UUID  `gorm:"type:uuid;not null"`
+	EntityType EntityType `gorm:"type:varchar(20);not null"`
+	EntityID   uuid.UUID  `gorm:"type:uuid;not null"`
+	// Deleted marks a tombstone: the entity was deleted or left the board
+	Deleted    bool      `gorm:"not null;default:false"`
+	RecordedAt time.Time `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+func (Change) TableName() string {
+	return "sync_changes"
+}
@@ -0,0 +1,59 @@
+package sync_change
+
+//go:generate mockgen -source=sync_change_repository.go -destination=mocks/sync_change_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Record appends a change unless the same event already recorded it
+	Record(ctx context.Context, change *Change) error
+	// GetSince returns up to limit of the board's changes after seq, oldest first, leaving
+	// out changes recorded at or after before
+	GetSince(ctx context.Context, boardID uuid.UUID, seq int64, before time.Time, limit int) ([]*Change, error)
+	// GetLatestSeq returns the highest seq recorded, or 0 when the journal is empty
+	GetLatestSeq(ctx context.Context) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Record(ctx context.Context, change *Change) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(change).Error
+}
+
+func (r *repository) GetSince(ctx context.Context, boardID uuid.UUID, seq int64, before time.Time, limit int) ([]*Change, error) {
+	var changes []*Change
+	result := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND seq > ? AND recorded_at < ?", boardID, seq, before).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&changes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return changes, nil
+}
+
+func (r *repository) GetLatestSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := transaction.DB(ctx, r.db).
+		Model(&Change{}).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error
+	return seq, err
+}
@@ -0,0 +1,21 @@
+package sync_mutation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppliedMutation is an offline mutation the server has applied, keyed by the ID the
+// client gave it
+type AppliedMutation struct {
+	UserID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClientMutationID string    `gorm:"type:varchar(100);primaryKey"`
+	// CardID is the card the mutation created or changed
+	CardID    *uuid.UUID `gorm:"type:uuid"`
+	AppliedAt time.Time  `gorm:"type:timestamptz;not null;default:now()"`
+}
+
+func (AppliedMutation) TableName() string {
+	return "sync_mutations"
+}
@@ -0,0 +1,43 @@
+package sync_mutation
+
+//go:generate mockgen -source=sync_mutation_repository.go -destination=mocks/sync_mutation_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Get(ctx context.Context, userID uuid.UUID, clientMutationID string) (*AppliedMutation, error)
+	// Record stores an applied mutation, keeping the first record if it was already stored
+	Record(ctx context.Context, mutation *AppliedMutation) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Get(ctx context.Context, userID uuid.UUID, clientMutationID string) (*AppliedMutation, error) {
+	var mutation AppliedMutation
+	result := transaction.DB(ctx, r.db).
+		Where("user_id = ? AND client_mutation_id = ?", userID, clientMutationID).
+		First(&mutation)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &mutation, nil
+}
+
+func (r *repository) Record(ctx context.Context, mutation *AppliedMutation) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(mutation).Error
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -28,12 +29,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, tag *Tag) error {
-	return r.db.WithContext(ctx).Create(tag).Error
+	return transaction.DB(ctx, r.db).Create(tag).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Tag, error) {
 	var tag Tag
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&tag).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&tag).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +43,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Tag, error) {
 
 func (r *repository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Tag, error) {
 	var tags []*Tag
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("project_id = ?", projectID).
 		Order("name ASC").
 		Find(&tags).Error
@@ -57,7 +58,7 @@ func (r *repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Tag, err
 	if len(ids) == 0 {
 		return tags, nil
 	}
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("id IN ?", ids).
 		Find(&tags).Error
 	if err != nil {
@@ -68,7 +69,7 @@ func (r *repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Tag, err
 
 func (r *repository) GetByName(ctx context.Context, projectID uuid.UUID, name string) (*Tag, error) {
 	var tag Tag
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Where("project_id = ? AND name = ?", projectID, name).
 		First(&tag).Error
 	if err != nil {
@@ -78,9 +79,9 @@ func (r *repository) GetByName(ctx context.Context, projectID uuid.UUID, name st
 }
 
 func (r *repository) Update(ctx context.Context, tag *Tag) error {
-	return r.db.WithContext(ctx).Save(tag).Error
+	return transaction.DB(ctx, r.db).Save(tag).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&Tag{}, "id = ?", id).Error
+	return transaction.DB(ctx, r.db).Delete(&Tag{}, "id = ?", id).Error
 }
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: undo_operation_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=undo_operation_repository.go -destination=mocks/undo_operation_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	undo_operation "github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, op *undo_operation.UndoOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, op)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, op)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetUndoableByBoardID mocks base method.
+func (m *MockRepository) GetUndoableByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndoableByBoardID", ctx, boardID, now)
+	ret0, _ := ret[0].([]*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndoableByBoardID indicates an expected call of GetUndoableByBoardID.
+func (mr *MockRepositoryMockRecorder) GetUndoableByBoardID(ctx, boardID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndoableByBoardID", reflect.TypeOf((*MockRepository)(nil).GetUndoableByBoardID), ctx, boardID, now)
+}
+
+// MarkUndone mocks base method.
+func (m *MockRepository) MarkUndone(ctx context.Context, id uuid.UUID, undoneBy *uuid.UUID, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUndone", ctx, id, undoneBy, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUndone indicates an expected call of MarkUndone.
+func (mr *MockRepositoryMockRecorder) MarkUndone(ctx, id, undoneBy, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUndone", reflect.TypeOf((*MockRepository)(nil).MarkUndone), ctx, id, undoneBy, now)
+}
@@ -0,0 +1,27 @@
+package undo_operation
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UndoOperation is a recorded bulk operation together with the inverse steps that revert
+// it. It can be undone once, until ExpiresAt.
+type UndoOperation struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Kind      string          `gorm:"type:varchar(50);not null"`
+	BoardID   uuid.UUID       `gorm:"type:uuid;not null"`
+	EntityID  uuid.UUID       `gorm:"type:uuid;not null"`
+	ActorID   *uuid.UUID      `gorm:"type:uuid"`
+	Inverse   json.RawMessage `gorm:"type:jsonb;not null;default:'[]'"`
+	ExpiresAt time.Time       `gorm:"not null"`
+	UndoneAt  *time.Time
+	UndoneBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+func (UndoOperation) TableName() string {
+	return "undo_operations"
+}
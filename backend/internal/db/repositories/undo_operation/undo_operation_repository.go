@@ -0,0 +1,70 @@
+package undo_operation
+
+//go:generate mockgen -source=undo_operation_repository.go -destination=mocks/undo_operation_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, op *UndoOperation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*UndoOperation, error)
+	// GetUndoableByBoardID returns the board's operations that have not been undone and
+	// whose undo window is still open at now, newest first
+	GetUndoableByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*UndoOperation, error)
+	// MarkUndone claims the operation for undoing. It reports false when the operation was
+	// already undone or has expired, so concurrent undos cannot both apply.
+	MarkUndone(ctx context.Context, id uuid.UUID, undoneBy *uuid.UUID, now time.Time) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, op *UndoOperation) error {
+	return transaction.DB(ctx, r.db).Create(op).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*UndoOperation, error) {
+	var op UndoOperation
+	result := transaction.DB(ctx, r.db).Where("id = ?", id).First(&op)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &op, nil
+}
+
+func (r *repository) GetUndoableByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*UndoOperation, error) {
+	var ops []*UndoOperation
+	result := transaction.DB(ctx, r.db).
+		Where("board_id = ? AND undone_at IS NULL AND expires_at > ?", boardID, now).
+		Order("created_at DESC").
+		Find(&ops)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ops, nil
+}
+
+func (r *repository) MarkUndone(ctx context.Context, id uuid.UUID, undoneBy *uuid.UUID, now time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&UndoOperation{}).
+		Where("id = ? AND undone_at IS NULL AND expires_at > ?", id, now).
+		Updates(map[string]interface{}{
+			"undone_at": now,
+			"undone_by": undoneBy,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
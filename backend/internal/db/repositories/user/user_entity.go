@@ -14,8 +14,11 @@ type User struct {
 	EmailVerified bool      `gorm:"default:false"`
 	DisplayName   *string   `gorm:"type:varchar(255)"`
 	AvatarURL     *string   `gorm:"type:text"`
-	CreatedAt     time.Time `gorm:"autoCreateTime"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	// Locale is the user's language preference for emails and messages; nil falls back to
+	// the organization's default
+	Locale    *string   `gorm:"type:varchar(16)"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
 
 func (User) TableName() string {
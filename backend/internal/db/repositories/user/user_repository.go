@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"gorm.io/gorm"
 )
 
@@ -27,12 +28,12 @@ func NewRepository(db *gorm.DB) Repository {
 }
 
 func (r *repository) Create(ctx context.Context, user *User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return transaction.DB(ctx, r.db).Create(user).Error
 }
 
 func (r *repository) GetByUsername(ctx context.Context, username string) (*User, error) {
 	var user User
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := transaction.DB(ctx, r.db).Where("username = ?", username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func (r *repository) GetByUsername(ctx context.Context, username string) (*User,
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	var user User
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +51,7 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*User, error) {
 
 func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := transaction.DB(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -58,12 +59,12 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error
 }
 
 func (r *repository) Update(ctx context.Context, user *User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return transaction.DB(ctx, r.db).Save(user).Error
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]*User, error) {
 	var users []*User
-	err := r.db.WithContext(ctx).Find(&users).Error
+	err := transaction.DB(ctx, r.db).Find(&users).Error
 	if err != nil {
 		return nil, err
 	}
@@ -2,67 +2,17 @@ package user
 
 import (
 	"context"
-	"fmt"
-	"os"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/postgres"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping test: could not connect to test database: %v", err)
-	}
-
-	// Setup schema
-	db.Exec(`
-		CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-		CREATE TABLE IF NOT EXISTS users (
-			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-			username VARCHAR(255) NOT NULL UNIQUE,
-			password_hash VARCHAR(255) NOT NULL,
-			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		);
-	`)
-
-	// Clean up before test
-	db.Exec("DELETE FROM users")
-
-	return db
-}
-
 func TestUserRepository_Create(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -70,7 +20,7 @@ func TestUserRepository_Create(t *testing.T) {
 
 	user := &User{
 		Username:     "testcreate",
-		PasswordHash: "hashedpassword",
+		PasswordHash: strPtr("hashedpassword"),
 	}
 
 	err := repo.Create(ctx, user)
@@ -82,7 +32,7 @@ func TestUserRepository_Create(t *testing.T) {
 }
 
 func TestUserRepository_Create_DuplicateUsername(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -90,11 +40,11 @@ func TestUserRepository_Create_DuplicateUsername(t *testing.T) {
 
 	user1 := &User{
 		Username:     "duplicate",
-		PasswordHash: "hash1",
+		PasswordHash: strPtr("hash1"),
 	}
 	user2 := &User{
 		Username:     "duplicate",
-		PasswordHash: "hash2",
+		PasswordHash: strPtr("hash2"),
 	}
 
 	err := repo.Create(ctx, user1)
@@ -105,7 +55,7 @@ func TestUserRepository_Create_DuplicateUsername(t *testing.T) {
 }
 
 func TestUserRepository_GetByUsername(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -114,7 +64,7 @@ func TestUserRepository_GetByUsername(t *testing.T) {
 	// Create a user first
 	user := &User{
 		Username:     "findme",
-		PasswordHash: "password",
+		PasswordHash: strPtr("password"),
 	}
 	repo.Create(ctx, user)
 
@@ -128,7 +78,7 @@ func TestUserRepository_GetByUsername(t *testing.T) {
 }
 
 func TestUserRepository_GetByUsername_NotFound(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -142,7 +92,7 @@ func TestUserRepository_GetByUsername_NotFound(t *testing.T) {
 }
 
 func TestUserRepository_GetByID(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -151,7 +101,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 	// Create a user first
 	user := &User{
 		Username:     "findbyid",
-		PasswordHash: "password",
+		PasswordHash: strPtr("password"),
 	}
 	repo.Create(ctx, user)
 
@@ -165,7 +115,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 }
 
 func TestUserRepository_GetByID_NotFound(t *testing.T) {
-	db := setupTestDB(t)
+	db := testsupport.NewTestDB(t)
 	defer db.Exec("DELETE FROM users")
 
 	repo := NewRepository(db)
@@ -178,3 +128,7 @@ func TestUserRepository_GetByID_NotFound(t *testing.T) {
 	assert.Nil(t, found)
 	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
 }
+
+func strPtr(s string) *string {
+	return &s
+}
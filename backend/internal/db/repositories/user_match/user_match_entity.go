@@ -0,0 +1,94 @@
+package user_match
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is where a user match stands
+type Status string
+
+const (
+	// StatusMatched maps the external user to UserID
+	StatusMatched Status = "matched"
+	// StatusPending awaits someone picking the user, as no candidate was certain enough
+	StatusPending Status = "pending"
+	// StatusNew has no existing user; the importer creates or invites one
+	StatusNew Status = "new"
+)
+
+// Reason is why a candidate may be the external user
+type Reason string
+
+const (
+	ReasonEmail          Reason = "email"            // Same email address
+	ReasonName           Reason = "name"             // Same name, perhaps reordered or abbreviated
+	ReasonSimilarName    Reason = "similar_name"     // Name a few typos apart
+	ReasonUsername       Reason = "username"         // Email local part is the username
+	ReasonEmailLocalPart Reason = "email_local_part" // Same email local part on another domain
+)
+
+// UserMatch maps a user of an external source to an existing user of the organization
+type UserMatch struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null"`
+	// Source is where the user comes from, e.g. "jira", "trello" or "invite"
+	Source      string     `gorm:"type:varchar(50);not null"`
+	ExternalID  string     `gorm:"type:varchar(255);not null"`
+	DisplayName *string    `gorm:"type:varchar(255)"`
+	Email       *string    `gorm:"type:varchar(255)"`
+	Status      Status     `gorm:"type:varchar(20);not null;default:'pending'"`
+	UserID      *uuid.UUID `gorm:"type:uuid"`
+	// Confidence is how sure the match to UserID is, from 0 to 1; the best candidate's
+	// confidence while pending
+	Confidence float64    `gorm:"not null;default:0"`
+	Candidates Candidates `gorm:"type:jsonb;not null;default:'[]'"`
+	// ResolvedBy is who settled the match by hand; nil when it was settled automatically
+	ResolvedBy *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt *time.Time `gorm:"type:timestamp with time zone"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (UserMatch) TableName() string {
+	return "user_matches"
+}
+
+// Candidate is an existing user who may be the external user
+type Candidate struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Confidence float64   `json:"confidence"`
+	Reason     Reason    `json:"reason"`
+}
+
+// Candidates is a list of candidates stored as a JSON array, most likely first
+type Candidates []Candidate
+
+func (c Candidates) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *Candidates) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into Candidates", value)
+	}
+}
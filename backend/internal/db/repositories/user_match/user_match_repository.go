@@ -0,0 +1,68 @@
+package user_match
+
+//go:generate mockgen -source=user_match_repository.go -destination=mocks/user_match_repository_mock.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, match *UserMatch) error
+	GetByID(ctx context.Context, id uuid.UUID) (*UserMatch, error)
+	GetByExternalID(ctx context.Context, orgID uuid.UUID, source, externalID string) (*UserMatch, error)
+	// GetPendingByOrgID returns the organization's matches awaiting resolution, oldest first
+	GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*UserMatch, error)
+	Update(ctx context.Context, match *UserMatch) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, match *UserMatch) error {
+	return transaction.DB(ctx, r.db).Create(match).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*UserMatch, error) {
+	var match UserMatch
+	err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&match).Error
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *repository) GetByExternalID(ctx context.Context, orgID uuid.UUID, source, externalID string) (*UserMatch, error) {
+	var match UserMatch
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND source = ? AND external_id = ?", orgID, source, externalID).
+		First(&match).Error
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *repository) GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*UserMatch, error) {
+	var matches []*UserMatch
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND status = ?", orgID, StatusPending).
+		Order("created_at ASC").
+		Find(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (r *repository) Update(ctx context.Context, match *UserMatch) error {
+	return transaction.DB(ctx, r.db).Save(match).Error
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: warehouse_sync_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=warehouse_sync_repository.go -destination=mocks/warehouse_sync_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	warehouse_sync "github.com/thatcatdev/kaimu/backend/internal/db/repositories/warehouse_sync"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAuditDaily mocks base method.
+func (m *MockRepository) GetAuditDaily(ctx context.Context, from, to time.Time) ([]*warehouse_sync.AuditDailyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditDaily", ctx, from, to)
+	ret0, _ := ret[0].([]*warehouse_sync.AuditDailyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditDaily indicates an expected call of GetAuditDaily.
+func (mr *MockRepositoryMockRecorder) GetAuditDaily(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditDaily", reflect.TypeOf((*MockRepository)(nil).GetAuditDaily), ctx, from, to)
+}
+
+// GetCardsAfter mocks base method.
+func (m *MockRepository) GetCardsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*warehouse_sync.CardRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsAfter", ctx, after, afterID, limit)
+	ret0, _ := ret[0].([]*warehouse_sync.CardRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsAfter indicates an expected call of GetCardsAfter.
+func (mr *MockRepositoryMockRecorder) GetCardsAfter(ctx, after, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsAfter", reflect.TypeOf((*MockRepository)(nil).GetCardsAfter), ctx, after, afterID, limit)
+}
+
+// GetCursor mocks base method.
+func (m *MockRepository) GetCursor(ctx context.Context, stream string) (*warehouse_sync.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursor", ctx, stream)
+	ret0, _ := ret[0].(*warehouse_sync.Cursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursor indicates an expected call of GetCursor.
+func (mr *MockRepositoryMockRecorder) GetCursor(ctx, stream any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursor", reflect.TypeOf((*MockRepository)(nil).GetCursor), ctx, stream)
+}
+
+// GetSprintsAfter mocks base method.
+func (m *MockRepository) GetSprintsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*warehouse_sync.SprintRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprintsAfter", ctx, after, afterID, limit)
+	ret0, _ := ret[0].([]*warehouse_sync.SprintRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprintsAfter indicates an expected call of GetSprintsAfter.
+func (mr *MockRepositoryMockRecorder) GetSprintsAfter(ctx, after, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintsAfter", reflect.TypeOf((*MockRepository)(nil).GetSprintsAfter), ctx, after, afterID, limit)
+}
+
+// SaveCursor mocks base method.
+func (m *MockRepository) SaveCursor(ctx context.Context, cursor *warehouse_sync.Cursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCursor", ctx, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCursor indicates an expected call of SaveCursor.
+func (mr *MockRepositoryMockRecorder) SaveCursor(ctx, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCursor", reflect.TypeOf((*MockRepository)(nil).SaveCursor), ctx, cursor)
+}
@@ -0,0 +1,67 @@
+package warehouse_sync
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is how far a stream has been synced to the warehouse
+type Cursor struct {
+	Stream    string     `gorm:"type:varchar(64);primary_key"`
+	CursorAt  time.Time  `gorm:"type:timestamptz;not null"`
+	CursorID  *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (Cursor) TableName() string {
+	return "warehouse_sync_cursors"
+}
+
+// CardRow is a card as it is synced, with the project and organization it belongs to
+type CardRow struct {
+	ID                  uuid.UUID
+	BoardID             uuid.UUID
+	ProjectID           uuid.UUID
+	OrganizationID      uuid.UUID
+	ColumnID            uuid.UUID
+	ColumnName          string
+	Done                bool
+	Priority            string
+	StoryPoints         *int
+	OriginalStoryPoints *int
+	AssigneeID          *uuid.UUID
+	EpicID              *uuid.UUID
+	DueDate             *time.Time
+	StartedAt           *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// SprintRow is a sprint as it is synced, with its committed and completed work
+type SprintRow struct {
+	ID              uuid.UUID
+	BoardID         uuid.UUID
+	ProjectID       uuid.UUID
+	OrganizationID  uuid.UUID
+	Name            string
+	Status          string
+	StartDate       *time.Time
+	EndDate         *time.Time
+	CommittedCards  int
+	CommittedPoints int
+	CompletedCards  int
+	CompletedPoints int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// AuditDailyRow counts a day's audit events per project, action and entity type
+type AuditDailyRow struct {
+	Day            time.Time
+	OrganizationID *uuid.UUID
+	ProjectID      *uuid.UUID
+	Action         string
+	EntityType     string
+	EventCount     int
+}
@@ -0,0 +1,119 @@
+package warehouse_sync
+
+//go:generate mockgen -source=warehouse_sync_repository.go -destination=mocks/warehouse_sync_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// GetCursor returns the stream's cursor, or gorm.ErrRecordNotFound before its first sync
+	GetCursor(ctx context.Context, stream string) (*Cursor, error)
+	SaveCursor(ctx context.Context, cursor *Cursor) error
+	// GetCardsAfter returns up to limit cards updated after (after, afterID), in that order
+	GetCardsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*CardRow, error)
+	// GetSprintsAfter returns up to limit sprints updated after (after, afterID), in that order
+	GetSprintsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*SprintRow, error)
+	// GetAuditDaily counts the audit events that occurred in [from, to) per UTC day
+	GetAuditDaily(ctx context.Context, from, to time.Time) ([]*AuditDailyRow, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCursor(ctx context.Context, stream string) (*Cursor, error) {
+	var cursor Cursor
+	err := transaction.DB(ctx, r.db).Where("stream = ?", stream).First(&cursor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func (r *repository) SaveCursor(ctx context.Context, cursor *Cursor) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "stream"}},
+			DoUpdates: clause.AssignmentColumns([]string{"cursor_at", "cursor_id", "updated_at"}),
+		}).
+		Create(cursor).Error
+}
+
+func (r *repository) GetCardsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*CardRow, error) {
+	var rows []*CardRow
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			c.id, c.board_id, b.project_id, p.organization_id, c.column_id,
+			col.name AS column_name, col.is_done AS done, c.priority::text AS priority,
+			c.story_points, c.original_story_points, c.assignee_id, c.epic_id,
+			c.due_date, c.started_at, c.created_at, c.updated_at
+		FROM cards c
+		JOIN boards b ON b.id = c.board_id
+		JOIN projects p ON p.id = b.project_id
+		JOIN board_columns col ON col.id = c.column_id
+		WHERE (c.updated_at, c.id) > (?, ?)
+		ORDER BY c.updated_at, c.id
+		LIMIT ?
+	`, after, afterID, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repository) GetSprintsAfter(ctx context.Context, after time.Time, afterID uuid.UUID, limit int) ([]*SprintRow, error) {
+	var rows []*SprintRow
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			s.id, s.board_id, b.project_id, p.organization_id, s.name, s.status::text AS status,
+			s.start_date, s.end_date,
+			COUNT(c.id) AS committed_cards,
+			COALESCE(SUM(c.story_points), 0) AS committed_points,
+			COUNT(c.id) FILTER (WHERE col.is_done) AS completed_cards,
+			COALESCE(SUM(c.story_points) FILTER (WHERE col.is_done), 0) AS completed_points,
+			s.created_at, s.updated_at
+		FROM sprints s
+		JOIN boards b ON b.id = s.board_id
+		JOIN projects p ON p.id = b.project_id
+		LEFT JOIN card_sprints cs ON cs.sprint_id = s.id
+		LEFT JOIN cards c ON c.id = cs.card_id
+		LEFT JOIN board_columns col ON col.id = c.column_id
+		WHERE (s.updated_at, s.id) > (?, ?)
+		GROUP BY s.id, b.project_id, p.organization_id
+		ORDER BY s.updated_at, s.id
+		LIMIT ?
+	`, after, afterID, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repository) GetAuditDaily(ctx context.Context, from, to time.Time) ([]*AuditDailyRow, error) {
+	var rows []*AuditDailyRow
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT
+			date_trunc('day', occurred_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+			organization_id, project_id, action::text AS action, entity_type::text AS entity_type,
+			COUNT(*) AS event_count
+		FROM audit_events
+		WHERE occurred_at >= ? AND occurred_at < ?
+		GROUP BY 1, organization_id, project_id, action, entity_type
+		ORDER BY 1
+	`, from, to).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
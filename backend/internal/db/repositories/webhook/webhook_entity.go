@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is an HTTP endpoint that receives an organization's events. Requests are signed
+// with Secret so the receiver can check they came from this server.
+type Webhook struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null"`
+	URL            string     `gorm:"type:text;not null"`
+	Description    string     `gorm:"type:varchar(255);not null;default:''"`
+	Events         EventNames `gorm:"type:jsonb;not null;default:'[]'"`
+	Secret         string     `gorm:"type:varchar(100);not null"`
+	// Disabled webhooks get no new deliveries and their pending ones fail
+	Enabled   bool       `gorm:"not null;default:true"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventNames is a list of event names stored as a JSON array
+type EventNames []string
+
+func (e EventNames) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (e *EventNames) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into EventNames", value)
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed deliveries ran out of attempts
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery is one event sent to one webhook, with the outcome of its last attempt. Body is
+// the request body, kept so every attempt sends the same request.
+type Delivery struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	WebhookID     uuid.UUID       `gorm:"type:uuid;not null"`
+	EventID       uuid.UUID       `gorm:"type:uuid;not null"`
+	EventName     string          `gorm:"type:varchar(100);not null"`
+	Body          json.RawMessage `gorm:"type:jsonb;not null"`
+	Status        DeliveryStatus  `gorm:"type:webhook_delivery_status;not null;default:'pending'"`
+	Attempts      int             `gorm:"type:integer;not null;default:0"`
+	NextAttemptAt time.Time       `gorm:"not null"`
+	LastAttemptAt *time.Time
+	// ResponseStatus is the status of the last response, nil when the last attempt got none.
+	// Response bodies aren't kept, so webhooks can't be used to read other services.
+	ResponseStatus *int    `gorm:"type:integer"`
+	LastError      *string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
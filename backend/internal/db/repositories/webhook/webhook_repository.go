@@ -0,0 +1,213 @@
+package webhook
+
+//go:generate mockgen -source=webhook_repository.go -destination=mocks/webhook_repository_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttemptResult is the outcome of one delivery attempt
+type AttemptResult struct {
+	AttemptedAt    time.Time
+	ResponseStatus *int
+	// Error is nil for successful attempts
+	Error *string
+	// RetryAt schedules the next attempt of a failed one; nil gives up on the delivery
+	RetryAt *time.Time
+}
+
+type Repository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Webhook, error)
+	// GetEnabledByOrgAndEvent returns the organization's enabled webhooks subscribed to the
+	// event
+	GetEnabledByOrgAndEvent(ctx context.Context, orgID uuid.UUID, event string) ([]*Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateDelivery queues a delivery; a delivery of the same event to the same webhook is
+	// left as it is
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	GetDeliveryByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	// GetDeliveries returns one page of the webhook's deliveries, newest first, and how many
+	// there are in total
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*Delivery, int64, error)
+	// ClaimDueDeliveries leases up to limit pending deliveries that are due. Claimed
+	// deliveries count an attempt and aren't due again until the lease expires, so a crashed
+	// sender's deliveries are retried.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+	// RecordAttempt stores the outcome of an attempt: succeeded without an error, pending
+	// again with a retry time, and failed otherwise
+	RecordAttempt(ctx context.Context, id uuid.UUID, result AttemptResult) error
+	// Requeue makes a delivery pending and due at the given time
+	Requeue(ctx context.Context, id uuid.UUID, at time.Time) error
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, webhook *Webhook) error {
+	return transaction.DB(ctx, r.db).Create(webhook).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Webhook, error) {
+	var webhook Webhook
+	if err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *repository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ?", orgID).
+		Order("created_at ASC").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *repository) GetEnabledByOrgAndEvent(ctx context.Context, orgID uuid.UUID, event string) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	err := transaction.DB(ctx, r.db).
+		Where("organization_id = ? AND enabled AND events @> ?::jsonb", orgID, EventNames{event}).
+		Order("created_at ASC").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *repository) Update(ctx context.Context, webhook *Webhook) error {
+	return transaction.DB(ctx, r.db).Save(webhook).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return transaction.DB(ctx, r.db).Delete(&Webhook{}, "id = ?", id).Error
+}
+
+func (r *repository) CreateDelivery(ctx context.Context, delivery *Delivery) error {
+	return transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(delivery).Error
+}
+
+func (r *repository) GetDeliveryByID(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	var delivery Delivery
+	if err := transaction.DB(ctx, r.db).Where("id = ?", id).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *repository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*Delivery, int64, error) {
+	db := transaction.DB(ctx, r.db)
+
+	var total int64
+	if err := db.Model(&Delivery{}).Where("webhook_id = ?", webhookID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []*Delivery
+	err := db.Where("webhook_id = ?", webhookID).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (r *repository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error) {
+	var claimed []*Delivery
+
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// SKIP LOCKED lets several senders claim disjoint batches concurrently
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+			Order("next_attempt_at ASC, created_at ASC").
+			Limit(limit).
+			Find(&claimed).Error
+		if err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(claimed))
+		leaseUntil := now.Add(lease)
+		for i, d := range claimed {
+			ids[i] = d.ID
+			d.Attempts++
+			d.NextAttemptAt = leaseUntil
+		}
+
+		return tx.Model(&Delivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": leaseUntil,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *repository) RecordAttempt(ctx context.Context, id uuid.UUID, result AttemptResult) error {
+	updates := map[string]interface{}{
+		"last_attempt_at": result.AttemptedAt,
+		"response_status": result.ResponseStatus,
+		"last_error":      result.Error,
+	}
+	switch {
+	case result.Error == nil:
+		updates["status"] = DeliverySucceeded
+		updates["delivered_at"] = result.AttemptedAt
+	case result.RetryAt != nil:
+		updates["status"] = DeliveryPending
+		updates["next_attempt_at"] = *result.RetryAt
+	default:
+		updates["status"] = DeliveryFailed
+	}
+	return transaction.DB(ctx, r.db).Model(&Delivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) Requeue(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return transaction.DB(ctx, r.db).Model(&Delivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          DeliveryPending,
+			"next_attempt_at": at,
+		}).Error
+}
+
+func (r *repository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := transaction.DB(ctx, r.db).
+		Where("status <> ? AND created_at < ?", DeliveryPending, before).
+		Delete(&Delivery{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,61 @@
+// Package transaction provides a unit of work spanning several repositories.
+//
+// Manager.WithinTransaction stores the open transaction in the context it hands to the
+// callback. Repositories resolve their connection with DB(ctx, r.db), so every repository
+// call made with that context joins the transaction without any repository knowing about
+// the others. Nested calls reuse the outer transaction.
+package transaction
+
+//go:generate mockgen -source=transaction.go -destination=mocks/transaction_mock.go -package=mocks
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type contextKey struct{}
+
+type Manager interface {
+	// WithinTransaction runs fn in a database transaction, committing when fn returns nil
+	// and rolling back every write made through ctx when it returns an error or panics
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type manager struct {
+	db *gorm.DB
+}
+
+func NewManager(db *gorm.DB) Manager {
+	return &manager{db: db}
+}
+
+func (m *manager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(contextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, contextKey{}, tx))
+	})
+}
+
+// DB returns the transaction bound to ctx, or db when ctx carries none
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(contextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+type noopManager struct{}
+
+// NewNoopManager returns a Manager that runs callbacks directly, for services whose
+// repositories are not backed by a database (unit tests with mocks or fakes)
+func NewNoopManager() Manager {
+	return noopManager{}
+}
+
+func (noopManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,76 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
+	"gorm.io/gorm"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	db := testsupport.NewTestDB(t)
+	manager := transaction.NewManager(db)
+	userRepo := user.NewRepository(db)
+	orgRepo := organization.NewRepository(db)
+	ctx := context.Background()
+
+	t.Run("commits writes from several repositories", func(t *testing.T) {
+		u := &user.User{Username: "tx-commit"}
+		org := &organization.Organization{Name: "Tx Commit", Slug: "tx-commit"}
+
+		err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := userRepo.Create(ctx, u); err != nil {
+				return err
+			}
+			org.OwnerID = u.ID
+			return orgRepo.Create(ctx, org)
+		})
+		require.NoError(t, err)
+
+		_, err = userRepo.GetByID(ctx, u.ID)
+		assert.NoError(t, err)
+		_, err = orgRepo.GetByID(ctx, org.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolls back every write when the callback fails", func(t *testing.T) {
+		u := &user.User{Username: "tx-rollback"}
+		failure := errors.New("second step failed")
+
+		err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := userRepo.Create(ctx, u); err != nil {
+				return err
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+
+		_, err = userRepo.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("nested units of work join the outer transaction", func(t *testing.T) {
+		u := &user.User{Username: "tx-nested"}
+		failure := errors.New("outer failed")
+
+		err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+				return userRepo.Create(ctx, u)
+			}); err != nil {
+				return err
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+
+		_, err = userRepo.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
@@ -1,7 +1,13 @@
 package directives
 
-import "github.com/thatcatdev/kaimu/backend/graph/generated"
+import (
+	"github.com/thatcatdev/kaimu/backend/graph/generated"
+	"github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
 
-func GetDirectives() generated.DirectiveRoot {
-	return generated.DirectiveRoot{}
+func GetDirectives(rbacSvc rbac.Service, invitationSvc invitation.Service) generated.DirectiveRoot {
+	return generated.DirectiveRoot{
+		Sensitive: Sensitive(rbacSvc, invitationSvc),
+	}
 }
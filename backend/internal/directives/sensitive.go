@@ -0,0 +1,79 @@
+package directives
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// Sensitive implements @sensitive. Hidden values are null, or the zero value of non-null
+// fields. Without services only owners see the fields, and objects it doesn't know are
+// always hidden.
+func Sensitive(rbacSvc rbac.Service, invitationSvc invitation.Service) func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	return func(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+		res, err := next(ctx)
+		if err != nil || res == nil {
+			return res, err
+		}
+
+		visible, err := canSee(ctx, rbacSvc, invitationSvc, obj)
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			return res, nil
+		}
+
+		if fc := graphql.GetFieldContext(ctx); fc != nil && fc.Field.Definition != nil && fc.Field.Definition.Type.NonNull {
+			return reflect.Zero(reflect.TypeOf(res)).Interface(), nil
+		}
+		return nil, nil
+	}
+}
+
+// canSee reports whether the current user owns obj or can manage an organization of its owner
+func canSee(ctx context.Context, rbacSvc rbac.Service, invitationSvc invitation.Service, obj interface{}) (bool, error) {
+	viewerID := middleware.GetUserIDFromContext(ctx)
+	if viewerID == nil {
+		return false, nil
+	}
+
+	switch o := obj.(type) {
+	case *model.User:
+		userID, err := uuid.Parse(o.ID)
+		if err != nil {
+			return false, nil
+		}
+		if userID == *viewerID {
+			return true, nil
+		}
+		if rbacSvc == nil {
+			return false, nil
+		}
+		return rbacSvc.CanManageUser(ctx, *viewerID, userID)
+
+	case *model.Invitation:
+		if invitationSvc == nil || rbacSvc == nil {
+			return false, nil
+		}
+		invID, err := uuid.Parse(o.ID)
+		if err != nil {
+			return false, nil
+		}
+		inv, err := invitationSvc.GetInvitation(ctx, invID)
+		if err != nil {
+			return false, err
+		}
+		if inv.InvitedBy == *viewerID {
+			return true, nil
+		}
+		return rbacSvc.HasOrgPermission(ctx, *viewerID, inv.OrganizationID, "org:manage")
+	}
+	return false, nil
+}
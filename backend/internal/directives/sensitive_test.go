@@ -0,0 +1,147 @@
+package directives
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	invitationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeInvitationService returns the invitations it holds
+type fakeInvitationService struct {
+	invitation.Service
+	invitations map[uuid.UUID]*invitationRepo.Invitation
+}
+
+func (f *fakeInvitationService) GetInvitation(ctx context.Context, id uuid.UUID) (*invitationRepo.Invitation, error) {
+	return f.invitations[id], nil
+}
+
+func returning(v interface{}) graphql.Resolver {
+	return func(ctx context.Context) (interface{}, error) {
+		return v, nil
+	}
+}
+
+func asUser(userID uuid.UUID) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func TestSensitive_User(t *testing.T) {
+	ownerID := uuid.New()
+	viewerID := uuid.New()
+	email := "owner@example.com"
+	owner := &model.User{ID: ownerID.String(), Email: &email}
+
+	t.Run("shows the user their own data", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directive := Sensitive(rbacMocks.NewMockService(ctrl), &fakeInvitationService{})
+
+		res, err := directive(asUser(ownerID), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Equal(t, &email, res)
+	})
+
+	t.Run("shows organization admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		rbacSvc := rbacMocks.NewMockService(ctrl)
+		rbacSvc.EXPECT().CanManageUser(gomock.Any(), viewerID, ownerID).Return(true, nil)
+		directive := Sensitive(rbacSvc, &fakeInvitationService{})
+
+		res, err := directive(asUser(viewerID), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Equal(t, &email, res)
+	})
+
+	t.Run("hides from other members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		rbacSvc := rbacMocks.NewMockService(ctrl)
+		rbacSvc.EXPECT().CanManageUser(gomock.Any(), viewerID, ownerID).Return(false, nil)
+		directive := Sensitive(rbacSvc, &fakeInvitationService{})
+
+		res, err := directive(asUser(viewerID), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("hides from anonymous requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directive := Sensitive(rbacMocks.NewMockService(ctrl), &fakeInvitationService{})
+
+		res, err := directive(context.Background(), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("only shows owners without services", func(t *testing.T) {
+		directive := Sensitive(nil, nil)
+
+		res, err := directive(asUser(ownerID), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Equal(t, &email, res)
+
+		res, err = directive(asUser(viewerID), owner, returning(&email))
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSensitive_Invitation(t *testing.T) {
+	inviterID := uuid.New()
+	viewerID := uuid.New()
+	orgID := uuid.New()
+	inv := &invitationRepo.Invitation{ID: uuid.New(), OrganizationID: orgID, InvitedBy: inviterID, Token: "secret"}
+	invitations := &fakeInvitationService{invitations: map[uuid.UUID]*invitationRepo.Invitation{inv.ID: inv}}
+	obj := &model.Invitation{ID: inv.ID.String(), Token: inv.Token}
+
+	// Invitation.token is non-null, so hidden tokens read as an empty string
+	fieldCtx := func(ctx context.Context) context.Context {
+		return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Field: graphql.CollectedField{Field: &ast.Field{
+				Name:       "token",
+				Definition: &ast.FieldDefinition{Name: "token", Type: ast.NonNullNamedType("String", nil)},
+			}},
+		})
+	}
+
+	t.Run("shows the member who sent it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		directive := Sensitive(rbacMocks.NewMockService(ctrl), invitations)
+
+		res, err := directive(fieldCtx(asUser(inviterID)), obj, returning("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, "secret", res)
+	})
+
+	t.Run("shows organization admins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		rbacSvc := rbacMocks.NewMockService(ctrl)
+		rbacSvc.EXPECT().HasOrgPermission(gomock.Any(), viewerID, orgID, "org:manage").Return(true, nil)
+		directive := Sensitive(rbacSvc, invitations)
+
+		res, err := directive(fieldCtx(asUser(viewerID)), obj, returning("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, "secret", res)
+	})
+
+	t.Run("blanks the token for members who may only invite", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		rbacSvc := rbacMocks.NewMockService(ctrl)
+		rbacSvc.EXPECT().HasOrgPermission(gomock.Any(), viewerID, orgID, "org:manage").Return(false, nil)
+		directive := Sensitive(rbacSvc, invitations)
+
+		res, err := directive(fieldCtx(asUser(viewerID)), obj, returning("secret"))
+		require.NoError(t, err)
+		assert.Equal(t, "", res)
+	})
+}
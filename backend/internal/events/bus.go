@@ -0,0 +1,134 @@
+package events
+
+//go:generate mockgen -source=bus.go -destination=mocks/bus_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// Handler reacts to a published event. Returned errors never reach the publisher; they are
+// logged, and events delivered through the outbox are retried. Handlers may therefore see
+// the same event (same ID) more than once and must be idempotent.
+type Handler func(ctx context.Context, event Event) error
+
+type Bus interface {
+	// Publish hands event over for delivery to every handler subscribed to its name.
+	// Publishers call it inside the transaction of the state change; an error means the
+	// event was not recorded and the transaction should roll back.
+	Publish(ctx context.Context, event Event) error
+	// Subscribe registers handler for events with the given name
+	Subscribe(name Name, handler Handler)
+}
+
+// InProcessBus delivers events to handlers registered in the same process
+type InProcessBus struct {
+	mu       sync.RWMutex
+	handlers map[Name][]Handler
+	sync     bool
+	inFlight sync.WaitGroup
+}
+
+// NewBus returns a bus that runs each handler in its own goroutine, so slow consumers
+// never add latency to the request that published the event
+func NewBus() *InProcessBus {
+	return &InProcessBus{handlers: make(map[Name][]Handler)}
+}
+
+// NewSyncBus returns a bus that runs handlers before Publish returns, for tests that
+// assert on consumer side effects
+func NewSyncBus() *InProcessBus {
+	return &InProcessBus{handlers: make(map[Name][]Handler), sync: true}
+}
+
+func (b *InProcessBus) Subscribe(name Name, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers the event immediately. Handler errors are logged, never returned.
+func (b *InProcessBus) Publish(ctx context.Context, event Event) error {
+	handlers := b.handlersFor(event.Name)
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	// Handlers outlive the request, but keep its values (trace, actor)
+	ctx = context.WithoutCancel(ctx)
+
+	for _, handler := range handlers {
+		if b.sync {
+			b.logFailure(ctx, event, b.deliver(ctx, handler, event))
+			continue
+		}
+		b.inFlight.Add(1)
+		go func(handler Handler) {
+			defer b.inFlight.Done()
+			b.logFailure(ctx, event, b.deliver(ctx, handler, event))
+		}(handler)
+	}
+	return nil
+}
+
+// Deliver runs every handler for the event before returning and reports their failures,
+// for callers that retry delivery such as the outbox dispatcher
+func (b *InProcessBus) Deliver(ctx context.Context, event Event) error {
+	var errs []error
+	for _, handler := range b.handlersFor(event.Name) {
+		if err := b.deliver(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *InProcessBus) handlersFor(name Name) []Handler {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]Handler(nil), b.handlers[name]...)
+}
+
+// Drain waits until every in-flight handler has returned or ctx is done, so shutdown does
+// not cut off consumers mid-delivery
+func (b *InProcessBus) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver runs one handler, turning a panic into an error so one consumer cannot take
+// down the others
+func (b *InProcessBus) deliver(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+func (b *InProcessBus) logFailure(ctx context.Context, event Event, err error) {
+	if err == nil {
+		return
+	}
+	log := logger.FromCtx(ctx)
+	log.Error().
+		Str("event", string(event.Name)).
+		Str("event_id", event.ID.String()).
+		Err(err).
+		Msg("event handler failed")
+}
@@ -0,0 +1,157 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcessBus(t *testing.T) {
+	t.Run("delivers only to handlers subscribed to the event name", func(t *testing.T) {
+		bus := NewSyncBus()
+
+		var moved, created []Event
+		bus.Subscribe(CardMoved, func(ctx context.Context, e Event) error {
+			moved = append(moved, e)
+			return nil
+		})
+		bus.Subscribe(CardCreated, func(ctx context.Context, e Event) error {
+			created = append(created, e)
+			return nil
+		})
+
+		cardID := uuid.New()
+		bus.Publish(context.Background(), New(context.Background(), CardMoved, CardMovedPayload{CardID: cardID}))
+
+		require.Len(t, moved, 1)
+		assert.Empty(t, created)
+		assert.Equal(t, cardID, moved[0].Payload.(CardMovedPayload).CardID)
+	})
+
+	t.Run("a failing or panicking handler does not stop the others", func(t *testing.T) {
+		bus := NewSyncBus()
+
+		calls := 0
+		bus.Subscribe(CardDeleted, func(ctx context.Context, e Event) error {
+			return errors.New("boom")
+		})
+		bus.Subscribe(CardDeleted, func(ctx context.Context, e Event) error {
+			panic("boom")
+		})
+		bus.Subscribe(CardDeleted, func(ctx context.Context, e Event) error {
+			calls++
+			return nil
+		})
+
+		err := bus.Publish(context.Background(), New(context.Background(), CardDeleted, CardPayload{}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("async handlers outlive the publishing request", func(t *testing.T) {
+		bus := NewBus()
+
+		var mu sync.Mutex
+		var ctxErr error
+		delivered := false
+		release := make(chan struct{})
+		bus.Subscribe(SprintCompleted, func(ctx context.Context, e Event) error {
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			ctxErr = ctx.Err()
+			delivered = true
+			return nil
+		})
+
+		reqCtx, cancel := context.WithCancel(context.Background())
+		bus.Publish(reqCtx, New(reqCtx, SprintCompleted, SprintCompletedPayload{}))
+		cancel()
+		close(release)
+
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), time.Second)
+		defer drainCancel()
+		require.NoError(t, bus.Drain(drainCtx))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.True(t, delivered)
+		assert.NoError(t, ctxErr)
+	})
+
+	t.Run("Deliver reports handler failures", func(t *testing.T) {
+		bus := NewBus()
+
+		calls := 0
+		bus.Subscribe(BoardCreated, func(ctx context.Context, e Event) error {
+			calls++
+			return errors.New("index unavailable")
+		})
+		bus.Subscribe(BoardCreated, func(ctx context.Context, e Event) error {
+			calls++
+			panic("boom")
+		})
+
+		err := bus.Deliver(context.Background(), New(context.Background(), BoardCreated, BoardPayload{}))
+
+		assert.Equal(t, 2, calls)
+		assert.ErrorContains(t, err, "index unavailable")
+		assert.ErrorContains(t, err, "panicked")
+		assert.NoError(t, bus.Deliver(context.Background(), New(context.Background(), BoardDeleted, BoardPayload{})))
+	})
+
+	t.Run("events carry the actor from the context", func(t *testing.T) {
+		userID := uuid.New()
+		ctx := WithActor(context.Background(), userID)
+
+		e := New(ctx, MemberAdded, MemberAddedPayload{})
+
+		require.NotNil(t, e.ActorID)
+		assert.Equal(t, userID, *e.ActorID)
+		assert.Nil(t, New(context.Background(), MemberAdded, MemberAddedPayload{}).ActorID)
+	})
+}
+
+func TestDecodePayload(t *testing.T) {
+	t.Run("round-trips every payload type", func(t *testing.T) {
+		nextSprintID := uuid.New()
+		payloads := map[Name]any{
+			CardMoved:       CardMovedPayload{CardID: uuid.New(), BoardID: uuid.New(), FromColumnID: uuid.New(), ToColumnID: uuid.New()},
+			CardCreated:     CardPayload{CardID: uuid.New(), BoardID: uuid.New(), ColumnID: uuid.New()},
+			BoardDeleted:    BoardPayload{BoardID: uuid.New(), ProjectID: uuid.New()},
+			ProjectUpdated:  ProjectPayload{ProjectID: uuid.New(), OrganizationID: uuid.New()},
+			SprintCompleted: SprintCompletedPayload{SprintID: uuid.New(), BoardID: uuid.New(), NextSprintID: &nextSprintID},
+			MemberAdded:     MemberAddedPayload{OrganizationID: uuid.New(), UserID: uuid.New()},
+			OperationUndone: OperationUndonePayload{OperationID: uuid.New(), Kind: "complete_sprint", BoardID: uuid.New(), EntityID: uuid.New()},
+			CardSLABreached: SLABreachedPayload{BreachID: uuid.New(), PolicyID: uuid.New(), CardID: uuid.New(), BoardID: uuid.New(), ProjectID: uuid.New()},
+			OrganizationMerged: OrganizationMergedPayload{
+				SourceOrganizationID: uuid.New(),
+				TargetOrganizationID: uuid.New(),
+				ProjectIDs:           []uuid.UUID{uuid.New()},
+				UserIDs:              []uuid.UUID{uuid.New()},
+			},
+		}
+
+		for name, payload := range payloads {
+			data, err := json.Marshal(payload)
+			require.NoError(t, err)
+
+			decoded, err := DecodePayload(name, data)
+			require.NoError(t, err)
+			assert.Equal(t, payload, decoded, name)
+		}
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := DecodePayload("card.exploded", []byte(`{}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// decoders maps each event name to its payload type, so events that were serialized
+// (e.g. into the outbox) come back with the same Payload type the publisher used
+var decoders = map[Name]func(data []byte) (any, error){
+	CardCreated:     decodeAs[CardPayload],
+	CardUpdated:     decodeAs[CardPayload],
+	CardMoved:       decodeAs[CardMovedPayload],
+	CardDeleted:     decodeAs[CardPayload],
+	CardArchived:    decodeAs[CardPayload],
+	CardSLABreached: decodeAs[SLABreachedPayload],
+	BoardCreated:    decodeAs[BoardPayload],
+	BoardUpdated:    decodeAs[BoardPayload],
+	BoardDeleted:    decodeAs[BoardPayload],
+	ProjectCreated:  decodeAs[ProjectPayload],
+	ProjectUpdated:  decodeAs[ProjectPayload],
+	ProjectDeleted:  decodeAs[ProjectPayload],
+	SprintStarted:   decodeAs[SprintStartedPayload],
+	SprintCompleted: decodeAs[SprintCompletedPayload],
+	MemberAdded:     decodeAs[MemberAddedPayload],
+	MemberInvited:   decodeAs[MemberInvitedPayload],
+	OperationUndone: decodeAs[OperationUndonePayload],
+
+	OrganizationMerged: decodeAs[OrganizationMergedPayload],
+	BoardCardsArchived: decodeAs[CardsArchivedPayload],
+
+	AuditAnomalyDetected: decodeAs[AuditAnomalyPayload],
+	ColumnAlertRaised:    decodeAs[ColumnAlertPayload],
+
+	CardAssigned:     decodeAs[CardAssignedPayload],
+	CommentMentioned: decodeAs[CommentMentionedPayload],
+}
+
+// DecodePayload restores the payload of a serialized event
+func DecodePayload(name Name, data []byte) (any, error) {
+	decode, ok := decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+	return decode(data)
+}
+
+func decodeAs[T any](data []byte) (any, error) {
+	var payload T
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
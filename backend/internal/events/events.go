@@ -0,0 +1,219 @@
+// Package events is the in-process domain event bus.
+//
+// Services publish an Event after a state change has been written. Side effects that used
+// to be wired into resolvers or called service-to-service (search indexing, metrics
+// snapshots, and later webhooks and notifications) subscribe to the names they care about,
+// so adding a new consumer never touches the publishing service.
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Name identifies the kind of an event, e.g. "card.moved"
+type Name string
+
+const (
+	CardCreated Name = "card.created"
+	CardUpdated Name = "card.updated"
+	CardMoved   Name = "card.moved"
+	CardDeleted Name = "card.deleted"
+	// CardArchived takes a card off its board; unarchiving publishes card.updated
+	CardArchived Name = "card.archived"
+
+	CardSLABreached Name = "card.sla_breached"
+
+	// CardAssigned follows the card.created or card.updated that gave a card a new assignee
+	CardAssigned Name = "card.assigned"
+
+	// CommentMentioned is a comment that @mentioned members for the first time
+	CommentMentioned Name = "comment.mentioned"
+
+	BoardCreated Name = "board.created"
+	BoardUpdated Name = "board.updated"
+	BoardDeleted Name = "board.deleted"
+
+	// BoardCardsArchived follows the card.archived events of one auto-archival run
+	BoardCardsArchived Name = "board.cards_archived"
+
+	ProjectCreated Name = "project.created"
+	ProjectUpdated Name = "project.updated"
+	ProjectDeleted Name = "project.deleted"
+
+	SprintStarted   Name = "sprint.started"
+	SprintCompleted Name = "sprint.completed"
+
+	MemberAdded   Name = "member.added"
+	MemberInvited Name = "member.invited"
+
+	OrganizationMerged Name = "organization.merged"
+
+	OperationUndone Name = "operation.undone"
+
+	AuditAnomalyDetected Name = "audit.anomaly_detected"
+
+	// ColumnAlertRaised is a column that stayed over its WIP limit or whose cards grew old
+	ColumnAlertRaised Name = "column.alert_raised"
+)
+
+// Event is a fact about something that has already happened
+type Event struct {
+	ID         uuid.UUID
+	Name       Name
+	OccurredAt time.Time
+	// ActorID is the user whose request caused the event, nil for system actions
+	ActorID *uuid.UUID
+	// Payload is one of the *Payload types below, matching Name
+	Payload any
+}
+
+// New creates an event, taking the actor from ctx
+func New(ctx context.Context, name Name, payload any) Event {
+	return Event{
+		ID:         uuid.New(),
+		Name:       name,
+		OccurredAt: time.Now(),
+		ActorID:    ActorFromContext(ctx),
+		Payload:    payload,
+	}
+}
+
+// CardPayload is carried by card.created, card.updated, card.deleted and card.archived
+type CardPayload struct {
+	CardID   uuid.UUID `json:"card_id"`
+	BoardID  uuid.UUID `json:"board_id"`
+	ColumnID uuid.UUID `json:"column_id"`
+}
+
+// CardMovedPayload is carried by card.moved
+type CardMovedPayload struct {
+	CardID       uuid.UUID `json:"card_id"`
+	BoardID      uuid.UUID `json:"board_id"`
+	FromColumnID uuid.UUID `json:"from_column_id"`
+	ToColumnID   uuid.UUID `json:"to_column_id"`
+}
+
+// CardAssignedPayload is carried by card.assigned
+type CardAssignedPayload struct {
+	CardID     uuid.UUID `json:"card_id"`
+	BoardID    uuid.UUID `json:"board_id"`
+	AssigneeID uuid.UUID `json:"assignee_id"`
+}
+
+// CommentMentionedPayload is carried by comment.mentioned
+type CommentMentionedPayload struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	CardID    uuid.UUID `json:"card_id"`
+	BoardID   uuid.UUID `json:"board_id"`
+	// UserIDs are the members mentioned in the comment for the first time
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+// SLABreachedPayload is carried by card.sla_breached
+type SLABreachedPayload struct {
+	BreachID  uuid.UUID `json:"breach_id"`
+	PolicyID  uuid.UUID `json:"policy_id"`
+	CardID    uuid.UUID `json:"card_id"`
+	BoardID   uuid.UUID `json:"board_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// BoardPayload is carried by board.created, board.updated and board.deleted
+type BoardPayload struct {
+	BoardID   uuid.UUID `json:"board_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// CardsArchivedPayload is carried by board.cards_archived
+type CardsArchivedPayload struct {
+	RunID     uuid.UUID `json:"run_id"`
+	BoardID   uuid.UUID `json:"board_id"`
+	CardCount int       `json:"card_count"`
+}
+
+// ProjectPayload is carried by project.created, project.updated and project.deleted
+type ProjectPayload struct {
+	ProjectID      uuid.UUID `json:"project_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+// SprintStartedPayload is carried by sprint.started
+type SprintStartedPayload struct {
+	SprintID uuid.UUID `json:"sprint_id"`
+	BoardID  uuid.UUID `json:"board_id"`
+}
+
+// SprintCompletedPayload is carried by sprint.completed
+type SprintCompletedPayload struct {
+	SprintID uuid.UUID `json:"sprint_id"`
+	BoardID  uuid.UUID `json:"board_id"`
+	// NextSprintID is the sprint unfinished cards were moved to, if any
+	NextSprintID *uuid.UUID `json:"next_sprint_id,omitempty"`
+}
+
+// MemberAddedPayload is carried by member.added
+type MemberAddedPayload struct {
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	RoleID         *uuid.UUID `json:"role_id,omitempty"`
+}
+
+// MemberInvitedPayload is carried by member.invited
+type MemberInvitedPayload struct {
+	InvitationID   uuid.UUID  `json:"invitation_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Email          string     `json:"email"`
+	RoleID         *uuid.UUID `json:"role_id,omitempty"`
+	ProjectID      *uuid.UUID `json:"project_id,omitempty"`
+	IsGuest        bool       `json:"is_guest"`
+}
+
+// OrganizationMergedPayload is carried by organization.merged, published after the source
+// organization's members and projects moved to the target and the source was deleted
+type OrganizationMergedPayload struct {
+	SourceOrganizationID uuid.UUID   `json:"source_organization_id"`
+	TargetOrganizationID uuid.UUID   `json:"target_organization_id"`
+	ProjectIDs           []uuid.UUID `json:"project_ids"`
+	// UserIDs are the members that joined the target organization
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+// OperationUndonePayload is carried by operation.undone
+type OperationUndonePayload struct {
+	OperationID uuid.UUID `json:"operation_id"`
+	Kind        string    `json:"kind"`
+	BoardID     uuid.UUID `json:"board_id"`
+	EntityID    uuid.UUID `json:"entity_id"`
+}
+
+// AuditAnomalyPayload is carried by audit.anomaly_detected
+type AuditAnomalyPayload struct {
+	AnomalyID      uuid.UUID `json:"anomaly_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+// ColumnAlertPayload is carried by column.alert_raised
+type ColumnAlertPayload struct {
+	AlertID  uuid.UUID `json:"alert_id"`
+	BoardID  uuid.UUID `json:"board_id"`
+	ColumnID uuid.UUID `json:"column_id"`
+}
+
+type actorKey struct{}
+
+// WithActor records the user acting in ctx so events published with it carry the actor
+func WithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the user recorded by WithActor, or nil
+func ActorFromContext(ctx context.Context) *uuid.UUID {
+	userID, ok := ctx.Value(actorKey{}).(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bus.go
+//
+// Generated by this command:
+//
+//	mockgen -source=bus.go -destination=mocks/bus_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	events "github.com/thatcatdev/kaimu/backend/internal/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBus is a mock of Bus interface.
+type MockBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusMockRecorder
+	isgomock struct{}
+}
+
+// MockBusMockRecorder is the mock recorder for MockBus.
+type MockBusMockRecorder struct {
+	mock *MockBus
+}
+
+// NewMockBus creates a new mock instance.
+func NewMockBus(ctrl *gomock.Controller) *MockBus {
+	mock := &MockBus{ctrl: ctrl}
+	mock.recorder = &MockBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBus) EXPECT() *MockBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockBus) Publish(ctx context.Context, event events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockBusMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockBus)(nil).Publish), ctx, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockBus) Subscribe(name events.Name, handler events.Handler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", name, handler)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBusMockRecorder) Subscribe(name, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBus)(nil).Subscribe), name, handler)
+}
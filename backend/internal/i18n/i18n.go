@@ -0,0 +1,167 @@
+// Package i18n translates text the server writes for people: emails, notification
+// messages and validation errors. Message catalogs are JSON files embedded in the
+// binary, one per locale, mapping message keys to text with {name} placeholders.
+// Missing translations fall back to English, then to the key itself.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is used when neither the user nor their organization chose a locale
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	catalogs = mustLoadCatalogs()
+	// matchOrder lists the locales in the order the matcher knows them, default first
+	matchOrder = matcherLocales()
+	matcher    = newMatcher(matchOrder)
+)
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read catalogs: %v", err))
+	}
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read catalog %s: %v", entry.Name(), err))
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("i18n: missing catalog for the default locale")
+	}
+	return loaded
+}
+
+func matcherLocales() []string {
+	locales := []string{DefaultLocale}
+	for _, locale := range Supported() {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// newMatcher matches requested languages against the catalogs; the first locale is the
+// fallback when nothing matches well
+func newMatcher(locales []string) language.Matcher {
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.Make(locale)
+	}
+	return language.NewMatcher(tags)
+}
+
+// Supported returns the locales that have a catalog, sorted
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// IsSupported reports whether locale has a catalog
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Normalize maps a BCP 47 tag such as "es-MX" to the supported locale serving it, or ""
+// when no catalog covers the language
+func Normalize(tag string) string {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return ""
+	}
+	return match(t)
+}
+
+// MatchAcceptLanguage picks the supported locale best serving an Accept-Language header,
+// or "" when the header names no supported language
+func MatchAcceptLanguage(header string) string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	return match(tags...)
+}
+
+func match(tags ...language.Tag) string {
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+	return matchOrder[index]
+}
+
+// Resolve returns the first candidate that is a supported locale, in order of preference,
+// or DefaultLocale
+func Resolve(candidates ...string) string {
+	for _, candidate := range candidates {
+		if IsSupported(candidate) {
+			return candidate
+		}
+	}
+	return DefaultLocale
+}
+
+// T returns the message for key in locale with its {name} placeholders replaced by args
+func T(locale, key string, args map[string]string) string {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(args)*2)
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context whose messages are written in locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the context's locale, or DefaultLocale when none was set
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Tc is T in the context's locale
+func Tc(ctx context.Context, key string, args map[string]string) string {
+	return T(FromContext(ctx), key, args)
+}
@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for _, locale := range Supported() {
+		for key := range catalogs[DefaultLocale] {
+			assert.Contains(t, catalogs[locale], key, "%s catalog is missing %s", locale, key)
+		}
+		for key := range catalogs[locale] {
+			assert.Contains(t, catalogs[DefaultLocale], key, "%s catalog has unknown key %s", locale, key)
+		}
+	}
+}
+
+func TestCatalogsKeepPlaceholders(t *testing.T) {
+	for _, locale := range Supported() {
+		for key, message := range catalogs[DefaultLocale] {
+			for _, part := range strings.Split(message, "{")[1:] {
+				placeholder := "{" + part[:strings.Index(part, "}")+1]
+				assert.Contains(t, catalogs[locale][key], placeholder, "%s: %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Run("replaces placeholders", func(t *testing.T) {
+		assert.Equal(t, "Te han invitado a unirte a Acme", T("es", "email.invitation.subject", map[string]string{"organization": "Acme"}))
+	})
+
+	t.Run("falls back to English for unknown locales", func(t *testing.T) {
+		assert.Equal(t, "1 hour", T("fr", "duration.hour", nil))
+	})
+
+	t.Run("returns the key for unknown messages", func(t *testing.T) {
+		assert.Equal(t, "no.such.key", T("en", "no.such.key", nil))
+	})
+
+	t.Run("does not expand placeholders inside arguments", func(t *testing.T) {
+		got := T("en", "notification.card_created", map[string]string{"card": "{project}", "project": "Platform"})
+		assert.Equal(t, "{project} was created in Platform", got)
+	})
+}
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"es-MX,es;q=0.9,en;q=0.8", "es"},
+		{"de-DE", "de"},
+		{"fr-FR,de;q=0.5", "de"},
+		{"en-GB", "en"},
+		{"ja", ""},
+		{"", ""},
+		{"not a header;;", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "es", Normalize("es-AR"))
+	assert.Equal(t, "en", Normalize("en"))
+	assert.Equal(t, "", Normalize("xx-invalid-tag"))
+	assert.Equal(t, "", Normalize("ja"))
+}
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, "de", Resolve("", "fr", "de", "es"))
+	assert.Equal(t, DefaultLocale, Resolve("", "fr"))
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, DefaultLocale, FromContext(context.Background()))
+	ctx := WithLocale(context.Background(), "de")
+	assert.Equal(t, "de", FromContext(ctx))
+	assert.Equal(t, "1 Stunde", Tc(ctx, "duration.hour", nil))
+}
@@ -0,0 +1,38 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+// Budget is the latency and error budget a scenario must stay within
+type Budget struct {
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	// MinSuccess is the minimum ratio of non-error responses, between 0 and 1
+	MinSuccess float64
+}
+
+// Violations returns a description of every budget limit exceeded by the metrics.
+// Zero limits are not enforced.
+func (b Budget) Violations(m *vegeta.Metrics) []string {
+	var violations []string
+
+	check := func(name string, limit, actual time.Duration) {
+		if limit > 0 && actual > limit {
+			violations = append(violations, fmt.Sprintf("%s latency %s exceeds budget %s", name, actual, limit))
+		}
+	}
+	check("p50", b.P50, m.Latencies.P50)
+	check("p95", b.P95, m.Latencies.P95)
+	check("p99", b.P99, m.Latencies.P99)
+
+	if b.MinSuccess > 0 && m.Success < b.MinSuccess {
+		violations = append(violations, fmt.Sprintf("success ratio %.2f%% below budget %.2f%%", m.Success*100, b.MinSuccess*100))
+	}
+
+	return violations
+}
@@ -0,0 +1,72 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const loginMutation = `mutation Login($input: LoginInput!) { login(input: $input) { user { id } } }`
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLBody encodes a GraphQL request body
+func graphQLBody(query string, variables map[string]any) []byte {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		// Variables are built from strings and IDs only, so this cannot fail
+		panic(err)
+	}
+	return body
+}
+
+// Login authenticates against the API and returns the Cookie header carrying the session
+func Login(ctx context.Context, client *http.Client, graphqlURL, username, password string) (string, error) {
+	body := graphQLBody(loginMutation, map[string]any{
+		"input": map[string]any{"username": username, "password": password},
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphqlURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode login response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return "", fmt.Errorf("login failed: %s", result.Errors[0].Message)
+	}
+
+	var cookie bytes.Buffer
+	for _, c := range resp.Cookies() {
+		if cookie.Len() > 0 {
+			cookie.WriteString("; ")
+		}
+		cookie.WriteString(c.Name + "=" + c.Value)
+	}
+	if cookie.Len() == 0 {
+		return "", fmt.Errorf("login response did not set any cookies")
+	}
+
+	return cookie.String(), nil
+}
@@ -0,0 +1,214 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// DatasetPassword is the password of the user created by GenerateDataset
+const DatasetPassword = "loadtest-password"
+
+const cardBatchSize = 500
+
+var datasetColumns = []struct {
+	name      string
+	isBacklog bool
+	isDone    bool
+}{
+	{name: "Backlog", isBacklog: true},
+	{name: "Todo"},
+	{name: "In Progress"},
+	{name: "Review"},
+	{name: "Done", isDone: true},
+}
+
+var titleVerbs = []string{"Fix", "Add", "Refactor", "Document", "Optimize", "Remove", "Migrate", "Investigate"}
+var titleNouns = []string{"login", "billing", "search", "notifications", "export", "dashboard", "onboarding", "permissions", "webhooks", "reports"}
+
+// DatasetSpec sizes the generated dataset
+type DatasetSpec struct {
+	Projects         int
+	BoardsPerProject int
+	CardsPerBoard    int
+}
+
+// DefaultDatasetSpec produces roughly 20k cards, large enough to surface slow queries
+var DefaultDatasetSpec = DatasetSpec{
+	Projects:         10,
+	BoardsPerProject: 2,
+	CardsPerBoard:    1000,
+}
+
+// DatasetBoard is a generated board and the IDs scenarios pick from
+type DatasetBoard struct {
+	ID        uuid.UUID
+	ColumnIDs []uuid.UUID
+	CardIDs   []uuid.UUID
+}
+
+// Dataset describes everything GenerateDataset created
+type Dataset struct {
+	Username       string
+	Password       string
+	OrganizationID uuid.UUID
+	Boards         []DatasetBoard
+	SearchTerms    []string
+}
+
+// GenerateDataset seeds a new user owning an organization with projects, boards and cards.
+// Cards are inserted in batches and are not indexed for search; run the index command
+// afterwards for the search scenario.
+func GenerateDataset(ctx context.Context, db *gorm.DB, spec DatasetSpec) (*Dataset, error) {
+	if spec.Projects < 1 || spec.BoardsPerProject < 1 || spec.CardsPerBoard < 1 {
+		return nil, fmt.Errorf("dataset needs at least one project, board and card")
+	}
+
+	runID := strings.Split(uuid.NewString(), "-")[0]
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(DatasetPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash := string(hash)
+	email := fmt.Sprintf("loadtest-%s@example.com", runID)
+	u := &user.User{
+		Username:      "loadtest-" + runID,
+		Email:         &email,
+		PasswordHash:  &passwordHash,
+		EmailVerified: true,
+	}
+	if err := user.NewRepository(db).Create(ctx, u); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	org := &organization.Organization{
+		Name:    "Load Test " + runID,
+		Slug:    "load-test-" + runID,
+		OwnerID: u.ID,
+	}
+	if err := organization.NewRepository(db).Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	ownerRoleID := role.OwnerRoleID
+	if err := organization_member.NewRepository(db).Create(ctx, &organization_member.OrganizationMember{
+		OrganizationID: org.ID,
+		UserID:         u.ID,
+		Role:           "owner",
+		RoleID:         &ownerRoleID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create organization member: %w", err)
+	}
+
+	dataset := &Dataset{
+		Username:       u.Username,
+		Password:       DatasetPassword,
+		OrganizationID: org.ID,
+		SearchTerms:    titleNouns,
+	}
+
+	projectRepo := project.NewRepository(db)
+	boardRepo := board.NewRepository(db)
+	columnRepo := board_column.NewRepository(db)
+
+	for p := 0; p < spec.Projects; p++ {
+		proj := &project.Project{
+			OrganizationID: org.ID,
+			Name:           fmt.Sprintf("Project %d", p+1),
+			Key:            fmt.Sprintf("LT%d", p+1),
+		}
+		if err := projectRepo.Create(ctx, proj); err != nil {
+			return nil, fmt.Errorf("failed to create project: %w", err)
+		}
+
+		for b := 0; b < spec.BoardsPerProject; b++ {
+			brd := &board.Board{
+				ProjectID: proj.ID,
+				Name:      fmt.Sprintf("Board %d", b+1),
+				IsDefault: b == 0,
+			}
+			if err := boardRepo.Create(ctx, brd); err != nil {
+				return nil, fmt.Errorf("failed to create board: %w", err)
+			}
+
+			generated := DatasetBoard{ID: brd.ID}
+			for i, c := range datasetColumns {
+				col := &board_column.BoardColumn{
+					BoardID:   brd.ID,
+					Name:      c.name,
+					Position:  i,
+					IsBacklog: c.isBacklog,
+					IsHidden:  c.isBacklog,
+					IsDone:    c.isDone,
+					Color:     "#6B7280",
+				}
+				if err := columnRepo.Create(ctx, col); err != nil {
+					return nil, fmt.Errorf("failed to create column: %w", err)
+				}
+				generated.ColumnIDs = append(generated.ColumnIDs, col.ID)
+			}
+
+			cardIDs, err := generateCards(ctx, db, brd.ID, generated.ColumnIDs, spec.CardsPerBoard, u.ID)
+			if err != nil {
+				return nil, err
+			}
+			generated.CardIDs = cardIDs
+
+			dataset.Boards = append(dataset.Boards, generated)
+		}
+	}
+
+	return dataset, nil
+}
+
+func generateCards(ctx context.Context, db *gorm.DB, boardID uuid.UUID, columnIDs []uuid.UUID, count int, userID uuid.UUID) ([]uuid.UUID, error) {
+	priorities := []card.CardPriority{card.PriorityNone, card.PriorityLow, card.PriorityMedium, card.PriorityHigh, card.PriorityUrgent}
+
+	// The cards are inserted in batches rather than through Create, so they are numbered here
+	first, err := card.NewRepository(db).ReserveNumbers(ctx, boardID, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to number cards: %w", err)
+	}
+
+	cards := make([]*card.Card, count)
+	for i := range cards {
+		points := rand.IntN(8) + 1
+		cards[i] = &card.Card{
+			ID:          uuid.New(),
+			BoardID:     boardID,
+			ColumnID:    columnIDs[i%len(columnIDs)],
+			Number:      first + i,
+			Title:       fmt.Sprintf("%s %s #%d", titleVerbs[rand.IntN(len(titleVerbs))], titleNouns[rand.IntN(len(titleNouns))], i+1),
+			Description: "Generated by the load test dataset",
+			Position:    float64(i/len(columnIDs)+1) * 1000,
+			Priority:    priorities[rand.IntN(len(priorities))],
+			StoryPoints: &points,
+			AssigneeID:  &userID,
+			CreatedBy:   &userID,
+		}
+	}
+
+	if err := db.WithContext(ctx).CreateInBatches(cards, cardBatchSize).Error; err != nil {
+		return nil, fmt.Errorf("failed to create cards: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(cards))
+	for i, c := range cards {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
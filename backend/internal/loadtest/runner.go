@@ -0,0 +1,71 @@
+// Package loadtest drives scripted GraphQL workloads against a running API and checks
+// the observed latencies against per-scenario budgets.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+// Result is the outcome of running a single scenario
+type Result struct {
+	Scenario   Scenario
+	Metrics    vegeta.Metrics
+	Violations []string
+}
+
+// Passed reports whether the scenario stayed within its budget
+func (r *Result) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// Run attacks the target with the scenario's workload until its duration elapses or ctx
+// is cancelled. Responses carrying GraphQL errors are recorded as failures even though
+// they are served with status 200.
+func Run(ctx context.Context, target *Target, scenario Scenario) *Result {
+	attacker := vegeta.NewAttacker(vegeta.Timeout(10 * time.Second))
+
+	results := attacker.Attack(scenario.Targeter(target), scenario.Rate, scenario.Duration, scenario.Name)
+
+	var metrics vegeta.Metrics
+	done := ctx.Done()
+loop:
+	for {
+		select {
+		case <-done:
+			attacker.Stop()
+			done = nil
+		case res, ok := <-results:
+			if !ok {
+				break loop
+			}
+			if msg := graphQLError(res.Body); msg != "" {
+				res.Code = 0
+				res.Error = msg
+			}
+			metrics.Add(res)
+		}
+	}
+	metrics.Close()
+
+	return &Result{
+		Scenario:   scenario,
+		Metrics:    metrics,
+		Violations: scenario.Budget.Violations(&metrics),
+	}
+}
+
+func graphQLError(body []byte) string {
+	if !bytes.Contains(body, []byte(`"errors"`)) {
+		return ""
+	}
+	var resp graphQLResponse
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Errors) == 0 {
+		return ""
+	}
+	return "graphql: " + resp.Errors[0].Message
+}
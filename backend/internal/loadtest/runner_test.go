@@ -0,0 +1,113 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+func testDataset() *Dataset {
+	return &Dataset{
+		Boards: []DatasetBoard{{
+			ID:        uuid.New(),
+			ColumnIDs: []uuid.UUID{uuid.New(), uuid.New()},
+			CardIDs:   []uuid.UUID{uuid.New(), uuid.New(), uuid.New()},
+		}},
+		SearchTerms: []string{"login"},
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("sends authenticated GraphQL requests and passes budget", func(t *testing.T) {
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			assert.Equal(t, "kaimu_access_token=abc", r.Header.Get("Cookie"))
+
+			var req graphQLRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Contains(t, req.Query, "moveCard")
+
+			_, _ = io.WriteString(w, `{"data":{"moveCard":{"id":"1"}}}`)
+		}))
+		defer server.Close()
+
+		scenario, ok := ScenarioByName("card-moves")
+		require.True(t, ok)
+		scenario.Rate = vegeta.Rate{Freq: 50, Per: time.Second}
+		scenario.Duration = 200 * time.Millisecond
+
+		target := &Target{GraphQLURL: server.URL, Cookie: "kaimu_access_token=abc", Dataset: testDataset()}
+		result := Run(context.Background(), target, scenario)
+
+		assert.True(t, result.Passed(), result.Violations)
+		assert.Equal(t, uint64(requests.Load()), result.Metrics.Requests)
+		assert.Equal(t, 1.0, result.Metrics.Success)
+	})
+
+	t.Run("GraphQL errors count as failures", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"errors":[{"message":"unauthorized"}],"data":null}`)
+		}))
+		defer server.Close()
+
+		scenario, ok := ScenarioByName("search")
+		require.True(t, ok)
+		scenario.Rate = vegeta.Rate{Freq: 50, Per: time.Second}
+		scenario.Duration = 100 * time.Millisecond
+
+		result := Run(context.Background(), &Target{GraphQLURL: server.URL, Dataset: testDataset()}, scenario)
+
+		assert.False(t, result.Passed())
+		assert.Equal(t, 0.0, result.Metrics.Success)
+		assert.Contains(t, result.Metrics.Errors, "graphql: unauthorized")
+	})
+}
+
+func TestBudgetViolations(t *testing.T) {
+	metrics := &vegeta.Metrics{Success: 0.95}
+	metrics.Latencies.P50 = 50 * time.Millisecond
+	metrics.Latencies.P95 = 500 * time.Millisecond
+	metrics.Latencies.P99 = time.Second
+
+	t.Run("within budget", func(t *testing.T) {
+		budget := Budget{P50: 100 * time.Millisecond, P95: time.Second, P99: 2 * time.Second, MinSuccess: 0.9}
+		assert.Empty(t, budget.Violations(metrics))
+	})
+
+	t.Run("exceeded limits are reported", func(t *testing.T) {
+		budget := Budget{P50: 100 * time.Millisecond, P95: 200 * time.Millisecond, P99: 2 * time.Second, MinSuccess: 0.99}
+		violations := budget.Violations(metrics)
+		require.Len(t, violations, 2)
+		assert.True(t, strings.HasPrefix(violations[0], "p95"))
+		assert.True(t, strings.HasPrefix(violations[1], "success ratio"))
+	})
+
+	t.Run("zero limits are not enforced", func(t *testing.T) {
+		assert.Empty(t, Budget{}.Violations(metrics))
+	})
+}
+
+func TestLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "kaimu_access_token", Value: "access"})
+		http.SetCookie(w, &http.Cookie{Name: "kaimu_refresh_token", Value: "refresh"})
+		_, _ = io.WriteString(w, `{"data":{"login":{"user":{"id":"1"}}}}`)
+	}))
+	defer server.Close()
+
+	cookie, err := Login(context.Background(), server.Client(), server.URL, "user", "pass")
+	require.NoError(t, err)
+	assert.Equal(t, "kaimu_access_token=access; kaimu_refresh_token=refresh", cookie)
+}
@@ -0,0 +1,135 @@
+package loadtest
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+const boardQuery = `query Board($id: ID!) {
+	board(id: $id) {
+		id
+		name
+		columns {
+			id
+			name
+			cards { id title priority position storyPoints assignee { id } tags { id name } }
+		}
+	}
+}`
+
+const moveCardMutation = `mutation MoveCard($input: MoveCardInput!) {
+	moveCard(input: $input) { id position column { id } }
+}`
+
+const searchQuery = `query Search($query: String!) {
+	search(query: $query, limit: 20) { totalCount results { id type title } }
+}`
+
+// Target is the API under test together with the seeded data the scenarios act on
+type Target struct {
+	GraphQLURL string
+	// Cookie carries the authenticated session returned by Login
+	Cookie  string
+	Dataset *Dataset
+}
+
+// Scenario is a scripted workload with the budget it must meet
+type Scenario struct {
+	Name        string
+	Description string
+	Rate        vegeta.Rate
+	Duration    time.Duration
+	Budget      Budget
+	Targeter    func(t *Target) vegeta.Targeter
+}
+
+// Scenarios returns the built-in scenarios. Budgets are sized for a local compose stack.
+func Scenarios() []Scenario {
+	return []Scenario{
+		{
+			Name:        "board-load",
+			Description: "Load full boards with columns, cards, assignees and tags",
+			Rate:        vegeta.Rate{Freq: 50, Per: time.Second},
+			Duration:    30 * time.Second,
+			Budget:      Budget{P50: 150 * time.Millisecond, P95: 400 * time.Millisecond, P99: 800 * time.Millisecond, MinSuccess: 0.99},
+			Targeter:    boardLoadTargeter,
+		},
+		{
+			Name:        "card-moves",
+			Description: "Concurrently move cards between columns of a single hot board",
+			Rate:        vegeta.Rate{Freq: 30, Per: time.Second},
+			Duration:    30 * time.Second,
+			Budget:      Budget{P50: 100 * time.Millisecond, P95: 300 * time.Millisecond, P99: 600 * time.Millisecond, MinSuccess: 0.99},
+			Targeter:    cardMovesTargeter,
+		},
+		{
+			Name:        "search",
+			Description: "Full-text search across the seeded organization",
+			Rate:        vegeta.Rate{Freq: 40, Per: time.Second},
+			Duration:    30 * time.Second,
+			Budget:      Budget{P50: 100 * time.Millisecond, P95: 250 * time.Millisecond, P99: 500 * time.Millisecond, MinSuccess: 0.99},
+			Targeter:    searchTargeter,
+		},
+	}
+}
+
+// ScenarioByName returns the built-in scenario with the given name
+func ScenarioByName(name string) (Scenario, bool) {
+	for _, s := range Scenarios() {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Scenario{}, false
+}
+
+func boardLoadTargeter(t *Target) vegeta.Targeter {
+	return graphQLTargeter(t, func() []byte {
+		board := t.Dataset.Boards[rand.IntN(len(t.Dataset.Boards))]
+		return graphQLBody(boardQuery, map[string]any{"id": board.ID.String()})
+	})
+}
+
+func cardMovesTargeter(t *Target) vegeta.Targeter {
+	// All moves hit the first board so requests contend for the same columns
+	board := t.Dataset.Boards[0]
+	return graphQLTargeter(t, func() []byte {
+		cardID := board.CardIDs[rand.IntN(len(board.CardIDs))]
+		columnID := board.ColumnIDs[rand.IntN(len(board.ColumnIDs))]
+		return graphQLBody(moveCardMutation, map[string]any{
+			"input": map[string]any{
+				"cardId":         cardID.String(),
+				"targetColumnId": columnID.String(),
+			},
+		})
+	})
+}
+
+func searchTargeter(t *Target) vegeta.Targeter {
+	return graphQLTargeter(t, func() []byte {
+		term := t.Dataset.SearchTerms[rand.IntN(len(t.Dataset.SearchTerms))]
+		return graphQLBody(searchQuery, map[string]any{"query": term})
+	})
+}
+
+func graphQLTargeter(t *Target, body func() []byte) vegeta.Targeter {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if t.Cookie != "" {
+		header.Set("Cookie", t.Cookie)
+	}
+
+	return func(tgt *vegeta.Target) error {
+		if tgt == nil {
+			return vegeta.ErrNilTarget
+		}
+		tgt.Method = http.MethodPost
+		tgt.URL = t.GraphQLURL
+		tgt.Header = header
+		tgt.Body = body()
+		return nil
+	}
+}
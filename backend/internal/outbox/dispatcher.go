@@ -0,0 +1,177 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/outbox_event"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// Deliverer runs every handler for an event and reports whether all of them succeeded.
+// events.InProcessBus implements it.
+type Deliverer interface {
+	Deliver(ctx context.Context, event events.Event) error
+}
+
+type Config struct {
+	// PollInterval is how often the dispatcher looks for due events when not notified
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease is how long a claimed event stays hidden from other dispatchers
+	Lease time.Duration
+	// MaxAttempts after which an event is left in the table with its last error for inspection
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry, doubled for each further attempt
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Retention is how long dispatched events are kept before cleanup deletes them
+	Retention time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		Lease:        time.Minute,
+		MaxAttempts:  10,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   10 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// cleanupInterval is how often dispatched events past their retention are deleted
+const cleanupInterval = time.Hour
+
+// Dispatcher delivers committed outbox events to subscribers
+type Dispatcher struct {
+	repo      outbox_event.Repository
+	deliverer Deliverer
+	cfg       Config
+	wake      chan struct{}
+	now       func() time.Time
+}
+
+func NewDispatcher(repo outbox_event.Repository, deliverer Deliverer, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		repo:      repo,
+		deliverer: deliverer,
+		cfg:       cfg,
+		wake:      make(chan struct{}, 1),
+		now:       time.Now,
+	}
+}
+
+// Notify wakes the dispatcher early. It never blocks.
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches events until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		// Keep claiming while full batches come back so a backlog drains quickly
+		for {
+			n, err := d.DispatchPending(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to dispatch outbox events")
+				break
+			}
+			if n < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		case <-cleanup.C:
+			if _, err := d.Cleanup(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to clean up outbox events")
+			}
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due events and returns how many were claimed
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	claimed, err := d.repo.ClaimDue(ctx, d.cfg.BatchSize, d.cfg.Lease, d.cfg.MaxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, row := range claimed {
+		if err := d.dispatch(ctx, row); err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), nil
+}
+
+// dispatch delivers one event and records the outcome. Only failures to record the outcome
+// are returned; a failed delivery is scheduled for retry.
+func (d *Dispatcher) dispatch(ctx context.Context, row *outbox_event.OutboxEvent) error {
+	event, err := toEvent(row)
+	if err == nil {
+		err = d.deliverer.Deliver(ctx, event)
+	}
+
+	if err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().
+			Str("event", row.EventName).
+			Str("event_id", row.ID.String()).
+			Int("attempts", row.Attempts).
+			Err(err).
+			Msg("Outbox event delivery failed")
+		return d.repo.MarkFailed(ctx, row.ID, err.Error(), d.now().Add(d.backoff(row.Attempts)))
+	}
+
+	return d.repo.MarkDispatched(ctx, row.ID)
+}
+
+// backoff returns the delay before retrying an event that has failed attempts times
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.cfg.MaxBackoff {
+			return d.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Cleanup deletes dispatched events older than the retention period
+func (d *Dispatcher) Cleanup(ctx context.Context) (int64, error) {
+	return d.repo.DeleteDispatchedBefore(ctx, d.now().Add(-d.cfg.Retention))
+}
+
+func toEvent(row *outbox_event.OutboxEvent) (events.Event, error) {
+	name := events.Name(row.EventName)
+	payload, err := events.DecodePayload(name, row.Payload)
+	if err != nil {
+		return events.Event{}, err
+	}
+	return events.Event{
+		ID:         row.ID,
+		Name:       name,
+		OccurredAt: row.OccurredAt,
+		ActorID:    row.ActorID,
+		Payload:    payload,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/outbox_event"
+	outboxMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/outbox_event/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := outboxMocks.NewMockRepository(ctrl)
+	notified := 0
+	publisher := NewPublisher(mockRepo, events.NewSyncBus(), func() { notified++ })
+
+	userID := uuid.New()
+	ctx := events.WithActor(context.Background(), userID)
+	payload := events.CardPayload{CardID: uuid.New(), BoardID: uuid.New(), ColumnID: uuid.New()}
+
+	t.Run("records the event with its ID and encoded payload", func(t *testing.T) {
+		event := events.New(ctx, events.CardCreated, payload)
+
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, row *outbox_event.OutboxEvent) error {
+			assert.Equal(t, event.ID, row.ID)
+			assert.Equal(t, "card.created", row.EventName)
+			assert.Equal(t, &userID, row.ActorID)
+
+			var stored events.CardPayload
+			require.NoError(t, json.Unmarshal(row.Payload, &stored))
+			assert.Equal(t, payload, stored)
+			return nil
+		})
+
+		require.NoError(t, publisher.Publish(ctx, event))
+		assert.Equal(t, 1, notified)
+	})
+
+	t.Run("returns the error so the transaction rolls back", func(t *testing.T) {
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+		err := publisher.Publish(ctx, events.New(ctx, events.CardCreated, payload))
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Equal(t, 1, notified)
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	newRow := func(name events.Name, payload any, attempts int) *outbox_event.OutboxEvent {
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return &outbox_event.OutboxEvent{
+			ID:         uuid.New(),
+			EventName:  string(name),
+			Payload:    data,
+			OccurredAt: now,
+			Attempts:   attempts,
+		}
+	}
+
+	setup := func(t *testing.T) (*outboxMocks.MockRepository, *events.InProcessBus, *Dispatcher) {
+		ctrl := gomock.NewController(t)
+		mockRepo := outboxMocks.NewMockRepository(ctrl)
+		bus := events.NewBus()
+		d := NewDispatcher(mockRepo, bus, DefaultConfig())
+		d.now = func() time.Time { return now }
+		return mockRepo, bus, d
+	}
+
+	t.Run("delivers decoded events and marks them dispatched", func(t *testing.T) {
+		mockRepo, bus, d := setup(t)
+
+		payload := events.CardMovedPayload{CardID: uuid.New(), BoardID: uuid.New(), FromColumnID: uuid.New(), ToColumnID: uuid.New()}
+		row := newRow(events.CardMoved, payload, 1)
+
+		var received []events.Event
+		bus.Subscribe(events.CardMoved, func(ctx context.Context, e events.Event) error {
+			received = append(received, e)
+			return nil
+		})
+
+		mockRepo.EXPECT().ClaimDue(gomock.Any(), 100, time.Minute, 10).Return([]*outbox_event.OutboxEvent{row}, nil)
+		mockRepo.EXPECT().MarkDispatched(gomock.Any(), row.ID).Return(nil)
+
+		n, err := d.DispatchPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		require.Len(t, received, 1)
+		assert.Equal(t, row.ID, received[0].ID)
+		assert.Equal(t, payload, received[0].Payload)
+	})
+
+	t.Run("schedules a retry with backoff when a handler fails", func(t *testing.T) {
+		mockRepo, bus, d := setup(t)
+
+		row := newRow(events.SprintCompleted, events.SprintCompletedPayload{SprintID: uuid.New()}, 3)
+		bus.Subscribe(events.SprintCompleted, func(ctx context.Context, e events.Event) error {
+			return errors.New("metrics store unavailable")
+		})
+
+		mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*outbox_event.OutboxEvent{row}, nil)
+		mockRepo.EXPECT().MarkFailed(gomock.Any(), row.ID, "metrics store unavailable", now.Add(4*time.Second)).Return(nil)
+
+		_, err := d.DispatchPending(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("undecodable events are retried rather than dropped", func(t *testing.T) {
+		mockRepo, _, d := setup(t)
+
+		row := newRow("card.exploded", struct{}{}, 1)
+
+		mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*outbox_event.OutboxEvent{row}, nil)
+		mockRepo.EXPECT().MarkFailed(gomock.Any(), row.ID, gomock.Any(), now.Add(time.Second)).Return(nil)
+
+		_, err := d.DispatchPending(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("claim errors are returned", func(t *testing.T) {
+		mockRepo, _, d := setup(t)
+
+		mockRepo.EXPECT().ClaimDue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+
+		_, err := d.DispatchPending(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("backoff doubles up to the maximum", func(t *testing.T) {
+		_, _, d := setup(t)
+
+		assert.Equal(t, time.Second, d.backoff(1))
+		assert.Equal(t, 2*time.Second, d.backoff(2))
+		assert.Equal(t, 8*time.Second, d.backoff(4))
+		assert.Equal(t, 10*time.Minute, d.backoff(20))
+	})
+
+	t.Run("cleanup deletes events dispatched before the retention period", func(t *testing.T) {
+		mockRepo, _, d := setup(t)
+
+		mockRepo.EXPECT().DeleteDispatchedBefore(gomock.Any(), now.Add(-7*24*time.Hour)).Return(int64(3), nil)
+
+		n, err := d.Cleanup(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+	})
+}
@@ -0,0 +1,63 @@
+// Package outbox makes domain event delivery reliable with a transactional outbox.
+//
+// Publisher records each event in the outbox_events table through the caller's context,
+// so when a service publishes inside txManager.WithinTransaction the event commits or rolls
+// back together with the state change. Dispatcher then delivers committed events to the
+// in-process subscribers, retrying with backoff until every handler succeeds, which gives
+// at-least-once delivery that survives crashes and restarts.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/outbox_event"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+)
+
+// Publisher is an events.Bus that writes events to the outbox instead of delivering them
+type Publisher struct {
+	repo        outbox_event.Repository
+	subscribers events.Bus
+	notify      func()
+}
+
+// NewPublisher returns a Publisher whose subscriptions are registered on subscribers, the
+// bus the dispatcher delivers to. notify, if set, is called after each event is recorded so
+// the dispatcher can pick it up without waiting for its next poll.
+func NewPublisher(repo outbox_event.Repository, subscribers events.Bus, notify func()) *Publisher {
+	return &Publisher{
+		repo:        repo,
+		subscribers: subscribers,
+		notify:      notify,
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("encode %s payload: %w", event.Name, err)
+	}
+
+	row := &outbox_event.OutboxEvent{
+		ID:          event.ID,
+		EventName:   string(event.Name),
+		Payload:     payload,
+		ActorID:     event.ActorID,
+		OccurredAt:  event.OccurredAt,
+		AvailableAt: event.OccurredAt,
+	}
+	if err := p.repo.Create(ctx, row); err != nil {
+		return fmt.Errorf("record %s in outbox: %w", event.Name, err)
+	}
+
+	if p.notify != nil {
+		p.notify()
+	}
+	return nil
+}
+
+func (p *Publisher) Subscribe(name events.Name, handler events.Handler) {
+	p.subscribers.Subscribe(name, handler)
+}
@@ -0,0 +1,88 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	activityService "github.com/thatcatdev/kaimu/backend/internal/services/activity"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// ContributorActivity returns a heatmap of a project's activity per contributor and day.
+// Members who can't manage the project only see their own activity.
+func ContributorActivity(ctx context.Context, rbacSvc rbacService.Service, activitySvc activityService.Service, userSvc userService.Service, projectID string, dateRange *model.DateRangeInput) (*model.ContributorActivity, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	canManage, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:manage")
+	if err != nil {
+		return nil, err
+	}
+	var onlyUser *uuid.UUID
+	if !canManage {
+		onlyUser = userID
+	}
+
+	var from, to *time.Time
+	if dateRange != nil {
+		from, to = dateRange.From, dateRange.To
+	}
+	heatmap, err := activitySvc.GetContributorActivity(ctx, projID, onlyUser, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ContributorActivity{
+		ProjectID:     heatmap.ProjectID.String(),
+		From:          heatmap.From,
+		To:            heatmap.To,
+		MaxDailyCount: heatmap.MaxDailyCount,
+		Contributors:  make([]*model.ContributorActivityRow, 0, len(heatmap.Contributors)),
+	}
+	for _, c := range heatmap.Contributors {
+		u, err := userSvc.GetByID(ctx, c.UserID)
+		if err != nil {
+			// Activity of users that can't be loaded is left out
+			continue
+		}
+		row := &model.ContributorActivityRow{
+			User:         UserToModel(u),
+			CardsCreated: c.CardsCreated,
+			CardsMoved:   c.CardsMoved,
+			Comments:     c.Comments,
+			Total:        c.Total(),
+			Days:         make([]*model.ContributorActivityDay, len(c.Days)),
+		}
+		for i, d := range c.Days {
+			row.Days[i] = &model.ContributorActivityDay{
+				Date:         d.Date,
+				CardsCreated: d.CardsCreated,
+				CardsMoved:   d.CardsMoved,
+				Comments:     d.Comments,
+				Total:        d.Total(),
+				Level:        d.Level,
+			}
+		}
+		result.Contributors = append(result.Contributors, row)
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate"
+	aggregateService "github.com/thatcatdev/kaimu/backend/internal/services/aggregate"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// AggregateCards counts a project's cards and sums their story points per group
+func AggregateCards(ctx context.Context, rbacSvc rbacService.Service, aggregateSvc aggregateService.Service, projectID string, groupBy []model.CardAggregateField, filter *model.CardAggregateFilter) ([]*model.CardAggregateGroup, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	fields := make([]card_aggregate.Field, len(groupBy))
+	for i, f := range groupBy {
+		fields[i] = card_aggregate.Field(strings.ToLower(string(f)))
+	}
+
+	var aggregateFilter card_aggregate.Filter
+	if filter != nil {
+		if aggregateFilter.BoardIDs, err = parseIDs(filter.BoardIds); err != nil {
+			return nil, err
+		}
+		if aggregateFilter.AssigneeIDs, err = parseIDs(filter.AssigneeIds); err != nil {
+			return nil, err
+		}
+		if aggregateFilter.TagIDs, err = parseIDs(filter.TagIds); err != nil {
+			return nil, err
+		}
+		for _, p := range filter.Priorities {
+			aggregateFilter.Priorities = append(aggregateFilter.Priorities, modelPriorityToCard(p))
+		}
+		aggregateFilter.Done = filter.Done
+	}
+
+	groups, err := aggregateSvc.AggregateCards(ctx, projID, fields, aggregateFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CardAggregateGroup, len(groups))
+	for i, g := range groups {
+		keys := make([]*model.CardAggregateKey, len(groupBy))
+		for j, field := range groupBy {
+			key := &model.CardAggregateKey{Field: field, Value: g.Keys[j], Label: g.Labels[j]}
+			// Priorities are reported as their GraphQL enum values
+			if field == model.CardAggregateFieldPriority && key.Value != nil {
+				priority := string(cardPriorityToModel(card.CardPriority(*key.Value)))
+				key.Value, key.Label = &priority, &priority
+			}
+			keys[j] = key
+		}
+		result[i] = &model.CardAggregateGroup{
+			Keys:               keys,
+			CardCount:          g.CardCount,
+			EstimatedCardCount: g.EstimatedCardCount,
+			StoryPoints:        g.StoryPoints,
+		}
+	}
+	return result, nil
+}
+
+func parseIDs(ids []string) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	parsed := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = u
+	}
+	return parsed, nil
+}
@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	anomalyService "github.com/thatcatdev/kaimu/backend/internal/services/anomaly"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// AuditAnomalySettings returns the organization's anomaly detection settings
+func AuditAnomalySettings(ctx context.Context, rbacSvc rbacService.Service, anomalySvc anomalyService.Service, organizationID string) (*model.AuditAnomalySettings, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := anomalySvc.GetSettings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return auditAnomalySettingsToModel(setting), nil
+}
+
+// UpdateAuditAnomalySettings replaces the organization's anomaly detection settings
+func UpdateAuditAnomalySettings(ctx context.Context, rbacSvc rbacService.Service, anomalySvc anomalyService.Service, input model.UpdateAuditAnomalySettingsInput) (*model.AuditAnomalySettings, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := anomalySvc.UpdateSettings(ctx, orgID, anomalyService.SettingsInput{
+		Enabled:                   input.Enabled,
+		MassDeletionThreshold:     input.MassDeletionThreshold,
+		MassDeletionWindowMinutes: input.MassDeletionWindowMinutes,
+		BusinessHoursStart:        input.BusinessHoursStart,
+		BusinessHoursEnd:          input.BusinessHoursEnd,
+		Timezone:                  input.Timezone,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return auditAnomalySettingsToModel(setting), nil
+}
+
+// AuditAnomalies returns the organization's most recently detected anomalies
+func AuditAnomalies(ctx context.Context, rbacSvc rbacService.Service, anomalySvc anomalyService.Service, userSvc userService.Service, organizationID string, limit *int) ([]*model.AuditAnomaly, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	n := defaultLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxLimit)
+	}
+	anomalies, err := anomalySvc.GetAnomalies(ctx, orgID, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.AuditAnomaly, len(anomalies))
+	for i, a := range anomalies {
+		result[i] = auditAnomalyToModel(a)
+		if a.ActorID != nil {
+			if actor, err := userSvc.GetByID(ctx, *a.ActorID); err == nil && actor != nil {
+				result[i].Actor = UserToModel(actor)
+			}
+		}
+	}
+	return result, nil
+}
+
+func auditAnomalySettingsToModel(s *audit_anomaly_setting.AuditAnomalySetting) *model.AuditAnomalySettings {
+	return &model.AuditAnomalySettings{
+		OrganizationID:            s.OrganizationID.String(),
+		Enabled:                   s.Enabled,
+		MassDeletionThreshold:     s.MassDeletionThreshold,
+		MassDeletionWindowMinutes: s.MassDeletionWindowMinutes,
+		BusinessHoursStart:        s.BusinessHoursStart,
+		BusinessHoursEnd:          s.BusinessHoursEnd,
+		Timezone:                  s.Timezone,
+	}
+}
+
+func auditAnomalyToModel(a *audit_anomaly.AuditAnomaly) *model.AuditAnomaly {
+	result := &model.AuditAnomaly{
+		ID:          a.ID.String(),
+		Kind:        model.AuditAnomalyKindMassDeletion,
+		EventCount:  a.EventCount,
+		WindowStart: a.WindowStart,
+		WindowEnd:   a.WindowEnd,
+		DetectedAt:  a.DetectedAt,
+		NotifiedAt:  a.NotifiedAt,
+	}
+	if a.Kind == audit_anomaly.KindOffHoursRoleChange {
+		result.Kind = model.AuditAnomalyKindOffHoursRoleChange
+	}
+	if a.AuditEventID != nil {
+		eventID := a.AuditEventID.String()
+		result.AuditEventID = &eventID
+	}
+	return result
+}
@@ -0,0 +1,180 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	apitokenService "github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// CreatePersonalAccessToken creates a token acting as the current user
+func CreatePersonalAccessToken(ctx context.Context, userSvc userService.Service, tokenSvc apitokenService.Service, input model.CreateAPITokenInput) (*model.CreatedAPIToken, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	token, apiToken, err := tokenSvc.CreatePersonalToken(ctx, apiTokenInput(*userID, input))
+	if err != nil {
+		return nil, err
+	}
+	return &model.CreatedAPIToken{
+		Token:    token,
+		APIToken: apiTokenToModel(ctx, userSvc, apiToken),
+	}, nil
+}
+
+// CreateServiceToken creates a token for the organization acting as a new service account
+func CreateServiceToken(ctx context.Context, rbacSvc rbacService.Service, userSvc userService.Service, tokenSvc apitokenService.Service, organizationID string, input model.CreateAPITokenInput) (*model.CreatedAPIToken, error) {
+	userID, orgID, err := requireOrgManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, apiToken, err := tokenSvc.CreateServiceToken(ctx, orgID, apiTokenInput(userID, input))
+	if err != nil {
+		return nil, err
+	}
+	return &model.CreatedAPIToken{
+		Token:    token,
+		APIToken: apiTokenToModel(ctx, userSvc, apiToken),
+	}, nil
+}
+
+// MyAPITokens returns the current user's usable personal access tokens
+func MyAPITokens(ctx context.Context, userSvc userService.Service, tokenSvc apitokenService.Service) ([]*model.APIToken, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	tokens, err := tokenSvc.GetUserTokens(ctx, *userID)
+	if err != nil {
+		return nil, err
+	}
+	return apiTokensToModel(ctx, userSvc, tokens), nil
+}
+
+// OrganizationServiceTokens returns the organization's usable service tokens
+func OrganizationServiceTokens(ctx context.Context, rbacSvc rbacService.Service, userSvc userService.Service, tokenSvc apitokenService.Service, organizationID string) ([]*model.APIToken, error) {
+	_, orgID, err := requireOrgManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenSvc.GetOrganizationTokens(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return apiTokensToModel(ctx, userSvc, tokens), nil
+}
+
+// RevokeAPIToken revokes one of the current user's personal access tokens, or a service
+// token of an organization they can manage
+func RevokeAPIToken(ctx context.Context, rbacSvc rbacService.Service, userSvc userService.Service, tokenSvc apitokenService.Service, id string) (*model.APIToken, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	apiToken, err := tokenSvc.GetToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone else's personal token is reported as not found rather than forbidden
+	if apiToken.Kind == api_token.KindPersonal && apiToken.UserID != *userID {
+		return nil, apitokenService.ErrTokenNotFound
+	}
+	if apiToken.Kind == api_token.KindService {
+		hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, *apiToken.OrganizationID, "org:manage")
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	apiToken, err = tokenSvc.RevokeToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return apiTokenToModel(ctx, userSvc, apiToken), nil
+}
+
+// requireOrgManager parses the organization ID, requiring the current user to be able to
+// manage the organization
+func requireOrgManager(ctx context.Context, rbacSvc rbacService.Service, organizationID string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, orgID, "org:manage")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+	return *userID, orgID, nil
+}
+
+func apiTokenInput(createdBy uuid.UUID, input model.CreateAPITokenInput) apitokenService.CreateInput {
+	return apitokenService.CreateInput{
+		Name:      input.Name,
+		Scope:     api_token.Scope(strings.ToLower(string(input.Scope))),
+		ExpiresAt: input.ExpiresAt,
+		CreatedBy: createdBy,
+	}
+}
+
+func apiTokensToModel(ctx context.Context, userSvc userService.Service, tokens []*api_token.APIToken) []*model.APIToken {
+	result := make([]*model.APIToken, len(tokens))
+	for i, t := range tokens {
+		result[i] = apiTokenToModel(ctx, userSvc, t)
+	}
+	return result
+}
+
+func apiTokenToModel(ctx context.Context, userSvc userService.Service, t *api_token.APIToken) *model.APIToken {
+	result := &model.APIToken{
+		ID:         t.ID.String(),
+		Name:       t.Name,
+		Kind:       model.APITokenKind(strings.ToUpper(string(t.Kind))),
+		Scope:      model.APITokenScope(strings.ToUpper(string(t.Scope))),
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+		RevokedAt:  t.RevokedAt,
+	}
+	if t.OrganizationID != nil {
+		orgID := t.OrganizationID.String()
+		result.OrganizationID = &orgID
+	}
+	if u, err := userSvc.GetByID(ctx, t.UserID); err == nil && u != nil {
+		result.User = UserToModel(u)
+	}
+	if t.CreatedBy != nil {
+		if u, err := userSvc.GetByID(ctx, *t.CreatedBy); err == nil && u != nil {
+			result.CreatedBy = UserToModel(u)
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	appearanceService "github.com/thatcatdev/kaimu/backend/internal/services/appearance"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ColumnPalettes returns the column palette presets
+func ColumnPalettes(ctx context.Context) ([]*model.ColumnPalette, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+
+	palettes := make([]*model.ColumnPalette, len(appearanceService.Palettes))
+	for i, p := range appearanceService.Palettes {
+		palettes[i] = &model.ColumnPalette{Name: p.Name, Colors: p.Colors}
+	}
+	return palettes, nil
+}
+
+// SetBoardAppearance replaces the board's appearance
+func SetBoardAppearance(ctx context.Context, rbacSvc rbacService.Service, appearanceSvc appearanceService.Service, boardID string, input model.BoardAppearanceInput) (*model.Board, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var appearance appearanceService.Appearance
+	if input.BackgroundColor != nil {
+		appearance.BackgroundColor = *input.BackgroundColor
+	}
+	if input.BackgroundImageURL != nil {
+		appearance.BackgroundImageURL = *input.BackgroundImageURL
+	}
+	if input.ColumnPalette != nil {
+		appearance.ColumnPalette = *input.ColumnPalette
+	}
+	recolor := input.RecolorColumns != nil && *input.RecolorColumns
+
+	b, err := appearanceSvc.SetAppearance(ctx, bID, appearance, recolor)
+	if err != nil {
+		return nil, err
+	}
+	return boardToModel(b), nil
+}
+
+func boardAppearanceToModel(b *board.Board) *model.BoardAppearance {
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	return &model.BoardAppearance{
+		BackgroundColor:    optional(b.BackgroundColor),
+		BackgroundImageURL: optional(b.BackgroundImageURL),
+		ColumnPalette:      optional(b.ColumnPalette),
+	}
+}
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	archiveService "github.com/thatcatdev/kaimu/backend/internal/services/archive"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ArchivedCards returns the board's archived cards
+func ArchivedCards(ctx context.Context, rbacSvc rbacService.Service, archiveSvc archiveService.Service, boardID string) ([]*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	cards, err := archiveSvc.GetArchivedCards(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Card, len(cards))
+	for i, c := range cards {
+		result[i] = cardToModel(c)
+	}
+	return result, nil
+}
+
+// SetBoardAutoArchive sets how many days cards may stay in the board's done columns
+// before they're archived
+func SetBoardAutoArchive(ctx context.Context, rbacSvc rbacService.Service, archiveSvc archiveService.Service, boardID string, days *int) (*model.Board, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := archiveSvc.SetAutoArchiveDays(ctx, bID, days)
+	if err != nil {
+		return nil, err
+	}
+	return boardToModel(b), nil
+}
+
+// UnarchiveCard brings an archived card back to its column
+func UnarchiveCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, archiveSvc archiveService.Service, id string) (*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cardID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cardID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	c, err := archiveSvc.UnarchiveCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	return cardToModel(c), nil
+}
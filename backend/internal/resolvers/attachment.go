@@ -0,0 +1,212 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	attachmentService "github.com/thatcatdev/kaimu/backend/internal/services/attachment"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// RequestAttachmentUpload starts uploading a file to a card
+func RequestAttachmentUpload(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, attachmentSvc attachmentService.Service, input model.RequestAttachmentUploadInput) (*model.AttachmentUpload, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cardID, err := uuid.Parse(input.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cardID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	upload, err := attachmentSvc.RequestUpload(ctx, cardID, *userID, input.Filename, input.ContentType, int64(input.SizeBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*model.UploadHeader, 0, len(upload.Headers))
+	for name, value := range upload.Headers {
+		headers = append(headers, &model.UploadHeader{Name: name, Value: value})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+
+	return &model.AttachmentUpload{
+		Attachment:    attachmentToModel(upload.Attachment),
+		UploadURL:     upload.URL,
+		UploadHeaders: headers,
+		ExpiresAt:     upload.ExpiresAt,
+	}, nil
+}
+
+// CompleteAttachmentUpload confirms an upload finished
+func CompleteAttachmentUpload(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, attachmentSvc attachmentService.Service, id string) (*model.CardAttachment, error) {
+	attachmentID, _, err := requireAttachmentEdit(ctx, rbacSvc, cardSvc, attachmentSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := attachmentSvc.CompleteUpload(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentToModel(a), nil
+}
+
+// DeleteCardAttachment deletes an attachment, returning it for the audit log
+func DeleteCardAttachment(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, attachmentSvc attachmentService.Service, holdSvc legalholdService.Service, id string) (*model.CardAttachment, error) {
+	attachmentID, cardID, err := requireAttachmentEdit(ctx, rbacSvc, cardSvc, attachmentSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := cardSvc.GetBoardByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := holdSvc.CheckBoard(ctx, b.ID); err != nil {
+		return nil, err
+	}
+
+	a, err := attachmentSvc.DeleteAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentToModel(a), nil
+}
+
+// requireAttachmentEdit parses the attachment ID, requiring the current user to be able to
+// edit its card, and returns it with the card's ID
+func requireAttachmentEdit(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, attachmentSvc attachmentService.Service, id string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	a, err := attachmentSvc.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, *a.CardID, "card:edit"); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return attachmentID, *a.CardID, nil
+}
+
+// SetOrganizationAttachmentLimits overrides the attachment limits of an organization
+func SetOrganizationAttachmentLimits(ctx context.Context, rbacSvc rbacService.Service, attachmentSvc attachmentService.Service, organizationID string, maxBytes, quotaBytes *int) (*model.Organization, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := attachmentSvc.SetOrganizationLimits(ctx, orgID, intToInt64Ptr(maxBytes), intToInt64Ptr(quotaBytes))
+	if err != nil {
+		return nil, err
+	}
+	return organizationToModel(org), nil
+}
+
+// CardAttachments resolves the attachments field of a Card
+func CardAttachments(ctx context.Context, attachmentSvc attachmentService.Service, c *model.Card) ([]*model.CardAttachment, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := attachmentSvc.GetAttachments(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CardAttachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = attachmentToModel(a)
+	}
+	return result, nil
+}
+
+// CardAttachmentUploadedBy resolves the uploadedBy field of a CardAttachment
+func CardAttachmentUploadedBy(ctx context.Context, attachmentSvc attachmentService.Service, userSvc userService.Service, a *model.CardAttachment) (*model.User, error) {
+	attachmentID, err := uuid.Parse(a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := attachmentSvc.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if entity.UploadedBy == nil {
+		return nil, nil
+	}
+
+	user, err := userSvc.GetByID(ctx, *entity.UploadedBy)
+	if err != nil {
+		return nil, err
+	}
+	return UserToModel(user), nil
+}
+
+// CardAttachmentDownloadURL resolves the downloadUrl field of a CardAttachment
+func CardAttachmentDownloadURL(ctx context.Context, attachmentSvc attachmentService.Service, a *model.CardAttachment) (string, error) {
+	attachmentID, err := uuid.Parse(a.ID)
+	if err != nil {
+		return "", err
+	}
+
+	entity, err := attachmentSvc.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return "", err
+	}
+	return attachmentSvc.DownloadURL(ctx, entity)
+}
+
+func intToInt64Ptr(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
+
+func int64ToIntPtr(v *int64) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func attachmentToModel(a *attachment.Attachment) *model.CardAttachment {
+	m := &model.CardAttachment{
+		ID:          a.ID.String(),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		SizeBytes:   int(a.SizeBytes),
+		CreatedAt:   a.CreatedAt,
+	}
+	if a.CardID != nil {
+		m.CardID = a.CardID.String()
+	}
+	return m
+}
+
+// AttachmentToModel converts an attachment entity to a GraphQL model (exported for audit logging)
+func AttachmentToModel(a *attachment.Attachment) *model.CardAttachment {
+	return attachmentToModel(a)
+}
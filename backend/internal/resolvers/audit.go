@@ -13,6 +13,7 @@ import (
 	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/services/audit"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
@@ -198,6 +199,56 @@ func BoardActivity(
 	return buildAuditEventConnection(ctx, events, total, limit, offset, services), nil
 }
 
+// CardActivity returns audit events for a card, including its comments, attachments and checklist items
+func CardActivity(
+	ctx context.Context,
+	rbacSvc rbacService.Service,
+	cardSvc cardService.Service,
+	auditSvc audit.Service,
+	services *AuditServices,
+	cardID string,
+	first *int,
+	after *string,
+) (*model.AuditEventConnection, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cID, "card:view"); err != nil {
+		return nil, err
+	}
+
+	limit := defaultLimit
+	if first != nil && *first > 0 {
+		limit = *first
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+
+	offset := 0
+	if after != nil {
+		offset, err = auditDecodeCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events, total, err := auditSvc.GetCardActivity(ctx, cID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAuditEventConnection(ctx, events, total, limit, offset, services), nil
+}
+
 // EntityHistory returns audit events for a specific entity
 func EntityHistory(
 	ctx context.Context,
@@ -300,7 +351,8 @@ func UserActivity(
 // Helper functions
 
 func hasFilters(filters *model.AuditFilters) bool {
-	return filters != nil && (len(filters.Actions) > 0 || len(filters.EntityTypes) > 0 || filters.ActorID != nil || filters.StartDate != nil || filters.EndDate != nil)
+	return filters != nil && (len(filters.Actions) > 0 || len(filters.EntityTypes) > 0 || filters.ActorID != nil || filters.StartDate != nil || filters.EndDate != nil ||
+		filters.IPAddress != nil || filters.Impersonated != nil)
 }
 
 func convertFilters(filters *model.AuditFilters) auditrepo.QueryFilters {
@@ -335,6 +387,9 @@ func convertFilters(filters *model.AuditFilters) auditrepo.QueryFilters {
 		qf.EndDate = filters.EndDate
 	}
 
+	qf.IPAddress = filters.IPAddress
+	qf.Impersonated = filters.Impersonated
+
 	return qf
 }
 
@@ -386,6 +441,13 @@ func auditEventToModel(ctx context.Context, e *auditrepo.AuditEvent, services *A
 			}
 		}
 
+		// Fetch impersonator
+		if e.ImpersonatorID != nil && services.UserSvc != nil {
+			if user, err := services.UserSvc.GetByID(ctx, *e.ImpersonatorID); err == nil && user != nil {
+				event.Impersonator = UserToModel(user)
+			}
+		}
+
 		// Fetch organization
 		if e.OrganizationID != nil && services.OrgSvc != nil {
 			if org, err := services.OrgSvc.GetOrganization(ctx, *e.OrganizationID); err == nil && org != nil {
@@ -425,6 +487,24 @@ func auditEventToModel(ctx context.Context, e *auditrepo.AuditEvent, services *A
 	event.IPAddress = e.IPAddress
 	event.UserAgent = e.UserAgent
 	event.TraceID = e.TraceID
+	event.Impersonated = e.ImpersonatorID != nil
+	if e.AuthMethod != nil {
+		method := repoAuthMethodToModel(*e.AuthMethod)
+		event.AuthMethod = &method
+	}
+	if e.APITokenID != nil {
+		tokenID := e.APITokenID.String()
+		event.APITokenID = &tokenID
+	}
+
+	var actorName string
+	if event.Actor != nil {
+		actorName = event.Actor.Username
+		if event.Actor.DisplayName != nil {
+			actorName = *event.Actor.DisplayName
+		}
+	}
+	event.Summary = audit.Summarize(ctx, e, actorName)
 
 	return event
 }
@@ -486,6 +566,26 @@ func modelActionToRepo(a model.AuditAction) auditrepo.AuditAction {
 		return auditrepo.ActionUserLoggedIn
 	case model.AuditActionUserLoggedOut:
 		return auditrepo.ActionUserLoggedOut
+	case model.AuditActionCardSplit:
+		return auditrepo.ActionCardSplit
+	case model.AuditActionCardMerged:
+		return auditrepo.ActionCardMerged
+	case model.AuditActionLegalHoldPlaced:
+		return auditrepo.ActionLegalHoldPlaced
+	case model.AuditActionLegalHoldLifted:
+		return auditrepo.ActionLegalHoldLifted
+	case model.AuditActionBackupCreated:
+		return auditrepo.ActionBackupCreated
+	case model.AuditActionFreezeOverridden:
+		return auditrepo.ActionFreezeOverridden
+	case model.AuditActionCardsImported:
+		return auditrepo.ActionCardsImported
+	case model.AuditActionProjectExportRequested:
+		return auditrepo.ActionProjectExportRequested
+	case model.AuditActionAPITokenCreated:
+		return auditrepo.ActionAPITokenCreated
+	case model.AuditActionAPITokenRevoked:
+		return auditrepo.ActionAPITokenRevoked
 	default:
 		return auditrepo.ActionCreated
 	}
@@ -529,11 +629,42 @@ func repoActionToModel(a auditrepo.AuditAction) model.AuditAction {
 		return model.AuditActionUserLoggedIn
 	case auditrepo.ActionUserLoggedOut:
 		return model.AuditActionUserLoggedOut
+	case auditrepo.ActionCardSplit:
+		return model.AuditActionCardSplit
+	case auditrepo.ActionCardMerged:
+		return model.AuditActionCardMerged
+	case auditrepo.ActionLegalHoldPlaced:
+		return model.AuditActionLegalHoldPlaced
+	case auditrepo.ActionLegalHoldLifted:
+		return model.AuditActionLegalHoldLifted
+	case auditrepo.ActionBackupCreated:
+		return model.AuditActionBackupCreated
+	case auditrepo.ActionFreezeOverridden:
+		return model.AuditActionFreezeOverridden
+	case auditrepo.ActionCardsImported:
+		return model.AuditActionCardsImported
+	case auditrepo.ActionProjectExportRequested:
+		return model.AuditActionProjectExportRequested
+	case auditrepo.ActionAPITokenCreated:
+		return model.AuditActionAPITokenCreated
+	case auditrepo.ActionAPITokenRevoked:
+		return model.AuditActionAPITokenRevoked
 	default:
 		return model.AuditActionCreated
 	}
 }
 
+func repoAuthMethodToModel(m auditrepo.AuthMethod) model.AuditAuthMethod {
+	switch m {
+	case auditrepo.AuthMethodToken:
+		return model.AuditAuthMethodToken
+	case auditrepo.AuthMethodAPIToken:
+		return model.AuditAuthMethodAPIToken
+	default:
+		return model.AuditAuthMethodSession
+	}
+}
+
 func modelEntityTypeToRepo(e model.AuditEntityType) auditrepo.EntityType {
 	switch e {
 	case model.AuditEntityTypeUser:
@@ -556,6 +687,12 @@ func modelEntityTypeToRepo(e model.AuditEntityType) auditrepo.EntityType {
 		return auditrepo.EntityRole
 	case model.AuditEntityTypeInvitation:
 		return auditrepo.EntityInvitation
+	case model.AuditEntityTypeComment:
+		return auditrepo.EntityComment
+	case model.AuditEntityTypeAttachment:
+		return auditrepo.EntityAttachment
+	case model.AuditEntityTypeChecklistItem:
+		return auditrepo.EntityChecklistItem
 	default:
 		return auditrepo.EntityUser
 	}
@@ -583,6 +720,12 @@ func repoEntityTypeToModel(e auditrepo.EntityType) model.AuditEntityType {
 		return model.AuditEntityTypeRole
 	case auditrepo.EntityInvitation:
 		return model.AuditEntityTypeInvitation
+	case auditrepo.EntityComment:
+		return model.AuditEntityTypeComment
+	case auditrepo.EntityAttachment:
+		return model.AuditEntityTypeAttachment
+	case auditrepo.EntityChecklistItem:
+		return model.AuditEntityTypeChecklistItem
 	default:
 		return model.AuditEntityTypeUser
 	}
@@ -127,6 +127,7 @@ func UserToModel(u *user.User) *model.User {
 		EmailVerified: u.EmailVerified,
 		DisplayName:   u.DisplayName,
 		AvatarURL:     u.AvatarURL,
+		Locale:        u.Locale,
 		CreatedAt:     u.CreatedAt,
 	}
 }
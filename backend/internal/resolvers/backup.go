@@ -0,0 +1,54 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	backupService "github.com/thatcatdev/kaimu/backend/internal/services/backup"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// OrganizationBackups returns the organization's stored backups, newest first
+func OrganizationBackups(ctx context.Context, rbacSvc rbacService.Service, backupSvc backupService.Service, organizationID string) ([]*model.OrganizationBackup, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	backups, err := backupSvc.GetOrganizationBackups(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.OrganizationBackup, len(backups))
+	for i, b := range backups {
+		result[i] = backupToModel(b)
+	}
+	return result, nil
+}
+
+// CreateOrganizationBackup takes a backup of the organization and stores it
+func CreateOrganizationBackup(ctx context.Context, rbacSvc rbacService.Service, backupSvc backupService.Service, organizationID string) (*model.OrganizationBackup, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := backupSvc.CreateOrganizationBackup(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return backupToModel(b), nil
+}
+
+func backupToModel(b *backupService.Backup) *model.OrganizationBackup {
+	result := &model.OrganizationBackup{
+		Key:       b.Key,
+		SizeBytes: int(b.SizeBytes),
+		CreatedAt: b.CreatedAt,
+	}
+	if b.Summary != nil {
+		rows := b.Summary.Total()
+		result.RowCount = &rows
+	}
+	return result
+}
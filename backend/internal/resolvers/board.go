@@ -8,11 +8,14 @@ import (
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
 )
 
 // Board returns a board by ID
@@ -163,7 +166,7 @@ func UpdateBoard(ctx context.Context, rbacSvc rbacService.Service, boardSvc boar
 }
 
 // DeleteBoard deletes a board
-func DeleteBoard(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, id string) (bool, error) {
+func DeleteBoard(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -188,6 +191,10 @@ func DeleteBoard(ctx context.Context, rbacSvc rbacService.Service, boardSvc boar
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckOrganization(ctx, proj.OrganizationID); err != nil {
+		return false, err
+	}
+
 	if err := boardSvc.DeleteBoard(ctx, boardID); err != nil {
 		return false, err
 	}
@@ -235,7 +242,7 @@ func CreateColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boa
 }
 
 // UpdateColumn updates a board column
-func UpdateColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, input model.UpdateColumnInput) (*model.BoardColumn, error) {
+func UpdateColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, cardSvc cardService.Service, input model.UpdateColumnInput) (*model.BoardColumn, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, ErrUnauthorized
@@ -281,10 +288,26 @@ func UpdateColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boa
 	} else if input.WipLimit != nil {
 		col.WipLimit = input.WipLimit
 	}
+	if input.ClearPointLimit != nil && *input.ClearPointLimit {
+		col.PointLimit = nil
+	} else if input.PointLimit != nil {
+		col.PointLimit = input.PointLimit
+	}
 	if input.IsDone != nil {
 		col.IsDone = *input.IsDone
 	}
 
+	// Card defaults are validated first, so invalid defaults leave the column unchanged
+	if input.CardDefaults != nil {
+		defaultsInput, err := columnDefaultsInputFromModel(input.CardDefaults)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := cardSvc.SetColumnDefaults(ctx, colID, defaultsInput); err != nil {
+			return nil, err
+		}
+	}
+
 	updated, err := boardSvc.UpdateColumn(ctx, col)
 	if err != nil {
 		return nil, err
@@ -340,6 +363,53 @@ func ReorderColumns(ctx context.Context, rbacSvc rbacService.Service, boardSvc b
 	return result, nil
 }
 
+// SetColumnTransitions replaces the board workflow
+func SetColumnTransitions(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, workflowSvc workflowService.Service, boardID string, input []*model.ColumnTransitionInput) ([]*model.ColumnTransition, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	proj, err := boardSvc.GetProject(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, proj.ID, "board:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	transitions := make([]workflowService.Transition, len(input))
+	for i, t := range input {
+		fromID, err := uuid.Parse(t.FromColumnID)
+		if err != nil {
+			return nil, err
+		}
+		toID, err := uuid.Parse(t.ToColumnID)
+		if err != nil {
+			return nil, err
+		}
+		transitions[i] = workflowService.Transition{FromColumnID: fromID, ToColumnID: toID}
+	}
+
+	rows, err := workflowSvc.SetTransitions(ctx, bID, transitions)
+	if err != nil {
+		return nil, err
+	}
+
+	return columnTransitionsToModel(rows), nil
+}
+
 // ToggleColumnVisibility toggles column visibility
 func ToggleColumnVisibility(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, id string) (*model.BoardColumn, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -380,7 +450,7 @@ func ToggleColumnVisibility(ctx context.Context, rbacSvc rbacService.Service, bo
 }
 
 // DeleteColumn deletes a column
-func DeleteColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, id string) (bool, error) {
+func DeleteColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -410,6 +480,10 @@ func DeleteColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boa
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckOrganization(ctx, proj.OrganizationID); err != nil {
+		return false, err
+	}
+
 	if err := boardSvc.DeleteColumn(ctx, colID); err != nil {
 		return false, err
 	}
@@ -457,6 +531,59 @@ func BoardColumns(ctx context.Context, boardSvc boardService.Service, b *model.B
 	return result, nil
 }
 
+// BoardColumnTransitions resolves the columnTransitions field of a Board
+func BoardColumnTransitions(ctx context.Context, workflowSvc workflowService.Service, b *model.Board) ([]*model.ColumnTransition, error) {
+	boardID, err := uuid.Parse(b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := workflowSvc.GetTransitions(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return columnTransitionsToModel(rows), nil
+}
+
+// BoardColumnStats resolves the columnStats field of a Board
+func BoardColumnStats(ctx context.Context, boardSvc boardService.Service, b *model.Board) ([]*model.ColumnStats, error) {
+	boardID, err := uuid.Parse(b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := boardSvc.GetColumnStats(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ColumnStats, len(stats))
+	for i, s := range stats {
+		result[i] = &model.ColumnStats{
+			ColumnID:            s.ColumnID.String(),
+			CardCount:           s.CardCount,
+			StoryPoints:         s.StoryPoints,
+			EstimatedCardCount:  s.EstimatedCardCount,
+			WipLimit:            s.WipLimit,
+			OverWipLimit:        s.OverWipLimit(),
+			PointLimit:          s.PointLimit,
+			OverPointLimit:      s.OverPointLimit(),
+			AverageAgeDays:      secondsToDays(s.AverageAgeSeconds),
+			AverageDaysInColumn: secondsToDays(s.AverageTimeInColumnSeconds),
+		}
+	}
+	return result, nil
+}
+
+func secondsToDays(seconds *float64) *float64 {
+	if seconds == nil {
+		return nil
+	}
+	days := *seconds / (24 * 60 * 60)
+	return &days
+}
+
 // ColumnBoard resolves the board field of a BoardColumn
 func ColumnBoard(ctx context.Context, boardSvc boardService.Service, col *model.BoardColumn) (*model.Board, error) {
 	colID, err := uuid.Parse(col.ID)
@@ -491,6 +618,15 @@ func ColumnCards(ctx context.Context, cardSvc cardService.Service, col *model.Bo
 	return result, nil
 }
 
+// ColumnPointsInColumn resolves the pointsInColumn field of a BoardColumn
+func ColumnPointsInColumn(ctx context.Context, cardSvc cardService.Service, col *model.BoardColumn) (int, error) {
+	colID, err := uuid.Parse(col.ID)
+	if err != nil {
+		return 0, err
+	}
+	return cardSvc.GetColumnStoryPoints(ctx, colID)
+}
+
 // ProjectBoards resolves the boards field of a Project
 func ProjectBoards(ctx context.Context, boardSvc boardService.Service, proj *model.Project) ([]*model.Board, error) {
 	projID, err := uuid.Parse(proj.ID)
@@ -535,12 +671,16 @@ func boardToModel(b *board.Board) *model.Board {
 		description = &b.Description
 	}
 	return &model.Board{
-		ID:          b.ID.String(),
-		Name:        b.Name,
-		Description: description,
-		IsDefault:   b.IsDefault,
-		CreatedAt:   b.CreatedAt,
-		UpdatedAt:   b.UpdatedAt,
+		ID:                       b.ID.String(),
+		Name:                     b.Name,
+		Description:              description,
+		IsDefault:                b.IsDefault,
+		AutoArchiveDays:          b.AutoArchiveDays,
+		NotificationBatchMinutes: b.NotificationBatchMinutes,
+		SprintAutoMembership:     b.SprintAutoMembership,
+		Appearance:               boardAppearanceToModel(b),
+		CreatedAt:                b.CreatedAt,
+		UpdatedAt:                b.UpdatedAt,
 	}
 }
 
@@ -555,15 +695,27 @@ func columnToModel(col *board_column.BoardColumn) *model.BoardColumn {
 		color = &col.Color
 	}
 	return &model.BoardColumn{
-		ID:        col.ID.String(),
-		Name:      col.Name,
-		Position:  col.Position,
-		IsBacklog: col.IsBacklog,
-		IsHidden:  col.IsHidden,
-		IsDone:    col.IsDone,
-		Color:     color,
-		WipLimit:  col.WipLimit,
-		CreatedAt: col.CreatedAt,
-		UpdatedAt: col.UpdatedAt,
+		ID:         col.ID.String(),
+		Name:       col.Name,
+		Position:   col.Position,
+		IsBacklog:  col.IsBacklog,
+		IsHidden:   col.IsHidden,
+		IsDone:     col.IsDone,
+		Color:      color,
+		WipLimit:   col.WipLimit,
+		PointLimit: col.PointLimit,
+		CreatedAt:  col.CreatedAt,
+		UpdatedAt:  col.UpdatedAt,
+	}
+}
+
+func columnTransitionsToModel(rows []*column_transition.ColumnTransition) []*model.ColumnTransition {
+	result := make([]*model.ColumnTransition, len(rows))
+	for i, t := range rows {
+		result[i] = &model.ColumnTransition{
+			FromColumnID: t.FromColumnID.String(),
+			ToColumnID:   t.ToColumnID.String(),
+		}
 	}
+	return result
 }
@@ -0,0 +1,94 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	boarddefService "github.com/thatcatdev/kaimu/backend/internal/services/boarddef"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ExportBoardDefinition returns the board's structure as an indented JSON board definition
+func ExportBoardDefinition(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, boarddefSvc boarddefService.Service, boardID string) (string, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return "", ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return "", err
+	}
+
+	proj, err := boardSvc.GetProject(ctx, bID)
+	if err != nil {
+		return "", err
+	}
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, proj.ID, "board:view")
+	if err != nil {
+		return "", err
+	}
+	if !hasPermission {
+		return "", ErrUnauthorized
+	}
+
+	def, err := boarddefSvc.Export(ctx, bID)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ImportBoardDefinition creates a board in the project from a JSON board definition
+func ImportBoardDefinition(ctx context.Context, rbacSvc rbacService.Service, boarddefSvc boarddefService.Service, projectID string, definition string, name *string) (*model.Board, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	def, err := boarddefService.Parse([]byte(definition))
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "board:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+	// SLA policies are otherwise only created by project managers
+	if len(def.SLAPolicies) > 0 {
+		hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:manage")
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	boardName := ""
+	if name != nil {
+		boardName = *name
+	}
+	b, err := boarddefSvc.Import(ctx, projID, def, boardName, userID)
+	if err != nil {
+		return nil, contentError(ctx, err)
+	}
+	return boardToModel(b), nil
+}
@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding"
+	brandingService "github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// OrganizationBranding returns the organization's mail branding
+func OrganizationBranding(ctx context.Context, rbacSvc rbacService.Service, brandingSvc brandingService.Service, organizationID string) (*model.OrganizationBranding, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := brandingSvc.GetBranding(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return organizationBrandingToModel(brandingSvc, b), nil
+}
+
+// UpdateOrganizationBranding replaces the organization's mail branding
+func UpdateOrganizationBranding(ctx context.Context, rbacSvc rbacService.Service, brandingSvc brandingService.Service, input model.UpdateOrganizationBrandingInput) (*model.OrganizationBranding, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var branding brandingService.Input
+	if input.FromName != nil {
+		branding.FromName = *input.FromName
+	}
+	if input.FromEmail != nil {
+		branding.FromEmail = *input.FromEmail
+	}
+	if input.LogoURL != nil {
+		branding.LogoURL = *input.LogoURL
+	}
+	if input.AccentColor != nil {
+		branding.AccentColor = *input.AccentColor
+	}
+
+	b, err := brandingSvc.UpdateBranding(ctx, orgID, branding)
+	if err != nil {
+		return nil, err
+	}
+	return organizationBrandingToModel(brandingSvc, b), nil
+}
+
+// VerifyBrandingDomain checks the DNS records of the organization's sender domain
+func VerifyBrandingDomain(ctx context.Context, rbacSvc rbacService.Service, brandingSvc brandingService.Service, organizationID string) (*model.OrganizationBranding, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := brandingSvc.VerifyDomain(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return organizationBrandingToModel(brandingSvc, b), nil
+}
+
+func organizationBrandingToModel(brandingSvc brandingService.Service, b *organization_branding.OrganizationBranding) *model.OrganizationBranding {
+	records := brandingSvc.DNSRecords(b)
+	result := &model.OrganizationBranding{
+		OrganizationID:   b.OrganizationID.String(),
+		FromName:         stringPtr(b.FromName),
+		FromEmail:        stringPtr(b.FromEmail),
+		LogoURL:          stringPtr(b.LogoURL),
+		AccentColor:      stringPtr(b.AccentColor),
+		Domain:           stringPtr(b.Domain()),
+		DomainVerifiedAt: b.DomainVerifiedAt,
+		DomainCheckedAt:  b.DomainCheckedAt,
+		DNSRecords:       make([]*model.BrandingDNSRecord, len(records)),
+	}
+	for i, r := range records {
+		purpose := model.BrandingDNSRecordPurposeOwnership
+		switch r.Purpose {
+		case "spf":
+			purpose = model.BrandingDNSRecordPurposeSpf
+		case "dkim":
+			purpose = model.BrandingDNSRecordPurposeDkim
+		}
+		result.DNSRecords[i] = &model.BrandingDNSRecord{
+			Purpose:  purpose,
+			Name:     r.Name,
+			Value:    r.Value,
+			Verified: r.Verified,
+		}
+	}
+	return result
+}
@@ -0,0 +1,245 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ProjectCalendar returns a project's calendar with its upcoming holidays
+func ProjectCalendar(ctx context.Context, rbacSvc rbacService.Service, calendarSvc calendarService.Service, projectID string) (*model.ProjectCalendar, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	return projectCalendar(ctx, calendarSvc, projID)
+}
+
+// UpdateProjectCalendar changes a project's working days or pace
+func UpdateProjectCalendar(ctx context.Context, rbacSvc rbacService.Service, calendarSvc calendarService.Service, projectID string, input model.UpdateProjectCalendarInput) (*model.ProjectCalendar, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	update := calendarService.UpdateInput{PointsPerDay: input.PointsPerDay}
+	if input.WorkingDays != nil {
+		update.WorkingDays = make([]time.Weekday, len(input.WorkingDays))
+		for i, day := range input.WorkingDays {
+			update.WorkingDays[i] = weekdayFromModel(day)
+		}
+	}
+	if _, err := calendarSvc.UpdateCalendar(ctx, projID, update); err != nil {
+		return nil, err
+	}
+
+	return projectCalendar(ctx, calendarSvc, projID)
+}
+
+// AddProjectHoliday marks a date as a day off for a project
+func AddProjectHoliday(ctx context.Context, rbacSvc rbacService.Service, calendarSvc calendarService.Service, projectID string, date string, name string) (*model.ProjectHoliday, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	holiday, err := calendarSvc.AddHoliday(ctx, projID, day, name)
+	if err != nil {
+		return nil, err
+	}
+	return projectHolidayToModel(holiday), nil
+}
+
+// RemoveProjectHoliday deletes a project holiday
+func RemoveProjectHoliday(ctx context.Context, rbacSvc rbacService.Service, calendarSvc calendarService.Service, id string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	holidayID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	holiday, err := calendarSvc.GetHoliday(ctx, holidayID)
+	if err != nil {
+		return false, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, holiday.ProjectID, "project:manage")
+	if err != nil {
+		return false, err
+	}
+	if !hasPermission {
+		return false, ErrUnauthorized
+	}
+
+	if err := calendarSvc.RemoveHoliday(ctx, holidayID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SuggestDueDate proposes a due date for the card editor
+func SuggestDueDate(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, input model.SuggestDueDateInput) (*model.DueDateSuggestion, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	boardID, err := uuid.Parse(input.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, boardID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	suggestInput := cardService.SuggestDueDateInput{
+		BoardID:     boardID,
+		StoryPoints: input.StoryPoints,
+	}
+	if input.CardID != nil {
+		cardID, err := uuid.Parse(*input.CardID)
+		if err != nil {
+			return nil, err
+		}
+		suggestInput.CardID = &cardID
+	}
+	if input.AssigneeID != nil {
+		assigneeID, err := uuid.Parse(*input.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		suggestInput.AssigneeID = &assigneeID
+	}
+	if input.StartDate != nil {
+		start, err := time.Parse(time.DateOnly, *input.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		suggestInput.StartDate = &start
+	}
+
+	suggestion, err := cardSvc.SuggestDueDate(ctx, suggestInput)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make([]*model.ProjectHoliday, len(suggestion.SkippedHolidays))
+	for i, h := range suggestion.SkippedHolidays {
+		holidays[i] = projectHolidayToModel(h)
+	}
+	return &model.DueDateSuggestion{
+		DueDate:         suggestion.DueDate,
+		WorkingDays:     suggestion.WorkingDays,
+		EstimatePoints:  suggestion.EstimatePoints,
+		WorkloadPoints:  suggestion.WorkloadPoints,
+		WorkloadCards:   suggestion.WorkloadCards,
+		SkippedHolidays: holidays,
+	}, nil
+}
+
+func projectCalendar(ctx context.Context, calendarSvc calendarService.Service, projectID uuid.UUID) (*model.ProjectCalendar, error) {
+	cal, err := calendarSvc.GetCalendar(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := calendarSvc.GetHolidays(ctx, projectID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ProjectCalendar{
+		ProjectID:    projectID.String(),
+		WorkingDays:  []model.Weekday{},
+		PointsPerDay: cal.PointsPerDay,
+		Holidays:     make([]*model.ProjectHoliday, len(holidays)),
+	}
+	for _, day := range model.AllWeekday {
+		if cal.IsWorkingWeekday(weekdayFromModel(day)) {
+			result.WorkingDays = append(result.WorkingDays, day)
+		}
+	}
+	for i, h := range holidays {
+		result.Holidays[i] = projectHolidayToModel(h)
+	}
+	return result, nil
+}
+
+// weekdayFromModel relies on model.AllWeekday listing days in time.Weekday order
+func weekdayFromModel(day model.Weekday) time.Weekday {
+	for i, d := range model.AllWeekday {
+		if d == day {
+			return time.Weekday(i)
+		}
+	}
+	return time.Sunday
+}
+
+func projectHolidayToModel(h *project_calendar.ProjectHoliday) *model.ProjectHoliday {
+	return &model.ProjectHoliday{
+		ID:        h.ID.String(),
+		ProjectID: h.ProjectID.String(),
+		Date:      h.Date.Format(time.DateOnly),
+		Name:      h.Name,
+	}
+}
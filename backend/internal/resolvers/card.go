@@ -2,17 +2,28 @@ package resolvers
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/graph/model"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	dependencyService "github.com/thatcatdev/kaimu/backend/internal/services/dependency"
+	freezeService "github.com/thatcatdev/kaimu/backend/internal/services/freeze"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
+	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
 	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 // Card returns a card by ID
@@ -54,6 +65,72 @@ func Card(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.
 	return cardToModel(c), nil
 }
 
+// ErrAmbiguousCardKey is returned when more than one of the user's organizations has a project
+// with the key's project key
+var ErrAmbiguousCardKey = errors.New("several organizations have a project with this key; pass organizationId")
+
+// CardByKey returns a card by its key, looking for the project in the organization or in any
+// of the user's organizations
+func CardByKey(ctx context.Context, rbacSvc rbacService.Service, orgSvc orgService.Service, projectSvc projectService.Service, cardSvc cardService.Service, key string, organizationID *string) (*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projectKey, number, err := cardService.ParseKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgIDs []uuid.UUID
+	if organizationID != nil {
+		orgID, err := uuid.Parse(*organizationID)
+		if err != nil {
+			return nil, err
+		}
+		orgIDs = append(orgIDs, orgID)
+	} else {
+		orgs, err := orgSvc.GetUserOrganizations(ctx, *userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			orgIDs = append(orgIDs, org.ID)
+		}
+	}
+
+	// Projects the user can't see are treated as missing, so keys don't reveal them
+	var projectIDs []uuid.UUID
+	for _, orgID := range orgIDs {
+		proj, err := projectSvc.GetProjectByKey(ctx, orgID, projectKey)
+		if err != nil {
+			if errors.Is(err, projectService.ErrProjectNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, proj.ID, "card:view")
+		if err != nil {
+			return nil, err
+		}
+		if hasPermission {
+			projectIDs = append(projectIDs, proj.ID)
+		}
+	}
+	switch {
+	case len(projectIDs) == 0:
+		return nil, cardService.ErrCardNotFound
+	case len(projectIDs) > 1:
+		return nil, ErrAmbiguousCardKey
+	}
+
+	c, err := cardSvc.GetCardByNumber(ctx, projectIDs[0], number)
+	if err != nil {
+		return nil, err
+	}
+	return cardToModel(c), nil
+}
+
 // MyCards returns all cards assigned to the current user
 func MyCards(ctx context.Context, cardSvc cardService.Service) ([]*model.Card, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -104,10 +181,10 @@ func CreateCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardSe
 		return nil, ErrUnauthorized
 	}
 
+	// Priority stays empty unless given, so the column's default priority can apply
 	createInput := cardService.CreateCardInput{
 		ColumnID:  colID,
 		Title:     input.Title,
-		Priority:  card.PriorityNone,
 		CreatedBy: userID,
 	}
 
@@ -144,12 +221,50 @@ func CreateCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardSe
 
 	c, err := cardSvc.CreateCard(ctx, createInput)
 	if err != nil {
-		return nil, err
+		return nil, contentError(ctx, err)
 	}
 
 	return cardToModel(c), nil
 }
 
+// CreateCardsFromText creates a card for each line of pasted text
+func CreateCardsFromText(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, columnID, text string) ([]*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	colID, err := uuid.Parse(columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission via column -> board -> project
+	b, err := boardSvc.GetBoardByColumnID(ctx, colID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, b.ProjectID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	cards, err := cardSvc.CreateCardsFromText(ctx, colID, text, userID)
+	if err != nil {
+		return nil, contentError(ctx, err)
+	}
+
+	result := make([]*model.Card, len(cards))
+	for i, c := range cards {
+		result[i] = cardToModel(c)
+	}
+	return result, nil
+}
+
 // UpdateCard updates a card
 func UpdateCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, input model.UpdateCardInput) (*model.Card, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -228,67 +343,97 @@ func UpdateCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardSe
 
 	c, err := cardSvc.UpdateCard(ctx, updateInput)
 	if err != nil {
-		return nil, err
+		return nil, contentError(ctx, err)
 	}
 
 	return cardToModel(c), nil
 }
 
-// MoveCard moves a card to a different column
-func MoveCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, input model.MoveCardInput) (*model.Card, error) {
+// MoveCard moves a card to a different column. A move into a column frozen by a freeze
+// window is returned as a FreezeOverride so the caller can audit the justification.
+func MoveCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, freezeSvc freezeService.Service, dependencySvc dependencyService.Service, input model.MoveCardInput) (*model.Card, *FreezeOverride, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
-		return nil, ErrUnauthorized
+		return nil, nil, ErrUnauthorized
 	}
 
 	cardID, err := uuid.Parse(input.CardID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	targetColID, err := uuid.Parse(input.TargetColumnID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Check permission via card -> board -> project
 	b, err := cardSvc.GetBoardByCardID(ctx, cardID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	proj, err := boardSvc.GetProject(ctx, b.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, proj.ID, "card:move")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !hasPermission {
-		return nil, ErrUnauthorized
+		return nil, nil, ErrUnauthorized
 	}
 
 	var afterCardID *uuid.UUID
 	if input.AfterCardID != nil {
 		id, err := uuid.Parse(*input.AfterCardID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		afterCardID = &id
 	}
 
-	c, err := cardSvc.MoveCard(ctx, cardID, targetColID, afterCardID)
+	// Roles with the bypass permission are not held to the board workflow
+	bypassWorkflow, err := rbacSvc.HasBoardPermission(ctx, *userID, b.ID, "board:bypass_workflow")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return cardToModel(c), nil
+	override, err := checkFreeze(ctx, rbacSvc, cardSvc, freezeSvc, *userID, b.ID, cardID, targetColID, input.FreezeOverrideReason)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blockedWarning, err := checkOpenBlockers(ctx, cardSvc, boardSvc, dependencySvc, cardID, targetColID, input.OpenBlockers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c, err := cardSvc.MoveCard(ctx, cardID, targetColID, afterCardID, bypassWorkflow)
+	if err != nil {
+		var transitionErr *workflowService.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			return nil, nil, invalidTransitionError(ctx, transitionErr)
+		}
+		var limitErr *cardService.PointLimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, nil, pointLimitExceededError(ctx, limitErr)
+		}
+		return nil, nil, err
+	}
+
+	// The move went through; the warning is reported next to the moved card
+	if blockedWarning != nil {
+		graphql.AddError(ctx, blockedWarning)
+	}
+
+	return cardToModel(c), override, nil
 }
 
 // DeleteCard deletes a card
-func DeleteCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, id string) (bool, error) {
+func DeleteCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, boardSvc boardService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -318,6 +463,10 @@ func DeleteCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardSe
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckOrganization(ctx, proj.OrganizationID); err != nil {
+		return false, err
+	}
+
 	if err := cardSvc.DeleteCard(ctx, cardID); err != nil {
 		return false, err
 	}
@@ -355,6 +504,24 @@ func CardBoard(ctx context.Context, cardSvc cardService.Service, c *model.Card)
 	return boardToModel(b), nil
 }
 
+// CardKey resolves the key field of a Card from its project's current key
+func CardKey(ctx context.Context, cardSvc cardService.Service, boardSvc boardService.Service, c *model.Card) (string, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := cardSvc.GetBoardByCardID(ctx, cardID)
+	if err != nil {
+		return "", err
+	}
+	proj, err := boardSvc.GetProject(ctx, b.ID)
+	if err != nil {
+		return "", err
+	}
+	return cardService.Key(proj.Key, c.Number), nil
+}
+
 // CardTags resolves the tags field of a Card
 func CardTags(ctx context.Context, cardSvc cardService.Service, c *model.Card) ([]*model.Tag, error) {
 	cardID, err := uuid.Parse(c.ID)
@@ -431,16 +598,30 @@ func cardToModel(c *card.Card) *model.Card {
 	if c.DueDate != nil {
 		dueDate = c.DueDate
 	}
+	var epicID *string
+	if c.EpicID != nil {
+		id := c.EpicID.String()
+		epicID = &id
+	}
+	var mergedIntoID *string
+	if c.MergedIntoID != nil {
+		id := c.MergedIntoID.String()
+		mergedIntoID = &id
+	}
 	return &model.Card{
-		ID:          c.ID.String(),
-		Title:       c.Title,
-		Description: description,
-		Position:    c.Position,
-		Priority:    cardPriorityToModel(c.Priority),
-		DueDate:     dueDate,
-		StoryPoints: c.StoryPoints,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
+		ID:           c.ID.String(),
+		Number:       c.Number,
+		Title:        c.Title,
+		Description:  description,
+		Position:     c.Position,
+		Priority:     cardPriorityToModel(c.Priority),
+		DueDate:      dueDate,
+		StoryPoints:  c.StoryPoints,
+		EpicID:       epicID,
+		ArchivedAt:   c.ArchivedAt,
+		MergedIntoID: mergedIntoID,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
 	}
 }
 
@@ -497,3 +678,34 @@ func ProjectTags(ctx context.Context, tagSvc tagService.Service, proj *model.Pro
 	}
 	return result, nil
 }
+
+// invalidTransitionError exposes a workflow violation with a machine-readable code so
+// clients can tell it apart from other move failures
+func invalidTransitionError(ctx context.Context, err *workflowService.InvalidTransitionError) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: i18n.Tc(ctx, "errors.invalid_transition", nil),
+		Extensions: map[string]interface{}{
+			"code":         "INVALID_TRANSITION",
+			"fromColumnId": err.FromColumnID.String(),
+			"toColumnId":   err.ToColumnID.String(),
+		},
+	}
+}
+
+// pointLimitExceededError exposes a move refused by the target column's point limit with a
+// machine-readable code and the numbers behind it
+func pointLimitExceededError(ctx context.Context, err *cardService.PointLimitExceededError) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: i18n.Tc(ctx, "errors.point_limit_exceeded", map[string]string{
+			"total": strconv.Itoa(err.Points + err.CardPoints),
+			"limit": strconv.Itoa(err.PointLimit),
+		}),
+		Extensions: map[string]interface{}{
+			"code":       "POINT_LIMIT_EXCEEDED",
+			"columnId":   err.ColumnID.String(),
+			"points":     err.Points,
+			"cardPoints": err.CardPoints,
+			"pointLimit": err.PointLimit,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/services/carddraft"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// DraftCard expands a one-line prompt into a card draft for a project the user may create
+// cards in
+func DraftCard(ctx context.Context, rbacSvc rbacService.Service, draftSvc carddraft.Service, input model.DraftCardInput) (*model.CardDraft, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projectID, err := uuid.Parse(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projectID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	draft, err := draftSvc.Draft(ctx, *userID, projectID, input.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*model.CardDraftTag, len(draft.Tags))
+	for i, t := range draft.Tags {
+		tags[i] = &model.CardDraftTag{Name: t.Name}
+		if t.Tag != nil {
+			tags[i].Tag = tagToModel(t.Tag)
+		}
+	}
+	return &model.CardDraft{
+		Title:              draft.Title,
+		Description:        draft.Description,
+		AcceptanceCriteria: draft.AcceptanceCriteria,
+		SuggestedTags:      tags,
+	}, nil
+}
+
+// SetAIDraftingEnabled turns card drafting on or off for an organization
+func SetAIDraftingEnabled(ctx context.Context, rbacSvc rbacService.Service, draftSvc carddraft.Service, organizationID string, enabled bool) (*model.Organization, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := draftSvc.SetEnabled(ctx, orgID, enabled)
+	if err != nil {
+		return nil, err
+	}
+	return organizationToModel(org), nil
+}
@@ -0,0 +1,233 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	cardimportService "github.com/thatcatdev/kaimu/backend/internal/services/cardimport"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ImportCards validates a CSV document of cards and, unless it is a dry run or a row has
+// errors, creates the cards in the board
+func ImportCards(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, cardimportSvc cardimportService.Service, boardID string, csv string, columnMapping []*model.CardImportColumnMappingInput, columnID *string, dryRun *bool) (*model.CardImportResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := boardSvc.GetProject(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, proj.ID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	input := cardimportService.ImportInput{
+		CSV:       csv,
+		DryRun:    dryRun != nil && *dryRun,
+		CreatedBy: userID,
+	}
+	if columnID != nil {
+		colID, err := uuid.Parse(*columnID)
+		if err != nil {
+			return nil, err
+		}
+		input.ColumnID = &colID
+	}
+	for _, m := range columnMapping {
+		input.Mapping = append(input.Mapping, cardimportService.ColumnMapping{
+			Header: m.Header,
+			Field:  cardImportFieldFromModel(m.Field),
+		})
+	}
+
+	result, err := cardimportSvc.Import(ctx, bID, input)
+	if err != nil {
+		return nil, err
+	}
+	return cardImportResultToModel(ctx, result), nil
+}
+
+// The GraphQL enum values are the upper case service field names
+func cardImportFieldFromModel(f model.CardImportField) cardimportService.Field {
+	return cardimportService.Field(strings.ToLower(string(f)))
+}
+
+func cardImportFieldToModel(f cardimportService.Field) model.CardImportField {
+	return model.CardImportField(strings.ToUpper(string(f)))
+}
+
+func cardImportResultToModel(ctx context.Context, r *cardimportService.Result) *model.CardImportResult {
+	result := &model.CardImportResult{
+		Valid:    r.Valid(),
+		ColumnID: r.ColumnID.String(),
+		Rows:     make([]*model.CardImportRow, len(r.Rows)),
+		NewTags:  r.NewTags,
+		Cards:    make([]*model.Card, len(r.Cards)),
+	}
+	if result.NewTags == nil {
+		result.NewTags = []string{}
+	}
+	for i, row := range r.Rows {
+		result.Rows[i] = cardImportRowToModel(ctx, row)
+	}
+	for i, c := range r.Cards {
+		result.Cards[i] = cardToModel(c)
+	}
+	return result
+}
+
+func cardImportRowToModel(ctx context.Context, row *cardimportService.Row) *model.CardImportRow {
+	m := &model.CardImportRow{
+		Line:        row.Line,
+		Title:       row.Title,
+		Tags:        row.Tags,
+		StoryPoints: row.StoryPoints,
+		DueDate:     row.DueDate,
+		Errors:      make([]*model.CardImportRowError, len(row.Errors)),
+		ColumnID:    row.ColumnID.String(),
+	}
+	if m.Tags == nil {
+		m.Tags = []string{}
+	}
+	if row.Description != "" {
+		m.Description = &row.Description
+	}
+	if row.AssigneeEmail != "" {
+		m.AssigneeEmail = &row.AssigneeEmail
+	}
+	if row.SourceKey != "" {
+		m.SourceKey = &row.SourceKey
+	}
+	if row.Status != "" {
+		m.Status = &row.Status
+	}
+	if row.Assignee != nil {
+		name := row.Assignee.DisplayName
+		if name == "" {
+			name = row.Assignee.ExternalID
+		}
+		m.Assignee = &name
+	}
+	if row.AssigneeID != nil {
+		id := row.AssigneeID.String()
+		m.AssigneeID = &id
+	}
+	for j, e := range row.Errors {
+		m.Errors[j] = &model.CardImportRowError{
+			Field: cardImportFieldToModel(e.Field),
+			Code:  e.Code,
+			Message: i18n.Tc(ctx, "errors.card_import."+e.Code, map[string]string{
+				"value": e.Value,
+			}),
+		}
+	}
+	return m
+}
+
+// ImportProjectCards reads a Jira or Trello export and, unless it is a dry run or a card has
+// errors, creates its cards on a board of the project
+func ImportProjectCards(ctx context.Context, rbacSvc rbacService.Service, cardimportSvc cardimportService.Service, projectID string, format model.CardImportFormat, payload string, boardID *string, statusMapping []*model.CardImportStatusMappingInput, dryRun *bool) (*model.ProjectCardImportResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	pID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, pID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	input := cardimportService.ExternalInput{
+		Format:        cardimportService.Format(strings.ToLower(string(format))),
+		Payload:       payload,
+		StatusMapping: make(map[string]uuid.UUID, len(statusMapping)),
+		DryRun:        dryRun != nil && *dryRun,
+		CreatedBy:     userID,
+	}
+	if boardID != nil {
+		bID, err := uuid.Parse(*boardID)
+		if err != nil {
+			return nil, err
+		}
+		input.BoardID = &bID
+	}
+	for _, m := range statusMapping {
+		colID, err := uuid.Parse(m.ColumnID)
+		if err != nil {
+			return nil, err
+		}
+		input.StatusMapping[m.Status] = colID
+	}
+
+	result, err := cardimportSvc.ImportExternal(ctx, pID, input)
+	if err != nil {
+		return nil, err
+	}
+	return projectCardImportResultToModel(ctx, result), nil
+}
+
+func projectCardImportResultToModel(ctx context.Context, r *cardimportService.Result) *model.ProjectCardImportResult {
+	result := &model.ProjectCardImportResult{
+		Valid:           r.Valid(),
+		BoardID:         r.BoardID.String(),
+		DefaultColumnID: r.ColumnID.String(),
+		Rows:            make([]*model.CardImportRow, len(r.Rows)),
+		Statuses:        make([]*model.CardImportStatus, len(r.Statuses)),
+		Assignees:       make([]*model.CardImportAssignee, len(r.Assignees)),
+		NewTags:         r.NewTags,
+		Cards:           make([]*model.Card, len(r.Cards)),
+	}
+	if result.NewTags == nil {
+		result.NewTags = []string{}
+	}
+	for i, row := range r.Rows {
+		result.Rows[i] = cardImportRowToModel(ctx, row)
+	}
+	for i, s := range r.Statuses {
+		result.Statuses[i] = &model.CardImportStatus{
+			Status:    s.Status,
+			ColumnID:  s.ColumnID.String(),
+			Matched:   s.Matched,
+			CardCount: s.Cards,
+		}
+	}
+	for i, a := range r.Assignees {
+		m := &model.CardImportAssignee{
+			Match:     userMatchToModel(a.Match),
+			CardCount: a.Cards,
+		}
+		if a.UserID != nil {
+			id := a.UserID.String()
+			m.AssigneeID = &id
+		}
+		result.Assignees[i] = m
+	}
+	for i, c := range r.Cards {
+		result.Cards[i] = cardToModel(c)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	carryoverService "github.com/thatcatdev/kaimu/backend/internal/services/carryover"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// CarryoverReport returns the cards carried across a board's last closed sprints
+func CarryoverReport(ctx context.Context, rbacSvc rbacService.Service, carryoverSvc carryoverService.Service, boardID string, lastN *int) (*model.CarryoverReport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	report, err := carryoverSvc.GetReport(ctx, bID, lastN)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CarryoverReport{
+		BoardID:           report.BoardID.String(),
+		Sprints:           make([]*model.SprintCarryover, len(report.Sprints)),
+		Cards:             make([]*model.CarriedCard, len(report.Cards)),
+		TotalCarryovers:   report.TotalCarryovers,
+		UndeliveredPoints: report.UndeliveredPoints,
+	}
+	sprints := make(map[uuid.UUID]*model.Sprint, len(report.Sprints))
+	for i, s := range report.Sprints {
+		sprints[s.Sprint.ID] = sprintToModel(s.Sprint)
+		result.Sprints[i] = &model.SprintCarryover{
+			Sprint:           sprints[s.Sprint.ID],
+			CommittedCards:   s.CommittedCards,
+			CommittedPoints:  s.CommittedPoints,
+			CarriedInCards:   s.CarriedInCards,
+			CarriedOutCards:  s.CarriedOutCards,
+			CarriedOutPoints: s.CarriedOutPoints,
+		}
+	}
+	for i, c := range report.Cards {
+		carried := &model.CarriedCard{
+			CardID:            c.CardID.String(),
+			Title:             c.Title,
+			StoryPoints:       c.StoryPoints,
+			Sprints:           make([]*model.Sprint, len(c.SprintIDs)),
+			TimesCarried:      c.TimesCarried,
+			UndeliveredPoints: c.UndeliveredPoints,
+			Delivered:         c.Done,
+		}
+		for j, sprintID := range c.SprintIDs {
+			carried.Sprints[j] = sprints[sprintID]
+		}
+		result.Cards[i] = carried
+	}
+	return result, nil
+}
+
+// CardSprintHistory resolves the sprintHistory field of a Card
+func CardSprintHistory(ctx context.Context, carryoverSvc carryoverService.Service, c *model.Card) ([]*model.CardSprintStay, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := carryoverSvc.GetCardSprintHistory(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.CardSprintStay, len(history))
+	for i, stay := range history {
+		result[i] = &model.CardSprintStay{
+			Sprint:    sprintToModel(stay.Sprint),
+			AddedAt:   stay.AddedAt,
+			RemovedAt: stay.RemovedAt,
+			InSprint:  stay.InSprint,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,214 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	checklistService "github.com/thatcatdev/kaimu/backend/internal/services/checklist"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// CreateChecklistItem adds an item to the end of a card's checklist
+func CreateChecklistItem(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, checklistSvc checklistService.Service, input model.CreateChecklistItemInput) (*model.ChecklistItem, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cardID, err := uuid.Parse(input.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cardID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	var assigneeID *uuid.UUID
+	if input.AssigneeID != nil {
+		id, err := uuid.Parse(*input.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		assigneeID = &id
+	}
+
+	item, err := checklistSvc.CreateItem(ctx, cardID, input.Title, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	return checklistItemToModel(item), nil
+}
+
+// UpdateChecklistItem changes the title, done flag or assignee of a checklist item
+func UpdateChecklistItem(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, checklistSvc checklistService.Service, input model.UpdateChecklistItemInput) (*model.ChecklistItem, error) {
+	itemID, err := checklistItemEditAccess(ctx, rbacSvc, cardSvc, checklistSvc, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceInput := checklistService.UpdateItemInput{
+		ID:            itemID,
+		Title:         input.Title,
+		Done:          input.Done,
+		ClearAssignee: input.ClearAssignee != nil && *input.ClearAssignee,
+	}
+	if input.AssigneeID != nil {
+		id, err := uuid.Parse(*input.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		serviceInput.AssigneeID = &id
+	}
+
+	item, err := checklistSvc.UpdateItem(ctx, serviceInput)
+	if err != nil {
+		return nil, err
+	}
+	return checklistItemToModel(item), nil
+}
+
+// ReorderChecklistItems puts a card's checklist in the given order
+func ReorderChecklistItems(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, checklistSvc checklistService.Service, cardID string, itemIDs []string) ([]*model.ChecklistItem, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i], err = uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := checklistSvc.ReorderItems(ctx, cID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return checklistItemsToModel(items), nil
+}
+
+// DeleteChecklistItem deletes a checklist item, returning it for the audit log
+func DeleteChecklistItem(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, checklistSvc checklistService.Service, id string) (*model.ChecklistItem, error) {
+	itemID, err := checklistItemEditAccess(ctx, rbacSvc, cardSvc, checklistSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := checklistSvc.DeleteItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return checklistItemToModel(item), nil
+}
+
+// checklistItemEditAccess parses the item ID, requiring the current user to be able to edit
+// its card
+func checklistItemEditAccess(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, checklistSvc checklistService.Service, id string) (uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	itemID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	item, err := checklistSvc.GetItem(ctx, itemID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, item.CardID, "card:edit"); err != nil {
+		return uuid.Nil, err
+	}
+	return itemID, nil
+}
+
+// CardChecklist resolves the checklist field of a Card
+func CardChecklist(ctx context.Context, checklistSvc checklistService.Service, c *model.Card) ([]*model.ChecklistItem, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := checklistSvc.GetItems(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	return checklistItemsToModel(items), nil
+}
+
+// CardChecklistCompletion resolves the checklistCompletion field of a Card
+func CardChecklistCompletion(ctx context.Context, checklistSvc checklistService.Service, c *model.Card) (*int, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := checklistSvc.GetProgress(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	return checklistService.Completion(progress), nil
+}
+
+// ChecklistItemAssignee resolves the assignee field of a ChecklistItem
+func ChecklistItemAssignee(ctx context.Context, checklistSvc checklistService.Service, userSvc userService.Service, item *model.ChecklistItem) (*model.User, error) {
+	itemID, err := uuid.Parse(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemEntity, err := checklistSvc.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if itemEntity.AssigneeID == nil {
+		return nil, nil
+	}
+
+	user, err := userSvc.GetByID(ctx, *itemEntity.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	return UserToModel(user), nil
+}
+
+func checklistItemToModel(item *checklist.Item) *model.ChecklistItem {
+	return &model.ChecklistItem{
+		ID:        item.ID.String(),
+		CardID:    item.CardID.String(),
+		Title:     item.Title,
+		Done:      item.Done,
+		Position:  item.Position,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func checklistItemsToModel(items []*checklist.Item) []*model.ChecklistItem {
+	result := make([]*model.ChecklistItem, len(items))
+	for i, item := range items {
+		result[i] = checklistItemToModel(item)
+	}
+	return result
+}
+
+// ChecklistItemToModel converts a checklist item entity to a GraphQL model (exported for audit logging)
+func ChecklistItemToModel(item *checklist.Item) *model.ChecklistItem {
+	return checklistItemToModel(item)
+}
@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// ColumnCardDefaults resolves the cardDefaults field of a BoardColumn
+func ColumnCardDefaults(ctx context.Context, cardSvc cardService.Service, userSvc userService.Service, tagSvc tagService.Service, col *model.BoardColumn) (*model.ColumnCardDefaults, error) {
+	colID, err := uuid.Parse(col.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := cardSvc.GetColumnDefaults(ctx, colID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ColumnCardDefaults{
+		Priority:    optionalPriorityToModel(defaults.Priority),
+		StoryPoints: defaults.StoryPoints,
+		Tags:        []*model.Tag{},
+		Checklist:   []string(defaults.Checklist),
+	}
+	if result.Checklist == nil {
+		result.Checklist = []string{}
+	}
+	if defaults.Description != "" {
+		result.Description = &defaults.Description
+	}
+	if defaults.AssigneeID != nil {
+		user, err := userSvc.GetByID(ctx, *defaults.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		result.Assignee = UserToModel(user)
+	}
+	if len(defaults.TagIDs) > 0 {
+		tags, err := tagSvc.GetTagsByIDs(ctx, defaults.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			result.Tags = append(result.Tags, tagToModel(t))
+		}
+	}
+	return result, nil
+}
+
+func columnDefaultsInputFromModel(input *model.ColumnCardDefaultsInput) (cardService.ColumnDefaultsInput, error) {
+	defaultsInput := cardService.ColumnDefaultsInput{
+		StoryPoints: input.StoryPoints,
+		Checklist:   input.Checklist,
+	}
+	if input.Priority != nil {
+		p := modelPriorityToCard(*input.Priority)
+		defaultsInput.Priority = &p
+	}
+	if input.AssigneeID != nil {
+		assigneeID, err := uuid.Parse(*input.AssigneeID)
+		if err != nil {
+			return cardService.ColumnDefaultsInput{}, err
+		}
+		defaultsInput.AssigneeID = &assigneeID
+	}
+	for _, id := range input.TagIds {
+		tagID, err := uuid.Parse(id)
+		if err != nil {
+			return cardService.ColumnDefaultsInput{}, err
+		}
+		defaultsInput.TagIDs = append(defaultsInput.TagIDs, tagID)
+	}
+	if input.Description != nil {
+		defaultsInput.Description = *input.Description
+	}
+	return defaultsInput, nil
+}
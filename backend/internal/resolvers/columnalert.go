@@ -0,0 +1,115 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	columnAlertService "github.com/thatcatdev/kaimu/backend/internal/services/columnalert"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ColumnAlertSettings returns the board's column alert settings. They hold a webhook URL,
+// so reading them needs board:manage.
+func ColumnAlertSettings(ctx context.Context, rbacSvc rbacService.Service, columnAlertSvc columnAlertService.Service, boardID string) (*model.ColumnAlertSettings, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := columnAlertSvc.GetSettings(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+	return columnAlertSettingsToModel(setting), nil
+}
+
+// UpdateColumnAlertSettings replaces the board's column alert settings
+func UpdateColumnAlertSettings(ctx context.Context, rbacSvc rbacService.Service, columnAlertSvc columnAlertService.Service, input model.UpdateColumnAlertSettingsInput) (*model.ColumnAlertSettings, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, input.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := columnAlertSvc.UpdateSettings(ctx, bID, columnAlertService.SettingsInput{
+		Enabled:            input.Enabled,
+		WIPExceededMinutes: input.WipExceededMinutes,
+		AverageAgeHours:    input.AverageAgeHours,
+		SlackWebhookURL:    input.SlackWebhookURL,
+		SlackChannel:       input.SlackChannel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return columnAlertSettingsToModel(setting), nil
+}
+
+// ColumnAlerts returns the board's most recently raised column alerts
+func ColumnAlerts(ctx context.Context, rbacSvc rbacService.Service, columnAlertSvc columnAlertService.Service, boardID string, limit *int) ([]*model.ColumnAlert, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	n := defaultLimit
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxLimit)
+	}
+	alerts, err := columnAlertSvc.GetAlerts(ctx, bID, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ColumnAlert, len(alerts))
+	for i, a := range alerts {
+		result[i] = columnAlertToModel(a)
+	}
+	return result, nil
+}
+
+func columnAlertSettingsToModel(s *column_alert_setting.ColumnAlertSetting) *model.ColumnAlertSettings {
+	return &model.ColumnAlertSettings{
+		BoardID:            s.BoardID.String(),
+		Enabled:            s.Enabled,
+		WipExceededMinutes: s.WIPExceededMinutes,
+		AverageAgeHours:    s.AverageAgeHours,
+		SlackWebhookURL:    s.SlackWebhookURL,
+		SlackChannel:       s.SlackChannel,
+	}
+}
+
+func columnAlertToModel(a *column_alert.ColumnAlert) *model.ColumnAlert {
+	result := &model.ColumnAlert{
+		ID:             a.ID.String(),
+		ColumnID:       a.ColumnID.String(),
+		Kind:           model.ColumnAlertKindWipExceeded,
+		StartedAt:      a.StartedAt,
+		ResolvedAt:     a.ResolvedAt,
+		CardCount:      a.CardCount,
+		WipLimit:       a.WipLimit,
+		AverageAgeDays: secondsToDays(a.AverageAgeSeconds),
+	}
+	if a.Kind == column_alert.KindAging {
+		result.Kind = model.ColumnAlertKindAging
+	}
+	if a.RaisedAt != nil {
+		result.RaisedAt = *a.RaisedAt
+	}
+	return result
+}
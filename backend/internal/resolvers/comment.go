@@ -0,0 +1,231 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	commentService "github.com/thatcatdev/kaimu/backend/internal/services/comment"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// CreateCardComment comments on a card as the current user
+func CreateCardComment(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, commentSvc commentService.Service, cardID, body string) (*model.CardComment, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cID, "card:view"); err != nil {
+		return nil, err
+	}
+
+	cm, err := commentSvc.CreateComment(ctx, cID, *userID, body)
+	if err != nil {
+		return nil, contentError(ctx, err)
+	}
+	return commentToModel(cm), nil
+}
+
+// UpdateCardComment edits one of the current user's comments
+func UpdateCardComment(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, commentSvc commentService.Service, id, body string) (*model.CardComment, error) {
+	userID, commentID, err := commentAuthorAccess(ctx, rbacSvc, cardSvc, commentSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := commentSvc.UpdateComment(ctx, commentID, userID, body)
+	if err != nil {
+		return nil, contentError(ctx, err)
+	}
+	return commentToModel(cm), nil
+}
+
+// DeleteCardComment deletes one of the current user's comments, returning it for the audit log
+func DeleteCardComment(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, commentSvc commentService.Service, id string) (*model.CardComment, error) {
+	userID, commentID, err := commentAuthorAccess(ctx, rbacSvc, cardSvc, commentSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := commentSvc.DeleteComment(ctx, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return commentToModel(cm), nil
+}
+
+// commentAuthorAccess parses the comment ID, requiring the current user to still view its
+// card; the service checks they wrote it
+func commentAuthorAccess(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, commentSvc commentService.Service, id string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	commentID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	cm, err := commentSvc.GetComment(ctx, commentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cm.CardID, "card:view"); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return *userID, commentID, nil
+}
+
+// CardComments resolves the comments field of a Card
+func CardComments(ctx context.Context, commentSvc commentService.Service, c *model.Card) ([]*model.CardComment, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := commentSvc.GetComments(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CardComment, len(comments))
+	for i, cm := range comments {
+		result[i] = commentToModel(cm)
+	}
+	return result, nil
+}
+
+// CardCommentAuthor resolves the author field of a CardComment
+func CardCommentAuthor(ctx context.Context, commentSvc commentService.Service, userSvc userService.Service, cm *model.CardComment) (*model.User, error) {
+	commentID, err := uuid.Parse(cm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	commentEntity, err := commentSvc.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if commentEntity.AuthorID == nil {
+		return nil, nil
+	}
+
+	user, err := userSvc.GetByID(ctx, *commentEntity.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	return UserToModel(user), nil
+}
+
+// CardCommentMentions resolves the mentions field of a CardComment
+func CardCommentMentions(ctx context.Context, commentSvc commentService.Service, userSvc userService.Service, cm *model.CardComment) ([]*model.User, error) {
+	commentID, err := uuid.Parse(cm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := commentSvc.GetMentionedUserIDs(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := userSvc.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, UserToModel(user))
+	}
+	return result, nil
+}
+
+// MyMentions returns the current user's mention notifications
+func MyMentions(ctx context.Context, cardSvc cardService.Service, commentSvc commentService.Service, userSvc userService.Service, unreadOnly *bool, first *int) ([]*model.CommentMention, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	limit := 50
+	if first != nil {
+		limit = *first
+	}
+	mentions, err := commentSvc.GetMentions(ctx, *userID, unreadOnly != nil && *unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CommentMention, len(mentions))
+	for i, m := range mentions {
+		cm, err := commentSvc.GetComment(ctx, m.CommentID)
+		if err != nil {
+			return nil, err
+		}
+		c, err := cardSvc.GetCard(ctx, m.CardID)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &model.CommentMention{
+			ID:        m.ID.String(),
+			Comment:   commentToModel(cm),
+			Card:      cardToModel(c),
+			CreatedAt: m.CreatedAt,
+			ReadAt:    m.ReadAt,
+		}
+		if m.ActorID != nil {
+			actor, err := userSvc.GetByID(ctx, *m.ActorID)
+			if err != nil {
+				return nil, err
+			}
+			result[i].MentionedBy = UserToModel(actor)
+		}
+	}
+	return result, nil
+}
+
+// MarkMentionsRead marks the current user's mentions read
+func MarkMentionsRead(ctx context.Context, commentSvc commentService.Service, ids []string) (int, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return 0, ErrUnauthorized
+	}
+	if ids != nil && len(ids) == 0 {
+		return 0, nil
+	}
+
+	mentionIDs := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		mentionID, err := uuid.Parse(id)
+		if err != nil {
+			return 0, err
+		}
+		mentionIDs[i] = mentionID
+	}
+	return commentSvc.MarkMentionsRead(ctx, *userID, mentionIDs)
+}
+
+func commentToModel(cm *comment.Comment) *model.CardComment {
+	return &model.CardComment{
+		ID:        cm.ID.String(),
+		CardID:    cm.CardID.String(),
+		Body:      cm.Body,
+		EditedAt:  cm.EditedAt,
+		CreatedAt: cm.CreatedAt,
+		UpdatedAt: cm.UpdatedAt,
+	}
+}
+
+// CommentToModel converts a comment entity to a GraphQL model (exported for audit logging)
+func CommentToModel(cm *comment.Comment) *model.CardComment {
+	return commentToModel(cm)
+}
@@ -0,0 +1,113 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ContentLimits returns the length limits enforced on card text and comments
+func ContentLimits(ctx context.Context, contentSvc contentService.Service) (*model.ContentLimits, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+
+	limits := contentSvc.Limits()
+	return &model.ContentLimits{
+		CardTitle:       limits.Title,
+		CardDescription: limits.Description,
+		Comment:         limits.Comment,
+	}, nil
+}
+
+// SetOrganizationContentModeration turns content moderation on or off for an organization
+func SetOrganizationContentModeration(ctx context.Context, rbacSvc rbacService.Service, contentSvc contentService.Service, organizationID string, enabled bool) (*model.Organization, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, orgID, "org:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	org, err := contentSvc.SetModerationEnabled(ctx, orgID, enabled)
+	if err != nil {
+		return nil, err
+	}
+	return organizationToModel(org), nil
+}
+
+// contentError exposes content limit, policy and flood violations with a machine-readable code so
+// clients can point at the offending field, and a message in the request's language. Other
+// errors are returned unchanged.
+func contentError(ctx context.Context, err error) error {
+	var limitErr *contentService.LimitError
+	if errors.As(err, &limitErr) {
+		return &gqlerror.Error{
+			Message: i18n.Tc(ctx, "errors.content_too_long", map[string]string{
+				"field":  i18n.Tc(ctx, "field."+string(limitErr.Field), nil),
+				"length": strconv.Itoa(limitErr.Length),
+				"max":    strconv.Itoa(limitErr.Max),
+			}),
+			Extensions: map[string]interface{}{
+				"code":   "CONTENT_TOO_LONG",
+				"field":  string(limitErr.Field),
+				"length": limitErr.Length,
+				"max":    limitErr.Max,
+			},
+		}
+	}
+
+	var policyErr *contentService.PolicyError
+	if errors.As(err, &policyErr) {
+		return &gqlerror.Error{
+			Message: i18n.Tc(ctx, "errors.content_policy", map[string]string{
+				"field": i18n.Tc(ctx, "field."+string(policyErr.Field), nil),
+			}),
+			Extensions: map[string]interface{}{
+				"code":    "CONTENT_POLICY_VIOLATION",
+				"field":   string(policyErr.Field),
+				"scanner": policyErr.Scanner,
+				"reason":  policyErr.Reason,
+			},
+		}
+	}
+
+	var floodErr *contentService.FloodError
+	if errors.As(err, &floodErr) {
+		retryAfter := int(math.Ceil(floodErr.RetryAfter.Seconds()))
+		return &gqlerror.Error{
+			Message: i18n.Tc(ctx, "errors.content_flood", map[string]string{
+				"field":   i18n.Tc(ctx, "field."+string(floodErr.Field), nil),
+				"count":   strconv.Itoa(floodErr.Max),
+				"seconds": strconv.Itoa(retryAfter),
+			}),
+			Extensions: map[string]interface{}{
+				"code":              "CONTENT_FLOOD",
+				"field":             string(floodErr.Field),
+				"max":               floodErr.Max,
+				"retryAfterSeconds": retryAfter,
+			},
+		}
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	demoService "github.com/thatcatdev/kaimu/backend/internal/services/demo"
+	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
+)
+
+// SeedDemoData creates a demo organization owned by the current user
+func SeedDemoData(ctx context.Context, demoSvc demoService.Service, orgSvc orgService.Service, searchIndexer *SearchIndexer) (*model.Organization, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	if demoSvc == nil || !demoSvc.Enabled() {
+		return nil, demoService.ErrSeedingDisabled
+	}
+
+	result, err := demoSvc.SeedDemoData(ctx, *userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects, boards and cards are indexed from the events the seeding services publish;
+	// organizations have no events yet
+	if searchIndexer != nil {
+		searchIndexer.IndexOrganizationAsync(ctx, result.Organization.ID, []string{userID.String()})
+	}
+
+	owner, err := orgSvc.GetOwner(ctx, result.Organization.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	org := organizationToModel(result.Organization)
+	projects := make([]*model.Project, len(result.Projects))
+	for i, proj := range result.Projects {
+		projects[i] = projectToModelWithOrg(proj, org)
+	}
+
+	return organizationToModelWithRelations(result.Organization, UserToModel(owner), nil, projects), nil
+}
@@ -0,0 +1,282 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	dependencyService "github.com/thatcatdev/kaimu/backend/internal/services/dependency"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ProjectDependencyGraph returns the dependency graph of a project's cards
+func ProjectDependencyGraph(ctx context.Context, rbacSvc rbacService.Service, dependencySvc dependencyService.Service, projectID string) (*model.DependencyGraph, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	graph, err := dependencySvc.GetProjectGraph(ctx, projID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.DependencyGraph{
+		Nodes:     make([]*model.DependencyGraphNode, len(graph.Nodes)),
+		Edges:     make([]*model.DependencyGraphEdge, len(graph.Edges)),
+		HasCycles: graph.HasCycles,
+	}
+	for i, node := range graph.Nodes {
+		result.Nodes[i] = &model.DependencyGraphNode{
+			Card:    cardToModel(node.Card),
+			Level:   node.Level,
+			Order:   i,
+			InCycle: node.InCycle,
+		}
+	}
+	for i, edge := range graph.Edges {
+		result.Edges[i] = &model.DependencyGraphEdge{
+			ID:         edge.Dependency.ID.String(),
+			Kind:       dependencyKindToModel(edge.Dependency.Kind),
+			FromCardID: edge.Dependency.FromCardID.String(),
+			ToCardID:   edge.Dependency.ToCardID.String(),
+			InCycle:    edge.InCycle,
+		}
+	}
+	return result, nil
+}
+
+// AddCardDependency links two cards of a project
+func AddCardDependency(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, dependencySvc dependencyService.Service, input model.AddCardDependencyInput) (*model.CardDependency, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	fromCardID, err := uuid.Parse(input.FromCardID)
+	if err != nil {
+		return nil, err
+	}
+	toCardID, err := uuid.Parse(input.ToCardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, fromCardID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	d, err := dependencySvc.AddDependency(ctx, fromCardID, toCardID, dependencyKindFromModel(input.Kind), *userID)
+	if err != nil {
+		return nil, err
+	}
+	return cardDependencyToModel(ctx, cardSvc, d)
+}
+
+// RemoveCardDependency deletes a link between two cards
+func RemoveCardDependency(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, dependencySvc dependencyService.Service, id string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	dependencyID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	d, err := dependencySvc.GetDependency(ctx, dependencyID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, d.FromCardID, "card:edit"); err != nil {
+		return false, err
+	}
+
+	if err := dependencySvc.RemoveDependency(ctx, dependencyID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CardLinks resolves the links field of a Card
+func CardLinks(ctx context.Context, cardSvc cardService.Service, dependencySvc dependencyService.Service, c *model.Card) ([]*model.CardLink, error) {
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := dependencySvc.GetCardLinks(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CardLink, 0, len(links))
+	for _, link := range links {
+		other, err := cardSvc.GetCard(ctx, link.CardID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &model.CardLink{
+			ID:        link.Dependency.ID.String(),
+			Relation:  linkRelationToModel(link.Relation),
+			Card:      cardToModel(other),
+			CreatedAt: link.Dependency.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// checkOpenBlockers applies the move's open blocker policy when a card is moved into a
+// done column. It returns the warning to report once the card has moved, if any.
+func checkOpenBlockers(ctx context.Context, cardSvc cardService.Service, boardSvc boardService.Service, dependencySvc dependencyService.Service, cardID, targetColumnID uuid.UUID, policy *model.OpenBlockerPolicy) (*gqlerror.Error, error) {
+	if policy == nil || *policy == model.OpenBlockerPolicyIgnore {
+		return nil, nil
+	}
+
+	target, err := boardSvc.GetColumn(ctx, targetColumnID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.IsDone {
+		return nil, nil
+	}
+	current, err := cardSvc.GetColumnByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	// Reordering within a done column does not finish the card again
+	if current.ID == targetColumnID {
+		return nil, nil
+	}
+
+	blockers, err := dependencySvc.GetOpenBlockers(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockers) == 0 {
+		return nil, nil
+	}
+
+	if *policy == model.OpenBlockerPolicyFail {
+		return nil, cardBlockedError(ctx, blockers, "errors.card_blocked")
+	}
+	return cardBlockedError(ctx, blockers, "errors.card_blocked_warning"), nil
+}
+
+// cardBlockedError lists the open cards blocking a card
+func cardBlockedError(ctx context.Context, blockers []*card.Card, key string) *gqlerror.Error {
+	blockerIDs := make([]string, len(blockers))
+	for i, b := range blockers {
+		blockerIDs[i] = b.ID.String()
+	}
+	return &gqlerror.Error{
+		Message: i18n.Tc(ctx, key, map[string]string{"count": strconv.Itoa(len(blockers))}),
+		Extensions: map[string]interface{}{
+			"code":       "CARD_BLOCKED",
+			"blockerIds": blockerIDs,
+		},
+	}
+}
+
+// requireCardPermission checks a project permission on the project of the card's board
+func requireCardPermission(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, userID, cardID uuid.UUID, permission string) error {
+	b, err := cardSvc.GetBoardByCardID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, userID, b.ProjectID, permission)
+	if err != nil {
+		return err
+	}
+	if !hasPermission {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func cardDependencyToModel(ctx context.Context, cardSvc cardService.Service, d *card_dependency.CardDependency) (*model.CardDependency, error) {
+	fromCard, err := cardSvc.GetCard(ctx, d.FromCardID)
+	if err != nil {
+		return nil, err
+	}
+	toCard, err := cardSvc.GetCard(ctx, d.ToCardID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CardDependency{
+		ID:        d.ID.String(),
+		Kind:      dependencyKindToModel(d.Kind),
+		FromCard:  cardToModel(fromCard),
+		ToCard:    cardToModel(toCard),
+		CreatedAt: d.CreatedAt,
+	}, nil
+}
+
+func dependencyKindToModel(kind card_dependency.Kind) model.CardDependencyKind {
+	switch kind {
+	case card_dependency.KindRelates:
+		return model.CardDependencyKindRelates
+	case card_dependency.KindSplitFrom:
+		return model.CardDependencyKindSplitFrom
+	case card_dependency.KindDuplicates:
+		return model.CardDependencyKindDuplicates
+	default:
+		return model.CardDependencyKindBlocks
+	}
+}
+
+func linkRelationToModel(relation dependencyService.Relation) model.CardLinkRelation {
+	switch relation {
+	case dependencyService.RelationBlockedBy:
+		return model.CardLinkRelationBlockedBy
+	case dependencyService.RelationRelatesTo:
+		return model.CardLinkRelationRelatesTo
+	case dependencyService.RelationDuplicates:
+		return model.CardLinkRelationDuplicates
+	case dependencyService.RelationDuplicatedBy:
+		return model.CardLinkRelationDuplicatedBy
+	case dependencyService.RelationSplitFrom:
+		return model.CardLinkRelationSplitFrom
+	case dependencyService.RelationSplitInto:
+		return model.CardLinkRelationSplitInto
+	default:
+		return model.CardLinkRelationBlocks
+	}
+}
+
+func dependencyKindFromModel(kind model.CardDependencyKind) card_dependency.Kind {
+	switch kind {
+	case model.CardDependencyKindRelates:
+		return card_dependency.KindRelates
+	case model.CardDependencyKindSplitFrom:
+		return card_dependency.KindSplitFrom
+	case model.CardDependencyKindDuplicates:
+		return card_dependency.KindDuplicates
+	default:
+		return card_dependency.KindBlocks
+	}
+}
@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// maxDirectoryPageSize caps how many members one directory page holds
+const maxDirectoryPageSize = 100
+
+// OrganizationDirectory returns a filtered, sorted page of an organization's members
+func OrganizationDirectory(ctx context.Context, svc rbac.Service, organizationID string, filter *model.OrganizationDirectoryFilter, sort *model.OrganizationDirectorySort, descending *bool, first *int, after *string) (*model.OrganizationMemberConnection, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	hasAccess, err := svc.HasOrgPermission(ctx, *userID, orgID, "org:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, ErrUnauthorized
+	}
+
+	query := organization_member.DirectoryQuery{
+		Sort:  organization_member.DirectorySortName,
+		Limit: 50,
+	}
+	if filter != nil {
+		if filter.Search != nil {
+			query.Search = *filter.Search
+			// Only those who manage members may find them by email
+			query.SearchEmail, err = svc.HasOrgPermission(ctx, *userID, orgID, "org:invite")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if filter.RoleID != nil {
+			roleID, err := uuid.Parse(*filter.RoleID)
+			if err != nil {
+				return nil, err
+			}
+			query.RoleID = &roleID
+		}
+	}
+	if sort != nil {
+		query.Sort = directorySortFromModel(*sort)
+	}
+	if descending != nil {
+		query.Descending = *descending
+	}
+	if first != nil && *first > 0 {
+		query.Limit = min(*first, maxDirectoryPageSize)
+	}
+	if after != nil && *after != "" {
+		query.Offset, err = parseCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries, totalCount, err := svc.GetOrgDirectory(ctx, orgID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.OrganizationMemberEdge, len(entries))
+	for i, entry := range entries {
+		node := orgMemberToModel(&entry.OrganizationMember)
+		node.LastActiveAt = entry.LastActiveAt
+		edges[i] = &model.OrganizationMemberEdge{
+			Node:   node,
+			Cursor: encodeCursor(query.Offset + i),
+		}
+	}
+
+	var startCursor, endCursor *string
+	if len(edges) > 0 {
+		startCursor = &edges[0].Cursor
+		endCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.OrganizationMemberConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     int64(query.Offset+len(entries)) < totalCount,
+			HasPreviousPage: query.Offset > 0,
+			StartCursor:     startCursor,
+			EndCursor:       endCursor,
+			TotalCount:      int(totalCount),
+		},
+	}, nil
+}
+
+func directorySortFromModel(sort model.OrganizationDirectorySort) organization_member.DirectorySort {
+	switch sort {
+	case model.OrganizationDirectorySortJoinedAt:
+		return organization_member.DirectorySortJoinedAt
+	case model.OrganizationDirectorySortLastActiveAt:
+		return organization_member.DirectorySortLastActiveAt
+	default:
+		return organization_member.DirectorySortName
+	}
+}
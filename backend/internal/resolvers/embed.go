@@ -0,0 +1,179 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	embedService "github.com/thatcatdev/kaimu/backend/internal/services/embed"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// GenerateMetricsEmbedToken creates a token for reading the board's charts without a session
+func GenerateMetricsEmbedToken(ctx context.Context, rbacSvc rbacService.Service, embedSvc embedService.Service, boardID string, charts []model.MetricsEmbedChart, expiresAt time.Time) (*model.GeneratedMetricsEmbedToken, error) {
+	userID, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := embedService.GenerateInput{
+		BoardID:   bID,
+		Charts:    make([]metrics_embed_token.Chart, len(charts)),
+		ExpiresAt: expiresAt,
+		CreatedBy: userID,
+	}
+	for i, chart := range charts {
+		input.Charts[i] = metrics_embed_token.Chart(strings.ToLower(string(chart)))
+	}
+
+	token, embedToken, err := embedSvc.GenerateToken(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &model.GeneratedMetricsEmbedToken{
+		Token:      token,
+		EmbedToken: metricsEmbedTokenToModel(embedToken),
+	}, nil
+}
+
+// MetricsEmbedTokens returns the board's usable embed tokens
+func MetricsEmbedTokens(ctx context.Context, rbacSvc rbacService.Service, embedSvc embedService.Service, boardID string) ([]*model.MetricsEmbedToken, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := embedSvc.GetBoardTokens(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.MetricsEmbedToken, len(tokens))
+	for i, t := range tokens {
+		result[i] = metricsEmbedTokenToModel(t)
+	}
+	return result, nil
+}
+
+// RevokeMetricsEmbedToken stops an embed token from reading its board's charts
+func RevokeMetricsEmbedToken(ctx context.Context, rbacSvc rbacService.Service, embedSvc embedService.Service, id string) (*model.MetricsEmbedToken, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	embedToken, err := embedSvc.GetToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, embedToken.BoardID, "board:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	embedToken, err = embedSvc.RevokeToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return metricsEmbedTokenToModel(embedToken), nil
+}
+
+// EmbeddedMetrics returns the charts an embed token allows. The token stands in for a
+// user session, so no user is needed.
+func EmbeddedMetrics(ctx context.Context, embedSvc embedService.Service, boardSvc boardService.Service, token string, sprintID *string, mode model.MetricMode, sprintCount *int) (*model.EmbeddedMetrics, error) {
+	input := embedService.MetricsInput{
+		Mode:        metrics.MetricModeCardCount,
+		SprintCount: sprintCount,
+	}
+	if mode == model.MetricModeStoryPoints {
+		input.Mode = metrics.MetricModeStoryPoints
+	}
+	if sprintID != nil {
+		id, err := uuid.Parse(*sprintID)
+		if err != nil {
+			return nil, err
+		}
+		input.SprintID = &id
+	}
+
+	data, err := embedSvc.GetMetrics(ctx, token, input)
+	if err != nil {
+		return nil, err
+	}
+	b, err := boardSvc.GetBoard(ctx, data.Token.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.EmbeddedMetrics{
+		BoardID:   b.ID.String(),
+		BoardName: b.Name,
+	}
+	if data.Sprint != nil {
+		result.Sprint = sprintToModel(data.Sprint)
+	}
+	if data.BurnDown != nil {
+		result.BurnDown = burnDownToModel(data.BurnDown)
+	}
+	if data.BurnUp != nil {
+		result.BurnUp = burnUpToModel(data.BurnUp)
+	}
+	if data.Velocity != nil {
+		result.Velocity = velocityToModel(data.Velocity)
+	}
+	if data.CumulativeFlow != nil {
+		result.CumulativeFlow = cumulativeFlowToModel(data.CumulativeFlow)
+	}
+	return result, nil
+}
+
+// requireBoardManager parses the board ID, requiring the current user to be able to
+// manage the board
+func requireBoardManager(ctx context.Context, rbacSvc rbacService.Service, boardID string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:manage")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+	return *userID, bID, nil
+}
+
+func metricsEmbedTokenToModel(t *metrics_embed_token.MetricsEmbedToken) *model.MetricsEmbedToken {
+	charts := make([]model.MetricsEmbedChart, len(t.Charts))
+	for i, chart := range t.Charts {
+		charts[i] = model.MetricsEmbedChart(strings.ToUpper(string(chart)))
+	}
+	return &model.MetricsEmbedToken{
+		ID:        t.ID.String(),
+		BoardID:   t.BoardID.String(),
+		Charts:    charts,
+		ExpiresAt: t.ExpiresAt,
+		CreatedAt: t.CreatedAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
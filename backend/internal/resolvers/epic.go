@@ -0,0 +1,303 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	epicService "github.com/thatcatdev/kaimu/backend/internal/services/epic"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// Epics returns a project's epics
+func Epics(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, projectID string) ([]*model.Epic, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	epics, err := epicSvc.GetProjectEpics(ctx, projID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Epic, len(epics))
+	for i, e := range epics {
+		result[i] = epicToModel(e)
+	}
+	return result, nil
+}
+
+// Epic returns an epic by ID
+func Epic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, id string) (*model.Epic, error) {
+	e, err := viewableEpic(ctx, rbacSvc, epicSvc, id)
+	if err != nil {
+		return nil, err
+	}
+	return epicToModel(e), nil
+}
+
+// EpicCards resolves the cards field of an Epic
+func EpicCards(ctx context.Context, epicSvc epicService.Service, obj *model.Epic) ([]*model.Card, error) {
+	epicID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := epicSvc.GetEpicCards(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Card, len(cards))
+	for i, c := range cards {
+		result[i] = cardToModel(c)
+	}
+	return result, nil
+}
+
+// CreateEpic creates an epic in a project
+func CreateEpic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, input model.CreateEpicInput) (*model.Epic, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	description := ""
+	if input.Description != nil {
+		description = *input.Description
+	}
+
+	e, err := epicSvc.CreateEpic(ctx, projID, input.Name, description, *userID)
+	if err != nil {
+		return nil, err
+	}
+	return epicToModel(e), nil
+}
+
+// UpdateEpic renames an epic or changes its description
+func UpdateEpic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, input model.UpdateEpicInput) (*model.Epic, error) {
+	e, err := authorizedEpic(ctx, rbacSvc, epicSvc, input.ID, "card:edit")
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := epicSvc.UpdateEpic(ctx, e.ID, epicService.UpdateEpicInput{
+		Name:        input.Name,
+		Description: input.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return epicToModel(updated), nil
+}
+
+// DeleteEpic deletes an epic, leaving its cards without one
+func DeleteEpic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, id string) (bool, error) {
+	e, err := authorizedEpic(ctx, rbacSvc, epicSvc, id, "card:delete")
+	if err != nil {
+		return false, err
+	}
+
+	if err := epicSvc.DeleteEpic(ctx, e.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EpicProgress resolves the progress field of an Epic
+func EpicProgress(ctx context.Context, epicSvc epicService.Service, obj *model.Epic) (*model.EpicProgress, error) {
+	epicID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := epicSvc.GetEpicProgress(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.EpicProgress{
+		TotalCards:           progress.TotalCards,
+		CompletedCards:       progress.CompletedCards,
+		TotalStoryPoints:     progress.TotalStoryPoints,
+		CompletedStoryPoints: progress.CompletedStoryPoints,
+	}, nil
+}
+
+// EpicBurnUpData returns burn up chart data for an epic
+func EpicBurnUpData(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, metricsSvc metrics.Service, epicID string, mode model.MetricMode) (*model.EpicBurnUpData, error) {
+	e, err := viewableEpic(ctx, rbacSvc, epicSvc, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsMode := metrics.MetricModeCardCount
+	if mode == model.MetricModeStoryPoints {
+		metricsMode = metrics.MetricModeStoryPoints
+	}
+
+	data, err := metricsSvc.GetEpicBurnUpData(ctx, e.ID, metricsMode)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeLine := make([]*model.DataPoint, len(data.ScopeLine))
+	for i, p := range data.ScopeLine {
+		scopeLine[i] = &model.DataPoint{Date: p.Date, Value: p.Value}
+	}
+	doneLine := make([]*model.DataPoint, len(data.DoneLine))
+	for i, p := range data.DoneLine {
+		doneLine[i] = &model.DataPoint{Date: p.Date, Value: p.Value}
+	}
+
+	return &model.EpicBurnUpData{
+		EpicID:    data.EpicID.String(),
+		EpicName:  data.EpicName,
+		StartDate: data.StartDate,
+		EndDate:   data.EndDate,
+		ScopeLine: scopeLine,
+		DoneLine:  doneLine,
+	}, nil
+}
+
+// SetCardEpic assigns a card to an epic or removes it from its epic
+func SetCardEpic(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, epicSvc epicService.Service, cardID string, epicID *string) (*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var eID *uuid.UUID
+	if epicID != nil {
+		parsed, err := uuid.Parse(*epicID)
+		if err != nil {
+			return nil, err
+		}
+		eID = &parsed
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	c, err := epicSvc.SetCardEpic(ctx, cID, eID)
+	if err != nil {
+		return nil, err
+	}
+	return cardToModel(c), nil
+}
+
+// CriticalPath schedules an epic's cards and finds the chain that determines its end
+func CriticalPath(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, epicID string) (*model.CriticalPath, error) {
+	e, err := viewableEpic(ctx, rbacSvc, epicSvc, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	cp, err := epicSvc.GetCriticalPath(ctx, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CriticalPath{
+		EpicID: e.ID.String(),
+		Length: cp.Length,
+		Path:   make([]*model.Card, len(cp.Path)),
+		Cards:  make([]*model.CriticalPathCard, len(cp.Cards)),
+	}
+	for i, c := range cp.Path {
+		result.Path[i] = cardToModel(c)
+	}
+	for i, sc := range cp.Cards {
+		result.Cards[i] = &model.CriticalPathCard{
+			Card:            cardToModel(sc.Card),
+			RemainingPoints: sc.Remaining,
+			Estimated:       sc.Estimated,
+			EarliestStart:   sc.EarliestStart,
+			EarliestFinish:  sc.EarliestFinish,
+			LatestStart:     sc.LatestStart,
+			LatestFinish:    sc.LatestFinish,
+			Slack:           sc.Slack,
+			Critical:        sc.Critical,
+		}
+	}
+	return result, nil
+}
+
+// viewableEpic loads an epic the current user can view
+func viewableEpic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, id string) (*epic.Epic, error) {
+	return authorizedEpic(ctx, rbacSvc, epicSvc, id, "project:view")
+}
+
+// authorizedEpic loads an epic the current user has the permission on in its project
+func authorizedEpic(ctx context.Context, rbacSvc rbacService.Service, epicSvc epicService.Service, id, permission string) (*epic.Epic, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	epicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := epicSvc.GetEpic(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, e.ProjectID, permission)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+	return e, nil
+}
+
+func epicToModel(e *epic.Epic) *model.Epic {
+	return &model.Epic{
+		ID:          e.ID.String(),
+		ProjectID:   e.ProjectID.String(),
+		Name:        e.Name,
+		Description: e.Description,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	estimationService "github.com/thatcatdev/kaimu/backend/internal/services/estimation"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// EstimationAccuracy compares original estimates with cycle time for a project's cards
+// completed in the range
+func EstimationAccuracy(ctx context.Context, rbacSvc rbacService.Service, estimationSvc estimationService.Service, projectID string, dateRange *model.DateRangeInput) (*model.EstimationAccuracy, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	var from, to *time.Time
+	if dateRange != nil {
+		from, to = dateRange.From, dateRange.To
+	}
+	report, err := estimationSvc.GetReport(ctx, projID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.EstimationAccuracy{
+		ProjectID:            report.ProjectID.String(),
+		From:                 report.From,
+		To:                   report.To,
+		CardCount:            report.CardCount,
+		Points:               report.Points,
+		AverageCycleTimeDays: report.AverageCycleTimeDays,
+		DaysPerPoint:         report.DaysPerPoint,
+		MeanDeviation:        report.MeanDeviation,
+		ReestimatedCount:     report.ReestimatedCount,
+		UnestimatedCount:     report.UnestimatedCount,
+		Cards:                make([]*model.CardEstimationAccuracy, len(report.Cards)),
+		Assignees:            make([]*model.AssigneeEstimationAccuracy, len(report.Assignees)),
+		Periods:              make([]*model.EstimationPeriod, len(report.Periods)),
+		Calibration:          make([]*model.StoryPointCalibration, len(report.Calibration)),
+	}
+	for i, c := range report.Cards {
+		result.Cards[i] = &model.CardEstimationAccuracy{
+			CardID:              c.CardID.String(),
+			Title:               c.Title,
+			AssigneeID:          optionalID(c.AssigneeID),
+			OriginalStoryPoints: c.OriginalStoryPoints,
+			StoryPoints:         c.StoryPoints,
+			CycleTimeDays:       c.CycleTimeDays,
+			ExpectedDays:        c.ExpectedDays,
+			Ratio:               c.Ratio,
+			CompletedAt:         c.CompletedAt,
+		}
+	}
+	for i, a := range report.Assignees {
+		result.Assignees[i] = &model.AssigneeEstimationAccuracy{
+			AssigneeID:           optionalID(a.AssigneeID),
+			CardCount:            a.CardCount,
+			Points:               a.Points,
+			AverageCycleTimeDays: a.AverageCycleTimeDays,
+			DaysPerPoint:         a.DaysPerPoint,
+			MeanDeviation:        a.MeanDeviation,
+			ReestimatedCount:     a.ReestimatedCount,
+		}
+	}
+	for i, p := range report.Periods {
+		result.Periods[i] = &model.EstimationPeriod{
+			Start:                p.Start,
+			CardCount:            p.CardCount,
+			Points:               p.Points,
+			AverageCycleTimeDays: p.AverageCycleTimeDays,
+			DaysPerPoint:         p.DaysPerPoint,
+			MeanDeviation:        p.MeanDeviation,
+			ReestimatedCount:     p.ReestimatedCount,
+		}
+	}
+	for i, p := range report.Calibration {
+		result.Calibration[i] = &model.StoryPointCalibration{
+			StoryPoints:          p.StoryPoints,
+			CardCount:            p.CardCount,
+			AverageCycleTimeDays: p.AverageCycleTimeDays,
+		}
+	}
+	return result, nil
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	exportService "github.com/thatcatdev/kaimu/backend/internal/services/export"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ExportCardsPDF renders a board's cards, or the given cards, as a printable PDF
+func ExportCardsPDF(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, exportSvc exportService.Service, input model.ExportCardsPDFInput) (*model.CardPDFExport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	exportInput := exportService.CardsPDFInput{Layout: exportService.Layout(strings.ToLower(string(input.Layout)))}
+	if input.BoardID != nil {
+		bID, err := uuid.Parse(*input.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, ErrUnauthorized
+		}
+		exportInput.BoardID = &bID
+	}
+	if len(input.CardIds) > exportService.MaxCards {
+		return nil, exportService.ErrTooManyCards
+	}
+	for _, id := range input.CardIds {
+		cID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, cID, "card:view"); err != nil {
+			return nil, err
+		}
+		exportInput.CardIDs = append(exportInput.CardIDs, cID)
+	}
+
+	download, err := exportSvc.ExportCardsPDF(ctx, exportInput)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CardPDFExport{
+		DownloadURL: download.URL,
+		ExpiresAt:   download.ExpiresAt,
+		CardCount:   download.CardCount,
+		PageCount:   download.PageCount,
+	}, nil
+}
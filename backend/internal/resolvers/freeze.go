@@ -0,0 +1,238 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	freezeService "github.com/thatcatdev/kaimu/backend/internal/services/freeze"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// MaxFreezeOverrideReasonLength is the longest justification accepted for overriding a freeze
+const MaxFreezeOverrideReasonLength = 1000
+
+// FreezeOverride is a move into a frozen column made by someone allowed to override the freeze
+type FreezeOverride struct {
+	Window   *freeze_window.FreezeWindow
+	ColumnID uuid.UUID
+	Reason   string
+}
+
+// FreezeWindows returns the freeze windows of a board
+func FreezeWindows(ctx context.Context, rbacSvc rbacService.Service, freezeSvc freezeService.Service, boardID string) ([]*model.FreezeWindow, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	windows, err := freezeSvc.GetBoardWindows(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]*model.FreezeWindow, len(windows))
+	for i, w := range windows {
+		result[i] = freezeWindowToModel(w, now)
+	}
+	return result, nil
+}
+
+// CreateFreezeWindow schedules a freeze window on a board
+func CreateFreezeWindow(ctx context.Context, rbacSvc rbacService.Service, freezeSvc freezeService.Service, boardID string, input model.FreezeWindowInput) (*model.FreezeWindow, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	windowInput, err := freezeWindowInputFromModel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := freezeSvc.CreateWindow(ctx, bID, windowInput)
+	if err != nil {
+		return nil, err
+	}
+	return freezeWindowToModel(window, time.Now()), nil
+}
+
+// UpdateFreezeWindow replaces the settings of a freeze window
+func UpdateFreezeWindow(ctx context.Context, rbacSvc rbacService.Service, freezeSvc freezeService.Service, id string, input model.FreezeWindowInput) (*model.FreezeWindow, error) {
+	windowID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := freezeSvc.GetWindow(ctx, windowID)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := requireBoardManager(ctx, rbacSvc, existing.BoardID.String()); err != nil {
+		return nil, err
+	}
+
+	windowInput, err := freezeWindowInputFromModel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := freezeSvc.UpdateWindow(ctx, windowID, windowInput)
+	if err != nil {
+		return nil, err
+	}
+	return freezeWindowToModel(window, time.Now()), nil
+}
+
+// DeleteFreezeWindow removes a freeze window
+func DeleteFreezeWindow(ctx context.Context, rbacSvc rbacService.Service, freezeSvc freezeService.Service, id string) (bool, error) {
+	windowID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := freezeSvc.GetWindow(ctx, windowID)
+	if err != nil {
+		return false, err
+	}
+	if _, _, err := requireBoardManager(ctx, rbacSvc, existing.BoardID.String()); err != nil {
+		return false, err
+	}
+
+	if err := freezeSvc.DeleteWindow(ctx, windowID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkFreeze stops moves into a column frozen by an active freeze window. Users allowed to
+// override the freeze may still make the move if they give a reason, which is returned for
+// the audit log.
+func checkFreeze(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, freezeSvc freezeService.Service, userID, boardID, cardID, targetColumnID uuid.UUID, reason *string) (*FreezeOverride, error) {
+	col, err := cardSvc.GetColumnByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	// Reordering within a frozen column is not a move into it
+	if col.ID == targetColumnID {
+		return nil, nil
+	}
+
+	err = freezeSvc.CheckMove(ctx, targetColumnID)
+	var frozen *freezeService.FrozenError
+	if !errors.As(err, &frozen) {
+		return nil, err
+	}
+
+	canOverride, err := rbacSvc.HasBoardPermission(ctx, userID, boardID, "board:override_freeze")
+	if err != nil {
+		return nil, err
+	}
+	if !canOverride {
+		return nil, boardFrozenError(ctx, frozen, false)
+	}
+
+	justification := ""
+	if reason != nil {
+		justification = strings.TrimSpace(*reason)
+	}
+	if justification == "" {
+		return nil, boardFrozenError(ctx, frozen, true)
+	}
+	if length := utf8.RuneCountInString(justification); length > MaxFreezeOverrideReasonLength {
+		return nil, &gqlerror.Error{
+			Message: i18n.Tc(ctx, "errors.freeze_override_reason_too_long", map[string]string{
+				"length": strconv.Itoa(length),
+				"max":    strconv.Itoa(MaxFreezeOverrideReasonLength),
+			}),
+			Extensions: map[string]interface{}{
+				"code": "FREEZE_OVERRIDE_REASON_TOO_LONG",
+				"max":  MaxFreezeOverrideReasonLength,
+			},
+		}
+	}
+
+	return &FreezeOverride{Window: frozen.Window, ColumnID: targetColumnID, Reason: justification}, nil
+}
+
+// boardFrozenError tells the client which window froze the column and whether the user may
+// retry with a freezeOverrideReason
+func boardFrozenError(ctx context.Context, err *freezeService.FrozenError, canOverride bool) *gqlerror.Error {
+	key := "errors.board_frozen"
+	if canOverride {
+		key = "errors.board_frozen_reason_required"
+	}
+	return &gqlerror.Error{
+		Message: i18n.Tc(ctx, key, map[string]string{
+			"window": err.Window.Name,
+			"endsAt": err.Window.EndsAt.UTC().Format("2006-01-02 15:04 UTC"),
+		}),
+		Extensions: map[string]interface{}{
+			"code":        "BOARD_FROZEN",
+			"windowId":    err.Window.ID.String(),
+			"windowName":  err.Window.Name,
+			"columnId":    err.ColumnID.String(),
+			"endsAt":      err.Window.EndsAt.UTC().Format(time.RFC3339),
+			"canOverride": canOverride,
+		},
+	}
+}
+
+func freezeWindowInputFromModel(input model.FreezeWindowInput) (freezeService.WindowInput, error) {
+	columnIDs := make([]uuid.UUID, len(input.ColumnIds))
+	for i, id := range input.ColumnIds {
+		columnID, err := uuid.Parse(id)
+		if err != nil {
+			return freezeService.WindowInput{}, err
+		}
+		columnIDs[i] = columnID
+	}
+	return freezeService.WindowInput{
+		Name:      input.Name,
+		StartsAt:  input.StartsAt,
+		EndsAt:    input.EndsAt,
+		ColumnIDs: columnIDs,
+	}, nil
+}
+
+func freezeWindowToModel(w *freeze_window.FreezeWindow, now time.Time) *model.FreezeWindow {
+	columnIDs := make([]string, len(w.ColumnIDs))
+	for i, id := range w.ColumnIDs {
+		columnIDs[i] = id.String()
+	}
+	return &model.FreezeWindow{
+		ID:        w.ID.String(),
+		BoardID:   w.BoardID.String(),
+		Name:      w.Name,
+		StartsAt:  w.StartsAt,
+		EndsAt:    w.EndsAt,
+		ColumnIds: columnIDs,
+		Active:    w.IsActive(now),
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
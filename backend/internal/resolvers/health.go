@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	healthService "github.com/thatcatdev/kaimu/backend/internal/services/health"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ProjectHealth resolves the health field of a Project
+func ProjectHealth(ctx context.Context, healthSvc healthService.Service, p *model.Project) (*model.ProjectHealth, error) {
+	projectID, err := uuid.Parse(p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := healthSvc.GetProjectHealth(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ProjectHealth{
+		Status: healthStatusToModel(report.Status),
+		Score:  report.Score,
+	}, nil
+}
+
+// ProjectHealthBreakdown returns the signals behind a project's health
+func ProjectHealthBreakdown(ctx context.Context, rbacSvc rbacService.Service, healthSvc healthService.Service, projectID string) (*model.ProjectHealthBreakdown, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	report, err := healthSvc.GetProjectHealth(ctx, projID)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := make([]*model.ProjectHealthSignal, len(report.Signals))
+	for i, s := range report.Signals {
+		signal := &model.ProjectHealthSignal{
+			Kind:   model.ProjectHealthSignalKind(strings.ToUpper(string(s.Kind))),
+			Status: healthStatusToModel(s.Status),
+			Value:  s.Value,
+			Count:  s.Count,
+			Total:  s.Total,
+		}
+		if s.Trend != nil {
+			trend := model.VelocityTrend(strings.ToUpper(string(*s.Trend)))
+			signal.Trend = &trend
+		}
+		signals[i] = signal
+	}
+
+	return &model.ProjectHealthBreakdown{
+		ProjectID:  report.ProjectID.String(),
+		Status:     healthStatusToModel(report.Status),
+		Score:      report.Score,
+		Signals:    signals,
+		ComputedAt: report.ComputedAt,
+	}, nil
+}
+
+func healthStatusToModel(status healthService.Status) model.ProjectHealthStatus {
+	return model.ProjectHealthStatus(strings.ToUpper(string(status)))
+}
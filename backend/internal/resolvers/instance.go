@@ -0,0 +1,78 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting"
+	instanceService "github.com/thatcatdev/kaimu/backend/internal/services/instance"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mjml"
+)
+
+// InstanceInfo returns the instance's branding to anyone, signed in or not
+func InstanceInfo(ctx context.Context, instanceSvc instanceService.Service) (*model.InstanceInfo, error) {
+	setting, err := instanceSvc.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return instanceInfoToModel(setting), nil
+}
+
+// IsPlatformAdmin reports whether the current user manages the instance settings
+func IsPlatformAdmin(ctx context.Context, instanceSvc instanceService.Service) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, nil
+	}
+	return instanceSvc.IsAdmin(ctx, *userID)
+}
+
+// UpdateInstanceSettings replaces the instance's branding; only platform admins may
+func UpdateInstanceSettings(ctx context.Context, instanceSvc instanceService.Service, input model.UpdateInstanceSettingsInput) (*model.InstanceInfo, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	isAdmin, err := instanceSvc.IsAdmin(ctx, *userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, ErrUnauthorized
+	}
+
+	var settings instanceService.Input
+	if input.ProductName != nil {
+		settings.ProductName = *input.ProductName
+	}
+	if input.LogoURL != nil {
+		settings.LogoURL = *input.LogoURL
+	}
+	if input.SupportEmail != nil {
+		settings.SupportEmail = *input.SupportEmail
+	}
+	if input.TermsURL != nil {
+		settings.TermsURL = *input.TermsURL
+	}
+
+	setting, err := instanceSvc.UpdateSettings(ctx, settings, *userID)
+	if err != nil {
+		return nil, err
+	}
+	return instanceInfoToModel(setting), nil
+}
+
+func instanceInfoToModel(setting *instance_setting.InstanceSetting) *model.InstanceInfo {
+	productName := setting.ProductName
+	if productName == "" {
+		productName = mjml.DefaultProductName
+	}
+	return &model.InstanceInfo{
+		ProductName:  productName,
+		LogoURL:      stringPtr(setting.LogoURL),
+		SupportEmail: stringPtr(setting.SupportEmail),
+		TermsURL:     stringPtr(setting.TermsURL),
+	}
+}
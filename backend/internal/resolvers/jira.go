@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	jiraService "github.com/thatcatdev/kaimu/backend/internal/services/jira"
+	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// ExportProjectAsJira exports a project for Jira's JSON and CSV importers
+func ExportProjectAsJira(ctx context.Context, rbacSvc rbacService.Service, projectSvc projectService.Service, jiraSvc jiraService.Service, projectID string) (*model.JiraExport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	pID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := projectSvc.GetProject(ctx, pID)
+	if err != nil {
+		return nil, err
+	}
+	// The export holds the emails of the users it references
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, proj.OrganizationID, "org:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	export, err := jiraSvc.ExportProject(ctx, pID)
+	if err != nil {
+		return nil, err
+	}
+	document, err := export.MarshalJSONDocument()
+	if err != nil {
+		return nil, err
+	}
+	var csv strings.Builder
+	if err := export.WriteCSV(&csv); err != nil {
+		return nil, err
+	}
+
+	result := &model.JiraExport{
+		ProjectKey: export.ProjectKey,
+		JSON:       string(document),
+		CSV:        csv.String(),
+		Statuses:   make([]*model.JiraStatus, len(export.Statuses)),
+		Sprints:    make([]*model.JiraSprint, len(export.Sprints)),
+		IssueCount: len(export.Issues),
+	}
+	for i, s := range export.Statuses {
+		result.Statuses[i] = &model.JiraStatus{Name: s.Name, Category: jiraStatusCategoryToModel(s.Category)}
+	}
+	for i, s := range export.Sprints {
+		result.Sprints[i] = &model.JiraSprint{
+			Name:      s.Name,
+			State:     s.State,
+			StartDate: s.StartDate,
+			EndDate:   s.EndDate,
+		}
+		if s.Goal != "" {
+			goal := s.Goal
+			result.Sprints[i].Goal = &goal
+		}
+	}
+	return result, nil
+}
+
+func jiraStatusCategoryToModel(c jiraService.StatusCategory) model.JiraStatusCategory {
+	switch c {
+	case jiraService.StatusCategoryDone:
+		return model.JiraStatusCategoryDone
+	case jiraService.StatusCategoryInProgress:
+		return model.JiraStatusCategoryInProgress
+	default:
+		return model.JiraStatusCategoryToDo
+	}
+}
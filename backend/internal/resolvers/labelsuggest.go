@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/labelsuggest"
+)
+
+// CardLabelSuggestions resolves the labelSuggestions field of a Card. Without a signed-in user
+// nothing is suggested.
+func CardLabelSuggestions(ctx context.Context, suggestSvc labelsuggest.Service, c *model.Card) (*model.LabelSuggestions, error) {
+	result := &model.LabelSuggestions{Tags: []*model.TagSuggestion{}}
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return result, nil
+	}
+
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	suggestions, err := suggestSvc.Suggest(ctx, *userID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range suggestions.Tags {
+		result.Tags = append(result.Tags, &model.TagSuggestion{Tag: tagToModel(s.Tag), Confidence: s.Confidence})
+	}
+	if suggestions.Priority != nil {
+		result.Priority = &model.PrioritySuggestion{
+			Priority:   cardPriorityToModel(suggestions.Priority.Priority),
+			Confidence: suggestions.Priority.Confidence,
+		}
+	}
+	switch suggestions.Source {
+	case labelsuggest.SourceHistory:
+		source := model.LabelSuggestionSourceHistory
+		result.Source = &source
+	case labelsuggest.SourceLanguageModel:
+		source := model.LabelSuggestionSourceLanguageModel
+		result.Source = &source
+	}
+	return result, nil
+}
+
+// AcceptLabelSuggestions adds accepted tags to a card and sets an accepted priority through
+// the updateCard mutation, so it gets the same permission check and audit logging
+func AcceptLabelSuggestions(ctx context.Context, cardSvc cardService.Service, mutator CardMutator, input model.AcceptLabelSuggestionsInput) (*model.Card, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+
+	cardID, err := uuid.Parse(input.CardID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := cardSvc.GetTagsForCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]string, 0, len(tags)+len(input.TagIds))
+	for _, t := range tags {
+		tagIDs = append(tagIDs, t.ID.String())
+	}
+	for _, id := range input.TagIds {
+		tagID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(tagIDs, tagID.String()) {
+			tagIDs = append(tagIDs, tagID.String())
+		}
+	}
+
+	return mutator.UpdateCard(ctx, model.UpdateCardInput{
+		ID:       input.CardID,
+		TagIds:   tagIDs,
+		Priority: input.Priority,
+	})
+}
@@ -0,0 +1,94 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// LegalHold returns the organization's active legal hold, or nil
+func LegalHold(ctx context.Context, rbacSvc rbacService.Service, holdSvc legalholdService.Service, userSvc userService.Service, organizationID string) (*model.LegalHold, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := holdSvc.GetActiveHold(ctx, orgID)
+	if err != nil || hold == nil {
+		return nil, err
+	}
+	return legalHoldToModel(ctx, userSvc, hold), nil
+}
+
+// LegalHolds returns the organization's legal holds, newest first
+func LegalHolds(ctx context.Context, rbacSvc rbacService.Service, holdSvc legalholdService.Service, userSvc userService.Service, organizationID string) ([]*model.LegalHold, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := holdSvc.GetHolds(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.LegalHold, len(holds))
+	for i, h := range holds {
+		result[i] = legalHoldToModel(ctx, userSvc, h)
+	}
+	return result, nil
+}
+
+// PlaceLegalHold blocks permanent deletions in the organization until the hold is lifted
+func PlaceLegalHold(ctx context.Context, rbacSvc rbacService.Service, holdSvc legalholdService.Service, userSvc userService.Service, organizationID, reason string) (*model.LegalHold, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := holdSvc.PlaceHold(ctx, orgID, *middleware.GetUserIDFromContext(ctx), reason)
+	if err != nil {
+		return nil, err
+	}
+	return legalHoldToModel(ctx, userSvc, hold), nil
+}
+
+// LiftLegalHold lifts the organization's active legal hold
+func LiftLegalHold(ctx context.Context, rbacSvc rbacService.Service, holdSvc legalholdService.Service, userSvc userService.Service, organizationID, reason string) (*model.LegalHold, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := holdSvc.LiftHold(ctx, orgID, *middleware.GetUserIDFromContext(ctx), reason)
+	if err != nil {
+		return nil, err
+	}
+	return legalHoldToModel(ctx, userSvc, hold), nil
+}
+
+func legalHoldToModel(ctx context.Context, userSvc userService.Service, h *legal_hold.LegalHold) *model.LegalHold {
+	result := &model.LegalHold{
+		ID:         h.ID.String(),
+		Reason:     h.Reason,
+		PlacedAt:   h.PlacedAt,
+		LiftReason: h.LiftReason,
+		LiftedAt:   h.LiftedAt,
+		Active:     h.IsActive(),
+	}
+	if h.PlacedBy != nil {
+		if u, err := userSvc.GetByID(ctx, *h.PlacedBy); err == nil && u != nil {
+			result.PlacedBy = UserToModel(u)
+		}
+	}
+	if h.LiftedBy != nil {
+		if u, err := userSvc.GetByID(ctx, *h.LiftedBy); err == nil && u != nil {
+			result.LiftedBy = UserToModel(u)
+		}
+	}
+	return result
+}
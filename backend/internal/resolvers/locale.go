@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	localeService "github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// SupportedLocales returns the locales the server has translations for
+func SupportedLocales(ctx context.Context) ([]string, error) {
+	return i18n.Supported(), nil
+}
+
+// SetMyLocale sets or clears the current user's language preference
+func SetMyLocale(ctx context.Context, localeSvc localeService.Service, locale *string) (*model.User, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrNotAuthenticated
+	}
+
+	u, err := localeSvc.SetUserLocale(ctx, *userID, locale)
+	if err != nil {
+		return nil, localeError(err)
+	}
+	return UserToModel(u), nil
+}
+
+// SetOrganizationDefaultLocale sets the language for organization members without a preference
+func SetOrganizationDefaultLocale(ctx context.Context, rbacSvc rbacService.Service, localeSvc localeService.Service, organizationID string, locale string) (*model.Organization, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, orgID, "org:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	org, err := localeSvc.SetOrganizationDefaultLocale(ctx, orgID, locale)
+	if err != nil {
+		return nil, localeError(err)
+	}
+	return organizationToModel(org), nil
+}
+
+// localeError tags unsupported locales with a code and lists the supported ones
+func localeError(err error) error {
+	if errors.Is(err, localeService.ErrUnsupportedLocale) {
+		return &gqlerror.Error{
+			Message: err.Error(),
+			Extensions: map[string]interface{}{
+				"code":             "UNSUPPORTED_LOCALE",
+				"supportedLocales": i18n.Supported(),
+			},
+		}
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	mergeService "github.com/thatcatdev/kaimu/backend/internal/services/merge"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// MergeCards merges duplicate cards into the primary card
+func MergeCards(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mergeSvc mergeService.Service, primaryID string, duplicateIDs []string) (*model.MergeCardsResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	pID, err := uuid.Parse(primaryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, pID, "card:edit"); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(duplicateIDs))
+	for i, duplicateID := range duplicateIDs {
+		if ids[i], err = uuid.Parse(duplicateID); err != nil {
+			return nil, err
+		}
+		if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, ids[i], "card:edit"); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := mergeSvc.MergeCards(ctx, pID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]*model.Card, len(result.Duplicates))
+	for i, c := range result.Duplicates {
+		duplicates[i] = cardToModel(c)
+	}
+	return &model.MergeCardsResult{
+		Card:       cardToModel(result.Card),
+		Duplicates: duplicates,
+		TagsMoved:  result.TagsMoved,
+		LinksMoved: result.LinksMoved,
+	}, nil
+}
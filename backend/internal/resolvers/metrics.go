@@ -37,8 +37,11 @@ func (r *MetricsResolver) BurnDownData(ctx context.Context, sprintID string, mod
 	if err != nil {
 		return nil, err
 	}
+	return burnDownToModel(data), nil
+}
 
-	// Convert to GraphQL model
+// burnDownToModel converts burn down chart data to the GraphQL model
+func burnDownToModel(data *metrics.BurnDownData) *model.BurnDownData {
 	idealLine := make([]*model.DataPoint, len(data.IdealLine))
 	for i, p := range data.IdealLine {
 		idealLine[i] = &model.DataPoint{
@@ -62,7 +65,7 @@ func (r *MetricsResolver) BurnDownData(ctx context.Context, sprintID string, mod
 		EndDate:    data.EndDate,
 		IdealLine:  idealLine,
 		ActualLine: actualLine,
-	}, nil
+	}
 }
 
 // BurnUpData returns burn up chart data for a sprint
@@ -81,8 +84,11 @@ func (r *MetricsResolver) BurnUpData(ctx context.Context, sprintID string, mode
 	if err != nil {
 		return nil, err
 	}
+	return burnUpToModel(data), nil
+}
 
-	// Convert to GraphQL model
+// burnUpToModel converts burn up chart data to the GraphQL model
+func burnUpToModel(data *metrics.BurnUpData) *model.BurnUpData {
 	scopeLine := make([]*model.DataPoint, len(data.ScopeLine))
 	for i, p := range data.ScopeLine {
 		scopeLine[i] = &model.DataPoint{
@@ -106,7 +112,7 @@ func (r *MetricsResolver) BurnUpData(ctx context.Context, sprintID string, mode
 		EndDate:    data.EndDate,
 		ScopeLine:  scopeLine,
 		DoneLine:   doneLine,
-	}, nil
+	}
 }
 
 // VelocityData returns velocity data for closed sprints on a board
@@ -130,8 +136,11 @@ func (r *MetricsResolver) VelocityData(ctx context.Context, boardID string, spri
 	if err != nil {
 		return nil, err
 	}
+	return velocityToModel(data), nil
+}
 
-	// Convert to GraphQL model
+// velocityToModel converts velocity data to the GraphQL model
+func velocityToModel(data *metrics.VelocityData) *model.VelocityData {
 	sprints := make([]*model.SprintVelocity, len(data.Sprints))
 	for i, sv := range data.Sprints {
 		sprints[i] = &model.SprintVelocity{
@@ -144,7 +153,7 @@ func (r *MetricsResolver) VelocityData(ctx context.Context, boardID string, spri
 
 	return &model.VelocityData{
 		Sprints: sprints,
-	}, nil
+	}
 }
 
 // CumulativeFlowData returns cumulative flow diagram data for a sprint
@@ -163,8 +172,11 @@ func (r *MetricsResolver) CumulativeFlowData(ctx context.Context, sprintID strin
 	if err != nil {
 		return nil, err
 	}
+	return cumulativeFlowToModel(data), nil
+}
 
-	// Convert to GraphQL model
+// cumulativeFlowToModel converts cumulative flow diagram data to the GraphQL model
+func cumulativeFlowToModel(data *metrics.CumulativeFlowData) *model.CumulativeFlowData {
 	columns := make([]*model.ColumnFlowData, len(data.Columns))
 	for i, col := range data.Columns {
 		columns[i] = &model.ColumnFlowData{
@@ -187,7 +199,7 @@ func (r *MetricsResolver) CumulativeFlowData(ctx context.Context, sprintID strin
 		SprintName: data.SprintName,
 		Columns:    columns,
 		Dates:      dates,
-	}, nil
+	}
 }
 
 // SprintStats returns current statistics for a sprint
@@ -203,11 +215,13 @@ func (r *MetricsResolver) SprintStats(ctx context.Context, sprintID string) (*mo
 	}
 
 	return &model.SprintStats{
-		TotalCards:           stats.TotalCards,
-		CompletedCards:       stats.CompletedCards,
-		TotalStoryPoints:     stats.TotalStoryPoints,
-		CompletedStoryPoints: stats.CompletedStoryPoints,
-		DaysRemaining:        stats.DaysRemaining,
-		DaysElapsed:          stats.DaysElapsed,
+		TotalCards:              stats.TotalCards,
+		CompletedCards:          stats.CompletedCards,
+		TotalStoryPoints:        stats.TotalStoryPoints,
+		CompletedStoryPoints:    stats.CompletedStoryPoints,
+		DaysRemaining:           stats.DaysRemaining,
+		DaysElapsed:             stats.DaysElapsed,
+		TotalChecklistItems:     stats.TotalChecklistItems,
+		CompletedChecklistItems: stats.CompletedChecklistItems,
 	}, nil
 }
@@ -0,0 +1,165 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	mirrorService "github.com/thatcatdev/kaimu/backend/internal/services/mirror"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// CardMirrors returns the mirror links of a card
+func CardMirrors(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mirrorSvc mirrorService.Service, cardID string) ([]*model.CardMirror, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, id, "project:view"); err != nil {
+		return nil, err
+	}
+
+	mirrors, err := mirrorSvc.GetCardMirrors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.CardMirror, 0, len(mirrors))
+	for _, m := range mirrors {
+		converted, err := cardMirrorToModel(ctx, cardSvc, m)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// MirrorCard mirrors a card onto the default board of another project
+func MirrorCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mirrorSvc mirrorService.Service, cardID, targetProjectID string, direction model.CardMirrorDirection) (*model.CardMirror, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+	projID, err := uuid.Parse(targetProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, id, "card:edit"); err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "card:create")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	m, err := mirrorSvc.MirrorCard(ctx, id, projID, mirrorDirectionFromModel(direction), *userID)
+	if err != nil {
+		return nil, err
+	}
+	return cardMirrorToModel(ctx, cardSvc, m)
+}
+
+// SetCardMirrorDirection changes whether a mirror also syncs back to its source
+func SetCardMirrorDirection(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mirrorSvc mirrorService.Service, id string, direction model.CardMirrorDirection) (*model.CardMirror, error) {
+	mirrorID, err := requireMirrorPermission(ctx, rbacSvc, cardSvc, mirrorSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := mirrorSvc.SetDirection(ctx, mirrorID, mirrorDirectionFromModel(direction))
+	if err != nil {
+		return nil, err
+	}
+	return cardMirrorToModel(ctx, cardSvc, m)
+}
+
+// RemoveCardMirror unlinks a mirror from its source card
+func RemoveCardMirror(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mirrorSvc mirrorService.Service, id string) (bool, error) {
+	mirrorID, err := requireMirrorPermission(ctx, rbacSvc, cardSvc, mirrorSvc, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := mirrorSvc.RemoveMirror(ctx, mirrorID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// requireMirrorPermission lets members who can edit cards on either side manage the link
+func requireMirrorPermission(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, mirrorSvc mirrorService.Service, id string) (uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	mirrorID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	m, err := mirrorSvc.GetMirror(ctx, mirrorID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	err = requireCardPermission(ctx, rbacSvc, cardSvc, *userID, m.SourceCardID, "card:edit")
+	if err == ErrUnauthorized {
+		err = requireCardPermission(ctx, rbacSvc, cardSvc, *userID, m.MirrorCardID, "card:edit")
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return mirrorID, nil
+}
+
+func cardMirrorToModel(ctx context.Context, cardSvc cardService.Service, m *card_mirror.CardMirror) (*model.CardMirror, error) {
+	sourceCard, err := cardSvc.GetCard(ctx, m.SourceCardID)
+	if err != nil {
+		return nil, err
+	}
+	mirrorCard, err := cardSvc.GetCard(ctx, m.MirrorCardID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CardMirror{
+		ID:         m.ID.String(),
+		Direction:  mirrorDirectionToModel(m.Direction),
+		SourceCard: cardToModel(sourceCard),
+		MirrorCard: cardToModel(mirrorCard),
+		CreatedAt:  m.CreatedAt,
+	}, nil
+}
+
+func mirrorDirectionToModel(direction card_mirror.Direction) model.CardMirrorDirection {
+	if direction == card_mirror.DirectionTwoWay {
+		return model.CardMirrorDirectionTwoWay
+	}
+	return model.CardMirrorDirectionOneWay
+}
+
+func mirrorDirectionFromModel(direction model.CardMirrorDirection) card_mirror.Direction {
+	if direction == model.CardMirrorDirectionTwoWay {
+		return card_mirror.DirectionTwoWay
+	}
+	return card_mirror.DirectionOneWay
+}
@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+)
+
+// MySprintWork returns the requesting user's cards in the board's active sprint
+func MySprintWork(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, boardID string) (*model.MySprintWork, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "sprint:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	work, err := sprintSvc.GetMySprintWork(ctx, bID, *userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.MySprintWork{
+		Columns: make([]*model.SprintWorkColumn, len(work.Columns)),
+		Totals:  sprintWorkTotalsToModel(work.Totals),
+	}
+	if work.Sprint != nil {
+		result.Sprint = sprintToModel(work.Sprint)
+	}
+	for i, col := range work.Columns {
+		cards := make([]*model.Card, len(col.Cards))
+		for j, c := range col.Cards {
+			cards[j] = cardToModel(c)
+		}
+		result.Columns[i] = &model.SprintWorkColumn{
+			Column: columnToModel(col.Column),
+			Cards:  cards,
+			Totals: sprintWorkTotalsToModel(col.Totals),
+		}
+	}
+	return result, nil
+}
+
+func sprintWorkTotalsToModel(t sprintService.SprintWorkTotals) *model.SprintWorkTotals {
+	return &model.SprintWorkTotals{
+		CardCount:       t.CardCount,
+		StoryPoints:     t.StoryPoints,
+		DoneCardCount:   t.DoneCardCount,
+		DoneStoryPoints: t.DoneStoryPoints,
+	}
+}
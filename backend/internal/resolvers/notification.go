@@ -0,0 +1,380 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	notificationService "github.com/thatcatdev/kaimu/backend/internal/services/notification"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// notificationRuleEvents maps the GraphQL event enum to the domain events rules watch
+var notificationRuleEvents = map[model.NotificationRuleEvent]events.Name{
+	model.NotificationRuleEventCardCreated:     events.CardCreated,
+	model.NotificationRuleEventCardUpdated:     events.CardUpdated,
+	model.NotificationRuleEventCardMoved:       events.CardMoved,
+	model.NotificationRuleEventCardDeleted:     events.CardDeleted,
+	model.NotificationRuleEventCardSLABreached: events.CardSLABreached,
+}
+
+// MyNotificationRules returns the current user's notification rules
+func MyNotificationRules(ctx context.Context, notificationSvc notificationService.Service) ([]*model.NotificationRule, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	rules, err := notificationSvc.GetRulesByUserID(ctx, *userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.NotificationRule, len(rules))
+	for i, rule := range rules {
+		result[i] = notificationRuleToModel(rule)
+	}
+	return result, nil
+}
+
+// CreateNotificationRule adds a notification rule for the current user
+func CreateNotificationRule(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, input model.NotificationRuleInput) (*model.NotificationRule, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	ruleInput, err := notificationRuleInputFromModel(ctx, rbacSvc, *userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := notificationSvc.CreateRule(ctx, *userID, ruleInput)
+	if err != nil {
+		return nil, err
+	}
+	return notificationRuleToModel(rule), nil
+}
+
+// UpdateNotificationRule replaces one of the current user's notification rules
+func UpdateNotificationRule(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, id string, input model.NotificationRuleInput) (*model.NotificationRule, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	rule, err := ownNotificationRule(ctx, notificationSvc, *userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleInput, err := notificationRuleInputFromModel(ctx, rbacSvc, *userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err = notificationSvc.UpdateRule(ctx, rule.ID, ruleInput)
+	if err != nil {
+		return nil, err
+	}
+	return notificationRuleToModel(rule), nil
+}
+
+// DeleteNotificationRule removes one of the current user's notification rules
+func DeleteNotificationRule(ctx context.Context, notificationSvc notificationService.Service, id string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	rule, err := ownNotificationRule(ctx, notificationSvc, *userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := notificationSvc.DeleteRule(ctx, rule.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TestNotificationRule sends a sample notification for one of the current user's rules
+func TestNotificationRule(ctx context.Context, notificationSvc notificationService.Service, id string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	rule, err := ownNotificationRule(ctx, notificationSvc, *userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := notificationSvc.TestRule(ctx, rule.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ownNotificationRule loads a rule, failing unless it belongs to userID
+func ownNotificationRule(ctx context.Context, notificationSvc notificationService.Service, userID uuid.UUID, id string) (*notification_rule.NotificationRule, error) {
+	ruleID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := notificationSvc.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return rule, nil
+}
+
+// notificationRuleInputFromModel converts the input, requiring the user to be able to view
+// the project the rule watches
+func notificationRuleInputFromModel(ctx context.Context, rbacSvc rbacService.Service, userID uuid.UUID, input model.NotificationRuleInput) (notificationService.RuleInput, error) {
+	projectID, err := uuid.Parse(input.ProjectID)
+	if err != nil {
+		return notificationService.RuleInput{}, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, userID, projectID, "project:view")
+	if err != nil {
+		return notificationService.RuleInput{}, err
+	}
+	if !hasPermission {
+		return notificationService.RuleInput{}, ErrUnauthorized
+	}
+
+	ruleInput := notificationService.RuleInput{
+		ProjectID: projectID,
+		Name:      input.Name,
+		Event:     notificationRuleEvents[input.Event],
+		Enabled:   input.Enabled == nil || *input.Enabled,
+	}
+	if input.TagID != nil {
+		tagID, err := uuid.Parse(*input.TagID)
+		if err != nil {
+			return notificationService.RuleInput{}, err
+		}
+		ruleInput.TagID = &tagID
+	}
+	if input.ColumnID != nil {
+		columnID, err := uuid.Parse(*input.ColumnID)
+		if err != nil {
+			return notificationService.RuleInput{}, err
+		}
+		ruleInput.ColumnID = &columnID
+	}
+	if input.Priority != nil {
+		p := modelPriorityToCard(*input.Priority)
+		ruleInput.Priority = &p
+	}
+	return ruleInput, nil
+}
+
+func notificationRuleToModel(rule *notification_rule.NotificationRule) *model.NotificationRule {
+	m := &model.NotificationRule{
+		ID:        rule.ID.String(),
+		ProjectID: rule.ProjectID.String(),
+		Name:      rule.Name,
+		Priority:  optionalPriorityToModel(rule.Priority),
+		Enabled:   rule.Enabled,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+	for event, name := range notificationRuleEvents {
+		if string(name) == rule.Event {
+			m.Event = event
+		}
+	}
+	if rule.TagID != nil {
+		tagID := rule.TagID.String()
+		m.TagID = &tagID
+	}
+	if rule.ColumnID != nil {
+		columnID := rule.ColumnID.String()
+		m.ColumnID = &columnID
+	}
+	return m
+}
+
+// ProjectNotificationSettings returns where each card event in a project is delivered
+func ProjectNotificationSettings(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, projectID string) ([]*model.NotificationChannelSetting, error) {
+	id, err := requireProjectManager(ctx, rbacSvc, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := notificationSvc.GetProjectChannelRoutes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return channelRoutesToModel(routes), nil
+}
+
+// OrganizationNotificationSettings returns an organization's default delivery per card event
+func OrganizationNotificationSettings(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, organizationID string) ([]*model.NotificationChannelSetting, error) {
+	id, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := notificationSvc.GetOrganizationChannelRoutes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return channelRoutesToModel(routes), nil
+}
+
+// UpdateProjectNotificationSettings replaces a project's channel overrides
+func UpdateProjectNotificationSettings(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, projectID string, settings []*model.NotificationChannelSettingInput) ([]*model.NotificationChannelSetting, error) {
+	id, err := requireProjectManager(ctx, rbacSvc, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := channelRouteInputsFromModel(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := notificationSvc.SetProjectChannelRoutes(ctx, id, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return channelRoutesToModel(routes), nil
+}
+
+// UpdateOrganizationNotificationSettings replaces an organization's default channels
+func UpdateOrganizationNotificationSettings(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, organizationID string, settings []*model.NotificationChannelSettingInput) ([]*model.NotificationChannelSetting, error) {
+	id, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := channelRouteInputsFromModel(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := notificationSvc.SetOrganizationChannelRoutes(ctx, id, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return channelRoutesToModel(routes), nil
+}
+
+// SetBoardQuietMode turns a board's notification batching on for minutes, or off for nil
+func SetBoardQuietMode(ctx context.Context, rbacSvc rbacService.Service, notificationSvc notificationService.Service, boardID string, minutes *int) (*model.Board, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := notificationSvc.SetBoardQuietMode(ctx, bID, minutes)
+	if err != nil {
+		return nil, err
+	}
+	return boardToModel(b), nil
+}
+
+// requireProjectManager parses the project ID, requiring the current user to manage the
+// project. Channel settings hold webhook URLs, so even reading them needs project:manage.
+func requireProjectManager(ctx context.Context, rbacSvc rbacService.Service, projectID string) (uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(projectID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, id, "project:manage")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, ErrUnauthorized
+	}
+	return id, nil
+}
+
+// requireOrganizationManager parses the organization ID, requiring the current user to
+// manage the organization
+func requireOrganizationManager(ctx context.Context, rbacSvc rbacService.Service, organizationID string) (uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, id, "org:manage")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, ErrUnauthorized
+	}
+	return id, nil
+}
+
+// channelRouteInputsFromModel converts the settings, requiring a webhook for SLACK and
+// dropping Slack details when SLACK isn't chosen
+func channelRouteInputsFromModel(settings []*model.NotificationChannelSettingInput) ([]notificationService.ChannelRouteInput, error) {
+	inputs := make([]notificationService.ChannelRouteInput, len(settings))
+	for i, setting := range settings {
+		input := notificationService.ChannelRouteInput{
+			Event: notificationRuleEvents[setting.Event],
+			Email: slices.Contains(setting.Channels, model.NotificationChannelEmail),
+		}
+		if slices.Contains(setting.Channels, model.NotificationChannelSLACk) {
+			if setting.SlackWebhookURL == nil {
+				return nil, errors.New("a SLACK channel needs a slackWebhookUrl")
+			}
+			input.SlackWebhookURL = setting.SlackWebhookURL
+			input.SlackChannel = setting.SlackChannel
+		}
+		inputs[i] = input
+	}
+	return inputs, nil
+}
+
+func channelRoutesToModel(routes []*notificationService.ChannelRoute) []*model.NotificationChannelSetting {
+	result := make([]*model.NotificationChannelSetting, len(routes))
+	for i, route := range routes {
+		m := &model.NotificationChannelSetting{
+			Channels:        []model.NotificationChannel{},
+			SlackWebhookURL: route.SlackWebhookURL,
+			SlackChannel:    route.SlackChannel,
+			Inherited:       route.Inherited,
+		}
+		for event, name := range notificationRuleEvents {
+			if name == route.Event {
+				m.Event = event
+			}
+		}
+		if route.Email {
+			m.Channels = append(m.Channels, model.NotificationChannelEmail)
+		}
+		if route.SlackWebhookURL != nil {
+			m.Channels = append(m.Channels, model.NotificationChannelSLACk)
+		}
+		result[i] = m
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	commentService "github.com/thatcatdev/kaimu/backend/internal/services/comment"
+	invitationSvc "github.com/thatcatdev/kaimu/backend/internal/services/invitation"
+	notificationCenterService "github.com/thatcatdev/kaimu/backend/internal/services/notificationcenter"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// Notifications returns the current user's notifications, newest first
+func Notifications(ctx context.Context, centerSvc notificationCenterService.Service, cardSvc cardService.Service, commentSvc commentService.Service, sprintSvc sprintService.Service, invSvc invitationSvc.Service, userSvc userService.Service, unreadOnly *bool, first *int) ([]*model.Notification, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	limit := 50
+	if first != nil {
+		limit = *first
+	}
+	notifications, err := centerSvc.GetNotifications(ctx, *userID, unreadOnly != nil && *unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Notification, len(notifications))
+	for i, n := range notifications {
+		if result[i], err = notificationToModel(ctx, cardSvc, commentSvc, sprintSvc, invSvc, userSvc, n); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// MarkNotificationRead marks one of the current user's notifications read
+func MarkNotificationRead(ctx context.Context, centerSvc notificationCenterService.Service, cardSvc cardService.Service, commentSvc commentService.Service, sprintSvc sprintService.Service, invSvc invitationSvc.Service, userSvc userService.Service, id string) (*model.Notification, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	notificationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	n, err := centerSvc.MarkRead(ctx, *userID, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	return notificationToModel(ctx, cardSvc, commentSvc, sprintSvc, invSvc, userSvc, n)
+}
+
+// MarkAllNotificationsRead marks all the current user's notifications read
+func MarkAllNotificationsRead(ctx context.Context, centerSvc notificationCenterService.Service) (int, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return 0, ErrUnauthorized
+	}
+	return centerSvc.MarkAllRead(ctx, *userID)
+}
+
+// UserUnreadNotificationCount resolves the unreadNotificationCount field of a User, which
+// only the user themselves can read
+func UserUnreadNotificationCount(ctx context.Context, centerSvc notificationCenterService.Service, u *model.User) (*int, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil || userID.String() != u.ID {
+		return nil, nil
+	}
+
+	count, err := centerSvc.CountUnread(ctx, *userID)
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// NotificationPreferences returns the current user's notification preferences in an
+// organization
+func NotificationPreferences(ctx context.Context, rbacSvc rbacService.Service, centerSvc notificationCenterService.Service, organizationID string) ([]*model.NotificationPreference, error) {
+	userID, orgID, err := requireOrgViewer(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := centerSvc.GetPreferences(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.NotificationPreference, len(preferences))
+	for i, p := range preferences {
+		result[i] = notificationPreferenceToModel(p)
+	}
+	return result, nil
+}
+
+// SetNotificationPreference changes how the current user gets a category of notifications
+// from an organization
+func SetNotificationPreference(ctx context.Context, rbacSvc rbacService.Service, centerSvc notificationCenterService.Service, organizationID string, category model.NotificationCategory, inApp, email *bool) (*model.NotificationPreference, error) {
+	userID, orgID, err := requireOrgViewer(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := centerSvc.SetPreference(ctx, userID, orgID, notification_preference.Category(strings.ToLower(string(category))), inApp, email)
+	if err != nil {
+		return nil, err
+	}
+	return notificationPreferenceToModel(p), nil
+}
+
+// requireOrgViewer parses the organization ID, requiring the current user to be able to
+// view the organization, and returns the user's ID with it
+func requireOrgViewer(ctx context.Context, rbacSvc rbacService.Service, organizationID string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, orgID, "org:view")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+	return *userID, orgID, nil
+}
+
+func notificationPreferenceToModel(p *notification_preference.Preference) *model.NotificationPreference {
+	return &model.NotificationPreference{
+		Category: model.NotificationCategory(strings.ToUpper(string(p.Category))),
+		InApp:    p.InApp,
+		Email:    p.Email,
+	}
+}
+
+func notificationToModel(ctx context.Context, cardSvc cardService.Service, commentSvc commentService.Service, sprintSvc sprintService.Service, invSvc invitationSvc.Service, userSvc userService.Service, n *notification.Notification) (*model.Notification, error) {
+	m := &model.Notification{
+		ID:        n.ID.String(),
+		Kind:      model.NotificationKind(strings.ToUpper(string(n.Kind))),
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+
+	if n.ActorID != nil {
+		actor, err := userSvc.GetByID(ctx, *n.ActorID)
+		if err != nil {
+			return nil, err
+		}
+		m.Actor = UserToModel(actor)
+	}
+	if n.CardID != nil {
+		c, err := cardSvc.GetCard(ctx, *n.CardID)
+		if err != nil {
+			return nil, err
+		}
+		m.Card = cardToModel(c)
+	}
+	if n.CommentID != nil {
+		cm, err := commentSvc.GetComment(ctx, *n.CommentID)
+		if err != nil {
+			return nil, err
+		}
+		m.Comment = commentToModel(cm)
+	}
+	if n.SprintID != nil {
+		sp, err := sprintSvc.GetSprint(ctx, *n.SprintID)
+		if err != nil {
+			return nil, err
+		}
+		m.Sprint = sprintToModel(sp)
+	}
+	if n.InvitationID != nil {
+		inv, err := invSvc.GetInvitation(ctx, *n.InvitationID)
+		if err != nil {
+			return nil, err
+		}
+		m.Invitation = invitationToModel(inv)
+	}
+	return m, nil
+}
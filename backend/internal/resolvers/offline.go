@@ -0,0 +1,288 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	offlineService "github.com/thatcatdev/kaimu/backend/internal/services/offline"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// CardMutator applies card mutations the way the GraphQL mutations do, so offline mutations
+// get the same permission checks and audit logging
+type CardMutator interface {
+	CreateCard(ctx context.Context, input model.CreateCardInput) (*model.Card, error)
+	UpdateCard(ctx context.Context, input model.UpdateCardInput) (*model.Card, error)
+	MoveCard(ctx context.Context, input model.MoveCardInput) (*model.Card, error)
+	DeleteCard(ctx context.Context, id string) (bool, error)
+}
+
+// BoardChanges returns what changed on a board since a sync cursor
+func BoardChanges(ctx context.Context, rbacSvc rbacService.Service, offlineSvc offlineService.Service, boardID string, cursor *string, limit *int) (*model.BoardChangeSet, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	var seq *int64
+	if cursor != nil {
+		parsed, err := parseSyncCursor(*cursor)
+		if err != nil {
+			return nil, err
+		}
+		seq = &parsed
+	}
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+
+	set, err := offlineSvc.GetBoardChanges(ctx, bID, seq, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BoardChangeSet{
+		Columns:    make([]*model.BoardColumn, len(set.Columns)),
+		Cards:      make([]*model.Card, len(set.Cards)),
+		Tombstones: make([]*model.SyncTombstone, len(set.Tombstones)),
+		Cursor:     encodeSyncCursor(set.Cursor),
+		HasMore:    set.HasMore,
+	}
+	if set.Board != nil {
+		result.Board = boardToModel(set.Board)
+	}
+	for i, col := range set.Columns {
+		result.Columns[i] = columnToModel(col)
+	}
+	for i, c := range set.Cards {
+		result.Cards[i] = cardToModel(c)
+	}
+	for i, t := range set.Tombstones {
+		entityType := model.SyncEntityTypeCard
+		if t.EntityType == sync_change.EntityBoard {
+			entityType = model.SyncEntityTypeBoard
+		}
+		result.Tombstones[i] = &model.SyncTombstone{
+			EntityType: entityType,
+			ID:         t.EntityID.String(),
+			DeletedAt:  t.DeletedAt,
+		}
+	}
+	return result, nil
+}
+
+// SubmitOfflineMutations applies mutations queued while offline, in order
+func SubmitOfflineMutations(ctx context.Context, rbacSvc rbacService.Service, offlineSvc offlineService.Service, mutator CardMutator, inputs []*model.OfflineMutationInput) ([]*model.OfflineMutationResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	results := make([]*model.OfflineMutationResult, len(inputs))
+	for i, input := range inputs {
+		result, err := applyOfflineMutation(ctx, rbacSvc, offlineSvc, mutator, *userID, input)
+		if err != nil {
+			message := err.Error()
+			result = &model.OfflineMutationResult{
+				ClientMutationID: input.ClientMutationID,
+				Status:           model.OfflineMutationStatusRejected,
+				Message:          &message,
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func applyOfflineMutation(ctx context.Context, rbacSvc rbacService.Service, offlineSvc offlineService.Service, mutator CardMutator, userID uuid.UUID, input *model.OfflineMutationInput) (*model.OfflineMutationResult, error) {
+	result := &model.OfflineMutationResult{ClientMutationID: input.ClientMutationID}
+
+	kind, cardRef, err := offlineMutationKind(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// A replayed mutation reports the card's current state without applying it again
+	applied, err := offlineSvc.GetAppliedMutation(ctx, userID, input.ClientMutationID)
+	if err != nil {
+		return nil, err
+	}
+	if applied != nil {
+		result.Status = model.OfflineMutationStatusApplied
+		if applied.CardID != nil {
+			result.Card, err = visibleCard(ctx, rbacSvc, offlineSvc, userID, *applied.CardID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	var cardID uuid.UUID
+	if kind != offlineService.MutationCreate {
+		cardID, err = resolveOfflineCardRef(ctx, offlineSvc, userID, cardRef)
+		if err != nil {
+			return nil, err
+		}
+
+		strategy := offlineService.ServerWins
+		if input.OnConflict != nil && *input.OnConflict == model.ConflictStrategyClientWins {
+			strategy = offlineService.ClientWins
+		}
+
+		current, err := offlineSvc.CheckConflict(ctx, kind, cardID, input.BaseUpdatedAt, strategy)
+		switch {
+		case errors.Is(err, offlineService.ErrCardDeleted):
+			message := err.Error()
+			result.Status = model.OfflineMutationStatusConflict
+			result.Message = &message
+			return result, nil
+		case errors.Is(err, offlineService.ErrConflict):
+			hasPermission, err := rbacSvc.HasBoardPermission(ctx, userID, current.BoardID, "board:view")
+			if err != nil {
+				return nil, err
+			}
+			if !hasPermission {
+				return nil, ErrUnauthorized
+			}
+			message := offlineService.ErrConflict.Error()
+			result.Status = model.OfflineMutationStatusConflict
+			result.Card = cardToModel(current)
+			result.Message = &message
+			return result, nil
+		case err != nil:
+			return nil, err
+		case current == nil:
+			// Deleting a card that is already gone
+			result.Status = model.OfflineMutationStatusApplied
+			return result, offlineSvc.RecordAppliedMutation(ctx, userID, input.ClientMutationID, nil)
+		}
+	}
+
+	switch kind {
+	case offlineService.MutationCreate:
+		result.Card, err = mutator.CreateCard(ctx, *input.CreateCard)
+	case offlineService.MutationUpdate:
+		update := *input.UpdateCard
+		update.ID = cardID.String()
+		result.Card, err = mutator.UpdateCard(ctx, update)
+	case offlineService.MutationMove:
+		move := *input.MoveCard
+		move.CardID = cardID.String()
+		if move.AfterCardID != nil {
+			afterCardID, err := resolveOfflineCardRef(ctx, offlineSvc, userID, *move.AfterCardID)
+			if err != nil {
+				return nil, err
+			}
+			after := afterCardID.String()
+			move.AfterCardID = &after
+		}
+		result.Card, err = mutator.MoveCard(ctx, move)
+	case offlineService.MutationDelete:
+		_, err = mutator.DeleteCard(ctx, cardID.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var appliedCardID *uuid.UUID
+	if result.Card != nil {
+		id, err := uuid.Parse(result.Card.ID)
+		if err != nil {
+			return nil, err
+		}
+		appliedCardID = &id
+	}
+	if err := offlineSvc.RecordAppliedMutation(ctx, userID, input.ClientMutationID, appliedCardID); err != nil {
+		return nil, err
+	}
+
+	result.Status = model.OfflineMutationStatusApplied
+	return result, nil
+}
+
+// offlineMutationKind returns which mutation the input holds and the card it targets
+func offlineMutationKind(input *model.OfflineMutationInput) (offlineService.MutationKind, string, error) {
+	var kind offlineService.MutationKind
+	var cardRef string
+	set := 0
+	if input.CreateCard != nil {
+		kind = offlineService.MutationCreate
+		set++
+	}
+	if input.UpdateCard != nil {
+		kind, cardRef = offlineService.MutationUpdate, input.UpdateCard.ID
+		set++
+	}
+	if input.MoveCard != nil {
+		kind, cardRef = offlineService.MutationMove, input.MoveCard.CardID
+		set++
+	}
+	if input.DeleteCardID != nil {
+		kind, cardRef = offlineService.MutationDelete, *input.DeleteCardID
+		set++
+	}
+	if set != 1 {
+		return "", "", errors.New("exactly one of createCard, updateCard, moveCard and deleteCardId must be set")
+	}
+	return kind, cardRef, nil
+}
+
+// resolveOfflineCardRef turns a card ID, or the client mutation ID of a card created offline,
+// into the card's ID
+func resolveOfflineCardRef(ctx context.Context, offlineSvc offlineService.Service, userID uuid.UUID, ref string) (uuid.UUID, error) {
+	created, err := offlineSvc.GetAppliedMutation(ctx, userID, ref)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if created != nil && created.CardID != nil {
+		return *created.CardID, nil
+	}
+	return uuid.Parse(ref)
+}
+
+// visibleCard returns the card's current state if it still exists and the user can view it
+func visibleCard(ctx context.Context, rbacSvc rbacService.Service, offlineSvc offlineService.Service, userID, cardID uuid.UUID) (*model.Card, error) {
+	c, err := offlineSvc.CheckConflict(ctx, offlineService.MutationDelete, cardID, nil, offlineService.ClientWins)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, userID, c.BoardID, "board:view")
+	if err != nil || !hasPermission {
+		return nil, err
+	}
+	return cardToModel(c), nil
+}
+
+// encodeSyncCursor encodes a journal seq as a sync cursor
+func encodeSyncCursor(seq int64) string {
+	return fmt.Sprintf("sync:%d", seq)
+}
+
+// parseSyncCursor parses a sync cursor into a journal seq
+func parseSyncCursor(cursor string) (int64, error) {
+	var seq int64
+	if _, err := fmt.Sscanf(cursor, "sync:%d", &seq); err != nil {
+		return 0, fmt.Errorf("invalid sync cursor: %w", err)
+	}
+	return seq, nil
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 )
 
 var ErrUnauthorized = errors.New("unauthorized")
@@ -43,7 +46,7 @@ func CreateOrganization(ctx context.Context, svc orgService.Service, input model
 }
 
 // Organizations returns all organizations for the current user
-func Organizations(ctx context.Context, svc orgService.Service, projectSvc projectService.Service, boardSvc boardService.Service) ([]*model.Organization, error) {
+func Organizations(ctx context.Context, svc orgService.Service, projectSvc projectService.Service, boardSvc boardService.Service, rbacSvc rbacService.Service) ([]*model.Organization, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, ErrUnauthorized
@@ -67,6 +70,10 @@ func Organizations(ctx context.Context, svc orgService.Service, projectSvc proje
 		if err != nil {
 			return nil, err
 		}
+		projects, err = rbacSvc.FilterVisibleProjects(ctx, *userID, org.ID, projects)
+		if err != nil {
+			return nil, err
+		}
 
 		projectModels := make([]*model.Project, len(projects))
 		for j, proj := range projects {
@@ -84,7 +91,7 @@ func Organizations(ctx context.Context, svc orgService.Service, projectSvc proje
 }
 
 // Organization returns a specific organization by ID
-func Organization(ctx context.Context, svc orgService.Service, projectSvc projectService.Service, id string) (*model.Organization, error) {
+func Organization(ctx context.Context, svc orgService.Service, projectSvc projectService.Service, rbacSvc rbacService.Service, id string) (*model.Organization, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, ErrUnauthorized
@@ -120,6 +127,10 @@ func Organization(ctx context.Context, svc orgService.Service, projectSvc projec
 	if err != nil {
 		return nil, err
 	}
+	projects, err = rbacSvc.FilterVisibleProjects(ctx, *userID, orgID, projects)
+	if err != nil {
+		return nil, err
+	}
 
 	projectModels := make([]*model.Project, len(projects))
 	for i, proj := range projects {
@@ -232,7 +243,7 @@ func UpdateOrganization(ctx context.Context, svc orgService.Service, input model
 }
 
 // DeleteOrganization deletes an organization by ID
-func DeleteOrganization(ctx context.Context, svc orgService.Service, id string) (bool, error) {
+func DeleteOrganization(ctx context.Context, svc orgService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -252,6 +263,10 @@ func DeleteOrganization(ctx context.Context, svc orgService.Service, id string)
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckOrganization(ctx, orgID); err != nil {
+		return false, err
+	}
+
 	err = svc.DeleteOrganization(ctx, orgID)
 	if err != nil {
 		return false, err
@@ -270,12 +285,20 @@ func organizationToModel(org *organization.Organization) *model.Organization {
 		description = &org.Description
 	}
 	return &model.Organization{
-		ID:          org.ID.String(),
-		Name:        org.Name,
-		Slug:        org.Slug,
-		Description: description,
-		CreatedAt:   org.CreatedAt,
-		UpdatedAt:   org.UpdatedAt,
+		ID:                        org.ID.String(),
+		Name:                      org.Name,
+		Slug:                      org.Slug,
+		Description:               description,
+		CreatedAt:                 org.CreatedAt,
+		UpdatedAt:                 org.UpdatedAt,
+		ContentModerationEnabled:  org.ContentModerationEnabled,
+		DefaultLocale:             i18n.Resolve(org.DefaultLocale),
+		SearchAnalyticsAnonymized: org.SearchAnalyticsAnonymized,
+		AiDraftingEnabled:         org.AIDraftingEnabled,
+		AttachmentMaxBytes:        int64ToIntPtr(org.AttachmentMaxBytes),
+		AttachmentQuotaBytes:      int64ToIntPtr(org.AttachmentQuotaBytes),
+		InvitationTTLDays:         org.InvitationTTLDays,
+		InvitationReminderDays:    org.InvitationReminderDays,
 		// Note: Owner, Members, Projects are nil - they need to be populated separately
 		Owner:    nil,
 		Members:  []*model.OrganizationMember{},
@@ -300,15 +323,23 @@ func organizationToModelWithRelations(org *organization.Organization, owner *mod
 		projects = []*model.Project{}
 	}
 	return &model.Organization{
-		ID:          org.ID.String(),
-		Name:        org.Name,
-		Slug:        org.Slug,
-		Description: description,
-		Owner:       owner,
-		Members:     members,
-		Projects:    projects,
-		CreatedAt:   org.CreatedAt,
-		UpdatedAt:   org.UpdatedAt,
+		ID:                        org.ID.String(),
+		Name:                      org.Name,
+		Slug:                      org.Slug,
+		Description:               description,
+		Owner:                     owner,
+		Members:                   members,
+		Projects:                  projects,
+		CreatedAt:                 org.CreatedAt,
+		UpdatedAt:                 org.UpdatedAt,
+		ContentModerationEnabled:  org.ContentModerationEnabled,
+		DefaultLocale:             i18n.Resolve(org.DefaultLocale),
+		SearchAnalyticsAnonymized: org.SearchAnalyticsAnonymized,
+		AiDraftingEnabled:         org.AIDraftingEnabled,
+		AttachmentMaxBytes:        int64ToIntPtr(org.AttachmentMaxBytes),
+		AttachmentQuotaBytes:      int64ToIntPtr(org.AttachmentQuotaBytes),
+		InvitationTTLDays:         org.InvitationTTLDays,
+		InvitationReminderDays:    org.InvitationReminderDays,
 	}
 }
 
@@ -316,6 +347,7 @@ func organizationMemberToModel(member *organization_member.OrganizationMember) *
 	return &model.OrganizationMember{
 		ID:         member.ID.String(),
 		LegacyRole: member.Role,
+		IsGuest:    member.IsGuest,
 		CreatedAt:  member.CreatedAt,
 		User:       nil, // Needs to be populated separately via field resolver
 		Role:       nil, // Needs to be populated separately via field resolver
@@ -327,6 +359,7 @@ func organizationMemberToModelWithUser(member *organization_member.OrganizationM
 		ID:         member.ID.String(),
 		User:       user,
 		LegacyRole: member.Role,
+		IsGuest:    member.IsGuest,
 		CreatedAt:  member.CreatedAt,
 		Role:       nil, // Needs to be populated separately via field resolver
 	}
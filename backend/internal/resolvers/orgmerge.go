@@ -0,0 +1,108 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	orgmergeService "github.com/thatcatdev/kaimu/backend/internal/services/orgmerge"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// MergeOrganizations moves one organization into another, or reports what that would do
+func MergeOrganizations(ctx context.Context, rbacSvc rbacService.Service, mergeSvc orgmergeService.Service, holdSvc legalholdService.Service, userSvc userService.Service, sourceID, targetID string, dryRun bool) (*model.OrganizationMergeReport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	srcID, err := uuid.Parse(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	tgtID, err := uuid.Parse(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	canDeleteSource, err := rbacSvc.HasOrgPermission(ctx, *userID, srcID, "org:delete")
+	if err != nil {
+		return nil, err
+	}
+	canManageTarget, err := rbacSvc.HasOrgPermission(ctx, *userID, tgtID, "org:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !canDeleteSource || !canManageTarget {
+		return nil, ErrUnauthorized
+	}
+
+	// The merge deletes the source organization; a dry run only reports
+	if !dryRun {
+		if err := holdSvc.CheckOrganization(ctx, srcID); err != nil {
+			return nil, err
+		}
+	}
+
+	report, err := mergeSvc.Merge(ctx, srcID, tgtID, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.OrganizationMergeReport{
+		SourceID:    report.Source.ID.String(),
+		SourceName:  report.Source.Name,
+		Target:      organizationToModel(report.Target),
+		DryRun:      report.DryRun,
+		Members:     make([]*model.MergedMember, len(report.Members)),
+		Projects:    make([]*model.MergedProject, len(report.Projects)),
+		Roles:       make([]*model.MergedRole, len(report.Roles)),
+		Invitations: make([]*model.MergedInvitation, len(report.Invitations)),
+	}
+	for i, change := range report.Members {
+		u, err := userSvc.GetByID(ctx, change.Member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		action := model.MergeMemberActionMoved
+		if change.Action == orgmergeService.MemberAlreadyInTarget {
+			action = model.MergeMemberActionAlreadyMember
+		}
+		var roleID *string
+		if change.RoleID != nil {
+			id := change.RoleID.String()
+			roleID = &id
+		}
+		result.Members[i] = &model.MergedMember{User: UserToModel(u), Action: action, RoleID: roleID}
+	}
+	for i, change := range report.Projects {
+		result.Projects[i] = &model.MergedProject{
+			ProjectID: change.Project.ID.String(),
+			Name:      change.Project.Name,
+			OldKey:    change.OldKey,
+			NewKey:    change.NewKey,
+		}
+	}
+	for i, change := range report.Roles {
+		result.Roles[i] = &model.MergedRole{
+			RoleID:  change.Role.ID.String(),
+			OldName: change.OldName,
+			NewName: change.NewName,
+		}
+	}
+	for i, change := range report.Invitations {
+		action := model.MergeInvitationActionMoved
+		if change.Action == orgmergeService.InvitationSkipped {
+			action = model.MergeInvitationActionSkipped
+		}
+		result.Invitations[i] = &model.MergedInvitation{
+			InvitationID: change.Invitation.ID.String(),
+			Email:        change.Invitation.Email,
+			Action:       action,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	peopleService "github.com/thatcatdev/kaimu/backend/internal/services/people"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// People returns the organization's members with their workload
+func People(ctx context.Context, rbacSvc rbacService.Service, peopleSvc peopleService.Service, organizationID string) ([]*model.Person, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, orgID, "org:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	people, err := peopleSvc.GetPeople(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Person, len(people))
+	for i, p := range people {
+		result[i] = personToModel(p)
+	}
+	return result, nil
+}
+
+func personToModel(p *peopleService.Person) *model.Person {
+	person := &model.Person{
+		Member:                orgMemberToModel(p.Member),
+		Projects:              make([]*model.PersonProject, len(p.Projects)),
+		SprintCommitments:     make([]*model.PersonSprintCommitment, len(p.Sprints)),
+		OpenCardCount:         p.OpenCards,
+		OpenStoryPoints:       p.OpenStoryPoints,
+		OverdueCardCount:      p.OverdueCards,
+		SprintStoryPoints:     p.SprintStoryPoints,
+		SprintDoneStoryPoints: p.SprintDoneStoryPoints,
+	}
+	for i, proj := range p.Projects {
+		person.Projects[i] = &model.PersonProject{
+			ProjectID:        proj.ProjectID.String(),
+			ProjectName:      proj.ProjectName,
+			IsMember:         proj.IsMember,
+			OpenCardCount:    proj.OpenCards,
+			OpenStoryPoints:  proj.OpenStoryPoints,
+			OverdueCardCount: proj.OverdueCards,
+		}
+		if proj.RoleID != nil {
+			roleID := proj.RoleID.String()
+			person.Projects[i].RoleID = &roleID
+		}
+	}
+	for i, s := range p.Sprints {
+		person.SprintCommitments[i] = &model.PersonSprintCommitment{
+			SprintID:        s.SprintID.String(),
+			SprintName:      s.SprintName,
+			BoardID:         s.BoardID.String(),
+			ProjectID:       s.ProjectID.String(),
+			EndDate:         s.EndDate,
+			CardCount:       s.CardCount,
+			StoryPoints:     s.StoryPoints,
+			DoneCardCount:   s.DoneCardCount,
+			DoneStoryPoints: s.DoneStoryPoints,
+		}
+	}
+	return person
+}
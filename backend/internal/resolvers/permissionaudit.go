@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	permissionAuditService "github.com/thatcatdev/kaimu/backend/internal/services/permissionaudit"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// PermissionAuditReport returns who can reach what in the organization
+func PermissionAuditReport(ctx context.Context, rbacSvc rbacService.Service, userSvc userService.Service, auditSvc permissionAuditService.Service, organizationID string) (*model.PermissionAuditReport, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := auditSvc.GetReport(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	var csv strings.Builder
+	if err := report.WriteCSV(&csv); err != nil {
+		return nil, err
+	}
+
+	result := &model.PermissionAuditReport{
+		OrganizationID: report.OrganizationID.String(),
+		GeneratedAt:    report.GeneratedAt,
+		Members:        make([]*model.MemberPermissionAudit, len(report.Members)),
+		CSV:            csv.String(),
+	}
+	for i, m := range report.Members {
+		member := &model.MemberPermissionAudit{
+			User:             UserToModel(m.User),
+			OrgRole:          roleToModel(m.OrgRole),
+			IsGuest:          m.Member.IsGuest,
+			Projects:         make([]*model.ProjectPermissionAudit, len(m.Projects)),
+			RestrictedBoards: make([]*model.Board, len(m.RestrictedBoards)),
+			EmbedTokens:      make([]*model.MetricsEmbedToken, len(m.EmbedTokens)),
+			APITokens:        apiTokensToModel(ctx, userSvc, m.APITokens),
+		}
+		for j, p := range m.Projects {
+			member.Projects[j] = &model.ProjectPermissionAudit{
+				Project:       projectToModel(p.Project),
+				EffectiveRole: roleToModel(p.EffectiveRole(m)),
+			}
+			if p.Role != nil {
+				member.Projects[j].Role = roleToModel(p.Role)
+			}
+		}
+		for j, b := range m.RestrictedBoards {
+			member.RestrictedBoards[j] = boardToModel(b)
+		}
+		for j, t := range m.EmbedTokens {
+			member.EmbedTokens[j] = metricsEmbedTokenToModel(t)
+		}
+		result.Members[i] = member
+	}
+	return result, nil
+}
@@ -0,0 +1,231 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	presenceService "github.com/thatcatdev/kaimu/backend/internal/services/presence"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// BoardViewers returns the users currently present on a board
+func BoardViewers(ctx context.Context, rbacSvc rbacService.Service, presenceSvc presenceService.Service, userSvc userService.Service, boardID string) ([]*model.BoardViewer, error) {
+	_, bID, err := presenceBoard(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return boardViewersToModel(ctx, userSvc, presenceSvc.GetViewers(ctx, bID)), nil
+}
+
+// BoardHeartbeat marks the current user present on a board
+func BoardHeartbeat(ctx context.Context, rbacSvc rbacService.Service, presenceSvc presenceService.Service, boardID string, activity model.PresenceActivity) (bool, error) {
+	userID, bID, err := presenceBoard(ctx, rbacSvc, boardID)
+	if err != nil {
+		return false, err
+	}
+
+	presenceSvc.Heartbeat(ctx, bID, userID, modelActivityToPresence(activity))
+	return true, nil
+}
+
+// LeaveBoard removes the current user from a board's viewers
+func LeaveBoard(ctx context.Context, presenceSvc presenceService.Service, boardID string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return false, err
+	}
+
+	presenceSvc.Leave(ctx, bID, *userID)
+	return true, nil
+}
+
+// BoardPresence streams a board's viewers, keeping the current user present while subscribed
+func BoardPresence(ctx context.Context, rbacSvc rbacService.Service, presenceSvc presenceService.Service, userSvc userService.Service, boardID string) (<-chan []*model.BoardViewer, error) {
+	userID, bID, err := presenceBoard(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := presenceSvc.Watch(ctx, bID, userID)
+	out := make(chan []*model.BoardViewer, 1)
+	go func() {
+		defer close(out)
+		for viewers := range updates {
+			select {
+			case out <- boardViewersToModel(ctx, userSvc, viewers):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// BroadcastCardDrag shares an in-progress card drag with the card's other board viewers.
+// Drags over the sender's rate limit are dropped and reported as false.
+func BroadcastCardDrag(ctx context.Context, rbacSvc rbacService.Service, presenceSvc presenceService.Service, cardSvc cardService.Service, input model.CardDragInput) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	cardID, err := uuid.Parse(input.CardID)
+	if err != nil {
+		return false, err
+	}
+	drag := presenceService.Drag{UserID: *userID, CardID: cardID}
+	if input.ColumnID != nil {
+		columnID, err := uuid.Parse(*input.ColumnID)
+		if err != nil {
+			return false, err
+		}
+		drag.ColumnID = &columnID
+	}
+	if input.AfterCardID != nil {
+		afterCardID, err := uuid.Parse(*input.AfterCardID)
+		if err != nil {
+			return false, err
+		}
+		drag.AfterCardID = &afterCardID
+	}
+
+	// Limit per websocket connection; plain HTTP requests share the user's limit
+	source := *userID
+	if connectionID := middleware.GetConnectionID(ctx); connectionID != nil {
+		source = *connectionID
+	}
+	if !presenceSvc.AllowDrag(ctx, source) {
+		return false, nil
+	}
+
+	c, err := cardSvc.GetCard(ctx, drag.CardID)
+	if err != nil {
+		return false, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, c.BoardID, "card:move")
+	if err != nil {
+		return false, err
+	}
+	if !hasPermission {
+		return false, ErrUnauthorized
+	}
+
+	drag.BoardID = c.BoardID
+	presenceSvc.PublishDrag(ctx, drag)
+	return true, nil
+}
+
+// CardDragPreviews streams other viewers' in-progress card drags on a board
+func CardDragPreviews(ctx context.Context, rbacSvc rbacService.Service, presenceSvc presenceService.Service, userSvc userService.Service, boardID string) (<-chan *model.CardDragPreview, error) {
+	userID, bID, err := presenceBoard(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	drags := presenceSvc.WatchDrags(ctx, bID, userID)
+	out := make(chan *model.CardDragPreview, 1)
+	go func() {
+		defer close(out)
+		// Drags arrive many times a second, so look each dragging user up only once
+		users := make(map[uuid.UUID]*model.User)
+		for drag := range drags {
+			u, ok := users[drag.UserID]
+			if !ok {
+				found, err := userSvc.GetByID(ctx, drag.UserID)
+				if err != nil {
+					continue
+				}
+				u = UserToModel(found)
+				users[drag.UserID] = u
+			}
+
+			select {
+			case out <- cardDragToModel(u, drag):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// presenceBoard parses the board ID and checks the current user may view the board
+func presenceBoard(ctx context.Context, rbacSvc rbacService.Service, boardID string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+	return *userID, bID, nil
+}
+
+// boardViewersToModel converts viewers, leaving out users that no longer exist
+func boardViewersToModel(ctx context.Context, userSvc userService.Service, viewers []presenceService.Viewer) []*model.BoardViewer {
+	result := make([]*model.BoardViewer, 0, len(viewers))
+	for _, v := range viewers {
+		u, err := userSvc.GetByID(ctx, v.UserID)
+		if err != nil {
+			continue
+		}
+		result = append(result, &model.BoardViewer{
+			User:       UserToModel(u),
+			Activity:   presenceActivityToModel(v.Activity),
+			LastSeenAt: v.LastSeenAt,
+		})
+	}
+	return result
+}
+
+func modelActivityToPresence(a model.PresenceActivity) presenceService.Activity {
+	if a == model.PresenceActivityEditing {
+		return presenceService.ActivityEditing
+	}
+	return presenceService.ActivityViewing
+}
+
+func presenceActivityToModel(a presenceService.Activity) model.PresenceActivity {
+	if a == presenceService.ActivityEditing {
+		return model.PresenceActivityEditing
+	}
+	return model.PresenceActivityViewing
+}
+
+func cardDragToModel(u *model.User, drag presenceService.Drag) *model.CardDragPreview {
+	m := &model.CardDragPreview{
+		User:   u,
+		CardID: drag.CardID.String(),
+		SentAt: drag.SentAt,
+	}
+	if drag.ColumnID != nil {
+		columnID := drag.ColumnID.String()
+		m.ColumnID = &columnID
+	}
+	if drag.AfterCardID != nil {
+		afterCardID := drag.AfterCardID.String()
+		m.AfterCardID = &afterCardID
+	}
+	return m
+}
@@ -8,14 +8,14 @@ import (
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
-	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 )
 
 // CreateProject creates a new project
-func CreateProject(ctx context.Context, rbacSvc rbacService.Service, orgSvc orgService.Service, projSvc projectService.Service, boardSvc boardService.Service, input model.CreateProjectInput) (*model.Project, error) {
+func CreateProject(ctx context.Context, rbacSvc rbacService.Service, orgSvc orgService.Service, projSvc projectService.Service, input model.CreateProjectInput) (*model.Project, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, ErrUnauthorized
@@ -40,18 +40,12 @@ func CreateProject(ctx context.Context, rbacSvc rbacService.Service, orgSvc orgS
 		description = *input.Description
 	}
 
-	proj, err := projSvc.CreateProject(ctx, orgID, input.Name, input.Key, description)
+	// The default board is created in the same transaction as the project
+	proj, err := projSvc.CreateProject(ctx, orgID, input.Name, input.Key, description, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create default board for the project
-	_, err = boardSvc.CreateDefaultBoard(ctx, proj.ID, userID)
-	if err != nil {
-		// Log error but don't fail project creation
-		// The board can be created later
-	}
-
 	// Fetch the organization for the project
 	org, err := orgSvc.GetOrganization(ctx, orgID)
 	if err != nil {
@@ -164,7 +158,7 @@ func UpdateProject(ctx context.Context, rbacSvc rbacService.Service, projSvc pro
 }
 
 // DeleteProject deletes a project by ID
-func DeleteProject(ctx context.Context, rbacSvc rbacService.Service, projSvc projectService.Service, id string) (bool, error) {
+func DeleteProject(ctx context.Context, rbacSvc rbacService.Service, projSvc projectService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -184,6 +178,10 @@ func DeleteProject(ctx context.Context, rbacSvc rbacService.Service, projSvc pro
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckProject(ctx, projID); err != nil {
+		return false, err
+	}
+
 	err = projSvc.DeleteProject(ctx, projID)
 	if err != nil {
 		return false, err
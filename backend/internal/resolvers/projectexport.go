@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export"
+	projectExportService "github.com/thatcatdev/kaimu/backend/internal/services/projectexport"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+)
+
+// RequestProjectExport queues an export of the project
+func RequestProjectExport(ctx context.Context, rbacSvc rbacService.Service, exportSvc projectExportService.Service, projectID string) (*model.ProjectExport, error) {
+	pID, err := requireProjectManager(ctx, rbacSvc, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := exportSvc.RequestExport(ctx, pID, middleware.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return projectExportToModel(ctx, exportSvc, export)
+}
+
+// ProjectExport returns an export, or nil when there is no such export
+func ProjectExport(ctx context.Context, rbacSvc rbacService.Service, exportSvc projectExportService.Service, id string) (*model.ProjectExport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	exportID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	export, err := exportSvc.GetExport(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, projectExportService.ErrExportNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if _, err := requireProjectManager(ctx, rbacSvc, export.ProjectID.String()); err != nil {
+		return nil, err
+	}
+	return projectExportToModel(ctx, exportSvc, export)
+}
+
+// ProjectExports returns the project's recent exports
+func ProjectExports(ctx context.Context, rbacSvc rbacService.Service, exportSvc projectExportService.Service, projectID string) ([]*model.ProjectExport, error) {
+	pID, err := requireProjectManager(ctx, rbacSvc, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	exports, err := exportSvc.GetProjectExports(ctx, pID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ProjectExport, 0, len(exports))
+	for _, export := range exports {
+		m, err := projectExportToModel(ctx, exportSvc, export)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func projectExportToModel(ctx context.Context, exportSvc projectExportService.Service, export *project_export.ProjectExport) (*model.ProjectExport, error) {
+	m := &model.ProjectExport{
+		ID:          export.ID.String(),
+		Status:      model.ProjectExportStatus(strings.ToUpper(string(export.Status))),
+		CreatedAt:   export.CreatedAt,
+		StartedAt:   export.StartedAt,
+		CompletedAt: export.CompletedAt,
+		Error:       export.Error,
+	}
+	if export.Status != project_export.StatusCompleted {
+		return m, nil
+	}
+
+	cardCount := export.CardCount
+	sizeBytes := int(export.SizeBytes)
+	m.CardCount = &cardCount
+	m.SizeBytes = &sizeBytes
+	url, expiresAt, err := exportSvc.DownloadURL(ctx, export)
+	if err != nil {
+		return nil, err
+	}
+	m.DownloadURL = &url
+	m.DownloadURLExpiresAt = &expiresAt
+	return m, nil
+}
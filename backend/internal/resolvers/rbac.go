@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/graph/model"
@@ -129,6 +130,39 @@ func GetOrganizationMembersRBAC(ctx context.Context, svc rbac.Service, organizat
 	return result, nil
 }
 
+// OrganizationGuests returns the guests of an organization
+func OrganizationGuests(ctx context.Context, svc rbac.Service, organizationID string) ([]*model.OrganizationMember, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guests are managed by those who invite them
+	hasAccess, err := svc.HasOrgPermission(ctx, *userID, orgID, "org:invite")
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, ErrUnauthorized
+	}
+
+	guests, err := svc.GetOrgGuests(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.OrganizationMember, len(guests))
+	for i, m := range guests {
+		result[i] = orgMemberToModel(m)
+	}
+	return result, nil
+}
+
 // ProjectMembers returns all members of a project
 func ProjectMembers(ctx context.Context, svc rbac.Service, projectID string) ([]*model.ProjectMember, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -363,6 +397,81 @@ func ChangeMemberRole(ctx context.Context, svc rbac.Service, organizationID stri
 	return orgMemberToModel(member), nil
 }
 
+// BulkChangeMemberRoles gives many members of an organization the same role, all of them or
+// none
+func BulkChangeMemberRoles(ctx context.Context, svc rbac.Service, organizationID string, userIDs []string, roleID string) (*model.BulkMemberRoleChangeResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rID, err := uuid.Parse(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUserIDs := make([]uuid.UUID, len(userIDs))
+	for i, id := range userIDs {
+		targetUserIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hasAccess, err := svc.HasOrgPermission(ctx, *userID, orgID, "org:manage_roles")
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, ErrUnauthorized
+	}
+
+	change, err := svc.BulkAssignOrgRole(ctx, orgID, targetUserIDs, rID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkMemberRoleChangeResult{
+		Applied: change.Applied,
+		Results: make([]*model.MemberRoleChangeResult, len(change.Changes)),
+	}
+	for i, c := range change.Changes {
+		r := &model.MemberRoleChangeResult{
+			UserID: c.UserID.String(),
+			Status: memberRoleChangeStatusToModel(c.Status),
+		}
+		if c.Member != nil {
+			r.Member = orgMemberToModel(c.Member)
+		}
+		if c.PreviousRoleID != nil {
+			previous := c.PreviousRoleID.String()
+			r.PreviousRoleID = &previous
+		}
+		result.Results[i] = r
+	}
+	return result, nil
+}
+
+func memberRoleChangeStatusToModel(status rbac.MemberRoleChangeStatus) model.MemberRoleChangeStatus {
+	switch status {
+	case rbac.MemberRoleUnchanged:
+		return model.MemberRoleChangeStatusUnchanged
+	case rbac.MemberRoleNotMember:
+		return model.MemberRoleChangeStatusNotAMember
+	case rbac.MemberRoleLastOwner:
+		return model.MemberRoleChangeStatusLastOwner
+	case rbac.MemberRoleRolledBack:
+		return model.MemberRoleChangeStatusRolledBack
+	default:
+		return model.MemberRoleChangeStatusChanged
+	}
+}
+
 // RemoveMember removes a member from an organization
 func RemoveMember(ctx context.Context, svc rbac.Service, organizationID, targetUserID string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -513,6 +622,7 @@ func orgMemberToModel(m *organization_member.OrganizationMember) *model.Organiza
 		User:       nil, // Resolved by field resolver
 		Role:       nil, // Resolved by field resolver
 		LegacyRole: m.Role,
+		IsGuest:    m.IsGuest,
 		CreatedAt:  m.CreatedAt,
 	}
 }
@@ -535,15 +645,18 @@ func invitationToModel(inv *invitation.Invitation) *model.Invitation {
 		Role:         nil, // Resolved by field resolver
 		Organization: nil, // Resolved by field resolver
 		InvitedBy:    nil, // Resolved by field resolver
+		IsGuest:      inv.IsGuest,
 		ExpiresAt:    inv.ExpiresAt,
+		RemindedAt:   inv.RemindedAt,
 		CreatedAt:    inv.CreatedAt,
 	}
 }
 
 // Invitation resolvers
 
-// Invitations returns all pending invitations for an organization
-func Invitations(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Service, organizationID string) ([]*model.Invitation, error) {
+// Invitations returns an organization's pending or expired invitations, pending when status
+// is nil
+func Invitations(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Service, organizationID string, status *model.InvitationStatus) ([]*model.Invitation, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, ErrUnauthorized
@@ -563,7 +676,11 @@ func Invitations(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Se
 		return nil, ErrUnauthorized
 	}
 
-	invitations, err := svc.GetPendingInvitations(ctx, orgID)
+	filter := invitation.StatusPending
+	if status != nil && *status == model.InvitationStatusExpired {
+		filter = invitation.StatusExpired
+	}
+	invitations, err := svc.GetInvitations(ctx, orgID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -575,6 +692,21 @@ func Invitations(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Se
 	return result, nil
 }
 
+// SetInvitationExpiryPolicy sets how long an organization's invitations stay valid and when
+// invitees are reminded
+func SetInvitationExpiryPolicy(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Service, organizationID string, ttlDays, reminderDays *int) (*model.Organization, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := svc.SetExpiryPolicy(ctx, orgID, ttlDays, reminderDays)
+	if err != nil {
+		return nil, err
+	}
+	return organizationToModel(org), nil
+}
+
 // InviteMember creates a new invitation
 func InviteMember(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.Service, input model.InviteMemberInput) (*model.Invitation, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
@@ -587,9 +719,12 @@ func InviteMember(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.S
 		return nil, err
 	}
 
-	roleID, err := uuid.Parse(input.RoleID)
-	if err != nil {
-		return nil, err
+	roleID := role.ViewerRoleID
+	if input.RoleID != nil {
+		roleID, err = uuid.Parse(*input.RoleID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Check permission
@@ -601,7 +736,33 @@ func InviteMember(ctx context.Context, svc invitationSvc.Service, rbacSvc rbac.S
 		return nil, ErrUnauthorized
 	}
 
-	inv, err := svc.CreateInvitation(ctx, orgID, input.Email, roleID, *userID)
+	guest := input.Guest != nil && *input.Guest
+	if guest && input.ProjectID == nil {
+		return nil, errors.New("a guest invitation needs a projectId")
+	}
+
+	var inv *invitation.Invitation
+	if input.ProjectID != nil {
+		projectID, err := uuid.Parse(*input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		var projectRoleID *uuid.UUID
+		if input.ProjectRoleID != nil {
+			parsed, err := uuid.Parse(*input.ProjectRoleID)
+			if err != nil {
+				return nil, err
+			}
+			projectRoleID = &parsed
+		}
+		if guest {
+			inv, err = svc.CreateGuestInvitation(ctx, orgID, input.Email, roleID, projectID, projectRoleID, *userID)
+		} else {
+			inv, err = svc.CreateProjectInvitation(ctx, orgID, input.Email, roleID, projectID, projectRoleID, *userID)
+		}
+	} else {
+		inv, err = svc.CreateInvitation(ctx, orgID, input.Email, roleID, *userID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -814,6 +975,42 @@ func InvitationOrganization(ctx context.Context, svc invitationSvc.Service, inv
 	return organizationToModel(org), nil
 }
 
+// InvitationProject resolves the project field of Invitation
+func InvitationProject(ctx context.Context, svc invitationSvc.Service, inv *model.Invitation) (*model.Project, error) {
+	invID, err := uuid.Parse(inv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := svc.GetInvitationProject(ctx, invID)
+	if err != nil {
+		return nil, err
+	}
+	if proj == nil {
+		return nil, nil
+	}
+
+	return projectToModel(proj), nil
+}
+
+// InvitationProjectRole resolves the projectRole field of Invitation
+func InvitationProjectRole(ctx context.Context, svc invitationSvc.Service, inv *model.Invitation) (*model.Role, error) {
+	invID, err := uuid.Parse(inv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := svc.GetInvitationProjectRole(ctx, invID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, nil
+	}
+
+	return roleToModel(r), nil
+}
+
 // InvitationInvitedBy resolves the invitedBy field of Invitation
 func InvitationInvitedBy(ctx context.Context, svc invitationSvc.Service, inv *model.Invitation) (*model.User, error) {
 	invID, err := uuid.Parse(inv.ID)
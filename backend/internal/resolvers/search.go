@@ -7,40 +7,70 @@ import (
 	"github.com/thatcatdev/kaimu/backend/graph/model"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchanalytics"
 )
 
-// Search performs a full-text search across multiple entity types
-func Search(ctx context.Context, searchService search.Service, query string, scope *model.SearchScope, limit *int) (*model.SearchResults, error) {
+// Search performs a full-text search across multiple entity types, recording it in the
+// search analytics of the organizations searched
+func Search(ctx context.Context, searchService search.Service, analyticsSvc searchanalytics.Service, query string, scope *model.SearchScope, limit *int) (*model.SearchResults, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return nil, errors.New("not authenticated")
 	}
 
-	// Convert GraphQL scope to service scope
-	var serviceScope *search.SearchScope
-	if scope != nil {
-		serviceScope = &search.SearchScope{}
-		if scope.OrganizationID != nil {
-			serviceScope.OrganizationID = *scope.OrganizationID
-		}
-		if scope.ProjectID != nil {
-			serviceScope.ProjectID = *scope.ProjectID
-		}
+	// Perform search
+	results, err := searchService.Search(ctx, *userID, query, toSearchScope(scope), searchLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	if analyticsSvc != nil {
+		analyticsSvc.RecordAsync(ctx, *userID, results)
 	}
+	return searchResultsToModel(results), nil
+}
 
-	// Get limit with default
-	searchLimit := 20
-	if limit != nil {
-		searchLimit = *limit
+// SearchSemantic searches cards by meaning as well as by keyword, recording the search in the
+// search analytics of the organizations searched
+func SearchSemantic(ctx context.Context, searchService search.Service, analyticsSvc searchanalytics.Service, query string, scope *model.SearchScope, limit *int) (*model.SearchResults, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, errors.New("not authenticated")
 	}
 
-	// Perform search
-	results, err := searchService.Search(ctx, *userID, query, serviceScope, searchLimit)
+	results, err := searchService.SearchSemantic(ctx, *userID, query, toSearchScope(scope), searchLimit(limit))
 	if err != nil {
 		return nil, err
 	}
+	if analyticsSvc != nil {
+		analyticsSvc.RecordAsync(ctx, *userID, results)
+	}
+	return searchResultsToModel(results), nil
+}
+
+// toSearchScope converts a GraphQL scope to the service scope
+func toSearchScope(scope *model.SearchScope) *search.SearchScope {
+	if scope == nil {
+		return nil
+	}
+	serviceScope := &search.SearchScope{}
+	if scope.OrganizationID != nil {
+		serviceScope.OrganizationID = *scope.OrganizationID
+	}
+	if scope.ProjectID != nil {
+		serviceScope.ProjectID = *scope.ProjectID
+	}
+	return serviceScope
+}
+
+// searchLimit returns the requested limit, 20 by default
+func searchLimit(limit *int) int {
+	if limit != nil {
+		return *limit
+	}
+	return 20
+}
 
-	// Convert service results to GraphQL model
+func searchResultsToModel(results *search.SearchResults) *model.SearchResults {
 	modelResults := make([]*model.SearchResult, len(results.Results))
 	for i, r := range results.Results {
 		modelResults[i] = &model.SearchResult{
@@ -64,7 +94,8 @@ func Search(ctx context.Context, searchService search.Service, query string, sco
 		Results:    modelResults,
 		TotalCount: results.TotalCount,
 		Query:      results.Query,
-	}, nil
+		Throttled:  results.Throttled,
+	}
 }
 
 func convertEntityType(t search.EntityType) model.SearchEntityType {
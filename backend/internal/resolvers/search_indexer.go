@@ -2,11 +2,13 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
 	organizationService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
@@ -59,6 +61,167 @@ func NewSearchIndexer(
 	}
 }
 
+// Subscribe registers the indexer as a consumer of domain events, so project, board, card
+// and membership changes reach the search index without the resolvers calling it
+func (si *SearchIndexer) Subscribe(bus events.Bus) {
+	if si == nil {
+		return
+	}
+
+	bus.Subscribe(events.ProjectCreated, si.handleProjectChanged)
+	bus.Subscribe(events.ProjectUpdated, si.handleProjectChanged)
+	bus.Subscribe(events.ProjectDeleted, si.handleProjectDeleted)
+
+	bus.Subscribe(events.BoardCreated, si.handleBoardChanged)
+	bus.Subscribe(events.BoardUpdated, si.handleBoardChanged)
+	bus.Subscribe(events.BoardDeleted, si.handleBoardDeleted)
+
+	bus.Subscribe(events.CardCreated, si.handleCardChanged)
+	bus.Subscribe(events.CardUpdated, si.handleCardChanged)
+	bus.Subscribe(events.CardMoved, si.handleCardMoved)
+	bus.Subscribe(events.CardDeleted, si.handleCardDeleted)
+	bus.Subscribe(events.CardArchived, si.handleCardChanged)
+
+	bus.Subscribe(events.MemberAdded, si.handleMemberAdded)
+	bus.Subscribe(events.OrganizationMerged, si.handleOrganizationMerged)
+}
+
+func (si *SearchIndexer) handleProjectChanged(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.ProjectPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	si.indexProject(ctx, payload.ProjectID)
+	return nil
+}
+
+func (si *SearchIndexer) handleProjectDeleted(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.ProjectPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	return si.searchSvc.DeleteProject(ctx, payload.ProjectID.String())
+}
+
+func (si *SearchIndexer) handleBoardChanged(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.BoardPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	si.indexBoard(ctx, payload.BoardID)
+	return nil
+}
+
+func (si *SearchIndexer) handleBoardDeleted(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.BoardPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	return si.searchSvc.DeleteBoard(ctx, payload.BoardID.String())
+}
+
+func (si *SearchIndexer) handleCardChanged(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	si.indexCard(ctx, payload.CardID)
+	return nil
+}
+
+func (si *SearchIndexer) handleCardMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	si.indexCard(ctx, payload.CardID)
+	return nil
+}
+
+func (si *SearchIndexer) handleCardDeleted(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	return si.searchSvc.DeleteCard(ctx, payload.CardID.String())
+}
+
+// handleMemberAdded refreshes the organization's member list, which search uses to
+// decide who may see its documents
+func (si *SearchIndexer) handleMemberAdded(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.MemberAddedPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	return si.refreshOrganizationMembers(ctx, payload.OrganizationID)
+}
+
+func (si *SearchIndexer) refreshOrganizationMembers(ctx context.Context, orgID uuid.UUID) error {
+	members, err := si.orgSvc.GetMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	memberIDs := make([]string, len(members))
+	for i, m := range members {
+		memberIDs[i] = m.UserID.String()
+	}
+
+	si.indexOrganization(ctx, orgID, memberIDs)
+	return nil
+}
+
+// handleOrganizationMerged removes the merged organization from the index and reindexes
+// what moved to the target: its projects with their boards and cards, and the members
+// that joined it
+func (si *SearchIndexer) handleOrganizationMerged(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.OrganizationMergedPayload)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+
+	if err := si.searchSvc.DeleteOrganization(ctx, payload.SourceOrganizationID.String()); err != nil {
+		return err
+	}
+	if err := si.refreshOrganizationMembers(ctx, payload.TargetOrganizationID); err != nil {
+		return err
+	}
+
+	for _, projectID := range payload.ProjectIDs {
+		si.indexProject(ctx, projectID)
+		boards, err := si.boardSvc.GetBoardsByProjectID(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		for _, b := range boards {
+			si.indexBoard(ctx, b.ID)
+			cards, err := si.cardSvc.GetCardsByBoardID(ctx, b.ID)
+			if err != nil {
+				return err
+			}
+			for _, c := range cards {
+				si.indexCard(ctx, c.ID)
+			}
+		}
+	}
+
+	for _, userID := range payload.UserIDs {
+		orgs, err := si.orgSvc.GetUserOrganizations(ctx, userID)
+		if err != nil {
+			return err
+		}
+		orgIDs := make([]string, len(orgs))
+		for i, org := range orgs {
+			orgIDs[i] = org.ID.String()
+		}
+		si.indexUser(ctx, userID, orgIDs)
+	}
+	return nil
+}
+
+func unexpectedPayload(event events.Event) error {
+	return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+}
+
 // IndexOrganizationAsync indexes an organization asynchronously
 func (si *SearchIndexer) IndexOrganizationAsync(ctx context.Context, orgID uuid.UUID, memberIDs []string) {
 	if si == nil {
@@ -95,14 +258,6 @@ func (si *SearchIndexer) DeleteOrganizationAsync(ctx context.Context, orgID stri
 	go si.searchSvc.DeleteOrganization(context.Background(), orgID)
 }
 
-// IndexProjectAsync indexes a project asynchronously
-func (si *SearchIndexer) IndexProjectAsync(ctx context.Context, projectID uuid.UUID) {
-	if si == nil {
-		return
-	}
-	go si.indexProject(context.Background(), projectID)
-}
-
 func (si *SearchIndexer) indexProject(ctx context.Context, projectID uuid.UUID) {
 	proj, err := si.projectSvc.GetProject(ctx, projectID)
 	if err != nil {
@@ -130,22 +285,6 @@ func (si *SearchIndexer) indexProject(ctx context.Context, projectID uuid.UUID)
 	_ = si.searchSvc.IndexProject(ctx, doc)
 }
 
-// DeleteProjectAsync deletes a project from the index asynchronously
-func (si *SearchIndexer) DeleteProjectAsync(ctx context.Context, projectID string) {
-	if si == nil {
-		return
-	}
-	go si.searchSvc.DeleteProject(context.Background(), projectID)
-}
-
-// IndexBoardAsync indexes a board asynchronously
-func (si *SearchIndexer) IndexBoardAsync(ctx context.Context, boardID uuid.UUID) {
-	if si == nil {
-		return
-	}
-	go si.indexBoard(context.Background(), boardID)
-}
-
 func (si *SearchIndexer) indexBoard(ctx context.Context, boardID uuid.UUID) {
 	board, err := si.boardSvc.GetBoard(ctx, boardID)
 	if err != nil {
@@ -182,28 +321,18 @@ func (si *SearchIndexer) indexBoard(ctx context.Context, boardID uuid.UUID) {
 	_ = si.searchSvc.IndexBoard(ctx, doc)
 }
 
-// DeleteBoardAsync deletes a board from the index asynchronously
-func (si *SearchIndexer) DeleteBoardAsync(ctx context.Context, boardID string) {
-	if si == nil {
-		return
-	}
-	go si.searchSvc.DeleteBoard(context.Background(), boardID)
-}
-
-// IndexCardAsync indexes a card asynchronously
-func (si *SearchIndexer) IndexCardAsync(ctx context.Context, cardID uuid.UUID) {
-	if si == nil {
-		return
-	}
-	go si.indexCard(context.Background(), cardID)
-}
-
 func (si *SearchIndexer) indexCard(ctx context.Context, cardID uuid.UUID) {
 	card, err := si.cardSvc.GetCard(ctx, cardID)
 	if err != nil {
 		return
 	}
 
+	// Archived cards, merged duplicates among them, are left out of search
+	if card.ArchivedAt != nil {
+		_ = si.searchSvc.DeleteCard(ctx, cardID.String())
+		return
+	}
+
 	// Get board info
 	board, err := si.cardSvc.GetBoardByCardID(ctx, cardID)
 	if err != nil {
@@ -232,6 +361,7 @@ func (si *SearchIndexer) indexCard(ctx context.Context, cardID uuid.UUID) {
 	// Build document
 	doc := &search.CardDocument{
 		ID:               card.ID.String(),
+		Key:              cardService.Key(proj.Key, card.Number),
 		Title:            card.Title,
 		Description:      StripHTML(card.Description),
 		Priority:         string(card.Priority),
@@ -260,14 +390,6 @@ func (si *SearchIndexer) indexCard(ctx context.Context, cardID uuid.UUID) {
 	_ = si.searchSvc.IndexCard(ctx, doc)
 }
 
-// DeleteCardAsync deletes a card from the index asynchronously
-func (si *SearchIndexer) DeleteCardAsync(ctx context.Context, cardID string) {
-	if si == nil {
-		return
-	}
-	go si.searchSvc.DeleteCard(context.Background(), cardID)
-}
-
 // IndexUserAsync indexes a user asynchronously
 func (si *SearchIndexer) IndexUserAsync(ctx context.Context, userID uuid.UUID, orgIDs []string) {
 	if si == nil {
@@ -0,0 +1,72 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchanalytics"
+)
+
+// SearchAnalytics returns an organization's popular and zero-result queries since a time,
+// by default over searchanalytics.DefaultPeriod
+func SearchAnalytics(ctx context.Context, rbacSvc rbacService.Service, analyticsSvc searchanalytics.Service, organizationID string, since *time.Time) (*model.SearchAnalytics, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now().Add(-searchanalytics.DefaultPeriod)
+	if since != nil {
+		from = *since
+	}
+	analytics, err := analyticsSvc.GetAnalytics(ctx, orgID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*model.SearchSynonymSuggestion, len(analytics.SynonymSuggestions))
+	for i, s := range analytics.SynonymSuggestions {
+		suggestions[i] = &model.SearchSynonymSuggestion{Term: s.Term, Synonym: s.Synonym, Searches: s.Searches}
+	}
+	return &model.SearchAnalytics{
+		OrganizationID:     analytics.OrganizationID.String(),
+		Since:              analytics.Since,
+		Searches:           analytics.Totals.Searches,
+		ZeroResultSearches: analytics.Totals.ZeroResultSearches,
+		Searchers:          analytics.Totals.Searchers,
+		PopularQueries:     queryStatsToModels(analytics.Popular),
+		ZeroResultQueries:  queryStatsToModels(analytics.ZeroResult),
+		SynonymSuggestions: suggestions,
+	}, nil
+}
+
+// SetSearchAnalyticsAnonymized turns anonymized search analytics on or off for an organization
+func SetSearchAnalyticsAnonymized(ctx context.Context, rbacSvc rbacService.Service, analyticsSvc searchanalytics.Service, organizationID string, anonymized bool) (*model.Organization, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := analyticsSvc.SetAnonymized(ctx, orgID, anonymized)
+	if err != nil {
+		return nil, err
+	}
+	return organizationToModel(org), nil
+}
+
+func queryStatsToModels(stats []*search_query.QueryStats) []*model.SearchQueryStats {
+	models := make([]*model.SearchQueryStats, len(stats))
+	for i, s := range stats {
+		models[i] = &model.SearchQueryStats{
+			Query:          s.Query,
+			Searches:       s.Searches,
+			Searchers:      s.Searchers,
+			AverageResults: s.AvgResults,
+			LastSearchedAt: s.LastSearchedAt,
+		}
+	}
+	return models
+}
@@ -0,0 +1,114 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/thatcatdev/kaimu/backend/internal/services/searchvocabulary"
+)
+
+// SearchSynonymSets returns an organization's search synonym sets
+func SearchSynonymSets(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, organizationID string) ([]*model.SearchSynonymSet, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	sets, err := vocabularySvc.GetSynonymSets(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	models := make([]*model.SearchSynonymSet, len(sets))
+	for i, set := range sets {
+		models[i] = synonymSetToModel(set)
+	}
+	return models, nil
+}
+
+// SearchStopWords returns the words left out of an organization's search queries
+func SearchStopWords(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, organizationID string) ([]string, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return vocabularySvc.GetStopWords(ctx, orgID)
+}
+
+// CreateSearchSynonymSet adds a search synonym set to an organization
+func CreateSearchSynonymSet(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, organizationID string, words []string) (*model.SearchSynonymSet, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := vocabularySvc.CreateSynonymSet(ctx, orgID, words)
+	if err != nil {
+		return nil, err
+	}
+	return synonymSetToModel(set), nil
+}
+
+// UpdateSearchSynonymSet replaces the words of a search synonym set
+func UpdateSearchSynonymSet(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, id string, words []string) (*model.SearchSynonymSet, error) {
+	set, err := managedSynonymSet(ctx, rbacSvc, vocabularySvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err = vocabularySvc.UpdateSynonymSet(ctx, set.ID, words)
+	if err != nil {
+		return nil, err
+	}
+	return synonymSetToModel(set), nil
+}
+
+// DeleteSearchSynonymSet removes a search synonym set, returning it
+func DeleteSearchSynonymSet(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, id string) (*model.SearchSynonymSet, error) {
+	set, err := managedSynonymSet(ctx, rbacSvc, vocabularySvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := vocabularySvc.DeleteSynonymSet(ctx, set.ID); err != nil {
+		return nil, err
+	}
+	return synonymSetToModel(set), nil
+}
+
+// SetSearchStopWords replaces the words left out of an organization's search queries
+func SetSearchStopWords(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, organizationID string, words []string) ([]string, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return vocabularySvc.SetStopWords(ctx, orgID, words)
+}
+
+// managedSynonymSet returns a synonym set of an organization the user manages
+func managedSynonymSet(ctx context.Context, rbacSvc rbacService.Service, vocabularySvc searchvocabulary.Service, id string) (*search_synonym_set.SearchSynonymSet, error) {
+	setID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	set, err := vocabularySvc.GetSynonymSet(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := requireOrganizationManager(ctx, rbacSvc, set.OrganizationID.String()); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func synonymSetToModel(set *search_synonym_set.SearchSynonymSet) *model.SearchSynonymSet {
+	return &model.SearchSynonymSet{
+		ID:             set.ID.String(),
+		OrganizationID: set.OrganizationID.String(),
+		Words:          set.Words,
+		CreatedAt:      set.CreatedAt,
+		UpdatedAt:      set.UpdatedAt,
+	}
+}
@@ -0,0 +1,252 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	slaService "github.com/thatcatdev/kaimu/backend/internal/services/sla"
+	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+)
+
+// SLAPolicies returns the SLA policies of a project
+func SLAPolicies(ctx context.Context, rbacSvc rbacService.Service, slaSvc slaService.Service, projectID string) ([]*model.SLAPolicy, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	policies, err := slaSvc.GetPoliciesByProjectID(ctx, projID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.SLAPolicy, len(policies))
+	for i, p := range policies {
+		result[i] = slaPolicyToModel(p)
+	}
+	return result, nil
+}
+
+// CreateSLAPolicy adds an SLA policy to a project
+func CreateSLAPolicy(ctx context.Context, rbacSvc rbacService.Service, slaSvc slaService.Service, projectID string, input model.SLAPolicyInput) (*model.SLAPolicy, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	projID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, projID, "project:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	policyInput, err := slaPolicyInputFromModel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := slaSvc.CreatePolicy(ctx, projID, policyInput)
+	if err != nil {
+		return nil, err
+	}
+	return slaPolicyToModel(policy), nil
+}
+
+// UpdateSLAPolicy replaces the settings of an SLA policy
+func UpdateSLAPolicy(ctx context.Context, rbacSvc rbacService.Service, slaSvc slaService.Service, id string, input model.SLAPolicyInput) (*model.SLAPolicy, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	policyID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := slaSvc.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, existing.ProjectID, "project:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	policyInput, err := slaPolicyInputFromModel(input)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := slaSvc.UpdatePolicy(ctx, policyID, policyInput)
+	if err != nil {
+		return nil, err
+	}
+	return slaPolicyToModel(policy), nil
+}
+
+// DeleteSLAPolicy removes an SLA policy together with its breach history
+func DeleteSLAPolicy(ctx context.Context, rbacSvc rbacService.Service, slaSvc slaService.Service, id string) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, ErrUnauthorized
+	}
+
+	policyID, err := uuid.Parse(id)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := slaSvc.GetPolicy(ctx, policyID)
+	if err != nil {
+		return false, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, existing.ProjectID, "project:manage")
+	if err != nil {
+		return false, err
+	}
+	if !hasPermission {
+		return false, ErrUnauthorized
+	}
+
+	if err := slaSvc.DeletePolicy(ctx, policyID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SLAReport summarizes SLA compliance of the cards in a sprint
+func SLAReport(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, slaSvc slaService.Service, sprintID string) (*model.SLAReport, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	spID, err := uuid.Parse(sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := sprintSvc.GetBoard(ctx, spID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, board.ID, "sprint:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	report, err := slaSvc.GetSprintReport(ctx, spID)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]*model.SLAPolicyCompliance, len(report.Policies))
+	for i, c := range report.Policies {
+		policies[i] = &model.SLAPolicyCompliance{
+			Policy:         slaPolicyToModel(c.Policy),
+			TrackedCards:   c.TrackedCards,
+			BreachedCards:  c.BreachedCards,
+			OpenBreaches:   c.OpenBreaches,
+			ComplianceRate: c.ComplianceRate(),
+		}
+	}
+
+	return &model.SLAReport{
+		SprintID:       report.SprintID.String(),
+		TrackedCards:   report.TrackedCards,
+		BreachedCards:  report.BreachedCards,
+		ComplianceRate: report.ComplianceRate(),
+		Policies:       policies,
+	}, nil
+}
+
+func slaPolicyInputFromModel(input model.SLAPolicyInput) (slaService.PolicyInput, error) {
+	columnID, err := uuid.Parse(input.ColumnID)
+	if err != nil {
+		return slaService.PolicyInput{}, err
+	}
+
+	policyInput := slaService.PolicyInput{
+		Name:               input.Name,
+		ColumnID:           columnID,
+		MaxDurationMinutes: input.MaxDurationMinutes,
+	}
+	if input.Priority != nil {
+		p := modelPriorityToCard(*input.Priority)
+		policyInput.Priority = &p
+	}
+	if input.EscalateToPriority != nil {
+		p := modelPriorityToCard(*input.EscalateToPriority)
+		policyInput.EscalateToPriority = &p
+	}
+	if input.BreachTagID != nil {
+		tagID, err := uuid.Parse(*input.BreachTagID)
+		if err != nil {
+			return slaService.PolicyInput{}, err
+		}
+		policyInput.BreachTagID = &tagID
+	}
+	return policyInput, nil
+}
+
+func slaPolicyToModel(p *sla_policy.SLAPolicy) *model.SLAPolicy {
+	m := &model.SLAPolicy{
+		ID:                 p.ID.String(),
+		ProjectID:          p.ProjectID.String(),
+		Name:               p.Name,
+		ColumnID:           p.ColumnID.String(),
+		MaxDurationMinutes: p.MaxDurationMinutes,
+		Priority:           optionalPriorityToModel(p.Priority),
+		EscalateToPriority: optionalPriorityToModel(p.EscalateToPriority),
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+	if p.BreachTagID != nil {
+		tagID := p.BreachTagID.String()
+		m.BreachTagID = &tagID
+	}
+	return m
+}
+
+func optionalPriorityToModel(p *card.CardPriority) *model.CardPriority {
+	if p == nil {
+		return nil
+	}
+	mp := cardPriorityToModel(*p)
+	return &mp
+}
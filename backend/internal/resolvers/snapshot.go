@@ -0,0 +1,48 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	snapshotService "github.com/thatcatdev/kaimu/backend/internal/services/snapshot"
+)
+
+// GenerateBoardSnapshotImage renders an image of the board for embedding elsewhere
+func GenerateBoardSnapshotImage(ctx context.Context, rbacSvc rbacService.Service, snapshotSvc snapshotService.Service, boardID string, format *model.BoardSnapshotFormat) (*model.BoardSnapshotImage, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	f := snapshotService.FormatPNG
+	if format != nil {
+		f = snapshotService.Format(strings.ToLower(string(*format)))
+	}
+	img, err := snapshotSvc.GenerateBoardSnapshot(ctx, bID, f)
+	if err != nil {
+		return nil, err
+	}
+	return &model.BoardSnapshotImage{
+		URL:       img.URL,
+		ExpiresAt: img.ExpiresAt,
+		Format:    model.BoardSnapshotFormat(strings.ToUpper(string(img.Format))),
+		Width:     img.Width,
+		Height:    img.Height,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	splitService "github.com/thatcatdev/kaimu/backend/internal/services/split"
+)
+
+// SplitCard splits a card into one new card per title
+func SplitCard(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, splitSvc splitService.Service, cardID string, titles []string, options *model.SplitCardOptions) (*model.SplitCardResult, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, id, "card:edit"); err != nil {
+		return nil, err
+	}
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, id, "card:create"); err != nil {
+		return nil, err
+	}
+
+	input := splitService.Input{
+		CardID:       id,
+		Titles:       titles,
+		CopyTags:     true,
+		CopyAssignee: true,
+		CreatedBy:    *userID,
+	}
+	if options != nil {
+		if options.CopyTags != nil {
+			input.CopyTags = *options.CopyTags
+		}
+		if options.CopyAssignee != nil {
+			input.CopyAssignee = *options.CopyAssignee
+		}
+		if options.SplitStoryPoints != nil {
+			input.SplitStoryPoints = *options.SplitStoryPoints
+		}
+	}
+
+	result, err := splitSvc.SplitCard(ctx, input)
+	if err != nil {
+		return nil, contentError(ctx, err)
+	}
+
+	cards := make([]*model.Card, len(result.Cards))
+	for i, c := range result.Cards {
+		cards[i] = cardToModel(c)
+	}
+	return &model.SplitCardResult{
+		Card:  cardToModel(result.Card),
+		Cards: cards,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
 	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
@@ -382,7 +383,7 @@ func UpdateSprint(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sp
 }
 
 // DeleteSprint deletes a sprint
-func DeleteSprint(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, id string) (bool, error) {
+func DeleteSprint(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -407,6 +408,10 @@ func DeleteSprint(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sp
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckProject(ctx, board.ProjectID); err != nil {
+		return false, err
+	}
+
 	if err := sprintSvc.DeleteSprint(ctx, sprintID); err != nil {
 		return false, err
 	}
@@ -814,10 +819,25 @@ func sprintToModel(sp *sprint.Sprint) *model.Sprint {
 		Position:  sp.Position,
 		CreatedAt: sp.CreatedAt,
 		UpdatedAt: sp.UpdatedAt,
+		Summary:   sprintSummaryToModel(sp),
 		// Board and CreatedBy are resolved by field resolvers
 	}
 }
 
+// SetBoardSprintAutoMembership turns the board's backlog column policy on or off
+func SetBoardSprintAutoMembership(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, boardID string, enabled bool) (*model.Board, error) {
+	_, bID, err := requireBoardManager(ctx, rbacSvc, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := sprintSvc.SetSprintAutoMembership(ctx, bID, enabled)
+	if err != nil {
+		return nil, err
+	}
+	return boardToModel(b), nil
+}
+
 func sprintStatusToModel(status sprint.SprintStatus) model.SprintStatus {
 	switch status {
 	case sprint.SprintStatusActive:
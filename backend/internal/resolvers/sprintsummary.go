@@ -0,0 +1,56 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/services/sprintsummary"
+)
+
+// GenerateSprintSummary summarizes a sprint the user may manage with the configured language
+// model
+func GenerateSprintSummary(ctx context.Context, rbacSvc rbacService.Service, sprintSvc sprintService.Service, summarySvc sprintsummary.Service, sprintID string) (*model.SprintSummary, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := sprintSvc.GetBoard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, board.ID, "sprint:manage")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	sp, err := summarySvc.Generate(ctx, *userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return sprintSummaryToModel(sp), nil
+}
+
+// sprintSummaryToModel is the sprint's last generated summary, nil when there is none
+func sprintSummaryToModel(sp *sprint.Sprint) *model.SprintSummary {
+	if sp.SummaryGeneratedAt == nil {
+		return nil
+	}
+	return &model.SprintSummary{
+		Text:        sp.Summary,
+		GeneratedAt: *sp.SummaryGeneratedAt,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/thatcatdev/kaimu/backend/graph/model"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	legalholdService "github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
@@ -139,7 +140,7 @@ func UpdateTag(ctx context.Context, orgSvc orgService.Service, tagSvc tagService
 }
 
 // DeleteTag deletes a tag
-func DeleteTag(ctx context.Context, orgSvc orgService.Service, tagSvc tagService.Service, id string) (bool, error) {
+func DeleteTag(ctx context.Context, orgSvc orgService.Service, tagSvc tagService.Service, holdSvc legalholdService.Service, id string) (bool, error) {
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == nil {
 		return false, ErrUnauthorized
@@ -164,6 +165,10 @@ func DeleteTag(ctx context.Context, orgSvc orgService.Service, tagSvc tagService
 		return false, ErrUnauthorized
 	}
 
+	if err := holdSvc.CheckOrganization(ctx, proj.OrganizationID); err != nil {
+		return false, err
+	}
+
 	if err := tagSvc.DeleteTag(ctx, tagID); err != nil {
 		return false, err
 	}
@@ -0,0 +1,117 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	undoService "github.com/thatcatdev/kaimu/backend/internal/services/undo"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+)
+
+// undoPermissions is the board permission needed to undo each kind of operation, the same
+// one the original operation required
+var undoPermissions = map[undoService.Kind]string{
+	undoService.KindCompleteSprint: "sprint:manage",
+}
+
+// UndoableOperations returns the operations on a board that can still be undone
+func UndoableOperations(ctx context.Context, rbacSvc rbacService.Service, undoSvc undoService.Service, userSvc userService.Service, boardID string) ([]*model.UndoableOperation, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	bID, err := uuid.Parse(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, bID, "board:view")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	ops, err := undoSvc.GetUndoableOperations(ctx, bID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.UndoableOperation, len(ops))
+	for i, op := range ops {
+		result[i] = undoOperationToModel(ctx, userSvc, op)
+	}
+	return result, nil
+}
+
+// UndoOperation reverts a recorded bulk operation
+func UndoOperation(ctx context.Context, rbacSvc rbacService.Service, undoSvc undoService.Service, userSvc userService.Service, operationID string) (*model.UndoableOperation, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	opID, err := uuid.Parse(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := undoSvc.GetOperation(ctx, opID)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, ok := undoPermissions[undoService.Kind(op.Kind)]
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+	hasPermission, err := rbacSvc.HasBoardPermission(ctx, *userID, op.BoardID, permission)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	op, err = undoSvc.Undo(ctx, opID)
+	if err != nil {
+		return nil, err
+	}
+
+	return undoOperationToModel(ctx, userSvc, op), nil
+}
+
+func undoOperationToModel(ctx context.Context, userSvc userService.Service, op *undo_operation.UndoOperation) *model.UndoableOperation {
+	result := &model.UndoableOperation{
+		ID:        op.ID.String(),
+		Kind:      undoKindToModel(undoService.Kind(op.Kind)),
+		BoardID:   op.BoardID.String(),
+		EntityID:  op.EntityID.String(),
+		CreatedAt: op.CreatedAt,
+		ExpiresAt: op.ExpiresAt,
+		UndoneAt:  op.UndoneAt,
+	}
+
+	if op.ActorID != nil && userSvc != nil {
+		if user, err := userSvc.GetByID(ctx, *op.ActorID); err == nil && user != nil {
+			result.Actor = UserToModel(user)
+		}
+	}
+
+	return result
+}
+
+func undoKindToModel(kind undoService.Kind) model.UndoOperationKind {
+	switch kind {
+	case undoService.KindCompleteSprint:
+		return model.UndoOperationKindCompleteSprint
+	default:
+		return model.UndoOperationKind(kind)
+	}
+}
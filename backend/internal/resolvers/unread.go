@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	unreadService "github.com/thatcatdev/kaimu/backend/internal/services/unread"
+)
+
+// CardHasUnreadActivity resolves the hasUnreadActivity field of a Card for the current user
+func CardHasUnreadActivity(ctx context.Context, unreadSvc unreadService.Service, c *model.Card) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, nil
+	}
+
+	cardID, err := uuid.Parse(c.ID)
+	if err != nil {
+		return false, err
+	}
+	return unreadSvc.HasUnreadActivity(ctx, *userID, cardID, c.UpdatedAt)
+}
+
+// BoardUnreadCount resolves the unreadCount field of a Board for the current user
+func BoardUnreadCount(ctx context.Context, unreadSvc unreadService.Service, b *model.Board) (int, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return 0, nil
+	}
+
+	boardID, err := uuid.Parse(b.ID)
+	if err != nil {
+		return 0, err
+	}
+	return unreadSvc.CountUnread(ctx, *userID, boardID)
+}
+
+// MarkCardViewed records that the current user viewed a card
+func MarkCardViewed(ctx context.Context, rbacSvc rbacService.Service, cardSvc cardService.Service, unreadSvc unreadService.Service, cardID string) (*model.Card, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireCardPermission(ctx, rbacSvc, cardSvc, *userID, id, "card:view"); err != nil {
+		return nil, err
+	}
+
+	if err := unreadSvc.MarkViewed(ctx, *userID, id); err != nil {
+		return nil, err
+	}
+
+	c, err := cardSvc.GetCard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cardToModel(c), nil
+}
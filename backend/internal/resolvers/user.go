@@ -32,9 +32,12 @@ func UpdateMe(ctx context.Context, userSvc userService.Service, orgSvc organizat
 	if searchIndexer != nil {
 		orgs, err := orgSvc.GetUserOrganizations(ctx, *userID)
 		if err == nil {
-			orgIDs := make([]string, len(orgs))
-			for i, org := range orgs {
-				orgIDs[i] = org.ID.String()
+			// Guests cannot be found by the organizations they are a guest of
+			orgIDs := make([]string, 0, len(orgs))
+			for _, org := range orgs {
+				if isGuest, err := orgSvc.IsGuest(ctx, org.ID, *userID); err == nil && !isGuest {
+					orgIDs = append(orgIDs, org.ID.String())
+				}
 			}
 			searchIndexer.IndexUserAsync(ctx, *userID, orgIDs)
 		}
@@ -0,0 +1,183 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	userService "github.com/thatcatdev/kaimu/backend/internal/services/user"
+	usermatchService "github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+// MatchExternalUsers maps users of an external source to existing users of the organization
+func MatchExternalUsers(ctx context.Context, rbacSvc rbacService.Service, matchSvc usermatchService.Service, organizationID string, source string, users []*model.ExternalUserInput) ([]*model.UserMatch, error) {
+	orgID, err := requireOrgInviter(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	external := make([]usermatchService.ExternalUser, len(users))
+	for i, u := range users {
+		if u.ExternalID != nil {
+			external[i].ExternalID = *u.ExternalID
+		}
+		if u.DisplayName != nil {
+			external[i].DisplayName = *u.DisplayName
+		}
+		if u.Email != nil {
+			external[i].Email = *u.Email
+		}
+	}
+
+	matches, err := matchSvc.MatchUsers(ctx, orgID, source, external)
+	if err != nil {
+		return nil, err
+	}
+	return userMatchesToModel(matches), nil
+}
+
+// UserMatchQueue returns the organization's user matches awaiting resolution
+func UserMatchQueue(ctx context.Context, rbacSvc rbacService.Service, matchSvc usermatchService.Service, organizationID string) ([]*model.UserMatch, error) {
+	orgID, err := requireOrgInviter(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := matchSvc.GetPendingMatches(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return userMatchesToModel(matches), nil
+}
+
+// ResolveUserMatch settles a user match by hand
+func ResolveUserMatch(ctx context.Context, rbacSvc rbacService.Service, matchSvc usermatchService.Service, id string, userID *string) (*model.UserMatch, error) {
+	currentUserID := middleware.GetUserIDFromContext(ctx)
+	if currentUserID == nil {
+		return nil, ErrUnauthorized
+	}
+
+	matchID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	var uID *uuid.UUID
+	if userID != nil {
+		parsed, err := uuid.Parse(*userID)
+		if err != nil {
+			return nil, err
+		}
+		uID = &parsed
+	}
+
+	match, err := matchSvc.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *currentUserID, match.OrganizationID, "org:invite")
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, ErrUnauthorized
+	}
+
+	match, err = matchSvc.ResolveMatch(ctx, matchID, uID, *currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	return userMatchToModel(match), nil
+}
+
+// UserMatchUser resolves the user field of UserMatch
+func UserMatchUser(ctx context.Context, userSvc userService.Service, obj *model.UserMatch) (*model.User, error) {
+	if obj.UserID == nil {
+		return nil, nil
+	}
+	return userByID(ctx, userSvc, *obj.UserID)
+}
+
+// UserMatchCandidateUser resolves the user field of UserMatchCandidate
+func UserMatchCandidateUser(ctx context.Context, userSvc userService.Service, obj *model.UserMatchCandidate) (*model.User, error) {
+	return userByID(ctx, userSvc, obj.UserID)
+}
+
+// requireOrgInviter parses the organization ID, requiring the current user to be able to
+// invite to the organization. Matching users is how imports and bulk invites add people.
+func requireOrgInviter(ctx context.Context, rbacSvc rbacService.Service, organizationID string) (uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, ErrUnauthorized
+	}
+
+	id, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasOrgPermission(ctx, *userID, id, "org:invite")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, ErrUnauthorized
+	}
+	return id, nil
+}
+
+func userByID(ctx context.Context, userSvc userService.Service, id string) (*model.User, error) {
+	uID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := userSvc.GetByID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+	return UserToModel(u), nil
+}
+
+func userMatchesToModel(matches []*user_match.UserMatch) []*model.UserMatch {
+	result := make([]*model.UserMatch, len(matches))
+	for i, m := range matches {
+		result[i] = userMatchToModel(m)
+	}
+	return result
+}
+
+func userMatchToModel(m *user_match.UserMatch) *model.UserMatch {
+	candidates := make([]*model.UserMatchCandidate, len(m.Candidates))
+	for i, c := range m.Candidates {
+		candidates[i] = &model.UserMatchCandidate{
+			UserID:     c.UserID.String(),
+			Confidence: c.Confidence,
+			Reason:     model.UserMatchReason(strings.ToUpper(string(c.Reason))),
+		}
+	}
+	result := &model.UserMatch{
+		ID:             m.ID.String(),
+		OrganizationID: m.OrganizationID.String(),
+		Source:         m.Source,
+		ExternalID:     m.ExternalID,
+		DisplayName:    m.DisplayName,
+		Email:          m.Email,
+		Status:         model.UserMatchStatus(strings.ToUpper(string(m.Status))),
+		Confidence:     m.Confidence,
+		Candidates:     candidates,
+		ResolvedAt:     m.ResolvedAt,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.UserID != nil {
+		id := m.UserID.String()
+		result.UserID = &id
+	}
+	if m.ResolvedBy != nil {
+		id := m.ResolvedBy.String()
+		result.ResolvedBy = &id
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	watchService "github.com/thatcatdev/kaimu/backend/internal/services/watch"
+)
+
+// ColumnWatcherCount resolves the watcherCount field of a BoardColumn
+func ColumnWatcherCount(ctx context.Context, watchSvc watchService.Service, col *model.BoardColumn) (int, error) {
+	colID, err := uuid.Parse(col.ID)
+	if err != nil {
+		return 0, err
+	}
+	return watchSvc.CountColumnWatchers(ctx, colID)
+}
+
+// ColumnIsWatching resolves the isWatching field of a BoardColumn for the current user
+func ColumnIsWatching(ctx context.Context, watchSvc watchService.Service, col *model.BoardColumn) (bool, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return false, nil
+	}
+
+	colID, err := uuid.Parse(col.ID)
+	if err != nil {
+		return false, err
+	}
+	return watchSvc.IsWatchingColumn(ctx, *userID, colID)
+}
+
+// WatchColumn subscribes the current user to cards entering and leaving a column
+func WatchColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, watchSvc watchService.Service, columnID string) (*model.BoardColumn, error) {
+	userID, colID, err := requireColumnViewer(ctx, rbacSvc, boardSvc, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watchSvc.WatchColumn(ctx, userID, colID); err != nil {
+		return nil, err
+	}
+
+	col, err := boardSvc.GetColumn(ctx, colID)
+	if err != nil {
+		return nil, err
+	}
+	return columnToModel(col), nil
+}
+
+// UnwatchColumn removes the current user's watch on a column
+func UnwatchColumn(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, watchSvc watchService.Service, columnID string) (*model.BoardColumn, error) {
+	userID, colID, err := requireColumnViewer(ctx, rbacSvc, boardSvc, columnID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watchSvc.UnwatchColumn(ctx, userID, colID); err != nil {
+		return nil, err
+	}
+
+	col, err := boardSvc.GetColumn(ctx, colID)
+	if err != nil {
+		return nil, err
+	}
+	return columnToModel(col), nil
+}
+
+// requireColumnViewer parses the column ID, requiring the current user to be able to view
+// the column's board
+func requireColumnViewer(ctx context.Context, rbacSvc rbacService.Service, boardSvc boardService.Service, columnID string) (uuid.UUID, uuid.UUID, error) {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == nil {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+
+	colID, err := uuid.Parse(columnID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	b, err := boardSvc.GetBoardByColumnID(ctx, colID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	hasPermission, err := rbacSvc.HasProjectPermission(ctx, *userID, b.ProjectID, "board:view")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hasPermission {
+		return uuid.Nil, uuid.Nil, ErrUnauthorized
+	}
+	return *userID, colID, nil
+}
@@ -0,0 +1,249 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/graph/model"
+	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	webhookService "github.com/thatcatdev/kaimu/backend/internal/services/webhook"
+)
+
+// maxWebhookDeliveryPageSize caps the first argument of webhookDeliveries
+const maxWebhookDeliveryPageSize = 100
+
+// requireWebhookManager loads the webhook after checking the requester can manage its
+// organization
+func requireWebhookManager(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, id string) (*webhookrepo.Webhook, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+	webhookID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := webhookSvc.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := requireOrganizationManager(ctx, rbacSvc, webhook.OrganizationID.String()); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// WebhookEvents returns the event names webhooks can subscribe to
+func WebhookEvents(ctx context.Context) ([]string, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+	return webhookService.EventNames(), nil
+}
+
+// Webhooks returns the organization's webhooks
+func Webhooks(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, organizationID string) ([]*model.Webhook, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := webhookSvc.GetWebhooks(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.Webhook, len(webhooks))
+	for i, w := range webhooks {
+		result[i] = webhookToModel(w)
+	}
+	return result, nil
+}
+
+// CreateWebhook registers a webhook and returns it with its secret
+func CreateWebhook(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, organizationID string, input model.CreateWebhookInput) (*model.WebhookWithSecret, error) {
+	orgID, err := requireOrganizationManager(ctx, rbacSvc, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := webhookService.CreateInput{
+		URL:       input.URL,
+		Events:    input.Events,
+		CreatedBy: middleware.GetUserIDFromContext(ctx),
+	}
+	if input.Description != nil {
+		createInput.Description = *input.Description
+	}
+	webhook, err := webhookSvc.CreateWebhook(ctx, orgID, createInput)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WebhookWithSecret{Webhook: webhookToModel(webhook), Secret: webhook.Secret}, nil
+}
+
+// UpdateWebhook changes the given fields of a webhook
+func UpdateWebhook(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, id string, input model.UpdateWebhookInput) (*model.Webhook, error) {
+	webhook, err := requireWebhookManager(ctx, rbacSvc, webhookSvc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err = webhookSvc.UpdateWebhook(ctx, webhook.ID, webhookService.UpdateInput{
+		URL:         input.URL,
+		Description: input.Description,
+		Events:      input.Events,
+		Enabled:     input.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return webhookToModel(webhook), nil
+}
+
+// DeleteWebhook deletes a webhook with its deliveries
+func DeleteWebhook(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, id string) (bool, error) {
+	webhook, err := requireWebhookManager(ctx, rbacSvc, webhookSvc, id)
+	if err != nil {
+		return false, err
+	}
+	if err := webhookSvc.DeleteWebhook(ctx, webhook.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RotateWebhookSecret gives a webhook a new secret and returns it
+func RotateWebhookSecret(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, id string) (*model.WebhookWithSecret, error) {
+	webhook, err := requireWebhookManager(ctx, rbacSvc, webhookSvc, id)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err = webhookSvc.RotateSecret(ctx, webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.WebhookWithSecret{Webhook: webhookToModel(webhook), Secret: webhook.Secret}, nil
+}
+
+// WebhookDeliveries returns one page of a webhook's delivery log
+func WebhookDeliveries(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, webhookID string, first *int, after *string) (*model.WebhookDeliveryConnection, error) {
+	webhook, err := requireWebhookManager(ctx, rbacSvc, webhookSvc, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 20
+	if first != nil && *first > 0 {
+		limit = min(*first, maxWebhookDeliveryPageSize)
+	}
+	offset := 0
+	if after != nil && *after != "" {
+		offset, err = parseCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries, totalCount, err := webhookSvc.GetDeliveries(ctx, webhook.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.WebhookDeliveryEdge, len(deliveries))
+	for i, d := range deliveries {
+		edges[i] = &model.WebhookDeliveryEdge{
+			Node:   webhookDeliveryToModel(d),
+			Cursor: encodeCursor(offset + i),
+		}
+	}
+
+	var startCursor, endCursor *string
+	if len(edges) > 0 {
+		startCursor = &edges[0].Cursor
+		endCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.WebhookDeliveryConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     int64(offset+len(deliveries)) < totalCount,
+			HasPreviousPage: offset > 0,
+			StartCursor:     startCursor,
+			EndCursor:       endCursor,
+			TotalCount:      int(totalCount),
+		},
+	}, nil
+}
+
+// RedeliverWebhookDelivery queues a delivery to be sent again
+func RedeliverWebhookDelivery(ctx context.Context, rbacSvc rbacService.Service, webhookSvc webhookService.Service, id string) (*model.WebhookDelivery, error) {
+	if middleware.GetUserIDFromContext(ctx) == nil {
+		return nil, ErrUnauthorized
+	}
+	deliveryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := webhookSvc.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := requireWebhookManager(ctx, rbacSvc, webhookSvc, delivery.WebhookID.String()); err != nil {
+		return nil, err
+	}
+
+	delivery, err = webhookSvc.Redeliver(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return webhookDeliveryToModel(delivery), nil
+}
+
+func webhookToModel(w *webhookrepo.Webhook) *model.Webhook {
+	eventNames := []string(w.Events)
+	if eventNames == nil {
+		eventNames = []string{}
+	}
+	return &model.Webhook{
+		ID:          w.ID.String(),
+		URL:         w.URL,
+		Description: w.Description,
+		Events:      eventNames,
+		Enabled:     w.Enabled,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+func webhookDeliveryToModel(d *webhookrepo.Delivery) *model.WebhookDelivery {
+	delivery := &model.WebhookDelivery{
+		ID:             d.ID.String(),
+		EventID:        d.EventID.String(),
+		Event:          d.EventName,
+		Body:           string(d.Body),
+		Status:         webhookDeliveryStatusToModel(d.Status),
+		Attempts:       d.Attempts,
+		LastAttemptAt:  d.LastAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == webhookrepo.DeliveryPending {
+		nextAttemptAt := d.NextAttemptAt
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+	return delivery
+}
+
+func webhookDeliveryStatusToModel(status webhookrepo.DeliveryStatus) model.WebhookDeliveryStatus {
+	switch status {
+	case webhookrepo.DeliverySucceeded:
+		return model.WebhookDeliveryStatusSucceeded
+	case webhookrepo.DeliveryFailed:
+		return model.WebhookDeliveryStatusFailed
+	default:
+		return model.WebhookDeliveryStatusPending
+	}
+}
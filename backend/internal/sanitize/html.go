@@ -1,6 +1,7 @@
 package sanitize
 
 import (
+	"html"
 	"regexp"
 	"sync"
 
@@ -70,3 +71,21 @@ func HTMLPtr(html *string) *string {
 	sanitized := HTML(*html)
 	return &sanitized
 }
+
+var (
+	textPolicy     *bluemonday.Policy
+	textPolicyOnce sync.Once
+)
+
+// PlainText strips all markup from HTML content and decodes entities, leaving the text a
+// reader would see. Stripped tags become spaces so words in adjacent elements stay apart.
+func PlainText(content string) string {
+	if content == "" {
+		return ""
+	}
+	textPolicyOnce.Do(func() {
+		textPolicy = bluemonday.StrictPolicy()
+		textPolicy.AddSpaceWhenStrippingTag(true)
+	})
+	return html.UnescapeString(textPolicy.Sanitize(content))
+}
@@ -0,0 +1,217 @@
+// Package schemacompat detects GraphQL schema changes that would break existing clients.
+//
+// Mobile and desktop clients ship with queries compiled against an older schema, so a
+// field, argument or enum value may only disappear after it has been marked @deprecated
+// in a released schema. Nullability may only change in the direction that old clients
+// already handle: output fields may become non-null, inputs may become nullable.
+package schemacompat
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// BreakingChange describes a single incompatible difference between two schemas
+type BreakingChange struct {
+	// Path is the schema coordinate, e.g. "Card.title" or "Query.board(id:)"
+	Path    string
+	Message string
+}
+
+func (c BreakingChange) String() string {
+	return c.Path + ": " + c.Message
+}
+
+// Print renders a schema as SDL with type extensions merged, suitable for snapshots
+func Print(schema *ast.Schema) string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(schema)
+	return buf.String()
+}
+
+// Parse reads an SDL snapshot produced by Print
+func Parse(name, sdl string) (*ast.SchemaDocument, error) {
+	doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Check returns the changes in next that break clients written against prev,
+// sorted by path
+func Check(prev, next *ast.SchemaDocument) []BreakingChange {
+	c := &checker{}
+
+	nextDefs := definitionsByName(next)
+	for _, oldDef := range prev.Definitions {
+		newDef, ok := nextDefs[oldDef.Name]
+		if !ok {
+			c.add(oldDef.Name, "type was removed")
+			continue
+		}
+		if oldDef.Kind != newDef.Kind {
+			c.add(oldDef.Name, fmt.Sprintf("kind changed from %s to %s", oldDef.Kind, newDef.Kind))
+			continue
+		}
+
+		switch oldDef.Kind {
+		case ast.Object, ast.Interface:
+			c.checkOutputFields(oldDef, newDef)
+		case ast.InputObject:
+			c.checkInputFields(oldDef, newDef)
+		case ast.Enum:
+			c.checkEnumValues(oldDef, newDef)
+		case ast.Union:
+			c.checkUnionMembers(oldDef, newDef)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Path < c.changes[j].Path
+	})
+	return c.changes
+}
+
+type checker struct {
+	changes []BreakingChange
+}
+
+func (c *checker) add(path, message string) {
+	c.changes = append(c.changes, BreakingChange{Path: path, Message: message})
+}
+
+func (c *checker) checkOutputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		path := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			if !isDeprecated(oldField.Directives) {
+				c.add(path, "field was removed without being deprecated first")
+			}
+			continue
+		}
+
+		if !outputTypeCompatible(oldField.Type, newField.Type) {
+			c.add(path, fmt.Sprintf("type changed from %s to %s", oldField.Type, newField.Type))
+		}
+
+		c.checkArguments(path, oldField.Arguments, newField.Arguments)
+	}
+}
+
+func (c *checker) checkArguments(fieldPath string, oldArgs, newArgs ast.ArgumentDefinitionList) {
+	for _, oldArg := range oldArgs {
+		path := fmt.Sprintf("%s(%s:)", fieldPath, oldArg.Name)
+		newArg := newArgs.ForName(oldArg.Name)
+		if newArg == nil {
+			if !isDeprecated(oldArg.Directives) {
+				c.add(path, "argument was removed without being deprecated first")
+			}
+			continue
+		}
+		if !inputTypeCompatible(oldArg.Type, newArg.Type, newArg.DefaultValue != nil) {
+			c.add(path, fmt.Sprintf("type changed from %s to %s", oldArg.Type, newArg.Type))
+		}
+	}
+
+	for _, newArg := range newArgs {
+		if oldArgs.ForName(newArg.Name) == nil && isRequired(newArg.Type, newArg.DefaultValue) {
+			c.add(fmt.Sprintf("%s(%s:)", fieldPath, newArg.Name), "required argument was added")
+		}
+	}
+}
+
+func (c *checker) checkInputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		path := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			if !isDeprecated(oldField.Directives) {
+				c.add(path, "input field was removed without being deprecated first")
+			}
+			continue
+		}
+		if !inputTypeCompatible(oldField.Type, newField.Type, newField.DefaultValue != nil) {
+			c.add(path, fmt.Sprintf("type changed from %s to %s", oldField.Type, newField.Type))
+		}
+	}
+
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) == nil && isRequired(newField.Type, newField.DefaultValue) {
+			c.add(newDef.Name+"."+newField.Name, "required input field was added")
+		}
+	}
+}
+
+func (c *checker) checkEnumValues(oldDef, newDef *ast.Definition) {
+	for _, oldValue := range oldDef.EnumValues {
+		if newDef.EnumValues.ForName(oldValue.Name) == nil && !isDeprecated(oldValue.Directives) {
+			c.add(oldDef.Name+"."+oldValue.Name, "enum value was removed without being deprecated first")
+		}
+	}
+}
+
+func (c *checker) checkUnionMembers(oldDef, newDef *ast.Definition) {
+	members := make(map[string]bool, len(newDef.Types))
+	for _, name := range newDef.Types {
+		members[name] = true
+	}
+	for _, name := range oldDef.Types {
+		if !members[name] {
+			c.add(oldDef.Name, fmt.Sprintf("member %s was removed", name))
+		}
+	}
+}
+
+// outputTypeCompatible reports whether clients expecting prev can read next.
+// Tightening a nullable output to non-null is safe, loosening it is not.
+func outputTypeCompatible(prev, next *ast.Type) bool {
+	if prev.NonNull && !next.NonNull {
+		return false
+	}
+	if (prev.Elem == nil) != (next.Elem == nil) {
+		return false
+	}
+	if prev.Elem != nil {
+		return outputTypeCompatible(prev.Elem, next.Elem)
+	}
+	return prev.NamedType == next.NamedType
+}
+
+// inputTypeCompatible reports whether values sent by clients built against prev are still
+// accepted by next. Loosening a non-null input is safe; tightening it is only safe when a
+// default value fills in for clients that omit it.
+func inputTypeCompatible(prev, next *ast.Type, nextHasDefault bool) bool {
+	if !prev.NonNull && next.NonNull && !nextHasDefault {
+		return false
+	}
+	if (prev.Elem == nil) != (next.Elem == nil) {
+		return false
+	}
+	if prev.Elem != nil {
+		return inputTypeCompatible(prev.Elem, next.Elem, false)
+	}
+	return prev.NamedType == next.NamedType
+}
+
+func isRequired(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+func isDeprecated(directives ast.DirectiveList) bool {
+	return directives.ForName("deprecated") != nil
+}
+
+func definitionsByName(doc *ast.SchemaDocument) map[string]*ast.Definition {
+	defs := make(map[string]*ast.Definition, len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		defs[def.Name] = def
+	}
+	return defs
+}
@@ -0,0 +1,196 @@
+package schemacompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseSchema = `
+type Query {
+	card(id: ID!): Card
+	cards(first: Int): [Card!]!
+}
+type Card {
+	id: ID!
+	title: String!
+	description: String
+	legacyRole: String @deprecated(reason: "gone soon")
+	priority: Priority!
+}
+enum Priority {
+	LOW
+	HIGH
+	OLD @deprecated
+}
+input CreateCardInput {
+	title: String!
+	description: String
+}
+union SearchResult = Card | Query
+`
+
+func check(t *testing.T, next string) []BreakingChange {
+	t.Helper()
+	prevDoc, err := Parse("prev", baseSchema)
+	require.NoError(t, err)
+	nextDoc, err := Parse("next", next)
+	require.NoError(t, err)
+	return Check(prevDoc, nextDoc)
+}
+
+func paths(changes []BreakingChange) []string {
+	result := make([]string, len(changes))
+	for i, c := range changes {
+		result[i] = c.Path
+	}
+	return result
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("identical schema has no breaking changes", func(t *testing.T) {
+		assert.Empty(t, check(t, baseSchema))
+	})
+
+	t.Run("additive changes are compatible", func(t *testing.T) {
+		changes := check(t, `
+type Query {
+	card(id: ID!, includeArchived: Boolean): Card
+	cards(first: Int, after: String = null): [Card!]!
+	me: Card
+}
+type Card {
+	id: ID!
+	title: String!
+	description: String!
+	legacyRole: String @deprecated(reason: "gone soon")
+	priority: Priority!
+}
+enum Priority {
+	LOW
+	HIGH
+	URGENT
+	OLD @deprecated
+}
+input CreateCardInput {
+	title: String
+	description: String
+	color: String
+}
+union SearchResult = Card | Query
+`)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("removing deprecated members is compatible", func(t *testing.T) {
+		changes := check(t, `
+type Query {
+	card(id: ID!): Card
+	cards(first: Int): [Card!]!
+}
+type Card {
+	id: ID!
+	title: String!
+	description: String
+	priority: Priority!
+}
+enum Priority {
+	LOW
+	HIGH
+}
+input CreateCardInput {
+	title: String!
+	description: String
+}
+union SearchResult = Card | Query
+`)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("detects removals and nullability changes", func(t *testing.T) {
+		changes := check(t, `
+type Query {
+	card(id: ID!, version: Int!): Card
+	cards: [Card]!
+}
+type Card {
+	id: ID!
+	title: String
+	legacyRole: String @deprecated(reason: "gone soon")
+	priority: Priority!
+}
+enum Priority {
+	HIGH
+	OLD @deprecated
+}
+input CreateCardInput {
+	title: String!
+	description: String!
+	boardId: ID!
+}
+union SearchResult = Card
+`)
+		assert.Equal(t, []string{
+			"Card.description",
+			"Card.title",
+			"CreateCardInput.boardId",
+			"CreateCardInput.description",
+			"Priority.LOW",
+			"Query.card(version:)",
+			"Query.cards",
+			"Query.cards(first:)",
+			"SearchResult",
+		}, paths(changes))
+	})
+
+	t.Run("detects removed types and kind changes", func(t *testing.T) {
+		changes := check(t, `
+type Query {
+	card(id: ID!): Card
+	cards(first: Int): [Card!]!
+}
+interface Card {
+	id: ID!
+}
+input CreateCardInput {
+	title: String!
+	description: String
+}
+union SearchResult = Card | Query
+`)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "Card", changes[0].Path)
+		assert.Contains(t, changes[0].Message, "kind changed")
+		assert.Equal(t, "Priority", changes[1].Path)
+		assert.Contains(t, changes[1].Message, "removed")
+	})
+
+	t.Run("required input with default is compatible", func(t *testing.T) {
+		changes := check(t, `
+type Query {
+	card(id: ID!): Card
+	cards(first: Int! = 20): [Card!]!
+}
+type Card {
+	id: ID!
+	title: String!
+	description: String
+	legacyRole: String @deprecated(reason: "gone soon")
+	priority: Priority!
+}
+enum Priority {
+	LOW
+	HIGH
+	OLD @deprecated
+}
+input CreateCardInput {
+	title: String!
+	description: String
+	position: Float! = 0
+}
+union SearchResult = Card | Query
+`)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,221 @@
+package activity
+
+//go:generate mockgen -source=activity_service.go -destination=mocks/activity_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultRangeDays is how far back a heatmap looks when no start is given
+	DefaultRangeDays = 365
+	// MaxRangeDays caps the span of one heatmap
+	MaxRangeDays = 366
+	// MaxLevel is the level of a contributor's busiest days
+	MaxLevel = 4
+)
+
+var (
+	ErrInvalidRange = errors.New("the range must end after it starts")
+	ErrRangeTooLong = fmt.Errorf("the range can span at most %d days", MaxRangeDays)
+)
+
+// Counts are the events counted towards a contributor's activity
+type Counts struct {
+	CardsCreated int
+	CardsMoved   int
+	Comments     int
+}
+
+func (c Counts) Total() int {
+	return c.CardsCreated + c.CardsMoved + c.Comments
+}
+
+func (c *Counts) add(o Counts) {
+	c.CardsCreated += o.CardsCreated
+	c.CardsMoved += o.CardsMoved
+	c.Comments += o.Comments
+}
+
+// Day is a contributor's activity on one UTC day
+type Day struct {
+	// Date is the start of the day
+	Date time.Time
+	Counts
+	// Level buckets the day's total against the busiest day of the heatmap, from 0 (no
+	// activity) to MaxLevel, so every contributor is shaded on the same scale
+	Level int
+}
+
+// Contributor is one user's activity over the heatmap's range
+type Contributor struct {
+	UserID uuid.UUID
+	// Counts are the totals over the range
+	Counts
+	// Days has an entry for every day of the range, oldest first
+	Days []Day
+}
+
+// Heatmap is a project's contributor activity over the UTC days in [From, To)
+type Heatmap struct {
+	ProjectID uuid.UUID
+	From      time.Time
+	To        time.Time
+	// MaxDailyCount is the highest total of any contributor on any day
+	MaxDailyCount int
+	// Contributors are ordered by total activity, most first
+	Contributors []Contributor
+}
+
+type Service interface {
+	// GetContributorActivity buckets the project's card creations, card moves and comments
+	// per contributor and UTC day. The range is widened to whole days and defaults to the
+	// last DefaultRangeDays days. A non-nil userID limits the heatmap to that user.
+	GetContributorActivity(ctx context.Context, projectID uuid.UUID, userID *uuid.UUID, from, to *time.Time) (*Heatmap, error)
+}
+
+type service struct {
+	auditRepo auditrepo.Repository
+	now       func() time.Time
+}
+
+func NewService(auditRepo auditrepo.Repository) Service {
+	return &service{
+		auditRepo: auditRepo,
+		now:       time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "activity.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "activity"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetContributorActivity(ctx context.Context, projectID uuid.UUID, userID *uuid.UUID, from, to *time.Time) (*Heatmap, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetContributorActivity")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	// The range covers whole days, so today is included by default
+	end := startOfDay(s.now()).AddDate(0, 0, 1)
+	if to != nil {
+		end = startOfDay(*to)
+		if !end.Equal(to.UTC()) {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+	start := end.AddDate(0, 0, -DefaultRangeDays)
+	if from != nil {
+		start = startOfDay(*from)
+	}
+	if !end.After(start) {
+		return nil, ErrInvalidRange
+	}
+	days := dayIndex(start, end)
+	if days > MaxRangeDays {
+		return nil, ErrRangeTooLong
+	}
+
+	counts, err := s.auditRepo.CountDailyByActor(ctx, projectID, userID,
+		[]auditrepo.AuditAction{auditrepo.ActionCreated, auditrepo.ActionCardMoved},
+		[]auditrepo.EntityType{auditrepo.EntityCard, auditrepo.EntityComment},
+		start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &Heatmap{ProjectID: projectID, From: start, To: end}
+	byUser := map[uuid.UUID]*Contributor{}
+	for _, c := range counts {
+		kind, ok := classify(c.Action, c.EntityType, c.Count)
+		if !ok {
+			continue
+		}
+		i := dayIndex(start, c.Day)
+		if i < 0 || i >= days {
+			continue
+		}
+		contributor := byUser[c.ActorID]
+		if contributor == nil {
+			contributor = &Contributor{UserID: c.ActorID, Days: make([]Day, days)}
+			for d := range contributor.Days {
+				contributor.Days[d].Date = start.AddDate(0, 0, d)
+			}
+			byUser[c.ActorID] = contributor
+		}
+		contributor.Days[i].add(kind)
+		contributor.add(kind)
+	}
+
+	for _, contributor := range byUser {
+		for _, day := range contributor.Days {
+			heatmap.MaxDailyCount = max(heatmap.MaxDailyCount, day.Total())
+		}
+	}
+	for _, contributor := range byUser {
+		for d := range contributor.Days {
+			contributor.Days[d].Level = level(contributor.Days[d].Total(), heatmap.MaxDailyCount)
+		}
+		heatmap.Contributors = append(heatmap.Contributors, *contributor)
+	}
+	sort.Slice(heatmap.Contributors, func(i, j int) bool {
+		a, b := heatmap.Contributors[i], heatmap.Contributors[j]
+		if a.Total() != b.Total() {
+			return a.Total() > b.Total()
+		}
+		return a.UserID.String() < b.UserID.String()
+	})
+	span.SetAttributes(attribute.Int("activity.contributors", len(heatmap.Contributors)))
+
+	return heatmap, nil
+}
+
+// classify turns a count of audit events into the activity it stands for
+func classify(action auditrepo.AuditAction, entityType auditrepo.EntityType, count int) (Counts, bool) {
+	switch {
+	case action == auditrepo.ActionCreated && entityType == auditrepo.EntityCard:
+		return Counts{CardsCreated: count}, true
+	case action == auditrepo.ActionCardMoved && entityType == auditrepo.EntityCard:
+		return Counts{CardsMoved: count}, true
+	case action == auditrepo.ActionCreated && entityType == auditrepo.EntityComment:
+		return Counts{Comments: count}, true
+	}
+	return Counts{}, false
+}
+
+// level scales a day's total to 1..MaxLevel against the busiest day; only days without
+// activity are level 0
+func level(total, maxTotal int) int {
+	if total <= 0 || maxTotal <= 0 {
+		return 0
+	}
+	return (total*MaxLevel + maxTotal - 1) / maxTotal
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dayIndex is the number of whole days from start to day
+func dayIndex(start, day time.Time) int {
+	return int(startOfDay(day).Sub(start).Hours() / 24)
+}
@@ -0,0 +1,106 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	auditMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetContributorActivity(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	projectID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	ctx := context.Background()
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("buckets events per contributor and day", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		from := time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)
+		mockAuditRepo.EXPECT().CountDailyByActor(gomock.Any(), projectID, nil, gomock.Any(), gomock.Any(), day(8), day(11)).
+			Return([]*auditrepo.DailyActorCount{
+				{ActorID: alice, Day: day(8), Action: auditrepo.ActionCreated, EntityType: auditrepo.EntityCard, Count: 2},
+				{ActorID: alice, Day: day(8), Action: auditrepo.ActionCardMoved, EntityType: auditrepo.EntityCard, Count: 6},
+				{ActorID: alice, Day: day(10), Action: auditrepo.ActionCreated, EntityType: auditrepo.EntityComment, Count: 1},
+				{ActorID: bob, Day: day(9), Action: auditrepo.ActionCardMoved, EntityType: auditrepo.EntityCard, Count: 3},
+				{ActorID: bob, Day: day(9), Action: auditrepo.ActionCreated, EntityType: auditrepo.EntityCard, Count: 1},
+			}, nil)
+
+		heatmap, err := svc.GetContributorActivity(ctx, projectID, nil, &from, nil)
+		require.NoError(t, err)
+		assert.Equal(t, day(8), heatmap.From)
+		assert.Equal(t, day(11), heatmap.To)
+		assert.Equal(t, 8, heatmap.MaxDailyCount)
+		require.Len(t, heatmap.Contributors, 2)
+
+		a := heatmap.Contributors[0]
+		assert.Equal(t, alice, a.UserID)
+		assert.Equal(t, Counts{CardsCreated: 2, CardsMoved: 6, Comments: 1}, a.Counts)
+		require.Len(t, a.Days, 3)
+		assert.Equal(t, []time.Time{day(8), day(9), day(10)}, []time.Time{a.Days[0].Date, a.Days[1].Date, a.Days[2].Date})
+		assert.Equal(t, []int{4, 0, 1}, []int{a.Days[0].Level, a.Days[1].Level, a.Days[2].Level})
+
+		b := heatmap.Contributors[1]
+		assert.Equal(t, bob, b.UserID)
+		assert.Equal(t, 4, b.Days[1].Total())
+		assert.Equal(t, 2, b.Days[1].Level)
+	})
+
+	t.Run("defaults to the last year including today", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockAuditRepo.EXPECT().CountDailyByActor(gomock.Any(), projectID, &alice, gomock.Any(), gomock.Any(), day(11).AddDate(0, 0, -DefaultRangeDays), day(11)).
+			Return(nil, nil)
+
+		heatmap, err := svc.GetContributorActivity(ctx, projectID, &alice, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, heatmap.Contributors)
+		assert.Zero(t, heatmap.MaxDailyCount)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewService(auditMocks.NewMockRepository(ctrl)).(*service)
+		svc.now = func() time.Time { return now }
+
+		to := day(5)
+		from := day(5)
+		_, err := svc.GetContributorActivity(ctx, projectID, nil, &from, &to)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+
+		from = to.AddDate(-2, 0, 0)
+		_, err = svc.GetContributorActivity(ctx, projectID, nil, &from, &to)
+		assert.ErrorIs(t, err, ErrRangeTooLong)
+	})
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, 0, level(0, 10))
+	assert.Equal(t, 1, level(1, 10))
+	assert.Equal(t, 2, level(5, 10))
+	assert.Equal(t, 4, level(10, 10))
+	assert.Equal(t, 0, level(0, 0))
+}
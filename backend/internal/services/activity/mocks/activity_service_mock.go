@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=activity_service.go -destination=mocks/activity_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	activity "github.com/thatcatdev/kaimu/backend/internal/services/activity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetContributorActivity mocks base method.
+func (m *MockService) GetContributorActivity(ctx context.Context, projectID uuid.UUID, userID *uuid.UUID, from, to *time.Time) (*activity.Heatmap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributorActivity", ctx, projectID, userID, from, to)
+	ret0, _ := ret[0].(*activity.Heatmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributorActivity indicates an expected call of GetContributorActivity.
+func (mr *MockServiceMockRecorder) GetContributorActivity(ctx, projectID, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributorActivity", reflect.TypeOf((*MockService)(nil).GetContributorActivity), ctx, projectID, userID, from, to)
+}
@@ -0,0 +1,74 @@
+package aggregate
+
+//go:generate mockgen -source=aggregate_service.go -destination=mocks/aggregate_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrNoGroupBy        = errors.New("at least one field to group by is required")
+	ErrInvalidGroupBy   = errors.New("cards cannot be grouped by this field")
+	ErrDuplicateGroupBy = errors.New("a field can only be grouped by once")
+)
+
+type Service interface {
+	// AggregateCards counts a project's cards and sums their story points per combination
+	// of the groupBy fields, computed by the database
+	AggregateCards(ctx context.Context, projectID uuid.UUID, groupBy []card_aggregate.Field, filter card_aggregate.Filter) ([]*card_aggregate.Group, error)
+}
+
+type service struct {
+	aggregateRepo card_aggregate.Repository
+}
+
+func NewService(aggregateRepo card_aggregate.Repository) Service {
+	return &service{aggregateRepo: aggregateRepo}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "aggregate.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "aggregate"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) AggregateCards(ctx context.Context, projectID uuid.UUID, groupBy []card_aggregate.Field, filter card_aggregate.Filter) ([]*card_aggregate.Group, error) {
+	ctx, span := s.startServiceSpan(ctx, "AggregateCards")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if len(groupBy) == 0 {
+		return nil, ErrNoGroupBy
+	}
+	seen := make(map[card_aggregate.Field]bool, len(groupBy))
+	for _, field := range groupBy {
+		if !field.IsValid() {
+			return nil, ErrInvalidGroupBy
+		}
+		if seen[field] {
+			return nil, ErrDuplicateGroupBy
+		}
+		seen[field] = true
+	}
+
+	groups, err := s.aggregateRepo.Aggregate(ctx, projectID, groupBy, filter)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("aggregate.groups", len(groups)))
+	return groups, nil
+}
@@ -0,0 +1,52 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAggregateCards(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockRepository(ctrl)
+		svc := NewService(repo)
+
+		done := false
+		groupBy := []card_aggregate.Field{card_aggregate.FieldAssignee, card_aggregate.FieldPriority}
+		filter := card_aggregate.Filter{Done: &done}
+		high := "high"
+		groups := []*card_aggregate.Group{{Keys: []*string{nil, &high}, Labels: []*string{nil, &high}, CardCount: 3, StoryPoints: 8}}
+		repo.EXPECT().Aggregate(gomock.Any(), projectID, groupBy, filter).Return(groups, nil)
+
+		result, err := svc.AggregateCards(ctx, projectID, groupBy, filter)
+		require.NoError(t, err)
+		assert.Equal(t, groups, result)
+	})
+
+	for name, tc := range map[string]struct {
+		groupBy []card_aggregate.Field
+		err     error
+	}{
+		"fail - no fields":       {groupBy: nil, err: ErrNoGroupBy},
+		"fail - unknown field":   {groupBy: []card_aggregate.Field{"type"}, err: ErrInvalidGroupBy},
+		"fail - duplicate field": {groupBy: []card_aggregate.Field{card_aggregate.FieldTag, card_aggregate.FieldTag}, err: ErrDuplicateGroupBy},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewService(mocks.NewMockRepository(ctrl))
+
+			_, err := svc.AggregateCards(ctx, projectID, tc.groupBy, card_aggregate.Filter{})
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
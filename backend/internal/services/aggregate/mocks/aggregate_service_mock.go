@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: aggregate_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=aggregate_service.go -destination=mocks/aggregate_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	card_aggregate "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_aggregate"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AggregateCards mocks base method.
+func (m *MockService) AggregateCards(ctx context.Context, projectID uuid.UUID, groupBy []card_aggregate.Field, filter card_aggregate.Filter) ([]*card_aggregate.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateCards", ctx, projectID, groupBy, filter)
+	ret0, _ := ret[0].([]*card_aggregate.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateCards indicates an expected call of AggregateCards.
+func (mr *MockServiceMockRecorder) AggregateCards(ctx, projectID, groupBy, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateCards", reflect.TypeOf((*MockService)(nil).AggregateCards), ctx, projectID, groupBy, filter)
+}
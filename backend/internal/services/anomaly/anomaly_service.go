@@ -0,0 +1,281 @@
+package anomaly
+
+//go:generate mockgen -source=anomaly_service.go -destination=mocks/anomaly_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScanWindow is how far back each detection run looks for new audit events. It spans
+// several runs, so events recorded late or missed by a failed run are still checked;
+// anomalies already flagged are not flagged again.
+const ScanWindow = time.Hour
+
+// MinMassDeletionThreshold keeps single deletions from being flagged as mass deletions
+const MinMassDeletionThreshold = 2
+
+// MaxMassDeletionWindowMinutes caps the mass deletion window at a day
+const MaxMassDeletionWindowMinutes = 24 * 60
+
+var (
+	ErrInvalidThreshold     = errors.New("mass deletion threshold must be at least 2")
+	ErrInvalidWindow        = errors.New("mass deletion window must be between 1 and 1440 minutes")
+	ErrInvalidBusinessHours = errors.New("business hours must start before they end, within 0 to 24")
+	ErrInvalidTimezone      = errors.New("unknown timezone")
+)
+
+// SettingsInput describes an organization's anomaly detection settings
+type SettingsInput struct {
+	Enabled                   bool
+	MassDeletionThreshold     int
+	MassDeletionWindowMinutes int
+	// BusinessHoursStart and BusinessHoursEnd are hours of the day in Timezone; role
+	// changes outside them, or on weekends, are flagged
+	BusinessHoursStart int
+	BusinessHoursEnd   int
+	Timezone           string
+}
+
+type Service interface {
+	// GetSettings returns the organization's settings, or the defaults when it has none
+	GetSettings(ctx context.Context, orgID uuid.UUID) (*audit_anomaly_setting.AuditAnomalySetting, error)
+	UpdateSettings(ctx context.Context, orgID uuid.UUID, input SettingsInput) (*audit_anomaly_setting.AuditAnomalySetting, error)
+	// GetAnomalies returns the organization's most recently detected anomalies
+	GetAnomalies(ctx context.Context, orgID uuid.UUID, limit int) ([]*audit_anomaly.AuditAnomaly, error)
+	// Detect checks the audit log of recently active organizations for mass deletions and
+	// role changes outside business hours, returning how many anomalies it flagged
+	Detect(ctx context.Context) (int, error)
+}
+
+type service struct {
+	auditRepo   audit.Repository
+	anomalyRepo audit_anomaly.Repository
+	settingRepo audit_anomaly_setting.Repository
+	bus         events.Bus
+	now         func() time.Time
+}
+
+func NewService(auditRepo audit.Repository, anomalyRepo audit_anomaly.Repository, settingRepo audit_anomaly_setting.Repository, bus events.Bus) Service {
+	return &service{
+		auditRepo:   auditRepo,
+		anomalyRepo: anomalyRepo,
+		settingRepo: settingRepo,
+		bus:         bus,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "anomaly.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "anomaly"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetSettings(ctx context.Context, orgID uuid.UUID) (*audit_anomaly_setting.AuditAnomalySetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSettings")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.settingRepo.GetByOrgID(ctx, orgID)
+}
+
+func (s *service) UpdateSettings(ctx context.Context, orgID uuid.UUID, input SettingsInput) (*audit_anomaly_setting.AuditAnomalySetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateSettings")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	if input.MassDeletionThreshold < MinMassDeletionThreshold {
+		return nil, ErrInvalidThreshold
+	}
+	if input.MassDeletionWindowMinutes < 1 || input.MassDeletionWindowMinutes > MaxMassDeletionWindowMinutes {
+		return nil, ErrInvalidWindow
+	}
+	if input.BusinessHoursStart < 0 || input.BusinessHoursEnd > 24 || input.BusinessHoursStart >= input.BusinessHoursEnd {
+		return nil, ErrInvalidBusinessHours
+	}
+	if input.Timezone == "" {
+		input.Timezone = audit_anomaly_setting.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(input.Timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	setting := &audit_anomaly_setting.AuditAnomalySetting{
+		OrganizationID:            orgID,
+		Enabled:                   input.Enabled,
+		MassDeletionThreshold:     input.MassDeletionThreshold,
+		MassDeletionWindowMinutes: input.MassDeletionWindowMinutes,
+		BusinessHoursStart:        input.BusinessHoursStart,
+		BusinessHoursEnd:          input.BusinessHoursEnd,
+		Timezone:                  input.Timezone,
+	}
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (s *service) GetAnomalies(ctx context.Context, orgID uuid.UUID, limit int) ([]*audit_anomaly.AuditAnomaly, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAnomalies")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.anomalyRepo.GetByOrgID(ctx, orgID, limit)
+}
+
+func (s *service) Detect(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Detect")
+	defer span.End()
+
+	now := s.now()
+	orgIDs, err := s.auditRepo.GetActiveOrganizationIDs(ctx, now.Add(-ScanWindow), now)
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for _, orgID := range orgIDs {
+		setting, err := s.settingRepo.GetByOrgID(ctx, orgID)
+		if err != nil {
+			return flagged, err
+		}
+		if !setting.Enabled {
+			continue
+		}
+
+		n, err := s.detectMassDeletions(ctx, setting, now)
+		flagged += n
+		if err != nil {
+			return flagged, err
+		}
+		n, err = s.detectOffHoursRoleChanges(ctx, setting, now)
+		flagged += n
+		if err != nil {
+			return flagged, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("anomaly.flagged", flagged))
+	return flagged, nil
+}
+
+// detectMassDeletions flags actors who deleted at least the threshold within the window.
+// An actor is flagged once per burst: not again while a flagged window still overlaps.
+func (s *service) detectMassDeletions(ctx context.Context, setting *audit_anomaly_setting.AuditAnomalySetting, now time.Time) (int, error) {
+	windowStart := now.Add(-setting.MassDeletionWindow())
+	counts, err := s.auditRepo.CountByActor(ctx, setting.OrganizationID, audit.ActionDeleted, windowStart, now)
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for _, c := range counts {
+		if c.Count < setting.MassDeletionThreshold {
+			continue
+		}
+		exists, err := s.anomalyRepo.ExistsSince(ctx, setting.OrganizationID, audit_anomaly.KindMassDeletion, c.ActorID, windowStart)
+		if err != nil {
+			return flagged, err
+		}
+		if exists {
+			continue
+		}
+
+		actorID := c.ActorID
+		created, err := s.flag(ctx, &audit_anomaly.AuditAnomaly{
+			OrganizationID: setting.OrganizationID,
+			Kind:           audit_anomaly.KindMassDeletion,
+			ActorID:        &actorID,
+			EventCount:     c.Count,
+			WindowStart:    windowStart,
+			WindowEnd:      now,
+			DetectedAt:     now,
+		})
+		if err != nil {
+			return flagged, err
+		}
+		if created {
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+// detectOffHoursRoleChanges flags each role change made on a weekend or outside business
+// hours in the organization's timezone
+func (s *service) detectOffHoursRoleChanges(ctx context.Context, setting *audit_anomaly_setting.AuditAnomalySetting, now time.Time) (int, error) {
+	loc, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	changes, err := s.auditRepo.GetRoleChanges(ctx, setting.OrganizationID, now.Add(-ScanWindow), now)
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	for _, change := range changes {
+		if withinBusinessHours(change.OccurredAt.In(loc), setting.BusinessHoursStart, setting.BusinessHoursEnd) {
+			continue
+		}
+
+		eventID := change.ID
+		created, err := s.flag(ctx, &audit_anomaly.AuditAnomaly{
+			OrganizationID: setting.OrganizationID,
+			Kind:           audit_anomaly.KindOffHoursRoleChange,
+			ActorID:        change.ActorID,
+			AuditEventID:   &eventID,
+			EventCount:     1,
+			WindowStart:    change.OccurredAt,
+			WindowEnd:      change.OccurredAt,
+			DetectedAt:     now,
+		})
+		if err != nil {
+			return flagged, err
+		}
+		if created {
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+// flag records the anomaly and announces it, reporting false when it was already recorded
+func (s *service) flag(ctx context.Context, anomaly *audit_anomaly.AuditAnomaly) (bool, error) {
+	created, err := s.anomalyRepo.CreateIfAbsent(ctx, anomaly)
+	if err != nil || !created {
+		return false, err
+	}
+	err = s.bus.Publish(ctx, events.New(ctx, events.AuditAnomalyDetected, events.AuditAnomalyPayload{
+		AnomalyID:      anomaly.ID,
+		OrganizationID: anomaly.OrganizationID,
+	}))
+	return err == nil, err
+}
+
+// withinBusinessHours reports whether a local time falls on a weekday between the start and
+// end hours
+func withinBusinessHours(t time.Time, start, end int) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return t.Hour() >= start && t.Hour() < end
+}
@@ -0,0 +1,185 @@
+package anomaly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	auditMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	anomalyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	settingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDetect(t *testing.T) {
+	ctx := context.Background()
+	// A Tuesday, at 23:00 in Berlin
+	now := time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)
+	orgID := uuid.New()
+	actorID := uuid.New()
+
+	t.Run("success - flags mass deletions and off-hours role changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		var detected []uuid.UUID
+		bus.Subscribe(events.AuditAnomalyDetected, func(ctx context.Context, event events.Event) error {
+			detected = append(detected, event.Payload.(events.AuditAnomalyPayload).AnomalyID)
+			return nil
+		})
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAnomalyRepo := anomalyMocks.NewMockRepository(ctrl)
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo, mockAnomalyRepo, mockSettingRepo, bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		setting := audit_anomaly_setting.Default(orgID)
+		setting.Timezone = "Europe/Berlin"
+		windowStart := now.Add(-setting.MassDeletionWindow())
+		quiet := uuid.New()
+		daytime := &audit.AuditEvent{ID: uuid.New(), ActorID: &actorID, OccurredAt: now.Add(-10 * time.Hour)}
+		lateNight := &audit.AuditEvent{ID: uuid.New(), ActorID: &actorID, OccurredAt: now.Add(-30 * time.Minute)}
+
+		mockAuditRepo.EXPECT().GetActiveOrganizationIDs(gomock.Any(), now.Add(-ScanWindow), now).Return([]uuid.UUID{orgID}, nil)
+		mockSettingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(setting, nil)
+		mockAuditRepo.EXPECT().CountByActor(gomock.Any(), orgID, audit.ActionDeleted, windowStart, now).Return([]*audit.ActorCount{
+			{ActorID: actorID, Count: 30},
+			{ActorID: quiet, Count: 3},
+		}, nil)
+		mockAnomalyRepo.EXPECT().ExistsSince(gomock.Any(), orgID, audit_anomaly.KindMassDeletion, actorID, windowStart).Return(false, nil)
+		mockAuditRepo.EXPECT().GetRoleChanges(gomock.Any(), orgID, now.Add(-ScanWindow), now).
+			Return([]*audit.AuditEvent{daytime, lateNight}, nil)
+
+		var created []*audit_anomaly.AuditAnomaly
+		mockAnomalyRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, a *audit_anomaly.AuditAnomaly) (bool, error) {
+				a.ID = uuid.New()
+				created = append(created, a)
+				return true, nil
+			})
+
+		flagged, err := svc.Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, flagged)
+		require.Len(t, created, 2)
+		assert.Equal(t, audit_anomaly.KindMassDeletion, created[0].Kind)
+		assert.Equal(t, 30, created[0].EventCount)
+		assert.Equal(t, audit_anomaly.KindOffHoursRoleChange, created[1].Kind)
+		assert.Equal(t, &lateNight.ID, created[1].AuditEventID)
+		assert.Equal(t, []uuid.UUID{created[0].ID, created[1].ID}, detected)
+	})
+
+	t.Run("success - skips flagged bursts and disabled organizations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAnomalyRepo := anomalyMocks.NewMockRepository(ctrl)
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo, mockAnomalyRepo, mockSettingRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		disabledOrgID := uuid.New()
+		disabled := audit_anomaly_setting.Default(disabledOrgID)
+		disabled.Enabled = false
+		setting := audit_anomaly_setting.Default(orgID)
+		windowStart := now.Add(-setting.MassDeletionWindow())
+		recorded := &audit.AuditEvent{ID: uuid.New(), ActorID: &actorID, OccurredAt: now.Add(-time.Minute)}
+
+		mockAuditRepo.EXPECT().GetActiveOrganizationIDs(gomock.Any(), gomock.Any(), now).Return([]uuid.UUID{disabledOrgID, orgID}, nil)
+		mockSettingRepo.EXPECT().GetByOrgID(gomock.Any(), disabledOrgID).Return(disabled, nil)
+		mockSettingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(setting, nil)
+		mockAuditRepo.EXPECT().CountByActor(gomock.Any(), orgID, audit.ActionDeleted, windowStart, now).
+			Return([]*audit.ActorCount{{ActorID: actorID, Count: 40}}, nil)
+		mockAnomalyRepo.EXPECT().ExistsSince(gomock.Any(), orgID, audit_anomaly.KindMassDeletion, actorID, windowStart).Return(true, nil)
+		mockAuditRepo.EXPECT().GetRoleChanges(gomock.Any(), orgID, gomock.Any(), now).Return([]*audit.AuditEvent{recorded}, nil)
+		mockAnomalyRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		flagged, err := svc.Detect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, flagged)
+	})
+}
+
+func TestUpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	valid := SettingsInput{
+		Enabled:                   true,
+		MassDeletionThreshold:     10,
+		MassDeletionWindowMinutes: 5,
+		BusinessHoursStart:        8,
+		BusinessHoursEnd:          18,
+		Timezone:                  "America/New_York",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAnomalyRepo := anomalyMocks.NewMockRepository(ctrl)
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo, mockAnomalyRepo, mockSettingRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockSettingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		setting, err := svc.UpdateSettings(ctx, orgID, valid)
+		require.NoError(t, err)
+		assert.Equal(t, orgID, setting.OrganizationID)
+		assert.Equal(t, 5*time.Minute, setting.MassDeletionWindow())
+		assert.Equal(t, "America/New_York", setting.Timezone)
+	})
+
+	t.Run("fail - invalid settings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAnomalyRepo := anomalyMocks.NewMockRepository(ctrl)
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockAuditRepo, mockAnomalyRepo, mockSettingRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		input := valid
+		input.MassDeletionThreshold = 1
+		_, err := svc.UpdateSettings(ctx, orgID, input)
+		assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+		input = valid
+		input.MassDeletionWindowMinutes = 0
+		_, err = svc.UpdateSettings(ctx, orgID, input)
+		assert.ErrorIs(t, err, ErrInvalidWindow)
+
+		input = valid
+		input.BusinessHoursStart = 18
+		_, err = svc.UpdateSettings(ctx, orgID, input)
+		assert.ErrorIs(t, err, ErrInvalidBusinessHours)
+
+		input = valid
+		input.Timezone = "Mars/Olympus_Mons"
+		_, err = svc.UpdateSettings(ctx, orgID, input)
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+	})
+}
+
+func TestWithinBusinessHours(t *testing.T) {
+	assert.True(t, withinBusinessHours(time.Date(2026, 3, 10, 7, 0, 0, 0, time.UTC), 7, 19))
+	assert.False(t, withinBusinessHours(time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC), 7, 19))
+	assert.False(t, withinBusinessHours(time.Date(2026, 3, 10, 6, 59, 0, 0, time.UTC), 7, 19))
+	// Saturday
+	assert.False(t, withinBusinessHours(time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC), 7, 19))
+}
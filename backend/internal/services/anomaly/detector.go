@@ -0,0 +1,44 @@
+package anomaly
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultDetectionInterval is how often the detector scans the audit log for anomalies
+const DefaultDetectionInterval = 5 * time.Minute
+
+// Detector runs Service.Detect in the background
+type Detector struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewDetector(svc Service, interval time.Duration) *Detector {
+	return &Detector{svc: svc, interval: interval}
+}
+
+// Run scans the audit log every interval until ctx is cancelled
+func (d *Detector) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		flagged, err := d.svc.Detect(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to detect audit anomalies")
+		} else if flagged > 0 {
+			log.Info().Int("flagged", flagged).Msg("Flagged audit anomalies")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
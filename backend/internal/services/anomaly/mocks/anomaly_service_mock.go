@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: anomaly_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=anomaly_service.go -destination=mocks/anomaly_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	audit_anomaly "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	audit_anomaly_setting "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	anomaly "github.com/thatcatdev/kaimu/backend/internal/services/anomaly"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Detect mocks base method.
+func (m *MockService) Detect(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockServiceMockRecorder) Detect(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockService)(nil).Detect), ctx)
+}
+
+// GetAnomalies mocks base method.
+func (m *MockService) GetAnomalies(ctx context.Context, orgID uuid.UUID, limit int) ([]*audit_anomaly.AuditAnomaly, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnomalies", ctx, orgID, limit)
+	ret0, _ := ret[0].([]*audit_anomaly.AuditAnomaly)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnomalies indicates an expected call of GetAnomalies.
+func (mr *MockServiceMockRecorder) GetAnomalies(ctx, orgID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnomalies", reflect.TypeOf((*MockService)(nil).GetAnomalies), ctx, orgID, limit)
+}
+
+// GetSettings mocks base method.
+func (m *MockService) GetSettings(ctx context.Context, orgID uuid.UUID) (*audit_anomaly_setting.AuditAnomalySetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, orgID)
+	ret0, _ := ret[0].(*audit_anomaly_setting.AuditAnomalySetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockServiceMockRecorder) GetSettings(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockService)(nil).GetSettings), ctx, orgID)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockService) UpdateSettings(ctx context.Context, orgID uuid.UUID, input anomaly.SettingsInput) (*audit_anomaly_setting.AuditAnomalySetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, orgID, input)
+	ret0, _ := ret[0].(*audit_anomaly_setting.AuditAnomalySetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockServiceMockRecorder) UpdateSettings(ctx, orgID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockService)(nil).UpdateSettings), ctx, orgID, input)
+}
@@ -0,0 +1,198 @@
+package anomaly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"gorm.io/gorm"
+)
+
+// AnomalyNotifier emails an organization's admins, the members who can manage it, about
+// each anomaly detected in its audit log
+type AnomalyNotifier struct {
+	anomalyRepo   audit_anomaly.Repository
+	settingRepo   audit_anomaly_setting.Repository
+	orgRepo       organization.Repository
+	orgMemberRepo organization_member.Repository
+	userRepo      user.Repository
+	rbacSvc       rbac.Service
+	mailSvc       mail.MailService
+	localeSvc     locale.Service
+	now           func() time.Time
+}
+
+func NewAnomalyNotifier(
+	anomalyRepo audit_anomaly.Repository,
+	settingRepo audit_anomaly_setting.Repository,
+	orgRepo organization.Repository,
+	orgMemberRepo organization_member.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+) *AnomalyNotifier {
+	return &AnomalyNotifier{
+		anomalyRepo:   anomalyRepo,
+		settingRepo:   settingRepo,
+		orgRepo:       orgRepo,
+		orgMemberRepo: orgMemberRepo,
+		userRepo:      userRepo,
+		rbacSvc:       rbacSvc,
+		mailSvc:       mailSvc,
+		localeSvc:     localeSvc,
+		now:           time.Now,
+	}
+}
+
+// Subscribe registers the notification handler on the bus
+func (n *AnomalyNotifier) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.AuditAnomalyDetected, n.handleDetected)
+}
+
+// handleDetected emails the admins once; redelivered events find the anomaly already
+// marked as notified
+func (n *AnomalyNotifier) handleDetected(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.AuditAnomalyPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	anomaly, err := n.anomalyRepo.GetByID(ctx, payload.AnomalyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if anomaly.NotifiedAt != nil {
+		return nil
+	}
+
+	org, err := n.orgRepo.GetByID(ctx, anomaly.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	setting, err := n.settingRepo.GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	actorName, err := n.actorName(ctx, anomaly)
+	if err != nil {
+		return err
+	}
+
+	admins, err := n.admins(ctx, anomaly)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		name := admin.Username
+		if admin.DisplayName != nil {
+			name = *admin.DisplayName
+		}
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForUser(ctx, admin, org.ID))
+		subject := i18n.Tc(ctx, "email.audit_anomaly.subject", map[string]string{"organization": org.Name})
+		err = n.mailSvc.SendMail(ctx, []string{*admin.Email}, subject, "audit_anomaly.mjml", map[string]string{
+			"name":              name,
+			"organization_name": org.Name,
+			"details":           describe(ctx, anomaly, setting, actorName),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send audit anomaly email: %w", err)
+		}
+	}
+
+	_, err = n.anomalyRepo.MarkNotified(ctx, anomaly.ID, n.now())
+	return err
+}
+
+// admins returns the organization's members who can manage it and have an email address
+func (n *AnomalyNotifier) admins(ctx context.Context, anomaly *audit_anomaly.AuditAnomaly) ([]*user.User, error) {
+	members, err := n.orgMemberRepo.GetByOrgID(ctx, anomaly.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var admins []*user.User
+	for _, m := range members {
+		canManage, err := n.rbacSvc.HasOrgPermission(ctx, m.UserID, anomaly.OrganizationID, "org:manage")
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			continue
+		}
+		u, err := n.userRepo.GetByID(ctx, m.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if u.Email != nil {
+			admins = append(admins, u)
+		}
+	}
+	return admins, nil
+}
+
+// actorName returns the display name of the user behind the anomaly, or "" when unknown
+func (n *AnomalyNotifier) actorName(ctx context.Context, anomaly *audit_anomaly.AuditAnomaly) (string, error) {
+	if anomaly.ActorID == nil {
+		return "", nil
+	}
+	actor, err := n.userRepo.GetByID(ctx, *anomaly.ActorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if actor.DisplayName != nil {
+		return *actor.DisplayName, nil
+	}
+	return actor.Username, nil
+}
+
+// describe renders what was detected in the context's locale, with times in the
+// organization's timezone
+func describe(ctx context.Context, anomaly *audit_anomaly.AuditAnomaly, setting *audit_anomaly_setting.AuditAnomalySetting, actorName string) string {
+	if actorName == "" {
+		actorName = i18n.Tc(ctx, "email.audit_anomaly.unknown_actor", nil)
+	}
+	loc, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	switch anomaly.Kind {
+	case audit_anomaly.KindMassDeletion:
+		minutes := int(anomaly.WindowEnd.Sub(anomaly.WindowStart) / time.Minute)
+		return i18n.Tc(ctx, "email.audit_anomaly.mass_deletion", map[string]string{
+			"actor":   actorName,
+			"count":   strconv.Itoa(anomaly.EventCount),
+			"minutes": strconv.Itoa(minutes),
+		})
+	default:
+		return i18n.Tc(ctx, "email.audit_anomaly.off_hours_role_change", map[string]string{
+			"actor": actorName,
+			"time":  anomaly.WindowStart.In(loc).Format("Mon 2006-01-02 15:04 MST"),
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package anomaly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly"
+	anomalyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting"
+	settingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit_anomaly_setting/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAnomalyNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	anomalyRepo := anomalyMocks.NewMockRepository(ctrl)
+	settingRepo := settingMocks.NewMockRepository(ctrl)
+	orgRepo := orgMocks.NewMockRepository(ctrl)
+	orgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	rbacSvc := rbacMocks.NewMockService(ctrl)
+	localeSvc := localeMocks.NewMockService(ctrl)
+	mailSvc := fakes.NewMailService()
+
+	bus := events.NewSyncBus()
+	NewAnomalyNotifier(anomalyRepo, settingRepo, orgRepo, orgMemberRepo, userRepo, rbacSvc, mailSvc, localeSvc).Subscribe(bus)
+	ctx := context.Background()
+
+	org := &organization.Organization{ID: uuid.New(), Name: "Acme"}
+	email := "admin@example.com"
+	admin := &user.User{ID: uuid.New(), Username: "admin", Email: &email}
+	member := &user.User{ID: uuid.New(), Username: "member"}
+	actor := &user.User{ID: uuid.New(), Username: "mallory"}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	anomaly := &audit_anomaly.AuditAnomaly{
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		Kind:           audit_anomaly.KindMassDeletion,
+		ActorID:        &actor.ID,
+		EventCount:     30,
+		WindowStart:    now.Add(-10 * time.Minute),
+		WindowEnd:      now,
+	}
+
+	publish := func() error {
+		return bus.Publish(ctx, events.New(ctx, events.AuditAnomalyDetected, events.AuditAnomalyPayload{
+			AnomalyID:      anomaly.ID,
+			OrganizationID: org.ID,
+		}))
+	}
+
+	t.Run("emails the organization's admins", func(t *testing.T) {
+		anomalyRepo.EXPECT().GetByID(gomock.Any(), anomaly.ID).Return(anomaly, nil)
+		orgRepo.EXPECT().GetByID(gomock.Any(), org.ID).Return(org, nil)
+		settingRepo.EXPECT().GetByOrgID(gomock.Any(), org.ID).Return(audit_anomaly_setting.Default(org.ID), nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), actor.ID).Return(actor, nil)
+		orgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), org.ID).Return([]*organization_member.OrganizationMember{
+			{OrganizationID: org.ID, UserID: admin.ID},
+			{OrganizationID: org.ID, UserID: member.ID},
+		}, nil)
+		rbacSvc.EXPECT().HasOrgPermission(gomock.Any(), admin.ID, org.ID, "org:manage").Return(true, nil)
+		rbacSvc.EXPECT().HasOrgPermission(gomock.Any(), member.ID, org.ID, "org:manage").Return(false, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), admin.ID).Return(admin, nil)
+		localeSvc.EXPECT().ForUser(gomock.Any(), admin, org.ID).Return("en")
+		anomalyRepo.EXPECT().MarkNotified(gomock.Any(), anomaly.ID, gomock.Any()).Return(true, nil)
+
+		require.NoError(t, publish())
+
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, mailSvc.Sent[0].To)
+		assert.Equal(t, "audit_anomaly.mjml", mailSvc.Sent[0].Template)
+		assert.Equal(t, "Acme: unusual activity detected", mailSvc.Sent[0].Subject)
+		assert.Equal(t, "mallory deleted 30 items within 10 minutes.", mailSvc.Sent[0].Values["details"])
+	})
+
+	t.Run("redelivery does not email again", func(t *testing.T) {
+		notified := *anomaly
+		notified.NotifiedAt = &now
+		anomalyRepo.EXPECT().GetByID(gomock.Any(), anomaly.ID).Return(&notified, nil)
+
+		require.NoError(t, publish())
+		assert.Len(t, mailSvc.Sent, 1)
+	})
+}
@@ -0,0 +1,309 @@
+package apitoken
+
+//go:generate mockgen -source=apitoken_service.go -destination=mocks/apitoken_service_mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// TokenLength is the length of the secret part of a token in bytes (before base64
+	// encoding)
+	TokenLength = 32
+	// PersonalTokenPrefix starts every personal access token
+	PersonalTokenPrefix = "kaimu_pat_"
+	// ServiceTokenPrefix starts every service token
+	ServiceTokenPrefix = "kaimu_svc_"
+	// MaxNameLength caps the length of a token's name
+	MaxNameLength = 100
+)
+
+var (
+	ErrNameRequired  = errors.New("an API token needs a name")
+	ErrNameTooLong   = errors.New("an API token name may be at most 100 characters")
+	ErrInvalidScope  = errors.New("invalid API token scope")
+	ErrExpiryInPast  = errors.New("an API token must expire in the future")
+	ErrInvalidToken  = errors.New("invalid, revoked or expired API token")
+	ErrTokenNotFound = errors.New("API token not found")
+)
+
+// serviceAccountRoles is the organization role of a service token's service account, the
+// least that covers the token's scope
+var serviceAccountRoles = map[api_token.Scope]uuid.UUID{
+	api_token.ScopeReadOnly:  role.ViewerRoleID,
+	api_token.ScopeCardWrite: role.MemberRoleID,
+	api_token.ScopeAdmin:     role.AdminRoleID,
+}
+
+// CreateInput describes a new token
+type CreateInput struct {
+	Name  string
+	Scope api_token.Scope
+	// ExpiresAt is nil for a token that doesn't expire
+	ExpiresAt *time.Time
+	CreatedBy uuid.UUID
+}
+
+// IsAPIToken reports whether a bearer credential is an API token rather than a session's
+// access token
+func IsAPIToken(credential string) bool {
+	return strings.HasPrefix(credential, PersonalTokenPrefix) || strings.HasPrefix(credential, ServiceTokenPrefix)
+}
+
+type Service interface {
+	// CreatePersonalToken creates a token acting as its creator. The token is only
+	// returned here; just its hash is stored.
+	CreatePersonalToken(ctx context.Context, input CreateInput) (string, *api_token.APIToken, error)
+	// CreateServiceToken creates a token acting as a new service account, which joins the
+	// organization with the least role covering the scope. The token is only returned
+	// here; just its hash is stored.
+	CreateServiceToken(ctx context.Context, orgID uuid.UUID, input CreateInput) (string, *api_token.APIToken, error)
+	GetToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error)
+	// GetUserTokens returns the user's personal tokens that can still be used
+	GetUserTokens(ctx context.Context, userID uuid.UUID) ([]*api_token.APIToken, error)
+	// GetOrganizationTokens returns the organization's service tokens that can still be used
+	GetOrganizationTokens(ctx context.Context, orgID uuid.UUID) ([]*api_token.APIToken, error)
+	// RevokeToken stops the token from authenticating; a service token's service account
+	// also leaves its organization
+	RevokeToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error)
+	// Authenticate returns the token a credential is, or ErrInvalidToken when it is unknown,
+	// revoked or expired, and records that it was used
+	Authenticate(ctx context.Context, credential string) (*api_token.APIToken, error)
+}
+
+type service struct {
+	tokenRepo     api_token.Repository
+	userRepo      user.Repository
+	orgMemberRepo organization_member.Repository
+	txManager     transaction.Manager
+	now           func() time.Time
+}
+
+func NewService(tokenRepo api_token.Repository, userRepo user.Repository, orgMemberRepo organization_member.Repository, txManager transaction.Manager) Service {
+	return &service{
+		tokenRepo:     tokenRepo,
+		userRepo:      userRepo,
+		orgMemberRepo: orgMemberRepo,
+		txManager:     txManager,
+		now:           time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "apitoken.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "apitoken"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreatePersonalToken(ctx context.Context, input CreateInput) (string, *api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreatePersonalToken")
+	span.SetAttributes(attribute.String("user.id", input.CreatedBy.String()))
+	defer span.End()
+
+	if err := s.validate(&input); err != nil {
+		return "", nil, err
+	}
+	token, err := generateToken(PersonalTokenPrefix)
+	if err != nil {
+		return "", nil, err
+	}
+
+	apiToken := newToken(token, api_token.KindPersonal, input)
+	apiToken.UserID = input.CreatedBy
+	if err := s.tokenRepo.Create(ctx, apiToken); err != nil {
+		return "", nil, err
+	}
+	return token, apiToken, nil
+}
+
+func (s *service) CreateServiceToken(ctx context.Context, orgID uuid.UUID, input CreateInput) (string, *api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateServiceToken")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	if err := s.validate(&input); err != nil {
+		return "", nil, err
+	}
+	token, err := generateToken(ServiceTokenPrefix)
+	if err != nil {
+		return "", nil, err
+	}
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", nil, err
+	}
+
+	apiToken := newToken(token, api_token.KindService, input)
+	apiToken.OrganizationID = &orgID
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The service account has no password or email, so it can only use its token
+		account := &user.User{
+			Username:    "service-" + hex.EncodeToString(suffix),
+			DisplayName: &input.Name,
+		}
+		if err := s.userRepo.Create(ctx, account); err != nil {
+			return err
+		}
+		roleID := serviceAccountRoles[input.Scope]
+		if err := s.orgMemberRepo.Create(ctx, &organization_member.OrganizationMember{
+			OrganizationID: orgID,
+			UserID:         account.ID,
+			Role:           "member",
+			RoleID:         &roleID,
+		}); err != nil {
+			return err
+		}
+		apiToken.UserID = account.ID
+		return s.tokenRepo.Create(ctx, apiToken)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, apiToken, nil
+}
+
+// validate checks a new token's input, trimming its name
+func (s *service) validate(input *CreateInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return ErrNameRequired
+	}
+	if len([]rune(input.Name)) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if !input.Scope.IsValid() {
+		return ErrInvalidScope
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(s.now()) {
+		return ErrExpiryInPast
+	}
+	return nil
+}
+
+func newToken(token string, kind api_token.Kind, input CreateInput) *api_token.APIToken {
+	return &api_token.APIToken{
+		TokenHash: tokenhash.Hash(token),
+		Name:      input.Name,
+		Kind:      kind,
+		Scope:     input.Scope,
+		CreatedBy: &input.CreatedBy,
+		ExpiresAt: input.ExpiresAt,
+	}
+}
+
+func (s *service) GetToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetToken")
+	defer span.End()
+
+	token, err := s.tokenRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTokenNotFound
+	}
+	return token, err
+}
+
+func (s *service) GetUserTokens(ctx context.Context, userID uuid.UUID) ([]*api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetUserTokens")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	return s.tokenRepo.GetPersonalByUserID(ctx, userID, s.now())
+}
+
+func (s *service) GetOrganizationTokens(ctx context.Context, orgID uuid.UUID) ([]*api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOrganizationTokens")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.tokenRepo.GetServiceByOrgID(ctx, orgID, s.now())
+}
+
+func (s *service) RevokeToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "RevokeToken")
+	defer span.End()
+
+	token, err := s.GetToken(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	now := s.now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tokenRepo.Revoke(ctx, id, now); err != nil {
+			return err
+		}
+		if token.Kind != api_token.KindService {
+			return nil
+		}
+		return s.orgMemberRepo.Delete(ctx, *token.OrganizationID, token.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	token.RevokedAt = &now
+	return token, nil
+}
+
+func (s *service) Authenticate(ctx context.Context, credential string) (*api_token.APIToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "Authenticate")
+	defer span.End()
+
+	if !IsAPIToken(credential) {
+		return nil, ErrInvalidToken
+	}
+	token, err := s.tokenRepo.GetByTokenHash(ctx, tokenhash.Hash(credential))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if !token.IsValid(now) {
+		return nil, ErrInvalidToken
+	}
+	// Failing to record the use must not fail the request
+	if err := s.tokenRepo.MarkUsed(ctx, token.ID, now); err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).Str("token_id", token.ID.String()).Msg("Failed to record API token use")
+	}
+	return token, nil
+}
+
+func generateToken(prefix string) (string, error) {
+	bytes := make([]byte, TokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,329 @@
+package apitoken
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	tokenMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope      api_token.Scope
+		permission string
+		want       bool
+	}{
+		{api_token.ScopeReadOnly, "board:view", true},
+		{api_token.ScopeReadOnly, "card:edit", false},
+		{api_token.ScopeCardWrite, "project:view", true},
+		{api_token.ScopeCardWrite, "card:move", true},
+		{api_token.ScopeCardWrite, "board:manage", false},
+		{api_token.ScopeAdmin, "org:manage_roles", true},
+		{api_token.Scope("unknown"), "org:view", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.scope.Allows(tt.permission), "%s %s", tt.scope, tt.permission)
+	}
+}
+
+func TestCreatePersonalToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	t.Run("success - stores only the token's hash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		var stored *api_token.APIToken
+		mockTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *api_token.APIToken) error {
+			stored = token
+			return nil
+		})
+
+		token, apiToken, err := svc.CreatePersonalToken(ctx, CreateInput{Name: "  CI  ", Scope: api_token.ScopeCardWrite, CreatedBy: userID})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, PersonalTokenPrefix))
+		assert.Same(t, stored, apiToken)
+		assert.Equal(t, tokenhash.Hash(token), stored.TokenHash)
+		assert.NotContains(t, stored.TokenHash, token)
+		assert.Equal(t, "CI", stored.Name)
+		assert.Equal(t, api_token.KindPersonal, stored.Kind)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Nil(t, stored.OrganizationID)
+		assert.Nil(t, stored.ExpiresAt)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		past := now.Add(-time.Hour)
+
+		tests := []struct {
+			input CreateInput
+			err   error
+		}{
+			{CreateInput{Name: " ", Scope: api_token.ScopeAdmin}, ErrNameRequired},
+			{CreateInput{Name: strings.Repeat("a", MaxNameLength+1), Scope: api_token.ScopeAdmin}, ErrNameTooLong},
+			{CreateInput{Name: "CI", Scope: "write"}, ErrInvalidScope},
+			{CreateInput{Name: "CI", Scope: api_token.ScopeAdmin, ExpiresAt: &past}, ErrExpiryInPast},
+		}
+		for _, tt := range tests {
+			_, _, err := svc.CreatePersonalToken(ctx, tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		}
+	})
+}
+
+func TestCreateServiceToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID, creatorID, accountID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		scope api_token.Scope
+		role  uuid.UUID
+	}{
+		{api_token.ScopeReadOnly, role.ViewerRoleID},
+		{api_token.ScopeCardWrite, role.MemberRoleID},
+		{api_token.ScopeAdmin, role.AdminRoleID},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.scope), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+			mockUserRepo := userMocks.NewMockRepository(ctrl)
+			mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+			svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+			svc.now = func() time.Time { return now }
+
+			mockUserRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *user.User) error {
+				assert.True(t, strings.HasPrefix(u.Username, "service-"))
+				assert.Equal(t, "Deploy bot", *u.DisplayName)
+				assert.Nil(t, u.PasswordHash)
+				assert.Nil(t, u.Email)
+				u.ID = accountID
+				return nil
+			})
+			mockOrgMemberRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, member *organization_member.OrganizationMember) error {
+				assert.Equal(t, orgID, member.OrganizationID)
+				assert.Equal(t, accountID, member.UserID)
+				assert.Equal(t, tt.role, *member.RoleID)
+				return nil
+			})
+			mockTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			token, apiToken, err := svc.CreateServiceToken(ctx, orgID, CreateInput{Name: "Deploy bot", Scope: tt.scope, CreatedBy: creatorID})
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(token, ServiceTokenPrefix))
+			assert.Equal(t, api_token.KindService, apiToken.Kind)
+			assert.Equal(t, accountID, apiToken.UserID)
+			assert.Equal(t, &orgID, apiToken.OrganizationID)
+			assert.Equal(t, &creatorID, apiToken.CreatedBy)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	credential := PersonalTokenPrefix + "secret"
+
+	t.Run("valid token records its use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		stored := &api_token.APIToken{ID: uuid.New(), Scope: api_token.ScopeReadOnly}
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), tokenhash.Hash(credential)).Return(stored, nil)
+		mockTokenRepo.EXPECT().MarkUsed(gomock.Any(), stored.ID, now).Return(nil)
+
+		token, err := svc.Authenticate(ctx, credential)
+		require.NoError(t, err)
+		assert.Same(t, stored, token)
+	})
+
+	t.Run("failing to record the use still authenticates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		stored := &api_token.APIToken{ID: uuid.New()}
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(stored, nil)
+		mockTokenRepo.EXPECT().MarkUsed(gomock.Any(), stored.ID, now).Return(errors.New("db down"))
+
+		_, err := svc.Authenticate(ctx, credential)
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired or revoked token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		past := now.Add(-time.Minute)
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(&api_token.APIToken{ExpiresAt: &past}, nil)
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(&api_token.APIToken{RevokedAt: &past}, nil)
+
+		_, err := svc.Authenticate(ctx, credential)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, err = svc.Authenticate(ctx, credential)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Authenticate(ctx, credential)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("not an API token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.Authenticate(ctx, "eyJhbGciOiJIUzI1NiJ9.session")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("service token's account leaves the organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		orgID := uuid.New()
+		stored := &api_token.APIToken{ID: uuid.New(), Kind: api_token.KindService, UserID: uuid.New(), OrganizationID: &orgID}
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), stored.ID).Return(stored, nil)
+		mockTokenRepo.EXPECT().Revoke(gomock.Any(), stored.ID, now).Return(nil)
+		mockOrgMemberRepo.EXPECT().Delete(gomock.Any(), orgID, stored.UserID).Return(nil)
+
+		token, err := svc.RevokeToken(ctx, stored.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &now, token.RevokedAt)
+	})
+
+	t.Run("personal token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		stored := &api_token.APIToken{ID: uuid.New(), Kind: api_token.KindPersonal}
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), stored.ID).Return(stored, nil)
+		mockTokenRepo.EXPECT().Revoke(gomock.Any(), stored.ID, now).Return(nil)
+
+		_, err := svc.RevokeToken(ctx, stored.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		revokedAt := now.Add(-time.Hour)
+		stored := &api_token.APIToken{ID: uuid.New(), RevokedAt: &revokedAt}
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), stored.ID).Return(stored, nil)
+
+		token, err := svc.RevokeToken(ctx, stored.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &revokedAt, token.RevokedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockTokenRepo, mockUserRepo, mockOrgMemberRepo, transaction.NewNoopManager()).(*service)
+		svc.now = func() time.Time { return now }
+
+		id := uuid.New()
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.RevokeToken(ctx, id)
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+	})
+}
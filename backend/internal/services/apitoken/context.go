@@ -0,0 +1,21 @@
+package apitoken
+
+import (
+	"context"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+)
+
+type contextKey struct{}
+
+// WithToken records the API token a request authenticated with on its context
+func WithToken(ctx context.Context, token *api_token.APIToken) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// FromContext returns the API token the request authenticated with, or nil when it
+// authenticated otherwise or not at all
+func FromContext(ctx context.Context) *api_token.APIToken {
+	token, _ := ctx.Value(contextKey{}).(*api_token.APIToken)
+	return token
+}
@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: apitoken_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=apitoken_service.go -destination=mocks/apitoken_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	api_token "github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	apitoken "github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockService) Authenticate(ctx context.Context, credential string) (*api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, credential)
+	ret0, _ := ret[0].(*api_token.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockServiceMockRecorder) Authenticate(ctx, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockService)(nil).Authenticate), ctx, credential)
+}
+
+// CreatePersonalToken mocks base method.
+func (m *MockService) CreatePersonalToken(ctx context.Context, input apitoken.CreateInput) (string, *api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalToken", ctx, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*api_token.APIToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePersonalToken indicates an expected call of CreatePersonalToken.
+func (mr *MockServiceMockRecorder) CreatePersonalToken(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalToken", reflect.TypeOf((*MockService)(nil).CreatePersonalToken), ctx, input)
+}
+
+// CreateServiceToken mocks base method.
+func (m *MockService) CreateServiceToken(ctx context.Context, orgID uuid.UUID, input apitoken.CreateInput) (string, *api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceToken", ctx, orgID, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*api_token.APIToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateServiceToken indicates an expected call of CreateServiceToken.
+func (mr *MockServiceMockRecorder) CreateServiceToken(ctx, orgID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceToken", reflect.TypeOf((*MockService)(nil).CreateServiceToken), ctx, orgID, input)
+}
+
+// GetOrganizationTokens mocks base method.
+func (m *MockService) GetOrganizationTokens(ctx context.Context, orgID uuid.UUID) ([]*api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationTokens", ctx, orgID)
+	ret0, _ := ret[0].([]*api_token.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationTokens indicates an expected call of GetOrganizationTokens.
+func (mr *MockServiceMockRecorder) GetOrganizationTokens(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationTokens", reflect.TypeOf((*MockService)(nil).GetOrganizationTokens), ctx, orgID)
+}
+
+// GetToken mocks base method.
+func (m *MockService) GetToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", ctx, id)
+	ret0, _ := ret[0].(*api_token.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockServiceMockRecorder) GetToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockService)(nil).GetToken), ctx, id)
+}
+
+// GetUserTokens mocks base method.
+func (m *MockService) GetUserTokens(ctx context.Context, userID uuid.UUID) ([]*api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTokens", ctx, userID)
+	ret0, _ := ret[0].([]*api_token.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTokens indicates an expected call of GetUserTokens.
+func (mr *MockServiceMockRecorder) GetUserTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokens", reflect.TypeOf((*MockService)(nil).GetUserTokens), ctx, userID)
+}
+
+// RevokeToken mocks base method.
+func (m *MockService) RevokeToken(ctx context.Context, id uuid.UUID) (*api_token.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id)
+	ret0, _ := ret[0].(*api_token.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockServiceMockRecorder) RevokeToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockService)(nil).RevokeToken), ctx, id)
+}
@@ -0,0 +1,155 @@
+package appearance
+
+//go:generate mockgen -source=appearance_service.go -destination=mocks/appearance_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// MaxImageURLLength is the longest background image reference accepted
+const MaxImageURLLength = 2048
+
+var (
+	ErrBoardNotFound   = errors.New("board not found")
+	ErrInvalidColor    = errors.New("background color must look like #RRGGBB")
+	ErrInvalidImageURL = errors.New("background image must be an https URL of at most 2048 characters")
+	ErrUnknownPalette  = errors.New("unknown column palette")
+
+	colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// Appearance is how a board looks; empty fields are left to the client's defaults
+type Appearance struct {
+	BackgroundColor    string
+	BackgroundImageURL string
+	// ColumnPalette is the name of one of the Palettes
+	ColumnPalette string
+}
+
+type Service interface {
+	// SetAppearance replaces the board's appearance. With recolorColumns, the board's columns
+	// are given the palette's colors in board order.
+	SetAppearance(ctx context.Context, boardID uuid.UUID, appearance Appearance, recolorColumns bool) (*board.Board, error)
+}
+
+type service struct {
+	boardRepo  board.Repository
+	columnRepo board_column.Repository
+	txManager  transaction.Manager
+	bus        events.Bus
+}
+
+func NewService(boardRepo board.Repository, columnRepo board_column.Repository, txManager transaction.Manager, bus events.Bus) Service {
+	return &service{
+		boardRepo:  boardRepo,
+		columnRepo: columnRepo,
+		txManager:  txManager,
+		bus:        bus,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "appearance.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "appearance"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) SetAppearance(ctx context.Context, boardID uuid.UUID, appearance Appearance, recolorColumns bool) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetAppearance")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("appearance.column_palette", appearance.ColumnPalette),
+		attribute.Bool("appearance.recolor_columns", recolorColumns),
+	)
+	defer span.End()
+
+	if err := appearance.Validate(); err != nil {
+		return nil, err
+	}
+	appearance.BackgroundColor = strings.ToUpper(appearance.BackgroundColor)
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	b.BackgroundColor = appearance.BackgroundColor
+	b.BackgroundImageURL = appearance.BackgroundImageURL
+	b.ColumnPalette = appearance.ColumnPalette
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Update(ctx, b); err != nil {
+			return err
+		}
+		if p, ok := palette(appearance.ColumnPalette); ok && recolorColumns {
+			columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+			if err != nil {
+				return err
+			}
+			sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+			for i, col := range columns {
+				if color := p.Colors[i%len(p.Colors)]; col.Color != color {
+					col.Color = color
+					if err := s.columnRepo.Update(ctx, col); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.BoardUpdated, events.BoardPayload{
+			BoardID:   b.ID,
+			ProjectID: b.ProjectID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate checks the background color, image reference and palette name. Only https images
+// are accepted, so boards never make clients load mixed content or local files.
+func (a Appearance) Validate() error {
+	if a.BackgroundColor != "" && !colorPattern.MatchString(a.BackgroundColor) {
+		return ErrInvalidColor
+	}
+	if a.BackgroundImageURL != "" {
+		if len(a.BackgroundImageURL) > MaxImageURLLength {
+			return ErrInvalidImageURL
+		}
+		u, err := url.Parse(a.BackgroundImageURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+			return ErrInvalidImageURL
+		}
+	}
+	if a.ColumnPalette != "" {
+		if _, ok := palette(a.ColumnPalette); !ok {
+			return ErrUnknownPalette
+		}
+	}
+	return nil
+}
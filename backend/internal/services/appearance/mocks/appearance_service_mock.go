@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: appearance_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=appearance_service.go -destination=mocks/appearance_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	appearance "github.com/thatcatdev/kaimu/backend/internal/services/appearance"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// SetAppearance mocks base method.
+func (m *MockService) SetAppearance(ctx context.Context, boardID uuid.UUID, arg2 appearance.Appearance, recolorColumns bool) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAppearance", ctx, boardID, arg2, recolorColumns)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAppearance indicates an expected call of SetAppearance.
+func (mr *MockServiceMockRecorder) SetAppearance(ctx, boardID, arg2, recolorColumns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppearance", reflect.TypeOf((*MockService)(nil).SetAppearance), ctx, boardID, arg2, recolorColumns)
+}
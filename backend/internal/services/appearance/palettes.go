@@ -0,0 +1,29 @@
+package appearance
+
+// Palette is a preset of column colors. Applying one colors a board's columns in order,
+// starting over once the colors run out.
+type Palette struct {
+	Name   string
+	Colors []string
+}
+
+// Palettes are the column palette presets boards can use, in the order clients list them
+var Palettes = []Palette{
+	// Default matches the colors of a new board's columns
+	{Name: "default", Colors: []string{"#6B7280", "#3B82F6", "#F59E0B", "#10B981", "#8B5CF6", "#EF4444", "#EC4899", "#14B8A6"}},
+	{Name: "pastel", Colors: []string{"#CBD5E1", "#BFDBFE", "#FDE68A", "#BBF7D0", "#DDD6FE", "#FECACA", "#FBCFE8", "#99F6E4"}},
+	{Name: "vivid", Colors: []string{"#64748B", "#2563EB", "#EA580C", "#16A34A", "#7C3AED", "#DC2626", "#DB2777", "#0D9488"}},
+	{Name: "ocean", Colors: []string{"#0C4A6E", "#0369A1", "#0284C7", "#0EA5E9", "#38BDF8", "#06B6D4", "#0891B2", "#155E75"}},
+	{Name: "forest", Colors: []string{"#365314", "#3F6212", "#4D7C0F", "#65A30D", "#15803D", "#166534", "#A16207", "#78350F"}},
+	{Name: "monochrome", Colors: []string{"#111827", "#374151", "#4B5563", "#6B7280", "#9CA3AF", "#D1D5DB"}},
+}
+
+// palette returns the preset with the given name
+func palette(name string) (Palette, bool) {
+	for _, p := range Palettes {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Palette{}, false
+}
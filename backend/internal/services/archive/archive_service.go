@@ -0,0 +1,255 @@
+package archive
+
+//go:generate mockgen -source=archive_service.go -destination=mocks/archive_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// MaxAutoArchiveDays caps how long a board may keep cards in done columns before archiving
+const MaxAutoArchiveDays = 365
+
+var (
+	ErrBoardNotFound          = errors.New("board not found")
+	ErrCardNotFound           = errors.New("card not found")
+	ErrCardNotArchived        = errors.New("card is not archived")
+	ErrInvalidAutoArchiveDays = fmt.Errorf("auto-archive days must be between 1 and %d", MaxAutoArchiveDays)
+)
+
+type Service interface {
+	// SetAutoArchiveDays makes the board archive cards that stayed in a done column for the
+	// given number of days; nil stops archiving
+	SetAutoArchiveDays(ctx context.Context, boardID uuid.UUID, days *int) (*board.Board, error)
+	GetArchivedCards(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error)
+	// UnarchiveCard puts an archived card back in its column
+	UnarchiveCard(ctx context.Context, cardID uuid.UUID) (*card.Card, error)
+	// ArchiveDue archives the cards that stayed in a done column of an auto-archiving board
+	// for longer than the board allows, and returns the number archived. Every board with
+	// archived cards gets a board.cards_archived event for its summary. Running it
+	// concurrently is safe.
+	ArchiveDue(ctx context.Context) (int, error)
+}
+
+type service struct {
+	boardRepo  board.Repository
+	columnRepo board_column.Repository
+	cardRepo   card.Repository
+	runRepo    auto_archive_run.Repository
+	txManager  transaction.Manager
+	bus        events.Bus
+	now        func() time.Time
+}
+
+func NewService(
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+	runRepo auto_archive_run.Repository,
+	txManager transaction.Manager,
+	bus events.Bus,
+) Service {
+	return &service{
+		boardRepo:  boardRepo,
+		columnRepo: columnRepo,
+		cardRepo:   cardRepo,
+		runRepo:    runRepo,
+		txManager:  txManager,
+		bus:        bus,
+		now:        time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "archive.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "archive"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) SetAutoArchiveDays(ctx context.Context, boardID uuid.UUID, days *int) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetAutoArchiveDays")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	if days != nil && (*days < 1 || *days > MaxAutoArchiveDays) {
+		return nil, ErrInvalidAutoArchiveDays
+	}
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	b.AutoArchiveDays = days
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Update(ctx, b); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.BoardUpdated, events.BoardPayload{
+			BoardID:   b.ID,
+			ProjectID: b.ProjectID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *service) GetArchivedCards(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetArchivedCards")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.cardRepo.GetArchivedByBoardID(ctx, boardID)
+}
+
+func (s *service) UnarchiveCard(ctx context.Context, cardID uuid.UUID) (*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "UnarchiveCard")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	if c.ArchivedAt == nil {
+		return nil, ErrCardNotArchived
+	}
+
+	now := s.now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Unarchive(ctx, c.ID, now); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.CardUpdated, events.CardPayload{
+			CardID:   c.ID,
+			BoardID:  c.BoardID,
+			ColumnID: c.ColumnID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.ArchivedAt = nil
+	c.ColumnEnteredAt = now
+	return c, nil
+}
+
+func (s *service) ArchiveDue(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "ArchiveDue")
+	defer span.End()
+
+	boards, err := s.boardRepo.GetAutoArchiving(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	archived := 0
+	for _, b := range boards {
+		n, err := s.archiveBoard(ctx, b, now)
+		archived += n
+		if err != nil {
+			return archived, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("archive.archived", archived))
+	return archived, nil
+}
+
+// archiveBoard archives the cards that entered a done column of the board more than its
+// auto-archive days before now
+func (s *service) archiveBoard(ctx context.Context, b *board.Board, now time.Time) (int, error) {
+	columns, err := s.columnRepo.GetByBoardID(ctx, b.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.AddDate(0, 0, -*b.AutoArchiveDays)
+	var due []*card.Card
+	for _, col := range columns {
+		if !col.IsDone {
+			continue
+		}
+		cards, err := s.cardRepo.GetByColumnEnteredBefore(ctx, col.ID, cutoff)
+		if err != nil {
+			return 0, err
+		}
+		due = append(due, cards...)
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, len(due))
+	for i, c := range due {
+		ids[i] = c.ID
+	}
+
+	var archived int64
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		archived, err = s.cardRepo.Archive(ctx, ids, now)
+		// Another run archived them first
+		if err != nil || archived == 0 {
+			return err
+		}
+
+		for _, c := range due {
+			if err := s.bus.Publish(ctx, events.New(ctx, events.CardArchived, events.CardPayload{
+				CardID:   c.ID,
+				BoardID:  c.BoardID,
+				ColumnID: c.ColumnID,
+			})); err != nil {
+				return err
+			}
+		}
+
+		run := &auto_archive_run.AutoArchiveRun{
+			BoardID:    b.ID,
+			CardCount:  int(archived),
+			ArchivedAt: now,
+		}
+		if err := s.runRepo.Create(ctx, run); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.BoardCardsArchived, events.CardsArchivedPayload{
+			RunID:     run.ID,
+			BoardID:   b.ID,
+			CardCount: run.CardCount,
+		}))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(archived), nil
+}
@@ -0,0 +1,245 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run"
+	runMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// recordEvents subscribes to names on bus and returns the events published to them
+func recordEvents(bus events.Bus, names ...events.Name) *[]events.Event {
+	var published []events.Event
+	for _, name := range names {
+		bus.Subscribe(name, func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		})
+	}
+	return &published
+}
+
+func TestArchiveDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	days := 14
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New(), AutoArchiveDays: &days}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, IsDone: true}
+	stale := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: done.ID}
+
+	t.Run("success - archives cards left in done columns and records the run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.CardArchived, events.BoardCardsArchived)
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockBoardRepo.EXPECT().GetAutoArchiving(gomock.Any()).Return([]*board.Board{b}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{todo, done}, nil)
+		mockCardRepo.EXPECT().GetByColumnEnteredBefore(gomock.Any(), done.ID, now.AddDate(0, 0, -14)).Return([]*card.Card{stale}, nil)
+		mockCardRepo.EXPECT().Archive(gomock.Any(), []uuid.UUID{stale.ID}, now).Return(int64(1), nil)
+		var run *auto_archive_run.AutoArchiveRun
+		mockRunRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, r *auto_archive_run.AutoArchiveRun) error {
+			r.ID = uuid.New()
+			run = r
+			return nil
+		})
+
+		archived, err := svc.ArchiveDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, archived)
+		assert.Equal(t, 1, run.CardCount)
+		assert.Equal(t, now, run.ArchivedAt)
+
+		require.Len(t, *published, 2)
+		assert.Equal(t, events.CardArchived, (*published)[0].Name)
+		assert.Equal(t, stale.ID, (*published)[0].Payload.(events.CardPayload).CardID)
+		assert.Equal(t, events.CardsArchivedPayload{RunID: run.ID, BoardID: b.ID, CardCount: 1}, (*published)[1].Payload)
+	})
+
+	t.Run("success - no run when another archiver got there first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.CardArchived, events.BoardCardsArchived)
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockBoardRepo.EXPECT().GetAutoArchiving(gomock.Any()).Return([]*board.Board{b}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{done}, nil)
+		mockCardRepo.EXPECT().GetByColumnEnteredBefore(gomock.Any(), done.ID, gomock.Any()).Return([]*card.Card{stale}, nil)
+		mockCardRepo.EXPECT().Archive(gomock.Any(), []uuid.UUID{stale.ID}, now).Return(int64(0), nil)
+
+		archived, err := svc.ArchiveDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, archived)
+		assert.Empty(t, *published)
+	})
+
+	t.Run("success - nothing due", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockBoardRepo.EXPECT().GetAutoArchiving(gomock.Any()).Return([]*board.Board{b}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{todo, done}, nil)
+		mockCardRepo.EXPECT().GetByColumnEnteredBefore(gomock.Any(), done.ID, gomock.Any()).Return(nil, nil)
+
+		archived, err := svc.ArchiveDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, archived)
+	})
+}
+
+func TestSetAutoArchiveDays(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.BoardUpdated)
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		b := &board.Board{ID: uuid.New()}
+		days := 30
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockBoardRepo.EXPECT().Update(gomock.Any(), b).Return(nil)
+
+		updated, err := svc.SetAutoArchiveDays(ctx, b.ID, &days)
+		require.NoError(t, err)
+		assert.Equal(t, &days, updated.AutoArchiveDays)
+		assert.Len(t, *published, 1)
+	})
+
+	t.Run("fail - out of range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		for _, days := range []int{0, MaxAutoArchiveDays + 1} {
+			_, err := svc.SetAutoArchiveDays(ctx, uuid.New(), &days)
+			assert.ErrorIs(t, err, ErrInvalidAutoArchiveDays)
+		}
+	})
+
+	t.Run("fail - board not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		id := uuid.New()
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetAutoArchiveDays(ctx, id, nil)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+}
+
+func TestUnarchiveCard(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success - restarts the card's stay in its column", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.CardUpdated)
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		archivedAt := now.AddDate(0, 0, -1)
+		c := &card.Card{ID: uuid.New(), BoardID: uuid.New(), ArchivedAt: &archivedAt}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockCardRepo.EXPECT().Unarchive(gomock.Any(), c.ID, now).Return(nil)
+
+		restored, err := svc.UnarchiveCard(ctx, c.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.ArchivedAt)
+		assert.Equal(t, now, restored.ColumnEnteredAt)
+		assert.Len(t, *published, 1)
+	})
+
+	t.Run("fail - card is not archived", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockRunRepo := runMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockRunRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		c := &card.Card{ID: uuid.New()}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+
+		_, err := svc.UnarchiveCard(ctx, c.ID)
+		assert.ErrorIs(t, err, ErrCardNotArchived)
+	})
+}
@@ -0,0 +1,44 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultArchiveInterval is how often the archiver looks for cards to archive
+const DefaultArchiveInterval = time.Hour
+
+// Archiver runs Service.ArchiveDue in the background
+type Archiver struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewArchiver(svc Service, interval time.Duration) *Archiver {
+	return &Archiver{svc: svc, interval: interval}
+}
+
+// Run archives due cards every interval until ctx is cancelled
+func (a *Archiver) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		archived, err := a.svc.ArchiveDue(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to auto-archive cards")
+		} else if archived > 0 {
+			log.Info().Int("archived", archived).Msg("Auto-archived cards")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: archive_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=archive_service.go -destination=mocks/archive_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ArchiveDue mocks base method.
+func (m *MockService) ArchiveDue(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveDue", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveDue indicates an expected call of ArchiveDue.
+func (mr *MockServiceMockRecorder) ArchiveDue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveDue", reflect.TypeOf((*MockService)(nil).ArchiveDue), ctx)
+}
+
+// GetArchivedCards mocks base method.
+func (m *MockService) GetArchivedCards(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedCards", ctx, boardID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedCards indicates an expected call of GetArchivedCards.
+func (mr *MockServiceMockRecorder) GetArchivedCards(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedCards", reflect.TypeOf((*MockService)(nil).GetArchivedCards), ctx, boardID)
+}
+
+// SetAutoArchiveDays mocks base method.
+func (m *MockService) SetAutoArchiveDays(ctx context.Context, boardID uuid.UUID, days *int) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoArchiveDays", ctx, boardID, days)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAutoArchiveDays indicates an expected call of SetAutoArchiveDays.
+func (mr *MockServiceMockRecorder) SetAutoArchiveDays(ctx, boardID, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoArchiveDays", reflect.TypeOf((*MockService)(nil).SetAutoArchiveDays), ctx, boardID, days)
+}
+
+// UnarchiveCard mocks base method.
+func (m *MockService) UnarchiveCard(ctx context.Context, cardID uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveCard", ctx, cardID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveCard indicates an expected call of UnarchiveCard.
+func (mr *MockServiceMockRecorder) UnarchiveCard(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveCard", reflect.TypeOf((*MockService)(nil).UnarchiveCard), ctx, cardID)
+}
@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"gorm.io/gorm"
+)
+
+// SummaryNotifier emails the creator of a board how many cards an auto-archival run took
+// off it
+type SummaryNotifier struct {
+	runRepo   auto_archive_run.Repository
+	boardRepo board.Repository
+	userRepo  user.Repository
+	mailSvc   mail.MailService
+	localeSvc locale.Service
+	now       func() time.Time
+}
+
+func NewSummaryNotifier(runRepo auto_archive_run.Repository, boardRepo board.Repository, userRepo user.Repository, mailSvc mail.MailService, localeSvc locale.Service) *SummaryNotifier {
+	return &SummaryNotifier{
+		runRepo:   runRepo,
+		boardRepo: boardRepo,
+		userRepo:  userRepo,
+		mailSvc:   mailSvc,
+		localeSvc: localeSvc,
+		now:       time.Now,
+	}
+}
+
+// Subscribe registers the summary handler on the bus
+func (n *SummaryNotifier) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.BoardCardsArchived, n.handleArchived)
+}
+
+// handleArchived sends the run's summary once; redelivered events find the run already
+// marked as notified
+func (n *SummaryNotifier) handleArchived(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardsArchivedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	run, err := n.runRepo.GetByID(ctx, payload.RunID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if run.NotifiedAt != nil {
+		return nil
+	}
+
+	b, err := n.boardRepo.GetByID(ctx, run.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	owner, err := n.owner(ctx, b)
+	if err != nil {
+		return err
+	}
+	if owner != nil && owner.Email != nil {
+		name := owner.Username
+		if owner.DisplayName != nil {
+			name = *owner.DisplayName
+		}
+		count := strconv.Itoa(run.CardCount)
+
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, owner, b.ProjectID))
+		duration := ""
+		if b.AutoArchiveDays != nil {
+			duration = formatDays(ctx, *b.AutoArchiveDays)
+		}
+		subject := i18n.Tc(ctx, "email.auto_archive.subject", map[string]string{"count": count, "board": b.Name})
+		err = n.mailSvc.SendMail(ctx, []string{*owner.Email}, subject, "auto_archive.mjml", map[string]string{
+			"name":       name,
+			"board_name": b.Name,
+			"card_count": count,
+			"duration":   duration,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send auto-archive summary email: %w", err)
+		}
+	}
+
+	_, err = n.runRepo.MarkNotified(ctx, run.ID, n.now())
+	return err
+}
+
+// owner returns the board's creator, or nil when it has none
+func (n *SummaryNotifier) owner(ctx context.Context, b *board.Board) (*user.User, error) {
+	if b.CreatedBy == nil {
+		return nil, nil
+	}
+	owner, err := n.userRepo.GetByID(ctx, *b.CreatedBy)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return owner, nil
+}
+
+// formatDays renders a number of days, e.g. "14 days", in the context's locale
+func formatDays(ctx context.Context, days int) string {
+	if days == 1 {
+		return i18n.Tc(ctx, "duration.day", nil)
+	}
+	return i18n.Tc(ctx, "duration.days", map[string]string{"count": strconv.Itoa(days)})
+}
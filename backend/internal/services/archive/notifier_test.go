@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run"
+	runMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/auto_archive_run/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSummaryNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	runRepo := runMocks.NewMockRepository(ctrl)
+	boardRepo := boardMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	localeSvc := localeMocks.NewMockService(ctrl)
+	mailSvc := fakes.NewMailService()
+
+	bus := events.NewSyncBus()
+	NewSummaryNotifier(runRepo, boardRepo, userRepo, mailSvc, localeSvc).Subscribe(bus)
+	ctx := context.Background()
+
+	email := "owner@example.com"
+	owner := &user.User{ID: uuid.New(), Username: "owner", Email: &email}
+	days := 14
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New(), Name: "Platform", AutoArchiveDays: &days, CreatedBy: &owner.ID}
+	run := &auto_archive_run.AutoArchiveRun{ID: uuid.New(), BoardID: b.ID, CardCount: 7}
+
+	publish := func() error {
+		return bus.Publish(ctx, events.New(ctx, events.BoardCardsArchived, events.CardsArchivedPayload{
+			RunID:     run.ID,
+			BoardID:   b.ID,
+			CardCount: run.CardCount,
+		}))
+	}
+
+	t.Run("emails the board's creator", func(t *testing.T) {
+		runRepo.EXPECT().GetByID(gomock.Any(), run.ID).Return(run, nil)
+		boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(owner, nil)
+		localeSvc.EXPECT().ForProject(gomock.Any(), owner, b.ProjectID).Return("en")
+		runRepo.EXPECT().MarkNotified(gomock.Any(), run.ID, gomock.Any()).Return(true, nil)
+
+		require.NoError(t, publish())
+
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, mailSvc.Sent[0].To)
+		assert.Equal(t, "auto_archive.mjml", mailSvc.Sent[0].Template)
+		assert.Equal(t, "7", mailSvc.Sent[0].Values["card_count"])
+		assert.Equal(t, "14 days", mailSvc.Sent[0].Values["duration"])
+		assert.Equal(t, "Platform: 7 archived", mailSvc.Sent[0].Subject)
+	})
+
+	t.Run("redelivery does not email again", func(t *testing.T) {
+		notifiedAt := time.Now()
+		notified := *run
+		notified.NotifiedAt = &notifiedAt
+		runRepo.EXPECT().GetByID(gomock.Any(), run.ID).Return(&notified, nil)
+
+		require.NoError(t, publish())
+		assert.Len(t, mailSvc.Sent, 1)
+	})
+}
+
+func TestFormatDays(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "1 day", formatDays(ctx, 1))
+	assert.Equal(t, "30 days", formatDays(ctx, 30))
+	assert.Equal(t, "30 días", formatDays(i18n.WithLocale(ctx, "es"), 30))
+}
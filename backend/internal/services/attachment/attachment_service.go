@@ -0,0 +1,475 @@
+package attachment
+
+//go:generate mockgen -source=attachment_service.go -destination=mocks/attachment_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStorageDisabled      = errors.New("attachments are not enabled on this server")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrCardNotFound         = errors.New("card not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrFilenameRequired     = errors.New("attachment filename is required")
+	ErrInvalidSize          = errors.New("attachment size must be positive")
+	ErrFileTooLarge         = errors.New("file is larger than the organization allows")
+	ErrQuotaExceeded        = errors.New("the organization's attachment storage is full")
+	ErrUploadIncomplete     = errors.New("the file has not been uploaded")
+	ErrInvalidLimit         = errors.New("attachment limits must be positive")
+)
+
+const (
+	// PendingUploadTTL is how long an upload may go unconfirmed before the sweeper drops it
+	PendingUploadTTL = time.Hour
+	// maxFilenameLength and maxContentTypeLength match the columns
+	maxFilenameLength    = 255
+	maxContentTypeLength = 255
+	// sweepBatchSize is how many abandoned attachments a sweep deletes per query
+	sweepBatchSize  = 100
+	fallbackContent = "application/octet-stream"
+)
+
+// Limits are the configured defaults for the size of one file and of all of an
+// organization's files, and how long presigned URLs are valid
+type Limits struct {
+	MaxBytes int64
+	// QuotaBytes is 0 when organizations may store any amount
+	QuotaBytes int64
+	URLExpiry  time.Duration
+}
+
+// LimitsFromConfig reads the limits from the storage config
+func LimitsFromConfig(cfg config.StorageConfig) Limits {
+	return Limits{
+		MaxBytes:   cfg.MaxAttachmentBytes,
+		QuotaBytes: cfg.OrgQuotaBytes,
+		URLExpiry:  time.Duration(cfg.PresignExpiryMinutes) * time.Minute,
+	}
+}
+
+// Upload is a started upload: the client PUTs the file to URL with Headers before
+// ExpiresAt, then confirms it with CompleteUpload
+type Upload struct {
+	Attachment *attachment.Attachment
+	URL        string
+	Headers    map[string]string
+	ExpiresAt  time.Time
+}
+
+type Service interface {
+	// RequestUpload records a pending attachment on the card and returns where to upload it,
+	// checking the file against the organization's size limit and quota
+	RequestUpload(ctx context.Context, cardID, userID uuid.UUID, filename, contentType string, size int64) (*Upload, error)
+	// CompleteUpload confirms the file reached the store, showing it on the card
+	CompleteUpload(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error)
+	GetAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error)
+	// GetAttachments returns the card's uploaded attachments, oldest first
+	GetAttachments(ctx context.Context, cardID uuid.UUID) ([]*attachment.Attachment, error)
+	// DownloadURL returns a presigned URL for downloading the attachment
+	DownloadURL(ctx context.Context, a *attachment.Attachment) (string, error)
+	// DeleteAttachment deletes the attachment and its file
+	DeleteAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error)
+	// GetBoard returns the board of the attachment's card
+	GetBoard(ctx context.Context, a *attachment.Attachment) (*board.Board, error)
+
+	// SetOrganizationLimits overrides the configured limits for an organization; nil
+	// restores the configured one
+	SetOrganizationLimits(ctx context.Context, orgID uuid.UUID, maxBytes, quotaBytes *int64) (*organization.Organization, error)
+
+	// Sweep deletes the files and records of attachments whose card was deleted and of
+	// uploads never confirmed, returning how many it removed. Attachments of organizations
+	// under legal hold are kept.
+	Sweep(ctx context.Context) (int, error)
+}
+
+type service struct {
+	attachmentRepo attachment.Repository
+	cardRepo       card.Repository
+	boardRepo      board.Repository
+	projectRepo    project.Repository
+	orgRepo        organization.Repository
+	holdSvc        legalhold.Service
+	store          storage.Store
+	limits         Limits
+	now            func() time.Time
+}
+
+// NewService returns the attachments service; store is nil when no object store is
+// configured, which turns uploads off
+func NewService(
+	attachmentRepo attachment.Repository,
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	orgRepo organization.Repository,
+	holdSvc legalhold.Service,
+	store storage.Store,
+	limits Limits,
+) Service {
+	return &service{
+		attachmentRepo: attachmentRepo,
+		cardRepo:       cardRepo,
+		boardRepo:      boardRepo,
+		projectRepo:    projectRepo,
+		orgRepo:        orgRepo,
+		holdSvc:        holdSvc,
+		store:          store,
+		limits:         limits,
+		now:            time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "attachment.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "attachment"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) RequestUpload(ctx context.Context, cardID, userID uuid.UUID, filename, contentType string, size int64) (*Upload, error) {
+	ctx, span := s.startServiceSpan(ctx, "RequestUpload")
+	span.SetAttributes(
+		attribute.String("card.id", cardID.String()),
+		attribute.String("user.id", userID.String()),
+		attribute.Int64("size", size),
+	)
+	defer span.End()
+
+	if s.store == nil {
+		return nil, ErrStorageDisabled
+	}
+	filename = cleanFilename(filename)
+	if filename == "" {
+		return nil, ErrFilenameRequired
+	}
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	org, err := s.cardOrganization(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBytes, quotaBytes := s.orgLimits(org)
+	if size > maxBytes {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrFileTooLarge, maxBytes)
+	}
+	if quotaBytes > 0 {
+		used, err := s.attachmentRepo.SumBytesByOrganization(ctx, org.ID)
+		if err != nil {
+			return nil, err
+		}
+		if used+size > quotaBytes {
+			return nil, fmt.Errorf("%w: %d of %d bytes used", ErrQuotaExceeded, used, quotaBytes)
+		}
+	}
+
+	id := uuid.New()
+	a := &attachment.Attachment{
+		ID:             id,
+		CardID:         &c.ID,
+		OrganizationID: &org.ID,
+		ObjectKey:      fmt.Sprintf("attachments/%s/%s/%s", org.ID, c.ID, id),
+		Filename:       filename,
+		ContentType:    cleanContentType(contentType),
+		SizeBytes:      size,
+		UploadedBy:     &userID,
+	}
+	if err := s.attachmentRepo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().Add(s.limits.URLExpiry)
+	url, headers, err := s.store.PresignPut(ctx, a.ObjectKey, a.ContentType, a.SizeBytes, s.limits.URLExpiry)
+	if err != nil {
+		return nil, err
+	}
+	return &Upload{Attachment: a, URL: url, Headers: headers, ExpiresAt: expiresAt}, nil
+}
+
+func (s *service) CompleteUpload(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	ctx, span := s.startServiceSpan(ctx, "CompleteUpload")
+	span.SetAttributes(attribute.String("attachment.id", id.String()))
+	defer span.End()
+
+	if s.store == nil {
+		return nil, ErrStorageDisabled
+	}
+	a, err := s.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.UploadedAt != nil {
+		return a, nil
+	}
+
+	size, err := s.store.Size(ctx, a.ObjectKey)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, ErrUploadIncomplete
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size != a.SizeBytes {
+		return nil, fmt.Errorf("%w: %d of %d bytes stored", ErrUploadIncomplete, size, a.SizeBytes)
+	}
+
+	now := s.now()
+	a.UploadedAt = &now
+	if err := s.attachmentRepo.Update(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *service) GetAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAttachment")
+	span.SetAttributes(attribute.String("attachment.id", id.String()))
+	defer span.End()
+
+	a, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	// Attachments of deleted cards only wait for the sweeper
+	if a.CardID == nil {
+		return nil, ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+func (s *service) GetAttachments(ctx context.Context, cardID uuid.UUID) ([]*attachment.Attachment, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAttachments")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	return s.attachmentRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *service) DownloadURL(ctx context.Context, a *attachment.Attachment) (string, error) {
+	ctx, span := s.startServiceSpan(ctx, "DownloadURL")
+	span.SetAttributes(attribute.String("attachment.id", a.ID.String()))
+	defer span.End()
+
+	if s.store == nil {
+		return "", ErrStorageDisabled
+	}
+	return s.store.PresignGet(ctx, a.ObjectKey, a.Filename, s.limits.URLExpiry)
+}
+
+func (s *service) DeleteAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	ctx, span := s.startServiceSpan(ctx, "DeleteAttachment")
+	span.SetAttributes(attribute.String("attachment.id", id.String()))
+	defer span.End()
+
+	a, err := s.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.remove(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *service) GetBoard(ctx context.Context, a *attachment.Attachment) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBoard")
+	span.SetAttributes(attribute.String("attachment.id", a.ID.String()))
+	defer span.End()
+
+	if a.CardID == nil {
+		return nil, ErrCardNotFound
+	}
+	c, err := s.cardRepo.GetByID(ctx, *a.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	return s.boardRepo.GetByID(ctx, c.BoardID)
+}
+
+func (s *service) SetOrganizationLimits(ctx context.Context, orgID uuid.UUID, maxBytes, quotaBytes *int64) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetOrganizationLimits")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	if (maxBytes != nil && *maxBytes <= 0) || (quotaBytes != nil && *quotaBytes <= 0) {
+		return nil, ErrInvalidLimit
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	org.AttachmentMaxBytes = maxBytes
+	org.AttachmentQuotaBytes = quotaBytes
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *service) Sweep(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Sweep")
+	defer span.End()
+
+	held := make(map[uuid.UUID]bool)
+	removed := 0
+	for {
+		abandoned, err := s.attachmentRepo.GetAbandoned(ctx, s.now().Add(-PendingUploadTTL), sweepBatchSize)
+		if err != nil {
+			return removed, err
+		}
+
+		removedInBatch := 0
+		for _, a := range abandoned {
+			if a.OrganizationID != nil {
+				isHeld, ok := held[*a.OrganizationID]
+				if !ok {
+					err := s.holdSvc.CheckOrganization(ctx, *a.OrganizationID)
+					if err != nil && !errors.Is(err, legalhold.ErrUnderLegalHold) {
+						return removed, err
+					}
+					isHeld = err != nil
+					held[*a.OrganizationID] = isHeld
+				}
+				if isHeld {
+					continue
+				}
+			}
+			if err := s.remove(ctx, a); err != nil {
+				return removed, err
+			}
+			removedInBatch++
+		}
+		removed += removedInBatch
+
+		// Held attachments come back in every batch, so stop once a batch removes nothing
+		if len(abandoned) < sweepBatchSize || removedInBatch == 0 {
+			return removed, nil
+		}
+	}
+}
+
+// remove deletes the attachment's file before its record, so a failed delete leaves the
+// record for a retry rather than an orphaned file
+func (s *service) remove(ctx context.Context, a *attachment.Attachment) error {
+	if s.store != nil {
+		if err := s.store.Delete(ctx, a.ObjectKey); err != nil {
+			return err
+		}
+	}
+	return s.attachmentRepo.Delete(ctx, a.ID)
+}
+
+// cardOrganization returns the organization owning the card's project
+func (s *service) cardOrganization(ctx context.Context, c *card.Card) (*organization.Organization, error) {
+	b, err := s.boardRepo.GetByID(ctx, c.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, p.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// orgLimits returns the organization's file size limit and quota, falling back to the
+// configured ones
+func (s *service) orgLimits(org *organization.Organization) (int64, int64) {
+	maxBytes, quotaBytes := s.limits.MaxBytes, s.limits.QuotaBytes
+	if org.AttachmentMaxBytes != nil {
+		maxBytes = *org.AttachmentMaxBytes
+	}
+	if org.AttachmentQuotaBytes != nil {
+		quotaBytes = *org.AttachmentQuotaBytes
+	}
+	return maxBytes, quotaBytes
+}
+
+// cleanFilename keeps the base name of a client-supplied file name, without control
+// characters, cut to the column's length
+func cleanFilename(filename string) string {
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = strings.TrimSpace(path.Base(strings.ReplaceAll(filename, "\\", "/")))
+	if filename == "." || filename == "/" {
+		return ""
+	}
+	if runes := []rune(filename); len(runes) > maxFilenameLength {
+		filename = string(runes[:maxFilenameLength])
+	}
+	return filename
+}
+
+// cleanContentType returns the media type without parameters other than the charset, or
+// a generic binary type when it doesn't parse
+func cleanContentType(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || len(mediaType) > maxContentTypeLength {
+		return fallbackContent
+	}
+	if charset, ok := params["charset"]; ok {
+		if withCharset := mime.FormatMediaType(mediaType, map[string]string{"charset": charset}); withCharset != "" && len(withCharset) <= maxContentTypeLength {
+			return withCharset
+		}
+	}
+	return mediaType
+}
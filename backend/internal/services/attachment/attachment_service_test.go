@@ -0,0 +1,472 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	attachmentMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/legalhold"
+	legalholdMocks "github.com/thatcatdev/kaimu/backend/internal/services/legalhold/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
+	storageMocks "github.com/thatcatdev/kaimu/backend/internal/services/storage/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var testLimits = Limits{MaxBytes: 1000, QuotaBytes: 5000, URLExpiry: 15 * time.Minute}
+
+// expectCardOrganization makes the card belong to org through a board and project
+func expectCardOrganization(mockCardRepo *cardMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, mockProjectRepo *projectMocks.MockRepository, mockOrgRepo *orgMocks.MockRepository, c *card.Card, org *organization.Organization) {
+	projectID := uuid.New()
+	mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+	mockBoardRepo.EXPECT().GetByID(gomock.Any(), c.BoardID).Return(&board.Board{ID: c.BoardID, ProjectID: projectID}, nil)
+	mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: org.ID}, nil)
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), org.ID).Return(org, nil)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestRequestUpload(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	t.Run("records a pending attachment and presigns its upload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		c := &card.Card{ID: uuid.New(), BoardID: uuid.New()}
+		org := &organization.Organization{ID: uuid.New()}
+		expectCardOrganization(mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, c, org)
+		mockAttachmentRepo.EXPECT().SumBytesByOrganization(gomock.Any(), org.ID).Return(int64(4000), nil)
+
+		var created *attachment.Attachment
+		mockAttachmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *attachment.Attachment) error {
+			created = a
+			return nil
+		})
+		mockStore.EXPECT().PresignPut(gomock.Any(), gomock.Any(), "application/pdf", int64(1000), 15*time.Minute).
+			Return("https://store/upload", map[string]string{"Content-Type": "application/pdf"}, nil)
+
+		upload, err := svc.RequestUpload(context.Background(), c.ID, userID, `C:\reports\q1.pdf`, "application/pdf; name=q1.pdf", 1000)
+		require.NoError(t, err)
+
+		assert.Same(t, created, upload.Attachment)
+		assert.Equal(t, "q1.pdf", created.Filename)
+		assert.Equal(t, "application/pdf", created.ContentType)
+		assert.Equal(t, c.ID, *created.CardID)
+		assert.Equal(t, org.ID, *created.OrganizationID)
+		assert.Equal(t, userID, *created.UploadedBy)
+		assert.Nil(t, created.UploadedAt)
+		assert.Equal(t, "attachments/"+org.ID.String()+"/"+c.ID.String()+"/"+created.ID.String(), created.ObjectKey)
+		assert.Equal(t, "https://store/upload", upload.URL)
+		assert.Equal(t, now.Add(15*time.Minute), upload.ExpiresAt)
+	})
+
+	t.Run("rejects files over the configured limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		c := &card.Card{ID: uuid.New(), BoardID: uuid.New()}
+		expectCardOrganization(mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, c, &organization.Organization{ID: uuid.New()})
+
+		_, err := svc.RequestUpload(context.Background(), c.ID, userID, "big.zip", "application/zip", 1001)
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+	})
+
+	t.Run("the organization's limit overrides the configured one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		c := &card.Card{ID: uuid.New(), BoardID: uuid.New()}
+		org := &organization.Organization{ID: uuid.New(), AttachmentMaxBytes: int64Ptr(2000), AttachmentQuotaBytes: int64Ptr(100000)}
+		expectCardOrganization(mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, c, org)
+		mockAttachmentRepo.EXPECT().SumBytesByOrganization(gomock.Any(), org.ID).Return(int64(0), nil)
+		mockAttachmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mockStore.EXPECT().PresignPut(gomock.Any(), gomock.Any(), gomock.Any(), int64(1500), gomock.Any()).Return("https://store/upload", nil, nil)
+
+		_, err := svc.RequestUpload(context.Background(), c.ID, userID, "big.zip", "application/zip", 1500)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects files that would exceed the quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		c := &card.Card{ID: uuid.New(), BoardID: uuid.New()}
+		org := &organization.Organization{ID: uuid.New()}
+		expectCardOrganization(mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, c, org)
+		mockAttachmentRepo.EXPECT().SumBytesByOrganization(gomock.Any(), org.ID).Return(int64(4500), nil)
+
+		_, err := svc.RequestUpload(context.Background(), c.ID, userID, "notes.txt", "text/plain", 501)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+	})
+
+	t.Run("unparseable content types are stored as binary", func(t *testing.T) {
+		assert.Equal(t, "application/octet-stream", cleanContentType("not a type"))
+		assert.Equal(t, "text/plain; charset=utf-8", cleanContentType("text/plain; charset=utf-8; x=y"))
+	})
+
+	t.Run("validates the filename and size first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.RequestUpload(context.Background(), uuid.New(), userID, " \x00 ", "text/plain", 10)
+		assert.ErrorIs(t, err, ErrFilenameRequired)
+
+		_, err = svc.RequestUpload(context.Background(), uuid.New(), userID, "a.txt", "text/plain", 0)
+		assert.ErrorIs(t, err, ErrInvalidSize)
+	})
+
+	t.Run("fails without an object store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		svc.store = nil
+
+		_, err := svc.RequestUpload(context.Background(), uuid.New(), userID, "a.txt", "text/plain", 10)
+		assert.ErrorIs(t, err, ErrStorageDisabled)
+	})
+}
+
+func TestCompleteUpload(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	cardID := uuid.New()
+
+	newPending := func() *attachment.Attachment {
+		return &attachment.Attachment{ID: uuid.New(), CardID: &cardID, ObjectKey: "attachments/x", SizeBytes: 42}
+	}
+
+	t.Run("marks the attachment uploaded once the file is stored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		a := newPending()
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), a.ID).Return(a, nil)
+		mockStore.EXPECT().Size(gomock.Any(), a.ObjectKey).Return(int64(42), nil)
+		mockAttachmentRepo.EXPECT().Update(gomock.Any(), a).Return(nil)
+
+		result, err := svc.CompleteUpload(context.Background(), a.ID)
+		require.NoError(t, err)
+		require.NotNil(t, result.UploadedAt)
+		assert.Equal(t, now, *result.UploadedAt)
+	})
+
+	t.Run("fails while the file is missing or partial", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		a := newPending()
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), a.ID).Return(a, nil).Times(2)
+		mockStore.EXPECT().Size(gomock.Any(), a.ObjectKey).Return(int64(0), storage.ErrObjectNotFound)
+		mockStore.EXPECT().Size(gomock.Any(), a.ObjectKey).Return(int64(10), nil)
+
+		_, err := svc.CompleteUpload(context.Background(), a.ID)
+		assert.ErrorIs(t, err, ErrUploadIncomplete)
+		_, err = svc.CompleteUpload(context.Background(), a.ID)
+		assert.ErrorIs(t, err, ErrUploadIncomplete)
+		assert.Nil(t, a.UploadedAt)
+	})
+
+	t.Run("attachments of deleted cards are not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		a := newPending()
+		a.CardID = nil
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), a.ID).Return(a, nil)
+
+		_, err := svc.CompleteUpload(context.Background(), a.ID)
+		assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	cardID := uuid.New()
+
+	t.Run("deletes the file, then the record", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		a := &attachment.Attachment{ID: uuid.New(), CardID: &cardID, ObjectKey: "attachments/x"}
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), a.ID).Return(a, nil)
+		gomock.InOrder(
+			mockStore.EXPECT().Delete(gomock.Any(), a.ObjectKey).Return(nil),
+			mockAttachmentRepo.EXPECT().Delete(gomock.Any(), a.ID).Return(nil),
+		)
+
+		result, err := svc.DeleteAttachment(context.Background(), a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, a, result)
+	})
+
+	t.Run("keeps the record when the file can't be deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		a := &attachment.Attachment{ID: uuid.New(), CardID: &cardID, ObjectKey: "attachments/x"}
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), a.ID).Return(a, nil)
+		mockStore.EXPECT().Delete(gomock.Any(), a.ObjectKey).Return(errors.New("unavailable"))
+
+		_, err := svc.DeleteAttachment(context.Background(), a.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown attachments are not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		id := uuid.New()
+		mockAttachmentRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.DeleteAttachment(context.Background(), id)
+		assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	})
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("removes detached and stale pending attachments in batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		full := make([]*attachment.Attachment, sweepBatchSize)
+		for i := range full {
+			full[i] = &attachment.Attachment{ID: uuid.New(), ObjectKey: "attachments/" + uuid.NewString()}
+		}
+		last := []*attachment.Attachment{{ID: uuid.New(), ObjectKey: "attachments/last"}}
+		gomock.InOrder(
+			mockAttachmentRepo.EXPECT().GetAbandoned(gomock.Any(), now.Add(-PendingUploadTTL), sweepBatchSize).Return(full, nil),
+			mockAttachmentRepo.EXPECT().GetAbandoned(gomock.Any(), now.Add(-PendingUploadTTL), sweepBatchSize).Return(last, nil),
+		)
+		mockStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(sweepBatchSize + 1)
+		mockAttachmentRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(sweepBatchSize + 1)
+
+		removed, err := svc.Sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, sweepBatchSize+1, removed)
+	})
+
+	t.Run("keeps attachments of organizations under legal hold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		heldOrg, freeOrg := uuid.New(), uuid.New()
+		kept := &attachment.Attachment{ID: uuid.New(), OrganizationID: &heldOrg, ObjectKey: "attachments/kept"}
+		alsoKept := &attachment.Attachment{ID: uuid.New(), OrganizationID: &heldOrg, ObjectKey: "attachments/also-kept"}
+		swept := &attachment.Attachment{ID: uuid.New(), OrganizationID: &freeOrg, ObjectKey: "attachments/swept"}
+		mockAttachmentRepo.EXPECT().GetAbandoned(gomock.Any(), gomock.Any(), sweepBatchSize).
+			Return([]*attachment.Attachment{kept, swept, alsoKept}, nil)
+		mockHoldSvc.EXPECT().CheckOrganization(gomock.Any(), heldOrg).Return(legalhold.ErrUnderLegalHold)
+		mockHoldSvc.EXPECT().CheckOrganization(gomock.Any(), freeOrg).Return(nil)
+		mockStore.EXPECT().Delete(gomock.Any(), swept.ObjectKey).Return(nil)
+		mockAttachmentRepo.EXPECT().Delete(gomock.Any(), swept.ID).Return(nil)
+
+		removed, err := svc.Sweep(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+	})
+}
+
+func TestSetOrganizationLimits(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("overrides and restores the configured limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		org := &organization.Organization{ID: uuid.New(), AttachmentQuotaBytes: int64Ptr(10)}
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), org.ID).Return(org, nil)
+		mockOrgRepo.EXPECT().Update(gomock.Any(), org).Return(nil)
+
+		result, err := svc.SetOrganizationLimits(context.Background(), org.ID, int64Ptr(500), nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), *result.AttachmentMaxBytes)
+		assert.Nil(t, result.AttachmentQuotaBytes)
+	})
+
+	t.Run("rejects limits that aren't positive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockHoldSvc := legalholdMocks.NewMockService(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockAttachmentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockHoldSvc, mockStore, testLimits).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.SetOrganizationLimits(context.Background(), uuid.New(), int64Ptr(0), nil)
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=attachment_service.go -destination=mocks/attachment_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	attachment "github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	attachment0 "github.com/thatcatdev/kaimu/backend/internal/services/attachment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CompleteUpload mocks base method.
+func (m *MockService) CompleteUpload(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, id)
+	ret0, _ := ret[0].(*attachment.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockServiceMockRecorder) CompleteUpload(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockService)(nil).CompleteUpload), ctx, id)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockService) DeleteAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, id)
+	ret0, _ := ret[0].(*attachment.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockServiceMockRecorder) DeleteAttachment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockService)(nil).DeleteAttachment), ctx, id)
+}
+
+// DownloadURL mocks base method.
+func (m *MockService) DownloadURL(ctx context.Context, a *attachment.Attachment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURL", ctx, a)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadURL indicates an expected call of DownloadURL.
+func (mr *MockServiceMockRecorder) DownloadURL(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURL", reflect.TypeOf((*MockService)(nil).DownloadURL), ctx, a)
+}
+
+// GetAttachment mocks base method.
+func (m *MockService) GetAttachment(ctx context.Context, id uuid.UUID) (*attachment.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, id)
+	ret0, _ := ret[0].(*attachment.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockServiceMockRecorder) GetAttachment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockService)(nil).GetAttachment), ctx, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockService) GetAttachments(ctx context.Context, cardID uuid.UUID) ([]*attachment.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, cardID)
+	ret0, _ := ret[0].([]*attachment.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockServiceMockRecorder) GetAttachments(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockService)(nil).GetAttachments), ctx, cardID)
+}
+
+// GetBoard mocks base method.
+func (m *MockService) GetBoard(ctx context.Context, a *attachment.Attachment) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, a)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockServiceMockRecorder) GetBoard(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockService)(nil).GetBoard), ctx, a)
+}
+
+// RequestUpload mocks base method.
+func (m *MockService) RequestUpload(ctx context.Context, cardID, userID uuid.UUID, filename, contentType string, size int64) (*attachment0.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestUpload", ctx, cardID, userID, filename, contentType, size)
+	ret0, _ := ret[0].(*attachment0.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestUpload indicates an expected call of RequestUpload.
+func (mr *MockServiceMockRecorder) RequestUpload(ctx, cardID, userID, filename, contentType, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestUpload", reflect.TypeOf((*MockService)(nil).RequestUpload), ctx, cardID, userID, filename, contentType, size)
+}
+
+// SetOrganizationLimits mocks base method.
+func (m *MockService) SetOrganizationLimits(ctx context.Context, orgID uuid.UUID, maxBytes, quotaBytes *int64) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizationLimits", ctx, orgID, maxBytes, quotaBytes)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizationLimits indicates an expected call of SetOrganizationLimits.
+func (mr *MockServiceMockRecorder) SetOrganizationLimits(ctx, orgID, maxBytes, quotaBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationLimits", reflect.TypeOf((*MockService)(nil).SetOrganizationLimits), ctx, orgID, maxBytes, quotaBytes)
+}
+
+// Sweep mocks base method.
+func (m *MockService) Sweep(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockServiceMockRecorder) Sweep(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockService)(nil).Sweep), ctx)
+}
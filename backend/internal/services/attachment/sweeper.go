@@ -0,0 +1,47 @@
+package attachment
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultSweepInterval is how often the sweeper looks for abandoned attachments
+const DefaultSweepInterval = 10 * time.Minute
+
+// Sweeper runs Service.Sweep in the background, deleting the files of deleted cards
+type Sweeper struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewSweeper(svc Service, interval time.Duration) *Sweeper {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+	return &Sweeper{svc: svc, interval: interval}
+}
+
+// Run sweeps every interval until ctx is cancelled
+func (w *Sweeper) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := w.svc.Sweep(ctx)
+		if err != nil {
+			log.Error().Err(err).Int("removed", removed).Msg("Failed to sweep attachments")
+		} else if removed > 0 {
+			log.Info().Int("removed", removed).Msg("Deleted abandoned attachments")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -42,6 +42,7 @@ type Service interface {
 	// Query methods for history views
 	GetEntityHistory(ctx context.Context, entityType auditrepo.EntityType, entityID uuid.UUID, limit, offset int) ([]*auditrepo.AuditEvent, int64, error)
 	GetUserActivity(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*auditrepo.AuditEvent, int64, error)
+	GetCardActivity(ctx context.Context, cardID uuid.UUID, limit, offset int) ([]*auditrepo.AuditEvent, int64, error)
 
 	// Query methods for metrics
 	GetCardMovementsByBoardAndDateRange(ctx context.Context, boardID uuid.UUID, startDate, endDate time.Time) ([]*auditrepo.AuditEvent, error)
@@ -129,6 +130,11 @@ func (s *service) buildEvent(ctx context.Context, input EventInput) (*auditrepo.
 		if reqCtx.TraceID != "" {
 			event.TraceID = &reqCtx.TraceID
 		}
+		if reqCtx.AuthMethod != "" {
+			event.AuthMethod = &reqCtx.AuthMethod
+		}
+		event.ImpersonatorID = reqCtx.ImpersonatorID
+		event.APITokenID = reqCtx.APITokenID
 	}
 
 	return event, nil
@@ -164,6 +170,12 @@ func (s *service) GetUserActivity(ctx context.Context, userID uuid.UUID, limit,
 	return s.repo.GetByActorID(ctx, userID, limit, offset)
 }
 
+// GetCardActivity returns audit events for a card, including its comments, attachments and
+// checklist items
+func (s *service) GetCardActivity(ctx context.Context, cardID uuid.UUID, limit, offset int) ([]*auditrepo.AuditEvent, int64, error) {
+	return s.repo.GetByCardID(ctx, cardID, limit, offset)
+}
+
 // GetCardMovementsByBoardAndDateRange returns card movement events for metrics
 func (s *service) GetCardMovementsByBoardAndDateRange(ctx context.Context, boardID uuid.UUID, startDate, endDate time.Time) ([]*auditrepo.AuditEvent, error) {
 	return s.repo.GetCardMovementsByBoardAndDateRange(ctx, boardID, startDate, endDate)
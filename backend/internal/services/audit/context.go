@@ -1,6 +1,11 @@
 package audit
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+)
 
 type contextKey string
 
@@ -11,6 +16,11 @@ type RequestContext struct {
 	IPAddress string
 	UserAgent string
 	TraceID   string
+	// AuthMethod is empty for unauthenticated requests
+	AuthMethod     auditrepo.AuthMethod
+	ImpersonatorID *uuid.UUID
+	// APITokenID is set when AuthMethod is auditrepo.AuthMethodAPIToken
+	APITokenID *uuid.UUID
 }
 
 // WithRequestContext adds request context to the context for audit logging
@@ -25,3 +35,25 @@ func GetRequestContext(ctx context.Context) *RequestContext {
 	}
 	return nil
 }
+
+// WithAuthentication records how the request's user authenticated on the request context,
+// keeping the request information already on it
+func WithAuthentication(ctx context.Context, method auditrepo.AuthMethod, impersonatorID *uuid.UUID) context.Context {
+	reqCtx := RequestContext{}
+	if existing := GetRequestContext(ctx); existing != nil {
+		reqCtx = *existing
+	}
+	reqCtx.AuthMethod = method
+	reqCtx.ImpersonatorID = impersonatorID
+	reqCtx.APITokenID = nil
+	return WithRequestContext(ctx, &reqCtx)
+}
+
+// WithAPITokenAuthentication records on the request context that the request's user
+// authenticated with the API token tokenID
+func WithAPITokenAuthentication(ctx context.Context, tokenID uuid.UUID) context.Context {
+	ctx = WithAuthentication(ctx, auditrepo.AuthMethodAPIToken, nil)
+	reqCtx := GetRequestContext(ctx)
+	reqCtx.APITokenID = &tokenID
+	return ctx
+}
@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+)
+
+// snapshot holds the fields of entity snapshots and metadata that summaries mention
+type snapshot struct {
+	Title    string `json:"title"`
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+	Priority string `json:"priority"`
+	Done     *bool  `json:"done"`
+
+	FromColumnName string `json:"from_column_name"`
+	ToColumnName   string `json:"to_column_name"`
+	AssigneeName   string `json:"assignee_name"`
+}
+
+func decodeSnapshot(data []byte) snapshot {
+	var s snapshot
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &s)
+	}
+	return s
+}
+
+// Summarize renders the event as a sentence in the context's locale, e.g. `Ana moved
+// "Fix login" from To Do to Doing`. actor is the name of whoever caused the event, empty
+// when they are unknown.
+func Summarize(ctx context.Context, e *auditrepo.AuditEvent, actor string) string {
+	if actor == "" {
+		actor = i18n.Tc(ctx, "activity.someone", nil)
+	}
+	before := decodeSnapshot(e.StateBefore)
+	after := decodeSnapshot(e.StateAfter)
+	meta := decodeSnapshot(e.Metadata)
+
+	values := map[string]string{"actor": actor}
+	switch e.EntityType {
+	case auditrepo.EntityCard:
+		values["card"] = quoted(ctx, after.Title, before.Title, "activity.entity.card")
+		switch e.Action {
+		case auditrepo.ActionCreated:
+			return i18n.Tc(ctx, "activity.card_created", values)
+		case auditrepo.ActionDeleted:
+			return i18n.Tc(ctx, "activity.card_deleted", values)
+		case auditrepo.ActionUpdated:
+			switch {
+			case before.Title != "" && after.Title != "" && before.Title != after.Title:
+				values["from"], values["to"] = before.Title, after.Title
+				return i18n.Tc(ctx, "activity.card_renamed", values)
+			case before.Priority != "" && after.Priority != "" && before.Priority != after.Priority:
+				values["from"] = priority(ctx, before.Priority)
+				values["to"] = priority(ctx, after.Priority)
+				return i18n.Tc(ctx, "activity.card_priority_changed", values)
+			}
+			return i18n.Tc(ctx, "activity.card_updated", values)
+		case auditrepo.ActionCardMoved:
+			if meta.ToColumnName != "" {
+				values["to"] = meta.ToColumnName
+				if meta.FromColumnName != "" && meta.FromColumnName != meta.ToColumnName {
+					values["from"] = meta.FromColumnName
+					return i18n.Tc(ctx, "activity.card_moved", values)
+				}
+				return i18n.Tc(ctx, "activity.card_moved_to", values)
+			}
+			return i18n.Tc(ctx, "activity.card_repositioned", values)
+		case auditrepo.ActionCardAssigned:
+			if meta.AssigneeName != "" {
+				values["assignee"] = meta.AssigneeName
+				return i18n.Tc(ctx, "activity.card_assigned", values)
+			}
+		case auditrepo.ActionCardUnassigned:
+			return i18n.Tc(ctx, "activity.card_unassigned", values)
+		case auditrepo.ActionCardAddedToSprint:
+			return i18n.Tc(ctx, "activity.card_added_to_sprint", values)
+		case auditrepo.ActionCardRemovedFromSprint:
+			return i18n.Tc(ctx, "activity.card_removed_from_sprint", values)
+		}
+	case auditrepo.EntityComment:
+		switch e.Action {
+		case auditrepo.ActionCreated:
+			return i18n.Tc(ctx, "activity.comment_created", values)
+		case auditrepo.ActionUpdated:
+			return i18n.Tc(ctx, "activity.comment_updated", values)
+		case auditrepo.ActionDeleted:
+			return i18n.Tc(ctx, "activity.comment_deleted", values)
+		}
+	case auditrepo.EntityAttachment:
+		values["file"] = quoted(ctx, after.Filename, before.Filename, "activity.entity.attachment")
+		switch e.Action {
+		case auditrepo.ActionCreated:
+			return i18n.Tc(ctx, "activity.attachment_created", values)
+		case auditrepo.ActionDeleted:
+			return i18n.Tc(ctx, "activity.attachment_deleted", values)
+		}
+	case auditrepo.EntityChecklistItem:
+		values["item"] = quoted(ctx, after.Title, before.Title, "activity.entity.checklist_item")
+		switch e.Action {
+		case auditrepo.ActionCreated:
+			return i18n.Tc(ctx, "activity.checklist_item_created", values)
+		case auditrepo.ActionDeleted:
+			return i18n.Tc(ctx, "activity.checklist_item_deleted", values)
+		case auditrepo.ActionUpdated:
+			if before.Done != nil && after.Done != nil && *before.Done != *after.Done {
+				if *after.Done {
+					return i18n.Tc(ctx, "activity.checklist_item_completed", values)
+				}
+				return i18n.Tc(ctx, "activity.checklist_item_reopened", values)
+			}
+			return i18n.Tc(ctx, "activity.checklist_item_updated", values)
+		}
+	case auditrepo.EntitySprint:
+		values["sprint"] = quoted(ctx, after.Name, before.Name, "activity.entity.sprint")
+		switch e.Action {
+		case auditrepo.ActionSprintStarted:
+			return i18n.Tc(ctx, "activity.sprint_started", values)
+		case auditrepo.ActionSprintCompleted:
+			return i18n.Tc(ctx, "activity.sprint_completed", values)
+		}
+	}
+
+	values["entity"] = i18n.Tc(ctx, "activity.entity."+string(e.EntityType), nil)
+	switch e.Action {
+	case auditrepo.ActionCreated:
+		return i18n.Tc(ctx, "activity.created", values)
+	case auditrepo.ActionDeleted:
+		return i18n.Tc(ctx, "activity.deleted", values)
+	default:
+		return i18n.Tc(ctx, "activity.changed", values)
+	}
+}
+
+// quoted returns the first non-empty name in quotes, or the generic reference under
+// fallback when the snapshots carry no name
+func quoted(ctx context.Context, name, other, fallback string) string {
+	if name == "" {
+		name = other
+	}
+	if name == "" {
+		return i18n.Tc(ctx, fallback, nil)
+	}
+	return i18n.Tc(ctx, "activity.quoted", map[string]string{"name": name})
+}
+
+// priority translates a card priority as it appears in card snapshots, e.g. "HIGH"
+func priority(ctx context.Context, p string) string {
+	switch p {
+	case "LOW":
+		return i18n.Tc(ctx, "activity.priority.low", nil)
+	case "MEDIUM":
+		return i18n.Tc(ctx, "activity.priority.medium", nil)
+	case "HIGH":
+		return i18n.Tc(ctx, "activity.priority.high", nil)
+	case "URGENT":
+		return i18n.Tc(ctx, "activity.priority.urgent", nil)
+	default:
+		return i18n.Tc(ctx, "activity.priority.none", nil)
+	}
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+)
+
+func TestSummarize(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		event *auditrepo.AuditEvent
+		actor string
+		want  string
+	}{
+		{
+			name: "card moved between columns",
+			event: &auditrepo.AuditEvent{
+				Action:     auditrepo.ActionCardMoved,
+				EntityType: auditrepo.EntityCard,
+				StateAfter: []byte(`{"title":"Fix login","priority":"HIGH"}`),
+				Metadata:   []byte(`{"from_column_name":"To Do","to_column_name":"Doing"}`),
+			},
+			actor: "Ana",
+			want:  `Ana moved "Fix login" from To Do to Doing`,
+		},
+		{
+			name: "card reordered within its column",
+			event: &auditrepo.AuditEvent{
+				Action:     auditrepo.ActionCardMoved,
+				EntityType: auditrepo.EntityCard,
+				StateAfter: []byte(`{"title":"Fix login"}`),
+				Metadata:   []byte(`{"from_column_name":"Doing","to_column_name":"Doing"}`),
+			},
+			actor: "Ana",
+			want:  `Ana moved "Fix login" to Doing`,
+		},
+		{
+			name: "priority changed",
+			event: &auditrepo.AuditEvent{
+				Action:      auditrepo.ActionUpdated,
+				EntityType:  auditrepo.EntityCard,
+				StateBefore: []byte(`{"title":"Fix login","priority":"LOW"}`),
+				StateAfter:  []byte(`{"title":"Fix login","priority":"URGENT"}`),
+			},
+			actor: "Ana",
+			want:  `Ana changed the priority of "Fix login" from low to urgent`,
+		},
+		{
+			name: "card renamed",
+			event: &auditrepo.AuditEvent{
+				Action:      auditrepo.ActionUpdated,
+				EntityType:  auditrepo.EntityCard,
+				StateBefore: []byte(`{"title":"Fix login","priority":"LOW"}`),
+				StateAfter:  []byte(`{"title":"Fix sign in","priority":"HIGH"}`),
+			},
+			actor: "Ana",
+			want:  `Ana renamed "Fix login" to "Fix sign in"`,
+		},
+		{
+			name: "card assigned",
+			event: &auditrepo.AuditEvent{
+				Action:     auditrepo.ActionCardAssigned,
+				EntityType: auditrepo.EntityCard,
+				StateAfter: []byte(`{"title":"Fix login"}`),
+				Metadata:   []byte(`{"assignee_id":"5f0c","assignee_name":"Bo"}`),
+			},
+			actor: "Ana",
+			want:  `Ana assigned "Fix login" to Bo`,
+		},
+		{
+			name: "attachment removed",
+			event: &auditrepo.AuditEvent{
+				Action:      auditrepo.ActionDeleted,
+				EntityType:  auditrepo.EntityAttachment,
+				StateBefore: []byte(`{"filename":"trace.log"}`),
+				Metadata:    []byte(`{"card_id":"5f0c"}`),
+			},
+			actor: "Ana",
+			want:  `Ana removed the attachment "trace.log"`,
+		},
+		{
+			name: "checklist item checked off",
+			event: &auditrepo.AuditEvent{
+				Action:      auditrepo.ActionUpdated,
+				EntityType:  auditrepo.EntityChecklistItem,
+				StateBefore: []byte(`{"title":"Write tests","done":false}`),
+				StateAfter:  []byte(`{"title":"Write tests","done":true}`),
+				Metadata:    []byte(`{"card_id":"5f0c"}`),
+			},
+			actor: "Ana",
+			want:  `Ana checked off "Write tests"`,
+		},
+		{
+			name: "unknown actor and entity without a specific message",
+			event: &auditrepo.AuditEvent{
+				Action:     auditrepo.ActionColumnReordered,
+				EntityType: auditrepo.EntityBoardColumn,
+			},
+			want: "Someone changed a column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Summarize(ctx, tt.event, tt.actor))
+		})
+	}
+}
+
+func TestSummarizeTranslates(t *testing.T) {
+	ctx := i18n.WithLocale(context.Background(), "de")
+	event := &auditrepo.AuditEvent{
+		Action:     auditrepo.ActionCardMoved,
+		EntityType: auditrepo.EntityCard,
+		StateAfter: []byte(`{"title":"Login reparieren"}`),
+		Metadata:   []byte(`{"from_column_name":"Offen","to_column_name":"In Arbeit"}`),
+	}
+
+	assert.Equal(t, "Ana hat „Login reparieren“ von Offen nach In Arbeit verschoben", Summarize(ctx, event, "Ana"))
+}
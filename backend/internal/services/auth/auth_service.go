@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"time"
@@ -12,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -31,6 +31,9 @@ var (
 
 type Claims struct {
 	UserID uuid.UUID `json:"user_id"`
+	// ImpersonatorID is set on tokens that let a staff member act as the user, so what
+	// they do is audited as impersonated
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -185,7 +188,7 @@ func (s *service) RefreshTokens(ctx context.Context, refreshTokenStr string, use
 	defer span.End()
 
 	// Hash the refresh token to look it up
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 
 	// Find the refresh token
 	storedToken, err := s.refreshTokenRepository.GetByTokenHash(ctx, tokenHash)
@@ -212,7 +215,7 @@ func (s *service) RefreshTokens(ctx context.Context, refreshTokenStr string, use
 	}
 
 	// Revoke old refresh token (rotation)
-	newTokenHash := hashToken(newTokenPair.RefreshToken)
+	newTokenHash := tokenhash.Hash(newTokenPair.RefreshToken)
 	newStoredToken, _ := s.refreshTokenRepository.GetByTokenHash(ctx, newTokenHash)
 	var replacedByID *uuid.UUID
 	if newStoredToken != nil {
@@ -227,7 +230,7 @@ func (s *service) RevokeRefreshToken(ctx context.Context, refreshTokenStr string
 	ctx, span := s.startServiceSpan(ctx, "RevokeRefreshToken")
 	defer span.End()
 
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 	storedToken, err := s.refreshTokenRepository.GetByTokenHash(ctx, tokenHash)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -284,7 +287,7 @@ func (s *service) generateTokenPairInternal(ctx context.Context, userID uuid.UUI
 	}
 
 	// Store refresh token hash in database
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 	var ua, ip *string
 	if userAgent != "" {
 		ua = &userAgent
@@ -334,9 +337,3 @@ func generateRandomToken(length int) (string, error) {
 	}
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
-
-// hashToken creates a SHA-256 hash of the token for storage
-func hashToken(token string) string {
-	hash := sha256.Sum256([]byte(token))
-	return base64.URLEncoding.EncodeToString(hash[:])
-}
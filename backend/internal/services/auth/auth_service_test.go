@@ -12,6 +12,7 @@ import (
 	refreshtokenMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
 	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -284,7 +285,7 @@ func TestRefreshTokens_Success(t *testing.T) {
 
 	// Generate a refresh token (using package functions)
 	refreshTokenStr, _ := generateRandomToken(32)
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 
 	storedToken := &refreshtoken.RefreshToken{
 		ID:        uuid.New(),
@@ -338,7 +339,7 @@ func TestRefreshTokens_TokenExpired(t *testing.T) {
 
 	userID := uuid.New()
 	refreshTokenStr, _ := generateRandomToken(32)
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 
 	// Expired token
 	storedToken := &refreshtoken.RefreshToken{
@@ -367,7 +368,7 @@ func TestRefreshTokens_TokenRevoked(t *testing.T) {
 
 	userID := uuid.New()
 	refreshTokenStr, _ := generateRandomToken(32)
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 
 	// Revoked token
 	revokedAt := time.Now().Add(-1 * time.Hour)
@@ -399,7 +400,7 @@ func TestRevokeRefreshToken_Success(t *testing.T) {
 	svc := NewService(mockUserRepo, mockRefreshRepo, "test-secret", 5, 7)
 
 	refreshTokenStr, _ := generateRandomToken(32)
-	tokenHash := hashToken(refreshTokenStr)
+	tokenHash := tokenhash.Hash(refreshTokenStr)
 
 	storedToken := &refreshtoken.RefreshToken{
 		ID:        uuid.New(),
@@ -443,7 +444,11 @@ func TestGenerateTokenPair_Success(t *testing.T) {
 
 	userID := uuid.New()
 
-	mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	var stored *refreshtoken.RefreshToken
+	mockRefreshRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *refreshtoken.RefreshToken) error {
+		stored = token
+		return nil
+	})
 
 	tokenPair, err := svc.GenerateTokenPair(context.Background(), userID, "Test-Agent", "127.0.0.1")
 
@@ -452,4 +457,12 @@ func TestGenerateTokenPair_Success(t *testing.T) {
 	assert.NotEmpty(t, tokenPair.AccessToken)
 	assert.NotEmpty(t, tokenPair.RefreshToken)
 	assert.Equal(t, int64(5*60), tokenPair.ExpiresIn)
+
+	// The access token signs in as the user, and only the refresh token's hash is stored
+	claims, err := svc.ValidateToken(tokenPair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	require.NotNil(t, stored)
+	assert.Equal(t, userID, stored.UserID)
+	assert.Equal(t, tokenhash.Hash(tokenPair.RefreshToken), stored.TokenHash)
 }
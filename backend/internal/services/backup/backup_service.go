@@ -0,0 +1,142 @@
+package backup
+
+//go:generate mockgen -source=backup_service.go -destination=mocks/backup_service_mock.go -package=mocks
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	backupEngine "github.com/thatcatdev/kaimu/backend/internal/backup"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// keyTimeFormat names backups by when they were taken, so keys sort chronologically
+const keyTimeFormat = "20060102T150405Z"
+
+// Backup is a backup file in the store
+type Backup struct {
+	Key string
+	// OrganizationID is nil for instance backups
+	OrganizationID *uuid.UUID
+	SizeBytes      int64
+	CreatedAt      time.Time
+	// Summary is only set on backups that were just taken
+	Summary *backupEngine.Summary
+}
+
+type Service interface {
+	// CreateOrganizationBackup stores a consistent snapshot of the organization
+	CreateOrganizationBackup(ctx context.Context, orgID uuid.UUID) (*Backup, error)
+	// CreateInstanceBackup stores a consistent snapshot of every organization and user
+	CreateInstanceBackup(ctx context.Context) (*Backup, error)
+	// GetOrganizationBackups returns the organization's stored backups, newest first
+	GetOrganizationBackups(ctx context.Context, orgID uuid.UUID) ([]*Backup, error)
+	// Restore loads a stored backup into the database
+	Restore(ctx context.Context, key string) (*backupEngine.Manifest, *backupEngine.Summary, error)
+}
+
+type service struct {
+	engine backupEngine.Engine
+	store  backupEngine.Store
+	now    func() time.Time
+}
+
+func NewService(engine backupEngine.Engine, store backupEngine.Store) Service {
+	return &service{
+		engine: engine,
+		store:  store,
+		now:    time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "backup.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "backup"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateOrganizationBackup(ctx context.Context, orgID uuid.UUID) (*Backup, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateOrganizationBackup")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.create(ctx, organizationPrefix(orgID), &orgID)
+}
+
+func (s *service) CreateInstanceBackup(ctx context.Context) (*Backup, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateInstanceBackup")
+	defer span.End()
+
+	return s.create(ctx, instancePrefix, nil)
+}
+
+// create streams a snapshot into the store under prefix
+func (s *service) create(ctx context.Context, prefix string, orgID *uuid.UUID) (*Backup, error) {
+	key := prefix + s.now().UTC().Format(keyTimeFormat) + ".jsonl.gz"
+
+	pr, pw := io.Pipe()
+	var summary *backupEngine.Summary
+	go func() {
+		var err error
+		_, summary, err = s.engine.Snapshot(ctx, pw, orgID)
+		pw.CloseWithError(err)
+	}()
+	obj, err := s.store.Put(ctx, key, pr)
+	// Unblocks the snapshot if the store gave up early
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backup{Key: obj.Key, OrganizationID: orgID, SizeBytes: obj.Size, CreatedAt: obj.ModifiedAt, Summary: summary}, nil
+}
+
+func (s *service) GetOrganizationBackups(ctx context.Context, orgID uuid.UUID) ([]*Backup, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOrganizationBackups")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	objects, err := s.store.List(ctx, organizationPrefix(orgID))
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]*Backup, 0, len(objects))
+	for _, obj := range objects {
+		if !strings.HasSuffix(obj.Key, ".jsonl.gz") {
+			continue
+		}
+		backups = append(backups, &Backup{Key: obj.Key, OrganizationID: &orgID, SizeBytes: obj.Size, CreatedAt: obj.ModifiedAt})
+	}
+	return backups, nil
+}
+
+func (s *service) Restore(ctx context.Context, key string) (*backupEngine.Manifest, *backupEngine.Summary, error) {
+	ctx, span := s.startServiceSpan(ctx, "Restore")
+	span.SetAttributes(attribute.String("backup.key", key))
+	defer span.End()
+
+	f, err := s.store.Open(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return s.engine.Restore(ctx, f)
+}
+
+const instancePrefix = "instance/"
+
+func organizationPrefix(orgID uuid.UUID) string {
+	return "organizations/" + orgID.String() + "/"
+}
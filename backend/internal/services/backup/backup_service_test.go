@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	backupEngine "github.com/thatcatdev/kaimu/backend/internal/backup"
+	backupMocks "github.com/thatcatdev/kaimu/backend/internal/backup/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateOrganizationBackup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID := uuid.New()
+	key := "organizations/" + orgID.String() + "/20260310T120000Z.jsonl.gz"
+
+	t.Run("success - streams the snapshot into the store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEngine := backupMocks.NewMockEngine(ctrl)
+		mockStore := backupMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockEngine, mockStore).(*service)
+		svc.now = func() time.Time { return now }
+
+		summary := &backupEngine.Summary{Rows: map[string]int{"organizations": 1, "cards": 4}}
+		mockEngine.EXPECT().Snapshot(gomock.Any(), gomock.Any(), &orgID).
+			DoAndReturn(func(ctx context.Context, w io.Writer, orgID *uuid.UUID) (*backupEngine.Manifest, *backupEngine.Summary, error) {
+				_, err := w.Write([]byte("snapshot"))
+				return &backupEngine.Manifest{}, summary, err
+			})
+		mockStore.EXPECT().Put(gomock.Any(), key, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, r io.Reader) (*backupEngine.Object, error) {
+				data, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, "snapshot", string(data))
+				return &backupEngine.Object{Key: key, Size: int64(len(data)), ModifiedAt: now}, nil
+			})
+
+		b, err := svc.CreateOrganizationBackup(ctx, orgID)
+		require.NoError(t, err)
+		assert.Equal(t, key, b.Key)
+		assert.Equal(t, &orgID, b.OrganizationID)
+		assert.Equal(t, int64(8), b.SizeBytes)
+		assert.Equal(t, 5, b.Summary.Total())
+	})
+
+	t.Run("fail - snapshot error reaches the store", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEngine := backupMocks.NewMockEngine(ctrl)
+		mockStore := backupMocks.NewMockStore(ctrl)
+
+		svc := NewService(mockEngine, mockStore).(*service)
+		svc.now = func() time.Time { return now }
+
+		snapshotErr := errors.New("connection lost")
+		mockEngine.EXPECT().Snapshot(gomock.Any(), gomock.Any(), &orgID).Return(nil, nil, snapshotErr)
+		mockStore.EXPECT().Put(gomock.Any(), key, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, key string, r io.Reader) (*backupEngine.Object, error) {
+				_, err := io.ReadAll(r)
+				return nil, err
+			})
+
+		_, err := svc.CreateOrganizationBackup(ctx, orgID)
+		assert.ErrorIs(t, err, snapshotErr)
+	})
+}
+
+func TestGetOrganizationBackups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockEngine := backupMocks.NewMockEngine(ctrl)
+	mockStore := backupMocks.NewMockStore(ctrl)
+
+	svc := NewService(mockEngine, mockStore).(*service)
+	svc.now = func() time.Time { return now }
+
+	orgID := uuid.New()
+	prefix := "organizations/" + orgID.String() + "/"
+
+	mockStore.EXPECT().List(gomock.Any(), prefix).Return([]*backupEngine.Object{
+		{Key: prefix + "20260310T120000Z.jsonl.gz", Size: 10, ModifiedAt: now},
+		{Key: prefix + "notes.txt", Size: 1, ModifiedAt: now},
+	}, nil)
+
+	backups, err := svc.GetOrganizationBackups(context.Background(), orgID)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, prefix+"20260310T120000Z.jsonl.gz", backups[0].Key)
+	assert.Nil(t, backups[0].Summary)
+}
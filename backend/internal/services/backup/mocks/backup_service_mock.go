@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backup_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=backup_service.go -destination=mocks/backup_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	backup "github.com/thatcatdev/kaimu/backend/internal/backup"
+	backup0 "github.com/thatcatdev/kaimu/backend/internal/services/backup"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateInstanceBackup mocks base method.
+func (m *MockService) CreateInstanceBackup(ctx context.Context) (*backup0.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstanceBackup", ctx)
+	ret0, _ := ret[0].(*backup0.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstanceBackup indicates an expected call of CreateInstanceBackup.
+func (mr *MockServiceMockRecorder) CreateInstanceBackup(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstanceBackup", reflect.TypeOf((*MockService)(nil).CreateInstanceBackup), ctx)
+}
+
+// CreateOrganizationBackup mocks base method.
+func (m *MockService) CreateOrganizationBackup(ctx context.Context, orgID uuid.UUID) (*backup0.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganizationBackup", ctx, orgID)
+	ret0, _ := ret[0].(*backup0.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganizationBackup indicates an expected call of CreateOrganizationBackup.
+func (mr *MockServiceMockRecorder) CreateOrganizationBackup(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationBackup", reflect.TypeOf((*MockService)(nil).CreateOrganizationBackup), ctx, orgID)
+}
+
+// GetOrganizationBackups mocks base method.
+func (m *MockService) GetOrganizationBackups(ctx context.Context, orgID uuid.UUID) ([]*backup0.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationBackups", ctx, orgID)
+	ret0, _ := ret[0].([]*backup0.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationBackups indicates an expected call of GetOrganizationBackups.
+func (mr *MockServiceMockRecorder) GetOrganizationBackups(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationBackups", reflect.TypeOf((*MockService)(nil).GetOrganizationBackups), ctx, orgID)
+}
+
+// Restore mocks base method.
+func (m *MockService) Restore(ctx context.Context, key string) (*backup.Manifest, *backup.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, key)
+	ret0, _ := ret[0].(*backup.Manifest)
+	ret1, _ := ret[1].(*backup.Summary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockServiceMockRecorder) Restore(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockService)(nil).Restore), ctx, key)
+}
@@ -3,11 +3,14 @@ package board
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -19,6 +22,7 @@ var (
 	ErrColumnNotFound      = errors.New("column not found")
 	ErrProjectNotFound     = errors.New("project not found")
 	ErrCannotDeleteDefault = errors.New("cannot delete default board")
+	ErrInvalidPointLimit   = errors.New("point limit must be at least 1")
 )
 
 type Service interface {
@@ -37,6 +41,10 @@ type Service interface {
 	GetColumn(ctx context.Context, id uuid.UUID) (*board_column.BoardColumn, error)
 	GetColumnsByBoardID(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error)
 	GetVisibleColumns(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error)
+	// GetColumnStats returns the card count, story points, WIP load and average card age of
+	// every column of the board, aggregated by the database
+	GetColumnStats(ctx context.Context, boardID uuid.UUID) ([]*board_column.ColumnStats, error)
+	// UpdateColumn saves the column; a point limit below 1 fails with ErrInvalidPointLimit
 	UpdateColumn(ctx context.Context, col *board_column.BoardColumn) (*board_column.BoardColumn, error)
 	ReorderColumns(ctx context.Context, boardID uuid.UUID, columnIDs []uuid.UUID) ([]*board_column.BoardColumn, error)
 	ToggleColumnVisibility(ctx context.Context, id uuid.UUID) (*board_column.BoardColumn, error)
@@ -48,13 +56,17 @@ type service struct {
 	boardRepo   board.Repository
 	columnRepo  board_column.Repository
 	projectRepo project.Repository
+	txManager   transaction.Manager
+	bus         events.Bus
 }
 
-func NewService(boardRepo board.Repository, columnRepo board_column.Repository, projectRepo project.Repository) Service {
+func NewService(boardRepo board.Repository, columnRepo board_column.Repository, projectRepo project.Repository, txManager transaction.Manager, bus events.Bus) Service {
 	return &service{
 		boardRepo:   boardRepo,
 		columnRepo:  columnRepo,
 		projectRepo: projectRepo,
+		txManager:   txManager,
+		bus:         bus,
 	}
 }
 
@@ -98,12 +110,7 @@ func (s *service) CreateBoard(ctx context.Context, projectID uuid.UUID, name, de
 		CreatedBy:   createdBy,
 	}
 
-	if err := s.boardRepo.Create(ctx, b); err != nil {
-		return nil, err
-	}
-
-	// Create default columns for this board
-	if err := s.createDefaultColumns(ctx, b.ID); err != nil {
+	if err := s.createBoardWithColumns(ctx, b); err != nil {
 		return nil, err
 	}
 
@@ -123,18 +130,33 @@ func (s *service) CreateDefaultBoard(ctx context.Context, projectID uuid.UUID, c
 		CreatedBy:   createdBy,
 	}
 
-	if err := s.boardRepo.Create(ctx, b); err != nil {
-		return nil, err
-	}
-
-	// Create default columns
-	if err := s.createDefaultColumns(ctx, b.ID); err != nil {
+	if err := s.createBoardWithColumns(ctx, b); err != nil {
 		return nil, err
 	}
 
 	return b, nil
 }
 
+// createBoardWithColumns creates the board and its default columns atomically
+func (s *service) createBoardWithColumns(ctx context.Context, b *board.Board) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Create(ctx, b); err != nil {
+			return err
+		}
+		if err := s.createDefaultColumns(ctx, b.ID); err != nil {
+			return err
+		}
+		return s.publishBoardEvent(ctx, events.BoardCreated, b)
+	})
+}
+
+func (s *service) publishBoardEvent(ctx context.Context, name events.Name, b *board.Board) error {
+	return s.bus.Publish(ctx, events.New(ctx, name, events.BoardPayload{
+		BoardID:   b.ID,
+		ProjectID: b.ProjectID,
+	}))
+}
+
 func (s *service) createDefaultColumns(ctx context.Context, boardID uuid.UUID) error {
 	columns := []struct {
 		Name      string
@@ -209,7 +231,13 @@ func (s *service) UpdateBoard(ctx context.Context, b *board.Board) (*board.Board
 	span.SetAttributes(attribute.String("board.id", b.ID.String()))
 	defer span.End()
 
-	if err := s.boardRepo.Update(ctx, b); err != nil {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Update(ctx, b); err != nil {
+			return err
+		}
+		return s.publishBoardEvent(ctx, events.BoardUpdated, b)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return b, nil
@@ -221,15 +249,23 @@ func (s *service) DeleteBoard(ctx context.Context, id uuid.UUID) error {
 	defer span.End()
 
 	// Verify board exists
-	_, err := s.boardRepo.GetByID(ctx, id)
+	b, err := s.boardRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrBoardNotFound
 		}
 		return err
 	}
+	if b.IsDefault {
+		return ErrCannotDeleteDefault
+	}
 
-	return s.boardRepo.Delete(ctx, id)
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.publishBoardEvent(ctx, events.BoardDeleted, b)
+	})
 }
 
 func (s *service) GetProject(ctx context.Context, boardID uuid.UUID) (*project.Project, error) {
@@ -328,11 +364,23 @@ func (s *service) GetVisibleColumns(ctx context.Context, boardID uuid.UUID) ([]*
 	return s.columnRepo.GetVisibleByBoardID(ctx, boardID)
 }
 
+func (s *service) GetColumnStats(ctx context.Context, boardID uuid.UUID) ([]*board_column.ColumnStats, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetColumnStats")
+	span.SetAttributes(attribute.String("column.board_id", boardID.String()))
+	defer span.End()
+
+	return s.columnRepo.GetStatsByBoardID(ctx, boardID, time.Now())
+}
+
 func (s *service) UpdateColumn(ctx context.Context, col *board_column.BoardColumn) (*board_column.BoardColumn, error) {
 	ctx, span := s.startServiceSpan(ctx, "UpdateColumn")
 	span.SetAttributes(attribute.String("column.id", col.ID.String()))
 	defer span.End()
 
+	if col.PointLimit != nil && *col.PointLimit < 1 {
+		return nil, ErrInvalidPointLimit
+	}
+
 	if err := s.columnRepo.Update(ctx, col); err != nil {
 		return nil, err
 	}
@@ -13,6 +13,8 @@ import (
 	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
 	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -25,7 +27,7 @@ func TestCreateBoard(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	projectID := uuid.New()
@@ -78,7 +80,7 @@ func TestCreateDefaultBoard(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	projectID := uuid.New()
@@ -117,7 +119,7 @@ func TestGetBoard(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	boardID := uuid.New()
@@ -155,7 +157,7 @@ func TestGetBoardsByProjectID(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	projectID := uuid.New()
@@ -183,7 +185,7 @@ func TestGetDefaultBoard(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	projectID := uuid.New()
@@ -222,7 +224,7 @@ func TestDeleteBoard(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	t.Run("success - non-default board", func(t *testing.T) {
@@ -268,7 +270,7 @@ func TestCreateColumn(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	boardID := uuid.New()
@@ -316,7 +318,7 @@ func TestGetColumn(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	columnID := uuid.New()
@@ -346,6 +348,65 @@ func TestGetColumn(t *testing.T) {
 	})
 }
 
+func TestGetColumnStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	limit, pointLimit := 2, 5
+	expected := []*board_column.ColumnStats{
+		{ColumnID: uuid.New(), CardCount: 3, StoryPoints: 8, EstimatedCardCount: 2, WipLimit: &limit, PointLimit: &pointLimit},
+		{ColumnID: uuid.New()},
+	}
+	mockColumnRepo.EXPECT().
+		GetStatsByBoardID(gomock.Any(), boardID, gomock.Any()).
+		Return(expected, nil)
+
+	result, err := svc.GetColumnStats(ctx, boardID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.True(t, result[0].OverWipLimit())
+	assert.False(t, result[1].OverWipLimit())
+	assert.True(t, result[0].OverPointLimit())
+	assert.False(t, result[1].OverPointLimit())
+}
+
+func TestUpdateColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
+	ctx := context.Background()
+
+	t.Run("saves the point limit", func(t *testing.T) {
+		limit := 13
+		col := &board_column.BoardColumn{ID: uuid.New(), Name: "Doing", PointLimit: &limit}
+		mockColumnRepo.EXPECT().Update(gomock.Any(), col).Return(nil)
+
+		result, err := svc.UpdateColumn(ctx, col)
+		require.NoError(t, err)
+		assert.Equal(t, 13, *result.PointLimit)
+	})
+
+	t.Run("point limit below 1", func(t *testing.T) {
+		limit := 0
+		result, err := svc.UpdateColumn(ctx, &board_column.BoardColumn{ID: uuid.New(), PointLimit: &limit})
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrInvalidPointLimit)
+	})
+}
+
 func TestToggleColumnVisibility(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -354,7 +415,7 @@ func TestToggleColumnVisibility(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	columnID := uuid.New()
@@ -412,7 +473,7 @@ func TestReorderColumns(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	boardID := uuid.New()
@@ -458,7 +519,7 @@ func TestGetProject(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	boardID := uuid.New()
@@ -497,7 +558,7 @@ func TestGetBoardByColumnID(t *testing.T) {
 	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+	svc := NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	columnID := uuid.New()
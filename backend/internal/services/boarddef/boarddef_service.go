@@ -0,0 +1,424 @@
+package boarddef
+
+//go:generate mockgen -source=boarddef_service.go -destination=mocks/boarddef_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBoardNotFound   = errors.New("board not found")
+	ErrProjectNotFound = errors.New("project not found")
+)
+
+type Service interface {
+	// Export returns the board's columns, settings, workflow, column defaults and the SLA
+	// policies on its columns as a definition, without cards
+	Export(ctx context.Context, boardID uuid.UUID) (*Definition, error)
+	// Import creates a new board in the project from a definition, creating the tags it uses
+	// that the project doesn't have yet. The board is named after the definition unless a
+	// name is given.
+	Import(ctx context.Context, projectID uuid.UUID, def *Definition, name string, createdBy *uuid.UUID) (*board.Board, error)
+}
+
+type service struct {
+	boardRepo      board.Repository
+	columnRepo     board_column.Repository
+	defaultsRepo   column_defaults.Repository
+	transitionRepo column_transition.Repository
+	slaPolicyRepo  sla_policy.Repository
+	tagRepo        tag.Repository
+	projectRepo    project.Repository
+	contentSvc     content.Service
+	txManager      transaction.Manager
+	bus            events.Bus
+}
+
+func NewService(
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	defaultsRepo column_defaults.Repository,
+	transitionRepo column_transition.Repository,
+	slaPolicyRepo sla_policy.Repository,
+	tagRepo tag.Repository,
+	projectRepo project.Repository,
+	contentSvc content.Service,
+	txManager transaction.Manager,
+	bus events.Bus,
+) Service {
+	return &service{
+		boardRepo:      boardRepo,
+		columnRepo:     columnRepo,
+		defaultsRepo:   defaultsRepo,
+		transitionRepo: transitionRepo,
+		slaPolicyRepo:  slaPolicyRepo,
+		tagRepo:        tagRepo,
+		projectRepo:    projectRepo,
+		contentSvc:     contentSvc,
+		txManager:      txManager,
+		bus:            bus,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "boarddef.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "boarddef"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Export(ctx context.Context, boardID uuid.UUID) (*Definition, error) {
+	ctx, span := s.startServiceSpan(ctx, "Export")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+	projectTags, err := s.tagRepo.GetByProjectID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	tagsByID := make(map[uuid.UUID]*tag.Tag, len(projectTags))
+	for _, t := range projectTags {
+		tagsByID[t.ID] = t
+	}
+	usedTags := make(map[uuid.UUID]bool)
+	tagName := func(id uuid.UUID) (string, bool) {
+		t, ok := tagsByID[id]
+		if ok {
+			usedTags[id] = true
+			return t.Name, true
+		}
+		return "", false
+	}
+
+	def := &Definition{
+		Format:  Format,
+		Version: FormatVersion,
+		Board: BoardSettings{
+			Name:            b.Name,
+			Description:     b.Description,
+			AutoArchiveDays: b.AutoArchiveDays,
+
+			SprintAutoMembership: b.SprintAutoMembership,
+
+			BackgroundColor:    b.BackgroundColor,
+			BackgroundImageURL: b.BackgroundImageURL,
+			ColumnPalette:      b.ColumnPalette,
+		},
+		Columns: make([]ColumnDefinition, 0, len(columns)),
+	}
+
+	keys := make(map[uuid.UUID]string, len(columns))
+	taken := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		key := columnKey(col.Name)
+		for n := 2; taken[key]; n++ {
+			key = fmt.Sprintf("%s-%d", columnKey(col.Name), n)
+		}
+		taken[key] = true
+		keys[col.ID] = key
+
+		colDef := ColumnDefinition{
+			Key:        key,
+			Name:       col.Name,
+			Color:      col.Color,
+			IsBacklog:  col.IsBacklog,
+			IsHidden:   col.IsHidden,
+			IsDone:     col.IsDone,
+			WipLimit:   col.WipLimit,
+			PointLimit: col.PointLimit,
+		}
+		defaults, err := s.defaultsRepo.GetByColumnID(ctx, col.ID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			defaultsDef := &ColumnDefaultsDefinition{
+				Priority:    defaults.Priority,
+				StoryPoints: defaults.StoryPoints,
+				Description: defaults.Description,
+				Checklist:   defaults.Checklist,
+			}
+			for _, id := range defaults.TagIDs {
+				if name, ok := tagName(id); ok {
+					defaultsDef.Tags = append(defaultsDef.Tags, name)
+				}
+			}
+			// A column whose only default was an assignee has nothing left to export
+			if defaultsDef.Priority != nil || defaultsDef.StoryPoints != nil || defaultsDef.Description != "" ||
+				len(defaultsDef.Checklist) > 0 || len(defaultsDef.Tags) > 0 {
+				colDef.Defaults = defaultsDef
+			}
+		}
+		def.Columns = append(def.Columns, colDef)
+	}
+
+	transitions, err := s.transitionRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transitions {
+		from, okFrom := keys[t.FromColumnID]
+		to, okTo := keys[t.ToColumnID]
+		if okFrom && okTo {
+			def.Transitions = append(def.Transitions, TransitionDefinition{From: from, To: to})
+		}
+	}
+
+	policies, err := s.slaPolicyRepo.GetByProjectID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		column, ok := keys[p.ColumnID]
+		if !ok {
+			continue
+		}
+		policyDef := SLAPolicyDefinition{
+			Name:               p.Name,
+			Column:             column,
+			Priority:           p.Priority,
+			MaxDurationMinutes: p.MaxDurationMinutes,
+			EscalateToPriority: p.EscalateToPriority,
+		}
+		if p.BreachTagID != nil {
+			if name, ok := tagName(*p.BreachTagID); ok {
+				policyDef.BreachTag = &name
+			}
+		}
+		def.SLAPolicies = append(def.SLAPolicies, policyDef)
+	}
+
+	for _, t := range projectTags {
+		if usedTags[t.ID] {
+			def.Tags = append(def.Tags, TagDefinition{Name: t.Name, Color: t.Color, Description: t.Description})
+		}
+	}
+	sort.SliceStable(def.Tags, func(i, j int) bool { return def.Tags[i].Name < def.Tags[j].Name })
+
+	span.SetAttributes(attribute.Int("boarddef.columns", len(def.Columns)))
+	return def, nil
+}
+
+func (s *service) Import(ctx context.Context, projectID uuid.UUID, def *Definition, name string, createdBy *uuid.UUID) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "Import")
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.Int("boarddef.columns", len(def.Columns)),
+	)
+	defer span.End()
+
+	if name = strings.TrimSpace(name); name != "" {
+		renamed := *def
+		renamed.Board.Name = name
+		def = &renamed
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	b := &board.Board{
+		ProjectID:       projectID,
+		Name:            def.Board.Name,
+		Description:     def.Board.Description,
+		AutoArchiveDays: def.Board.AutoArchiveDays,
+		CreatedBy:       createdBy,
+
+		SprintAutoMembership: def.Board.SprintAutoMembership,
+
+		BackgroundColor:    strings.ToUpper(def.Board.BackgroundColor),
+		BackgroundImageURL: def.Board.BackgroundImageURL,
+		ColumnPalette:      def.Board.ColumnPalette,
+	}
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		tagIDs, err := s.ensureTags(ctx, projectID, def.Tags)
+		if err != nil {
+			return err
+		}
+		if err := s.boardRepo.Create(ctx, b); err != nil {
+			return err
+		}
+
+		columnIDs := make(map[string]uuid.UUID, len(def.Columns))
+		for i, colDef := range def.Columns {
+			col := &board_column.BoardColumn{
+				BoardID:    b.ID,
+				Name:       colDef.Name,
+				Position:   i,
+				IsBacklog:  colDef.IsBacklog,
+				IsHidden:   colDef.IsHidden,
+				IsDone:     colDef.IsDone,
+				Color:      colDef.Color,
+				WipLimit:   colDef.WipLimit,
+				PointLimit: colDef.PointLimit,
+			}
+			if col.Color == "" {
+				col.Color = "#6B7280"
+			}
+			if err := s.columnRepo.Create(ctx, col); err != nil {
+				return err
+			}
+			columnIDs[colDef.Key] = col.ID
+
+			if colDef.Defaults != nil {
+				if err := s.saveDefaults(ctx, b.ID, col.ID, colDef.Defaults, tagIDs); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(def.Transitions) > 0 {
+			rows := make([]*column_transition.ColumnTransition, 0, len(def.Transitions))
+			for _, t := range def.Transitions {
+				rows = append(rows, &column_transition.ColumnTransition{
+					BoardID:      b.ID,
+					FromColumnID: columnIDs[t.From],
+					ToColumnID:   columnIDs[t.To],
+				})
+			}
+			if err := s.transitionRepo.ReplaceForBoard(ctx, b.ID, rows); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range def.SLAPolicies {
+			policy := &sla_policy.SLAPolicy{
+				ProjectID:          projectID,
+				Name:               strings.TrimSpace(p.Name),
+				ColumnID:           columnIDs[p.Column],
+				Priority:           p.Priority,
+				MaxDurationMinutes: p.MaxDurationMinutes,
+				EscalateToPriority: p.EscalateToPriority,
+				CreatedBy:          createdBy,
+			}
+			if p.BreachTag != nil {
+				id := tagIDs[strings.ToLower(strings.TrimSpace(*p.BreachTag))]
+				policy.BreachTagID = &id
+			}
+			if err := s.slaPolicyRepo.Create(ctx, policy); err != nil {
+				return err
+			}
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.BoardCreated, events.BoardPayload{
+			BoardID:   b.ID,
+			ProjectID: b.ProjectID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ensureTags returns the IDs of the project's tags by lowercased name, creating the defined
+// tags the project doesn't have
+func (s *service) ensureTags(ctx context.Context, projectID uuid.UUID, defs []TagDefinition) (map[string]uuid.UUID, error) {
+	ids := make(map[string]uuid.UUID)
+	if len(defs) == 0 {
+		return ids, nil
+	}
+	existing, err := s.tagRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range existing {
+		ids[strings.ToLower(t.Name)] = t.ID
+	}
+	for _, def := range defs {
+		key := strings.ToLower(strings.TrimSpace(def.Name))
+		if _, ok := ids[key]; ok {
+			continue
+		}
+		t := &tag.Tag{
+			ProjectID:   projectID,
+			Name:        strings.TrimSpace(def.Name),
+			Color:       def.Color,
+			Description: def.Description,
+		}
+		if t.Color == "" {
+			t.Color = "#6B7280"
+		}
+		if err := s.tagRepo.Create(ctx, t); err != nil {
+			return nil, err
+		}
+		ids[key] = t.ID
+	}
+	return ids, nil
+}
+
+// saveDefaults stores a column's defaults the way card.Service.SetColumnDefaults does: the
+// description is sanitized and checked against the content limits and policy, and blank
+// checklist items are dropped
+func (s *service) saveDefaults(ctx context.Context, boardID, columnID uuid.UUID, def *ColumnDefaultsDefinition, tagIDs map[string]uuid.UUID) error {
+	defaults := &column_defaults.ColumnDefaults{
+		ColumnID:    columnID,
+		Priority:    def.Priority,
+		StoryPoints: def.StoryPoints,
+		Description: sanitize.HTML(def.Description),
+	}
+	if defaults.Description != "" {
+		if err := s.contentSvc.Check(ctx, boardID, content.FieldCardDescription, defaults.Description); err != nil {
+			return err
+		}
+	}
+	for _, item := range def.Checklist {
+		if item = strings.TrimSpace(item); item != "" {
+			defaults.Checklist = append(defaults.Checklist, item)
+		}
+	}
+	for _, name := range def.Tags {
+		if id := tagIDs[strings.ToLower(strings.TrimSpace(name))]; !slices.Contains(defaults.TagIDs, id) {
+			defaults.TagIDs = append(defaults.TagIDs, id)
+		}
+	}
+	if defaults.IsEmpty() {
+		return nil
+	}
+	return s.defaultsRepo.Save(ctx, defaults)
+}
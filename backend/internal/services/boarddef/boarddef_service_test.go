@@ -0,0 +1,246 @@
+package boarddef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	defaultsMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	transitionMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	slaPolicyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Todo", Position: 1, Color: "#3B82F6"}
+	review := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Review", Position: 2, Color: "#F59E0B"}
+	review2 := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "review", Position: 3, IsDone: true}
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Bug", Color: "#EF4444"}
+	unused := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Unused"}
+	high := card.PriorityHigh
+	wip, points := 3, 13
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+		mockSlaPolicyRepo := slaPolicyMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockDefaultsRepo, mockTransitionRepo, mockSlaPolicyRepo, mockTagRepo, mockProjectRepo,
+			content.NewService(nil, nil, nil, content.Limits{}), transaction.NewNoopManager(), events.NewSyncBus())
+
+		review.WipLimit = &wip
+		review.PointLimit = &points
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID, Name: "Support"}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{review2, todo, review}, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*tag.Tag{bug, unused}, nil)
+		assignee := uuid.New()
+		mockDefaultsRepo.EXPECT().GetByColumnID(gomock.Any(), todo.ID).
+			Return(&column_defaults.ColumnDefaults{ColumnID: todo.ID, Priority: &high, AssigneeID: &assignee, Checklist: column_defaults.Checklist{"Reproduce"}, TagIDs: []uuid.UUID{bug.ID}}, nil)
+		// Only an assignee, which isn't exported
+		mockDefaultsRepo.EXPECT().GetByColumnID(gomock.Any(), review.ID).
+			Return(&column_defaults.ColumnDefaults{ColumnID: review.ID, AssigneeID: &assignee}, nil)
+		mockDefaultsRepo.EXPECT().GetByColumnID(gomock.Any(), review2.ID).Return(nil, gorm.ErrRecordNotFound)
+		mockTransitionRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).
+			Return([]*column_transition.ColumnTransition{{FromColumnID: todo.ID, ToColumnID: review.ID}}, nil)
+		mockSlaPolicyRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*sla_policy.SLAPolicy{
+			{Name: "Review fast", ColumnID: review.ID, MaxDurationMinutes: 120, BreachTagID: &bug.ID},
+			{Name: "Other board", ColumnID: uuid.New(), MaxDurationMinutes: 5},
+		}, nil)
+
+		def, err := svc.Export(ctx, boardID)
+		require.NoError(t, err)
+
+		bugName := "Bug"
+		assert.Equal(t, &Definition{
+			Format:  Format,
+			Version: FormatVersion,
+			Board:   BoardSettings{Name: "Support"},
+			Tags:    []TagDefinition{{Name: "Bug", Color: "#EF4444"}},
+			Columns: []ColumnDefinition{
+				{Key: "todo", Name: "Todo", Color: "#3B82F6", Defaults: &ColumnDefaultsDefinition{Priority: &high, Checklist: []string{"Reproduce"}, Tags: []string{"Bug"}}},
+				{Key: "review", Name: "Review", Color: "#F59E0B", WipLimit: &wip, PointLimit: &points},
+				{Key: "review-2", Name: "review", IsDone: true},
+			},
+			Transitions: []TransitionDefinition{{From: "todo", To: "review"}},
+			SLAPolicies: []SLAPolicyDefinition{{Name: "Review fast", Column: "review", MaxDurationMinutes: 120, BreachTag: &bugName}},
+		}, def)
+	})
+
+	t.Run("error - board not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+		mockSlaPolicyRepo := slaPolicyMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockDefaultsRepo, mockTransitionRepo, mockSlaPolicyRepo, mockTagRepo, mockProjectRepo,
+			content.NewService(nil, nil, nil, content.Limits{}), transaction.NewNoopManager(), events.NewSyncBus())
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Export(ctx, boardID)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	userID := uuid.New()
+	high := card.PriorityHigh
+	overdue := "overdue"
+	def := &Definition{
+		Format:  Format,
+		Version: FormatVersion,
+		Board:   BoardSettings{Name: "Support"},
+		Tags:    []TagDefinition{{Name: "Bug"}, {Name: "Overdue", Color: "#EF4444"}},
+		Columns: []ColumnDefinition{
+			{Key: "new", Name: "New", Defaults: &ColumnDefaultsDefinition{Priority: &high, Checklist: []string{" Reproduce ", ""}, Tags: []string{"bug", "Bug"}}},
+			{Key: "done", Name: "Done", Color: "#10B981", IsDone: true},
+		},
+		Transitions: []TransitionDefinition{{From: "new", To: "done"}},
+		SLAPolicies: []SLAPolicyDefinition{{Name: "Triage", Column: "new", MaxDurationMinutes: 60, BreachTag: &overdue}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+		mockSlaPolicyRepo := slaPolicyMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockDefaultsRepo, mockTransitionRepo, mockSlaPolicyRepo, mockTagRepo, mockProjectRepo,
+			content.NewService(nil, nil, nil, content.Limits{}), transaction.NewNoopManager(), events.NewSyncBus())
+
+		bug := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "BUG"}
+		overdueID := uuid.New()
+		boardID := uuid.New()
+		var columnIDs []uuid.UUID
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*tag.Tag{bug}, nil)
+		mockTagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tg *tag.Tag) error {
+			assert.Equal(t, "Overdue", tg.Name)
+			assert.Equal(t, "#EF4444", tg.Color)
+			tg.ID = overdueID
+			return nil
+		})
+		mockBoardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, b *board.Board) error {
+			assert.Equal(t, "Support (copy)", b.Name)
+			assert.False(t, b.IsDefault)
+			b.ID = boardID
+			return nil
+		})
+		mockColumnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, col *board_column.BoardColumn) error {
+			assert.Equal(t, boardID, col.BoardID)
+			assert.Equal(t, len(columnIDs), col.Position)
+			col.ID = uuid.New()
+			columnIDs = append(columnIDs, col.ID)
+			return nil
+		}).Times(2)
+		mockDefaultsRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *column_defaults.ColumnDefaults) error {
+			assert.Equal(t, columnIDs[0], d.ColumnID)
+			assert.Equal(t, column_defaults.Checklist{"Reproduce"}, d.Checklist)
+			assert.Equal(t, []uuid.UUID{bug.ID}, d.TagIDs)
+			return nil
+		})
+		mockTransitionRepo.EXPECT().ReplaceForBoard(gomock.Any(), boardID, gomock.Any()).DoAndReturn(func(ctx context.Context, _ uuid.UUID, rows []*column_transition.ColumnTransition) error {
+			require.Len(t, rows, 1)
+			assert.Equal(t, columnIDs[0], rows[0].FromColumnID)
+			assert.Equal(t, columnIDs[1], rows[0].ToColumnID)
+			return nil
+		})
+		mockSlaPolicyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, p *sla_policy.SLAPolicy) error {
+			assert.Equal(t, projectID, p.ProjectID)
+			assert.Equal(t, columnIDs[0], p.ColumnID)
+			assert.Equal(t, &overdueID, p.BreachTagID)
+			assert.Equal(t, &userID, p.CreatedBy)
+			return nil
+		})
+
+		b, err := svc.Import(ctx, projectID, def, " Support (copy) ", &userID)
+		require.NoError(t, err)
+		assert.Equal(t, boardID, b.ID)
+		// The caller's definition is left as it was
+		assert.Equal(t, "Support", def.Board.Name)
+	})
+
+	t.Run("error - invalid definition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+		mockSlaPolicyRepo := slaPolicyMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockDefaultsRepo, mockTransitionRepo, mockSlaPolicyRepo, mockTagRepo, mockProjectRepo,
+			content.NewService(nil, nil, nil, content.Limits{}), transaction.NewNoopManager(), events.NewSyncBus())
+
+		_, err := svc.Import(ctx, projectID, &Definition{Format: Format, Version: FormatVersion, Board: BoardSettings{Name: "Empty"}}, "", &userID)
+		assert.ErrorIs(t, err, ErrInvalidDefinition)
+	})
+
+	t.Run("error - project not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+		mockSlaPolicyRepo := slaPolicyMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockDefaultsRepo, mockTransitionRepo, mockSlaPolicyRepo, mockTagRepo, mockProjectRepo,
+			content.NewService(nil, nil, nil, content.Limits{}), transaction.NewNoopManager(), events.NewSyncBus())
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Import(ctx, projectID, def, "", &userID)
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+}
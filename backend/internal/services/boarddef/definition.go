@@ -0,0 +1,319 @@
+package boarddef
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/appearance"
+)
+
+const (
+	// Format identifies a board definition document
+	Format = "kaimu.board"
+	// FormatVersion is the version Export writes and the newest one Parse reads. Since unknown
+	// fields are rejected, bump it for any new field. Version 2 added the board's appearance.
+	FormatVersion = 2
+	// MaxDefinitionSize is the largest definition document Parse accepts, in bytes
+	MaxDefinitionSize = 1 << 20
+
+	maxColumns             = 100
+	maxNameLength          = 255
+	maxTagNameLength       = 100
+	maxChecklistItemLength = 500
+)
+
+var (
+	ErrInvalidDefinition  = errors.New("invalid board definition")
+	ErrUnsupportedVersion = errors.New("unsupported board definition version")
+
+	colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// Definition is a board's structure without its cards, in a form that can be committed to a
+// repository or imported into another organization. Columns are referred to by their keys
+// and tags by their names, so a definition carries no IDs.
+type Definition struct {
+	Format      string                 `json:"format"`
+	Version     int                    `json:"version"`
+	Board       BoardSettings          `json:"board"`
+	Tags        []TagDefinition        `json:"tags,omitempty"`
+	Columns     []ColumnDefinition     `json:"columns"`
+	Transitions []TransitionDefinition `json:"transitions,omitempty"`
+	SLAPolicies []SLAPolicyDefinition  `json:"slaPolicies,omitempty"`
+}
+
+// BoardSettings are the board's own settings
+type BoardSettings struct {
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	AutoArchiveDays *int   `json:"autoArchiveDays,omitempty"`
+	// SprintAutoMembership is the backlog column policy
+	SprintAutoMembership bool `json:"sprintAutoMembership,omitempty"`
+	// Appearance is left out of version 1 definitions
+	BackgroundColor    string `json:"backgroundColor,omitempty"`
+	BackgroundImageURL string `json:"backgroundImageUrl,omitempty"`
+	ColumnPalette      string `json:"columnPalette,omitempty"`
+}
+
+// TagDefinition is a project tag used by column defaults or SLA policies. On import it is
+// matched to the project's tags by name, case-insensitively, and created when missing.
+type TagDefinition struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ColumnDefinition is a column, in board order
+type ColumnDefinition struct {
+	// Key identifies the column within the definition
+	Key        string                    `json:"key"`
+	Name       string                    `json:"name"`
+	Color      string                    `json:"color,omitempty"`
+	IsBacklog  bool                      `json:"isBacklog,omitempty"`
+	IsHidden   bool                      `json:"isHidden,omitempty"`
+	IsDone     bool                      `json:"isDone,omitempty"`
+	WipLimit   *int                      `json:"wipLimit,omitempty"`
+	PointLimit *int                      `json:"pointLimit,omitempty"`
+	Defaults   *ColumnDefaultsDefinition `json:"defaults,omitempty"`
+}
+
+// ColumnDefaultsDefinition is the template applied to cards created in a column. Default
+// assignees are people of one organization and aren't part of definitions.
+type ColumnDefaultsDefinition struct {
+	Priority    *card.CardPriority `json:"priority,omitempty"`
+	StoryPoints *int               `json:"storyPoints,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Checklist   []string           `json:"checklist,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+}
+
+// TransitionDefinition is a move the board workflow allows, between column keys
+type TransitionDefinition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SLAPolicyDefinition is an SLA policy on one of the board's columns
+type SLAPolicyDefinition struct {
+	Name               string             `json:"name"`
+	Column             string             `json:"column"`
+	Priority           *card.CardPriority `json:"priority,omitempty"`
+	MaxDurationMinutes int                `json:"maxDurationMinutes"`
+	EscalateToPriority *card.CardPriority `json:"escalateToPriority,omitempty"`
+	BreachTag          *string            `json:"breachTag,omitempty"`
+}
+
+// Parse reads and validates a definition document. Unknown fields are rejected, so typos in
+// hand-edited definitions don't go unnoticed.
+func Parse(data []byte) (*Definition, error) {
+	if len(data) > MaxDefinitionSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrInvalidDefinition, MaxDefinitionSize)
+	}
+
+	// The version is checked first, so a newer document fails as unsupported rather than
+	// on the fields this version doesn't know
+	var header struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDefinition, err)
+	}
+	if header.Format != Format {
+		return nil, fmt.Errorf("%w: format must be %q", ErrInvalidDefinition, Format)
+	}
+	if header.Version < 1 || header.Version > FormatVersion {
+		return nil, fmt.Errorf("%w: %d, this server reads versions up to %d", ErrUnsupportedVersion, header.Version, FormatVersion)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var def Definition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDefinition, err)
+	}
+	if def.Version < 2 && def.Board.appearance() != (appearance.Appearance{}) {
+		return nil, fmt.Errorf("%w: board appearance needs version 2", ErrInvalidDefinition)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// Validate checks that the definition can be imported: names and limits are within bounds
+// and every column key and tag name it refers to is defined
+func (d *Definition) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: "+format, append([]any{ErrInvalidDefinition}, args...)...)
+	}
+
+	if strings.TrimSpace(d.Board.Name) == "" {
+		return invalid("board name is required")
+	}
+	if utf8.RuneCountInString(d.Board.Name) > maxNameLength {
+		return invalid("board name is longer than %d characters", maxNameLength)
+	}
+	if d.Board.AutoArchiveDays != nil && *d.Board.AutoArchiveDays < 1 {
+		return invalid("autoArchiveDays must be at least 1")
+	}
+	if err := d.Board.appearance().Validate(); err != nil {
+		return invalid("%v", err)
+	}
+
+	tags := make(map[string]bool)
+	for _, t := range d.Tags {
+		name := strings.ToLower(strings.TrimSpace(t.Name))
+		if name == "" {
+			return invalid("tag name is required")
+		}
+		if utf8.RuneCountInString(t.Name) > maxTagNameLength {
+			return invalid("tag %q is longer than %d characters", t.Name, maxTagNameLength)
+		}
+		if tags[name] {
+			return invalid("tag %q is listed more than once", t.Name)
+		}
+		if t.Color != "" && !colorPattern.MatchString(t.Color) {
+			return invalid("tag %q color must look like #RRGGBB", t.Name)
+		}
+		tags[name] = true
+	}
+	checkTag := func(name string) error {
+		if !tags[strings.ToLower(strings.TrimSpace(name))] {
+			return invalid("tag %q is not listed in tags", name)
+		}
+		return nil
+	}
+
+	if len(d.Columns) == 0 {
+		return invalid("at least one column is required")
+	}
+	if len(d.Columns) > maxColumns {
+		return invalid("at most %d columns are allowed", maxColumns)
+	}
+	keys := make(map[string]bool)
+	for _, col := range d.Columns {
+		if col.Key == "" {
+			return invalid("column key is required")
+		}
+		if keys[col.Key] {
+			return invalid("column key %q is used more than once", col.Key)
+		}
+		keys[col.Key] = true
+		if strings.TrimSpace(col.Name) == "" {
+			return invalid("column %q name is required", col.Key)
+		}
+		if utf8.RuneCountInString(col.Name) > maxNameLength {
+			return invalid("column %q name is longer than %d characters", col.Key, maxNameLength)
+		}
+		if col.Color != "" && !colorPattern.MatchString(col.Color) {
+			return invalid("column %q color must look like #RRGGBB", col.Key)
+		}
+		if col.WipLimit != nil && *col.WipLimit < 1 {
+			return invalid("column %q wipLimit must be at least 1", col.Key)
+		}
+		if col.PointLimit != nil && *col.PointLimit < 1 {
+			return invalid("column %q pointLimit must be at least 1", col.Key)
+		}
+		if def := col.Defaults; def != nil {
+			if def.Priority != nil && !validPriority(*def.Priority) {
+				return invalid("column %q default priority %q is unknown", col.Key, *def.Priority)
+			}
+			if def.StoryPoints != nil && *def.StoryPoints < 0 {
+				return invalid("column %q default storyPoints must not be negative", col.Key)
+			}
+			for _, item := range def.Checklist {
+				if utf8.RuneCountInString(item) > maxChecklistItemLength {
+					return invalid("column %q checklist items are limited to %d characters", col.Key, maxChecklistItemLength)
+				}
+			}
+			for _, name := range def.Tags {
+				if err := checkTag(name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	transitions := make(map[TransitionDefinition]bool)
+	for _, t := range d.Transitions {
+		if !keys[t.From] || !keys[t.To] {
+			return invalid("transition %q to %q refers to an unknown column", t.From, t.To)
+		}
+		if t.From == t.To {
+			return invalid("transition %q to %q must connect two different columns", t.From, t.To)
+		}
+		if transitions[t] {
+			return invalid("transition %q to %q is listed more than once", t.From, t.To)
+		}
+		transitions[t] = true
+	}
+
+	for _, p := range d.SLAPolicies {
+		if strings.TrimSpace(p.Name) == "" {
+			return invalid("SLA policy name is required")
+		}
+		if !keys[p.Column] {
+			return invalid("SLA policy %q refers to unknown column %q", p.Name, p.Column)
+		}
+		if p.MaxDurationMinutes < 1 {
+			return invalid("SLA policy %q maxDurationMinutes must be at least 1", p.Name)
+		}
+		if p.Priority != nil && !validPriority(*p.Priority) {
+			return invalid("SLA policy %q priority %q is unknown", p.Name, *p.Priority)
+		}
+		if p.EscalateToPriority != nil && !validPriority(*p.EscalateToPriority) {
+			return invalid("SLA policy %q escalateToPriority %q is unknown", p.Name, *p.EscalateToPriority)
+		}
+		if p.BreachTag != nil {
+			if err := checkTag(*p.BreachTag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b BoardSettings) appearance() appearance.Appearance {
+	return appearance.Appearance{
+		BackgroundColor:    b.BackgroundColor,
+		BackgroundImageURL: b.BackgroundImageURL,
+		ColumnPalette:      b.ColumnPalette,
+	}
+}
+
+func validPriority(p card.CardPriority) bool {
+	switch p {
+	case card.PriorityNone, card.PriorityLow, card.PriorityMedium, card.PriorityHigh, card.PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// columnKey derives a readable key from a column name, e.g. "In Progress" becomes
+// "in-progress"
+func columnKey(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "column"
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package boarddef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		def, err := Parse([]byte(`{
+			"format": "kaimu.board",
+			"version": 2,
+			"board": {"name": "Support", "autoArchiveDays": 14, "backgroundColor": "#0F172A", "columnPalette": "ocean"},
+			"tags": [{"name": "Overdue", "color": "#EF4444"}],
+			"columns": [
+				{"key": "new", "name": "New", "defaults": {"priority": "high", "tags": ["overdue"]}},
+				{"key": "done", "name": "Done", "isDone": true}
+			],
+			"transitions": [{"from": "new", "to": "done"}],
+			"slaPolicies": [{"name": "Triage", "column": "new", "maxDurationMinutes": 60, "breachTag": "Overdue"}]
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Support", def.Board.Name)
+		assert.Equal(t, "ocean", def.Board.ColumnPalette)
+		require.Len(t, def.Columns, 2)
+		assert.Equal(t, card.PriorityHigh, *def.Columns[0].Defaults.Priority)
+		assert.Equal(t, []TransitionDefinition{{From: "new", To: "done"}}, def.Transitions)
+	})
+
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"not JSON", `columns:`, ErrInvalidDefinition},
+		{"wrong format", `{"format": "trello", "version": 1}`, ErrInvalidDefinition},
+		{"newer version", `{"format": "kaimu.board", "version": 3, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A"}], "swimlanes": []}`, ErrUnsupportedVersion},
+		{"unknown field", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A", "wip": 3}]}`, ErrInvalidDefinition},
+		{"no columns", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": []}`, ErrInvalidDefinition},
+		{"duplicate column key", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A"}, {"key": "a", "name": "B"}]}`, ErrInvalidDefinition},
+		{"unknown transition column", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A"}], "transitions": [{"from": "a", "to": "b"}]}`, ErrInvalidDefinition},
+		{"unlisted tag", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A", "defaults": {"tags": ["Bug"]}}]}`, ErrInvalidDefinition},
+		{"unknown priority", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A"}], "slaPolicies": [{"name": "P", "column": "a", "maxDurationMinutes": 5, "priority": "critical"}]}`, ErrInvalidDefinition},
+		{"appearance in version 1", `{"format": "kaimu.board", "version": 1, "board": {"name": "B", "columnPalette": "ocean"}, "columns": [{"key": "a", "name": "A"}]}`, ErrInvalidDefinition},
+		{"unknown palette", `{"format": "kaimu.board", "version": 2, "board": {"name": "B", "columnPalette": "neon"}, "columns": [{"key": "a", "name": "A"}]}`, ErrInvalidDefinition},
+		{"bad color", `{"format": "kaimu.board", "version": 1, "board": {"name": "B"}, "columns": [{"key": "a", "name": "A", "color": "red"}]}`, ErrInvalidDefinition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestColumnKey(t *testing.T) {
+	assert.Equal(t, "in-progress", columnKey("In Progress"))
+	assert.Equal(t, "qa-review", columnKey("  QA / Review!"))
+	assert.Equal(t, "column", columnKey("🚀"))
+}
@@ -0,0 +1,345 @@
+package branding
+
+//go:generate mockgen -source=branding_service.go -destination=mocks/branding_service_mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	netmail "net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// MaxFromNameLength is the longest sender name accepted
+	MaxFromNameLength = 100
+	// MaxLogoURLLength is the longest logo reference accepted
+	MaxLogoURLLength = 2048
+	// verificationPrefix starts the TXT record proving the organization owns its domain
+	verificationPrefix = "kaimu-verification="
+	// verificationHost is the name under the domain holding the ownership record
+	verificationHost = "_kaimu-verification"
+)
+
+var (
+	ErrInvalidFromName    = errors.New("sender name must be at most 100 characters on one line")
+	ErrInvalidFromEmail   = errors.New("sender address must be a plain email address")
+	ErrInvalidLogoURL     = errors.New("logo must be an https URL of at most 2048 characters")
+	ErrInvalidAccentColor = errors.New("accent color must look like #RRGGBB")
+	ErrNoDomain           = errors.New("set a sender address before verifying its domain")
+
+	colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// Input describes an organization's branding; empty fields use the platform's
+type Input struct {
+	FromName    string
+	FromEmail   string
+	LogoURL     string
+	AccentColor string
+}
+
+// DNSRecord is a TXT record an organization publishes to send mail from its domain
+type DNSRecord struct {
+	// Purpose is "ownership", "spf" or "dkim"
+	Purpose  string
+	Name     string
+	Value    string
+	Verified bool
+}
+
+// Resolver looks up DNS TXT records; *net.Resolver implements it
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type Service interface {
+	// GetBranding returns the organization's branding, or the defaults when it has none
+	GetBranding(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error)
+	// UpdateBranding replaces the organization's branding. A sender address on a new domain
+	// gets a new verification token and is only used once VerifyDomain passes.
+	UpdateBranding(ctx context.Context, orgID uuid.UUID, input Input) (*organization_branding.OrganizationBranding, error)
+	// VerifyDomain checks the ownership, SPF and DKIM records of the sender address's domain
+	// and records which ones pass
+	VerifyDomain(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error)
+	// DNSRecords returns the records the organization has to publish for its sender domain
+	DNSRecords(b *organization_branding.OrganizationBranding) []DNSRecord
+	// ForOrganization returns the branding to send the organization's mail with, or nil
+	ForOrganization(ctx context.Context, orgID uuid.UUID) *mail.Branding
+	// ForProject is ForOrganization for mail about a project
+	ForProject(ctx context.Context, projectID uuid.UUID) *mail.Branding
+}
+
+type service struct {
+	brandingRepo organization_branding.Repository
+	projectRepo  project.Repository
+	resolver     Resolver
+	config       config.EmailConfig
+	now          func() time.Time
+}
+
+func NewService(brandingRepo organization_branding.Repository, projectRepo project.Repository, resolver Resolver, cfg config.EmailConfig) Service {
+	return &service{
+		brandingRepo: brandingRepo,
+		projectRepo:  projectRepo,
+		resolver:     resolver,
+		config:       cfg,
+		now:          time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "branding.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "branding"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetBranding(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBranding")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.brandingRepo.GetByOrgID(ctx, orgID)
+}
+
+func (s *service) UpdateBranding(ctx context.Context, orgID uuid.UUID, input Input) (*organization_branding.OrganizationBranding, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateBranding")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	input.FromName = strings.TrimSpace(input.FromName)
+	input.FromEmail = strings.TrimSpace(input.FromEmail)
+	input.LogoURL = strings.TrimSpace(input.LogoURL)
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	b, err := s.brandingRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	previousDomain := b.Domain()
+
+	b.FromName = input.FromName
+	b.FromEmail = input.FromEmail
+	b.LogoURL = input.LogoURL
+	b.AccentColor = strings.ToUpper(input.AccentColor)
+
+	// A new domain has to be verified from scratch
+	if domain := b.Domain(); domain != previousDomain || b.VerificationToken == "" {
+		b.OwnershipVerified, b.SPFVerified, b.DKIMVerified = false, false, false
+		b.DomainVerifiedAt, b.DomainCheckedAt = nil, nil
+		b.VerificationToken = ""
+		if domain != "" {
+			if b.VerificationToken, err = generateToken(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.brandingRepo.Save(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *service) VerifyDomain(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error) {
+	ctx, span := s.startServiceSpan(ctx, "VerifyDomain")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	b, err := s.brandingRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	domain := b.Domain()
+	if domain == "" {
+		return nil, ErrNoDomain
+	}
+	span.SetAttributes(attribute.String("branding.domain", domain))
+
+	records := s.DNSRecords(b)
+	passed := make([]bool, len(records))
+	for i, record := range records {
+		values, err := s.lookupTXT(ctx, record.Name)
+		if err != nil {
+			return nil, err
+		}
+		passed[i] = s.matches(record.Purpose, record.Value, values)
+	}
+	b.OwnershipVerified, b.SPFVerified, b.DKIMVerified = passed[0], passed[1], passed[2]
+
+	now := s.now()
+	b.DomainCheckedAt = &now
+	if !b.OwnershipVerified || !b.SPFVerified || !b.DKIMVerified {
+		b.DomainVerifiedAt = nil
+	} else if b.DomainVerifiedAt == nil {
+		b.DomainVerifiedAt = &now
+	}
+
+	if err := s.brandingRepo.Save(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *service) DNSRecords(b *organization_branding.OrganizationBranding) []DNSRecord {
+	domain := b.Domain()
+	if domain == "" {
+		return nil
+	}
+
+	spf := "v=spf1 include:" + s.config.SPFInclude + " ~all"
+	if s.config.SPFInclude == "" {
+		spf = "v=spf1 ~all"
+	}
+	dkim := "v=DKIM1; k=rsa; p=" + s.config.DKIMPublicKey
+	return []DNSRecord{
+		{Purpose: "ownership", Name: verificationHost + "." + domain, Value: verificationPrefix + b.VerificationToken, Verified: b.OwnershipVerified},
+		{Purpose: "spf", Name: domain, Value: spf, Verified: b.SPFVerified},
+		{Purpose: "dkim", Name: s.config.DKIMSelector + "._domainkey." + domain, Value: dkim, Verified: b.DKIMVerified},
+	}
+}
+
+func (s *service) ForOrganization(ctx context.Context, orgID uuid.UUID) *mail.Branding {
+	ctx, span := s.startServiceSpan(ctx, "ForOrganization")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	// Unbranded mail beats no mail, so lookup failures only get traced
+	b, err := s.brandingRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		span.RecordError(err)
+		return nil
+	}
+
+	branding := &mail.Branding{LogoURL: b.LogoURL, AccentColor: b.AccentColor}
+	if b.DomainVerified() {
+		branding.FromName = b.FromName
+		branding.FromEmail = b.FromEmail
+	}
+	return branding
+}
+
+func (s *service) ForProject(ctx context.Context, projectID uuid.UUID) *mail.Branding {
+	ctx, span := s.startServiceSpan(ctx, "ForProject")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return nil
+	}
+	return s.ForOrganization(ctx, p.OrganizationID)
+}
+
+// lookupTXT returns the TXT records of name, none when it does not exist
+func (s *service) lookupTXT(ctx context.Context, name string) ([]string, error) {
+	values, err := s.resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return values, err
+}
+
+// matches reports whether one of the published values satisfies the expected record
+func (s *service) matches(purpose, expected string, values []string) bool {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		switch purpose {
+		case "ownership":
+			if value == expected {
+				return true
+			}
+		case "spf":
+			if !strings.HasPrefix(value, "v=spf1") {
+				continue
+			}
+			if s.config.SPFInclude == "" {
+				return true
+			}
+			for _, term := range strings.Fields(value) {
+				if strings.TrimLeft(term, "+") == "include:"+s.config.SPFInclude {
+					return true
+				}
+			}
+		case "dkim":
+			tags := dkimTags(value)
+			if tags["v"] != "DKIM1" {
+				continue
+			}
+			if s.config.DKIMPublicKey == "" || tags["p"] == s.config.DKIMPublicKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dkimTags parses a DKIM record's tag=value list, dropping the whitespace allowed in values
+func dkimTags(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(record, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.Join(strings.Fields(value), "")
+	}
+	return tags
+}
+
+func (i Input) validate() error {
+	if utf8.RuneCountInString(i.FromName) > MaxFromNameLength || strings.ContainsAny(i.FromName, "\r\n") {
+		return ErrInvalidFromName
+	}
+	if i.FromEmail != "" {
+		addr, err := netmail.ParseAddress(i.FromEmail)
+		if err != nil || addr.Name != "" || addr.Address != i.FromEmail {
+			return ErrInvalidFromEmail
+		}
+	}
+	if i.LogoURL != "" {
+		u, err := url.Parse(i.LogoURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" || len(i.LogoURL) > MaxLogoURLLength {
+			return ErrInvalidLogoURL
+		}
+	}
+	if i.AccentColor != "" && !colorPattern.MatchString(i.AccentColor) {
+		return ErrInvalidAccentColor
+	}
+	return nil
+}
+
+// generateToken creates the random value of the ownership record
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
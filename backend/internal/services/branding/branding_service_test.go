@@ -0,0 +1,224 @@
+package branding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding"
+	brandingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding/mocks"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeResolver serves TXT records from a map; other names do not exist
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	values, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return values, nil
+}
+
+var testConfig = config.EmailConfig{SPFInclude: "_spf.kaimu.test", DKIMSelector: "kaimu", DKIMPublicKey: "MIGfMA0GCSqGSIb3"}
+
+func TestUpdateBranding(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("fail - invalid input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewService(brandingMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), &fakeResolver{}, testConfig).(*service)
+
+		cases := map[error]Input{
+			ErrInvalidFromName:    {FromName: "Acme\r\nBcc: someone@example.com"},
+			ErrInvalidFromEmail:   {FromEmail: "Acme <team@acme.example>"},
+			ErrInvalidLogoURL:     {LogoURL: "http://acme.example/logo.png"},
+			ErrInvalidAccentColor: {AccentColor: "red"},
+		}
+		for want, input := range cases {
+			_, err := svc.UpdateBranding(ctx, orgID, input)
+			assert.ErrorIs(t, err, want)
+		}
+	})
+
+	t.Run("success - a new domain restarts verification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{}, testConfig).(*service)
+
+		verifiedAt := time.Now()
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(&organization_branding.OrganizationBranding{
+			OrganizationID:    orgID,
+			FromEmail:         "team@old.example",
+			VerificationToken: "old",
+			OwnershipVerified: true,
+			SPFVerified:       true,
+			DKIMVerified:      true,
+			DomainVerifiedAt:  &verifiedAt,
+		}, nil)
+		mockBrandingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.UpdateBranding(ctx, orgID, Input{FromName: "Acme", FromEmail: "team@acme.example", AccentColor: "#ff5500"})
+		require.NoError(t, err)
+		assert.Equal(t, "acme.example", b.Domain())
+		assert.Equal(t, "#FF5500", b.AccentColor)
+		assert.Len(t, b.VerificationToken, 32)
+		assert.False(t, b.OwnershipVerified)
+		assert.Nil(t, b.DomainVerifiedAt)
+	})
+
+	t.Run("success - the same domain stays verified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{}, testConfig).(*service)
+
+		verifiedAt := time.Now()
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(&organization_branding.OrganizationBranding{
+			OrganizationID:    orgID,
+			FromEmail:         "team@acme.example",
+			VerificationToken: "token",
+			DomainVerifiedAt:  &verifiedAt,
+		}, nil)
+		mockBrandingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.UpdateBranding(ctx, orgID, Input{FromEmail: "news@ACME.example"})
+		require.NoError(t, err)
+		assert.Equal(t, "token", b.VerificationToken)
+		assert.True(t, b.DomainVerified())
+	})
+}
+
+func TestVerifyDomain(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	unverified := func() *organization_branding.OrganizationBranding {
+		return &organization_branding.OrganizationBranding{OrganizationID: orgID, FromEmail: "team@acme.example", VerificationToken: "abc123"}
+	}
+	published := map[string][]string{
+		"_kaimu-verification.acme.example": {"kaimu-verification=abc123"},
+		"acme.example":                     {"google-site-verification=x", "v=spf1 include:_spf.google.com include:_spf.kaimu.test ~all"},
+		"kaimu._domainkey.acme.example":    {"v=DKIM1; k=rsa; p=MIGfMA0G CSqGSIb3"},
+	}
+
+	t.Run("success - all records published", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{records: published}, testConfig).(*service)
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(unverified(), nil)
+		mockBrandingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.VerifyDomain(ctx, orgID)
+		require.NoError(t, err)
+		assert.True(t, b.OwnershipVerified)
+		assert.True(t, b.SPFVerified)
+		assert.True(t, b.DKIMVerified)
+		assert.True(t, b.DomainVerified())
+		assert.NotNil(t, b.DomainCheckedAt)
+	})
+
+	t.Run("success - a missing record keeps the domain unverified", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		records := map[string][]string{
+			"_kaimu-verification.acme.example": published["_kaimu-verification.acme.example"],
+			"acme.example":                     {"v=spf1 include:_spf.google.com ~all"},
+		}
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{records: records}, testConfig).(*service)
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(unverified(), nil)
+		mockBrandingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.VerifyDomain(ctx, orgID)
+		require.NoError(t, err)
+		assert.True(t, b.OwnershipVerified)
+		assert.False(t, b.SPFVerified)
+		assert.False(t, b.DKIMVerified)
+		assert.Nil(t, b.DomainVerifiedAt)
+	})
+
+	t.Run("fail - no sender address", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{}, testConfig).(*service)
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(organization_branding.Default(orgID), nil)
+
+		_, err := svc.VerifyDomain(ctx, orgID)
+		assert.ErrorIs(t, err, ErrNoDomain)
+	})
+
+	t.Run("fail - lookup error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		lookupErr := errors.New("i/o timeout")
+		mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{err: lookupErr}, testConfig).(*service)
+
+		mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(unverified(), nil)
+
+		_, err := svc.VerifyDomain(ctx, orgID)
+		assert.ErrorIs(t, err, lookupErr)
+	})
+}
+
+func TestForOrganization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBrandingRepo := brandingMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockBrandingRepo, projectMocks.NewMockRepository(ctrl), &fakeResolver{}, testConfig).(*service)
+
+	orgID := uuid.New()
+
+	mockBrandingRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(&organization_branding.OrganizationBranding{
+		OrganizationID: orgID,
+		FromName:       "Acme",
+		FromEmail:      "team@acme.example",
+		LogoURL:        "https://acme.example/logo.png",
+		AccentColor:    "#FF5500",
+	}, nil)
+
+	// The sender waits for the domain to be verified, the look doesn't
+	branding := svc.ForOrganization(context.Background(), orgID)
+	require.NotNil(t, branding)
+	assert.Empty(t, branding.FromEmail)
+	assert.Empty(t, branding.FromName)
+	assert.Equal(t, "https://acme.example/logo.png", branding.LogoURL)
+	assert.Equal(t, "#FF5500", branding.AccentColor)
+}
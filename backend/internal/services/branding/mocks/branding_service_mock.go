@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: branding_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=branding_service.go -destination=mocks/branding_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	organization_branding "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_branding"
+	branding "github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	mail "github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResolver is a mock of Resolver interface.
+type MockResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockResolverMockRecorder is the mock recorder for MockResolver.
+type MockResolverMockRecorder struct {
+	mock *MockResolver
+}
+
+// NewMockResolver creates a new mock instance.
+func NewMockResolver(ctrl *gomock.Controller) *MockResolver {
+	mock := &MockResolver{ctrl: ctrl}
+	mock.recorder = &MockResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResolver) EXPECT() *MockResolverMockRecorder {
+	return m.recorder
+}
+
+// LookupTXT mocks base method.
+func (m *MockResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupTXT", ctx, name)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupTXT indicates an expected call of LookupTXT.
+func (mr *MockResolverMockRecorder) LookupTXT(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupTXT", reflect.TypeOf((*MockResolver)(nil).LookupTXT), ctx, name)
+}
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// DNSRecords mocks base method.
+func (m *MockService) DNSRecords(b *organization_branding.OrganizationBranding) []branding.DNSRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DNSRecords", b)
+	ret0, _ := ret[0].([]branding.DNSRecord)
+	return ret0
+}
+
+// DNSRecords indicates an expected call of DNSRecords.
+func (mr *MockServiceMockRecorder) DNSRecords(b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNSRecords", reflect.TypeOf((*MockService)(nil).DNSRecords), b)
+}
+
+// ForOrganization mocks base method.
+func (m *MockService) ForOrganization(ctx context.Context, orgID uuid.UUID) *mail.Branding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForOrganization", ctx, orgID)
+	ret0, _ := ret[0].(*mail.Branding)
+	return ret0
+}
+
+// ForOrganization indicates an expected call of ForOrganization.
+func (mr *MockServiceMockRecorder) ForOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForOrganization", reflect.TypeOf((*MockService)(nil).ForOrganization), ctx, orgID)
+}
+
+// ForProject mocks base method.
+func (m *MockService) ForProject(ctx context.Context, projectID uuid.UUID) *mail.Branding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProject", ctx, projectID)
+	ret0, _ := ret[0].(*mail.Branding)
+	return ret0
+}
+
+// ForProject indicates an expected call of ForProject.
+func (mr *MockServiceMockRecorder) ForProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProject", reflect.TypeOf((*MockService)(nil).ForProject), ctx, projectID)
+}
+
+// GetBranding mocks base method.
+func (m *MockService) GetBranding(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranding", ctx, orgID)
+	ret0, _ := ret[0].(*organization_branding.OrganizationBranding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranding indicates an expected call of GetBranding.
+func (mr *MockServiceMockRecorder) GetBranding(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranding", reflect.TypeOf((*MockService)(nil).GetBranding), ctx, orgID)
+}
+
+// UpdateBranding mocks base method.
+func (m *MockService) UpdateBranding(ctx context.Context, orgID uuid.UUID, input branding.Input) (*organization_branding.OrganizationBranding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBranding", ctx, orgID, input)
+	ret0, _ := ret[0].(*organization_branding.OrganizationBranding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBranding indicates an expected call of UpdateBranding.
+func (mr *MockServiceMockRecorder) UpdateBranding(ctx, orgID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranding", reflect.TypeOf((*MockService)(nil).UpdateBranding), ctx, orgID, input)
+}
+
+// VerifyDomain mocks base method.
+func (m *MockService) VerifyDomain(ctx context.Context, orgID uuid.UUID) (*organization_branding.OrganizationBranding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDomain", ctx, orgID)
+	ret0, _ := ret[0].(*organization_branding.OrganizationBranding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyDomain indicates an expected call of VerifyDomain.
+func (mr *MockServiceMockRecorder) VerifyDomain(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDomain", reflect.TypeOf((*MockService)(nil).VerifyDomain), ctx, orgID)
+}
@@ -0,0 +1,223 @@
+package calendar
+
+//go:generate mockgen -source=calendar_service.go -destination=mocks/calendar_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProjectNotFound     = errors.New("project not found")
+	ErrHolidayNotFound     = errors.New("holiday not found")
+	ErrHolidayExists       = errors.New("the project already has a holiday on this date")
+	ErrNameRequired        = errors.New("holiday name is required")
+	ErrNoWorkingDays       = errors.New("a calendar needs at least one working day")
+	ErrInvalidPointsPerDay = errors.New("points per day must be greater than zero")
+)
+
+// UpdateInput changes a project calendar; nil fields are left unchanged
+type UpdateInput struct {
+	WorkingDays  []time.Weekday
+	PointsPerDay *float64
+}
+
+type Service interface {
+	// GetCalendar returns the project's calendar, or the default calendar when it has none
+	GetCalendar(ctx context.Context, projectID uuid.UUID) (*project_calendar.ProjectCalendar, error)
+	UpdateCalendar(ctx context.Context, projectID uuid.UUID, input UpdateInput) (*project_calendar.ProjectCalendar, error)
+	// GetHolidays returns the project's holidays on or after from, by date
+	GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*project_calendar.ProjectHoliday, error)
+	GetHoliday(ctx context.Context, id uuid.UUID) (*project_calendar.ProjectHoliday, error)
+	AddHoliday(ctx context.Context, projectID uuid.UUID, date time.Time, name string) (*project_calendar.ProjectHoliday, error)
+	RemoveHoliday(ctx context.Context, id uuid.UUID) error
+	// GetSchedule loads the project's calendar with its holidays from the given day on
+	GetSchedule(ctx context.Context, projectID uuid.UUID, from time.Time) (*Schedule, error)
+}
+
+type service struct {
+	calendarRepo project_calendar.Repository
+	projectRepo  project.Repository
+}
+
+func NewService(calendarRepo project_calendar.Repository, projectRepo project.Repository) Service {
+	return &service{
+		calendarRepo: calendarRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "calendar.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "calendar"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetCalendar(ctx context.Context, projectID uuid.UUID) (*project_calendar.ProjectCalendar, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCalendar")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	cal, err := s.calendarRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &project_calendar.ProjectCalendar{
+				ProjectID:    projectID,
+				WorkingDays:  project_calendar.DefaultWorkingDays,
+				PointsPerDay: project_calendar.DefaultPointsPerDay,
+			}, nil
+		}
+		return nil, err
+	}
+	return cal, nil
+}
+
+func (s *service) UpdateCalendar(ctx context.Context, projectID uuid.UUID, input UpdateInput) (*project_calendar.ProjectCalendar, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateCalendar")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if err := s.requireProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	cal, err := s.GetCalendar(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.WorkingDays != nil {
+		var mask int16
+		for _, day := range input.WorkingDays {
+			mask |= 1 << day
+		}
+		if mask == 0 {
+			return nil, ErrNoWorkingDays
+		}
+		cal.WorkingDays = mask
+	}
+	if input.PointsPerDay != nil {
+		if *input.PointsPerDay <= 0 {
+			return nil, ErrInvalidPointsPerDay
+		}
+		cal.PointsPerDay = *input.PointsPerDay
+	}
+
+	if err := s.calendarRepo.Save(ctx, cal); err != nil {
+		return nil, err
+	}
+	return cal, nil
+}
+
+func (s *service) GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*project_calendar.ProjectHoliday, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetHolidays")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	return s.calendarRepo.GetHolidays(ctx, projectID, Date(from))
+}
+
+func (s *service) GetHoliday(ctx context.Context, id uuid.UUID) (*project_calendar.ProjectHoliday, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetHoliday")
+	span.SetAttributes(attribute.String("holiday.id", id.String()))
+	defer span.End()
+
+	holiday, err := s.calendarRepo.GetHolidayByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHolidayNotFound
+		}
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *service) AddHoliday(ctx context.Context, projectID uuid.UUID, date time.Time, name string) (*project_calendar.ProjectHoliday, error) {
+	ctx, span := s.startServiceSpan(ctx, "AddHoliday")
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.String("holiday.date", date.Format(time.DateOnly)),
+	)
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if err := s.requireProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	date = Date(date)
+	existing, err := s.calendarRepo.GetHolidays(ctx, projectID, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 && existing[0].Date.Equal(date) {
+		return nil, ErrHolidayExists
+	}
+
+	holiday := &project_calendar.ProjectHoliday{
+		ProjectID: projectID,
+		Date:      date,
+		Name:      name,
+	}
+	if err := s.calendarRepo.CreateHoliday(ctx, holiday); err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (s *service) RemoveHoliday(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "RemoveHoliday")
+	span.SetAttributes(attribute.String("holiday.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetHoliday(ctx, id); err != nil {
+		return err
+	}
+	return s.calendarRepo.DeleteHoliday(ctx, id)
+}
+
+func (s *service) GetSchedule(ctx context.Context, projectID uuid.UUID, from time.Time) (*Schedule, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSchedule")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	cal, err := s.GetCalendar(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.calendarRepo.GetHolidays(ctx, projectID, Date(from))
+	if err != nil {
+		return nil, err
+	}
+	return NewSchedule(cal, holidays), nil
+}
+
+func (s *service) requireProject(ctx context.Context, projectID uuid.UUID) error {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	calendarMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGetCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+	projectID := uuid.New()
+
+	t.Run("defaults when the project has no calendar", func(t *testing.T) {
+		mockCalendarRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		cal, err := svc.GetCalendar(context.Background(), projectID)
+		require.NoError(t, err)
+		assert.Equal(t, int16(project_calendar.DefaultWorkingDays), cal.WorkingDays)
+		assert.Equal(t, project_calendar.DefaultPointsPerDay, cal.PointsPerDay)
+		assert.False(t, cal.IsWorkingWeekday(time.Saturday))
+	})
+}
+
+func TestUpdateCalendar(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockCalendarRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+		mockCalendarRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		pace := 3.0
+		cal, err := svc.UpdateCalendar(ctx, projectID, UpdateInput{
+			WorkingDays:  []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday},
+			PointsPerDay: &pace,
+		})
+		require.NoError(t, err)
+		assert.True(t, cal.IsWorkingWeekday(time.Sunday))
+		assert.False(t, cal.IsWorkingWeekday(time.Friday))
+		assert.Equal(t, 3.0, cal.PointsPerDay)
+	})
+
+	t.Run("fail - no working days", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockCalendarRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.UpdateCalendar(ctx, projectID, UpdateInput{WorkingDays: []time.Weekday{}})
+		assert.ErrorIs(t, err, ErrNoWorkingDays)
+	})
+
+	t.Run("fail - invalid pace", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockCalendarRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(&project_calendar.ProjectCalendar{ProjectID: projectID}, nil)
+
+		pace := 0.0
+		_, err := svc.UpdateCalendar(ctx, projectID, UpdateInput{PointsPerDay: &pace})
+		assert.ErrorIs(t, err, ErrInvalidPointsPerDay)
+	})
+}
+
+func TestAddHoliday(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	christmas := day("2026-12-25")
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockCalendarRepo.EXPECT().GetHolidays(gomock.Any(), projectID, christmas).Return(nil, nil)
+		mockCalendarRepo.EXPECT().CreateHoliday(gomock.Any(), gomock.Any()).Return(nil)
+
+		holiday, err := svc.AddHoliday(ctx, projectID, christmas.Add(15*time.Hour), " Christmas ")
+		require.NoError(t, err)
+		assert.Equal(t, christmas, holiday.Date)
+		assert.Equal(t, "Christmas", holiday.Name)
+	})
+
+	t.Run("fail - date already taken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+		mockCalendarRepo.EXPECT().GetHolidays(gomock.Any(), projectID, christmas).Return([]*project_calendar.ProjectHoliday{{Date: christmas}}, nil)
+
+		_, err := svc.AddHoliday(ctx, projectID, christmas, "Christmas")
+		assert.ErrorIs(t, err, ErrHolidayExists)
+	})
+
+	t.Run("fail - name required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCalendarRepo := calendarMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockCalendarRepo, mockProjectRepo)
+
+		_, err := svc.AddHoliday(ctx, projectID, christmas, "  ")
+		assert.ErrorIs(t, err, ErrNameRequired)
+	})
+}
@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendar_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=calendar_service.go -destination=mocks/calendar_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	project_calendar "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	calendar "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddHoliday mocks base method.
+func (m *MockService) AddHoliday(ctx context.Context, projectID uuid.UUID, date time.Time, name string) (*project_calendar.ProjectHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHoliday", ctx, projectID, date, name)
+	ret0, _ := ret[0].(*project_calendar.ProjectHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHoliday indicates an expected call of AddHoliday.
+func (mr *MockServiceMockRecorder) AddHoliday(ctx, projectID, date, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHoliday", reflect.TypeOf((*MockService)(nil).AddHoliday), ctx, projectID, date, name)
+}
+
+// GetCalendar mocks base method.
+func (m *MockService) GetCalendar(ctx context.Context, projectID uuid.UUID) (*project_calendar.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendar", ctx, projectID)
+	ret0, _ := ret[0].(*project_calendar.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendar indicates an expected call of GetCalendar.
+func (mr *MockServiceMockRecorder) GetCalendar(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendar", reflect.TypeOf((*MockService)(nil).GetCalendar), ctx, projectID)
+}
+
+// GetHoliday mocks base method.
+func (m *MockService) GetHoliday(ctx context.Context, id uuid.UUID) (*project_calendar.ProjectHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoliday", ctx, id)
+	ret0, _ := ret[0].(*project_calendar.ProjectHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoliday indicates an expected call of GetHoliday.
+func (mr *MockServiceMockRecorder) GetHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoliday", reflect.TypeOf((*MockService)(nil).GetHoliday), ctx, id)
+}
+
+// GetHolidays mocks base method.
+func (m *MockService) GetHolidays(ctx context.Context, projectID uuid.UUID, from time.Time) ([]*project_calendar.ProjectHoliday, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolidays", ctx, projectID, from)
+	ret0, _ := ret[0].([]*project_calendar.ProjectHoliday)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolidays indicates an expected call of GetHolidays.
+func (mr *MockServiceMockRecorder) GetHolidays(ctx, projectID, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolidays", reflect.TypeOf((*MockService)(nil).GetHolidays), ctx, projectID, from)
+}
+
+// GetSchedule mocks base method.
+func (m *MockService) GetSchedule(ctx context.Context, projectID uuid.UUID, from time.Time) (*calendar.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, projectID, from)
+	ret0, _ := ret[0].(*calendar.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockServiceMockRecorder) GetSchedule(ctx, projectID, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockService)(nil).GetSchedule), ctx, projectID, from)
+}
+
+// RemoveHoliday mocks base method.
+func (m *MockService) RemoveHoliday(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHoliday", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveHoliday indicates an expected call of RemoveHoliday.
+func (mr *MockServiceMockRecorder) RemoveHoliday(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHoliday", reflect.TypeOf((*MockService)(nil).RemoveHoliday), ctx, id)
+}
+
+// UpdateCalendar mocks base method.
+func (m *MockService) UpdateCalendar(ctx context.Context, projectID uuid.UUID, input calendar.UpdateInput) (*project_calendar.ProjectCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCalendar", ctx, projectID, input)
+	ret0, _ := ret[0].(*project_calendar.ProjectCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCalendar indicates an expected call of UpdateCalendar.
+func (mr *MockServiceMockRecorder) UpdateCalendar(ctx, projectID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalendar", reflect.TypeOf((*MockService)(nil).UpdateCalendar), ctx, projectID, input)
+}
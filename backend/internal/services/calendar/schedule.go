@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+)
+
+// Schedule answers which days a project's team works, from its calendar and holidays
+type Schedule struct {
+	calendar *project_calendar.ProjectCalendar
+	holidays map[string]*project_calendar.ProjectHoliday
+}
+
+func NewSchedule(cal *project_calendar.ProjectCalendar, holidays []*project_calendar.ProjectHoliday) *Schedule {
+	s := &Schedule{
+		calendar: cal,
+		holidays: make(map[string]*project_calendar.ProjectHoliday, len(holidays)),
+	}
+	for _, h := range holidays {
+		s.holidays[h.Date.Format(time.DateOnly)] = h
+	}
+	return s
+}
+
+// PointsPerDay is the story points the team completes per working day
+func (s *Schedule) PointsPerDay() float64 {
+	if s.calendar.PointsPerDay <= 0 {
+		return project_calendar.DefaultPointsPerDay
+	}
+	return s.calendar.PointsPerDay
+}
+
+// holiday returns the holiday on day, or nil
+func (s *Schedule) holiday(day time.Time) *project_calendar.ProjectHoliday {
+	return s.holidays[day.Format(time.DateOnly)]
+}
+
+// IsWorkingDay reports whether day is a working weekday and not a holiday
+func (s *Schedule) IsWorkingDay(day time.Time) bool {
+	return s.workingWeekday(day.Weekday()) && s.holiday(day) == nil
+}
+
+// workingWeekday guards against a calendar without working days, which would never reach
+// a due date
+func (s *Schedule) workingWeekday(day time.Weekday) bool {
+	mask := s.calendar.WorkingDays
+	if mask == 0 {
+		mask = project_calendar.DefaultWorkingDays
+	}
+	return mask&(1<<day) != 0
+}
+
+// AddWorkingDays returns the date of the n-th working day counting from start, which
+// counts itself when it is a working day, along with the holidays skipped on the way.
+// Dates are calendar days at UTC midnight.
+func (s *Schedule) AddWorkingDays(start time.Time, n int) (time.Time, []*project_calendar.ProjectHoliday) {
+	day := Date(start)
+	var skipped []*project_calendar.ProjectHoliday
+	for {
+		if s.workingWeekday(day.Weekday()) {
+			if h := s.holiday(day); h != nil {
+				skipped = append(skipped, h)
+			} else {
+				n--
+				if n <= 0 {
+					return day, skipped
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+}
+
+// Date truncates t to its calendar day at UTC midnight
+func Date(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,68 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	weekdays := &project_calendar.ProjectCalendar{WorkingDays: project_calendar.DefaultWorkingDays, PointsPerDay: 1}
+	christmas := &project_calendar.ProjectHoliday{Date: day("2026-12-25"), Name: "Christmas"}
+
+	tests := []struct {
+		name     string
+		calendar *project_calendar.ProjectCalendar
+		holidays []*project_calendar.ProjectHoliday
+		start    string
+		days     int
+		want     string
+		skipped  int
+	}{
+		{"start day counts when it is a working day", weekdays, nil, "2026-12-21", 1, "2026-12-21", 0},
+		{"skips the weekend", weekdays, nil, "2026-12-18", 2, "2026-12-21", 0},
+		{"weekend start begins on Monday", weekdays, nil, "2026-12-19", 1, "2026-12-21", 0},
+		{"skips holidays", weekdays, []*project_calendar.ProjectHoliday{christmas}, "2026-12-24", 2, "2026-12-28", 1},
+		{"holidays on days off are not skipped days", &project_calendar.ProjectCalendar{WorkingDays: 1 << time.Monday}, []*project_calendar.ProjectHoliday{christmas}, "2026-12-21", 2, "2026-12-28", 0},
+		{"calendar without working days falls back to weekdays", &project_calendar.ProjectCalendar{}, nil, "2026-12-19", 1, "2026-12-21", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, skipped := NewSchedule(tt.calendar, tt.holidays).AddWorkingDays(day(tt.start), tt.days)
+			assert.Equal(t, tt.want, due.Format(time.DateOnly))
+			assert.Len(t, skipped, tt.skipped)
+		})
+	}
+}
+
+func TestScheduleIsWorkingDay(t *testing.T) {
+	s := NewSchedule(&project_calendar.ProjectCalendar{WorkingDays: project_calendar.DefaultWorkingDays}, []*project_calendar.ProjectHoliday{
+		{Date: day("2026-12-25"), Name: "Christmas"},
+	})
+	assert.True(t, s.IsWorkingDay(day("2026-12-24")))
+	assert.False(t, s.IsWorkingDay(day("2026-12-25")))
+	assert.False(t, s.IsWorkingDay(day("2026-12-26")))
+}
+
+func TestDate(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// 21:00 in New York is already the next day in UTC
+	assert.Equal(t, day("2026-12-22"), Date(time.Date(2026, 12, 21, 21, 0, 0, 0, loc)))
+}
+
+func TestPointsPerDay(t *testing.T) {
+	assert.Equal(t, 2.5, NewSchedule(&project_calendar.ProjectCalendar{PointsPerDay: 2.5}, nil).PointsPerDay())
+	assert.Equal(t, project_calendar.DefaultPointsPerDay, NewSchedule(&project_calendar.ProjectCalendar{}, nil).PointsPerDay())
+}
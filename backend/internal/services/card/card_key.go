@@ -0,0 +1,51 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCardKey = errors.New("card key must be a project key and a card number, such as API-42")
+
+// Key is the key of a project's card with the number, such as API-42
+func Key(projectKey string, number int) string {
+	return strings.ToUpper(projectKey) + "-" + strconv.Itoa(number)
+}
+
+// ParseKey splits a card key into its project key, upper-cased, and card number
+func ParseKey(key string) (string, int, error) {
+	projectKey, number, ok := strings.Cut(strings.TrimSpace(key), "-")
+	if !ok || projectKey == "" {
+		return "", 0, ErrInvalidCardKey
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 || strconv.Itoa(n) != number {
+		return "", 0, ErrInvalidCardKey
+	}
+	return strings.ToUpper(projectKey), n, nil
+}
+
+func (s *service) GetCardByNumber(ctx context.Context, projectID uuid.UUID, number int) (*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCardByNumber")
+	span.SetAttributes(
+		attribute.String("card.project_id", projectID.String()),
+		attribute.Int("card.number", number),
+	)
+	defer span.End()
+
+	c, err := s.cardRepo.GetByProjectNumber(ctx, projectID, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
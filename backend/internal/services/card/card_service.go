@@ -1,8 +1,11 @@
 package card
 
+//go:generate mockgen -source=card_service.go -destination=mocks/card_service_mock.go -package=mocks
+
 import (
 	"context"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,8 +13,15 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"github.com/thatcatdev/kaimu/backend/internal/services/calendar"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/internal/services/workflow"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -22,8 +32,31 @@ var (
 	ErrCardNotFound   = errors.New("card not found")
 	ErrColumnNotFound = errors.New("column not found")
 	ErrBoardNotFound  = errors.New("board not found")
+
+	ErrTagNotInProject      = errors.New("tag does not belong to the board's project")
+	ErrNegativeStoryPoints  = errors.New("story points cannot be negative")
+	ErrChecklistItemTooLong = errors.New("checklist items are limited to 500 characters")
+	ErrPointLimitExceeded   = errors.New("the move would take the column over its point limit")
 )
 
+// PointLimitExceededError reports a move refused because the target column would hold
+// more story points than its point limit. It matches ErrPointLimitExceeded with errors.Is.
+type PointLimitExceededError struct {
+	ColumnID uuid.UUID
+	// Points is what the column holds before the move, CardPoints what the card adds
+	Points     int
+	CardPoints int
+	PointLimit int
+}
+
+func (e *PointLimitExceededError) Error() string {
+	return ErrPointLimitExceeded.Error()
+}
+
+func (e *PointLimitExceededError) Unwrap() error {
+	return ErrPointLimitExceeded
+}
+
 type CreateCardInput struct {
 	ColumnID    uuid.UUID
 	Title       string
@@ -34,6 +67,11 @@ type CreateCardInput struct {
 	DueDate     *time.Time
 	StoryPoints *int
 	CreatedBy   *uuid.UUID
+	// SkipColumnDefaults creates the card exactly as given, for cards copied from elsewhere
+	SkipColumnDefaults bool
+	// SkipFloodCheck leaves the card out of flood control, for cards created together from one
+	// submission (pasted lists, imports, splits) that may repeat a title
+	SkipFloodCheck bool
 }
 
 type UpdateCardInput struct {
@@ -50,26 +88,78 @@ type UpdateCardInput struct {
 	ClearStoryPoints bool
 }
 
+// SuggestDueDateInput describes the work a due date is suggested for
+type SuggestDueDateInput struct {
+	BoardID uuid.UUID
+	// CardID is the card being edited, left out of its assignee's workload
+	CardID     *uuid.UUID
+	AssigneeID *uuid.UUID
+	// StoryPoints is the card's estimate; unestimated work takes at least one working day
+	StoryPoints *int
+	// StartDate defaults to today
+	StartDate *time.Time
+}
+
+// DueDateSuggestion is a proposed due date and how it was reached
+type DueDateSuggestion struct {
+	DueDate time.Time
+	// WorkingDays is the working days needed for the workload and the estimate
+	WorkingDays    int
+	EstimatePoints int
+	// WorkloadPoints and WorkloadCards are the assignee's other unfinished cards, which
+	// are assumed to be done first
+	WorkloadPoints  int
+	WorkloadCards   int
+	SkippedHolidays []*project_calendar.ProjectHoliday
+}
+
 type Service interface {
 	CreateCard(ctx context.Context, input CreateCardInput) (*card.Card, error)
+	// CreateCardsFromText creates one card per title found in pasted text (see
+	// ParseCardTitles) in a single transaction, returning them in text order
+	CreateCardsFromText(ctx context.Context, columnID uuid.UUID, text string, createdBy *uuid.UUID) ([]*card.Card, error)
 	GetCard(ctx context.Context, id uuid.UUID) (*card.Card, error)
+	// GetCardByNumber returns the project's card with the number, the number of its key (see
+	// Key), even when it is archived
+	GetCardByNumber(ctx context.Context, projectID uuid.UUID, number int) (*card.Card, error)
 	GetCardsByColumnID(ctx context.Context, columnID uuid.UUID) ([]*card.Card, error)
 	GetCardsByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error)
 	GetCardsByAssigneeID(ctx context.Context, assigneeID uuid.UUID) ([]*card.Card, error)
 	UpdateCard(ctx context.Context, input UpdateCardInput) (*card.Card, error)
-	MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID, afterCardID *uuid.UUID) (*card.Card, error)
+	// MoveCard moves a card within or between columns. Moves the board workflow does not
+	// allow fail with a *workflow.InvalidTransitionError, and moves that would take the
+	// target column over its point limit with a *PointLimitExceededError, unless
+	// bypassWorkflow is set.
+	MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID, afterCardID *uuid.UUID, bypassWorkflow bool) (*card.Card, error)
 	DeleteCard(ctx context.Context, id uuid.UUID) error
 	GetTagsForCard(ctx context.Context, cardID uuid.UUID) ([]*tag.Tag, error)
 	GetBoardByCardID(ctx context.Context, cardID uuid.UUID) (*board.Board, error)
 	GetColumnByCardID(ctx context.Context, cardID uuid.UUID) (*board_column.BoardColumn, error)
+	// GetColumnStoryPoints sums the story points of the column's unarchived cards
+	GetColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error)
+	// SuggestDueDate proposes a due date for an estimate, after the assignee's unfinished
+	// cards, counting only the working days of the board's project calendar
+	SuggestDueDate(ctx context.Context, input SuggestDueDateInput) (*DueDateSuggestion, error)
+	// GetColumnDefaults returns the defaults applied to cards created in the column, empty
+	// when it has none
+	GetColumnDefaults(ctx context.Context, columnID uuid.UUID) (*column_defaults.ColumnDefaults, error)
+	// SetColumnDefaults replaces the column's defaults; empty defaults remove them
+	SetColumnDefaults(ctx context.Context, columnID uuid.UUID, input ColumnDefaultsInput) (*column_defaults.ColumnDefaults, error)
 }
 
 type service struct {
-	cardRepo    card.Repository
-	columnRepo  board_column.Repository
-	boardRepo   board.Repository
-	tagRepo     tag.Repository
-	cardTagRepo card_tag.Repository
+	cardRepo     card.Repository
+	columnRepo   board_column.Repository
+	boardRepo    board.Repository
+	tagRepo      tag.Repository
+	cardTagRepo  card_tag.Repository
+	defaultsRepo column_defaults.Repository
+	workflowSvc  workflow.Service
+	contentSvc   content.Service
+	calendarSvc  calendar.Service
+	txManager    transaction.Manager
+	bus          events.Bus
+	now          func() time.Time
 }
 
 func NewService(
@@ -78,13 +168,26 @@ func NewService(
 	boardRepo board.Repository,
 	tagRepo tag.Repository,
 	cardTagRepo card_tag.Repository,
+	defaultsRepo column_defaults.Repository,
+	workflowSvc workflow.Service,
+	contentSvc content.Service,
+	calendarSvc calendar.Service,
+	txManager transaction.Manager,
+	bus events.Bus,
 ) Service {
 	return &service{
-		cardRepo:    cardRepo,
-		columnRepo:  columnRepo,
-		boardRepo:   boardRepo,
-		tagRepo:     tagRepo,
-		cardTagRepo: cardTagRepo,
+		cardRepo:     cardRepo,
+		columnRepo:   columnRepo,
+		boardRepo:    boardRepo,
+		tagRepo:      tagRepo,
+		cardTagRepo:  cardTagRepo,
+		defaultsRepo: defaultsRepo,
+		workflowSvc:  workflowSvc,
+		contentSvc:   contentSvc,
+		calendarSvc:  calendarSvc,
+		txManager:    txManager,
+		bus:          bus,
+		now:          time.Now,
 	}
 }
 
@@ -118,6 +221,16 @@ func (s *service) CreateCard(ctx context.Context, input CreateCardInput) (*card.
 		return nil, err
 	}
 
+	if !input.SkipColumnDefaults {
+		if err := s.applyColumnDefaults(ctx, &input); err != nil {
+			return nil, err
+		}
+	}
+
+	description := sanitize.HTML(input.Description) // Sanitize HTML to prevent XSS
+	if err := s.checkContent(ctx, col.BoardID, &input.Title, &description); err != nil {
+		return nil, err
+	}
 	// Get max position in column
 	maxPos, err := s.cardRepo.GetMaxPosition(ctx, input.ColumnID)
 	if err != nil {
@@ -128,28 +241,46 @@ func (s *service) CreateCard(ctx context.Context, input CreateCardInput) (*card.
 		ColumnID:    input.ColumnID,
 		BoardID:     col.BoardID,
 		Title:       input.Title,
-		Description: sanitize.HTML(input.Description), // Sanitize HTML to prevent XSS
-		Position:    maxPos + 1000,                    // Start at 1000 intervals
+		Description: description,
+		Position:    maxPos + 1000, // Start at 1000 intervals
 		Priority:    input.Priority,
 		AssigneeID:  input.AssigneeID,
 		DueDate:     input.DueDate,
 		StoryPoints: input.StoryPoints,
 		CreatedBy:   input.CreatedBy,
+
+		OriginalStoryPoints: input.StoryPoints,
 	}
 
 	if c.Priority == "" {
 		c.Priority = card.PriorityNone
 	}
 
-	if err := s.cardRepo.Create(ctx, c); err != nil {
-		return nil, err
-	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Create(ctx, c); err != nil {
+			return err
+		}
+		// Counted once the insert went through; a flooded card is rolled back
+		if input.CreatedBy != nil && !input.SkipFloodCheck {
+			if err := s.contentSvc.CheckFlood(ctx, *input.CreatedBy, col.BoardID, content.FieldCard, c.Title+"\n"+c.Description); err != nil {
+				return err
+			}
+		}
 
-	// Add tags if provided
-	if len(input.TagIDs) > 0 {
-		if err := s.cardTagRepo.SetTagsForCard(ctx, c.ID, input.TagIDs); err != nil {
-			return nil, err
+		// Add tags if provided
+		if len(input.TagIDs) > 0 {
+			if err := s.cardTagRepo.SetTagsForCard(ctx, c.ID, input.TagIDs); err != nil {
+				return err
+			}
 		}
+
+		if err := s.publishCardEvent(ctx, events.CardCreated, c); err != nil {
+			return err
+		}
+		return s.publishAssigned(ctx, c, nil)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return c, nil
@@ -207,15 +338,21 @@ func (s *service) UpdateCard(ctx context.Context, input UpdateCardInput) (*card.
 		return nil, err
 	}
 
+	description := sanitize.HTMLPtr(input.Description) // Sanitize HTML to prevent XSS
+	if err := s.checkContent(ctx, c.BoardID, input.Title, description); err != nil {
+		return nil, err
+	}
+
 	if input.Title != nil {
 		c.Title = *input.Title
 	}
-	if input.Description != nil {
-		c.Description = sanitize.HTML(*input.Description) // Sanitize HTML to prevent XSS
+	if description != nil {
+		c.Description = *description
 	}
 	if input.Priority != nil {
 		c.Priority = *input.Priority
 	}
+	previousAssignee := c.AssigneeID
 	if input.ClearAssignee {
 		c.AssigneeID = nil
 	} else if input.AssigneeID != nil {
@@ -230,23 +367,37 @@ func (s *service) UpdateCard(ctx context.Context, input UpdateCardInput) (*card.
 		c.StoryPoints = nil
 	} else if input.StoryPoints != nil {
 		c.StoryPoints = input.StoryPoints
+		// Re-estimates keep the first estimate for estimation accuracy
+		if c.OriginalStoryPoints == nil {
+			c.OriginalStoryPoints = input.StoryPoints
+		}
 	}
 
-	if err := s.cardRepo.Update(ctx, c); err != nil {
-		return nil, err
-	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Update(ctx, c); err != nil {
+			return err
+		}
 
-	// Update tags if provided
-	if input.TagIDs != nil {
-		if err := s.cardTagRepo.SetTagsForCard(ctx, c.ID, input.TagIDs); err != nil {
-			return nil, err
+		// Update tags if provided
+		if input.TagIDs != nil {
+			if err := s.cardTagRepo.SetTagsForCard(ctx, c.ID, input.TagIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := s.publishCardEvent(ctx, events.CardUpdated, c); err != nil {
+			return err
 		}
+		return s.publishAssigned(ctx, c, previousAssignee)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return c, nil
 }
 
-func (s *service) MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID, afterCardID *uuid.UUID) (*card.Card, error) {
+func (s *service) MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID, afterCardID *uuid.UUID, bypassWorkflow bool) (*card.Card, error) {
 	ctx, span := s.startServiceSpan(ctx, "MoveCard")
 	span.SetAttributes(
 		attribute.String("card.id", cardID.String()),
@@ -271,21 +422,54 @@ func (s *service) MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID
 		return nil, err
 	}
 
-	// Calculate new position
-	newPos, err := s.cardRepo.GetPositionBetween(ctx, targetColumnID, afterCardID)
-	if err != nil {
-		return nil, err
+	// The workflow governs moves between columns of the same board
+	if !bypassWorkflow && col.BoardID == c.BoardID {
+		if err := s.workflowSvc.CheckTransition(ctx, c.BoardID, c.ColumnID, targetColumnID); err != nil {
+			return nil, err
+		}
 	}
 
-	c.ColumnID = targetColumnID
-	c.BoardID = col.BoardID
-	c.Position = newPos
+	var moved *card.Card
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Only cards arriving from another column count against the point limit; the column
+		// stays locked until commit so concurrent moves cannot both squeeze in
+		if !bypassWorkflow && col.PointLimit != nil && c.ColumnID != targetColumnID && c.StoryPoints != nil {
+			points, err := s.cardRepo.LockColumnStoryPoints(ctx, targetColumnID)
+			if err != nil {
+				return err
+			}
+			if points+*c.StoryPoints > *col.PointLimit {
+				return &PointLimitExceededError{
+					ColumnID:   targetColumnID,
+					Points:     points,
+					CardPoints: *c.StoryPoints,
+					PointLimit: *col.PointLimit,
+				}
+			}
+		}
 
-	if err := s.cardRepo.Update(ctx, c); err != nil {
+		// Position assignment and the update happen atomically in the repository so
+		// concurrent drags into the same column cannot collide
+		moved, err = s.cardRepo.MoveCard(ctx, c.ID, targetColumnID, col.BoardID, afterCardID)
+		if err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.CardMoved, events.CardMovedPayload{
+			CardID:       moved.ID,
+			BoardID:      moved.BoardID,
+			FromColumnID: c.ColumnID,
+			ToColumnID:   moved.ColumnID,
+		}))
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
 		return nil, err
 	}
 
-	return c, nil
+	return moved, nil
 }
 
 func (s *service) DeleteCard(ctx context.Context, id uuid.UUID) error {
@@ -293,7 +477,41 @@ func (s *service) DeleteCard(ctx context.Context, id uuid.UUID) error {
 	span.SetAttributes(attribute.String("card.id", id.String()))
 	defer span.End()
 
-	return s.cardRepo.Delete(ctx, id)
+	// Load the card first so consumers of card.deleted know where it lived
+	c, err := s.cardRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCardNotFound
+		}
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.cardRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.publishCardEvent(ctx, events.CardDeleted, c)
+	})
+}
+
+func (s *service) publishCardEvent(ctx context.Context, name events.Name, c *card.Card) error {
+	return s.bus.Publish(ctx, events.New(ctx, name, events.CardPayload{
+		CardID:   c.ID,
+		BoardID:  c.BoardID,
+		ColumnID: c.ColumnID,
+	}))
+}
+
+// publishAssigned publishes card.assigned when the card has an assignee other than previous
+func (s *service) publishAssigned(ctx context.Context, c *card.Card, previous *uuid.UUID) error {
+	if c.AssigneeID == nil || (previous != nil && *previous == *c.AssigneeID) {
+		return nil
+	}
+	return s.bus.Publish(ctx, events.New(ctx, events.CardAssigned, events.CardAssignedPayload{
+		CardID:     c.ID,
+		BoardID:    c.BoardID,
+		AssigneeID: *c.AssigneeID,
+	}))
 }
 
 func (s *service) GetTagsForCard(ctx context.Context, cardID uuid.UUID) ([]*tag.Tag, error) {
@@ -365,3 +583,105 @@ func (s *service) GetColumnByCardID(ctx context.Context, cardID uuid.UUID) (*boa
 
 	return col, nil
 }
+
+func (s *service) GetColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetColumnStoryPoints")
+	span.SetAttributes(attribute.String("column.id", columnID.String()))
+	defer span.End()
+
+	return s.cardRepo.GetStoryPointsByColumnID(ctx, columnID)
+}
+
+func (s *service) SuggestDueDate(ctx context.Context, input SuggestDueDateInput) (*DueDateSuggestion, error) {
+	ctx, span := s.startServiceSpan(ctx, "SuggestDueDate")
+	span.SetAttributes(attribute.String("board.id", input.BoardID.String()))
+	defer span.End()
+
+	b, err := s.boardRepo.GetByID(ctx, input.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	start := s.now()
+	if input.StartDate != nil {
+		start = *input.StartDate
+	}
+	schedule, err := s.calendarSvc.GetSchedule(ctx, b.ProjectID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion := &DueDateSuggestion{}
+	if input.StoryPoints != nil && *input.StoryPoints > 0 {
+		suggestion.EstimatePoints = *input.StoryPoints
+	}
+	if input.AssigneeID != nil {
+		suggestion.WorkloadPoints, suggestion.WorkloadCards, err = s.openWorkload(ctx, *input.AssigneeID, input.CardID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	points := float64(suggestion.WorkloadPoints + suggestion.EstimatePoints)
+	suggestion.WorkingDays = max(1, int(math.Ceil(points/schedule.PointsPerDay())))
+	suggestion.DueDate, suggestion.SkippedHolidays = schedule.AddWorkingDays(start, suggestion.WorkingDays)
+
+	span.SetAttributes(
+		attribute.Int("due_date.working_days", suggestion.WorkingDays),
+		attribute.Int("due_date.workload_points", suggestion.WorkloadPoints),
+	)
+	return suggestion, nil
+}
+
+// openWorkload sums the story points of the assignee's cards outside done columns, except
+// the card being planned
+func (s *service) openWorkload(ctx context.Context, assigneeID uuid.UUID, exceptCardID *uuid.UUID) (int, int, error) {
+	cards, err := s.cardRepo.GetByAssigneeID(ctx, assigneeID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	done := make(map[uuid.UUID]bool)
+	points, count := 0, 0
+	for _, c := range cards {
+		if exceptCardID != nil && c.ID == *exceptCardID {
+			continue
+		}
+		isDone, seen := done[c.ColumnID]
+		if !seen {
+			col, err := s.columnRepo.GetByID(ctx, c.ColumnID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, 0, err
+			}
+			isDone = col != nil && col.IsDone
+			done[c.ColumnID] = isDone
+		}
+		if isDone {
+			continue
+		}
+		count++
+		if c.StoryPoints != nil {
+			points += *c.StoryPoints
+		}
+	}
+	return points, count, nil
+}
+
+// checkContent applies the content limits and policy to a card's new title and description;
+// nil means the field isn't changing
+func (s *service) checkContent(ctx context.Context, boardID uuid.UUID, title, description *string) error {
+	if title != nil {
+		if err := s.contentSvc.Check(ctx, boardID, content.FieldCardTitle, *title); err != nil {
+			return err
+		}
+	}
+	if description != nil {
+		if err := s.contentSvc.Check(ctx, boardID, content.FieldCardDescription, *description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package card
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,8 +18,20 @@ import (
 	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
 	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	defaultsMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
+	calendarRepoMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
 	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	eventMocks "github.com/thatcatdev/kaimu/backend/internal/events/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/calendar"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	contentMocks "github.com/thatcatdev/kaimu/backend/internal/services/content/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	workflowMocks "github.com/thatcatdev/kaimu/backend/internal/services/workflow/mocks"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -31,14 +45,21 @@ func TestCreateCard(t *testing.T) {
 	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+	mockDefaultsRepo := defaultsMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, mockDefaultsRepo, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	columnID := uuid.New()
 	boardID := uuid.New()
 	userID := uuid.New()
 
+	// The column has no card defaults
+	mockDefaultsRepo.EXPECT().
+		GetByColumnID(gomock.Any(), columnID).
+		Return(nil, gorm.ErrRecordNotFound).
+		AnyTimes()
+
 	t.Run("success without tags", func(t *testing.T) {
 		mockColumnRepo.EXPECT().
 			GetByID(gomock.Any(), columnID).
@@ -121,6 +142,303 @@ func TestCreateCard(t *testing.T) {
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, ErrColumnNotFound)
 	})
+
+	t.Run("content limit exceeded", func(t *testing.T) {
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+		svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, mockDefaultsRepo, workflowMocks.NewMockService(ctrl), mockContentSvc, nil, transaction.NewNoopManager(), events.NewSyncBus())
+		limitErr := &content.LimitError{Field: content.FieldCardTitle, Length: 600, Max: 500}
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), columnID).
+			Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockContentSvc.EXPECT().
+			Check(gomock.Any(), boardID, content.FieldCardTitle, "Too long").
+			Return(limitErr)
+
+		result, err := svc.CreateCard(ctx, CreateCardInput{ColumnID: columnID, Title: "Too long"})
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, limitErr)
+	})
+
+	t.Run("identical card flooded", func(t *testing.T) {
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+		svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, mockDefaultsRepo, workflowMocks.NewMockService(ctrl), mockContentSvc, nil, transaction.NewNoopManager(), events.NewSyncBus())
+		userID := uuid.New()
+		floodErr := &content.FloodError{Field: content.FieldCard, Max: 5, RetryAfter: 30 * time.Second}
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), columnID).
+			Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockContentSvc.EXPECT().Check(gomock.Any(), boardID, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		mockCardRepo.EXPECT().
+			GetMaxPosition(gomock.Any(), columnID).
+			Return(float64(0), nil)
+		mockCardRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil)
+		mockContentSvc.EXPECT().
+			CheckFlood(gomock.Any(), userID, boardID, content.FieldCard, "Again\n<p>Same</p>").
+			Return(floodErr)
+
+		result, err := svc.CreateCard(ctx, CreateCardInput{ColumnID: columnID, Title: "Again", Description: "<p>Same</p>", CreatedBy: &userID})
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, floodErr)
+	})
+
+	t.Run("fails when the event cannot be recorded", func(t *testing.T) {
+		mockBus := eventMocks.NewMockBus(ctrl)
+		svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, mockDefaultsRepo, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), mockBus)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), columnID).
+			Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockCardRepo.EXPECT().
+			GetMaxPosition(gomock.Any(), columnID).
+			Return(float64(0), nil)
+		mockCardRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		publishErr := errors.New("outbox insert failed")
+		mockBus.EXPECT().
+			Publish(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, e events.Event) error {
+				assert.Equal(t, events.CardCreated, e.Name)
+				return publishErr
+			})
+
+		result, err := svc.CreateCard(ctx, CreateCardInput{ColumnID: columnID, Title: "Test Card"})
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, publishErr)
+	})
+}
+
+func TestCreateCardColumnDefaults(t *testing.T) {
+	ctx := context.Background()
+	columnID := uuid.New()
+	boardID := uuid.New()
+	assigneeID := uuid.New()
+	defaultTagID := uuid.New()
+	high := card.PriorityHigh
+	points := 3
+
+	newService := func(ctrl *gomock.Controller) (Service, *cardMocks.MockRepository, *columnMocks.MockRepository, *cardTagMocks.MockRepository, *defaultsMocks.MockRepository) {
+		cardRepo := cardMocks.NewMockRepository(ctrl)
+		columnRepo := columnMocks.NewMockRepository(ctrl)
+		cardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		defaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		svc := NewService(cardRepo, columnRepo, boardMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), cardTagRepo, defaultsRepo, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		defaultsRepo.EXPECT().GetByColumnID(gomock.Any(), columnID).Return(&column_defaults.ColumnDefaults{
+			ColumnID:    columnID,
+			Priority:    &high,
+			AssigneeID:  &assigneeID,
+			StoryPoints: &points,
+			Checklist:   column_defaults.Checklist{"Tests written", "Docs <updated>"},
+			TagIDs:      []uuid.UUID{defaultTagID},
+		}, nil)
+		cardRepo.EXPECT().GetMaxPosition(gomock.Any(), columnID).Return(float64(0), nil)
+		return svc, cardRepo, columnRepo, cardTagRepo, defaultsRepo
+	}
+
+	t.Run("fills in what the input leaves empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, cardRepo, _, cardTagRepo, _ := newService(ctrl)
+
+		givenTagID := uuid.New()
+		cardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		cardTagRepo.EXPECT().SetTagsForCard(gomock.Any(), gomock.Any(), []uuid.UUID{givenTagID, defaultTagID}).Return(nil)
+
+		result, err := svc.CreateCard(ctx, CreateCardInput{
+			ColumnID:    columnID,
+			Title:       "Release notes",
+			Description: "<p>For 2.0</p>",
+			Priority:    card.PriorityLow,
+			TagIDs:      []uuid.UUID{givenTagID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, card.PriorityLow, result.Priority)
+		assert.Equal(t, &assigneeID, result.AssigneeID)
+		assert.Equal(t, &points, result.StoryPoints)
+		assert.Equal(t, "<p>For 2.0</p><ul><li><p>[ ] Tests written</p></li><li><p>[ ] Docs &lt;updated&gt;</p></li></ul>", result.Description)
+	})
+
+	t.Run("skipped for copied cards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cardRepo := cardMocks.NewMockRepository(ctrl)
+		columnRepo := columnMocks.NewMockRepository(ctrl)
+		svc := NewService(cardRepo, columnRepo, boardMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), cardTagMocks.NewMockRepository(ctrl), defaultsMocks.NewMockRepository(ctrl), workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		cardRepo.EXPECT().GetMaxPosition(gomock.Any(), columnID).Return(float64(0), nil)
+		cardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := svc.CreateCard(ctx, CreateCardInput{ColumnID: columnID, Title: "Mirror", SkipColumnDefaults: true})
+		require.NoError(t, err)
+		assert.Equal(t, card.PriorityNone, result.Priority)
+		assert.Nil(t, result.AssigneeID)
+	})
+}
+
+func TestSetColumnDefaults(t *testing.T) {
+	ctx := context.Background()
+	columnID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	col := &board_column.BoardColumn{ID: columnID, BoardID: b.ID}
+
+	newService := func(ctrl *gomock.Controller) (Service, *columnMocks.MockRepository, *boardMocks.MockRepository, *tagMocks.MockRepository, *defaultsMocks.MockRepository) {
+		columnRepo := columnMocks.NewMockRepository(ctrl)
+		boardRepo := boardMocks.NewMockRepository(ctrl)
+		tagRepo := tagMocks.NewMockRepository(ctrl)
+		defaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		svc := NewService(cardMocks.NewMockRepository(ctrl), columnRepo, boardRepo, tagRepo, cardTagMocks.NewMockRepository(ctrl), defaultsRepo, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+		return svc, columnRepo, boardRepo, tagRepo, defaultsRepo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, columnRepo, boardRepo, tagRepo, defaultsRepo := newService(ctrl)
+
+		tagID := uuid.New()
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(col, nil)
+		boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		tagRepo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{tagID}).Return([]*tag.Tag{{ID: tagID, ProjectID: b.ProjectID}}, nil)
+		defaultsRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		defaults, err := svc.SetColumnDefaults(ctx, columnID, ColumnDefaultsInput{
+			TagIDs:    []uuid.UUID{tagID},
+			Checklist: []string{" Reviewed ", "", "Deployed"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, column_defaults.Checklist{"Reviewed", "Deployed"}, defaults.Checklist)
+		assert.Equal(t, []uuid.UUID{tagID}, defaults.TagIDs)
+	})
+
+	t.Run("empty defaults are removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, columnRepo, _, _, defaultsRepo := newService(ctrl)
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(col, nil)
+		defaultsRepo.EXPECT().Delete(gomock.Any(), columnID).Return(nil)
+
+		_, err := svc.SetColumnDefaults(ctx, columnID, ColumnDefaultsInput{Checklist: []string{"  "}})
+		require.NoError(t, err)
+	})
+
+	t.Run("fail - tag of another project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, columnRepo, boardRepo, tagRepo, _ := newService(ctrl)
+
+		tagID := uuid.New()
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(col, nil)
+		boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		tagRepo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{tagID}).Return([]*tag.Tag{{ID: tagID, ProjectID: uuid.New()}}, nil)
+
+		_, err := svc.SetColumnDefaults(ctx, columnID, ColumnDefaultsInput{TagIDs: []uuid.UUID{tagID}})
+		assert.ErrorIs(t, err, ErrTagNotInProject)
+	})
+
+	t.Run("fail - negative story points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, columnRepo, _, _, _ := newService(ctrl)
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(col, nil)
+
+		points := -1
+		_, err := svc.SetColumnDefaults(ctx, columnID, ColumnDefaultsInput{StoryPoints: &points})
+		assert.ErrorIs(t, err, ErrNegativeStoryPoints)
+	})
+}
+
+func TestParseCardTitles(t *testing.T) {
+	text := "## Launch checklist\r\n\n- [ ] Write docs\n- [x] Fix login\n* Update changelog\n2. Tag release\n  plain line  \n-\n[ ]\n"
+	assert.Equal(t, []string{"Launch checklist", "Write docs", "Fix login", "Update changelog", "Tag release", "plain line"}, ParseCardTitles(text))
+	assert.Empty(t, ParseCardTitles(" \n\t\n"))
+}
+
+func TestCreateCardsFromText(t *testing.T) {
+	ctx := context.Background()
+	columnID := uuid.New()
+	boardID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("creates cards in text order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cardRepo := cardMocks.NewMockRepository(ctrl)
+		columnRepo := columnMocks.NewMockRepository(ctrl)
+		defaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		svc := NewService(cardRepo, columnRepo, boardMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), cardTagMocks.NewMockRepository(ctrl), defaultsRepo, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil).Times(2)
+		defaultsRepo.EXPECT().GetByColumnID(gomock.Any(), columnID).Return(nil, gorm.ErrRecordNotFound).Times(2)
+		maxPos := float64(0)
+		cardRepo.EXPECT().GetMaxPosition(gomock.Any(), columnID).DoAndReturn(func(ctx context.Context, columnID uuid.UUID) (float64, error) {
+			return maxPos, nil
+		}).Times(2)
+		cardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *card.Card) error {
+			maxPos = c.Position
+			return nil
+		}).Times(2)
+
+		cards, err := svc.CreateCardsFromText(ctx, columnID, "- [ ] First\n- [ ] Second", &userID)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		assert.Equal(t, "First", cards[0].Title)
+		assert.Equal(t, "Second", cards[1].Title)
+		assert.Less(t, cards[0].Position, cards[1].Position)
+		assert.Equal(t, &userID, cards[1].CreatedBy)
+	})
+
+	t.Run("repeated titles aren't flood controlled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cardRepo := cardMocks.NewMockRepository(ctrl)
+		columnRepo := columnMocks.NewMockRepository(ctrl)
+		defaultsRepo := defaultsMocks.NewMockRepository(ctrl)
+		contentSvc := content.NewService(nil, nil, nil, content.Limits{IdenticalPerMinute: 1})
+		svc := NewService(cardRepo, columnRepo, boardMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), cardTagMocks.NewMockRepository(ctrl), defaultsRepo, workflowMocks.NewMockService(ctrl), contentSvc, nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		columnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil).Times(3)
+		defaultsRepo.EXPECT().GetByColumnID(gomock.Any(), columnID).Return(nil, gorm.ErrRecordNotFound).Times(3)
+		cardRepo.EXPECT().GetMaxPosition(gomock.Any(), columnID).Return(float64(0), nil).Times(3)
+		cardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+		cards, err := svc.CreateCardsFromText(ctx, columnID, "- Follow up\n- Follow up\n- Follow up", &userID)
+		require.NoError(t, err)
+		assert.Len(t, cards, 3)
+
+		// The paste didn't use up the user's allowance for single cards
+		require.NoError(t, contentSvc.CheckFlood(ctx, userID, boardID, content.FieldCard, "Follow up\n"))
+	})
+
+	t.Run("fail - no titles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc := NewService(nil, nil, nil, nil, nil, nil, nil, nil, nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		_, err := svc.CreateCardsFromText(ctx, columnID, "\n- \n", &userID)
+		assert.ErrorIs(t, err, ErrNoCardTitles)
+	})
+
+	t.Run("fail - too many titles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc := NewService(nil, nil, nil, nil, nil, nil, nil, nil, nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		_, err := svc.CreateCardsFromText(ctx, columnID, strings.Repeat("card\n", MaxCardsFromText+1), &userID)
+		assert.ErrorIs(t, err, ErrTooManyCards)
+	})
 }
 
 func TestGetCard(t *testing.T) {
@@ -133,7 +451,7 @@ func TestGetCard(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -163,6 +481,46 @@ func TestGetCard(t *testing.T) {
 	})
 }
 
+func TestCardKeys(t *testing.T) {
+	assert.Equal(t, "API-42", Key("api", 42))
+
+	projectKey, number, err := ParseKey(" api-42 ")
+	require.NoError(t, err)
+	assert.Equal(t, "API", projectKey)
+	assert.Equal(t, 42, number)
+
+	for _, key := range []string{"", "API", "API-", "-42", "API-0", "API-042", "API-4x", "API-+4"} {
+		_, _, err := ParseKey(key)
+		assert.ErrorIs(t, err, ErrInvalidCardKey, key)
+	}
+}
+
+func TestGetCardByNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	svc := NewService(mockCardRepo, nil, nil, nil, nil, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		expected := &card.Card{ID: uuid.New(), Number: 7}
+		mockCardRepo.EXPECT().GetByProjectNumber(gomock.Any(), projectID, 7).Return(expected, nil)
+
+		result, err := svc.GetCardByNumber(ctx, projectID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockCardRepo.EXPECT().GetByProjectNumber(gomock.Any(), projectID, 8).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetCardByNumber(ctx, projectID, 8)
+		assert.ErrorIs(t, err, ErrCardNotFound)
+	})
+}
+
 func TestGetCardsByColumnID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -173,7 +531,7 @@ func TestGetCardsByColumnID(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	columnID := uuid.New()
@@ -203,7 +561,7 @@ func TestUpdateCard(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -267,6 +625,55 @@ func TestUpdateCard(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("success - re-estimate keeps the original estimate", func(t *testing.T) {
+		points := func(n int) *int { return &n }
+		for _, tc := range []struct {
+			name     string
+			original *int
+			want     int
+		}{
+			{name: "first estimate", original: nil, want: 8},
+			{name: "re-estimate", original: points(3), want: 3},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				mockCardRepo.EXPECT().
+					GetByID(gomock.Any(), cardID).
+					Return(&card.Card{ID: cardID, StoryPoints: tc.original, OriginalStoryPoints: tc.original}, nil)
+				mockCardRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+				result, err := svc.UpdateCard(ctx, UpdateCardInput{ID: cardID, StoryPoints: points(8)})
+				require.NoError(t, err)
+				assert.Equal(t, 8, *result.StoryPoints)
+				assert.Equal(t, tc.want, *result.OriginalStoryPoints)
+			})
+		}
+	})
+
+	t.Run("success - publishes card.assigned only for a new assignee", func(t *testing.T) {
+		bus := events.NewSyncBus()
+		var assigned []events.Event
+		bus.Subscribe(events.CardAssigned, func(ctx context.Context, e events.Event) error {
+			assigned = append(assigned, e)
+			return nil
+		})
+		svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), bus)
+
+		boardID := uuid.New()
+		assigneeID := uuid.New()
+		for _, previous := range []*uuid.UUID{nil, &assigneeID} {
+			mockCardRepo.EXPECT().
+				GetByID(gomock.Any(), cardID).
+				Return(&card.Card{ID: cardID, BoardID: boardID, AssigneeID: previous}, nil)
+			mockCardRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			_, err := svc.UpdateCard(ctx, UpdateCardInput{ID: cardID, AssigneeID: &assigneeID})
+			require.NoError(t, err)
+		}
+
+		require.Len(t, assigned, 1)
+		assert.Equal(t, events.CardAssignedPayload{CardID: cardID, BoardID: boardID, AssigneeID: assigneeID}, assigned[0].Payload)
+	})
+
 	t.Run("card not found", func(t *testing.T) {
 		mockCardRepo.EXPECT().
 			GetByID(gomock.Any(), cardID).
@@ -289,7 +696,16 @@ func TestMoveCard(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	mockWorkflowSvc := workflowMocks.NewMockService(ctrl)
+
+	bus := events.NewSyncBus()
+	var moved []events.Event
+	bus.Subscribe(events.CardMoved, func(ctx context.Context, e events.Event) error {
+		moved = append(moved, e)
+		return nil
+	})
+
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, mockWorkflowSvc, content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), bus)
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -312,21 +728,25 @@ func TestMoveCard(t *testing.T) {
 			GetByID(gomock.Any(), targetColumnID).
 			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID}, nil)
 
-		mockCardRepo.EXPECT().
-			GetPositionBetween(gomock.Any(), targetColumnID, (*uuid.UUID)(nil)).
-			Return(float64(500), nil)
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(nil)
 
 		mockCardRepo.EXPECT().
-			Update(gomock.Any(), gomock.Any()).
-			DoAndReturn(func(ctx context.Context, c *card.Card) error {
-				assert.Equal(t, targetColumnID, c.ColumnID)
-				assert.Equal(t, float64(500), c.Position)
-				return nil
-			})
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID, Position: 500}, nil)
 
-		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil)
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
 		require.NoError(t, err)
 		assert.Equal(t, targetColumnID, result.ColumnID)
+
+		require.Len(t, moved, 1)
+		assert.Equal(t, events.CardMovedPayload{
+			CardID:       cardID,
+			BoardID:      boardID,
+			FromColumnID: sourceColumnID,
+			ToColumnID:   targetColumnID,
+		}, moved[0].Payload)
 	})
 
 	t.Run("success - move after another card", func(t *testing.T) {
@@ -344,20 +764,168 @@ func TestMoveCard(t *testing.T) {
 			GetByID(gomock.Any(), targetColumnID).
 			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID}, nil)
 
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(nil)
+
 		mockCardRepo.EXPECT().
-			GetPositionBetween(gomock.Any(), targetColumnID, &afterCardID).
-			Return(float64(1500), nil) // Between 1000 and 2000
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, &afterCardID).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID, Position: 1500}, nil) // Between 1000 and 2000
 
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, &afterCardID, false)
+		require.NoError(t, err)
+		assert.Equal(t, float64(1500), result.Position)
+	})
+
+	t.Run("rejected by the board workflow", func(t *testing.T) {
 		mockCardRepo.EXPECT().
-			Update(gomock.Any(), gomock.Any()).
-			DoAndReturn(func(ctx context.Context, c *card.Card) error {
-				assert.Equal(t, float64(1500), c.Position)
-				return nil
-			})
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID}, nil)
+
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(&workflow.InvalidTransitionError{FromColumnID: sourceColumnID, ToColumnID: targetColumnID})
 
-		result, err := svc.MoveCard(ctx, cardID, targetColumnID, &afterCardID)
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, workflow.ErrInvalidTransition)
+	})
+
+	t.Run("bypass skips the board workflow", func(t *testing.T) {
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID}, nil)
+
+		mockCardRepo.EXPECT().
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID}, nil)
+
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, targetColumnID, result.ColumnID)
+	})
+
+	t.Run("rejected over the column point limit", func(t *testing.T) {
+		points, limit := 5, 8
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID, StoryPoints: &points}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID, PointLimit: &limit}, nil)
+
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(nil)
+
+		mockCardRepo.EXPECT().
+			LockColumnStoryPoints(gomock.Any(), targetColumnID).
+			Return(4, nil)
+
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrPointLimitExceeded)
+		var limitErr *PointLimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, PointLimitExceededError{ColumnID: targetColumnID, Points: 4, CardPoints: 5, PointLimit: 8}, *limitErr)
+	})
+
+	t.Run("allowed up to the column point limit", func(t *testing.T) {
+		points, limit := 4, 8
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID, StoryPoints: &points}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID, PointLimit: &limit}, nil)
+
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(nil)
+
+		mockCardRepo.EXPECT().
+			LockColumnStoryPoints(gomock.Any(), targetColumnID).
+			Return(4, nil)
+
+		mockCardRepo.EXPECT().
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID}, nil)
+
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, targetColumnID, result.ColumnID)
+	})
+
+	t.Run("reordering within a full column is not limited", func(t *testing.T) {
+		points, limit := 5, 3
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID, StoryPoints: &points}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID, PointLimit: &limit}, nil)
+
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, targetColumnID, targetColumnID).
+			Return(nil)
+
+		mockCardRepo.EXPECT().
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID}, nil)
+
+		_, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("bypass skips the column point limit", func(t *testing.T) {
+		points, limit := 5, 3
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID, StoryPoints: &points}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID, PointLimit: &limit}, nil)
+
+		mockCardRepo.EXPECT().
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(&card.Card{ID: cardID, ColumnID: targetColumnID, BoardID: boardID}, nil)
+
+		_, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, true)
 		require.NoError(t, err)
-		assert.NotNil(t, result)
+	})
+
+	t.Run("card deleted during move", func(t *testing.T) {
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, ColumnID: sourceColumnID, BoardID: boardID}, nil)
+
+		mockColumnRepo.EXPECT().
+			GetByID(gomock.Any(), targetColumnID).
+			Return(&board_column.BoardColumn{ID: targetColumnID, BoardID: boardID}, nil)
+
+		mockWorkflowSvc.EXPECT().
+			CheckTransition(gomock.Any(), boardID, sourceColumnID, targetColumnID).
+			Return(nil)
+
+		mockCardRepo.EXPECT().
+			MoveCard(gomock.Any(), cardID, targetColumnID, boardID, (*uuid.UUID)(nil)).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrCardNotFound)
 	})
 
 	t.Run("card not found", func(t *testing.T) {
@@ -365,7 +933,7 @@ func TestMoveCard(t *testing.T) {
 			GetByID(gomock.Any(), cardID).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil)
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, ErrCardNotFound)
 	})
@@ -380,7 +948,7 @@ func TestMoveCard(t *testing.T) {
 			GetByID(gomock.Any(), targetColumnID).
 			Return(nil, gorm.ErrRecordNotFound)
 
-		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil)
+		result, err := svc.MoveCard(ctx, cardID, targetColumnID, nil, false)
 		assert.Nil(t, result)
 		assert.ErrorIs(t, err, ErrColumnNotFound)
 	})
@@ -396,18 +964,43 @@ func TestDeleteCard(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	bus := events.NewSyncBus()
+	var deleted []events.Event
+	bus.Subscribe(events.CardDeleted, func(ctx context.Context, e events.Event) error {
+		deleted = append(deleted, e)
+		return nil
+	})
+
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), bus)
 	ctx := context.Background()
 
 	cardID := uuid.New()
+	boardID := uuid.New()
+	columnID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(&card.Card{ID: cardID, BoardID: boardID, ColumnID: columnID}, nil)
 		mockCardRepo.EXPECT().
 			Delete(gomock.Any(), cardID).
 			Return(nil)
 
 		err := svc.DeleteCard(ctx, cardID)
 		require.NoError(t, err)
+
+		require.Len(t, deleted, 1)
+		assert.Equal(t, events.CardPayload{CardID: cardID, BoardID: boardID, ColumnID: columnID}, deleted[0].Payload)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		mockCardRepo.EXPECT().
+			GetByID(gomock.Any(), cardID).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		err := svc.DeleteCard(ctx, cardID)
+		assert.ErrorIs(t, err, ErrCardNotFound)
+		assert.Len(t, deleted, 1)
 	})
 }
 
@@ -421,7 +1014,7 @@ func TestGetTagsForCard(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -470,7 +1063,7 @@ func TestGetBoardByCardID(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -511,7 +1104,7 @@ func TestGetColumnByCardID(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	cardID := uuid.New()
@@ -552,7 +1145,7 @@ func TestGetCardsByAssigneeID(t *testing.T) {
 	mockTagRepo := tagMocks.NewMockRepository(ctrl)
 	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, mockTagRepo, mockCardTagRepo, nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
 	ctx := context.Background()
 
 	assigneeID := uuid.New()
@@ -572,3 +1165,83 @@ func TestGetCardsByAssigneeID(t *testing.T) {
 		assert.Len(t, result, 2)
 	})
 }
+
+func TestSuggestDueDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockCalendarRepo := calendarRepoMocks.NewMockRepository(ctrl)
+
+	calendarSvc := calendar.NewService(mockCalendarRepo, nil)
+	svc := NewService(mockCardRepo, mockColumnRepo, mockBoardRepo, tagMocks.NewMockRepository(ctrl), cardTagMocks.NewMockRepository(ctrl), nil, workflowMocks.NewMockService(ctrl), content.NewService(nil, nil, nil, content.Limits{}), calendarSvc, transaction.NewNoopManager(), events.NewSyncBus())
+	// Friday, 18 December 2026
+	svc.(*service).now = func() time.Time { return time.Date(2026, 12, 18, 9, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	projectID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: projectID}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, IsDone: true}
+	assigneeID := uuid.New()
+	points := func(n int) *int { return &n }
+
+	christmas := &project_calendar.ProjectHoliday{ID: uuid.New(), ProjectID: projectID, Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Name: "Christmas"}
+	expectCalendar := func() {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockCalendarRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(&project_calendar.ProjectCalendar{
+			ProjectID:    projectID,
+			WorkingDays:  project_calendar.DefaultWorkingDays,
+			PointsPerDay: 2,
+		}, nil)
+		mockCalendarRepo.EXPECT().GetHolidays(gomock.Any(), projectID, gomock.Any()).Return([]*project_calendar.ProjectHoliday{christmas}, nil)
+	}
+
+	t.Run("queues the estimate after the assignee's open cards", func(t *testing.T) {
+		editing := &card.Card{ID: uuid.New(), ColumnID: todo.ID, StoryPoints: points(8)}
+		expectCalendar()
+		mockCardRepo.EXPECT().GetByAssigneeID(gomock.Any(), assigneeID).Return([]*card.Card{
+			editing,
+			{ID: uuid.New(), ColumnID: todo.ID, StoryPoints: points(5)},
+			{ID: uuid.New(), ColumnID: todo.ID},
+			{ID: uuid.New(), ColumnID: done.ID, StoryPoints: points(13)},
+		}, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), todo.ID).Return(todo, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), done.ID).Return(done, nil)
+
+		suggestion, err := svc.SuggestDueDate(ctx, SuggestDueDateInput{
+			BoardID:     b.ID,
+			CardID:      &editing.ID,
+			AssigneeID:  &assigneeID,
+			StoryPoints: points(8),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, suggestion.WorkloadPoints)
+		assert.Equal(t, 2, suggestion.WorkloadCards)
+		assert.Equal(t, 8, suggestion.EstimatePoints)
+		// 13 points at 2 per day: Fri 18, Mon 21 to Thu 24, Mon 28 and Tue 29, skipping Christmas
+		assert.Equal(t, 7, suggestion.WorkingDays)
+		assert.Equal(t, "2026-12-29", suggestion.DueDate.Format(time.DateOnly))
+		require.Len(t, suggestion.SkippedHolidays, 1)
+		assert.Equal(t, "Christmas", suggestion.SkippedHolidays[0].Name)
+	})
+
+	t.Run("unestimated unassigned work takes one working day", func(t *testing.T) {
+		expectCalendar()
+		saturday := time.Date(2026, 12, 19, 0, 0, 0, 0, time.UTC)
+
+		suggestion, err := svc.SuggestDueDate(ctx, SuggestDueDateInput{BoardID: b.ID, StartDate: &saturday})
+		require.NoError(t, err)
+		assert.Equal(t, 1, suggestion.WorkingDays)
+		assert.Equal(t, "2026-12-21", suggestion.DueDate.Format(time.DateOnly))
+	})
+
+	t.Run("fail - board not found", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SuggestDueDate(ctx, SuggestDueDateInput{BoardID: b.ID})
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+}
@@ -0,0 +1,82 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MaxCardsFromText caps how many cards one paste can create
+const MaxCardsFromText = 100
+
+var (
+	ErrNoCardTitles = errors.New("the text contains no card titles")
+	ErrTooManyCards = fmt.Errorf("the text contains more than %d card titles", MaxCardsFromText)
+)
+
+var (
+	listMarkerPattern  = regexp.MustCompile(`^(?:[-*+]|\d+[.)])(?:\s+|$)`)
+	checkboxPattern    = regexp.MustCompile(`^\[[ xX]?\]\s*`)
+	headingMarkPattern = regexp.MustCompile(`^#{1,6}\s+`)
+)
+
+// ParseCardTitles splits pasted text into card titles, one per non-blank line. Markdown
+// list markers ("- ", "* ", "1. "), checkboxes ("[ ]", "[x]") and heading marks are
+// stripped, so lists copied from documents and issue descriptions paste cleanly.
+func ParseCardTitles(text string) []string {
+	var titles []string
+	for _, line := range strings.Split(text, "\n") {
+		title := strings.TrimSpace(line)
+		title = headingMarkPattern.ReplaceAllString(title, "")
+		title = listMarkerPattern.ReplaceAllString(title, "")
+		title = checkboxPattern.ReplaceAllString(title, "")
+		title = strings.TrimSpace(title)
+		if title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+func (s *service) CreateCardsFromText(ctx context.Context, columnID uuid.UUID, text string, createdBy *uuid.UUID) ([]*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateCardsFromText")
+	span.SetAttributes(attribute.String("card.column_id", columnID.String()))
+	defer span.End()
+
+	titles := ParseCardTitles(text)
+	if len(titles) == 0 {
+		return nil, ErrNoCardTitles
+	}
+	if len(titles) > MaxCardsFromText {
+		return nil, ErrTooManyCards
+	}
+	span.SetAttributes(attribute.Int("card.count", len(titles)))
+
+	cards := make([]*card.Card, 0, len(titles))
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, title := range titles {
+			c, err := s.CreateCard(ctx, CreateCardInput{
+				ColumnID:  columnID,
+				Title:     title,
+				CreatedBy: createdBy,
+				// A pasted list is one submission, even when it repeats a title
+				SkipFloodCheck: true,
+			})
+			if err != nil {
+				return err
+			}
+			cards = append(cards, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
@@ -0,0 +1,166 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"html"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// maxChecklistItemLength keeps checklist items to a line of text
+const maxChecklistItemLength = 500
+
+// ColumnDefaultsInput describes the defaults of a column; nil and empty fields are not defaulted
+type ColumnDefaultsInput struct {
+	Priority    *card.CardPriority
+	AssigneeID  *uuid.UUID
+	TagIDs      []uuid.UUID
+	StoryPoints *int
+	Description string
+	Checklist   []string
+}
+
+func (s *service) GetColumnDefaults(ctx context.Context, columnID uuid.UUID) (*column_defaults.ColumnDefaults, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetColumnDefaults")
+	span.SetAttributes(attribute.String("column.id", columnID.String()))
+	defer span.End()
+
+	defaults, err := s.defaultsRepo.GetByColumnID(ctx, columnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &column_defaults.ColumnDefaults{ColumnID: columnID}, nil
+		}
+		return nil, err
+	}
+	return defaults, nil
+}
+
+func (s *service) SetColumnDefaults(ctx context.Context, columnID uuid.UUID, input ColumnDefaultsInput) (*column_defaults.ColumnDefaults, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetColumnDefaults")
+	span.SetAttributes(attribute.String("column.id", columnID.String()))
+	defer span.End()
+
+	col, err := s.columnRepo.GetByID(ctx, columnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrColumnNotFound
+		}
+		return nil, err
+	}
+
+	if input.StoryPoints != nil && *input.StoryPoints < 0 {
+		return nil, ErrNegativeStoryPoints
+	}
+
+	defaults := &column_defaults.ColumnDefaults{
+		ColumnID:    columnID,
+		Priority:    input.Priority,
+		AssigneeID:  input.AssigneeID,
+		StoryPoints: input.StoryPoints,
+		Description: sanitize.HTML(input.Description),
+	}
+	if defaults.Description != "" {
+		if err := s.checkContent(ctx, col.BoardID, nil, &defaults.Description); err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range input.Checklist {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if utf8.RuneCountInString(item) > maxChecklistItemLength {
+			return nil, ErrChecklistItemTooLong
+		}
+		defaults.Checklist = append(defaults.Checklist, item)
+	}
+
+	if len(input.TagIDs) > 0 {
+		b, err := s.boardRepo.GetByID(ctx, col.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		tags, err := s.tagRepo.GetByIDs(ctx, input.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			if t.ProjectID != b.ProjectID {
+				return nil, ErrTagNotInProject
+			}
+			defaults.TagIDs = append(defaults.TagIDs, t.ID)
+		}
+		if len(defaults.TagIDs) != len(input.TagIDs) {
+			return nil, ErrTagNotInProject
+		}
+	}
+
+	if defaults.IsEmpty() {
+		if err := s.defaultsRepo.Delete(ctx, columnID); err != nil {
+			return nil, err
+		}
+		return defaults, nil
+	}
+	if err := s.defaultsRepo.Save(ctx, defaults); err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// applyColumnDefaults fills in the fields of a new card that the input leaves empty from
+// its column's defaults. Default tags are added to the given ones and the checklist is
+// appended to the description.
+func (s *service) applyColumnDefaults(ctx context.Context, input *CreateCardInput) error {
+	defaults, err := s.defaultsRepo.GetByColumnID(ctx, input.ColumnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if input.Priority == "" && defaults.Priority != nil {
+		input.Priority = *defaults.Priority
+	}
+	if input.AssigneeID == nil {
+		input.AssigneeID = defaults.AssigneeID
+	}
+	if input.StoryPoints == nil {
+		input.StoryPoints = defaults.StoryPoints
+	}
+	for _, tagID := range defaults.TagIDs {
+		if !slices.Contains(input.TagIDs, tagID) {
+			input.TagIDs = append(input.TagIDs, tagID)
+		}
+	}
+	if input.Description == "" {
+		input.Description = defaults.Description
+	}
+	input.Description += ChecklistHTML(defaults.Checklist)
+	return nil
+}
+
+// ChecklistHTML renders checklist items as an unticked task list the editor can show
+func ChecklistHTML(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("<ul>")
+	for _, item := range items {
+		b.WriteString("<li><p>[ ] ")
+		b.WriteString(html.EscapeString(item))
+		b.WriteString("</p></li>")
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
@@ -0,0 +1,302 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: card_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=card_service.go -destination=mocks/card_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	board_column "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	column_defaults "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	tag "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	card0 "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCard mocks base method.
+func (m *MockService) CreateCard(ctx context.Context, input card0.CreateCardInput) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCard", ctx, input)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockServiceMockRecorder) CreateCard(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockService)(nil).CreateCard), ctx, input)
+}
+
+// CreateCardsFromText mocks base method.
+func (m *MockService) CreateCardsFromText(ctx context.Context, columnID uuid.UUID, text string, createdBy *uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardsFromText", ctx, columnID, text, createdBy)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardsFromText indicates an expected call of CreateCardsFromText.
+func (mr *MockServiceMockRecorder) CreateCardsFromText(ctx, columnID, text, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardsFromText", reflect.TypeOf((*MockService)(nil).CreateCardsFromText), ctx, columnID, text, createdBy)
+}
+
+// DeleteCard mocks base method.
+func (m *MockService) DeleteCard(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCard", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCard indicates an expected call of DeleteCard.
+func (mr *MockServiceMockRecorder) DeleteCard(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockService)(nil).DeleteCard), ctx, id)
+}
+
+// GetBoardByCardID mocks base method.
+func (m *MockService) GetBoardByCardID(ctx context.Context, cardID uuid.UUID) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardByCardID", ctx, cardID)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardByCardID indicates an expected call of GetBoardByCardID.
+func (mr *MockServiceMockRecorder) GetBoardByCardID(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardByCardID", reflect.TypeOf((*MockService)(nil).GetBoardByCardID), ctx, cardID)
+}
+
+// GetCard mocks base method.
+func (m *MockService) GetCard(ctx context.Context, id uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCard", ctx, id)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCard indicates an expected call of GetCard.
+func (mr *MockServiceMockRecorder) GetCard(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCard", reflect.TypeOf((*MockService)(nil).GetCard), ctx, id)
+}
+
+// GetCardByNumber mocks base method.
+func (m *MockService) GetCardByNumber(ctx context.Context, projectID uuid.UUID, number int) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardByNumber", ctx, projectID, number)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardByNumber indicates an expected call of GetCardByNumber.
+func (mr *MockServiceMockRecorder) GetCardByNumber(ctx, projectID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardByNumber", reflect.TypeOf((*MockService)(nil).GetCardByNumber), ctx, projectID, number)
+}
+
+// GetCardsByAssigneeID mocks base method.
+func (m *MockService) GetCardsByAssigneeID(ctx context.Context, assigneeID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsByAssigneeID", ctx, assigneeID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsByAssigneeID indicates an expected call of GetCardsByAssigneeID.
+func (mr *MockServiceMockRecorder) GetCardsByAssigneeID(ctx, assigneeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsByAssigneeID", reflect.TypeOf((*MockService)(nil).GetCardsByAssigneeID), ctx, assigneeID)
+}
+
+// GetCardsByBoardID mocks base method.
+func (m *MockService) GetCardsByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsByBoardID", ctx, boardID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsByBoardID indicates an expected call of GetCardsByBoardID.
+func (mr *MockServiceMockRecorder) GetCardsByBoardID(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsByBoardID", reflect.TypeOf((*MockService)(nil).GetCardsByBoardID), ctx, boardID)
+}
+
+// GetCardsByColumnID mocks base method.
+func (m *MockService) GetCardsByColumnID(ctx context.Context, columnID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsByColumnID", ctx, columnID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsByColumnID indicates an expected call of GetCardsByColumnID.
+func (mr *MockServiceMockRecorder) GetCardsByColumnID(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsByColumnID", reflect.TypeOf((*MockService)(nil).GetCardsByColumnID), ctx, columnID)
+}
+
+// GetColumnByCardID mocks base method.
+func (m *MockService) GetColumnByCardID(ctx context.Context, cardID uuid.UUID) (*board_column.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnByCardID", ctx, cardID)
+	ret0, _ := ret[0].(*board_column.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnByCardID indicates an expected call of GetColumnByCardID.
+func (mr *MockServiceMockRecorder) GetColumnByCardID(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnByCardID", reflect.TypeOf((*MockService)(nil).GetColumnByCardID), ctx, cardID)
+}
+
+// GetColumnDefaults mocks base method.
+func (m *MockService) GetColumnDefaults(ctx context.Context, columnID uuid.UUID) (*column_defaults.ColumnDefaults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnDefaults", ctx, columnID)
+	ret0, _ := ret[0].(*column_defaults.ColumnDefaults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnDefaults indicates an expected call of GetColumnDefaults.
+func (mr *MockServiceMockRecorder) GetColumnDefaults(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnDefaults", reflect.TypeOf((*MockService)(nil).GetColumnDefaults), ctx, columnID)
+}
+
+// GetColumnStoryPoints mocks base method.
+func (m *MockService) GetColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnStoryPoints", ctx, columnID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnStoryPoints indicates an expected call of GetColumnStoryPoints.
+func (mr *MockServiceMockRecorder) GetColumnStoryPoints(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnStoryPoints", reflect.TypeOf((*MockService)(nil).GetColumnStoryPoints), ctx, columnID)
+}
+
+// GetTagsForCard mocks base method.
+func (m *MockService) GetTagsForCard(ctx context.Context, cardID uuid.UUID) ([]*tag.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagsForCard", ctx, cardID)
+	ret0, _ := ret[0].([]*tag.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagsForCard indicates an expected call of GetTagsForCard.
+func (mr *MockServiceMockRecorder) GetTagsForCard(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagsForCard", reflect.TypeOf((*MockService)(nil).GetTagsForCard), ctx, cardID)
+}
+
+// MoveCard mocks base method.
+func (m *MockService) MoveCard(ctx context.Context, cardID, targetColumnID uuid.UUID, afterCardID *uuid.UUID, bypassWorkflow bool) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCard", ctx, cardID, targetColumnID, afterCardID, bypassWorkflow)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCard indicates an expected call of MoveCard.
+func (mr *MockServiceMockRecorder) MoveCard(ctx, cardID, targetColumnID, afterCardID, bypassWorkflow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCard", reflect.TypeOf((*MockService)(nil).MoveCard), ctx, cardID, targetColumnID, afterCardID, bypassWorkflow)
+}
+
+// SetColumnDefaults mocks base method.
+func (m *MockService) SetColumnDefaults(ctx context.Context, columnID uuid.UUID, input card0.ColumnDefaultsInput) (*column_defaults.ColumnDefaults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetColumnDefaults", ctx, columnID, input)
+	ret0, _ := ret[0].(*column_defaults.ColumnDefaults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetColumnDefaults indicates an expected call of SetColumnDefaults.
+func (mr *MockServiceMockRecorder) SetColumnDefaults(ctx, columnID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetColumnDefaults", reflect.TypeOf((*MockService)(nil).SetColumnDefaults), ctx, columnID, input)
+}
+
+// SuggestDueDate mocks base method.
+func (m *MockService) SuggestDueDate(ctx context.Context, input card0.SuggestDueDateInput) (*card0.DueDateSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestDueDate", ctx, input)
+	ret0, _ := ret[0].(*card0.DueDateSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestDueDate indicates an expected call of SuggestDueDate.
+func (mr *MockServiceMockRecorder) SuggestDueDate(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestDueDate", reflect.TypeOf((*MockService)(nil).SuggestDueDate), ctx, input)
+}
+
+// UpdateCard mocks base method.
+func (m *MockService) UpdateCard(ctx context.Context, input card0.UpdateCardInput) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCard", ctx, input)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCard indicates an expected call of UpdateCard.
+func (mr *MockServiceMockRecorder) UpdateCard(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockService)(nil).UpdateCard), ctx, input)
+}
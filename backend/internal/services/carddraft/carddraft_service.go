@@ -0,0 +1,305 @@
+package carddraft
+
+//go:generate mockgen -source=carddraft_service.go -destination=mocks/carddraft_service_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/llm"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+const (
+	// DraftsPerHour is how many drafts a user may request per hour after a burst of DraftBurst
+	DraftsPerHour = 30
+	// DraftBurst is how many drafts a user may request at once
+	DraftBurst = 5
+	// MaxPromptLength is the longest prompt, in characters
+	MaxPromptLength = 500
+	// maxTitleLength, maxCriteria, maxCriterionLength, maxTags and maxTagLength bound what a
+	// draft keeps of the model's reply
+	maxTitleLength     = 200
+	maxCriteria        = 10
+	maxCriterionLength = 500
+	maxTags            = 5
+	maxTagLength       = 100
+	// limiterIdleTTL is how long a user's limiter is kept after their last draft
+	limiterIdleTTL = 2 * time.Hour
+)
+
+var (
+	ErrDraftingUnavailable  = errors.New("AI card drafting is not configured")
+	ErrDraftingDisabled     = errors.New("AI card drafting is not enabled for this organization")
+	ErrRateLimited          = errors.New("too many card drafts, try again later")
+	ErrInvalidPrompt        = errors.New("prompt must be 1 to 500 characters")
+	ErrInvalidDraft         = errors.New("the language model did not return a usable card draft")
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+)
+
+// systemPrompt asks the model for a card as JSON
+const systemPrompt = `You draft cards for a kanban board from a one-line request.
+Reply with only a JSON object, no other text, in this form:
+{"title": "...", "description": "...", "acceptance_criteria": ["..."], "tags": ["..."]}
+- title: a short imperative summary, at most 80 characters
+- description: one or two plain-text paragraphs explaining the context and goal; separate paragraphs with a blank line
+- acceptance_criteria: 2 to 6 concrete, testable conditions for the card to be done
+- tags: up to 3 tags; prefer the project's existing tags, and only suggest a new tag when none fit
+Write in the language of the request.`
+
+// Draft is a card drafted from a prompt. Drafts aren't stored; the user edits and creates the
+// card.
+type Draft struct {
+	Title string
+	// Description is HTML: the drafted paragraphs followed by the acceptance criteria as an
+	// unticked checklist, ready to use as the card's description
+	Description        string
+	AcceptanceCriteria []string
+	Tags               []*SuggestedTag
+}
+
+// SuggestedTag is a tag proposed for a drafted card
+type SuggestedTag struct {
+	Name string
+	// Tag is the project's existing tag of that name, nil when the tag would be new
+	Tag *tag.Tag
+}
+
+type Service interface {
+	// Draft expands a one-line prompt into a card for a project, with the configured language
+	// model. The project's organization must have enabled drafting, and each user may request
+	// DraftsPerHour drafts after a burst of DraftBurst.
+	Draft(ctx context.Context, userID, projectID uuid.UUID, prompt string) (*Draft, error)
+	// SetEnabled turns card drafting on or off for an organization
+	SetEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error)
+}
+
+// userLimiter is a user's draft rate limiter and when it was last used
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type service struct {
+	provider    llm.Provider
+	projectRepo project.Repository
+	orgRepo     organization.Repository
+	tagRepo     tag.Repository
+	now         func() time.Time
+
+	mu       sync.Mutex
+	limiters map[uuid.UUID]*userLimiter
+}
+
+// NewService creates the card drafting service. Without a provider, drafting is unavailable.
+func NewService(provider llm.Provider, projectRepo project.Repository, orgRepo organization.Repository, tagRepo tag.Repository) Service {
+	return &service{
+		provider:    provider,
+		projectRepo: projectRepo,
+		orgRepo:     orgRepo,
+		tagRepo:     tagRepo,
+		now:         time.Now,
+		limiters:    make(map[uuid.UUID]*userLimiter),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "carddraft.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "carddraft"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Draft(ctx context.Context, userID, projectID uuid.UUID, prompt string) (*Draft, error) {
+	ctx, span := s.startServiceSpan(ctx, "Draft")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if s.provider == nil {
+		return nil, ErrDraftingUnavailable
+	}
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" || utf8.RuneCountInString(prompt) > MaxPromptLength {
+		return nil, ErrInvalidPrompt
+	}
+
+	proj, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, proj.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	if !org.AIDraftingEnabled {
+		return nil, ErrDraftingDisabled
+	}
+	if !s.allow(userID) {
+		return nil, ErrRateLimited
+	}
+
+	tags, err := s.tagRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make([]string, len(tags))
+	for i, t := range tags {
+		tagNames[i] = t.Name
+	}
+	request := fmt.Sprintf("Project: %s\nExisting tags: %s\n\nRequest: %s", proj.Name, strings.Join(tagNames, ", "), prompt)
+
+	reply, err := s.provider.Complete(ctx, systemPrompt, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to draft card: %w", err)
+	}
+	return parseDraft(reply, tags)
+}
+
+func (s *service) SetEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetEnabled")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.Bool("enabled", enabled),
+	)
+	defer span.End()
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	org.AIDraftingEnabled = enabled
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// allow reports whether the user may request a draft now
+func (s *service) allow(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	l, ok := s.limiters[userID]
+	if !ok {
+		for id, idle := range s.limiters {
+			if idle.lastUsed.Before(now.Add(-limiterIdleTTL)) {
+				delete(s.limiters, id)
+			}
+		}
+		l = &userLimiter{limiter: rate.NewLimiter(rate.Limit(DraftsPerHour/time.Hour.Seconds()), DraftBurst)}
+		s.limiters[userID] = l
+	}
+	l.lastUsed = now
+	return l.limiter.AllowN(now, 1)
+}
+
+// rawDraft is the JSON the model is asked for
+type rawDraft struct {
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	AcceptanceCriteria []string `json:"acceptance_criteria"`
+	Tags               []string `json:"tags"`
+}
+
+// parseDraft reads the model's reply, which may wrap the JSON object in prose or a code
+// fence, and trims it to what a card holds
+func parseDraft(reply string, projectTags []*tag.Tag) (*Draft, error) {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, ErrInvalidDraft
+	}
+	var raw rawDraft
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &raw); err != nil {
+		return nil, ErrInvalidDraft
+	}
+
+	title := truncate(strings.Join(strings.Fields(raw.Title), " "), maxTitleLength)
+	if title == "" {
+		return nil, ErrInvalidDraft
+	}
+	draft := &Draft{Title: title, AcceptanceCriteria: []string{}, Tags: []*SuggestedTag{}}
+
+	for _, item := range raw.AcceptanceCriteria {
+		item = truncate(strings.Join(strings.Fields(item), " "), maxCriterionLength)
+		if item != "" && !slices.Contains(draft.AcceptanceCriteria, item) && len(draft.AcceptanceCriteria) < maxCriteria {
+			draft.AcceptanceCriteria = append(draft.AcceptanceCriteria, item)
+		}
+	}
+
+	for _, name := range raw.Tags {
+		name = truncate(strings.TrimSpace(name), maxTagLength)
+		if name == "" || len(draft.Tags) == maxTags || hasTag(draft.Tags, name) {
+			continue
+		}
+		suggested := &SuggestedTag{Name: name}
+		for _, t := range projectTags {
+			if strings.EqualFold(t.Name, name) {
+				suggested.Name, suggested.Tag = t.Name, t
+				break
+			}
+		}
+		draft.Tags = append(draft.Tags, suggested)
+	}
+
+	var description strings.Builder
+	for _, paragraph := range strings.Split(raw.Description, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			description.WriteString("<p>")
+			description.WriteString(html.EscapeString(paragraph))
+			description.WriteString("</p>")
+		}
+	}
+	description.WriteString(card.ChecklistHTML(draft.AcceptanceCriteria))
+	draft.Description = description.String()
+	return draft, nil
+}
+
+// hasTag reports whether a tag of that name, ignoring case, was suggested
+func hasTag(tags []*SuggestedTag, name string) bool {
+	return slices.ContainsFunc(tags, func(t *SuggestedTag) bool {
+		return strings.EqualFold(t.Name, name)
+	})
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return strings.TrimSpace(string(runes[:n]))
+	}
+	return s
+}
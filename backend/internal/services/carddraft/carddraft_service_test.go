@@ -0,0 +1,204 @@
+package carddraft
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	llmMocks "github.com/thatcatdev/kaimu/backend/internal/services/llm/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDraft(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+	projectID := uuid.New()
+	proj := &project.Project{ID: projectID, OrganizationID: orgID, Name: "Web app"}
+	authTag := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Auth"}
+
+	expectProject := func(mockProjectRepo *projectMocks.MockRepository, mockOrgRepo *orgMocks.MockRepository, enabled bool) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(proj, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, AIDraftingEnabled: enabled}, nil)
+	}
+
+	t.Run("success - structured card with existing tags matched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectProject(mockProjectRepo, mockOrgRepo, true)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*tag.Tag{authTag}, nil)
+		mockProvider.EXPECT().Complete(gomock.Any(), systemPrompt, "Project: Web app\nExisting tags: Auth\n\nRequest: login broken on safari").
+			Return("Here is the card:\n```json\n"+`{
+				"title": "Fix login on Safari",
+				"description": "Users on Safari can't sign in.\n\nThe session <cookie> is dropped.",
+				"acceptance_criteria": ["Users can sign in on Safari", " Users can sign in on Safari ", "", "Sessions survive a reload"],
+				"tags": ["auth", "Safari", "AUTH"]
+			}`+"\n```", nil)
+
+		draft, err := svc.Draft(ctx, userID, projectID, "  login broken on safari ")
+		require.NoError(t, err)
+		assert.Equal(t, "Fix login on Safari", draft.Title)
+		assert.Equal(t, []string{"Users can sign in on Safari", "Sessions survive a reload"}, draft.AcceptanceCriteria)
+		assert.Equal(t, "<p>Users on Safari can&#39;t sign in.</p><p>The session &lt;cookie&gt; is dropped.</p>"+
+			"<ul><li><p>[ ] Users can sign in on Safari</p></li><li><p>[ ] Sessions survive a reload</p></li></ul>", draft.Description)
+		require.Len(t, draft.Tags, 2)
+		assert.Equal(t, &SuggestedTag{Name: "Auth", Tag: authTag}, draft.Tags[0])
+		assert.Equal(t, &SuggestedTag{Name: "Safari"}, draft.Tags[1])
+	})
+
+	t.Run("error - drafting not configured", func(t *testing.T) {
+		svc := NewService(nil, nil, nil, nil)
+
+		_, err := svc.Draft(ctx, userID, projectID, "login broken")
+		assert.ErrorIs(t, err, ErrDraftingUnavailable)
+	})
+
+	t.Run("error - invalid prompt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.Draft(ctx, userID, projectID, "   ")
+		assert.ErrorIs(t, err, ErrInvalidPrompt)
+
+		_, err = svc.Draft(ctx, userID, projectID, strings.Repeat("a", MaxPromptLength+1))
+		assert.ErrorIs(t, err, ErrInvalidPrompt)
+	})
+
+	t.Run("error - organization hasn't enabled drafting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectProject(mockProjectRepo, mockOrgRepo, false)
+
+		_, err := svc.Draft(ctx, userID, projectID, "login broken")
+		assert.ErrorIs(t, err, ErrDraftingDisabled)
+	})
+
+	t.Run("error - unusable reply", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectProject(mockProjectRepo, mockOrgRepo, true)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(nil, nil)
+		mockProvider.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"description": "no title"}`, nil)
+
+		_, err := svc.Draft(ctx, userID, projectID, "login broken")
+		assert.ErrorIs(t, err, ErrInvalidDraft)
+	})
+
+	t.Run("error - provider failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectProject(mockProjectRepo, mockOrgRepo, true)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(nil, nil)
+		mockProvider.EXPECT().Complete(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("overloaded"))
+
+		_, err := svc.Draft(ctx, userID, projectID, "login broken")
+		assert.ErrorContains(t, err, "overloaded")
+	})
+
+	t.Run("error - rate limited after the burst", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		for i := 0; i < DraftBurst; i++ {
+			require.True(t, svc.allow(userID))
+		}
+		expectProject(mockProjectRepo, mockOrgRepo, true)
+
+		_, err := svc.Draft(ctx, userID, projectID, "login broken")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.True(t, svc.allow(uuid.New()), "other users are not limited")
+	})
+}
+
+func TestSetEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := llmMocks.NewMockProvider(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+	mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockProvider, mockProjectRepo, mockOrgRepo, mockTagRepo).(*service)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	orgID := uuid.New()
+
+	org := &organization.Organization{ID: orgID}
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(org, nil)
+	mockOrgRepo.EXPECT().Update(gomock.Any(), org).Return(nil)
+
+	result, err := svc.SetEnabled(context.Background(), orgID, true)
+	require.NoError(t, err)
+	assert.True(t, result.AIDraftingEnabled)
+}
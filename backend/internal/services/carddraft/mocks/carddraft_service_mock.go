@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: carddraft_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=carddraft_service.go -destination=mocks/carddraft_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	carddraft "github.com/thatcatdev/kaimu/backend/internal/services/carddraft"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Draft mocks base method.
+func (m *MockService) Draft(ctx context.Context, userID, projectID uuid.UUID, prompt string) (*carddraft.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Draft", ctx, userID, projectID, prompt)
+	ret0, _ := ret[0].(*carddraft.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Draft indicates an expected call of Draft.
+func (mr *MockServiceMockRecorder) Draft(ctx, userID, projectID, prompt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Draft", reflect.TypeOf((*MockService)(nil).Draft), ctx, userID, projectID, prompt)
+}
+
+// SetEnabled mocks base method.
+func (m *MockService) SetEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnabled", ctx, orgID, enabled)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEnabled indicates an expected call of SetEnabled.
+func (mr *MockServiceMockRecorder) SetEnabled(ctx, orgID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnabled", reflect.TypeOf((*MockService)(nil).SetEnabled), ctx, orgID, enabled)
+}
@@ -0,0 +1,342 @@
+package cardimport
+
+//go:generate mockgen -source=cardimport_service.go -destination=mocks/cardimport_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// defaultTagColor is the color of tags an import creates
+const defaultTagColor = "#6B7280"
+
+var (
+	ErrBoardNotFound     = errors.New("board not found")
+	ErrColumnNotInBoard  = errors.New("column does not belong to the board")
+	ErrProjectNotFound   = errors.New("project not found")
+	ErrBoardNotInProject = errors.New("board does not belong to the project")
+)
+
+// ImportInput is a CSV import into one column of a board
+type ImportInput struct {
+	CSV     string
+	Mapping []ColumnMapping
+	// ColumnID is the column the cards are created in; nil picks the board's first backlog
+	// column, or else its first column
+	ColumnID *uuid.UUID
+	// DryRun only validates the rows
+	DryRun    bool
+	CreatedBy *uuid.UUID
+}
+
+// Result reports on every row of an import. Cards are only created when no row has errors
+// and the import isn't a dry run, in which case Cards holds them in row order.
+type Result struct {
+	BoardID uuid.UUID
+	// ColumnID is the column cards go in unless their status maps them to another
+	ColumnID uuid.UUID
+	Rows     []*Row
+	// NewTags are the tag names the import creates in the project
+	NewTags []string
+	// Statuses and Assignees report how a Jira or Trello import places the cards and whom
+	// it assigns them to
+	Statuses  []StatusResult
+	Assignees []AssigneeResult
+	Cards     []*card.Card
+}
+
+// Valid reports whether every row can be imported
+func (r *Result) Valid() bool {
+	for _, row := range r.Rows {
+		if len(row.Errors) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type Service interface {
+	// Import reads cards from a CSV document and validates every row: assignees must be
+	// able to view the board's project and titles and descriptions must pass the content
+	// limits and policy. Unknown tags are created in the project. Rows are created in one
+	// transaction, so either every row is imported or none is. Problems with the document
+	// or mapping fail the import; problems with rows are reported in the result.
+	Import(ctx context.Context, boardID uuid.UUID, input ImportInput) (*Result, error)
+	// ImportExternal imports the cards of a Jira CSV or Trello JSON export into a board of
+	// the project, its default board unless another is given. Statuses and Trello lists
+	// map to columns and labels to tags. Source users are matched to members with user
+	// matches; uncertain matches queue for resolution, even on a dry run, and leave their
+	// cards unassigned until resolved. Rows are validated and created as Import does.
+	ImportExternal(ctx context.Context, projectID uuid.UUID, input ExternalInput) (*Result, error)
+}
+
+type service struct {
+	projectRepo  project.Repository
+	boardRepo    board.Repository
+	columnRepo   board_column.Repository
+	tagRepo      tag.Repository
+	userRepo     user.Repository
+	cardSvc      cardService.Service
+	contentSvc   content.Service
+	rbacSvc      rbac.Service
+	userMatchSvc usermatch.Service
+	txManager    transaction.Manager
+}
+
+func NewService(
+	projectRepo project.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	tagRepo tag.Repository,
+	userRepo user.Repository,
+	cardSvc cardService.Service,
+	contentSvc content.Service,
+	rbacSvc rbac.Service,
+	userMatchSvc usermatch.Service,
+	txManager transaction.Manager,
+) Service {
+	return &service{
+		projectRepo:  projectRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		tagRepo:      tagRepo,
+		userRepo:     userRepo,
+		cardSvc:      cardSvc,
+		contentSvc:   contentSvc,
+		rbacSvc:      rbacSvc,
+		userMatchSvc: userMatchSvc,
+		txManager:    txManager,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "cardimport.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "cardimport"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Import(ctx context.Context, boardID uuid.UUID, input ImportInput) (*Result, error) {
+	ctx, span := s.startServiceSpan(ctx, "Import")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.Bool("import.dry_run", input.DryRun),
+	)
+	defer span.End()
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	columnID, err := s.targetColumn(ctx, boardID, input.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ParseCSV(input.CSV, input.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("import.rows", len(rows)))
+	for _, row := range rows {
+		row.ColumnID = columnID
+	}
+
+	result := &Result{BoardID: boardID, ColumnID: columnID, Rows: rows}
+	if err := s.run(ctx, b, result, input.DryRun, input.CreatedBy); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// run validates the result's rows and, unless it is a dry run or a row has errors, creates
+// the new tags and the rows' cards in one transaction
+func (s *service) run(ctx context.Context, b *board.Board, result *Result, dryRun bool, createdBy *uuid.UUID) error {
+	projectTags, err := s.tagRepo.GetByProjectID(ctx, b.ProjectID)
+	if err != nil {
+		return err
+	}
+	tagIDs := make(map[string]uuid.UUID, len(projectTags))
+	for _, t := range projectTags {
+		tagIDs[strings.ToLower(t.Name)] = t.ID
+	}
+
+	assignees := map[string]*uuid.UUID{}
+	newTags := map[string]bool{}
+	for _, row := range result.Rows {
+		if err := s.validateRow(ctx, b, row, assignees); err != nil {
+			return err
+		}
+		for _, name := range row.Tags {
+			key := strings.ToLower(name)
+			if _, ok := tagIDs[key]; !ok && !newTags[key] {
+				newTags[key] = true
+				result.NewTags = append(result.NewTags, name)
+			}
+		}
+	}
+	if dryRun || !result.Valid() {
+		return nil
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, name := range result.NewTags {
+			t := &tag.Tag{ProjectID: b.ProjectID, Name: name, Color: defaultTagColor}
+			if err := s.tagRepo.Create(ctx, t); err != nil {
+				return err
+			}
+			tagIDs[strings.ToLower(name)] = t.ID
+		}
+		for _, row := range result.Rows {
+			cardInput := cardService.CreateCardInput{
+				ColumnID:    row.ColumnID,
+				Title:       row.Title,
+				Description: row.Description,
+				Priority:    row.Priority,
+				AssigneeID:  row.AssigneeID,
+				DueDate:     row.DueDate,
+				StoryPoints: row.StoryPoints,
+				CreatedBy:   createdBy,
+				// An import is one submission, even when rows repeat a title
+				SkipFloodCheck: true,
+			}
+			for _, name := range row.Tags {
+				cardInput.TagIDs = append(cardInput.TagIDs, tagIDs[strings.ToLower(name)])
+			}
+			c, err := s.cardSvc.CreateCard(ctx, cardInput)
+			if err != nil {
+				return err
+			}
+			result.Cards = append(result.Cards, c)
+		}
+		return nil
+	})
+}
+
+// targetColumn returns the requested column after checking it is on the board, or else the
+// board's first backlog column, or else its first column
+func (s *service) targetColumn(ctx context.Context, boardID uuid.UUID, columnID *uuid.UUID) (uuid.UUID, error) {
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if columnID != nil {
+		for _, col := range columns {
+			if col.ID == *columnID {
+				return col.ID, nil
+			}
+		}
+		return uuid.Nil, ErrColumnNotInBoard
+	}
+	return defaultColumn(columns)
+}
+
+// defaultColumn returns the first backlog column, or else the first column
+func defaultColumn(columns []*board_column.BoardColumn) (uuid.UUID, error) {
+	if len(columns) == 0 {
+		return uuid.Nil, ErrColumnNotInBoard
+	}
+	for _, col := range columns {
+		if col.IsBacklog {
+			return col.ID, nil
+		}
+	}
+	return columns[0].ID, nil
+}
+
+// validateRow checks what depends on the board: the content of the title and description
+// and the assignee. Assignees are looked up once per email and cached in assignees, with
+// nil for unknown emails, and set on the row.
+func (s *service) validateRow(ctx context.Context, b *board.Board, row *Row, assignees map[string]*uuid.UUID) error {
+	if row.Title != "" {
+		if err := s.contentSvc.Check(ctx, b.ID, content.FieldCardTitle, row.Title); err != nil {
+			if !isContentError(err) {
+				return err
+			}
+			row.fail(FieldTitle, CodeContentRejected, row.Title)
+		}
+	}
+	if row.Description != "" {
+		if err := s.contentSvc.Check(ctx, b.ID, content.FieldCardDescription, sanitize.HTML(row.Description)); err != nil {
+			if !isContentError(err) {
+				return err
+			}
+			row.fail(FieldDescription, CodeContentRejected, "")
+		}
+	}
+
+	if row.AssigneeEmail == "" {
+		return nil
+	}
+	key := strings.ToLower(row.AssigneeEmail)
+	id, ok := assignees[key]
+	if !ok {
+		var err error
+		id, err = s.lookupAssignee(ctx, b.ProjectID, row.AssigneeEmail)
+		if err != nil {
+			return err
+		}
+		assignees[key] = id
+	}
+	if id == nil {
+		row.fail(FieldAssigneeEmail, CodeUnknownAssignee, row.AssigneeEmail)
+	}
+	row.AssigneeID = id
+	return nil
+}
+
+// lookupAssignee returns the user with the email if they can view the project, else nil
+func (s *service) lookupAssignee(ctx context.Context, projectID uuid.UUID, email string) (*uuid.UUID, error) {
+	u, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) && email != strings.ToLower(email) {
+		u, err = s.userRepo.GetByEmail(ctx, strings.ToLower(email))
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	canView, err := s.rbacSvc.HasProjectPermission(ctx, u.ID, projectID, "project:view")
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, nil
+	}
+	return &u.ID, nil
+}
+
+func isContentError(err error) bool {
+	var limitErr *content.LimitError
+	var policyErr *content.PolicyError
+	return errors.As(err, &limitErr) || errors.As(err, &policyErr)
+}
@@ -0,0 +1,192 @@
+package cardimport
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	cardServiceMocks "github.com/thatcatdev/kaimu/backend/internal/services/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	userMatchMocks "github.com/thatcatdev/kaimu/backend/internal/services/usermatch/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Position: 1}
+	backlog := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Position: 0, IsBacklog: true}
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Bug"}
+	ana := &user.User{ID: uuid.New()}
+	creator := uuid.New()
+
+	mapping := []ColumnMapping{
+		{Header: "Title", Field: FieldTitle},
+		{Header: "Assignee", Field: FieldAssigneeEmail},
+		{Header: "Tags", Field: FieldTags},
+	}
+	csv := "Title,Assignee,Tags\nFix login,ana@example.com,bug\nWrite docs,ANA@example.com,\"Docs, bug\"\n"
+
+	expectBoard := func(mockBoardRepo *boardMocks.MockRepository, mockColumnRepo *columnMocks.MockRepository, mockTagRepo *tagMocks.MockRepository) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{backlog, todo}, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*tag.Tag{bug}, nil)
+	}
+	expectAna := func(mockUserRepo *userMocks.MockRepository, mockRbacSvc *rbacMocks.MockService) {
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "ana@example.com").Return(ana, nil)
+		mockRbacSvc.EXPECT().HasProjectPermission(gomock.Any(), ana.ID, projectID, "project:view").Return(true, nil)
+	}
+
+	t.Run("creates every row into the backlog", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockBoardRepo, mockColumnRepo, mockTagRepo)
+		expectAna(mockUserRepo, mockRbacSvc)
+
+		var docsID uuid.UUID
+		mockTagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, t *tag.Tag) error {
+			t.ID = uuid.New()
+			docsID = t.ID
+			return nil
+		})
+		var inputs []cardService.CreateCardInput
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			inputs = append(inputs, input)
+			return &card.Card{ID: uuid.New(), Title: input.Title}, nil
+		})
+
+		result, err := svc.Import(ctx, boardID, ImportInput{CSV: csv, Mapping: mapping, CreatedBy: &creator})
+		require.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, backlog.ID, result.ColumnID)
+		assert.Equal(t, []string{"Docs"}, result.NewTags)
+		require.Len(t, result.Cards, 2)
+
+		require.Len(t, inputs, 2)
+		assert.Equal(t, backlog.ID, inputs[0].ColumnID)
+		assert.Equal(t, ana.ID, *inputs[0].AssigneeID)
+		assert.Equal(t, []uuid.UUID{bug.ID}, inputs[0].TagIDs)
+		assert.Equal(t, ana.ID, *inputs[1].AssigneeID)
+		assert.Equal(t, []uuid.UUID{docsID, bug.ID}, inputs[1].TagIDs)
+		assert.Equal(t, &creator, inputs[1].CreatedBy)
+	})
+
+	t.Run("dry run creates nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockBoardRepo, mockColumnRepo, mockTagRepo)
+		expectAna(mockUserRepo, mockRbacSvc)
+
+		result, err := svc.Import(ctx, boardID, ImportInput{CSV: csv, Mapping: mapping, DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Len(t, result.Rows, 2)
+		assert.Empty(t, result.Cards)
+	})
+
+	t.Run("row errors create nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockBoardRepo, mockColumnRepo, mockTagRepo)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "bob@example.com").Return(nil, gorm.ErrRecordNotFound)
+		outsider := &user.User{ID: uuid.New()}
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "eve@example.com").Return(outsider, nil)
+		mockRbacSvc.EXPECT().HasProjectPermission(gomock.Any(), outsider.ID, projectID, "project:view").Return(false, nil)
+
+		result, err := svc.Import(ctx, boardID, ImportInput{
+			CSV:     "Title,Assignee,Tags\n" + strings.Repeat("x", 21) + ",bob@example.com,\nOk,eve@example.com,\n",
+			Mapping: mapping,
+		})
+		require.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Equal(t, []RowError{
+			{Field: FieldTitle, Code: CodeContentRejected, Value: strings.Repeat("x", 21)},
+			{Field: FieldAssigneeEmail, Code: CodeUnknownAssignee, Value: "bob@example.com"},
+		}, result.Rows[0].Errors)
+		assert.Equal(t, []RowError{
+			{Field: FieldAssigneeEmail, Code: CodeUnknownAssignee, Value: "eve@example.com"},
+		}, result.Rows[1].Errors)
+		assert.Empty(t, result.Cards)
+	})
+
+	t.Run("column of another board", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{backlog, todo}, nil)
+
+		other := uuid.New()
+		_, err := svc.Import(ctx, boardID, ImportInput{CSV: csv, Mapping: mapping, ColumnID: &other})
+		assert.ErrorIs(t, err, ErrColumnNotInBoard)
+	})
+}
@@ -0,0 +1,273 @@
+package cardimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+const (
+	// MaxCSVSize is the largest CSV document accepted, in bytes
+	MaxCSVSize = 1 << 20
+	// MaxRows caps how many cards one import can create
+	MaxRows = 500
+
+	maxTagNameLength = 100
+)
+
+// Field is a card field a CSV column can be mapped to
+type Field string
+
+const (
+	FieldTitle         Field = "title"
+	FieldDescription   Field = "description"
+	FieldAssigneeEmail Field = "assignee_email"
+	FieldTags          Field = "tags"
+	FieldStoryPoints   Field = "story_points"
+	FieldDueDate       Field = "due_date"
+)
+
+// Row error codes
+const (
+	CodeRequired        = "required"
+	CodeInvalidNumber   = "invalid_number"
+	CodeInvalidDate     = "invalid_date"
+	CodeTagTooLong      = "tag_too_long"
+	CodeUnknownAssignee = "unknown_assignee"
+	CodeContentRejected = "content_rejected"
+)
+
+var (
+	ErrInvalidCSV      = errors.New("invalid CSV")
+	ErrEmptyCSV        = errors.New("the CSV has no rows below its header")
+	ErrTooManyRows     = fmt.Errorf("the CSV has more than %d rows", MaxRows)
+	ErrCSVTooLarge     = fmt.Errorf("the CSV is larger than %d bytes", MaxCSVSize)
+	ErrTitleNotMapped  = errors.New("a CSV column must be mapped to the card title")
+	ErrUnknownHeader   = errors.New("the column mapping names a header that is not in the CSV")
+	ErrDuplicateField  = errors.New("a card field is mapped more than once")
+	ErrDuplicateHeader = errors.New("a CSV header is mapped more than once")
+)
+
+// ColumnMapping maps the CSV column with the given header to a card field
+type ColumnMapping struct {
+	Header string
+	Field  Field
+}
+
+// RowError is a problem with one field of a row
+type RowError struct {
+	Field Field
+	Code  string
+	// Value is the offending value, as found in the CSV
+	Value string
+}
+
+// Row is one CSV row, or one card of a Jira or Trello export, read into card fields. Line
+// is the row's line in the CSV, counting the header as line 1, or the card's position in a
+// Trello export.
+type Row struct {
+	Line          int
+	Title         string
+	Description   string
+	AssigneeEmail string
+	Tags          []string
+	StoryPoints   *int
+	DueDate       *time.Time
+	Errors        []RowError
+
+	// SourceKey identifies the card in Jira or Trello, e.g. its issue key
+	SourceKey string
+	// Status is the card's status in Jira, or its list in Trello
+	Status string
+	// Assignee is who the card is assigned to in Jira or Trello
+	Assignee *usermatch.ExternalUser
+	// Priority is the card's priority in Jira
+	Priority card.CardPriority
+
+	// ColumnID and AssigneeID are the column the card is created in and who it is assigned
+	// to, once the import has resolved them
+	ColumnID   uuid.UUID
+	AssigneeID *uuid.UUID
+}
+
+func (r *Row) fail(field Field, code, value string) {
+	r.Errors = append(r.Errors, RowError{Field: field, Code: code, Value: value})
+}
+
+// dueDateLayouts are the due date formats accepted, tried in order
+var dueDateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// ParseCSV reads card rows from a CSV document with a header row. Values are checked for
+// form only; problems are reported on the rows, while problems with the document or the
+// mapping fail the whole import. Blank rows are skipped.
+func ParseCSV(data string, mapping []ColumnMapping) ([]*Row, error) {
+	if len(data) > MaxCSVSize {
+		return nil, ErrCSVTooLarge
+	}
+
+	reader := newCSVReader(data)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyCSV
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	index, err := fieldIndex(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		if blank(record) {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRow(line, record, index))
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyCSV
+	}
+	return rows, nil
+}
+
+// newCSVReader reads records of any length, skipping a byte order mark, which spreadsheets
+// like to write
+func newCSVReader(data string) *csv.Reader {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix([]byte(data), []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// fieldIndex resolves the mapping to column positions. Headers are matched ignoring case
+// and surrounding space.
+func fieldIndex(header []string, mapping []ColumnMapping) (map[Field]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, ok := positions[key]; !ok {
+			positions[key] = i
+		}
+	}
+
+	index := make(map[Field]int, len(mapping))
+	headers := make(map[string]bool, len(mapping))
+	for _, m := range mapping {
+		key := strings.ToLower(strings.TrimSpace(m.Header))
+		pos, ok := positions[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownHeader, m.Header)
+		}
+		if _, ok := index[m.Field]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateField, m.Field)
+		}
+		if headers[key] {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateHeader, m.Header)
+		}
+		index[m.Field] = pos
+		headers[key] = true
+	}
+	if _, ok := index[FieldTitle]; !ok {
+		return nil, ErrTitleNotMapped
+	}
+	return index, nil
+}
+
+func parseRow(line int, record []string, index map[Field]int) *Row {
+	value := func(field Field) string {
+		pos, ok := index[field]
+		if !ok || pos >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[pos])
+	}
+
+	row := &Row{
+		Line:          line,
+		Title:         value(FieldTitle),
+		Description:   value(FieldDescription),
+		AssigneeEmail: value(FieldAssigneeEmail),
+	}
+	if row.Title == "" {
+		row.fail(FieldTitle, CodeRequired, "")
+	}
+
+	if tags := value(FieldTags); tags != "" {
+		row.setTags(strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || r == ';' }))
+	}
+
+	if points := value(FieldStoryPoints); points != "" {
+		n, err := strconv.Atoi(points)
+		if err != nil || n < 0 {
+			row.fail(FieldStoryPoints, CodeInvalidNumber, points)
+		} else {
+			row.StoryPoints = &n
+		}
+	}
+
+	if due := value(FieldDueDate); due != "" {
+		row.setDueDate(due, dueDateLayouts)
+	}
+	return row
+}
+
+// setTags sets the row's tags to the named ones, once each ignoring case
+func (r *Row) setTags(names []string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if utf8.RuneCountInString(name) > maxTagNameLength {
+			r.fail(FieldTags, CodeTagTooLong, name)
+			continue
+		}
+		r.Tags = append(r.Tags, name)
+	}
+}
+
+// setDueDate parses the due date with the first of the layouts that fits
+func (r *Row) setDueDate(value string, layouts []string) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			r.DueDate = &t
+			return
+		}
+	}
+	r.fail(FieldDueDate, CodeInvalidDate, value)
+}
+
+func blank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
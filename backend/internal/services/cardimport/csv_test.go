@@ -0,0 +1,81 @@
+package cardimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMapping = []ColumnMapping{
+	{Header: "Summary", Field: FieldTitle},
+	{Header: "Details", Field: FieldDescription},
+	{Header: "Owner", Field: FieldAssigneeEmail},
+	{Header: "Labels", Field: FieldTags},
+	{Header: "Points", Field: FieldStoryPoints},
+	{Header: "Due", Field: FieldDueDate},
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rows, err := ParseCSV("\ufeffsummary,Details,Owner,Labels,Points,Due,Ignored\n"+
+			"Fix login,\"Users can't, log in\",ana@example.com,\"Bug; backend, bug\",3,2026-04-01,x\n"+
+			",,,,,,\n"+
+			"Write docs,,,,,2026-04-02T09:00:00Z\n", testMapping)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "Fix login", rows[0].Title)
+		assert.Equal(t, "Users can't, log in", rows[0].Description)
+		assert.Equal(t, "ana@example.com", rows[0].AssigneeEmail)
+		assert.Equal(t, []string{"Bug", "backend"}, rows[0].Tags)
+		assert.Equal(t, 3, *rows[0].StoryPoints)
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), *rows[0].DueDate)
+		assert.Empty(t, rows[0].Errors)
+
+		assert.Equal(t, 4, rows[1].Line)
+		assert.Nil(t, rows[1].StoryPoints)
+		assert.Equal(t, time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC), *rows[1].DueDate)
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		rows, err := ParseCSV("Summary,Points,Due\n,-1,tomorrow\n", []ColumnMapping{
+			{Header: "Summary", Field: FieldTitle},
+			{Header: "Points", Field: FieldStoryPoints},
+			{Header: "Due", Field: FieldDueDate},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, []RowError{
+			{Field: FieldTitle, Code: CodeRequired},
+			{Field: FieldStoryPoints, Code: CodeInvalidNumber, Value: "-1"},
+			{Field: FieldDueDate, Code: CodeInvalidDate, Value: "tomorrow"},
+		}, rows[0].Errors)
+	})
+
+	tests := []struct {
+		name    string
+		csv     string
+		mapping []ColumnMapping
+		err     error
+	}{
+		{"empty", "", testMapping[:1], ErrEmptyCSV},
+		{"header only", "Summary\n", testMapping[:1], ErrEmptyCSV},
+		{"title not mapped", "Summary,Details\na,b\n", testMapping[1:2], ErrTitleNotMapped},
+		{"unknown header", "Summary\na\n", testMapping[:2], ErrUnknownHeader},
+		{"field mapped twice", "Summary,Details\na,b\n", []ColumnMapping{{"Summary", FieldTitle}, {"Details", FieldTitle}}, ErrDuplicateField},
+		{"header mapped twice", "Summary\na\n", []ColumnMapping{{"Summary", FieldTitle}, {"summary", FieldDescription}}, ErrDuplicateHeader},
+		{"bad quoting", "Summary\n\"a\n", testMapping[:1], ErrInvalidCSV},
+		{"too many rows", "Summary\n" + strings.Repeat("card\n", MaxRows+1), testMapping[:1], ErrTooManyRows},
+		{"too large", strings.Repeat("x", MaxCSVSize+1), testMapping[:1], ErrCSVTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCSV(tt.csv, tt.mapping)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
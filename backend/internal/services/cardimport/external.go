@@ -0,0 +1,276 @@
+package cardimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// MaxExportSize is the largest Jira or Trello export accepted, in bytes. Trello exports
+// hold the board's whole history, so they run larger than the cards alone.
+const MaxExportSize = 10 << 20
+
+var (
+	ErrInvalidFormat  = errors.New("unknown import format")
+	ErrExportTooLarge = fmt.Errorf("the export is larger than %d bytes", MaxExportSize)
+)
+
+// Format is the kind of export ImportExternal reads
+type Format string
+
+const (
+	FormatJiraCSV    Format = "jira_csv"
+	FormatTrelloJSON Format = "trello_json"
+)
+
+// source is the user match source the format's users belong to
+func (f Format) source() string {
+	if f == FormatTrelloJSON {
+		return "trello"
+	}
+	return "jira"
+}
+
+// ExternalInput is a Jira or Trello export to import into a project
+type ExternalInput struct {
+	Format  Format
+	Payload string
+	// BoardID is the board the cards go on; nil picks the project's default board
+	BoardID *uuid.UUID
+	// StatusMapping sends the cards with a status, matched ignoring case, to a column. Other
+	// statuses go to the column of the same name, or else to the default column.
+	StatusMapping map[string]uuid.UUID
+	// DryRun only validates the cards
+	DryRun    bool
+	CreatedBy *uuid.UUID
+}
+
+// StatusResult is where the cards with a Jira status or in a Trello list go
+type StatusResult struct {
+	Status   string
+	ColumnID uuid.UUID
+	// Matched is false when no column matched the status, so its cards go to the default
+	// column
+	Matched bool
+	Cards   int
+}
+
+// AssigneeResult is a source user the cards are assigned to and the user they are matched to
+type AssigneeResult struct {
+	Match *user_match.UserMatch
+	// UserID is who the user's cards are assigned to: the matched user, when they can view
+	// the project. Nil leaves the cards unassigned.
+	UserID *uuid.UUID
+	Cards  int
+}
+
+func (s *service) ImportExternal(ctx context.Context, projectID uuid.UUID, input ExternalInput) (*Result, error) {
+	ctx, span := s.startServiceSpan(ctx, "ImportExternal")
+	span.SetAttributes(
+		attribute.String("project.id", projectID.String()),
+		attribute.String("import.format", string(input.Format)),
+		attribute.Bool("import.dry_run", input.DryRun),
+	)
+	defer span.End()
+
+	var parse func(string) ([]*Row, error)
+	switch input.Format {
+	case FormatJiraCSV:
+		parse = ParseJiraCSV
+	case FormatTrelloJSON:
+		parse = ParseTrelloJSON
+	default:
+		return nil, ErrInvalidFormat
+	}
+
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	b, err := s.importBoard(ctx, projectID, input.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.columnRepo.GetByBoardID(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	columnID, err := defaultColumn(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := parse(input.Payload)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("import.rows", len(rows)))
+
+	result := &Result{BoardID: b.ID, ColumnID: columnID, Rows: rows}
+	result.Statuses, err = mapStatuses(rows, columns, input.StatusMapping, columnID)
+	if err != nil {
+		return nil, err
+	}
+	result.Assignees, err = s.matchAssignees(ctx, p, input.Format.source(), rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.run(ctx, b, result, input.DryRun, input.CreatedBy); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importBoard returns the board after checking it is in the project, or else the project's
+// default board
+func (s *service) importBoard(ctx context.Context, projectID uuid.UUID, boardID *uuid.UUID) (*board.Board, error) {
+	var b *board.Board
+	var err error
+	if boardID == nil {
+		b, err = s.boardRepo.GetDefaultByProjectID(ctx, projectID)
+	} else {
+		b, err = s.boardRepo.GetByID(ctx, *boardID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	if b.ProjectID != projectID {
+		return nil, ErrBoardNotInProject
+	}
+	return b, nil
+}
+
+// mapStatuses sets the column of each row from its status and returns the statuses in the
+// order they first appear
+func mapStatuses(rows []*Row, columns []*board_column.BoardColumn, mapping map[string]uuid.UUID, fallback uuid.UUID) ([]StatusResult, error) {
+	onBoard := make(map[uuid.UUID]bool, len(columns))
+	byName := make(map[string]uuid.UUID, len(columns))
+	for _, col := range columns {
+		onBoard[col.ID] = true
+		name := strings.ToLower(strings.TrimSpace(col.Name))
+		if _, ok := byName[name]; !ok {
+			byName[name] = col.ID
+		}
+	}
+	mapped := make(map[string]uuid.UUID, len(mapping))
+	for status, columnID := range mapping {
+		if !onBoard[columnID] {
+			return nil, ErrColumnNotInBoard
+		}
+		mapped[strings.ToLower(strings.TrimSpace(status))] = columnID
+	}
+
+	var statuses []StatusResult
+	index := make(map[string]int)
+	for _, row := range rows {
+		key := strings.ToLower(strings.TrimSpace(row.Status))
+		i, ok := index[key]
+		if !ok {
+			status := StatusResult{Status: row.Status, ColumnID: fallback}
+			if columnID, ok := mapped[key]; ok {
+				status.ColumnID, status.Matched = columnID, true
+			} else if columnID, ok := byName[key]; ok && key != "" {
+				status.ColumnID, status.Matched = columnID, true
+			}
+			i = len(statuses)
+			index[key] = i
+			statuses = append(statuses, status)
+		}
+		statuses[i].Cards++
+		row.ColumnID = statuses[i].ColumnID
+	}
+	return statuses, nil
+}
+
+// matchAssignees matches the rows' source users to members of the project's organization
+// and assigns the rows to those matched for certain who can view the project
+func (s *service) matchAssignees(ctx context.Context, p *project.Project, source string, rows []*Row) ([]AssigneeResult, error) {
+	var users []usermatch.ExternalUser
+	index := make(map[string]int)
+	for _, row := range rows {
+		if row.Assignee == nil {
+			continue
+		}
+		if _, ok := index[row.Assignee.ExternalID]; !ok {
+			index[row.Assignee.ExternalID] = len(users)
+			users = append(users, *row.Assignee)
+		}
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	matches, err := s.userMatchSvc.MatchUsers(ctx, p.OrganizationID, source, users)
+	if err != nil {
+		return nil, err
+	}
+	assignees := make([]AssigneeResult, len(matches))
+	for i, m := range matches {
+		assignees[i].Match = m
+		if m.Status != user_match.StatusMatched || m.UserID == nil {
+			continue
+		}
+		canView, err := s.rbacSvc.HasProjectPermission(ctx, *m.UserID, p.ID, "project:view")
+		if err != nil {
+			return nil, err
+		}
+		if canView {
+			assignees[i].UserID = m.UserID
+		}
+	}
+	for _, row := range rows {
+		if row.Assignee == nil {
+			continue
+		}
+		i := index[row.Assignee.ExternalID]
+		assignees[i].Cards++
+		row.AssigneeID = assignees[i].UserID
+	}
+	return assignees, nil
+}
+
+var paragraphBreak = regexp.MustCompile(`\n[ \t]*\n`)
+
+// textToHTML turns the plain text descriptions of exports into paragraphs, keeping their
+// line breaks
+func textToHTML(text string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return ""
+	}
+	var sb strings.Builder
+	for _, paragraph := range paragraphBreak.Split(text, -1) {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		sb.WriteString("<p>")
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				sb.WriteString("<br>")
+			}
+			sb.WriteString(html.EscapeString(line))
+		}
+		sb.WriteString("</p>")
+	}
+	return sb.String()
+}
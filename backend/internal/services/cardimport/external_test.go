@@ -0,0 +1,248 @@
+package cardimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	cardServiceMocks "github.com/thatcatdev/kaimu/backend/internal/services/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	userMatchMocks "github.com/thatcatdev/kaimu/backend/internal/services/usermatch/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportExternal(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	proj := &project.Project{ID: uuid.New(), OrganizationID: orgID}
+	b := &board.Board{ID: uuid.New(), ProjectID: proj.ID}
+	backlog := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Backlog", IsBacklog: true}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "to do", Position: 1}
+	inProgress := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "In Progress", Position: 2}
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: proj.ID, Name: "bug"}
+	anaID := uuid.New()
+	ana := usermatch.ExternalUser{ExternalID: "m1", DisplayName: "Ana Lima"}
+
+	expectBoard := func(mockProjectRepo *projectMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, mockColumnRepo *columnMocks.MockRepository, mockTagRepo *tagMocks.MockRepository) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), proj.ID).Return(proj, nil)
+		mockBoardRepo.EXPECT().GetDefaultByProjectID(gomock.Any(), proj.ID).Return(b, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{backlog, todo, inProgress}, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), proj.ID).Return([]*tag.Tag{bug}, nil)
+	}
+
+	t.Run("maps lists, labels and members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo)
+		mockUserMatchSvc.EXPECT().MatchUsers(gomock.Any(), orgID, "trello", []usermatch.ExternalUser{ana}).
+			Return([]*user_match.UserMatch{{Status: user_match.StatusMatched, UserID: &anaID}}, nil)
+		mockRbacSvc.EXPECT().HasProjectPermission(gomock.Any(), anaID, proj.ID, "project:view").Return(true, nil)
+		mockTagRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, t *tag.Tag) error {
+			t.ID = uuid.New()
+			return nil
+		})
+		var inputs []cardService.CreateCardInput
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(_ context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			inputs = append(inputs, input)
+			return &card.Card{ID: uuid.New(), Title: input.Title}, nil
+		})
+
+		result, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{
+			Format:        FormatTrelloJSON,
+			Payload:       testTrelloBoard,
+			StatusMapping: map[string]uuid.UUID{"doing": inProgress.ID},
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, b.ID, result.BoardID)
+		assert.Equal(t, backlog.ID, result.ColumnID)
+		assert.Equal(t, []StatusResult{
+			{Status: "To Do", ColumnID: todo.ID, Matched: true, Cards: 2},
+			{Status: "Doing", ColumnID: inProgress.ID, Matched: true, Cards: 1},
+		}, result.Statuses)
+		require.Len(t, result.Assignees, 1)
+		assert.Equal(t, &anaID, result.Assignees[0].UserID)
+		assert.Equal(t, 1, result.Assignees[0].Cards)
+		assert.Equal(t, []string{"green"}, result.NewTags)
+
+		require.Len(t, inputs, 3)
+		assert.Equal(t, todo.ID, inputs[0].ColumnID)
+		assert.Nil(t, inputs[0].AssigneeID)
+		assert.Equal(t, inProgress.ID, inputs[2].ColumnID)
+		assert.Equal(t, &anaID, inputs[2].AssigneeID)
+		assert.Len(t, inputs[2].TagIDs, 2)
+		assert.Equal(t, bug.ID, inputs[2].TagIDs[0])
+	})
+
+	t.Run("dry run leaves uncertain matches unassigned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo)
+		mockUserMatchSvc.EXPECT().MatchUsers(gomock.Any(), orgID, "jira", gomock.Any()).
+			Return([]*user_match.UserMatch{{Status: user_match.StatusPending}}, nil)
+
+		result, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{
+			Format:  FormatJiraCSV,
+			Payload: "Summary,Status,Assignee\nFix login,Review,Ana Lima\n",
+			DryRun:  true,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Equal(t, []StatusResult{{Status: "Review", ColumnID: backlog.ID, Cards: 1}}, result.Statuses)
+		assert.Nil(t, result.Assignees[0].UserID)
+		assert.Nil(t, result.Rows[0].AssigneeID)
+		assert.Empty(t, result.Cards)
+	})
+
+	t.Run("keeps Jira priorities", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		expectBoard(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo)
+		var inputs []cardService.CreateCardInput
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			inputs = append(inputs, input)
+			return &card.Card{ID: uuid.New(), Title: input.Title}, nil
+		})
+
+		result, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{
+			Format:  FormatJiraCSV,
+			Payload: "Summary,Status,Priority\nFix login,To Do,Blocker\nFix login,To Do,Minor\n",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Valid())
+
+		require.Len(t, inputs, 2)
+		assert.Equal(t, card.PriorityUrgent, inputs[0].Priority)
+		assert.Equal(t, card.PriorityLow, inputs[1].Priority)
+		// One import may repeat a title without tripping flood control
+		assert.True(t, inputs[1].SkipFloodCheck)
+	})
+
+	t.Run("board of another project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		other := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), proj.ID).Return(proj, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), other.ID).Return(other, nil)
+
+		_, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{Format: FormatJiraCSV, BoardID: &other.ID})
+		assert.ErrorIs(t, err, ErrBoardNotInProject)
+	})
+
+	t.Run("status mapped to a column of another board", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), proj.ID).Return(proj, nil)
+		mockBoardRepo.EXPECT().GetDefaultByProjectID(gomock.Any(), proj.ID).Return(b, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{backlog}, nil)
+
+		_, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{
+			Format:        FormatJiraCSV,
+			Payload:       "Summary,Status\nFix login,Done\n",
+			StatusMapping: map[string]uuid.UUID{"Done": uuid.New()},
+		})
+		assert.ErrorIs(t, err, ErrColumnNotInBoard)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockUserMatchSvc := userMatchMocks.NewMockService(ctrl)
+
+		svc := NewService(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, mockUserRepo, mockCardSvc,
+			content.NewService(nil, nil, nil, content.Limits{Title: 20}), mockRbacSvc, mockUserMatchSvc, transaction.NewNoopManager())
+
+		_, err := svc.ImportExternal(ctx, proj.ID, ExternalInput{Format: "asana_csv"})
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
@@ -0,0 +1,146 @@
+package cardimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/services/jira"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+var ErrNotJiraCSV = errors.New("the CSV has no Summary column, so it is not a Jira export")
+
+// jiraStoryPointHeaders are the columns Jira exports story points in, which depend on the
+// kind of Jira project
+var jiraStoryPointHeaders = []string{
+	"custom field (story points)",
+	"custom field (story point estimate)",
+	"story points",
+	"story point estimate",
+}
+
+// jiraDueDateLayouts are the formats Jira exports dates in, which depend on the site's
+// settings, tried in order
+var jiraDueDateLayouts = []string{
+	"2/Jan/06 3:04 PM",
+	"2/Jan/06",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// ParseJiraCSV reads the issues of a Jira CSV export, as written by "Export Excel CSV (all
+// fields)". Columns are found by their Jira names, ignoring case: Summary, Issue key,
+// Status, Description, Assignee and Assignee Id, Labels, which Jira repeats for each label,
+// Due date and story points. Other columns are ignored. Descriptions are kept as plain text.
+func ParseJiraCSV(data string) ([]*Row, error) {
+	if len(data) > MaxExportSize {
+		return nil, ErrExportTooLarge
+	}
+
+	reader := newCSVReader(data)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyCSV
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	columns := make(map[string][]int)
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		columns[key] = append(columns[key], i)
+	}
+	if len(columns["summary"]) == 0 {
+		return nil, ErrNotJiraCSV
+	}
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		if blank(record) {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseJiraRow(line, record, columns))
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyCSV
+	}
+	return rows, nil
+}
+
+func parseJiraRow(line int, record []string, columns map[string][]int) *Row {
+	// values returns the column's values, of which Jira writes several for lists
+	values := func(header string) []string {
+		var out []string
+		for _, pos := range columns[header] {
+			if pos < len(record) {
+				if v := strings.TrimSpace(record[pos]); v != "" {
+					out = append(out, v)
+				}
+			}
+		}
+		return out
+	}
+	value := func(headers ...string) string {
+		for _, h := range headers {
+			if vs := values(h); len(vs) > 0 {
+				return vs[0]
+			}
+		}
+		return ""
+	}
+
+	row := &Row{
+		Line:        line,
+		Title:       value("summary"),
+		Description: textToHTML(value("description")),
+		SourceKey:   value("issue key"),
+		Status:      value("status"),
+		Priority:    jira.CardPriority(value("priority")),
+	}
+	if row.Title == "" {
+		row.fail(FieldTitle, CodeRequired, "")
+	}
+	row.setTags(values("labels"))
+
+	name, id := value("assignee"), value("assignee id")
+	if name != "" || id != "" {
+		if id == "" {
+			id = name
+		}
+		row.Assignee = &usermatch.ExternalUser{ExternalID: id, DisplayName: name}
+	}
+
+	// Jira estimates are decimals, such as 3.0; half points round up
+	if points := value(jiraStoryPointHeaders...); points != "" {
+		f, err := strconv.ParseFloat(points, 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			row.fail(FieldStoryPoints, CodeInvalidNumber, points)
+		} else {
+			n := int(math.Round(f))
+			row.StoryPoints = &n
+		}
+	}
+
+	if due := value("due date"); due != "" {
+		row.setDueDate(due, jiraDueDateLayouts)
+	}
+	return row
+}
@@ -0,0 +1,66 @@
+package cardimport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+func TestParseJiraCSV(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rows, err := ParseJiraCSV("Summary,Issue key,Issue Type,Status,Priority,Assignee,Assignee Id,Labels,Labels,Description,Due date,Custom field (Story Points)\n" +
+			"Fix login,API-1,Bug,In Progress,Highest,Ana Lima,5b10a2844c20165700ede21g,backend,Backend,\"Users can't log in.\n\nSee <logs>\",31/May/24 5:00 PM,2.5\n" +
+			"Write docs,API-2,Task,To Do,,,,,,,,\n")
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "Fix login", rows[0].Title)
+		assert.Equal(t, "API-1", rows[0].SourceKey)
+		assert.Equal(t, "In Progress", rows[0].Status)
+		assert.Equal(t, card.PriorityUrgent, rows[0].Priority)
+		assert.Equal(t, &usermatch.ExternalUser{ExternalID: "5b10a2844c20165700ede21g", DisplayName: "Ana Lima"}, rows[0].Assignee)
+		assert.Equal(t, []string{"backend"}, rows[0].Tags)
+		assert.Equal(t, "<p>Users can&#39;t log in.</p><p>See &lt;logs&gt;</p>", rows[0].Description)
+		assert.Equal(t, time.Date(2024, 5, 31, 17, 0, 0, 0, time.UTC), *rows[0].DueDate)
+		assert.Equal(t, 3, *rows[0].StoryPoints)
+		assert.Empty(t, rows[0].Errors)
+
+		// The description's line breaks count
+		assert.Equal(t, 5, rows[1].Line)
+		assert.Nil(t, rows[1].Assignee)
+		assert.Empty(t, rows[1].Tags)
+		assert.Nil(t, rows[1].StoryPoints)
+		assert.Equal(t, card.PriorityNone, rows[1].Priority)
+	})
+
+	t.Run("assignee without an ID", func(t *testing.T) {
+		rows, err := ParseJiraCSV("Summary,Assignee\nFix login,ana\n")
+		require.NoError(t, err)
+		assert.Equal(t, &usermatch.ExternalUser{ExternalID: "ana", DisplayName: "ana"}, rows[0].Assignee)
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		rows, err := ParseJiraCSV("Summary,Story point estimate,Due Date\n,-1,someday\n")
+		require.NoError(t, err)
+		assert.Equal(t, []RowError{
+			{Field: FieldTitle, Code: CodeRequired},
+			{Field: FieldStoryPoints, Code: CodeInvalidNumber, Value: "-1"},
+			{Field: FieldDueDate, Code: CodeInvalidDate, Value: "someday"},
+		}, rows[0].Errors)
+	})
+
+	t.Run("not a Jira export", func(t *testing.T) {
+		_, err := ParseJiraCSV("Title,Owner\nFix login,ana\n")
+		assert.ErrorIs(t, err, ErrNotJiraCSV)
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		_, err := ParseJiraCSV("Summary,Status\n")
+		assert.ErrorIs(t, err, ErrEmptyCSV)
+	})
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cardimport_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cardimport_service.go -destination=mocks/cardimport_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	cardimport "github.com/thatcatdev/kaimu/backend/internal/services/cardimport"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockService) Import(ctx context.Context, boardID uuid.UUID, input cardimport.ImportInput) (*cardimport.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, boardID, input)
+	ret0, _ := ret[0].(*cardimport.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockServiceMockRecorder) Import(ctx, boardID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockService)(nil).Import), ctx, boardID, input)
+}
+
+// ImportExternal mocks base method.
+func (m *MockService) ImportExternal(ctx context.Context, projectID uuid.UUID, input cardimport.ExternalInput) (*cardimport.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportExternal", ctx, projectID, input)
+	ret0, _ := ret[0].(*cardimport.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportExternal indicates an expected call of ImportExternal.
+func (mr *MockServiceMockRecorder) ImportExternal(ctx, projectID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportExternal", reflect.TypeOf((*MockService)(nil).ImportExternal), ctx, projectID, input)
+}
@@ -0,0 +1,152 @@
+package cardimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+var (
+	ErrNotTrelloJSON = errors.New("the document is not a Trello board export")
+	ErrNoCards       = errors.New("the export has no open cards")
+	ErrTooManyCards  = fmt.Errorf("the export has more than %d open cards", MaxRows)
+)
+
+// trelloBoard is the part of a Trello board's JSON export an import reads. Exports also
+// hold the board's actions, checklists and settings, which are ignored.
+type trelloBoard struct {
+	Lists   []trelloList   `json:"lists"`
+	Cards   []trelloCard   `json:"cards"`
+	Labels  []trelloLabel  `json:"labels"`
+	Members []trelloMember `json:"members"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID        string     `json:"id"`
+	ShortLink string     `json:"shortLink"`
+	Name      string     `json:"name"`
+	Desc      string     `json:"desc"`
+	IDList    string     `json:"idList"`
+	IDLabels  []string   `json:"idLabels"`
+	IDMembers []string   `json:"idMembers"`
+	Due       *time.Time `json:"due"`
+	Closed    bool       `json:"closed"`
+	Pos       float64    `json:"pos"`
+}
+
+type trelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloMember struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+	Username string `json:"username"`
+}
+
+// ParseTrelloJSON reads the open cards of a Trello board's JSON export, list by list in the
+// order the board shows them. Archived cards and the cards of archived lists are left out.
+// Labels without a name are named after their color, and a card with several members is
+// assigned to the first. Descriptions are kept as plain text.
+func ParseTrelloJSON(data string) ([]*Row, error) {
+	if len(data) > MaxExportSize {
+		return nil, ErrExportTooLarge
+	}
+
+	var export trelloBoard
+	if err := json.Unmarshal([]byte(data), &export); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotTrelloJSON, err)
+	}
+	if export.Lists == nil || export.Cards == nil {
+		return nil, ErrNotTrelloJSON
+	}
+
+	lists := make(map[string]trelloList, len(export.Lists))
+	for _, l := range export.Lists {
+		lists[l.ID] = l
+	}
+	labels := make(map[string]string, len(export.Labels))
+	for _, l := range export.Labels {
+		name := strings.TrimSpace(l.Name)
+		if name == "" {
+			name = l.Color
+		}
+		labels[l.ID] = name
+	}
+	members := make(map[string]trelloMember, len(export.Members))
+	for _, m := range export.Members {
+		members[m.ID] = m
+	}
+
+	var cards []trelloCard
+	for _, c := range export.Cards {
+		if l, ok := lists[c.IDList]; c.Closed || !ok || l.Closed {
+			continue
+		}
+		cards = append(cards, c)
+	}
+	if len(cards) == 0 {
+		return nil, ErrNoCards
+	}
+	if len(cards) > MaxRows {
+		return nil, ErrTooManyCards
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := lists[cards[i].IDList], lists[cards[j].IDList]
+		if a.Pos != b.Pos {
+			return a.Pos < b.Pos
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+
+	rows := make([]*Row, len(cards))
+	for i, c := range cards {
+		row := &Row{
+			Line:        i + 1,
+			Title:       strings.TrimSpace(c.Name),
+			Description: textToHTML(c.Desc),
+			DueDate:     c.Due,
+			SourceKey:   c.ShortLink,
+			Status:      strings.TrimSpace(lists[c.IDList].Name),
+		}
+		if row.SourceKey == "" {
+			row.SourceKey = c.ID
+		}
+		if row.Title == "" {
+			row.fail(FieldTitle, CodeRequired, "")
+		}
+
+		var names []string
+		for _, id := range c.IDLabels {
+			names = append(names, labels[id])
+		}
+		row.setTags(names)
+
+		for _, id := range c.IDMembers {
+			if m, ok := members[id]; ok {
+				name := m.FullName
+				if name == "" {
+					name = m.Username
+				}
+				row.Assignee = &usermatch.ExternalUser{ExternalID: m.ID, DisplayName: name}
+				break
+			}
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
@@ -0,0 +1,67 @@
+package cardimport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+)
+
+const testTrelloBoard = `{
+	"name": "Launch",
+	"lists": [
+		{"id": "l2", "name": "Doing", "pos": 2},
+		{"id": "l1", "name": "To Do", "pos": 1},
+		{"id": "l3", "name": "Old", "pos": 3, "closed": true}
+	],
+	"labels": [
+		{"id": "b1", "name": "Bug", "color": "red"},
+		{"id": "b2", "name": "", "color": "green"}
+	],
+	"members": [{"id": "m1", "fullName": "Ana Lima", "username": "ana"}],
+	"actions": [{"id": "a1", "type": "createCard"}],
+	"cards": [
+		{"id": "c1", "shortLink": "AbC1", "name": "Fix login", "desc": "Line one\nLine two", "idList": "l2", "idLabels": ["b1", "b2"], "idMembers": ["m1", "m2"], "due": "2024-05-31T12:00:00.000Z", "pos": 1},
+		{"id": "c2", "shortLink": "AbC2", "name": "Write docs", "idList": "l1", "pos": 2},
+		{"id": "c3", "shortLink": "AbC3", "name": "Plan", "idList": "l1", "pos": 1},
+		{"id": "c4", "name": "Archived", "idList": "l1", "closed": true},
+		{"id": "c5", "name": "In an archived list", "idList": "l3"}
+	]
+}`
+
+func TestParseTrelloJSON(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		rows, err := ParseTrelloJSON(testTrelloBoard)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Equal(t, "Plan", rows[0].Title)
+		assert.Equal(t, "To Do", rows[0].Status)
+		assert.Equal(t, "Write docs", rows[1].Title)
+		assert.Nil(t, rows[1].Assignee)
+
+		assert.Equal(t, 3, rows[2].Line)
+		assert.Equal(t, "Fix login", rows[2].Title)
+		assert.Equal(t, "AbC1", rows[2].SourceKey)
+		assert.Equal(t, "Doing", rows[2].Status)
+		assert.Equal(t, "<p>Line one<br>Line two</p>", rows[2].Description)
+		assert.Equal(t, []string{"Bug", "green"}, rows[2].Tags)
+		assert.Equal(t, &usermatch.ExternalUser{ExternalID: "m1", DisplayName: "Ana Lima"}, rows[2].Assignee)
+		assert.Equal(t, time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC), *rows[2].DueDate)
+	})
+
+	t.Run("not a Trello export", func(t *testing.T) {
+		_, err := ParseTrelloJSON(`{"issues": []}`)
+		assert.ErrorIs(t, err, ErrNotTrelloJSON)
+
+		_, err = ParseTrelloJSON(`Summary,Status`)
+		assert.ErrorIs(t, err, ErrNotTrelloJSON)
+	})
+
+	t.Run("no open cards", func(t *testing.T) {
+		_, err := ParseTrelloJSON(`{"lists": [{"id": "l1"}], "cards": [{"id": "c1", "idList": "l1", "closed": true}]}`)
+		assert.ErrorIs(t, err, ErrNoCards)
+	})
+}
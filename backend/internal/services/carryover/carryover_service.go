@@ -0,0 +1,381 @@
+package carryover
+
+//go:generate mockgen -source=carryover_service.go -destination=mocks/carryover_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint_membership"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultSprintCount is how many closed sprints a report covers when none is given
+	DefaultSprintCount = 5
+	// MaxSprintCount caps how many closed sprints one report covers
+	MaxSprintCount = 20
+)
+
+var ErrInvalidSprintCount = fmt.Errorf("the sprint count must be between 1 and %d", MaxSprintCount)
+
+// CarriedCard is a card that was carried out of at least one of the report's sprints
+type CarriedCard struct {
+	CardID      uuid.UUID
+	Title       string
+	StoryPoints *int
+	// Done is set when the card has since been delivered
+	Done bool
+	// SprintIDs are the report's sprints the card was in, oldest first
+	SprintIDs []uuid.UUID
+	// TimesCarried is how many of the report's sprints the card was in without being
+	// delivered, shown by it being in a later sprint too
+	TimesCarried int
+	// UndeliveredPoints is the card's story points for each time it was carried
+	UndeliveredPoints int
+}
+
+// SprintCarryover is the carryover in and out of one closed sprint
+type SprintCarryover struct {
+	Sprint          *sprint.Sprint
+	CommittedCards  int
+	CommittedPoints int
+	// CarriedInCards were in an earlier sprint too
+	CarriedInCards int
+	// CarriedOutCards were in a later sprint too, so were not delivered in this one
+	CarriedOutCards  int
+	CarriedOutPoints int
+}
+
+// Report is the carryover across a board's last closed sprints
+type Report struct {
+	BoardID uuid.UUID
+	// Sprints are oldest first
+	Sprints []SprintCarryover
+	// Cards are the most carried first
+	Cards             []CarriedCard
+	TotalCarryovers   int
+	UndeliveredPoints int
+}
+
+// SprintStay is one stretch of a card being in a sprint
+type SprintStay struct {
+	Sprint *sprint.Sprint
+	// AddedAt is nil when the card joined the sprint before its history was recorded
+	AddedAt *time.Time
+	// RemovedAt is nil while the card is still in the sprint, or when it left without an
+	// audit event
+	RemovedAt *time.Time
+	// InSprint is set when the card is in the sprint now. Cards stay in the closed sprints
+	// they were carried out of
+	InSprint bool
+}
+
+type Service interface {
+	// GetReport reports on the carryover across the board's last lastN closed sprints,
+	// DefaultSprintCount when lastN is nil
+	GetReport(ctx context.Context, boardID uuid.UUID, lastN *int) (*Report, error)
+	// GetCardSprintHistory returns every stay of the card in a sprint, oldest first, from
+	// its current sprints and the audit events that changed them. Deleted sprints are left
+	// out
+	GetCardSprintHistory(ctx context.Context, cardID uuid.UUID) ([]*SprintStay, error)
+}
+
+type service struct {
+	sprintRepo     sprint.Repository
+	membershipRepo sprint_membership.Repository
+}
+
+func NewService(sprintRepo sprint.Repository, membershipRepo sprint_membership.Repository) Service {
+	return &service{
+		sprintRepo:     sprintRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "carryover.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "carryover"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetReport(ctx context.Context, boardID uuid.UUID, lastN *int) (*Report, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetReport")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	count := DefaultSprintCount
+	if lastN != nil {
+		count = *lastN
+	}
+	if count < 1 || count > MaxSprintCount {
+		return nil, ErrInvalidSprintCount
+	}
+
+	sprints, err := s.sprintRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	sortSprints(sprints)
+
+	// The report covers the last closed sprints; later sprints only tell whether a card
+	// was carried out of them
+	var closed []int
+	for i, sp := range sprints {
+		if sp.Status == sprint.SprintStatusClosed {
+			closed = append(closed, i)
+		}
+	}
+	if len(closed) > count {
+		closed = closed[len(closed)-count:]
+	}
+	report := &Report{BoardID: boardID, Sprints: make([]SprintCarryover, len(closed))}
+	if len(closed) == 0 {
+		return report, nil
+	}
+
+	memberships, err := s.membershipRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make(map[uuid.UUID]int, len(sprints))
+	for i, sp := range sprints {
+		order[sp.ID] = i
+	}
+	type history struct {
+		card *sprint_membership.Membership
+		// in holds the order of every sprint the card was in
+		in map[int]bool
+	}
+	histories := map[uuid.UUID]*history{}
+	var cardIDs []uuid.UUID
+	for _, m := range memberships {
+		i, ok := order[m.SprintID]
+		if !ok {
+			continue
+		}
+		h := histories[m.CardID]
+		if h == nil {
+			h = &history{card: m, in: map[int]bool{}}
+			histories[m.CardID] = h
+			cardIDs = append(cardIDs, m.CardID)
+		}
+		h.in[i] = true
+	}
+
+	for _, cardID := range cardIDs {
+		h := histories[cardID]
+		first, last := len(sprints), -1
+		for i := range h.in {
+			first = min(first, i)
+			last = max(last, i)
+		}
+		points := 0
+		if h.card.StoryPoints != nil {
+			points = *h.card.StoryPoints
+		}
+
+		carried := CarriedCard{
+			CardID:      h.card.CardID,
+			Title:       h.card.Title,
+			StoryPoints: h.card.StoryPoints,
+			Done:        h.card.Done,
+		}
+		for j, i := range closed {
+			if !h.in[i] {
+				continue
+			}
+			stat := &report.Sprints[j]
+			stat.CommittedCards++
+			stat.CommittedPoints += points
+			if i > first {
+				stat.CarriedInCards++
+			}
+			carried.SprintIDs = append(carried.SprintIDs, sprints[i].ID)
+			if i < last {
+				stat.CarriedOutCards++
+				stat.CarriedOutPoints += points
+				carried.TimesCarried++
+			}
+		}
+		if carried.TimesCarried == 0 {
+			continue
+		}
+		carried.UndeliveredPoints = points * carried.TimesCarried
+		report.Cards = append(report.Cards, carried)
+		report.TotalCarryovers += carried.TimesCarried
+		report.UndeliveredPoints += carried.UndeliveredPoints
+	}
+
+	for j, i := range closed {
+		report.Sprints[j].Sprint = sprints[i]
+	}
+	sort.SliceStable(report.Cards, func(i, j int) bool {
+		a, b := report.Cards[i], report.Cards[j]
+		if a.TimesCarried != b.TimesCarried {
+			return a.TimesCarried > b.TimesCarried
+		}
+		return a.UndeliveredPoints > b.UndeliveredPoints
+	})
+	span.SetAttributes(attribute.Int("carryover.cards", len(report.Cards)))
+	return report, nil
+}
+
+// sortSprints puts sprints in the order they run: closed and active sprints by start
+// date, then future sprints by position
+func sortSprints(sprints []*sprint.Sprint) {
+	phase := func(sp *sprint.Sprint) int {
+		switch sp.Status {
+		case sprint.SprintStatusClosed:
+			return 0
+		case sprint.SprintStatusActive:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(sprints, func(i, j int) bool {
+		a, b := sprints[i], sprints[j]
+		if phase(a) != phase(b) {
+			return phase(a) < phase(b)
+		}
+		if a.StartDate != nil && b.StartDate != nil && !a.StartDate.Equal(*b.StartDate) {
+			return a.StartDate.Before(*b.StartDate)
+		}
+		return a.Position < b.Position
+	})
+}
+
+func (s *service) GetCardSprintHistory(ctx context.Context, cardID uuid.UUID) ([]*SprintStay, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCardSprintHistory")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	changes, err := s.membershipRepo.GetCardChanges(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.membershipRepo.GetCardSprints(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	type stay struct {
+		sprintID  uuid.UUID
+		addedAt   *time.Time
+		removedAt *time.Time
+		inSprint  bool
+	}
+	var stays []*stay
+	open := map[uuid.UUID]*stay{}
+	add := func(sprintID uuid.UUID, at time.Time) {
+		if open[sprintID] == nil {
+			st := &stay{sprintID: sprintID, addedAt: &at}
+			open[sprintID] = st
+			stays = append(stays, st)
+		}
+	}
+	remove := func(sprintID uuid.UUID, at time.Time) {
+		st := open[sprintID]
+		if st == nil {
+			// In the sprint since before its history was recorded
+			st = &stay{sprintID: sprintID}
+			stays = append(stays, st)
+		}
+		st.removedAt = &at
+		delete(open, sprintID)
+	}
+
+	for _, c := range changes {
+		switch c.Kind {
+		case sprint_membership.ChangeAdded:
+			for _, id := range c.SprintIDs {
+				add(id, c.OccurredAt)
+			}
+		case sprint_membership.ChangeRemoved:
+			if len(c.SprintIDs) == 0 {
+				for _, st := range stays {
+					if open[st.sprintID] == st {
+						remove(st.sprintID, c.OccurredAt)
+					}
+				}
+			}
+			for _, id := range c.SprintIDs {
+				remove(id, c.OccurredAt)
+			}
+		case sprint_membership.ChangeSet:
+			kept := make(map[uuid.UUID]bool, len(c.SprintIDs))
+			for _, id := range c.SprintIDs {
+				kept[id] = true
+			}
+			for _, st := range stays {
+				if open[st.sprintID] == st && !kept[st.sprintID] {
+					remove(st.sprintID, c.OccurredAt)
+				}
+			}
+			for _, id := range c.SprintIDs {
+				add(id, c.OccurredAt)
+			}
+		}
+	}
+
+	// The card's sprints now are the truth: carrying cards over and the sprint column policy
+	// change them without audit events
+	for _, cs := range current {
+		st := open[cs.SprintID]
+		if st == nil {
+			addedAt := cs.AddedAt
+			st = &stay{sprintID: cs.SprintID, addedAt: &addedAt}
+			stays = append(stays, st)
+		}
+		st.inSprint = true
+	}
+
+	sort.SliceStable(stays, func(i, j int) bool {
+		a, b := stays[i].addedAt, stays[j].addedAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+
+	sprints := map[uuid.UUID]*sprint.Sprint{}
+	history := make([]*SprintStay, 0, len(stays))
+	for _, st := range stays {
+		sp, ok := sprints[st.sprintID]
+		if !ok {
+			sp, err = s.sprintRepo.GetByID(ctx, st.sprintID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			sprints[st.sprintID] = sp
+		}
+		if sp == nil {
+			continue
+		}
+		history = append(history, &SprintStay{
+			Sprint:    sp,
+			AddedAt:   st.addedAt,
+			RemovedAt: st.removedAt,
+			InSprint:  st.inSprint,
+		})
+	}
+	span.SetAttributes(attribute.Int("carryover.stays", len(history)))
+	return history, nil
+}
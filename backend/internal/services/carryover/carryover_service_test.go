@@ -0,0 +1,208 @@
+package carryover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint_membership"
+	membershipMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint_membership/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGetReport(t *testing.T) {
+	ctx := context.Background()
+	boardID := uuid.New()
+
+	started := func(days int) *time.Time {
+		at := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC).AddDate(0, 0, days)
+		return &at
+	}
+	// Returned out of order to check the report sorts them
+	s1 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 1", Status: sprint.SprintStatusClosed, StartDate: started(0), Position: 3}
+	s2 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 2", Status: sprint.SprintStatusClosed, StartDate: started(14), Position: 1}
+	s3 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 3", Status: sprint.SprintStatusClosed, StartDate: started(28), Position: 2}
+	active := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 4", Status: sprint.SprintStatusActive, StartDate: started(42), Position: 0}
+	sprints := []*sprint.Sprint{active, s3, s1, s2}
+
+	points := func(n int) *int { return &n }
+	carriedTwice := uuid.New()
+	carriedOnce := uuid.New()
+	intoActive := uuid.New()
+	delivered := uuid.New()
+	member := func(cardID uuid.UUID, sp *sprint.Sprint, storyPoints *int, done bool) *sprint_membership.Membership {
+		return &sprint_membership.Membership{CardID: cardID, SprintID: sp.ID, Title: "Card", StoryPoints: storyPoints, Done: done}
+	}
+	memberships := []*sprint_membership.Membership{
+		member(carriedTwice, s1, points(5), true),
+		member(carriedTwice, s2, points(5), true),
+		member(carriedTwice, s3, points(5), true),
+		member(carriedOnce, s2, points(3), true),
+		member(carriedOnce, s3, points(3), true),
+		member(intoActive, s3, nil, false),
+		member(intoActive, active, nil, false),
+		member(delivered, s1, points(8), true),
+	}
+
+	setup := func(t *testing.T) (Service, *sprintMocks.MockRepository, *membershipMocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		sprintRepo := sprintMocks.NewMockRepository(ctrl)
+		membershipRepo := membershipMocks.NewMockRepository(ctrl)
+		return NewService(sprintRepo, membershipRepo), sprintRepo, membershipRepo
+	}
+
+	t.Run("success - counts cards carried across sprints", func(t *testing.T) {
+		svc, sprintRepo, membershipRepo := setup(t)
+		sprintRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(sprints, nil)
+		membershipRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(memberships, nil)
+
+		report, err := svc.GetReport(ctx, boardID, nil)
+		require.NoError(t, err)
+
+		require.Len(t, report.Sprints, 3)
+		assert.Equal(t, SprintCarryover{Sprint: s1, CommittedCards: 2, CommittedPoints: 13, CarriedOutCards: 1, CarriedOutPoints: 5}, report.Sprints[0])
+		assert.Equal(t, SprintCarryover{Sprint: s2, CommittedCards: 2, CommittedPoints: 8, CarriedInCards: 1, CarriedOutCards: 2, CarriedOutPoints: 8}, report.Sprints[1])
+		assert.Equal(t, SprintCarryover{Sprint: s3, CommittedCards: 3, CommittedPoints: 8, CarriedInCards: 2, CarriedOutCards: 1}, report.Sprints[2])
+
+		require.Len(t, report.Cards, 3)
+		assert.Equal(t, carriedTwice, report.Cards[0].CardID)
+		assert.Equal(t, 2, report.Cards[0].TimesCarried)
+		assert.Equal(t, 10, report.Cards[0].UndeliveredPoints)
+		assert.Equal(t, []uuid.UUID{s1.ID, s2.ID, s3.ID}, report.Cards[0].SprintIDs)
+		assert.Equal(t, carriedOnce, report.Cards[1].CardID)
+		assert.Equal(t, 3, report.Cards[1].UndeliveredPoints)
+		assert.Equal(t, intoActive, report.Cards[2].CardID)
+		assert.Equal(t, 0, report.Cards[2].UndeliveredPoints)
+		assert.False(t, report.Cards[2].Done)
+
+		assert.Equal(t, 4, report.TotalCarryovers)
+		assert.Equal(t, 13, report.UndeliveredPoints)
+	})
+
+	t.Run("success - only the last N sprints", func(t *testing.T) {
+		svc, sprintRepo, membershipRepo := setup(t)
+		sprintRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(sprints, nil)
+		membershipRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(memberships, nil)
+
+		lastN := 1
+		report, err := svc.GetReport(ctx, boardID, &lastN)
+		require.NoError(t, err)
+
+		require.Len(t, report.Sprints, 1)
+		assert.Equal(t, s3, report.Sprints[0].Sprint)
+		assert.Equal(t, 2, report.Sprints[0].CarriedInCards)
+		require.Len(t, report.Cards, 1)
+		assert.Equal(t, intoActive, report.Cards[0].CardID)
+		assert.Equal(t, 1, report.TotalCarryovers)
+	})
+
+	t.Run("success - no closed sprints", func(t *testing.T) {
+		svc, sprintRepo, _ := setup(t)
+		sprintRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*sprint.Sprint{active}, nil)
+
+		report, err := svc.GetReport(ctx, boardID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, report.Sprints)
+		assert.Empty(t, report.Cards)
+	})
+
+	t.Run("fail - invalid sprint count", func(t *testing.T) {
+		svc, _, _ := setup(t)
+		for _, n := range []int{0, MaxSprintCount + 1} {
+			_, err := svc.GetReport(ctx, boardID, &n)
+			assert.ErrorIs(t, err, ErrInvalidSprintCount)
+		}
+	})
+
+	t.Run("fail - repository error", func(t *testing.T) {
+		svc, sprintRepo, membershipRepo := setup(t)
+		sprintRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(sprints, nil)
+		membershipRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(nil, errors.New("db error"))
+
+		_, err := svc.GetReport(ctx, boardID, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetCardSprintHistory(t *testing.T) {
+	ctx := context.Background()
+	cardID := uuid.New()
+	at := func(day int) time.Time {
+		return time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day)
+	}
+	s0 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 0", Status: sprint.SprintStatusClosed}
+	s1 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 1", Status: sprint.SprintStatusClosed}
+	s2 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 2", Status: sprint.SprintStatusActive}
+	s4 := &sprint.Sprint{ID: uuid.New(), Name: "Sprint 4", Status: sprint.SprintStatusFuture}
+	deleted := uuid.New()
+
+	setup := func(t *testing.T) (Service, *sprintMocks.MockRepository, *membershipMocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		sprintRepo := sprintMocks.NewMockRepository(ctrl)
+		membershipRepo := membershipMocks.NewMockRepository(ctrl)
+		return NewService(sprintRepo, membershipRepo), sprintRepo, membershipRepo
+	}
+
+	t.Run("success - follows the card across sprints", func(t *testing.T) {
+		svc, sprintRepo, membershipRepo := setup(t)
+		membershipRepo.EXPECT().GetCardChanges(gomock.Any(), cardID).Return([]*sprint_membership.Change{
+			// In Sprint 0 since before its history was recorded
+			{Kind: sprint_membership.ChangeRemoved, SprintIDs: []uuid.UUID{s0.ID}, OccurredAt: at(1)},
+			{Kind: sprint_membership.ChangeAdded, SprintIDs: []uuid.UUID{s1.ID}, OccurredAt: at(2)},
+			{Kind: sprint_membership.ChangeAdded, SprintIDs: []uuid.UUID{deleted}, OccurredAt: at(3)},
+			{Kind: sprint_membership.ChangeSet, SprintIDs: []uuid.UUID{s1.ID, s4.ID}, OccurredAt: at(4)},
+			// Moved to the backlog
+			{Kind: sprint_membership.ChangeRemoved, SprintIDs: []uuid.UUID{}, OccurredAt: at(5)},
+			{Kind: sprint_membership.ChangeAdded, SprintIDs: []uuid.UUID{s1.ID}, OccurredAt: at(6)},
+		}, nil)
+		// Carried over from Sprint 1 into Sprint 2, without an audit event
+		membershipRepo.EXPECT().GetCardSprints(gomock.Any(), cardID).Return([]*sprint_membership.CardSprint{
+			{SprintID: s1.ID, AddedAt: at(6)},
+			{SprintID: s2.ID, AddedAt: at(7)},
+		}, nil)
+		for _, sp := range []*sprint.Sprint{s0, s1, s2, s4} {
+			sprintRepo.EXPECT().GetByID(gomock.Any(), sp.ID).Return(sp, nil)
+		}
+		sprintRepo.EXPECT().GetByID(gomock.Any(), deleted).Return(nil, gorm.ErrRecordNotFound)
+
+		history, err := svc.GetCardSprintHistory(ctx, cardID)
+		require.NoError(t, err)
+
+		ptr := func(day int) *time.Time {
+			d := at(day)
+			return &d
+		}
+		assert.Equal(t, []*SprintStay{
+			{Sprint: s0, RemovedAt: ptr(1)},
+			{Sprint: s1, AddedAt: ptr(2), RemovedAt: ptr(5)},
+			{Sprint: s4, AddedAt: ptr(4), RemovedAt: ptr(5)},
+			{Sprint: s1, AddedAt: ptr(6), InSprint: true},
+			{Sprint: s2, AddedAt: ptr(7), InSprint: true},
+		}, history)
+	})
+
+	t.Run("success - never in a sprint", func(t *testing.T) {
+		svc, _, membershipRepo := setup(t)
+		membershipRepo.EXPECT().GetCardChanges(gomock.Any(), cardID).Return(nil, nil)
+		membershipRepo.EXPECT().GetCardSprints(gomock.Any(), cardID).Return(nil, nil)
+
+		history, err := svc.GetCardSprintHistory(ctx, cardID)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+	})
+
+	t.Run("fail - repository error", func(t *testing.T) {
+		svc, _, membershipRepo := setup(t)
+		membershipRepo.EXPECT().GetCardChanges(gomock.Any(), cardID).Return(nil, errors.New("db error"))
+
+		_, err := svc.GetCardSprintHistory(ctx, cardID)
+		assert.Error(t, err)
+	})
+}
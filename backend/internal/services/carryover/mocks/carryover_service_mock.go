@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: carryover_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=carryover_service.go -destination=mocks/carryover_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	carryover "github.com/thatcatdev/kaimu/backend/internal/services/carryover"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCardSprintHistory mocks base method.
+func (m *MockService) GetCardSprintHistory(ctx context.Context, cardID uuid.UUID) ([]*carryover.SprintStay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardSprintHistory", ctx, cardID)
+	ret0, _ := ret[0].([]*carryover.SprintStay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardSprintHistory indicates an expected call of GetCardSprintHistory.
+func (mr *MockServiceMockRecorder) GetCardSprintHistory(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardSprintHistory", reflect.TypeOf((*MockService)(nil).GetCardSprintHistory), ctx, cardID)
+}
+
+// GetReport mocks base method.
+func (m *MockService) GetReport(ctx context.Context, boardID uuid.UUID, lastN *int) (*carryover.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, boardID, lastN)
+	ret0, _ := ret[0].(*carryover.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockServiceMockRecorder) GetReport(ctx, boardID, lastN any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockService)(nil).GetReport), ctx, boardID, lastN)
+}
@@ -0,0 +1,331 @@
+package checklist
+
+//go:generate mockgen -source=checklist_service.go -destination=mocks/checklist_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrItemNotFound      = errors.New("checklist item not found")
+	ErrCardNotFound      = errors.New("card not found")
+	ErrTitleRequired     = errors.New("checklist item title is required")
+	ErrTitleTooLong      = errors.New("checklist item title is too long")
+	ErrTooManyItems      = errors.New("card checklist is full")
+	ErrAssigneeNotMember = errors.New("checklist item assignee must be a member of the card's organization")
+	ErrInvalidItemOrder  = errors.New("item order must list every item of the card's checklist once")
+)
+
+const (
+	// MaxTitleLength is the longest checklist item title, in characters
+	MaxTitleLength = 500
+	// MaxItemsPerCard caps the length of a card's checklist
+	MaxItemsPerCard = 100
+)
+
+// UpdateItemInput changes the fields of a checklist item that are set
+type UpdateItemInput struct {
+	ID            uuid.UUID
+	Title         *string
+	Done          *bool
+	AssigneeID    *uuid.UUID
+	ClearAssignee bool
+}
+
+type Service interface {
+	// CreateItem appends an item to the card's checklist
+	CreateItem(ctx context.Context, cardID uuid.UUID, title string, assigneeID *uuid.UUID) (*checklist.Item, error)
+	UpdateItem(ctx context.Context, input UpdateItemInput) (*checklist.Item, error)
+	// ReorderItems puts the card's checklist in the order of itemIDs, which must list each of
+	// its items once, and returns the reordered checklist
+	ReorderItems(ctx context.Context, cardID uuid.UUID, itemIDs []uuid.UUID) ([]*checklist.Item, error)
+	// DeleteItem deletes the item, returning it
+	DeleteItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error)
+	GetItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error)
+	// GetItems returns the card's checklist in order
+	GetItems(ctx context.Context, cardID uuid.UUID) ([]*checklist.Item, error)
+	// GetProgress counts the card's checklist items and those done
+	GetProgress(ctx context.Context, cardID uuid.UUID) (*checklist.Progress, error)
+}
+
+type service struct {
+	checklistRepo checklist.Repository
+	cardRepo      card.Repository
+	boardRepo     board.Repository
+	projectRepo   project.Repository
+	orgMemberRepo organization_member.Repository
+	txManager     transaction.Manager
+}
+
+func NewService(
+	checklistRepo checklist.Repository,
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	orgMemberRepo organization_member.Repository,
+	txManager transaction.Manager,
+) Service {
+	return &service{
+		checklistRepo: checklistRepo,
+		cardRepo:      cardRepo,
+		boardRepo:     boardRepo,
+		projectRepo:   projectRepo,
+		orgMemberRepo: orgMemberRepo,
+		txManager:     txManager,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "checklist.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "checklist"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateItem(ctx context.Context, cardID uuid.UUID, title string, assigneeID *uuid.UUID) (*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateItem")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	c, err := s.getCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	title, err = checkTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	if assigneeID != nil {
+		if err := s.checkAssignee(ctx, c, *assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	item := &checklist.Item{CardID: cardID, Title: title, AssigneeID: assigneeID}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := s.checklistRepo.GetByCardID(ctx, cardID)
+		if err != nil {
+			return err
+		}
+		if len(items) >= MaxItemsPerCard {
+			return ErrTooManyItems
+		}
+
+		maxPos, err := s.checklistRepo.GetMaxPosition(ctx, cardID)
+		if err != nil {
+			return err
+		}
+		item.Position = maxPos + 1
+		return s.checklistRepo.Create(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *service) UpdateItem(ctx context.Context, input UpdateItemInput) (*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateItem")
+	span.SetAttributes(attribute.String("checklist_item.id", input.ID.String()))
+	defer span.End()
+
+	item, err := s.GetItem(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		title, err := checkTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		item.Title = title
+	}
+	if input.Done != nil {
+		item.Done = *input.Done
+	}
+	if input.ClearAssignee {
+		item.AssigneeID = nil
+	} else if input.AssigneeID != nil {
+		c, err := s.getCard(ctx, item.CardID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkAssignee(ctx, c, *input.AssigneeID); err != nil {
+			return nil, err
+		}
+		item.AssigneeID = input.AssigneeID
+	}
+
+	if err := s.checklistRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *service) ReorderItems(ctx context.Context, cardID uuid.UUID, itemIDs []uuid.UUID) ([]*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "ReorderItems")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	if _, err := s.getCard(ctx, cardID); err != nil {
+		return nil, err
+	}
+
+	var items []*checklist.Item
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.checklistRepo.GetByCardID(ctx, cardID)
+		if err != nil {
+			return err
+		}
+		if len(current) != len(itemIDs) {
+			return ErrInvalidItemOrder
+		}
+		byID := make(map[uuid.UUID]*checklist.Item, len(current))
+		for _, item := range current {
+			byID[item.ID] = item
+		}
+
+		items = make([]*checklist.Item, len(itemIDs))
+		for i, id := range itemIDs {
+			item, ok := byID[id]
+			if !ok {
+				return ErrInvalidItemOrder
+			}
+			delete(byID, id)
+			item.Position = i
+			items[i] = item
+		}
+		return s.checklistRepo.UpdatePositions(ctx, itemIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *service) DeleteItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "DeleteItem")
+	span.SetAttributes(attribute.String("checklist_item.id", id.String()))
+	defer span.End()
+
+	item, err := s.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checklistRepo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *service) GetItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetItem")
+	span.SetAttributes(attribute.String("checklist_item.id", id.String()))
+	defer span.End()
+
+	item, err := s.checklistRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *service) GetItems(ctx context.Context, cardID uuid.UUID) ([]*checklist.Item, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetItems")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	return s.checklistRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *service) GetProgress(ctx context.Context, cardID uuid.UUID) (*checklist.Progress, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProgress")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	progress, err := s.checklistRepo.GetProgressByCardIDs(ctx, []uuid.UUID{cardID})
+	if err != nil {
+		return nil, err
+	}
+	if len(progress) == 0 {
+		return &checklist.Progress{CardID: cardID}, nil
+	}
+	return progress[0], nil
+}
+
+func (s *service) getCard(ctx context.Context, cardID uuid.UUID) (*card.Card, error) {
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkAssignee requires the user to belong to the organization of the card
+func (s *service) checkAssignee(ctx context.Context, c *card.Card, userID uuid.UUID) error {
+	b, err := s.boardRepo.GetByID(ctx, c.BoardID)
+	if err != nil {
+		return err
+	}
+	p, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.orgMemberRepo.GetByOrgAndUser(ctx, p.OrganizationID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAssigneeNotMember
+		}
+		return err
+	}
+	return nil
+}
+
+// checkTitle trims the title and enforces its length
+func checkTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", ErrTitleRequired
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", ErrTitleTooLong
+	}
+	return title, nil
+}
+
+// Completion is the share of the checklist done as a whole percentage, rounded down, or nil
+// for cards without a checklist
+func Completion(p *checklist.Progress) *int {
+	if p == nil || p.Total == 0 {
+		return nil
+	}
+	percent := p.Done * 100 / p.Total
+	return &percent
+}
@@ -0,0 +1,255 @@
+package checklist
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	checklistMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateItem(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	p := &project.Project{ID: uuid.New(), OrganizationID: orgID}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID}
+	c := &card.Card{ID: uuid.New(), BoardID: b.ID}
+	assigneeID := uuid.New()
+
+	t.Run("appends the item to the checklist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, assigneeID).Return(&organization_member.OrganizationMember{}, nil)
+		mockChecklistRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*checklist.Item{{}, {}}, nil)
+		mockChecklistRepo.EXPECT().GetMaxPosition(gomock.Any(), c.ID).Return(1, nil)
+		mockChecklistRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		item, err := svc.CreateItem(ctx, c.ID, "  Write tests  ", &assigneeID)
+		require.NoError(t, err)
+		assert.Equal(t, "Write tests", item.Title)
+		assert.Equal(t, 2, item.Position)
+		assert.Equal(t, &assigneeID, item.AssigneeID)
+		assert.False(t, item.Done)
+	})
+
+	t.Run("rejects assignees outside the card's organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, assigneeID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateItem(ctx, c.ID, "Write tests", &assigneeID)
+		assert.ErrorIs(t, err, ErrAssigneeNotMember)
+	})
+
+	t.Run("validates the title", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil).Times(2)
+
+		_, err := svc.CreateItem(ctx, c.ID, "   ", nil)
+		assert.ErrorIs(t, err, ErrTitleRequired)
+		_, err = svc.CreateItem(ctx, c.ID, strings.Repeat("a", MaxTitleLength+1), nil)
+		assert.ErrorIs(t, err, ErrTitleTooLong)
+	})
+
+	t.Run("refuses items beyond the limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockChecklistRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return(make([]*checklist.Item, MaxItemsPerCard), nil)
+
+		_, err := svc.CreateItem(ctx, c.ID, "One more", nil)
+		assert.ErrorIs(t, err, ErrTooManyItems)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateItem(ctx, c.ID, "Write tests", nil)
+		assert.ErrorIs(t, err, ErrCardNotFound)
+	})
+}
+
+func TestUpdateItem(t *testing.T) {
+	ctx := context.Background()
+	assigneeID := uuid.New()
+
+	t.Run("checks the item off and clears its assignee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		item := &checklist.Item{ID: uuid.New(), CardID: uuid.New(), Title: "Write tests", AssigneeID: &assigneeID}
+		done := true
+		mockChecklistRepo.EXPECT().GetByID(gomock.Any(), item.ID).Return(item, nil)
+		mockChecklistRepo.EXPECT().Update(gomock.Any(), item).Return(nil)
+
+		updated, err := svc.UpdateItem(ctx, UpdateItemInput{ID: item.ID, Done: &done, ClearAssignee: true})
+		require.NoError(t, err)
+		assert.True(t, updated.Done)
+		assert.Nil(t, updated.AssigneeID)
+		assert.Equal(t, "Write tests", updated.Title)
+	})
+
+	t.Run("item not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		id := uuid.New()
+		mockChecklistRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.UpdateItem(ctx, UpdateItemInput{ID: id})
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+}
+
+func TestReorderItems(t *testing.T) {
+	ctx := context.Background()
+	c := &card.Card{ID: uuid.New()}
+	first := &checklist.Item{ID: uuid.New(), CardID: c.ID, Position: 0}
+	second := &checklist.Item{ID: uuid.New(), CardID: c.ID, Position: 1}
+	third := &checklist.Item{ID: uuid.New(), CardID: c.ID, Position: 2}
+
+	t.Run("rewrites positions in the given order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+		order := []uuid.UUID{third.ID, first.ID, second.ID}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockChecklistRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*checklist.Item{first, second, third}, nil)
+		mockChecklistRepo.EXPECT().UpdatePositions(gomock.Any(), order).Return(nil)
+
+		items, err := svc.ReorderItems(ctx, c.ID, order)
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		assert.Equal(t, third.ID, items[0].ID)
+		assert.Equal(t, 0, items[0].Position)
+		assert.Equal(t, 2, items[2].Position)
+	})
+
+	tests := []struct {
+		name  string
+		order []uuid.UUID
+	}{
+		{name: "missing item", order: []uuid.UUID{first.ID, second.ID}},
+		{name: "repeated item", order: []uuid.UUID{first.ID, first.ID, second.ID}},
+		{name: "item of another card", order: []uuid.UUID{first.ID, second.ID, uuid.New()}},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+			mockCardRepo := cardMocks.NewMockRepository(ctrl)
+			mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+			mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+			mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+
+			svc := NewService(mockChecklistRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockOrgMemberRepo, transaction.NewNoopManager())
+
+			mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+			mockChecklistRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*checklist.Item{first, second, third}, nil)
+
+			_, err := svc.ReorderItems(ctx, c.ID, tt.order)
+			assert.ErrorIs(t, err, ErrInvalidItemOrder)
+		})
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	assert.Nil(t, Completion(&checklist.Progress{}))
+	assert.Equal(t, 66, *Completion(&checklist.Progress{Total: 3, Done: 2}))
+	assert.Equal(t, 100, *Completion(&checklist.Progress{Total: 4, Done: 4}))
+}
@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: checklist_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=checklist_service.go -destination=mocks/checklist_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	checklist "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	checklist0 "github.com/thatcatdev/kaimu/backend/internal/services/checklist"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateItem mocks base method.
+func (m *MockService) CreateItem(ctx context.Context, cardID uuid.UUID, title string, assigneeID *uuid.UUID) (*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItem", ctx, cardID, title, assigneeID)
+	ret0, _ := ret[0].(*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItem indicates an expected call of CreateItem.
+func (mr *MockServiceMockRecorder) CreateItem(ctx, cardID, title, assigneeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockService)(nil).CreateItem), ctx, cardID, title, assigneeID)
+}
+
+// DeleteItem mocks base method.
+func (m *MockService) DeleteItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
+	ret0, _ := ret[0].(*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockServiceMockRecorder) DeleteItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockService)(nil).DeleteItem), ctx, id)
+}
+
+// GetItem mocks base method.
+func (m *MockService) GetItem(ctx context.Context, id uuid.UUID) (*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
+	ret0, _ := ret[0].(*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItem indicates an expected call of GetItem.
+func (mr *MockServiceMockRecorder) GetItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockService)(nil).GetItem), ctx, id)
+}
+
+// GetItems mocks base method.
+func (m *MockService) GetItems(ctx context.Context, cardID uuid.UUID) ([]*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, cardID)
+	ret0, _ := ret[0].([]*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockServiceMockRecorder) GetItems(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockService)(nil).GetItems), ctx, cardID)
+}
+
+// GetProgress mocks base method.
+func (m *MockService) GetProgress(ctx context.Context, cardID uuid.UUID) (*checklist.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", ctx, cardID)
+	ret0, _ := ret[0].(*checklist.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockServiceMockRecorder) GetProgress(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockService)(nil).GetProgress), ctx, cardID)
+}
+
+// ReorderItems mocks base method.
+func (m *MockService) ReorderItems(ctx context.Context, cardID uuid.UUID, itemIDs []uuid.UUID) ([]*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderItems", ctx, cardID, itemIDs)
+	ret0, _ := ret[0].([]*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderItems indicates an expected call of ReorderItems.
+func (mr *MockServiceMockRecorder) ReorderItems(ctx, cardID, itemIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderItems", reflect.TypeOf((*MockService)(nil).ReorderItems), ctx, cardID, itemIDs)
+}
+
+// UpdateItem mocks base method.
+func (m *MockService) UpdateItem(ctx context.Context, input checklist0.UpdateItemInput) (*checklist.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, input)
+	ret0, _ := ret[0].(*checklist.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockServiceMockRecorder) UpdateItem(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockService)(nil).UpdateItem), ctx, input)
+}
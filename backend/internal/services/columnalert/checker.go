@@ -0,0 +1,44 @@
+package columnalert
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultCheckInterval is how often the checker compares columns against their board's thresholds
+const DefaultCheckInterval = time.Minute
+
+// Checker runs Service.Check in the background
+type Checker struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewChecker(svc Service, interval time.Duration) *Checker {
+	return &Checker{svc: svc, interval: interval}
+}
+
+// Run checks the boards with column alerts every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		raised, err := c.svc.Check(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to check column alerts")
+		} else if raised > 0 {
+			log.Info().Int("raised", raised).Msg("Raised column alerts")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package columnalert
+
+//go:generate mockgen -source=columnalert_service.go -destination=mocks/columnalert_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// MaxWIPExceededMinutes caps how long a column may stay over its WIP limit at a week
+const MaxWIPExceededMinutes = 7 * 24 * 60
+
+// MaxAverageAgeHours caps the average card age threshold at a year
+const MaxAverageAgeHours = 365 * 24
+
+const slackWebhookPrefix = "https://hooks.slack.com/"
+
+var (
+	ErrBoardNotFound              = errors.New("board not found")
+	ErrInvalidWIPExceededMinutes  = errors.New("WIP alert delay must be between 1 and 10080 minutes")
+	ErrInvalidAverageAgeHours     = errors.New("average age threshold must be between 1 and 8760 hours")
+	ErrInvalidSlackWebhook        = errors.New("slack webhook must be an " + slackWebhookPrefix + " URL")
+	ErrSlackChannelWithoutWebhook = errors.New("a slack channel needs a slack webhook")
+)
+
+// SettingsInput describes a board's column alert settings
+type SettingsInput struct {
+	Enabled bool
+	// WIPExceededMinutes is how long a column may stay over its WIP limit, nil to not alert
+	WIPExceededMinutes *int
+	// AverageAgeHours is the average card age that raises an alert, nil to not alert
+	AverageAgeHours *int
+	SlackWebhookURL *string
+	SlackChannel    *string
+}
+
+type Service interface {
+	// GetSettings returns the board's settings, or the defaults when it has none
+	GetSettings(ctx context.Context, boardID uuid.UUID) (*column_alert_setting.ColumnAlertSetting, error)
+	// UpdateSettings replaces the board's settings; turning alerts off resolves its open alerts
+	UpdateSettings(ctx context.Context, boardID uuid.UUID, input SettingsInput) (*column_alert_setting.ColumnAlertSetting, error)
+	// GetAlerts returns the board's most recently raised alerts
+	GetAlerts(ctx context.Context, boardID uuid.UUID, limit int) ([]*column_alert.ColumnAlert, error)
+	// Check compares the columns of every board with alerts turned on against its
+	// thresholds, returning how many alerts it raised
+	Check(ctx context.Context) (int, error)
+}
+
+type service struct {
+	settingRepo column_alert_setting.Repository
+	alertRepo   column_alert.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	bus         events.Bus
+	now         func() time.Time
+}
+
+func NewService(
+	settingRepo column_alert_setting.Repository,
+	alertRepo column_alert.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	bus events.Bus,
+) Service {
+	return &service{
+		settingRepo: settingRepo,
+		alertRepo:   alertRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		bus:         bus,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "columnalert.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "columnalert"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetSettings(ctx context.Context, boardID uuid.UUID) (*column_alert_setting.ColumnAlertSetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSettings")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.settingRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *service) UpdateSettings(ctx context.Context, boardID uuid.UUID, input SettingsInput) (*column_alert_setting.ColumnAlertSetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateSettings")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	if m := input.WIPExceededMinutes; m != nil && (*m < 1 || *m > MaxWIPExceededMinutes) {
+		return nil, ErrInvalidWIPExceededMinutes
+	}
+	if h := input.AverageAgeHours; h != nil && (*h < 1 || *h > MaxAverageAgeHours) {
+		return nil, ErrInvalidAverageAgeHours
+	}
+
+	setting := &column_alert_setting.ColumnAlertSetting{
+		BoardID:            boardID,
+		Enabled:            input.Enabled,
+		WIPExceededMinutes: input.WIPExceededMinutes,
+		AverageAgeHours:    input.AverageAgeHours,
+	}
+	if input.SlackWebhookURL != nil {
+		if webhook := strings.TrimSpace(*input.SlackWebhookURL); webhook != "" {
+			if !strings.HasPrefix(webhook, slackWebhookPrefix) {
+				return nil, ErrInvalidSlackWebhook
+			}
+			setting.SlackWebhookURL = &webhook
+		}
+	}
+	if input.SlackChannel != nil {
+		setting.SlackChannel = strings.TrimSpace(*input.SlackChannel)
+		if setting.SlackChannel != "" && setting.SlackWebhookURL == nil {
+			return nil, ErrSlackChannelWithoutWebhook
+		}
+	}
+
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	// Alerts that can no longer clear or be raised are closed; the others are picked up by
+	// the next check
+	if !setting.Enabled {
+		if err := s.alertRepo.ResolveByBoardID(ctx, boardID, s.now()); err != nil {
+			return nil, err
+		}
+	}
+	return setting, nil
+}
+
+func (s *service) GetAlerts(ctx context.Context, boardID uuid.UUID, limit int) ([]*column_alert.ColumnAlert, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAlerts")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.alertRepo.GetByBoardID(ctx, boardID, limit)
+}
+
+func (s *service) Check(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Check")
+	defer span.End()
+
+	settings, err := s.settingRepo.GetEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	raised := 0
+	for _, setting := range settings {
+		n, err := s.checkBoard(ctx, setting, now)
+		raised += n
+		if err != nil {
+			return raised, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("columnalert.raised", raised))
+	return raised, nil
+}
+
+// checkBoard opens an alert for each condition newly seen on the board's columns, raises
+// the open alerts that have lasted long enough and resolves those whose condition cleared
+func (s *service) checkBoard(ctx context.Context, setting *column_alert_setting.ColumnAlertSetting, now time.Time) (int, error) {
+	stats, err := s.columnRepo.GetStatsByBoardID(ctx, setting.BoardID, now)
+	if err != nil {
+		return 0, err
+	}
+	open, err := s.alertRepo.GetOpenByBoardID(ctx, setting.BoardID)
+	if err != nil {
+		return 0, err
+	}
+
+	type key struct {
+		columnID uuid.UUID
+		kind     column_alert.Kind
+	}
+	openByKey := make(map[key]*column_alert.ColumnAlert, len(open))
+	for _, a := range open {
+		openByKey[key{a.ColumnID, a.Kind}] = a
+	}
+
+	raised := 0
+	for _, st := range stats {
+		conditions := []struct {
+			kind   column_alert.Kind
+			active bool
+			delay  time.Duration
+		}{
+			{column_alert.KindWIPExceeded, setting.WIPExceededMinutes != nil && st.OverWipLimit(), setting.WIPExceededDuration()},
+			{column_alert.KindAging, setting.AverageAgeHours != nil && st.AverageAgeSeconds != nil &&
+				*st.AverageAgeSeconds >= setting.AverageAge().Seconds(), 0},
+		}
+		for _, c := range conditions {
+			alert := openByKey[key{st.ColumnID, c.kind}]
+
+			if !c.active {
+				if alert != nil {
+					if err := s.alertRepo.Resolve(ctx, alert.ID, now); err != nil {
+						return raised, err
+					}
+				}
+				continue
+			}
+
+			if alert == nil {
+				alert = &column_alert.ColumnAlert{
+					BoardID:   setting.BoardID,
+					ColumnID:  st.ColumnID,
+					Kind:      c.kind,
+					StartedAt: now,
+				}
+				created, err := s.alertRepo.CreateIfAbsent(ctx, alert)
+				if err != nil {
+					return raised, err
+				}
+				if !created {
+					continue
+				}
+			}
+			if alert.RaisedAt != nil || now.Sub(alert.StartedAt) < c.delay {
+				continue
+			}
+
+			ok, err := s.raise(ctx, alert, st, now)
+			if err != nil {
+				return raised, err
+			}
+			if ok {
+				raised++
+			}
+		}
+	}
+	return raised, nil
+}
+
+// raise records the column's state on the alert and announces it, reporting false when it
+// was already raised
+func (s *service) raise(ctx context.Context, alert *column_alert.ColumnAlert, st *board_column.ColumnStats, now time.Time) (bool, error) {
+	cardCount := st.CardCount
+	alert.CardCount = &cardCount
+	alert.WipLimit = st.WipLimit
+	alert.AverageAgeSeconds = st.AverageAgeSeconds
+
+	raised, err := s.alertRepo.Raise(ctx, alert, now)
+	if err != nil || !raised {
+		return false, err
+	}
+	err = s.bus.Publish(ctx, events.New(ctx, events.ColumnAlertRaised, events.ColumnAlertPayload{
+		AlertID:  alert.ID,
+		BoardID:  alert.BoardID,
+		ColumnID: alert.ColumnID,
+	}))
+	return err == nil, err
+}
@@ -0,0 +1,242 @@
+package columnalert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	alertMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	settingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	boardID := uuid.New()
+	setting := &column_alert_setting.ColumnAlertSetting{
+		BoardID:            boardID,
+		Enabled:            true,
+		WIPExceededMinutes: intPtr(30),
+		AverageAgeHours:    intPtr(48),
+	}
+
+	// subscribe records the alerts announced on the bus
+	subscribe := func(bus events.Bus) *[]uuid.UUID {
+		var raised []uuid.UUID
+		bus.Subscribe(events.ColumnAlertRaised, func(ctx context.Context, event events.Event) error {
+			raised = append(raised, event.Payload.(events.ColumnAlertPayload).AlertID)
+			return nil
+		})
+		return &raised
+	}
+
+	t.Run("opens a WIP alert when the limit is first exceeded, without raising it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		bus := events.NewSyncBus()
+		raised := subscribe(bus)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		columnID := uuid.New()
+		mockSettingRepo.EXPECT().GetEnabled(gomock.Any()).Return([]*column_alert_setting.ColumnAlertSetting{setting}, nil)
+		mockColumnRepo.EXPECT().GetStatsByBoardID(gomock.Any(), boardID, now).Return([]*board_column.ColumnStats{
+			{ColumnID: columnID, CardCount: 6, WipLimit: intPtr(5), AverageAgeSeconds: floatPtr(3600)},
+			{ColumnID: uuid.New(), CardCount: 0},
+		}, nil)
+		mockAlertRepo.EXPECT().GetOpenByBoardID(gomock.Any(), boardID).Return(nil, nil)
+		mockAlertRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *column_alert.ColumnAlert) (bool, error) {
+			assert.Equal(t, columnID, a.ColumnID)
+			assert.Equal(t, column_alert.KindWIPExceeded, a.Kind)
+			assert.Equal(t, now, a.StartedAt)
+			return true, nil
+		})
+
+		n, err := svc.Check(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		assert.Empty(t, *raised)
+	})
+
+	t.Run("raises a WIP alert once the column has been over its limit long enough", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		bus := events.NewSyncBus()
+		raised := subscribe(bus)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		columnID := uuid.New()
+		open := &column_alert.ColumnAlert{
+			ID:        uuid.New(),
+			BoardID:   boardID,
+			ColumnID:  columnID,
+			Kind:      column_alert.KindWIPExceeded,
+			StartedAt: now.Add(-30 * time.Minute),
+		}
+		mockSettingRepo.EXPECT().GetEnabled(gomock.Any()).Return([]*column_alert_setting.ColumnAlertSetting{setting}, nil)
+		mockColumnRepo.EXPECT().GetStatsByBoardID(gomock.Any(), boardID, now).Return([]*board_column.ColumnStats{
+			{ColumnID: columnID, CardCount: 7, WipLimit: intPtr(5), AverageAgeSeconds: floatPtr(3600)},
+		}, nil)
+		mockAlertRepo.EXPECT().GetOpenByBoardID(gomock.Any(), boardID).Return([]*column_alert.ColumnAlert{open}, nil)
+		mockAlertRepo.EXPECT().Raise(gomock.Any(), open, now).Return(true, nil)
+
+		n, err := svc.Check(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []uuid.UUID{open.ID}, *raised)
+		assert.Equal(t, 7, *open.CardCount)
+		assert.Equal(t, 5, *open.WipLimit)
+	})
+
+	t.Run("raises aging alerts at once and resolves cleared alerts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		bus := events.NewSyncBus()
+		raised := subscribe(bus)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		agingID, clearedID := uuid.New(), uuid.New()
+		cleared := &column_alert.ColumnAlert{
+			ID:        uuid.New(),
+			BoardID:   boardID,
+			ColumnID:  clearedID,
+			Kind:      column_alert.KindWIPExceeded,
+			StartedAt: now.Add(-time.Hour),
+		}
+		mockSettingRepo.EXPECT().GetEnabled(gomock.Any()).Return([]*column_alert_setting.ColumnAlertSetting{setting}, nil)
+		mockColumnRepo.EXPECT().GetStatsByBoardID(gomock.Any(), boardID, now).Return([]*board_column.ColumnStats{
+			{ColumnID: agingID, CardCount: 2, AverageAgeSeconds: floatPtr(72 * 3600)},
+			{ColumnID: clearedID, CardCount: 5, WipLimit: intPtr(5), AverageAgeSeconds: floatPtr(3600)},
+		}, nil)
+		mockAlertRepo.EXPECT().GetOpenByBoardID(gomock.Any(), boardID).Return([]*column_alert.ColumnAlert{cleared}, nil)
+
+		var aging *column_alert.ColumnAlert
+		mockAlertRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *column_alert.ColumnAlert) (bool, error) {
+			a.ID = uuid.New()
+			aging = a
+			return true, nil
+		})
+		mockAlertRepo.EXPECT().Raise(gomock.Any(), gomock.Any(), now).Return(true, nil)
+		mockAlertRepo.EXPECT().Resolve(gomock.Any(), cleared.ID, now).Return(nil)
+
+		n, err := svc.Check(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		require.NotNil(t, aging)
+		assert.Equal(t, column_alert.KindAging, aging.Kind)
+		assert.Equal(t, agingID, aging.ColumnID)
+		assert.Equal(t, []uuid.UUID{aging.ID}, *raised)
+	})
+
+	t.Run("skips thresholds the board turned off", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		wipOnly := &column_alert_setting.ColumnAlertSetting{BoardID: boardID, Enabled: true, WIPExceededMinutes: intPtr(30)}
+		mockSettingRepo.EXPECT().GetEnabled(gomock.Any()).Return([]*column_alert_setting.ColumnAlertSetting{wipOnly}, nil)
+		mockColumnRepo.EXPECT().GetStatsByBoardID(gomock.Any(), boardID, now).Return([]*board_column.ColumnStats{
+			{ColumnID: uuid.New(), CardCount: 2, AverageAgeSeconds: floatPtr(1000 * 3600)},
+		}, nil)
+		mockAlertRepo.EXPECT().GetOpenByBoardID(gomock.Any(), boardID).Return(nil, nil)
+
+		n, err := svc.Check(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
+
+func TestUpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	boardID := uuid.New()
+
+	t.Run("validates thresholds and the Slack webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.UpdateSettings(ctx, boardID, SettingsInput{Enabled: true, WIPExceededMinutes: intPtr(0)})
+		assert.ErrorIs(t, err, ErrInvalidWIPExceededMinutes)
+		_, err = svc.UpdateSettings(ctx, boardID, SettingsInput{Enabled: true, AverageAgeHours: intPtr(MaxAverageAgeHours + 1)})
+		assert.ErrorIs(t, err, ErrInvalidAverageAgeHours)
+
+		webhook := "https://example.com/hook"
+		_, err = svc.UpdateSettings(ctx, boardID, SettingsInput{Enabled: true, SlackWebhookURL: &webhook})
+		assert.ErrorIs(t, err, ErrInvalidSlackWebhook)
+
+		channel := "#alerts"
+		_, err = svc.UpdateSettings(ctx, boardID, SettingsInput{Enabled: true, SlackChannel: &channel})
+		assert.ErrorIs(t, err, ErrSlackChannelWithoutWebhook)
+	})
+
+	t.Run("turning alerts off resolves the board's open alerts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+		mockAlertRepo := alertMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, mockAlertRepo, mockBoardRepo, mockColumnRepo, events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		webhook := " https://hooks.slack.com/services/T/B/X "
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+		mockSettingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		mockAlertRepo.EXPECT().ResolveByBoardID(gomock.Any(), boardID, now).Return(nil)
+
+		setting, err := svc.UpdateSettings(ctx, boardID, SettingsInput{WIPExceededMinutes: intPtr(30), SlackWebhookURL: &webhook})
+		require.NoError(t, err)
+		assert.False(t, setting.Enabled)
+		assert.Equal(t, "https://hooks.slack.com/services/T/B/X", *setting.SlackWebhookURL)
+	})
+}
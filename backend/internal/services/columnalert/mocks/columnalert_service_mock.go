@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: columnalert_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=columnalert_service.go -destination=mocks/columnalert_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_alert "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	column_alert_setting "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	columnalert "github.com/thatcatdev/kaimu/backend/internal/services/columnalert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockService) Check(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockServiceMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockService)(nil).Check), ctx)
+}
+
+// GetAlerts mocks base method.
+func (m *MockService) GetAlerts(ctx context.Context, boardID uuid.UUID, limit int) ([]*column_alert.ColumnAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx, boardID, limit)
+	ret0, _ := ret[0].([]*column_alert.ColumnAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockServiceMockRecorder) GetAlerts(ctx, boardID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockService)(nil).GetAlerts), ctx, boardID, limit)
+}
+
+// GetSettings mocks base method.
+func (m *MockService) GetSettings(ctx context.Context, boardID uuid.UUID) (*column_alert_setting.ColumnAlertSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, boardID)
+	ret0, _ := ret[0].(*column_alert_setting.ColumnAlertSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockServiceMockRecorder) GetSettings(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockService)(nil).GetSettings), ctx, boardID)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockService) UpdateSettings(ctx context.Context, boardID uuid.UUID, input columnalert.SettingsInput) (*column_alert_setting.ColumnAlertSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, boardID, input)
+	ret0, _ := ret[0].(*column_alert_setting.ColumnAlertSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockServiceMockRecorder) UpdateSettings(ctx, boardID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockService)(nil).UpdateSettings), ctx, boardID, input)
+}
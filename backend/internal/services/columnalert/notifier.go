@@ -0,0 +1,227 @@
+package columnalert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"gorm.io/gorm"
+)
+
+// AlertNotifier emails a board's admins, the members who can manage it, about each raised
+// column alert, and posts it to the board's Slack webhook when one is set
+type AlertNotifier struct {
+	alertRepo     column_alert.Repository
+	settingRepo   column_alert_setting.Repository
+	boardRepo     board.Repository
+	columnRepo    board_column.Repository
+	projectRepo   project.Repository
+	orgMemberRepo organization_member.Repository
+	userRepo      user.Repository
+	rbacSvc       rbac.Service
+	mailSvc       mail.MailService
+	localeSvc     locale.Service
+	slack         notification.SlackPoster
+	now           func() time.Time
+}
+
+func NewAlertNotifier(
+	alertRepo column_alert.Repository,
+	settingRepo column_alert_setting.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	projectRepo project.Repository,
+	orgMemberRepo organization_member.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+	slack notification.SlackPoster,
+) *AlertNotifier {
+	return &AlertNotifier{
+		alertRepo:     alertRepo,
+		settingRepo:   settingRepo,
+		boardRepo:     boardRepo,
+		columnRepo:    columnRepo,
+		projectRepo:   projectRepo,
+		orgMemberRepo: orgMemberRepo,
+		userRepo:      userRepo,
+		rbacSvc:       rbacSvc,
+		mailSvc:       mailSvc,
+		localeSvc:     localeSvc,
+		slack:         slack,
+		now:           time.Now,
+	}
+}
+
+// Subscribe registers the notification handler on the bus
+func (n *AlertNotifier) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.ColumnAlertRaised, n.handleRaised)
+}
+
+// handleRaised alerts the admins once; redelivered events find the alert already marked as
+// notified. Alerts resolved before they were sent are dropped.
+func (n *AlertNotifier) handleRaised(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.ColumnAlertPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	alert, err := n.alertRepo.GetByID(ctx, payload.AlertID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if alert.NotifiedAt != nil || alert.ResolvedAt != nil {
+		return nil
+	}
+
+	b, err := n.boardRepo.GetByID(ctx, alert.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	column, err := n.columnRepo.GetByID(ctx, alert.ColumnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	proj, err := n.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	setting, err := n.settingRepo.GetByBoardID(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+
+	admins, err := n.admins(ctx, b, proj)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		name := admin.Username
+		if admin.DisplayName != nil {
+			name = *admin.DisplayName
+		}
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, admin, proj.ID))
+		subject := i18n.Tc(ctx, "email.column_alert.subject", map[string]string{"board": b.Name, "column": column.Name})
+		err = n.mailSvc.SendMail(ctx, []string{*admin.Email}, subject, "column_alert.mjml", map[string]string{
+			"name":        name,
+			"board_name":  b.Name,
+			"column_name": column.Name,
+			"details":     describe(ctx, alert, setting, b, column),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send column alert email: %w", err)
+		}
+	}
+
+	if setting.SlackWebhookURL != nil {
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, nil, proj.ID))
+		if err := n.slack.Post(ctx, *setting.SlackWebhookURL, setting.SlackChannel, describe(ctx, alert, setting, b, column)); err != nil {
+			return err
+		}
+	}
+
+	_, err = n.alertRepo.MarkNotified(ctx, alert.ID, n.now())
+	return err
+}
+
+// admins returns the organization members who can manage the board and have an email address
+func (n *AlertNotifier) admins(ctx context.Context, b *board.Board, proj *project.Project) ([]*user.User, error) {
+	members, err := n.orgMemberRepo.GetByOrgID(ctx, proj.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var admins []*user.User
+	for _, m := range members {
+		canManage, err := n.rbacSvc.HasBoardPermission(ctx, m.UserID, b.ID, "board:manage")
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			continue
+		}
+		u, err := n.userRepo.GetByID(ctx, m.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if u.Email != nil {
+			admins = append(admins, u)
+		}
+	}
+	return admins, nil
+}
+
+// describe renders what the alert is about in the context's locale
+func describe(ctx context.Context, alert *column_alert.ColumnAlert, setting *column_alert_setting.ColumnAlertSetting, b *board.Board, column *board_column.BoardColumn) string {
+	values := map[string]string{"board": b.Name, "column": column.Name}
+	if alert.CardCount != nil {
+		values["count"] = strconv.Itoa(*alert.CardCount)
+	}
+
+	switch alert.Kind {
+	case column_alert.KindAging:
+		if alert.AverageAgeSeconds != nil {
+			values["age"] = formatDuration(ctx, time.Duration(*alert.AverageAgeSeconds)*time.Second)
+		}
+		values["threshold"] = formatDuration(ctx, setting.AverageAge())
+		return i18n.Tc(ctx, "notification.column_aging", values)
+	default:
+		if alert.WipLimit != nil {
+			values["limit"] = strconv.Itoa(*alert.WipLimit)
+		}
+		values["duration"] = formatDuration(ctx, setting.WIPExceededDuration())
+		return i18n.Tc(ctx, "notification.column_wip_exceeded", values)
+	}
+}
+
+// formatDuration renders a duration as whole days, hours or minutes, whichever is the
+// largest unit it spans, e.g. "3 days", in the context's locale
+func formatDuration(ctx context.Context, d time.Duration) string {
+	day := 24 * time.Hour
+	switch {
+	case d >= day:
+		return formatCount(ctx, "duration.day", "duration.days", int(d/day))
+	case d >= time.Hour:
+		return formatCount(ctx, "duration.hour", "duration.hours", int(d/time.Hour))
+	default:
+		return formatCount(ctx, "duration.minute", "duration.minutes", int(d/time.Minute))
+	}
+}
+
+func formatCount(ctx context.Context, one, many string, count int) string {
+	if count == 1 {
+		return i18n.Tc(ctx, one, nil)
+	}
+	return i18n.Tc(ctx, many, map[string]string{"count": strconv.Itoa(count)})
+}
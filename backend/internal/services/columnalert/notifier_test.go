@@ -0,0 +1,148 @@
+package columnalert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert"
+	alertMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting"
+	settingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_alert_setting/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+)
+
+type slackPost struct {
+	webhookURL string
+	channel    string
+	text       string
+}
+
+type mockSlackPoster struct {
+	posts []slackPost
+}
+
+func (m *mockSlackPoster) Post(ctx context.Context, webhookURL, channel, text string) error {
+	m.posts = append(m.posts, slackPost{webhookURL: webhookURL, channel: channel, text: text})
+	return nil
+}
+
+func TestAlertNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alertRepo := alertMocks.NewMockRepository(ctrl)
+	settingRepo := settingMocks.NewMockRepository(ctrl)
+	boardRepo := boardMocks.NewMockRepository(ctrl)
+	columnRepo := columnMocks.NewMockRepository(ctrl)
+	projectRepo := projectMocks.NewMockRepository(ctrl)
+	orgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	rbacSvc := rbacMocks.NewMockService(ctrl)
+	localeSvc := localeMocks.NewMockService(ctrl)
+	mailSvc := fakes.NewMailService()
+	slack := &mockSlackPoster{}
+
+	bus := events.NewSyncBus()
+	NewAlertNotifier(alertRepo, settingRepo, boardRepo, columnRepo, projectRepo, orgMemberRepo, userRepo, rbacSvc, mailSvc, localeSvc, slack).Subscribe(bus)
+	ctx := context.Background()
+
+	proj := &project.Project{ID: uuid.New(), OrganizationID: uuid.New()}
+	b := &board.Board{ID: uuid.New(), ProjectID: proj.ID, Name: "Platform"}
+	column := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Review"}
+	email := "lead@example.com"
+	admin := &user.User{ID: uuid.New(), Username: "lead", Email: &email}
+	member := &user.User{ID: uuid.New(), Username: "member"}
+	webhook := "https://hooks.slack.com/services/T/B/X"
+	setting := &column_alert_setting.ColumnAlertSetting{
+		BoardID:            b.ID,
+		Enabled:            true,
+		WIPExceededMinutes: intPtr(90),
+		SlackWebhookURL:    &webhook,
+		SlackChannel:       "#delivery",
+	}
+	now := time.Date(2026, 4, 7, 10, 0, 0, 0, time.UTC)
+	alert := &column_alert.ColumnAlert{
+		ID:        uuid.New(),
+		BoardID:   b.ID,
+		ColumnID:  column.ID,
+		Kind:      column_alert.KindWIPExceeded,
+		StartedAt: now.Add(-2 * time.Hour),
+		RaisedAt:  &now,
+		CardCount: intPtr(8),
+		WipLimit:  intPtr(5),
+	}
+
+	publish := func() error {
+		return bus.Publish(ctx, events.New(ctx, events.ColumnAlertRaised, events.ColumnAlertPayload{
+			AlertID:  alert.ID,
+			BoardID:  b.ID,
+			ColumnID: column.ID,
+		}))
+	}
+
+	t.Run("emails the board's admins and posts to Slack", func(t *testing.T) {
+		alertRepo.EXPECT().GetByID(gomock.Any(), alert.ID).Return(alert, nil)
+		boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		columnRepo.EXPECT().GetByID(gomock.Any(), column.ID).Return(column, nil)
+		projectRepo.EXPECT().GetByID(gomock.Any(), proj.ID).Return(proj, nil)
+		settingRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return(setting, nil)
+		orgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), proj.OrganizationID).Return([]*organization_member.OrganizationMember{
+			{OrganizationID: proj.OrganizationID, UserID: admin.ID},
+			{OrganizationID: proj.OrganizationID, UserID: member.ID},
+		}, nil)
+		rbacSvc.EXPECT().HasBoardPermission(gomock.Any(), admin.ID, b.ID, "board:manage").Return(true, nil)
+		rbacSvc.EXPECT().HasBoardPermission(gomock.Any(), member.ID, b.ID, "board:manage").Return(false, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), admin.ID).Return(admin, nil)
+		localeSvc.EXPECT().ForProject(gomock.Any(), admin, proj.ID).Return("en")
+		localeSvc.EXPECT().ForProject(gomock.Any(), nil, proj.ID).Return("en")
+		alertRepo.EXPECT().MarkNotified(gomock.Any(), alert.ID, gomock.Any()).Return(true, nil)
+
+		require.NoError(t, publish())
+
+		details := "Review on Platform has held 8 cards, over its WIP limit of 5, for more than 1 hour"
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, mailSvc.Sent[0].To)
+		assert.Equal(t, "column_alert.mjml", mailSvc.Sent[0].Template)
+		assert.Equal(t, "Platform: Review needs attention", mailSvc.Sent[0].Subject)
+		assert.Equal(t, details, mailSvc.Sent[0].Values["details"])
+		require.Len(t, slack.posts, 1)
+		assert.Equal(t, slackPost{webhookURL: webhook, channel: "#delivery", text: details}, slack.posts[0])
+	})
+
+	t.Run("redelivery does not alert again", func(t *testing.T) {
+		notified := *alert
+		notified.NotifiedAt = &now
+		alertRepo.EXPECT().GetByID(gomock.Any(), alert.ID).Return(&notified, nil)
+
+		require.NoError(t, publish())
+		assert.Len(t, mailSvc.Sent, 1)
+		assert.Len(t, slack.posts, 1)
+	})
+}
+
+func TestDescribeAging(t *testing.T) {
+	ctx := context.Background()
+	setting := &column_alert_setting.ColumnAlertSetting{AverageAgeHours: intPtr(48)}
+	alert := &column_alert.ColumnAlert{Kind: column_alert.KindAging, CardCount: intPtr(3), AverageAgeSeconds: floatPtr(80 * 3600)}
+
+	text := describe(ctx, alert, setting, &board.Board{Name: "Platform"}, &board_column.BoardColumn{Name: "Review"})
+	assert.Equal(t, "Cards in Review on Platform are 3 days old on average, over the board's limit of 2 days", text)
+}
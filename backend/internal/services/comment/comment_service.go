@@ -0,0 +1,412 @@
+package comment
+
+//go:generate mockgen -source=comment_service.go -destination=mocks/comment_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrCardNotFound    = errors.New("card not found")
+	ErrBodyRequired    = errors.New("comment body is required")
+	ErrNotAuthor       = errors.New("only the author can change a comment")
+)
+
+// MaxMentions caps how many mentions a page of the mention inbox returns
+const MaxMentions = 100
+
+// mentionPattern matches an @username that doesn't continue a word or an email address
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([\w.-]+)`)
+
+type Service interface {
+	// CreateComment adds a comment to the card and notifies the organization members it
+	// @mentions who can view the card
+	CreateComment(ctx context.Context, cardID, authorID uuid.UUID, body string) (*comment.Comment, error)
+	// UpdateComment replaces the body of the user's own comment; only members mentioned for
+	// the first time are notified
+	UpdateComment(ctx context.Context, id, userID uuid.UUID, body string) (*comment.Comment, error)
+	// DeleteComment deletes the user's own comment and its mentions
+	DeleteComment(ctx context.Context, id, userID uuid.UUID) (*comment.Comment, error)
+	GetComment(ctx context.Context, id uuid.UUID) (*comment.Comment, error)
+	// GetComments returns the card's comments, oldest first
+	GetComments(ctx context.Context, cardID uuid.UUID) ([]*comment.Comment, error)
+	// GetMentionedUserIDs returns the users notified about the comment
+	GetMentionedUserIDs(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error)
+	// GetBoard returns the board of the comment's card
+	GetBoard(ctx context.Context, commentID uuid.UUID) (*board.Board, error)
+
+	// GetMentions returns the user's mention notifications, newest first
+	GetMentions(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*comment.Mention, error)
+	// MarkMentionsRead marks the user's mentions among ids read, or all of them when ids is
+	// empty, and returns how many were unread
+	MarkMentionsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+}
+
+type service struct {
+	commentRepo   comment.Repository
+	cardRepo      card.Repository
+	boardRepo     board.Repository
+	projectRepo   project.Repository
+	userRepo      user.Repository
+	orgMemberRepo organization_member.Repository
+	rbacSvc       rbac.Service
+	contentSvc    content.Service
+	txManager     transaction.Manager
+	bus           events.Bus
+	now           func() time.Time
+}
+
+func NewService(
+	commentRepo comment.Repository,
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	userRepo user.Repository,
+	orgMemberRepo organization_member.Repository,
+	rbacSvc rbac.Service,
+	contentSvc content.Service,
+	txManager transaction.Manager,
+	bus events.Bus,
+) Service {
+	return &service{
+		commentRepo:   commentRepo,
+		cardRepo:      cardRepo,
+		boardRepo:     boardRepo,
+		projectRepo:   projectRepo,
+		userRepo:      userRepo,
+		orgMemberRepo: orgMemberRepo,
+		rbacSvc:       rbacSvc,
+		contentSvc:    contentSvc,
+		txManager:     txManager,
+		bus:           bus,
+		now:           time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "comment.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "comment"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateComment(ctx context.Context, cardID, authorID uuid.UUID, body string) (*comment.Comment, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateComment")
+	span.SetAttributes(
+		attribute.String("card.id", cardID.String()),
+		attribute.String("user.id", authorID.String()),
+	)
+	defer span.End()
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	body, err = s.checkBody(ctx, c.BoardID, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contentSvc.CheckFlood(ctx, authorID, c.BoardID, content.FieldComment, body); err != nil {
+		return nil, err
+	}
+
+	cm := &comment.Comment{CardID: cardID, AuthorID: &authorID, Body: body}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Create(ctx, cm); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, cm, c.BoardID, authorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+func (s *service) UpdateComment(ctx context.Context, id, userID uuid.UUID, body string) (*comment.Comment, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateComment")
+	span.SetAttributes(attribute.String("comment.id", id.String()))
+	defer span.End()
+
+	cm, err := s.ownComment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.getBoard(ctx, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err = s.checkBody(ctx, b.ID, body)
+	if err != nil {
+		return nil, err
+	}
+	if body == cm.Body {
+		return cm, nil
+	}
+
+	now := s.now()
+	cm.Body = body
+	cm.EditedAt = &now
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.commentRepo.Update(ctx, cm); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, cm, b.ID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+func (s *service) DeleteComment(ctx context.Context, id, userID uuid.UUID) (*comment.Comment, error) {
+	ctx, span := s.startServiceSpan(ctx, "DeleteComment")
+	span.SetAttributes(attribute.String("comment.id", id.String()))
+	defer span.End()
+
+	cm, err := s.ownComment(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.commentRepo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+func (s *service) GetComment(ctx context.Context, id uuid.UUID) (*comment.Comment, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetComment")
+	span.SetAttributes(attribute.String("comment.id", id.String()))
+	defer span.End()
+
+	cm, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return cm, nil
+}
+
+func (s *service) GetComments(ctx context.Context, cardID uuid.UUID) ([]*comment.Comment, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetComments")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	return s.commentRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *service) GetMentionedUserIDs(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMentionedUserIDs")
+	span.SetAttributes(attribute.String("comment.id", commentID.String()))
+	defer span.End()
+
+	return s.commentRepo.GetMentionedUserIDs(ctx, commentID)
+}
+
+func (s *service) GetBoard(ctx context.Context, commentID uuid.UUID) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBoard")
+	span.SetAttributes(attribute.String("comment.id", commentID.String()))
+	defer span.End()
+
+	cm, err := s.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.getBoard(ctx, cm)
+}
+
+func (s *service) GetMentions(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*comment.Mention, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMentions")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	if limit <= 0 || limit > MaxMentions {
+		limit = MaxMentions
+	}
+	return s.commentRepo.GetMentionsByUserID(ctx, userID, unreadOnly, limit)
+}
+
+func (s *service) MarkMentionsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "MarkMentionsRead")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	marked, err := s.commentRepo.MarkMentionsRead(ctx, userID, ids, s.now())
+	if err != nil {
+		return 0, err
+	}
+	return int(marked), nil
+}
+
+// checkBody sanitizes the body and enforces the board's content rules on it
+func (s *service) checkBody(ctx context.Context, boardID uuid.UUID, body string) (string, error) {
+	body = sanitize.HTML(strings.TrimSpace(body))
+	if strings.TrimSpace(sanitize.PlainText(body)) == "" {
+		return "", ErrBodyRequired
+	}
+	if err := s.contentSvc.Check(ctx, boardID, content.FieldComment, body); err != nil {
+		return "", err
+	}
+	return body, nil
+}
+
+// ownComment returns the comment when userID wrote it
+func (s *service) ownComment(ctx context.Context, id, userID uuid.UUID) (*comment.Comment, error) {
+	cm, err := s.GetComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cm.AuthorID == nil || *cm.AuthorID != userID {
+		return nil, ErrNotAuthor
+	}
+	return cm, nil
+}
+
+func (s *service) getBoard(ctx context.Context, cm *comment.Comment) (*board.Board, error) {
+	c, err := s.cardRepo.GetByID(ctx, cm.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	return s.boardRepo.GetByID(ctx, c.BoardID)
+}
+
+// notifyMentions records a mention for every member of the card's organization the comment
+// @mentions, other than its author, who can view the card
+func (s *service) notifyMentions(ctx context.Context, cm *comment.Comment, boardID, actorID uuid.UUID) error {
+	usernames := ParseMentions(sanitize.PlainText(cm.Body))
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	p, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	var mentions []*comment.Mention
+	for _, username := range usernames {
+		u, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		if u.ID == actorID {
+			continue
+		}
+
+		if _, err := s.orgMemberRepo.GetByOrgAndUser(ctx, p.OrganizationID, u.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		canView, err := s.rbacSvc.HasBoardPermission(ctx, u.ID, boardID, "card:view")
+		if err != nil {
+			return err
+		}
+		if !canView {
+			continue
+		}
+
+		mentions = append(mentions, &comment.Mention{
+			CommentID: cm.ID,
+			CardID:    cm.CardID,
+			UserID:    u.ID,
+			ActorID:   &actorID,
+		})
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	// Members the comment already mentioned were notified when it was first saved
+	already, err := s.commentRepo.GetMentionedUserIDs(ctx, cm.ID)
+	if err != nil {
+		return err
+	}
+	notified := make(map[uuid.UUID]bool, len(already))
+	for _, userID := range already {
+		notified[userID] = true
+	}
+	var fresh []*comment.Mention
+	var userIDs []uuid.UUID
+	for _, m := range mentions {
+		if !notified[m.UserID] {
+			fresh = append(fresh, m)
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	if err := s.commentRepo.CreateMentions(ctx, fresh); err != nil {
+		return err
+	}
+	return s.bus.Publish(ctx, events.New(ctx, events.CommentMentioned, events.CommentMentionedPayload{
+		CommentID: cm.ID,
+		CardID:    cm.CardID,
+		BoardID:   boardID,
+		UserIDs:   userIDs,
+	}))
+}
+
+// ParseMentions returns the usernames @mentioned in text, each once, in order of
+// appearance. A trailing period belongs to the sentence, not the username.
+func ParseMentions(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
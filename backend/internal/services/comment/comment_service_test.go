@@ -0,0 +1,410 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	commentMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/content"
+	contentMocks "github.com/thatcatdev/kaimu/backend/internal/services/content/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"none", "no mentions here", nil},
+		{"start and middle", "@alice can you pair with @bob.smith?", []string{"alice", "bob.smith"}},
+		{"trailing period", "Thanks @alice.", []string{"alice"}},
+		{"each once", "@alice @bob @alice", []string{"alice", "bob"}},
+		{"not an email address", "mail alice@example.com", nil},
+		{"not mid-word", "foo@bar and (@carol)", []string{"carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	orgID := uuid.New()
+	p := &project.Project{ID: uuid.New(), OrganizationID: orgID}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID}
+	c := &card.Card{ID: uuid.New(), BoardID: b.ID}
+	authorID := uuid.New()
+	alice := &user.User{ID: uuid.New(), Username: "alice"}
+	bob := &user.User{ID: uuid.New(), Username: "bob"}
+	outsider := &user.User{ID: uuid.New(), Username: "outsider"}
+	author := &user.User{ID: authorID, Username: "author"}
+
+	t.Run("records mentions of members who can view the card", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		body := "<p>@alice @bob @outsider @nobody @author please review</p>"
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockContentSvc.EXPECT().Check(gomock.Any(), b.ID, content.FieldComment, body).Return(nil)
+		mockContentSvc.EXPECT().CheckFlood(gomock.Any(), authorID, b.ID, content.FieldComment, body).Return(nil)
+		mockCommentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, cm *comment.Comment) error {
+			cm.ID = uuid.New()
+			return nil
+		})
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+
+		mockUserRepo.EXPECT().GetByUsername(gomock.Any(), "alice").Return(alice, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, alice.ID).Return(&organization_member.OrganizationMember{}, nil)
+		mockRbacSvc.EXPECT().HasBoardPermission(gomock.Any(), alice.ID, b.ID, "card:view").Return(true, nil)
+
+		// Bob is a member without access to the board
+		mockUserRepo.EXPECT().GetByUsername(gomock.Any(), "bob").Return(bob, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, bob.ID).Return(&organization_member.OrganizationMember{}, nil)
+		mockRbacSvc.EXPECT().HasBoardPermission(gomock.Any(), bob.ID, b.ID, "card:view").Return(false, nil)
+
+		mockUserRepo.EXPECT().GetByUsername(gomock.Any(), "outsider").Return(outsider, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, outsider.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		mockUserRepo.EXPECT().GetByUsername(gomock.Any(), "nobody").Return(nil, gorm.ErrRecordNotFound)
+		mockUserRepo.EXPECT().GetByUsername(gomock.Any(), "author").Return(author, nil)
+
+		mockCommentRepo.EXPECT().GetMentionedUserIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+		var recorded []*comment.Mention
+		mockCommentRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mentions []*comment.Mention) error {
+			recorded = mentions
+			return nil
+		})
+
+		cm, err := svc.CreateComment(ctx, c.ID, authorID, "  "+body+"  ")
+		require.NoError(t, err)
+		assert.Equal(t, body, cm.Body)
+		assert.Equal(t, &authorID, cm.AuthorID)
+
+		require.Len(t, recorded, 1)
+		assert.Equal(t, alice.ID, recorded[0].UserID)
+		assert.Equal(t, cm.ID, recorded[0].CommentID)
+		assert.Equal(t, c.ID, recorded[0].CardID)
+		assert.Equal(t, &authorID, recorded[0].ActorID)
+
+		require.Len(t, *mentioned, 1)
+		assert.Equal(t, events.CommentMentionedPayload{
+			CommentID: cm.ID,
+			CardID:    c.ID,
+			BoardID:   b.ID,
+			UserIDs:   []uuid.UUID{alice.ID},
+		}, (*mentioned)[0].Payload)
+	})
+
+	t.Run("requires a body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+
+		_, err := svc.CreateComment(ctx, c.ID, authorID, "<p> </p>")
+		assert.ErrorIs(t, err, ErrBodyRequired)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateComment(ctx, c.ID, authorID, "Looks good")
+		assert.ErrorIs(t, err, ErrCardNotFound)
+	})
+}
+
+func TestUpdateComment(t *testing.T) {
+	now := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	c := &card.Card{ID: uuid.New(), BoardID: b.ID}
+	authorID := uuid.New()
+
+	t.Run("edits the author's comment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		cm := &comment.Comment{ID: uuid.New(), CardID: c.ID, AuthorID: &authorID, Body: "Looks good"}
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(cm, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockContentSvc.EXPECT().Check(gomock.Any(), b.ID, content.FieldComment, "Looks great").Return(nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), cm).Return(nil)
+
+		updated, err := svc.UpdateComment(ctx, cm.ID, authorID, "Looks great")
+		require.NoError(t, err)
+		assert.Equal(t, "Looks great", updated.Body)
+		assert.Equal(t, &now, updated.EditedAt)
+	})
+
+	t.Run("notifies only newly mentioned members", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		p := &project.Project{ID: b.ProjectID, OrganizationID: uuid.New()}
+		alice := &user.User{ID: uuid.New(), Username: "alice"}
+		bob := &user.User{ID: uuid.New(), Username: "bob"}
+		cm := &comment.Comment{ID: uuid.New(), CardID: c.ID, AuthorID: &authorID, Body: "@alice look"}
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(cm, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil).Times(2)
+		mockContentSvc.EXPECT().Check(gomock.Any(), b.ID, content.FieldComment, "@alice @bob look").Return(nil)
+		mockCommentRepo.EXPECT().Update(gomock.Any(), cm).Return(nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		for _, u := range []*user.User{alice, bob} {
+			mockUserRepo.EXPECT().GetByUsername(gomock.Any(), u.Username).Return(u, nil)
+			mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), p.OrganizationID, u.ID).Return(&organization_member.OrganizationMember{}, nil)
+			mockRbacSvc.EXPECT().HasBoardPermission(gomock.Any(), u.ID, b.ID, "card:view").Return(true, nil)
+		}
+		mockCommentRepo.EXPECT().GetMentionedUserIDs(gomock.Any(), cm.ID).Return([]uuid.UUID{alice.ID}, nil)
+		mockCommentRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mentions []*comment.Mention) error {
+			require.Len(t, mentions, 1)
+			assert.Equal(t, bob.ID, mentions[0].UserID)
+			return nil
+		})
+
+		_, err := svc.UpdateComment(ctx, cm.ID, authorID, "@alice @bob look")
+		require.NoError(t, err)
+		require.Len(t, *mentioned, 1)
+		assert.Equal(t, []uuid.UUID{bob.ID}, (*mentioned)[0].Payload.(events.CommentMentionedPayload).UserIDs)
+	})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		cm := &comment.Comment{ID: uuid.New(), CardID: c.ID, AuthorID: &authorID, Body: "Looks good"}
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(cm, nil)
+
+		_, err := svc.UpdateComment(ctx, cm.ID, uuid.New(), "Looks bad")
+		assert.ErrorIs(t, err, ErrNotAuthor)
+	})
+}
+
+func TestDeleteComment(t *testing.T) {
+	ctx := context.Background()
+	authorID := uuid.New()
+	cm := &comment.Comment{ID: uuid.New(), CardID: uuid.New(), AuthorID: &authorID, Body: "Looks good"}
+
+	t.Run("deletes the author's comment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(cm, nil)
+		mockCommentRepo.EXPECT().Delete(gomock.Any(), cm.ID).Return(nil)
+
+		deleted, err := svc.DeleteComment(ctx, cm.ID, authorID)
+		require.NoError(t, err)
+		assert.Equal(t, cm, deleted)
+	})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(cm, nil)
+
+		_, err := svc.DeleteComment(ctx, cm.ID, uuid.New())
+		assert.ErrorIs(t, err, ErrNotAuthor)
+	})
+
+	t.Run("comment not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockRbacSvc := rbacMocks.NewMockService(ctrl)
+		mockContentSvc := contentMocks.NewMockService(ctrl)
+
+		mentioned := &[]events.Event{}
+		bus := events.NewSyncBus()
+		bus.Subscribe(events.CommentMentioned, func(_ context.Context, e events.Event) error {
+			*mentioned = append(*mentioned, e)
+			return nil
+		})
+		svc := NewService(mockCommentRepo, mockCardRepo, mockBoardRepo, mockProjectRepo, mockUserRepo, mockOrgMemberRepo, mockRbacSvc, mockContentSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockCommentRepo.EXPECT().GetByID(gomock.Any(), cm.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.DeleteComment(ctx, cm.ID, authorID)
+		assert.ErrorIs(t, err, ErrCommentNotFound)
+	})
+}
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=comment_service.go -destination=mocks/comment_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	comment "github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockService) CreateComment(ctx context.Context, cardID, authorID uuid.UUID, body string) (*comment.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, cardID, authorID, body)
+	ret0, _ := ret[0].(*comment.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockServiceMockRecorder) CreateComment(ctx, cardID, authorID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), ctx, cardID, authorID, body)
+}
+
+// DeleteComment mocks base method.
+func (m *MockService) DeleteComment(ctx context.Context, id, userID uuid.UUID) (*comment.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, id, userID)
+	ret0, _ := ret[0].(*comment.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockServiceMockRecorder) DeleteComment(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), ctx, id, userID)
+}
+
+// GetBoard mocks base method.
+func (m *MockService) GetBoard(ctx context.Context, commentID uuid.UUID) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, commentID)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockServiceMockRecorder) GetBoard(ctx, commentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockService)(nil).GetBoard), ctx, commentID)
+}
+
+// GetComment mocks base method.
+func (m *MockService) GetComment(ctx context.Context, id uuid.UUID) (*comment.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ctx, id)
+	ret0, _ := ret[0].(*comment.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockServiceMockRecorder) GetComment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockService)(nil).GetComment), ctx, id)
+}
+
+// GetComments mocks base method.
+func (m *MockService) GetComments(ctx context.Context, cardID uuid.UUID) ([]*comment.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, cardID)
+	ret0, _ := ret[0].([]*comment.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockServiceMockRecorder) GetComments(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockService)(nil).GetComments), ctx, cardID)
+}
+
+// GetMentionedUserIDs mocks base method.
+func (m *MockService) GetMentionedUserIDs(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentionedUserIDs", ctx, commentID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentionedUserIDs indicates an expected call of GetMentionedUserIDs.
+func (mr *MockServiceMockRecorder) GetMentionedUserIDs(ctx, commentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentionedUserIDs", reflect.TypeOf((*MockService)(nil).GetMentionedUserIDs), ctx, commentID)
+}
+
+// GetMentions mocks base method.
+func (m *MockService) GetMentions(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*comment.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", ctx, userID, unreadOnly, limit)
+	ret0, _ := ret[0].([]*comment.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockServiceMockRecorder) GetMentions(ctx, userID, unreadOnly, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockService)(nil).GetMentions), ctx, userID, unreadOnly, limit)
+}
+
+// MarkMentionsRead mocks base method.
+func (m *MockService) MarkMentionsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMentionsRead", ctx, userID, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMentionsRead indicates an expected call of MarkMentionsRead.
+func (mr *MockServiceMockRecorder) MarkMentionsRead(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionsRead", reflect.TypeOf((*MockService)(nil).MarkMentionsRead), ctx, userID, ids)
+}
+
+// UpdateComment mocks base method.
+func (m *MockService) UpdateComment(ctx context.Context, id, userID uuid.UUID, body string) (*comment.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, id, userID, body)
+	ret0, _ := ret[0].(*comment.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockServiceMockRecorder) UpdateComment(ctx, id, userID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), ctx, id, userID, body)
+}
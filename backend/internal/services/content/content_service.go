@@ -0,0 +1,253 @@
+package content
+
+//go:generate mockgen -source=content_service.go -destination=mocks/content_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBoardNotFound        = errors.New("board not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+)
+
+// Field is a piece of user-written text that limits and moderation apply to
+type Field string
+
+const (
+	FieldCardTitle       Field = "card.title"
+	FieldCardDescription Field = "card.description"
+	FieldComment         Field = "comment.body"
+	// FieldCard is a whole card, as flood control sees it
+	FieldCard Field = "card"
+)
+
+// html reports whether the field holds editor HTML rather than plain text
+func (f Field) html() bool {
+	return f == FieldCardDescription || f == FieldComment
+}
+
+// Limits are the maximum lengths of user-written text, in characters, and how often it may be
+// repeated
+type Limits struct {
+	Title       int
+	Description int
+	Comment     int
+	// IdenticalPerMinute is how many identical cards or comments a user may submit to a board
+	// per FloodWindow; 0 turns flood control off
+	IdenticalPerMinute int
+}
+
+// LimitsFromConfig reads the limits from the content config
+func LimitsFromConfig(cfg config.ContentConfig) Limits {
+	return Limits{
+		Title:              cfg.MaxTitleLength,
+		Description:        cfg.MaxDescriptionLength,
+		Comment:            cfg.MaxCommentLength,
+		IdenticalPerMinute: cfg.MaxIdenticalPerMinute,
+	}
+}
+
+// max returns the limit for field; 0 means unlimited
+func (l Limits) max(field Field) int {
+	switch field {
+	case FieldCardTitle:
+		return l.Title
+	case FieldCardDescription:
+		return l.Description
+	case FieldComment:
+		return l.Comment
+	}
+	return 0
+}
+
+// LimitError is returned when text is longer than its field allows
+type LimitError struct {
+	Field  Field
+	Length int
+	Max    int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s is %d characters long, the limit is %d", e.Field, e.Length, e.Max)
+}
+
+// PolicyError is returned when a moderation scanner rejects text
+type PolicyError struct {
+	Field   Field
+	Scanner string
+	Reason  string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s was rejected by the content policy: %s", e.Field, e.Reason)
+}
+
+type Service interface {
+	// Limits returns the configured length limits
+	Limits() Limits
+	// Check enforces the field's length limit on text and, when the board's organization
+	// enabled content moderation, runs the moderation scanners on it. Violations are
+	// returned as *LimitError or *PolicyError.
+	Check(ctx context.Context, boardID uuid.UUID, field Field, text string) error
+	// CheckFlood records a user's submission of a card or comment to a board and returns a
+	// *FloodError when they already submitted the identical payload the limit's number of
+	// times in the last FloodWindow, protecting shared boards from scripts and retry loops.
+	// Rejected submissions aren't counted.
+	CheckFlood(ctx context.Context, userID, boardID uuid.UUID, field Field, payload string) error
+	// SetModerationEnabled turns content moderation on or off for an organization
+	SetModerationEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error)
+}
+
+type service struct {
+	boardRepo   board.Repository
+	projectRepo project.Repository
+	orgRepo     organization.Repository
+	limits      Limits
+	scanners    []Scanner
+	now         func() time.Time
+
+	floodMu     sync.Mutex
+	submissions map[string][]time.Time
+	lastSweep   time.Time
+}
+
+func NewService(
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	orgRepo organization.Repository,
+	limits Limits,
+	scanners ...Scanner,
+) Service {
+	return &service{
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		orgRepo:     orgRepo,
+		limits:      limits,
+		scanners:    scanners,
+		now:         time.Now,
+		submissions: make(map[string][]time.Time),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "content.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "content"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Limits() Limits {
+	return s.limits
+}
+
+func (s *service) Check(ctx context.Context, boardID uuid.UUID, field Field, text string) error {
+	ctx, span := s.startServiceSpan(ctx, "Check")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("content.field", string(field)),
+	)
+	defer span.End()
+
+	if max := s.limits.max(field); max > 0 {
+		if length := utf8.RuneCountInString(text); length > max {
+			return &LimitError{Field: field, Length: length, Max: max}
+		}
+	}
+
+	if len(s.scanners) == 0 || text == "" {
+		return nil
+	}
+	org, err := s.boardOrganization(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if !org.ContentModerationEnabled {
+		return nil
+	}
+
+	// Scanners judge what readers see, not the markup
+	if field.html() {
+		text = sanitize.PlainText(text)
+	}
+	for _, scanner := range s.scanners {
+		finding, err := scanner.Scan(ctx, text)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("content scanner %s: %w", scanner.Name(), err)
+		}
+		if finding != nil {
+			span.SetAttributes(attribute.String("content.rejected_by", scanner.Name()))
+			return &PolicyError{Field: field, Scanner: scanner.Name(), Reason: finding.Reason}
+		}
+	}
+	return nil
+}
+
+func (s *service) SetModerationEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetModerationEnabled")
+	span.SetAttributes(
+		attribute.String("organization.id", orgID.String()),
+		attribute.Bool("content.moderation_enabled", enabled),
+	)
+	defer span.End()
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	org.ContentModerationEnabled = enabled
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// boardOrganization returns the organization that owns the board
+func (s *service) boardOrganization(ctx context.Context, boardID uuid.UUID) (*organization.Organization, error) {
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	p, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, p.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return org, nil
+}
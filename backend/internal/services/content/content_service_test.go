@@ -0,0 +1,204 @@
+package content
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockBoardRepo, mockProjectRepo, mockOrgRepo, Limits{Title: 10, Description: 40}, NewWordListScanner([]string{"darn"}))
+
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	projectID := uuid.New()
+	orgID := uuid.New()
+	expectOrg := func(moderated bool) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, ContentModerationEnabled: moderated}, nil)
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		expectOrg(true)
+
+		assert.NoError(t, svc.Check(ctx, boardID, FieldCardTitle, "Fix login"))
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		err := svc.Check(ctx, boardID, FieldCardTitle, strings.Repeat("é", 11))
+
+		var limitErr *LimitError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, FieldCardTitle, limitErr.Field)
+		assert.Equal(t, 11, limitErr.Length)
+		assert.Equal(t, 10, limitErr.Max)
+	})
+
+	t.Run("zero limit is unlimited", func(t *testing.T) {
+		expectOrg(false)
+
+		assert.NoError(t, svc.Check(ctx, boardID, FieldComment, strings.Repeat("a", 1000)))
+	})
+
+	t.Run("scanners skipped without moderation", func(t *testing.T) {
+		expectOrg(false)
+
+		assert.NoError(t, svc.Check(ctx, boardID, FieldCardTitle, "darn it"))
+	})
+
+	t.Run("scanners reject with moderation", func(t *testing.T) {
+		expectOrg(true)
+
+		err := svc.Check(ctx, boardID, FieldCardTitle, "Darn it")
+
+		var policyErr *PolicyError
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, FieldCardTitle, policyErr.Field)
+		assert.Equal(t, "word_list", policyErr.Scanner)
+	})
+
+	t.Run("descriptions are scanned as text", func(t *testing.T) {
+		expectOrg(true)
+		assert.Error(t, svc.Check(ctx, boardID, FieldCardDescription, "<p>oh</p><p>darn</p>"))
+
+		expectOrg(true)
+		assert.NoError(t, svc.Check(ctx, boardID, FieldCardDescription, `<p class="darn">fine</p>`))
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, svc.Check(ctx, boardID, FieldCardTitle, "x"), ErrBoardNotFound)
+	})
+}
+
+func TestCheck_NoScanners(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Without scanners the board's organization is never looked up
+	svc := NewService(boardMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), orgMocks.NewMockRepository(ctrl), Limits{Title: 5})
+
+	assert.NoError(t, svc.Check(context.Background(), uuid.New(), FieldCardTitle, "short"))
+}
+
+func TestSetModerationEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockBoardRepo, mockProjectRepo, mockOrgRepo, Limits{})
+
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID}, nil)
+		mockOrgRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, org *organization.Organization) error {
+			assert.True(t, org.ContentModerationEnabled)
+			return nil
+		})
+
+		org, err := svc.SetModerationEnabled(ctx, orgID, true)
+		require.NoError(t, err)
+		assert.True(t, org.ContentModerationEnabled)
+	})
+
+	t.Run("organization not found", func(t *testing.T) {
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetModerationEnabled(ctx, orgID, true)
+		assert.ErrorIs(t, err, ErrOrganizationNotFound)
+	})
+}
+
+func TestCheckFlood(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	boardID := uuid.New()
+
+	newFloodService := func(ctrl *gomock.Controller, limits Limits) (*service, *time.Time) {
+		svc := NewService(boardMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), orgMocks.NewMockRepository(ctrl), limits)
+
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		s := svc.(*service)
+		s.now = func() time.Time { return now }
+		return s, &now
+	}
+
+	t.Run("rejects identical payloads over the limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, _ := newFloodService(ctrl, Limits{IdenticalPerMinute: 2})
+
+		require.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+		require.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+
+		err := svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login")
+		var floodErr *FloodError
+		require.ErrorAs(t, err, &floodErr)
+		assert.Equal(t, &FloodError{Field: FieldCard, Max: 2, RetryAfter: FloodWindow}, floodErr)
+	})
+
+	t.Run("counts payloads, users, boards and fields separately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, _ := newFloodService(ctrl, Limits{IdenticalPerMinute: 1})
+
+		require.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+		assert.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix logout"))
+		assert.NoError(t, svc.CheckFlood(ctx, uuid.New(), boardID, FieldCard, "Fix login"))
+		assert.NoError(t, svc.CheckFlood(ctx, userID, uuid.New(), FieldCard, "Fix login"))
+		assert.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldComment, "Fix login"))
+	})
+
+	t.Run("allows the payload again once the window passes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, now := newFloodService(ctrl, Limits{IdenticalPerMinute: 1})
+
+		require.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+		*now = now.Add(40 * time.Second)
+		var floodErr *FloodError
+		require.ErrorAs(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"), &floodErr)
+		assert.Equal(t, 20*time.Second, floodErr.RetryAfter)
+
+		*now = now.Add(20 * time.Second)
+		assert.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+	})
+
+	t.Run("off without a limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		svc, _ := newFloodService(ctrl, Limits{})
+
+		for i := 0; i < 20; i++ {
+			require.NoError(t, svc.CheckFlood(ctx, userID, boardID, FieldCard, "Fix login"))
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package content
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// FloodWindow is the period identical submissions are counted over
+const FloodWindow = time.Minute
+
+// FloodError is returned when a user submits the same card or comment to a board too often
+type FloodError struct {
+	Field Field
+	// Max is how many identical submissions are allowed per FloodWindow
+	Max int
+	// RetryAfter is how long until the oldest counted submission leaves the window
+	RetryAfter time.Duration
+}
+
+func (e *FloodError) Error() string {
+	return fmt.Sprintf("%s was already submitted %d times in the last minute, try again in %s", e.Field, e.Max, e.RetryAfter.Round(time.Second))
+}
+
+func (s *service) CheckFlood(ctx context.Context, userID, boardID uuid.UUID, field Field, payload string) error {
+	_, span := s.startServiceSpan(ctx, "CheckFlood")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("content.field", string(field)),
+	)
+	defer span.End()
+
+	max := s.limits.IdenticalPerMinute
+	if max <= 0 {
+		return nil
+	}
+	// The payload is only kept as a hash
+	sum := sha256.Sum256([]byte(payload))
+	key := userID.String() + "|" + boardID.String() + "|" + string(field) + "|" + hex.EncodeToString(sum[:])
+
+	s.floodMu.Lock()
+	defer s.floodMu.Unlock()
+
+	now := s.now()
+	cutoff := now.Add(-FloodWindow)
+	if now.Sub(s.lastSweep) > FloodWindow {
+		for k, times := range s.submissions {
+			if !times[len(times)-1].After(cutoff) {
+				delete(s.submissions, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	recent := s.submissions[key][:0]
+	for _, at := range s.submissions[key] {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	if len(recent) >= max {
+		s.submissions[key] = recent
+		span.SetAttributes(attribute.Bool("content.flooded", true))
+		return &FloodError{Field: field, Max: max, RetryAfter: recent[0].Sub(cutoff)}
+	}
+	s.submissions[key] = append(recent, now)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: content_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=content_service.go -destination=mocks/content_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	content "github.com/thatcatdev/kaimu/backend/internal/services/content"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockService) Check(ctx context.Context, boardID uuid.UUID, field content.Field, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, boardID, field, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockServiceMockRecorder) Check(ctx, boardID, field, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockService)(nil).Check), ctx, boardID, field, text)
+}
+
+// CheckFlood mocks base method.
+func (m *MockService) CheckFlood(ctx context.Context, userID, boardID uuid.UUID, field content.Field, payload string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckFlood", ctx, userID, boardID, field, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckFlood indicates an expected call of CheckFlood.
+func (mr *MockServiceMockRecorder) CheckFlood(ctx, userID, boardID, field, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckFlood", reflect.TypeOf((*MockService)(nil).CheckFlood), ctx, userID, boardID, field, payload)
+}
+
+// Limits mocks base method.
+func (m *MockService) Limits() content.Limits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits")
+	ret0, _ := ret[0].(content.Limits)
+	return ret0
+}
+
+// Limits indicates an expected call of Limits.
+func (mr *MockServiceMockRecorder) Limits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*MockService)(nil).Limits))
+}
+
+// SetModerationEnabled mocks base method.
+func (m *MockService) SetModerationEnabled(ctx context.Context, orgID uuid.UUID, enabled bool) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetModerationEnabled", ctx, orgID, enabled)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetModerationEnabled indicates an expected call of SetModerationEnabled.
+func (mr *MockServiceMockRecorder) SetModerationEnabled(ctx, orgID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetModerationEnabled", reflect.TypeOf((*MockService)(nil).SetModerationEnabled), ctx, orgID, enabled)
+}
@@ -0,0 +1,104 @@
+package content
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Scanner inspects text for content an organization doesn't allow. Implementations
+// (profanity filters, PII detectors, external moderation APIs) are registered with
+// NewService and run for organizations that enabled content moderation.
+type Scanner interface {
+	// Name identifies the scanner in errors and traces
+	Name() string
+	// Scan returns a finding when text violates the scanner's policy, or nil
+	Scan(ctx context.Context, text string) (*Finding, error)
+}
+
+// Finding explains why a scanner rejected text
+type Finding struct {
+	Reason string
+}
+
+// WordListScanner rejects text containing any of a list of words, matched case-insensitively
+// as whole words
+type WordListScanner struct {
+	words map[string]bool
+}
+
+func NewWordListScanner(words []string) *WordListScanner {
+	s := &WordListScanner{words: make(map[string]bool, len(words))}
+	for _, w := range words {
+		s.words[strings.ToLower(w)] = true
+	}
+	return s
+}
+
+func (s *WordListScanner) Name() string {
+	return "word_list"
+}
+
+func (s *WordListScanner) Scan(ctx context.Context, text string) (*Finding, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, w := range words {
+		if s.words[w] {
+			return &Finding{Reason: "contains a blocked word"}, nil
+		}
+	}
+	return nil, nil
+}
+
+var (
+	// cardNumberPattern matches 13-19 digits, optionally grouped by spaces or dashes
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	ssnPattern        = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+)
+
+// PIIScanner rejects text containing payment card numbers or US social security numbers
+type PIIScanner struct{}
+
+func NewPIIScanner() *PIIScanner {
+	return &PIIScanner{}
+}
+
+func (s *PIIScanner) Name() string {
+	return "pii"
+}
+
+func (s *PIIScanner) Scan(ctx context.Context, text string) (*Finding, error) {
+	for _, match := range cardNumberPattern.FindAllString(text, -1) {
+		if luhnValid(match) {
+			return &Finding{Reason: "contains a payment card number"}, nil
+		}
+	}
+	if ssnPattern.MatchString(text) {
+		return &Finding{Reason: "contains a social security number"}, nil
+	}
+	return nil, nil
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum used by card numbers
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
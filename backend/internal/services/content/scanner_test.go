@@ -0,0 +1,44 @@
+package content
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordListScanner(t *testing.T) {
+	scanner := NewWordListScanner([]string{"Heck"})
+	ctx := context.Background()
+
+	tests := map[string]bool{
+		"what the heck":   true,
+		"HECK!":           true,
+		"heckle the band": false,
+		"all good":        false,
+	}
+	for text, rejected := range tests {
+		finding, err := scanner.Scan(ctx, text)
+		require.NoError(t, err)
+		assert.Equal(t, rejected, finding != nil, text)
+	}
+}
+
+func TestPIIScanner(t *testing.T) {
+	scanner := NewPIIScanner()
+	ctx := context.Background()
+
+	tests := map[string]bool{
+		"card 4111 1111 1111 1111 on file": true,
+		"card 4111-1111-1111-1111":         true,
+		"order 4111111111111112":           false, // fails the Luhn check
+		"ssn 078-05-1120":                  true,
+		"call 555-0100 about ticket 1234":  false,
+	}
+	for text, rejected := range tests {
+		finding, err := scanner.Scan(ctx, text)
+		require.NoError(t, err)
+		assert.Equal(t, rejected, finding != nil, text)
+	}
+}
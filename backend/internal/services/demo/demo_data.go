@@ -0,0 +1,128 @@
+package demo
+
+import (
+	"fmt"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+)
+
+type organizationSeed struct {
+	name        string
+	description string
+	projects    []projectSeed
+}
+
+type projectSeed struct {
+	name        string
+	key         string
+	description string
+	// Sprint statuses in board order; exactly one should be active
+	sprints    []sprint.SprintStatus
+	cardTitles []string
+}
+
+type tagSeed struct {
+	name  string
+	color string
+}
+
+var demoOrganization = organizationSeed{
+	name:        "Acme Demo",
+	description: "Sample organization with realistic projects, sprints and history",
+	projects: []projectSeed{
+		{
+			name:        "Mobile App",
+			key:         "MOB",
+			description: "iOS and Android clients",
+			sprints: []sprint.SprintStatus{
+				sprint.SprintStatusClosed,
+				sprint.SprintStatusClosed,
+				sprint.SprintStatusActive,
+				sprint.SprintStatusFuture,
+			},
+			cardTitles: []string{
+				"Onboarding carousel",
+				"Push notification opt-in",
+				"Biometric login",
+				"Offline mode for task list",
+				"Crash on rotating settings screen",
+				"Dark mode theme",
+				"Deep links to boards",
+				"Pull to refresh on activity feed",
+				"Image attachments upload",
+				"Accessibility labels audit",
+				"Reduce cold start time",
+				"Localize date pickers",
+				"Tablet split view layout",
+				"In-app feedback form",
+				"Upgrade navigation library",
+				"Fix badge count after logout",
+				"Search screen empty state",
+				"Widget for today's tasks",
+			},
+		},
+		{
+			name:        "Platform API",
+			key:         "API",
+			description: "Public GraphQL API and background workers",
+			sprints: []sprint.SprintStatus{
+				sprint.SprintStatusClosed,
+				sprint.SprintStatusActive,
+				sprint.SprintStatusFuture,
+			},
+			cardTitles: []string{
+				"Paginate organization activity",
+				"Add request tracing to workers",
+				"Rate limit login attempts",
+				"Index cards in search on update",
+				"Nightly metrics snapshot job",
+				"Migrate sessions to refresh tokens",
+				"Slow query on board columns",
+				"Webhook retry with backoff",
+				"Document GraphQL error codes",
+				"Health check for Typesense",
+				"Upgrade Postgres driver",
+				"Archive closed sprints endpoint",
+				"Validate card positions on move",
+				"Audit log retention policy",
+			},
+		},
+	},
+}
+
+var demoTags = []tagSeed{
+	{name: "bug", color: "#EF4444"},
+	{name: "feature", color: "#3B82F6"},
+	{name: "tech-debt", color: "#F59E0B"},
+	{name: "design", color: "#8B5CF6"},
+}
+
+var demoSprintGoals = []string{
+	"Ship the first public beta",
+	"Stabilize and fix top crashers",
+	"Polish onboarding and notifications",
+	"Prepare for general availability",
+}
+
+// demoSprintStages lists the final stage of each card planned into a sprint
+var demoSprintStages = map[sprint.SprintStatus][]int{
+	sprint.SprintStatusClosed: {stageDone, stageDone, stageDone, stageDone, stageInProgress},
+	sprint.SprintStatusActive: {stageDone, stageDone, stageInProgress, stageInProgress, stageTodo, stageTodo},
+	sprint.SprintStatusFuture: {stageTodo, stageTodo, stageTodo},
+}
+
+var demoStoryPoints = []int{3, 5, 2, 8, 1, 3, 5}
+
+var demoPriorities = []card.CardPriority{
+	card.PriorityMedium,
+	card.PriorityHigh,
+	card.PriorityLow,
+	card.PriorityUrgent,
+	card.PriorityNone,
+	card.PriorityMedium,
+}
+
+func sprintName(index int) string {
+	return fmt.Sprintf("Sprint %d", index+1)
+}
@@ -0,0 +1,561 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	auditrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	organizationService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
+	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
+	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrSeedingDisabled = errors.New("demo data seeding is disabled in production")
+)
+
+const sprintLength = 14 * 24 * time.Hour
+
+// SeedResult holds the top-level entities created by a demo seed
+type SeedResult struct {
+	Organization *organization.Organization
+	Projects     []*project.Project
+	Boards       []*board.Board
+	Sprints      []*sprint.Sprint
+	Cards        []*card.Card
+}
+
+type Service interface {
+	// Enabled reports whether demo seeding is allowed in this environment
+	Enabled() bool
+	// SeedDemoData creates a demo organization owned by userID, with projects, boards,
+	// sprints with history, audit events and metrics snapshots
+	SeedDemoData(ctx context.Context, userID uuid.UUID) (*SeedResult, error)
+}
+
+type service struct {
+	enabled         bool
+	orgSvc          organizationService.Service
+	projectSvc      projectService.Service
+	boardSvc        boardService.Service
+	cardSvc         cardService.Service
+	sprintSvc       sprintService.Service
+	tagSvc          tagService.Service
+	auditRepo       auditrepo.Repository
+	metricsHistRepo metrics_history.Repository
+	clock           func() time.Time
+}
+
+func NewService(
+	enabled bool,
+	orgSvc organizationService.Service,
+	projectSvc projectService.Service,
+	boardSvc boardService.Service,
+	cardSvc cardService.Service,
+	sprintSvc sprintService.Service,
+	tagSvc tagService.Service,
+	auditRepo auditrepo.Repository,
+	metricsHistRepo metrics_history.Repository,
+) Service {
+	return &service{
+		enabled:         enabled,
+		orgSvc:          orgSvc,
+		projectSvc:      projectSvc,
+		boardSvc:        boardSvc,
+		cardSvc:         cardSvc,
+		sprintSvc:       sprintSvc,
+		tagSvc:          tagSvc,
+		auditRepo:       auditRepo,
+		metricsHistRepo: metricsHistRepo,
+		clock:           time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "demo.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "demo"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Enabled() bool {
+	return s.enabled
+}
+
+func (s *service) SeedDemoData(ctx context.Context, userID uuid.UUID) (*SeedResult, error) {
+	ctx, span := s.startServiceSpan(ctx, "SeedDemoData")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	if !s.enabled {
+		return nil, ErrSeedingDisabled
+	}
+
+	now := s.clock()
+	// Everything is backdated relative to the start of the active sprints
+	activeStart := startOfDay(now).Add(-6 * 24 * time.Hour)
+	orgCreatedAt := activeStart.Add(-3 * sprintLength)
+
+	org, err := s.orgSvc.CreateOrganization(ctx, userID, demoOrganization.name, demoOrganization.description)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := &seeder{
+		service: s,
+		userID:  userID,
+		orgID:   org.ID,
+		now:     now,
+		result:  &SeedResult{Organization: org},
+	}
+	sd.event(orgCreatedAt, auditrepo.ActionCreated, auditrepo.EntityOrganization, org.ID, nil, nil, nil)
+
+	for i, ps := range demoOrganization.projects {
+		if err := sd.seedProject(ctx, ps, orgCreatedAt.Add(time.Duration(i+1)*time.Hour), activeStart); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.auditRepo.CreateBatch(ctx, sd.events); err != nil {
+		return nil, err
+	}
+
+	return sd.result, nil
+}
+
+// seeder carries the state of a single SeedDemoData run
+type seeder struct {
+	*service
+	userID uuid.UUID
+	orgID  uuid.UUID
+	now    time.Time
+	result *SeedResult
+	events []*auditrepo.AuditEvent
+
+	// Per-project state
+	projectID uuid.UUID
+	boardID   uuid.UUID
+	columns   map[int]*board_column.BoardColumn
+	tagIDs    []uuid.UUID
+	titles    []string
+}
+
+// Card stages, which double as indexes into seeder.columns
+const (
+	stageBacklog = iota
+	stageTodo
+	stageInProgress
+	stageDone
+)
+
+// cardTimeline describes when a card moved through the board
+type cardTimeline struct {
+	card         *card.Card
+	createdAt    time.Time
+	inProgressAt *time.Time
+	doneAt       *time.Time
+}
+
+func (t *cardTimeline) stageAt(at time.Time) int {
+	if t.doneAt != nil && !t.doneAt.After(at) {
+		return stageDone
+	}
+	if t.inProgressAt != nil && !t.inProgressAt.After(at) {
+		return stageInProgress
+	}
+	return stageTodo
+}
+
+func (t *cardTimeline) finalStage() int {
+	if t.doneAt != nil {
+		return stageDone
+	}
+	if t.inProgressAt != nil {
+		return stageInProgress
+	}
+	return stageTodo
+}
+
+func (sd *seeder) seedProject(ctx context.Context, ps projectSeed, createdAt, activeStart time.Time) error {
+	proj, err := sd.projectSvc.CreateProject(ctx, sd.orgID, ps.name, ps.key, ps.description, &sd.userID)
+	if err != nil {
+		return err
+	}
+	sd.result.Projects = append(sd.result.Projects, proj)
+	sd.projectID = proj.ID
+	sd.titles = ps.cardTitles
+	sd.event(createdAt, auditrepo.ActionCreated, auditrepo.EntityProject, proj.ID, nil, nil, nil)
+
+	b, err := sd.boardSvc.GetDefaultBoard(ctx, proj.ID)
+	if err != nil {
+		return err
+	}
+	sd.result.Boards = append(sd.result.Boards, b)
+	sd.boardID = b.ID
+	sd.event(createdAt.Add(time.Minute), auditrepo.ActionCreated, auditrepo.EntityBoard, b.ID, nil, nil, nil)
+
+	columns, err := sd.boardSvc.GetColumnsByBoardID(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+	if len(columns) < 4 {
+		return errors.New("default board is missing columns")
+	}
+	sd.columns = make(map[int]*board_column.BoardColumn, len(columns))
+	for _, col := range columns {
+		sd.columns[col.Position] = col
+	}
+	// Mark the last column as done so sprint completion and metrics pick it up
+	sd.columns[stageDone].IsDone = true
+	if _, err := sd.boardSvc.UpdateColumn(ctx, sd.columns[stageDone]); err != nil {
+		return err
+	}
+
+	sd.tagIDs = sd.tagIDs[:0]
+	for _, ts := range demoTags {
+		t, err := sd.tagSvc.CreateTag(ctx, proj.ID, ts.name, ts.color, "")
+		if err != nil {
+			return err
+		}
+		sd.tagIDs = append(sd.tagIDs, t.ID)
+		sd.event(createdAt.Add(2*time.Minute), auditrepo.ActionCreated, auditrepo.EntityTag, t.ID, nil, nil, nil)
+	}
+
+	// Sprints are laid out back to back so that the active one started on activeStart
+	activeIndex := 0
+	for i, status := range ps.sprints {
+		if status == sprint.SprintStatusActive {
+			activeIndex = i
+		}
+	}
+
+	sprints := make([]*sprint.Sprint, len(ps.sprints))
+	for i := range ps.sprints {
+		start := activeStart.Add(time.Duration(i-activeIndex) * sprintLength)
+		end := start.Add(sprintLength - time.Second)
+		sp, err := sd.sprintSvc.CreateSprint(ctx, b.ID, sprintName(i), demoSprintGoals[i%len(demoSprintGoals)], &start, &end, &sd.userID)
+		if err != nil {
+			return err
+		}
+		sprints[i] = sp
+		sd.result.Sprints = append(sd.result.Sprints, sp)
+		sd.event(minTime(start, activeStart).Add(-2*24*time.Hour), auditrepo.ActionCreated, auditrepo.EntitySprint, sp.ID, nil, nil, nil)
+	}
+
+	var carried []*cardTimeline
+	for i, status := range ps.sprints {
+		carried, err = sd.seedSprint(ctx, sprints[i], status, carried)
+		if err != nil {
+			return err
+		}
+	}
+
+	for range 3 {
+		if _, err := sd.createCard(ctx, &cardTimeline{createdAt: activeStart.Add(-24 * time.Hour)}, stageBacklog); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedSprint fills a sprint with cards and history. carriedIn holds unfinished cards from the
+// previous sprint; the cards left unfinished by this sprint are returned.
+func (sd *seeder) seedSprint(ctx context.Context, sp *sprint.Sprint, status sprint.SprintStatus, carriedIn []*cardTimeline) ([]*cardTimeline, error) {
+	start := *sp.StartDate
+	stages := demoSprintStages[status]
+
+	// Days of the sprint that have already happened
+	elapsed := sprintLength
+	if status == sprint.SprintStatusActive {
+		elapsed = sd.now.Sub(start)
+	}
+	elapsedDays := int(elapsed / (24 * time.Hour))
+	if elapsedDays < 1 {
+		elapsedDays = 1
+	}
+	latest := start.Add(elapsed - time.Hour)
+
+	// Carried over cards are finished early in the sprint
+	for _, tl := range carriedIn {
+		if status == sprint.SprintStatusFuture {
+			break
+		}
+		doneAt := start.Add(2*24*time.Hour + 11*time.Hour)
+		if doneAt.After(latest) {
+			doneAt = latest
+		}
+		tl.doneAt = &doneAt
+	}
+
+	timelines := make([]*cardTimeline, 0, len(stages))
+	var unfinished []*cardTimeline
+	for k, stage := range stages {
+		tl := &cardTimeline{createdAt: start.Add(-24*time.Hour + time.Duration(k)*time.Minute)}
+		if status != sprint.SprintStatusFuture && stage >= stageInProgress {
+			inProgressAt := start.Add(time.Duration(k*(elapsedDays-1)/len(stages))*24*time.Hour + 10*time.Hour)
+			tl.inProgressAt = &inProgressAt
+			if stage == stageDone {
+				doneAt := inProgressAt.Add(time.Duration(1+k%3)*24*time.Hour + 5*time.Hour)
+				if doneAt.After(latest) {
+					doneAt = latest
+				}
+				if !doneAt.After(inProgressAt) {
+					doneAt = inProgressAt.Add(time.Hour)
+				}
+				tl.doneAt = &doneAt
+			}
+		}
+		timelines = append(timelines, tl)
+		if status == sprint.SprintStatusClosed && stage == stageInProgress {
+			unfinished = append(unfinished, tl)
+		}
+	}
+
+	// Cards are created in their final column once their whole timeline is known
+	for _, tl := range carriedIn {
+		if err := sd.createCardWithHistory(ctx, tl); err != nil {
+			return nil, err
+		}
+	}
+	for _, tl := range timelines {
+		if err := sd.createCardWithHistory(ctx, tl); err != nil {
+			return nil, err
+		}
+	}
+
+	all := append(append([]*cardTimeline{}, carriedIn...), timelines...)
+	for _, tl := range all {
+		if _, err := sd.sprintSvc.AddCardToSprint(ctx, tl.card.ID, sp.ID); err != nil {
+			return nil, err
+		}
+		addedAt := start.Add(-time.Hour)
+		if tl.createdAt.After(addedAt) {
+			addedAt = tl.createdAt
+		}
+		sd.event(addedAt, auditrepo.ActionCardAddedToSprint, auditrepo.EntityCard, tl.card.ID, nil,
+			map[string]interface{}{"sprint_id": sp.ID.String(), "sprint_name": sp.Name}, nil)
+	}
+
+	if status == sprint.SprintStatusFuture {
+		return nil, nil
+	}
+
+	if _, err := sd.sprintSvc.StartSprint(ctx, sp.ID); err != nil {
+		return nil, err
+	}
+	sd.event(start, auditrepo.ActionSprintStarted, auditrepo.EntitySprint, sp.ID, nil, nil, nil)
+
+	if err := sd.recordSnapshots(ctx, sp.ID, start, elapsedDays, all); err != nil {
+		return nil, err
+	}
+
+	if status == sprint.SprintStatusActive {
+		return nil, nil
+	}
+
+	if _, err := sd.sprintSvc.CompleteSprint(ctx, sp.ID, false); err != nil {
+		return nil, err
+	}
+	sd.event(*sp.EndDate, auditrepo.ActionSprintCompleted, auditrepo.EntitySprint, sp.ID, nil, nil, nil)
+
+	return unfinished, nil
+}
+
+// createCardWithHistory creates a card that has already been placed in a sprint, together with
+// the audit events describing how it got to its current column
+func (sd *seeder) createCardWithHistory(ctx context.Context, tl *cardTimeline) error {
+	if tl.card != nil {
+		// Carried over from a previous sprint, bring it to its final column
+		if tl.finalStage() == sd.stageOf(tl.card) {
+			return nil
+		}
+		moved, err := sd.cardSvc.MoveCard(ctx, tl.card.ID, sd.columns[tl.finalStage()].ID, nil, false)
+		if err != nil {
+			return err
+		}
+		tl.card = moved
+		sd.moveEvent(*tl.doneAt, moved.ID, stageInProgress, stageDone)
+		return nil
+	}
+
+	c, err := sd.createCard(ctx, tl, tl.finalStage())
+	if err != nil {
+		return err
+	}
+	if tl.inProgressAt != nil {
+		sd.moveEvent(*tl.inProgressAt, c.ID, stageTodo, stageInProgress)
+	}
+	if tl.doneAt != nil {
+		sd.moveEvent(*tl.doneAt, c.ID, stageInProgress, stageDone)
+	}
+	return nil
+}
+
+func (sd *seeder) createCard(ctx context.Context, tl *cardTimeline, stage int) (*card.Card, error) {
+	n := len(sd.result.Cards)
+	title := sd.titles[n%len(sd.titles)]
+	points := demoStoryPoints[n%len(demoStoryPoints)]
+	assignee := &sd.userID
+	if n%4 == 3 {
+		assignee = nil
+	}
+
+	c, err := sd.cardSvc.CreateCard(ctx, cardService.CreateCardInput{
+		ColumnID:    sd.columns[stage].ID,
+		Title:       title,
+		Description: "<p>" + title + ".</p>",
+		Priority:    demoPriorities[n%len(demoPriorities)],
+		AssigneeID:  assignee,
+		TagIDs:      []uuid.UUID{sd.tagIDs[n%len(sd.tagIDs)]},
+		StoryPoints: &points,
+		CreatedBy:   &sd.userID,
+		// Seeded cards aren't user submissions
+		SkipFloodCheck: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tl.card = c
+	sd.result.Cards = append(sd.result.Cards, c)
+	sd.event(tl.createdAt, auditrepo.ActionCreated, auditrepo.EntityCard, c.ID, nil,
+		map[string]interface{}{"column_id": c.ColumnID.String()}, &cardState{ColumnID: c.ColumnID.String(), StoryPoints: c.StoryPoints})
+
+	return c, nil
+}
+
+// recordSnapshots writes one metrics snapshot per elapsed sprint day, replaying the card timelines
+func (sd *seeder) recordSnapshots(ctx context.Context, sprintID uuid.UUID, start time.Time, days int, cards []*cardTimeline) error {
+	for d := 0; d <= days; d++ {
+		day := start.Add(time.Duration(d) * 24 * time.Hour)
+		at := day.Add(24*time.Hour - time.Second)
+		if at.After(sd.now) {
+			at = sd.now
+		}
+
+		history := &metrics_history.MetricsHistory{
+			SprintID:     sprintID,
+			RecordedDate: day,
+		}
+		snapshot := make(map[string]metrics_history.ColumnSnapshotData)
+		for _, tl := range cards {
+			if tl.createdAt.After(at) {
+				continue
+			}
+			points := 0
+			if tl.card.StoryPoints != nil {
+				points = *tl.card.StoryPoints
+			}
+			stage := tl.stageAt(at)
+			history.TotalCards++
+			history.TotalStoryPoints += points
+			if stage == stageDone {
+				history.CompletedCards++
+				history.CompletedStoryPoints += points
+			}
+
+			col := sd.columns[stage]
+			snap := snapshot[col.ID.String()]
+			snap.Name = col.Name
+			snap.CardCount++
+			snap.StoryPoints += points
+			snapshot[col.ID.String()] = snap
+		}
+		if err := history.SetColumnSnapshot(snapshot); err != nil {
+			return err
+		}
+		if err := sd.metricsHistRepo.Upsert(ctx, history); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sd *seeder) stageOf(c *card.Card) int {
+	for stage, col := range sd.columns {
+		if col.ID == c.ColumnID {
+			return stage
+		}
+	}
+	return stageTodo
+}
+
+// cardState mirrors the card fields the metrics service reads from audit event state
+type cardState struct {
+	ColumnID    string `json:"column_id"`
+	StoryPoints *int   `json:"story_points"`
+}
+
+func (sd *seeder) moveEvent(at time.Time, cardID uuid.UUID, from, to int) {
+	fromCol, toCol := sd.columns[from], sd.columns[to]
+	sd.event(at, auditrepo.ActionCardMoved, auditrepo.EntityCard, cardID, nil, map[string]interface{}{
+		"from_column_id":   fromCol.ID.String(),
+		"from_column_name": fromCol.Name,
+		"to_column_id":     toCol.ID.String(),
+		"to_column_name":   toCol.Name,
+	}, nil)
+}
+
+// event queues a backdated audit event; events are written in one batch at the end of the seed
+func (sd *seeder) event(at time.Time, action auditrepo.AuditAction, entityType auditrepo.EntityType, entityID uuid.UUID, before interface{}, metadata map[string]interface{}, after interface{}) {
+	evt := &auditrepo.AuditEvent{
+		OccurredAt:     at,
+		ActorID:        &sd.userID,
+		Action:         action,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		OrganizationID: &sd.orgID,
+	}
+	if entityType != auditrepo.EntityOrganization {
+		projectID := sd.projectID
+		evt.ProjectID = &projectID
+	}
+	if entityType != auditrepo.EntityOrganization && entityType != auditrepo.EntityProject && sd.boardID != uuid.Nil {
+		boardID := sd.boardID
+		evt.BoardID = &boardID
+	}
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["source"] = "demo_seed"
+
+	// Marshalling these plain values cannot fail
+	_ = evt.SetStateBefore(before)
+	_ = evt.SetStateAfter(after)
+	_ = evt.SetMetadata(metadata)
+
+	sd.events = append(sd.events, evt)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.Truncate(24 * time.Hour)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
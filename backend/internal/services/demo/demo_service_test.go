@@ -0,0 +1,60 @@
+package demo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+)
+
+func TestSeedDemoData(t *testing.T) {
+	t.Run("disabled in production", func(t *testing.T) {
+		svc := NewService(false, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.False(t, svc.Enabled())
+		result, err := svc.SeedDemoData(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, ErrSeedingDisabled)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCardTimeline(t *testing.T) {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	inProgressAt := start.Add(10 * time.Hour)
+	doneAt := start.Add(3 * 24 * time.Hour)
+
+	t.Run("done card", func(t *testing.T) {
+		tl := &cardTimeline{createdAt: start, inProgressAt: &inProgressAt, doneAt: &doneAt}
+
+		assert.Equal(t, stageTodo, tl.stageAt(start))
+		assert.Equal(t, stageInProgress, tl.stageAt(inProgressAt))
+		assert.Equal(t, stageInProgress, tl.stageAt(doneAt.Add(-time.Second)))
+		assert.Equal(t, stageDone, tl.stageAt(doneAt))
+		assert.Equal(t, stageDone, tl.finalStage())
+	})
+
+	t.Run("untouched card", func(t *testing.T) {
+		tl := &cardTimeline{createdAt: start}
+
+		assert.Equal(t, stageTodo, tl.stageAt(doneAt))
+		assert.Equal(t, stageTodo, tl.finalStage())
+	})
+}
+
+func TestDemoData(t *testing.T) {
+	for _, ps := range demoOrganization.projects {
+		active := 0
+		for _, status := range ps.sprints {
+			require.Contains(t, demoSprintStages, status)
+			if status == sprint.SprintStatusActive {
+				active++
+			}
+		}
+		assert.Equal(t, 1, active, "project %s should have exactly one active sprint", ps.key)
+		assert.NotEmpty(t, ps.cardTitles)
+	}
+}
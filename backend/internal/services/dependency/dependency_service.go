@@ -0,0 +1,364 @@
+package dependency
+
+//go:generate mockgen -source=dependency_service.go -destination=mocks/dependency_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCardNotFound       = errors.New("card not found")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrSelfDependency     = errors.New("a card cannot depend on itself")
+	ErrDependencyExists   = errors.New("the cards are already linked")
+	ErrDifferentProjects  = errors.New("only cards of the same project can be linked")
+	ErrInvalidKind        = errors.New("invalid dependency kind")
+)
+
+// Graph is a project's dependency graph
+type Graph struct {
+	// Nodes are the cards with a dependency, in topological order: a card comes after
+	// every card blocking it, except along cycles
+	Nodes []*Node
+	Edges []*Edge
+	// HasCycles is set when some cards (transitively) block themselves
+	HasCycles bool
+}
+
+type Node struct {
+	Card *card.Card
+	// Level is the length of the longest chain of blocking cards leading to the card
+	Level   int
+	InCycle bool
+}
+
+type Edge struct {
+	Dependency *card_dependency.CardDependency
+	// InCycle is set for 'blocks' links that are part of a cycle
+	InCycle bool
+}
+
+// Relation is how a card relates to the other card of a link
+type Relation string
+
+const (
+	RelationBlocks       Relation = "blocks"
+	RelationBlockedBy    Relation = "blocked_by"
+	RelationRelatesTo    Relation = "relates_to"
+	RelationDuplicates   Relation = "duplicates"
+	RelationDuplicatedBy Relation = "duplicated_by"
+	RelationSplitFrom    Relation = "split_from"
+	RelationSplitInto    Relation = "split_into"
+)
+
+// Link is a dependency seen from one of its cards
+type Link struct {
+	Dependency *card_dependency.CardDependency
+	Relation   Relation
+	// CardID is the other card of the link
+	CardID uuid.UUID
+}
+
+type Service interface {
+	// AddDependency links two cards of the same project
+	AddDependency(ctx context.Context, fromCardID, toCardID uuid.UUID, kind card_dependency.Kind, createdBy uuid.UUID) (*card_dependency.CardDependency, error)
+	GetDependency(ctx context.Context, id uuid.UUID) (*card_dependency.CardDependency, error)
+	RemoveDependency(ctx context.Context, id uuid.UUID) error
+	// GetCardDependencies returns the links from or to a card
+	GetCardDependencies(ctx context.Context, cardID uuid.UUID) ([]*card_dependency.CardDependency, error)
+	// GetCardLinks returns the links from or to a card, each with its relation seen from the card
+	GetCardLinks(ctx context.Context, cardID uuid.UUID) ([]*Link, error)
+	// GetOpenBlockers returns the cards blocking a card that are neither archived nor done
+	GetOpenBlockers(ctx context.Context, cardID uuid.UUID) ([]*card.Card, error)
+	// GetProjectGraph returns the dependency graph of a project's cards
+	GetProjectGraph(ctx context.Context, projectID uuid.UUID) (*Graph, error)
+}
+
+type service struct {
+	dependencyRepo card_dependency.Repository
+	cardRepo       card.Repository
+	boardRepo      board.Repository
+}
+
+func NewService(dependencyRepo card_dependency.Repository, cardRepo card.Repository, boardRepo board.Repository) Service {
+	return &service{
+		dependencyRepo: dependencyRepo,
+		cardRepo:       cardRepo,
+		boardRepo:      boardRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "dependency.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "dependency"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) AddDependency(ctx context.Context, fromCardID, toCardID uuid.UUID, kind card_dependency.Kind, createdBy uuid.UUID) (*card_dependency.CardDependency, error) {
+	ctx, span := s.startServiceSpan(ctx, "AddDependency")
+	span.SetAttributes(
+		attribute.String("card.from_id", fromCardID.String()),
+		attribute.String("card.to_id", toCardID.String()),
+		attribute.String("dependency.kind", string(kind)),
+	)
+	defer span.End()
+
+	// 'split_from' links record card splits and are only created by them
+	if kind != card_dependency.KindBlocks && kind != card_dependency.KindRelates && kind != card_dependency.KindDuplicates {
+		return nil, ErrInvalidKind
+	}
+	if fromCardID == toCardID {
+		return nil, ErrSelfDependency
+	}
+
+	fromProject, err := s.cardProjectID(ctx, fromCardID)
+	if err != nil {
+		return nil, err
+	}
+	toProject, err := s.cardProjectID(ctx, toCardID)
+	if err != nil {
+		return nil, err
+	}
+	if fromProject != toProject {
+		return nil, ErrDifferentProjects
+	}
+
+	existing, err := s.dependencyRepo.GetByCardID(ctx, fromCardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range existing {
+		if d.Kind != kind {
+			continue
+		}
+		sameDirection := d.FromCardID == fromCardID && d.ToCardID == toCardID
+		// 'relates' links have no direction, and two cards cannot duplicate each other
+		reversed := kind != card_dependency.KindBlocks && d.FromCardID == toCardID && d.ToCardID == fromCardID
+		if sameDirection || reversed {
+			return nil, ErrDependencyExists
+		}
+	}
+
+	dependency := &card_dependency.CardDependency{
+		FromCardID: fromCardID,
+		ToCardID:   toCardID,
+		Kind:       kind,
+		CreatedBy:  &createdBy,
+	}
+	if err := s.dependencyRepo.Create(ctx, dependency); err != nil {
+		return nil, err
+	}
+	return dependency, nil
+}
+
+func (s *service) GetDependency(ctx context.Context, id uuid.UUID) (*card_dependency.CardDependency, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetDependency")
+	span.SetAttributes(attribute.String("dependency.id", id.String()))
+	defer span.End()
+
+	dependency, err := s.dependencyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDependencyNotFound
+		}
+		return nil, err
+	}
+	return dependency, nil
+}
+
+func (s *service) RemoveDependency(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "RemoveDependency")
+	span.SetAttributes(attribute.String("dependency.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetDependency(ctx, id); err != nil {
+		return err
+	}
+	return s.dependencyRepo.Delete(ctx, id)
+}
+
+func (s *service) GetCardDependencies(ctx context.Context, cardID uuid.UUID) ([]*card_dependency.CardDependency, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCardDependencies")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	return s.dependencyRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *service) GetCardLinks(ctx context.Context, cardID uuid.UUID) ([]*Link, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCardLinks")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	dependencies, err := s.dependencyRepo.GetByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*Link, len(dependencies))
+	for i, d := range dependencies {
+		outgoing := d.FromCardID == cardID
+		link := &Link{Dependency: d, CardID: d.FromCardID}
+		if outgoing {
+			link.CardID = d.ToCardID
+		}
+		switch d.Kind {
+		case card_dependency.KindBlocks:
+			link.Relation = pickRelation(outgoing, RelationBlocks, RelationBlockedBy)
+		case card_dependency.KindDuplicates:
+			link.Relation = pickRelation(outgoing, RelationDuplicates, RelationDuplicatedBy)
+		case card_dependency.KindSplitFrom:
+			link.Relation = pickRelation(outgoing, RelationSplitFrom, RelationSplitInto)
+		default:
+			link.Relation = RelationRelatesTo
+		}
+		links[i] = link
+	}
+	return links, nil
+}
+
+func (s *service) GetOpenBlockers(ctx context.Context, cardID uuid.UUID) ([]*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOpenBlockers")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	ids, err := s.dependencyRepo.GetOpenBlockerIDs(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers := make([]*card.Card, 0, len(ids))
+	for _, id := range ids {
+		c, err := s.cardRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Deleted since the blockers were listed
+				continue
+			}
+			return nil, err
+		}
+		blockers = append(blockers, c)
+	}
+	return blockers, nil
+}
+
+func (s *service) GetProjectGraph(ctx context.Context, projectID uuid.UUID) (*Graph, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProjectGraph")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	graphNodes, err := s.dependencyRepo.GetGraphNodes(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := s.dependencyRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.projectCards(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{
+		Nodes: make([]*Node, 0, len(graphNodes)),
+		Edges: make([]*Edge, len(dependencies)),
+	}
+	cycleSuccessors := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, gn := range graphNodes {
+		c, ok := cards[gn.CardID]
+		if !ok {
+			// Deleted since the graph was computed
+			continue
+		}
+		graph.Nodes = append(graph.Nodes, &Node{Card: c, Level: gn.Level, InCycle: gn.InCycle})
+		if gn.InCycle {
+			graph.HasCycles = true
+			successors := make(map[uuid.UUID]bool, len(gn.CycleSuccessors))
+			for _, id := range gn.CycleSuccessors {
+				successors[id] = true
+			}
+			cycleSuccessors[gn.CardID] = successors
+		}
+	}
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		if !a.Card.CreatedAt.Equal(b.Card.CreatedAt) {
+			return a.Card.CreatedAt.Before(b.Card.CreatedAt)
+		}
+		return a.Card.ID.String() < b.Card.ID.String()
+	})
+
+	for i, d := range dependencies {
+		graph.Edges[i] = &Edge{
+			Dependency: d,
+			InCycle:    d.Kind == card_dependency.KindBlocks && cycleSuccessors[d.FromCardID][d.ToCardID],
+		}
+	}
+	return graph, nil
+}
+
+// pickRelation returns the relation of the link's from card when outgoing is set, and the
+// one of its to card otherwise
+func pickRelation(outgoing bool, from, to Relation) Relation {
+	if outgoing {
+		return from
+	}
+	return to
+}
+
+// cardProjectID returns the project of the card's board
+func (s *service) cardProjectID(ctx context.Context, cardID uuid.UUID) (uuid.UUID, error) {
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, ErrCardNotFound
+		}
+		return uuid.Nil, err
+	}
+	b, err := s.boardRepo.GetByID(ctx, c.BoardID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return b.ProjectID, nil
+}
+
+// projectCards returns the cards on the project's boards by ID
+func (s *service) projectCards(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*card.Card, error) {
+	boards, err := s.boardRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	cards := make(map[uuid.UUID]*card.Card)
+	for _, b := range boards {
+		boardCards, err := s.cardRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range boardCards {
+			cards[c.ID] = c
+		}
+	}
+	return cards, nil
+}
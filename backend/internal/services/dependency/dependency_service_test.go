@@ -0,0 +1,321 @@
+package dependency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependencyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAddDependency(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	projectID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: projectID}
+	from := &card.Card{ID: uuid.New(), BoardID: b.ID}
+	to := &card.Card{ID: uuid.New(), BoardID: b.ID}
+
+	expectCards := func(mockCardRepo *cardMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, cards ...*card.Card) {
+		for _, c := range cards {
+			mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		}
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil).Times(len(cards))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		expectCards(mockCardRepo, mockBoardRepo, from, to)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), from.ID).Return(nil, nil)
+		mockDependencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		d, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindBlocks, userID)
+		require.NoError(t, err)
+		assert.Equal(t, from.ID, d.FromCardID)
+		assert.Equal(t, to.ID, d.ToCardID)
+		assert.Equal(t, card_dependency.KindBlocks, d.Kind)
+		assert.Equal(t, userID, *d.CreatedBy)
+	})
+
+	t.Run("fail - self dependency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		_, err := svc.AddDependency(ctx, from.ID, from.ID, card_dependency.KindBlocks, userID)
+		assert.ErrorIs(t, err, ErrSelfDependency)
+	})
+
+	t.Run("fail - invalid kind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, "clones", userID)
+		assert.ErrorIs(t, err, ErrInvalidKind)
+	})
+
+	t.Run("fail - split links are not added by hand", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindSplitFrom, userID)
+		assert.ErrorIs(t, err, ErrInvalidKind)
+	})
+
+	t.Run("fail - card not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), from.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindBlocks, userID)
+		assert.ErrorIs(t, err, ErrCardNotFound)
+	})
+
+	t.Run("fail - different projects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		otherBoard := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+		other := &card.Card{ID: uuid.New(), BoardID: otherBoard.ID}
+		expectCards(mockCardRepo, mockBoardRepo, from)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), other.ID).Return(other, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), otherBoard.ID).Return(otherBoard, nil)
+
+		_, err := svc.AddDependency(ctx, from.ID, other.ID, card_dependency.KindBlocks, userID)
+		assert.ErrorIs(t, err, ErrDifferentProjects)
+	})
+
+	t.Run("fail - reversed relates link exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		expectCards(mockCardRepo, mockBoardRepo, from, to)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), from.ID).Return([]*card_dependency.CardDependency{
+			{ID: uuid.New(), FromCardID: to.ID, ToCardID: from.ID, Kind: card_dependency.KindRelates},
+		}, nil)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindRelates, userID)
+		assert.ErrorIs(t, err, ErrDependencyExists)
+	})
+
+	t.Run("fail - reversed duplicates link exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		expectCards(mockCardRepo, mockBoardRepo, from, to)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), from.ID).Return([]*card_dependency.CardDependency{
+			{ID: uuid.New(), FromCardID: to.ID, ToCardID: from.ID, Kind: card_dependency.KindDuplicates},
+		}, nil)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindDuplicates, userID)
+		assert.ErrorIs(t, err, ErrDependencyExists)
+	})
+
+	t.Run("success - reversed blocks link makes a cycle", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+		expectCards(mockCardRepo, mockBoardRepo, from, to)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), from.ID).Return([]*card_dependency.CardDependency{
+			{ID: uuid.New(), FromCardID: to.ID, ToCardID: from.ID, Kind: card_dependency.KindBlocks},
+		}, nil)
+		mockDependencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := svc.AddDependency(ctx, from.ID, to.ID, card_dependency.KindBlocks, userID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRemoveDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+	id := uuid.New()
+
+	mockDependencyRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+	err := svc.RemoveDependency(context.Background(), id)
+	assert.ErrorIs(t, err, ErrDependencyNotFound)
+}
+
+func TestGetCardLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+	cardID := uuid.New()
+	other := uuid.New()
+
+	mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), cardID).Return([]*card_dependency.CardDependency{
+		{ID: uuid.New(), FromCardID: cardID, ToCardID: other, Kind: card_dependency.KindBlocks},
+		{ID: uuid.New(), FromCardID: other, ToCardID: cardID, Kind: card_dependency.KindBlocks},
+		{ID: uuid.New(), FromCardID: other, ToCardID: cardID, Kind: card_dependency.KindRelates},
+		{ID: uuid.New(), FromCardID: cardID, ToCardID: other, Kind: card_dependency.KindDuplicates},
+		{ID: uuid.New(), FromCardID: other, ToCardID: cardID, Kind: card_dependency.KindDuplicates},
+		{ID: uuid.New(), FromCardID: other, ToCardID: cardID, Kind: card_dependency.KindSplitFrom},
+	}, nil)
+
+	links, err := svc.GetCardLinks(context.Background(), cardID)
+	require.NoError(t, err)
+
+	relations := make([]Relation, len(links))
+	for i, link := range links {
+		assert.Equal(t, other, link.CardID)
+		relations[i] = link.Relation
+	}
+	assert.Equal(t, []Relation{
+		RelationBlocks, RelationBlockedBy, RelationRelatesTo, RelationDuplicates, RelationDuplicatedBy, RelationSplitInto,
+	}, relations)
+}
+
+func TestGetOpenBlockers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+	cardID := uuid.New()
+	blocker := &card.Card{ID: uuid.New()}
+	deleted := uuid.New()
+
+	mockDependencyRepo.EXPECT().GetOpenBlockerIDs(gomock.Any(), cardID).Return([]uuid.UUID{deleted, blocker.ID}, nil)
+	mockCardRepo.EXPECT().GetByID(gomock.Any(), deleted).Return(nil, gorm.ErrRecordNotFound)
+	mockCardRepo.EXPECT().GetByID(gomock.Any(), blocker.ID).Return(blocker, nil)
+
+	blockers, err := svc.GetOpenBlockers(context.Background(), cardID)
+	require.NoError(t, err)
+	assert.Equal(t, []*card.Card{blocker}, blockers)
+}
+
+func TestGetProjectGraph(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockDependencyRepo, mockCardRepo, mockBoardRepo)
+
+	projectID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: projectID}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCard := func(i int) *card.Card {
+		return &card.Card{ID: uuid.New(), BoardID: b.ID, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+	}
+	a, c, d, e, f := newCard(0), newCard(1), newCard(2), newCard(3), newCard(4)
+
+	// a blocks c, d and e block each other, c relates to f
+	ac := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: a.ID, ToCardID: c.ID, Kind: card_dependency.KindBlocks}
+	de := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: d.ID, ToCardID: e.ID, Kind: card_dependency.KindBlocks}
+	ed := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: e.ID, ToCardID: d.ID, Kind: card_dependency.KindBlocks}
+	cf := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: c.ID, ToCardID: f.ID, Kind: card_dependency.KindRelates}
+
+	mockDependencyRepo.EXPECT().GetGraphNodes(gomock.Any(), projectID).Return([]*card_dependency.GraphNode{
+		{CardID: c.ID, Level: 1},
+		{CardID: f.ID, Level: 0},
+		{CardID: e.ID, Level: 1, InCycle: true, CycleSuccessors: []uuid.UUID{d.ID}},
+		{CardID: a.ID, Level: 0},
+		{CardID: d.ID, Level: 1, InCycle: true, CycleSuccessors: []uuid.UUID{e.ID}},
+	}, nil)
+	mockDependencyRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*card_dependency.CardDependency{ac, de, ed, cf}, nil)
+	mockBoardRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*board.Board{b}, nil)
+	mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*card.Card{a, c, d, e, f}, nil)
+
+	graph, err := svc.GetProjectGraph(context.Background(), projectID)
+	require.NoError(t, err)
+
+	order := make([]uuid.UUID, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		order[i] = node.Card.ID
+	}
+	assert.Equal(t, []uuid.UUID{a.ID, f.ID, c.ID, d.ID, e.ID}, order)
+	assert.True(t, graph.HasCycles)
+
+	require.Len(t, graph.Edges, 4)
+	assert.False(t, graph.Edges[0].InCycle)
+	assert.True(t, graph.Edges[1].InCycle)
+	assert.True(t, graph.Edges[2].InCycle)
+	assert.False(t, graph.Edges[3].InCycle)
+}
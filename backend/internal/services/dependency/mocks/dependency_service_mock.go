@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dependency_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dependency_service.go -destination=mocks/dependency_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	card_dependency "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependency "github.com/thatcatdev/kaimu/backend/internal/services/dependency"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddDependency mocks base method.
+func (m *MockService) AddDependency(ctx context.Context, fromCardID, toCardID uuid.UUID, kind card_dependency.Kind, createdBy uuid.UUID) (*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, fromCardID, toCardID, kind, createdBy)
+	ret0, _ := ret[0].(*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockServiceMockRecorder) AddDependency(ctx, fromCardID, toCardID, kind, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockService)(nil).AddDependency), ctx, fromCardID, toCardID, kind, createdBy)
+}
+
+// GetCardDependencies mocks base method.
+func (m *MockService) GetCardDependencies(ctx context.Context, cardID uuid.UUID) ([]*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardDependencies", ctx, cardID)
+	ret0, _ := ret[0].([]*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardDependencies indicates an expected call of GetCardDependencies.
+func (mr *MockServiceMockRecorder) GetCardDependencies(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardDependencies", reflect.TypeOf((*MockService)(nil).GetCardDependencies), ctx, cardID)
+}
+
+// GetCardLinks mocks base method.
+func (m *MockService) GetCardLinks(ctx context.Context, cardID uuid.UUID) ([]*dependency.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardLinks", ctx, cardID)
+	ret0, _ := ret[0].([]*dependency.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardLinks indicates an expected call of GetCardLinks.
+func (mr *MockServiceMockRecorder) GetCardLinks(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLinks", reflect.TypeOf((*MockService)(nil).GetCardLinks), ctx, cardID)
+}
+
+// GetDependency mocks base method.
+func (m *MockService) GetDependency(ctx context.Context, id uuid.UUID) (*card_dependency.CardDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependency", ctx, id)
+	ret0, _ := ret[0].(*card_dependency.CardDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependency indicates an expected call of GetDependency.
+func (mr *MockServiceMockRecorder) GetDependency(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependency", reflect.TypeOf((*MockService)(nil).GetDependency), ctx, id)
+}
+
+// GetOpenBlockers mocks base method.
+func (m *MockService) GetOpenBlockers(ctx context.Context, cardID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenBlockers", ctx, cardID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenBlockers indicates an expected call of GetOpenBlockers.
+func (mr *MockServiceMockRecorder) GetOpenBlockers(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenBlockers", reflect.TypeOf((*MockService)(nil).GetOpenBlockers), ctx, cardID)
+}
+
+// GetProjectGraph mocks base method.
+func (m *MockService) GetProjectGraph(ctx context.Context, projectID uuid.UUID) (*dependency.Graph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectGraph", ctx, projectID)
+	ret0, _ := ret[0].(*dependency.Graph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectGraph indicates an expected call of GetProjectGraph.
+func (mr *MockServiceMockRecorder) GetProjectGraph(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectGraph", reflect.TypeOf((*MockService)(nil).GetProjectGraph), ctx, projectID)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockService) RemoveDependency(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockServiceMockRecorder) RemoveDependency(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockService)(nil).RemoveDependency), ctx, id)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/thatcatdev/kaimu/backend/config"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/email_verification_token"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
 	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
 )
 
@@ -64,8 +65,8 @@ func (s *emailVerificationService) SendVerificationEmail(ctx context.Context, us
 	// Build verification URL
 	verificationURL := fmt.Sprintf("%s?token=%s", s.config.VerificationURL, token.Token)
 
-	// Send email
-	err = s.mailService.SendMail(ctx, []string{email}, "Verify your Kaimu account", "verification.mjml", map[string]string{
+	// Send email, in the request's language for new accounts
+	err = s.mailService.SendMail(ctx, []string{email}, i18n.Tc(ctx, "email.verification.subject", nil), "verification.mjml", map[string]string{
 		"name":      name,
 		"token_url": verificationURL,
 	})
@@ -140,5 +141,9 @@ func (s *emailVerificationService) ResendVerificationEmail(ctx context.Context,
 		name = *u.DisplayName
 	}
 
+	if u.Locale != nil {
+		ctx = i18n.WithLocale(ctx, i18n.Resolve(*u.Locale, i18n.FromContext(ctx)))
+	}
+
 	return s.SendVerificationEmail(ctx, userID, email, name)
 }
@@ -0,0 +1,290 @@
+package embed
+
+//go:generate mockgen -source=embed_service.go -destination=mocks/embed_service_mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// TokenLength is the length of an embed token in bytes (before base64 encoding)
+	TokenLength = 32
+	// MaxTokenLifetime is how far ahead an embed token may expire
+	MaxTokenLifetime = 366 * 24 * time.Hour
+	// DefaultVelocitySprints is how many sprints the velocity chart covers by default
+	DefaultVelocitySprints = 10
+	// MaxVelocitySprints caps how many sprints the velocity chart covers
+	MaxVelocitySprints = 50
+)
+
+var (
+	ErrNoCharts           = errors.New("an embed token needs at least one chart")
+	ErrInvalidChart       = errors.New("invalid chart")
+	ErrExpiryInPast       = errors.New("an embed token must expire in the future")
+	ErrExpiryTooFar       = errors.New("an embed token may expire at most a year ahead")
+	ErrInvalidToken       = errors.New("invalid or expired embed token")
+	ErrTokenNotFound      = errors.New("embed token not found")
+	ErrSprintNotOnBoard   = errors.New("sprint does not belong to the token's board")
+	ErrInvalidSprintCount = fmt.Errorf("sprint count must be between 1 and %d", MaxVelocitySprints)
+)
+
+// GenerateInput describes a new embed token
+type GenerateInput struct {
+	BoardID   uuid.UUID
+	Charts    []metrics_embed_token.Chart
+	ExpiresAt time.Time
+	CreatedBy uuid.UUID
+}
+
+// MetricsInput selects the data of the charts read with a token
+type MetricsInput struct {
+	// SprintID picks the sprint of the sprint charts; the board's active sprint when nil
+	SprintID    *uuid.UUID
+	Mode        metrics.MetricMode
+	SprintCount *int
+}
+
+// Metrics are the charts an embed token reads. Charts the token doesn't allow are nil,
+// as are the sprint charts when there is no sprint to chart.
+type Metrics struct {
+	Token          *metrics_embed_token.MetricsEmbedToken
+	Sprint         *sprint.Sprint
+	BurnDown       *metrics.BurnDownData
+	BurnUp         *metrics.BurnUpData
+	Velocity       *metrics.VelocityData
+	CumulativeFlow *metrics.CumulativeFlowData
+}
+
+type Service interface {
+	// GenerateToken creates a token reading the board's charts until it expires. The token
+	// is only returned here; just its hash is stored.
+	GenerateToken(ctx context.Context, input GenerateInput) (string, *metrics_embed_token.MetricsEmbedToken, error)
+	GetToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error)
+	// GetBoardTokens returns the board's tokens that can still be used
+	GetBoardTokens(ctx context.Context, boardID uuid.UUID) ([]*metrics_embed_token.MetricsEmbedToken, error)
+	RevokeToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error)
+	// GetMetrics returns the charts the token allows, or ErrInvalidToken when it is unknown,
+	// revoked or expired
+	GetMetrics(ctx context.Context, token string, input MetricsInput) (*Metrics, error)
+}
+
+type service struct {
+	tokenRepo  metrics_embed_token.Repository
+	sprintRepo sprint.Repository
+	metricsSvc metrics.Service
+	now        func() time.Time
+}
+
+func NewService(tokenRepo metrics_embed_token.Repository, sprintRepo sprint.Repository, metricsSvc metrics.Service) Service {
+	return &service{
+		tokenRepo:  tokenRepo,
+		sprintRepo: sprintRepo,
+		metricsSvc: metricsSvc,
+		now:        time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "embed.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "embed"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GenerateToken(ctx context.Context, input GenerateInput) (string, *metrics_embed_token.MetricsEmbedToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GenerateToken")
+	span.SetAttributes(attribute.String("board.id", input.BoardID.String()))
+	defer span.End()
+
+	if len(input.Charts) == 0 {
+		return "", nil, ErrNoCharts
+	}
+	var charts metrics_embed_token.Charts
+	for _, chart := range input.Charts {
+		switch chart {
+		case metrics_embed_token.ChartBurnDown, metrics_embed_token.ChartBurnUp,
+			metrics_embed_token.ChartVelocity, metrics_embed_token.ChartCumulativeFlow:
+		default:
+			return "", nil, ErrInvalidChart
+		}
+		if !slices.Contains(charts, chart) {
+			charts = append(charts, chart)
+		}
+	}
+
+	now := s.now()
+	if !input.ExpiresAt.After(now) {
+		return "", nil, ErrExpiryInPast
+	}
+	if input.ExpiresAt.Sub(now) > MaxTokenLifetime {
+		return "", nil, ErrExpiryTooFar
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+	embedToken := &metrics_embed_token.MetricsEmbedToken{
+		BoardID:   input.BoardID,
+		TokenHash: tokenhash.Hash(token),
+		Charts:    charts,
+		ExpiresAt: input.ExpiresAt,
+		CreatedBy: &input.CreatedBy,
+	}
+	if err := s.tokenRepo.Create(ctx, embedToken); err != nil {
+		return "", nil, err
+	}
+	return token, embedToken, nil
+}
+
+func (s *service) GetToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetToken")
+	defer span.End()
+
+	token, err := s.tokenRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTokenNotFound
+	}
+	return token, err
+}
+
+func (s *service) GetBoardTokens(ctx context.Context, boardID uuid.UUID) ([]*metrics_embed_token.MetricsEmbedToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBoardTokens")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.tokenRepo.GetByBoardID(ctx, boardID, s.now())
+}
+
+func (s *service) RevokeToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error) {
+	ctx, span := s.startServiceSpan(ctx, "RevokeToken")
+	defer span.End()
+
+	token, err := s.GetToken(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	now := s.now()
+	if err := s.tokenRepo.Revoke(ctx, id, now); err != nil {
+		return nil, err
+	}
+	token.RevokedAt = &now
+	return token, nil
+}
+
+func (s *service) GetMetrics(ctx context.Context, token string, input MetricsInput) (*Metrics, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMetrics")
+	defer span.End()
+
+	embedToken, err := s.tokenRepo.GetByTokenHash(ctx, tokenhash.Hash(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !embedToken.IsValid(s.now()) {
+		return nil, ErrInvalidToken
+	}
+	span.SetAttributes(attribute.String("board.id", embedToken.BoardID.String()))
+
+	result := &Metrics{Token: embedToken}
+
+	if embedToken.Allows(metrics_embed_token.ChartVelocity) {
+		count := DefaultVelocitySprints
+		if input.SprintCount != nil {
+			count = *input.SprintCount
+		}
+		if count < 1 || count > MaxVelocitySprints {
+			return nil, ErrInvalidSprintCount
+		}
+		if result.Velocity, err = s.metricsSvc.GetVelocityData(ctx, embedToken.BoardID, count, input.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if !embedToken.Allows(metrics_embed_token.ChartBurnDown) && !embedToken.Allows(metrics_embed_token.ChartBurnUp) &&
+		!embedToken.Allows(metrics_embed_token.ChartCumulativeFlow) {
+		return result, nil
+	}
+	result.Sprint, err = s.getSprint(ctx, embedToken.BoardID, input.SprintID)
+	if err != nil || result.Sprint == nil {
+		return result, err
+	}
+
+	if embedToken.Allows(metrics_embed_token.ChartBurnDown) {
+		if result.BurnDown, err = s.metricsSvc.GetBurnDownData(ctx, result.Sprint.ID, input.Mode); err != nil {
+			return nil, err
+		}
+	}
+	if embedToken.Allows(metrics_embed_token.ChartBurnUp) {
+		if result.BurnUp, err = s.metricsSvc.GetBurnUpData(ctx, result.Sprint.ID, input.Mode); err != nil {
+			return nil, err
+		}
+	}
+	if embedToken.Allows(metrics_embed_token.ChartCumulativeFlow) {
+		if result.CumulativeFlow, err = s.metricsSvc.GetCumulativeFlowData(ctx, result.Sprint.ID, input.Mode); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getSprint returns the sprint to chart: the given one, which must be on the board, or
+// else the board's active sprint, or nil when it has none
+func (s *service) getSprint(ctx context.Context, boardID uuid.UUID, sprintID *uuid.UUID) (*sprint.Sprint, error) {
+	if sprintID == nil {
+		sp, err := s.sprintRepo.GetActiveByBoardID(ctx, boardID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return sp, err
+	}
+
+	sp, err := s.sprintRepo.GetByID(ctx, *sprintID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSprintNotOnBoard
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sp.BoardID != boardID {
+		return nil, ErrSprintNotOnBoard
+	}
+	return sp, nil
+}
+
+// generateToken creates a secure random embed token
+func generateToken() (string, error) {
+	bytes := make([]byte, TokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,264 @@
+package embed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	tokenMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"github.com/thatcatdev/kaimu/backend/internal/tokenhash"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// fakeMetricsService returns chart data naming the sprint or board it was asked for
+type fakeMetricsService struct {
+	metrics.Service
+	velocityCounts []int
+}
+
+func (f *fakeMetricsService) GetBurnDownData(ctx context.Context, sprintID uuid.UUID, mode metrics.MetricMode) (*metrics.BurnDownData, error) {
+	return &metrics.BurnDownData{SprintID: sprintID}, nil
+}
+
+func (f *fakeMetricsService) GetBurnUpData(ctx context.Context, sprintID uuid.UUID, mode metrics.MetricMode) (*metrics.BurnUpData, error) {
+	return &metrics.BurnUpData{SprintID: sprintID}, nil
+}
+
+func (f *fakeMetricsService) GetVelocityData(ctx context.Context, boardID uuid.UUID, sprintCount int, mode metrics.MetricMode) (*metrics.VelocityData, error) {
+	f.velocityCounts = append(f.velocityCounts, sprintCount)
+	return &metrics.VelocityData{}, nil
+}
+
+func (f *fakeMetricsService) GetCumulativeFlowData(ctx context.Context, sprintID uuid.UUID, mode metrics.MetricMode) (*metrics.CumulativeFlowData, error) {
+	return &metrics.CumulativeFlowData{SprintID: sprintID}, nil
+}
+
+func TestGenerateToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	input := GenerateInput{
+		BoardID:   uuid.New(),
+		Charts:    []metrics_embed_token.Chart{metrics_embed_token.ChartBurnDown, metrics_embed_token.ChartVelocity, metrics_embed_token.ChartBurnDown},
+		ExpiresAt: now.AddDate(0, 1, 0),
+		CreatedBy: uuid.New(),
+	}
+
+	t.Run("success - stores only the token's hash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		var stored *metrics_embed_token.MetricsEmbedToken
+		mockTokenRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, token *metrics_embed_token.MetricsEmbedToken) error {
+			stored = token
+			return nil
+		})
+
+		token, embedToken, err := svc.GenerateToken(ctx, input)
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Same(t, stored, embedToken)
+		assert.Equal(t, tokenhash.Hash(token), stored.TokenHash)
+		assert.NotContains(t, stored.TokenHash, token)
+		assert.Equal(t, metrics_embed_token.Charts{metrics_embed_token.ChartBurnDown, metrics_embed_token.ChartVelocity}, stored.Charts)
+		assert.Equal(t, input.ExpiresAt, stored.ExpiresAt)
+	})
+
+	t.Run("fail - invalid input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		noCharts := input
+		noCharts.Charts = nil
+		_, _, err := svc.GenerateToken(ctx, noCharts)
+		assert.ErrorIs(t, err, ErrNoCharts)
+
+		badChart := input
+		badChart.Charts = []metrics_embed_token.Chart{"pie"}
+		_, _, err = svc.GenerateToken(ctx, badChart)
+		assert.ErrorIs(t, err, ErrInvalidChart)
+
+		past := input
+		past.ExpiresAt = now
+		_, _, err = svc.GenerateToken(ctx, past)
+		assert.ErrorIs(t, err, ErrExpiryInPast)
+
+		tooFar := input
+		tooFar.ExpiresAt = now.AddDate(2, 0, 0)
+		_, _, err = svc.GenerateToken(ctx, tooFar)
+		assert.ErrorIs(t, err, ErrExpiryTooFar)
+	})
+}
+
+func TestGetMetrics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	boardID := uuid.New()
+	active := &sprint.Sprint{ID: uuid.New(), BoardID: boardID}
+	embedToken := func(charts ...metrics_embed_token.Chart) *metrics_embed_token.MetricsEmbedToken {
+		return &metrics_embed_token.MetricsEmbedToken{
+			ID:        uuid.New(),
+			BoardID:   boardID,
+			TokenHash: tokenhash.Hash("secret"),
+			Charts:    charts,
+			ExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	t.Run("success - returns only the allowed charts for the active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), tokenhash.Hash("secret")).
+			Return(embedToken(metrics_embed_token.ChartBurnDown, metrics_embed_token.ChartVelocity), nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(active, nil)
+
+		result, err := svc.GetMetrics(ctx, "secret", MetricsInput{Mode: metrics.MetricModeStoryPoints})
+		require.NoError(t, err)
+		assert.Equal(t, active, result.Sprint)
+		require.NotNil(t, result.BurnDown)
+		assert.Equal(t, active.ID, result.BurnDown.SprintID)
+		assert.NotNil(t, result.Velocity)
+		assert.Equal(t, []int{DefaultVelocitySprints}, metricsSvc.velocityCounts)
+		assert.Nil(t, result.BurnUp)
+		assert.Nil(t, result.CumulativeFlow)
+	})
+
+	t.Run("success - sprint charts are empty without an active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(embedToken(metrics_embed_token.ChartCumulativeFlow), nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := svc.GetMetrics(ctx, "secret", MetricsInput{})
+		require.NoError(t, err)
+		assert.Nil(t, result.Sprint)
+		assert.Nil(t, result.CumulativeFlow)
+	})
+
+	t.Run("fail - sprint of another board", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		other := &sprint.Sprint{ID: uuid.New(), BoardID: uuid.New()}
+		mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(embedToken(metrics_embed_token.ChartBurnUp), nil)
+		mockSprintRepo.EXPECT().GetByID(gomock.Any(), other.ID).Return(other, nil)
+
+		_, err := svc.GetMetrics(ctx, "secret", MetricsInput{SprintID: &other.ID})
+		assert.ErrorIs(t, err, ErrSprintNotOnBoard)
+	})
+
+	t.Run("fail - unknown, expired or revoked token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		expired := embedToken(metrics_embed_token.ChartVelocity)
+		expired.ExpiresAt = now
+		revoked := embedToken(metrics_embed_token.ChartVelocity)
+		revoked.RevokedAt = &now
+		gomock.InOrder(
+			mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound),
+			mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(expired, nil),
+			mockTokenRepo.EXPECT().GetByTokenHash(gomock.Any(), gomock.Any()).Return(revoked, nil),
+		)
+
+		for range 3 {
+			_, err := svc.GetMetrics(ctx, "secret", MetricsInput{})
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		}
+		assert.Empty(t, metricsSvc.velocityCounts)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		token := &metrics_embed_token.MetricsEmbedToken{ID: uuid.New()}
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), token.ID).Return(token, nil)
+		mockTokenRepo.EXPECT().Revoke(gomock.Any(), token.ID, now).Return(nil)
+
+		revoked, err := svc.RevokeToken(ctx, token.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &now, revoked.RevokedAt)
+	})
+
+	t.Run("fail - not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTokenRepo := tokenMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		metricsSvc := &fakeMetricsService{}
+		svc := NewService(mockTokenRepo, mockSprintRepo, metricsSvc).(*service)
+		svc.now = func() time.Time { return now }
+
+		id := uuid.New()
+		mockTokenRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.RevokeToken(ctx, id)
+		assert.ErrorIs(t, err, ErrTokenNotFound)
+	})
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: embed_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=embed_service.go -destination=mocks/embed_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	metrics_embed_token "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	embed "github.com/thatcatdev/kaimu/backend/internal/services/embed"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GenerateToken mocks base method.
+func (m *MockService) GenerateToken(ctx context.Context, input embed.GenerateInput) (string, *metrics_embed_token.MetricsEmbedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", ctx, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*metrics_embed_token.MetricsEmbedToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockServiceMockRecorder) GenerateToken(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockService)(nil).GenerateToken), ctx, input)
+}
+
+// GetBoardTokens mocks base method.
+func (m *MockService) GetBoardTokens(ctx context.Context, boardID uuid.UUID) ([]*metrics_embed_token.MetricsEmbedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardTokens", ctx, boardID)
+	ret0, _ := ret[0].([]*metrics_embed_token.MetricsEmbedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardTokens indicates an expected call of GetBoardTokens.
+func (mr *MockServiceMockRecorder) GetBoardTokens(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardTokens", reflect.TypeOf((*MockService)(nil).GetBoardTokens), ctx, boardID)
+}
+
+// GetMetrics mocks base method.
+func (m *MockService) GetMetrics(ctx context.Context, token string, input embed.MetricsInput) (*embed.Metrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetrics", ctx, token, input)
+	ret0, _ := ret[0].(*embed.Metrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetrics indicates an expected call of GetMetrics.
+func (mr *MockServiceMockRecorder) GetMetrics(ctx, token, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockService)(nil).GetMetrics), ctx, token, input)
+}
+
+// GetToken mocks base method.
+func (m *MockService) GetToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", ctx, id)
+	ret0, _ := ret[0].(*metrics_embed_token.MetricsEmbedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockServiceMockRecorder) GetToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockService)(nil).GetToken), ctx, id)
+}
+
+// RevokeToken mocks base method.
+func (m *MockService) RevokeToken(ctx context.Context, id uuid.UUID) (*metrics_embed_token.MetricsEmbedToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id)
+	ret0, _ := ret[0].(*metrics_embed_token.MetricsEmbedToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockServiceMockRecorder) RevokeToken(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockService)(nil).RevokeToken), ctx, id)
+}
@@ -0,0 +1,105 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+type openAIProvider struct {
+	client     *http.Client
+	url        string
+	apiKey     string
+	model      string
+	dimensions int
+}
+
+// NewOpenAIProvider embeds texts through an OpenAI-compatible embeddings API, which OpenAI,
+// Azure OpenAI, Ollama and vLLM all serve
+func NewOpenAIProvider(cfg config.EmbeddingsConfig) (Provider, error) {
+	if cfg.URL == "" || cfg.Model == "" || cfg.Dimensions <= 0 {
+		return nil, ErrInvalidConfig
+	}
+	return newOpenAIProvider(&http.Client{Timeout: requestTimeout}, cfg), nil
+}
+
+func newOpenAIProvider(client *http.Client, cfg config.EmbeddingsConfig) *openAIProvider {
+	return &openAIProvider{
+		client:     client,
+		url:        strings.TrimSuffix(cfg.URL, "/") + "/embeddings",
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+		dimensions: cfg.Dimensions,
+	}
+}
+
+type openAIEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (p *openAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(openAIEmbeddingsRequest{Model: p.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embeddings request failed with status %d: %s", resp.StatusCode, detail)
+	}
+
+	var parsed openAIEmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings response has %d vectors for %d texts", len(parsed.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings response has an unknown index %d", d.Index)
+		}
+		if len(d.Embedding) != p.dimensions {
+			return nil, fmt.Errorf("%w: got %d, configured %d", ErrDimensionMismatch, len(d.Embedding), p.dimensions)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+func (p *openAIProvider) Dimensions() int {
+	return p.dimensions
+}
@@ -0,0 +1,43 @@
+package embeddings
+
+//go:generate mockgen -source=provider.go -destination=mocks/provider_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const ProviderOpenAI = "openai"
+
+// requestTimeout bounds one embeddings request
+const requestTimeout = 30 * time.Second
+
+var (
+	ErrUnknownProvider   = errors.New("unknown embeddings provider")
+	ErrInvalidConfig     = errors.New("embeddings need a URL, a model and positive dimensions")
+	ErrDimensionMismatch = errors.New("embedding has the wrong number of dimensions")
+)
+
+// Provider turns text into vectors whose distance reflects how close the texts are in meaning
+type Provider interface {
+	// Embed returns a vector for each text, in order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Dimensions is the length of the vectors
+	Dimensions() int
+}
+
+// NewProvider returns the configured provider, or nil when embeddings are off
+func NewProvider(cfg config.EmbeddingsConfig) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderOpenAI:
+		return NewOpenAIProvider(cfg)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
@@ -0,0 +1,80 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(config.EmbeddingsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = NewProvider(config.EmbeddingsConfig{Provider: "word2vec"})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = NewProvider(config.EmbeddingsConfig{Provider: ProviderOpenAI, URL: "https://api.openai.com/v1", Model: "text-embedding-3-small"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	provider, err = NewProvider(config.EmbeddingsConfig{Provider: ProviderOpenAI, URL: "https://api.openai.com/v1", Model: "text-embedding-3-small", Dimensions: 1536})
+	require.NoError(t, err)
+	assert.Equal(t, 1536, provider.Dimensions())
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	cfg := config.EmbeddingsConfig{APIKey: "secret", Model: "text-embedding-3-small", Dimensions: 3}
+
+	t.Run("returns the vectors in input order", func(t *testing.T) {
+		var got openAIEmbeddingsRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/embeddings", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"data": [
+				{"index": 1, "embedding": [0, 1, 0]},
+				{"index": 0, "embedding": [1, 0, 0]}
+			]}`))
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL + "/v1/"
+		provider := newOpenAIProvider(server.Client(), cfg)
+		vectors, err := provider.Embed(context.Background(), []string{"login broken", "authentication failure"})
+		require.NoError(t, err)
+
+		assert.Equal(t, openAIEmbeddingsRequest{Model: "text-embedding-3-small", Input: []string{"login broken", "authentication failure"}}, got)
+		assert.Equal(t, [][]float32{{1, 0, 0}, {0, 1, 0}}, vectors)
+	})
+
+	t.Run("rejects vectors of the wrong length", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data": [{"index": 0, "embedding": [1, 0]}]}`))
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL
+		_, err := newOpenAIProvider(server.Client(), cfg).Embed(context.Background(), []string{"login"})
+		assert.ErrorIs(t, err, ErrDimensionMismatch)
+	})
+
+	t.Run("reports failed requests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error": {"message": "invalid api key"}}`, http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL
+		_, err := newOpenAIProvider(server.Client(), cfg).Embed(context.Background(), []string{"login"})
+		assert.ErrorContains(t, err, "status 401")
+	})
+}
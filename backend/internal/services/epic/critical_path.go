@@ -0,0 +1,132 @@
+package epic
+
+import (
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+)
+
+// CriticalPath schedules an epic's cards along their blocking links. Times are in story
+// points of remaining work from now, assuming cards without blockers can start at once.
+type CriticalPath struct {
+	// Length is the remaining work along the longest chain of blocking cards, which
+	// is when the epic can be done at the earliest
+	Length int
+	// Path is that chain, first card first; empty when no work remains
+	Path []*card.Card
+	// Cards are every card of the epic, in topological order
+	Cards []*ScheduledCard
+}
+
+// ScheduledCard is a card's place in the epic's schedule
+type ScheduledCard struct {
+	Card *card.Card
+	// Remaining is the card's estimate, or 0 once it is done or when it has none
+	Remaining int
+	// Estimated is false for unfinished cards without story points
+	Estimated      bool
+	EarliestStart  int
+	EarliestFinish int
+	LatestStart    int
+	LatestFinish   int
+	// Slack is how far the card can slip without moving the epic's end
+	Slack    int
+	Critical bool
+}
+
+// computeCriticalPath runs the critical path method over the cards. Only 'blocks' links
+// between the given cards are followed; done holds the IDs of finished cards.
+func computeCriticalPath(cards []*card.Card, links []*card_dependency.CardDependency, done map[uuid.UUID]bool) (*CriticalPath, error) {
+	scheduled := make(map[uuid.UUID]*ScheduledCard, len(cards))
+	for _, c := range cards {
+		sc := &ScheduledCard{Card: c, Estimated: true}
+		if !done[c.ID] {
+			if c.StoryPoints != nil {
+				sc.Remaining = *c.StoryPoints
+			} else {
+				sc.Estimated = false
+			}
+		}
+		scheduled[c.ID] = sc
+	}
+
+	predecessors := make(map[uuid.UUID][]uuid.UUID)
+	successors := make(map[uuid.UUID][]uuid.UUID)
+	inDegree := make(map[uuid.UUID]int, len(cards))
+	for _, l := range links {
+		if l.Kind != card_dependency.KindBlocks || scheduled[l.FromCardID] == nil || scheduled[l.ToCardID] == nil {
+			continue
+		}
+		predecessors[l.ToCardID] = append(predecessors[l.ToCardID], l.FromCardID)
+		successors[l.FromCardID] = append(successors[l.FromCardID], l.ToCardID)
+		inDegree[l.ToCardID]++
+	}
+
+	// Kahn's algorithm, keeping the given card order among ready cards
+	order := make([]*ScheduledCard, 0, len(cards))
+	var ready []uuid.UUID
+	for _, c := range cards {
+		if inDegree[c.ID] == 0 {
+			ready = append(ready, c.ID)
+		}
+	}
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, scheduled[id])
+		for _, next := range successors[id] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	if len(order) < len(cards) {
+		return nil, ErrDependencyCycle
+	}
+
+	result := &CriticalPath{Cards: order}
+	for _, sc := range order {
+		for _, pred := range predecessors[sc.Card.ID] {
+			sc.EarliestStart = max(sc.EarliestStart, scheduled[pred].EarliestFinish)
+		}
+		sc.EarliestFinish = sc.EarliestStart + sc.Remaining
+		result.Length = max(result.Length, sc.EarliestFinish)
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		sc := order[i]
+		sc.LatestFinish = result.Length
+		for _, next := range successors[sc.Card.ID] {
+			sc.LatestFinish = min(sc.LatestFinish, scheduled[next].LatestStart)
+		}
+		sc.LatestStart = sc.LatestFinish - sc.Remaining
+		sc.Slack = sc.LatestStart - sc.EarliestStart
+		sc.Critical = sc.Slack == 0 && result.Length > 0
+	}
+
+	if result.Length == 0 {
+		return result, nil
+	}
+
+	// Walk back from the first card finishing last through critical blockers
+	var current *ScheduledCard
+	for _, sc := range order {
+		if sc.Critical && sc.EarliestFinish == result.Length {
+			current = sc
+			break
+		}
+	}
+	for current != nil {
+		result.Path = append([]*card.Card{current.Card}, result.Path...)
+		var previous *ScheduledCard
+		for _, pred := range predecessors[current.Card.ID] {
+			if p := scheduled[pred]; p.Critical && p.EarliestFinish == current.EarliestStart {
+				previous = p
+				break
+			}
+		}
+		current = previous
+	}
+	return result, nil
+}
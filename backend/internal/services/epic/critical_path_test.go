@@ -0,0 +1,95 @@
+package epic
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+)
+
+func pointsCard(points *int) *card.Card {
+	return &card.Card{ID: uuid.New(), StoryPoints: points}
+}
+
+func blocks(from, to *card.Card) *card_dependency.CardDependency {
+	return &card_dependency.CardDependency{ID: uuid.New(), FromCardID: from.ID, ToCardID: to.ID, Kind: card_dependency.KindBlocks}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestComputeCriticalPath(t *testing.T) {
+	t.Run("longest chain and slack", func(t *testing.T) {
+		a, b, c, d := pointsCard(intPtr(3)), pointsCard(intPtr(5)), pointsCard(intPtr(2)), pointsCard(intPtr(1))
+		links := []*card_dependency.CardDependency{blocks(a, b), blocks(a, c), blocks(b, d), blocks(c, d)}
+
+		cp, err := computeCriticalPath([]*card.Card{d, c, b, a}, links, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 9, cp.Length)
+		assert.Equal(t, []*card.Card{a, b, d}, cp.Path)
+		require.Len(t, cp.Cards, 4)
+		assert.Equal(t, a, cp.Cards[0].Card)
+		assert.Equal(t, d, cp.Cards[3].Card)
+
+		byCard := make(map[uuid.UUID]*ScheduledCard)
+		for _, sc := range cp.Cards {
+			byCard[sc.Card.ID] = sc
+		}
+		assert.Equal(t, 0, byCard[b.ID].Slack)
+		assert.True(t, byCard[b.ID].Critical)
+		assert.Equal(t, 3, byCard[c.ID].EarliestStart)
+		assert.Equal(t, 6, byCard[c.ID].LatestStart)
+		assert.Equal(t, 3, byCard[c.ID].Slack)
+		assert.False(t, byCard[c.ID].Critical)
+		assert.Equal(t, 8, byCard[d.ID].EarliestStart)
+	})
+
+	t.Run("done and unestimated cards take no time", func(t *testing.T) {
+		a, b, c := pointsCard(intPtr(8)), pointsCard(nil), pointsCard(intPtr(2))
+		links := []*card_dependency.CardDependency{blocks(a, c), blocks(b, c)}
+
+		cp, err := computeCriticalPath([]*card.Card{a, b, c}, links, map[uuid.UUID]bool{a.ID: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, cp.Length)
+		assert.Equal(t, 0, cp.Cards[0].Remaining)
+		assert.True(t, cp.Cards[0].Estimated)
+		assert.False(t, cp.Cards[1].Estimated)
+		assert.Equal(t, []*card.Card{a, c}, cp.Path)
+	})
+
+	t.Run("links outside the epic and relates links are ignored", func(t *testing.T) {
+		a, b, outside := pointsCard(intPtr(1)), pointsCard(intPtr(1)), pointsCard(intPtr(13))
+		relates := &card_dependency.CardDependency{FromCardID: a.ID, ToCardID: b.ID, Kind: card_dependency.KindRelates}
+
+		cp, err := computeCriticalPath([]*card.Card{a, b}, []*card_dependency.CardDependency{blocks(outside, a), relates}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, cp.Length)
+		assert.Equal(t, []*card.Card{a}, cp.Path)
+		assert.True(t, cp.Cards[1].Critical)
+	})
+
+	t.Run("no remaining work", func(t *testing.T) {
+		a := pointsCard(nil)
+
+		cp, err := computeCriticalPath([]*card.Card{a}, nil, nil)
+		require.NoError(t, err)
+
+		assert.Zero(t, cp.Length)
+		assert.Empty(t, cp.Path)
+		assert.False(t, cp.Cards[0].Critical)
+	})
+
+	t.Run("fail - cycle", func(t *testing.T) {
+		a, b := pointsCard(intPtr(1)), pointsCard(intPtr(1))
+
+		_, err := computeCriticalPath([]*card.Card{a, b}, []*card_dependency.CardDependency{blocks(a, b), blocks(b, a)}, nil)
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+	})
+}
@@ -0,0 +1,313 @@
+package epic
+
+//go:generate mockgen -source=epic_service.go -destination=mocks/epic_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEpicNotFound      = errors.New("epic not found")
+	ErrProjectNotFound   = errors.New("project not found")
+	ErrCardNotFound      = errors.New("card not found")
+	ErrNameRequired      = errors.New("epic name is required")
+	ErrDifferentProjects = errors.New("the card and the epic belong to different projects")
+	ErrDependencyCycle   = errors.New("the epic's cards block each other in a cycle")
+)
+
+// UpdateEpicInput holds the epic fields to change; nil fields are left as they are
+type UpdateEpicInput struct {
+	Name        *string
+	Description *string
+}
+
+// Progress rolls up how much of an epic's work is done
+type Progress struct {
+	TotalCards           int
+	CompletedCards       int
+	TotalStoryPoints     int
+	CompletedStoryPoints int
+}
+
+type Service interface {
+	CreateEpic(ctx context.Context, projectID uuid.UUID, name, description string, createdBy uuid.UUID) (*epic.Epic, error)
+	GetEpic(ctx context.Context, id uuid.UUID) (*epic.Epic, error)
+	GetProjectEpics(ctx context.Context, projectID uuid.UUID) ([]*epic.Epic, error)
+	UpdateEpic(ctx context.Context, id uuid.UUID, input UpdateEpicInput) (*epic.Epic, error)
+	// DeleteEpic deletes the epic and leaves its cards without an epic
+	DeleteEpic(ctx context.Context, id uuid.UUID) error
+	GetEpicCards(ctx context.Context, epicID uuid.UUID) ([]*card.Card, error)
+	// GetEpicProgress counts the epic's cards and story points, and those in done columns
+	GetEpicProgress(ctx context.Context, epicID uuid.UUID) (*Progress, error)
+	// SetCardEpic assigns a card to an epic of its project, or removes it from its epic
+	// when epicID is nil
+	SetCardEpic(ctx context.Context, cardID uuid.UUID, epicID *uuid.UUID) (*card.Card, error)
+	// GetCriticalPath schedules the epic's cards along their blocking links and finds
+	// the chain that determines when the epic can be done
+	GetCriticalPath(ctx context.Context, epicID uuid.UUID) (*CriticalPath, error)
+}
+
+type service struct {
+	epicRepo       epic.Repository
+	projectRepo    project.Repository
+	cardRepo       card.Repository
+	boardRepo      board.Repository
+	columnRepo     board_column.Repository
+	dependencyRepo card_dependency.Repository
+}
+
+func NewService(
+	epicRepo epic.Repository,
+	projectRepo project.Repository,
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	dependencyRepo card_dependency.Repository,
+) Service {
+	return &service{
+		epicRepo:       epicRepo,
+		projectRepo:    projectRepo,
+		cardRepo:       cardRepo,
+		boardRepo:      boardRepo,
+		columnRepo:     columnRepo,
+		dependencyRepo: dependencyRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "epic.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "epic"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateEpic(ctx context.Context, projectID uuid.UUID, name, description string, createdBy uuid.UUID) (*epic.Epic, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateEpic")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	e := &epic.Epic{
+		ProjectID:   projectID,
+		Name:        name,
+		Description: description,
+		CreatedBy:   &createdBy,
+	}
+	if err := s.epicRepo.Create(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *service) GetEpic(ctx context.Context, id uuid.UUID) (*epic.Epic, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetEpic")
+	span.SetAttributes(attribute.String("epic.id", id.String()))
+	defer span.End()
+
+	e, err := s.epicRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEpicNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *service) GetProjectEpics(ctx context.Context, projectID uuid.UUID) ([]*epic.Epic, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProjectEpics")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	return s.epicRepo.GetByProjectID(ctx, projectID)
+}
+
+func (s *service) UpdateEpic(ctx context.Context, id uuid.UUID, input UpdateEpicInput) (*epic.Epic, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateEpic")
+	span.SetAttributes(attribute.String("epic.id", id.String()))
+	defer span.End()
+
+	e, err := s.GetEpic(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, ErrNameRequired
+		}
+		e.Name = name
+	}
+	if input.Description != nil {
+		e.Description = *input.Description
+	}
+
+	if err := s.epicRepo.Update(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *service) DeleteEpic(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeleteEpic")
+	span.SetAttributes(attribute.String("epic.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetEpic(ctx, id); err != nil {
+		return err
+	}
+	return s.epicRepo.Delete(ctx, id)
+}
+
+func (s *service) GetEpicCards(ctx context.Context, epicID uuid.UUID) ([]*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetEpicCards")
+	span.SetAttributes(attribute.String("epic.id", epicID.String()))
+	defer span.End()
+
+	return s.epicRepo.GetCards(ctx, epicID)
+}
+
+func (s *service) SetCardEpic(ctx context.Context, cardID uuid.UUID, epicID *uuid.UUID) (*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetCardEpic")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	if epicID != nil {
+		e, err := s.GetEpic(ctx, *epicID)
+		if err != nil {
+			return nil, err
+		}
+		b, err := s.boardRepo.GetByID(ctx, c.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if b.ProjectID != e.ProjectID {
+			return nil, ErrDifferentProjects
+		}
+	}
+
+	if err := s.epicRepo.SetCardEpic(ctx, cardID, epicID); err != nil {
+		return nil, err
+	}
+	c.EpicID = epicID
+	return c, nil
+}
+
+func (s *service) GetCriticalPath(ctx context.Context, epicID uuid.UUID) (*CriticalPath, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCriticalPath")
+	span.SetAttributes(attribute.String("epic.id", epicID.String()))
+	defer span.End()
+
+	e, err := s.GetEpic(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.epicRepo.GetCards(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.dependencyRepo.GetByProjectID(ctx, e.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.doneCards(ctx, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeCriticalPath(cards, links, done)
+}
+
+func (s *service) GetEpicProgress(ctx context.Context, epicID uuid.UUID) (*Progress, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetEpicProgress")
+	span.SetAttributes(attribute.String("epic.id", epicID.String()))
+	defer span.End()
+
+	cards, err := s.epicRepo.GetCards(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.doneCards(ctx, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &Progress{TotalCards: len(cards)}
+	for _, c := range cards {
+		points := 0
+		if c.StoryPoints != nil {
+			points = *c.StoryPoints
+		}
+		progress.TotalStoryPoints += points
+		if done[c.ID] {
+			progress.CompletedCards++
+			progress.CompletedStoryPoints += points
+		}
+	}
+	return progress, nil
+}
+
+// doneCards returns the IDs of the cards that are in a done column
+func (s *service) doneCards(ctx context.Context, cards []*card.Card) (map[uuid.UUID]bool, error) {
+	doneColumns := make(map[uuid.UUID]bool)
+	loadedBoards := make(map[uuid.UUID]bool)
+	done := make(map[uuid.UUID]bool)
+	for _, c := range cards {
+		if !loadedBoards[c.BoardID] {
+			columns, err := s.columnRepo.GetByBoardID(ctx, c.BoardID)
+			if err != nil {
+				return nil, err
+			}
+			for _, col := range columns {
+				if col.IsDone {
+					doneColumns[col.ID] = true
+				}
+			}
+			loadedBoards[c.BoardID] = true
+		}
+		if doneColumns[c.ColumnID] {
+			done[c.ID] = true
+		}
+	}
+	return done, nil
+}
@@ -0,0 +1,248 @@
+package epic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependencyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
+	epicMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic/mocks"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateEpic(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("fail - name required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		_, err := svc.CreateEpic(ctx, projectID, "  ", "", uuid.New())
+		assert.ErrorIs(t, err, ErrNameRequired)
+	})
+
+	t.Run("fail - project not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateEpic(ctx, projectID, "Checkout", "", uuid.New())
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+}
+
+func TestUpdateEpic(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - only the given fields change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		e := &epic.Epic{ID: uuid.New(), Name: "Checkout", Description: "Pay for orders"}
+		name := " Payments "
+		mockEpicRepo.EXPECT().GetByID(gomock.Any(), e.ID).Return(e, nil)
+		mockEpicRepo.EXPECT().Update(gomock.Any(), e).Return(nil)
+
+		updated, err := svc.UpdateEpic(ctx, e.ID, UpdateEpicInput{Name: &name})
+		require.NoError(t, err)
+		assert.Equal(t, "Payments", updated.Name)
+		assert.Equal(t, "Pay for orders", updated.Description)
+	})
+
+	t.Run("fail - name required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		e := &epic.Epic{ID: uuid.New(), Name: "Checkout"}
+		name := ""
+		mockEpicRepo.EXPECT().GetByID(gomock.Any(), e.ID).Return(e, nil)
+
+		_, err := svc.UpdateEpic(ctx, e.ID, UpdateEpicInput{Name: &name})
+		assert.ErrorIs(t, err, ErrNameRequired)
+	})
+}
+
+func TestDeleteEpic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+	id := uuid.New()
+	mockEpicRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+	err := svc.DeleteEpic(context.Background(), id)
+	assert.ErrorIs(t, err, ErrEpicNotFound)
+}
+
+func TestSetCardEpic(t *testing.T) {
+	ctx := context.Background()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	c := &card.Card{ID: uuid.New(), BoardID: b.ID}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		e := &epic.Epic{ID: uuid.New(), ProjectID: b.ProjectID}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockEpicRepo.EXPECT().GetByID(gomock.Any(), e.ID).Return(e, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockEpicRepo.EXPECT().SetCardEpic(gomock.Any(), c.ID, &e.ID).Return(nil)
+
+		updated, err := svc.SetCardEpic(ctx, c.ID, &e.ID)
+		require.NoError(t, err)
+		assert.Equal(t, e.ID, *updated.EpicID)
+	})
+
+	t.Run("fail - epic of another project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+		e := &epic.Epic{ID: uuid.New(), ProjectID: uuid.New()}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		mockEpicRepo.EXPECT().GetByID(gomock.Any(), e.ID).Return(e, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+
+		_, err := svc.SetCardEpic(ctx, c.ID, &e.ID)
+		assert.ErrorIs(t, err, ErrDifferentProjects)
+	})
+}
+
+func TestGetCriticalPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+	e := &epic.Epic{ID: uuid.New(), ProjectID: uuid.New()}
+	boardID := uuid.New()
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, IsDone: true}
+	finished := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: done.ID, StoryPoints: intPtr(5)}
+	open := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: todo.ID, StoryPoints: intPtr(3)}
+
+	mockEpicRepo.EXPECT().GetByID(gomock.Any(), e.ID).Return(e, nil)
+	mockEpicRepo.EXPECT().GetCards(gomock.Any(), e.ID).Return([]*card.Card{finished, open}, nil)
+	mockDependencyRepo.EXPECT().GetByProjectID(gomock.Any(), e.ProjectID).Return([]*card_dependency.CardDependency{blocks(finished, open)}, nil)
+	mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{todo, done}, nil)
+
+	cp, err := svc.GetCriticalPath(context.Background(), e.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, cp.Length)
+	assert.Equal(t, 0, cp.Cards[0].Remaining)
+	assert.Equal(t, 3, cp.Cards[1].Remaining)
+}
+
+func TestGetEpicProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEpicRepo := epicMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockEpicRepo, mockProjectRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockDependencyRepo)
+
+	epicID := uuid.New()
+	boardID := uuid.New()
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, IsDone: true}
+	cards := []*card.Card{
+		{ID: uuid.New(), BoardID: boardID, ColumnID: done.ID, StoryPoints: intPtr(5)},
+		{ID: uuid.New(), BoardID: boardID, ColumnID: done.ID},
+		{ID: uuid.New(), BoardID: boardID, ColumnID: todo.ID, StoryPoints: intPtr(3)},
+	}
+
+	mockEpicRepo.EXPECT().GetCards(gomock.Any(), epicID).Return(cards, nil)
+	mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{todo, done}, nil)
+
+	progress, err := svc.GetEpicProgress(context.Background(), epicID)
+	require.NoError(t, err)
+	assert.Equal(t, &Progress{TotalCards: 3, CompletedCards: 2, TotalStoryPoints: 8, CompletedStoryPoints: 5}, progress)
+}
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: epic_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=epic_service.go -destination=mocks/epic_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	epic "github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
+	epic0 "github.com/thatcatdev/kaimu/backend/internal/services/epic"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateEpic mocks base method.
+func (m *MockService) CreateEpic(ctx context.Context, projectID uuid.UUID, name, description string, createdBy uuid.UUID) (*epic.Epic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEpic", ctx, projectID, name, description, createdBy)
+	ret0, _ := ret[0].(*epic.Epic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEpic indicates an expected call of CreateEpic.
+func (mr *MockServiceMockRecorder) CreateEpic(ctx, projectID, name, description, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEpic", reflect.TypeOf((*MockService)(nil).CreateEpic), ctx, projectID, name, description, createdBy)
+}
+
+// DeleteEpic mocks base method.
+func (m *MockService) DeleteEpic(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEpic", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEpic indicates an expected call of DeleteEpic.
+func (mr *MockServiceMockRecorder) DeleteEpic(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEpic", reflect.TypeOf((*MockService)(nil).DeleteEpic), ctx, id)
+}
+
+// GetCriticalPath mocks base method.
+func (m *MockService) GetCriticalPath(ctx context.Context, epicID uuid.UUID) (*epic0.CriticalPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCriticalPath", ctx, epicID)
+	ret0, _ := ret[0].(*epic0.CriticalPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCriticalPath indicates an expected call of GetCriticalPath.
+func (mr *MockServiceMockRecorder) GetCriticalPath(ctx, epicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCriticalPath", reflect.TypeOf((*MockService)(nil).GetCriticalPath), ctx, epicID)
+}
+
+// GetEpic mocks base method.
+func (m *MockService) GetEpic(ctx context.Context, id uuid.UUID) (*epic.Epic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpic", ctx, id)
+	ret0, _ := ret[0].(*epic.Epic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpic indicates an expected call of GetEpic.
+func (mr *MockServiceMockRecorder) GetEpic(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpic", reflect.TypeOf((*MockService)(nil).GetEpic), ctx, id)
+}
+
+// GetEpicCards mocks base method.
+func (m *MockService) GetEpicCards(ctx context.Context, epicID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpicCards", ctx, epicID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpicCards indicates an expected call of GetEpicCards.
+func (mr *MockServiceMockRecorder) GetEpicCards(ctx, epicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpicCards", reflect.TypeOf((*MockService)(nil).GetEpicCards), ctx, epicID)
+}
+
+// GetEpicProgress mocks base method.
+func (m *MockService) GetEpicProgress(ctx context.Context, epicID uuid.UUID) (*epic0.Progress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpicProgress", ctx, epicID)
+	ret0, _ := ret[0].(*epic0.Progress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpicProgress indicates an expected call of GetEpicProgress.
+func (mr *MockServiceMockRecorder) GetEpicProgress(ctx, epicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpicProgress", reflect.TypeOf((*MockService)(nil).GetEpicProgress), ctx, epicID)
+}
+
+// GetProjectEpics mocks base method.
+func (m *MockService) GetProjectEpics(ctx context.Context, projectID uuid.UUID) ([]*epic.Epic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectEpics", ctx, projectID)
+	ret0, _ := ret[0].([]*epic.Epic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectEpics indicates an expected call of GetProjectEpics.
+func (mr *MockServiceMockRecorder) GetProjectEpics(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectEpics", reflect.TypeOf((*MockService)(nil).GetProjectEpics), ctx, projectID)
+}
+
+// SetCardEpic mocks base method.
+func (m *MockService) SetCardEpic(ctx context.Context, cardID uuid.UUID, epicID *uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardEpic", ctx, cardID, epicID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCardEpic indicates an expected call of SetCardEpic.
+func (mr *MockServiceMockRecorder) SetCardEpic(ctx, cardID, epicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardEpic", reflect.TypeOf((*MockService)(nil).SetCardEpic), ctx, cardID, epicID)
+}
+
+// UpdateEpic mocks base method.
+func (m *MockService) UpdateEpic(ctx context.Context, id uuid.UUID, input epic0.UpdateEpicInput) (*epic.Epic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEpic", ctx, id, input)
+	ret0, _ := ret[0].(*epic.Epic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEpic indicates an expected call of UpdateEpic.
+func (mr *MockServiceMockRecorder) UpdateEpic(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEpic", reflect.TypeOf((*MockService)(nil).UpdateEpic), ctx, id, input)
+}
@@ -0,0 +1,317 @@
+package estimation
+
+//go:generate mockgen -source=estimation_service.go -destination=mocks/estimation_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/estimation_accuracy"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultRangeDays is how far back a report looks when no start is given
+	DefaultRangeDays = 90
+	// MaxRangeDays caps the span of one report
+	MaxRangeDays = 366
+)
+
+var (
+	ErrInvalidRange = errors.New("the range must end after it starts")
+	ErrRangeTooLong = fmt.Errorf("the range can span at most %d days", MaxRangeDays)
+)
+
+// Summary aggregates the accuracy of a set of estimated cards
+type Summary struct {
+	CardCount int
+	// Points is the sum of the cards' original estimates
+	Points int
+	// AverageCycleTimeDays is the mean days from start to completion
+	AverageCycleTimeDays float64
+	// DaysPerPoint is the cycle time the cards took per original point; nil without points
+	DaysPerPoint *float64
+	// MeanDeviation is the mean distance of the cards' ratios from 1, so 0 means every
+	// card took exactly as long as its estimate suggested
+	MeanDeviation *float64
+	// ReestimatedCount is how many cards ended with a different estimate than they started with
+	ReestimatedCount int
+}
+
+// CardAccuracy compares a completed card's original estimate with its cycle time
+type CardAccuracy struct {
+	CardID              uuid.UUID
+	Title               string
+	AssigneeID          *uuid.UUID
+	OriginalStoryPoints int
+	StoryPoints         *int
+	CycleTimeDays       float64
+	// ExpectedDays is the original estimate at the report's overall days per point
+	ExpectedDays float64
+	// Ratio is the cycle time over the expected days: above 1 the card took longer than
+	// its estimate suggested, below 1 it went faster. Nil when nothing was expected.
+	Ratio       *float64
+	CompletedAt time.Time
+}
+
+// AssigneeAccuracy is the accuracy of the cards an assignee completed; AssigneeID is nil
+// for unassigned cards
+type AssigneeAccuracy struct {
+	AssigneeID *uuid.UUID
+	Summary
+}
+
+// PeriodAccuracy is the accuracy of the cards completed in the week starting at Start
+type PeriodAccuracy struct {
+	Start time.Time
+	Summary
+}
+
+// PointAccuracy is how long cards of one original estimate took, for calibrating points
+type PointAccuracy struct {
+	StoryPoints          int
+	CardCount            int
+	AverageCycleTimeDays float64
+}
+
+// Report is a project's estimation accuracy over the cards completed in [From, To)
+type Report struct {
+	ProjectID uuid.UUID
+	From      time.Time
+	To        time.Time
+	Summary
+	// UnestimatedCount is how many completed cards had no original estimate; they are
+	// left out of everything else
+	UnestimatedCount int
+	// Cards are in completion order
+	Cards []CardAccuracy
+	// Assignees are ordered by card count, most first
+	Assignees []AssigneeAccuracy
+	// Periods are the weeks (from Monday, UTC) overlapping the range, oldest first
+	Periods []PeriodAccuracy
+	// Calibration is ordered by story points
+	Calibration []PointAccuracy
+}
+
+type Service interface {
+	// GetReport reports on the project's cards completed between from and to, which
+	// default to the last DefaultRangeDays days
+	GetReport(ctx context.Context, projectID uuid.UUID, from, to *time.Time) (*Report, error)
+}
+
+type service struct {
+	accuracyRepo estimation_accuracy.Repository
+	now          func() time.Time
+}
+
+func NewService(accuracyRepo estimation_accuracy.Repository) Service {
+	return &service{
+		accuracyRepo: accuracyRepo,
+		now:          time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "estimation.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "estimation"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetReport(ctx context.Context, projectID uuid.UUID, from, to *time.Time) (*Report, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetReport")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	end := s.now()
+	if to != nil {
+		end = *to
+	}
+	start := end.AddDate(0, 0, -DefaultRangeDays)
+	if from != nil {
+		start = *from
+	}
+	if !end.After(start) {
+		return nil, ErrInvalidRange
+	}
+	if end.Sub(start) > MaxRangeDays*24*time.Hour {
+		return nil, ErrRangeTooLong
+	}
+
+	samples, err := s.accuracyRepo.GetCompletedSamples(ctx, projectID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("estimation.samples", len(samples)))
+
+	report := &Report{ProjectID: projectID, From: start, To: end}
+
+	var overall tally
+	for _, sample := range samples {
+		if sample.OriginalStoryPoints == nil || *sample.OriginalStoryPoints <= 0 {
+			report.UnestimatedCount++
+			continue
+		}
+		report.Cards = append(report.Cards, CardAccuracy{
+			CardID:              sample.CardID,
+			Title:               sample.Title,
+			AssigneeID:          sample.AssigneeID,
+			OriginalStoryPoints: *sample.OriginalStoryPoints,
+			StoryPoints:         sample.StoryPoints,
+			CycleTimeDays:       max(sample.CompletedAt.Sub(sample.StartedAt).Hours()/24, 0),
+			CompletedAt:         sample.CompletedAt,
+		})
+	}
+
+	// The overall pace sets what each card was expected to take
+	for i := range report.Cards {
+		overall.add(&report.Cards[i], false)
+	}
+	daysPerPoint := overall.daysPerPoint()
+	for i := range report.Cards {
+		c := &report.Cards[i]
+		if daysPerPoint != nil {
+			c.ExpectedDays = float64(c.OriginalStoryPoints) * *daysPerPoint
+		}
+		if c.ExpectedDays > 0 {
+			ratio := c.CycleTimeDays / c.ExpectedDays
+			c.Ratio = &ratio
+		}
+	}
+
+	overall = tally{}
+	assignees := map[uuid.UUID]*tally{}
+	var unassigned tally
+	weeks := map[time.Time]*tally{}
+	points := map[int]*tally{}
+	for i := range report.Cards {
+		c := &report.Cards[i]
+		reestimated := c.StoryPoints == nil || *c.StoryPoints != c.OriginalStoryPoints
+		overall.add(c, reestimated)
+
+		if c.AssigneeID == nil {
+			unassigned.add(c, reestimated)
+		} else {
+			if assignees[*c.AssigneeID] == nil {
+				assignees[*c.AssigneeID] = &tally{}
+			}
+			assignees[*c.AssigneeID].add(c, reestimated)
+		}
+
+		week := weekStart(c.CompletedAt)
+		if weeks[week] == nil {
+			weeks[week] = &tally{}
+		}
+		weeks[week].add(c, reestimated)
+
+		if points[c.OriginalStoryPoints] == nil {
+			points[c.OriginalStoryPoints] = &tally{}
+		}
+		points[c.OriginalStoryPoints].add(c, reestimated)
+	}
+	report.Summary = overall.summary()
+
+	for assigneeID, t := range assignees {
+		id := assigneeID
+		report.Assignees = append(report.Assignees, AssigneeAccuracy{AssigneeID: &id, Summary: t.summary()})
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.CardCount != b.CardCount {
+			return a.CardCount > b.CardCount
+		}
+		return a.AssigneeID.String() < b.AssigneeID.String()
+	})
+	if unassigned.cards > 0 {
+		report.Assignees = append(report.Assignees, AssigneeAccuracy{Summary: unassigned.summary()})
+	}
+
+	for week := weekStart(start); week.Before(end); week = week.AddDate(0, 0, 7) {
+		t := weeks[week]
+		if t == nil {
+			t = &tally{}
+		}
+		report.Periods = append(report.Periods, PeriodAccuracy{Start: week, Summary: t.summary()})
+	}
+
+	for storyPoints, t := range points {
+		report.Calibration = append(report.Calibration, PointAccuracy{
+			StoryPoints:          storyPoints,
+			CardCount:            t.cards,
+			AverageCycleTimeDays: t.cycleDays / float64(t.cards),
+		})
+	}
+	sort.Slice(report.Calibration, func(i, j int) bool {
+		return report.Calibration[i].StoryPoints < report.Calibration[j].StoryPoints
+	})
+
+	return report, nil
+}
+
+// tally accumulates cards into a Summary
+type tally struct {
+	cards       int
+	points      int
+	cycleDays   float64
+	deviation   float64
+	ratios      int
+	reestimated int
+}
+
+func (t *tally) add(c *CardAccuracy, reestimated bool) {
+	t.cards++
+	t.points += c.OriginalStoryPoints
+	t.cycleDays += c.CycleTimeDays
+	if c.Ratio != nil {
+		t.deviation += math.Abs(*c.Ratio - 1)
+		t.ratios++
+	}
+	if reestimated {
+		t.reestimated++
+	}
+}
+
+func (t *tally) daysPerPoint() *float64 {
+	if t.points == 0 {
+		return nil
+	}
+	dpp := t.cycleDays / float64(t.points)
+	return &dpp
+}
+
+func (t *tally) summary() Summary {
+	summary := Summary{
+		CardCount:        t.cards,
+		Points:           t.points,
+		DaysPerPoint:     t.daysPerPoint(),
+		ReestimatedCount: t.reestimated,
+	}
+	if t.cards > 0 {
+		summary.AverageCycleTimeDays = t.cycleDays / float64(t.cards)
+	}
+	if t.ratios > 0 {
+		deviation := t.deviation / float64(t.ratios)
+		summary.MeanDeviation = &deviation
+	}
+	return summary
+}
+
+// weekStart returns the Monday, in UTC, of the week t falls in
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,144 @@
+package estimation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/estimation_accuracy"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/estimation_accuracy/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestGetReport(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	// A Wednesday
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	setup := func(t *testing.T) (*service, *mocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockRepository(ctrl)
+		svc := NewService(repo).(*service)
+		svc.now = func() time.Time { return now }
+		return svc, repo
+	}
+
+	sample := func(assigneeID *uuid.UUID, original, points *int, cycle time.Duration, completedAt time.Time) *estimation_accuracy.Sample {
+		return &estimation_accuracy.Sample{
+			CardID:              uuid.New(),
+			AssigneeID:          assigneeID,
+			OriginalStoryPoints: original,
+			StoryPoints:         points,
+			StartedAt:           completedAt.Add(-cycle),
+			CompletedAt:         completedAt,
+		}
+	}
+
+	t.Run("success - compares estimates with cycle time", func(t *testing.T) {
+		svc, repo := setup(t)
+		from := now.Add(-14 * day)
+
+		samples := []*estimation_accuracy.Sample{
+			// 2 points in 2 days, last week
+			sample(&alice, intPtr(2), intPtr(2), 2*day, now.Add(-8*day)),
+			// 2 points in 6 days, re-estimated to 5
+			sample(&alice, intPtr(2), intPtr(5), 6*day, now.Add(-day)),
+			// 4 points in 4 days, unassigned
+			sample(nil, intPtr(4), intPtr(4), 4*day, now.Add(-day)),
+			// Never estimated
+			sample(&bob, nil, nil, 3*day, now.Add(-day)),
+		}
+		repo.EXPECT().GetCompletedSamples(gomock.Any(), projectID, from, now).Return(samples, nil)
+
+		report, err := svc.GetReport(ctx, projectID, &from, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, report.CardCount)
+		assert.Equal(t, 8, report.Points)
+		assert.Equal(t, 1, report.UnestimatedCount)
+		assert.Equal(t, 1, report.ReestimatedCount)
+		assert.InDelta(t, 4, report.AverageCycleTimeDays, 0.0001)
+		require.NotNil(t, report.DaysPerPoint)
+		assert.InDelta(t, 1.5, *report.DaysPerPoint, 0.0001)
+
+		require.Len(t, report.Cards, 3)
+		assert.InDelta(t, 3, report.Cards[0].ExpectedDays, 0.0001)
+		assert.InDelta(t, 2.0/3, *report.Cards[0].Ratio, 0.0001)
+		assert.InDelta(t, 2, *report.Cards[1].Ratio, 0.0001)
+		assert.InDelta(t, 4.0/6, *report.Cards[2].Ratio, 0.0001)
+		// (1/3 + 1 + 1/3) / 3
+		assert.InDelta(t, 5.0/9, *report.MeanDeviation, 0.0001)
+
+		require.Len(t, report.Assignees, 2)
+		assert.Equal(t, &alice, report.Assignees[0].AssigneeID)
+		assert.Equal(t, 2, report.Assignees[0].CardCount)
+		assert.InDelta(t, 2, *report.Assignees[0].DaysPerPoint, 0.0001)
+		assert.Nil(t, report.Assignees[1].AssigneeID)
+		assert.Equal(t, 1, report.Assignees[1].CardCount)
+
+		// Weeks starting Mon 23 Feb, 2 Mar and 9 Mar
+		require.Len(t, report.Periods, 3)
+		assert.Equal(t, time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), report.Periods[0].Start)
+		assert.Equal(t, 0, report.Periods[0].CardCount)
+		assert.Equal(t, 1, report.Periods[1].CardCount)
+		assert.Equal(t, 2, report.Periods[2].CardCount)
+
+		require.Len(t, report.Calibration, 2)
+		assert.Equal(t, PointAccuracy{StoryPoints: 2, CardCount: 2, AverageCycleTimeDays: 4}, report.Calibration[0])
+		assert.Equal(t, PointAccuracy{StoryPoints: 4, CardCount: 1, AverageCycleTimeDays: 4}, report.Calibration[1])
+	})
+
+	t.Run("success - defaults to the last 90 days", func(t *testing.T) {
+		svc, repo := setup(t)
+		repo.EXPECT().GetCompletedSamples(gomock.Any(), projectID, now.AddDate(0, 0, -DefaultRangeDays), now).Return(nil, nil)
+
+		report, err := svc.GetReport(ctx, projectID, nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, report.CardCount)
+		assert.Nil(t, report.DaysPerPoint)
+		assert.Nil(t, report.MeanDeviation)
+		assert.Empty(t, report.Cards)
+	})
+
+	t.Run("fail - range ends before it starts", func(t *testing.T) {
+		svc, _ := setup(t)
+		from := now
+		to := now.Add(-day)
+
+		_, err := svc.GetReport(ctx, projectID, &from, &to)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+	})
+
+	t.Run("fail - range too long", func(t *testing.T) {
+		svc, _ := setup(t)
+		from := now.AddDate(-2, 0, 0)
+
+		_, err := svc.GetReport(ctx, projectID, &from, nil)
+		assert.ErrorIs(t, err, ErrRangeTooLong)
+	})
+
+	t.Run("fail - repository error", func(t *testing.T) {
+		svc, repo := setup(t)
+		repo.EXPECT().GetCompletedSamples(gomock.Any(), projectID, gomock.Any(), now).Return(nil, errors.New("db error"))
+
+		_, err := svc.GetReport(ctx, projectID, nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, weekStart(time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)))
+	assert.Equal(t, monday, weekStart(time.Date(2026, 3, 15, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, monday.AddDate(0, 0, 7), weekStart(time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,407 @@
+package export
+
+//go:generate mockgen -source=export_service.go -destination=mocks/export_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStorageDisabled = errors.New("exports need object storage, which is not enabled on this server")
+	ErrInvalidLayout   = errors.New("invalid print layout")
+	ErrSourceRequired  = errors.New("either card IDs or a board is required, not both")
+	ErrNoCards         = errors.New("there are no cards to export")
+	ErrTooManyCards    = errors.New("too many cards to export at once")
+	ErrCardNotFound    = errors.New("card not found")
+	ErrBoardNotFound   = errors.New("board not found")
+)
+
+const (
+	// MaxCards caps how many cards one PDF holds
+	MaxCards = 500
+	// DownloadExpiry is how long the download URL of an export is valid
+	DownloadExpiry = time.Hour
+	// KeyPrefix is where exports are stored; a lifecycle rule on it can remove old ones
+	KeyPrefix = "exports/"
+)
+
+// CardsPDFInput selects the cards to print: the given cards in order, or the cards of a board
+// column by column
+type CardsPDFInput struct {
+	CardIDs []uuid.UUID
+	BoardID *uuid.UUID
+	Layout  Layout
+}
+
+// Download is a stored export
+type Download struct {
+	URL       string
+	ExpiresAt time.Time
+	CardCount int
+	PageCount int
+}
+
+type Service interface {
+	// ExportCardsPDF renders the cards as printable sheets, stores the PDF and returns a URL
+	// to download it from. Archived cards are printed when asked for by ID.
+	ExportCardsPDF(ctx context.Context, input CardsPDFInput) (*Download, error)
+}
+
+type service struct {
+	cardRepo    card.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	projectRepo project.Repository
+	cardTagRepo card_tag.Repository
+	tagRepo     tag.Repository
+	userRepo    user.Repository
+	store       storage.Store
+}
+
+// NewService returns the export service; store is nil when object storage is not configured
+func NewService(
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	projectRepo project.Repository,
+	cardTagRepo card_tag.Repository,
+	tagRepo tag.Repository,
+	userRepo user.Repository,
+	store storage.Store,
+) Service {
+	return &service{
+		cardRepo:    cardRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		projectRepo: projectRepo,
+		cardTagRepo: cardTagRepo,
+		tagRepo:     tagRepo,
+		userRepo:    userRepo,
+		store:       store,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "export.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "export"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) ExportCardsPDF(ctx context.Context, input CardsPDFInput) (*Download, error) {
+	ctx, span := s.startServiceSpan(ctx, "ExportCardsPDF")
+	span.SetAttributes(attribute.String("export.layout", string(input.Layout)))
+	defer span.End()
+
+	if !input.Layout.IsValid() {
+		return nil, ErrInvalidLayout
+	}
+	if (len(input.CardIDs) == 0) == (input.BoardID == nil) {
+		return nil, ErrSourceRequired
+	}
+	if s.store == nil {
+		return nil, ErrStorageDisabled
+	}
+
+	l := newLoader(s)
+	var cards []*card.Card
+	var err error
+	if input.BoardID != nil {
+		cards, err = l.boardCards(ctx, *input.BoardID)
+	} else {
+		cards, err = l.cards(ctx, input.CardIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, ErrNoCards
+	}
+	if len(cards) > MaxCards {
+		return nil, ErrTooManyCards
+	}
+	span.SetAttributes(attribute.Int("export.cards", len(cards)))
+
+	sheet, err := l.sheet(ctx, cards, input.Layout)
+	if err != nil {
+		return nil, err
+	}
+	pdf, pages, err := Render(*sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	key := KeyPrefix + uuid.NewString() + "/cards.pdf"
+	if err := s.store.Put(ctx, key, "application/pdf", pdf); err != nil {
+		return nil, fmt.Errorf("storing export: %w", err)
+	}
+	url, err := s.store.PresignGet(ctx, key, "cards.pdf", DownloadExpiry)
+	if err != nil {
+		return nil, err
+	}
+	return &Download{
+		URL:       url,
+		ExpiresAt: sheet.GeneratedAt.Add(DownloadExpiry),
+		CardCount: len(cards),
+		PageCount: pages,
+	}, nil
+}
+
+// loader looks up the boards, columns, projects and users the printed cards refer to, once
+// each
+type loader struct {
+	s        *service
+	boards   map[uuid.UUID]*board.Board
+	columns  map[uuid.UUID][]*board_column.BoardColumn
+	projects map[uuid.UUID]*project.Project
+	users    map[uuid.UUID]string
+}
+
+func newLoader(s *service) *loader {
+	return &loader{
+		s:        s,
+		boards:   make(map[uuid.UUID]*board.Board),
+		columns:  make(map[uuid.UUID][]*board_column.BoardColumn),
+		projects: make(map[uuid.UUID]*project.Project),
+		users:    make(map[uuid.UUID]string),
+	}
+}
+
+// boardCards returns the board's cards column by column, in the order the board shows them
+func (l *loader) boardCards(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	if _, err := l.board(ctx, boardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	cards, err := l.s.cardRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := l.boardColumns(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	order := make(map[uuid.UUID]int, len(columns))
+	for _, col := range columns {
+		order[col.ID] = col.Position
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		if order[cards[i].ColumnID] != order[cards[j].ColumnID] {
+			return order[cards[i].ColumnID] < order[cards[j].ColumnID]
+		}
+		return cards[i].Position < cards[j].Position
+	})
+	return cards, nil
+}
+
+// cards returns the cards in the order given, each once
+func (l *loader) cards(ctx context.Context, ids []uuid.UUID) ([]*card.Card, error) {
+	if len(ids) > MaxCards {
+		return nil, ErrTooManyCards
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	cards := make([]*card.Card, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		c, err := l.s.cardRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCardNotFound
+			}
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// sheet describes the cards for printing, titled after their board when they share one
+func (l *loader) sheet(ctx context.Context, cards []*card.Card, layout Layout) (*Sheet, error) {
+	tags, err := l.tagNames(ctx, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &Sheet{Layout: layout, Cards: make([]Card, len(cards)), GeneratedAt: time.Now()}
+	boardIDs := make(map[uuid.UUID]bool)
+	for i, c := range cards {
+		boardIDs[c.BoardID] = true
+		b, err := l.board(ctx, c.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		p, err := l.project(ctx, b.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		column, err := l.columnName(ctx, c.BoardID, c.ColumnID)
+		if err != nil {
+			return nil, err
+		}
+		assignee, err := l.username(ctx, c.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		sheet.Cards[i] = Card{
+			Key:         cardService.Key(p.Key, c.Number),
+			Column:      column,
+			Title:       c.Title,
+			Description: sanitize.PlainText(c.Description),
+			Priority:    string(c.Priority),
+			StoryPoints: c.StoryPoints,
+			DueDate:     c.DueDate,
+			Assignee:    assignee,
+			Tags:        tags[c.ID],
+		}
+	}
+
+	sheet.Title = "Cards"
+	if len(boardIDs) == 1 {
+		b := l.boards[cards[0].BoardID]
+		sheet.Title = l.projects[b.ProjectID].Name + " · " + b.Name
+	}
+	return sheet, nil
+}
+
+// tagNames returns the names of each card's tags, sorted
+func (l *loader) tagNames(ctx context.Context, cards []*card.Card) (map[uuid.UUID][]string, error) {
+	cardIDs := make([]uuid.UUID, len(cards))
+	for i, c := range cards {
+		cardIDs[i] = c.ID
+	}
+	cardTags, err := l.s.cardTagRepo.GetByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(cardTags) == 0 {
+		return map[uuid.UUID][]string{}, nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var tagIDs []uuid.UUID
+	for _, ct := range cardTags {
+		if !seen[ct.TagID] {
+			seen[ct.TagID] = true
+			tagIDs = append(tagIDs, ct.TagID)
+		}
+	}
+	tags, err := l.s.tagRepo.GetByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(tags))
+	for _, t := range tags {
+		names[t.ID] = t.Name
+	}
+
+	byCard := make(map[uuid.UUID][]string)
+	for _, ct := range cardTags {
+		if name, ok := names[ct.TagID]; ok {
+			byCard[ct.CardID] = append(byCard[ct.CardID], name)
+		}
+	}
+	for _, n := range byCard {
+		sort.Strings(n)
+	}
+	return byCard, nil
+}
+
+func (l *loader) board(ctx context.Context, id uuid.UUID) (*board.Board, error) {
+	if b, ok := l.boards[id]; ok {
+		return b, nil
+	}
+	b, err := l.s.boardRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	l.boards[id] = b
+	return b, nil
+}
+
+func (l *loader) project(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	if p, ok := l.projects[id]; ok {
+		return p, nil
+	}
+	p, err := l.s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	l.projects[id] = p
+	return p, nil
+}
+
+func (l *loader) boardColumns(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error) {
+	if columns, ok := l.columns[boardID]; ok {
+		return columns, nil
+	}
+	columns, err := l.s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	l.columns[boardID] = columns
+	return columns, nil
+}
+
+func (l *loader) columnName(ctx context.Context, boardID, columnID uuid.UUID) (string, error) {
+	columns, err := l.boardColumns(ctx, boardID)
+	if err != nil {
+		return "", err
+	}
+	for _, col := range columns {
+		if col.ID == columnID {
+			return col.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// username returns the user's username, empty for nil IDs and deleted users
+func (l *loader) username(ctx context.Context, id *uuid.UUID) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if name, ok := l.users[*id]; ok {
+		return name, nil
+	}
+	u, err := l.s.userRepo.GetByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			l.users[*id] = ""
+			return "", nil
+		}
+		return "", err
+	}
+	l.users[*id] = u.Username
+	return u.Username, nil
+}
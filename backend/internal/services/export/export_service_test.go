@@ -0,0 +1,225 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	storageMocks "github.com/thatcatdev/kaimu/backend/internal/services/storage/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestExportCardsPDF(t *testing.T) {
+	ctx := context.Background()
+	p := &project.Project{ID: uuid.New(), Name: "Kaimu", Key: "kai"}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID, Name: "Main"}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "To Do", Position: 0}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Done", Position: 1}
+	ana := &user.User{ID: uuid.New(), Username: "ana"}
+	goneID := uuid.New()
+	points := 5
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: p.ID, Name: "bug"}
+
+	shipped := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: done.ID, Number: 2, Title: "Ship it", Position: 1, AssigneeID: &goneID}
+	login := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Number: 1, Title: "Fix login", Description: "<p>Users <b>can't</b> sign in</p>",
+		Position: 2, Priority: card.PriorityHigh, StoryPoints: &points, AssigneeID: &ana.ID}
+
+	t.Run("prints a board column by column", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockCardRepo, mockBoardRepo, mockColumnRepo, mockProjectRepo, mockCardTagRepo, mockTagRepo, mockUserRepo, mockStore)
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*card.Card{shipped, login}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{done, todo}, nil)
+		mockCardTagRepo.EXPECT().GetByCardIDs(gomock.Any(), []uuid.UUID{login.ID, shipped.ID}).
+			Return([]*card_tag.CardTag{{CardID: login.ID, TagID: bug.ID}}, nil)
+		mockTagRepo.EXPECT().GetByIDs(gomock.Any(), []uuid.UUID{bug.ID}).Return([]*tag.Tag{bug}, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), ana.ID).Return(ana, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), goneID).Return(nil, gorm.ErrRecordNotFound)
+
+		var stored []byte
+		var key string
+		mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), "application/pdf", gomock.Any()).
+			DoAndReturn(func(_ context.Context, k, _ string, body []byte) error {
+				key, stored = k, body
+				return nil
+			})
+		mockStore.EXPECT().PresignGet(gomock.Any(), gomock.Any(), "cards.pdf", DownloadExpiry).
+			DoAndReturn(func(_ context.Context, k, _ string, _ time.Duration) (string, error) {
+				assert.Equal(t, key, k)
+				return "https://storage.example.com/" + k, nil
+			})
+
+		download, err := svc.ExportCardsPDF(ctx, CardsPDFInput{BoardID: &b.ID, Layout: LayoutCards})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(key, KeyPrefix))
+		assert.Equal(t, "https://storage.example.com/"+key, download.URL)
+		assert.Equal(t, 2, download.CardCount)
+		assert.Equal(t, 1, download.PageCount)
+		assert.WithinDuration(t, time.Now().Add(DownloadExpiry), download.ExpiresAt, time.Minute)
+
+		page := pageContents(t, stored)[0]
+		assert.Less(t, strings.Index(page, "(Fix login)"), strings.Index(page, "(Ship it)"), "cards follow the board's columns")
+		assert.Contains(t, page, "(KAI-1 \\267 To Do)")
+		assert.Contains(t, page, "(Users can't sign in)")
+		assert.Contains(t, page, "(5 pts \\267 High \\267 @ana)")
+		assert.Contains(t, page, "(#bug)")
+		assert.Contains(t, page, "(Kaimu \\267 Main \\267 ")
+	})
+
+	t.Run("prints the given cards in order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockCardRepo, mockBoardRepo, mockColumnRepo, mockProjectRepo, mockCardTagRepo, mockTagRepo, mockUserRepo, mockStore)
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), shipped.ID).Return(shipped, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), login.ID).Return(login, nil)
+		mockCardTagRepo.EXPECT().GetByCardIDs(gomock.Any(), []uuid.UUID{shipped.ID, login.ID}).Return(nil, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{todo, done}, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), goneID).Return(nil, gorm.ErrRecordNotFound)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), ana.ID).Return(ana, nil)
+
+		var stored []byte
+		mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), "application/pdf", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, body []byte) error {
+				stored = body
+				return nil
+			})
+		mockStore.EXPECT().PresignGet(gomock.Any(), gomock.Any(), "cards.pdf", DownloadExpiry).Return("https://storage.example.com/x", nil)
+
+		download, err := svc.ExportCardsPDF(ctx, CardsPDFInput{
+			CardIDs: []uuid.UUID{shipped.ID, login.ID, shipped.ID},
+			Layout:  LayoutList,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, download.CardCount)
+
+		page := pageContents(t, stored)[0]
+		assert.Contains(t, page, "(2 cards)")
+		assert.Less(t, strings.Index(page, "(Ship it)"), strings.Index(page, "(Fix login)"))
+	})
+
+	t.Run("rejects bad input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockCardRepo, mockBoardRepo, mockColumnRepo, mockProjectRepo, mockCardTagRepo, mockTagRepo, mockUserRepo, mockStore)
+
+		_, err := svc.ExportCardsPDF(ctx, CardsPDFInput{BoardID: &b.ID, Layout: "poster"})
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+
+		_, err = svc.ExportCardsPDF(ctx, CardsPDFInput{Layout: LayoutCards})
+		assert.ErrorIs(t, err, ErrSourceRequired)
+
+		_, err = svc.ExportCardsPDF(ctx, CardsPDFInput{CardIDs: []uuid.UUID{login.ID}, BoardID: &b.ID, Layout: LayoutCards})
+		assert.ErrorIs(t, err, ErrSourceRequired)
+
+		ids := make([]uuid.UUID, MaxCards+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+		_, err = svc.ExportCardsPDF(ctx, CardsPDFInput{CardIDs: ids, Layout: LayoutCards})
+		assert.ErrorIs(t, err, ErrTooManyCards)
+	})
+
+	t.Run("reports missing cards and empty boards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockCardRepo, mockBoardRepo, mockColumnRepo, mockProjectRepo, mockCardTagRepo, mockTagRepo, mockUserRepo, mockStore)
+
+		missing := uuid.New()
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), missing).Return(nil, gorm.ErrRecordNotFound)
+		_, err := svc.ExportCardsPDF(ctx, CardsPDFInput{CardIDs: []uuid.UUID{missing}, Layout: LayoutCards})
+		assert.ErrorIs(t, err, ErrCardNotFound)
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return(nil, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return(nil, nil)
+		_, err = svc.ExportCardsPDF(ctx, CardsPDFInput{BoardID: &b.ID, Layout: LayoutStickyNotes})
+		assert.ErrorIs(t, err, ErrNoCards)
+	})
+
+	t.Run("needs object storage", func(t *testing.T) {
+		svc := NewService(nil, nil, nil, nil, nil, nil, nil, nil)
+		_, err := svc.ExportCardsPDF(ctx, CardsPDFInput{BoardID: &b.ID, Layout: LayoutCards})
+		assert.ErrorIs(t, err, ErrStorageDisabled)
+	})
+}
+
+func TestRender(t *testing.T) {
+	cards := make([]Card, 13)
+	for i := range cards {
+		cards[i] = Card{Key: fmt.Sprintf("KAI-%d", i+1), Title: "Card"}
+	}
+
+	for layout, pages := range map[Layout]int{LayoutCards: 2, LayoutStickyNotes: 2, LayoutList: 1} {
+		_, n, err := Render(Sheet{Title: "Board", Layout: layout, Cards: cards, GeneratedAt: time.Now()})
+		require.NoError(t, err)
+		assert.Equal(t, pages, n, layout)
+	}
+
+	many := make([]Card, 60)
+	for i := range many {
+		many[i] = Card{Title: "Row"}
+	}
+	pdf, n, err := Render(Sheet{Title: "Review", Layout: LayoutList, Cards: many, GeneratedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	contents := pageContents(t, pdf)
+	for _, page := range contents {
+		assert.Contains(t, page, "(Title) Tj", "each page repeats the table head")
+	}
+	assert.Contains(t, contents[2], "(Page 3 of 3)")
+}
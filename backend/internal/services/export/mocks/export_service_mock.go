@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=export_service.go -destination=mocks/export_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	export "github.com/thatcatdev/kaimu/backend/internal/services/export"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportCardsPDF mocks base method.
+func (m *MockService) ExportCardsPDF(ctx context.Context, input export.CardsPDFInput) (*export.Download, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCardsPDF", ctx, input)
+	ret0, _ := ret[0].(*export.Download)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCardsPDF indicates an expected call of ExportCardsPDF.
+func (mr *MockServiceMockRecorder) ExportCardsPDF(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCardsPDF", reflect.TypeOf((*MockService)(nil).ExportCardsPDF), ctx, input)
+}
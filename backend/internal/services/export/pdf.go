@@ -0,0 +1,221 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/thatcatdev/kaimu/backend/internal/textlayout"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 in points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+)
+
+type font int
+
+const (
+	regular font = iota
+	bold
+)
+
+// resourceName is the name a page's resources give the font
+func (f font) resourceName() string {
+	if f == bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Widths of the printable ASCII characters in thousandths of the font size, from the
+// metrics of the standard Helvetica fonts
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// defaultWidth is used for characters outside printable ASCII, most of which are accented
+// letters about as wide as a lower case letter
+const defaultWidth = 556
+
+// encode converts text to the Windows-1252 bytes the fonts are encoded with. Characters it
+// lacks become question marks and control characters, such as line breaks, spaces.
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			out = append(out, ' ')
+			continue
+		}
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// textWidth returns how wide text is in points at size
+func textWidth(s string, f font, size float64) float64 {
+	widths := &helveticaWidths
+	if f == bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range encode(s) {
+		if b >= 32 && b < 127 {
+			total += widths[b-32]
+		} else {
+			total += defaultWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// wrap breaks text into lines at most width wide, ending text cut short with "..."
+func wrap(s string, f font, size, width float64, maxLines int) []string {
+	return textlayout.Wrap(s, measure(f, size), width, maxLines, "...")
+}
+
+// truncate shortens text to one line at most width wide
+func truncate(s string, f font, size, width float64) string {
+	return textlayout.Truncate(s, measure(f, size), width, "...")
+}
+
+func measure(f font, size float64) textlayout.Measure {
+	return func(s string) float64 { return textWidth(s, f, size) }
+}
+
+// document builds a PDF of A4 pages drawn with the standard Helvetica fonts, which every
+// viewer provides, so no font has to be embedded. Positions are in points from the top left
+// corner of the page, and text is placed by its baseline.
+type document struct {
+	title string
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+}
+
+func newDocument(title string) *document {
+	return &document{title: title}
+}
+
+func (d *document) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+// text draws one line of text in black, or in gray when gray is above 0
+func (d *document) text(x, y float64, f font, size, gray float64, s string) {
+	fmt.Fprintf(d.page, "BT %s g /%s %s Tf %s %s Td (%s) Tj ET\n",
+		num(gray), f.resourceName(), num(size), num(x), num(pageHeight-y), escape(encode(s)))
+}
+
+// rect outlines a rectangle whose top left corner is at x, y
+func (d *document) rect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(d.page, "%s w 0 G %s %s %s %s re S\n", num(lineWidth), num(x), num(pageHeight-y-h), num(w), num(h))
+}
+
+// fillRect fills a rectangle with a shade of gray, 0 being black and 1 white
+func (d *document) fillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.page, "%s g %s %s %s %s re f\n", num(gray), num(x), num(pageHeight-y-h), num(w), num(h))
+}
+
+func (d *document) line(x1, y1, x2, y2, lineWidth float64) {
+	fmt.Fprintf(d.page, "%s w 0 G %s %s m %s %s l S\n", num(lineWidth), num(x1), num(pageHeight-y1), num(x2), num(pageHeight-y2))
+}
+
+// bytes writes out the document
+func (d *document) bytes() ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 to 4 are the catalog, page tree, fonts and document information, followed by
+	// each page and its contents
+	const firstPage = 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 %s %s] >>",
+		strings.Join(kids, " "), len(d.pages), num(pageWidth), num(pageHeight)))
+	object("<< /F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >> " +
+		"/F2 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >> >>")
+	object(fmt.Sprintf("<< /Title (%s) >>", escape(encode(d.title))))
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Resources << /Font 3 0 R >> /Contents %d 0 R >>", firstPage+2*i+1))
+
+		var compressed bytes.Buffer
+		w := zlib.NewWriter(&compressed)
+		if _, err := w.Write(page.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
+
+// escape quotes bytes for a PDF string literal, writing those outside ASCII as octal
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '\\' || c == '(' || c == ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// num formats a coordinate to two decimal places, without trailing zeros
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	t.Run("breaks lines at spaces", func(t *testing.T) {
+		width := textWidth("alpha beta", regular, 10)
+		assert.Equal(t, []string{"alpha beta", "gamma"}, wrap("alpha  beta\ngamma", regular, 10, width, 5))
+	})
+
+	t.Run("breaks words wider than a line", func(t *testing.T) {
+		width := textWidth("abcd", regular, 10)
+		assert.Equal(t, []string{"abcd", "abcd", "ab"}, wrap("abcdabcdab", regular, 10, width, 5))
+	})
+
+	t.Run("ends text cut short with an ellipsis", func(t *testing.T) {
+		width := textWidth("one two", regular, 10)
+		lines := wrap("one two three four", regular, 10, width, 1)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "one t...", lines[0])
+		assert.LessOrEqual(t, textWidth(lines[0], regular, 10), width)
+	})
+
+	t.Run("empty text has no lines", func(t *testing.T) {
+		assert.Empty(t, wrap("  ", regular, 10, 100, 3))
+		assert.Equal(t, "", truncate("", regular, 10, 100))
+	})
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.InDelta(t, 5.56, textWidth("a", regular, 10), 0.001)
+	assert.InDelta(t, 6.11, textWidth("b", bold, 10), 0.001)
+	assert.Greater(t, textWidth("WWW", regular, 10), textWidth("iii", regular, 10))
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, []byte("caf\xe9 ?\x80 a b"), encode("café 日€ a\nb"))
+	assert.Equal(t, `a\(b\)\\ \351`, escape([]byte("a(b)\\ \xe9")))
+}
+
+func TestDocument(t *testing.T) {
+	d := newDocument("Sprint (12)")
+	d.addPage()
+	d.text(36, 50, bold, 12, 0, "First page")
+	d.addPage()
+	d.rect(36, 36, 100, 50, 1)
+	d.text(36, 50, regular, 10, 0.5, "Second page")
+
+	pdf, err := d.bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count 2")
+	assert.Contains(t, string(pdf), `/Title (Sprint \(12\))`)
+
+	// Every entry of the cross-reference table points at its object
+	xref := regexp.MustCompile(`(?s)startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, xref)
+	start, err := strconv.Atoi(string(xref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[start:], []byte("xref\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[start:], -1)
+	require.Len(t, offsets, 8)
+	for i, m := range offsets {
+		offset, err := strconv.Atoi(string(m[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+
+	contents := pageContents(t, pdf)
+	require.Len(t, contents, 2)
+	assert.Contains(t, contents[0], "/F2 12 Tf 36 792 Td (First page) Tj")
+	assert.Contains(t, contents[1], "36 756 100 50 re S")
+	assert.Contains(t, contents[1], "0.5 g /F1 10 Tf 36 792 Td (Second page) Tj")
+}
+
+// pageContents returns the inflated content stream of each page
+func pageContents(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	var contents []string
+	streams := regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	for _, loc := range streams.FindAllSubmatchIndex(pdf, -1) {
+		length, err := strconv.Atoi(string(pdf[loc[2]:loc[3]]))
+		require.NoError(t, err)
+		r, err := zlib.NewReader(bytes.NewReader(pdf[loc[1] : loc[1]+length]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		contents = append(contents, string(data))
+	}
+	return contents
+}
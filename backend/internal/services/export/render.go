@@ -0,0 +1,261 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is how cards are arranged on printed pages
+type Layout string
+
+const (
+	// LayoutCards prints eight index cards a page, with their descriptions, to cut out for a
+	// physical board
+	LayoutCards Layout = "cards"
+	// LayoutStickyNotes prints twelve square notes a page, showing little more than the title
+	LayoutStickyNotes Layout = "sticky_notes"
+	// LayoutList prints the cards as a table, for reviews
+	LayoutList Layout = "list"
+)
+
+// IsValid reports whether l is a known layout
+func (l Layout) IsValid() bool {
+	switch l {
+	case LayoutCards, LayoutStickyNotes, LayoutList:
+		return true
+	}
+	return false
+}
+
+// Card is what a printed card shows
+type Card struct {
+	// Key is the card's key, such as API-42
+	Key    string
+	Column string
+	Title  string
+	// Description is plain text
+	Description string
+	Priority    string
+	StoryPoints *int
+	DueDate     *time.Time
+	// Assignee is the username of the assignee, empty for unassigned cards
+	Assignee string
+	Tags     []string
+}
+
+// Sheet is a set of cards to print
+type Sheet struct {
+	Title       string
+	Layout      Layout
+	Cards       []Card
+	GeneratedAt time.Time
+}
+
+const (
+	margin       = 36.0
+	gutter       = 12.0
+	footerHeight = 18.0
+	padding      = 10.0
+	// contentBottom is how far down the page cards and rows may reach
+	contentBottom = pageHeight - margin - footerHeight
+)
+
+// Render lays the sheet's cards out and returns the PDF and its number of pages
+func Render(sheet Sheet) ([]byte, int, error) {
+	d := newDocument(sheet.Title)
+	switch sheet.Layout {
+	case LayoutList:
+		renderList(d, sheet)
+	case LayoutStickyNotes:
+		renderGrid(d, sheet, 3, 4, true)
+	default:
+		renderGrid(d, sheet, 2, 4, false)
+	}
+
+	generated := sheet.GeneratedAt.UTC().Format("2006-01-02 15:04 MST")
+	for i, page := range d.pages {
+		d.page = page
+		d.text(margin, pageHeight-margin, regular, 8, 0.4, truncate(sheet.Title+" · "+generated, regular, 8, 400))
+		label := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		d.text(pageWidth-margin-textWidth(label, regular, 8), pageHeight-margin, regular, 8, 0.4, label)
+	}
+
+	pdf, err := d.bytes()
+	if err != nil {
+		return nil, 0, err
+	}
+	return pdf, len(d.pages), nil
+}
+
+// renderGrid prints cols by rows cards a page, with outlines to cut along. Sticky notes are
+// square and leave descriptions out.
+func renderGrid(d *document, sheet Sheet, cols, rows int, sticky bool) {
+	w := (pageWidth - 2*margin - float64(cols-1)*gutter) / float64(cols)
+	h := (contentBottom - margin - float64(rows-1)*gutter) / float64(rows)
+	if sticky {
+		h = w
+	}
+	perPage := cols * rows
+
+	for i, c := range sheet.Cards {
+		if i%perPage == 0 {
+			d.addPage()
+		}
+		slot := i % perPage
+		x := margin + float64(slot%cols)*(w+gutter)
+		y := margin + float64(slot/cols)*(h+gutter)
+		renderCard(d, c, x, y, w, h, sticky)
+	}
+	if len(sheet.Cards) == 0 {
+		d.addPage()
+	}
+}
+
+func renderCard(d *document, c Card, x, y, w, h float64, sticky bool) {
+	d.rect(x, y, w, h, 0.75)
+	inner := w - 2*padding
+	bottom := y + h - padding
+
+	header := c.Key
+	if c.Column != "" {
+		header += " · " + c.Column
+	}
+	d.text(x+padding, y+padding+7, regular, 8, 0.4, truncate(header, regular, 8, inner))
+
+	titleSize, titleLines := 13.0, 3
+	if sticky {
+		titleSize, titleLines = 15.0, 5
+	}
+	cursor := y + padding + 10
+	for _, line := range wrap(c.Title, bold, titleSize, inner, titleLines) {
+		cursor += titleSize * 1.2
+		d.text(x+padding, cursor, bold, titleSize, 0, line)
+	}
+
+	// The details and tags sit on the bottom lines, and the description fills what is left
+	details := cardDetails(c)
+	footerLines := 1
+	if len(c.Tags) > 0 {
+		footerLines++
+	}
+	d.text(x+padding, bottom, regular, 8, 0.25, truncate(details, regular, 8, inner))
+	if len(c.Tags) > 0 {
+		d.text(x+padding, bottom-10, regular, 8, 0.4, truncate("#"+strings.Join(c.Tags, " #"), regular, 8, inner))
+	}
+
+	if sticky || c.Description == "" {
+		return
+	}
+	cursor += 4
+	available := bottom - float64(footerLines)*10 - 4 - cursor
+	if lines := int(available / 11); lines > 0 {
+		for _, line := range wrap(c.Description, regular, 9, inner, lines) {
+			cursor += 11
+			d.text(x+padding, cursor, regular, 9, 0.15, line)
+		}
+	}
+}
+
+// cardDetails summarises a card's estimate, priority, assignee and due date on one line
+func cardDetails(c Card) string {
+	var parts []string
+	if c.StoryPoints != nil {
+		parts = append(parts, strconv.Itoa(*c.StoryPoints)+" pts")
+	}
+	if p := priorityLabel(c.Priority); p != "" {
+		parts = append(parts, p)
+	}
+	if c.Assignee != "" {
+		parts = append(parts, "@"+c.Assignee)
+	}
+	if c.DueDate != nil {
+		parts = append(parts, "Due "+c.DueDate.Format("2006-01-02"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// priorityLabel capitalises a priority, leaving out the lack of one
+func priorityLabel(priority string) string {
+	if priority == "" || priority == "none" {
+		return ""
+	}
+	return strings.ToUpper(priority[:1]) + priority[1:]
+}
+
+// listColumn is a column of the review table; the title column takes the width left over
+type listColumn struct {
+	name  string
+	width float64
+	value func(Card) string
+}
+
+var listColumns = []listColumn{
+	{name: "Column", width: 90, value: func(c Card) string { return c.Column }},
+	{name: "Assignee", width: 80, value: func(c Card) string { return c.Assignee }},
+	{name: "Priority", width: 55, value: func(c Card) string { return priorityLabel(c.Priority) }},
+	{name: "Points", width: 40, value: func(c Card) string {
+		if c.StoryPoints == nil {
+			return ""
+		}
+		return strconv.Itoa(*c.StoryPoints)
+	}},
+	{name: "Due", width: 60, value: func(c Card) string {
+		if c.DueDate == nil {
+			return ""
+		}
+		return c.DueDate.Format("2006-01-02")
+	}},
+}
+
+const (
+	rowHeight       = 30.0
+	tableHeadHeight = 18.0
+	cellPadding     = 4.0
+)
+
+// renderList prints a table of the cards, repeating its head on each page
+func renderList(d *document, sheet Sheet) {
+	titleWidth := pageWidth - 2*margin
+	for _, col := range listColumns {
+		titleWidth -= col.width
+	}
+
+	d.addPage()
+	d.text(margin, margin+16, bold, 16, 0, truncate(sheet.Title, bold, 16, pageWidth-2*margin))
+	d.text(margin, margin+30, regular, 9, 0.4, fmt.Sprintf("%d cards", len(sheet.Cards)))
+	y := tableHead(d, margin+42, titleWidth)
+
+	for _, c := range sheet.Cards {
+		if y+rowHeight > contentBottom {
+			d.addPage()
+			y = tableHead(d, margin, titleWidth)
+		}
+
+		lineY := y + cellPadding + 9
+		for _, line := range wrap(c.Title, regular, 9, titleWidth-2*cellPadding, 2) {
+			d.text(margin+cellPadding, lineY, regular, 9, 0, line)
+			lineY += 11
+		}
+		x := margin + titleWidth
+		for _, col := range listColumns {
+			d.text(x+cellPadding, y+cellPadding+9, regular, 9, 0, truncate(col.value(c), regular, 9, col.width-2*cellPadding))
+			x += col.width
+		}
+		y += rowHeight
+		d.line(margin, y, pageWidth-margin, y, 0.25)
+	}
+}
+
+// tableHead draws the head of the review table at y and returns where its rows start
+func tableHead(d *document, y, titleWidth float64) float64 {
+	d.fillRect(margin, y, pageWidth-2*margin, tableHeadHeight, 0.9)
+	d.text(margin+cellPadding, y+12, bold, 9, 0, "Title")
+	x := margin + titleWidth
+	for _, col := range listColumns {
+		d.text(x+cellPadding, y+12, bold, 9, 0, col.name)
+		x += col.width
+	}
+	return y + tableHeadHeight
+}
@@ -0,0 +1,236 @@
+package freeze
+
+//go:generate mockgen -source=freeze_service.go -destination=mocks/freeze_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const maxNameLength = 255
+
+var (
+	ErrWindowNotFound   = errors.New("freeze window not found")
+	ErrBoardNotFound    = errors.New("board not found")
+	ErrNameRequired     = errors.New("freeze window name is required")
+	ErrNameTooLong      = errors.New("freeze window name must be at most 255 characters")
+	ErrInvalidPeriod    = errors.New("freeze window must end after it starts")
+	ErrColumnsRequired  = errors.New("freeze window needs at least one column")
+	ErrColumnNotInBoard = errors.New("column does not belong to the board")
+	ErrFrozen           = errors.New("column is frozen")
+)
+
+// FrozenError reports a move into a column an active freeze window covers. It matches
+// ErrFrozen with errors.Is.
+type FrozenError struct {
+	Window   *freeze_window.FreezeWindow
+	ColumnID uuid.UUID
+}
+
+func (e *FrozenError) Error() string {
+	return ErrFrozen.Error()
+}
+
+func (e *FrozenError) Unwrap() error {
+	return ErrFrozen
+}
+
+// WindowInput describes a freeze window to create or replace
+type WindowInput struct {
+	Name     string
+	StartsAt time.Time
+	EndsAt   time.Time
+	// ColumnIDs are the columns cards may not be moved into while the window is active
+	ColumnIDs []uuid.UUID
+}
+
+type Service interface {
+	CreateWindow(ctx context.Context, boardID uuid.UUID, input WindowInput) (*freeze_window.FreezeWindow, error)
+	UpdateWindow(ctx context.Context, id uuid.UUID, input WindowInput) (*freeze_window.FreezeWindow, error)
+	DeleteWindow(ctx context.Context, id uuid.UUID) error
+	GetWindow(ctx context.Context, id uuid.UUID) (*freeze_window.FreezeWindow, error)
+	// GetBoardWindows returns the board's windows, past and upcoming, earliest start first
+	GetBoardWindows(ctx context.Context, boardID uuid.UUID) ([]*freeze_window.FreezeWindow, error)
+	// CheckMove returns a *FrozenError when an active window covers the column. When several
+	// do, the error names the one ending first.
+	CheckMove(ctx context.Context, columnID uuid.UUID) error
+}
+
+type service struct {
+	windowRepo freeze_window.Repository
+	boardRepo  board.Repository
+	columnRepo board_column.Repository
+	now        func() time.Time
+}
+
+func NewService(windowRepo freeze_window.Repository, boardRepo board.Repository, columnRepo board_column.Repository) Service {
+	return &service{
+		windowRepo: windowRepo,
+		boardRepo:  boardRepo,
+		columnRepo: columnRepo,
+		now:        time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "freeze.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "freeze"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateWindow(ctx context.Context, boardID uuid.UUID, input WindowInput) (*freeze_window.FreezeWindow, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateWindow")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	window := &freeze_window.FreezeWindow{
+		BoardID:   boardID,
+		CreatedBy: events.ActorFromContext(ctx),
+	}
+	if err := s.applyInput(ctx, window, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.windowRepo.Create(ctx, window); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+func (s *service) UpdateWindow(ctx context.Context, id uuid.UUID, input WindowInput) (*freeze_window.FreezeWindow, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateWindow")
+	span.SetAttributes(attribute.String("freeze_window.id", id.String()))
+	defer span.End()
+
+	window, err := s.GetWindow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyInput(ctx, window, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.windowRepo.Update(ctx, window); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// applyInput validates input against the window's board and copies it onto the window
+func (s *service) applyInput(ctx context.Context, window *freeze_window.FreezeWindow, input WindowInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return ErrNameTooLong
+	}
+	if !input.EndsAt.After(input.StartsAt) {
+		return ErrInvalidPeriod
+	}
+	if len(input.ColumnIDs) == 0 {
+		return ErrColumnsRequired
+	}
+
+	columns, err := s.columnRepo.GetByBoardID(ctx, window.BoardID)
+	if err != nil {
+		return err
+	}
+	onBoard := make(map[uuid.UUID]bool, len(columns))
+	for _, col := range columns {
+		onBoard[col.ID] = true
+	}
+	seen := make(map[uuid.UUID]bool, len(input.ColumnIDs))
+	columnIDs := make([]uuid.UUID, 0, len(input.ColumnIDs))
+	for _, id := range input.ColumnIDs {
+		if !onBoard[id] {
+			return ErrColumnNotInBoard
+		}
+		if !seen[id] {
+			seen[id] = true
+			columnIDs = append(columnIDs, id)
+		}
+	}
+
+	window.Name = name
+	window.StartsAt = input.StartsAt
+	window.EndsAt = input.EndsAt
+	window.ColumnIDs = columnIDs
+	return nil
+}
+
+func (s *service) DeleteWindow(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeleteWindow")
+	span.SetAttributes(attribute.String("freeze_window.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetWindow(ctx, id); err != nil {
+		return err
+	}
+	return s.windowRepo.Delete(ctx, id)
+}
+
+func (s *service) GetWindow(ctx context.Context, id uuid.UUID) (*freeze_window.FreezeWindow, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetWindow")
+	span.SetAttributes(attribute.String("freeze_window.id", id.String()))
+	defer span.End()
+
+	window, err := s.windowRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWindowNotFound
+		}
+		return nil, err
+	}
+	return window, nil
+}
+
+func (s *service) GetBoardWindows(ctx context.Context, boardID uuid.UUID) ([]*freeze_window.FreezeWindow, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBoardWindows")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.windowRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *service) CheckMove(ctx context.Context, columnID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "CheckMove")
+	span.SetAttributes(attribute.String("column.id", columnID.String()))
+	defer span.End()
+
+	windows, err := s.windowRepo.GetActiveByColumnID(ctx, columnID, s.now())
+	if err != nil {
+		return err
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	return &FrozenError{Window: windows[0], ColumnID: columnID}
+}
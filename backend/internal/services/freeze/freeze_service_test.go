@@ -0,0 +1,173 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window"
+	windowMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWindowRepo := windowMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockWindowRepo, mockBoardRepo, mockColumnRepo).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	doneID := uuid.New()
+	releaseID := uuid.New()
+	starts := time.Date(2026, 12, 14, 0, 0, 0, 0, time.UTC)
+	ends := starts.AddDate(0, 0, 7)
+
+	expectBoard := func() {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+	}
+	expectColumns := func() {
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{
+			{ID: doneID, BoardID: boardID},
+			{ID: releaseID, BoardID: boardID},
+		}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectBoard()
+		expectColumns()
+		mockWindowRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		window, err := svc.CreateWindow(ctx, boardID, WindowInput{
+			Name:      "  Code freeze  ",
+			StartsAt:  starts,
+			EndsAt:    ends,
+			ColumnIDs: []uuid.UUID{releaseID, doneID, releaseID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Code freeze", window.Name)
+		assert.Equal(t, boardID, window.BoardID)
+		assert.Equal(t, []uuid.UUID{releaseID, doneID}, window.ColumnIDs)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateWindow(ctx, boardID, WindowInput{Name: "Freeze", StartsAt: starts, EndsAt: ends, ColumnIDs: []uuid.UUID{doneID}})
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+
+	tests := []struct {
+		name  string
+		input WindowInput
+		err   error
+	}{
+		{"name required", WindowInput{Name: " ", StartsAt: starts, EndsAt: ends, ColumnIDs: []uuid.UUID{doneID}}, ErrNameRequired},
+		{"ends before it starts", WindowInput{Name: "Freeze", StartsAt: ends, EndsAt: starts, ColumnIDs: []uuid.UUID{doneID}}, ErrInvalidPeriod},
+		{"no columns", WindowInput{Name: "Freeze", StartsAt: starts, EndsAt: ends}, ErrColumnsRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectBoard()
+
+			_, err := svc.CreateWindow(ctx, boardID, tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("column of another board", func(t *testing.T) {
+		expectBoard()
+		expectColumns()
+
+		_, err := svc.CreateWindow(ctx, boardID, WindowInput{Name: "Freeze", StartsAt: starts, EndsAt: ends, ColumnIDs: []uuid.UUID{uuid.New()}})
+		assert.ErrorIs(t, err, ErrColumnNotInBoard)
+	})
+}
+
+func TestDeleteWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWindowRepo := windowMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockWindowRepo, mockBoardRepo, mockColumnRepo).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockWindowRepo.EXPECT().GetByID(gomock.Any(), id).Return(&freeze_window.FreezeWindow{ID: id}, nil)
+		mockWindowRepo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+
+		assert.NoError(t, svc.DeleteWindow(ctx, id))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockWindowRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, svc.DeleteWindow(ctx, id), ErrWindowNotFound)
+	})
+}
+
+func TestCheckMove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 12, 16, 9, 0, 0, 0, time.UTC)
+
+	mockWindowRepo := windowMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockWindowRepo, mockBoardRepo, mockColumnRepo).(*service)
+	svc.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	columnID := uuid.New()
+
+	t.Run("not frozen", func(t *testing.T) {
+		mockWindowRepo.EXPECT().GetActiveByColumnID(gomock.Any(), columnID, now).Return(nil, nil)
+
+		assert.NoError(t, svc.CheckMove(ctx, columnID))
+	})
+
+	t.Run("frozen", func(t *testing.T) {
+		window := &freeze_window.FreezeWindow{ID: uuid.New(), Name: "Code freeze"}
+		mockWindowRepo.EXPECT().GetActiveByColumnID(gomock.Any(), columnID, now).Return([]*freeze_window.FreezeWindow{window, {ID: uuid.New()}}, nil)
+
+		err := svc.CheckMove(ctx, columnID)
+		assert.ErrorIs(t, err, ErrFrozen)
+		var frozen *FrozenError
+		require.True(t, errors.As(err, &frozen))
+		assert.Equal(t, window, frozen.Window)
+		assert.Equal(t, columnID, frozen.ColumnID)
+	})
+}
+
+func TestFreezeWindowIsActive(t *testing.T) {
+	starts := time.Date(2026, 12, 14, 0, 0, 0, 0, time.UTC)
+	window := &freeze_window.FreezeWindow{StartsAt: starts, EndsAt: starts.AddDate(0, 0, 7)}
+
+	assert.False(t, window.IsActive(starts.Add(-time.Second)))
+	assert.True(t, window.IsActive(starts))
+	assert.True(t, window.IsActive(starts.AddDate(0, 0, 3)))
+	assert.False(t, window.IsActive(starts.AddDate(0, 0, 7)))
+}
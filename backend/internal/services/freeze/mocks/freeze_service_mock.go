@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: freeze_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=freeze_service.go -destination=mocks/freeze_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	freeze_window "github.com/thatcatdev/kaimu/backend/internal/db/repositories/freeze_window"
+	freeze "github.com/thatcatdev/kaimu/backend/internal/services/freeze"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckMove mocks base method.
+func (m *MockService) CheckMove(ctx context.Context, columnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMove", ctx, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMove indicates an expected call of CheckMove.
+func (mr *MockServiceMockRecorder) CheckMove(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMove", reflect.TypeOf((*MockService)(nil).CheckMove), ctx, columnID)
+}
+
+// CreateWindow mocks base method.
+func (m *MockService) CreateWindow(ctx context.Context, boardID uuid.UUID, input freeze.WindowInput) (*freeze_window.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWindow", ctx, boardID, input)
+	ret0, _ := ret[0].(*freeze_window.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWindow indicates an expected call of CreateWindow.
+func (mr *MockServiceMockRecorder) CreateWindow(ctx, boardID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWindow", reflect.TypeOf((*MockService)(nil).CreateWindow), ctx, boardID, input)
+}
+
+// DeleteWindow mocks base method.
+func (m *MockService) DeleteWindow(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWindow", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWindow indicates an expected call of DeleteWindow.
+func (mr *MockServiceMockRecorder) DeleteWindow(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWindow", reflect.TypeOf((*MockService)(nil).DeleteWindow), ctx, id)
+}
+
+// GetBoardWindows mocks base method.
+func (m *MockService) GetBoardWindows(ctx context.Context, boardID uuid.UUID) ([]*freeze_window.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWindows", ctx, boardID)
+	ret0, _ := ret[0].([]*freeze_window.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWindows indicates an expected call of GetBoardWindows.
+func (mr *MockServiceMockRecorder) GetBoardWindows(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWindows", reflect.TypeOf((*MockService)(nil).GetBoardWindows), ctx, boardID)
+}
+
+// GetWindow mocks base method.
+func (m *MockService) GetWindow(ctx context.Context, id uuid.UUID) (*freeze_window.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWindow", ctx, id)
+	ret0, _ := ret[0].(*freeze_window.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWindow indicates an expected call of GetWindow.
+func (mr *MockServiceMockRecorder) GetWindow(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWindow", reflect.TypeOf((*MockService)(nil).GetWindow), ctx, id)
+}
+
+// UpdateWindow mocks base method.
+func (m *MockService) UpdateWindow(ctx context.Context, id uuid.UUID, input freeze.WindowInput) (*freeze_window.FreezeWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWindow", ctx, id, input)
+	ret0, _ := ret[0].(*freeze_window.FreezeWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWindow indicates an expected call of UpdateWindow.
+func (mr *MockServiceMockRecorder) UpdateWindow(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWindow", reflect.TypeOf((*MockService)(nil).UpdateWindow), ctx, id, input)
+}
@@ -0,0 +1,255 @@
+package health
+
+//go:generate mockgen -source=health_service.go -destination=mocks/health_service_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_health"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// VelocitySprints is how many closed sprints per board the velocity trend looks back on
+const VelocitySprints = 6
+
+// stableVelocityChange is how far velocity may move either way and still count as stable
+const stableVelocityChange = 0.10
+
+type Status string
+
+const (
+	StatusOnTrack  Status = "on_track"
+	StatusAtRisk   Status = "at_risk"
+	StatusOffTrack Status = "off_track"
+)
+
+type SignalKind string
+
+const (
+	// SignalOverdue measures the share of open cards past their due date
+	SignalOverdue SignalKind = "overdue"
+	// SignalScopeChurn measures the share of active sprint cards added after the sprint started
+	SignalScopeChurn SignalKind = "scope_churn"
+	// SignalBlocked measures the mean days open cards have been blocked
+	SignalBlocked SignalKind = "blocked"
+	// SignalVelocityTrend measures the change in completed points between the earlier and
+	// the recent half of the boards' last closed sprints
+	SignalVelocityTrend SignalKind = "velocity_trend"
+)
+
+type Trend string
+
+const (
+	TrendImproving Trend = "improving"
+	TrendStable    Trend = "stable"
+	TrendDeclining Trend = "declining"
+)
+
+// threshold is where a signal's value puts the project at risk and off track
+type threshold struct {
+	atRisk   float64
+	offTrack float64
+}
+
+var (
+	overdueThreshold  = threshold{atRisk: 0.10, offTrack: 0.25}
+	churnThreshold    = threshold{atRisk: 0.20, offTrack: 0.40}
+	blockedThreshold  = threshold{atRisk: 3, offTrack: 7}
+	velocityThreshold = threshold{atRisk: stableVelocityChange, offTrack: 0.30}
+)
+
+func (t threshold) status(value float64) Status {
+	switch {
+	case value >= t.offTrack:
+		return StatusOffTrack
+	case value >= t.atRisk:
+		return StatusAtRisk
+	default:
+		return StatusOnTrack
+	}
+}
+
+// Signal is one input to a project's health
+type Signal struct {
+	Kind   SignalKind
+	Status Status
+	// Value is what the status is judged on: a share for overdue and scope churn, days for
+	// blocked and a relative change for the velocity trend. Nil when there is nothing to
+	// measure yet.
+	Value *float64
+	// Count and Total are the numbers behind Value: overdue and open cards, added and
+	// sprint cards, blocked and open cards, or recent and earlier completed points (cards
+	// when the boards are not estimated)
+	Count int
+	Total int
+	// Trend is set on the velocity signal once there are enough closed sprints
+	Trend *Trend
+}
+
+// Report is a project's health and the signals it was derived from
+type Report struct {
+	ProjectID uuid.UUID
+	// Status is the worst status of the signals
+	Status Status
+	// Score starts at 100 and loses 15 points per signal at risk and 35 per signal off track
+	Score      int
+	Signals    []Signal
+	ComputedAt time.Time
+}
+
+type Service interface {
+	GetProjectHealth(ctx context.Context, projectID uuid.UUID) (*Report, error)
+}
+
+type service struct {
+	healthRepo project_health.Repository
+	boardRepo  board.Repository
+	metricsSvc metrics.Service
+	now        func() time.Time
+}
+
+func NewService(healthRepo project_health.Repository, boardRepo board.Repository, metricsSvc metrics.Service) Service {
+	return &service{
+		healthRepo: healthRepo,
+		boardRepo:  boardRepo,
+		metricsSvc: metricsSvc,
+		now:        time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "health.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "health"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetProjectHealth(ctx context.Context, projectID uuid.UUID) (*Report, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProjectHealth")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	now := s.now()
+	indicators, err := s.healthRepo.GetIndicators(ctx, projectID, now)
+	if err != nil {
+		return nil, err
+	}
+	velocity, err := s.velocitySignal(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		ProjectID: projectID,
+		Signals: []Signal{
+			ratioSignal(SignalOverdue, indicators.OverdueCards, indicators.OpenCards, overdueThreshold),
+			ratioSignal(SignalScopeChurn, indicators.AddedSprintCards, indicators.SprintCards, churnThreshold),
+			blockedSignal(indicators),
+			velocity,
+		},
+		ComputedAt: now,
+	}
+	report.Status, report.Score = summarize(report.Signals)
+	span.SetAttributes(attribute.String("health.status", string(report.Status)))
+	return report, nil
+}
+
+// ratioSignal judges the share count/total, with no value when total is zero
+func ratioSignal(kind SignalKind, count, total int, t threshold) Signal {
+	signal := Signal{Kind: kind, Status: StatusOnTrack, Count: count, Total: total}
+	if total > 0 {
+		ratio := float64(count) / float64(total)
+		signal.Value = &ratio
+		signal.Status = t.status(ratio)
+	}
+	return signal
+}
+
+func blockedSignal(indicators *project_health.Indicators) Signal {
+	signal := Signal{Kind: SignalBlocked, Status: StatusOnTrack, Count: indicators.BlockedCards, Total: indicators.OpenCards}
+	if indicators.BlockedCards > 0 {
+		days := indicators.BlockedSeconds / (24 * 60 * 60)
+		signal.Value = &days
+		signal.Status = blockedThreshold.status(days)
+	}
+	return signal
+}
+
+// velocitySignal compares the completed points of the earlier and the recent half of each
+// board's last closed sprints, summed over the project's boards. Boards with fewer than two
+// closed sprints are left out, and completed cards stand in when no points were completed.
+func (s *service) velocitySignal(ctx context.Context, projectID uuid.UUID) (Signal, error) {
+	signal := Signal{Kind: SignalVelocityTrend, Status: StatusOnTrack}
+
+	boards, err := s.boardRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return signal, err
+	}
+	var earlierPoints, recentPoints, earlierCards, recentCards int
+	for _, b := range boards {
+		data, err := s.metricsSvc.GetVelocityData(ctx, b.ID, VelocitySprints, metrics.MetricModeStoryPoints)
+		if err != nil {
+			return signal, err
+		}
+		// Sprints come oldest first; an odd middle sprint belongs to neither half
+		half := len(data.Sprints) / 2
+		for i := 0; i < half; i++ {
+			earlier, recent := data.Sprints[i], data.Sprints[len(data.Sprints)-half+i]
+			earlierPoints += earlier.CompletedPoints
+			recentPoints += recent.CompletedPoints
+			earlierCards += earlier.CompletedCards
+			recentCards += recent.CompletedCards
+		}
+	}
+	signal.Count, signal.Total = recentPoints, earlierPoints
+	if earlierPoints == 0 {
+		signal.Count, signal.Total = recentCards, earlierCards
+	}
+	if signal.Total == 0 {
+		return signal, nil
+	}
+
+	change := float64(signal.Count-signal.Total) / float64(signal.Total)
+	trend := TrendStable
+	switch {
+	case change > stableVelocityChange:
+		trend = TrendImproving
+	case change < -stableVelocityChange:
+		trend = TrendDeclining
+	}
+	signal.Value = &change
+	signal.Trend = &trend
+	signal.Status = velocityThreshold.status(-change)
+	return signal, nil
+}
+
+// summarize returns the worst status of the signals and the score they add up to
+func summarize(signals []Signal) (Status, int) {
+	status := StatusOnTrack
+	score := 100
+	for _, signal := range signals {
+		switch signal.Status {
+		case StatusAtRisk:
+			score -= 15
+			if status == StatusOnTrack {
+				status = StatusAtRisk
+			}
+		case StatusOffTrack:
+			score -= 35
+			status = StatusOffTrack
+		}
+	}
+	return status, max(score, 0)
+}
@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_health"
+	healthMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_health/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/metrics"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeMetricsService returns fixed velocity data per board
+type fakeMetricsService struct {
+	metrics.Service
+	velocity map[uuid.UUID]*metrics.VelocityData
+}
+
+func (f *fakeMetricsService) GetVelocityData(ctx context.Context, boardID uuid.UUID, sprintCount int, mode metrics.MetricMode) (*metrics.VelocityData, error) {
+	if data, ok := f.velocity[boardID]; ok {
+		return data, nil
+	}
+	return &metrics.VelocityData{}, nil
+}
+
+func sprints(points ...int) *metrics.VelocityData {
+	data := &metrics.VelocityData{}
+	for _, p := range points {
+		data.Sprints = append(data.Sprints, metrics.SprintVelocity{SprintID: uuid.New(), CompletedPoints: p, CompletedCards: p})
+	}
+	return data
+}
+
+func TestGetProjectHealth(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, indicators *project_health.Indicators, velocity map[uuid.UUID]*metrics.VelocityData) *service {
+		ctrl := gomock.NewController(t)
+		healthRepo := healthMocks.NewMockRepository(ctrl)
+		boardRepo := boardMocks.NewMockRepository(ctrl)
+		healthRepo.EXPECT().GetIndicators(gomock.Any(), projectID, now).Return(indicators, nil)
+		boardRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*board.Board{{ID: boardID}}, nil).AnyTimes()
+
+		svc := NewService(healthRepo, boardRepo, &fakeMetricsService{velocity: velocity}).(*service)
+		svc.now = func() time.Time { return now }
+		return svc
+	}
+
+	t.Run("on track with nothing to measure", func(t *testing.T) {
+		svc := setup(t, &project_health.Indicators{}, nil)
+
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusOnTrack, report.Status)
+		assert.Equal(t, 100, report.Score)
+		assert.Equal(t, now, report.ComputedAt)
+		require.Len(t, report.Signals, 4)
+		for _, signal := range report.Signals {
+			assert.Equal(t, StatusOnTrack, signal.Status)
+			assert.Nil(t, signal.Value)
+		}
+	})
+
+	t.Run("at risk from overdue cards and scope churn", func(t *testing.T) {
+		svc := setup(t, &project_health.Indicators{
+			OpenCards:        20,
+			OverdueCards:     3,
+			SprintCards:      10,
+			AddedSprintCards: 2,
+		}, nil)
+
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusAtRisk, report.Status)
+		assert.Equal(t, 70, report.Score)
+
+		overdue := report.Signals[0]
+		assert.Equal(t, SignalOverdue, overdue.Kind)
+		assert.Equal(t, StatusAtRisk, overdue.Status)
+		assert.InDelta(t, 0.15, *overdue.Value, 0.0001)
+		assert.Equal(t, 3, overdue.Count)
+		assert.Equal(t, 20, overdue.Total)
+
+		churn := report.Signals[1]
+		assert.Equal(t, SignalScopeChurn, churn.Kind)
+		assert.Equal(t, StatusAtRisk, churn.Status)
+		assert.InDelta(t, 0.2, *churn.Value, 0.0001)
+	})
+
+	t.Run("off track from long blocked cards", func(t *testing.T) {
+		svc := setup(t, &project_health.Indicators{
+			OpenCards:      10,
+			BlockedCards:   2,
+			BlockedSeconds: 8 * 24 * 60 * 60,
+		}, nil)
+
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusOffTrack, report.Status)
+		assert.Equal(t, 65, report.Score)
+
+		blocked := report.Signals[2]
+		assert.Equal(t, SignalBlocked, blocked.Kind)
+		assert.Equal(t, StatusOffTrack, blocked.Status)
+		assert.InDelta(t, 8, *blocked.Value, 0.0001)
+		assert.Equal(t, 2, blocked.Count)
+	})
+
+	t.Run("declining velocity", func(t *testing.T) {
+		// Earlier half 20+20, recent half 12+14; the middle sprint is left out
+		svc := setup(t, &project_health.Indicators{}, map[uuid.UUID]*metrics.VelocityData{
+			boardID: sprints(20, 20, 99, 12, 14),
+		})
+
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+
+		velocity := report.Signals[3]
+		assert.Equal(t, SignalVelocityTrend, velocity.Kind)
+		assert.Equal(t, StatusOffTrack, velocity.Status)
+		assert.Equal(t, TrendDeclining, *velocity.Trend)
+		assert.InDelta(t, -0.35, *velocity.Value, 0.0001)
+		assert.Equal(t, 26, velocity.Count)
+		assert.Equal(t, 40, velocity.Total)
+	})
+
+	t.Run("improving and stable velocity", func(t *testing.T) {
+		svc := setup(t, &project_health.Indicators{}, map[uuid.UUID]*metrics.VelocityData{
+			boardID: sprints(10, 15),
+		})
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusOnTrack, report.Signals[3].Status)
+		assert.Equal(t, TrendImproving, *report.Signals[3].Trend)
+
+		svc = setup(t, &project_health.Indicators{}, map[uuid.UUID]*metrics.VelocityData{
+			boardID: sprints(10, 11),
+		})
+		report, err = svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, TrendStable, *report.Signals[3].Trend)
+	})
+
+	t.Run("velocity falls back to cards without points", func(t *testing.T) {
+		data := &metrics.VelocityData{Sprints: []metrics.SprintVelocity{
+			{CompletedCards: 10},
+			{CompletedCards: 8},
+		}}
+		svc := setup(t, &project_health.Indicators{}, map[uuid.UUID]*metrics.VelocityData{boardID: data})
+
+		report, err := svc.GetProjectHealth(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusAtRisk, report.Signals[3].Status)
+		assert.Equal(t, 8, report.Signals[3].Count)
+		assert.Equal(t, 10, report.Signals[3].Total)
+	})
+
+	t.Run("fail - repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		healthRepo := healthMocks.NewMockRepository(ctrl)
+		healthRepo.EXPECT().GetIndicators(gomock.Any(), projectID, gomock.Any()).Return(nil, errors.New("db error"))
+
+		svc := NewService(healthRepo, boardMocks.NewMockRepository(ctrl), &fakeMetricsService{})
+		_, err := svc.GetProjectHealth(ctx, projectID)
+		assert.Error(t, err)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	status, score := summarize([]Signal{
+		{Status: StatusOffTrack},
+		{Status: StatusOffTrack},
+		{Status: StatusOffTrack},
+		{Status: StatusAtRisk},
+	})
+	assert.Equal(t, StatusOffTrack, status)
+	assert.Equal(t, 0, score)
+}
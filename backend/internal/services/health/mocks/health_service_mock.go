@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health_service.go -destination=mocks/health_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	health "github.com/thatcatdev/kaimu/backend/internal/services/health"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProjectHealth mocks base method.
+func (m *MockService) GetProjectHealth(ctx context.Context, projectID uuid.UUID) (*health.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectHealth", ctx, projectID)
+	ret0, _ := ret[0].(*health.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectHealth indicates an expected call of GetProjectHealth.
+func (mr *MockServiceMockRecorder) GetProjectHealth(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectHealth", reflect.TypeOf((*MockService)(nil).GetProjectHealth), ctx, projectID)
+}
@@ -0,0 +1,185 @@
+package instance
+
+//go:generate mockgen -source=instance_service.go -destination=mocks/instance_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	netmail "net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxProductNameLength is the longest product name accepted
+	MaxProductNameLength = 100
+	// MaxURLLength is the longest logo or terms URL accepted
+	MaxURLLength = 2048
+)
+
+var (
+	ErrInvalidProductName  = errors.New("product name must be at most 100 characters on one line")
+	ErrInvalidLogoURL      = errors.New("logo must be an https URL of at most 2048 characters")
+	ErrInvalidSupportEmail = errors.New("support email must be a plain email address")
+	ErrInvalidTermsURL     = errors.New("terms URL must be an http or https URL of at most 2048 characters")
+)
+
+// Input describes the instance's branding; empty fields use the built-in one
+type Input struct {
+	ProductName  string
+	LogoURL      string
+	SupportEmail string
+	TermsURL     string
+}
+
+type Service interface {
+	// GetSettings returns the instance's settings, or the defaults when it has none
+	GetSettings(ctx context.Context) (*instance_setting.InstanceSetting, error)
+	// UpdateSettings replaces the instance's settings
+	UpdateSettings(ctx context.Context, input Input, updatedBy uuid.UUID) (*instance_setting.InstanceSetting, error)
+	// IsAdmin reports whether the user is a platform admin: one whose verified email is
+	// listed in INSTANCE_ADMIN_EMAILS
+	IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error)
+	// MailInstance returns the branding mail is sent with (mail.InstanceSource)
+	MailInstance(ctx context.Context) *mail.Instance
+}
+
+type service struct {
+	settingRepo instance_setting.Repository
+	userRepo    user.Repository
+	adminEmails []string
+}
+
+func NewService(settingRepo instance_setting.Repository, userRepo user.Repository, cfg config.InstanceConfig) Service {
+	return &service{
+		settingRepo: settingRepo,
+		userRepo:    userRepo,
+		adminEmails: cfg.GetAdminEmails(),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "instance.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "instance"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetSettings(ctx context.Context) (*instance_setting.InstanceSetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSettings")
+	defer span.End()
+
+	return s.settingRepo.Get(ctx)
+}
+
+func (s *service) UpdateSettings(ctx context.Context, input Input, updatedBy uuid.UUID) (*instance_setting.InstanceSetting, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateSettings")
+	span.SetAttributes(attribute.String("user.id", updatedBy.String()))
+	defer span.End()
+
+	input.ProductName = strings.TrimSpace(input.ProductName)
+	input.LogoURL = strings.TrimSpace(input.LogoURL)
+	input.SupportEmail = strings.TrimSpace(input.SupportEmail)
+	input.TermsURL = strings.TrimSpace(input.TermsURL)
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.settingRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	setting.ProductName = input.ProductName
+	setting.LogoURL = input.LogoURL
+	setting.SupportEmail = input.SupportEmail
+	setting.TermsURL = input.TermsURL
+	setting.UpdatedBy = &updatedBy
+
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (s *service) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "IsAdmin")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	if len(s.adminEmails) == 0 {
+		return false, nil
+	}
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	// An unverified email could have been registered by anyone
+	if u.Email == nil || !u.EmailVerified {
+		return false, nil
+	}
+	return slices.Contains(s.adminEmails, strings.ToLower(*u.Email)), nil
+}
+
+func (s *service) MailInstance(ctx context.Context) *mail.Instance {
+	ctx, span := s.startServiceSpan(ctx, "MailInstance")
+	defer span.End()
+
+	// Mail with the built-in branding beats no mail, so lookup failures only get traced
+	setting, err := s.settingRepo.Get(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil
+	}
+	return &mail.Instance{
+		ProductName:  setting.ProductName,
+		LogoURL:      setting.LogoURL,
+		SupportEmail: setting.SupportEmail,
+		TermsURL:     setting.TermsURL,
+	}
+}
+
+func (i Input) validate() error {
+	if utf8.RuneCountInString(i.ProductName) > MaxProductNameLength || strings.ContainsAny(i.ProductName, "\r\n") {
+		return ErrInvalidProductName
+	}
+	if i.LogoURL != "" && !validURL(i.LogoURL, "https") {
+		return ErrInvalidLogoURL
+	}
+	if i.SupportEmail != "" {
+		addr, err := netmail.ParseAddress(i.SupportEmail)
+		if err != nil || addr.Name != "" || addr.Address != i.SupportEmail {
+			return ErrInvalidSupportEmail
+		}
+	}
+	if i.TermsURL != "" && !validURL(i.TermsURL, "http", "https") {
+		return ErrInvalidTermsURL
+	}
+	return nil
+}
+
+// validURL reports whether raw is an absolute URL with one of the schemes that fits MaxURLLength
+func validURL(raw string, schemes ...string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && slices.Contains(schemes, u.Scheme) && u.Host != "" && len(raw) <= MaxURLLength
+}
@@ -0,0 +1,127 @@
+package instance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting"
+	settingMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestUpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSettingRepo := settingMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSettingRepo, userMocks.NewMockRepository(ctrl), config.InstanceConfig{AdminEmails: ""})
+
+		mockSettingRepo.EXPECT().Get(gomock.Any()).Return(instance_setting.Default(), nil)
+		mockSettingRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+		setting, err := svc.UpdateSettings(ctx, Input{
+			ProductName:  " Acme Boards ",
+			SupportEmail: "help@acme.example",
+			TermsURL:     "https://acme.example/terms",
+		}, adminID)
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Boards", setting.ProductName)
+		assert.Equal(t, "help@acme.example", setting.SupportEmail)
+		assert.Empty(t, setting.LogoURL)
+		assert.Equal(t, adminID, *setting.UpdatedBy)
+	})
+
+	t.Run("fail - invalid input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewService(settingMocks.NewMockRepository(ctrl), userMocks.NewMockRepository(ctrl), config.InstanceConfig{AdminEmails: ""})
+
+		cases := map[error]Input{
+			ErrInvalidProductName:  {ProductName: "Acme\nBoards"},
+			ErrInvalidLogoURL:      {LogoURL: "http://acme.example/logo.png"},
+			ErrInvalidSupportEmail: {SupportEmail: "Help <help@acme.example>"},
+			ErrInvalidTermsURL:     {TermsURL: "javascript:alert(1)"},
+		}
+		for want, input := range cases {
+			_, err := svc.UpdateSettings(ctx, input, adminID)
+			assert.ErrorIs(t, err, want)
+		}
+	})
+}
+
+func TestIsAdmin(t *testing.T) {
+	ctx := context.Background()
+	email := "Ops@Kaimu.example"
+
+	t.Run("success - verified listed email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(settingMocks.NewMockRepository(ctrl), mockUserRepo, config.InstanceConfig{AdminEmails: "root@kaimu.example, ops@kaimu.example"})
+
+		u := &user.User{ID: uuid.New(), Email: &email, EmailVerified: true}
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), u.ID).Return(u, nil)
+
+		isAdmin, err := svc.IsAdmin(ctx, u.ID)
+		require.NoError(t, err)
+		assert.True(t, isAdmin)
+	})
+
+	t.Run("fail - unverified email", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(settingMocks.NewMockRepository(ctrl), mockUserRepo, config.InstanceConfig{AdminEmails: "ops@kaimu.example"})
+
+		u := &user.User{ID: uuid.New(), Email: &email}
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), u.ID).Return(u, nil)
+
+		isAdmin, err := svc.IsAdmin(ctx, u.ID)
+		require.NoError(t, err)
+		assert.False(t, isAdmin)
+	})
+
+	t.Run("fail - unknown user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(settingMocks.NewMockRepository(ctrl), mockUserRepo, config.InstanceConfig{AdminEmails: "ops@kaimu.example"})
+
+		id := uuid.New()
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		isAdmin, err := svc.IsAdmin(ctx, id)
+		require.NoError(t, err)
+		assert.False(t, isAdmin)
+	})
+
+	t.Run("fail - no admins configured", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewService(settingMocks.NewMockRepository(ctrl), userMocks.NewMockRepository(ctrl), config.InstanceConfig{AdminEmails: ""})
+
+		isAdmin, err := svc.IsAdmin(ctx, uuid.New())
+		require.NoError(t, err)
+		assert.False(t, isAdmin)
+	})
+}
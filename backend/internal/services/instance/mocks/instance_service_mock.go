@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: instance_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=instance_service.go -destination=mocks/instance_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	instance_setting "github.com/thatcatdev/kaimu/backend/internal/db/repositories/instance_setting"
+	instance "github.com/thatcatdev/kaimu/backend/internal/services/instance"
+	mail "github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSettings mocks base method.
+func (m *MockService) GetSettings(ctx context.Context) (*instance_setting.InstanceSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx)
+	ret0, _ := ret[0].(*instance_setting.InstanceSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockServiceMockRecorder) GetSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockService)(nil).GetSettings), ctx)
+}
+
+// IsAdmin mocks base method.
+func (m *MockService) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAdmin indicates an expected call of IsAdmin.
+func (mr *MockServiceMockRecorder) IsAdmin(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockService)(nil).IsAdmin), ctx, userID)
+}
+
+// MailInstance mocks base method.
+func (m *MockService) MailInstance(ctx context.Context) *mail.Instance {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MailInstance", ctx)
+	ret0, _ := ret[0].(*mail.Instance)
+	return ret0
+}
+
+// MailInstance indicates an expected call of MailInstance.
+func (mr *MockServiceMockRecorder) MailInstance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MailInstance", reflect.TypeOf((*MockService)(nil).MailInstance), ctx)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockService) UpdateSettings(ctx context.Context, input instance.Input, updatedBy uuid.UUID) (*instance_setting.InstanceSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, input, updatedBy)
+	ret0, _ := ret[0].(*instance_setting.InstanceSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockServiceMockRecorder) UpdateSettings(ctx, input, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockService)(nil).UpdateSettings), ctx, input, updatedBy)
+}
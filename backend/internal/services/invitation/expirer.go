@@ -0,0 +1,54 @@
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultExpiryInterval is how often the expirer reminds invitees and expires invitations
+const DefaultExpiryInterval = 15 * time.Minute
+
+// Expirer runs Service.SendReminders and Service.ExpireStale in the background
+type Expirer struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewExpirer(svc Service, interval time.Duration) *Expirer {
+	if interval <= 0 {
+		interval = DefaultExpiryInterval
+	}
+	return &Expirer{svc: svc, interval: interval}
+}
+
+// Run reminds and expires every interval until ctx is cancelled
+func (w *Expirer) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		reminded, err := w.svc.SendReminders(ctx)
+		if err != nil {
+			log.Error().Err(err).Int("reminded", reminded).Msg("Failed to send invitation reminders")
+		} else if reminded > 0 {
+			log.Info().Int("reminded", reminded).Msg("Sent invitation reminders")
+		}
+
+		expired, err := w.svc.ExpireStale(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to expire invitations")
+		} else if expired > 0 {
+			log.Info().Int("expired", expired).Msg("Expired invitations")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
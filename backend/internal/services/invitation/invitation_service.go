@@ -8,6 +8,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +17,15 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
 	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
@@ -27,8 +36,13 @@ import (
 const (
 	// InvitationExpiry is the default expiration time for invitations
 	InvitationExpiry = 7 * 24 * time.Hour // 7 days
+	// MaxInvitationTTLDays is the longest an organization may keep invitations valid
+	MaxInvitationTTLDays = 90
 	// TokenLength is the length of the invitation token in bytes (before base64 encoding)
 	TokenLength = 32
+
+	// reminderBatchSize is how many reminders SendReminders sends per query
+	reminderBatchSize = 100
 )
 
 var (
@@ -39,12 +53,25 @@ var (
 	ErrPendingInvitation  = errors.New("there is already a pending invitation for this email")
 	ErrEmailMismatch      = errors.New("your email does not match the invitation")
 	ErrOrgNotFound        = errors.New("organization not found")
+	ErrProjectNotInOrg    = errors.New("project does not belong to this organization")
+	ErrGuestLimitReached  = errors.New("the organization has reached its guest limit")
+	// ErrInvalidExpiryPolicy is a TTL outside 1 to MaxInvitationTTLDays days, or a reminder
+	// period that is not shorter than the TTL
+	ErrInvalidExpiryPolicy = errors.New("invalid invitation expiry policy")
 )
 
 type Service interface {
 	// Create a new invitation
 	CreateInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error)
 
+	// Create an invitation that also adds the invitee to one project of the organization,
+	// with the given project role (nil inherits the organization role)
+	CreateProjectInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID uuid.UUID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error)
+
+	// Create an invitation that makes the invitee a guest of the organization, reaching
+	// only the given project. Fails when the organization is at its guest limit.
+	CreateGuestInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID uuid.UUID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error)
+
 	// Get invitation by ID
 	GetInvitation(ctx context.Context, id uuid.UUID) (*invitation.Invitation, error)
 
@@ -54,13 +81,16 @@ type Service interface {
 	// Get pending invitations for an organization
 	GetPendingInvitations(ctx context.Context, orgID uuid.UUID) ([]*invitation.Invitation, error)
 
+	// Get the organization's invitations that are not accepted, pending or expired
+	GetInvitations(ctx context.Context, orgID uuid.UUID, status invitation.Status) ([]*invitation.Invitation, error)
+
 	// Cancel (delete) an invitation
 	CancelInvitation(ctx context.Context, id uuid.UUID) error
 
 	// Resend invitation (generates new token and extends expiration)
 	ResendInvitation(ctx context.Context, id uuid.UUID) (*invitation.Invitation, error)
 
-	// Accept an invitation (creates membership)
+	// Accept an invitation (creates membership, and project membership for project invitations)
 	AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*organization.Organization, error)
 
 	// Get organization for invitation
@@ -71,16 +101,40 @@ type Service interface {
 
 	// Get inviter for invitation
 	GetInviter(ctx context.Context, invID uuid.UUID) (*user.User, error)
+
+	// Get the project of a project invitation, nil for organization invitations
+	GetInvitationProject(ctx context.Context, invID uuid.UUID) (*project.Project, error)
+
+	// Get the project role of a project invitation, nil when it inherits the org role
+	GetInvitationProjectRole(ctx context.Context, invID uuid.UUID) (*role.Role, error)
+
+	// Set how many days the organization's invitations stay valid (nil for the default) and
+	// how many days before expiry invitees are reminded (nil for no reminders). Applies to
+	// invitations sent or resent from now on; reminders follow the current setting.
+	SetExpiryPolicy(ctx context.Context, orgID uuid.UUID, ttlDays, reminderDays *int) (*organization.Organization, error)
+
+	// Email the invitees of pending invitations within their organization's reminder period,
+	// once per invitation, and return how many were reminded
+	SendReminders(ctx context.Context) (int, error)
+
+	// Mark invitations past their expiry as expired and return how many changed
+	ExpireStale(ctx context.Context) (int, error)
 }
 
 type service struct {
-	invitationRepo invitation.Repository
-	orgRepo        organization.Repository
-	orgMemberRepo  organization_member.Repository
-	userRepo       user.Repository
-	roleRepo       role.Repository
-	mailService    mail.MailService
-	emailConfig    config.EmailConfig
+	invitationRepo   invitation.Repository
+	orgRepo          organization.Repository
+	orgMemberRepo    organization_member.Repository
+	userRepo         user.Repository
+	roleRepo         role.Repository
+	projectRepo      project.Repository
+	projMemberRepo   project_member.Repository
+	mailService      mail.MailService
+	brandingService  branding.Service
+	emailConfig      config.EmailConfig
+	membershipConfig config.MembershipConfig
+	txManager        transaction.Manager
+	bus              events.Bus
 }
 
 func NewService(
@@ -89,17 +143,29 @@ func NewService(
 	orgMemberRepo organization_member.Repository,
 	userRepo user.Repository,
 	roleRepo role.Repository,
+	projectRepo project.Repository,
+	projMemberRepo project_member.Repository,
 	mailService mail.MailService,
+	brandingService branding.Service,
 	emailConfig config.EmailConfig,
+	membershipConfig config.MembershipConfig,
+	txManager transaction.Manager,
+	bus events.Bus,
 ) Service {
 	return &service{
-		invitationRepo: invitationRepo,
-		orgRepo:        orgRepo,
-		orgMemberRepo:  orgMemberRepo,
-		userRepo:       userRepo,
-		roleRepo:       roleRepo,
-		mailService:    mailService,
-		emailConfig:    emailConfig,
+		invitationRepo:   invitationRepo,
+		orgRepo:          orgRepo,
+		orgMemberRepo:    orgMemberRepo,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		projectRepo:      projectRepo,
+		projMemberRepo:   projMemberRepo,
+		mailService:      mailService,
+		brandingService:  brandingService,
+		emailConfig:      emailConfig,
+		membershipConfig: membershipConfig,
+		txManager:        txManager,
+		bus:              bus,
 	}
 }
 
@@ -134,8 +200,119 @@ func (s *service) CreateInvitation(ctx context.Context, orgID uuid.UUID, email s
 	)
 	defer span.End()
 
+	return s.createInvitation(ctx, &invitation.Invitation{
+		OrganizationID: orgID,
+		Email:          email,
+		RoleID:         &roleID,
+		InvitedBy:      invitedBy,
+	})
+}
+
+func (s *service) CreateProjectInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID uuid.UUID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateProjectInvitation")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("email", email),
+		attribute.String("role.id", roleID.String()),
+		attribute.String("project.id", projectID.String()),
+	)
+	defer span.End()
+
+	if err := s.checkProjectInOrg(ctx, orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.createInvitation(ctx, &invitation.Invitation{
+		OrganizationID: orgID,
+		Email:          email,
+		RoleID:         &roleID,
+		ProjectID:      &projectID,
+		ProjectRoleID:  projectRoleID,
+		InvitedBy:      invitedBy,
+	})
+}
+
+func (s *service) CreateGuestInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID uuid.UUID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateGuestInvitation")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("email", email),
+		attribute.String("role.id", roleID.String()),
+		attribute.String("project.id", projectID.String()),
+	)
+	defer span.End()
+
+	if err := s.checkProjectInOrg(ctx, orgID, projectID); err != nil {
+		return nil, err
+	}
+
+	// Pending guest invitations hold a place, so the limit cannot be outrun by inviting
+	// more guests than it allows before any of them accept
+	guests, err := s.countGuests(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.invitationRepo.GetPendingByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range pending {
+		if inv.IsGuest && inv.Email != email {
+			guests++
+		}
+	}
+	if s.guestLimitReached(guests) {
+		return nil, ErrGuestLimitReached
+	}
+
+	return s.createInvitation(ctx, &invitation.Invitation{
+		OrganizationID: orgID,
+		Email:          email,
+		RoleID:         &roleID,
+		ProjectID:      &projectID,
+		ProjectRoleID:  projectRoleID,
+		IsGuest:        true,
+		InvitedBy:      invitedBy,
+	})
+}
+
+// checkProjectInOrg returns ErrProjectNotInOrg unless the project exists in the organization
+func (s *service) checkProjectInOrg(ctx context.Context, orgID, projectID uuid.UUID) error {
+	proj, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProjectNotInOrg
+		}
+		return err
+	}
+	if proj.OrganizationID != orgID {
+		return ErrProjectNotInOrg
+	}
+	return nil
+}
+
+func (s *service) countGuests(ctx context.Context, orgID uuid.UUID) (int, error) {
+	guests, err := s.orgMemberRepo.GetGuestsByOrgID(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return len(guests), nil
+}
+
+// guestLimitReached reports whether an organization with the given number of guests can
+// take no more
+func (s *service) guestLimitReached(guests int) bool {
+	limit := s.membershipConfig.MaxGuestsPerOrg
+	return limit > 0 && guests >= limit
+}
+
+// createInvitation checks the invitee is not a member or already invited, then stores the
+// invitation with a fresh token and sends it
+func (s *service) createInvitation(ctx context.Context, inv *invitation.Invitation) (*invitation.Invitation, error) {
+	orgID, email, invitedBy := inv.OrganizationID, inv.Email, inv.InvitedBy
+
 	// Check if organization exists
-	_, err := s.orgRepo.GetByID(ctx, orgID)
+	org, err := s.orgRepo.GetByID(ctx, orgID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrOrgNotFound
@@ -169,21 +346,30 @@ func (s *service) CreateInvitation(ctx context.Context, orgID uuid.UUID, email s
 		return nil, err
 	}
 
-	inv := &invitation.Invitation{
-		OrganizationID: orgID,
-		Email:          email,
-		RoleID:         &roleID,
-		InvitedBy:      invitedBy,
-		Token:          token,
-		ExpiresAt:      time.Now().Add(InvitationExpiry),
-	}
+	inv.Token = token
+	inv.ExpiresAt = time.Now().Add(invitationTTL(org))
 
-	if err := s.invitationRepo.Create(ctx, inv); err != nil {
+	// The invitation and its event are recorded together
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.invitationRepo.Create(ctx, inv); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.MemberInvited, events.MemberInvitedPayload{
+			InvitationID:   inv.ID,
+			OrganizationID: inv.OrganizationID,
+			Email:          inv.Email,
+			RoleID:         inv.RoleID,
+			ProjectID:      inv.ProjectID,
+			IsGuest:        inv.IsGuest,
+		}))
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Send invitation email asynchronously (use background context since request context will be canceled)
-	go s.sendInvitationEmail(context.Background(), inv, invitedBy)
+	// Send the invitation email asynchronously; sending outlives the request, but keeps its
+	// values (trace, logger)
+	go s.sendInvitationEmail(context.WithoutCancel(ctx), inv, invitedBy, "email.invitation.subject")
 
 	return inv, nil
 }
@@ -225,6 +411,20 @@ func (s *service) GetPendingInvitations(ctx context.Context, orgID uuid.UUID) ([
 	return s.invitationRepo.GetPendingByOrgID(ctx, orgID)
 }
 
+func (s *service) GetInvitations(ctx context.Context, orgID uuid.UUID, status invitation.Status) ([]*invitation.Invitation, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetInvitations")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("status", string(status)),
+	)
+	defer span.End()
+
+	if status == invitation.StatusExpired {
+		return s.invitationRepo.GetExpiredByOrgID(ctx, orgID)
+	}
+	return s.invitationRepo.GetPendingByOrgID(ctx, orgID)
+}
+
 func (s *service) CancelInvitation(ctx context.Context, id uuid.UUID) error {
 	ctx, span := s.startServiceSpan(ctx, "CancelInvitation")
 	span.SetAttributes(attribute.String("invitation.id", id.String()))
@@ -259,21 +459,31 @@ func (s *service) ResendInvitation(ctx context.Context, id uuid.UUID) (*invitati
 		return nil, ErrInvitationAccepted
 	}
 
-	// Generate new token and extend expiration
+	org, err := s.orgRepo.GetByID(ctx, inv.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+
+	// Generate new token and extend expiration; the new period gets its own reminder
 	token, err := generateToken()
 	if err != nil {
 		return nil, err
 	}
 
 	inv.Token = token
-	inv.ExpiresAt = time.Now().Add(InvitationExpiry)
+	inv.ExpiresAt = time.Now().Add(invitationTTL(org))
+	inv.RemindedAt = nil
+	inv.ExpiredAt = nil
 
 	if err := s.invitationRepo.Update(ctx, inv); err != nil {
 		return nil, err
 	}
 
-	// Send invitation email asynchronously (use background context since request context will be canceled)
-	go s.sendInvitationEmail(context.Background(), inv, inv.InvitedBy)
+	// Send the invitation email with the new link asynchronously
+	go s.sendInvitationEmail(context.WithoutCancel(ctx), inv, inv.InvitedBy, "email.invitation.subject")
 
 	return inv, nil
 }
@@ -317,22 +527,56 @@ func (s *service) AcceptInvitation(ctx context.Context, token string, userID uui
 		return nil, ErrAlreadyMember
 	}
 
+	// The limit may have been lowered since the invitation was sent
+	if inv.IsGuest {
+		guests, err := s.countGuests(ctx, inv.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if s.guestLimitReached(guests) {
+			return nil, ErrGuestLimitReached
+		}
+	}
+
 	// Create membership
 	member := &organization_member.OrganizationMember{
 		OrganizationID: inv.OrganizationID,
 		UserID:         userID,
 		RoleID:         inv.RoleID,
 		Role:           "member", // Legacy field
+		IsGuest:        inv.IsGuest,
 	}
 
-	if err := s.orgMemberRepo.Create(ctx, member); err != nil {
-		return nil, err
-	}
+	// Membership, acceptance and the event are recorded together
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orgMemberRepo.Create(ctx, member); err != nil {
+			return err
+		}
 
-	// Mark invitation as accepted
-	now := time.Now()
-	inv.AcceptedAt = &now
-	if err := s.invitationRepo.Update(ctx, inv); err != nil {
+		if inv.ProjectID != nil {
+			if err := s.projMemberRepo.Create(ctx, &project_member.ProjectMember{
+				ProjectID: *inv.ProjectID,
+				UserID:    userID,
+				RoleID:    inv.ProjectRoleID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Mark invitation as accepted
+		now := time.Now()
+		inv.AcceptedAt = &now
+		if err := s.invitationRepo.Update(ctx, inv); err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.MemberAdded, events.MemberAddedPayload{
+			OrganizationID: inv.OrganizationID,
+			UserID:         userID,
+			RoleID:         inv.RoleID,
+		}))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -383,17 +627,136 @@ func (s *service) GetInviter(ctx context.Context, invID uuid.UUID) (*user.User,
 	return s.userRepo.GetByID(ctx, inv.InvitedBy)
 }
 
-// sendInvitationEmail sends an invitation email to the invitee
-func (s *service) sendInvitationEmail(ctx context.Context, inv *invitation.Invitation, invitedByID uuid.UUID) {
+func (s *service) GetInvitationProject(ctx context.Context, invID uuid.UUID) (*project.Project, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetInvitationProject")
+	span.SetAttributes(attribute.String("invitation.id", invID.String()))
+	defer span.End()
+
+	inv, err := s.invitationRepo.GetByID(ctx, invID)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.ProjectID == nil {
+		return nil, nil
+	}
+
+	return s.projectRepo.GetByID(ctx, *inv.ProjectID)
+}
+
+func (s *service) GetInvitationProjectRole(ctx context.Context, invID uuid.UUID) (*role.Role, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetInvitationProjectRole")
+	span.SetAttributes(attribute.String("invitation.id", invID.String()))
+	defer span.End()
+
+	inv, err := s.invitationRepo.GetByID(ctx, invID)
+	if err != nil {
+		return nil, err
+	}
+
+	if inv.ProjectRoleID == nil {
+		return nil, nil
+	}
+
+	return s.roleRepo.GetByID(ctx, *inv.ProjectRoleID)
+}
+
+func (s *service) SetExpiryPolicy(ctx context.Context, orgID uuid.UUID, ttlDays, reminderDays *int) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetExpiryPolicy")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	ttl := int(InvitationExpiry / (24 * time.Hour))
+	if ttlDays != nil {
+		ttl = *ttlDays
+	}
+	if ttl < 1 || ttl > MaxInvitationTTLDays || (reminderDays != nil && (*reminderDays < 1 || *reminderDays >= ttl)) {
+		return nil, ErrInvalidExpiryPolicy
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+
+	org.InvitationTTLDays = ttlDays
+	org.InvitationReminderDays = reminderDays
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (s *service) SendReminders(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "SendReminders")
+	defer span.End()
+
+	reminded := 0
+	for {
+		now := time.Now()
+		due, err := s.invitationRepo.GetDueReminders(ctx, now, reminderBatchSize)
+		if err != nil {
+			return reminded, err
+		}
+
+		for _, inv := range due {
+			// Claiming the reminder before sending keeps instances running the expirer side
+			// by side from reminding twice; a failed send is logged and not retried
+			claimed, err := s.invitationRepo.MarkReminded(ctx, inv.ID, now)
+			if err != nil {
+				return reminded, err
+			}
+			if !claimed {
+				continue
+			}
+			s.sendInvitationEmail(ctx, inv, inv.InvitedBy, "email.invitation.reminder_subject")
+			reminded++
+		}
+
+		if len(due) < reminderBatchSize {
+			return reminded, nil
+		}
+	}
+}
+
+func (s *service) ExpireStale(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "ExpireStale")
+	defer span.End()
+
+	expired, err := s.invitationRepo.ExpireStale(ctx, time.Now())
+	return int(expired), err
+}
+
+// invitationTTL is how long the organization's invitations stay valid
+func invitationTTL(org *organization.Organization) time.Duration {
+	if org.InvitationTTLDays != nil {
+		return time.Duration(*org.InvitationTTLDays) * 24 * time.Hour
+	}
+	return InvitationExpiry
+}
+
+// sendInvitationEmail sends an invitation email with the accept link to the invitee, with the
+// subject of subjectKey. Failures are logged; the invitation stands and can be resent
+func (s *service) sendInvitationEmail(ctx context.Context, inv *invitation.Invitation, invitedByID uuid.UUID, subjectKey string) {
+	if s.mailService == nil {
+		return
+	}
+	log := logger.FromCtx(ctx)
+
 	// Get organization name
 	org, err := s.orgRepo.GetByID(ctx, inv.OrganizationID)
 	if err != nil {
-		return // Silently fail - email is not critical
+		log.Error().Err(err).Str("invitation_id", inv.ID.String()).Msg("Failed to load the organization of an invitation email")
+		return
 	}
 
 	// Get inviter name
 	inviter, err := s.userRepo.GetByID(ctx, invitedByID)
 	if err != nil {
+		log.Error().Err(err).Str("invitation_id", inv.ID.String()).Msg("Failed to load the inviter of an invitation email")
 		return
 	}
 	inviterName := inviter.Username
@@ -401,8 +764,15 @@ func (s *service) sendInvitationEmail(ctx context.Context, inv *invitation.Invit
 		inviterName = *inviter.DisplayName
 	}
 
+	// Write in the invitee's language when they already have an account, else the org's
+	var inviteeLocale string
+	if invitee, err := s.userRepo.GetByEmail(ctx, inv.Email); err == nil && invitee.Locale != nil {
+		inviteeLocale = *invitee.Locale
+	}
+	ctx = i18n.WithLocale(ctx, i18n.Resolve(inviteeLocale, org.DefaultLocale))
+
 	// Get role name
-	roleName := "Member"
+	roleName := i18n.Tc(ctx, "email.invitation.default_role", nil)
 	if inv.RoleID != nil {
 		role, err := s.roleRepo.GetByID(ctx, *inv.RoleID)
 		if err == nil && role != nil {
@@ -413,18 +783,20 @@ func (s *service) sendInvitationEmail(ctx context.Context, inv *invitation.Invit
 	// Build invitation URL
 	inviteURL := fmt.Sprintf("%s/%s", s.emailConfig.InvitationURL, inv.Token)
 
-	// Send the email if mail service is configured
-	if s.mailService == nil {
-		return
+	if s.brandingService != nil {
+		ctx = mail.WithBranding(ctx, s.brandingService.ForOrganization(ctx, org.ID))
 	}
-	err = s.mailService.SendMail(ctx, []string{inv.Email}, fmt.Sprintf("You've been invited to join %s", org.Name), "invitation.mjml", map[string]string{
+	// Whole days left, so a fresh invitation reads as its full TTL
+	expiryDays := int(math.Ceil(time.Until(inv.ExpiresAt).Hours() / 24))
+
+	err = s.mailService.SendMail(ctx, []string{inv.Email}, i18n.Tc(ctx, subjectKey, map[string]string{"organization": org.Name}), "invitation.mjml", map[string]string{
 		"organization_name": org.Name,
 		"inviter_name":      inviterName,
 		"role_name":         roleName,
 		"invite_url":        inviteURL,
+		"expiry_days":       strconv.Itoa(expiryDays),
 	})
 	if err != nil {
-		// Log error but don't fail - email is not critical
-		return
+		log.Error().Err(err).Str("invitation_id", inv.ID.String()).Msg("Failed to send invitation email")
 	}
 }
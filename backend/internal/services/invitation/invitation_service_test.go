@@ -0,0 +1,132 @@
+package invitation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	invitationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestSetExpiryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sets the TTL and reminder period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(invitationMocks.NewMockRepository(ctrl), mockOrgRepo, nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+		org := &organization.Organization{ID: uuid.New()}
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), org.ID).Return(org, nil)
+		mockOrgRepo.EXPECT().Update(gomock.Any(), org).Return(nil)
+
+		result, err := svc.SetExpiryPolicy(ctx, org.ID, intPtr(14), intPtr(3))
+		require.NoError(t, err)
+		assert.Equal(t, 14, *result.InvitationTTLDays)
+		assert.Equal(t, 3, *result.InvitationReminderDays)
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		for name, tc := range map[string]struct{ ttlDays, reminderDays *int }{
+			"TTL below a day":            {ttlDays: intPtr(0)},
+			"TTL over the maximum":       {ttlDays: intPtr(MaxInvitationTTLDays + 1)},
+			"reminder not before expiry": {ttlDays: intPtr(5), reminderDays: intPtr(5)},
+			"reminder past the default":  {reminderDays: intPtr(7)},
+			"reminder below a day":       {reminderDays: intPtr(0)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				svc := NewService(invitationMocks.NewMockRepository(ctrl), orgMocks.NewMockRepository(ctrl), nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+				_, err := svc.SetExpiryPolicy(ctx, uuid.New(), tc.ttlDays, tc.reminderDays)
+				assert.ErrorIs(t, err, ErrInvalidExpiryPolicy)
+			})
+		}
+	})
+}
+
+func TestResendInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockInvitationRepo, mockOrgRepo, nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+	reminded := time.Now().Add(-time.Hour)
+	inv := &invitation.Invitation{ID: uuid.New(), OrganizationID: uuid.New(), Token: "old", RemindedAt: &reminded, ExpiredAt: &reminded}
+	mockInvitationRepo.EXPECT().GetByID(gomock.Any(), inv.ID).Return(inv, nil)
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), inv.OrganizationID).Return(&organization.Organization{ID: inv.OrganizationID, InvitationTTLDays: intPtr(30)}, nil)
+	mockInvitationRepo.EXPECT().Update(gomock.Any(), inv).Return(nil)
+
+	result, err := svc.ResendInvitation(context.Background(), inv.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, "old", result.Token)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), result.ExpiresAt, time.Minute)
+	assert.Nil(t, result.RemindedAt)
+	assert.Nil(t, result.ExpiredAt)
+}
+
+func TestGetInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockInvitationRepo, orgMocks.NewMockRepository(ctrl), nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+	orgID := uuid.New()
+	pending := []*invitation.Invitation{{ID: uuid.New()}}
+	expired := []*invitation.Invitation{{ID: uuid.New()}}
+	mockInvitationRepo.EXPECT().GetPendingByOrgID(gomock.Any(), orgID).Return(pending, nil)
+	mockInvitationRepo.EXPECT().GetExpiredByOrgID(gomock.Any(), orgID).Return(expired, nil)
+
+	result, err := svc.GetInvitations(context.Background(), orgID, invitation.StatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, pending, result)
+
+	result, err = svc.GetInvitations(context.Background(), orgID, invitation.StatusExpired)
+	require.NoError(t, err)
+	assert.Equal(t, expired, result)
+}
+
+func TestSendReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockInvitationRepo, orgMocks.NewMockRepository(ctrl), nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+	claimed := &invitation.Invitation{ID: uuid.New()}
+	taken := &invitation.Invitation{ID: uuid.New()}
+	mockInvitationRepo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), reminderBatchSize).Return([]*invitation.Invitation{claimed, taken}, nil)
+	mockInvitationRepo.EXPECT().MarkReminded(gomock.Any(), claimed.ID, gomock.Any()).Return(true, nil)
+	// Another instance reminded this one first
+	mockInvitationRepo.EXPECT().MarkReminded(gomock.Any(), taken.ID, gomock.Any()).Return(false, nil)
+
+	reminded, err := svc.SendReminders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, reminded)
+}
+
+func TestExpireStale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockInvitationRepo, orgMocks.NewMockRepository(ctrl), nil, nil, nil, nil, nil, nil, nil, config.EmailConfig{}, config.MembershipConfig{}, transaction.NewNoopManager(), events.NewSyncBus())
+
+	mockInvitationRepo.EXPECT().ExpireStale(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+
+	expired, err := svc.ExpireStale(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, expired)
+}
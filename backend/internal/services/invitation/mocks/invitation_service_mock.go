@@ -0,0 +1,316 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invitation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=invitation_service.go -destination=mocks/invitation_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	invitation "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	project "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	role "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	user "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockService) AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, token, userID)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockServiceMockRecorder) AcceptInvitation(ctx, token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockService)(nil).AcceptInvitation), ctx, token, userID)
+}
+
+// CancelInvitation mocks base method.
+func (m *MockService) CancelInvitation(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelInvitation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelInvitation indicates an expected call of CancelInvitation.
+func (mr *MockServiceMockRecorder) CancelInvitation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelInvitation", reflect.TypeOf((*MockService)(nil).CancelInvitation), ctx, id)
+}
+
+// CreateGuestInvitation mocks base method.
+func (m *MockService) CreateGuestInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestInvitation", ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestInvitation indicates an expected call of CreateGuestInvitation.
+func (mr *MockServiceMockRecorder) CreateGuestInvitation(ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestInvitation", reflect.TypeOf((*MockService)(nil).CreateGuestInvitation), ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockService) CreateInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID, invitedBy uuid.UUID) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", ctx, orgID, email, roleID, invitedBy)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockServiceMockRecorder) CreateInvitation(ctx, orgID, email, roleID, invitedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockService)(nil).CreateInvitation), ctx, orgID, email, roleID, invitedBy)
+}
+
+// CreateProjectInvitation mocks base method.
+func (m *MockService) CreateProjectInvitation(ctx context.Context, orgID uuid.UUID, email string, roleID, projectID uuid.UUID, projectRoleID *uuid.UUID, invitedBy uuid.UUID) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProjectInvitation", ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProjectInvitation indicates an expected call of CreateProjectInvitation.
+func (mr *MockServiceMockRecorder) CreateProjectInvitation(ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectInvitation", reflect.TypeOf((*MockService)(nil).CreateProjectInvitation), ctx, orgID, email, roleID, projectID, projectRoleID, invitedBy)
+}
+
+// ExpireStale mocks base method.
+func (m *MockService) ExpireStale(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireStale", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireStale indicates an expected call of ExpireStale.
+func (mr *MockServiceMockRecorder) ExpireStale(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireStale", reflect.TypeOf((*MockService)(nil).ExpireStale), ctx)
+}
+
+// GetInvitation mocks base method.
+func (m *MockService) GetInvitation(ctx context.Context, id uuid.UUID) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, id)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockServiceMockRecorder) GetInvitation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockService)(nil).GetInvitation), ctx, id)
+}
+
+// GetInvitationByToken mocks base method.
+func (m *MockService) GetInvitationByToken(ctx context.Context, token string) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByToken", ctx, token)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationByToken indicates an expected call of GetInvitationByToken.
+func (mr *MockServiceMockRecorder) GetInvitationByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByToken", reflect.TypeOf((*MockService)(nil).GetInvitationByToken), ctx, token)
+}
+
+// GetInvitationOrganization mocks base method.
+func (m *MockService) GetInvitationOrganization(ctx context.Context, invID uuid.UUID) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationOrganization", ctx, invID)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationOrganization indicates an expected call of GetInvitationOrganization.
+func (mr *MockServiceMockRecorder) GetInvitationOrganization(ctx, invID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationOrganization", reflect.TypeOf((*MockService)(nil).GetInvitationOrganization), ctx, invID)
+}
+
+// GetInvitationProject mocks base method.
+func (m *MockService) GetInvitationProject(ctx context.Context, invID uuid.UUID) (*project.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationProject", ctx, invID)
+	ret0, _ := ret[0].(*project.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationProject indicates an expected call of GetInvitationProject.
+func (mr *MockServiceMockRecorder) GetInvitationProject(ctx, invID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationProject", reflect.TypeOf((*MockService)(nil).GetInvitationProject), ctx, invID)
+}
+
+// GetInvitationProjectRole mocks base method.
+func (m *MockService) GetInvitationProjectRole(ctx context.Context, invID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationProjectRole", ctx, invID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationProjectRole indicates an expected call of GetInvitationProjectRole.
+func (mr *MockServiceMockRecorder) GetInvitationProjectRole(ctx, invID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationProjectRole", reflect.TypeOf((*MockService)(nil).GetInvitationProjectRole), ctx, invID)
+}
+
+// GetInvitationRole mocks base method.
+func (m *MockService) GetInvitationRole(ctx context.Context, invID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationRole", ctx, invID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationRole indicates an expected call of GetInvitationRole.
+func (mr *MockServiceMockRecorder) GetInvitationRole(ctx, invID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationRole", reflect.TypeOf((*MockService)(nil).GetInvitationRole), ctx, invID)
+}
+
+// GetInvitations mocks base method.
+func (m *MockService) GetInvitations(ctx context.Context, orgID uuid.UUID, status invitation.Status) ([]*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, orgID, status)
+	ret0, _ := ret[0].([]*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockServiceMockRecorder) GetInvitations(ctx, orgID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockService)(nil).GetInvitations), ctx, orgID, status)
+}
+
+// GetInviter mocks base method.
+func (m *MockService) GetInviter(ctx context.Context, invID uuid.UUID) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInviter", ctx, invID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInviter indicates an expected call of GetInviter.
+func (mr *MockServiceMockRecorder) GetInviter(ctx, invID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInviter", reflect.TypeOf((*MockService)(nil).GetInviter), ctx, invID)
+}
+
+// GetPendingInvitations mocks base method.
+func (m *MockService) GetPendingInvitations(ctx context.Context, orgID uuid.UUID) ([]*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvitations", ctx, orgID)
+	ret0, _ := ret[0].([]*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvitations indicates an expected call of GetPendingInvitations.
+func (mr *MockServiceMockRecorder) GetPendingInvitations(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitations", reflect.TypeOf((*MockService)(nil).GetPendingInvitations), ctx, orgID)
+}
+
+// ResendInvitation mocks base method.
+func (m *MockService) ResendInvitation(ctx context.Context, id uuid.UUID) (*invitation.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendInvitation", ctx, id)
+	ret0, _ := ret[0].(*invitation.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendInvitation indicates an expected call of ResendInvitation.
+func (mr *MockServiceMockRecorder) ResendInvitation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendInvitation", reflect.TypeOf((*MockService)(nil).ResendInvitation), ctx, id)
+}
+
+// SendReminders mocks base method.
+func (m *MockService) SendReminders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReminders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendReminders indicates an expected call of SendReminders.
+func (mr *MockServiceMockRecorder) SendReminders(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReminders", reflect.TypeOf((*MockService)(nil).SendReminders), ctx)
+}
+
+// SetExpiryPolicy mocks base method.
+func (m *MockService) SetExpiryPolicy(ctx context.Context, orgID uuid.UUID, ttlDays, reminderDays *int) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiryPolicy", ctx, orgID, ttlDays, reminderDays)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExpiryPolicy indicates an expected call of SetExpiryPolicy.
+func (mr *MockServiceMockRecorder) SetExpiryPolicy(ctx, orgID, ttlDays, reminderDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiryPolicy", reflect.TypeOf((*MockService)(nil).SetExpiryPolicy), ctx, orgID, ttlDays, reminderDays)
+}
@@ -0,0 +1,232 @@
+package jira
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export is a project in the terms of Jira's importers. Users are referred to by their
+// Kaimu username, and statuses by the names of the columns issues are in.
+type Export struct {
+	ProjectKey         string
+	ProjectName        string
+	ProjectDescription string
+	Users              []User
+	// Statuses are the distinct column names of the project's boards, with the category of
+	// the first column of each name
+	Statuses []Status
+	Sprints  []Sprint
+	// Issues are numbered in the order their cards were created
+	Issues []Issue
+}
+
+type User struct {
+	Name     string
+	FullName string
+	Email    string
+}
+
+type Status struct {
+	Name     string
+	Category StatusCategory
+}
+
+type Sprint struct {
+	Name      string
+	State     string
+	Goal      string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type Issue struct {
+	Key string
+	// ExternalID is the ID of the card
+	ExternalID  string
+	Summary     string
+	Description string
+	Status      string
+	Priority    string
+	// Resolution is empty for unresolved issues
+	Resolution  string
+	Reporter    string
+	Assignee    string
+	Created     time.Time
+	Updated     time.Time
+	DueDate     *time.Time
+	Labels      []string
+	StoryPoints *int
+	// Sprints are the names of the sprints the card was in
+	Sprints []string
+}
+
+// The document read by Jira's JSON importer
+type jsonDocument struct {
+	Users    []jsonUser    `json:"users"`
+	Projects []jsonProject `json:"projects"`
+}
+
+type jsonUser struct {
+	Name     string `json:"name"`
+	FullName string `json:"fullname"`
+	Email    string `json:"email,omitempty"`
+}
+
+type jsonProject struct {
+	Name        string      `json:"name"`
+	Key         string      `json:"key"`
+	Description string      `json:"description,omitempty"`
+	Type        string      `json:"type"`
+	Issues      []jsonIssue `json:"issues"`
+}
+
+type jsonIssue struct {
+	ExternalID        string            `json:"externalId"`
+	Key               string            `json:"key"`
+	IssueType         string            `json:"issueType"`
+	Summary           string            `json:"summary"`
+	Description       string            `json:"description,omitempty"`
+	Status            string            `json:"status"`
+	Priority          string            `json:"priority,omitempty"`
+	Resolution        string            `json:"resolution,omitempty"`
+	Reporter          string            `json:"reporter,omitempty"`
+	Assignee          string            `json:"assignee,omitempty"`
+	Created           string            `json:"created"`
+	Updated           string            `json:"updated"`
+	DueDate           string            `json:"duedate,omitempty"`
+	Labels            []string          `json:"labels,omitempty"`
+	CustomFieldValues []jsonCustomField `json:"customFieldValues,omitempty"`
+}
+
+type jsonCustomField struct {
+	FieldName string      `json:"fieldName"`
+	FieldType string      `json:"fieldType"`
+	Value     interface{} `json:"value"`
+}
+
+// MarshalJSONDocument returns the export as an indented document for Jira's JSON importer
+func (e *Export) MarshalJSONDocument() ([]byte, error) {
+	users := make([]jsonUser, len(e.Users))
+	for i, u := range e.Users {
+		users[i] = jsonUser{Name: u.Name, FullName: u.FullName, Email: u.Email}
+	}
+
+	issues := make([]jsonIssue, len(e.Issues))
+	for i, is := range e.Issues {
+		issues[i] = jsonIssue{
+			ExternalID:  is.ExternalID,
+			Key:         is.Key,
+			IssueType:   IssueType,
+			Summary:     is.Summary,
+			Description: is.Description,
+			Status:      is.Status,
+			Priority:    is.Priority,
+			Resolution:  is.Resolution,
+			Reporter:    is.Reporter,
+			Assignee:    is.Assignee,
+			Created:     FormatTime(is.Created),
+			Updated:     FormatTime(is.Updated),
+			Labels:      is.Labels,
+		}
+		if is.DueDate != nil {
+			issues[i].DueDate = FormatTime(*is.DueDate)
+		}
+		if is.StoryPoints != nil {
+			issues[i].CustomFieldValues = append(issues[i].CustomFieldValues, jsonCustomField{
+				FieldName: FieldStoryPoints,
+				FieldType: fieldTypeStoryPoints,
+				Value:     strconv.Itoa(*is.StoryPoints),
+			})
+		}
+		if len(is.Sprints) > 0 {
+			issues[i].CustomFieldValues = append(issues[i].CustomFieldValues, jsonCustomField{
+				FieldName: FieldSprint,
+				FieldType: fieldTypeSprint,
+				Value:     is.Sprints,
+			})
+		}
+	}
+
+	return json.MarshalIndent(jsonDocument{
+		Users: users,
+		Projects: []jsonProject{{
+			Name:        e.ProjectName,
+			Key:         e.ProjectKey,
+			Description: e.ProjectDescription,
+			Type:        "software",
+			Issues:      issues,
+		}},
+	}, "", "  ")
+}
+
+// WriteCSV writes the export for Jira's CSV importer, one row per issue. Labels and sprints
+// take as many repeated columns as the issue with the most of them needs, as the importer
+// expects multiple values. Dates are in CSVDateFormat, in UTC.
+func (e *Export) WriteCSV(w io.Writer) error {
+	maxLabels, maxSprints := 0, 0
+	for _, is := range e.Issues {
+		maxLabels = max(maxLabels, len(is.Labels))
+		maxSprints = max(maxSprints, len(is.Sprints))
+	}
+
+	header := []string{"Issue key", "Issue id", "Issue Type", "Summary", "Description", "Status", "Resolution", "Priority", "Reporter", "Assignee", "Created", "Updated", "Due date", FieldStoryPoints}
+	header = append(header, repeat("Labels", maxLabels)...)
+	header = append(header, repeat(FieldSprint, maxSprints)...)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, is := range e.Issues {
+		dueDate, storyPoints := "", ""
+		if is.DueDate != nil {
+			dueDate = FormatCSVTime(*is.DueDate)
+		}
+		if is.StoryPoints != nil {
+			storyPoints = strconv.Itoa(*is.StoryPoints)
+		}
+
+		row := []string{
+			is.Key,
+			is.ExternalID,
+			IssueType,
+			is.Summary,
+			is.Description,
+			is.Status,
+			is.Resolution,
+			is.Priority,
+			is.Reporter,
+			is.Assignee,
+			FormatCSVTime(is.Created),
+			FormatCSVTime(is.Updated),
+			dueDate,
+			storyPoints,
+		}
+		row = append(row, padded(is.Labels, maxLabels)...)
+		row = append(row, padded(is.Sprints, maxSprints)...)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func repeat(s string, n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = s
+	}
+	return values
+}
+
+// padded returns values with empty strings appended up to n
+func padded(values []string, n int) []string {
+	result := make([]string, n)
+	copy(result, values)
+	return result
+}
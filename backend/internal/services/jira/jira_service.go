@@ -0,0 +1,287 @@
+package jira
+
+//go:generate mockgen -source=jira_service.go -destination=mocks/jira_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrTooManyIssues   = errors.New("project has too many cards to export at once")
+)
+
+// MaxIssues caps how many cards a project export holds
+const MaxIssues = 10000
+
+type Service interface {
+	// ExportProject describes the project's cards, including archived ones, their columns and
+	// sprints and the users they reference as Jira issues, statuses, sprints and users.
+	// Cards merged into another card are left out.
+	ExportProject(ctx context.Context, projectID uuid.UUID) (*Export, error)
+}
+
+type service struct {
+	projectRepo project.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	cardRepo    card.Repository
+	cardTagRepo card_tag.Repository
+	tagRepo     tag.Repository
+	sprintRepo  sprint.Repository
+	userRepo    user.Repository
+}
+
+func NewService(
+	projectRepo project.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+	cardTagRepo card_tag.Repository,
+	tagRepo tag.Repository,
+	sprintRepo sprint.Repository,
+	userRepo user.Repository,
+) Service {
+	return &service{
+		projectRepo: projectRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		cardRepo:    cardRepo,
+		cardTagRepo: cardTagRepo,
+		tagRepo:     tagRepo,
+		sprintRepo:  sprintRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "jira.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "jira"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) ExportProject(ctx context.Context, projectID uuid.UUID) (*Export, error) {
+	ctx, span := s.startServiceSpan(ctx, "ExportProject")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	boards, err := s.boardRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		ProjectKey:         strings.ToUpper(p.Key),
+		ProjectName:        p.Name,
+		ProjectDescription: Description(p.Description),
+	}
+	columnNames := make(map[uuid.UUID]string)
+	categories := make(map[uuid.UUID]StatusCategory)
+	seenStatuses := make(map[string]bool)
+	sprintNames := make(map[uuid.UUID][]string)
+	var cards []*card.Card
+
+	for _, b := range boards {
+		columns, err := s.columnRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+		for id, category := range StatusCategories(columns) {
+			categories[id] = category
+		}
+		for _, col := range columns {
+			columnNames[col.ID] = col.Name
+			if !seenStatuses[strings.ToLower(col.Name)] {
+				seenStatuses[strings.ToLower(col.Name)] = true
+				export.Statuses = append(export.Statuses, Status{Name: col.Name, Category: categories[col.ID]})
+			}
+		}
+
+		sprints, err := s.sprintRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sp := range sprints {
+			export.Sprints = append(export.Sprints, Sprint{
+				Name:      sp.Name,
+				State:     SprintState(sp.Status),
+				Goal:      sp.Goal,
+				StartDate: sp.StartDate,
+				EndDate:   sp.EndDate,
+			})
+			sprintCards, err := s.cardRepo.GetBySprintID(ctx, sp.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range sprintCards {
+				sprintNames[c.ID] = append(sprintNames[c.ID], sp.Name)
+			}
+		}
+
+		active, err := s.cardRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := s.cardRepo.GetArchivedByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, active...)
+		for _, c := range archived {
+			if c.MergedIntoID == nil {
+				cards = append(cards, c)
+			}
+		}
+		if len(cards) > MaxIssues {
+			return nil, ErrTooManyIssues
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if !cards[i].CreatedAt.Equal(cards[j].CreatedAt) {
+			return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+		}
+		return cards[i].ID.String() < cards[j].ID.String()
+	})
+
+	labels, err := s.labelsByCard(ctx, projectID, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	users := newUserCollector(s.userRepo)
+	export.Issues = make([]Issue, len(cards))
+	for i, c := range cards {
+		issue := Issue{
+			Key:         IssueKey(p.Key, i+1),
+			ExternalID:  c.ID.String(),
+			Summary:     c.Title,
+			Description: Description(c.Description),
+			Status:      columnNames[c.ColumnID],
+			Priority:    Priority(c.Priority),
+			Created:     c.CreatedAt,
+			Updated:     c.UpdatedAt,
+			DueDate:     c.DueDate,
+			Labels:      labels[c.ID],
+			StoryPoints: c.StoryPoints,
+			Sprints:     sprintNames[c.ID],
+		}
+		if categories[c.ColumnID] == StatusCategoryDone {
+			issue.Resolution = ResolutionDone
+		}
+		if issue.Reporter, err = users.name(ctx, c.CreatedBy); err != nil {
+			return nil, err
+		}
+		if issue.Assignee, err = users.name(ctx, c.AssigneeID); err != nil {
+			return nil, err
+		}
+		export.Issues[i] = issue
+	}
+	export.Users = users.users
+	return export, nil
+}
+
+// labelsByCard returns the labels of each card's tags, sorted
+func (s *service) labelsByCard(ctx context.Context, projectID uuid.UUID, cards []*card.Card) (map[uuid.UUID][]string, error) {
+	tags, err := s.tagRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make(map[uuid.UUID]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+	}
+
+	cardIDs := make([]uuid.UUID, len(cards))
+	for i, c := range cards {
+		cardIDs[i] = c.ID
+	}
+	cardTags, err := s.cardTagRepo.GetByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[uuid.UUID][]string)
+	for _, ct := range cardTags {
+		if name, ok := tagNames[ct.TagID]; ok {
+			labels[ct.CardID] = append(labels[ct.CardID], Label(name))
+		}
+	}
+	for _, l := range labels {
+		sort.Strings(l)
+	}
+	return labels, nil
+}
+
+// userCollector looks up the users issues reference, once each, in order of first reference
+type userCollector struct {
+	userRepo user.Repository
+	names    map[uuid.UUID]string
+	users    []User
+}
+
+func newUserCollector(userRepo user.Repository) *userCollector {
+	return &userCollector{userRepo: userRepo, names: make(map[uuid.UUID]string)}
+}
+
+// name returns the user's username, empty for nil IDs and deleted users
+func (c *userCollector) name(ctx context.Context, id *uuid.UUID) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if name, ok := c.names[*id]; ok {
+		return name, nil
+	}
+
+	u, err := c.userRepo.GetByID(ctx, *id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.names[*id] = ""
+			return "", nil
+		}
+		return "", err
+	}
+
+	exported := User{Name: u.Username, FullName: u.Username}
+	if u.DisplayName != nil && *u.DisplayName != "" {
+		exported.FullName = *u.DisplayName
+	}
+	if u.Email != nil {
+		exported.Email = *u.Email
+	}
+	c.users = append(c.users, exported)
+	c.names[*id] = u.Username
+	return u.Username, nil
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestExportProject(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectRepo := projectMocks.NewMockRepository(ctrl)
+	boardRepo := boardMocks.NewMockRepository(ctrl)
+	columnRepo := columnMocks.NewMockRepository(ctrl)
+	cardRepo := cardMocks.NewMockRepository(ctrl)
+	cardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+	tagRepo := tagMocks.NewMockRepository(ctrl)
+	sprintRepo := sprintMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	svc := NewService(projectRepo, boardRepo, columnRepo, cardRepo, cardTagRepo, tagRepo, sprintRepo, userRepo)
+
+	created := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	p := &project.Project{ID: uuid.New(), Name: "Kaimu", Key: "kai"}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "To Do", Position: 0}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Done", Position: 1, IsDone: true}
+	sp := &sprint.Sprint{ID: uuid.New(), BoardID: b.ID, Name: "Sprint 1", Status: sprint.SprintStatusActive}
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: p.ID, Name: "needs review"}
+
+	email := "ana@example.com"
+	displayName := "Ana Lima"
+	ana := &user.User{ID: uuid.New(), Username: "ana", Email: &email, DisplayName: &displayName}
+	goneID := uuid.New()
+	points := 3
+
+	second := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: done.ID, Title: "Ship it", Priority: card.PriorityUrgent, CreatedBy: &ana.ID, AssigneeID: &goneID, CreatedAt: created.Add(time.Hour), UpdatedAt: created.Add(time.Hour)}
+	first := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Title: "Fix login", Description: "<p>Users <b>can't</b> sign in</p>", StoryPoints: &points, AssigneeID: &ana.ID, CreatedAt: created, UpdatedAt: created}
+	archived := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: done.ID, Title: "Old", CreatedAt: created.Add(2 * time.Hour), UpdatedAt: created.Add(2 * time.Hour)}
+	merged := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Title: "Duplicate", MergedIntoID: &first.ID, CreatedAt: created}
+
+	projectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+	boardRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).Return([]*board.Board{b}, nil)
+	columnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{done, todo}, nil)
+	sprintRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*sprint.Sprint{sp}, nil)
+	cardRepo.EXPECT().GetBySprintID(gomock.Any(), sp.ID).Return([]*card.Card{first}, nil)
+	cardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*card.Card{second, first}, nil)
+	cardRepo.EXPECT().GetArchivedByBoardID(gomock.Any(), b.ID).Return([]*card.Card{archived, merged}, nil)
+	tagRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).Return([]*tag.Tag{bug}, nil)
+	cardTagRepo.EXPECT().GetByCardIDs(gomock.Any(), []uuid.UUID{first.ID, second.ID, archived.ID}).
+		Return([]*card_tag.CardTag{{CardID: first.ID, TagID: bug.ID}}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), ana.ID).Return(ana, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), goneID).Return(nil, gorm.ErrRecordNotFound)
+
+	export, err := svc.ExportProject(ctx, p.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "KAI", export.ProjectKey)
+	assert.Equal(t, []Status{{Name: "To Do", Category: StatusCategoryToDo}, {Name: "Done", Category: StatusCategoryDone}}, export.Statuses)
+	assert.Equal(t, []Sprint{{Name: "Sprint 1", State: "ACTIVE"}}, export.Sprints)
+	assert.Equal(t, []User{{Name: "ana", FullName: "Ana Lima", Email: email}}, export.Users)
+
+	require.Len(t, export.Issues, 3)
+	assert.Equal(t, Issue{
+		Key:         "KAI-1",
+		ExternalID:  first.ID.String(),
+		Summary:     "Fix login",
+		Description: "Users can't sign in",
+		Status:      "To Do",
+		Assignee:    "ana",
+		Created:     created,
+		Updated:     created,
+		Labels:      []string{"needs_review"},
+		StoryPoints: &points,
+		Sprints:     []string{"Sprint 1"},
+	}, export.Issues[0])
+	assert.Equal(t, "KAI-2", export.Issues[1].Key)
+	assert.Equal(t, "Highest", export.Issues[1].Priority)
+	assert.Equal(t, ResolutionDone, export.Issues[1].Resolution)
+	assert.Equal(t, "ana", export.Issues[1].Reporter)
+	assert.Empty(t, export.Issues[1].Assignee)
+	assert.Equal(t, "Old", export.Issues[2].Summary)
+
+	t.Run("JSON document", func(t *testing.T) {
+		data, err := export.MarshalJSONDocument()
+		require.NoError(t, err)
+
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &doc))
+		issue := doc["projects"].([]interface{})[0].(map[string]interface{})["issues"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "KAI-1", issue["key"])
+		assert.Equal(t, "2026-03-02T09:30:00.000+0000", issue["created"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"fieldName": "Story Points", "fieldType": fieldTypeStoryPoints, "value": "3"},
+			map[string]interface{}{"fieldName": "Sprint", "fieldType": fieldTypeSprint, "value": []interface{}{"Sprint 1"}},
+		}, issue["customFieldValues"])
+	})
+
+	t.Run("CSV document", func(t *testing.T) {
+		var out strings.Builder
+		require.NoError(t, export.WriteCSV(&out))
+
+		rows, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, []string{"Labels", "Sprint"}, rows[0][len(rows[0])-2:])
+		assert.Equal(t, []string{"KAI-1", first.ID.String(), "Task", "Fix login", "Users can't sign in", "To Do", "", "", "", "ana", "2026-03-02 09:30", "2026-03-02 09:30", "", "3", "needs_review", "Sprint 1"}, rows[1])
+		assert.Equal(t, []string{"", ""}, rows[3][len(rows[3])-2:])
+	})
+}
+
+func TestExportProject_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectRepo := projectMocks.NewMockRepository(ctrl)
+	svc := NewService(projectRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	id := uuid.New()
+	projectRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := svc.ExportProject(context.Background(), id)
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
@@ -0,0 +1,139 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+)
+
+// The mapping between Kaimu and Jira concepts, in both directions where an importer shares
+// it with the export
+
+// StatusCategory is the Jira category a status belongs to
+type StatusCategory string
+
+const (
+	StatusCategoryToDo       StatusCategory = "To Do"
+	StatusCategoryInProgress StatusCategory = "In Progress"
+	StatusCategoryDone       StatusCategory = "Done"
+)
+
+const (
+	// IssueType is the Jira issue type cards are exported as
+	IssueType = "Task"
+	// ResolutionDone resolves issues in done columns
+	ResolutionDone = "Done"
+
+	// TimeLayout is the Go layout of dates in JSON import documents
+	TimeLayout = "2006-01-02T15:04:05.000-0700"
+	// CSVTimeLayout is the Go layout of dates in CSV import documents; CSVDateFormat is the
+	// same layout as Jira's import wizard expects it
+	CSVTimeLayout = "2006-01-02 15:04"
+	CSVDateFormat = "yyyy-MM-dd HH:mm"
+
+	// FieldStoryPoints and FieldSprint are the custom fields holding story points and sprints
+	FieldStoryPoints     = "Story Points"
+	FieldSprint          = "Sprint"
+	fieldTypeStoryPoints = "com.atlassian.jira.plugin.system.customfieldtypes:float"
+	fieldTypeSprint      = "com.pyxis.greenhopper.jira:gh-sprint"
+)
+
+// StatusCategories maps the ID of each column of a board, given in board order, to a Jira
+// status category: done columns are Done, backlog columns and the first other column To Do,
+// the rest In Progress
+func StatusCategories(columns []*board_column.BoardColumn) map[uuid.UUID]StatusCategory {
+	categories := make(map[uuid.UUID]StatusCategory, len(columns))
+	seenWork := false
+	for _, col := range columns {
+		switch {
+		case col.IsDone:
+			categories[col.ID] = StatusCategoryDone
+		case col.IsBacklog:
+			categories[col.ID] = StatusCategoryToDo
+		case !seenWork:
+			categories[col.ID] = StatusCategoryToDo
+			seenWork = true
+		default:
+			categories[col.ID] = StatusCategoryInProgress
+		}
+	}
+	return categories
+}
+
+// Priority returns the Jira priority of a card priority, empty for cards without one so
+// Jira applies its default
+func Priority(p card.CardPriority) string {
+	switch p {
+	case card.PriorityUrgent:
+		return "Highest"
+	case card.PriorityHigh:
+		return "High"
+	case card.PriorityMedium:
+		return "Medium"
+	case card.PriorityLow:
+		return "Low"
+	default:
+		return ""
+	}
+}
+
+// CardPriority returns the card priority of a Jira priority, including the names of Jira's
+// older priority scheme
+func CardPriority(p string) card.CardPriority {
+	switch strings.ToLower(strings.TrimSpace(p)) {
+	case "highest", "blocker", "critical":
+		return card.PriorityUrgent
+	case "high", "major":
+		return card.PriorityHigh
+	case "medium":
+		return card.PriorityMedium
+	case "low", "lowest", "minor", "trivial":
+		return card.PriorityLow
+	default:
+		return card.PriorityNone
+	}
+}
+
+// SprintState returns the Jira state of a sprint
+func SprintState(s sprint.SprintStatus) string {
+	switch s {
+	case sprint.SprintStatusActive:
+		return "ACTIVE"
+	case sprint.SprintStatusClosed:
+		return "CLOSED"
+	default:
+		return "FUTURE"
+	}
+}
+
+// Description turns a card's HTML description into the plain text Jira imports, with its
+// whitespace collapsed
+func Description(html string) string {
+	return strings.Join(strings.Fields(sanitize.PlainText(html)), " ")
+}
+
+// Label turns a tag name into a Jira label, which can't contain spaces
+func Label(tagName string) string {
+	return strings.Join(strings.Fields(tagName), "_")
+}
+
+// IssueKey is the key of the n-th issue of the project, counting from 1
+func IssueKey(projectKey string, n int) string {
+	return fmt.Sprintf("%s-%d", strings.ToUpper(projectKey), n)
+}
+
+// FormatTime formats a time in UTC for JSON import documents
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(TimeLayout)
+}
+
+// FormatCSVTime formats a time in UTC for CSV import documents
+func FormatCSVTime(t time.Time) string {
+	return t.UTC().Format(CSVTimeLayout)
+}
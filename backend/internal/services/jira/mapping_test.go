@@ -0,0 +1,48 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+)
+
+func TestStatusCategories(t *testing.T) {
+	backlog := &board_column.BoardColumn{ID: uuid.New(), Name: "Backlog", IsBacklog: true}
+	todo := &board_column.BoardColumn{ID: uuid.New(), Name: "To Do"}
+	doing := &board_column.BoardColumn{ID: uuid.New(), Name: "Doing"}
+	review := &board_column.BoardColumn{ID: uuid.New(), Name: "Review"}
+	done := &board_column.BoardColumn{ID: uuid.New(), Name: "Done", IsDone: true}
+
+	categories := StatusCategories([]*board_column.BoardColumn{backlog, todo, doing, review, done})
+	assert.Equal(t, map[uuid.UUID]StatusCategory{
+		backlog.ID: StatusCategoryToDo,
+		todo.ID:    StatusCategoryToDo,
+		doing.ID:   StatusCategoryInProgress,
+		review.ID:  StatusCategoryInProgress,
+		done.ID:    StatusCategoryDone,
+	}, categories)
+}
+
+func TestPriorityRoundTrip(t *testing.T) {
+	for _, p := range []card.CardPriority{card.PriorityNone, card.PriorityLow, card.PriorityMedium, card.PriorityHigh, card.PriorityUrgent} {
+		assert.Equal(t, p, CardPriority(Priority(p)), p)
+	}
+	assert.Equal(t, card.PriorityUrgent, CardPriority("Blocker"))
+	assert.Equal(t, card.PriorityHigh, CardPriority(" major "))
+	assert.Equal(t, card.PriorityLow, CardPriority("Trivial"))
+}
+
+func TestSprintState(t *testing.T) {
+	assert.Equal(t, "FUTURE", SprintState(sprint.SprintStatusFuture))
+	assert.Equal(t, "ACTIVE", SprintState(sprint.SprintStatusActive))
+	assert.Equal(t, "CLOSED", SprintState(sprint.SprintStatusClosed))
+}
+
+func TestLabel(t *testing.T) {
+	assert.Equal(t, "needs_design_review", Label("  needs design\treview "))
+	assert.Equal(t, "bug", Label("bug"))
+}
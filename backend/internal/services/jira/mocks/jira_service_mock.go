@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jira_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=jira_service.go -destination=mocks/jira_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	jira "github.com/thatcatdev/kaimu/backend/internal/services/jira"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportProject mocks base method.
+func (m *MockService) ExportProject(ctx context.Context, projectID uuid.UUID) (*jira.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProject", ctx, projectID)
+	ret0, _ := ret[0].(*jira.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportProject indicates an expected call of ExportProject.
+func (mr *MockServiceMockRecorder) ExportProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProject", reflect.TypeOf((*MockService)(nil).ExportProject), ctx, projectID)
+}
@@ -0,0 +1,153 @@
+package labelsuggest
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+)
+
+const (
+	// neighbours is how many of the most similar labelled cards vote on the suggestions
+	neighbours = 10
+	// minSimilarity is how similar, by cosine, a card must be to vote at all
+	minSimilarity = 0.1
+	// minTagConfidence is the share of the votes a tag needs to be suggested
+	minTagConfidence = 0.3
+	// minPriorityConfidence is the share of the votes a priority needs to be suggested
+	minPriorityConfidence = 0.5
+)
+
+// example is a labelled card of the project's history
+type example struct {
+	text     string
+	tagIDs   []uuid.UUID
+	priority card.CardPriority
+}
+
+// vector is a text's TF-IDF weights by term, normalized to unit length
+type vector map[string]float64
+
+// suggestFromHistory labels a card like the examples worded most like it: the neighbours most
+// similar by TF-IDF cosine vote with their similarity for their tags and priority, and the
+// tags and priority with enough of the vote are suggested
+func suggestFromHistory(text string, examples []*example, tags []*tag.Tag, have []uuid.UUID, current card.CardPriority) *Suggestions {
+	suggestions := &Suggestions{Tags: []*TagSuggestion{}}
+	terms := tokenize(text)
+	if len(terms) == 0 {
+		return suggestions
+	}
+
+	docs := make([][]string, len(examples))
+	df := make(map[string]int)
+	for i, ex := range examples {
+		docs[i] = tokenize(ex.text)
+		seen := make(map[string]bool)
+		for _, term := range docs[i] {
+			if !seen[term] {
+				seen[term] = true
+				df[term]++
+			}
+		}
+	}
+	idf := func(term string) float64 {
+		return math.Log(float64(1+len(examples))/float64(1+df[term])) + 1
+	}
+
+	query := weigh(terms, idf)
+	type neighbour struct {
+		example    *example
+		similarity float64
+	}
+	var nearest []neighbour
+	for i, ex := range examples {
+		if sim := cosine(query, weigh(docs[i], idf)); sim >= minSimilarity {
+			nearest = append(nearest, neighbour{example: ex, similarity: sim})
+		}
+	}
+	sort.SliceStable(nearest, func(i, j int) bool { return nearest[i].similarity > nearest[j].similarity })
+	nearest = nearest[:min(len(nearest), neighbours)]
+
+	var total float64
+	tagVotes := make(map[uuid.UUID]float64)
+	priorityVotes := make(map[card.CardPriority]float64)
+	for _, n := range nearest {
+		total += n.similarity
+		for _, id := range n.example.tagIDs {
+			tagVotes[id] += n.similarity
+		}
+		priorityVotes[n.example.priority] += n.similarity
+	}
+	if total == 0 {
+		return suggestions
+	}
+
+	for _, t := range tags {
+		if confidence := tagVotes[t.ID] / total; confidence >= minTagConfidence && !slices.Contains(have, t.ID) {
+			suggestions.Tags = append(suggestions.Tags, &TagSuggestion{Tag: t, Confidence: confidence})
+		}
+	}
+	sort.SliceStable(suggestions.Tags, func(i, j int) bool { return suggestions.Tags[i].Confidence > suggestions.Tags[j].Confidence })
+	suggestions.Tags = suggestions.Tags[:min(len(suggestions.Tags), maxSuggestedTags)]
+
+	if current == card.PriorityNone {
+		for _, p := range []card.CardPriority{card.PriorityLow, card.PriorityMedium, card.PriorityHigh, card.PriorityUrgent} {
+			if confidence := priorityVotes[p] / total; confidence >= minPriorityConfidence {
+				suggestions.Priority = &PrioritySuggestion{Priority: p, Confidence: confidence}
+			}
+		}
+	}
+	if len(suggestions.Tags) > 0 || suggestions.Priority != nil {
+		suggestions.Source = SourceHistory
+	}
+	return suggestions
+}
+
+// tokenize lowercases text and splits it into words of at least two letters or digits
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := words[:0]
+	for _, w := range words {
+		if len([]rune(w)) >= 2 {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// weigh is the unit TF-IDF vector of the terms
+func weigh(terms []string, idf func(string) float64) vector {
+	v := make(vector)
+	for _, term := range terms {
+		v[term]++
+	}
+	var norm float64
+	for term, tf := range v {
+		v[term] = tf * idf(term)
+		norm += v[term] * v[term]
+	}
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
+
+// cosine is the cosine similarity of unit vectors
+func cosine(a, b vector) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
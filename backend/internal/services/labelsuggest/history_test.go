@@ -0,0 +1,58 @@
+package labelsuggest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+)
+
+func TestSuggestFromHistory(t *testing.T) {
+	bug := &tag.Tag{ID: uuid.New(), Name: "Bug"}
+	ui := &tag.Tag{ID: uuid.New(), Name: "UI"}
+	docs := &tag.Tag{ID: uuid.New(), Name: "Docs"}
+	tags := []*tag.Tag{bug, ui, docs}
+
+	examples := []*example{
+		{text: "Login button crashes the app", tagIDs: []uuid.UUID{bug.ID, ui.ID}, priority: card.PriorityHigh},
+		{text: "App crashes on logout", tagIDs: []uuid.UUID{bug.ID}, priority: card.PriorityHigh},
+		{text: "Settings page crashes", tagIDs: []uuid.UUID{bug.ID}, priority: card.PriorityUrgent},
+		{text: "Document the API", tagIDs: []uuid.UUID{docs.ID}, priority: card.PriorityLow},
+		{text: "Write the setup guide", tagIDs: []uuid.UUID{docs.ID}},
+	}
+
+	t.Run("success - labels of similarly worded cards", func(t *testing.T) {
+		suggestions := suggestFromHistory("The app crashes when saving", examples, tags, nil, card.PriorityNone)
+
+		assert.Equal(t, SourceHistory, suggestions.Source)
+		require.Len(t, suggestions.Tags, 2)
+		assert.Equal(t, bug, suggestions.Tags[0].Tag)
+		assert.Greater(t, suggestions.Tags[0].Confidence, suggestions.Tags[1].Confidence)
+		assert.Equal(t, ui, suggestions.Tags[1].Tag)
+		require.NotNil(t, suggestions.Priority)
+		assert.Equal(t, card.PriorityHigh, suggestions.Priority.Priority)
+	})
+
+	t.Run("success - leaves out what the card already has", func(t *testing.T) {
+		suggestions := suggestFromHistory("The app crashes when saving", examples, tags, []uuid.UUID{bug.ID}, card.PriorityLow)
+
+		require.Len(t, suggestions.Tags, 1)
+		assert.Equal(t, ui, suggestions.Tags[0].Tag)
+		assert.Nil(t, suggestions.Priority)
+	})
+
+	t.Run("success - nothing for unrelated wording", func(t *testing.T) {
+		suggestions := suggestFromHistory("Quarterly planning", examples, tags, nil, card.PriorityNone)
+
+		assert.Empty(t, suggestions.Tags)
+		assert.Nil(t, suggestions.Priority)
+		assert.Empty(t, suggestions.Source)
+	})
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"fix", "the", "log", "in", "ui", "été", "42"}, tokenize("Fix the log-in UI (été) #42 a"))
+}
@@ -0,0 +1,360 @@
+package labelsuggest
+
+//go:generate mockgen -source=labelsuggest_service.go -destination=mocks/labelsuggest_service_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/sanitize"
+	"github.com/thatcatdev/kaimu/backend/internal/services/llm"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+const (
+	// MinHistory is how many labelled cards a project needs before suggestions come from its
+	// history; below it, the language model is asked when the organization has enabled AI
+	// features
+	MinHistory = 10
+	// ModelSuggestionsPerHour is how many language model suggestions a user may request per
+	// hour after a burst of ModelSuggestionBurst
+	ModelSuggestionsPerHour = 30
+	// ModelSuggestionBurst is how many language model suggestions a user may request at once
+	ModelSuggestionBurst = 5
+	// maxHistory is how many of the project's most recent cards suggestions learn from
+	maxHistory = 500
+	// maxSuggestedTags is how many tags are suggested for a card
+	maxSuggestedTags = 3
+	// maxDescriptionLength is how much of the card's description the model is given, in
+	// characters
+	maxDescriptionLength = 2000
+	// limiterIdleTTL is how long a user's limiter is kept after their last model suggestion
+	limiterIdleTTL = 2 * time.Hour
+)
+
+var ErrCardNotFound = errors.New("card not found")
+
+// Source is how suggestions were made
+type Source string
+
+const (
+	// SourceHistory suggestions are the labels of the project's similarly worded cards
+	SourceHistory Source = "history"
+	// SourceLanguageModel suggestions were picked by the configured language model
+	SourceLanguageModel Source = "language_model"
+)
+
+// systemPrompt asks the model to pick from the project's tags
+const systemPrompt = `You label cards on a kanban board.
+Reply with only a JSON object, no other text, in this form:
+{"tags": ["..."], "priority": "..."}
+- tags: up to 3 of the listed project tags that fit the card, spelled as listed; an empty list when none fit
+- priority: one of none, low, medium, high or urgent, judged from the card's wording; none when it gives no hint`
+
+// Suggestions are labels proposed for a card
+type Suggestions struct {
+	Tags []*TagSuggestion
+	// Priority is nil when no priority is suggested
+	Priority *PrioritySuggestion
+	// Source is empty when nothing is suggested
+	Source Source
+}
+
+// TagSuggestion is a project tag proposed for a card
+type TagSuggestion struct {
+	Tag *tag.Tag
+	// Confidence is the share of the similar cards' votes for the tag, from 0 to 1; 1 for
+	// language model suggestions
+	Confidence float64
+}
+
+// PrioritySuggestion is a priority proposed for a card
+type PrioritySuggestion struct {
+	Priority   card.CardPriority
+	Confidence float64
+}
+
+type Service interface {
+	// Suggest proposes project tags the card doesn't have, and a priority when it has none,
+	// from the labels of the project's cards worded most like it. While the project has fewer
+	// than MinHistory labelled cards, the configured language model picks from the project's
+	// tags instead, if the organization has enabled AI features.
+	Suggest(ctx context.Context, userID, cardID uuid.UUID) (*Suggestions, error)
+}
+
+// userLimiter is a user's model suggestion rate limiter and when it was last used
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type service struct {
+	cardRepo    card.Repository
+	cardTagRepo card_tag.Repository
+	tagRepo     tag.Repository
+	boardRepo   board.Repository
+	projectRepo project.Repository
+	orgRepo     organization.Repository
+	provider    llm.Provider
+	now         func() time.Time
+
+	mu       sync.Mutex
+	limiters map[uuid.UUID]*userLimiter
+}
+
+// NewService creates the label suggestion service. The provider is optional; without it,
+// suggestions only come from history.
+func NewService(
+	cardRepo card.Repository,
+	cardTagRepo card_tag.Repository,
+	tagRepo tag.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	orgRepo organization.Repository,
+	provider llm.Provider,
+) Service {
+	return &service{
+		cardRepo:    cardRepo,
+		cardTagRepo: cardTagRepo,
+		tagRepo:     tagRepo,
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		orgRepo:     orgRepo,
+		provider:    provider,
+		now:         time.Now,
+		limiters:    make(map[uuid.UUID]*userLimiter),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "labelsuggest.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "labelsuggest"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Suggest(ctx context.Context, userID, cardID uuid.UUID) (*Suggestions, error) {
+	ctx, span := s.startServiceSpan(ctx, "Suggest")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	b, err := s.boardRepo.GetByID(ctx, c.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagRepo.GetByProjectID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.projectHistory(ctx, b.ProjectID, c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.cardLabels(ctx, c, history, tags)
+	if err != nil {
+		return nil, err
+	}
+	examples := make([]*example, 0, len(history))
+	for _, h := range history {
+		ex := &example{text: cardText(h), tagIDs: labels[h.ID], priority: h.Priority}
+		if len(ex.tagIDs) > 0 || ex.priority != card.PriorityNone {
+			examples = append(examples, ex)
+		}
+	}
+
+	have := labels[c.ID]
+	if len(examples) >= MinHistory {
+		return suggestFromHistory(cardText(c), examples, tags, have, c.Priority), nil
+	}
+	return s.suggestWithModel(ctx, userID, c, b.ProjectID, tags, have)
+}
+
+// projectHistory returns the most recent cards on the project's boards, other than the card
+func (s *service) projectHistory(ctx context.Context, projectID, cardID uuid.UUID) ([]*card.Card, error) {
+	boards, err := s.boardRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var history []*card.Card
+	for _, b := range boards {
+		cards, err := s.cardRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cards {
+			if c.ID != cardID {
+				history = append(history, c)
+			}
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].CreatedAt.After(history[j].CreatedAt) })
+	return history[:min(len(history), maxHistory)], nil
+}
+
+// cardLabels returns the project tag IDs of the card and of the history cards
+func (s *service) cardLabels(ctx context.Context, c *card.Card, history []*card.Card, tags []*tag.Tag) (map[uuid.UUID][]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(history)+1)
+	ids = append(ids, c.ID)
+	for _, h := range history {
+		ids = append(ids, h.ID)
+	}
+	cardTags, err := s.cardTagRepo.GetByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	projectTags := make(map[uuid.UUID]bool, len(tags))
+	for _, t := range tags {
+		projectTags[t.ID] = true
+	}
+	labels := make(map[uuid.UUID][]uuid.UUID)
+	for _, ct := range cardTags {
+		if projectTags[ct.TagID] {
+			labels[ct.CardID] = append(labels[ct.CardID], ct.TagID)
+		}
+	}
+	return labels, nil
+}
+
+// suggestWithModel asks the language model to label the card. Without a provider, an opt-in
+// or tags to pick from, or when the user is rate limited or the model fails, nothing is
+// suggested.
+func (s *service) suggestWithModel(ctx context.Context, userID uuid.UUID, c *card.Card, projectID uuid.UUID, tags []*tag.Tag, have []uuid.UUID) (*Suggestions, error) {
+	none := &Suggestions{Tags: []*TagSuggestion{}}
+	if s.provider == nil || len(tags) == 0 {
+		return none, nil
+	}
+	proj, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, proj.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.AIDraftingEnabled || !s.allow(userID) {
+		return none, nil
+	}
+
+	tagNames := make([]string, len(tags))
+	for i, t := range tags {
+		tagNames[i] = t.Name
+	}
+	description := []rune(strings.Join(strings.Fields(sanitize.PlainText(c.Description)), " "))
+	request := fmt.Sprintf("Project tags: %s\n\nCard title: %s\nCard description: %s",
+		strings.Join(tagNames, ", "), c.Title, string(description[:min(len(description), maxDescriptionLength)]))
+	reply, err := s.provider.Complete(ctx, systemPrompt, request)
+	if err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).Str("card_id", c.ID.String()).Msg("Failed to suggest labels with the language model")
+		return none, nil
+	}
+	return parseModelSuggestions(reply, tags, have, c.Priority), nil
+}
+
+// allow reports whether the user may request a language model suggestion now
+func (s *service) allow(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	l, ok := s.limiters[userID]
+	if !ok {
+		for id, idle := range s.limiters {
+			if idle.lastUsed.Before(now.Add(-limiterIdleTTL)) {
+				delete(s.limiters, id)
+			}
+		}
+		l = &userLimiter{limiter: rate.NewLimiter(rate.Limit(ModelSuggestionsPerHour/time.Hour.Seconds()), ModelSuggestionBurst)}
+		s.limiters[userID] = l
+	}
+	l.lastUsed = now
+	return l.limiter.AllowN(now, 1)
+}
+
+// parseModelSuggestions reads the model's reply, keeping only the project's tags the card
+// doesn't have and a priority when the card has none
+func parseModelSuggestions(reply string, tags []*tag.Tag, have []uuid.UUID, current card.CardPriority) *Suggestions {
+	suggestions := &Suggestions{Tags: []*TagSuggestion{}}
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return suggestions
+	}
+	var raw struct {
+		Tags     []string `json:"tags"`
+		Priority string   `json:"priority"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &raw); err != nil {
+		return suggestions
+	}
+
+	for _, name := range raw.Tags {
+		for _, t := range tags {
+			if !strings.EqualFold(t.Name, strings.TrimSpace(name)) || slices.Contains(have, t.ID) || hasTag(suggestions.Tags, t.ID) {
+				continue
+			}
+			if len(suggestions.Tags) < maxSuggestedTags {
+				suggestions.Tags = append(suggestions.Tags, &TagSuggestion{Tag: t, Confidence: 1})
+			}
+		}
+	}
+	priority := card.CardPriority(strings.ToLower(strings.TrimSpace(raw.Priority)))
+	if current == card.PriorityNone && isPriority(priority) && priority != card.PriorityNone {
+		suggestions.Priority = &PrioritySuggestion{Priority: priority, Confidence: 1}
+	}
+	if len(suggestions.Tags) > 0 || suggestions.Priority != nil {
+		suggestions.Source = SourceLanguageModel
+	}
+	return suggestions
+}
+
+// hasTag reports whether the tag was suggested already
+func hasTag(suggestions []*TagSuggestion, tagID uuid.UUID) bool {
+	return slices.ContainsFunc(suggestions, func(s *TagSuggestion) bool { return s.Tag.ID == tagID })
+}
+
+func isPriority(p card.CardPriority) bool {
+	switch p {
+	case card.PriorityNone, card.PriorityLow, card.PriorityMedium, card.PriorityHigh, card.PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// cardText is what a card is compared by: its title and its description as plain text
+func cardText(c *card.Card) string {
+	return c.Title + " " + sanitize.PlainText(c.Description)
+}
@@ -0,0 +1,190 @@
+package labelsuggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	llmMocks "github.com/thatcatdev/kaimu/backend/internal/services/llm/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestSuggest(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Bug"}
+	docs := &tag.Tag{ID: uuid.New(), ProjectID: projectID, Name: "Docs"}
+	otherProjectTag := uuid.New()
+	newCard := &card.Card{ID: uuid.New(), BoardID: boardID, Title: "Export crashes", Description: "<p>The <b>export</b> crashes</p>", Priority: card.PriorityNone}
+
+	expectCard := func(mockCardRepo *cardMocks.MockRepository, mockCardTagRepo *cardTagMocks.MockRepository, mockTagRepo *tagMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, history []*card.Card, cardTags []*card_tag.CardTag) {
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), newCard.ID).Return(newCard, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*tag.Tag{bug, docs}, nil)
+		mockBoardRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*board.Board{{ID: boardID, ProjectID: projectID}}, nil)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(append([]*card.Card{newCard}, history...), nil)
+		mockCardTagRepo.EXPECT().GetByCardIDs(gomock.Any(), gomock.Any()).Return(cardTags, nil)
+	}
+	expectOrg := func(mockProjectRepo *projectMocks.MockRepository, mockOrgRepo *orgMocks.MockRepository, enabled bool) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, AIDraftingEnabled: enabled}, nil)
+	}
+
+	t.Run("success - from the project's history", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+
+		svc := NewService(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockProvider).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		var history []*card.Card
+		var cardTags []*card_tag.CardTag
+		for i := 0; i < MinHistory; i++ {
+			c := &card.Card{ID: uuid.New(), BoardID: boardID, Title: fmt.Sprintf("Report %d crashes", i), Priority: card.PriorityHigh}
+			history = append(history, c)
+			cardTags = append(cardTags, &card_tag.CardTag{CardID: c.ID, TagID: bug.ID}, &card_tag.CardTag{CardID: c.ID, TagID: otherProjectTag})
+		}
+		// The card already has the docs tag
+		cardTags = append(cardTags, &card_tag.CardTag{CardID: newCard.ID, TagID: docs.ID})
+		expectCard(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, history, cardTags)
+
+		suggestions, err := svc.Suggest(ctx, userID, newCard.ID)
+		require.NoError(t, err)
+		assert.Equal(t, SourceHistory, suggestions.Source)
+		require.Len(t, suggestions.Tags, 1)
+		assert.Equal(t, bug, suggestions.Tags[0].Tag)
+		require.NotNil(t, suggestions.Priority)
+		assert.Equal(t, card.PriorityHigh, suggestions.Priority.Priority)
+	})
+
+	t.Run("success - language model while the history is short", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+
+		svc := NewService(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockProvider).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectCard(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, nil, nil)
+		expectOrg(mockProjectRepo, mockOrgRepo, true)
+		mockProvider.EXPECT().Complete(gomock.Any(), systemPrompt, "Project tags: Bug, Docs\n\nCard title: Export crashes\nCard description: The export crashes").
+			Return(`{"tags": ["bug", "Feature", "Bug"], "priority": "HIGH"}`, nil)
+
+		suggestions, err := svc.Suggest(ctx, userID, newCard.ID)
+		require.NoError(t, err)
+		assert.Equal(t, SourceLanguageModel, suggestions.Source)
+		assert.Equal(t, []*TagSuggestion{{Tag: bug, Confidence: 1}}, suggestions.Tags)
+		assert.Equal(t, &PrioritySuggestion{Priority: card.PriorityHigh, Confidence: 1}, suggestions.Priority)
+	})
+
+	t.Run("success - nothing without the organization's opt-in", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+
+		svc := NewService(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockProvider).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectCard(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, nil, nil)
+		expectOrg(mockProjectRepo, mockOrgRepo, false)
+
+		suggestions, err := svc.Suggest(ctx, userID, newCard.ID)
+		require.NoError(t, err)
+		assert.Empty(t, suggestions.Tags)
+		assert.Nil(t, suggestions.Priority)
+		assert.Empty(t, suggestions.Source)
+	})
+
+	t.Run("success - nothing when the model fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+
+		svc := NewService(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockProvider).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectCard(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, nil, nil)
+		expectOrg(mockProjectRepo, mockOrgRepo, true)
+		mockProvider.EXPECT().Complete(gomock.Any(), systemPrompt, gomock.Any()).Return("", errors.New("timeout"))
+
+		suggestions, err := svc.Suggest(ctx, userID, newCard.ID)
+		require.NoError(t, err)
+		assert.Empty(t, suggestions.Tags)
+	})
+
+	t.Run("error - card not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+
+		svc := NewService(mockCardRepo, mockCardTagRepo, mockTagRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, mockProvider).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), newCard.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Suggest(ctx, userID, newCard.ID)
+		assert.ErrorIs(t, err, ErrCardNotFound)
+	})
+}
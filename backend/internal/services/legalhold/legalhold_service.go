@@ -0,0 +1,181 @@
+package legalhold
+
+//go:generate mockgen -source=legalhold_service.go -destination=mocks/legalhold_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReasonRequired = errors.New("a reason is required to place or lift a legal hold")
+	ErrAlreadyHeld    = errors.New("organization is already under legal hold")
+	ErrNotHeld        = errors.New("organization is not under legal hold")
+	// ErrUnderLegalHold is returned for permanent deletions in a held organization
+	ErrUnderLegalHold = errors.New("organization is under legal hold: permanent deletions are blocked until it is lifted")
+)
+
+type Service interface {
+	PlaceHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error)
+	LiftHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error)
+	// GetActiveHold returns the organization's active hold, or nil when it has none
+	GetActiveHold(ctx context.Context, orgID uuid.UUID) (*legal_hold.LegalHold, error)
+	// GetHolds returns the organization's holds, active and lifted, newest first
+	GetHolds(ctx context.Context, orgID uuid.UUID) ([]*legal_hold.LegalHold, error)
+
+	// CheckOrganization returns ErrUnderLegalHold when the organization is held; every
+	// permanent deletion and purge must call it (or CheckProject/CheckBoard) first
+	CheckOrganization(ctx context.Context, orgID uuid.UUID) error
+	// CheckProject is CheckOrganization for the project's organization
+	CheckProject(ctx context.Context, projectID uuid.UUID) error
+	// CheckBoard is CheckOrganization for the board's organization
+	CheckBoard(ctx context.Context, boardID uuid.UUID) error
+}
+
+type service struct {
+	holdRepo    legal_hold.Repository
+	projectRepo project.Repository
+	boardRepo   board.Repository
+	now         func() time.Time
+}
+
+func NewService(holdRepo legal_hold.Repository, projectRepo project.Repository, boardRepo board.Repository) Service {
+	return &service{
+		holdRepo:    holdRepo,
+		projectRepo: projectRepo,
+		boardRepo:   boardRepo,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "legalhold.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "legalhold"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) PlaceHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error) {
+	ctx, span := s.startServiceSpan(ctx, "PlaceHold")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	hold := &legal_hold.LegalHold{
+		OrganizationID: orgID,
+		Reason:         reason,
+		PlacedBy:       &userID,
+		PlacedAt:       s.now(),
+	}
+	placed, err := s.holdRepo.CreateIfAbsent(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+	if !placed {
+		return nil, ErrAlreadyHeld
+	}
+	return hold, nil
+}
+
+func (s *service) LiftHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error) {
+	ctx, span := s.startServiceSpan(ctx, "LiftHold")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	hold, err := s.GetActiveHold(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil {
+		return nil, ErrNotHeld
+	}
+
+	now := s.now()
+	lifted, err := s.holdRepo.Lift(ctx, hold.ID, userID, now, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !lifted {
+		return nil, ErrNotHeld
+	}
+	hold.LiftedBy = &userID
+	hold.LiftedAt = &now
+	hold.LiftReason = &reason
+	return hold, nil
+}
+
+func (s *service) GetActiveHold(ctx context.Context, orgID uuid.UUID) (*legal_hold.LegalHold, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetActiveHold")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	hold, err := s.holdRepo.GetActiveByOrgID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (s *service) GetHolds(ctx context.Context, orgID uuid.UUID) ([]*legal_hold.LegalHold, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetHolds")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.holdRepo.GetByOrgID(ctx, orgID)
+}
+
+func (s *service) CheckOrganization(ctx context.Context, orgID uuid.UUID) error {
+	hold, err := s.GetActiveHold(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if hold != nil {
+		return ErrUnderLegalHold
+	}
+	return nil
+}
+
+func (s *service) CheckProject(ctx context.Context, projectID uuid.UUID) error {
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	return s.CheckOrganization(ctx, p.OrganizationID)
+}
+
+func (s *service) CheckBoard(ctx context.Context, boardID uuid.UUID) error {
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	return s.CheckProject(ctx, b.ProjectID)
+}
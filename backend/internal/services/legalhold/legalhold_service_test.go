@@ -0,0 +1,165 @@
+package legalhold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	holdMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPlaceHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID, userID := uuid.New(), uuid.New()
+
+	t.Run("success - records who placed it, when and why", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockHoldRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		hold, err := svc.PlaceHold(ctx, orgID, userID, "  Litigation 2026-17 ")
+		require.NoError(t, err)
+		assert.Equal(t, "Litigation 2026-17", hold.Reason)
+		assert.Equal(t, &userID, hold.PlacedBy)
+		assert.Equal(t, now, hold.PlacedAt)
+		assert.True(t, hold.IsActive())
+	})
+
+	t.Run("fail - already held", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockHoldRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		_, err := svc.PlaceHold(ctx, orgID, userID, "Audit")
+		assert.ErrorIs(t, err, ErrAlreadyHeld)
+	})
+
+	t.Run("fail - reason required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.PlaceHold(ctx, orgID, userID, " ")
+		assert.ErrorIs(t, err, ErrReasonRequired)
+	})
+}
+
+func TestLiftHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID, userID := uuid.New(), uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		active := &legal_hold.LegalHold{ID: uuid.New(), OrganizationID: orgID, Reason: "Audit"}
+		mockHoldRepo.EXPECT().GetActiveByOrgID(gomock.Any(), orgID).Return(active, nil)
+		mockHoldRepo.EXPECT().Lift(gomock.Any(), active.ID, userID, now, "Case closed").Return(true, nil)
+
+		hold, err := svc.LiftHold(ctx, orgID, userID, "Case closed")
+		require.NoError(t, err)
+		assert.False(t, hold.IsActive())
+		assert.Equal(t, &userID, hold.LiftedBy)
+		assert.Equal(t, "Case closed", *hold.LiftReason)
+	})
+
+	t.Run("fail - not held", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockHoldRepo.EXPECT().GetActiveByOrgID(gomock.Any(), orgID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.LiftHold(ctx, orgID, userID, "Case closed")
+		assert.ErrorIs(t, err, ErrNotHeld)
+	})
+}
+
+func TestCheckBoard(t *testing.T) {
+	ctx := context.Background()
+	p := &project.Project{ID: uuid.New(), OrganizationID: uuid.New()}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID}
+
+	t.Run("blocked while held", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockHoldRepo.EXPECT().GetActiveByOrgID(gomock.Any(), p.OrganizationID).Return(&legal_hold.LegalHold{ID: uuid.New()}, nil)
+
+		assert.ErrorIs(t, svc.CheckBoard(ctx, b.ID), ErrUnderLegalHold)
+	})
+
+	t.Run("allowed without a hold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockHoldRepo := holdMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockHoldRepo, mockProjectRepo, mockBoardRepo).(*service)
+		svc.now = func() time.Time { return time.Now() }
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockHoldRepo.EXPECT().GetActiveByOrgID(gomock.Any(), p.OrganizationID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.NoError(t, svc.CheckBoard(ctx, b.ID))
+	})
+}
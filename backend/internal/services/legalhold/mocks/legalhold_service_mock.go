@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: legalhold_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=legalhold_service.go -destination=mocks/legalhold_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	legal_hold "github.com/thatcatdev/kaimu/backend/internal/db/repositories/legal_hold"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckBoard mocks base method.
+func (m *MockService) CheckBoard(ctx context.Context, boardID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBoard", ctx, boardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckBoard indicates an expected call of CheckBoard.
+func (mr *MockServiceMockRecorder) CheckBoard(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBoard", reflect.TypeOf((*MockService)(nil).CheckBoard), ctx, boardID)
+}
+
+// CheckOrganization mocks base method.
+func (m *MockService) CheckOrganization(ctx context.Context, orgID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrganization", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckOrganization indicates an expected call of CheckOrganization.
+func (mr *MockServiceMockRecorder) CheckOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrganization", reflect.TypeOf((*MockService)(nil).CheckOrganization), ctx, orgID)
+}
+
+// CheckProject mocks base method.
+func (m *MockService) CheckProject(ctx context.Context, projectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckProject indicates an expected call of CheckProject.
+func (mr *MockServiceMockRecorder) CheckProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProject", reflect.TypeOf((*MockService)(nil).CheckProject), ctx, projectID)
+}
+
+// GetActiveHold mocks base method.
+func (m *MockService) GetActiveHold(ctx context.Context, orgID uuid.UUID) (*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveHold", ctx, orgID)
+	ret0, _ := ret[0].(*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveHold indicates an expected call of GetActiveHold.
+func (mr *MockServiceMockRecorder) GetActiveHold(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveHold", reflect.TypeOf((*MockService)(nil).GetActiveHold), ctx, orgID)
+}
+
+// GetHolds mocks base method.
+func (m *MockService) GetHolds(ctx context.Context, orgID uuid.UUID) ([]*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolds", ctx, orgID)
+	ret0, _ := ret[0].([]*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolds indicates an expected call of GetHolds.
+func (mr *MockServiceMockRecorder) GetHolds(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolds", reflect.TypeOf((*MockService)(nil).GetHolds), ctx, orgID)
+}
+
+// LiftHold mocks base method.
+func (m *MockService) LiftHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftHold", ctx, orgID, userID, reason)
+	ret0, _ := ret[0].(*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LiftHold indicates an expected call of LiftHold.
+func (mr *MockServiceMockRecorder) LiftHold(ctx, orgID, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftHold", reflect.TypeOf((*MockService)(nil).LiftHold), ctx, orgID, userID, reason)
+}
+
+// PlaceHold mocks base method.
+func (m *MockService) PlaceHold(ctx context.Context, orgID, userID uuid.UUID, reason string) (*legal_hold.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", ctx, orgID, userID, reason)
+	ret0, _ := ret[0].(*legal_hold.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockServiceMockRecorder) PlaceHold(ctx, orgID, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockService)(nil).PlaceHold), ctx, orgID, userID, reason)
+}
@@ -0,0 +1,88 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	defaultAnthropicURL = "https://api.anthropic.com/v1"
+	anthropicVersion    = "2023-06-01"
+)
+
+type anthropicProvider struct {
+	client    *http.Client
+	url       string
+	apiKey    string
+	model     string
+	maxTokens int
+}
+
+// NewAnthropicProvider completes prompts through the Anthropic Messages API
+func NewAnthropicProvider(cfg config.LLMConfig) Provider {
+	return newAnthropicProvider(&http.Client{Timeout: requestTimeout}, cfg)
+}
+
+func newAnthropicProvider(client *http.Client, cfg config.LLMConfig) *anthropicProvider {
+	url := cfg.URL
+	if url == "" {
+		url = defaultAnthropicURL
+	}
+	return &anthropicProvider{
+		client:    client,
+		url:       strings.TrimSuffix(url, "/") + "/messages",
+		apiKey:    cfg.APIKey,
+		model:     cfg.Model,
+		maxTokens: cfg.MaxTokens,
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, system, prompt string) (string, error) {
+	headers := map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+	request := anthropicRequest{
+		Model:     p.model,
+		System:    system,
+		Messages:  []anthropicMessage{{Role: "user", Content: prompt}},
+		MaxTokens: p.maxTokens,
+	}
+
+	var resp anthropicResponse
+	if err := postJSON(ctx, p.client, p.url, headers, request, &resp); err != nil {
+		return "", err
+	}
+	var text strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return "", ErrEmptyCompletion
+	}
+	return text.String(), nil
+}
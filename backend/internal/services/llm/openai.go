@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const defaultOpenAIURL = "https://api.openai.com/v1"
+
+type openAIProvider struct {
+	client    *http.Client
+	url       string
+	apiKey    string
+	model     string
+	maxTokens int
+}
+
+// NewOpenAIProvider completes prompts through an OpenAI-compatible chat completions API,
+// which OpenAI, Azure OpenAI, Ollama and vLLM all serve
+func NewOpenAIProvider(cfg config.LLMConfig) Provider {
+	return newOpenAIProvider(&http.Client{Timeout: requestTimeout}, cfg)
+}
+
+func newOpenAIProvider(client *http.Client, cfg config.LLMConfig) *openAIProvider {
+	url := cfg.URL
+	if url == "" {
+		url = defaultOpenAIURL
+	}
+	return &openAIProvider{
+		client:    client,
+		url:       strings.TrimSuffix(url, "/") + "/chat/completions",
+		apiKey:    cfg.APIKey,
+		model:     cfg.Model,
+		maxTokens: cfg.MaxTokens,
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model     string          `json:"model"`
+	Messages  []openAIMessage `json:"messages"`
+	MaxTokens int             `json:"max_tokens"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *openAIProvider) Complete(ctx context.Context, system, prompt string) (string, error) {
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+	request := openAIChatRequest{
+		Model: p.model,
+		Messages: []openAIMessage{
+			{Role: "system", Content: system},
+			{Role: "user", Content: prompt},
+		},
+		MaxTokens: p.maxTokens,
+	}
+
+	var resp openAIChatResponse
+	if err := postJSON(ctx, p.client, p.url, headers, request, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
+		return "", ErrEmptyCompletion
+	}
+	return resp.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,51 @@
+package llm
+
+//go:generate mockgen -source=provider.go -destination=mocks/provider_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+)
+
+// requestTimeout bounds one completion request
+const requestTimeout = 60 * time.Second
+
+var (
+	ErrUnknownProvider = errors.New("unknown language model provider")
+	ErrInvalidConfig   = errors.New("the language model needs a model name and positive max tokens")
+	ErrEmptyCompletion = errors.New("the language model returned no text")
+)
+
+// Provider completes prompts with a language model
+type Provider interface {
+	// Complete returns the model's reply to the prompt, following the system instructions
+	Complete(ctx context.Context, system, prompt string) (string, error)
+}
+
+// NewProvider returns the configured provider, or nil when AI features are off
+func NewProvider(cfg config.LLMConfig) (Provider, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+	if cfg.Model == "" || cfg.MaxTokens <= 0 {
+		return nil, ErrInvalidConfig
+	}
+
+	switch cfg.Provider {
+	case ProviderOpenAI:
+		return NewOpenAIProvider(cfg), nil
+	case ProviderAnthropic:
+		return NewAnthropicProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
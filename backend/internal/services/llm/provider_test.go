@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(config.LLMConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = NewProvider(config.LLMConfig{Provider: "palm", Model: "bison", MaxTokens: 100})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = NewProvider(config.LLMConfig{Provider: ProviderOpenAI, MaxTokens: 100})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	provider, err = NewProvider(config.LLMConfig{Provider: ProviderAnthropic, Model: "claude-3-5-haiku-latest", MaxTokens: 100})
+	require.NoError(t, err)
+	assert.IsType(t, &anthropicProvider{}, provider)
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	cfg := config.LLMConfig{APIKey: "secret", Model: "gpt-4o-mini", MaxTokens: 256}
+
+	t.Run("sends the system and user messages", func(t *testing.T) {
+		var got openAIChatRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"title\": \"Fix login\"}"}}]}`))
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL + "/v1"
+		reply, err := newOpenAIProvider(server.Client(), cfg).Complete(context.Background(), "Reply in JSON", "login broken")
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"title": "Fix login"}`, reply)
+		assert.Equal(t, openAIChatRequest{
+			Model:     "gpt-4o-mini",
+			Messages:  []openAIMessage{{Role: "system", Content: "Reply in JSON"}, {Role: "user", Content: "login broken"}},
+			MaxTokens: 256,
+		}, got)
+	})
+
+	t.Run("reports failed requests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error": {"message": "rate limited"}}`, http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL
+		_, err := newOpenAIProvider(server.Client(), cfg).Complete(context.Background(), "", "login broken")
+		assert.ErrorContains(t, err, "status 429")
+	})
+}
+
+func TestAnthropicProvider(t *testing.T) {
+	cfg := config.LLMConfig{APIKey: "secret", Model: "claude-3-5-haiku-latest", MaxTokens: 256}
+
+	t.Run("joins the text blocks of the reply", func(t *testing.T) {
+		var got anthropicRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/messages", r.URL.Path)
+			assert.Equal(t, "secret", r.Header.Get("x-api-key"))
+			assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"content": [{"type": "text", "text": "{\"title\":"}, {"type": "text", "text": " \"Fix login\"}"}]}`))
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL
+		reply, err := newAnthropicProvider(server.Client(), cfg).Complete(context.Background(), "Reply in JSON", "login broken")
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"title": "Fix login"}`, reply)
+		assert.Equal(t, "Reply in JSON", got.System)
+		assert.Equal(t, []anthropicMessage{{Role: "user", Content: "login broken"}}, got.Messages)
+	})
+
+	t.Run("rejects empty replies", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"content": []}`))
+		}))
+		defer server.Close()
+
+		cfg := cfg
+		cfg.URL = server.URL
+		_, err := newAnthropicProvider(server.Client(), cfg).Complete(context.Background(), "", "login broken")
+		assert.ErrorIs(t, err, ErrEmptyCompletion)
+	})
+}
@@ -0,0 +1,162 @@
+package locale
+
+//go:generate mockgen -source=locale_service.go -destination=mocks/locale_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnsupportedLocale    = errors.New("unsupported locale")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+)
+
+type Service interface {
+	// ForUser returns the locale to write to u in: their own preference, else the default
+	// locale of the organization the message is about, else i18n.DefaultLocale
+	ForUser(ctx context.Context, u *user.User, orgID uuid.UUID) string
+	// ForProject is ForUser for a message about a project, using its organization's default
+	ForProject(ctx context.Context, u *user.User, projectID uuid.UUID) string
+	// SetUserLocale sets a user's preference; nil clears it. Tags such as "es-MX" are
+	// stored as the supported locale serving them.
+	SetUserLocale(ctx context.Context, userID uuid.UUID, locale *string) (*user.User, error)
+	// SetOrganizationDefaultLocale sets the locale for members without a preference
+	SetOrganizationDefaultLocale(ctx context.Context, orgID uuid.UUID, locale string) (*organization.Organization, error)
+}
+
+type service struct {
+	userRepo    user.Repository
+	projectRepo project.Repository
+	orgRepo     organization.Repository
+}
+
+func NewService(userRepo user.Repository, projectRepo project.Repository, orgRepo organization.Repository) Service {
+	return &service{
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "locale.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "locale"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) ForUser(ctx context.Context, u *user.User, orgID uuid.UUID) string {
+	ctx, span := s.startServiceSpan(ctx, "ForUser")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	if locale := userLocale(u); locale != "" {
+		return locale
+	}
+	// A message in the default locale beats no message, so lookup failures only get traced
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		span.RecordError(err)
+		return i18n.DefaultLocale
+	}
+	return i18n.Resolve(org.DefaultLocale)
+}
+
+func (s *service) ForProject(ctx context.Context, u *user.User, projectID uuid.UUID) string {
+	ctx, span := s.startServiceSpan(ctx, "ForProject")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if locale := userLocale(u); locale != "" {
+		return locale
+	}
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		span.RecordError(err)
+		return i18n.DefaultLocale
+	}
+	return s.ForUser(ctx, u, p.OrganizationID)
+}
+
+func (s *service) SetUserLocale(ctx context.Context, userID uuid.UUID, locale *string) (*user.User, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetUserLocale")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	var normalized *string
+	if locale != nil && *locale != "" {
+		l := i18n.Normalize(*locale)
+		if l == "" {
+			return nil, ErrUnsupportedLocale
+		}
+		normalized = &l
+	}
+
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	u.Locale = normalized
+	if err := s.userRepo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *service) SetOrganizationDefaultLocale(ctx context.Context, orgID uuid.UUID, locale string) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetOrganizationDefaultLocale")
+	span.SetAttributes(
+		attribute.String("organization.id", orgID.String()),
+		attribute.String("locale", locale),
+	)
+	defer span.End()
+
+	normalized := i18n.Normalize(locale)
+	if normalized == "" {
+		return nil, ErrUnsupportedLocale
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	org.DefaultLocale = normalized
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// userLocale returns the user's supported preference, or "" when they have none
+func userLocale(u *user.User) string {
+	if u == nil || u.Locale == nil || !i18n.IsSupported(*u.Locale) {
+		return ""
+	}
+	return *u.Locale
+}
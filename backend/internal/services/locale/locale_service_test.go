@@ -0,0 +1,183 @@
+package locale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestForProject(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	orgID := uuid.New()
+
+	t.Run("user preference wins", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		assert.Equal(t, "de", svc.ForProject(ctx, &user.User{Locale: strPtr("de")}, projectID))
+	})
+
+	t.Run("falls back to the organization default", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, DefaultLocale: "es"}, nil)
+
+		assert.Equal(t, "es", svc.ForProject(ctx, &user.User{}, projectID))
+	})
+
+	t.Run("ignores a preference without a catalog", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, DefaultLocale: "es"}, nil)
+
+		assert.Equal(t, "es", svc.ForProject(ctx, &user.User{Locale: strPtr("tlh")}, projectID))
+	})
+
+	t.Run("lookup failure uses the default locale", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.Equal(t, "en", svc.ForProject(ctx, nil, projectID))
+	})
+}
+
+func TestSetUserLocale(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("stores the supported locale for a regional tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&user.User{ID: userID}, nil)
+		mockUserRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		u, err := svc.SetUserLocale(ctx, userID, strPtr("es-MX"))
+		require.NoError(t, err)
+		require.NotNil(t, u.Locale)
+		assert.Equal(t, "es", *u.Locale)
+	})
+
+	t.Run("nil clears the preference", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), userID).Return(&user.User{ID: userID, Locale: strPtr("de")}, nil)
+		mockUserRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		u, err := svc.SetUserLocale(ctx, userID, nil)
+		require.NoError(t, err)
+		assert.Nil(t, u.Locale)
+	})
+
+	t.Run("fail - unsupported locale", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		_, err := svc.SetUserLocale(ctx, userID, strPtr("ja"))
+		assert.ErrorIs(t, err, ErrUnsupportedLocale)
+	})
+}
+
+func TestSetOrganizationDefaultLocale(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, DefaultLocale: "en"}, nil)
+		mockOrgRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		org, err := svc.SetOrganizationDefaultLocale(ctx, orgID, "de")
+		require.NoError(t, err)
+		assert.Equal(t, "de", org.DefaultLocale)
+	})
+
+	t.Run("fail - organization not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockUserRepo, mockProjectRepo, mockOrgRepo)
+
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetOrganizationDefaultLocale(ctx, orgID, "de")
+		assert.ErrorIs(t, err, ErrOrganizationNotFound)
+	})
+}
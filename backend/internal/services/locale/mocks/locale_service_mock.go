@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: locale_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=locale_service.go -destination=mocks/locale_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	user "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ForProject mocks base method.
+func (m *MockService) ForProject(ctx context.Context, u *user.User, projectID uuid.UUID) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForProject", ctx, u, projectID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ForProject indicates an expected call of ForProject.
+func (mr *MockServiceMockRecorder) ForProject(ctx, u, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForProject", reflect.TypeOf((*MockService)(nil).ForProject), ctx, u, projectID)
+}
+
+// ForUser mocks base method.
+func (m *MockService) ForUser(ctx context.Context, u *user.User, orgID uuid.UUID) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForUser", ctx, u, orgID)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ForUser indicates an expected call of ForUser.
+func (mr *MockServiceMockRecorder) ForUser(ctx, u, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForUser", reflect.TypeOf((*MockService)(nil).ForUser), ctx, u, orgID)
+}
+
+// SetOrganizationDefaultLocale mocks base method.
+func (m *MockService) SetOrganizationDefaultLocale(ctx context.Context, orgID uuid.UUID, locale string) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizationDefaultLocale", ctx, orgID, locale)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizationDefaultLocale indicates an expected call of SetOrganizationDefaultLocale.
+func (mr *MockServiceMockRecorder) SetOrganizationDefaultLocale(ctx, orgID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationDefaultLocale", reflect.TypeOf((*MockService)(nil).SetOrganizationDefaultLocale), ctx, orgID, locale)
+}
+
+// SetUserLocale mocks base method.
+func (m *MockService) SetUserLocale(ctx context.Context, userID uuid.UUID, locale *string) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLocale", ctx, userID, locale)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserLocale indicates an expected call of SetUserLocale.
+func (mr *MockServiceMockRecorder) SetUserLocale(ctx, userID, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLocale", reflect.TypeOf((*MockService)(nil).SetUserLocale), ctx, userID, locale)
+}
@@ -0,0 +1,150 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	netmail "net/mail"
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	defaultSendGridURL = "https://api.sendgrid.com"
+	defaultPostmarkURL = "https://api.postmarkapp.com"
+)
+
+type sendGridTransport struct {
+	client *http.Client
+	url    string
+	apiKey string
+}
+
+// NewSendGridTransport sends mail through SendGrid's v3 mail send API
+func NewSendGridTransport(cfg config.EmailConfig) Transport {
+	return newSendGridTransport(&http.Client{Timeout: requestTimeout}, cfg)
+}
+
+func newSendGridTransport(client *http.Client, cfg config.EmailConfig) *sendGridTransport {
+	url := cfg.APIURL
+	if url == "" {
+		url = defaultSendGridURL
+	}
+	return &sendGridTransport{
+		client: client,
+		url:    strings.TrimSuffix(url, "/") + "/v3/mail/send",
+		apiKey: cfg.APIKey,
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (t *sendGridTransport) Send(ctx context.Context, msg *Message) error {
+	to := make([]sendGridAddress, len(msg.To))
+	for i, address := range msg.To {
+		to[i] = sendGridAddress{Email: address}
+	}
+	request := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: to}},
+		From:             sendGridAddress{Email: msg.FromEmail, Name: msg.FromName},
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/html", Value: msg.HTML}},
+	}
+	return postJSON(ctx, t.client, t.url, map[string]string{"Authorization": "Bearer " + t.apiKey}, request)
+}
+
+type postmarkTransport struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+// NewPostmarkTransport sends mail through Postmark's email API, on the server's default
+// transactional stream
+func NewPostmarkTransport(cfg config.EmailConfig) Transport {
+	return newPostmarkTransport(&http.Client{Timeout: requestTimeout}, cfg)
+}
+
+func newPostmarkTransport(client *http.Client, cfg config.EmailConfig) *postmarkTransport {
+	url := cfg.APIURL
+	if url == "" {
+		url = defaultPostmarkURL
+	}
+	return &postmarkTransport{
+		client: client,
+		url:    strings.TrimSuffix(url, "/") + "/email",
+		token:  cfg.APIKey,
+	}
+}
+
+type postmarkRequest struct {
+	From     string `json:"From"`
+	To       string `json:"To"`
+	Subject  string `json:"Subject"`
+	HtmlBody string `json:"HtmlBody"`
+}
+
+func (t *postmarkTransport) Send(ctx context.Context, msg *Message) error {
+	from := netmail.Address{Name: msg.FromName, Address: msg.FromEmail}
+	request := postmarkRequest{
+		From:     from.String(),
+		To:       strings.Join(msg.To, ","),
+		Subject:  msg.Subject,
+		HtmlBody: msg.HTML,
+	}
+	headers := map[string]string{
+		"Accept":                  "application/json",
+		"X-Postmark-Server-Token": t.token,
+	}
+	return postJSON(ctx, t.client, t.url, headers, request)
+}
+
+// postJSON sends a JSON request to a mail provider, failing unless it is accepted
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to send email: status %d: %s", resp.StatusCode, detail)
+	}
+	return nil
+}
@@ -3,90 +3,126 @@ package mail
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/thatcatdev/kaimu/backend/config"
 	"github.com/thatcatdev/kaimu/backend/internal/services/mjml"
-	"github.com/wneessen/go-mail"
 )
 
+// Branding is how an organization's mail looks and who it comes from. Empty fields fall
+// back to the platform's.
+type Branding struct {
+	FromName string
+	// FromEmail is only set once the organization verified its domain
+	FromEmail   string
+	LogoURL     string
+	AccentColor string
+}
+
+// Instance is the whitelabel branding of the whole instance; organizations' branding is
+// laid over it. Empty fields fall back to the built-in look.
+type Instance struct {
+	ProductName  string
+	LogoURL      string
+	SupportEmail string
+	TermsURL     string
+}
+
+// InstanceSource provides the branding of the instance mail is sent with
+type InstanceSource interface {
+	// MailInstance returns the instance's branding, or nil for the built-in one
+	MailInstance(ctx context.Context) *Instance
+}
+
+type brandingKey struct{}
+
+// WithBranding returns a context whose mail is sent with branding
+func WithBranding(ctx context.Context, branding *Branding) context.Context {
+	return context.WithValue(ctx, brandingKey{}, branding)
+}
+
+// BrandingFromContext returns the context's branding, or nil when none was set
+func BrandingFromContext(ctx context.Context) *Branding {
+	branding, _ := ctx.Value(brandingKey{}).(*Branding)
+	return branding
+}
+
 type MailService interface {
+	// SendMail renders the template and sends it, with the instance's and the context's
+	// branding if any. {product} in the subject is replaced by the product name.
 	SendMail(ctx context.Context, to []string, subject string, template string, values map[string]string) error
 }
 
 type mailService struct {
-	mjml   mjml.MJMLService
-	config config.EmailConfig
-	client *mail.Client
+	mjml      mjml.MJMLService
+	config    config.EmailConfig
+	transport Transport
+	instance  InstanceSource
 }
 
-// NewMailService creates a new instance of MailService
-func NewMailService(cfg config.EmailConfig, mjmlService mjml.MJMLService) MailService {
-	var client *mail.Client
-	var err error
-
-	// Configure client based on SSL type and authentication requirements
-	if cfg.SSLType == "none" {
-		// For MailHog or other development SMTP servers without SSL/TLS
-		if cfg.Username == "" && cfg.Password == "" {
-			// No authentication required (e.g., MailHog)
-			client, err = mail.NewClient(cfg.Host,
-				mail.WithPort(cfg.Port),
-				mail.WithTLSPolicy(mail.NoTLS),
-			)
-		} else {
-			// Plain authentication without TLS
-			client, err = mail.NewClient(cfg.Host,
-				mail.WithPort(cfg.Port),
-				mail.WithSMTPAuth(mail.SMTPAuthPlain),
-				mail.WithUsername(cfg.Username),
-				mail.WithPassword(cfg.Password),
-				mail.WithTLSPolicy(mail.NoTLS),
-			)
-		}
-	} else {
-		// Standard configuration with TLS/SSL
-		client, err = mail.NewClient(cfg.Host,
-			mail.WithPort(cfg.Port),
-			mail.WithSMTPAuth(mail.SMTPAuthPlain),
-			mail.WithUsername(cfg.Username),
-			mail.WithPassword(cfg.Password),
-		)
-	}
-
+// NewMailService creates a new instance of MailService, sending through the configured
+// provider. instance may be nil to always send with the built-in branding.
+func NewMailService(cfg config.EmailConfig, mjmlService mjml.MJMLService, instance InstanceSource) MailService {
+	transport, err := NewTransport(cfg)
 	if err != nil {
-		panic(fmt.Errorf("failed to create mail client: %w", err))
+		panic(fmt.Errorf("failed to configure mail: %w", err))
 	}
+	return newMailService(cfg, mjmlService, transport, instance)
+}
 
+func newMailService(cfg config.EmailConfig, mjmlService mjml.MJMLService, transport Transport, instance InstanceSource) *mailService {
 	return &mailService{
-		client: client,
-		mjml:   mjmlService,
-		config: cfg,
+		mjml:      mjmlService,
+		config:    cfg,
+		transport: transport,
+		instance:  instance,
 	}
 }
 
 func (s *mailService) SendMail(ctx context.Context, to []string, subject string, template string, values map[string]string) error {
-	message := mail.NewMsg()
-	if err := message.FromFormat(s.config.FromName, s.config.FromEmail); err != nil {
-		return fmt.Errorf("failed to set from email: %w", err)
+	fromName, fromEmail := s.config.FromName, s.config.FromEmail
+	productName := mjml.DefaultProductName
+	branded := make(map[string]string, len(values)+5)
+	for k, v := range values {
+		branded[k] = v
 	}
-
-	if err := message.To(to...); err != nil {
-		return fmt.Errorf("failed to set to email: %w", err)
+	if s.instance != nil {
+		if instance := s.instance.MailInstance(ctx); instance != nil {
+			if instance.ProductName != "" {
+				productName = instance.ProductName
+			}
+			branded["logo_url"] = instance.LogoURL
+			branded["support_email"] = instance.SupportEmail
+			branded["terms_url"] = instance.TermsURL
+		}
+	}
+	branded["product_name"] = productName
+	if branding := BrandingFromContext(ctx); branding != nil {
+		if branding.FromEmail != "" {
+			fromEmail = branding.FromEmail
+		}
+		if branding.FromName != "" {
+			fromName = branding.FromName
+		}
+		if branding.AccentColor != "" {
+			branded["accent_color"] = branding.AccentColor
+		}
+		if branding.LogoURL != "" {
+			branded["logo_url"] = branding.LogoURL
+		}
 	}
-
-	message.Subject(subject)
 
 	// Generate the email body using MJML
-	body, err := s.mjml.GenerateHTMLFromMJML(ctx, template, values)
+	body, err := s.mjml.GenerateHTMLFromMJML(ctx, template, branded)
 	if err != nil {
 		return fmt.Errorf("failed to generate email body: %w", err)
 	}
 
-	message.SetBodyString(mail.TypeTextHTML, *body)
-
-	if err := s.client.DialAndSend(message); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
-	}
-
-	return nil
+	return s.transport.Send(ctx, &Message{
+		FromName:  fromName,
+		FromEmail: fromEmail,
+		To:        to,
+		Subject:   strings.ReplaceAll(subject, "{product}", productName),
+		HTML:      *body,
+	})
 }
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mjml"
+)
+
+type staticInstance struct{ instance *Instance }
+
+func (s staticInstance) MailInstance(context.Context) *Instance { return s.instance }
+
+// recordingTransport keeps the messages sent instead of sending them
+type recordingTransport struct{ sent []*Message }
+
+func (t *recordingTransport) Send(_ context.Context, msg *Message) error {
+	t.sent = append(t.sent, msg)
+	return nil
+}
+
+func TestSendMail(t *testing.T) {
+	cfg := config.EmailConfig{FromName: "Kaimu", FromEmail: "noreply@kaimu.local"}
+	values := map[string]string{
+		"organization_name": "Acme",
+		"inviter_name":      "Ana",
+		"role_name":         "Member",
+		"invite_url":        "https://kaimu.example/invite/abc",
+	}
+
+	t.Run("sends the rendered template", func(t *testing.T) {
+		transport := &recordingTransport{}
+		svc := newMailService(cfg, mjml.NewMJMLService(""), transport, staticInstance{&Instance{ProductName: "Acme Boards"}})
+
+		err := svc.SendMail(context.Background(), []string{"ben@example.com"}, "Join Acme on {product}", "invitation.mjml", values)
+		require.NoError(t, err)
+		require.Len(t, transport.sent, 1)
+		sent := transport.sent[0]
+		assert.Equal(t, "Kaimu", sent.FromName)
+		assert.Equal(t, "noreply@kaimu.local", sent.FromEmail)
+		assert.Equal(t, []string{"ben@example.com"}, sent.To)
+		assert.Equal(t, "Join Acme on Acme Boards", sent.Subject)
+		assert.Contains(t, sent.HTML, `href="https://kaimu.example/invite/abc"`)
+	})
+
+	t.Run("sends from the organization's verified address", func(t *testing.T) {
+		transport := &recordingTransport{}
+		svc := newMailService(cfg, mjml.NewMJMLService(""), transport, nil)
+
+		ctx := WithBranding(context.Background(), &Branding{FromName: "Acme", FromEmail: "boards@acme.example"})
+		require.NoError(t, svc.SendMail(ctx, []string{"ben@example.com"}, "Join Acme", "invitation.mjml", values))
+		require.Len(t, transport.sent, 1)
+		sent := transport.sent[0]
+		assert.Equal(t, "Acme", sent.FromName)
+		assert.Equal(t, "boards@acme.example", sent.FromEmail)
+	})
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/wneessen/go-mail"
+)
+
+type smtpTransport struct {
+	client *mail.Client
+}
+
+// NewSMTPTransport sends mail through the configured SMTP server
+func NewSMTPTransport(cfg config.EmailConfig) (Transport, error) {
+	var client *mail.Client
+	var err error
+
+	// Configure client based on SSL type and authentication requirements
+	if cfg.SSLType == "none" {
+		// For MailHog or other development SMTP servers without SSL/TLS
+		if cfg.Username == "" && cfg.Password == "" {
+			// No authentication required (e.g., MailHog)
+			client, err = mail.NewClient(cfg.Host,
+				mail.WithPort(cfg.Port),
+				mail.WithTLSPolicy(mail.NoTLS),
+			)
+		} else {
+			// Plain authentication without TLS
+			client, err = mail.NewClient(cfg.Host,
+				mail.WithPort(cfg.Port),
+				mail.WithSMTPAuth(mail.SMTPAuthPlain),
+				mail.WithUsername(cfg.Username),
+				mail.WithPassword(cfg.Password),
+				mail.WithTLSPolicy(mail.NoTLS),
+			)
+		}
+	} else {
+		// Standard configuration with TLS/SSL
+		client, err = mail.NewClient(cfg.Host,
+			mail.WithPort(cfg.Port),
+			mail.WithSMTPAuth(mail.SMTPAuthPlain),
+			mail.WithUsername(cfg.Username),
+			mail.WithPassword(cfg.Password),
+		)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mail client: %w", err)
+	}
+	return &smtpTransport{client: client}, nil
+}
+
+func (t *smtpTransport) Send(ctx context.Context, msg *Message) error {
+	message := mail.NewMsg()
+	if err := message.FromFormat(msg.FromName, msg.FromEmail); err != nil {
+		return fmt.Errorf("failed to set from email: %w", err)
+	}
+
+	if err := message.To(msg.To...); err != nil {
+		return fmt.Errorf("failed to set to email: %w", err)
+	}
+
+	message.Subject(msg.Subject)
+	message.SetBodyString(mail.TypeTextHTML, msg.HTML)
+
+	if err := t.client.DialAndSendWithContext(ctx, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
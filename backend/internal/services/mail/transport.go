@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+	ProviderPostmark = "postmark"
+)
+
+// requestTimeout bounds one request to a mail provider's API
+const requestTimeout = 30 * time.Second
+
+var (
+	ErrUnknownProvider = errors.New("unknown mail provider")
+	ErrMissingAPIKey   = errors.New("the mail provider needs an API key")
+)
+
+// Message is a rendered email ready to send
+type Message struct {
+	FromName  string
+	FromEmail string
+	To        []string
+	Subject   string
+	HTML      string
+}
+
+// Transport delivers rendered mail through an SMTP server or a provider's API
+type Transport interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewTransport returns the configured transport; SMTP unless another provider is set
+func NewTransport(cfg config.EmailConfig) (Transport, error) {
+	switch cfg.Provider {
+	case "", ProviderSMTP:
+		return NewSMTPTransport(cfg)
+	case ProviderSendGrid, ProviderPostmark:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingAPIKey, cfg.Provider)
+		}
+		if cfg.Provider == ProviderSendGrid {
+			return NewSendGridTransport(cfg), nil
+		}
+		return NewPostmarkTransport(cfg), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
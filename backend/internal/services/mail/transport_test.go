@@ -0,0 +1,90 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+var testMessage = &Message{
+	FromName:  "Acme Boards",
+	FromEmail: "boards@acme.example",
+	To:        []string{"ana@example.com", "ben@example.com"},
+	Subject:   "Join Acme",
+	HTML:      "<p>Join</p>",
+}
+
+func TestNewTransport(t *testing.T) {
+	transport, err := NewTransport(config.EmailConfig{Host: "localhost", Port: 1025, SSLType: "none"})
+	require.NoError(t, err)
+	assert.IsType(t, &smtpTransport{}, transport)
+
+	_, err = NewTransport(config.EmailConfig{Provider: "pigeon"})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = NewTransport(config.EmailConfig{Provider: ProviderSendGrid})
+	assert.ErrorIs(t, err, ErrMissingAPIKey)
+
+	transport, err = NewTransport(config.EmailConfig{Provider: ProviderPostmark, APIKey: "token"})
+	require.NoError(t, err)
+	assert.IsType(t, &postmarkTransport{}, transport)
+}
+
+func TestSendGridTransport(t *testing.T) {
+	t.Run("sends the message", func(t *testing.T) {
+		var got sendGridRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v3/mail/send", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		cfg := config.EmailConfig{APIKey: "secret", APIURL: server.URL}
+		require.NoError(t, newSendGridTransport(server.Client(), cfg).Send(context.Background(), testMessage))
+		assert.Equal(t, sendGridRequest{
+			Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: "ana@example.com"}, {Email: "ben@example.com"}}}},
+			From:             sendGridAddress{Email: "boards@acme.example", Name: "Acme Boards"},
+			Subject:          "Join Acme",
+			Content:          []sendGridContent{{Type: "text/html", Value: "<p>Join</p>"}},
+		}, got)
+	})
+
+	t.Run("reports rejected messages", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"errors": [{"message": "The from address does not match a verified Sender Identity"}]}`, http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		cfg := config.EmailConfig{APIKey: "secret", APIURL: server.URL}
+		err := newSendGridTransport(server.Client(), cfg).Send(context.Background(), testMessage)
+		assert.ErrorContains(t, err, "status 403")
+	})
+}
+
+func TestPostmarkTransport(t *testing.T) {
+	var got postmarkRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/email", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("X-Postmark-Server-Token"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Write([]byte(`{"ErrorCode": 0, "Message": "OK"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.EmailConfig{APIKey: "token", APIURL: server.URL + "/"}
+	require.NoError(t, newPostmarkTransport(server.Client(), cfg).Send(context.Background(), testMessage))
+	assert.Equal(t, postmarkRequest{
+		From:     `"Acme Boards" <boards@acme.example>`,
+		To:       "ana@example.com,ben@example.com",
+		Subject:  "Join Acme",
+		HtmlBody: "<p>Join</p>",
+	}, got)
+}
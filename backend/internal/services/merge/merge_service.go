@@ -0,0 +1,262 @@
+package merge
+
+//go:generate mockgen -source=merge_service.go -destination=mocks/merge_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxMergeCards caps how many duplicates one merge can close
+const MaxMergeCards = 20
+
+var (
+	ErrNoDuplicates      = errors.New("a merge needs at least one duplicate card")
+	ErrTooManyDuplicates = fmt.Errorf("at most %d cards can be merged at once", MaxMergeCards)
+	ErrMergeIntoItself   = errors.New("a card cannot be merged into itself")
+	ErrAlreadyMerged     = errors.New("card was already merged into another card")
+	ErrDifferentProject  = errors.New("cards can only be merged within a project")
+)
+
+// Result is a merge's primary card and the duplicates closed into it
+type Result struct {
+	Card       *card.Card
+	Duplicates []*card.Card
+	// TagsMoved is how many tags the primary card gained from the duplicates
+	TagsMoved int
+	// LinksMoved is how many dependencies of the duplicates now point at the primary card
+	LinksMoved int
+}
+
+type Service interface {
+	// MergeCards moves the duplicates' tags and dependencies onto the primary card and
+	// archives the duplicates, pointing them at it. Dependencies that would link the primary
+	// card to itself or repeat one it has are dropped.
+	MergeCards(ctx context.Context, primaryID uuid.UUID, duplicateIDs []uuid.UUID) (*Result, error)
+}
+
+type service struct {
+	cardRepo       card.Repository
+	dependencyRepo card_dependency.Repository
+	cardSvc        cardService.Service
+	txManager      transaction.Manager
+	bus            events.Bus
+	now            func() time.Time
+}
+
+func NewService(cardRepo card.Repository, dependencyRepo card_dependency.Repository, cardSvc cardService.Service, txManager transaction.Manager, bus events.Bus) Service {
+	return &service{
+		cardRepo:       cardRepo,
+		dependencyRepo: dependencyRepo,
+		cardSvc:        cardSvc,
+		txManager:      txManager,
+		bus:            bus,
+		now:            time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "merge.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "merge"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) MergeCards(ctx context.Context, primaryID uuid.UUID, duplicateIDs []uuid.UUID) (*Result, error) {
+	ctx, span := s.startServiceSpan(ctx, "MergeCards")
+	span.SetAttributes(attribute.String("card.id", primaryID.String()))
+	defer span.End()
+
+	var ids []uuid.UUID
+	for _, id := range duplicateIDs {
+		if id == primaryID {
+			return nil, ErrMergeIntoItself
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoDuplicates
+	}
+	if len(ids) > MaxMergeCards {
+		return nil, ErrTooManyDuplicates
+	}
+	span.SetAttributes(attribute.Int("merge.count", len(ids)))
+
+	primary, projectID, err := s.getMergeable(ctx, primaryID)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Card: primary, Duplicates: make([]*card.Card, 0, len(ids))}
+	for _, id := range ids {
+		duplicate, duplicateProjectID, err := s.getMergeable(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if duplicateProjectID != projectID {
+			return nil, ErrDifferentProject
+		}
+		result.Duplicates = append(result.Duplicates, duplicate)
+	}
+
+	tagIDs, err := s.getTagIDs(ctx, primary.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		duplicateTagIDs, err := s.getTagIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, tagID := range duplicateTagIDs {
+			if !slices.Contains(tagIDs, tagID) {
+				tagIDs = append(tagIDs, tagID)
+				result.TagsMoved++
+			}
+		}
+	}
+
+	now := s.now()
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if result.TagsMoved > 0 {
+			updated, err := s.cardSvc.UpdateCard(ctx, cardService.UpdateCardInput{ID: primary.ID, TagIDs: tagIDs})
+			if err != nil {
+				return err
+			}
+			result.Card = updated
+		}
+
+		if result.LinksMoved, err = s.moveDependencies(ctx, primary.ID, ids); err != nil {
+			return err
+		}
+
+		if err := s.cardRepo.Merge(ctx, ids, primary.ID, now); err != nil {
+			return err
+		}
+		for _, duplicate := range result.Duplicates {
+			duplicate.MergedIntoID = &primary.ID
+			if duplicate.ArchivedAt == nil {
+				duplicate.ArchivedAt = &now
+			}
+			err := s.bus.Publish(ctx, events.New(ctx, events.CardArchived, events.CardPayload{
+				CardID:   duplicate.ID,
+				BoardID:  duplicate.BoardID,
+				ColumnID: duplicate.ColumnID,
+			}))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getMergeable returns a card that may take part in a merge, and its project
+func (s *service) getMergeable(ctx context.Context, id uuid.UUID) (*card.Card, uuid.UUID, error) {
+	c, err := s.cardSvc.GetCard(ctx, id)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if c.MergedIntoID != nil {
+		return nil, uuid.Nil, ErrAlreadyMerged
+	}
+	b, err := s.cardSvc.GetBoardByCardID(ctx, id)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	return c, b.ProjectID, nil
+}
+
+func (s *service) getTagIDs(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
+	tags, err := s.cardSvc.GetTagsForCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	return ids, nil
+}
+
+// dependencyKey identifies a dependency by what it links, to find repeated ones
+type dependencyKey struct {
+	from, to uuid.UUID
+	kind     card_dependency.Kind
+}
+
+// moveDependencies points the duplicates' dependencies at the primary card, returning how
+// many it moved. Those that would become self-links or repeats are deleted.
+func (s *service) moveDependencies(ctx context.Context, primaryID uuid.UUID, duplicateIDs []uuid.UUID) (int, error) {
+	repoint := func(id uuid.UUID) uuid.UUID {
+		if slices.Contains(duplicateIDs, id) {
+			return primaryID
+		}
+		return id
+	}
+
+	existing, err := s.dependencyRepo.GetByCardID(ctx, primaryID)
+	if err != nil {
+		return 0, err
+	}
+	links := make(map[dependencyKey]bool, len(existing))
+	for _, d := range existing {
+		links[dependencyKey{from: d.FromCardID, to: d.ToCardID, kind: d.Kind}] = true
+	}
+
+	moved := 0
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range duplicateIDs {
+		dependencies, err := s.dependencyRepo.GetByCardID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		for _, d := range dependencies {
+			// A link between two duplicates is returned for both
+			if seen[d.ID] {
+				continue
+			}
+			seen[d.ID] = true
+
+			key := dependencyKey{from: repoint(d.FromCardID), to: repoint(d.ToCardID), kind: d.Kind}
+			if key.from == key.to || links[key] {
+				if err := s.dependencyRepo.Delete(ctx, d.ID); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			links[key] = true
+
+			d.FromCardID, d.ToCardID = key.from, key.to
+			if err := s.dependencyRepo.Update(ctx, d); err != nil {
+				return 0, err
+			}
+			moved++
+		}
+	}
+	return moved, nil
+}
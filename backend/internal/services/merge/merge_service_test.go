@@ -0,0 +1,152 @@
+package merge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependencyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	cardServiceMocks "github.com/thatcatdev/kaimu/backend/internal/services/card/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeCards(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	primary := &card.Card{ID: uuid.New(), BoardID: b.ID, Title: "Login fails"}
+	other := &card.Card{ID: uuid.New(), BoardID: b.ID}
+	shared, extra := &tag.Tag{ID: uuid.New()}, &tag.Tag{ID: uuid.New()}
+
+	t.Run("success - moves tags and links and archives the duplicates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		bus := events.NewSyncBus()
+		var archived []uuid.UUID
+		bus.Subscribe(events.CardArchived, func(ctx context.Context, event events.Event) error {
+			archived = append(archived, event.Payload.(events.CardPayload).CardID)
+			return nil
+		})
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockCardRepo, mockDependencyRepo, mockCardSvc, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		dup1 := &card.Card{ID: uuid.New(), BoardID: b.ID}
+		dup2 := &card.Card{ID: uuid.New(), BoardID: b.ID}
+		for _, c := range []*card.Card{primary, dup1, dup2} {
+			mockCardSvc.EXPECT().GetCard(gomock.Any(), c.ID).Return(c, nil)
+			mockCardSvc.EXPECT().GetBoardByCardID(gomock.Any(), c.ID).Return(b, nil)
+		}
+		mockCardSvc.EXPECT().GetTagsForCard(gomock.Any(), primary.ID).Return([]*tag.Tag{shared}, nil)
+		mockCardSvc.EXPECT().GetTagsForCard(gomock.Any(), dup1.ID).Return([]*tag.Tag{shared, extra}, nil)
+		mockCardSvc.EXPECT().GetTagsForCard(gomock.Any(), dup2.ID).Return(nil, nil)
+		mockCardSvc.EXPECT().UpdateCard(gomock.Any(), cardService.UpdateCardInput{ID: primary.ID, TagIDs: []uuid.UUID{shared.ID, extra.ID}}).
+			Return(primary, nil)
+
+		// dup1 blocks other (moved), dup1 relates to dup2 (a self-link once merged), dup2
+		// blocks other (a repeat of the moved link)
+		blocks := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: dup1.ID, ToCardID: other.ID, Kind: card_dependency.KindBlocks}
+		between := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: dup1.ID, ToCardID: dup2.ID, Kind: card_dependency.KindRelates}
+		repeat := &card_dependency.CardDependency{ID: uuid.New(), FromCardID: dup2.ID, ToCardID: other.ID, Kind: card_dependency.KindBlocks}
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), primary.ID).Return(nil, nil)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), dup1.ID).Return([]*card_dependency.CardDependency{blocks, between}, nil)
+		mockDependencyRepo.EXPECT().GetByCardID(gomock.Any(), dup2.ID).Return([]*card_dependency.CardDependency{between, repeat}, nil)
+		mockDependencyRepo.EXPECT().Update(gomock.Any(), blocks).Return(nil)
+		mockDependencyRepo.EXPECT().Delete(gomock.Any(), between.ID).Return(nil)
+		mockDependencyRepo.EXPECT().Delete(gomock.Any(), repeat.ID).Return(nil)
+		mockCardRepo.EXPECT().Merge(gomock.Any(), []uuid.UUID{dup1.ID, dup2.ID}, primary.ID, now).Return(nil)
+
+		result, err := svc.MergeCards(ctx, primary.ID, []uuid.UUID{dup1.ID, dup2.ID, dup1.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.TagsMoved)
+		assert.Equal(t, 1, result.LinksMoved)
+		assert.Equal(t, primary.ID, blocks.FromCardID)
+		require.Len(t, result.Duplicates, 2)
+		for _, d := range result.Duplicates {
+			assert.Equal(t, &primary.ID, d.MergedIntoID)
+			assert.Equal(t, &now, d.ArchivedAt)
+		}
+		assert.Equal(t, []uuid.UUID{dup1.ID, dup2.ID}, archived)
+	})
+
+	t.Run("fail - invalid duplicates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockCardRepo, mockDependencyRepo, mockCardSvc, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.MergeCards(ctx, primary.ID, nil)
+		assert.ErrorIs(t, err, ErrNoDuplicates)
+
+		_, err = svc.MergeCards(ctx, primary.ID, []uuid.UUID{uuid.New(), primary.ID})
+		assert.ErrorIs(t, err, ErrMergeIntoItself)
+
+		ids := make([]uuid.UUID, MaxMergeCards+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+		_, err = svc.MergeCards(ctx, primary.ID, ids)
+		assert.ErrorIs(t, err, ErrTooManyDuplicates)
+	})
+
+	t.Run("fail - duplicate in another project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockCardRepo, mockDependencyRepo, mockCardSvc, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		elsewhere := &card.Card{ID: uuid.New()}
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), primary.ID).Return(primary, nil)
+		mockCardSvc.EXPECT().GetBoardByCardID(gomock.Any(), primary.ID).Return(b, nil)
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), elsewhere.ID).Return(elsewhere, nil)
+		mockCardSvc.EXPECT().GetBoardByCardID(gomock.Any(), elsewhere.ID).Return(&board.Board{ID: uuid.New(), ProjectID: uuid.New()}, nil)
+
+		_, err := svc.MergeCards(ctx, primary.ID, []uuid.UUID{elsewhere.ID})
+		assert.ErrorIs(t, err, ErrDifferentProject)
+	})
+
+	t.Run("fail - duplicate already merged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockCardRepo, mockDependencyRepo, mockCardSvc, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		merged := &card.Card{ID: uuid.New(), MergedIntoID: &other.ID}
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), primary.ID).Return(primary, nil)
+		mockCardSvc.EXPECT().GetBoardByCardID(gomock.Any(), primary.ID).Return(b, nil)
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), merged.ID).Return(merged, nil)
+
+		_, err := svc.MergeCards(ctx, primary.ID, []uuid.UUID{merged.ID})
+		assert.ErrorIs(t, err, ErrAlreadyMerged)
+	})
+}
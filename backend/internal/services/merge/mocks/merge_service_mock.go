@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: merge_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=merge_service.go -destination=mocks/merge_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	merge "github.com/thatcatdev/kaimu/backend/internal/services/merge"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// MergeCards mocks base method.
+func (m *MockService) MergeCards(ctx context.Context, primaryID uuid.UUID, duplicateIDs []uuid.UUID) (*merge.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCards", ctx, primaryID, duplicateIDs)
+	ret0, _ := ret[0].(*merge.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCards indicates an expected call of MergeCards.
+func (mr *MockServiceMockRecorder) MergeCards(ctx, primaryID, duplicateIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCards", reflect.TypeOf((*MockService)(nil).MergeCards), ctx, primaryID, duplicateIDs)
+}
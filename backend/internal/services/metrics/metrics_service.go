@@ -11,6 +11,8 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
 	"github.com/thatcatdev/kaimu/backend/tracing"
@@ -22,6 +24,7 @@ import (
 var (
 	ErrSprintNotFound = errors.New("sprint not found")
 	ErrBoardNotFound  = errors.New("board not found")
+	ErrEpicNotFound   = errors.New("epic not found")
 )
 
 // MetricMode represents whether to use card count or story points
@@ -58,6 +61,16 @@ type BurnUpData struct {
 	DoneLine   []DataPoint
 }
 
+// EpicBurnUpData contains data for an epic's burn up chart
+type EpicBurnUpData struct {
+	EpicID    uuid.UUID
+	EpicName  string
+	StartDate time.Time
+	EndDate   time.Time
+	ScopeLine []DataPoint
+	DoneLine  []DataPoint
+}
+
 // SprintVelocity represents velocity data for a single sprint
 type SprintVelocity struct {
 	SprintID        uuid.UUID
@@ -95,6 +108,9 @@ type SprintStats struct {
 	CompletedStoryPoints int
 	DaysRemaining        int
 	DaysElapsed          int
+	// Checklist items on the sprint's cards, and those done
+	TotalChecklistItems     int
+	CompletedChecklistItems int
 }
 
 type Service interface {
@@ -104,6 +120,8 @@ type Service interface {
 	// Chart data queries
 	GetBurnDownData(ctx context.Context, sprintID uuid.UUID, mode MetricMode) (*BurnDownData, error)
 	GetBurnUpData(ctx context.Context, sprintID uuid.UUID, mode MetricMode) (*BurnUpData, error)
+	// GetEpicBurnUpData charts an epic's scope and done work from its creation until today
+	GetEpicBurnUpData(ctx context.Context, epicID uuid.UUID, mode MetricMode) (*EpicBurnUpData, error)
 	GetVelocityData(ctx context.Context, boardID uuid.UUID, sprintCount int, mode MetricMode) (*VelocityData, error)
 	GetCumulativeFlowData(ctx context.Context, sprintID uuid.UUID, mode MetricMode) (*CumulativeFlowData, error)
 
@@ -117,6 +135,8 @@ type service struct {
 	columnRepo      board_column.Repository
 	metricsHistRepo metrics_history.Repository
 	auditRepo       audit.Repository
+	checklistRepo   checklist.Repository
+	epicRepo        epic.Repository
 }
 
 func NewService(
@@ -125,6 +145,8 @@ func NewService(
 	columnRepo board_column.Repository,
 	metricsHistRepo metrics_history.Repository,
 	auditRepo audit.Repository,
+	checklistRepo checklist.Repository,
+	epicRepo epic.Repository,
 ) Service {
 	return &service{
 		sprintRepo:      sprintRepo,
@@ -132,6 +154,8 @@ func NewService(
 		columnRepo:      columnRepo,
 		metricsHistRepo: metricsHistRepo,
 		auditRepo:       auditRepo,
+		checklistRepo:   checklistRepo,
+		epicRepo:        epicRepo,
 	}
 }
 
@@ -685,6 +709,95 @@ func (s *service) calculateBurnUpFromAuditEvents(
 	return scopeLine, doneLine
 }
 
+// GetEpicBurnUpData returns burn up chart data for an epic using audit events. Epic
+// membership changes aren't audited, so the chart follows the epic's current cards from
+// their creation
+func (s *service) GetEpicBurnUpData(ctx context.Context, epicID uuid.UUID, mode MetricMode) (*EpicBurnUpData, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetEpicBurnUpData")
+	span.SetAttributes(
+		attribute.String("epic.id", epicID.String()),
+		attribute.String("mode", string(mode)),
+	)
+	defer span.End()
+
+	e, err := s.epicRepo.GetByID(ctx, epicID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEpicNotFound
+		}
+		return nil, err
+	}
+
+	startDate := e.CreatedAt
+	endDate := time.Now()
+
+	currentCards, err := s.epicRepo.GetCards(ctx, epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An epic's cards can be spread over the boards of its project
+	currentState := make(map[uuid.UUID]*cardState)
+	doneColumnIDs := make(map[uuid.UUID]bool)
+	var auditEvents []*audit.AuditEvent
+	loadedBoards := make(map[uuid.UUID]bool)
+	for _, c := range currentCards {
+		sp := 0
+		if c.StoryPoints != nil {
+			sp = *c.StoryPoints
+		}
+		currentState[c.ID] = &cardState{
+			columnID:    c.ColumnID,
+			storyPoints: sp,
+			inSprint:    true,
+		}
+
+		if loadedBoards[c.BoardID] {
+			continue
+		}
+		loadedBoards[c.BoardID] = true
+
+		columns, err := s.columnRepo.GetByBoardID(ctx, c.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range columns {
+			if col.IsDone {
+				doneColumnIDs[col.ID] = true
+			}
+		}
+
+		events, err := s.auditRepo.GetCardMovementsByBoardAndDateRange(ctx, c.BoardID, startDate, endDate.Add(24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		auditEvents = append(auditEvents, events...)
+	}
+
+	// Only moves and creations of the epic's cards change its lines; sprint changes don't
+	epicEvents := make([]*audit.AuditEvent, 0, len(auditEvents))
+	for _, evt := range auditEvents {
+		if _, ok := currentState[evt.EntityID]; !ok {
+			continue
+		}
+		if evt.Action == audit.ActionCardMoved || evt.Action == audit.ActionCreated {
+			epicEvents = append(epicEvents, evt)
+		}
+	}
+
+	dates := generateDateRange(startDate, endDate)
+	scopeLine, doneLine := s.calculateBurnUpFromAuditEvents(currentState, epicEvents, dates, doneColumnIDs, mode, uuid.Nil)
+
+	return &EpicBurnUpData{
+		EpicID:    epicID,
+		EpicName:  e.Name,
+		StartDate: startDate,
+		EndDate:   endDate,
+		ScopeLine: scopeLine,
+		DoneLine:  doneLine,
+	}, nil
+}
+
 // GetVelocityData returns velocity data for closed sprints on a board
 func (s *service) GetVelocityData(ctx context.Context, boardID uuid.UUID, sprintCount int, mode MetricMode) (*VelocityData, error) {
 	ctx, span := s.startServiceSpan(ctx, "GetVelocityData")
@@ -866,7 +979,9 @@ func (s *service) GetSprintStats(ctx context.Context, sprintID uuid.UUID) (*Spri
 
 	// Calculate stats
 	stats := &SprintStats{}
+	cardIDs := make([]uuid.UUID, 0, len(cards))
 	for _, c := range cards {
+		cardIDs = append(cardIDs, c.ID)
 		stats.TotalCards++
 		if c.StoryPoints != nil {
 			stats.TotalStoryPoints += *c.StoryPoints
@@ -880,6 +995,16 @@ func (s *service) GetSprintStats(ctx context.Context, sprintID uuid.UUID) (*Spri
 		}
 	}
 
+	// Count checklist items across the sprint's cards
+	progress, err := s.checklistRepo.GetProgressByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range progress {
+		stats.TotalChecklistItems += p.Total
+		stats.CompletedChecklistItems += p.Done
+	}
+
 	// Calculate days elapsed and remaining
 	now := time.Now()
 	if sp.StartDate != nil {
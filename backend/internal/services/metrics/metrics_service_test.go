@@ -2,16 +2,21 @@ package metrics
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	auditMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	checklistMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist/mocks"
+	epicMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history"
 	metricsHistMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
@@ -33,7 +38,13 @@ func TestGetSprintStats(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	mockAuditRepo.EXPECT().GetCardMovementsByBoardAndDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+	mockChecklistRepo.EXPECT().GetProgressByCardIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, mockChecklistRepo, epicMocks.NewMockRepository(ctrl))
+
 	ctx := context.Background()
 
 	sprintID := uuid.New()
@@ -132,7 +143,13 @@ func TestRecordDailySnapshot(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	mockAuditRepo.EXPECT().GetCardMovementsByBoardAndDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+	mockChecklistRepo.EXPECT().GetProgressByCardIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, mockChecklistRepo, epicMocks.NewMockRepository(ctrl))
+
 	ctx := context.Background()
 
 	sprintID := uuid.New()
@@ -193,120 +210,81 @@ func TestGetBurnDownData(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, nil, nil)
+
 	ctx := context.Background()
 
 	sprintID := uuid.New()
 	boardID := uuid.New()
+	colID := uuid.New()
+	doneColID := uuid.New()
+	movedCardID := uuid.New()
 
 	now := time.Now().Truncate(24 * time.Hour)
 	startDate := now.Add(-7 * 24 * time.Hour)
 	endDate := now.Add(7 * 24 * time.Hour)
-
-	t.Run("success with existing history - card count mode", func(t *testing.T) {
-		theSprint := &sprint.Sprint{
-			ID:        sprintID,
-			Name:      "Sprint 1",
-			BoardID:   boardID,
-			StartDate: &startDate,
-			EndDate:   &endDate,
-		}
-
-		// First GetByID call for burn down data
+	theSprint := &sprint.Sprint{
+		ID:        sprintID,
+		Name:      "Sprint 1",
+		BoardID:   boardID,
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	}
+
+	// The card in the done column got there today
+	expectBoard := func(todoPoints, donePoints int) {
 		mockSprintRepo.EXPECT().
 			GetByID(gomock.Any(), sprintID).
 			Return(theSprint, nil)
-
-		// Return existing history (includes today)
-		mockMetricsHistRepo.EXPECT().
-			GetBySprintIDAndDateRange(gomock.Any(), sprintID, startDate, endDate).
-			Return([]*metrics_history.MetricsHistory{
-				{SprintID: sprintID, RecordedDate: startDate, TotalCards: 10, CompletedCards: 0},
-				{SprintID: sprintID, RecordedDate: now, TotalCards: 10, CompletedCards: 5},
-			}, nil)
-
-		// GetSprintStats is called internally - needs sprint, cards, columns
-		mockSprintRepo.EXPECT().
-			GetByID(gomock.Any(), sprintID).
-			Return(theSprint, nil)
-
-		colID := uuid.New()
-		doneColID := uuid.New()
-		sp := 5
-		mockCardRepo.EXPECT().
-			GetBySprintID(gomock.Any(), sprintID).
-			Return([]*card.Card{
-				{ID: uuid.New(), ColumnID: colID, StoryPoints: &sp},
-				{ID: uuid.New(), ColumnID: doneColID, StoryPoints: &sp},
-			}, nil)
-
 		mockColumnRepo.EXPECT().
 			GetByBoardID(gomock.Any(), boardID).
 			Return([]*board_column.BoardColumn{
 				{ID: colID, Name: "Todo", IsDone: false},
 				{ID: doneColID, Name: "Done", IsDone: true},
 			}, nil)
+		mockCardRepo.EXPECT().
+			GetBySprintID(gomock.Any(), sprintID).
+			Return([]*card.Card{
+				{ID: uuid.New(), ColumnID: colID, StoryPoints: &todoPoints},
+				{ID: movedCardID, ColumnID: doneColID, StoryPoints: &donePoints},
+			}, nil)
+		mockAuditRepo.EXPECT().
+			GetCardMovementsByBoardAndDateRange(gomock.Any(), boardID, startDate, endDate.Add(24*time.Hour)).
+			Return([]*audit.AuditEvent{{
+				Action:     audit.ActionCardMoved,
+				EntityID:   movedCardID,
+				OccurredAt: now.Add(time.Hour),
+				Metadata:   json.RawMessage(`{"from_column_id":"` + colID.String() + `","to_column_id":"` + doneColID.String() + `"}`),
+			}}, nil)
+	}
+
+	t.Run("success - card count mode", func(t *testing.T) {
+		expectBoard(5, 5)
 
 		data, err := svc.GetBurnDownData(ctx, sprintID, MetricModeCardCount)
 		require.NoError(t, err)
 		assert.Equal(t, sprintID, data.SprintID)
 		assert.Equal(t, "Sprint 1", data.SprintName)
-		assert.NotEmpty(t, data.IdealLine)
-		assert.Equal(t, 2, len(data.ActualLine))
-		// First point: 10 total - 0 completed = 10 remaining
-		assert.Equal(t, float64(10), data.ActualLine[0].Value)
-		// Second point: 10 total - 5 completed = 5 remaining
-		assert.Equal(t, float64(5), data.ActualLine[1].Value)
+		require.Len(t, data.IdealLine, 15)
+		require.Len(t, data.ActualLine, 15)
+		assert.Equal(t, float64(2), data.IdealLine[0].Value)
+		assert.Equal(t, float64(0), data.IdealLine[14].Value)
+		// Both cards remained until the move today
+		assert.Equal(t, float64(2), data.ActualLine[0].Value)
+		assert.Equal(t, float64(2), data.ActualLine[6].Value)
+		assert.Equal(t, float64(1), data.ActualLine[7].Value)
+		assert.Equal(t, float64(1), data.ActualLine[14].Value)
 	})
 
-	t.Run("success with existing history - story points mode", func(t *testing.T) {
-		theSprint := &sprint.Sprint{
-			ID:        sprintID,
-			Name:      "Sprint 1",
-			BoardID:   boardID,
-			StartDate: &startDate,
-			EndDate:   &endDate,
-		}
-
-		mockSprintRepo.EXPECT().
-			GetByID(gomock.Any(), sprintID).
-			Return(theSprint, nil)
-
-		mockMetricsHistRepo.EXPECT().
-			GetBySprintIDAndDateRange(gomock.Any(), sprintID, startDate, endDate).
-			Return([]*metrics_history.MetricsHistory{
-				{SprintID: sprintID, RecordedDate: startDate, TotalStoryPoints: 50, CompletedStoryPoints: 0},
-				{SprintID: sprintID, RecordedDate: now, TotalStoryPoints: 50, CompletedStoryPoints: 20},
-			}, nil)
-
-		// GetSprintStats is called internally
-		mockSprintRepo.EXPECT().
-			GetByID(gomock.Any(), sprintID).
-			Return(theSprint, nil)
-
-		colID := uuid.New()
-		doneColID := uuid.New()
-		sp := 25
-		mockCardRepo.EXPECT().
-			GetBySprintID(gomock.Any(), sprintID).
-			Return([]*card.Card{
-				{ID: uuid.New(), ColumnID: colID, StoryPoints: &sp},
-				{ID: uuid.New(), ColumnID: doneColID, StoryPoints: &sp},
-			}, nil)
-
-		mockColumnRepo.EXPECT().
-			GetByBoardID(gomock.Any(), boardID).
-			Return([]*board_column.BoardColumn{
-				{ID: colID, Name: "Todo", IsDone: false},
-				{ID: doneColID, Name: "Done", IsDone: true},
-			}, nil)
+	t.Run("success - story points mode", func(t *testing.T) {
+		expectBoard(8, 5)
 
 		data, err := svc.GetBurnDownData(ctx, sprintID, MetricModeStoryPoints)
 		require.NoError(t, err)
-		// First point: 50 - 0 = 50 remaining
-		assert.Equal(t, float64(50), data.ActualLine[0].Value)
-		// Second point: 50 - 20 = 30 remaining
-		assert.Equal(t, float64(30), data.ActualLine[1].Value)
+		assert.Equal(t, float64(13), data.IdealLine[0].Value)
+		assert.Equal(t, float64(13), data.ActualLine[6].Value)
+		assert.Equal(t, float64(8), data.ActualLine[7].Value)
 	})
 
 	t.Run("sprint not found", func(t *testing.T) {
@@ -324,7 +302,9 @@ func TestGetBurnUpData(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, nil, nil)
+
 	ctx := context.Background()
 
 	sprintID := uuid.New()
@@ -335,56 +315,61 @@ func TestGetBurnUpData(t *testing.T) {
 	endDate := now.Add(7 * 24 * time.Hour)
 
 	t.Run("success - shows scope and done lines", func(t *testing.T) {
-		theSprint := &sprint.Sprint{
-			ID:        sprintID,
-			Name:      "Sprint 1",
-			BoardID:   boardID,
-			StartDate: &startDate,
-			EndDate:   &endDate,
-		}
-
 		mockSprintRepo.EXPECT().
 			GetByID(gomock.Any(), sprintID).
-			Return(theSprint, nil)
-
-		mockMetricsHistRepo.EXPECT().
-			GetBySprintIDAndDateRange(gomock.Any(), sprintID, startDate, endDate).
-			Return([]*metrics_history.MetricsHistory{
-				{SprintID: sprintID, RecordedDate: startDate, TotalCards: 10, CompletedCards: 0},
-				{SprintID: sprintID, RecordedDate: now, TotalCards: 12, CompletedCards: 5},
+			Return(&sprint.Sprint{
+				ID:        sprintID,
+				Name:      "Sprint 1",
+				BoardID:   boardID,
+				StartDate: &startDate,
+				EndDate:   &endDate,
 			}, nil)
 
-		// GetSprintStats is called internally
-		mockSprintRepo.EXPECT().
-			GetByID(gomock.Any(), sprintID).
-			Return(theSprint, nil)
-
 		colID := uuid.New()
 		doneColID := uuid.New()
-		mockCardRepo.EXPECT().
-			GetBySprintID(gomock.Any(), sprintID).
-			Return([]*card.Card{
-				{ID: uuid.New(), ColumnID: colID},
-				{ID: uuid.New(), ColumnID: doneColID},
-			}, nil)
-
+		addedCardID := uuid.New()
+		doneCardID := uuid.New()
 		mockColumnRepo.EXPECT().
 			GetByBoardID(gomock.Any(), boardID).
 			Return([]*board_column.BoardColumn{
 				{ID: colID, Name: "Todo", IsDone: false},
 				{ID: doneColID, Name: "Done", IsDone: true},
 			}, nil)
+		mockCardRepo.EXPECT().
+			GetBySprintID(gomock.Any(), sprintID).
+			Return([]*card.Card{
+				{ID: addedCardID, ColumnID: colID},
+				{ID: doneCardID, ColumnID: doneColID},
+			}, nil)
+
+		// Yesterday one card was done; today another joined the sprint
+		mockAuditRepo.EXPECT().
+			GetCardMovementsByBoardAndDateRange(gomock.Any(), boardID, startDate, endDate.Add(24*time.Hour)).
+			Return([]*audit.AuditEvent{
+				{
+					Action:     audit.ActionCardMoved,
+					EntityID:   doneCardID,
+					OccurredAt: now.Add(-23 * time.Hour),
+					Metadata:   json.RawMessage(`{"from_column_id":"` + colID.String() + `","to_column_id":"` + doneColID.String() + `"}`),
+				},
+				{
+					Action:     audit.ActionCardAddedToSprint,
+					EntityID:   addedCardID,
+					OccurredAt: now.Add(time.Hour),
+				},
+			}, nil)
 
 		data, err := svc.GetBurnUpData(ctx, sprintID, MetricModeCardCount)
 		require.NoError(t, err)
-		assert.Equal(t, 2, len(data.ScopeLine))
-		assert.Equal(t, 2, len(data.DoneLine))
-		// Scope line shows total
-		assert.Equal(t, float64(10), data.ScopeLine[0].Value)
-		assert.Equal(t, float64(12), data.ScopeLine[1].Value) // Scope increased
+		require.Len(t, data.ScopeLine, 15)
+		require.Len(t, data.DoneLine, 15)
+		// Scope line shows the cards in the sprint
+		assert.Equal(t, float64(1), data.ScopeLine[6].Value)
+		assert.Equal(t, float64(2), data.ScopeLine[7].Value) // Scope increased
 		// Done line shows completed
-		assert.Equal(t, float64(0), data.DoneLine[0].Value)
-		assert.Equal(t, float64(5), data.DoneLine[1].Value)
+		assert.Equal(t, float64(0), data.DoneLine[5].Value)
+		assert.Equal(t, float64(1), data.DoneLine[6].Value)
+		assert.Equal(t, float64(1), data.DoneLine[14].Value)
 	})
 }
 
@@ -392,7 +377,13 @@ func TestGetVelocityData(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	mockAuditRepo.EXPECT().GetCardMovementsByBoardAndDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+	mockChecklistRepo.EXPECT().GetProgressByCardIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, mockChecklistRepo, epicMocks.NewMockRepository(ctrl))
+
 	ctx := context.Background()
 
 	boardID := uuid.New()
@@ -473,7 +464,13 @@ func TestGetCumulativeFlowData(t *testing.T) {
 	ctrl, mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo := setupMocks(t)
 	defer ctrl.Finish()
 
-	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo)
+	mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+	mockAuditRepo.EXPECT().GetCardMovementsByBoardAndDateRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockChecklistRepo := checklistMocks.NewMockRepository(ctrl)
+	mockChecklistRepo.EXPECT().GetProgressByCardIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	svc := NewService(mockSprintRepo, mockCardRepo, mockColumnRepo, mockMetricsHistRepo, mockAuditRepo, mockChecklistRepo, epicMocks.NewMockRepository(ctrl))
+
 	ctx := context.Background()
 
 	sprintID := uuid.New()
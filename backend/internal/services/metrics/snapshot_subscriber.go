@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"gorm.io/gorm"
+)
+
+// SnapshotSubscriber keeps today's metrics snapshot current by re-recording it whenever
+// sprint progress changes, instead of waiting for a scheduled snapshot
+type SnapshotSubscriber struct {
+	metricsSvc Service
+	sprintRepo sprint.Repository
+	cardRepo   card.Repository
+}
+
+func NewSnapshotSubscriber(metricsSvc Service, sprintRepo sprint.Repository, cardRepo card.Repository) *SnapshotSubscriber {
+	return &SnapshotSubscriber{
+		metricsSvc: metricsSvc,
+		sprintRepo: sprintRepo,
+		cardRepo:   cardRepo,
+	}
+}
+
+// Subscribe registers the snapshot handlers on the bus
+func (s *SnapshotSubscriber) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardMoved, s.handleCardMoved)
+	bus.Subscribe(events.SprintCompleted, s.handleSprintCompleted)
+}
+
+// handleCardMoved refreshes the active sprint's snapshot when one of its cards changes column
+func (s *SnapshotSubscriber) handleCardMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	active, err := s.sprintRepo.GetActiveByBoardID(ctx, payload.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	sprintIDs, err := s.cardRepo.GetSprintIDsForCard(ctx, payload.CardID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(sprintIDs, active.ID) {
+		return nil
+	}
+
+	_, err = s.metricsSvc.RecordDailySnapshot(ctx, active.ID)
+	return err
+}
+
+// handleSprintCompleted records the closing snapshot of a completed sprint
+func (s *SnapshotSubscriber) handleSprintCompleted(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.SprintCompletedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	_, err := s.metricsSvc.RecordDailySnapshot(ctx, payload.SprintID)
+	return err
+}
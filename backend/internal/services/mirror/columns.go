@@ -0,0 +1,61 @@
+package mirror
+
+import (
+	"strings"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+)
+
+// mapColumn picks the column of another board that stands for the same status as col: the
+// column with the same name, else the first column of the same kind (backlog, done or in
+// progress), else the first column. columns are ordered by position.
+func mapColumn(col *board_column.BoardColumn, columns []*board_column.BoardColumn) *board_column.BoardColumn {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	name := strings.TrimSpace(col.Name)
+	for _, candidate := range columns {
+		if strings.EqualFold(strings.TrimSpace(candidate.Name), name) {
+			return candidate
+		}
+	}
+	for _, candidate := range columns {
+		if !candidate.IsHidden && columnKind(candidate) == columnKind(col) {
+			return candidate
+		}
+	}
+	return columns[0]
+}
+
+// inStep reports whether two cards in columns a and b of their boards show the same status,
+// read from either side. Checking both sides keeps a sync from moving a card back when the
+// mapping between the boards is not symmetric.
+func inStep(a *board_column.BoardColumn, aColumns []*board_column.BoardColumn, b *board_column.BoardColumn, bColumns []*board_column.BoardColumn) bool {
+	if mapped := mapColumn(a, bColumns); mapped != nil && mapped.ID == b.ID {
+		return true
+	}
+	if mapped := mapColumn(b, aColumns); mapped != nil && mapped.ID == a.ID {
+		return true
+	}
+	return false
+}
+
+type kind int
+
+const (
+	kindInProgress kind = iota
+	kindBacklog
+	kindDone
+)
+
+func columnKind(col *board_column.BoardColumn) kind {
+	switch {
+	case col.IsDone:
+		return kindDone
+	case col.IsBacklog:
+		return kindBacklog
+	default:
+		return kindInProgress
+	}
+}
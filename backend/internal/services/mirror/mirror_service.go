@@ -0,0 +1,328 @@
+package mirror
+
+//go:generate mockgen -source=mirror_service.go -destination=mocks/mirror_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCardNotFound     = errors.New("card not found")
+	ErrMirrorNotFound   = errors.New("card mirror not found")
+	ErrProjectNotFound  = errors.New("target project has no board")
+	ErrSameProject      = errors.New("a card cannot be mirrored into its own project")
+	ErrCardIsMirror     = errors.New("a mirror card cannot be mirrored again")
+	ErrAlreadyMirrored  = errors.New("the card is already mirrored into this project")
+	ErrInvalidDirection = errors.New("invalid mirror direction")
+)
+
+type Service interface {
+	// MirrorCard creates a copy of the card on the default board of the target project and
+	// links it to the card, so the copy's title and column follow the card
+	MirrorCard(ctx context.Context, cardID, targetProjectID uuid.UUID, direction card_mirror.Direction, createdBy uuid.UUID) (*card_mirror.CardMirror, error)
+	GetMirror(ctx context.Context, id uuid.UUID) (*card_mirror.CardMirror, error)
+	// GetCardMirrors returns the mirrors of the card, or its link to its source when the
+	// card is itself a mirror
+	GetCardMirrors(ctx context.Context, cardID uuid.UUID) ([]*card_mirror.CardMirror, error)
+	SetDirection(ctx context.Context, id uuid.UUID, direction card_mirror.Direction) (*card_mirror.CardMirror, error)
+	// RemoveMirror unlinks a mirror; both cards are kept and stop following each other
+	RemoveMirror(ctx context.Context, id uuid.UUID) error
+	// SyncCard copies the card's title and column to the cards linked to it, in the
+	// directions their links allow. Cards already in step are left untouched, so the
+	// events caused by a sync end once they reach the other side.
+	SyncCard(ctx context.Context, cardID uuid.UUID) error
+}
+
+type service struct {
+	mirrorRepo card_mirror.Repository
+	cardRepo   card.Repository
+	boardRepo  board.Repository
+	columnRepo board_column.Repository
+	cardSvc    cardService.Service
+	txManager  transaction.Manager
+}
+
+func NewService(
+	mirrorRepo card_mirror.Repository,
+	cardRepo card.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardSvc cardService.Service,
+	txManager transaction.Manager,
+) Service {
+	return &service{
+		mirrorRepo: mirrorRepo,
+		cardRepo:   cardRepo,
+		boardRepo:  boardRepo,
+		columnRepo: columnRepo,
+		cardSvc:    cardSvc,
+		txManager:  txManager,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "mirror.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "mirror"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) MirrorCard(ctx context.Context, cardID, targetProjectID uuid.UUID, direction card_mirror.Direction, createdBy uuid.UUID) (*card_mirror.CardMirror, error) {
+	ctx, span := s.startServiceSpan(ctx, "MirrorCard")
+	span.SetAttributes(
+		attribute.String("card.id", cardID.String()),
+		attribute.String("project.id", targetProjectID.String()),
+		attribute.String("mirror.direction", string(direction)),
+	)
+	defer span.End()
+
+	if !validDirection(direction) {
+		return nil, ErrInvalidDirection
+	}
+
+	source, err := s.getCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	sourceBoard, err := s.boardRepo.GetByID(ctx, source.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceBoard.ProjectID == targetProjectID {
+		return nil, ErrSameProject
+	}
+
+	links, err := s.mirrorRepo.GetByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.MirrorCardID == cardID {
+			return nil, ErrCardIsMirror
+		}
+		mirrorCard, err := s.getCard(ctx, link.MirrorCardID)
+		if err != nil {
+			return nil, err
+		}
+		mirrorBoard, err := s.boardRepo.GetByID(ctx, mirrorCard.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if mirrorBoard.ProjectID == targetProjectID {
+			return nil, ErrAlreadyMirrored
+		}
+	}
+
+	targetBoard, err := s.boardRepo.GetDefaultByProjectID(ctx, targetProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	sourceColumn, err := s.columnRepo.GetByID(ctx, source.ColumnID)
+	if err != nil {
+		return nil, err
+	}
+	targetColumns, err := s.columnRepo.GetByBoardID(ctx, targetBoard.ID)
+	if err != nil {
+		return nil, err
+	}
+	targetColumn := mapColumn(sourceColumn, targetColumns)
+	if targetColumn == nil {
+		return nil, ErrProjectNotFound
+	}
+
+	mirror := &card_mirror.CardMirror{
+		SourceCardID: source.ID,
+		Direction:    direction,
+		CreatedBy:    &createdBy,
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		mirrorCard, err := s.cardSvc.CreateCard(ctx, cardService.CreateCardInput{
+			ColumnID:    targetColumn.ID,
+			Title:       source.Title,
+			Description: source.Description,
+			Priority:    source.Priority,
+			StoryPoints: source.StoryPoints,
+			CreatedBy:   &createdBy,
+			// The mirror is a copy of the source, not new work in the target column
+			SkipColumnDefaults: true,
+		})
+		if err != nil {
+			return err
+		}
+		mirror.MirrorCardID = mirrorCard.ID
+		return s.mirrorRepo.Create(ctx, mirror)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mirror, nil
+}
+
+func (s *service) GetMirror(ctx context.Context, id uuid.UUID) (*card_mirror.CardMirror, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMirror")
+	span.SetAttributes(attribute.String("mirror.id", id.String()))
+	defer span.End()
+
+	mirror, err := s.mirrorRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMirrorNotFound
+		}
+		return nil, err
+	}
+	return mirror, nil
+}
+
+func (s *service) GetCardMirrors(ctx context.Context, cardID uuid.UUID) ([]*card_mirror.CardMirror, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetCardMirrors")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	return s.mirrorRepo.GetByCardID(ctx, cardID)
+}
+
+func (s *service) SetDirection(ctx context.Context, id uuid.UUID, direction card_mirror.Direction) (*card_mirror.CardMirror, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetDirection")
+	span.SetAttributes(
+		attribute.String("mirror.id", id.String()),
+		attribute.String("mirror.direction", string(direction)),
+	)
+	defer span.End()
+
+	if !validDirection(direction) {
+		return nil, ErrInvalidDirection
+	}
+
+	mirror, err := s.GetMirror(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mirror.Direction = direction
+	if err := s.mirrorRepo.Update(ctx, mirror); err != nil {
+		return nil, err
+	}
+	return mirror, nil
+}
+
+func (s *service) RemoveMirror(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "RemoveMirror")
+	span.SetAttributes(attribute.String("mirror.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetMirror(ctx, id); err != nil {
+		return err
+	}
+	return s.mirrorRepo.Delete(ctx, id)
+}
+
+func (s *service) SyncCard(ctx context.Context, cardID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "SyncCard")
+	span.SetAttributes(attribute.String("card.id", cardID.String()))
+	defer span.End()
+
+	links, err := s.mirrorRepo.GetByCardID(ctx, cardID)
+	if err != nil || len(links) == 0 {
+		return err
+	}
+
+	changed, err := s.getCard(ctx, cardID)
+	if err != nil {
+		return err
+	}
+	changedColumn, err := s.columnRepo.GetByID(ctx, changed.ColumnID)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		peerID := link.MirrorCardID
+		if link.MirrorCardID == cardID {
+			if link.Direction != card_mirror.DirectionTwoWay {
+				continue
+			}
+			peerID = link.SourceCardID
+		}
+		if err := s.syncPeer(ctx, changed, changedColumn, peerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncPeer brings the peer card in step with the changed card
+func (s *service) syncPeer(ctx context.Context, changed *card.Card, changedColumn *board_column.BoardColumn, peerID uuid.UUID) error {
+	peer, err := s.getCard(ctx, peerID)
+	if err != nil {
+		return err
+	}
+
+	if peer.Title != changed.Title {
+		title := changed.Title
+		if _, err := s.cardSvc.UpdateCard(ctx, cardService.UpdateCardInput{ID: peer.ID, Title: &title}); err != nil {
+			return err
+		}
+	}
+
+	peerColumns, err := s.columnRepo.GetByBoardID(ctx, peer.BoardID)
+	if err != nil {
+		return err
+	}
+	changedColumns, err := s.columnRepo.GetByBoardID(ctx, changed.BoardID)
+	if err != nil {
+		return err
+	}
+	var peerColumn *board_column.BoardColumn
+	for _, col := range peerColumns {
+		if col.ID == peer.ColumnID {
+			peerColumn = col
+		}
+	}
+	if peerColumn == nil || inStep(changedColumn, changedColumns, peerColumn, peerColumns) {
+		return nil
+	}
+
+	target := mapColumn(changedColumn, peerColumns)
+	if target == nil || target.ID == peer.ColumnID {
+		return nil
+	}
+	// The source team's workflow already allowed the change; the mirror only reflects it
+	_, err = s.cardSvc.MoveCard(ctx, peer.ID, target.ID, nil, true)
+	return err
+}
+
+func (s *service) getCard(ctx context.Context, id uuid.UUID) (*card.Card, error) {
+	c, err := s.cardRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func validDirection(direction card_mirror.Direction) bool {
+	return direction == card_mirror.DirectionOneWay || direction == card_mirror.DirectionTwoWay
+}
@@ -0,0 +1,303 @@
+package mirror
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror"
+	mirrorMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	cardServiceMocks "github.com/thatcatdev/kaimu/backend/internal/services/card/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// testBoard is a board with a backlog, an in-progress and a done column
+type testBoard struct {
+	board   *board.Board
+	columns []*board_column.BoardColumn
+}
+
+func newTestBoard(names ...string) testBoard {
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	columns := []*board_column.BoardColumn{
+		{ID: uuid.New(), BoardID: b.ID, Name: "Backlog", Position: 0, IsBacklog: true},
+		{ID: uuid.New(), BoardID: b.ID, Name: names[0], Position: 1},
+		{ID: uuid.New(), BoardID: b.ID, Name: "Done", Position: 2, IsDone: true},
+	}
+	return testBoard{board: b, columns: columns}
+}
+
+func TestMirrorCard(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sourceBoard := newTestBoard("In Progress")
+	targetBoard := newTestBoard("Doing")
+	source := &card.Card{ID: uuid.New(), BoardID: sourceBoard.board.ID, ColumnID: sourceBoard.columns[1].ID, Title: "Ship API"}
+	targetProjectID := targetBoard.board.ProjectID
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), sourceBoard.board.ID).Return(sourceBoard.board, nil)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), source.ID).Return(nil, nil)
+		mockBoardRepo.EXPECT().GetDefaultByProjectID(gomock.Any(), targetProjectID).Return(targetBoard.board, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), source.ColumnID).Return(sourceBoard.columns[1], nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), targetBoard.board.ID).Return(targetBoard.columns, nil)
+
+		mirrorCardID := uuid.New()
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			// "In Progress" has no namesake on the target board, so its in-progress column is used
+			assert.Equal(t, targetBoard.columns[1].ID, input.ColumnID)
+			assert.Equal(t, "Ship API", input.Title)
+			assert.True(t, input.SkipColumnDefaults)
+			return &card.Card{ID: mirrorCardID, ColumnID: input.ColumnID, Title: input.Title}, nil
+		})
+		mockMirrorRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		mirror, err := svc.MirrorCard(ctx, source.ID, targetProjectID, card_mirror.DirectionTwoWay, userID)
+		require.NoError(t, err)
+		assert.Equal(t, source.ID, mirror.SourceCardID)
+		assert.Equal(t, mirrorCardID, mirror.MirrorCardID)
+		assert.Equal(t, card_mirror.DirectionTwoWay, mirror.Direction)
+	})
+
+	t.Run("fail - same project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), sourceBoard.board.ID).Return(sourceBoard.board, nil)
+
+		_, err := svc.MirrorCard(ctx, source.ID, sourceBoard.board.ProjectID, card_mirror.DirectionOneWay, userID)
+		assert.ErrorIs(t, err, ErrSameProject)
+	})
+
+	t.Run("fail - card is a mirror", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), sourceBoard.board.ID).Return(sourceBoard.board, nil)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), source.ID).Return([]*card_mirror.CardMirror{
+			{ID: uuid.New(), SourceCardID: uuid.New(), MirrorCardID: source.ID},
+		}, nil)
+
+		_, err := svc.MirrorCard(ctx, source.ID, targetProjectID, card_mirror.DirectionOneWay, userID)
+		assert.ErrorIs(t, err, ErrCardIsMirror)
+	})
+
+	t.Run("fail - already mirrored into the project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		existing := &card.Card{ID: uuid.New(), BoardID: targetBoard.board.ID}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), sourceBoard.board.ID).Return(sourceBoard.board, nil)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), source.ID).Return([]*card_mirror.CardMirror{
+			{ID: uuid.New(), SourceCardID: source.ID, MirrorCardID: existing.ID},
+		}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), existing.ID).Return(existing, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), targetBoard.board.ID).Return(targetBoard.board, nil)
+
+		_, err := svc.MirrorCard(ctx, source.ID, targetProjectID, card_mirror.DirectionOneWay, userID)
+		assert.ErrorIs(t, err, ErrAlreadyMirrored)
+	})
+
+	t.Run("fail - invalid direction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		_, err := svc.MirrorCard(ctx, source.ID, targetProjectID, "sideways", userID)
+		assert.ErrorIs(t, err, ErrInvalidDirection)
+	})
+}
+
+func TestSyncCard(t *testing.T) {
+	ctx := context.Background()
+	sourceBoard := newTestBoard("In Progress")
+	targetBoard := newTestBoard("Doing")
+
+	newCards := func() (*card.Card, *card.Card, *card_mirror.CardMirror) {
+		source := &card.Card{ID: uuid.New(), BoardID: sourceBoard.board.ID, ColumnID: sourceBoard.columns[1].ID, Title: "Ship API"}
+		mirror := &card.Card{ID: uuid.New(), BoardID: targetBoard.board.ID, ColumnID: targetBoard.columns[1].ID, Title: "Ship API"}
+		link := &card_mirror.CardMirror{ID: uuid.New(), SourceCardID: source.ID, MirrorCardID: mirror.ID, Direction: card_mirror.DirectionOneWay}
+		return source, mirror, link
+	}
+	expectColumns := func(mockColumnRepo *columnMocks.MockRepository) {
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), sourceBoard.board.ID).Return(sourceBoard.columns, nil).AnyTimes()
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), targetBoard.board.ID).Return(targetBoard.columns, nil).AnyTimes()
+	}
+
+	t.Run("source changes reach the mirror", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		source, mirror, link := newCards()
+		source.Title = "Ship API v2"
+		source.ColumnID = sourceBoard.columns[2].ID
+
+		expectColumns(mockColumnRepo)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), source.ID).Return([]*card_mirror.CardMirror{link}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), source.ColumnID).Return(sourceBoard.columns[2], nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), mirror.ID).Return(mirror, nil)
+		mockCardSvc.EXPECT().UpdateCard(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input cardService.UpdateCardInput) (*card.Card, error) {
+			assert.Equal(t, mirror.ID, input.ID)
+			assert.Equal(t, "Ship API v2", *input.Title)
+			return mirror, nil
+		})
+		mockCardSvc.EXPECT().MoveCard(gomock.Any(), mirror.ID, targetBoard.columns[2].ID, nil, true).Return(mirror, nil)
+
+		require.NoError(t, svc.SyncCard(ctx, source.ID))
+	})
+
+	t.Run("cards in step are left alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		source, mirror, link := newCards()
+		link.Direction = card_mirror.DirectionTwoWay
+
+		// The mirror's own move event after a sync: "Doing" maps back to "In Progress"
+		expectColumns(mockColumnRepo)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), mirror.ID).Return([]*card_mirror.CardMirror{link}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), mirror.ID).Return(mirror, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), mirror.ColumnID).Return(targetBoard.columns[1], nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+
+		require.NoError(t, svc.SyncCard(ctx, mirror.ID))
+	})
+
+	t.Run("one-way mirrors do not change the source", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		_, mirror, link := newCards()
+		mirror.Title = "Renamed by the other team"
+
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), mirror.ID).Return([]*card_mirror.CardMirror{link}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), mirror.ID).Return(mirror, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), mirror.ColumnID).Return(targetBoard.columns[1], nil)
+
+		require.NoError(t, svc.SyncCard(ctx, mirror.ID))
+	})
+
+	t.Run("two-way mirrors change the source", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMirrorRepo := mirrorMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockMirrorRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, mockCardSvc, transaction.NewNoopManager())
+
+		source, mirror, link := newCards()
+		link.Direction = card_mirror.DirectionTwoWay
+		mirror.ColumnID = targetBoard.columns[0].ID
+
+		expectColumns(mockColumnRepo)
+		mockMirrorRepo.EXPECT().GetByCardID(gomock.Any(), mirror.ID).Return([]*card_mirror.CardMirror{link}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), mirror.ID).Return(mirror, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), mirror.ColumnID).Return(targetBoard.columns[0], nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), source.ID).Return(source, nil)
+		mockCardSvc.EXPECT().MoveCard(gomock.Any(), source.ID, sourceBoard.columns[0].ID, nil, true).Return(source, nil)
+
+		require.NoError(t, svc.SyncCard(ctx, mirror.ID))
+	})
+}
+
+func TestMapColumn(t *testing.T) {
+	target := newTestBoard("Review").columns
+
+	t.Run("same name", func(t *testing.T) {
+		assert.Equal(t, target[1], mapColumn(&board_column.BoardColumn{Name: " review "}, target))
+	})
+
+	t.Run("same kind", func(t *testing.T) {
+		assert.Equal(t, target[2], mapColumn(&board_column.BoardColumn{Name: "Shipped", IsDone: true}, target))
+		assert.Equal(t, target[1], mapColumn(&board_column.BoardColumn{Name: "QA"}, target))
+	})
+
+	t.Run("no columns", func(t *testing.T) {
+		assert.Nil(t, mapColumn(&board_column.BoardColumn{Name: "QA"}, nil))
+	})
+}
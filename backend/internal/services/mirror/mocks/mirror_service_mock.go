@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mirror_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mirror_service.go -destination=mocks/mirror_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	card_mirror "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_mirror"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCardMirrors mocks base method.
+func (m *MockService) GetCardMirrors(ctx context.Context, cardID uuid.UUID) ([]*card_mirror.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardMirrors", ctx, cardID)
+	ret0, _ := ret[0].([]*card_mirror.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardMirrors indicates an expected call of GetCardMirrors.
+func (mr *MockServiceMockRecorder) GetCardMirrors(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardMirrors", reflect.TypeOf((*MockService)(nil).GetCardMirrors), ctx, cardID)
+}
+
+// GetMirror mocks base method.
+func (m *MockService) GetMirror(ctx context.Context, id uuid.UUID) (*card_mirror.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMirror", ctx, id)
+	ret0, _ := ret[0].(*card_mirror.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMirror indicates an expected call of GetMirror.
+func (mr *MockServiceMockRecorder) GetMirror(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMirror", reflect.TypeOf((*MockService)(nil).GetMirror), ctx, id)
+}
+
+// MirrorCard mocks base method.
+func (m *MockService) MirrorCard(ctx context.Context, cardID, targetProjectID uuid.UUID, direction card_mirror.Direction, createdBy uuid.UUID) (*card_mirror.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MirrorCard", ctx, cardID, targetProjectID, direction, createdBy)
+	ret0, _ := ret[0].(*card_mirror.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MirrorCard indicates an expected call of MirrorCard.
+func (mr *MockServiceMockRecorder) MirrorCard(ctx, cardID, targetProjectID, direction, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MirrorCard", reflect.TypeOf((*MockService)(nil).MirrorCard), ctx, cardID, targetProjectID, direction, createdBy)
+}
+
+// RemoveMirror mocks base method.
+func (m *MockService) RemoveMirror(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMirror", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMirror indicates an expected call of RemoveMirror.
+func (mr *MockServiceMockRecorder) RemoveMirror(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMirror", reflect.TypeOf((*MockService)(nil).RemoveMirror), ctx, id)
+}
+
+// SetDirection mocks base method.
+func (m *MockService) SetDirection(ctx context.Context, id uuid.UUID, direction card_mirror.Direction) (*card_mirror.CardMirror, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDirection", ctx, id, direction)
+	ret0, _ := ret[0].(*card_mirror.CardMirror)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDirection indicates an expected call of SetDirection.
+func (mr *MockServiceMockRecorder) SetDirection(ctx, id, direction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDirection", reflect.TypeOf((*MockService)(nil).SetDirection), ctx, id, direction)
+}
+
+// SyncCard mocks base method.
+func (m *MockService) SyncCard(ctx context.Context, cardID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncCard", ctx, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncCard indicates an expected call of SyncCard.
+func (mr *MockServiceMockRecorder) SyncCard(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCard", reflect.TypeOf((*MockService)(nil).SyncCard), ctx, cardID)
+}
@@ -0,0 +1,50 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+)
+
+// Syncer keeps mirrored cards in step by syncing a card whenever it is edited or moved
+type Syncer struct {
+	mirrorSvc Service
+}
+
+func NewSyncer(mirrorSvc Service) *Syncer {
+	return &Syncer{mirrorSvc: mirrorSvc}
+}
+
+// Subscribe registers the sync handlers on the bus
+func (s *Syncer) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardUpdated, s.handleCardUpdated)
+	bus.Subscribe(events.CardMoved, s.handleCardMoved)
+}
+
+func (s *Syncer) handleCardUpdated(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	return s.sync(ctx, payload.CardID)
+}
+
+func (s *Syncer) handleCardMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	return s.sync(ctx, payload.CardID)
+}
+
+func (s *Syncer) sync(ctx context.Context, cardID uuid.UUID) error {
+	err := s.mirrorSvc.SyncCard(ctx, cardID)
+	// The card may have been deleted since the event was published
+	if errors.Is(err, ErrCardNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+package mirror
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mirror/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mirrorSvc := mocks.NewMockService(ctrl)
+	bus := events.NewSyncBus()
+	NewSyncer(mirrorSvc).Subscribe(bus)
+	ctx := context.Background()
+	cardID := uuid.New()
+
+	t.Run("card updated", func(t *testing.T) {
+		mirrorSvc.EXPECT().SyncCard(gomock.Any(), cardID).Return(nil)
+		event := events.New(ctx, events.CardUpdated, events.CardPayload{CardID: cardID})
+		require.NoError(t, bus.Publish(ctx, event))
+	})
+
+	t.Run("card moved", func(t *testing.T) {
+		mirrorSvc.EXPECT().SyncCard(gomock.Any(), cardID).Return(nil)
+		event := events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: cardID})
+		require.NoError(t, bus.Publish(ctx, event))
+	})
+
+	t.Run("deleted cards are skipped", func(t *testing.T) {
+		mirrorSvc.EXPECT().SyncCard(gomock.Any(), cardID).Return(ErrCardNotFound)
+		require.NoError(t, NewSyncer(mirrorSvc).handleCardUpdated(ctx, events.New(ctx, events.CardUpdated, events.CardPayload{CardID: cardID})))
+	})
+}
@@ -5,29 +5,47 @@ import (
 	"bytes"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/Boostport/mjml-go"
 	"github.com/aymerick/raymond"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
 )
 
 //go:embed templates
 var templates embed.FS
 
+const (
+	// DefaultAccentColor is the accent of templates rendered without an accent_color argument
+	DefaultAccentColor = "#2563EB"
+	// DefaultProductName names the product in templates rendered without a product_name argument
+	DefaultProductName = "Kaimu"
+)
+
+var accentColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
 type MJMLService interface {
 	GenerateHTMLFromMJML(ctx context.Context, template string, args map[string]string) (*string, error)
 }
 
-type mjmlService struct{}
+type mjmlService struct {
+	templateDir string
+}
 
-func NewMJMLService() MJMLService {
-	return &mjmlService{}
+// NewMJMLService renders the built-in templates. A template of the same name in templateDir,
+// when set, replaces the built-in one, as does an included file such as header.mjml
+func NewMJMLService(templateDir string) MJMLService {
+	return &mjmlService{templateDir: templateDir}
 }
 
 func (s *mjmlService) GenerateHTMLFromMJML(ctx context.Context, template string, args map[string]string) (*string, error) {
-	// Get file source from embed.FS
-	file, err := templates.ReadFile("templates/" + template)
+	file, err := s.readTemplate(template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read template %s: %w", template, err)
 	}
@@ -38,14 +56,30 @@ func (s *mjmlService) GenerateHTMLFromMJML(ctx context.Context, template string,
 		return nil, fmt.Errorf("failed to process includes: %w", err)
 	}
 
+	// MJML validates color attributes, so the accent is filled in before compiling
+	expandedTemplate = strings.ReplaceAll(expandedTemplate, "{{accent_color}}", accentColor(args))
+
 	// Parse MJML
 	output, err := mjml.ToHTML(ctx, expandedTemplate, mjml.WithMinify(true))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse MJML: %w", err)
 	}
 
-	// Render the template with provided arguments
-	result, err := raymond.Render(output, args)
+	// Render the template with provided arguments, translating {{t "key"}} in the
+	// context's locale
+	tpl, err := raymond.Parse(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	data := make(map[string]string, len(args)+1)
+	for k, v := range args {
+		data[k] = v
+	}
+	if data["product_name"] == "" {
+		data["product_name"] = DefaultProductName
+	}
+	tpl.RegisterHelper("t", translateHelper(i18n.FromContext(ctx), data["product_name"]))
+	result, err := tpl.Exec(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render template: %w", err)
 	}
@@ -53,6 +87,41 @@ func (s *mjmlService) GenerateHTMLFromMJML(ctx context.Context, template string,
 	return &result, nil
 }
 
+// accentColor returns the accent_color argument, or DefaultAccentColor when it is missing
+// or not a #RRGGBB color
+func accentColor(args map[string]string) string {
+	if color := args["accent_color"]; accentColorPattern.MatchString(color) {
+		return color
+	}
+	return DefaultAccentColor
+}
+
+// translateHelper returns the {{t "key" name=value}} helper for locale, which fills in
+// {product} with productName. Catalog text may contain markup, so only the arguments are
+// escaped.
+func translateHelper(locale, productName string) func(key string, options *raymond.Options) raymond.SafeString {
+	return func(key string, options *raymond.Options) raymond.SafeString {
+		args := make(map[string]string, len(options.Hash())+1)
+		args["product"] = raymond.Escape(productName)
+		for name, value := range options.Hash() {
+			args[name] = raymond.Escape(raymond.Str(value))
+		}
+		return raymond.SafeString(i18n.T(locale, key, args))
+	}
+}
+
+// readTemplate returns the template from the template directory, or the built-in one when
+// the directory doesn't have it
+func (s *mjmlService) readTemplate(name string) ([]byte, error) {
+	if s.templateDir != "" {
+		file, err := os.ReadFile(filepath.Join(s.templateDir, filepath.Clean("/"+name)))
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+	}
+	return templates.ReadFile("templates/" + name)
+}
+
 // handleIncludingTemplates replaces all mj-include tags with their content
 func (s *mjmlService) handleIncludingTemplates(template string) (string, error) {
 	var buffer bytes.Buffer
@@ -72,7 +141,7 @@ func (s *mjmlService) handleIncludingTemplates(template string) (string, error)
 			// Remove `./` from the path
 			includePath = strings.Replace(includePath, "./", "", 1)
 			includePath = strings.TrimSpace(includePath)
-			includeContent, err := templates.ReadFile("templates/" + includePath)
+			includeContent, err := s.readTemplate(includePath)
 			if err != nil {
 				return "", fmt.Errorf("failed to read included template %s: %w", includePath, err)
 			}
@@ -0,0 +1,112 @@
+package mjml
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+)
+
+func TestGenerateHTMLFromMJML(t *testing.T) {
+	svc := NewMJMLService("")
+	args := map[string]string{
+		"organization_name": "Acme <Corp>",
+		"inviter_name":      "Ana",
+		"role_name":         "Admin",
+		"invite_url":        "https://kaimu.example/invite/abc",
+		"expiry_days":       "14",
+	}
+
+	t.Run("renders the default locale", func(t *testing.T) {
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", args)
+		require.NoError(t, err)
+		assert.Contains(t, *html, "Accept Invitation")
+		assert.Contains(t, *html, "<strong>Ana</strong> has invited you to join")
+		assert.Contains(t, *html, "This invitation expires in 14 day(s).")
+	})
+
+	t.Run("renders the context locale", func(t *testing.T) {
+		ctx := i18n.WithLocale(context.Background(), "es")
+		html, err := svc.GenerateHTMLFromMJML(ctx, "invitation.mjml", args)
+		require.NoError(t, err)
+		assert.Contains(t, *html, "Aceptar invitación")
+		assert.NotContains(t, *html, "Accept Invitation")
+	})
+
+	t.Run("renders the branding", func(t *testing.T) {
+		branded := map[string]string{"accent_color": "#FF5500", "logo_url": "https://acme.example/logo.png"}
+		for k, v := range args {
+			branded[k] = v
+		}
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", branded)
+		require.NoError(t, err)
+		assert.Contains(t, *html, `bgcolor="#FF5500"`)
+		assert.Contains(t, *html, `<img src="https://acme.example/logo.png"`)
+		assert.NotContains(t, *html, DefaultAccentColor)
+	})
+
+	t.Run("falls back to the default accent", func(t *testing.T) {
+		branded := map[string]string{"accent_color": "red;x:y"}
+		for k, v := range args {
+			branded[k] = v
+		}
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", branded)
+		require.NoError(t, err)
+		assert.Contains(t, *html, `bgcolor="`+DefaultAccentColor+`"`)
+		assert.NotContains(t, *html, "red;x:y")
+	})
+
+	t.Run("renders the instance branding", func(t *testing.T) {
+		branded := map[string]string{"product_name": "Acme <Boards>", "support_email": "help@acme.example", "terms_url": "https://acme.example/terms"}
+		for k, v := range args {
+			branded[k] = v
+		}
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", branded)
+		require.NoError(t, err)
+		assert.Contains(t, *html, "on Acme &lt;Boards&gt; as a")
+		assert.Contains(t, *html, "help@acme.example")
+		assert.Contains(t, *html, `href="https://acme.example/terms"`)
+		assert.NotContains(t, *html, DefaultProductName)
+	})
+
+	t.Run("escapes translation arguments", func(t *testing.T) {
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", args)
+		require.NoError(t, err)
+		assert.Contains(t, *html, "Acme &lt;Corp&gt;")
+		assert.NotContains(t, *html, "Acme <Corp>")
+	})
+}
+
+func TestTemplateDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "templates")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.mjml"), []byte(`<mjml><mj-body></mj-body></mjml>`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation.mjml"), []byte(`<mjml><mj-body>
+<mj-include path="./header.mjml" />
+<mj-section><mj-column><mj-text>Join {{organization_name}} on staging</mj-text></mj-column></mj-section>
+</mj-body></mjml>`), 0o600))
+	svc := NewMJMLService(dir)
+
+	t.Run("replaces the built-in template", func(t *testing.T) {
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "invitation.mjml", map[string]string{"organization_name": "Acme"})
+		require.NoError(t, err)
+		assert.Contains(t, *html, "Join Acme on staging")
+		assert.NotContains(t, *html, "Accept Invitation")
+	})
+
+	t.Run("falls back to the built-in templates", func(t *testing.T) {
+		html, err := svc.GenerateHTMLFromMJML(context.Background(), "verification.mjml", map[string]string{"token_url": "https://kaimu.example/verify/abc"})
+		require.NoError(t, err)
+		assert.Contains(t, *html, `href="https://kaimu.example/verify/abc"`)
+	})
+
+	t.Run("stays within the directory", func(t *testing.T) {
+		_, err := svc.GenerateHTMLFromMJML(context.Background(), "../secret.mjml", nil)
+		assert.Error(t, err)
+	})
+}
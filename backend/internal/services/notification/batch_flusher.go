@@ -0,0 +1,215 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"gorm.io/gorm"
+)
+
+// DefaultBatchFlushInterval is how often the flusher looks for batch windows that closed
+const DefaultBatchFlushInterval = time.Minute
+
+// maxBatchLines caps the events listed in a summary; the rest are only counted
+const maxBatchLines = 20
+
+// BatchFlusher sends the notifications held back by boards in quiet mode: once a board's
+// window has passed since the oldest queued item, each recipient gets one summary
+type BatchFlusher struct {
+	batchRepo   notification_batch.Repository
+	ruleRepo    notification_rule.Repository
+	boardRepo   board.Repository
+	projectRepo project.Repository
+	userRepo    user.Repository
+	rbacSvc     rbac.Service
+	mailSvc     mail.MailService
+	localeSvc   locale.Service
+	brandingSvc branding.Service
+	slack       SlackPoster
+	txManager   transaction.Manager
+	interval    time.Duration
+	now         func() time.Time
+}
+
+func NewBatchFlusher(
+	batchRepo notification_batch.Repository,
+	ruleRepo notification_rule.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+	brandingSvc branding.Service,
+	slack SlackPoster,
+	txManager transaction.Manager,
+	interval time.Duration,
+) *BatchFlusher {
+	return &BatchFlusher{
+		batchRepo:   batchRepo,
+		ruleRepo:    ruleRepo,
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+		rbacSvc:     rbacSvc,
+		mailSvc:     mailSvc,
+		localeSvc:   localeSvc,
+		brandingSvc: brandingSvc,
+		slack:       slack,
+		txManager:   txManager,
+		interval:    interval,
+		now:         time.Now,
+	}
+}
+
+// Run flushes due batches every interval until ctx is cancelled
+func (f *BatchFlusher) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := f.Flush(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to flush notification batches")
+		} else if sent > 0 {
+			log.Info().Int("sent", sent).Msg("Sent batched notifications")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush sends every due batch and returns how many summaries went out. A batch is removed
+// in the same transaction as it is sent, so a failed send is retried on the next run.
+func (f *BatchFlusher) Flush(ctx context.Context) (int, error) {
+	batches, err := f.batchRepo.GetDueBatches(ctx, f.now())
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, batch := range batches {
+		err := f.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			items, err := f.batchRepo.TakeBatch(ctx, batch)
+			if err != nil || len(items) == 0 {
+				return err
+			}
+			ok, err := f.send(ctx, batch, items)
+			if ok {
+				sent++
+			}
+			return err
+		})
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// send delivers the batch's summary, reporting false when its recipient is gone
+func (f *BatchFlusher) send(ctx context.Context, batch *notification_batch.Batch, items []*notification_batch.Item) (bool, error) {
+	b, err := f.boardRepo.GetByID(ctx, batch.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if batch.RuleID == nil {
+		ctx = i18n.WithLocale(ctx, f.localeSvc.ForProject(ctx, nil, b.ProjectID))
+		header, lines, err := f.summarize(ctx, b, items)
+		if err != nil {
+			return false, err
+		}
+		text := header
+		if len(lines) > 0 {
+			text += ":\n• " + strings.Join(lines, "\n• ")
+		}
+		return true, f.slack.Post(ctx, *batch.SlackWebhookURL, batch.SlackChannel, text)
+	}
+
+	rule, err := f.ruleRepo.GetByID(ctx, *batch.RuleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	canView, err := f.rbacSvc.HasProjectPermission(ctx, rule.UserID, rule.ProjectID, "project:view")
+	if err != nil {
+		return false, err
+	}
+	owner, err := f.userRepo.GetByID(ctx, rule.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if !canView || owner == nil || owner.Email == nil {
+		return false, nil
+	}
+
+	ctx = i18n.WithLocale(ctx, f.localeSvc.ForProject(ctx, owner, rule.ProjectID))
+	header, lines, err := f.summarize(ctx, b, items)
+	if err != nil {
+		return false, err
+	}
+	message := header
+	if len(lines) > 0 {
+		message += ": " + strings.Join(lines, "; ")
+	}
+	return true, sendRuleMail(ctx, f.mailSvc, f.brandingSvc, owner, rule, header, message)
+}
+
+// summarize returns the headline of the summary and the events it lists. A batch of one
+// event is sent as that event's own message.
+func (f *BatchFlusher) summarize(ctx context.Context, b *board.Board, items []*notification_batch.Item) (string, []string, error) {
+	if len(items) == 1 {
+		return items[0].Message, nil, nil
+	}
+
+	projectName := i18n.Tc(ctx, "notification.unknown_project", nil)
+	if p, err := f.projectRepo.GetByID(ctx, b.ProjectID); err == nil {
+		projectName = p.Name
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil, err
+	}
+	header := i18n.Tc(ctx, "notification.batch_summary", map[string]string{
+		"count":   strconv.Itoa(len(items)),
+		"board":   b.Name,
+		"project": projectName,
+	})
+
+	lines := make([]string, 0, min(len(items), maxBatchLines+1))
+	for i, item := range items {
+		if i == maxBatchLines {
+			lines = append(lines, i18n.Tc(ctx, "notification.batch_more", map[string]string{
+				"count": strconv.Itoa(len(items) - maxBatchLines),
+			}))
+			break
+		}
+		lines = append(lines, item.Message)
+	}
+	return header, lines, nil
+}
@@ -0,0 +1,220 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	batchMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch/mocks"
+	channelMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	ruleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestBatchFlusher(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	projectID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: projectID, Name: "Grooming", NotificationBatchMinutes: ptr(15)}
+	email := "watcher@example.com"
+	watcher := &user.User{ID: uuid.New(), Username: "watcher", Email: &email}
+	rule := &notification_rule.NotificationRule{ID: uuid.New(), UserID: watcher.ID, ProjectID: projectID, Name: "Moves"}
+	webhook := "https://hooks.slack.com/services/T000/B000/XXXX"
+
+	items := func(n int) []*notification_batch.Item {
+		result := make([]*notification_batch.Item, n)
+		for i := range result {
+			result[i] = &notification_batch.Item{
+				ID:       uuid.New(),
+				BoardID:  b.ID,
+				EventID:  uuid.New(),
+				Message:  fmt.Sprintf(`Card "Card %d" was moved to Done in Platform`, i+1),
+				QueuedAt: now.Add(time.Duration(i-n) * time.Minute),
+			}
+		}
+		return result
+	}
+
+	type deps struct {
+		batchRepo   *batchMocks.MockRepository
+		ruleRepo    *ruleMocks.MockRepository
+		boardRepo   *boardMocks.MockRepository
+		projectRepo *projectMocks.MockRepository
+		userRepo    *userMocks.MockRepository
+		rbacSvc     *rbacMocks.MockService
+		mailSvc     *fakes.MailService
+		localeSvc   *localeMocks.MockService
+		slack       *mockSlackPoster
+		flusher     *BatchFlusher
+	}
+	setup := func(t *testing.T) deps {
+		ctrl := gomock.NewController(t)
+		d := deps{
+			batchRepo:   batchMocks.NewMockRepository(ctrl),
+			ruleRepo:    ruleMocks.NewMockRepository(ctrl),
+			boardRepo:   boardMocks.NewMockRepository(ctrl),
+			projectRepo: projectMocks.NewMockRepository(ctrl),
+			userRepo:    userMocks.NewMockRepository(ctrl),
+			rbacSvc:     rbacMocks.NewMockService(ctrl),
+			mailSvc:     fakes.NewMailService(),
+			localeSvc:   localeMocks.NewMockService(ctrl),
+			slack:       &mockSlackPoster{},
+		}
+		d.flusher = NewBatchFlusher(d.batchRepo, d.ruleRepo, d.boardRepo, d.projectRepo, d.userRepo, d.rbacSvc, d.mailSvc, d.localeSvc, noBranding(ctrl), d.slack, transaction.NewNoopManager(), time.Minute)
+		d.flusher.now = func() time.Time { return now }
+		return d
+	}
+
+	t.Run("emails the rule owner one summary", func(t *testing.T) {
+		d := setup(t)
+		batch := &notification_batch.Batch{BoardID: b.ID, RuleID: &rule.ID}
+
+		d.batchRepo.EXPECT().GetDueBatches(gomock.Any(), now).Return([]*notification_batch.Batch{batch}, nil)
+		d.batchRepo.EXPECT().TakeBatch(gomock.Any(), batch).Return(items(3), nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		d.ruleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(rule, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(true, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), watcher, projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+
+		sent, err := d.flusher.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		require.Len(t, d.mailSvc.Sent, 1)
+		assert.Equal(t, "3 card changes on Grooming in Platform", d.mailSvc.Sent[0].Subject)
+		assert.Equal(t, `3 card changes on Grooming in Platform: Card "Card 1" was moved to Done in Platform; Card "Card 2" was moved to Done in Platform; Card "Card 3" was moved to Done in Platform`, d.mailSvc.Sent[0].Values["message"])
+		assert.Equal(t, "Moves", d.mailSvc.Sent[0].Values["rule_name"])
+	})
+
+	t.Run("posts one summary to slack and counts events past the limit", func(t *testing.T) {
+		d := setup(t)
+		batch := &notification_batch.Batch{BoardID: b.ID, SlackWebhookURL: &webhook, SlackChannel: "#grooming"}
+
+		d.batchRepo.EXPECT().GetDueBatches(gomock.Any(), now).Return([]*notification_batch.Batch{batch}, nil)
+		d.batchRepo.EXPECT().TakeBatch(gomock.Any(), batch).Return(items(maxBatchLines+5), nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), gomock.Nil(), projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+
+		sent, err := d.flusher.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+
+		require.Len(t, d.slack.posts, 1)
+		post := d.slack.posts[0]
+		assert.Equal(t, webhook, post.webhookURL)
+		assert.Equal(t, "#grooming", post.channel)
+		assert.Contains(t, post.text, "25 card changes on Grooming in Platform:\n• ")
+		assert.Contains(t, post.text, `• Card "Card 20" was moved to Done in Platform`+"\n• and 5 more")
+		assert.NotContains(t, post.text, "Card 21")
+	})
+
+	t.Run("sends a batch of one event as that event", func(t *testing.T) {
+		d := setup(t)
+		batch := &notification_batch.Batch{BoardID: b.ID, SlackWebhookURL: &webhook}
+		single := items(1)
+
+		d.batchRepo.EXPECT().GetDueBatches(gomock.Any(), now).Return([]*notification_batch.Batch{batch}, nil)
+		d.batchRepo.EXPECT().TakeBatch(gomock.Any(), batch).Return(single, nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), gomock.Nil(), projectID).Return("en")
+
+		_, err := d.flusher.Flush(context.Background())
+		require.NoError(t, err)
+		require.Len(t, d.slack.posts, 1)
+		assert.Equal(t, single[0].Message, d.slack.posts[0].text)
+	})
+
+	t.Run("drops the batch of an owner who lost access", func(t *testing.T) {
+		d := setup(t)
+		batch := &notification_batch.Batch{BoardID: b.ID, RuleID: &rule.ID}
+
+		d.batchRepo.EXPECT().GetDueBatches(gomock.Any(), now).Return([]*notification_batch.Batch{batch}, nil)
+		d.batchRepo.EXPECT().TakeBatch(gomock.Any(), batch).Return(items(2), nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		d.ruleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(rule, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(false, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+
+		sent, err := d.flusher.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("drops the batch of a deleted rule", func(t *testing.T) {
+		d := setup(t)
+		batch := &notification_batch.Batch{BoardID: b.ID, RuleID: &rule.ID}
+
+		d.batchRepo.EXPECT().GetDueBatches(gomock.Any(), now).Return([]*notification_batch.Batch{batch}, nil)
+		d.batchRepo.EXPECT().TakeBatch(gomock.Any(), batch).Return(items(2), nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		d.ruleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		sent, err := d.flusher.Flush(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestSetBoardQuietMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	svc := NewService(ruleMocks.NewMockRepository(ctrl), channelMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), mockBoardRepo, columnMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), userMocks.NewMockRepository(ctrl), fakes.NewMailService(), localeMocks.NewMockService(ctrl), noBranding(ctrl), transaction.NewNoopManager())
+	ctx := context.Background()
+	boardID := uuid.New()
+
+	t.Run("turns quiet mode on", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+		mockBoardRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.SetBoardQuietMode(ctx, boardID, ptr(30))
+		require.NoError(t, err)
+		assert.Equal(t, ptr(30), b.NotificationBatchMinutes)
+	})
+
+	t.Run("turns quiet mode off", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, NotificationBatchMinutes: ptr(30)}, nil)
+		mockBoardRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := svc.SetBoardQuietMode(ctx, boardID, nil)
+		require.NoError(t, err)
+		assert.Nil(t, b.NotificationBatchMinutes)
+	})
+
+	t.Run("rejects windows out of range", func(t *testing.T) {
+		_, err := svc.SetBoardQuietMode(ctx, boardID, ptr(0))
+		assert.ErrorIs(t, err, ErrInvalidBatchMinutes)
+		_, err = svc.SetBoardQuietMode(ctx, boardID, ptr(MaxBatchMinutes+1))
+		assert.ErrorIs(t, err, ErrInvalidBatchMinutes)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetBoardQuietMode(ctx, boardID, ptr(30))
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+}
@@ -0,0 +1,221 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// slackWebhookPrefix is the start of every Slack incoming webhook URL
+const slackWebhookPrefix = "https://hooks.slack.com/"
+
+var (
+	ErrUnsupportedChannelEvent    = errors.New("notifications cannot be routed for this event")
+	ErrDuplicateChannelEvent      = errors.New("an event can only be routed once")
+	ErrInvalidSlackWebhook        = errors.New("slack webhook must be an " + slackWebhookPrefix + " URL")
+	ErrSlackChannelWithoutWebhook = errors.New("a slack channel needs a slack webhook")
+)
+
+// channelEvents are the events channel settings route, in a stable order
+var channelEvents = slices.Sorted(maps.Keys(SupportedEvents))
+
+// ChannelRoute is where one card event is delivered
+type ChannelRoute struct {
+	Event events.Name
+	// Email sends the event to the owners of matching notification rules
+	Email bool
+	// SlackWebhookURL posts every such event to Slack; nil keeps it out of Slack
+	SlackWebhookURL *string
+	SlackChannel    *string
+	// Inherited is set when the route isn't configured at the level asked for and comes
+	// from the organization default, or the built-in default of email only
+	Inherited bool
+}
+
+// ChannelRouteInput routes one event, for a project override or an organization default
+type ChannelRouteInput struct {
+	Event           events.Name
+	Email           bool
+	SlackWebhookURL *string
+	// SlackChannel overrides the webhook's default channel
+	SlackChannel *string
+}
+
+func (s *service) GetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID) ([]*ChannelRoute, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProjectChannelRoutes")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	overrides, err := s.channelRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := s.channelRepo.GetOrgDefaults(ctx, p.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRoutes(overrides, defaults), nil
+}
+
+func (s *service) GetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID) ([]*ChannelRoute, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOrganizationChannelRoutes")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	defaults, err := s.channelRepo.GetOrgDefaults(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRoutes(defaults, nil), nil
+}
+
+func (s *service) SetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID, inputs []ChannelRouteInput) ([]*ChannelRoute, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetProjectChannelRoutes")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	settings, err := channelSettingsFromInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.channelRepo.DeleteByProjectID(ctx, projectID); err != nil {
+			return err
+		}
+		for _, setting := range settings {
+			setting.OrganizationID = p.OrganizationID
+			setting.ProjectID = &p.ID
+			if err := s.channelRepo.Create(ctx, setting); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetProjectChannelRoutes(ctx, projectID)
+}
+
+func (s *service) SetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID, inputs []ChannelRouteInput) ([]*ChannelRoute, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetOrganizationChannelRoutes")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	settings, err := channelSettingsFromInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.channelRepo.DeleteOrgDefaults(ctx, orgID); err != nil {
+			return err
+		}
+		for _, setting := range settings {
+			setting.OrganizationID = orgID
+			if err := s.channelRepo.Create(ctx, setting); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetOrganizationChannelRoutes(ctx, orgID)
+}
+
+// channelSettingsFromInputs validates the inputs and converts them to settings, without
+// their organization or project
+func channelSettingsFromInputs(inputs []ChannelRouteInput) ([]*notification_channel.Setting, error) {
+	seen := make(map[events.Name]bool, len(inputs))
+	settings := make([]*notification_channel.Setting, len(inputs))
+	for i, input := range inputs {
+		if !SupportedEvents[input.Event] {
+			return nil, ErrUnsupportedChannelEvent
+		}
+		if seen[input.Event] {
+			return nil, ErrDuplicateChannelEvent
+		}
+		seen[input.Event] = true
+
+		setting := &notification_channel.Setting{Event: string(input.Event), Email: input.Email}
+		if input.SlackWebhookURL != nil {
+			webhook := strings.TrimSpace(*input.SlackWebhookURL)
+			if !strings.HasPrefix(webhook, slackWebhookPrefix) {
+				return nil, ErrInvalidSlackWebhook
+			}
+			setting.SlackWebhookURL = &webhook
+		}
+		if input.SlackChannel != nil {
+			if channel := strings.TrimSpace(*input.SlackChannel); channel != "" {
+				if setting.SlackWebhookURL == nil {
+					return nil, ErrSlackChannelWithoutWebhook
+				}
+				setting.SlackChannel = &channel
+			}
+		}
+		settings[i] = setting
+	}
+	return settings, nil
+}
+
+// resolveRoutes returns a route per routable event: the setting when there is one,
+// otherwise the inherited fallback, otherwise the built-in default of email only
+func resolveRoutes(settings, fallbacks []*notification_channel.Setting) []*ChannelRoute {
+	byEvent := make(map[string]*notification_channel.Setting, len(settings))
+	for _, setting := range settings {
+		byEvent[setting.Event] = setting
+	}
+	fallbackByEvent := make(map[string]*notification_channel.Setting, len(fallbacks))
+	for _, setting := range fallbacks {
+		fallbackByEvent[setting.Event] = setting
+	}
+
+	routes := make([]*ChannelRoute, len(channelEvents))
+	for i, event := range channelEvents {
+		route := &ChannelRoute{Event: event, Email: true, Inherited: true}
+		if setting, ok := byEvent[string(event)]; ok {
+			route = routeFromSetting(setting, false)
+		} else if setting, ok := fallbackByEvent[string(event)]; ok {
+			route = routeFromSetting(setting, true)
+		}
+		routes[i] = route
+	}
+	return routes
+}
+
+func routeFromSetting(setting *notification_channel.Setting, inherited bool) *ChannelRoute {
+	return &ChannelRoute{
+		Event:           events.Name(setting.Event),
+		Email:           setting.Email,
+		SlackWebhookURL: setting.SlackWebhookURL,
+		SlackChannel:    setting.SlackChannel,
+		Inherited:       inherited,
+	}
+}
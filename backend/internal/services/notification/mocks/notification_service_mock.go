@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_service.go -destination=mocks/notification_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	notification_rule "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	notification "github.com/thatcatdev/kaimu/backend/internal/services/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRule mocks base method.
+func (m *MockService) CreateRule(ctx context.Context, userID uuid.UUID, input notification.RuleInput) (*notification_rule.NotificationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, userID, input)
+	ret0, _ := ret[0].(*notification_rule.NotificationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockServiceMockRecorder) CreateRule(ctx, userID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockService)(nil).CreateRule), ctx, userID, input)
+}
+
+// DeleteRule mocks base method.
+func (m *MockService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockServiceMockRecorder) DeleteRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockService)(nil).DeleteRule), ctx, id)
+}
+
+// GetOrganizationChannelRoutes mocks base method.
+func (m *MockService) GetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID) ([]*notification.ChannelRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationChannelRoutes", ctx, orgID)
+	ret0, _ := ret[0].([]*notification.ChannelRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationChannelRoutes indicates an expected call of GetOrganizationChannelRoutes.
+func (mr *MockServiceMockRecorder) GetOrganizationChannelRoutes(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationChannelRoutes", reflect.TypeOf((*MockService)(nil).GetOrganizationChannelRoutes), ctx, orgID)
+}
+
+// GetProjectChannelRoutes mocks base method.
+func (m *MockService) GetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID) ([]*notification.ChannelRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectChannelRoutes", ctx, projectID)
+	ret0, _ := ret[0].([]*notification.ChannelRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectChannelRoutes indicates an expected call of GetProjectChannelRoutes.
+func (mr *MockServiceMockRecorder) GetProjectChannelRoutes(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectChannelRoutes", reflect.TypeOf((*MockService)(nil).GetProjectChannelRoutes), ctx, projectID)
+}
+
+// GetRule mocks base method.
+func (m *MockService) GetRule(ctx context.Context, id uuid.UUID) (*notification_rule.NotificationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRule", ctx, id)
+	ret0, _ := ret[0].(*notification_rule.NotificationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRule indicates an expected call of GetRule.
+func (mr *MockServiceMockRecorder) GetRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockService)(nil).GetRule), ctx, id)
+}
+
+// GetRulesByUserID mocks base method.
+func (m *MockService) GetRulesByUserID(ctx context.Context, userID uuid.UUID) ([]*notification_rule.NotificationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*notification_rule.NotificationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesByUserID indicates an expected call of GetRulesByUserID.
+func (mr *MockServiceMockRecorder) GetRulesByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByUserID", reflect.TypeOf((*MockService)(nil).GetRulesByUserID), ctx, userID)
+}
+
+// SetBoardQuietMode mocks base method.
+func (m *MockService) SetBoardQuietMode(ctx context.Context, boardID uuid.UUID, minutes *int) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBoardQuietMode", ctx, boardID, minutes)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBoardQuietMode indicates an expected call of SetBoardQuietMode.
+func (mr *MockServiceMockRecorder) SetBoardQuietMode(ctx, boardID, minutes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardQuietMode", reflect.TypeOf((*MockService)(nil).SetBoardQuietMode), ctx, boardID, minutes)
+}
+
+// SetOrganizationChannelRoutes mocks base method.
+func (m *MockService) SetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID, inputs []notification.ChannelRouteInput) ([]*notification.ChannelRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizationChannelRoutes", ctx, orgID, inputs)
+	ret0, _ := ret[0].([]*notification.ChannelRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizationChannelRoutes indicates an expected call of SetOrganizationChannelRoutes.
+func (mr *MockServiceMockRecorder) SetOrganizationChannelRoutes(ctx, orgID, inputs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationChannelRoutes", reflect.TypeOf((*MockService)(nil).SetOrganizationChannelRoutes), ctx, orgID, inputs)
+}
+
+// SetProjectChannelRoutes mocks base method.
+func (m *MockService) SetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID, inputs []notification.ChannelRouteInput) ([]*notification.ChannelRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProjectChannelRoutes", ctx, projectID, inputs)
+	ret0, _ := ret[0].([]*notification.ChannelRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProjectChannelRoutes indicates an expected call of SetProjectChannelRoutes.
+func (mr *MockServiceMockRecorder) SetProjectChannelRoutes(ctx, projectID, inputs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectChannelRoutes", reflect.TypeOf((*MockService)(nil).SetProjectChannelRoutes), ctx, projectID, inputs)
+}
+
+// TestRule mocks base method.
+func (m *MockService) TestRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TestRule indicates an expected call of TestRule.
+func (mr *MockServiceMockRecorder) TestRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestRule", reflect.TypeOf((*MockService)(nil).TestRule), ctx, id)
+}
+
+// UpdateRule mocks base method.
+func (m *MockService) UpdateRule(ctx context.Context, id uuid.UUID, input notification.RuleInput) (*notification_rule.NotificationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, id, input)
+	ret0, _ := ret[0].(*notification_rule.NotificationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockServiceMockRecorder) UpdateRule(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockService)(nil).UpdateRule), ctx, id, input)
+}
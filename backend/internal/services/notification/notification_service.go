@@ -0,0 +1,320 @@
+package notification
+
+//go:generate mockgen -source=notification_service.go -destination=mocks/notification_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRuleNotFound       = errors.New("notification rule not found")
+	ErrProjectNotFound    = errors.New("project not found")
+	ErrNameRequired       = errors.New("notification rule name is required")
+	ErrUnsupportedEvent   = errors.New("notification rules cannot watch this event")
+	ErrTagNotInProject    = errors.New("tag does not belong to the project")
+	ErrColumnNotInProject = errors.New("column does not belong to the project")
+	ErrNoEmail            = errors.New("user has no email address to notify")
+)
+
+// ruleTemplate is the email template used for rule notifications
+const ruleTemplate = "notification.mjml"
+
+// SupportedEvents are the card events a rule can watch
+var SupportedEvents = map[events.Name]bool{
+	events.CardCreated:     true,
+	events.CardUpdated:     true,
+	events.CardMoved:       true,
+	events.CardDeleted:     true,
+	events.CardSLABreached: true,
+}
+
+// BatchedEvents are the events a board's quiet mode holds back; SLA breaches always go
+// out at once
+var BatchedEvents = map[events.Name]bool{
+	events.CardCreated: true,
+	events.CardUpdated: true,
+	events.CardMoved:   true,
+	events.CardDeleted: true,
+}
+
+// RuleInput describes a notification rule to create or replace. Nil conditions match
+// every card.
+type RuleInput struct {
+	ProjectID uuid.UUID
+	Name      string
+	Event     events.Name
+	TagID     *uuid.UUID
+	// ColumnID matches the card's column, or the destination column for card.moved
+	ColumnID *uuid.UUID
+	Priority *card.CardPriority
+	Enabled  bool
+}
+
+type Service interface {
+	CreateRule(ctx context.Context, userID uuid.UUID, input RuleInput) (*notification_rule.NotificationRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, input RuleInput) (*notification_rule.NotificationRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	GetRule(ctx context.Context, id uuid.UUID) (*notification_rule.NotificationRule, error)
+	GetRulesByUserID(ctx context.Context, userID uuid.UUID) ([]*notification_rule.NotificationRule, error)
+	// TestRule sends the rule's owner a sample notification so they can check delivery
+	TestRule(ctx context.Context, id uuid.UUID) error
+
+	// GetProjectChannelRoutes returns where each event is delivered in the project: its
+	// override, else the organization default, else email only
+	GetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID) ([]*ChannelRoute, error)
+	// GetOrganizationChannelRoutes returns the organization's default route per event
+	GetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID) ([]*ChannelRoute, error)
+	// SetProjectChannelRoutes replaces the project's overrides; events left out follow the
+	// organization defaults
+	SetProjectChannelRoutes(ctx context.Context, projectID uuid.UUID, inputs []ChannelRouteInput) ([]*ChannelRoute, error)
+	// SetOrganizationChannelRoutes replaces the organization's defaults
+	SetOrganizationChannelRoutes(ctx context.Context, orgID uuid.UUID, inputs []ChannelRouteInput) ([]*ChannelRoute, error)
+
+	// SetBoardQuietMode collects the board's card notifications for minutes and sends one
+	// summary per recipient; nil sends them per event again
+	SetBoardQuietMode(ctx context.Context, boardID uuid.UUID, minutes *int) (*board.Board, error)
+}
+
+type service struct {
+	ruleRepo    notification_rule.Repository
+	channelRepo notification_channel.Repository
+	projectRepo project.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	tagRepo     tag.Repository
+	userRepo    user.Repository
+	mailSvc     mail.MailService
+	localeSvc   locale.Service
+	brandingSvc branding.Service
+	txManager   transaction.Manager
+}
+
+func NewService(
+	ruleRepo notification_rule.Repository,
+	channelRepo notification_channel.Repository,
+	projectRepo project.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	tagRepo tag.Repository,
+	userRepo user.Repository,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+	brandingSvc branding.Service,
+	txManager transaction.Manager,
+) Service {
+	return &service{
+		ruleRepo:    ruleRepo,
+		channelRepo: channelRepo,
+		projectRepo: projectRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		tagRepo:     tagRepo,
+		userRepo:    userRepo,
+		mailSvc:     mailSvc,
+		localeSvc:   localeSvc,
+		brandingSvc: brandingSvc,
+		txManager:   txManager,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "notification.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "notification"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateRule(ctx context.Context, userID uuid.UUID, input RuleInput) (*notification_rule.NotificationRule, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateRule")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("project.id", input.ProjectID.String()),
+	)
+	defer span.End()
+
+	rule := &notification_rule.NotificationRule{UserID: userID}
+	if err := s.applyInput(ctx, rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *service) UpdateRule(ctx context.Context, id uuid.UUID, input RuleInput) (*notification_rule.NotificationRule, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateRule")
+	span.SetAttributes(attribute.String("notification_rule.id", id.String()))
+	defer span.End()
+
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyInput(ctx, rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// applyInput validates input and copies it onto the rule
+func (s *service) applyInput(ctx context.Context, rule *notification_rule.NotificationRule, input RuleInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return ErrNameRequired
+	}
+	if !SupportedEvents[input.Event] {
+		return ErrUnsupportedEvent
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, input.ProjectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+
+	if input.TagID != nil {
+		t, err := s.tagRepo.GetByID(ctx, *input.TagID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if t == nil || t.ProjectID != input.ProjectID {
+			return ErrTagNotInProject
+		}
+	}
+
+	if input.ColumnID != nil {
+		col, err := s.columnRepo.GetByID(ctx, *input.ColumnID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if col == nil {
+			return ErrColumnNotInProject
+		}
+		b, err := s.boardRepo.GetByID(ctx, col.BoardID)
+		if err != nil {
+			return err
+		}
+		if b.ProjectID != input.ProjectID {
+			return ErrColumnNotInProject
+		}
+	}
+
+	rule.ProjectID = input.ProjectID
+	rule.Name = name
+	rule.Event = string(input.Event)
+	rule.TagID = input.TagID
+	rule.ColumnID = input.ColumnID
+	rule.Priority = input.Priority
+	rule.Enabled = input.Enabled
+	return nil
+}
+
+func (s *service) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeleteRule")
+	span.SetAttributes(attribute.String("notification_rule.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+	return s.ruleRepo.Delete(ctx, id)
+}
+
+func (s *service) GetRule(ctx context.Context, id uuid.UUID) (*notification_rule.NotificationRule, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetRule")
+	span.SetAttributes(attribute.String("notification_rule.id", id.String()))
+	defer span.End()
+
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *service) GetRulesByUserID(ctx context.Context, userID uuid.UUID) ([]*notification_rule.NotificationRule, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetRulesByUserID")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	return s.ruleRepo.GetByUserID(ctx, userID)
+}
+
+func (s *service) TestRule(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "TestRule")
+	span.SetAttributes(attribute.String("notification_rule.id", id.String()))
+	defer span.End()
+
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	owner, err := s.userRepo.GetByID(ctx, rule.UserID)
+	if err != nil {
+		return err
+	}
+	if owner.Email == nil {
+		return ErrNoEmail
+	}
+
+	ctx = i18n.WithLocale(ctx, s.localeSvc.ForProject(ctx, owner, rule.ProjectID))
+	args := map[string]string{"rule": rule.Name}
+	return sendRuleMail(ctx, s.mailSvc, s.brandingSvc, owner, rule, i18n.Tc(ctx, "email.notification.test_subject", args), i18n.Tc(ctx, "email.notification.test_message", args))
+}
+
+// sendRuleMail emails a rule notification to its owner, branded by the rule's organization
+func sendRuleMail(ctx context.Context, mailSvc mail.MailService, brandingSvc branding.Service, owner *user.User, rule *notification_rule.NotificationRule, subject, message string) error {
+	ctx = mail.WithBranding(ctx, brandingSvc.ForProject(ctx, rule.ProjectID))
+	name := owner.Username
+	if owner.DisplayName != nil {
+		name = *owner.DisplayName
+	}
+	err := mailSvc.SendMail(ctx, []string{*owner.Email}, subject, ruleTemplate, map[string]string{
+		"name":      name,
+		"rule_name": rule.Name,
+		"message":   message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	channelMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	ruleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	brandingMocks "github.com/thatcatdev/kaimu/backend/internal/services/branding/mocks"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuleRepo := ruleMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockTagRepo := tagMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockRuleRepo, channelMocks.NewMockRepository(ctrl), mockProjectRepo, mockBoardRepo, mockColumnRepo, mockTagRepo, userMocks.NewMockRepository(ctrl), fakes.NewMailService(), localeMocks.NewMockService(ctrl), noBranding(ctrl), transaction.NewNoopManager())
+	ctx := context.Background()
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	tagID := uuid.New()
+	columnID := uuid.New()
+	boardID := uuid.New()
+
+	expectProject := func() {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectProject()
+		mockTagRepo.EXPECT().GetByID(gomock.Any(), tagID).Return(&tag.Tag{ID: tagID, ProjectID: projectID}, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockRuleRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		rule, err := svc.CreateRule(ctx, userID, RuleInput{
+			ProjectID: projectID,
+			Name:      "Security cards",
+			Event:     events.CardCreated,
+			TagID:     &tagID,
+			ColumnID:  &columnID,
+			Enabled:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, userID, rule.UserID)
+		assert.Equal(t, string(events.CardCreated), rule.Event)
+		assert.True(t, rule.Enabled)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		_, err := svc.CreateRule(ctx, userID, RuleInput{ProjectID: projectID, Name: " ", Event: events.CardCreated})
+		assert.ErrorIs(t, err, ErrNameRequired)
+	})
+
+	t.Run("unsupported event", func(t *testing.T) {
+		_, err := svc.CreateRule(ctx, userID, RuleInput{ProjectID: projectID, Name: "r", Event: events.ProjectCreated})
+		assert.ErrorIs(t, err, ErrUnsupportedEvent)
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateRule(ctx, userID, RuleInput{ProjectID: projectID, Name: "r", Event: events.CardCreated})
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("tag from another project", func(t *testing.T) {
+		expectProject()
+		mockTagRepo.EXPECT().GetByID(gomock.Any(), tagID).Return(&tag.Tag{ID: tagID, ProjectID: uuid.New()}, nil)
+
+		_, err := svc.CreateRule(ctx, userID, RuleInput{ProjectID: projectID, Name: "r", Event: events.CardCreated, TagID: &tagID})
+		assert.ErrorIs(t, err, ErrTagNotInProject)
+	})
+
+	t.Run("column from another project", func(t *testing.T) {
+		expectProject()
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: uuid.New()}, nil)
+
+		_, err := svc.CreateRule(ctx, userID, RuleInput{ProjectID: projectID, Name: "r", Event: events.CardMoved, ColumnID: &columnID})
+		assert.ErrorIs(t, err, ErrColumnNotInProject)
+	})
+}
+
+func TestTestRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRuleRepo := ruleMocks.NewMockRepository(ctrl)
+	mockUserRepo := userMocks.NewMockRepository(ctrl)
+	mockLocaleSvc := localeMocks.NewMockService(ctrl)
+	mockBrandingSvc := brandingMocks.NewMockService(ctrl)
+	mailSvc := fakes.NewMailService()
+
+	svc := NewService(mockRuleRepo, channelMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), boardMocks.NewMockRepository(ctrl), columnMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), mockUserRepo, mailSvc, mockLocaleSvc, mockBrandingSvc, transaction.NewNoopManager())
+	ctx := context.Background()
+
+	email := "owner@example.com"
+	owner := &user.User{ID: uuid.New(), Username: "owner", Email: &email}
+	rule := &notification_rule.NotificationRule{ID: uuid.New(), UserID: owner.ID, Name: "Security cards"}
+
+	t.Run("sends a sample notification", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(rule, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(owner, nil)
+		mockLocaleSvc.EXPECT().ForProject(gomock.Any(), owner, rule.ProjectID).Return("en")
+		branding := &mail.Branding{FromEmail: "team@acme.example", AccentColor: "#FF5500"}
+		mockBrandingSvc.EXPECT().ForProject(gomock.Any(), rule.ProjectID).Return(branding)
+
+		require.NoError(t, svc.TestRule(ctx, rule.ID))
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, mailSvc.Sent[0].To)
+		assert.Equal(t, "Test notification: Security cards", mailSvc.Sent[0].Subject)
+		assert.Equal(t, branding, mailSvc.Sent[0].Branding)
+	})
+
+	t.Run("writes in the owner's locale", func(t *testing.T) {
+		mailSvc.Sent = nil
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(rule, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(owner, nil)
+		mockLocaleSvc.EXPECT().ForProject(gomock.Any(), owner, rule.ProjectID).Return("es")
+		mockBrandingSvc.EXPECT().ForProject(gomock.Any(), rule.ProjectID).Return(nil)
+
+		require.NoError(t, svc.TestRule(ctx, rule.ID))
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, "Notificación de prueba: Security cards", mailSvc.Sent[0].Subject)
+	})
+
+	t.Run("owner without email", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(rule, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(&user.User{ID: owner.ID}, nil)
+
+		assert.ErrorIs(t, svc.TestRule(ctx, rule.ID), ErrNoEmail)
+	})
+
+	t.Run("rule not found", func(t *testing.T) {
+		mockRuleRepo.EXPECT().GetByID(gomock.Any(), rule.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, svc.TestRule(ctx, rule.ID), ErrRuleNotFound)
+	})
+}
+
+func TestProjectChannelRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChannelRepo := channelMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+
+	svc := NewService(ruleMocks.NewMockRepository(ctrl), mockChannelRepo, mockProjectRepo, boardMocks.NewMockRepository(ctrl), columnMocks.NewMockRepository(ctrl), tagMocks.NewMockRepository(ctrl), userMocks.NewMockRepository(ctrl), fakes.NewMailService(), localeMocks.NewMockService(ctrl), noBranding(ctrl), transaction.NewNoopManager())
+	ctx := context.Background()
+
+	orgID := uuid.New()
+	p := &project.Project{ID: uuid.New(), OrganizationID: orgID}
+	webhook := "https://hooks.slack.com/services/T000/B000/XXXX"
+	channel := "#qa"
+
+	routeFor := func(routes []*ChannelRoute, event events.Name) *ChannelRoute {
+		for _, route := range routes {
+			if route.Event == event {
+				return route
+			}
+		}
+		return nil
+	}
+
+	t.Run("project overrides win over organization defaults and email only", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockChannelRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).Return([]*notification_channel.Setting{
+			{OrganizationID: orgID, ProjectID: &p.ID, Event: string(events.CardCreated), SlackWebhookURL: &webhook, SlackChannel: &channel},
+		}, nil)
+		mockChannelRepo.EXPECT().GetOrgDefaults(gomock.Any(), orgID).Return([]*notification_channel.Setting{
+			{OrganizationID: orgID, Event: string(events.CardCreated), Email: true},
+			{OrganizationID: orgID, Event: string(events.CardUpdated), Email: false},
+		}, nil)
+
+		routes, err := svc.GetProjectChannelRoutes(ctx, p.ID)
+		require.NoError(t, err)
+		require.Len(t, routes, len(SupportedEvents))
+
+		created := routeFor(routes, events.CardCreated)
+		assert.False(t, created.Email)
+		assert.Equal(t, &webhook, created.SlackWebhookURL)
+		assert.Equal(t, &channel, created.SlackChannel)
+		assert.False(t, created.Inherited)
+
+		updated := routeFor(routes, events.CardUpdated)
+		assert.False(t, updated.Email)
+		assert.True(t, updated.Inherited)
+
+		moved := routeFor(routes, events.CardMoved)
+		assert.True(t, moved.Email)
+		assert.Nil(t, moved.SlackWebhookURL)
+		assert.True(t, moved.Inherited)
+	})
+
+	t.Run("replaces the project's overrides", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil).Times(2)
+		mockChannelRepo.EXPECT().DeleteByProjectID(gomock.Any(), p.ID).Return(nil)
+		var created []*notification_channel.Setting
+		mockChannelRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *notification_channel.Setting) error {
+			created = append(created, s)
+			return nil
+		}).Times(2)
+		mockChannelRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).DoAndReturn(func(ctx context.Context, projectID uuid.UUID) ([]*notification_channel.Setting, error) {
+			return created, nil
+		})
+		mockChannelRepo.EXPECT().GetOrgDefaults(gomock.Any(), orgID).Return(nil, nil)
+
+		routes, err := svc.SetProjectChannelRoutes(ctx, p.ID, []ChannelRouteInput{
+			{Event: events.CardSLABreached, Email: true, SlackWebhookURL: &webhook, SlackChannel: &channel},
+			{Event: events.CardDeleted},
+		})
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.Equal(t, orgID, created[0].OrganizationID)
+		assert.Equal(t, &p.ID, created[0].ProjectID)
+
+		deleted := routeFor(routes, events.CardDeleted)
+		assert.False(t, deleted.Email)
+		assert.Nil(t, deleted.SlackWebhookURL)
+		assert.False(t, deleted.Inherited)
+		assert.Equal(t, &webhook, routeFor(routes, events.CardSLABreached).SlackWebhookURL)
+	})
+
+	t.Run("fail - invalid settings", func(t *testing.T) {
+		notSlack := "https://example.com/hook"
+		tests := []struct {
+			name   string
+			inputs []ChannelRouteInput
+			err    error
+		}{
+			{"unsupported event", []ChannelRouteInput{{Event: events.Name("board.created")}}, ErrUnsupportedChannelEvent},
+			{"duplicate event", []ChannelRouteInput{{Event: events.CardMoved}, {Event: events.CardMoved}}, ErrDuplicateChannelEvent},
+			{"not a slack webhook", []ChannelRouteInput{{Event: events.CardMoved, SlackWebhookURL: &notSlack}}, ErrInvalidSlackWebhook},
+			{"channel without webhook", []ChannelRouteInput{{Event: events.CardMoved, SlackChannel: &channel}}, ErrSlackChannelWithoutWebhook},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.SetProjectChannelRoutes(ctx, p.ID, tt.inputs)
+				assert.ErrorIs(t, err, tt.err)
+			})
+		}
+	})
+}
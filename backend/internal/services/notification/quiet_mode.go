@@ -0,0 +1,44 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// MaxBatchMinutes caps a board's quiet mode window
+const MaxBatchMinutes = 240
+
+var (
+	ErrBoardNotFound       = errors.New("board not found")
+	ErrInvalidBatchMinutes = fmt.Errorf("quiet mode minutes must be between 1 and %d", MaxBatchMinutes)
+)
+
+func (s *service) SetBoardQuietMode(ctx context.Context, boardID uuid.UUID, minutes *int) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetBoardQuietMode")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	if minutes != nil && (*minutes < 1 || *minutes > MaxBatchMinutes) {
+		return nil, ErrInvalidBatchMinutes
+	}
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	b.NotificationBatchMinutes = minutes
+
+	if err := s.boardRepo.Update(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,326 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"gorm.io/gorm"
+)
+
+// RuleNotifier delivers card events through the channels the project routes them to. For
+// email it evaluates users' notification rules and emails the owners of matching rules;
+// users are not notified about their own actions, nor about projects they can no longer
+// view. A Slack webhook gets every routed event in the project. On boards in quiet mode
+// card notifications are queued for BatchFlusher instead of sent.
+type RuleNotifier struct {
+	ruleRepo    notification_rule.Repository
+	channelRepo notification_channel.Repository
+	batchRepo   notification_batch.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	projectRepo project.Repository
+	cardRepo    card.Repository
+	cardTagRepo card_tag.Repository
+	userRepo    user.Repository
+	rbacSvc     rbac.Service
+	mailSvc     mail.MailService
+	localeSvc   locale.Service
+	brandingSvc branding.Service
+	slack       SlackPoster
+}
+
+func NewRuleNotifier(
+	ruleRepo notification_rule.Repository,
+	channelRepo notification_channel.Repository,
+	batchRepo notification_batch.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	projectRepo project.Repository,
+	cardRepo card.Repository,
+	cardTagRepo card_tag.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+	brandingSvc branding.Service,
+	slack SlackPoster,
+) *RuleNotifier {
+	return &RuleNotifier{
+		ruleRepo:    ruleRepo,
+		channelRepo: channelRepo,
+		batchRepo:   batchRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		projectRepo: projectRepo,
+		cardRepo:    cardRepo,
+		cardTagRepo: cardTagRepo,
+		userRepo:    userRepo,
+		rbacSvc:     rbacSvc,
+		mailSvc:     mailSvc,
+		localeSvc:   localeSvc,
+		brandingSvc: brandingSvc,
+		slack:       slack,
+	}
+}
+
+// Subscribe registers the rule handler for every event rules can watch
+func (n *RuleNotifier) Subscribe(bus events.Bus) {
+	for name := range SupportedEvents {
+		bus.Subscribe(name, n.handleEvent)
+	}
+}
+
+// subject is the card an event is about, as far as rule conditions are concerned
+type subject struct {
+	cardID  uuid.UUID
+	boardID uuid.UUID
+	// columnID is the card's column, or the destination column of a move
+	columnID uuid.UUID
+	// card is nil when the card no longer exists
+	card   *card.Card
+	tagIDs map[uuid.UUID]bool
+}
+
+func subjectOf(event events.Event) (*subject, error) {
+	switch payload := event.Payload.(type) {
+	case events.CardPayload:
+		return &subject{cardID: payload.CardID, boardID: payload.BoardID, columnID: payload.ColumnID}, nil
+	case events.CardMovedPayload:
+		return &subject{cardID: payload.CardID, boardID: payload.BoardID, columnID: payload.ToColumnID}, nil
+	case events.SLABreachedPayload:
+		return &subject{cardID: payload.CardID, boardID: payload.BoardID}, nil
+	default:
+		return nil, fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+}
+
+func (n *RuleNotifier) handleEvent(ctx context.Context, event events.Event) error {
+	subj, err := subjectOf(event)
+	if err != nil {
+		return err
+	}
+
+	b, err := n.boardRepo.GetByID(ctx, subj.boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	setting, err := n.channelRepo.GetEffective(ctx, b.ProjectID, string(event.Name))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	// Without a setting, events are emailed and kept out of Slack
+	sendEmail := setting == nil || setting.Email
+	postSlack := setting != nil && setting.SlackWebhookURL != nil
+
+	var rules []*notification_rule.NotificationRule
+	if sendEmail {
+		rules, err = n.ruleRepo.GetEnabledByProjectAndEvent(ctx, b.ProjectID, string(event.Name))
+		if err != nil {
+			return err
+		}
+	}
+	if len(rules) == 0 && !postSlack {
+		return nil
+	}
+
+	if err := n.loadCard(ctx, subj); err != nil {
+		return err
+	}
+
+	quiet := b.NotificationBatchMinutes != nil && BatchedEvents[event.Name]
+
+	if postSlack {
+		if err := n.postToSlack(ctx, event, b.ProjectID, setting, subj, quiet); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range rules {
+		if event.ActorID != nil && *event.ActorID == rule.UserID {
+			continue
+		}
+		if !matches(rule, subj) {
+			continue
+		}
+		if err := n.deliver(ctx, event, rule, subj, quiet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCard fills in the card and its tags unless the card has since been deleted
+func (n *RuleNotifier) loadCard(ctx context.Context, subj *subject) error {
+	c, err := n.cardRepo.GetByID(ctx, subj.cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	subj.card = c
+	if subj.columnID == uuid.Nil {
+		subj.columnID = c.ColumnID
+	}
+
+	cardTags, err := n.cardTagRepo.GetByCardID(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	subj.tagIDs = make(map[uuid.UUID]bool, len(cardTags))
+	for _, ct := range cardTags {
+		subj.tagIDs[ct.TagID] = true
+	}
+	return nil
+}
+
+// matches reports whether the card satisfies every condition of the rule. Priority and tag
+// conditions never match a deleted card.
+func matches(rule *notification_rule.NotificationRule, subj *subject) bool {
+	if rule.ColumnID != nil && *rule.ColumnID != subj.columnID {
+		return false
+	}
+	if rule.Priority != nil && (subj.card == nil || subj.card.Priority != *rule.Priority) {
+		return false
+	}
+	if rule.TagID != nil && !subj.tagIDs[*rule.TagID] {
+		return false
+	}
+	return true
+}
+
+// deliver notifies the rule's owner once per event, or queues the notification when the
+// board is quiet
+func (n *RuleNotifier) deliver(ctx context.Context, event events.Event, rule *notification_rule.NotificationRule, subj *subject, quiet bool) error {
+	delivered, err := n.ruleRepo.IsDelivered(ctx, rule.ID, event.ID)
+	if err != nil || delivered {
+		return err
+	}
+
+	canView, err := n.rbacSvc.HasProjectPermission(ctx, rule.UserID, rule.ProjectID, "project:view")
+	if err != nil {
+		return err
+	}
+
+	owner, err := n.userRepo.GetByID(ctx, rule.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if canView && owner != nil && owner.Email != nil {
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, owner, rule.ProjectID))
+		message, err := n.describe(ctx, event, rule.ProjectID, subj)
+		if err != nil {
+			return err
+		}
+		if quiet {
+			err = n.batchRepo.Enqueue(ctx, &notification_batch.Item{
+				BoardID:  subj.boardID,
+				RuleID:   &rule.ID,
+				EventID:  event.ID,
+				Message:  message,
+				QueuedAt: event.OccurredAt,
+			})
+		} else {
+			err = sendRuleMail(ctx, n.mailSvc, n.brandingSvc, owner, rule, message, message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return n.ruleRepo.MarkDelivered(ctx, rule.ID, event.ID)
+}
+
+// postToSlack posts the event to the setting's webhook once per event, in the project's
+// organization locale, or queues the post when the board is quiet
+func (n *RuleNotifier) postToSlack(ctx context.Context, event events.Event, projectID uuid.UUID, setting *notification_channel.Setting, subj *subject, quiet bool) error {
+	delivered, err := n.channelRepo.IsSlackDelivered(ctx, setting.ID, event.ID)
+	if err != nil || delivered {
+		return err
+	}
+
+	ctx = i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, nil, projectID))
+	message, err := n.describe(ctx, event, projectID, subj)
+	if err != nil {
+		return err
+	}
+	var channel string
+	if setting.SlackChannel != nil {
+		channel = *setting.SlackChannel
+	}
+	if quiet {
+		err = n.batchRepo.Enqueue(ctx, &notification_batch.Item{
+			BoardID:         subj.boardID,
+			SlackWebhookURL: setting.SlackWebhookURL,
+			SlackChannel:    channel,
+			EventID:         event.ID,
+			Message:         message,
+			QueuedAt:        event.OccurredAt,
+		})
+	} else {
+		err = n.slack.Post(ctx, *setting.SlackWebhookURL, channel, message)
+	}
+	if err != nil {
+		return err
+	}
+
+	return n.channelRepo.MarkSlackDelivered(ctx, setting.ID, event.ID)
+}
+
+// describe renders a one-line summary of the event for the notification, in the
+// context's locale
+func (n *RuleNotifier) describe(ctx context.Context, event events.Event, projectID uuid.UUID, subj *subject) (string, error) {
+	projectName := i18n.Tc(ctx, "notification.unknown_project", nil)
+	if p, err := n.projectRepo.GetByID(ctx, projectID); err == nil {
+		projectName = p.Name
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	title := i18n.Tc(ctx, "notification.deleted_card", nil)
+	if subj.card != nil {
+		title = i18n.Tc(ctx, "notification.card", map[string]string{"title": subj.card.Title})
+	}
+	args := map[string]string{"card": title, "project": projectName}
+
+	switch event.Name {
+	case events.CardCreated:
+		return i18n.Tc(ctx, "notification.card_created", args), nil
+	case events.CardUpdated:
+		return i18n.Tc(ctx, "notification.card_updated", args), nil
+	case events.CardMoved:
+		if col, err := n.columnRepo.GetByID(ctx, subj.columnID); err == nil {
+			args["column"] = col.Name
+			return i18n.Tc(ctx, "notification.card_moved_to", args), nil
+		}
+		return i18n.Tc(ctx, "notification.card_moved", args), nil
+	case events.CardDeleted:
+		return i18n.Tc(ctx, "notification.card_deleted", args), nil
+	case events.CardSLABreached:
+		return i18n.Tc(ctx, "notification.card_sla_breached", args), nil
+	default:
+		return i18n.Tc(ctx, "notification.card_changed", args), nil
+	}
+}
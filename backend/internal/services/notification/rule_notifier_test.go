@@ -0,0 +1,343 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch"
+	batchMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_batch/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	channelMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule"
+	ruleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_rule/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	brandingMocks "github.com/thatcatdev/kaimu/backend/internal/services/branding/mocks"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// noBranding returns a branding service that leaves mail unbranded
+func noBranding(ctrl *gomock.Controller) *brandingMocks.MockService {
+	svc := brandingMocks.NewMockService(ctrl)
+	svc.EXPECT().ForProject(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return svc
+}
+
+type slackPost struct {
+	webhookURL string
+	channel    string
+	text       string
+}
+
+type mockSlackPoster struct {
+	posts []slackPost
+}
+
+func (m *mockSlackPoster) Post(ctx context.Context, webhookURL, channel, text string) error {
+	m.posts = append(m.posts, slackPost{webhookURL: webhookURL, channel: channel, text: text})
+	return nil
+}
+
+func TestMatches(t *testing.T) {
+	columnID := uuid.New()
+	tagID := uuid.New()
+	urgent := card.PriorityUrgent
+
+	subj := &subject{
+		columnID: columnID,
+		card:     &card.Card{Priority: card.PriorityUrgent},
+		tagIDs:   map[uuid.UUID]bool{tagID: true},
+	}
+	deleted := &subject{columnID: columnID}
+
+	tests := []struct {
+		name string
+		rule *notification_rule.NotificationRule
+		subj *subject
+		want bool
+	}{
+		{"no conditions", &notification_rule.NotificationRule{}, subj, true},
+		{"all conditions met", &notification_rule.NotificationRule{ColumnID: &columnID, TagID: &tagID, Priority: &urgent}, subj, true},
+		{"other column", &notification_rule.NotificationRule{ColumnID: ptr(uuid.New())}, subj, false},
+		{"missing tag", &notification_rule.NotificationRule{TagID: ptr(uuid.New())}, subj, false},
+		{"other priority", &notification_rule.NotificationRule{Priority: ptr(card.PriorityLow)}, subj, false},
+		{"deleted card matches column", &notification_rule.NotificationRule{ColumnID: &columnID}, deleted, true},
+		{"deleted card never matches tag", &notification_rule.NotificationRule{TagID: &tagID}, deleted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matches(tt.rule, tt.subj))
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRuleNotifier(t *testing.T) {
+	projectID := uuid.New()
+	boardID := uuid.New()
+	columnID := uuid.New()
+	securityTag := uuid.New()
+	email := "watcher@example.com"
+	watcher := &user.User{ID: uuid.New(), Username: "watcher", Email: &email}
+	c := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: columnID, Title: "Rotate keys"}
+
+	rule := &notification_rule.NotificationRule{
+		ID:        uuid.New(),
+		UserID:    watcher.ID,
+		ProjectID: projectID,
+		Name:      "Security cards",
+		Event:     string(events.CardCreated),
+		TagID:     &securityTag,
+		Enabled:   true,
+	}
+
+	type deps struct {
+		ruleRepo    *ruleMocks.MockRepository
+		channelRepo *channelMocks.MockRepository
+		batchRepo   *batchMocks.MockRepository
+		boardRepo   *boardMocks.MockRepository
+		projectRepo *projectMocks.MockRepository
+		cardRepo    *cardMocks.MockRepository
+		cardTagRepo *cardTagMocks.MockRepository
+		userRepo    *userMocks.MockRepository
+		rbacSvc     *rbacMocks.MockService
+		mailSvc     *fakes.MailService
+		localeSvc   *localeMocks.MockService
+		slack       *mockSlackPoster
+		bus         events.Bus
+	}
+	// setup expects the event to be routed by setting, nil meaning the built-in email only,
+	// on a board whose quiet mode is batchMinutes
+	setup := func(t *testing.T, setting *notification_channel.Setting, batchMinutes *int) deps {
+		ctrl := gomock.NewController(t)
+		d := deps{
+			ruleRepo:    ruleMocks.NewMockRepository(ctrl),
+			channelRepo: channelMocks.NewMockRepository(ctrl),
+			batchRepo:   batchMocks.NewMockRepository(ctrl),
+			boardRepo:   boardMocks.NewMockRepository(ctrl),
+			projectRepo: projectMocks.NewMockRepository(ctrl),
+			cardRepo:    cardMocks.NewMockRepository(ctrl),
+			cardTagRepo: cardTagMocks.NewMockRepository(ctrl),
+			userRepo:    userMocks.NewMockRepository(ctrl),
+			rbacSvc:     rbacMocks.NewMockService(ctrl),
+			mailSvc:     fakes.NewMailService(),
+			localeSvc:   localeMocks.NewMockService(ctrl),
+			slack:       &mockSlackPoster{},
+			bus:         events.NewSyncBus(),
+		}
+		NewRuleNotifier(d.ruleRepo, d.channelRepo, d.batchRepo, d.boardRepo, columnMocks.NewMockRepository(ctrl), d.projectRepo, d.cardRepo, d.cardTagRepo, d.userRepo, d.rbacSvc, d.mailSvc, d.localeSvc, noBranding(ctrl), d.slack).Subscribe(d.bus)
+
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID, NotificationBatchMinutes: batchMinutes}, nil)
+		if setting == nil {
+			d.channelRepo.EXPECT().GetEffective(gomock.Any(), projectID, string(events.CardCreated)).Return(nil, gorm.ErrRecordNotFound)
+		} else {
+			d.channelRepo.EXPECT().GetEffective(gomock.Any(), projectID, string(events.CardCreated)).Return(setting, nil)
+		}
+		if setting == nil || setting.Email {
+			d.ruleRepo.EXPECT().GetEnabledByProjectAndEvent(gomock.Any(), projectID, string(events.CardCreated)).Return([]*notification_rule.NotificationRule{rule}, nil)
+		}
+		if setting == nil || setting.Email || setting.SlackWebhookURL != nil {
+			d.cardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		}
+		return d
+	}
+	created := func(ctx context.Context) events.Event {
+		return events.New(ctx, events.CardCreated, events.CardPayload{CardID: c.ID, BoardID: boardID, ColumnID: columnID})
+	}
+
+	t.Run("notifies the owner of a matching rule", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+		d.ruleRepo.EXPECT().IsDelivered(gomock.Any(), rule.ID, event.ID).Return(false, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(true, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), watcher, projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		d.ruleRepo.EXPECT().MarkDelivered(gomock.Any(), rule.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+
+		require.Len(t, d.mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, d.mailSvc.Sent[0].To)
+		assert.Equal(t, `Card "Rotate keys" was created in Platform`, d.mailSvc.Sent[0].Values["message"])
+		assert.Equal(t, "Security cards", d.mailSvc.Sent[0].Values["rule_name"])
+	})
+
+	t.Run("queues the email on a board in quiet mode", func(t *testing.T) {
+		d := setup(t, nil, ptr(15))
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+		d.ruleRepo.EXPECT().IsDelivered(gomock.Any(), rule.ID, event.ID).Return(false, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(true, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), watcher, projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		d.batchRepo.EXPECT().Enqueue(gomock.Any(), &notification_batch.Item{
+			BoardID:  boardID,
+			RuleID:   &rule.ID,
+			EventID:  event.ID,
+			Message:  `Card "Rotate keys" was created in Platform`,
+			QueuedAt: event.OccurredAt,
+		}).Return(nil)
+		d.ruleRepo.EXPECT().MarkDelivered(gomock.Any(), rule.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("describes the event in the owner's locale", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+		d.ruleRepo.EXPECT().IsDelivered(gomock.Any(), rule.ID, event.ID).Return(false, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(true, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), watcher, projectID).Return("de")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		d.ruleRepo.EXPECT().MarkDelivered(gomock.Any(), rule.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+
+		require.Len(t, d.mailSvc.Sent, 1)
+		assert.Equal(t, `Karte "Rotate keys" wurde in Platform erstellt`, d.mailSvc.Sent[0].Values["message"])
+	})
+
+	t.Run("skips cards that do not match", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := context.Background()
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return(nil, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, created(ctx)))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("skips the user's own actions", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := events.WithActor(context.Background(), watcher.ID)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, created(ctx)))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("does not notify twice for a redelivered event", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+		d.ruleRepo.EXPECT().IsDelivered(gomock.Any(), rule.ID, event.ID).Return(true, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("does not notify users who lost access to the project", func(t *testing.T) {
+		d := setup(t, nil, nil)
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return([]*card_tag.CardTag{{CardID: c.ID, TagID: securityTag}}, nil)
+		d.ruleRepo.EXPECT().IsDelivered(gomock.Any(), rule.ID, event.ID).Return(false, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), watcher.ID, projectID, "project:view").Return(false, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), watcher.ID).Return(watcher, nil)
+		d.ruleRepo.EXPECT().MarkDelivered(gomock.Any(), rule.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("keeps in-app only events out of email", func(t *testing.T) {
+		d := setup(t, &notification_channel.Setting{ID: uuid.New(), Event: string(events.CardCreated), Email: false}, nil)
+		ctx := context.Background()
+
+		require.NoError(t, d.bus.Publish(ctx, created(ctx)))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	webhook := "https://hooks.slack.com/services/T000/B000/XXXX"
+	qaChannel := "#qa"
+	slackSetting := &notification_channel.Setting{ID: uuid.New(), Event: string(events.CardCreated), SlackWebhookURL: &webhook, SlackChannel: &qaChannel}
+
+	t.Run("posts routed events to slack", func(t *testing.T) {
+		d := setup(t, slackSetting, nil)
+		ctx := events.WithActor(context.Background(), watcher.ID)
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return(nil, nil)
+		d.channelRepo.EXPECT().IsSlackDelivered(gomock.Any(), slackSetting.ID, event.ID).Return(false, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), gomock.Nil(), projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		d.channelRepo.EXPECT().MarkSlackDelivered(gomock.Any(), slackSetting.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+		require.Len(t, d.slack.posts, 1)
+		assert.Equal(t, slackPost{webhookURL: webhook, channel: qaChannel, text: `Card "Rotate keys" was created in Platform`}, d.slack.posts[0])
+	})
+
+	t.Run("queues the post on a board in quiet mode", func(t *testing.T) {
+		d := setup(t, slackSetting, ptr(15))
+		ctx := events.WithActor(context.Background(), watcher.ID)
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return(nil, nil)
+		d.channelRepo.EXPECT().IsSlackDelivered(gomock.Any(), slackSetting.ID, event.ID).Return(false, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), gomock.Nil(), projectID).Return("en")
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		d.batchRepo.EXPECT().Enqueue(gomock.Any(), &notification_batch.Item{
+			BoardID:         boardID,
+			SlackWebhookURL: &webhook,
+			SlackChannel:    qaChannel,
+			EventID:         event.ID,
+			Message:         `Card "Rotate keys" was created in Platform`,
+			QueuedAt:        event.OccurredAt,
+		}).Return(nil)
+		d.channelRepo.EXPECT().MarkSlackDelivered(gomock.Any(), slackSetting.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.slack.posts)
+	})
+
+	t.Run("does not post twice for a redelivered event", func(t *testing.T) {
+		d := setup(t, slackSetting, nil)
+		ctx := context.Background()
+		event := created(ctx)
+
+		d.cardTagRepo.EXPECT().GetByCardID(gomock.Any(), c.ID).Return(nil, nil)
+		d.channelRepo.EXPECT().IsSlackDelivered(gomock.Any(), slackSetting.ID, event.ID).Return(true, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.slack.posts)
+	})
+}
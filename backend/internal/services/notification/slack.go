@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// slackTimeout bounds a webhook post, so a slow Slack doesn't hold up event handling
+const slackTimeout = 10 * time.Second
+
+// SlackPoster posts messages to Slack incoming webhooks
+type SlackPoster interface {
+	// Post sends text to the webhook, to channel when it is not empty and the webhook's
+	// own channel otherwise
+	Post(ctx context.Context, webhookURL, channel, text string) error
+}
+
+type slackPoster struct {
+	client *http.Client
+}
+
+func NewSlackPoster() SlackPoster {
+	return &slackPoster{client: &http.Client{Timeout: slackTimeout}}
+}
+
+type slackMessage struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+func (p *slackPoster) Post(ctx context.Context, webhookURL, channel, text string) error {
+	body, err := json.Marshal(slackMessage{Text: text, Channel: channel})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post to slack: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackPoster(t *testing.T) {
+	t.Run("posts the text to the channel", func(t *testing.T) {
+		var got slackMessage
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		}))
+		defer server.Close()
+
+		require.NoError(t, NewSlackPoster().Post(context.Background(), server.URL, "#qa", "Card created"))
+		assert.Equal(t, slackMessage{Text: "Card created", Channel: "#qa"}, got)
+	})
+
+	t.Run("fail - slack rejects the message", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		err := NewSlackPoster().Post(context.Background(), server.URL, "", "Card created")
+		assert.ErrorContains(t, err, "status 404")
+	})
+}
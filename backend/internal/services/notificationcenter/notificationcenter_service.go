@@ -0,0 +1,191 @@
+package notificationcenter
+
+//go:generate mockgen -source=notificationcenter_service.go -destination=mocks/notificationcenter_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidCategory      = errors.New("invalid notification category")
+)
+
+// MaxNotifications caps how many notifications a page of the notification center returns
+const MaxNotifications = 100
+
+// Service is the in-app notification center. Notifications are recorded by the Recorder
+// from domain events; users list them and mark them read, and choose per organization how
+// they want each category delivered.
+type Service interface {
+	// GetNotifications returns the user's notifications, newest first
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*notification.Notification, error)
+	// CountUnread counts the user's unread notifications
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	// MarkRead marks one of the user's notifications read. Notifications of other users fail
+	// with ErrNotificationNotFound.
+	MarkRead(ctx context.Context, userID, id uuid.UUID) (*notification.Notification, error)
+	// MarkAllRead marks all the user's notifications read and returns how many were unread
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// GetPreferences returns the user's preference for every category in the organization,
+	// the default for those never changed
+	GetPreferences(ctx context.Context, userID, orgID uuid.UUID) ([]*notification_preference.Preference, error)
+	// SetPreference changes whether the user gets the category's notifications from the
+	// organization in the app and by email; nil leaves a channel as it is
+	SetPreference(ctx context.Context, userID, orgID uuid.UUID, category notification_preference.Category, inApp, email *bool) (*notification_preference.Preference, error)
+}
+
+type service struct {
+	notificationRepo notification.Repository
+	preferenceRepo   notification_preference.Repository
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewService(notificationRepo notification.Repository, preferenceRepo notification_preference.Repository) Service {
+	return &service{notificationRepo: notificationRepo, preferenceRepo: preferenceRepo, now: time.Now}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "notificationcenter.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "notificationcenter"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*notification.Notification, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetNotifications")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	if limit <= 0 || limit > MaxNotifications {
+		limit = MaxNotifications
+	}
+	return s.notificationRepo.GetByUserID(ctx, userID, unreadOnly, limit)
+}
+
+func (s *service) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "CountUnread")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	return s.notificationRepo.CountUnread(ctx, userID)
+}
+
+func (s *service) MarkRead(ctx context.Context, userID, id uuid.UUID) (*notification.Notification, error) {
+	ctx, span := s.startServiceSpan(ctx, "MarkRead")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("notification.id", id.String()),
+	)
+	defer span.End()
+
+	n, err := s.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if n.UserID != userID {
+		return nil, ErrNotificationNotFound
+	}
+	if n.ReadAt != nil {
+		return n, nil
+	}
+
+	now := s.now()
+	if _, err := s.notificationRepo.MarkRead(ctx, userID, []uuid.UUID{id}, now); err != nil {
+		return nil, err
+	}
+	n.ReadAt = &now
+	return n, nil
+}
+
+func (s *service) MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "MarkAllRead")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	count, err := s.notificationRepo.MarkRead(ctx, userID, nil, s.now())
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (s *service) GetPreferences(ctx context.Context, userID, orgID uuid.UUID) ([]*notification_preference.Preference, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetPreferences")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+	defer span.End()
+
+	changed, err := s.preferenceRepo.GetByUser(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	byCategory := make(map[notification_preference.Category]*notification_preference.Preference, len(changed))
+	for _, p := range changed {
+		byCategory[p.Category] = p
+	}
+
+	preferences := make([]*notification_preference.Preference, len(notification_preference.Categories))
+	for i, category := range notification_preference.Categories {
+		if p, ok := byCategory[category]; ok {
+			preferences[i] = p
+		} else {
+			preferences[i] = notification_preference.Default(userID, orgID, category)
+		}
+	}
+	return preferences, nil
+}
+
+func (s *service) SetPreference(ctx context.Context, userID, orgID uuid.UUID, category notification_preference.Category, inApp, email *bool) (*notification_preference.Preference, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetPreference")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+		attribute.String("category", string(category)),
+	)
+	defer span.End()
+
+	preferences, err := s.GetPreferences(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range preferences {
+		if p.Category != category {
+			continue
+		}
+		if inApp != nil {
+			p.InApp = *inApp
+		}
+		if email != nil {
+			p.Email = *email
+		}
+		if err := s.preferenceRepo.Upsert(ctx, p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	return nil, ErrInvalidCategory
+}
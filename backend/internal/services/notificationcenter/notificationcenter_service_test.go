@@ -0,0 +1,188 @@
+package notificationcenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	notificationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	preferenceMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := notificationMocks.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("caps the page size", func(t *testing.T) {
+		mockRepo.EXPECT().GetByUserID(gomock.Any(), userID, true, MaxNotifications).Return(nil, nil)
+
+		_, err := svc.GetNotifications(ctx, userID, true, 500)
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps a smaller page size", func(t *testing.T) {
+		expected := []*notification.Notification{{ID: uuid.New(), UserID: userID}}
+		mockRepo.EXPECT().GetByUserID(gomock.Any(), userID, false, 20).Return(expected, nil)
+
+		result, err := svc.GetNotifications(ctx, userID, false, 20)
+		require.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMarkRead(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*service, *notificationMocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		mockRepo := notificationMocks.NewMockRepository(ctrl)
+		svc := NewService(mockRepo, nil).(*service)
+		svc.now = func() time.Time { return now }
+		return svc, mockRepo
+	}
+
+	t.Run("marks the user's notification read", func(t *testing.T) {
+		svc, mockRepo := setup(t)
+		n := &notification.Notification{ID: uuid.New(), UserID: userID, Kind: notification.KindMentioned}
+		mockRepo.EXPECT().GetByID(gomock.Any(), n.ID).Return(n, nil)
+		mockRepo.EXPECT().MarkRead(gomock.Any(), userID, []uuid.UUID{n.ID}, now).Return(int64(1), nil)
+
+		result, err := svc.MarkRead(ctx, userID, n.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &now, result.ReadAt)
+	})
+
+	t.Run("already read", func(t *testing.T) {
+		svc, mockRepo := setup(t)
+		readAt := now.Add(-time.Hour)
+		n := &notification.Notification{ID: uuid.New(), UserID: userID, ReadAt: &readAt}
+		mockRepo.EXPECT().GetByID(gomock.Any(), n.ID).Return(n, nil)
+
+		result, err := svc.MarkRead(ctx, userID, n.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &readAt, result.ReadAt)
+	})
+
+	t.Run("another user's notification", func(t *testing.T) {
+		svc, mockRepo := setup(t)
+		n := &notification.Notification{ID: uuid.New(), UserID: uuid.New()}
+		mockRepo.EXPECT().GetByID(gomock.Any(), n.ID).Return(n, nil)
+
+		_, err := svc.MarkRead(ctx, userID, n.ID)
+		assert.ErrorIs(t, err, ErrNotificationNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, mockRepo := setup(t)
+		id := uuid.New()
+		mockRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.MarkRead(ctx, userID, id)
+		assert.ErrorIs(t, err, ErrNotificationNotFound)
+	})
+}
+
+func TestMarkAllRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := notificationMocks.NewMockRepository(ctrl)
+	svc := NewService(mockRepo, nil)
+	userID := uuid.New()
+
+	mockRepo.EXPECT().MarkRead(gomock.Any(), userID, nil, gomock.Any()).Return(int64(3), nil)
+
+	count, err := svc.MarkAllRead(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestGetPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	preferenceRepo := preferenceMocks.NewMockRepository(ctrl)
+	svc := NewService(nil, preferenceRepo)
+	ctx := context.Background()
+	userID, orgID := uuid.New(), uuid.New()
+
+	preferenceRepo.EXPECT().GetByUser(gomock.Any(), userID, orgID).Return([]*notification_preference.Preference{
+		{UserID: userID, OrganizationID: orgID, Category: notification_preference.CategoryMentions, InApp: false, Email: true},
+	}, nil)
+
+	preferences, err := svc.GetPreferences(ctx, userID, orgID)
+	require.NoError(t, err)
+	require.Len(t, preferences, 3)
+	assert.Equal(t, notification_preference.CategoryAssignments, preferences[0].Category)
+	assert.True(t, preferences[0].InApp)
+	assert.False(t, preferences[0].Email)
+	assert.Equal(t, notification_preference.CategoryMentions, preferences[1].Category)
+	assert.False(t, preferences[1].InApp)
+	assert.True(t, preferences[1].Email)
+	assert.Equal(t, notification_preference.CategorySprints, preferences[2].Category)
+	assert.True(t, preferences[2].InApp)
+}
+
+func TestSetPreference(t *testing.T) {
+	ctx := context.Background()
+	userID, orgID := uuid.New(), uuid.New()
+	on, off := true, false
+
+	setup := func(t *testing.T) (Service, *preferenceMocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		preferenceRepo := preferenceMocks.NewMockRepository(ctrl)
+		return NewService(nil, preferenceRepo), preferenceRepo
+	}
+
+	t.Run("changes only the given channels", func(t *testing.T) {
+		svc, preferenceRepo := setup(t)
+		preferenceRepo.EXPECT().GetByUser(gomock.Any(), userID, orgID).Return(nil, nil)
+		preferenceRepo.EXPECT().Upsert(gomock.Any(), &notification_preference.Preference{
+			UserID:         userID,
+			OrganizationID: orgID,
+			Category:       notification_preference.CategorySprints,
+			InApp:          true,
+			Email:          true,
+		}).Return(nil)
+
+		p, err := svc.SetPreference(ctx, userID, orgID, notification_preference.CategorySprints, nil, &on)
+		require.NoError(t, err)
+		assert.True(t, p.InApp)
+		assert.True(t, p.Email)
+	})
+
+	t.Run("keeps an earlier change", func(t *testing.T) {
+		svc, preferenceRepo := setup(t)
+		preferenceRepo.EXPECT().GetByUser(gomock.Any(), userID, orgID).Return([]*notification_preference.Preference{
+			{UserID: userID, OrganizationID: orgID, Category: notification_preference.CategoryAssignments, InApp: true, Email: true},
+		}, nil)
+		preferenceRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+
+		p, err := svc.SetPreference(ctx, userID, orgID, notification_preference.CategoryAssignments, &off, nil)
+		require.NoError(t, err)
+		assert.False(t, p.InApp)
+		assert.True(t, p.Email)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		svc, preferenceRepo := setup(t)
+		preferenceRepo.EXPECT().GetByUser(gomock.Any(), userID, orgID).Return(nil, nil)
+
+		_, err := svc.SetPreference(ctx, userID, orgID, "digests", &on, nil)
+		assert.ErrorIs(t, err, ErrInvalidCategory)
+	})
+}
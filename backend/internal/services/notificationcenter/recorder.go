@@ -0,0 +1,350 @@
+package notificationcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/branding"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"gorm.io/gorm"
+)
+
+// Recorder turns domain events into notifications for the users they concern. The acting
+// user is never notified about their own action, and events about something deleted by
+// the time they are delivered notify nobody. Notifications are keyed by event, so
+// redelivered events notify once.
+//
+// Assignments, mentions and sprint starts follow each user's preferences for the
+// organization they come from: in the app, by email, both or neither.
+type Recorder struct {
+	notificationRepo notification.Repository
+	preferenceRepo   notification_preference.Repository
+	cardRepo         card.Repository
+	commentRepo      comment.Repository
+	invitationRepo   invitation.Repository
+	sprintRepo       sprint.Repository
+	boardRepo        board.Repository
+	projectRepo      project.Repository
+	userRepo         user.Repository
+	mailSvc          mail.MailService
+	localeSvc        locale.Service
+	brandingSvc      branding.Service
+}
+
+func NewRecorder(
+	notificationRepo notification.Repository,
+	preferenceRepo notification_preference.Repository,
+	cardRepo card.Repository,
+	commentRepo comment.Repository,
+	invitationRepo invitation.Repository,
+	sprintRepo sprint.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	userRepo user.Repository,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+	brandingSvc branding.Service,
+) *Recorder {
+	return &Recorder{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		cardRepo:         cardRepo,
+		commentRepo:      commentRepo,
+		invitationRepo:   invitationRepo,
+		sprintRepo:       sprintRepo,
+		boardRepo:        boardRepo,
+		projectRepo:      projectRepo,
+		userRepo:         userRepo,
+		mailSvc:          mailSvc,
+		localeSvc:        localeSvc,
+		brandingSvc:      brandingSvc,
+	}
+}
+
+// Subscribe registers the recorder for the events that notify users
+func (r *Recorder) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardAssigned, r.handleCardAssigned)
+	bus.Subscribe(events.CommentMentioned, r.handleCommentMentioned)
+	bus.Subscribe(events.MemberInvited, r.handleMemberInvited)
+	bus.Subscribe(events.SprintStarted, r.handleSprintStarted)
+}
+
+// message is the email text of a notification, translated for each recipient. The actor's
+// name is added to args as "actor".
+type message struct {
+	key  string
+	args map[string]string
+}
+
+func (r *Recorder) handleCardAssigned(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardAssignedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	c, err := r.cardRepo.GetByID(ctx, payload.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	b, orgID, err := r.boardOrganization(ctx, c.BoardID)
+	if err != nil {
+		return err
+	}
+	msg := message{key: "email.user_notification.card_assigned", args: map[string]string{"card": c.Title, "board": b.Name}}
+	return r.notify(ctx, event, notification.KindCardAssigned, notification_preference.CategoryAssignments, orgID, []uuid.UUID{payload.AssigneeID}, msg, func(n *notification.Notification) {
+		n.CardID = &payload.CardID
+	})
+}
+
+func (r *Recorder) handleCommentMentioned(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CommentMentionedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	if _, err := r.commentRepo.GetByID(ctx, payload.CommentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	c, err := r.cardRepo.GetByID(ctx, payload.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	b, orgID, err := r.boardOrganization(ctx, c.BoardID)
+	if err != nil {
+		return err
+	}
+	msg := message{key: "email.user_notification.mentioned", args: map[string]string{"card": c.Title, "board": b.Name}}
+	return r.notify(ctx, event, notification.KindMentioned, notification_preference.CategoryMentions, orgID, payload.UserIDs, msg, func(n *notification.Notification) {
+		n.CardID = &payload.CardID
+		n.CommentID = &payload.CommentID
+	})
+}
+
+func (r *Recorder) handleMemberInvited(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.MemberInvitedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	inv, err := r.invitationRepo.GetByID(ctx, payload.InvitationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if inv.IsAccepted() {
+		return nil
+	}
+
+	// Only invitees who already have an account can be notified in the app. They are not
+	// members yet, so there are no preferences to follow, and the invitation is emailed anyway
+	invitee, err := r.userRepo.GetByEmail(ctx, payload.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return r.record(ctx, event, notification.KindInvitationReceived, []uuid.UUID{invitee.ID}, func(n *notification.Notification) {
+		n.InvitationID = &payload.InvitationID
+	})
+}
+
+func (r *Recorder) handleSprintStarted(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.SprintStartedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	s, err := r.sprintRepo.GetByID(ctx, payload.SprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// The assignees of the sprint's cards, each once
+	cards, err := r.cardRepo.GetBySprintID(ctx, payload.SprintID)
+	if err != nil {
+		return err
+	}
+	seen := make(map[uuid.UUID]bool)
+	var userIDs []uuid.UUID
+	for _, c := range cards {
+		if c.ArchivedAt != nil || c.AssigneeID == nil || seen[*c.AssigneeID] {
+			continue
+		}
+		seen[*c.AssigneeID] = true
+		userIDs = append(userIDs, *c.AssigneeID)
+	}
+
+	b, orgID, err := r.boardOrganization(ctx, s.BoardID)
+	if err != nil {
+		return err
+	}
+	msg := message{key: "email.user_notification.sprint_started", args: map[string]string{"sprint": s.Name, "board": b.Name}}
+	return r.notify(ctx, event, notification.KindSprintStarted, notification_preference.CategorySprints, orgID, userIDs, msg, func(n *notification.Notification) {
+		n.SprintID = &payload.SprintID
+	})
+}
+
+// boardOrganization returns the board and the organization it belongs to
+func (r *Recorder) boardOrganization(ctx context.Context, boardID uuid.UUID) (*board.Board, uuid.UUID, error) {
+	b, err := r.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	p, err := r.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	return b, p.OrganizationID, nil
+}
+
+// notify delivers a notification of the category to each of the users but the event's
+// actor, in the app and by email as their preferences for the organization say
+func (r *Recorder) notify(ctx context.Context, event events.Event, kind notification.Kind, category notification_preference.Category, orgID uuid.UUID, userIDs []uuid.UUID, msg message, fill func(n *notification.Notification)) error {
+	var recipients []uuid.UUID
+	for _, userID := range userIDs {
+		if event.ActorID == nil || *event.ActorID != userID {
+			recipients = append(recipients, userID)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	preferences, err := r.preferenceRepo.GetByUsers(ctx, orgID, category, recipients)
+	if err != nil {
+		return err
+	}
+	byUser := make(map[uuid.UUID]*notification_preference.Preference, len(preferences))
+	for _, p := range preferences {
+		byUser[p.UserID] = p
+	}
+
+	var inApp, email []uuid.UUID
+	for _, userID := range recipients {
+		p, ok := byUser[userID]
+		if !ok {
+			p = notification_preference.Default(userID, orgID, category)
+		}
+		if p.InApp {
+			inApp = append(inApp, userID)
+		}
+		if p.Email {
+			email = append(email, userID)
+		}
+	}
+
+	if err := r.record(ctx, event, kind, inApp, fill); err != nil {
+		return err
+	}
+	r.email(ctx, event, orgID, email, msg)
+	return nil
+}
+
+// record notifies each of the users but the event's actor in the app, with the references
+// set by fill
+func (r *Recorder) record(ctx context.Context, event events.Event, kind notification.Kind, userIDs []uuid.UUID, fill func(n *notification.Notification)) error {
+	var notifications []*notification.Notification
+	for _, userID := range userIDs {
+		if event.ActorID != nil && *event.ActorID == userID {
+			continue
+		}
+		n := &notification.Notification{
+			UserID:    userID,
+			Kind:      kind,
+			EventID:   event.ID,
+			ActorID:   event.ActorID,
+			CreatedAt: event.OccurredAt,
+		}
+		fill(n)
+		notifications = append(notifications, n)
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.notificationRepo.CreateMany(ctx, notifications)
+}
+
+// email sends msg to each of the users with an email address, in their language. Failures
+// are logged rather than returned: redelivering the event would email the others again.
+func (r *Recorder) email(ctx context.Context, event events.Event, orgID uuid.UUID, userIDs []uuid.UUID, msg message) {
+	if r.mailSvc == nil || len(userIDs) == 0 {
+		return
+	}
+	log := logger.FromCtx(ctx)
+
+	var actorName string
+	if event.ActorID != nil {
+		if actor, err := r.userRepo.GetByID(ctx, *event.ActorID); err == nil {
+			actorName = displayName(actor)
+		}
+	}
+	if r.brandingSvc != nil {
+		ctx = mail.WithBranding(ctx, r.brandingSvc.ForOrganization(ctx, orgID))
+	}
+
+	for _, userID := range userIDs {
+		u, err := r.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load the recipient of a notification email")
+			continue
+		}
+		if u.Email == nil || *u.Email == "" {
+			continue
+		}
+
+		ctx := i18n.WithLocale(ctx, r.localeSvc.ForUser(ctx, u, orgID))
+		args := map[string]string{"actor": actorName}
+		if actorName == "" {
+			args["actor"] = i18n.Tc(ctx, "email.user_notification.someone", nil)
+		}
+		for k, v := range msg.args {
+			args[k] = v
+		}
+		text := i18n.Tc(ctx, msg.key, args)
+		err = r.mailSvc.SendMail(ctx, []string{*u.Email}, text, "user_notification.mjml", map[string]string{
+			"name":    displayName(u),
+			"message": text,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userID.String()).Str("event_id", event.ID.String()).Msg("Failed to send notification email")
+		}
+	}
+}
+
+// displayName is the user's display name, or their username when they have none
+func displayName(u *user.User) string {
+	if u.DisplayName != nil && *u.DisplayName != "" {
+		return *u.DisplayName
+	}
+	return u.Username
+}
@@ -0,0 +1,254 @@
+package notificationcenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	commentMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	invitationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification"
+	notificationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference"
+	preferenceMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_preference/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type recorderDeps struct {
+	notificationRepo *notificationMocks.MockRepository
+	preferenceRepo   *preferenceMocks.MockRepository
+	cardRepo         *cardMocks.MockRepository
+	commentRepo      *commentMocks.MockRepository
+	invitationRepo   *invitationMocks.MockRepository
+	sprintRepo       *sprintMocks.MockRepository
+	boardRepo        *boardMocks.MockRepository
+	projectRepo      *projectMocks.MockRepository
+	userRepo         *userMocks.MockRepository
+	localeSvc        *localeMocks.MockService
+	mailSvc          *fakes.MailService
+}
+
+func newTestRecorder(t *testing.T) (events.Bus, recorderDeps) {
+	ctrl := gomock.NewController(t)
+	d := recorderDeps{
+		notificationRepo: notificationMocks.NewMockRepository(ctrl),
+		cardRepo:         cardMocks.NewMockRepository(ctrl),
+		commentRepo:      commentMocks.NewMockRepository(ctrl),
+		invitationRepo:   invitationMocks.NewMockRepository(ctrl),
+		preferenceRepo:   preferenceMocks.NewMockRepository(ctrl),
+		sprintRepo:       sprintMocks.NewMockRepository(ctrl),
+		boardRepo:        boardMocks.NewMockRepository(ctrl),
+		projectRepo:      projectMocks.NewMockRepository(ctrl),
+		userRepo:         userMocks.NewMockRepository(ctrl),
+		localeSvc:        localeMocks.NewMockService(ctrl),
+		mailSvc:          fakes.NewMailService(),
+	}
+	bus := events.NewSyncBus()
+	NewRecorder(d.notificationRepo, d.preferenceRepo, d.cardRepo, d.commentRepo, d.invitationRepo, d.sprintRepo, d.boardRepo, d.projectRepo, d.userRepo, d.mailSvc, d.localeSvc, nil).Subscribe(bus)
+	return bus, d
+}
+
+// expectBoard expects the board to be looked up with its project, and returns the
+// organization they belong to
+func expectBoard(d recorderDeps, b *board.Board) uuid.UUID {
+	orgID := uuid.New()
+	d.boardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+	d.projectRepo.EXPECT().GetByID(gomock.Any(), b.ProjectID).Return(&project.Project{ID: b.ProjectID, OrganizationID: orgID}, nil)
+	return orgID
+}
+
+func newEvent(name events.Name, actorID *uuid.UUID, payload any) events.Event {
+	return events.Event{
+		ID:         uuid.New(),
+		Name:       name,
+		OccurredAt: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC),
+		ActorID:    actorID,
+		Payload:    payload,
+	}
+}
+
+func TestRecorderCardAssigned(t *testing.T) {
+	ctx := context.Background()
+	actorID := uuid.New()
+	cardID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New(), Name: "Platform"}
+
+	t.Run("notifies the assignee", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		assigneeID := uuid.New()
+		event := newEvent(events.CardAssigned, &actorID, events.CardAssignedPayload{CardID: cardID, AssigneeID: assigneeID})
+		d.cardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(&card.Card{ID: cardID, BoardID: b.ID}, nil)
+		orgID := expectBoard(d, b)
+		d.preferenceRepo.EXPECT().GetByUsers(gomock.Any(), orgID, notification_preference.CategoryAssignments, []uuid.UUID{assigneeID}).Return(nil, nil)
+		d.notificationRepo.EXPECT().CreateMany(gomock.Any(), []*notification.Notification{{
+			UserID:    assigneeID,
+			Kind:      notification.KindCardAssigned,
+			EventID:   event.ID,
+			ActorID:   &actorID,
+			CardID:    &cardID,
+			CreatedAt: event.OccurredAt,
+		}}).Return(nil)
+
+		require.NoError(t, bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("emails an assignee who asked for email only", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		email := "alice@example.com"
+		alice := &user.User{ID: uuid.New(), Username: "alice", Email: &email}
+		actorName := "Bob"
+		actor := &user.User{ID: actorID, Username: "bob", DisplayName: &actorName}
+		event := newEvent(events.CardAssigned, &actorID, events.CardAssignedPayload{CardID: cardID, AssigneeID: alice.ID})
+		d.cardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(&card.Card{ID: cardID, BoardID: b.ID, Title: "Fix login"}, nil)
+		orgID := expectBoard(d, b)
+		d.preferenceRepo.EXPECT().GetByUsers(gomock.Any(), orgID, notification_preference.CategoryAssignments, []uuid.UUID{alice.ID}).Return([]*notification_preference.Preference{{
+			UserID:         alice.ID,
+			OrganizationID: orgID,
+			Category:       notification_preference.CategoryAssignments,
+			InApp:          false,
+			Email:          true,
+		}}, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), actorID).Return(actor, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), alice.ID).Return(alice, nil)
+		d.localeSvc.EXPECT().ForUser(gomock.Any(), alice, orgID).Return("en")
+
+		require.NoError(t, bus.Publish(ctx, event))
+		require.Len(t, d.mailSvc.Sent, 1)
+		sent := d.mailSvc.Sent[0]
+		assert.Equal(t, []string{email}, sent.To)
+		assert.Equal(t, "user_notification.mjml", sent.Template)
+		assert.Equal(t, "alice", sent.Values["name"])
+		assert.Contains(t, sent.Values["message"], "Bob")
+		assert.Contains(t, sent.Values["message"], "Fix login")
+		assert.Contains(t, sent.Values["message"], "Platform")
+	})
+
+	t.Run("skips people assigning themselves", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		event := newEvent(events.CardAssigned, &actorID, events.CardAssignedPayload{CardID: cardID, AssigneeID: actorID})
+		d.cardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(&card.Card{ID: cardID, BoardID: b.ID}, nil)
+		expectBoard(d, b)
+
+		require.NoError(t, bus.Publish(ctx, event))
+	})
+
+	t.Run("card deleted since", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		event := newEvent(events.CardAssigned, &actorID, events.CardAssignedPayload{CardID: cardID, AssigneeID: uuid.New()})
+		d.cardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, bus.Publish(ctx, event))
+	})
+}
+
+func TestRecorderCommentMentioned(t *testing.T) {
+	bus, d := newTestRecorder(t)
+	actorID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+	payload := events.CommentMentionedPayload{CommentID: uuid.New(), CardID: uuid.New(), BoardID: b.ID, UserIDs: []uuid.UUID{uuid.New(), uuid.New()}}
+	event := newEvent(events.CommentMentioned, &actorID, payload)
+
+	d.commentRepo.EXPECT().GetByID(gomock.Any(), payload.CommentID).Return(&comment.Comment{ID: payload.CommentID}, nil)
+	d.cardRepo.EXPECT().GetByID(gomock.Any(), payload.CardID).Return(&card.Card{ID: payload.CardID, BoardID: b.ID}, nil)
+	orgID := expectBoard(d, b)
+	d.preferenceRepo.EXPECT().GetByUsers(gomock.Any(), orgID, notification_preference.CategoryMentions, payload.UserIDs).Return(nil, nil)
+	d.notificationRepo.EXPECT().CreateMany(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notifications []*notification.Notification) error {
+		require.Len(t, notifications, 2)
+		for i, n := range notifications {
+			assert.Equal(t, payload.UserIDs[i], n.UserID)
+			assert.Equal(t, notification.KindMentioned, n.Kind)
+			assert.Equal(t, &payload.CardID, n.CardID)
+			assert.Equal(t, &payload.CommentID, n.CommentID)
+		}
+		return nil
+	})
+
+	require.NoError(t, bus.Publish(context.Background(), event))
+}
+
+func TestRecorderMemberInvited(t *testing.T) {
+	ctx := context.Background()
+	inviterID := uuid.New()
+	invitationID := uuid.New()
+	payload := events.MemberInvitedPayload{InvitationID: invitationID, Email: "alice@example.com"}
+
+	t.Run("notifies an invitee with an account", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		alice := &user.User{ID: uuid.New(), Username: "alice"}
+		d.invitationRepo.EXPECT().GetByID(gomock.Any(), invitationID).Return(&invitation.Invitation{ID: invitationID}, nil)
+		d.userRepo.EXPECT().GetByEmail(gomock.Any(), "alice@example.com").Return(alice, nil)
+		d.notificationRepo.EXPECT().CreateMany(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notifications []*notification.Notification) error {
+			require.Len(t, notifications, 1)
+			assert.Equal(t, alice.ID, notifications[0].UserID)
+			assert.Equal(t, notification.KindInvitationReceived, notifications[0].Kind)
+			assert.Equal(t, &invitationID, notifications[0].InvitationID)
+			return nil
+		})
+
+		require.NoError(t, bus.Publish(ctx, newEvent(events.MemberInvited, &inviterID, payload)))
+	})
+
+	t.Run("invitee without an account", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		d.invitationRepo.EXPECT().GetByID(gomock.Any(), invitationID).Return(&invitation.Invitation{ID: invitationID}, nil)
+		d.userRepo.EXPECT().GetByEmail(gomock.Any(), "alice@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, bus.Publish(ctx, newEvent(events.MemberInvited, &inviterID, payload)))
+	})
+
+	t.Run("invitation cancelled since", func(t *testing.T) {
+		bus, d := newTestRecorder(t)
+		d.invitationRepo.EXPECT().GetByID(gomock.Any(), invitationID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, bus.Publish(ctx, newEvent(events.MemberInvited, &inviterID, payload)))
+	})
+}
+
+func TestRecorderSprintStarted(t *testing.T) {
+	bus, d := newTestRecorder(t)
+	actorID := uuid.New()
+	alice, bob := uuid.New(), uuid.New()
+	archivedAt := time.Now()
+	sprintID := uuid.New()
+	b := &board.Board{ID: uuid.New(), ProjectID: uuid.New()}
+
+	d.sprintRepo.EXPECT().GetByID(gomock.Any(), sprintID).Return(&sprint.Sprint{ID: sprintID, BoardID: b.ID}, nil)
+	d.cardRepo.EXPECT().GetBySprintID(gomock.Any(), sprintID).Return([]*card.Card{
+		{ID: uuid.New(), AssigneeID: &alice},
+		{ID: uuid.New(), AssigneeID: &alice},
+		{ID: uuid.New()},
+		{ID: uuid.New(), AssigneeID: &actorID},
+		{ID: uuid.New(), AssigneeID: &bob, ArchivedAt: &archivedAt},
+	}, nil)
+	orgID := expectBoard(d, b)
+	d.preferenceRepo.EXPECT().GetByUsers(gomock.Any(), orgID, notification_preference.CategorySprints, []uuid.UUID{alice}).Return(nil, nil)
+	d.notificationRepo.EXPECT().CreateMany(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notifications []*notification.Notification) error {
+		require.Len(t, notifications, 1)
+		assert.Equal(t, alice, notifications[0].UserID)
+		assert.Equal(t, notification.KindSprintStarted, notifications[0].Kind)
+		assert.Equal(t, &sprintID, notifications[0].SprintID)
+		return nil
+	})
+
+	require.NoError(t, bus.Publish(context.Background(), newEvent(events.SprintStarted, &actorID, events.SprintStartedPayload{SprintID: sprintID})))
+}
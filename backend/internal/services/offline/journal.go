@@ -0,0 +1,83 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"gorm.io/gorm"
+)
+
+// Journal records card and board events in the sync journal. Recording is keyed by event,
+// so redelivered events don't add duplicate changes.
+type Journal struct {
+	changeRepo sync_change.Repository
+	columnRepo board_column.Repository
+}
+
+func NewJournal(changeRepo sync_change.Repository, columnRepo board_column.Repository) *Journal {
+	return &Journal{changeRepo: changeRepo, columnRepo: columnRepo}
+}
+
+// Subscribe registers the journal for the events offline clients need to see
+func (j *Journal) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardCreated, j.handleCard)
+	bus.Subscribe(events.CardUpdated, j.handleCard)
+	bus.Subscribe(events.CardDeleted, j.handleCard)
+	bus.Subscribe(events.CardArchived, j.handleCard)
+	bus.Subscribe(events.CardMoved, j.handleCardMoved)
+	bus.Subscribe(events.BoardUpdated, j.handleBoard)
+	bus.Subscribe(events.BoardDeleted, j.handleBoard)
+}
+
+func (j *Journal) handleCard(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	// Archived cards leave the board like deleted ones; unarchiving publishes card.updated
+	deleted := event.Name == events.CardDeleted || event.Name == events.CardArchived
+	return j.record(ctx, event, payload.BoardID, sync_change.EntityCard, payload.CardID, deleted)
+}
+
+func (j *Journal) handleCardMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	// A card moved to another board leaves a tombstone on the board it came from
+	from, err := j.columnRepo.GetByID(ctx, payload.FromColumnID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if from != nil && from.BoardID != payload.BoardID {
+		if err := j.record(ctx, event, from.BoardID, sync_change.EntityCard, payload.CardID, true); err != nil {
+			return err
+		}
+	}
+
+	return j.record(ctx, event, payload.BoardID, sync_change.EntityCard, payload.CardID, false)
+}
+
+func (j *Journal) handleBoard(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.BoardPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	return j.record(ctx, event, payload.BoardID, sync_change.EntityBoard, payload.BoardID, event.Name == events.BoardDeleted)
+}
+
+func (j *Journal) record(ctx context.Context, event events.Event, boardID uuid.UUID, entityType sync_change.EntityType, entityID uuid.UUID, deleted bool) error {
+	return j.changeRepo.Record(ctx, &sync_change.Change{
+		EventID:    event.ID,
+		BoardID:    boardID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Deleted:    deleted,
+	})
+}
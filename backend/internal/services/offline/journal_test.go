@@ -0,0 +1,84 @@
+package offline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	changeMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJournal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	changeRepo := changeMocks.NewMockRepository(ctrl)
+	columnRepo := columnMocks.NewMockRepository(ctrl)
+	bus := events.NewSyncBus()
+	NewJournal(changeRepo, columnRepo).Subscribe(bus)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	cardID := uuid.New()
+
+	var recorded []*sync_change.Change
+	changeRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, change *sync_change.Change) error {
+		recorded = append(recorded, change)
+		return nil
+	}).AnyTimes()
+
+	t.Run("card deleted", func(t *testing.T) {
+		recorded = nil
+		event := events.New(ctx, events.CardDeleted, events.CardPayload{CardID: cardID, BoardID: boardID})
+		require.NoError(t, bus.Publish(ctx, event))
+
+		require.Len(t, recorded, 1)
+		assert.Equal(t, event.ID, recorded[0].EventID)
+		assert.Equal(t, sync_change.EntityCard, recorded[0].EntityType)
+		assert.Equal(t, cardID, recorded[0].EntityID)
+		assert.True(t, recorded[0].Deleted)
+	})
+
+	t.Run("card moved within a board", func(t *testing.T) {
+		recorded = nil
+		fromColumnID := uuid.New()
+		columnRepo.EXPECT().GetByID(gomock.Any(), fromColumnID).Return(&board_column.BoardColumn{ID: fromColumnID, BoardID: boardID}, nil)
+
+		require.NoError(t, bus.Publish(ctx, events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: cardID, BoardID: boardID, FromColumnID: fromColumnID})))
+
+		require.Len(t, recorded, 1)
+		assert.Equal(t, boardID, recorded[0].BoardID)
+		assert.False(t, recorded[0].Deleted)
+	})
+
+	t.Run("card moved to another board", func(t *testing.T) {
+		recorded = nil
+		fromColumnID := uuid.New()
+		fromBoardID := uuid.New()
+		columnRepo.EXPECT().GetByID(gomock.Any(), fromColumnID).Return(&board_column.BoardColumn{ID: fromColumnID, BoardID: fromBoardID}, nil)
+
+		require.NoError(t, bus.Publish(ctx, events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: cardID, BoardID: boardID, FromColumnID: fromColumnID})))
+
+		require.Len(t, recorded, 2)
+		assert.Equal(t, fromBoardID, recorded[0].BoardID)
+		assert.True(t, recorded[0].Deleted)
+		assert.Equal(t, boardID, recorded[1].BoardID)
+		assert.False(t, recorded[1].Deleted)
+	})
+
+	t.Run("board updated", func(t *testing.T) {
+		recorded = nil
+		require.NoError(t, bus.Publish(ctx, events.New(ctx, events.BoardUpdated, events.BoardPayload{BoardID: boardID})))
+
+		require.Len(t, recorded, 1)
+		assert.Equal(t, sync_change.EntityBoard, recorded[0].EntityType)
+		assert.Equal(t, boardID, recorded[0].EntityID)
+	})
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: offline_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=offline_service.go -destination=mocks/offline_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	sync_mutation "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_mutation"
+	offline "github.com/thatcatdev/kaimu/backend/internal/services/offline"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckConflict mocks base method.
+func (m *MockService) CheckConflict(ctx context.Context, kind offline.MutationKind, cardID uuid.UUID, base *time.Time, strategy offline.ConflictStrategy) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConflict", ctx, kind, cardID, base, strategy)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckConflict indicates an expected call of CheckConflict.
+func (mr *MockServiceMockRecorder) CheckConflict(ctx, kind, cardID, base, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConflict", reflect.TypeOf((*MockService)(nil).CheckConflict), ctx, kind, cardID, base, strategy)
+}
+
+// GetAppliedMutation mocks base method.
+func (m *MockService) GetAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string) (*sync_mutation.AppliedMutation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppliedMutation", ctx, userID, clientMutationID)
+	ret0, _ := ret[0].(*sync_mutation.AppliedMutation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppliedMutation indicates an expected call of GetAppliedMutation.
+func (mr *MockServiceMockRecorder) GetAppliedMutation(ctx, userID, clientMutationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppliedMutation", reflect.TypeOf((*MockService)(nil).GetAppliedMutation), ctx, userID, clientMutationID)
+}
+
+// GetBoardChanges mocks base method.
+func (m *MockService) GetBoardChanges(ctx context.Context, boardID uuid.UUID, cursor *int64, limit int) (*offline.ChangeSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardChanges", ctx, boardID, cursor, limit)
+	ret0, _ := ret[0].(*offline.ChangeSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardChanges indicates an expected call of GetBoardChanges.
+func (mr *MockServiceMockRecorder) GetBoardChanges(ctx, boardID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardChanges", reflect.TypeOf((*MockService)(nil).GetBoardChanges), ctx, boardID, cursor, limit)
+}
+
+// RecordAppliedMutation mocks base method.
+func (m *MockService) RecordAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string, cardID *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAppliedMutation", ctx, userID, clientMutationID, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAppliedMutation indicates an expected call of RecordAppliedMutation.
+func (mr *MockServiceMockRecorder) RecordAppliedMutation(ctx, userID, clientMutationID, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAppliedMutation", reflect.TypeOf((*MockService)(nil).RecordAppliedMutation), ctx, userID, clientMutationID, cardID)
+}
@@ -0,0 +1,308 @@
+package offline
+
+//go:generate mockgen -source=offline_service.go -destination=mocks/offline_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_mutation"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBoardNotFound = errors.New("board not found")
+	ErrCardDeleted   = errors.New("card was deleted")
+	ErrConflict      = errors.New("card changed since the client last synced it")
+)
+
+const (
+	// DefaultChangeLimit is how many journal entries GetBoardChanges reads when no limit is given
+	DefaultChangeLimit = 500
+	// MaxChangeLimit caps the journal entries read by one GetBoardChanges call
+	MaxChangeLimit = 1000
+	// SettleWindow delays when changes become visible to sync. Journal rows are numbered
+	// when inserted but become visible when committed; waiting this long means a row with
+	// a lower seq can't commit after a client has already moved its cursor past it.
+	SettleWindow = 2 * time.Second
+)
+
+// MutationKind is the kind of queued offline mutation
+type MutationKind string
+
+const (
+	MutationCreate MutationKind = "create"
+	MutationUpdate MutationKind = "update"
+	MutationMove   MutationKind = "move"
+	MutationDelete MutationKind = "delete"
+)
+
+// ConflictStrategy decides what happens when a card changed on the server after the client
+// last synced it
+type ConflictStrategy string
+
+const (
+	// ServerWins rejects the offline mutation and returns the server's card
+	ServerWins ConflictStrategy = "server_wins"
+	// ClientWins applies the offline mutation over the server's changes
+	ClientWins ConflictStrategy = "client_wins"
+)
+
+// Tombstone tells the client to drop an entity it holds
+type Tombstone struct {
+	EntityType sync_change.EntityType
+	EntityID   uuid.UUID
+	DeletedAt  time.Time
+}
+
+// ChangeSet is what changed on a board since a sync cursor
+type ChangeSet struct {
+	// Board is set on a full sync or when the board itself changed
+	Board *board.Board
+	// Columns always holds every column of the board
+	Columns    []*board_column.BoardColumn
+	Cards      []*card.Card
+	Tombstones []Tombstone
+	// Cursor is passed to the next GetBoardChanges call
+	Cursor int64
+	// HasMore is set when more changes are waiting after Cursor
+	HasMore bool
+}
+
+type Service interface {
+	// GetBoardChanges returns the board's cards changed after cursor, or everything on the
+	// board together with the current cursor when cursor is nil
+	GetBoardChanges(ctx context.Context, boardID uuid.UUID, cursor *int64, limit int) (*ChangeSet, error)
+	// CheckConflict applies the conflict rules to an offline mutation of cardID, given the
+	// card's updatedAt when the client last synced it. It returns the card's current state,
+	// or nil with a nil error when deleting a card that is already gone. Without a base,
+	// the mutation always applies.
+	CheckConflict(ctx context.Context, kind MutationKind, cardID uuid.UUID, base *time.Time, strategy ConflictStrategy) (*card.Card, error)
+	// GetAppliedMutation returns the user's applied mutation with the client's ID, or nil
+	GetAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string) (*sync_mutation.AppliedMutation, error)
+	// RecordAppliedMutation remembers that a mutation was applied so replays are skipped
+	RecordAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string, cardID *uuid.UUID) error
+}
+
+type service struct {
+	changeRepo   sync_change.Repository
+	mutationRepo sync_mutation.Repository
+	boardRepo    board.Repository
+	columnRepo   board_column.Repository
+	cardRepo     card.Repository
+	now          func() time.Time
+}
+
+func NewService(
+	changeRepo sync_change.Repository,
+	mutationRepo sync_mutation.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+) Service {
+	return &service{
+		changeRepo:   changeRepo,
+		mutationRepo: mutationRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		cardRepo:     cardRepo,
+		now:          time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "offline.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "offline"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetBoardChanges(ctx context.Context, boardID uuid.UUID, cursor *int64, limit int) (*ChangeSet, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetBoardChanges")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	if limit <= 0 {
+		limit = DefaultChangeLimit
+	}
+	if limit > MaxChangeLimit {
+		limit = MaxChangeLimit
+	}
+
+	if cursor == nil {
+		return s.fullSync(ctx, boardID)
+	}
+	span.SetAttributes(attribute.Int64("sync.cursor", *cursor))
+
+	changes, err := s.changeRepo.GetSince(ctx, boardID, *cursor, s.now().Add(-SettleWindow), limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ChangeSet{Cursor: *cursor, Cards: []*card.Card{}, Tombstones: []Tombstone{}}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		set.HasMore = true
+	}
+	if len(changes) > 0 {
+		set.Cursor = changes[len(changes)-1].Seq
+	}
+
+	// Only the latest change to each entity matters
+	latest := make(map[uuid.UUID]*sync_change.Change, len(changes))
+	var order []uuid.UUID
+	for _, change := range changes {
+		if _, ok := latest[change.EntityID]; !ok {
+			order = append(order, change.EntityID)
+		}
+		latest[change.EntityID] = change
+	}
+
+	for _, entityID := range order {
+		change := latest[entityID]
+		if change.Deleted {
+			set.Tombstones = append(set.Tombstones, Tombstone{EntityType: change.EntityType, EntityID: entityID, DeletedAt: change.RecordedAt})
+			continue
+		}
+
+		switch change.EntityType {
+		case sync_change.EntityBoard:
+			b, err := s.boardRepo.GetByID(ctx, entityID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			if b == nil {
+				return nil, ErrBoardNotFound
+			}
+			set.Board = b
+		case sync_change.EntityCard:
+			c, err := s.cardRepo.GetByID(ctx, entityID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			// A later change not yet in this page may have deleted or moved the card
+			if c == nil || c.BoardID != boardID {
+				set.Tombstones = append(set.Tombstones, Tombstone{EntityType: sync_change.EntityCard, EntityID: entityID, DeletedAt: change.RecordedAt})
+				continue
+			}
+			set.Cards = append(set.Cards, c)
+		}
+	}
+
+	set.Columns, err = s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// fullSync returns everything on the board. The cursor is read first so changes made while
+// the board is read are sent again on the next sync rather than missed.
+func (s *service) fullSync(ctx context.Context, boardID uuid.UUID) (*ChangeSet, error) {
+	seq, err := s.changeRepo.GetLatestSeq(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cardRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeSet{
+		Board:      b,
+		Columns:    columns,
+		Cards:      cards,
+		Tombstones: []Tombstone{},
+		Cursor:     seq,
+	}, nil
+}
+
+func (s *service) CheckConflict(ctx context.Context, kind MutationKind, cardID uuid.UUID, base *time.Time, strategy ConflictStrategy) (*card.Card, error) {
+	ctx, span := s.startServiceSpan(ctx, "CheckConflict")
+	span.SetAttributes(
+		attribute.String("card.id", cardID.String()),
+		attribute.String("sync.mutation_kind", string(kind)),
+		attribute.String("sync.conflict_strategy", string(strategy)),
+	)
+	defer span.End()
+
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		// Deleting a card that is already gone is a no-op; anything else has nothing to apply to
+		if kind == MutationDelete {
+			return nil, nil
+		}
+		return nil, ErrCardDeleted
+	}
+
+	if base == nil || strategy == ClientWins {
+		return c, nil
+	}
+
+	// Moves only conflict with other moves, so offline edits elsewhere don't block them
+	changedAt := c.UpdatedAt
+	if kind == MutationMove {
+		changedAt = c.ColumnEnteredAt
+	}
+	if changedAt.After(*base) {
+		return c, ErrConflict
+	}
+	return c, nil
+}
+
+func (s *service) GetAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string) (*sync_mutation.AppliedMutation, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAppliedMutation")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	m, err := s.mutationRepo.Get(ctx, userID, clientMutationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *service) RecordAppliedMutation(ctx context.Context, userID uuid.UUID, clientMutationID string, cardID *uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "RecordAppliedMutation")
+	span.SetAttributes(attribute.String("user.id", userID.String()))
+	defer span.End()
+
+	return s.mutationRepo.Record(ctx, &sync_mutation.AppliedMutation{
+		UserID:           userID,
+		ClientMutationID: clientMutationID,
+		CardID:           cardID,
+	})
+}
@@ -0,0 +1,248 @@
+package offline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change"
+	changeMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_change/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_mutation"
+	mutationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sync_mutation/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGetBoardChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	mockChangeRepo := changeMocks.NewMockRepository(ctrl)
+	mockMutationRepo := mutationMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockChangeRepo, mockMutationRepo, mockBoardRepo, mockColumnRepo, mockCardRepo).(*service)
+	svc.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	columns := []*board_column.BoardColumn{{ID: uuid.New(), BoardID: boardID}}
+
+	t.Run("full sync reads the cursor before the board", func(t *testing.T) {
+		c := &card.Card{ID: uuid.New(), BoardID: boardID}
+		gomock.InOrder(
+			mockChangeRepo.EXPECT().GetLatestSeq(gomock.Any()).Return(int64(42), nil),
+			mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil),
+		)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(columns, nil)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*card.Card{c}, nil)
+
+		set, err := svc.GetBoardChanges(ctx, boardID, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), set.Cursor)
+		assert.Equal(t, boardID, set.Board.ID)
+		assert.Equal(t, columns, set.Columns)
+		assert.Equal(t, []*card.Card{c}, set.Cards)
+		assert.False(t, set.HasMore)
+	})
+
+	t.Run("full sync of a missing board", func(t *testing.T) {
+		mockChangeRepo.EXPECT().GetLatestSeq(gomock.Any()).Return(int64(0), nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetBoardChanges(ctx, boardID, nil, 0)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+
+	t.Run("delta keeps the latest change per entity", func(t *testing.T) {
+		updated := &card.Card{ID: uuid.New(), BoardID: boardID}
+		deletedID := uuid.New()
+		movedAwayID := uuid.New()
+		changes := []*sync_change.Change{
+			{Seq: 11, EntityType: sync_change.EntityCard, EntityID: updated.ID},
+			{Seq: 12, EntityType: sync_change.EntityCard, EntityID: deletedID},
+			{Seq: 13, EntityType: sync_change.EntityCard, EntityID: updated.ID},
+			{Seq: 14, EntityType: sync_change.EntityCard, EntityID: deletedID, Deleted: true, RecordedAt: now},
+			{Seq: 15, EntityType: sync_change.EntityCard, EntityID: movedAwayID},
+		}
+		cursor := int64(10)
+		mockChangeRepo.EXPECT().GetSince(gomock.Any(), boardID, cursor, now.Add(-SettleWindow), 6).Return(changes, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), updated.ID).Return(updated, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), movedAwayID).Return(&card.Card{ID: movedAwayID, BoardID: uuid.New()}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(columns, nil)
+
+		set, err := svc.GetBoardChanges(ctx, boardID, &cursor, 5)
+		require.NoError(t, err)
+		assert.Equal(t, int64(15), set.Cursor)
+		assert.False(t, set.HasMore)
+		assert.Nil(t, set.Board)
+		assert.Equal(t, []*card.Card{updated}, set.Cards)
+		require.Len(t, set.Tombstones, 2)
+		assert.Equal(t, deletedID, set.Tombstones[0].EntityID)
+		assert.Equal(t, movedAwayID, set.Tombstones[1].EntityID)
+	})
+
+	t.Run("delta pages past the limit", func(t *testing.T) {
+		changes := []*sync_change.Change{
+			{Seq: 21, EntityType: sync_change.EntityBoard, EntityID: boardID},
+			{Seq: 22, EntityType: sync_change.EntityBoard, EntityID: boardID},
+		}
+		cursor := int64(20)
+		mockChangeRepo.EXPECT().GetSince(gomock.Any(), boardID, cursor, gomock.Any(), 2).Return(changes, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(columns, nil)
+
+		set, err := svc.GetBoardChanges(ctx, boardID, &cursor, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), set.Cursor)
+		assert.True(t, set.HasMore)
+		assert.Equal(t, boardID, set.Board.ID)
+	})
+
+	t.Run("empty delta keeps the cursor", func(t *testing.T) {
+		cursor := int64(30)
+		mockChangeRepo.EXPECT().GetSince(gomock.Any(), boardID, cursor, gomock.Any(), DefaultChangeLimit+1).Return(nil, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return(columns, nil)
+
+		set, err := svc.GetBoardChanges(ctx, boardID, &cursor, 0)
+		require.NoError(t, err)
+		assert.Equal(t, cursor, set.Cursor)
+		assert.Empty(t, set.Cards)
+		assert.Empty(t, set.Tombstones)
+	})
+}
+
+func TestCheckConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChangeRepo := changeMocks.NewMockRepository(ctrl)
+	mockMutationRepo := mutationMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockChangeRepo, mockMutationRepo, mockBoardRepo, mockColumnRepo, mockCardRepo).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+
+	synced := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	before := synced.Add(-time.Hour)
+	after := synced.Add(time.Hour)
+	cardID := uuid.New()
+
+	expectCard := func(updatedAt, columnEnteredAt time.Time) *card.Card {
+		c := &card.Card{ID: cardID, UpdatedAt: updatedAt, ColumnEnteredAt: columnEnteredAt}
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(c, nil)
+		return c
+	}
+
+	t.Run("unchanged since sync", func(t *testing.T) {
+		c := expectCard(before, before)
+
+		got, err := svc.CheckConflict(ctx, MutationUpdate, cardID, &synced, ServerWins)
+		require.NoError(t, err)
+		assert.Equal(t, c, got)
+	})
+
+	t.Run("changed since sync", func(t *testing.T) {
+		c := expectCard(after, before)
+
+		got, err := svc.CheckConflict(ctx, MutationUpdate, cardID, &synced, ServerWins)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, c, got)
+	})
+
+	t.Run("client wins", func(t *testing.T) {
+		expectCard(after, after)
+
+		_, err := svc.CheckConflict(ctx, MutationUpdate, cardID, &synced, ClientWins)
+		assert.NoError(t, err)
+	})
+
+	t.Run("no base", func(t *testing.T) {
+		expectCard(after, after)
+
+		_, err := svc.CheckConflict(ctx, MutationDelete, cardID, nil, ServerWins)
+		assert.NoError(t, err)
+	})
+
+	t.Run("move ignores edits", func(t *testing.T) {
+		expectCard(after, before)
+
+		_, err := svc.CheckConflict(ctx, MutationMove, cardID, &synced, ServerWins)
+		assert.NoError(t, err)
+	})
+
+	t.Run("move conflicts with moves", func(t *testing.T) {
+		expectCard(before, after)
+
+		_, err := svc.CheckConflict(ctx, MutationMove, cardID, &synced, ServerWins)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("deleted card", func(t *testing.T) {
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CheckConflict(ctx, MutationUpdate, cardID, &synced, ServerWins)
+		assert.ErrorIs(t, err, ErrCardDeleted)
+	})
+
+	t.Run("deleting a deleted card", func(t *testing.T) {
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(nil, gorm.ErrRecordNotFound)
+
+		got, err := svc.CheckConflict(ctx, MutationDelete, cardID, &synced, ServerWins)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestGetAppliedMutation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChangeRepo := changeMocks.NewMockRepository(ctrl)
+	mockMutationRepo := mutationMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockChangeRepo, mockMutationRepo, mockBoardRepo, mockColumnRepo, mockCardRepo).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("not applied", func(t *testing.T) {
+		mockMutationRepo.EXPECT().Get(gomock.Any(), userID, "m1").Return(nil, gorm.ErrRecordNotFound)
+
+		got, err := svc.GetAppliedMutation(ctx, userID, "m1")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		cardID := uuid.New()
+		applied := &sync_mutation.AppliedMutation{UserID: userID, ClientMutationID: "m2", CardID: &cardID}
+		mockMutationRepo.EXPECT().Get(gomock.Any(), userID, "m2").Return(applied, nil)
+
+		got, err := svc.GetAppliedMutation(ctx, userID, "m2")
+		require.NoError(t, err)
+		assert.Equal(t, applied, got)
+	})
+}
@@ -12,5 +12,6 @@ var (
 	ErrNonceMismatch        = errors.New("ID token nonce does not match")
 	ErrUserCreationFailed   = errors.New("failed to create user from OIDC identity")
 	ErrIdentityLinkFailed   = errors.New("failed to link OIDC identity to user")
+	ErrProfileFetchFailed   = errors.New("failed to fetch the user's profile from the provider")
 	ErrIdentityAlreadyLinked = errors.New("OIDC identity is already linked to another user")
 )
@@ -0,0 +1,164 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/oidc_identity"
+	oidc_identity_mocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/oidc_identity/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	user_mocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// newGitHubServer fakes the token endpoint and REST API of a GitHub Enterprise server
+func newGitHubServer(t *testing.T, emails []githubEmail) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") != "Bearer gho_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "test-code", r.Form.Get("code"))
+		assert.Equal(t, "test-code-verifier", r.Form.Get("code_verifier"))
+		writeJSON(w, map[string]string{"access_token": "gho_test", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			writeJSON(w, githubUser{ID: 583231, Login: "octocat", AvatarURL: "https://avatars.example.com/583231"})
+		}
+	})
+	mux.HandleFunc("/api/v3/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		if authorized(w, r) {
+			writeJSON(w, emails)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newGitHubService(t *testing.T, issuerURL string) (Service, *oidc_identity_mocks.MockRepository, *user_mocks.MockRepository, *mockStateManager) {
+	ctrl := gomock.NewController(t)
+	identityRepo := oidc_identity_mocks.NewMockRepository(ctrl)
+	userRepo := user_mocks.NewMockRepository(ctrl)
+	stateManager := newMockStateManager()
+	stateManager.SetState("github-state", &StateData{
+		ProviderSlug: "github",
+		CodeVerifier: "test-code-verifier",
+		Nonce:        "test-nonce",
+		CreatedAt:    time.Now(),
+	})
+
+	providers := []config.OIDCProvider{{
+		Name:         "GitHub",
+		Slug:         "github",
+		Type:         config.OIDCProviderTypeGitHub,
+		IssuerURL:    issuerURL,
+		ClientID:     "github-client-id",
+		ClientSecret: "github-client-secret",
+		Scopes:       "read:user user:email",
+	}}
+	svc := NewService(providers, identityRepo, userRepo, stateManager, "http://localhost:3000", "http://localhost:4321")
+	return svc, identityRepo, userRepo, stateManager
+}
+
+func TestGitHubAuthorizationURL(t *testing.T) {
+	svc, _, _, _ := newGitHubService(t, "https://github.com")
+
+	result, err := svc.GetAuthorizationURL(context.Background(), "github", "http://localhost:4321/dashboard")
+	require.NoError(t, err)
+	assert.Contains(t, result.AuthURL, "https://github.com/login/oauth/authorize?")
+	assert.Contains(t, result.AuthURL, "client_id=github-client-id")
+	assert.Contains(t, result.AuthURL, "scope=read%3Auser+user%3Aemail")
+	assert.Contains(t, result.AuthURL, "redirect_uri=http%3A%2F%2Flocalhost%3A3000%2Fauth%2Foidc%2Fgithub%2Fcallback")
+}
+
+func TestGitHubAPIURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com", githubAPIURL("https://github.com/"))
+	assert.Equal(t, "https://github.example.com/api/v3", githubAPIURL("https://github.example.com"))
+}
+
+func TestGitHubCallback(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("links an existing user by the primary verified email", func(t *testing.T) {
+		server := newGitHubServer(t, []githubEmail{
+			{Email: "octocat@users.noreply.example.com", Verified: true},
+			{Email: "octocat@example.com", Primary: true, Verified: true},
+		})
+		svc, identityRepo, userRepo, stateManager := newGitHubService(t, server.URL)
+		existing := &user.User{ID: uuid.New(), Username: "octocat"}
+
+		identityRepo.EXPECT().GetByIssuerAndSubject(gomock.Any(), server.URL, "583231").Return(nil, gorm.ErrRecordNotFound)
+		userRepo.EXPECT().GetByEmail(gomock.Any(), "octocat@example.com").Return(existing, nil)
+		identityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, identity *oidc_identity.OIDCIdentity) error {
+			assert.Equal(t, existing.ID, identity.UserID)
+			assert.Equal(t, server.URL, identity.Issuer)
+			assert.Equal(t, "583231", identity.Subject)
+			assert.True(t, identity.EmailVerified)
+			return nil
+		})
+		userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := svc.HandleCallback(ctx, "github", "test-code", "github-state")
+		require.NoError(t, err)
+		assert.True(t, result.LinkedToExisting)
+		assert.Equal(t, "octocat", *result.User.DisplayName)
+		assert.Equal(t, "https://avatars.example.com/583231", *result.User.AvatarURL)
+
+		_, err = stateManager.GetState("github-state")
+		assert.ErrorIs(t, err, ErrInvalidState)
+	})
+
+	t.Run("never links by an unverified email", func(t *testing.T) {
+		server := newGitHubServer(t, []githubEmail{{Email: "octocat@example.com", Primary: true}})
+		svc, identityRepo, userRepo, _ := newGitHubService(t, server.URL)
+
+		identityRepo.EXPECT().GetByIssuerAndSubject(gomock.Any(), server.URL, "583231").Return(nil, gorm.ErrRecordNotFound)
+		userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *user.User) error {
+			assert.Nil(t, u.Email)
+			u.ID = uuid.New()
+			return nil
+		})
+		identityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := svc.HandleCallback(ctx, "github", "test-code", "github-state")
+		require.NoError(t, err)
+		assert.True(t, result.IsNewUser)
+	})
+
+	t.Run("API failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/login/oauth/access_token" {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"access_token":"gho_test","token_type":"bearer"}`))
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		svc, _, _, _ := newGitHubService(t, server.URL)
+
+		_, err := svc.HandleCallback(ctx, "github", "test-code", "github-state")
+		assert.ErrorIs(t, err, ErrProfileFetchFailed)
+	})
+}
@@ -37,6 +37,16 @@ type AuthorizationResponse struct {
 	CodeVerifier string
 }
 
+// idTokenClaims are the claims read from an ID token, or from the GitHub API for GitHub
+type idTokenClaims = struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"nonce"`
+}
+
 // CallbackResult contains the result of handling an OIDC callback
 type CallbackResult struct {
 	User             *user.User
@@ -128,10 +138,10 @@ func (s *service) GetAuthorizationURL(ctx context.Context, providerSlug, redirec
 		return nil, err
 	}
 
-	// Get OIDC provider metadata
-	oidcProvider, err := s.getOIDCProvider(ctx, provider)
+	// Build OAuth2 config
+	oauth2Config, _, err := s.oauth2Config(ctx, provider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get OIDC provider metadata: %w", err)
+		return nil, err
 	}
 
 	// Create state with PKCE
@@ -140,9 +150,6 @@ func (s *service) GetAuthorizationURL(ctx context.Context, providerSlug, redirec
 		return nil, fmt.Errorf("failed to create state: %w", err)
 	}
 
-	// Build OAuth2 config
-	oauth2Config := s.buildOAuth2Config(provider, oidcProvider)
-
 	// Generate code challenge for PKCE
 	codeChallenge := GenerateCodeChallenge(stateData.CodeVerifier)
 
@@ -182,15 +189,12 @@ func (s *service) HandleCallback(ctx context.Context, providerSlug, code, state
 		return nil, err
 	}
 
-	// Get OIDC provider metadata
-	oidcProvider, err := s.getOIDCProvider(ctx, provider)
+	// Build OAuth2 config
+	oauth2Config, oidcProvider, err := s.oauth2Config(ctx, provider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get OIDC provider metadata: %w", err)
+		return nil, err
 	}
 
-	// Build OAuth2 config
-	oauth2Config := s.buildOAuth2Config(provider, oidcProvider)
-
 	// Exchange code for tokens with PKCE verifier
 	token, err := oauth2Config.Exchange(
 		ctx,
@@ -201,6 +205,15 @@ func (s *service) HandleCallback(ctx context.Context, providerSlug, code, state
 		return nil, fmt.Errorf("%w: %v", ErrTokenExchangeFailed, err)
 	}
 
+	// GitHub issues no ID token; the state and PKCE verifier stand in for the nonce
+	if provider.Type == config.OIDCProviderTypeGitHub {
+		claims, err := s.githubClaims(ctx, provider, token)
+		if err != nil {
+			return nil, err
+		}
+		return s.findOrCreateUser(ctx, provider, claims)
+	}
+
 	// Extract and verify ID token
 	rawIDToken, ok := token.Extra("id_token").(string)
 	if !ok {
@@ -225,14 +238,7 @@ func (s *service) HandleCallback(ctx context.Context, providerSlug, code, state
 	}
 
 	// Extract claims
-	var claims struct {
-		Subject       string `json:"sub"`
-		Email         string `json:"email"`
-		EmailVerified bool   `json:"email_verified"`
-		Name          string `json:"name"`
-		Picture       string `json:"picture"`
-		Nonce         string `json:"nonce"`
-	}
+	var claims idTokenClaims
 	if err := idToken.Claims(&claims); err != nil {
 		return nil, fmt.Errorf("failed to parse claims: %w", err)
 	}
@@ -295,6 +301,21 @@ func (s *service) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider
 
 // Helper methods
 
+// oauth2Config builds the OAuth2 config of the provider, returning the OIDC provider
+// metadata it was built from, nil for GitHub
+func (s *service) oauth2Config(ctx context.Context, provider *config.OIDCProvider) (*oauth2.Config, *oidc.Provider, error) {
+	if provider.Type == config.OIDCProviderTypeGitHub {
+		return s.buildGitHubOAuth2Config(provider), nil, nil
+	}
+
+	// Get OIDC provider metadata
+	oidcProvider, err := s.getOIDCProvider(ctx, provider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get OIDC provider metadata: %w", err)
+	}
+	return s.buildOAuth2Config(provider, oidcProvider), oidcProvider, nil
+}
+
 func (s *service) getOIDCProvider(ctx context.Context, provider *config.OIDCProvider) (*oidc.Provider, error) {
 	// Check cache first
 	if oidcProv, ok := s.oidcProviderCache[provider.IssuerURL]; ok {
@@ -354,14 +375,7 @@ func (s *service) rewriteEndpoint(endpoint oauth2.Endpoint, issuerURL, discovery
 	}
 }
 
-func (s *service) findOrCreateUser(ctx context.Context, provider *config.OIDCProvider, claims *struct {
-	Subject       string `json:"sub"`
-	Email         string `json:"email"`
-	EmailVerified bool   `json:"email_verified"`
-	Name          string `json:"name"`
-	Picture       string `json:"picture"`
-	Nonce         string `json:"nonce"`
-}) (*CallbackResult, error) {
+func (s *service) findOrCreateUser(ctx context.Context, provider *config.OIDCProvider, claims *idTokenClaims) (*CallbackResult, error) {
 	// Check if identity already exists
 	existingIdentity, err := s.identityRepo.GetByIssuerAndSubject(ctx, provider.IssuerURL, claims.Subject)
 	if err == nil && existingIdentity != nil {
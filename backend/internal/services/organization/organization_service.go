@@ -10,6 +10,8 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -35,6 +37,9 @@ type Service interface {
 	AddMember(ctx context.Context, orgID, userID uuid.UUID, role string) (*organization_member.OrganizationMember, error)
 	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
 	IsMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	// IsGuest reports whether the user is a guest of the organization
+	IsGuest(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	// GetMembers returns the organization's members, without its guests
 	GetMembers(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error)
 	GetOwner(ctx context.Context, orgID uuid.UUID) (*user.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*user.User, error)
@@ -44,17 +49,23 @@ type service struct {
 	orgRepo    organization.Repository
 	memberRepo organization_member.Repository
 	userRepo   user.Repository
+	txManager  transaction.Manager
+	bus        events.Bus
 }
 
 func NewService(
 	orgRepo organization.Repository,
 	memberRepo organization_member.Repository,
 	userRepo user.Repository,
+	txManager transaction.Manager,
+	bus events.Bus,
 ) Service {
 	return &service{
 		orgRepo:    orgRepo,
 		memberRepo: memberRepo,
 		userRepo:   userRepo,
+		txManager:  txManager,
+		bus:        bus,
 	}
 }
 
@@ -255,7 +266,17 @@ func (s *service) AddMember(ctx context.Context, orgID, userID uuid.UUID, role s
 		Role:           role,
 	}
 
-	if err := s.memberRepo.Create(ctx, member); err != nil {
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.memberRepo.Create(ctx, member); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.MemberAdded, events.MemberAddedPayload{
+			OrganizationID: orgID,
+			UserID:         userID,
+			RoleID:         member.RoleID,
+		}))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -291,12 +312,30 @@ func (s *service) IsMember(ctx context.Context, orgID, userID uuid.UUID) (bool,
 	return member != nil, nil
 }
 
+func (s *service) IsGuest(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "IsGuest")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	member, err := s.memberRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return member.IsGuest, nil
+}
+
 func (s *service) GetMembers(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
 	ctx, span := s.startServiceSpan(ctx, "GetMembers")
 	span.SetAttributes(attribute.String("org.id", orgID.String()))
 	defer span.End()
 
-	return s.memberRepo.GetByOrgID(ctx, orgID)
+	return s.memberRepo.GetMembersByOrgID(ctx, orgID)
 }
 
 func (s *service) GetOwner(ctx context.Context, orgID uuid.UUID) (*user.User, error) {
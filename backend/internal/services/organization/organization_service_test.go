@@ -14,6 +14,8 @@ import (
 	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
 	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -26,7 +28,7 @@ func TestCreateOrganization_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	userID := uuid.New()
 
@@ -60,7 +62,7 @@ func TestCreateOrganization_SlugTaken(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	userID := uuid.New()
 	existingOrg := &organization.Organization{
@@ -98,7 +100,7 @@ func TestGetOrganization_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	expectedOrg := &organization.Organization{
@@ -125,7 +127,7 @@ func TestGetOrganization_NotFound(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
@@ -146,7 +148,7 @@ func TestGetOrganizationBySlug_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	expectedOrg := &organization.Organization{
 		ID:   uuid.New(),
@@ -171,7 +173,7 @@ func TestGetOrganizationBySlug_NotFound(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	mockOrgRepo.EXPECT().GetBySlug(gomock.Any(), "nonexistent").Return(nil, gorm.ErrRecordNotFound)
 
@@ -190,7 +192,7 @@ func TestGetUserOrganizations_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	userID := uuid.New()
 	expectedOrgs := []*organization.Organization{
@@ -214,7 +216,14 @@ func TestAddMember_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	bus := events.NewSyncBus()
+	var added []events.Event
+	bus.Subscribe(events.MemberAdded, func(ctx context.Context, e events.Event) error {
+		added = append(added, e)
+		return nil
+	})
+
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), bus)
 
 	orgID := uuid.New()
 	userID := uuid.New()
@@ -236,6 +245,9 @@ func TestAddMember_Success(t *testing.T) {
 	assert.Equal(t, orgID, member.OrganizationID)
 	assert.Equal(t, userID, member.UserID)
 	assert.Equal(t, "member", member.Role)
+
+	require.Len(t, added, 1)
+	assert.Equal(t, events.MemberAddedPayload{OrganizationID: orgID, UserID: userID}, added[0].Payload)
 }
 
 func TestAddMember_AlreadyMember(t *testing.T) {
@@ -246,7 +258,7 @@ func TestAddMember_AlreadyMember(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	userID := uuid.New()
@@ -275,7 +287,7 @@ func TestRemoveMember_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	userID := uuid.New()
@@ -295,7 +307,7 @@ func TestIsMember_True(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	userID := uuid.New()
@@ -322,7 +334,7 @@ func TestIsMember_False(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	userID := uuid.New()
@@ -335,6 +347,40 @@ func TestIsMember_False(t *testing.T) {
 	assert.False(t, isMember)
 }
 
+func TestIsGuest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+	mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
+
+	orgID := uuid.New()
+	guestID := uuid.New()
+	memberID := uuid.New()
+	strangerID := uuid.New()
+
+	mockMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, guestID).
+		Return(&organization_member.OrganizationMember{OrganizationID: orgID, UserID: guestID, IsGuest: true}, nil)
+	mockMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, memberID).
+		Return(&organization_member.OrganizationMember{OrganizationID: orgID, UserID: memberID}, nil)
+	mockMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, strangerID).Return(nil, gorm.ErrRecordNotFound)
+
+	isGuest, err := svc.IsGuest(context.Background(), orgID, guestID)
+	require.NoError(t, err)
+	assert.True(t, isGuest)
+
+	isGuest, err = svc.IsGuest(context.Background(), orgID, memberID)
+	require.NoError(t, err)
+	assert.False(t, isGuest)
+
+	isGuest, err = svc.IsGuest(context.Background(), orgID, strangerID)
+	require.NoError(t, err)
+	assert.False(t, isGuest)
+}
+
 func TestGetMembers_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -343,7 +389,7 @@ func TestGetMembers_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	expectedMembers := []*organization_member.OrganizationMember{
@@ -351,7 +397,7 @@ func TestGetMembers_Success(t *testing.T) {
 		{ID: uuid.New(), OrganizationID: orgID, UserID: uuid.New(), Role: "member"},
 	}
 
-	mockMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(expectedMembers, nil)
+	mockMemberRepo.EXPECT().GetMembersByOrgID(gomock.Any(), orgID).Return(expectedMembers, nil)
 
 	members, err := svc.GetMembers(context.Background(), orgID)
 
@@ -367,7 +413,7 @@ func TestGetOwner_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	ownerID := uuid.New()
@@ -400,7 +446,7 @@ func TestGetOwner_OrgNotFound(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
@@ -421,7 +467,7 @@ func TestGetUserByID_Success(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	userID := uuid.New()
 	expectedUser := &user.User{
@@ -446,7 +492,7 @@ func TestGetUserByID_NotFound(t *testing.T) {
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 	mockUserRepo := userMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo)
+	svc := NewService(mockOrgRepo, mockMemberRepo, mockUserRepo, transaction.NewNoopManager(), events.NewSyncBus())
 
 	userID := uuid.New()
 
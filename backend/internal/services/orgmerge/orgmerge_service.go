@@ -0,0 +1,456 @@
+package orgmerge
+
+//go:generate mockgen -source=orgmerge_service.go -destination=mocks/orgmerge_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrgNotFound   = errors.New("organization not found")
+	ErrSameOrg       = errors.New("cannot merge an organization into itself")
+	ErrNoProjectKeys = errors.New("no free project key left for a merged project")
+)
+
+const (
+	// maxProjectKeyLength and maxRoleNameLength match the column sizes
+	maxProjectKeyLength = 10
+	maxRoleNameLength   = 100
+)
+
+// MemberAction says what a merge does with a member of the source organization
+type MemberAction string
+
+const (
+	// MemberMoved members join the target organization with their source role
+	MemberMoved MemberAction = "moved"
+	// MemberAlreadyInTarget members keep their existing target membership and role
+	MemberAlreadyInTarget MemberAction = "already_member"
+)
+
+// InvitationAction says what a merge does with a pending invitation of the source organization
+type InvitationAction string
+
+const (
+	InvitationMoved InvitationAction = "moved"
+	// InvitationSkipped invitations are dropped because the target already invited the address
+	InvitationSkipped InvitationAction = "skipped"
+)
+
+// Report describes a merge, planned or done
+type Report struct {
+	Source *organization.Organization
+	Target *organization.Organization
+	// DryRun is set when nothing was changed
+	DryRun      bool
+	Members     []*MemberChange
+	Projects    []*ProjectChange
+	Roles       []*RoleChange
+	Invitations []*InvitationChange
+}
+
+type MemberChange struct {
+	Member *organization_member.OrganizationMember
+	Action MemberAction
+	// RoleID and LegacyRole are the member's role in the target organization
+	RoleID     *uuid.UUID
+	LegacyRole string
+}
+
+type ProjectChange struct {
+	Project *project.Project
+	OldKey  string
+	// NewKey differs from OldKey when the key was taken in the target organization
+	NewKey string
+}
+
+type RoleChange struct {
+	Role    *role.Role
+	OldName string
+	// NewName differs from OldName when the name was taken in the target organization
+	NewName string
+}
+
+type InvitationChange struct {
+	Invitation *invitation.Invitation
+	Action     InvitationAction
+}
+
+type Service interface {
+	// Merge moves the source organization's members, projects, custom roles, pending
+	// invitations and audit history into the target and deletes the source, in one
+	// transaction. With dryRun it only reports what would happen.
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID, dryRun bool) (*Report, error)
+}
+
+type service struct {
+	orgRepo        organization.Repository
+	memberRepo     organization_member.Repository
+	projectRepo    project.Repository
+	roleRepo       role.Repository
+	invitationRepo invitation.Repository
+	auditRepo      audit.Repository
+	attachmentRepo attachment.Repository
+	txManager      transaction.Manager
+	bus            events.Bus
+}
+
+func NewService(
+	orgRepo organization.Repository,
+	memberRepo organization_member.Repository,
+	projectRepo project.Repository,
+	roleRepo role.Repository,
+	invitationRepo invitation.Repository,
+	auditRepo audit.Repository,
+	attachmentRepo attachment.Repository,
+	txManager transaction.Manager,
+	bus events.Bus,
+) Service {
+	return &service{
+		orgRepo:        orgRepo,
+		memberRepo:     memberRepo,
+		projectRepo:    projectRepo,
+		roleRepo:       roleRepo,
+		invitationRepo: invitationRepo,
+		auditRepo:      auditRepo,
+		attachmentRepo: attachmentRepo,
+		txManager:      txManager,
+		bus:            bus,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "orgmerge.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "orgmerge"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Merge(ctx context.Context, sourceID, targetID uuid.UUID, dryRun bool) (*Report, error) {
+	ctx, span := s.startServiceSpan(ctx, "Merge")
+	span.SetAttributes(
+		attribute.String("organization.source_id", sourceID.String()),
+		attribute.String("organization.target_id", targetID.String()),
+		attribute.Bool("merge.dry_run", dryRun),
+	)
+	defer span.End()
+
+	if sourceID == targetID {
+		return nil, ErrSameOrg
+	}
+
+	if dryRun {
+		report, err := s.plan(ctx, sourceID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		report.DryRun = true
+		return report, nil
+	}
+
+	var report *Report
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		report, err = s.plan(ctx, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		return s.apply(ctx, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// plan works out every change of the merge without writing anything
+func (s *service) plan(ctx context.Context, sourceID, targetID uuid.UUID) (*Report, error) {
+	source, err := s.getOrg(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getOrg(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Source: source, Target: target}
+
+	if report.Roles, err = s.planRoles(ctx, source, target); err != nil {
+		return nil, err
+	}
+	if report.Members, err = s.planMembers(ctx, source, target); err != nil {
+		return nil, err
+	}
+	if report.Projects, err = s.planProjects(ctx, source, target); err != nil {
+		return nil, err
+	}
+	if report.Invitations, err = s.planInvitations(ctx, source, target); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *service) getOrg(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// planRoles moves the source's custom roles, suffixing names the target already uses
+func (s *service) planRoles(ctx context.Context, source, target *organization.Organization) ([]*RoleChange, error) {
+	targetRoles, err := s.roleRepo.GetByOrgID(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(targetRoles))
+	for _, r := range targetRoles {
+		taken[strings.ToLower(r.Name)] = true
+	}
+
+	sourceRoles, err := s.roleRepo.GetByOrgID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*RoleChange, 0, len(sourceRoles))
+	for _, r := range sourceRoles {
+		name := r.Name
+		for n := 1; taken[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%s)", source.Name)
+			if n > 1 {
+				suffix = fmt.Sprintf(" (%s %d)", source.Name, n)
+			}
+			name = truncate(r.Name, maxRoleNameLength-len(suffix)) + suffix
+		}
+		taken[strings.ToLower(name)] = true
+		changes = append(changes, &RoleChange{Role: r, OldName: r.Name, NewName: name})
+	}
+	return changes, nil
+}
+
+// planMembers moves source members that aren't in the target yet. The target keeps a
+// single owner, so the source owner joins as an admin.
+func (s *service) planMembers(ctx context.Context, source, target *organization.Organization) ([]*MemberChange, error) {
+	targetMembers, err := s.memberRepo.GetByOrgID(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uuid.UUID]*organization_member.OrganizationMember, len(targetMembers))
+	for _, m := range targetMembers {
+		existing[m.UserID] = m
+	}
+
+	sourceMembers, err := s.memberRepo.GetByOrgID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*MemberChange, 0, len(sourceMembers))
+	for _, m := range sourceMembers {
+		if current, ok := existing[m.UserID]; ok {
+			changes = append(changes, &MemberChange{
+				Member:     m,
+				Action:     MemberAlreadyInTarget,
+				RoleID:     current.RoleID,
+				LegacyRole: current.Role,
+			})
+			continue
+		}
+
+		change := &MemberChange{Member: m, Action: MemberMoved, RoleID: m.RoleID, LegacyRole: m.Role}
+		if (m.RoleID != nil && *m.RoleID == role.OwnerRoleID) || (m.RoleID == nil && m.Role == "owner") {
+			adminRoleID := role.AdminRoleID
+			change.RoleID = &adminRoleID
+			change.LegacyRole = "admin"
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// planProjects moves the source's projects, giving new keys to those whose key the target
+// already uses
+func (s *service) planProjects(ctx context.Context, source, target *organization.Organization) ([]*ProjectChange, error) {
+	targetProjects, err := s.projectRepo.GetByOrgID(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(targetProjects))
+	for _, p := range targetProjects {
+		taken[p.Key] = true
+	}
+
+	sourceProjects, err := s.projectRepo.GetByOrgID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*ProjectChange, 0, len(sourceProjects))
+	for _, p := range sourceProjects {
+		key := p.Key
+		if taken[key] {
+			if key, err = freeProjectKey(p.Key, taken); err != nil {
+				return nil, err
+			}
+		}
+		taken[key] = true
+		changes = append(changes, &ProjectChange{Project: p, OldKey: p.Key, NewKey: key})
+	}
+	return changes, nil
+}
+
+// freeProjectKey appends letters to key (A-Z, then AA-ZZ), shortening it when needed,
+// until it finds a key that isn't taken
+func freeProjectKey(key string, taken map[string]bool) (string, error) {
+	var suffixes []string
+	for c := 'A'; c <= 'Z'; c++ {
+		suffixes = append(suffixes, string(c))
+	}
+	for c1 := 'A'; c1 <= 'Z'; c1++ {
+		for c2 := 'A'; c2 <= 'Z'; c2++ {
+			suffixes = append(suffixes, string(c1)+string(c2))
+		}
+	}
+	for _, suffix := range suffixes {
+		candidate := truncate(key, maxProjectKeyLength-len(suffix)) + suffix
+		if !taken[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", ErrNoProjectKeys
+}
+
+// planInvitations moves the source's pending invitations unless the target already
+// invited the same address
+func (s *service) planInvitations(ctx context.Context, source, target *organization.Organization) ([]*InvitationChange, error) {
+	targetInvitations, err := s.invitationRepo.GetByOrgID(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	invited := make(map[string]bool, len(targetInvitations))
+	for _, inv := range targetInvitations {
+		invited[strings.ToLower(inv.Email)] = true
+	}
+
+	pending, err := s.invitationRepo.GetPendingByOrgID(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*InvitationChange, 0, len(pending))
+	for _, inv := range pending {
+		email := strings.ToLower(inv.Email)
+		if invited[email] {
+			changes = append(changes, &InvitationChange{Invitation: inv, Action: InvitationSkipped})
+			continue
+		}
+		invited[email] = true
+		changes = append(changes, &InvitationChange{Invitation: inv, Action: InvitationMoved})
+	}
+	return changes, nil
+}
+
+// apply performs a planned merge; ctx must carry the merge transaction
+func (s *service) apply(ctx context.Context, report *Report) error {
+	sourceID, targetID := report.Source.ID, report.Target.ID
+
+	for _, change := range report.Roles {
+		change.Role.OrganizationID = &targetID
+		change.Role.Name = change.NewName
+		if err := s.roleRepo.Update(ctx, change.Role); err != nil {
+			return err
+		}
+	}
+
+	var userIDs []uuid.UUID
+	for _, change := range report.Members {
+		if change.Action != MemberMoved {
+			continue
+		}
+		member := &organization_member.OrganizationMember{
+			OrganizationID: targetID,
+			UserID:         change.Member.UserID,
+			Role:           change.LegacyRole,
+			RoleID:         change.RoleID,
+		}
+		if err := s.memberRepo.Create(ctx, member); err != nil {
+			return err
+		}
+		userIDs = append(userIDs, change.Member.UserID)
+	}
+
+	projectIDs := make([]uuid.UUID, len(report.Projects))
+	for i, change := range report.Projects {
+		change.Project.OrganizationID = targetID
+		change.Project.Key = change.NewKey
+		if err := s.projectRepo.Update(ctx, change.Project); err != nil {
+			return err
+		}
+		projectIDs[i] = change.Project.ID
+	}
+
+	for _, change := range report.Invitations {
+		if change.Action != InvitationMoved {
+			continue
+		}
+		change.Invitation.OrganizationID = targetID
+		if err := s.invitationRepo.Update(ctx, change.Invitation); err != nil {
+			return err
+		}
+	}
+
+	if err := s.auditRepo.ReassignOrganization(ctx, sourceID, targetID); err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.ReassignOrganization(ctx, sourceID, targetID); err != nil {
+		return err
+	}
+	// Deleting the source removes its remaining memberships and invitations
+	if err := s.orgRepo.Delete(ctx, sourceID); err != nil {
+		return err
+	}
+
+	return s.bus.Publish(ctx, events.New(ctx, events.OrganizationMerged, events.OrganizationMergedPayload{
+		SourceOrganizationID: sourceID,
+		TargetOrganizationID: targetID,
+		ProjectIDs:           projectIDs,
+		UserIDs:              userIDs,
+	}))
+}
+
+// truncate shortens s to at most n bytes without splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,235 @@
+package orgmerge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	attachmentMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/attachment/mocks"
+	auditMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
+	invitationMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	roleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// mergeFixture is an acquired organization with overlapping members, a colliding
+// project key, a colliding role name and an invitation both organizations sent
+type mergeFixture struct {
+	source, target   *organization.Organization
+	sourceOwner      uuid.UUID
+	sharedUser       uuid.UUID
+	newUser          uuid.UUID
+	customRole       *role.Role
+	webProject       *project.Project
+	apiProject       *project.Project
+	sharedInvitation *invitation.Invitation
+	newInvitation    *invitation.Invitation
+}
+
+func newMergeFixture(mockOrgRepo *orgMocks.MockRepository, mockMemberRepo *memberMocks.MockRepository, mockProjectRepo *projectMocks.MockRepository, mockRoleRepo *roleMocks.MockRepository, mockInvitationRepo *invitationMocks.MockRepository) *mergeFixture {
+	f := &mergeFixture{
+		source:      &organization.Organization{ID: uuid.New(), Name: "Acme"},
+		target:      &organization.Organization{ID: uuid.New(), Name: "Globex"},
+		sourceOwner: uuid.New(),
+		sharedUser:  uuid.New(),
+		newUser:     uuid.New(),
+	}
+	f.customRole = &role.Role{ID: uuid.New(), OrganizationID: &f.source.ID, Name: "Reviewer"}
+	f.webProject = &project.Project{ID: uuid.New(), OrganizationID: f.source.ID, Key: "WEB"}
+	f.apiProject = &project.Project{ID: uuid.New(), OrganizationID: f.source.ID, Key: "API"}
+	expires := time.Now().Add(24 * time.Hour)
+	f.sharedInvitation = &invitation.Invitation{ID: uuid.New(), OrganizationID: f.source.ID, Email: "Sam@example.com", ExpiresAt: expires}
+	f.newInvitation = &invitation.Invitation{ID: uuid.New(), OrganizationID: f.source.ID, Email: "kim@example.com", ExpiresAt: expires}
+
+	ownerRoleID, memberRoleID := role.OwnerRoleID, role.MemberRoleID
+	targetOwnerRoleID := role.OwnerRoleID
+
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), f.source.ID).Return(f.source, nil)
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), f.target.ID).Return(f.target, nil)
+	mockRoleRepo.EXPECT().GetByOrgID(gomock.Any(), f.target.ID).Return([]*role.Role{{ID: uuid.New(), Name: "reviewer"}}, nil)
+	mockRoleRepo.EXPECT().GetByOrgID(gomock.Any(), f.source.ID).Return([]*role.Role{f.customRole}, nil)
+	mockMemberRepo.EXPECT().GetByOrgID(gomock.Any(), f.target.ID).Return([]*organization_member.OrganizationMember{
+		{OrganizationID: f.target.ID, UserID: uuid.New(), Role: "owner", RoleID: &targetOwnerRoleID},
+		{OrganizationID: f.target.ID, UserID: f.sharedUser, Role: "member", RoleID: &memberRoleID},
+	}, nil)
+	mockMemberRepo.EXPECT().GetByOrgID(gomock.Any(), f.source.ID).Return([]*organization_member.OrganizationMember{
+		{OrganizationID: f.source.ID, UserID: f.sourceOwner, Role: "owner", RoleID: &ownerRoleID},
+		{OrganizationID: f.source.ID, UserID: f.sharedUser, Role: "admin", RoleID: &f.customRole.ID},
+		{OrganizationID: f.source.ID, UserID: f.newUser, Role: "member", RoleID: &f.customRole.ID},
+	}, nil)
+	mockProjectRepo.EXPECT().GetByOrgID(gomock.Any(), f.target.ID).Return([]*project.Project{{Key: "WEB"}, {Key: "WEBA"}}, nil)
+	mockProjectRepo.EXPECT().GetByOrgID(gomock.Any(), f.source.ID).Return([]*project.Project{f.webProject, f.apiProject}, nil)
+	mockInvitationRepo.EXPECT().GetByOrgID(gomock.Any(), f.target.ID).Return([]*invitation.Invitation{{Email: "sam@example.com"}}, nil)
+	mockInvitationRepo.EXPECT().GetPendingByOrgID(gomock.Any(), f.source.ID).Return([]*invitation.Invitation{f.sharedInvitation, f.newInvitation}, nil)
+	return f
+}
+
+func TestMerge(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run reports without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockRoleRepo := roleMocks.NewMockRepository(ctrl)
+		mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+
+		bus := events.NewSyncBus()
+		svc := NewService(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo, mockAuditRepo, mockAttachmentRepo, transaction.NewNoopManager(), bus)
+
+		f := newMergeFixture(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo)
+
+		report, err := svc.Merge(ctx, f.source.ID, f.target.ID, true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+
+		require.Len(t, report.Roles, 1)
+		assert.Equal(t, "Reviewer (Acme)", report.Roles[0].NewName)
+
+		require.Len(t, report.Members, 3)
+		assert.Equal(t, MemberMoved, report.Members[0].Action)
+		assert.Equal(t, role.AdminRoleID, *report.Members[0].RoleID, "the source owner joins as admin")
+		assert.Equal(t, "admin", report.Members[0].LegacyRole)
+		assert.Equal(t, MemberAlreadyInTarget, report.Members[1].Action)
+		assert.Equal(t, role.MemberRoleID, *report.Members[1].RoleID, "the target role is kept")
+		assert.Equal(t, MemberMoved, report.Members[2].Action)
+		assert.Equal(t, f.customRole.ID, *report.Members[2].RoleID)
+
+		require.Len(t, report.Projects, 2)
+		assert.Equal(t, "WEBB", report.Projects[0].NewKey)
+		assert.Equal(t, "API", report.Projects[1].NewKey)
+
+		require.Len(t, report.Invitations, 2)
+		assert.Equal(t, InvitationSkipped, report.Invitations[0].Action)
+		assert.Equal(t, InvitationMoved, report.Invitations[1].Action)
+
+		assert.Equal(t, f.source.ID, f.webProject.OrganizationID, "entities are left untouched")
+		assert.Equal(t, "Reviewer", f.customRole.Name)
+	})
+
+	t.Run("merge moves everything and deletes the source", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockRoleRepo := roleMocks.NewMockRepository(ctrl)
+		mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+
+		bus := events.NewSyncBus()
+		svc := NewService(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo, mockAuditRepo, mockAttachmentRepo, transaction.NewNoopManager(), bus)
+
+		f := newMergeFixture(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo)
+
+		var published []events.OrganizationMergedPayload
+		bus.Subscribe(events.OrganizationMerged, func(ctx context.Context, e events.Event) error {
+			published = append(published, e.Payload.(events.OrganizationMergedPayload))
+			return nil
+		})
+
+		mockRoleRepo.EXPECT().Update(gomock.Any(), f.customRole).Return(nil)
+		var created []*organization_member.OrganizationMember
+		mockMemberRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, member *organization_member.OrganizationMember) error {
+			created = append(created, member)
+			return nil
+		}).Times(2)
+		mockProjectRepo.EXPECT().Update(gomock.Any(), f.webProject).Return(nil)
+		mockProjectRepo.EXPECT().Update(gomock.Any(), f.apiProject).Return(nil)
+		mockInvitationRepo.EXPECT().Update(gomock.Any(), f.newInvitation).Return(nil)
+		mockAuditRepo.EXPECT().ReassignOrganization(gomock.Any(), f.source.ID, f.target.ID).Return(nil)
+		mockAttachmentRepo.EXPECT().ReassignOrganization(gomock.Any(), f.source.ID, f.target.ID).Return(nil)
+		mockOrgRepo.EXPECT().Delete(gomock.Any(), f.source.ID).Return(nil)
+
+		report, err := svc.Merge(ctx, f.source.ID, f.target.ID, false)
+		require.NoError(t, err)
+		assert.False(t, report.DryRun)
+
+		assert.Equal(t, f.target.ID, *f.customRole.OrganizationID)
+		assert.Equal(t, "Reviewer (Acme)", f.customRole.Name)
+		require.Len(t, created, 2)
+		assert.Equal(t, f.sourceOwner, created[0].UserID)
+		assert.Equal(t, f.target.ID, created[0].OrganizationID)
+		assert.Equal(t, role.AdminRoleID, *created[0].RoleID)
+		assert.Equal(t, f.newUser, created[1].UserID)
+		assert.Equal(t, f.target.ID, f.webProject.OrganizationID)
+		assert.Equal(t, "WEBB", f.webProject.Key)
+		assert.Equal(t, f.target.ID, f.newInvitation.OrganizationID)
+		assert.Equal(t, f.source.ID, f.sharedInvitation.OrganizationID)
+
+		require.Len(t, published, 1)
+		assert.Equal(t, []uuid.UUID{f.webProject.ID, f.apiProject.ID}, published[0].ProjectIDs)
+		assert.Equal(t, []uuid.UUID{f.sourceOwner, f.newUser}, published[0].UserIDs)
+	})
+
+	t.Run("fail - same organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockRoleRepo := roleMocks.NewMockRepository(ctrl)
+		mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+
+		bus := events.NewSyncBus()
+		svc := NewService(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo, mockAuditRepo, mockAttachmentRepo, transaction.NewNoopManager(), bus)
+
+		id := uuid.New()
+
+		_, err := svc.Merge(ctx, id, id, true)
+		assert.ErrorIs(t, err, ErrSameOrg)
+	})
+
+	t.Run("fail - organization not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+		mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockRoleRepo := roleMocks.NewMockRepository(ctrl)
+		mockInvitationRepo := invitationMocks.NewMockRepository(ctrl)
+		mockAuditRepo := auditMocks.NewMockRepository(ctrl)
+		mockAttachmentRepo := attachmentMocks.NewMockRepository(ctrl)
+
+		bus := events.NewSyncBus()
+		svc := NewService(mockOrgRepo, mockMemberRepo, mockProjectRepo, mockRoleRepo, mockInvitationRepo, mockAuditRepo, mockAttachmentRepo, transaction.NewNoopManager(), bus)
+
+		sourceID := uuid.New()
+
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), sourceID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Merge(ctx, sourceID, uuid.New(), false)
+		assert.ErrorIs(t, err, ErrOrgNotFound)
+	})
+}
+
+func TestFreeProjectKey(t *testing.T) {
+	key, err := freeProjectKey("PLATFORMXY", map[string]bool{"PLATFORMXY": true, "PLATFORMXA": true})
+	require.NoError(t, err)
+	assert.Equal(t, "PLATFORMXB", key)
+}
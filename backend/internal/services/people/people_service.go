@@ -0,0 +1,169 @@
+package people
+
+//go:generate mockgen -source=people_service.go -destination=mocks/people_service_mock.go -package=mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	peoplerepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/people"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Load is an amount of open work: cards that are neither archived nor in a done column
+type Load struct {
+	OpenCards       int
+	OpenStoryPoints int
+	OverdueCards    int
+}
+
+func (l *Load) add(c *peoplerepo.OpenCardCount) {
+	l.OpenCards += c.OpenCards
+	l.OpenStoryPoints += c.OpenStoryPoints
+	l.OverdueCards += c.OverdueCards
+}
+
+// ProjectLoad is a person's open work in one project
+type ProjectLoad struct {
+	ProjectID   uuid.UUID
+	ProjectName string
+	// IsMember is set for projects the person was added to; others only have cards
+	// assigned to them
+	IsMember bool
+	// RoleID is the person's project role, nil when they inherit their organization role
+	RoleID *uuid.UUID
+	Load
+}
+
+// Person is an organization member with their project assignments and workload
+type Person struct {
+	Member *organization_member.OrganizationMember
+	// Projects are the person's project memberships plus the projects they have open cards
+	// in, by name
+	Projects []ProjectLoad
+	// Sprints are the person's commitments in active sprints, ending soonest first
+	Sprints []*peoplerepo.SprintCommitment
+	// Load is the person's open work over all projects
+	Load
+	// SprintStoryPoints and SprintDoneStoryPoints total the active sprint commitments
+	SprintStoryPoints     int
+	SprintDoneStoryPoints int
+}
+
+type Service interface {
+	// GetPeople returns the organization's members, guests included, with their workload,
+	// busiest first: by story points committed to active sprints, then by open story points.
+	// Cards assigned to users who left the organization are left out.
+	GetPeople(ctx context.Context, orgID uuid.UUID) ([]*Person, error)
+}
+
+type service struct {
+	orgMemberRepo organization_member.Repository
+	peopleRepo    peoplerepo.Repository
+	now           func() time.Time
+}
+
+func NewService(orgMemberRepo organization_member.Repository, peopleRepo peoplerepo.Repository) Service {
+	return &service{
+		orgMemberRepo: orgMemberRepo,
+		peopleRepo:    peopleRepo,
+		now:           time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "people.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "people"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetPeople(ctx context.Context, orgID uuid.UUID) ([]*Person, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetPeople")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	members, err := s.orgMemberRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.peopleRepo.GetProjectAssignments(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.peopleRepo.CountOpenCards(ctx, orgID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := s.peopleRepo.GetSprintCommitments(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	people := make([]*Person, len(members))
+	byUser := make(map[uuid.UUID]*Person, len(members))
+	// projects indexes each person's Projects by project
+	projects := make(map[uuid.UUID]map[uuid.UUID]int, len(members))
+	for i, m := range members {
+		people[i] = &Person{Member: m}
+		byUser[m.UserID] = people[i]
+		projects[m.UserID] = map[uuid.UUID]int{}
+	}
+	project := func(p *Person, projectID uuid.UUID, name string) *ProjectLoad {
+		i, ok := projects[p.Member.UserID][projectID]
+		if !ok {
+			i = len(p.Projects)
+			p.Projects = append(p.Projects, ProjectLoad{ProjectID: projectID, ProjectName: name})
+			projects[p.Member.UserID][projectID] = i
+		}
+		return &p.Projects[i]
+	}
+
+	for _, a := range assignments {
+		if p := byUser[a.UserID]; p != nil {
+			load := project(p, a.ProjectID, a.ProjectName)
+			load.IsMember = true
+			load.RoleID = a.RoleID
+		}
+	}
+	for _, c := range counts {
+		if p := byUser[c.UserID]; p != nil {
+			project(p, c.ProjectID, c.ProjectName).add(c)
+			p.add(c)
+		}
+	}
+	for _, c := range commitments {
+		if p := byUser[c.UserID]; p != nil {
+			p.Sprints = append(p.Sprints, c)
+			p.SprintStoryPoints += c.StoryPoints
+			p.SprintDoneStoryPoints += c.DoneStoryPoints
+		}
+	}
+
+	for _, p := range people {
+		sort.SliceStable(p.Projects, func(i, j int) bool {
+			return p.Projects[i].ProjectName < p.Projects[j].ProjectName
+		})
+	}
+	sort.SliceStable(people, func(i, j int) bool {
+		a, b := people[i], people[j]
+		if a.SprintStoryPoints != b.SprintStoryPoints {
+			return a.SprintStoryPoints > b.SprintStoryPoints
+		}
+		return a.OpenStoryPoints > b.OpenStoryPoints
+	})
+	span.SetAttributes(attribute.Int("people.count", len(people)))
+
+	return people, nil
+}
@@ -0,0 +1,96 @@
+package people
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	peoplerepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/people"
+	peopleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/people/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPeople(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	ctx := context.Background()
+	orgID := uuid.New()
+	alice := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: uuid.New()}
+	bob := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: uuid.New()}
+	formerMember := uuid.New()
+	apollo := uuid.New()
+	zeus := uuid.New()
+	roleID := uuid.New()
+
+	t.Run("combines memberships, open cards and sprint commitments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockPeopleRepo := peopleMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockOrgMemberRepo, mockPeopleRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		sprint := &peoplerepo.SprintCommitment{UserID: bob.UserID, SprintID: uuid.New(), ProjectID: zeus, CardCount: 3, StoryPoints: 8, DoneCardCount: 1, DoneStoryPoints: 3}
+		mockOrgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return([]*organization_member.OrganizationMember{alice, bob}, nil)
+		mockPeopleRepo.EXPECT().GetProjectAssignments(gomock.Any(), orgID).Return([]*peoplerepo.ProjectAssignment{
+			{UserID: alice.UserID, ProjectID: zeus, ProjectName: "Zeus", RoleID: &roleID},
+			{UserID: bob.UserID, ProjectID: zeus, ProjectName: "Zeus"},
+		}, nil)
+		mockPeopleRepo.EXPECT().CountOpenCards(gomock.Any(), orgID, now).Return([]*peoplerepo.OpenCardCount{
+			{UserID: alice.UserID, ProjectID: apollo, ProjectName: "Apollo", OpenCards: 2, OpenStoryPoints: 5, OverdueCards: 1},
+			{UserID: alice.UserID, ProjectID: zeus, ProjectName: "Zeus", OpenCards: 1, OpenStoryPoints: 2},
+			{UserID: bob.UserID, ProjectID: zeus, ProjectName: "Zeus", OpenCards: 4, OpenStoryPoints: 1},
+			{UserID: formerMember, ProjectID: zeus, ProjectName: "Zeus", OpenCards: 9, OpenStoryPoints: 9},
+		}, nil)
+		mockPeopleRepo.EXPECT().GetSprintCommitments(gomock.Any(), orgID).Return([]*peoplerepo.SprintCommitment{sprint}, nil)
+
+		people, err := svc.GetPeople(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, people, 2)
+
+		// Bob has sprint commitments, so comes first despite fewer open story points
+		b := people[0]
+		assert.Equal(t, bob, b.Member)
+		assert.Equal(t, Load{OpenCards: 4, OpenStoryPoints: 1}, b.Load)
+		assert.Equal(t, []*peoplerepo.SprintCommitment{sprint}, b.Sprints)
+		assert.Equal(t, 8, b.SprintStoryPoints)
+		assert.Equal(t, 3, b.SprintDoneStoryPoints)
+
+		a := people[1]
+		assert.Equal(t, alice, a.Member)
+		assert.Equal(t, Load{OpenCards: 3, OpenStoryPoints: 7, OverdueCards: 1}, a.Load)
+		assert.Equal(t, []ProjectLoad{
+			{ProjectID: apollo, ProjectName: "Apollo", Load: Load{OpenCards: 2, OpenStoryPoints: 5, OverdueCards: 1}},
+			{ProjectID: zeus, ProjectName: "Zeus", IsMember: true, RoleID: &roleID, Load: Load{OpenCards: 1, OpenStoryPoints: 2}},
+		}, a.Projects)
+		assert.Empty(t, a.Sprints)
+	})
+
+	t.Run("members without work", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockPeopleRepo := peopleMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockOrgMemberRepo, mockPeopleRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockOrgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return([]*organization_member.OrganizationMember{alice}, nil)
+		mockPeopleRepo.EXPECT().GetProjectAssignments(gomock.Any(), orgID).Return(nil, nil)
+		mockPeopleRepo.EXPECT().CountOpenCards(gomock.Any(), orgID, now).Return(nil, nil)
+		mockPeopleRepo.EXPECT().GetSprintCommitments(gomock.Any(), orgID).Return(nil, nil)
+
+		people, err := svc.GetPeople(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, people, 1)
+		assert.Equal(t, Load{}, people[0].Load)
+		assert.Empty(t, people[0].Projects)
+	})
+}
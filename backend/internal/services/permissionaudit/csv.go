@@ -0,0 +1,76 @@
+package permissionaudit
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvHeader names the columns of WriteCSV
+var csvHeader = []string{"user_id", "username", "email", "org_role", "guest", "project_roles", "restricted_boards", "embed_tokens", "api_tokens"}
+
+// WriteCSV writes the report with one row per member. Multi-valued cells are separated
+// by "; ": project roles as KEY=Role (the organization role when no project role is set),
+// embed tokens by the ID of their board and their expiry, and API tokens by their name, kind,
+// scope and expiry.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, m := range r.Members {
+		email := ""
+		if m.User.Email != nil {
+			email = *m.User.Email
+		}
+		orgRole := ""
+		if m.OrgRole != nil {
+			orgRole = m.OrgRole.Name
+		}
+
+		projects := make([]string, len(m.Projects))
+		for i, p := range m.Projects {
+			roleName := ""
+			if effective := p.EffectiveRole(m); effective != nil {
+				roleName = effective.Name
+			}
+			projects[i] = p.Project.Key + "=" + roleName
+		}
+		boards := make([]string, len(m.RestrictedBoards))
+		for i, b := range m.RestrictedBoards {
+			boards[i] = b.Name
+		}
+		tokens := make([]string, len(m.EmbedTokens))
+		for i, t := range m.EmbedTokens {
+			tokens[i] = t.BoardID.String() + " until " + t.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		apiTokens := make([]string, len(m.APITokens))
+		for i, t := range m.APITokens {
+			apiTokens[i] = t.Name + " (" + string(t.Kind) + ", " + string(t.Scope) + ")"
+			if t.ExpiresAt != nil {
+				apiTokens[i] += " until " + t.ExpiresAt.UTC().Format(time.RFC3339)
+			}
+		}
+
+		err := cw.Write([]string{
+			m.User.ID.String(),
+			m.User.Username,
+			email,
+			orgRole,
+			strconv.FormatBool(m.Member.IsGuest),
+			strings.Join(projects, "; "),
+			strings.Join(boards, "; "),
+			strings.Join(tokens, "; "),
+			strings.Join(apiTokens, "; "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,214 @@
+package permissionaudit
+
+//go:generate mockgen -source=permissionaudit_service.go -destination=mocks/permissionaudit_service_mock.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Report is who can reach what in an organization, for security reviews
+type Report struct {
+	OrganizationID uuid.UUID
+	GeneratedAt    time.Time
+	// Members are the organization's members and guests, in membership order
+	Members []*MemberAccess
+}
+
+// MemberAccess is what one member of the organization can reach
+type MemberAccess struct {
+	Member *organization_member.OrganizationMember
+	User   *user.User
+	// OrgRole is the member's effective organization role
+	OrgRole *role.Role
+	// Projects are the projects the member was added to
+	Projects []*ProjectAccess
+	// RestrictedBoards are the only boards a guest can reach; empty for members, who reach
+	// every board of the organization
+	RestrictedBoards []*board.Board
+	// EmbedTokens are the usable metrics embed tokens the member created
+	EmbedTokens []*metrics_embed_token.MetricsEmbedToken
+	// APITokens are the usable API tokens acting as the member: their personal access
+	// tokens, or the organization's service tokens when the member is their service account
+	APITokens []*api_token.APIToken
+}
+
+// ProjectAccess is a member's role in a project they were added to
+type ProjectAccess struct {
+	Project *project.Project
+	// Role is the member's project-specific role; nil when their organization role applies
+	Role *role.Role
+}
+
+// EffectiveRole returns the role that applies to the member in the project
+func (p *ProjectAccess) EffectiveRole(member *MemberAccess) *role.Role {
+	if p.Role != nil {
+		return p.Role
+	}
+	return member.OrgRole
+}
+
+type Service interface {
+	// GetReport returns every member's effective roles, the boards guests are restricted to,
+	// the embed tokens members created and the API tokens acting as them
+	GetReport(ctx context.Context, orgID uuid.UUID) (*Report, error)
+}
+
+type service struct {
+	orgMemberRepo     organization_member.Repository
+	projectRepo       project.Repository
+	projectMemberRepo project_member.Repository
+	boardRepo         board.Repository
+	tokenRepo         metrics_embed_token.Repository
+	apiTokenRepo      api_token.Repository
+	userRepo          user.Repository
+	rbacSvc           rbac.Service
+	now               func() time.Time
+}
+
+func NewService(
+	orgMemberRepo organization_member.Repository,
+	projectRepo project.Repository,
+	projectMemberRepo project_member.Repository,
+	boardRepo board.Repository,
+	tokenRepo metrics_embed_token.Repository,
+	apiTokenRepo api_token.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+) Service {
+	return &service{
+		orgMemberRepo:     orgMemberRepo,
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		boardRepo:         boardRepo,
+		tokenRepo:         tokenRepo,
+		apiTokenRepo:      apiTokenRepo,
+		userRepo:          userRepo,
+		rbacSvc:           rbacSvc,
+		now:               time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "permissionaudit.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "permissionaudit"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetReport(ctx context.Context, orgID uuid.UUID) (*Report, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetReport")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	now := s.now()
+	members, err := s.orgMemberRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projectRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.rbacSvc.GetRolesForOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	rolesByID := make(map[uuid.UUID]*role.Role, len(roles))
+	for _, r := range roles {
+		rolesByID[r.ID] = r
+	}
+
+	report := &Report{OrganizationID: orgID, GeneratedAt: now, Members: make([]*MemberAccess, 0, len(members))}
+	byUser := make(map[uuid.UUID]*MemberAccess, len(members))
+	for _, m := range members {
+		u, err := s.userRepo.GetByID(ctx, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		orgRole, err := s.rbacSvc.GetOrgMemberRole(ctx, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		// Personal access tokens act as their user in every organization the user is in
+		personalTokens, err := s.apiTokenRepo.GetPersonalByUserID(ctx, m.UserID, now)
+		if err != nil {
+			return nil, err
+		}
+		access := &MemberAccess{Member: m, User: u, OrgRole: orgRole, APITokens: personalTokens}
+		report.Members = append(report.Members, access)
+		byUser[m.UserID] = access
+	}
+
+	serviceTokens, err := s.apiTokenRepo.GetServiceByOrgID(ctx, orgID, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range serviceTokens {
+		if access, ok := byUser[t.UserID]; ok {
+			access.APITokens = append(access.APITokens, t)
+		}
+	}
+
+	for _, p := range projects {
+		projectMembers, err := s.projectMemberRepo.GetByProjectID(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		boards, err := s.boardRepo.GetByProjectID(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pm := range projectMembers {
+			access, ok := byUser[pm.UserID]
+			if !ok {
+				continue
+			}
+			projectAccess := &ProjectAccess{Project: p}
+			if pm.RoleID != nil {
+				projectAccess.Role = rolesByID[*pm.RoleID]
+			}
+			access.Projects = append(access.Projects, projectAccess)
+			if access.Member.IsGuest {
+				access.RestrictedBoards = append(access.RestrictedBoards, boards...)
+			}
+		}
+
+		for _, b := range boards {
+			tokens, err := s.tokenRepo.GetByBoardID(ctx, b.ID, now)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range tokens {
+				if t.CreatedBy == nil {
+					continue
+				}
+				if access, ok := byUser[*t.CreatedBy]; ok {
+					access.EmbedTokens = append(access.EmbedTokens, t)
+				}
+			}
+		}
+	}
+	return report, nil
+}
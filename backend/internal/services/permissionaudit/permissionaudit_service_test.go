@@ -0,0 +1,121 @@
+package permissionaudit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	apiTokenMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token"
+	tokenMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_embed_token/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
+	projectMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+	projectRepo := projectMocks.NewMockRepository(ctrl)
+	projectMemberRepo := projectMemberMocks.NewMockRepository(ctrl)
+	boardRepo := boardMocks.NewMockRepository(ctrl)
+	tokenRepo := tokenMocks.NewMockRepository(ctrl)
+	apiTokenRepo := apiTokenMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	rbacSvc := rbacMocks.NewMockService(ctrl)
+	svc := NewService(orgMemberRepo, projectRepo, projectMemberRepo, boardRepo, tokenRepo, apiTokenRepo, userRepo, rbacSvc).(*service)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	orgID := uuid.New()
+	admin := &role.Role{ID: role.AdminRoleID, Name: "Admin"}
+	viewer := &role.Role{ID: role.ViewerRoleID, Name: "Viewer"}
+	email := "ada@example.com"
+	ada := &user.User{ID: uuid.New(), Username: "ada", Email: &email}
+	gus := &user.User{ID: uuid.New(), Username: "gus"}
+	adaMember := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: ada.ID}
+	gusMember := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: gus.ID, IsGuest: true}
+	bot := &user.User{ID: uuid.New(), Username: "service-1a2b"}
+	botMember := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: bot.ID}
+	proj := &project.Project{ID: uuid.New(), OrganizationID: orgID, Key: "WEB"}
+	b := &board.Board{ID: uuid.New(), ProjectID: proj.ID, Name: "Sprint board"}
+	token := &metrics_embed_token.MetricsEmbedToken{ID: uuid.New(), BoardID: b.ID, CreatedBy: &ada.ID, ExpiresAt: now.AddDate(0, 1, 0)}
+	expires := now.AddDate(1, 0, 0)
+	personalToken := &api_token.APIToken{ID: uuid.New(), Name: "cli", Kind: api_token.KindPersonal, Scope: api_token.ScopeCardWrite, UserID: ada.ID, ExpiresAt: &expires}
+	serviceToken := &api_token.APIToken{ID: uuid.New(), Name: "deploy", Kind: api_token.KindService, Scope: api_token.ScopeAdmin, UserID: bot.ID, OrganizationID: &orgID}
+
+	orgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return([]*organization_member.OrganizationMember{adaMember, gusMember, botMember}, nil)
+	projectRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return([]*project.Project{proj}, nil)
+	rbacSvc.EXPECT().GetRolesForOrg(gomock.Any(), orgID).Return([]*role.Role{admin, viewer}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), ada.ID).Return(ada, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), gus.ID).Return(gus, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), bot.ID).Return(bot, nil)
+	rbacSvc.EXPECT().GetOrgMemberRole(gomock.Any(), adaMember.ID).Return(admin, nil)
+	rbacSvc.EXPECT().GetOrgMemberRole(gomock.Any(), gusMember.ID).Return(viewer, nil)
+	rbacSvc.EXPECT().GetOrgMemberRole(gomock.Any(), botMember.ID).Return(admin, nil)
+	apiTokenRepo.EXPECT().GetPersonalByUserID(gomock.Any(), ada.ID, now).Return([]*api_token.APIToken{personalToken}, nil)
+	apiTokenRepo.EXPECT().GetPersonalByUserID(gomock.Any(), gus.ID, now).Return(nil, nil)
+	apiTokenRepo.EXPECT().GetPersonalByUserID(gomock.Any(), bot.ID, now).Return(nil, nil)
+	apiTokenRepo.EXPECT().GetServiceByOrgID(gomock.Any(), orgID, now).Return([]*api_token.APIToken{serviceToken}, nil)
+	projectMemberRepo.EXPECT().GetByProjectID(gomock.Any(), proj.ID).Return([]*project_member.ProjectMember{
+		{ProjectID: proj.ID, UserID: ada.ID},
+		{ProjectID: proj.ID, UserID: gus.ID, RoleID: &admin.ID},
+	}, nil)
+	boardRepo.EXPECT().GetByProjectID(gomock.Any(), proj.ID).Return([]*board.Board{b}, nil)
+	tokenRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID, now).Return([]*metrics_embed_token.MetricsEmbedToken{token}, nil)
+
+	report, err := svc.GetReport(ctx, orgID)
+	require.NoError(t, err)
+	require.Len(t, report.Members, 3)
+
+	adaAccess, gusAccess, botAccess := report.Members[0], report.Members[1], report.Members[2]
+	assert.Equal(t, admin, adaAccess.OrgRole)
+	require.Len(t, adaAccess.Projects, 1)
+	assert.Nil(t, adaAccess.Projects[0].Role)
+	assert.Equal(t, admin, adaAccess.Projects[0].EffectiveRole(adaAccess))
+	assert.Empty(t, adaAccess.RestrictedBoards)
+	assert.Equal(t, []*metrics_embed_token.MetricsEmbedToken{token}, adaAccess.EmbedTokens)
+	assert.Equal(t, []*api_token.APIToken{personalToken}, adaAccess.APITokens)
+
+	assert.Equal(t, viewer, gusAccess.OrgRole)
+	require.Len(t, gusAccess.Projects, 1)
+	assert.Equal(t, admin, gusAccess.Projects[0].Role)
+	assert.Equal(t, []*board.Board{b}, gusAccess.RestrictedBoards)
+	assert.Empty(t, gusAccess.EmbedTokens)
+	assert.Empty(t, gusAccess.APITokens)
+
+	assert.Empty(t, botAccess.Projects)
+	assert.Equal(t, []*api_token.APIToken{serviceToken}, botAccess.APITokens)
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, report.WriteCSV(&buf))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, csvHeader, rows[0])
+		assert.Equal(t, []string{ada.ID.String(), "ada", email, "Admin", "false", "WEB=Admin", "", b.ID.String() + " until 2026-04-10T12:00:00Z", "cli (personal, card_write) until 2027-03-10T12:00:00Z"}, rows[1])
+		assert.Equal(t, []string{gus.ID.String(), "gus", "", "Viewer", "true", "WEB=Admin", "Sprint board", "", ""}, rows[2])
+		assert.Equal(t, []string{bot.ID.String(), "service-1a2b", "", "Admin", "false", "", "", "", "deploy (service, admin)"}, rows[3])
+	})
+}
@@ -0,0 +1,103 @@
+package presence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DragRate is how many drags per second a source may publish
+	DragRate = 10
+	// DragBurst is how many drags a source may publish at once before DragRate applies
+	DragBurst = 5
+	// dragBuffer is how many drags a slow watcher may fall behind before drags are dropped
+	dragBuffer = 16
+)
+
+// Drag is an in-progress card drag. Drags are ephemeral: they're only sent to current
+// watchers and never stored.
+type Drag struct {
+	BoardID uuid.UUID
+	UserID  uuid.UUID
+	CardID  uuid.UUID
+	// ColumnID is the column the card hovers over; nil when the drag ended or was cancelled
+	ColumnID *uuid.UUID
+	// AfterCardID is the card the dragged card would be dropped after; nil for the top
+	AfterCardID *uuid.UUID
+	SentAt      time.Time
+}
+
+// sourceLimiter is a source's drag rate limiter and when it was last used
+type sourceLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func (s *service) AllowDrag(ctx context.Context, source uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	l, ok := s.limiters[source]
+	if !ok {
+		l = &sourceLimiter{limiter: rate.NewLimiter(DragRate, DragBurst)}
+		s.limiters[source] = l
+	}
+	l.lastUsed = now
+	return l.limiter.AllowN(now, 1)
+}
+
+func (s *service) PublishDrag(ctx context.Context, drag Drag) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.boards[drag.BoardID]
+	if !ok {
+		return
+	}
+	drag.SentAt = s.now()
+	for ch, watcher := range b.dragWatchers {
+		if watcher == drag.UserID {
+			continue
+		}
+		// Drags are superseded quickly, so a watcher that falls behind just misses some
+		select {
+		case ch <- drag:
+		default:
+		}
+	}
+}
+
+func (s *service) WatchDrags(ctx context.Context, boardID, userID uuid.UUID) <-chan Drag {
+	ch := make(chan Drag, dragBuffer)
+
+	s.mu.Lock()
+	b := s.board(boardID)
+	b.dragWatchers[ch] = userID
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(b.dragWatchers, ch)
+		s.cleanup(boardID, b)
+		close(ch)
+	}()
+
+	return ch
+}
+
+// sweepLimiters forgets sources that haven't published since cutoff. Callers hold s.mu.
+func (s *service) sweepLimiters(cutoff time.Time) {
+	for source, l := range s.limiters {
+		if l.lastUsed.Before(cutoff) {
+			delete(s.limiters, source)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowDrag(t *testing.T) {
+	ctx := context.Background()
+	svc, c := setup()
+	source := uuid.New()
+
+	for i := 0; i < DragBurst; i++ {
+		assert.True(t, svc.AllowDrag(ctx, source), "drag %d within burst", i)
+	}
+	assert.False(t, svc.AllowDrag(ctx, source))
+
+	// Other sources have their own limit
+	assert.True(t, svc.AllowDrag(ctx, uuid.New()))
+
+	c.advance(time.Second / DragRate)
+	assert.True(t, svc.AllowDrag(ctx, source))
+	assert.False(t, svc.AllowDrag(ctx, source))
+
+	// Idle limiters are swept with expired viewers
+	c.advance(TTL + time.Second)
+	svc.Sweep(ctx)
+	assert.Empty(t, svc.limiters)
+}
+
+func TestPublishDrag(t *testing.T) {
+	boardID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	cardID := uuid.New()
+	columnID := uuid.New()
+
+	svc, c := setup()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	aliceDrags := svc.WatchDrags(ctx, boardID, alice)
+	bobDrags := svc.WatchDrags(ctx, boardID, bob)
+
+	svc.PublishDrag(context.Background(), Drag{BoardID: boardID, UserID: alice, CardID: cardID, ColumnID: &columnID})
+
+	select {
+	case drag := <-bobDrags:
+		assert.Equal(t, cardID, drag.CardID)
+		assert.Equal(t, &columnID, drag.ColumnID)
+		assert.Equal(t, c.now, drag.SentAt)
+	case <-time.After(time.Second):
+		t.Fatal("bob did not see alice's drag")
+	}
+
+	select {
+	case <-aliceDrags:
+		t.Fatal("alice received her own drag")
+	default:
+	}
+
+	// Drags on other boards aren't delivered
+	svc.PublishDrag(context.Background(), Drag{BoardID: uuid.New(), UserID: alice, CardID: cardID})
+	select {
+	case <-bobDrags:
+		t.Fatal("bob received a drag from another board")
+	default:
+	}
+
+	// Slow watchers drop drags instead of blocking publishers
+	for i := 0; i < dragBuffer+5; i++ {
+		svc.PublishDrag(context.Background(), Drag{BoardID: boardID, UserID: alice, CardID: cardID})
+	}
+	assert.Len(t, bobDrags, dragBuffer)
+
+	cancel()
+	for range bobDrags {
+	}
+	_, ok := <-aliceDrags
+	require.False(t, ok)
+	assert.Empty(t, svc.boards)
+}
@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: presence_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=presence_service.go -destination=mocks/presence_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	presence "github.com/thatcatdev/kaimu/backend/internal/services/presence"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AllowDrag mocks base method.
+func (m *MockService) AllowDrag(ctx context.Context, source uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllowDrag", ctx, source)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AllowDrag indicates an expected call of AllowDrag.
+func (mr *MockServiceMockRecorder) AllowDrag(ctx, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowDrag", reflect.TypeOf((*MockService)(nil).AllowDrag), ctx, source)
+}
+
+// GetViewers mocks base method.
+func (m *MockService) GetViewers(ctx context.Context, boardID uuid.UUID) []presence.Viewer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewers", ctx, boardID)
+	ret0, _ := ret[0].([]presence.Viewer)
+	return ret0
+}
+
+// GetViewers indicates an expected call of GetViewers.
+func (mr *MockServiceMockRecorder) GetViewers(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewers", reflect.TypeOf((*MockService)(nil).GetViewers), ctx, boardID)
+}
+
+// Heartbeat mocks base method.
+func (m *MockService) Heartbeat(ctx context.Context, boardID, userID uuid.UUID, activity presence.Activity) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Heartbeat", ctx, boardID, userID, activity)
+}
+
+// Heartbeat indicates an expected call of Heartbeat.
+func (mr *MockServiceMockRecorder) Heartbeat(ctx, boardID, userID, activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockService)(nil).Heartbeat), ctx, boardID, userID, activity)
+}
+
+// Leave mocks base method.
+func (m *MockService) Leave(ctx context.Context, boardID, userID uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Leave", ctx, boardID, userID)
+}
+
+// Leave indicates an expected call of Leave.
+func (mr *MockServiceMockRecorder) Leave(ctx, boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Leave", reflect.TypeOf((*MockService)(nil).Leave), ctx, boardID, userID)
+}
+
+// PublishDrag mocks base method.
+func (m *MockService) PublishDrag(ctx context.Context, drag presence.Drag) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishDrag", ctx, drag)
+}
+
+// PublishDrag indicates an expected call of PublishDrag.
+func (mr *MockServiceMockRecorder) PublishDrag(ctx, drag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDrag", reflect.TypeOf((*MockService)(nil).PublishDrag), ctx, drag)
+}
+
+// Sweep mocks base method.
+func (m *MockService) Sweep(ctx context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockServiceMockRecorder) Sweep(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockService)(nil).Sweep), ctx)
+}
+
+// Watch mocks base method.
+func (m *MockService) Watch(ctx context.Context, boardID, userID uuid.UUID) <-chan []presence.Viewer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, boardID, userID)
+	ret0, _ := ret[0].(<-chan []presence.Viewer)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockServiceMockRecorder) Watch(ctx, boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockService)(nil).Watch), ctx, boardID, userID)
+}
+
+// WatchDrags mocks base method.
+func (m *MockService) WatchDrags(ctx context.Context, boardID, userID uuid.UUID) <-chan presence.Drag {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchDrags", ctx, boardID, userID)
+	ret0, _ := ret[0].(<-chan presence.Drag)
+	return ret0
+}
+
+// WatchDrags indicates an expected call of WatchDrags.
+func (mr *MockServiceMockRecorder) WatchDrags(ctx, boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDrags", reflect.TypeOf((*MockService)(nil).WatchDrags), ctx, boardID, userID)
+}
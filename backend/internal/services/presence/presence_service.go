@@ -0,0 +1,242 @@
+package presence
+
+//go:generate mockgen -source=presence_service.go -destination=mocks/presence_service_mock.go -package=mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TTL is how long a user stays present on a board after their last heartbeat. Users with an
+// open boardPresence subscription stay present until it closes.
+const TTL = 45 * time.Second
+
+type Activity string
+
+const (
+	ActivityViewing Activity = "viewing"
+	ActivityEditing Activity = "editing"
+)
+
+// Viewer is a user currently present on a board
+type Viewer struct {
+	UserID     uuid.UUID
+	Activity   Activity
+	LastSeenAt time.Time
+}
+
+// Service tracks who is looking at each board. State is held in memory, so presence is
+// per API instance.
+type Service interface {
+	// Heartbeat marks the user present on the board until TTL passes without another heartbeat
+	Heartbeat(ctx context.Context, boardID, userID uuid.UUID, activity Activity)
+	// Leave removes the user from the board unless a subscription still holds them there
+	Leave(ctx context.Context, boardID, userID uuid.UUID)
+	GetViewers(ctx context.Context, boardID uuid.UUID) []Viewer
+	// Watch marks the user present for as long as ctx lives and streams the board's viewers,
+	// starting with the current ones, every time they change. Only the latest list is kept
+	// for slow readers. The channel is closed once ctx is done.
+	Watch(ctx context.Context, boardID, userID uuid.UUID) <-chan []Viewer
+	// AllowDrag reports whether source (usually a connection) may publish another drag. Each
+	// source may publish DragRate drags per second, with bursts of DragBurst.
+	AllowDrag(ctx context.Context, source uuid.UUID) bool
+	// PublishDrag broadcasts an in-progress card drag to the board's drag watchers
+	PublishDrag(ctx context.Context, drag Drag)
+	// WatchDrags streams drags on the board by users other than userID until ctx is done.
+	// Drags are dropped for readers that fall too far behind.
+	WatchDrags(ctx context.Context, boardID, userID uuid.UUID) <-chan Drag
+	// Sweep drops viewers whose heartbeats have expired and returns how many it dropped
+	Sweep(ctx context.Context) int
+}
+
+type entry struct {
+	activity Activity
+	lastSeen time.Time
+	// watches counts the user's open Watch calls on the board
+	watches int
+}
+
+type board struct {
+	viewers  map[uuid.UUID]*entry
+	watchers map[chan []Viewer]struct{}
+	// dragWatchers maps each drag channel to the user watching it
+	dragWatchers map[chan Drag]uuid.UUID
+}
+
+type service struct {
+	mu     sync.Mutex
+	boards map[uuid.UUID]*board
+	// limiters rate limits drags per source
+	limiters map[uuid.UUID]*sourceLimiter
+	now      func() time.Time
+}
+
+func NewService() Service {
+	return &service{
+		boards:   make(map[uuid.UUID]*board),
+		limiters: make(map[uuid.UUID]*sourceLimiter),
+		now:      time.Now,
+	}
+}
+
+func (s *service) Heartbeat(ctx context.Context, boardID, userID uuid.UUID, activity Activity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.board(boardID)
+	e, ok := b.viewers[userID]
+	if !ok {
+		e = &entry{}
+		b.viewers[userID] = e
+	}
+	changed := !ok || e.activity != activity
+	e.activity = activity
+	e.lastSeen = s.now()
+
+	if changed {
+		s.broadcast(b)
+	}
+}
+
+func (s *service) Leave(ctx context.Context, boardID, userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.boards[boardID]
+	if !ok {
+		return
+	}
+	e, ok := b.viewers[userID]
+	if !ok || e.watches > 0 {
+		return
+	}
+	delete(b.viewers, userID)
+	s.broadcast(b)
+}
+
+func (s *service) GetViewers(ctx context.Context, boardID uuid.UUID) []Viewer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.boards[boardID]
+	if !ok {
+		return []Viewer{}
+	}
+	return s.snapshot(b)
+}
+
+func (s *service) Watch(ctx context.Context, boardID, userID uuid.UUID) <-chan []Viewer {
+	ch := make(chan []Viewer, 1)
+
+	s.mu.Lock()
+	b := s.board(boardID)
+	e, ok := b.viewers[userID]
+	if !ok {
+		e = &entry{activity: ActivityViewing}
+		b.viewers[userID] = e
+	}
+	e.watches++
+	e.lastSeen = s.now()
+	b.watchers[ch] = struct{}{}
+	if ok {
+		ch <- s.snapshot(b)
+	} else {
+		s.broadcast(b)
+	}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(b.watchers, ch)
+		if e, ok := b.viewers[userID]; ok {
+			e.watches--
+			// Closing the subscription counts as the user's last sighting
+			e.lastSeen = s.now()
+		}
+		s.cleanup(boardID, b)
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (s *service) Sweep(ctx context.Context) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := s.now().Add(-TTL)
+	swept := 0
+	for boardID, b := range s.boards {
+		removed := false
+		for userID, e := range b.viewers {
+			if e.watches == 0 && e.lastSeen.Before(cutoff) {
+				delete(b.viewers, userID)
+				removed = true
+				swept++
+			}
+		}
+		if removed {
+			s.broadcast(b)
+		}
+		s.cleanup(boardID, b)
+	}
+	s.sweepLimiters(cutoff)
+	return swept
+}
+
+// board returns the board's state, creating it if needed. Callers hold s.mu.
+func (s *service) board(boardID uuid.UUID) *board {
+	b, ok := s.boards[boardID]
+	if !ok {
+		b = &board{
+			viewers:      make(map[uuid.UUID]*entry),
+			watchers:     make(map[chan []Viewer]struct{}),
+			dragWatchers: make(map[chan Drag]uuid.UUID),
+		}
+		s.boards[boardID] = b
+	}
+	return b
+}
+
+// cleanup forgets boards nobody is present on or watching. Callers hold s.mu.
+func (s *service) cleanup(boardID uuid.UUID, b *board) {
+	if len(b.viewers) == 0 && len(b.watchers) == 0 && len(b.dragWatchers) == 0 && s.boards[boardID] == b {
+		delete(s.boards, boardID)
+	}
+}
+
+// broadcast sends the board's viewers to every watcher, replacing any list a watcher has not
+// read yet. Callers hold s.mu.
+func (s *service) broadcast(b *board) {
+	viewers := s.snapshot(b)
+	for ch := range b.watchers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- viewers
+	}
+}
+
+// snapshot lists the board's viewers, most recently seen first. Callers hold s.mu.
+func (s *service) snapshot(b *board) []Viewer {
+	viewers := make([]Viewer, 0, len(b.viewers))
+	for userID, e := range b.viewers {
+		viewers = append(viewers, Viewer{UserID: userID, Activity: e.activity, LastSeenAt: e.lastSeen})
+	}
+	sort.Slice(viewers, func(i, j int) bool {
+		if !viewers[i].LastSeenAt.Equal(viewers[j].LastSeenAt) {
+			return viewers[i].LastSeenAt.After(viewers[j].LastSeenAt)
+		}
+		return viewers[i].UserID.String() < viewers[j].UserID.String()
+	})
+	return viewers
+}
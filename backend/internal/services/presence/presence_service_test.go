@@ -0,0 +1,161 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func setup() (*service, *clock) {
+	c := &clock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	svc := NewService().(*service)
+	svc.now = func() time.Time { return c.now }
+	return svc, c
+}
+
+func receive(t *testing.T, ch <-chan []Viewer) []Viewer {
+	t.Helper()
+	select {
+	case viewers, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return viewers
+	case <-time.After(time.Second):
+		t.Fatal("no presence update")
+		return nil
+	}
+}
+
+func userIDs(viewers []Viewer) []uuid.UUID {
+	ids := make([]uuid.UUID, len(viewers))
+	for i, v := range viewers {
+		ids[i] = v.UserID
+	}
+	return ids
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctx := context.Background()
+	boardID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	t.Run("viewers expire after TTL without heartbeats", func(t *testing.T) {
+		svc, c := setup()
+
+		svc.Heartbeat(ctx, boardID, alice, ActivityViewing)
+		c.advance(10 * time.Second)
+		svc.Heartbeat(ctx, boardID, bob, ActivityEditing)
+
+		viewers := svc.GetViewers(ctx, boardID)
+		assert.Equal(t, []uuid.UUID{bob, alice}, userIDs(viewers))
+		assert.Equal(t, ActivityEditing, viewers[0].Activity)
+
+		c.advance(TTL - 5*time.Second)
+		assert.Equal(t, 1, svc.Sweep(ctx))
+		assert.Equal(t, []uuid.UUID{bob}, userIDs(svc.GetViewers(ctx, boardID)))
+
+		c.advance(10 * time.Second)
+		assert.Equal(t, 1, svc.Sweep(ctx))
+		assert.Empty(t, svc.GetViewers(ctx, boardID))
+		assert.Empty(t, svc.boards)
+	})
+
+	t.Run("another heartbeat extends presence", func(t *testing.T) {
+		svc, c := setup()
+
+		svc.Heartbeat(ctx, boardID, alice, ActivityViewing)
+		c.advance(TTL - time.Second)
+		svc.Heartbeat(ctx, boardID, alice, ActivityViewing)
+		c.advance(TTL - time.Second)
+
+		assert.Equal(t, 0, svc.Sweep(ctx))
+		assert.Len(t, svc.GetViewers(ctx, boardID), 1)
+	})
+
+	t.Run("leave removes the viewer", func(t *testing.T) {
+		svc, _ := setup()
+
+		svc.Heartbeat(ctx, boardID, alice, ActivityViewing)
+		svc.Leave(ctx, boardID, alice)
+
+		assert.Empty(t, svc.GetViewers(ctx, boardID))
+	})
+}
+
+func TestWatch(t *testing.T) {
+	boardID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+
+	t.Run("subscribing marks the user present and streams changes", func(t *testing.T) {
+		svc, _ := setup()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		updates := svc.Watch(ctx, boardID, alice)
+		assert.Equal(t, []uuid.UUID{alice}, userIDs(receive(t, updates)))
+
+		svc.Heartbeat(context.Background(), boardID, bob, ActivityEditing)
+		assert.ElementsMatch(t, []uuid.UUID{alice, bob}, userIDs(receive(t, updates)))
+
+		svc.Leave(context.Background(), boardID, bob)
+		assert.Equal(t, []uuid.UUID{alice}, userIDs(receive(t, updates)))
+	})
+
+	t.Run("subscribed users outlive the TTL and leave", func(t *testing.T) {
+		svc, c := setup()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		updates := svc.Watch(ctx, boardID, alice)
+		receive(t, updates)
+
+		c.advance(2 * TTL)
+		assert.Equal(t, 0, svc.Sweep(context.Background()))
+		svc.Leave(context.Background(), boardID, alice)
+		assert.Len(t, svc.GetViewers(context.Background(), boardID), 1)
+
+		cancel()
+		_, ok := <-updates
+		assert.False(t, ok)
+
+		// Closing the subscription starts the TTL
+		c.advance(TTL + time.Second)
+		assert.Equal(t, 1, svc.Sweep(context.Background()))
+		assert.Empty(t, svc.boards)
+	})
+
+	t.Run("slow readers only get the latest viewers", func(t *testing.T) {
+		svc, _ := setup()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		updates := svc.Watch(ctx, boardID, alice)
+		svc.Heartbeat(context.Background(), boardID, bob, ActivityViewing)
+		svc.Heartbeat(context.Background(), boardID, bob, ActivityEditing)
+
+		viewers := receive(t, updates)
+		require.Len(t, viewers, 2)
+		for _, v := range viewers {
+			if v.UserID == bob {
+				assert.Equal(t, ActivityEditing, v.Activity)
+			}
+		}
+		select {
+		case <-updates:
+			t.Fatal("unexpected stale update")
+		default:
+		}
+	})
+}
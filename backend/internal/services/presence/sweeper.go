@@ -0,0 +1,41 @@
+package presence
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultSweepInterval is how often the sweeper drops expired viewers
+const DefaultSweepInterval = 15 * time.Second
+
+// Sweeper runs Service.Sweep in the background
+type Sweeper struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewSweeper(svc Service, interval time.Duration) *Sweeper {
+	return &Sweeper{svc: svc, interval: interval}
+}
+
+// Run sweeps expired viewers every interval until ctx is cancelled
+func (s *Sweeper) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if swept := s.svc.Sweep(ctx); swept > 0 {
+			log.Debug().Int("swept", swept).Msg("Dropped expired board viewers")
+		}
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/board"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -22,7 +25,8 @@ var (
 )
 
 type Service interface {
-	CreateProject(ctx context.Context, orgID uuid.UUID, name, key, description string) (*project.Project, error)
+	// CreateProject creates the project together with its default board in one transaction
+	CreateProject(ctx context.Context, orgID uuid.UUID, name, key, description string, createdBy *uuid.UUID) (*project.Project, error)
 	GetProject(ctx context.Context, id uuid.UUID) (*project.Project, error)
 	GetProjectByKey(ctx context.Context, orgID uuid.UUID, key string) (*project.Project, error)
 	GetOrgProjects(ctx context.Context, orgID uuid.UUID) ([]*project.Project, error)
@@ -34,12 +38,18 @@ type Service interface {
 type service struct {
 	projectRepo project.Repository
 	orgRepo     organization.Repository
+	boardSvc    board.Service
+	txManager   transaction.Manager
+	bus         events.Bus
 }
 
-func NewService(projectRepo project.Repository, orgRepo organization.Repository) Service {
+func NewService(projectRepo project.Repository, orgRepo organization.Repository, boardSvc board.Service, txManager transaction.Manager, bus events.Bus) Service {
 	return &service{
 		projectRepo: projectRepo,
 		orgRepo:     orgRepo,
+		boardSvc:    boardSvc,
+		txManager:   txManager,
+		bus:         bus,
 	}
 }
 
@@ -69,7 +79,7 @@ func validateKey(key string) error {
 	return nil
 }
 
-func (s *service) CreateProject(ctx context.Context, orgID uuid.UUID, name, key, description string) (*project.Project, error) {
+func (s *service) CreateProject(ctx context.Context, orgID uuid.UUID, name, key, description string, createdBy *uuid.UUID) (*project.Project, error) {
 	ctx, span := s.startServiceSpan(ctx, "CreateProject")
 	span.SetAttributes(
 		attribute.String("project.name", name),
@@ -111,13 +121,30 @@ func (s *service) CreateProject(ctx context.Context, orgID uuid.UUID, name, key,
 		Description:    description,
 	}
 
-	if err := s.projectRepo.Create(ctx, proj); err != nil {
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.Create(ctx, proj); err != nil {
+			return err
+		}
+		if err := s.publishProjectEvent(ctx, events.ProjectCreated, proj); err != nil {
+			return err
+		}
+		_, err := s.boardSvc.CreateDefaultBoard(ctx, proj.ID, createdBy)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return proj, nil
 }
 
+func (s *service) publishProjectEvent(ctx context.Context, name events.Name, proj *project.Project) error {
+	return s.bus.Publish(ctx, events.New(ctx, name, events.ProjectPayload{
+		ProjectID:      proj.ID,
+		OrganizationID: proj.OrganizationID,
+	}))
+}
+
 func (s *service) GetProject(ctx context.Context, id uuid.UUID) (*project.Project, error) {
 	ctx, span := s.startServiceSpan(ctx, "GetProject")
 	span.SetAttributes(attribute.String("project.id", id.String()))
@@ -164,7 +191,13 @@ func (s *service) UpdateProject(ctx context.Context, proj *project.Project) (*pr
 	span.SetAttributes(attribute.String("project.id", proj.ID.String()))
 	defer span.End()
 
-	if err := s.projectRepo.Update(ctx, proj); err != nil {
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.Update(ctx, proj); err != nil {
+			return err
+		}
+		return s.publishProjectEvent(ctx, events.ProjectUpdated, proj)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return proj, nil
@@ -175,7 +208,20 @@ func (s *service) DeleteProject(ctx context.Context, id uuid.UUID) error {
 	span.SetAttributes(attribute.String("project.id", id.String()))
 	defer span.End()
 
-	return s.projectRepo.Delete(ctx, id)
+	proj, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.publishProjectEvent(ctx, events.ProjectDeleted, proj)
+	})
 }
 
 func (s *service) GetOrganization(ctx context.Context, projectID uuid.UUID) (*organization.Organization, error) {
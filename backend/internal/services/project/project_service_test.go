@@ -2,16 +2,24 @@ package project
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
 	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	txMocks "github.com/thatcatdev/kaimu/backend/internal/db/transaction/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -23,7 +31,9 @@ func TestCreateProject_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	repos := fakes.NewRepositories()
+	boardSvc := boardService.NewService(repos.Boards, repos.Columns, mockProjectRepo, transaction.NewNoopManager(), events.NewSyncBus())
+	svc := NewService(mockProjectRepo, mockOrgRepo, boardSvc, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	org := &organization.Organization{
@@ -44,13 +54,56 @@ func TestCreateProject_Success(t *testing.T) {
 		return nil
 	})
 
-	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "test", "A test project")
+	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "test", "A test project", nil)
 
 	require.NoError(t, err)
 	assert.NotNil(t, proj)
 	assert.Equal(t, "Test Project", proj.Name)
 	assert.Equal(t, "TEST", proj.Key) // Should be uppercase
 	assert.Equal(t, orgID, proj.OrganizationID)
+
+	// Default board and columns are created with the project
+	defaultBoard, err := repos.Boards.GetDefaultByProjectID(context.Background(), proj.ID)
+	require.NoError(t, err)
+	columns, err := repos.Columns.GetByBoardID(context.Background(), defaultBoard.ID)
+	require.NoError(t, err)
+	assert.Len(t, columns, 4)
+}
+
+func TestCreateProject_DefaultBoardFailureRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockTxManager := txMocks.NewMockManager(ctrl)
+
+	boardSvc := boardService.NewService(mockBoardRepo, mockColumnRepo, mockProjectRepo, mockTxManager, events.NewSyncBus())
+	svc := NewService(mockProjectRepo, mockOrgRepo, boardSvc, mockTxManager, events.NewSyncBus())
+
+	orgID := uuid.New()
+	columnErr := errors.New("insert failed")
+
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID}, nil)
+	mockProjectRepo.EXPECT().GetByKey(gomock.Any(), orgID, "TEST").Return(nil, gorm.ErrRecordNotFound)
+
+	// Both the project and the nested board creation run inside the unit of work
+	mockTxManager.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(2)
+	mockProjectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockBoardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockColumnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(columnErr)
+
+	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "TEST", "A test project", nil)
+
+	assert.ErrorIs(t, err, columnErr)
+	assert.Nil(t, proj)
 }
 
 func TestCreateProject_KeyTaken(t *testing.T) {
@@ -60,7 +113,7 @@ func TestCreateProject_KeyTaken(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	org := &organization.Organization{
@@ -80,7 +133,7 @@ func TestCreateProject_KeyTaken(t *testing.T) {
 	// Key is already taken
 	mockProjectRepo.EXPECT().GetByKey(gomock.Any(), orgID, "TEST").Return(existingProject, nil)
 
-	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "TEST", "A test project")
+	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "TEST", "A test project", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrKeyTaken, err)
@@ -94,14 +147,14 @@ func TestCreateProject_OrgNotFound(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
 	// Organization doesn't exist
 	mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(nil, gorm.ErrRecordNotFound)
 
-	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "TEST", "A test project")
+	proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", "TEST", "A test project", nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrOrgNotFound, err)
@@ -115,7 +168,7 @@ func TestCreateProject_InvalidKey(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
@@ -131,7 +184,7 @@ func TestCreateProject_InvalidKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", tt.key, "A test project")
+			proj, err := svc.CreateProject(context.Background(), orgID, "Test Project", tt.key, "A test project", nil)
 
 			assert.Error(t, err)
 			assert.Equal(t, ErrInvalidKey, err)
@@ -147,7 +200,7 @@ func TestGetProject_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
 	expectedProject := &project.Project{
@@ -173,7 +226,7 @@ func TestGetProject_NotFound(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
 
@@ -193,7 +246,7 @@ func TestGetProjectByKey_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	expectedProject := &project.Project{
@@ -219,7 +272,7 @@ func TestGetProjectByKey_NotFound(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
@@ -239,7 +292,7 @@ func TestGetOrgProjects_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 	expectedProjects := []*project.Project{
@@ -262,7 +315,7 @@ func TestGetOrgProjects_Empty(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	orgID := uuid.New()
 
@@ -281,7 +334,7 @@ func TestUpdateProject_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	proj := &project.Project{
 		ID:          uuid.New(),
@@ -306,10 +359,12 @@ func TestDeleteProject_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
+	orgID := uuid.New()
 
+	mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
 	mockProjectRepo.EXPECT().Delete(gomock.Any(), projectID).Return(nil)
 
 	err := svc.DeleteProject(context.Background(), projectID)
@@ -317,6 +372,24 @@ func TestDeleteProject_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDeleteProject_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+	projectID := uuid.New()
+
+	mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := svc.DeleteProject(context.Background(), projectID)
+
+	assert.ErrorIs(t, err, ErrProjectNotFound)
+}
+
 func TestGetOrganization_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -324,7 +397,7 @@ func TestGetOrganization_Success(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
 	orgID := uuid.New()
@@ -356,7 +429,7 @@ func TestGetOrganization_ProjectNotFound(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
 
@@ -376,7 +449,7 @@ func TestGetOrganization_OrgNotFound(t *testing.T) {
 	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
 	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockProjectRepo, mockOrgRepo)
+	svc := NewService(mockProjectRepo, mockOrgRepo, nil, transaction.NewNoopManager(), events.NewSyncBus())
 
 	projectID := uuid.New()
 	orgID := uuid.New()
@@ -0,0 +1,121 @@
+package projectexport
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+)
+
+const (
+	// Format identifies a project archive
+	Format = "kaimu.project-export"
+	// FormatVersion is the version of the archives written. Readers should ignore fields they
+	// don't know; bump it when a field changes meaning or goes away.
+	FormatVersion = 1
+)
+
+// Archive is everything a project holds, for backing it up or moving it to another tool.
+// Rows keep their IDs, and refer to each other and to users by them.
+type Archive struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Project    Project   `json:"project"`
+	// Users are the users the archive refers to, without their emails
+	Users  []User  `json:"users"`
+	Tags   []Tag   `json:"tags"`
+	Boards []Board `json:"boards"`
+	// Cards include archived and merged ones, ordered by number
+	Cards []Card `json:"cards"`
+}
+
+type Project struct {
+	ID          uuid.UUID `json:"id"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type User struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"displayName,omitempty"`
+}
+
+type Tag struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Description string    `json:"description,omitempty"`
+}
+
+type Board struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	IsDefault   bool       `json:"isDefault,omitempty"`
+	CreatedBy   *uuid.UUID `json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	// Columns are in board order
+	Columns []Column `json:"columns"`
+	Sprints []Sprint `json:"sprints"`
+}
+
+type Column struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Color      string    `json:"color,omitempty"`
+	IsBacklog  bool      `json:"isBacklog,omitempty"`
+	IsHidden   bool      `json:"isHidden,omitempty"`
+	IsDone     bool      `json:"isDone,omitempty"`
+	WipLimit   *int      `json:"wipLimit,omitempty"`
+	PointLimit *int      `json:"pointLimit,omitempty"`
+}
+
+type Sprint struct {
+	ID        uuid.UUID           `json:"id"`
+	Name      string              `json:"name"`
+	Goal      string              `json:"goal,omitempty"`
+	Status    sprint.SprintStatus `json:"status"`
+	StartDate *time.Time          `json:"startDate,omitempty"`
+	EndDate   *time.Time          `json:"endDate,omitempty"`
+	CreatedBy *uuid.UUID          `json:"createdBy,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
+
+type Card struct {
+	ID       uuid.UUID `json:"id"`
+	Key      string    `json:"key"`
+	Number   int       `json:"number"`
+	BoardID  uuid.UUID `json:"boardId"`
+	ColumnID uuid.UUID `json:"columnId"`
+	// Position orders the cards of a column, lowest first
+	Position    float64           `json:"position"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Priority    card.CardPriority `json:"priority"`
+	StoryPoints *int              `json:"storyPoints,omitempty"`
+	DueDate     *time.Time        `json:"dueDate,omitempty"`
+	AssigneeID  *uuid.UUID        `json:"assigneeId,omitempty"`
+	CreatedBy   *uuid.UUID        `json:"createdBy,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	ArchivedAt  *time.Time        `json:"archivedAt,omitempty"`
+	// MergedIntoID is the card this one was merged into as a duplicate
+	MergedIntoID *uuid.UUID  `json:"mergedIntoId,omitempty"`
+	TagIDs       []uuid.UUID `json:"tagIds"`
+	SprintIDs    []uuid.UUID `json:"sprintIds"`
+	Comments     []Comment   `json:"comments"`
+}
+
+type Comment struct {
+	ID uuid.UUID `json:"id"`
+	// AuthorID is left out once the author's account is deleted
+	AuthorID  *uuid.UUID `json:"authorId,omitempty"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+}
@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: projectexport_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=projectexport_service.go -destination=mocks/projectexport_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	project_export "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Cleanup mocks base method.
+func (m *MockService) Cleanup(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cleanup", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cleanup indicates an expected call of Cleanup.
+func (mr *MockServiceMockRecorder) Cleanup(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockService)(nil).Cleanup), ctx)
+}
+
+// DownloadURL mocks base method.
+func (m *MockService) DownloadURL(ctx context.Context, export *project_export.ProjectExport) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadURL", ctx, export)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadURL indicates an expected call of DownloadURL.
+func (mr *MockServiceMockRecorder) DownloadURL(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadURL", reflect.TypeOf((*MockService)(nil).DownloadURL), ctx, export)
+}
+
+// GetExport mocks base method.
+func (m *MockService) GetExport(ctx context.Context, id uuid.UUID) (*project_export.ProjectExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, id)
+	ret0, _ := ret[0].(*project_export.ProjectExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockServiceMockRecorder) GetExport(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockService)(nil).GetExport), ctx, id)
+}
+
+// GetProjectExports mocks base method.
+func (m *MockService) GetProjectExports(ctx context.Context, projectID uuid.UUID) ([]*project_export.ProjectExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectExports", ctx, projectID)
+	ret0, _ := ret[0].([]*project_export.ProjectExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectExports indicates an expected call of GetProjectExports.
+func (mr *MockServiceMockRecorder) GetProjectExports(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectExports", reflect.TypeOf((*MockService)(nil).GetProjectExports), ctx, projectID)
+}
+
+// ProcessNext mocks base method.
+func (m *MockService) ProcessNext(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessNext", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessNext indicates an expected call of ProcessNext.
+func (mr *MockServiceMockRecorder) ProcessNext(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessNext", reflect.TypeOf((*MockService)(nil).ProcessNext), ctx)
+}
+
+// RequestExport mocks base method.
+func (m *MockService) RequestExport(ctx context.Context, projectID uuid.UUID, requestedBy *uuid.UUID) (*project_export.ProjectExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExport", ctx, projectID, requestedBy)
+	ret0, _ := ret[0].(*project_export.ProjectExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestExport indicates an expected call of RequestExport.
+func (mr *MockServiceMockRecorder) RequestExport(ctx, projectID, requestedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExport", reflect.TypeOf((*MockService)(nil).RequestExport), ctx, projectID, requestedBy)
+}
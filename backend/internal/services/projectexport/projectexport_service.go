@@ -0,0 +1,531 @@
+package projectexport
+
+//go:generate mockgen -source=projectexport_service.go -destination=mocks/projectexport_service_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStorageDisabled = errors.New("project exports need object storage, which is not enabled on this server")
+	ErrProjectNotFound = errors.New("project not found")
+	ErrExportNotFound  = errors.New("project export not found")
+	ErrTooManyCards    = fmt.Errorf("project has more than %d cards to export", MaxCards)
+)
+
+const (
+	// MaxCards caps how many cards an archive holds
+	MaxCards = 10000
+	// MaxAttempts is how often an export is tried before it is marked failed
+	MaxAttempts = 3
+	// Lease is how long a worker may build one export before another may take it over
+	Lease = 15 * time.Minute
+	// DownloadExpiry is how long a download URL of a completed export is valid
+	DownloadExpiry = time.Hour
+	// Retention is how long exports and their archives are kept
+	Retention = 7 * 24 * time.Hour
+	// KeyPrefix is where archives are stored
+	KeyPrefix = "project-exports/"
+	// listLimit caps how many of a project's exports are listed
+	listLimit = 20
+	// cleanupBatch is how many expired exports one Cleanup pass deletes
+	cleanupBatch = 100
+)
+
+type Service interface {
+	// RequestExport queues an export of the project for the worker to build. While the
+	// project has an export queued or running, that export is returned instead.
+	RequestExport(ctx context.Context, projectID uuid.UUID, requestedBy *uuid.UUID) (*project_export.ProjectExport, error)
+	GetExport(ctx context.Context, id uuid.UUID) (*project_export.ProjectExport, error)
+	// GetProjectExports returns the project's recent exports, newest first
+	GetProjectExports(ctx context.Context, projectID uuid.UUID) ([]*project_export.ProjectExport, error)
+	// DownloadURL returns a URL the archive of a completed export can be downloaded from
+	// until the returned time
+	DownloadURL(ctx context.Context, export *project_export.ProjectExport) (string, time.Time, error)
+	// ProcessNext builds and stores the archive of the next queued export. It reports false
+	// when no export was waiting. Failures are recorded on the export, and only errors
+	// recording them are returned.
+	ProcessNext(ctx context.Context) (bool, error)
+	// Cleanup deletes exports older than Retention with their archives and returns how many
+	// it deleted
+	Cleanup(ctx context.Context) (int, error)
+}
+
+type service struct {
+	exportRepo  project_export.Repository
+	projectRepo project.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	cardRepo    card.Repository
+	cardTagRepo card_tag.Repository
+	tagRepo     tag.Repository
+	sprintRepo  sprint.Repository
+	commentRepo comment.Repository
+	userRepo    user.Repository
+	store       storage.Store
+	now         func() time.Time
+}
+
+// NewService returns the project export service; store is nil when object storage is not
+// configured
+func NewService(
+	exportRepo project_export.Repository,
+	projectRepo project.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+	cardTagRepo card_tag.Repository,
+	tagRepo tag.Repository,
+	sprintRepo sprint.Repository,
+	commentRepo comment.Repository,
+	userRepo user.Repository,
+	store storage.Store,
+) Service {
+	return &service{
+		exportRepo:  exportRepo,
+		projectRepo: projectRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		cardRepo:    cardRepo,
+		cardTagRepo: cardTagRepo,
+		tagRepo:     tagRepo,
+		sprintRepo:  sprintRepo,
+		commentRepo: commentRepo,
+		userRepo:    userRepo,
+		store:       store,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "projectexport.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "projectexport"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) RequestExport(ctx context.Context, projectID uuid.UUID, requestedBy *uuid.UUID) (*project_export.ProjectExport, error) {
+	ctx, span := s.startServiceSpan(ctx, "RequestExport")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if s.store == nil {
+		return nil, ErrStorageDisabled
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	queued, err := s.exportRepo.GetQueuedByProjectID(ctx, projectID)
+	if err == nil {
+		return queued, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	export := &project_export.ProjectExport{
+		ProjectID:   projectID,
+		RequestedBy: requestedBy,
+		Status:      project_export.StatusPending,
+	}
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *service) GetExport(ctx context.Context, id uuid.UUID) (*project_export.ProjectExport, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetExport")
+	span.SetAttributes(attribute.String("project_export.id", id.String()))
+	defer span.End()
+
+	export, err := s.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *service) GetProjectExports(ctx context.Context, projectID uuid.UUID) ([]*project_export.ProjectExport, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetProjectExports")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	return s.exportRepo.GetByProjectID(ctx, projectID, listLimit)
+}
+
+func (s *service) DownloadURL(ctx context.Context, export *project_export.ProjectExport) (string, time.Time, error) {
+	ctx, span := s.startServiceSpan(ctx, "DownloadURL")
+	span.SetAttributes(attribute.String("project_export.id", export.ID.String()))
+	defer span.End()
+
+	if s.store == nil {
+		return "", time.Time{}, ErrStorageDisabled
+	}
+	expiresAt := s.now().Add(DownloadExpiry)
+	url, err := s.store.PresignGet(ctx, export.StorageKey, filename(export.StorageKey), DownloadExpiry)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return url, expiresAt, nil
+}
+
+func (s *service) ProcessNext(ctx context.Context) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "ProcessNext")
+	defer span.End()
+
+	if s.store == nil {
+		return false, nil
+	}
+	export, err := s.exportRepo.ClaimNext(ctx, Lease)
+	if err != nil || export == nil {
+		return false, err
+	}
+	span.SetAttributes(
+		attribute.String("project_export.id", export.ID.String()),
+		attribute.String("project.id", export.ProjectID.String()),
+	)
+
+	key, size, cards, err := s.build(ctx, export)
+	if err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).
+			Str("project_export_id", export.ID.String()).
+			Int("attempts", export.Attempts).
+			Msg("Project export failed")
+
+		message := err.Error()
+		export.Error = &message
+		export.LeaseUntil = nil
+		export.Status = project_export.StatusPending
+		// Missing projects and oversized ones fail the same way every time
+		if export.Attempts >= MaxAttempts || errors.Is(err, ErrProjectNotFound) || errors.Is(err, ErrTooManyCards) {
+			now := s.now()
+			export.Status = project_export.StatusFailed
+			export.CompletedAt = &now
+		}
+		return true, s.exportRepo.Update(ctx, export)
+	}
+
+	now := s.now()
+	export.Status = project_export.StatusCompleted
+	export.StorageKey = key
+	export.SizeBytes = size
+	export.CardCount = cards
+	export.Error = nil
+	export.LeaseUntil = nil
+	export.CompletedAt = &now
+	return true, s.exportRepo.Update(ctx, export)
+}
+
+// build writes the archive of the export's project to the store and returns its key, size
+// and card count
+func (s *service) build(ctx context.Context, export *project_export.ProjectExport) (string, int64, int, error) {
+	archive, err := s.archive(ctx, export.ProjectID)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	body, err := json.Marshal(archive)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	name := fmt.Sprintf("%s-export-%s.json", strings.ToLower(archive.Project.Key), archive.ExportedAt.UTC().Format("20060102"))
+	key := KeyPrefix + export.ID.String() + "/" + name
+	if err := s.store.Put(ctx, key, "application/json", body); err != nil {
+		return "", 0, 0, fmt.Errorf("storing archive: %w", err)
+	}
+	return key, int64(len(body)), len(archive.Cards), nil
+}
+
+// archive gathers everything the project holds
+func (s *service) archive(ctx context.Context, projectID uuid.UUID) (*Archive, error) {
+	p, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	archive := &Archive{
+		Format:     Format,
+		Version:    FormatVersion,
+		ExportedAt: s.now(),
+		Project: Project{
+			ID:          p.ID,
+			Key:         p.Key,
+			Name:        p.Name,
+			Description: p.Description,
+			CreatedAt:   p.CreatedAt,
+		},
+		Users:  []User{},
+		Tags:   []Tag{},
+		Boards: []Board{},
+		Cards:  []Card{},
+	}
+	users := newUserCollector(s.userRepo)
+
+	tags, err := s.tagRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		archive.Tags = append(archive.Tags, Tag{ID: t.ID, Name: t.Name, Color: t.Color, Description: t.Description})
+	}
+
+	boards, err := s.boardRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var cards []*card.Card
+	sprintIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, b := range boards {
+		exported := Board{
+			ID:          b.ID,
+			Name:        b.Name,
+			Description: b.Description,
+			IsDefault:   b.IsDefault,
+			CreatedBy:   b.CreatedBy,
+			CreatedAt:   b.CreatedAt,
+			Columns:     []Column{},
+			Sprints:     []Sprint{},
+		}
+		users.add(b.CreatedBy)
+
+		columns, err := s.columnRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+		for _, col := range columns {
+			exported.Columns = append(exported.Columns, Column{
+				ID:         col.ID,
+				Name:       col.Name,
+				Color:      col.Color,
+				IsBacklog:  col.IsBacklog,
+				IsHidden:   col.IsHidden,
+				IsDone:     col.IsDone,
+				WipLimit:   col.WipLimit,
+				PointLimit: col.PointLimit,
+			})
+		}
+
+		sprints, err := s.sprintRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sp := range sprints {
+			exported.Sprints = append(exported.Sprints, Sprint{
+				ID:        sp.ID,
+				Name:      sp.Name,
+				Goal:      sp.Goal,
+				Status:    sp.Status,
+				StartDate: sp.StartDate,
+				EndDate:   sp.EndDate,
+				CreatedBy: sp.CreatedBy,
+				CreatedAt: sp.CreatedAt,
+			})
+			users.add(sp.CreatedBy)
+			sprintCards, err := s.cardRepo.GetBySprintID(ctx, sp.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range sprintCards {
+				sprintIDs[c.ID] = append(sprintIDs[c.ID], sp.ID)
+			}
+		}
+		archive.Boards = append(archive.Boards, exported)
+
+		active, err := s.cardRepo.GetByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		archived, err := s.cardRepo.GetArchivedByBoardID(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, active...)
+		cards = append(cards, archived...)
+		if len(cards) > MaxCards {
+			return nil, ErrTooManyCards
+		}
+	}
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Number < cards[j].Number })
+
+	cardIDs := make([]uuid.UUID, len(cards))
+	for i, c := range cards {
+		cardIDs[i] = c.ID
+	}
+	cardTags, err := s.cardTagRepo.GetByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, ct := range cardTags {
+		tagIDs[ct.CardID] = append(tagIDs[ct.CardID], ct.TagID)
+	}
+	comments, err := s.commentRepo.GetByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	commentsByCard := make(map[uuid.UUID][]Comment)
+	for _, cm := range comments {
+		commentsByCard[cm.CardID] = append(commentsByCard[cm.CardID], Comment{
+			ID:        cm.ID,
+			AuthorID:  cm.AuthorID,
+			Body:      cm.Body,
+			CreatedAt: cm.CreatedAt,
+			EditedAt:  cm.EditedAt,
+		})
+		users.add(cm.AuthorID)
+	}
+
+	for _, c := range cards {
+		exported := Card{
+			ID:           c.ID,
+			Key:          cardService.Key(p.Key, c.Number),
+			Number:       c.Number,
+			BoardID:      c.BoardID,
+			ColumnID:     c.ColumnID,
+			Position:     c.Position,
+			Title:        c.Title,
+			Description:  c.Description,
+			Priority:     c.Priority,
+			StoryPoints:  c.StoryPoints,
+			DueDate:      c.DueDate,
+			AssigneeID:   c.AssigneeID,
+			CreatedBy:    c.CreatedBy,
+			CreatedAt:    c.CreatedAt,
+			UpdatedAt:    c.UpdatedAt,
+			ArchivedAt:   c.ArchivedAt,
+			MergedIntoID: c.MergedIntoID,
+			TagIDs:       nonNil(tagIDs[c.ID]),
+			SprintIDs:    nonNil(sprintIDs[c.ID]),
+			Comments:     commentsByCard[c.ID],
+		}
+		if exported.Comments == nil {
+			exported.Comments = []Comment{}
+		}
+		users.add(c.AssigneeID)
+		users.add(c.CreatedBy)
+		archive.Cards = append(archive.Cards, exported)
+	}
+
+	if archive.Users, err = users.lookup(ctx); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (s *service) Cleanup(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Cleanup")
+	defer span.End()
+
+	expired, err := s.exportRepo.GetFinishedBefore(ctx, s.now().Add(-Retention), cleanupBatch)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, export := range expired {
+		if export.StorageKey != "" && s.store != nil {
+			if err := s.store.Delete(ctx, export.StorageKey); err != nil {
+				return deleted, err
+			}
+		}
+		if err := s.exportRepo.Delete(ctx, export.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	span.SetAttributes(attribute.Int("project_export.deleted", deleted))
+	return deleted, nil
+}
+
+// filename is the name an archive downloads as: the last part of its key
+func filename(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
+
+func nonNil(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
+
+// userCollector gathers the users an archive refers to, in order of first reference
+type userCollector struct {
+	userRepo user.Repository
+	seen     map[uuid.UUID]bool
+	ids      []uuid.UUID
+}
+
+func newUserCollector(userRepo user.Repository) *userCollector {
+	return &userCollector{userRepo: userRepo, seen: make(map[uuid.UUID]bool)}
+}
+
+func (c *userCollector) add(id *uuid.UUID) {
+	if id == nil || c.seen[*id] {
+		return
+	}
+	c.seen[*id] = true
+	c.ids = append(c.ids, *id)
+}
+
+// lookup returns the users added, leaving out deleted ones
+func (c *userCollector) lookup(ctx context.Context) ([]User, error) {
+	users := []User{}
+	for _, id := range c.ids {
+		u, err := c.userRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		exported := User{ID: u.ID, Username: u.Username}
+		if u.DisplayName != nil {
+			exported.DisplayName = *u.DisplayName
+		}
+		users = append(users, exported)
+	}
+	return users, nil
+}
@@ -0,0 +1,370 @@
+package projectexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment"
+	commentMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/comment/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export"
+	exportMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_export/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	storageMocks "github.com/thatcatdev/kaimu/backend/internal/services/storage/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var testNow = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func TestRequestExport(t *testing.T) {
+	ctx := context.Background()
+	p := &project.Project{ID: uuid.New(), Key: "KAI"}
+	requester := uuid.New()
+
+	t.Run("queues an export", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockExportRepo.EXPECT().GetQueuedByProjectID(gomock.Any(), p.ID).Return(nil, gorm.ErrRecordNotFound)
+		mockExportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		export, err := svc.RequestExport(ctx, p.ID, &requester)
+		require.NoError(t, err)
+		assert.Equal(t, project_export.StatusPending, export.Status)
+		assert.Equal(t, &requester, export.RequestedBy)
+	})
+
+	t.Run("returns the export already queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		queued := &project_export.ProjectExport{ID: uuid.New(), ProjectID: p.ID, Status: project_export.StatusRunning}
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockExportRepo.EXPECT().GetQueuedByProjectID(gomock.Any(), p.ID).Return(queued, nil)
+
+		export, err := svc.RequestExport(ctx, p.ID, &requester)
+		require.NoError(t, err)
+		assert.Equal(t, queued, export)
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.RequestExport(ctx, p.ID, &requester)
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("storage disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		svc.store = nil
+
+		_, err := svc.RequestExport(ctx, p.ID, &requester)
+		assert.ErrorIs(t, err, ErrStorageDisabled)
+	})
+}
+
+func TestProcessNext(t *testing.T) {
+	ctx := context.Background()
+	p := &project.Project{ID: uuid.New(), Key: "KAI", Name: "Kaimu"}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID, Name: "Main", IsDefault: true}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "To Do", Position: 1}
+	backlog := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Backlog", Position: 0, IsBacklog: true}
+	sp := &sprint.Sprint{ID: uuid.New(), BoardID: b.ID, Name: "Sprint 1", Status: sprint.SprintStatusActive}
+	bug := &tag.Tag{ID: uuid.New(), ProjectID: p.ID, Name: "bug", Color: "#EF4444"}
+	ana := &user.User{ID: uuid.New(), Username: "ana"}
+	gone := uuid.New()
+	archivedAt := testNow.Add(-time.Hour)
+	first := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Number: 1, Title: "First", AssigneeID: &ana.ID, CreatedBy: &gone}
+	second := &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Number: 2, Title: "Second", ArchivedAt: &archivedAt}
+	note := &comment.Comment{ID: uuid.New(), CardID: first.ID, AuthorID: &ana.ID, Body: "<p>Done?</p>"}
+
+	expectProject := func(mockProjectRepo *projectMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, mockColumnRepo *columnMocks.MockRepository, mockCardRepo *cardMocks.MockRepository, mockTagRepo *tagMocks.MockRepository, mockSprintRepo *sprintMocks.MockRepository) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockTagRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).Return([]*tag.Tag{bug}, nil)
+		mockBoardRepo.EXPECT().GetByProjectID(gomock.Any(), p.ID).Return([]*board.Board{b}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{todo, backlog}, nil)
+		mockSprintRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*sprint.Sprint{sp}, nil)
+		mockCardRepo.EXPECT().GetBySprintID(gomock.Any(), sp.ID).Return([]*card.Card{first}, nil)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*card.Card{first}, nil)
+		mockCardRepo.EXPECT().GetArchivedByBoardID(gomock.Any(), b.ID).Return([]*card.Card{second}, nil)
+	}
+
+	t.Run("stores the project's archive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		export := &project_export.ProjectExport{ID: uuid.New(), ProjectID: p.ID, Status: project_export.StatusRunning, Attempts: 1}
+		mockExportRepo.EXPECT().ClaimNext(gomock.Any(), Lease).Return(export, nil)
+		expectProject(mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockTagRepo, mockSprintRepo)
+		mockCardTagRepo.EXPECT().GetByCardIDs(gomock.Any(), []uuid.UUID{first.ID, second.ID}).
+			Return([]*card_tag.CardTag{{CardID: second.ID, TagID: bug.ID}}, nil)
+		mockCommentRepo.EXPECT().GetByCardIDs(gomock.Any(), []uuid.UUID{first.ID, second.ID}).Return([]*comment.Comment{note}, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), ana.ID).Return(ana, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), gone).Return(nil, gorm.ErrRecordNotFound)
+
+		var key string
+		var body []byte
+		mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), "application/json", gomock.Any()).
+			DoAndReturn(func(_ context.Context, k, _ string, b []byte) error {
+				key, body = k, b
+				return nil
+			})
+		mockExportRepo.EXPECT().Update(gomock.Any(), export).Return(nil)
+
+		processed, err := svc.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Equal(t, project_export.StatusCompleted, export.Status)
+		assert.Equal(t, KeyPrefix+export.ID.String()+"/kai-export-20260501.json", key)
+		assert.Equal(t, int64(len(body)), export.SizeBytes)
+		assert.Equal(t, 2, export.CardCount)
+		assert.Equal(t, &testNow, export.CompletedAt)
+
+		var archive Archive
+		require.NoError(t, json.Unmarshal(body, &archive))
+		assert.Equal(t, Format, archive.Format)
+		assert.Equal(t, "Kaimu", archive.Project.Name)
+		assert.Equal(t, []User{{ID: ana.ID, Username: "ana"}}, archive.Users)
+		require.Len(t, archive.Boards, 1)
+		assert.Equal(t, "Backlog", archive.Boards[0].Columns[0].Name)
+		assert.Equal(t, sp.ID, archive.Boards[0].Sprints[0].ID)
+
+		require.Len(t, archive.Cards, 2)
+		assert.Equal(t, "KAI-1", archive.Cards[0].Key)
+		assert.Equal(t, []uuid.UUID{sp.ID}, archive.Cards[0].SprintIDs)
+		assert.Equal(t, "<p>Done?</p>", archive.Cards[0].Comments[0].Body)
+		assert.Equal(t, []uuid.UUID{bug.ID}, archive.Cards[1].TagIDs)
+		assert.Empty(t, archive.Cards[1].SprintIDs)
+		assert.NotNil(t, archive.Cards[1].ArchivedAt)
+	})
+
+	t.Run("retries a failed export", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		export := &project_export.ProjectExport{ID: uuid.New(), ProjectID: p.ID, Status: project_export.StatusRunning, Attempts: 1}
+		mockExportRepo.EXPECT().ClaimNext(gomock.Any(), Lease).Return(export, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(nil, errors.New("connection reset"))
+		mockExportRepo.EXPECT().Update(gomock.Any(), export).Return(nil)
+
+		processed, err := svc.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Equal(t, project_export.StatusPending, export.Status)
+		assert.Equal(t, "connection reset", *export.Error)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		export := &project_export.ProjectExport{ID: uuid.New(), ProjectID: p.ID, Status: project_export.StatusRunning, Attempts: MaxAttempts}
+		mockExportRepo.EXPECT().ClaimNext(gomock.Any(), Lease).Return(export, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(nil, errors.New("connection reset"))
+		mockExportRepo.EXPECT().Update(gomock.Any(), export).Return(nil)
+
+		_, err := svc.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, project_export.StatusFailed, export.Status)
+		assert.Equal(t, &testNow, export.CompletedAt)
+	})
+
+	t.Run("a deleted project fails at once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		export := &project_export.ProjectExport{ID: uuid.New(), ProjectID: p.ID, Status: project_export.StatusRunning, Attempts: 1}
+		mockExportRepo.EXPECT().ClaimNext(gomock.Any(), Lease).Return(export, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(nil, gorm.ErrRecordNotFound)
+		mockExportRepo.EXPECT().Update(gomock.Any(), export).Return(nil)
+
+		_, err := svc.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, project_export.StatusFailed, export.Status)
+	})
+
+	t.Run("nothing queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExportRepo := exportMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+			mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return testNow }
+
+		mockExportRepo.EXPECT().ClaimNext(gomock.Any(), Lease).Return(nil, nil)
+
+		processed, err := svc.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockExportRepo := exportMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+	mockTagRepo := tagMocks.NewMockRepository(ctrl)
+	mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+	mockCommentRepo := commentMocks.NewMockRepository(ctrl)
+	mockUserRepo := userMocks.NewMockRepository(ctrl)
+	mockStore := storageMocks.NewMockStore(ctrl)
+	svc := NewService(mockExportRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo,
+		mockTagRepo, mockSprintRepo, mockCommentRepo, mockUserRepo, mockStore).(*service)
+	svc.now = func() time.Time { return testNow }
+
+	done := &project_export.ProjectExport{ID: uuid.New(), Status: project_export.StatusCompleted, StorageKey: KeyPrefix + "a/kai-export.json"}
+	failed := &project_export.ProjectExport{ID: uuid.New(), Status: project_export.StatusFailed}
+	mockExportRepo.EXPECT().GetFinishedBefore(gomock.Any(), testNow.Add(-Retention), cleanupBatch).
+		Return([]*project_export.ProjectExport{done, failed}, nil)
+	mockStore.EXPECT().Delete(gomock.Any(), done.StorageKey).Return(nil)
+	mockExportRepo.EXPECT().Delete(gomock.Any(), done.ID).Return(nil)
+	mockExportRepo.EXPECT().Delete(gomock.Any(), failed.ID).Return(nil)
+
+	deleted, err := svc.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
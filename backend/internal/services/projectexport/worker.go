@@ -0,0 +1,64 @@
+package projectexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultPollInterval is how often the worker looks for queued exports
+const DefaultPollInterval = 5 * time.Second
+
+// cleanupInterval is how often expired exports are deleted
+const cleanupInterval = time.Hour
+
+// Worker builds queued project exports in the background, one at a time
+type Worker struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewWorker(svc Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Worker{svc: svc, interval: interval}
+}
+
+// Run builds exports until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		// Keep going while exports are waiting so a queue drains quickly
+		for ctx.Err() == nil {
+			processed, err := w.svc.ProcessNext(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to process project export")
+				break
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-cleanup.C:
+			deleted, err := w.svc.Cleanup(ctx)
+			if err != nil {
+				log.Error().Err(err).Int("deleted", deleted).Msg("Failed to clean up project exports")
+			} else if deleted > 0 {
+				log.Info().Int("deleted", deleted).Msg("Deleted expired project exports")
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// memorySweepInterval is how often buckets that have refilled are forgotten
+const memorySweepInterval = time.Minute
+
+type memoryBucket struct {
+	limiter *rate.Limiter
+	// full is when the bucket will have refilled if no more requests take from it
+	full time.Time
+}
+
+// memoryStore keeps the buckets of one API instance
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newMemoryStore(now func() time.Time) *memoryStore {
+	return &memoryStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     now,
+	}
+}
+
+func (m *memoryStore) take(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.lastSweep) >= memorySweepInterval {
+		m.sweep(now)
+	}
+
+	b, ok := m.buckets[key]
+	if !ok || b.limiter.Burst() != limit.Burst || b.limiter.Limit() != rate.Limit(limit.perSecond()) {
+		b = &memoryBucket{limiter: rate.NewLimiter(rate.Limit(limit.perSecond()), limit.Burst)}
+		m.buckets[key] = b
+	}
+
+	allowed := b.limiter.AllowN(now, 1)
+	tokens := b.limiter.TokensAt(now)
+	b.full = now.Add(limit.wait(tokens, float64(limit.Burst)))
+	return result(limit, allowed, tokens), nil
+}
+
+// sweep forgets buckets that are full again, which are the same as new ones. Callers hold
+// m.mu.
+func (m *memoryStore) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if !b.full.After(now) {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastSweep = now
+}
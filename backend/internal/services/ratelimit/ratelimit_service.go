@@ -0,0 +1,137 @@
+package ratelimit
+
+//go:generate mockgen -source=ratelimit_service.go -destination=mocks/ratelimit_service_mock.go -package=mocks
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// redisWarnInterval is how often a failing Redis is logged while requests fall back to the
+// instance's own buckets
+const redisWarnInterval = time.Minute
+
+// Limit is a token bucket: Burst requests at once, refilled at PerMinute a minute. Both
+// must be positive.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// PerMinute returns a limit of perMinute requests a minute, with bursts of burst
+func PerMinute(perMinute, burst int) Limit {
+	return Limit{PerMinute: perMinute, Burst: burst}
+}
+
+// perSecond is the rate the bucket refills at, in tokens a second
+func (l Limit) perSecond() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+// wait returns how long the bucket takes to refill from tokens to want
+func (l Limit) wait(tokens, want float64) time.Duration {
+	return time.Duration((want - tokens) / l.perSecond() * float64(time.Second))
+}
+
+// Result is the state of a bucket after a request tried to take a token from it
+type Result struct {
+	Allowed bool
+	// Limit is the bucket's burst
+	Limit int
+	// Remaining is how many more requests the bucket allows now
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until a denied request would be allowed; 0 when allowed
+	RetryAfter time.Duration
+}
+
+// result builds the Result of a request that left tokens in the bucket
+func result(limit Limit, allowed bool, tokens float64) Result {
+	r := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     limit.wait(tokens, float64(limit.Burst)),
+	}
+	if !allowed {
+		r.RetryAfter = limit.wait(tokens, 1)
+	}
+	return r
+}
+
+// store holds token buckets by key
+type store interface {
+	take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Service rate limits requests with token buckets
+type Service interface {
+	// Allow takes a token from key's bucket, which is created full. Buckets live in Redis when
+	// the service has a client, shared by every API instance; while Redis fails, each
+	// instance falls back to its own buckets.
+	Allow(ctx context.Context, key string, limit Limit) Result
+}
+
+type service struct {
+	redis  store
+	memory store
+
+	mu            sync.Mutex
+	lastRedisWarn time.Time
+	now           func() time.Time
+}
+
+// NewService creates a Service keeping its buckets in Redis, or in memory when client is nil
+func NewService(client redis.UniversalClient) Service {
+	s := &service{now: time.Now}
+	s.memory = newMemoryStore(func() time.Time { return s.now() })
+	if client != nil {
+		s.redis = newRedisStore(client)
+	}
+	return s
+}
+
+// NewRedisClient creates a client for the Redis at url, such as redis://localhost:6379/0. It
+// connects lazily, so Redis doesn't have to be up yet.
+func NewRedisClient(url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(opts), nil
+}
+
+func (s *service) Allow(ctx context.Context, key string, limit Limit) Result {
+	if s.redis != nil {
+		r, err := s.redis.take(ctx, key, limit)
+		if err == nil {
+			return r
+		}
+		s.warnRedis(ctx, err)
+	}
+
+	// The memory store never fails
+	r, _ := s.memory.take(ctx, key, limit)
+	return r
+}
+
+// warnRedis logs a Redis failure, at most once every redisWarnInterval
+func (s *service) warnRedis(ctx context.Context, err error) {
+	s.mu.Lock()
+	now := s.now()
+	if now.Sub(s.lastRedisWarn) < redisWarnInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastRedisWarn = now
+	s.mu.Unlock()
+
+	log := logger.FromCtx(ctx)
+	log.Warn().Err(err).Msg("Rate limiting falls back to in-memory buckets, Redis failed")
+}
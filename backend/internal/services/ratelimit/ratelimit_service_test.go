@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllow_Memory(t *testing.T) {
+	svc := NewService(nil).(*service)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := PerMinute(60, 3)
+
+	for i := 2; i >= 0; i-- {
+		r := svc.Allow(ctx, "ip:10.0.0.1", limit)
+		require.True(t, r.Allowed)
+		assert.Equal(t, 3, r.Limit)
+		assert.Equal(t, i, r.Remaining)
+		assert.Zero(t, r.RetryAfter)
+	}
+
+	r := svc.Allow(ctx, "ip:10.0.0.1", limit)
+	assert.False(t, r.Allowed)
+	assert.Equal(t, 0, r.Remaining)
+	assert.Equal(t, time.Second, r.RetryAfter)
+	assert.Equal(t, 3*time.Second, r.Reset)
+
+	t.Run("other keys have their own bucket", func(t *testing.T) {
+		assert.True(t, svc.Allow(ctx, "ip:10.0.0.2", limit).Allowed)
+	})
+
+	t.Run("refills at the rate", func(t *testing.T) {
+		now = now.Add(time.Second)
+		assert.True(t, svc.Allow(ctx, "ip:10.0.0.1", limit).Allowed)
+		assert.False(t, svc.Allow(ctx, "ip:10.0.0.1", limit).Allowed)
+	})
+
+	t.Run("forgets buckets once full", func(t *testing.T) {
+		now = now.Add(memorySweepInterval)
+		svc.Allow(ctx, "ip:10.0.0.3", limit)
+		memory := svc.memory.(*memoryStore)
+		assert.NotContains(t, memory.buckets, "ip:10.0.0.1")
+		assert.Contains(t, memory.buckets, "ip:10.0.0.3")
+	})
+}
+
+func TestAllow_Redis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	svc := NewService(client)
+	ctx := context.Background()
+	limit := PerMinute(60, 3)
+
+	for i := 2; i >= 0; i-- {
+		r := svc.Allow(ctx, "user:1", limit)
+		require.True(t, r.Allowed)
+		assert.Equal(t, i, r.Remaining)
+	}
+	r := svc.Allow(ctx, "user:1", limit)
+	assert.False(t, r.Allowed)
+	assert.Equal(t, time.Second, r.RetryAfter)
+	assert.Equal(t, 3*time.Second, r.Reset)
+	assert.True(t, mr.Exists(redisKeyPrefix+"user:1"))
+
+	mr.SetTime(now.Add(1500 * time.Millisecond))
+	r = svc.Allow(ctx, "user:1", limit)
+	assert.True(t, r.Allowed)
+	assert.Equal(t, 0, r.Remaining)
+
+	t.Run("buckets expire once full", func(t *testing.T) {
+		mr.FastForward(5 * time.Second)
+		assert.False(t, mr.Exists(redisKeyPrefix+"user:1"))
+	})
+}
+
+type failingStore struct{}
+
+func (failingStore) take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func TestAllow_RedisFailureFallsBackToMemory(t *testing.T) {
+	svc := NewService(nil).(*service)
+	svc.redis = failingStore{}
+	ctx := context.Background()
+	limit := PerMinute(60, 1)
+
+	assert.True(t, svc.Allow(ctx, "ip:10.0.0.1", limit).Allowed)
+	assert.False(t, svc.Allow(ctx, "ip:10.0.0.1", limit).Allowed)
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the buckets in a Redis that may be shared with other uses
+const redisKeyPrefix = "kaimu:ratelimit:"
+
+// takeScript refills the bucket in KEYS[1] for the time since it was last used, then takes a
+// token if there is one. It reads the time from Redis so every API instance agrees on it,
+// and expires the bucket once it would be full again. ARGV is the refill rate in tokens a
+// second and the burst; it returns whether a token was taken and the tokens left.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// redisStore keeps buckets in Redis, shared by every API instance
+type redisStore struct {
+	client redis.Scripter
+}
+
+func newRedisStore(client redis.Scripter) *redisStore {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) take(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, limit.perSecond(), limit.Burst).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, _ := reply[0].(int64)
+	tokensReply, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensReply, 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return result(limit, allowed == 1, tokens), nil
+}
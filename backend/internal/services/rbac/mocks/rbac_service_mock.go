@@ -0,0 +1,497 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rbac_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rbac_service.go -destination=mocks/rbac_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	organization_member "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	permission "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
+	project "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	project_member "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
+	role "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	user "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	rbac "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignOrgRole mocks base method.
+func (m *MockService) AssignOrgRole(ctx context.Context, orgID, userID, roleID uuid.UUID) (*organization_member.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOrgRole", ctx, orgID, userID, roleID)
+	ret0, _ := ret[0].(*organization_member.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignOrgRole indicates an expected call of AssignOrgRole.
+func (mr *MockServiceMockRecorder) AssignOrgRole(ctx, orgID, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrgRole", reflect.TypeOf((*MockService)(nil).AssignOrgRole), ctx, orgID, userID, roleID)
+}
+
+// AssignProjectRole mocks base method.
+func (m *MockService) AssignProjectRole(ctx context.Context, projectID, userID uuid.UUID, roleID *uuid.UUID) (*project_member.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignProjectRole", ctx, projectID, userID, roleID)
+	ret0, _ := ret[0].(*project_member.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignProjectRole indicates an expected call of AssignProjectRole.
+func (mr *MockServiceMockRecorder) AssignProjectRole(ctx, projectID, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProjectRole", reflect.TypeOf((*MockService)(nil).AssignProjectRole), ctx, projectID, userID, roleID)
+}
+
+// BulkAssignOrgRole mocks base method.
+func (m *MockService) BulkAssignOrgRole(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (*rbac.BulkRoleChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAssignOrgRole", ctx, orgID, userIDs, roleID)
+	ret0, _ := ret[0].(*rbac.BulkRoleChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAssignOrgRole indicates an expected call of BulkAssignOrgRole.
+func (mr *MockServiceMockRecorder) BulkAssignOrgRole(ctx, orgID, userIDs, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAssignOrgRole", reflect.TypeOf((*MockService)(nil).BulkAssignOrgRole), ctx, orgID, userIDs, roleID)
+}
+
+// CanManageUser mocks base method.
+func (m *MockService) CanManageUser(ctx context.Context, viewerID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageUser", ctx, viewerID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanManageUser indicates an expected call of CanManageUser.
+func (mr *MockServiceMockRecorder) CanManageUser(ctx, viewerID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageUser", reflect.TypeOf((*MockService)(nil).CanManageUser), ctx, viewerID, userID)
+}
+
+// CreateRole mocks base method.
+func (m *MockService) CreateRole(ctx context.Context, orgID uuid.UUID, name, description string, permissionCodes []string) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", ctx, orgID, name, description, permissionCodes)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockServiceMockRecorder) CreateRole(ctx, orgID, name, description, permissionCodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockService)(nil).CreateRole), ctx, orgID, name, description, permissionCodes)
+}
+
+// DeleteRole mocks base method.
+func (m *MockService) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockServiceMockRecorder) DeleteRole(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockService)(nil).DeleteRole), ctx, roleID)
+}
+
+// FilterVisibleProjects mocks base method.
+func (m *MockService) FilterVisibleProjects(ctx context.Context, userID, orgID uuid.UUID, projects []*project.Project) ([]*project.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterVisibleProjects", ctx, userID, orgID, projects)
+	ret0, _ := ret[0].([]*project.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterVisibleProjects indicates an expected call of FilterVisibleProjects.
+func (mr *MockServiceMockRecorder) FilterVisibleProjects(ctx, userID, orgID, projects any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterVisibleProjects", reflect.TypeOf((*MockService)(nil).FilterVisibleProjects), ctx, userID, orgID, projects)
+}
+
+// GetAllPermissions mocks base method.
+func (m *MockService) GetAllPermissions(ctx context.Context) ([]*permission.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPermissions", ctx)
+	ret0, _ := ret[0].([]*permission.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPermissions indicates an expected call of GetAllPermissions.
+func (mr *MockServiceMockRecorder) GetAllPermissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPermissions", reflect.TypeOf((*MockService)(nil).GetAllPermissions), ctx)
+}
+
+// GetOrgDirectory mocks base method.
+func (m *MockService) GetOrgDirectory(ctx context.Context, orgID uuid.UUID, query organization_member.DirectoryQuery) ([]*organization_member.DirectoryEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgDirectory", ctx, orgID, query)
+	ret0, _ := ret[0].([]*organization_member.DirectoryEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrgDirectory indicates an expected call of GetOrgDirectory.
+func (mr *MockServiceMockRecorder) GetOrgDirectory(ctx, orgID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgDirectory", reflect.TypeOf((*MockService)(nil).GetOrgDirectory), ctx, orgID, query)
+}
+
+// GetOrgGuests mocks base method.
+func (m *MockService) GetOrgGuests(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgGuests", ctx, orgID)
+	ret0, _ := ret[0].([]*organization_member.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgGuests indicates an expected call of GetOrgGuests.
+func (mr *MockServiceMockRecorder) GetOrgGuests(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgGuests", reflect.TypeOf((*MockService)(nil).GetOrgGuests), ctx, orgID)
+}
+
+// GetOrgMemberRole mocks base method.
+func (m *MockService) GetOrgMemberRole(ctx context.Context, memberID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgMemberRole", ctx, memberID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgMemberRole indicates an expected call of GetOrgMemberRole.
+func (mr *MockServiceMockRecorder) GetOrgMemberRole(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgMemberRole", reflect.TypeOf((*MockService)(nil).GetOrgMemberRole), ctx, memberID)
+}
+
+// GetOrgMemberUser mocks base method.
+func (m *MockService) GetOrgMemberUser(ctx context.Context, memberID uuid.UUID) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgMemberUser", ctx, memberID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgMemberUser indicates an expected call of GetOrgMemberUser.
+func (mr *MockServiceMockRecorder) GetOrgMemberUser(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgMemberUser", reflect.TypeOf((*MockService)(nil).GetOrgMemberUser), ctx, memberID)
+}
+
+// GetOrgMembers mocks base method.
+func (m *MockService) GetOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgMembers", ctx, orgID)
+	ret0, _ := ret[0].([]*organization_member.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgMembers indicates an expected call of GetOrgMembers.
+func (mr *MockServiceMockRecorder) GetOrgMembers(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgMembers", reflect.TypeOf((*MockService)(nil).GetOrgMembers), ctx, orgID)
+}
+
+// GetProjectMemberProject mocks base method.
+func (m *MockService) GetProjectMemberProject(ctx context.Context, memberID uuid.UUID) (*project.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectMemberProject", ctx, memberID)
+	ret0, _ := ret[0].(*project.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectMemberProject indicates an expected call of GetProjectMemberProject.
+func (mr *MockServiceMockRecorder) GetProjectMemberProject(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMemberProject", reflect.TypeOf((*MockService)(nil).GetProjectMemberProject), ctx, memberID)
+}
+
+// GetProjectMemberRole mocks base method.
+func (m *MockService) GetProjectMemberRole(ctx context.Context, memberID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectMemberRole", ctx, memberID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectMemberRole indicates an expected call of GetProjectMemberRole.
+func (mr *MockServiceMockRecorder) GetProjectMemberRole(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMemberRole", reflect.TypeOf((*MockService)(nil).GetProjectMemberRole), ctx, memberID)
+}
+
+// GetProjectMemberUser mocks base method.
+func (m *MockService) GetProjectMemberUser(ctx context.Context, memberID uuid.UUID) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectMemberUser", ctx, memberID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectMemberUser indicates an expected call of GetProjectMemberUser.
+func (mr *MockServiceMockRecorder) GetProjectMemberUser(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMemberUser", reflect.TypeOf((*MockService)(nil).GetProjectMemberUser), ctx, memberID)
+}
+
+// GetProjectMembers mocks base method.
+func (m *MockService) GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectMembers", ctx, projectID)
+	ret0, _ := ret[0].([]*project_member.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectMembers indicates an expected call of GetProjectMembers.
+func (mr *MockServiceMockRecorder) GetProjectMembers(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectMembers", reflect.TypeOf((*MockService)(nil).GetProjectMembers), ctx, projectID)
+}
+
+// GetRole mocks base method.
+func (m *MockService) GetRole(ctx context.Context, roleID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, roleID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockServiceMockRecorder) GetRole(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockService)(nil).GetRole), ctx, roleID)
+}
+
+// GetRolePermissions mocks base method.
+func (m *MockService) GetRolePermissions(ctx context.Context, roleID uuid.UUID) ([]*permission.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissions", ctx, roleID)
+	ret0, _ := ret[0].([]*permission.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissions indicates an expected call of GetRolePermissions.
+func (mr *MockServiceMockRecorder) GetRolePermissions(ctx, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissions", reflect.TypeOf((*MockService)(nil).GetRolePermissions), ctx, roleID)
+}
+
+// GetRolesForOrg mocks base method.
+func (m *MockService) GetRolesForOrg(ctx context.Context, orgID uuid.UUID) ([]*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesForOrg", ctx, orgID)
+	ret0, _ := ret[0].([]*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesForOrg indicates an expected call of GetRolesForOrg.
+func (mr *MockServiceMockRecorder) GetRolesForOrg(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesForOrg", reflect.TypeOf((*MockService)(nil).GetRolesForOrg), ctx, orgID)
+}
+
+// GetUserOrgPermissions mocks base method.
+func (m *MockService) GetUserOrgPermissions(ctx context.Context, userID, orgID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrgPermissions", ctx, userID, orgID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrgPermissions indicates an expected call of GetUserOrgPermissions.
+func (mr *MockServiceMockRecorder) GetUserOrgPermissions(ctx, userID, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrgPermissions", reflect.TypeOf((*MockService)(nil).GetUserOrgPermissions), ctx, userID, orgID)
+}
+
+// GetUserOrgRole mocks base method.
+func (m *MockService) GetUserOrgRole(ctx context.Context, orgID, userID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrgRole", ctx, orgID, userID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrgRole indicates an expected call of GetUserOrgRole.
+func (mr *MockServiceMockRecorder) GetUserOrgRole(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrgRole", reflect.TypeOf((*MockService)(nil).GetUserOrgRole), ctx, orgID, userID)
+}
+
+// GetUserProjectPermissions mocks base method.
+func (m *MockService) GetUserProjectPermissions(ctx context.Context, userID, projectID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProjectPermissions", ctx, userID, projectID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProjectPermissions indicates an expected call of GetUserProjectPermissions.
+func (mr *MockServiceMockRecorder) GetUserProjectPermissions(ctx, userID, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProjectPermissions", reflect.TypeOf((*MockService)(nil).GetUserProjectPermissions), ctx, userID, projectID)
+}
+
+// GetUserProjectRole mocks base method.
+func (m *MockService) GetUserProjectRole(ctx context.Context, projectID, userID uuid.UUID) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProjectRole", ctx, projectID, userID)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProjectRole indicates an expected call of GetUserProjectRole.
+func (mr *MockServiceMockRecorder) GetUserProjectRole(ctx, projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProjectRole", reflect.TypeOf((*MockService)(nil).GetUserProjectRole), ctx, projectID, userID)
+}
+
+// HasBoardPermission mocks base method.
+func (m *MockService) HasBoardPermission(ctx context.Context, userID, boardID uuid.UUID, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasBoardPermission", ctx, userID, boardID, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasBoardPermission indicates an expected call of HasBoardPermission.
+func (mr *MockServiceMockRecorder) HasBoardPermission(ctx, userID, boardID, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasBoardPermission", reflect.TypeOf((*MockService)(nil).HasBoardPermission), ctx, userID, boardID, arg3)
+}
+
+// HasOrgPermission mocks base method.
+func (m *MockService) HasOrgPermission(ctx context.Context, userID, orgID uuid.UUID, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOrgPermission", ctx, userID, orgID, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOrgPermission indicates an expected call of HasOrgPermission.
+func (mr *MockServiceMockRecorder) HasOrgPermission(ctx, userID, orgID, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOrgPermission", reflect.TypeOf((*MockService)(nil).HasOrgPermission), ctx, userID, orgID, arg3)
+}
+
+// HasProjectPermission mocks base method.
+func (m *MockService) HasProjectPermission(ctx context.Context, userID, projectID uuid.UUID, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasProjectPermission", ctx, userID, projectID, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasProjectPermission indicates an expected call of HasProjectPermission.
+func (mr *MockServiceMockRecorder) HasProjectPermission(ctx, userID, projectID, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasProjectPermission", reflect.TypeOf((*MockService)(nil).HasProjectPermission), ctx, userID, projectID, arg3)
+}
+
+// RemoveOrgMember mocks base method.
+func (m *MockService) RemoveOrgMember(ctx context.Context, orgID, userID, actorID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrgMember", ctx, orgID, userID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOrgMember indicates an expected call of RemoveOrgMember.
+func (mr *MockServiceMockRecorder) RemoveOrgMember(ctx, orgID, userID, actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrgMember", reflect.TypeOf((*MockService)(nil).RemoveOrgMember), ctx, orgID, userID, actorID)
+}
+
+// RemoveProjectMember mocks base method.
+func (m *MockService) RemoveProjectMember(ctx context.Context, projectID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProjectMember", ctx, projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProjectMember indicates an expected call of RemoveProjectMember.
+func (mr *MockServiceMockRecorder) RemoveProjectMember(ctx, projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProjectMember", reflect.TypeOf((*MockService)(nil).RemoveProjectMember), ctx, projectID, userID)
+}
+
+// UpdateRole mocks base method.
+func (m *MockService) UpdateRole(ctx context.Context, roleID uuid.UUID, name, description *string, permissionCodes []string) (*role.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, roleID, name, description, permissionCodes)
+	ret0, _ := ret[0].(*role.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockServiceMockRecorder) UpdateRole(ctx, roleID, name, description, permissionCodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockService)(nil).UpdateRole), ctx, roleID, name, description, permissionCodes)
+}
@@ -5,6 +5,7 @@ package rbac
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
@@ -15,6 +16,7 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -28,8 +30,51 @@ var (
 	ErrCannotDeleteOwner  = errors.New("cannot delete owner role assignment")
 	ErrLastOwner          = errors.New("cannot remove the last owner")
 	ErrInvalidPermission  = errors.New("invalid permission code")
+	ErrTooManyMembers     = errors.New("too many members for one role change")
 )
 
+// MaxBulkRoleChanges is the most members one BulkAssignOrgRole call may change
+const MaxBulkRoleChanges = 200
+
+// MemberRoleChangeStatus is the outcome of one member's role change in a bulk change
+type MemberRoleChangeStatus string
+
+const (
+	// MemberRoleChanged is a member that now has the role
+	MemberRoleChanged MemberRoleChangeStatus = "changed"
+	// MemberRoleUnchanged is a member that already had the role
+	MemberRoleUnchanged MemberRoleChangeStatus = "unchanged"
+	// MemberRoleNotMember is a user that is not a member of the organization
+	MemberRoleNotMember MemberRoleChangeStatus = "not_member"
+	// MemberRoleLastOwner is an owner whose demotion would leave the organization without one
+	MemberRoleLastOwner MemberRoleChangeStatus = "last_owner"
+	// MemberRoleRolledBack is a member that could take the role, left as it was because
+	// another member could not
+	MemberRoleRolledBack MemberRoleChangeStatus = "rolled_back"
+)
+
+// MemberRoleChange is what a bulk role change did to one of the users
+type MemberRoleChange struct {
+	UserID uuid.UUID
+	Status MemberRoleChangeStatus
+	// Member is the membership as it is after the change, nil for MemberRoleNotMember
+	Member *organization_member.OrganizationMember
+	// PreviousRoleID is the member's role before the change
+	PreviousRoleID *uuid.UUID
+}
+
+// BulkRoleChange is the outcome of BulkAssignOrgRole. Applied is only set when every user
+// could take the role; otherwise no member changed.
+type BulkRoleChange struct {
+	Applied bool
+	// Changes has one entry per user, in the order they were given
+	Changes []*MemberRoleChange
+}
+
+// GuestOrgPermissions are all a guest may do at organization level. In the projects they
+// were added to, guests have the permissions of their project role, or else of their org role.
+var GuestOrgPermissions = []string{"org:view"}
+
 type Service interface {
 	// Permission checks
 	HasOrgPermission(ctx context.Context, userID, orgID uuid.UUID, permission string) (bool, error)
@@ -37,6 +82,12 @@ type Service interface {
 	HasBoardPermission(ctx context.Context, userID, boardID uuid.UUID, permission string) (bool, error)
 	GetUserOrgPermissions(ctx context.Context, userID, orgID uuid.UUID) ([]string, error)
 	GetUserProjectPermissions(ctx context.Context, userID, projectID uuid.UUID) ([]string, error)
+	// CanManageUser reports whether the viewer holds org:manage in an organization the user
+	// belongs to
+	CanManageUser(ctx context.Context, viewerID, userID uuid.UUID) (bool, error)
+	// FilterVisibleProjects narrows projects of an organization to those the user may see:
+	// all of them for members, only the ones they were added to for guests
+	FilterVisibleProjects(ctx context.Context, userID, orgID uuid.UUID, projects []*project.Project) ([]*project.Project, error)
 
 	// Role queries
 	GetAllPermissions(ctx context.Context) ([]*permission.Permission, error)
@@ -51,12 +102,19 @@ type Service interface {
 
 	// Role assignments
 	AssignOrgRole(ctx context.Context, orgID, userID, roleID uuid.UUID) (*organization_member.OrganizationMember, error)
+	// BulkAssignOrgRole gives many members of an organization the role at once: all of
+	// them, or none when one of them cannot take it
+	BulkAssignOrgRole(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (*BulkRoleChange, error)
 	AssignProjectRole(ctx context.Context, projectID, userID uuid.UUID, roleID *uuid.UUID) (*project_member.ProjectMember, error)
 	GetUserOrgRole(ctx context.Context, orgID, userID uuid.UUID) (*role.Role, error)
 	GetUserProjectRole(ctx context.Context, projectID, userID uuid.UUID) (*role.Role, error)
 
 	// Member queries
 	GetOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error)
+	GetOrgGuests(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error)
+	// GetOrgDirectory searches, sorts and pages the members of an organization, returning
+	// the page and the total number of matching members
+	GetOrgDirectory(ctx context.Context, orgID uuid.UUID, query organization_member.DirectoryQuery) ([]*organization_member.DirectoryEntry, int64, error)
 	GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error)
 	RemoveOrgMember(ctx context.Context, orgID, userID, actorID uuid.UUID) error
 	RemoveProjectMember(ctx context.Context, projectID, userID uuid.UUID) error
@@ -140,6 +198,31 @@ func (s *service) HasOrgPermission(ctx context.Context, userID, orgID uuid.UUID,
 	return false, nil
 }
 
+func (s *service) CanManageUser(ctx context.Context, viewerID, userID uuid.UUID) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "CanManageUser")
+	span.SetAttributes(
+		attribute.String("viewer.id", viewerID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	memberships, err := s.orgMemberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, m := range memberships {
+		canManage, err := s.HasOrgPermission(ctx, viewerID, m.OrganizationID, "org:manage")
+		if err != nil {
+			return false, err
+		}
+		if canManage {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // HasProjectPermission checks if a user has a specific permission in a project
 func (s *service) HasProjectPermission(ctx context.Context, userID, projectID uuid.UUID, permissionCode string) (bool, error) {
 	ctx, span := s.startServiceSpan(ctx, "HasProjectPermission")
@@ -205,26 +288,53 @@ func (s *service) GetUserOrgPermissions(ctx context.Context, userID, orgID uuid.
 		return nil, err
 	}
 
-	// Get the role ID (prefer RoleID, fall back to legacy Role field)
-	var roleID uuid.UUID
-	if member.RoleID != nil {
-		roleID = *member.RoleID
-	} else {
-		// Legacy fallback
-		switch member.Role {
-		case "owner":
-			roleID = role.OwnerRoleID
-		case "admin":
-			roleID = role.AdminRoleID
-		case "member":
-			roleID = role.MemberRoleID
-		default:
-			roleID = role.ViewerRoleID
-		}
+	if member.IsGuest {
+		return tokenScoped(ctx, append([]string(nil), GuestOrgPermissions...)), nil
 	}
 
 	// Get permissions for this role
-	return s.rolePermissionRepo.GetPermissionCodesByRoleID(ctx, roleID)
+	return s.rolePermissionCodes(ctx, orgMemberRoleID(member))
+}
+
+// rolePermissionCodes returns the role's permission codes the request may use
+func (s *service) rolePermissionCodes(ctx context.Context, roleID uuid.UUID) ([]string, error) {
+	permissions, err := s.rolePermissionRepo.GetPermissionCodesByRoleID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return tokenScoped(ctx, permissions), nil
+}
+
+// tokenScoped narrows permissions to those the scope of the request's API token allows;
+// requests with a session keep them all
+func tokenScoped(ctx context.Context, permissions []string) []string {
+	token := apitoken.FromContext(ctx)
+	if token == nil {
+		return permissions
+	}
+	return slices.DeleteFunc(permissions, func(p string) bool {
+		return !token.Scope.Allows(p)
+	})
+}
+
+// orgMemberRoleID returns the member's organization role (prefer RoleID, fall back to
+// legacy Role field)
+func orgMemberRoleID(member *organization_member.OrganizationMember) uuid.UUID {
+	if member.RoleID != nil {
+		return *member.RoleID
+	}
+
+	// Legacy fallback
+	switch member.Role {
+	case "owner":
+		return role.OwnerRoleID
+	case "admin":
+		return role.AdminRoleID
+	case "member":
+		return role.MemberRoleID
+	default:
+		return role.ViewerRoleID
+	}
 }
 
 // GetUserProjectPermissions returns all permission codes a user has in a project
@@ -244,13 +354,65 @@ func (s *service) GetUserProjectPermissions(ctx context.Context, userID, project
 
 	// Check for project-specific role first
 	projectMember, err := s.projectMemberRepo.GetByProjectAndUser(ctx, projectID, userID)
-	if err == nil && projectMember != nil && projectMember.RoleID != nil {
+	isProjectMember := err == nil && projectMember != nil
+	if isProjectMember && projectMember.RoleID != nil {
 		// User has project-specific role
-		return s.rolePermissionRepo.GetPermissionCodesByRoleID(ctx, *projectMember.RoleID)
+		return s.rolePermissionCodes(ctx, *projectMember.RoleID)
 	}
 
 	// Fall back to organization role
-	return s.GetUserOrgPermissions(ctx, userID, proj.OrganizationID)
+	member, err := s.orgMemberRepo.GetByOrgAndUser(ctx, proj.OrganizationID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []string{}, nil // Not a member, no permissions
+		}
+		return nil, err
+	}
+
+	// Guests only reach the projects they were added to
+	if member.IsGuest && !isProjectMember {
+		return []string{}, nil
+	}
+
+	return s.rolePermissionCodes(ctx, orgMemberRoleID(member))
+}
+
+// FilterVisibleProjects narrows projects of an organization to those the user may see
+func (s *service) FilterVisibleProjects(ctx context.Context, userID, orgID uuid.UUID, projects []*project.Project) ([]*project.Project, error) {
+	ctx, span := s.startServiceSpan(ctx, "FilterVisibleProjects")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("org.id", orgID.String()),
+	)
+	defer span.End()
+
+	member, err := s.orgMemberRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return projects, nil
+		}
+		return nil, err
+	}
+	if !member.IsGuest {
+		return projects, nil
+	}
+
+	projectIDs, err := s.orgMemberRepo.GetGuestProjectIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[uuid.UUID]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		granted[id] = true
+	}
+
+	visible := make([]*project.Project, 0, len(projectIDs))
+	for _, p := range projects {
+		if granted[p.ID] {
+			visible = append(visible, p)
+		}
+	}
+	return visible, nil
 }
 
 // GetAllPermissions returns all defined permissions
@@ -456,6 +618,110 @@ func (s *service) AssignOrgRole(ctx context.Context, orgID, userID, roleID uuid.
 	return member, nil
 }
 
+// BulkAssignOrgRole assigns a role to many users of an organization in one update
+func (s *service) BulkAssignOrgRole(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (*BulkRoleChange, error) {
+	ctx, span := s.startServiceSpan(ctx, "BulkAssignOrgRole")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("role.id", roleID.String()),
+		attribute.Int("user.count", len(userIDs)),
+	)
+	defer span.End()
+
+	if len(userIDs) > MaxBulkRoleChanges {
+		return nil, ErrTooManyMembers
+	}
+
+	// The role must be a system role or one of the organization's own
+	r, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if r.OrganizationID != nil && *r.OrganizationID != orgID {
+		return nil, ErrRoleNotFound
+	}
+
+	members, err := s.orgMemberRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[uuid.UUID]*organization_member.OrganizationMember, len(members))
+	owners := 0
+	for _, m := range members {
+		byUser[m.UserID] = m
+		if isOrgOwner(m) {
+			owners++
+		}
+	}
+
+	result := &BulkRoleChange{Applied: true}
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	var demotedOwners []*MemberRoleChange
+	var changedUserIDs []uuid.UUID
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		change := &MemberRoleChange{UserID: userID}
+		result.Changes = append(result.Changes, change)
+		m, ok := byUser[userID]
+		if !ok {
+			change.Status = MemberRoleNotMember
+			result.Applied = false
+			continue
+		}
+		change.Member = m
+		change.PreviousRoleID = m.RoleID
+		if m.RoleID != nil && *m.RoleID == roleID {
+			change.Status = MemberRoleUnchanged
+			continue
+		}
+		change.Status = MemberRoleChanged
+		changedUserIDs = append(changedUserIDs, userID)
+		if isOrgOwner(m) && roleID != role.OwnerRoleID {
+			demotedOwners = append(demotedOwners, change)
+		}
+	}
+
+	// Demoting every owner at once would leave nobody to manage the organization
+	if len(demotedOwners) > 0 && len(demotedOwners) >= owners {
+		for _, change := range demotedOwners {
+			change.Status = MemberRoleLastOwner
+		}
+		result.Applied = false
+	}
+
+	if !result.Applied {
+		for _, change := range result.Changes {
+			if change.Status == MemberRoleChanged {
+				change.Status = MemberRoleRolledBack
+			}
+		}
+		return result, nil
+	}
+
+	if _, err := s.orgMemberRepo.UpdateRoles(ctx, orgID, changedUserIDs, roleID); err != nil {
+		return nil, err
+	}
+	for _, change := range result.Changes {
+		if change.Status == MemberRoleChanged {
+			change.Member.RoleID = &roleID
+			change.Member.Role = "" // Cleared with the legacy field
+		}
+	}
+	return result, nil
+}
+
+// isOrgOwner reports whether the member is an owner, by role or by the legacy field
+func isOrgOwner(m *organization_member.OrganizationMember) bool {
+	return (m.RoleID != nil && *m.RoleID == role.OwnerRoleID) || m.Role == "owner"
+}
+
 // countOrgOwners counts the number of owners in an organization
 func (s *service) countOrgOwners(ctx context.Context, orgID uuid.UUID) (int, error) {
 	members, err := s.orgMemberRepo.GetByOrgID(ctx, orgID)
@@ -465,9 +731,7 @@ func (s *service) countOrgOwners(ctx context.Context, orgID uuid.UUID) (int, err
 
 	count := 0
 	for _, m := range members {
-		if m.RoleID != nil && *m.RoleID == role.OwnerRoleID {
-			count++
-		} else if m.Role == "owner" {
+		if isOrgOwner(m) {
 			count++
 		}
 	}
@@ -565,13 +829,36 @@ func (s *service) GetUserProjectRole(ctx context.Context, projectID, userID uuid
 	return s.roleRepo.GetByID(ctx, *member.RoleID)
 }
 
-// GetOrgMembers returns all members of an organization
+// GetOrgMembers returns all members of an organization, without its guests
 func (s *service) GetOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
 	ctx, span := s.startServiceSpan(ctx, "GetOrgMembers")
 	span.SetAttributes(attribute.String("org.id", orgID.String()))
 	defer span.End()
 
-	return s.orgMemberRepo.GetByOrgID(ctx, orgID)
+	return s.orgMemberRepo.GetMembersByOrgID(ctx, orgID)
+}
+
+// GetOrgGuests returns the guests of an organization
+func (s *service) GetOrgGuests(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOrgGuests")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.orgMemberRepo.GetGuestsByOrgID(ctx, orgID)
+}
+
+// GetOrgDirectory returns a page of an organization's members
+func (s *service) GetOrgDirectory(ctx context.Context, orgID uuid.UUID, query organization_member.DirectoryQuery) ([]*organization_member.DirectoryEntry, int64, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOrgDirectory")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.String("directory.sort", string(query.Sort)),
+		attribute.Int("directory.limit", query.Limit),
+		attribute.Int("directory.offset", query.Offset),
+	)
+	defer span.End()
+
+	return s.orgMemberRepo.GetDirectory(ctx, orgID, query)
 }
 
 // GetProjectMembers returns all members of a project
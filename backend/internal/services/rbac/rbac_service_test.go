@@ -0,0 +1,151 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/api_token"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	roleMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role/mocks"
+	rolePermissionMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/apitoken"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestBulkAssignOrgRole(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	ownerRoleID, adminRoleID, memberRoleID := role.OwnerRoleID, role.AdminRoleID, role.MemberRoleID
+
+	setup := func(t *testing.T, members ...*organization_member.OrganizationMember) (Service, *roleMocks.MockRepository, *orgMemberMocks.MockRepository) {
+		ctrl := gomock.NewController(t)
+		roleRepo := roleMocks.NewMockRepository(ctrl)
+		orgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		orgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(members, nil).AnyTimes()
+		return NewService(nil, roleRepo, nil, orgMemberRepo, nil, nil, nil, nil), roleRepo, orgMemberRepo
+	}
+	member := func(roleID uuid.UUID) *organization_member.OrganizationMember {
+		id := roleID
+		return &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: uuid.New(), RoleID: &id}
+	}
+
+	t.Run("changes every member in one update", func(t *testing.T) {
+		owner, alice, bob := member(ownerRoleID), member(memberRoleID), member(adminRoleID)
+		svc, roleRepo, orgMemberRepo := setup(t, owner, alice, bob)
+		roleRepo.EXPECT().GetByID(gomock.Any(), adminRoleID).Return(&role.Role{ID: adminRoleID, IsSystem: true}, nil)
+		orgMemberRepo.EXPECT().UpdateRoles(gomock.Any(), orgID, []uuid.UUID{alice.UserID}, adminRoleID).Return(int64(1), nil)
+
+		result, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{alice.UserID, bob.UserID, alice.UserID}, adminRoleID)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+		require.Len(t, result.Changes, 2)
+		assert.Equal(t, MemberRoleChanged, result.Changes[0].Status)
+		assert.Equal(t, &memberRoleID, result.Changes[0].PreviousRoleID)
+		assert.Equal(t, &adminRoleID, result.Changes[0].Member.RoleID)
+		assert.Equal(t, MemberRoleUnchanged, result.Changes[1].Status)
+	})
+
+	t.Run("changes nobody when a user is not a member", func(t *testing.T) {
+		owner, alice := member(ownerRoleID), member(memberRoleID)
+		stranger := uuid.New()
+		svc, roleRepo, _ := setup(t, owner, alice)
+		roleRepo.EXPECT().GetByID(gomock.Any(), adminRoleID).Return(&role.Role{ID: adminRoleID, IsSystem: true}, nil)
+
+		result, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{alice.UserID, stranger}, adminRoleID)
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		assert.Equal(t, MemberRoleRolledBack, result.Changes[0].Status)
+		assert.Equal(t, &memberRoleID, result.Changes[0].Member.RoleID)
+		assert.Equal(t, MemberRoleNotMember, result.Changes[1].Status)
+		assert.Nil(t, result.Changes[1].Member)
+	})
+
+	t.Run("keeps an owner", func(t *testing.T) {
+		owner, other := member(ownerRoleID), member(ownerRoleID)
+		legacy := &organization_member.OrganizationMember{ID: uuid.New(), OrganizationID: orgID, UserID: uuid.New(), Role: "owner"}
+		svc, roleRepo, _ := setup(t, owner, other, legacy)
+		roleRepo.EXPECT().GetByID(gomock.Any(), memberRoleID).Return(&role.Role{ID: memberRoleID, IsSystem: true}, nil)
+
+		result, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{owner.UserID, other.UserID, legacy.UserID}, memberRoleID)
+		require.NoError(t, err)
+		assert.False(t, result.Applied)
+		for _, change := range result.Changes {
+			assert.Equal(t, MemberRoleLastOwner, change.Status)
+		}
+	})
+
+	t.Run("demotes some of the owners", func(t *testing.T) {
+		owner, other := member(ownerRoleID), member(ownerRoleID)
+		svc, roleRepo, orgMemberRepo := setup(t, owner, other)
+		roleRepo.EXPECT().GetByID(gomock.Any(), memberRoleID).Return(&role.Role{ID: memberRoleID, IsSystem: true}, nil)
+		orgMemberRepo.EXPECT().UpdateRoles(gomock.Any(), orgID, []uuid.UUID{other.UserID}, memberRoleID).Return(int64(1), nil)
+
+		result, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{other.UserID}, memberRoleID)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+	})
+
+	t.Run("role of another organization", func(t *testing.T) {
+		otherOrgID := uuid.New()
+		customRoleID := uuid.New()
+		svc, roleRepo, _ := setup(t)
+		roleRepo.EXPECT().GetByID(gomock.Any(), customRoleID).Return(&role.Role{ID: customRoleID, OrganizationID: &otherOrgID}, nil)
+
+		_, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{uuid.New()}, customRoleID)
+		assert.ErrorIs(t, err, ErrRoleNotFound)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		roleID := uuid.New()
+		svc, roleRepo, _ := setup(t)
+		roleRepo.EXPECT().GetByID(gomock.Any(), roleID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.BulkAssignOrgRole(ctx, orgID, []uuid.UUID{uuid.New()}, roleID)
+		assert.ErrorIs(t, err, ErrRoleNotFound)
+	})
+
+	t.Run("too many members", func(t *testing.T) {
+		svc, _, _ := setup(t)
+
+		_, err := svc.BulkAssignOrgRole(ctx, orgID, make([]uuid.UUID, MaxBulkRoleChanges+1), adminRoleID)
+		assert.ErrorIs(t, err, ErrTooManyMembers)
+	})
+}
+
+func TestPermissionsNarrowedByAPIToken(t *testing.T) {
+	orgID, userID := uuid.New(), uuid.New()
+	roleID := role.MemberRoleID
+	ctrl := gomock.NewController(t)
+	rolePermissionRepo := rolePermissionMocks.NewMockRepository(ctrl)
+	orgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+	orgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, userID).
+		Return(&organization_member.OrganizationMember{OrganizationID: orgID, UserID: userID, RoleID: &roleID}, nil).AnyTimes()
+	rolePermissionRepo.EXPECT().GetPermissionCodesByRoleID(gomock.Any(), roleID).DoAndReturn(func(context.Context, uuid.UUID) ([]string, error) {
+		return []string{"org:view", "project:create", "board:view", "card:create", "card:move"}, nil
+	}).AnyTimes()
+	svc := NewService(nil, nil, rolePermissionRepo, orgMemberRepo, nil, nil, nil, nil)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"session", context.Background(), []string{"org:view", "project:create", "board:view", "card:create", "card:move"}},
+		{"read-only token", apitoken.WithToken(context.Background(), &api_token.APIToken{Scope: api_token.ScopeReadOnly}), []string{"org:view", "board:view"}},
+		{"card write token", apitoken.WithToken(context.Background(), &api_token.APIToken{Scope: api_token.ScopeCardWrite}), []string{"org:view", "board:view", "card:create", "card:move"}},
+		{"admin token", apitoken.WithToken(context.Background(), &api_token.APIToken{Scope: api_token.ScopeAdmin}), []string{"org:view", "project:create", "board:view", "card:create", "card:move"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissions, err := svc.GetUserOrgPermissions(tt.ctx, userID, orgID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, permissions)
+		})
+	}
+}
@@ -67,6 +67,7 @@ type BoardDocument struct {
 // CardDocument represents a card in the search index
 type CardDocument struct {
 	ID               string   `json:"id"`
+	Key              string   `json:"key"` // Such as API-42
 	Title            string   `json:"title"`
 	Description      string   `json:"description"`
 	Priority         string   `json:"priority"`
@@ -84,6 +85,9 @@ type CardDocument struct {
 	DueDate          int64    `json:"due_date"` // Unix timestamp, 0 if not set
 	CreatedAt        int64    `json:"created_at"`
 	UpdatedAt        int64    `json:"updated_at"`
+	// Embedding is the vector of the title and description, set by IndexCard when an
+	// embeddings provider is configured
+	Embedding []float32 `json:"embedding,omitempty"`
 }
 
 // SearchResult represents a single search result
@@ -108,6 +112,11 @@ type SearchResults struct {
 	Results    []*SearchResult `json:"results"`
 	TotalCount int             `json:"total_count"`
 	Query      string          `json:"query"`
+	// OrganizationIDs are the organizations that were searched
+	OrganizationIDs []string `json:"organization_ids"`
+	// Throttled is set when the user was searching too fast and the results come from their
+	// recent searches; they may be incomplete
+	Throttled bool `json:"throttled"`
 }
 
 // SearchScope defines the context for filtering search results
@@ -56,6 +56,21 @@ func (mr *MockTypesenseClientMockRecorder) CreateCollection(ctx, schema any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockTypesenseClient)(nil).CreateCollection), ctx, schema)
 }
 
+// DeleteCollection mocks base method.
+func (m *MockTypesenseClient) DeleteCollection(ctx context.Context, name string) (*api.CollectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", ctx, name)
+	ret0, _ := ret[0].(*api.CollectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockTypesenseClientMockRecorder) DeleteCollection(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockTypesenseClient)(nil).DeleteCollection), ctx, name)
+}
+
 // DeleteDocument mocks base method.
 func (m *MockTypesenseClient) DeleteDocument(ctx context.Context, collection, id string) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +101,21 @@ func (mr *MockTypesenseClientMockRecorder) MultiSearch(ctx, params, searches any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiSearch", reflect.TypeOf((*MockTypesenseClient)(nil).MultiSearch), ctx, params, searches)
 }
 
+// RetrieveAlias mocks base method.
+func (m *MockTypesenseClient) RetrieveAlias(ctx context.Context, name string) (*api.CollectionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveAlias", ctx, name)
+	ret0, _ := ret[0].(*api.CollectionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveAlias indicates an expected call of RetrieveAlias.
+func (mr *MockTypesenseClientMockRecorder) RetrieveAlias(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveAlias", reflect.TypeOf((*MockTypesenseClient)(nil).RetrieveAlias), ctx, name)
+}
+
 // RetrieveCollection mocks base method.
 func (m *MockTypesenseClient) RetrieveCollection(ctx context.Context, name string) (*api.CollectionResponse, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +131,21 @@ func (mr *MockTypesenseClientMockRecorder) RetrieveCollection(ctx, name any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveCollection", reflect.TypeOf((*MockTypesenseClient)(nil).RetrieveCollection), ctx, name)
 }
 
+// UpsertAlias mocks base method.
+func (m *MockTypesenseClient) UpsertAlias(ctx context.Context, name, collection string) (*api.CollectionAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAlias", ctx, name, collection)
+	ret0, _ := ret[0].(*api.CollectionAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertAlias indicates an expected call of UpsertAlias.
+func (mr *MockTypesenseClientMockRecorder) UpsertAlias(ctx, name, collection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAlias", reflect.TypeOf((*MockTypesenseClient)(nil).UpsertAlias), ctx, name, collection)
+}
+
 // UpsertDocument mocks base method.
 func (m *MockTypesenseClient) UpsertDocument(ctx context.Context, collection string, document any) (map[string]any, error) {
 	m.ctrl.T.Helper()
@@ -1,10 +1,13 @@
 package search
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/typesense/typesense-go/v2/typesense/api"
 )
 
-// Collection names
+// Collection names. Each is an alias for the collection of the schema's current version.
 const (
 	CollectionOrganizations = "organizations"
 	CollectionUsers         = "users"
@@ -13,6 +16,23 @@ const (
 	CollectionCards         = "cards"
 )
 
+// SchemaVersions are the current versions of the collection schemas. Bump a collection's
+// version whenever its schema changes: the next startup creates the new version's
+// collection next to the old one, and the next `index` run backfills it and swaps the
+// alias over to it.
+var SchemaVersions = map[string]int{
+	CollectionOrganizations: 1,
+	CollectionUsers:         1,
+	CollectionProjects:      1,
+	CollectionBoards:        1,
+	CollectionCards:         2,
+}
+
+// VersionedCollectionName returns the name of the collection holding a version of a schema
+func VersionedCollectionName(alias string, version int) string {
+	return fmt.Sprintf("%s_v%d", alias, version)
+}
+
 // Ptr returns a pointer to the value
 func Ptr[T any](v T) *T {
 	return &v
@@ -99,6 +119,7 @@ func GetCardSchema() *api.CollectionSchema {
 		Name: CollectionCards,
 		Fields: []api.Field{
 			{Name: "id", Type: "string"},
+			{Name: "key", Type: "string"},
 			{Name: "title", Type: "string"},
 			{Name: "description", Type: "string", Optional: Ptr(true)},
 			{Name: "priority", Type: "string"},
@@ -121,6 +142,21 @@ func GetCardSchema() *api.CollectionSchema {
 	}
 }
 
+// embeddingField holds a card's vector in collections of embedded cards
+const embeddingField = "embedding"
+
+// withEmbedding returns a copy of the schema with a vector field of the given length
+func withEmbedding(schema *api.CollectionSchema, dimensions int) *api.CollectionSchema {
+	embedded := *schema
+	embedded.Fields = append(slices.Clone(schema.Fields), api.Field{
+		Name:     embeddingField,
+		Type:     "float[]",
+		NumDim:   Ptr(dimensions),
+		Optional: Ptr(true),
+	})
+	return &embedded
+}
+
 // GetAllSchemas returns all collection schemas
 func GetAllSchemas() []*api.CollectionSchema {
 	return []*api.CollectionSchema{
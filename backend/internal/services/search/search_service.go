@@ -2,11 +2,19 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/embeddings"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"github.com/typesense/typesense-go/v2/typesense"
 	"github.com/typesense/typesense-go/v2/typesense/api"
@@ -17,8 +25,15 @@ import (
 
 // Service defines the search service interface
 type Service interface {
-	// Search performs a multi-collection search with access control
+	// Search performs a multi-collection search with access control, applying each
+	// organization's synonyms and stop words to its part of the search. A user searching faster
+	// than SearchRate after a burst of SearchBurst gets results from their recent searches,
+	// marked Throttled, instead of a Typesense query.
 	Search(ctx context.Context, userID uuid.UUID, query string, scope *SearchScope, limit int) (*SearchResults, error)
+	// SearchSemantic searches cards by meaning as well as by keyword, blending each card's
+	// keyword relevance with the similarity of its embedding to the query's, so paraphrases
+	// match. Without an embeddings provider it is a keyword search of cards.
+	SearchSemantic(ctx context.Context, userID uuid.UUID, query string, scope *SearchScope, limit int) (*SearchResults, error)
 
 	// Indexing methods
 	IndexOrganization(ctx context.Context, doc *OrganizationDocument) error
@@ -34,29 +49,65 @@ type Service interface {
 	DeleteBoard(ctx context.Context, id string) error
 	DeleteCard(ctx context.Context, id string) error
 
-	// Initialize creates all collections if they don't exist
+	// InitializeCollections creates the collections of the current schema versions. A
+	// collection whose alias still points at an older version is left to be backfilled:
+	// until CompleteMigrations, documents are written to both versions.
 	InitializeCollections(ctx context.Context) error
+	// PendingMigrations returns the collections whose new schema version awaits a backfill
+	PendingMigrations() []string
+	// CompleteMigrations points each pending collection's alias at its backfilled new
+	// version and drops the old one
+	CompleteMigrations(ctx context.Context) error
+}
+
+// migration is a collection being moved to a new schema version
+type migration struct {
+	// target is the new version's collection, written alongside the alias until the swap
+	target string
+	// previous is the collection the alias is replacing, dropped after the swap
+	previous string
 }
 
 type service struct {
 	client     TypesenseClient
 	memberRepo organization_member.Repository
+	vocabulary VocabularySource
+	embedder   embeddings.Provider
+
+	mu         sync.RWMutex
+	migrations map[string]migration
+
+	throttleMu sync.Mutex
+	throttles  map[uuid.UUID]*userThrottle
+	now        func() time.Time
 }
 
-// NewService creates a new search service using the TypesenseClient interface
-func NewService(client TypesenseClient, memberRepo organization_member.Repository) Service {
+// NewService creates a new search service using the TypesenseClient interface. Without a
+// vocabulary source, queries are searched as typed; without an embedder, cards aren't
+// embedded and semantic search is a keyword search.
+func NewService(client TypesenseClient, memberRepo organization_member.Repository, vocabulary VocabularySource, embedder embeddings.Provider) Service {
 	return &service{
 		client:     client,
 		memberRepo: memberRepo,
+		vocabulary: vocabulary,
+		embedder:   embedder,
+		migrations: make(map[string]migration),
+		throttles:  make(map[uuid.UUID]*userThrottle),
+		now:        time.Now,
 	}
 }
 
 // NewServiceFromRawClient creates a new search service from a raw Typesense client
 // This is provided for backward compatibility
-func NewServiceFromRawClient(client *typesense.Client, memberRepo organization_member.Repository) Service {
+func NewServiceFromRawClient(client *typesense.Client, memberRepo organization_member.Repository, vocabulary VocabularySource, embedder embeddings.Provider) Service {
 	return &service{
 		client:     NewTypesenseClientFromRaw(client),
 		memberRepo: memberRepo,
+		vocabulary: vocabulary,
+		embedder:   embedder,
+		migrations: make(map[string]migration),
+		throttles:  make(map[uuid.UUID]*userThrottle),
+		now:        time.Now,
 	}
 }
 
@@ -73,42 +124,192 @@ func (s *service) startServiceSpan(ctx context.Context, operationName string) (c
 	)
 }
 
-// InitializeCollections creates all search collections if they don't exist
+// InitializeCollections creates the collections of the current schema versions, behind
+// aliases named after the collections
 func (s *service) InitializeCollections(ctx context.Context) error {
 	ctx, span := s.startServiceSpan(ctx, "InitializeCollections")
 	defer span.End()
 
-	schemas := GetAllSchemas()
-	for _, schema := range schemas {
-		// Check if collection exists
-		_, err := s.client.RetrieveCollection(ctx, schema.Name)
-		if err == nil {
-			// Collection exists, skip
+	for _, schema := range GetAllSchemas() {
+		alias := schema.Name
+		target := VersionedCollectionName(alias, SchemaVersions[alias])
+		if alias == CollectionCards && s.embedder != nil {
+			// Embedded cards get a collection per vector length, so turning embeddings on or
+			// changing the model's dimensions is backfilled like a new schema version
+			schema = withEmbedding(schema, s.embedder.Dimensions())
+			target = fmt.Sprintf("%s_e%d", target, s.embedder.Dimensions())
+		}
+
+		current, aliasErr := s.client.RetrieveAlias(ctx, alias)
+		if aliasErr == nil && current.CollectionName == target {
 			continue
 		}
 
-		// Create collection
-		_, err = s.client.CreateCollection(ctx, schema)
-		if err != nil {
-			return fmt.Errorf("failed to create collection %s: %w", schema.Name, err)
+		if _, err := s.client.RetrieveCollection(ctx, target); err != nil {
+			versioned := *schema
+			versioned.Name = target
+			if _, err := s.client.CreateCollection(ctx, &versioned); err != nil {
+				return fmt.Errorf("failed to create collection %s: %w", target, err)
+			}
+		}
+
+		previous := alias
+		if aliasErr == nil {
+			previous = current.CollectionName
+		} else if _, err := s.client.RetrieveCollection(ctx, alias); err != nil {
+			// Nothing is indexed yet, so there is nothing to backfill
+			if _, err := s.client.UpsertAlias(ctx, alias, target); err != nil {
+				return fmt.Errorf("failed to point alias %s at %s: %w", alias, target, err)
+			}
+			continue
+		}
+
+		// Searches keep using the previous collection until the new one is backfilled
+		s.mu.Lock()
+		s.migrations[alias] = migration{target: target, previous: previous}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (s *service) PendingMigrations() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	aliases := slices.Collect(maps.Keys(s.migrations))
+	slices.Sort(aliases)
+	return aliases
+}
+
+func (s *service) CompleteMigrations(ctx context.Context) error {
+	ctx, span := s.startServiceSpan(ctx, "CompleteMigrations")
+	defer span.End()
+
+	for _, alias := range s.PendingMigrations() {
+		s.mu.RLock()
+		m := s.migrations[alias]
+		s.mu.RUnlock()
+
+		if _, err := s.client.UpsertAlias(ctx, alias, m.target); err != nil {
+			return fmt.Errorf("failed to point alias %s at %s: %w", alias, m.target, err)
+		}
+		s.mu.Lock()
+		delete(s.migrations, alias)
+		s.mu.Unlock()
+
+		// Before aliases, the collection had the alias's name; dropping it lets the alias resolve
+		if _, err := s.client.DeleteCollection(ctx, m.previous); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to drop collection %s: %w", m.previous, err)
 		}
 	}
+	return nil
+}
 
+// upsertDocument writes a document to a collection, and to the collection's new schema
+// version while it is being backfilled
+func (s *service) upsertDocument(ctx context.Context, collection string, doc interface{}) error {
+	if _, err := s.client.UpsertDocument(ctx, collection, doc); err != nil {
+		return err
+	}
+	if m, ok := s.migration(collection); ok {
+		if _, err := s.client.UpsertDocument(ctx, m.target, doc); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// getUserOrgIDs returns the organization IDs the user has access to
-func (s *service) getUserOrgIDs(ctx context.Context, userID uuid.UUID) ([]string, error) {
+// deleteDocument removes a document from a collection, and from the collection's new schema
+// version while it is being backfilled, which may not hold it yet
+func (s *service) deleteDocument(ctx context.Context, collection string, id string) error {
+	if _, err := s.client.DeleteDocument(ctx, collection, id); err != nil {
+		return err
+	}
+	if m, ok := s.migration(collection); ok {
+		if _, err := s.client.DeleteDocument(ctx, m.target, id); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) migration(collection string) (migration, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.migrations[collection]
+	return m, ok
+}
+
+func isNotFound(err error) bool {
+	var httpErr *typesense.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound
+}
+
+// searchAccess is what a user may find: everything in the organizations they are a member
+// of, and in the organizations they are a guest of only the projects they were added to
+type searchAccess struct {
+	orgIDs          []string
+	guestOrgIDs     []string
+	guestProjectIDs []string
+}
+
+// getUserAccess returns what the user has access to
+func (s *service) getUserAccess(ctx context.Context, userID uuid.UUID) (*searchAccess, error) {
 	members, err := s.memberRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	orgIDs := make([]string, len(members))
-	for i, m := range members {
-		orgIDs[i] = m.OrganizationID.String()
+	access := &searchAccess{}
+	for _, m := range members {
+		if m.IsGuest {
+			access.guestOrgIDs = append(access.guestOrgIDs, m.OrganizationID.String())
+		} else {
+			access.orgIDs = append(access.orgIDs, m.OrganizationID.String())
+		}
 	}
-	return orgIDs, nil
+
+	if len(access.guestOrgIDs) > 0 {
+		projectIDs, err := s.memberRepo.GetGuestProjectIDs(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range projectIDs {
+			access.guestProjectIDs = append(access.guestProjectIDs, id.String())
+		}
+	}
+	return access, nil
+}
+
+func (a *searchAccess) empty() bool {
+	return len(a.orgIDs) == 0 && len(a.guestProjectIDs) == 0
+}
+
+// isMemberOf reports whether the user sees all of the organization
+func (a *searchAccess) isMemberOf(orgID string) bool {
+	return slices.Contains(a.orgIDs, orgID)
+}
+
+// isGuestOf reports whether the user sees some projects of the organization
+func (a *searchAccess) isGuestOf(orgID string) bool {
+	return slices.Contains(a.guestOrgIDs, orgID) && len(a.guestProjectIDs) > 0
+}
+
+// filter matches the documents the user may see, by the organization field for the
+// organizations they are a member of and by the project field for their guest projects
+func (a *searchAccess) filter(orgField, projectField string) string {
+	var parts []string
+	if len(a.orgIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("%s:[%s]", orgField, strings.Join(a.orgIDs, ",")))
+	}
+	if len(a.guestProjectIDs) > 0 {
+		parts = append(parts, fmt.Sprintf("%s:[%s]", projectField, strings.Join(a.guestProjectIDs, ",")))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return "(" + strings.Join(parts, " || ") + ")"
 }
 
 // Search performs a multi-collection search with access control
@@ -127,13 +328,18 @@ func (s *service) Search(ctx context.Context, userID uuid.UUID, query string, sc
 		limit = 50
 	}
 
-	// Get user's accessible organization IDs for filtering
-	orgIDs, err := s.getUserOrgIDs(ctx, userID)
+	if !s.allowSearch(userID) {
+		span.SetAttributes(attribute.Bool("search.throttled", true))
+		return s.throttledResults(userID, query, scope, limit), nil
+	}
+
+	// Get what the user has access to for filtering
+	access, err := s.getUserAccess(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user organizations: %w", err)
 	}
 
-	if len(orgIDs) == 0 {
+	if access.empty() {
 		// User has no organizations, return empty results
 		return &SearchResults{
 			Results:    []*SearchResult{},
@@ -143,50 +349,70 @@ func (s *service) Search(ctx context.Context, userID uuid.UUID, query string, sc
 	}
 
 	// Build filter based on scope and access control
-	orgFilter := fmt.Sprintf("organization_id:[%s]", strings.Join(orgIDs, ","))
+	searched := append(slices.Clone(access.orgIDs), access.guestOrgIDs...)
+	orgFilter := access.filter("organization_id", "project_id")
+	projectsFilter := access.filter("organization_id", "id")
 	memberFilter := fmt.Sprintf("member_ids:[%s]", userID.String())
-	userOrgFilter := fmt.Sprintf("organization_ids:[%s]", strings.Join(orgIDs, ","))
+	// Users are found through the organizations they are a member of; a user who is only
+	// a guest finds no one but themselves
+	userOrgFilter := fmt.Sprintf("id:=%s", userID.String())
+	if len(access.orgIDs) > 0 {
+		userOrgFilter = fmt.Sprintf("organization_ids:[%s]", strings.Join(access.orgIDs, ","))
+	}
 
 	// Apply scope filters if provided
 	if scope != nil && scope.OrganizationID != "" {
 		// Verify user has access to this org
-		hasAccess := false
-		for _, id := range orgIDs {
-			if id == scope.OrganizationID {
-				hasAccess = true
-				break
-			}
-		}
-		if !hasAccess {
+		isMember := access.isMemberOf(scope.OrganizationID)
+		if !isMember && !access.isGuestOf(scope.OrganizationID) {
 			return &SearchResults{
 				Results:    []*SearchResult{},
 				TotalCount: 0,
 				Query:      query,
 			}, nil
 		}
-		orgFilter = fmt.Sprintf("organization_id:=%s", scope.OrganizationID)
+		searched = []string{scope.OrganizationID}
+		orgFilter, projectsFilter = access.organizationFilters(scope.OrganizationID)
 		memberFilter = fmt.Sprintf("member_ids:[%s] && id:=%s", userID.String(), scope.OrganizationID)
 	}
 
-	projectFilter := orgFilter
-	if scope != nil && scope.ProjectID != "" {
-		projectFilter = fmt.Sprintf("%s && project_id:=%s", orgFilter, scope.ProjectID)
+	boardsFilter := func(orgFilter string) string {
+		if scope != nil && scope.ProjectID != "" {
+			return fmt.Sprintf("%s && project_id:=%s", orgFilter, scope.ProjectID)
+		}
+		return orgFilter
+	}
+	projectFilter := boardsFilter(orgFilter)
+
+	// Organizations whose vocabulary changes the query are searched separately, once per
+	// query variant
+	variants, err := s.queryVariants(ctx, query, searched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search vocabularies: %w", err)
+	}
+	customOrgIDs := slices.Sorted(maps.Keys(variants))
+	if len(customOrgIDs) > 0 {
+		exclude := fmt.Sprintf(" && organization_id:!=[%s]", strings.Join(customOrgIDs, ","))
+		orgFilter += exclude
+		projectsFilter += exclude
+		projectFilter += exclude
 	}
 
 	// Build multi-search request
 	searches := []api.MultiSearchCollectionParameters{
 		{
-			Collection: CollectionCards,
-			Q:          pointer.String(query),
-			QueryBy:    pointer.String("title,description"),
-			FilterBy:   pointer.String(orgFilter),
-			PerPage:    pointer.Int(limit),
+			Collection:    CollectionCards,
+			Q:             pointer.String(query),
+			QueryBy:       pointer.String("key,title,description"),
+			FilterBy:      pointer.String(orgFilter),
+			PerPage:       pointer.Int(limit),
+			ExcludeFields: pointer.String(embeddingField),
 		},
 		{
 			Collection: CollectionProjects,
 			Q:          pointer.String(query),
 			QueryBy:    pointer.String("name,key,description"),
-			FilterBy:   pointer.String(orgFilter),
+			FilterBy:   pointer.String(projectsFilter),
 			PerPage:    pointer.Int(limit),
 		},
 		{
@@ -212,6 +438,44 @@ func (s *service) Search(ctx context.Context, userID uuid.UUID, query string, sc
 		},
 	}
 
+	// collections are the collection index of each search, and groups the organization and
+	// collection of each variant search, whose counts overlap
+	collections := []int{0, 1, 2, 3, 4}
+	groups := make([]string, len(searches))
+	for _, orgID := range customOrgIDs {
+		cardsFilter, orgProjectsFilter := access.organizationFilters(orgID)
+		for _, variant := range variants[orgID] {
+			searches = append(searches,
+				api.MultiSearchCollectionParameters{
+					Collection:    CollectionCards,
+					Q:             pointer.String(variant),
+					QueryBy:       pointer.String("title,description"),
+					FilterBy:      pointer.String(cardsFilter),
+					PerPage:       pointer.Int(limit),
+					ExcludeFields: pointer.String(embeddingField),
+				},
+				api.MultiSearchCollectionParameters{
+					Collection: CollectionProjects,
+					Q:          pointer.String(variant),
+					QueryBy:    pointer.String("name,key,description"),
+					FilterBy:   pointer.String(orgProjectsFilter),
+					PerPage:    pointer.Int(limit),
+				},
+				api.MultiSearchCollectionParameters{
+					Collection: CollectionBoards,
+					Q:          pointer.String(variant),
+					QueryBy:    pointer.String("name,description"),
+					FilterBy:   pointer.String(boardsFilter(cardsFilter)),
+					PerPage:    pointer.Int(limit),
+				},
+			)
+			for i := 0; i < 3; i++ {
+				collections = append(collections, i)
+				groups = append(groups, fmt.Sprintf("%s/%d", orgID, i))
+			}
+		}
+	}
+
 	// Execute multi-search
 	params := &api.MultiSearchParams{}
 	searchBody := api.MultiSearchSearchesParameter{
@@ -222,33 +486,59 @@ func (s *service) Search(ctx context.Context, userID uuid.UUID, query string, sc
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
-	// Process results
-	results := make([]*SearchResult, 0)
+	// Process results, by collection. A document found by several query variants is listed
+	// once, with its best score.
+	byCollection := make([][]*SearchResult, 5)
+	seen := make(map[string]*SearchResult)
 	totalCount := 0
+	variantFound := make(map[string]int)
 
 	for i, searchResult := range resp.Results {
-		if searchResult.Found == nil {
+		if searchResult.Found == nil || i >= len(collections) {
 			continue
 		}
-		totalCount += *searchResult.Found
+		if groups[i] == "" {
+			totalCount += *searchResult.Found
+		} else {
+			variantFound[groups[i]] = max(variantFound[groups[i]], *searchResult.Found)
+		}
 
 		if searchResult.Hits == nil {
 			continue
 		}
 
 		for _, hit := range *searchResult.Hits {
-			result := s.hitToSearchResult(hit, i)
-			if result != nil {
-				results = append(results, result)
+			result := s.hitToSearchResult(hit, collections[i])
+			if result == nil {
+				continue
+			}
+			key := string(result.Type) + ":" + result.ID
+			if existing, ok := seen[key]; ok {
+				if result.Score > existing.Score {
+					*existing = *result
+				}
+				continue
 			}
+			seen[key] = result
+			byCollection[collections[i]] = append(byCollection[collections[i]], result)
 		}
 	}
+	for _, found := range variantFound {
+		totalCount += found
+	}
+	results := slices.Concat(byCollection...)
+	if results == nil {
+		results = make([]*SearchResult, 0)
+	}
 
-	return &SearchResults{
-		Results:    results,
-		TotalCount: totalCount,
-		Query:      query,
-	}, nil
+	searchResults := &SearchResults{
+		Results:         results,
+		TotalCount:      totalCount,
+		Query:           query,
+		OrganizationIDs: searched,
+	}
+	s.rememberResults(userID, scope, limit, searchResults)
+	return searchResults, nil
 }
 
 func (s *service) hitToSearchResult(hit api.SearchResultHit, collectionIndex int) *SearchResult {
@@ -364,8 +654,7 @@ func (s *service) IndexOrganization(ctx context.Context, doc *OrganizationDocume
 	span.SetAttributes(attribute.String("organization.id", doc.ID))
 	defer span.End()
 
-	_, err := s.client.UpsertDocument(ctx, CollectionOrganizations, doc)
-	return err
+	return s.upsertDocument(ctx, CollectionOrganizations, doc)
 }
 
 // IndexUser indexes or updates a user document
@@ -374,8 +663,7 @@ func (s *service) IndexUser(ctx context.Context, doc *UserDocument) error {
 	span.SetAttributes(attribute.String("user.id", doc.ID))
 	defer span.End()
 
-	_, err := s.client.UpsertDocument(ctx, CollectionUsers, doc)
-	return err
+	return s.upsertDocument(ctx, CollectionUsers, doc)
 }
 
 // IndexProject indexes or updates a project document
@@ -384,8 +672,7 @@ func (s *service) IndexProject(ctx context.Context, doc *ProjectDocument) error
 	span.SetAttributes(attribute.String("project.id", doc.ID))
 	defer span.End()
 
-	_, err := s.client.UpsertDocument(ctx, CollectionProjects, doc)
-	return err
+	return s.upsertDocument(ctx, CollectionProjects, doc)
 }
 
 // IndexBoard indexes or updates a board document
@@ -394,8 +681,7 @@ func (s *service) IndexBoard(ctx context.Context, doc *BoardDocument) error {
 	span.SetAttributes(attribute.String("board.id", doc.ID))
 	defer span.End()
 
-	_, err := s.client.UpsertDocument(ctx, CollectionBoards, doc)
-	return err
+	return s.upsertDocument(ctx, CollectionBoards, doc)
 }
 
 // IndexCard indexes or updates a card document
@@ -404,8 +690,17 @@ func (s *service) IndexCard(ctx context.Context, doc *CardDocument) error {
 	span.SetAttributes(attribute.String("card.id", doc.ID))
 	defer span.End()
 
-	_, err := s.client.UpsertDocument(ctx, CollectionCards, doc)
-	return err
+	if s.embedder != nil && doc.Embedding == nil {
+		// A card that can't be embedded stays findable by keyword; it is embedded again on
+		// its next update or index run
+		if embedding, err := s.embedCard(ctx, doc); err != nil {
+			log := logger.FromCtx(ctx)
+			log.Warn().Err(err).Str("card_id", doc.ID).Msg("Failed to embed card")
+		} else {
+			doc.Embedding = embedding
+		}
+	}
+	return s.upsertDocument(ctx, CollectionCards, doc)
 }
 
 // DeleteOrganization removes an organization from the index
@@ -414,8 +709,7 @@ func (s *service) DeleteOrganization(ctx context.Context, id string) error {
 	span.SetAttributes(attribute.String("organization.id", id))
 	defer span.End()
 
-	_, err := s.client.DeleteDocument(ctx, CollectionOrganizations, id)
-	return err
+	return s.deleteDocument(ctx, CollectionOrganizations, id)
 }
 
 // DeleteUser removes a user from the index
@@ -424,8 +718,7 @@ func (s *service) DeleteUser(ctx context.Context, id string) error {
 	span.SetAttributes(attribute.String("user.id", id))
 	defer span.End()
 
-	_, err := s.client.DeleteDocument(ctx, CollectionUsers, id)
-	return err
+	return s.deleteDocument(ctx, CollectionUsers, id)
 }
 
 // DeleteProject removes a project from the index
@@ -434,8 +727,7 @@ func (s *service) DeleteProject(ctx context.Context, id string) error {
 	span.SetAttributes(attribute.String("project.id", id))
 	defer span.End()
 
-	_, err := s.client.DeleteDocument(ctx, CollectionProjects, id)
-	return err
+	return s.deleteDocument(ctx, CollectionProjects, id)
 }
 
 // DeleteBoard removes a board from the index
@@ -444,8 +736,7 @@ func (s *service) DeleteBoard(ctx context.Context, id string) error {
 	span.SetAttributes(attribute.String("board.id", id))
 	defer span.End()
 
-	_, err := s.client.DeleteDocument(ctx, CollectionBoards, id)
-	return err
+	return s.deleteDocument(ctx, CollectionBoards, id)
 }
 
 // DeleteCard removes a card from the index
@@ -454,6 +745,5 @@ func (s *service) DeleteCard(ctx context.Context, id string) error {
 	span.SetAttributes(attribute.String("card.id", id))
 	defer span.End()
 
-	_, err := s.client.DeleteDocument(ctx, CollectionCards, id)
-	return err
+	return s.deleteDocument(ctx, CollectionCards, id)
 }
@@ -3,6 +3,7 @@ package search
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search/mocks"
+	"github.com/typesense/typesense-go/v2/typesense"
 	"github.com/typesense/typesense-go/v2/typesense/api"
 	"go.uber.org/mock/gomock"
 )
@@ -22,67 +24,124 @@ func ptr[T any](v T) *T {
 }
 
 func TestInitializeCollections(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockClient := mocks.NewMockTypesenseClient(ctrl)
-	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
-
-	svc := NewService(mockClient, mockMemberRepo)
 	ctx := context.Background()
-
-	t.Run("creates collections when they don't exist", func(t *testing.T) {
-		schemas := GetAllSchemas()
-
-		// Expect RetrieveCollection to fail for all collections (indicating they don't exist)
-		for _, schema := range schemas {
-			mockClient.EXPECT().
-				RetrieveCollection(gomock.Any(), schema.Name).
-				Return(nil, errors.New("collection not found"))
-		}
-
-		// Expect CreateCollection to be called for all collections
-		for _, schema := range schemas {
-			mockClient.EXPECT().
-				CreateCollection(gomock.Any(), schema).
-				Return(&api.CollectionResponse{Name: schema.Name}, nil)
+	notFound := &typesense.HTTPError{Status: http.StatusNotFound}
+
+	newService := func(t *testing.T) (Service, *mocks.MockTypesenseClient) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockTypesenseClient(ctrl)
+		return NewService(mockClient, memberMocks.NewMockRepository(ctrl), nil, nil), mockClient
+	}
+
+	t.Run("creates versioned collections behind aliases when nothing is indexed", func(t *testing.T) {
+		svc, mockClient := newService(t)
+
+		for _, schema := range GetAllSchemas() {
+			target := VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])
+			versioned := *schema
+			versioned.Name = target
+
+			mockClient.EXPECT().RetrieveAlias(gomock.Any(), schema.Name).Return(nil, notFound)
+			mockClient.EXPECT().RetrieveCollection(gomock.Any(), target).Return(nil, notFound)
+			mockClient.EXPECT().CreateCollection(gomock.Any(), &versioned).Return(&api.CollectionResponse{Name: target}, nil)
+			mockClient.EXPECT().RetrieveCollection(gomock.Any(), schema.Name).Return(nil, notFound)
+			mockClient.EXPECT().UpsertAlias(gomock.Any(), schema.Name, target).Return(&api.CollectionAlias{CollectionName: target}, nil)
 		}
 
-		err := svc.InitializeCollections(ctx)
-		require.NoError(t, err)
+		require.NoError(t, svc.InitializeCollections(ctx))
+		assert.Empty(t, svc.PendingMigrations())
 	})
 
-	t.Run("skips existing collections", func(t *testing.T) {
-		schemas := GetAllSchemas()
+	t.Run("skips collections already at the current version", func(t *testing.T) {
+		svc, mockClient := newService(t)
 
-		// Expect RetrieveCollection to succeed for all collections (indicating they exist)
-		for _, schema := range schemas {
+		for _, schema := range GetAllSchemas() {
 			mockClient.EXPECT().
-				RetrieveCollection(gomock.Any(), schema.Name).
-				Return(&api.CollectionResponse{Name: schema.Name}, nil)
+				RetrieveAlias(gomock.Any(), schema.Name).
+				Return(&api.CollectionAlias{CollectionName: VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])}, nil)
 		}
 
-		// CreateCollection should not be called
-
-		err := svc.InitializeCollections(ctx)
-		require.NoError(t, err)
+		require.NoError(t, svc.InitializeCollections(ctx))
+		assert.Empty(t, svc.PendingMigrations())
 	})
 
 	t.Run("returns error if collection creation fails", func(t *testing.T) {
-		// First collection doesn't exist
-		mockClient.EXPECT().
-			RetrieveCollection(gomock.Any(), CollectionOrganizations).
-			Return(nil, errors.New("collection not found"))
+		svc, mockClient := newService(t)
 
-		// CreateCollection fails
-		mockClient.EXPECT().
-			CreateCollection(gomock.Any(), gomock.Any()).
-			Return(nil, errors.New("creation failed"))
+		mockClient.EXPECT().RetrieveAlias(gomock.Any(), CollectionOrganizations).Return(nil, notFound)
+		mockClient.EXPECT().RetrieveCollection(gomock.Any(), gomock.Any()).Return(nil, notFound)
+		mockClient.EXPECT().CreateCollection(gomock.Any(), gomock.Any()).Return(nil, errors.New("creation failed"))
 
 		err := svc.InitializeCollections(ctx)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create collection")
 	})
+
+	t.Run("dual-writes a new schema version until the alias is swapped", func(t *testing.T) {
+		SchemaVersions[CollectionCards] = 3
+		defer func() { SchemaVersions[CollectionCards] = 2 }()
+		svc, mockClient := newService(t)
+
+		for _, schema := range GetAllSchemas() {
+			if schema.Name == CollectionCards {
+				continue
+			}
+			mockClient.EXPECT().
+				RetrieveAlias(gomock.Any(), schema.Name).
+				Return(&api.CollectionAlias{CollectionName: VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])}, nil)
+		}
+		mockClient.EXPECT().RetrieveAlias(gomock.Any(), CollectionCards).Return(&api.CollectionAlias{CollectionName: "cards_v2"}, nil)
+		mockClient.EXPECT().RetrieveCollection(gomock.Any(), "cards_v3").Return(nil, notFound)
+		mockClient.EXPECT().CreateCollection(gomock.Any(), gomock.Any()).Return(&api.CollectionResponse{Name: "cards_v3"}, nil)
+
+		require.NoError(t, svc.InitializeCollections(ctx))
+		assert.Equal(t, []string{CollectionCards}, svc.PendingMigrations())
+
+		doc := &CardDocument{ID: "card-123"}
+		mockClient.EXPECT().UpsertDocument(gomock.Any(), CollectionCards, doc).Return(nil, nil)
+		mockClient.EXPECT().UpsertDocument(gomock.Any(), "cards_v3", doc).Return(nil, nil)
+		require.NoError(t, svc.IndexCard(ctx, doc))
+
+		mockClient.EXPECT().DeleteDocument(gomock.Any(), CollectionCards, "card-456").Return(nil, nil)
+		mockClient.EXPECT().DeleteDocument(gomock.Any(), "cards_v3", "card-456").Return(nil, notFound)
+		require.NoError(t, svc.DeleteCard(ctx, "card-456"))
+
+		gomock.InOrder(
+			mockClient.EXPECT().UpsertAlias(gomock.Any(), CollectionCards, "cards_v3").Return(&api.CollectionAlias{CollectionName: "cards_v3"}, nil),
+			mockClient.EXPECT().DeleteCollection(gomock.Any(), "cards_v2").Return(&api.CollectionResponse{Name: "cards_v2"}, nil),
+		)
+		require.NoError(t, svc.CompleteMigrations(ctx))
+		assert.Empty(t, svc.PendingMigrations())
+
+		// Once swapped, writes go to the alias only
+		mockClient.EXPECT().UpsertDocument(gomock.Any(), CollectionCards, doc).Return(nil, nil)
+		require.NoError(t, svc.IndexCard(ctx, doc))
+	})
+
+	t.Run("migrates a collection indexed before aliases", func(t *testing.T) {
+		svc, mockClient := newService(t)
+
+		for _, schema := range GetAllSchemas() {
+			target := VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])
+			mockClient.EXPECT().RetrieveAlias(gomock.Any(), schema.Name).Return(nil, notFound)
+			mockClient.EXPECT().RetrieveCollection(gomock.Any(), target).Return(nil, notFound)
+			mockClient.EXPECT().CreateCollection(gomock.Any(), gomock.Any()).Return(&api.CollectionResponse{Name: target}, nil)
+			mockClient.EXPECT().RetrieveCollection(gomock.Any(), schema.Name).Return(&api.CollectionResponse{Name: schema.Name}, nil)
+		}
+
+		require.NoError(t, svc.InitializeCollections(ctx))
+		assert.Len(t, svc.PendingMigrations(), len(GetAllSchemas()))
+
+		for _, schema := range GetAllSchemas() {
+			target := VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])
+			gomock.InOrder(
+				mockClient.EXPECT().UpsertAlias(gomock.Any(), schema.Name, target).Return(&api.CollectionAlias{CollectionName: target}, nil),
+				mockClient.EXPECT().DeleteCollection(gomock.Any(), schema.Name).Return(&api.CollectionResponse{Name: schema.Name}, nil),
+			)
+		}
+		require.NoError(t, svc.CompleteMigrations(ctx))
+		assert.Empty(t, svc.PendingMigrations())
+	})
 }
 
 func TestSearch(t *testing.T) {
@@ -92,7 +151,7 @@ func TestSearch(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -295,6 +354,89 @@ func TestSearch(t *testing.T) {
 		_, err := svc.Search(ctx, userID, "test", scope, 10)
 		require.NoError(t, err)
 	})
+
+	t.Run("limits guests to the projects they were added to", func(t *testing.T) {
+		guestOrgID := uuid.New()
+		guestProjectID := uuid.New()
+		mockMemberRepo.EXPECT().
+			GetByUserID(gomock.Any(), userID).
+			Return([]*organization_member.OrganizationMember{
+				{OrganizationID: orgID, UserID: userID},
+				{OrganizationID: guestOrgID, UserID: userID, IsGuest: true},
+			}, nil)
+		mockMemberRepo.EXPECT().
+			GetGuestProjectIDs(gomock.Any(), userID).
+			Return([]uuid.UUID{guestProjectID}, nil)
+
+		mockClient.EXPECT().
+			MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, params *api.MultiSearchParams, searches api.MultiSearchSearchesParameter) (*api.MultiSearchResult, error) {
+				assert.Equal(t, "(organization_id:["+orgID.String()+"] || project_id:["+guestProjectID.String()+"])", *searches.Searches[0].FilterBy)
+				assert.Equal(t, "(organization_id:["+orgID.String()+"] || id:["+guestProjectID.String()+"])", *searches.Searches[1].FilterBy)
+				// The guest organization's users stay hidden
+				assert.Equal(t, "organization_ids:["+orgID.String()+"]", *searches.Searches[4].FilterBy)
+				return &api.MultiSearchResult{
+					Results: []api.SearchResult{
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+					},
+				}, nil
+			})
+
+		_, err := svc.Search(ctx, userID, "test", nil, 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("scopes a guest organization to the guest's projects", func(t *testing.T) {
+		guestProjectID := uuid.New()
+		mockMemberRepo.EXPECT().
+			GetByUserID(gomock.Any(), userID).
+			Return([]*organization_member.OrganizationMember{
+				{OrganizationID: orgID, UserID: userID, IsGuest: true},
+			}, nil)
+		mockMemberRepo.EXPECT().
+			GetGuestProjectIDs(gomock.Any(), userID).
+			Return([]uuid.UUID{guestProjectID}, nil)
+
+		mockClient.EXPECT().
+			MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, params *api.MultiSearchParams, searches api.MultiSearchSearchesParameter) (*api.MultiSearchResult, error) {
+				assert.Equal(t, "organization_id:="+orgID.String()+" && project_id:["+guestProjectID.String()+"]", *searches.Searches[0].FilterBy)
+				assert.Equal(t, "organization_id:="+orgID.String()+" && id:["+guestProjectID.String()+"]", *searches.Searches[1].FilterBy)
+				assert.Equal(t, "id:="+userID.String(), *searches.Searches[4].FilterBy)
+				return &api.MultiSearchResult{
+					Results: []api.SearchResult{
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+						{Found: ptr(0)},
+					},
+				}, nil
+			})
+
+		scope := &SearchScope{OrganizationID: orgID.String()}
+		_, err := svc.Search(ctx, userID, "test", scope, 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("returns empty results for a guest without projects", func(t *testing.T) {
+		mockMemberRepo.EXPECT().
+			GetByUserID(gomock.Any(), userID).
+			Return([]*organization_member.OrganizationMember{
+				{OrganizationID: orgID, UserID: userID, IsGuest: true},
+			}, nil)
+		mockMemberRepo.EXPECT().
+			GetGuestProjectIDs(gomock.Any(), userID).
+			Return(nil, nil)
+
+		results, err := svc.Search(ctx, userID, "test", nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, results.Results)
+	})
 }
 
 func TestIndexOrganization(t *testing.T) {
@@ -304,7 +446,7 @@ func TestIndexOrganization(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -341,7 +483,7 @@ func TestIndexUser(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -367,7 +509,7 @@ func TestIndexProject(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -394,7 +536,7 @@ func TestIndexBoard(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -420,7 +562,7 @@ func TestIndexCard(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -447,7 +589,7 @@ func TestDeleteOrganization(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -476,7 +618,7 @@ func TestDeleteUser(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -496,7 +638,7 @@ func TestDeleteProject(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -516,7 +658,7 @@ func TestDeleteBoard(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -536,7 +678,7 @@ func TestDeleteCard(t *testing.T) {
 	mockClient := mocks.NewMockTypesenseClient(ctrl)
 	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
 
-	svc := NewService(mockClient, mockMemberRepo)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil)
 	ctx := context.Background()
 
 	t.Run("success", func(t *testing.T) {
@@ -0,0 +1,220 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/typesense/typesense-go/v2/typesense/api"
+	"github.com/typesense/typesense-go/v2/typesense/api/pointer"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// SemanticWeight is the share of vector similarity in a semantic search score; the rest
+	// is keyword relevance
+	SemanticWeight = 0.6
+	// maxVectorDistance is the cosine distance past which a card isn't close enough in meaning
+	// to the query to be a result
+	maxVectorDistance = 0.7
+)
+
+func (s *service) SearchSemantic(ctx context.Context, userID uuid.UUID, query string, scope *SearchScope, limit int) (*SearchResults, error) {
+	ctx, span := s.startServiceSpan(ctx, "SearchSemantic")
+	span.SetAttributes(
+		attribute.String("search.query", query),
+		attribute.Int("search.limit", limit),
+	)
+	defer span.End()
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	empty := &SearchResults{Results: []*SearchResult{}, Query: query}
+	// Semantic searches share the keyword search rate limit, but recent results are keyword
+	// results of every type, so a throttled semantic search finds nothing
+	if !s.allowSearch(userID) {
+		span.SetAttributes(attribute.Bool("search.throttled", true))
+		empty.Throttled = true
+		return empty, nil
+	}
+
+	access, err := s.getUserAccess(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user organizations: %w", err)
+	}
+	if access.empty() {
+		return empty, nil
+	}
+	filter, searched, ok := access.cardsFilter(scope)
+	if !ok {
+		return empty, nil
+	}
+
+	searches := []api.MultiSearchCollectionParameters{
+		{
+			Collection:    CollectionCards,
+			Q:             pointer.String(query),
+			QueryBy:       pointer.String("title,description"),
+			FilterBy:      pointer.String(filter),
+			PerPage:       pointer.Int(limit),
+			ExcludeFields: pointer.String(embeddingField),
+		},
+	}
+	if vector := s.embedQuery(ctx, query); vector != nil {
+		span.SetAttributes(attribute.Bool("search.semantic", true))
+		searches = append(searches, api.MultiSearchCollectionParameters{
+			Collection:    CollectionCards,
+			Q:             pointer.String("*"),
+			FilterBy:      pointer.String(filter),
+			PerPage:       pointer.Int(limit),
+			ExcludeFields: pointer.String(embeddingField),
+			VectorQuery:   pointer.String(vectorQuery(vector, limit)),
+		})
+	}
+
+	resp, err := s.client.MultiSearch(ctx, &api.MultiSearchParams{}, api.MultiSearchSearchesParameter{Searches: searches})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	results, totalCount := s.blendResults(resp.Results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return &SearchResults{
+		Results:         results,
+		TotalCount:      totalCount,
+		Query:           query,
+		OrganizationIDs: searched,
+	}, nil
+}
+
+// blendResults scores the cards of a keyword search, and of the vector search that may follow
+// it, by their keyword relevance relative to the best keyword match, plus their similarity to
+// the query weighted by SemanticWeight. Best scored cards come first.
+func (s *service) blendResults(searchResults []api.SearchResult) ([]*SearchResult, int) {
+	results := make([]*SearchResult, 0)
+	if len(searchResults) == 0 {
+		return results, 0
+	}
+
+	keywordWeight := 1.0
+	if len(searchResults) > 1 {
+		keywordWeight = 1 - SemanticWeight
+	}
+
+	totalCount := 0
+	byID := make(map[string]*SearchResult)
+	keyword := searchResults[0]
+	if keyword.Found != nil {
+		totalCount = *keyword.Found
+	}
+	if keyword.Hits != nil {
+		var bestMatch int64
+		for _, hit := range *keyword.Hits {
+			if hit.TextMatch != nil {
+				bestMatch = max(bestMatch, *hit.TextMatch)
+			}
+		}
+		for _, hit := range *keyword.Hits {
+			result := s.hitToSearchResult(hit, 0)
+			if result == nil {
+				continue
+			}
+			result.Score = 0
+			if hit.TextMatch != nil && bestMatch > 0 {
+				result.Score = keywordWeight * float64(*hit.TextMatch) / float64(bestMatch)
+			}
+			byID[result.ID] = result
+			results = append(results, result)
+		}
+	}
+
+	if len(searchResults) > 1 && searchResults[1].Hits != nil {
+		for _, hit := range *searchResults[1].Hits {
+			if hit.VectorDistance == nil {
+				continue
+			}
+			result := s.hitToSearchResult(hit, 0)
+			if result == nil {
+				continue
+			}
+			similarity := SemanticWeight * max(0, 1-float64(*hit.VectorDistance))
+			if existing, ok := byID[result.ID]; ok {
+				existing.Score += similarity
+				continue
+			}
+			result.Score = similarity
+			byID[result.ID] = result
+			results = append(results, result)
+			totalCount++
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b *SearchResult) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return results, totalCount
+}
+
+// embedQuery returns the query's vector, or nil without an embedder or when embedding fails,
+// leaving a keyword search
+func (s *service) embedQuery(ctx context.Context, query string) []float32 {
+	if s.embedder == nil || strings.TrimSpace(query) == "" {
+		return nil
+	}
+	vectors, err := s.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().Err(err).Msg("Failed to embed search query")
+		return nil
+	}
+	return vectors[0]
+}
+
+// embedCard returns the vector of a card's title and description
+func (s *service) embedCard(ctx context.Context, doc *CardDocument) ([]float32, error) {
+	text := strings.TrimSpace(doc.Title + "\n\n" + doc.Description)
+	vectors, err := s.embedder.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+// vectorQuery finds the cards nearest to the vector, up to k and within maxVectorDistance
+func vectorQuery(vector []float32, k int) string {
+	values := make([]string, len(vector))
+	for i, v := range vector {
+		values[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	return fmt.Sprintf("%s:([%s], k: %d, distance_threshold: %g)", embeddingField, strings.Join(values, ","), k, maxVectorDistance)
+}
+
+// cardsFilter returns the filter of the cards the user may see within the scope, and the
+// organizations searched. ok is false when the user can't see the scope's organization.
+func (a *searchAccess) cardsFilter(scope *SearchScope) (filter string, searched []string, ok bool) {
+	searched = append(slices.Clone(a.orgIDs), a.guestOrgIDs...)
+	filter = a.filter("organization_id", "project_id")
+	if scope != nil && scope.OrganizationID != "" {
+		if !a.isMemberOf(scope.OrganizationID) && !a.isGuestOf(scope.OrganizationID) {
+			return "", nil, false
+		}
+		searched = []string{scope.OrganizationID}
+		filter, _ = a.organizationFilters(scope.OrganizationID)
+	}
+	if scope != nil && scope.ProjectID != "" {
+		filter = fmt.Sprintf("%s && project_id:=%s", filter, scope.ProjectID)
+	}
+	return filter, searched, true
+}
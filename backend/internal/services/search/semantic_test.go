@@ -0,0 +1,162 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	embeddingsMocks "github.com/thatcatdev/kaimu/backend/internal/services/embeddings/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search/mocks"
+	"github.com/typesense/typesense-go/v2/typesense"
+	"github.com/typesense/typesense-go/v2/typesense/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchSemantic(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	cardHit := func(id string, textMatch *int64, distance *float32) api.SearchResultHit {
+		return api.SearchResultHit{
+			Document:       &map[string]interface{}{"id": id, "title": id, "organization_id": orgID.String()},
+			TextMatch:      textMatch,
+			VectorDistance: distance,
+		}
+	}
+	newService := func(t *testing.T) (*mocks.MockTypesenseClient, *embeddingsMocks.MockProvider, Service) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockTypesenseClient(ctrl)
+		mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+		mockEmbedder := embeddingsMocks.NewMockProvider(ctrl)
+		mockMemberRepo.EXPECT().GetByUserID(gomock.Any(), userID).
+			Return([]*organization_member.OrganizationMember{{OrganizationID: orgID, UserID: userID}}, nil)
+		return mockClient, mockEmbedder, NewService(mockClient, mockMemberRepo, nil, mockEmbedder)
+	}
+
+	t.Run("success - blends keyword and vector scores", func(t *testing.T) {
+		mockClient, mockEmbedder, svc := newService(t)
+
+		mockEmbedder.EXPECT().Embed(gomock.Any(), []string{"login broken"}).Return([][]float32{{0.5, -0.25}}, nil)
+		mockClient.EXPECT().MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, params *api.MultiSearchParams, body api.MultiSearchSearchesParameter) (*api.MultiSearchResult, error) {
+				require.Len(t, body.Searches, 2)
+				filter := "organization_id:[" + orgID.String() + "] && project_id:=project-1"
+				assert.Equal(t, filter, *body.Searches[0].FilterBy)
+				assert.Equal(t, filter, *body.Searches[1].FilterBy)
+				assert.Equal(t, "*", *body.Searches[1].Q)
+				assert.Equal(t, "embedding:([0.5,-0.25], k: 10, distance_threshold: 0.7)", *body.Searches[1].VectorQuery)
+				assert.Equal(t, embeddingField, *body.Searches[1].ExcludeFields)
+
+				return &api.MultiSearchResult{Results: []api.SearchResult{
+					{Found: ptr(2), Hits: &[]api.SearchResultHit{cardHit("login-bug", ptr[int64](100), nil), cardHit("login-page", ptr[int64](50), nil)}},
+					{Found: ptr(2), Hits: &[]api.SearchResultHit{cardHit("auth-failure", nil, ptr[float32](0.2)), cardHit("login-bug", nil, ptr[float32](0.5))}},
+				}}, nil
+			})
+
+		results, err := svc.SearchSemantic(ctx, userID, "login broken", &SearchScope{ProjectID: "project-1"}, 10)
+		require.NoError(t, err)
+		require.Len(t, results.Results, 3)
+		assert.Equal(t, "login-bug", results.Results[0].ID)
+		assert.InDelta(t, 0.4+0.3, results.Results[0].Score, 1e-6)
+		assert.Equal(t, "auth-failure", results.Results[1].ID, "a paraphrase is found by meaning alone")
+		assert.InDelta(t, 0.48, results.Results[1].Score, 1e-6)
+		assert.Equal(t, "login-page", results.Results[2].ID)
+		assert.Equal(t, 3, results.TotalCount)
+		assert.Equal(t, []string{orgID.String()}, results.OrganizationIDs)
+	})
+
+	t.Run("success - keyword search when the query can't be embedded", func(t *testing.T) {
+		mockClient, mockEmbedder, svc := newService(t)
+
+		mockEmbedder.EXPECT().Embed(gomock.Any(), gomock.Any()).Return(nil, errors.New("rate limited"))
+		mockClient.EXPECT().MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, params *api.MultiSearchParams, body api.MultiSearchSearchesParameter) (*api.MultiSearchResult, error) {
+				require.Len(t, body.Searches, 1)
+				assert.Nil(t, body.Searches[0].VectorQuery)
+				return &api.MultiSearchResult{Results: []api.SearchResult{
+					{Found: ptr(1), Hits: &[]api.SearchResultHit{cardHit("login-bug", ptr[int64](80), nil)}},
+				}}, nil
+			})
+
+		results, err := svc.SearchSemantic(ctx, userID, "login", nil, 10)
+		require.NoError(t, err)
+		require.Len(t, results.Results, 1)
+		assert.Equal(t, 1.0, results.Results[0].Score)
+	})
+
+	t.Run("success - organization outside the user's access", func(t *testing.T) {
+		_, _, svc := newService(t)
+
+		results, err := svc.SearchSemantic(ctx, userID, "login", &SearchScope{OrganizationID: uuid.NewString()}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, results.Results)
+	})
+}
+
+func TestIndexCardEmbedding(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - embeds the title and description", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockTypesenseClient(ctrl)
+		mockEmbedder := embeddingsMocks.NewMockProvider(ctrl)
+		svc := NewService(mockClient, memberMocks.NewMockRepository(ctrl), nil, mockEmbedder)
+
+		doc := &CardDocument{ID: "card-1", Title: "Authentication failure", Description: "Users are signed out"}
+		mockEmbedder.EXPECT().Embed(gomock.Any(), []string{"Authentication failure\n\nUsers are signed out"}).Return([][]float32{{0.1, 0.2}}, nil)
+		mockClient.EXPECT().UpsertDocument(gomock.Any(), CollectionCards, doc).Return(nil, nil)
+
+		require.NoError(t, svc.IndexCard(ctx, doc))
+		assert.Equal(t, []float32{0.1, 0.2}, doc.Embedding)
+	})
+
+	t.Run("success - indexes without an embedding when embedding fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockClient := mocks.NewMockTypesenseClient(ctrl)
+		mockEmbedder := embeddingsMocks.NewMockProvider(ctrl)
+		svc := NewService(mockClient, memberMocks.NewMockRepository(ctrl), nil, mockEmbedder)
+
+		doc := &CardDocument{ID: "card-1", Title: "Authentication failure"}
+		mockEmbedder.EXPECT().Embed(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+		mockClient.EXPECT().UpsertDocument(gomock.Any(), CollectionCards, doc).Return(nil, nil)
+
+		require.NoError(t, svc.IndexCard(ctx, doc))
+		assert.Nil(t, doc.Embedding)
+	})
+}
+
+func TestInitializeCollectionsWithEmbeddings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockTypesenseClient(ctrl)
+	mockEmbedder := embeddingsMocks.NewMockProvider(ctrl)
+	svc := NewService(mockClient, memberMocks.NewMockRepository(ctrl), nil, mockEmbedder)
+	mockEmbedder.EXPECT().Dimensions().Return(768).AnyTimes()
+
+	for _, schema := range GetAllSchemas() {
+		if schema.Name == CollectionCards {
+			continue
+		}
+		mockClient.EXPECT().
+			RetrieveAlias(gomock.Any(), schema.Name).
+			Return(&api.CollectionAlias{CollectionName: VersionedCollectionName(schema.Name, SchemaVersions[schema.Name])}, nil)
+	}
+
+	// Cards indexed without embeddings are backfilled into a collection with a vector field
+	embedded := withEmbedding(GetCardSchema(), 768)
+	embedded.Name = "cards_v2_e768"
+	mockClient.EXPECT().RetrieveAlias(gomock.Any(), CollectionCards).Return(&api.CollectionAlias{CollectionName: "cards_v2"}, nil)
+	mockClient.EXPECT().RetrieveCollection(gomock.Any(), "cards_v2_e768").Return(nil, &typesense.HTTPError{Status: http.StatusNotFound})
+	mockClient.EXPECT().CreateCollection(gomock.Any(), embedded).Return(&api.CollectionResponse{Name: "cards_v2_e768"}, nil)
+
+	require.NoError(t, svc.InitializeCollections(context.Background()))
+	assert.Equal(t, []string{CollectionCards}, svc.PendingMigrations())
+	assert.Equal(t, embeddingField, embedded.Fields[len(embedded.Fields)-1].Name)
+	assert.Len(t, GetCardSchema().Fields, len(embedded.Fields)-1, "the base schema is left unchanged")
+}
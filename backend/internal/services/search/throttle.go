@@ -0,0 +1,160 @@
+package search
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// SearchRate is how many searches per second a user may send to Typesense
+	SearchRate = 5
+	// SearchBurst is how many searches a user may send at once before SearchRate applies,
+	// enough for a typeahead to keep up with a fast typist
+	SearchBurst = 20
+	// searchCacheSize is how many recent results are kept per user to answer throttled searches
+	searchCacheSize = 8
+	// searchCacheTTL is how long results may answer throttled searches; it bounds how stale
+	// they are, including access the user has lost since
+	searchCacheTTL = time.Minute
+	// searchIdleTTL is how long a user's limiter is kept after their last search
+	searchIdleTTL = 10 * time.Minute
+)
+
+// userThrottle is a user's search rate limiter and their most recent results
+type userThrottle struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+	// recent is newest last
+	recent []cachedSearch
+}
+
+// cachedSearch is the results of a search a user ran
+type cachedSearch struct {
+	query    string
+	scope    SearchScope
+	limit    int
+	results  *SearchResults
+	cachedAt time.Time
+}
+
+// allowSearch reports whether the user may send a search to Typesense now
+func (s *service) allowSearch(userID uuid.UUID) bool {
+	s.throttleMu.Lock()
+	defer s.throttleMu.Unlock()
+
+	now := s.now()
+	t, ok := s.throttles[userID]
+	if !ok {
+		s.sweepThrottles(now.Add(-searchIdleTTL))
+		t = &userThrottle{limiter: rate.NewLimiter(SearchRate, SearchBurst)}
+		s.throttles[userID] = t
+	}
+	t.lastUsed = now
+	return t.limiter.AllowN(now, 1)
+}
+
+// rememberResults keeps the results of a search to answer the user's throttled searches
+func (s *service) rememberResults(userID uuid.UUID, scope *SearchScope, limit int, results *SearchResults) {
+	s.throttleMu.Lock()
+	defer s.throttleMu.Unlock()
+
+	t, ok := s.throttles[userID]
+	if !ok {
+		return
+	}
+	entry := cachedSearch{
+		query:    normalizeQuery(results.Query),
+		scope:    scopeOf(scope),
+		limit:    limit,
+		results:  results,
+		cachedAt: s.now(),
+	}
+	t.recent = append(t.recent, entry)
+	if len(t.recent) > searchCacheSize {
+		t.recent = t.recent[len(t.recent)-searchCacheSize:]
+	}
+}
+
+// throttledResults answers a throttled search from the user's recent results: those of the
+// same search, or else the matching results of the longest earlier query it extends, as a
+// typeahead does. Without either, it's empty. Results are always marked Throttled.
+func (s *service) throttledResults(userID uuid.UUID, query string, scope *SearchScope, limit int) *SearchResults {
+	s.throttleMu.Lock()
+	defer s.throttleMu.Unlock()
+
+	throttled := &SearchResults{Results: []*SearchResult{}, Query: query, Throttled: true}
+	t, ok := s.throttles[userID]
+	if !ok {
+		return throttled
+	}
+
+	normalized := normalizeQuery(query)
+	wantScope := scopeOf(scope)
+	cutoff := s.now().Add(-searchCacheTTL)
+	var best *cachedSearch
+	for i := len(t.recent) - 1; i >= 0; i-- {
+		c := &t.recent[i]
+		if c.cachedAt.Before(cutoff) || c.scope != wantScope || !strings.HasPrefix(normalized, c.query) {
+			continue
+		}
+		if c.query == normalized && c.limit >= limit {
+			results := *c.results
+			results.Results = results.Results[:min(limit, len(results.Results))]
+			results.Query = query
+			results.Throttled = true
+			return &results
+		}
+		if best == nil || len(c.query) > len(best.query) {
+			best = c
+		}
+	}
+	if best == nil {
+		return throttled
+	}
+
+	words := strings.Fields(normalized)
+	for _, r := range best.results.Results {
+		if len(throttled.Results) == limit {
+			break
+		}
+		if matchesWords(r, words) {
+			throttled.Results = append(throttled.Results, r)
+		}
+	}
+	throttled.TotalCount = len(throttled.Results)
+	return throttled
+}
+
+// sweepThrottles forgets users who haven't searched since cutoff. Callers hold s.throttleMu.
+func (s *service) sweepThrottles(cutoff time.Time) {
+	for userID, t := range s.throttles {
+		if t.lastUsed.Before(cutoff) {
+			delete(s.throttles, userID)
+		}
+	}
+}
+
+// matchesWords reports whether every word is part of the result's title or description
+func matchesWords(r *SearchResult, words []string) bool {
+	text := strings.ToLower(r.Title + " " + r.Description)
+	for _, w := range words {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+func scopeOf(scope *SearchScope) SearchScope {
+	if scope == nil {
+		return SearchScope{}
+	}
+	return *scope
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search/mocks"
+	"github.com/typesense/typesense-go/v2/typesense/api"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchThrottling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockTypesenseClient(ctrl)
+	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+	svc := NewService(mockClient, mockMemberRepo, nil, nil).(*service)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	userID := uuid.New()
+	orgID := uuid.New()
+	mockMemberRepo.EXPECT().
+		GetByUserID(gomock.Any(), userID).
+		Return([]*organization_member.OrganizationMember{{OrganizationID: orgID, UserID: userID}}, nil).
+		AnyTimes()
+
+	found := 2
+	cardHit := func(id, title string) api.SearchResultHit {
+		return api.SearchResultHit{Document: &map[string]interface{}{"id": id, "title": title, "organization_id": orgID.String()}}
+	}
+	mockClient.EXPECT().MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).Return(&api.MultiSearchResult{
+		Results: []api.SearchResult{{Found: &found, Hits: &[]api.SearchResultHit{cardHit("1", "Login bug"), cardHit("2", "Logout flow")}}},
+	}, nil)
+
+	results, err := svc.Search(ctx, userID, "Log", nil, 10)
+	require.NoError(t, err)
+	assert.False(t, results.Throttled)
+	assert.Len(t, results.Results, 2)
+
+	// Use up the rest of the burst
+	for i := 1; i < SearchBurst; i++ {
+		require.True(t, svc.allowSearch(userID))
+	}
+
+	t.Run("same search - answered from its results", func(t *testing.T) {
+		results, err := svc.Search(ctx, userID, "log ", nil, 10)
+		require.NoError(t, err)
+		assert.True(t, results.Throttled)
+		assert.Len(t, results.Results, 2)
+		assert.Equal(t, "log ", results.Query)
+	})
+
+	t.Run("extended query - matching results of the earlier query", func(t *testing.T) {
+		results, err := svc.Search(ctx, userID, "logi", nil, 10)
+		require.NoError(t, err)
+		assert.True(t, results.Throttled)
+		require.Len(t, results.Results, 1)
+		assert.Equal(t, "Login bug", results.Results[0].Title)
+		assert.Equal(t, 1, results.TotalCount)
+	})
+
+	t.Run("unrelated query or other scope - empty", func(t *testing.T) {
+		results, err := svc.Search(ctx, userID, "sprint", nil, 10)
+		require.NoError(t, err)
+		assert.True(t, results.Throttled)
+		assert.Empty(t, results.Results)
+
+		results, err = svc.Search(ctx, userID, "log", &SearchScope{OrganizationID: orgID.String()}, 10)
+		require.NoError(t, err)
+		assert.True(t, results.Throttled)
+		assert.Empty(t, results.Results)
+	})
+
+	t.Run("stale results are not used", func(t *testing.T) {
+		now = now.Add(searchCacheTTL + time.Second)
+		for svc.allowSearch(userID) {
+		}
+
+		results, err := svc.Search(ctx, userID, "log", nil, 10)
+		require.NoError(t, err)
+		assert.True(t, results.Throttled)
+		assert.Empty(t, results.Results)
+	})
+
+	t.Run("other users are not throttled", func(t *testing.T) {
+		assert.True(t, svc.allowSearch(uuid.New()))
+	})
+}
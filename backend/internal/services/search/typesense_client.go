@@ -17,6 +17,11 @@ type TypesenseClient interface {
 	// Collection operations
 	RetrieveCollection(ctx context.Context, name string) (*api.CollectionResponse, error)
 	CreateCollection(ctx context.Context, schema *api.CollectionSchema) (*api.CollectionResponse, error)
+	DeleteCollection(ctx context.Context, name string) (*api.CollectionResponse, error)
+
+	// Alias operations
+	RetrieveAlias(ctx context.Context, name string) (*api.CollectionAlias, error)
+	UpsertAlias(ctx context.Context, name string, collection string) (*api.CollectionAlias, error)
 
 	// Document operations
 	UpsertDocument(ctx context.Context, collection string, document interface{}) (map[string]interface{}, error)
@@ -53,6 +58,18 @@ func (c *typesenseClientImpl) CreateCollection(ctx context.Context, schema *api.
 	return c.client.Collections().Create(ctx, schema)
 }
 
+func (c *typesenseClientImpl) DeleteCollection(ctx context.Context, name string) (*api.CollectionResponse, error) {
+	return c.client.Collection(name).Delete(ctx)
+}
+
+func (c *typesenseClientImpl) RetrieveAlias(ctx context.Context, name string) (*api.CollectionAlias, error) {
+	return c.client.Alias(name).Retrieve(ctx)
+}
+
+func (c *typesenseClientImpl) UpsertAlias(ctx context.Context, name string, collection string) (*api.CollectionAlias, error) {
+	return c.client.Aliases().Upsert(ctx, name, &api.CollectionAliasSchema{CollectionName: collection})
+}
+
 func (c *typesenseClientImpl) UpsertDocument(ctx context.Context, collection string, document interface{}) (map[string]interface{}, error) {
 	return c.client.Collection(collection).Documents().Upsert(ctx, document)
 }
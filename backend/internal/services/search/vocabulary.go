@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxQueryVariants is how many queries an organization's vocabulary may search for one query
+const maxQueryVariants = 4
+
+// Vocabulary is an organization's search synonyms and stop words, lowercased
+type Vocabulary struct {
+	// Synonyms are sets of words or phrases searched as one another
+	Synonyms [][]string
+	// StopWords are left out of queries
+	StopWords []string
+}
+
+// VocabularySource returns the vocabularies of organizations, leaving out those without one
+type VocabularySource interface {
+	Vocabularies(ctx context.Context, orgIDs []uuid.UUID) (map[uuid.UUID]*Vocabulary, error)
+}
+
+// queries returns what to search in an organization with this vocabulary instead of query:
+// the query without stop words, then the query with a matched word or phrase swapped for each
+// of its synonyms. It's nil when the vocabulary doesn't change the query.
+func (v *Vocabulary) queries(query string) []string {
+	tokens := strings.Fields(strings.ToLower(query))
+	kept := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !slices.Contains(v.StopWords, token) {
+			kept = append(kept, token)
+		}
+	}
+	// A query of only stop words is searched as typed
+	if len(kept) == 0 {
+		kept = tokens
+	}
+	changed := len(kept) != len(tokens)
+
+	base := strings.Join(kept, " ")
+	variants := []string{base}
+	padded := " " + base + " "
+	for _, set := range v.Synonyms {
+		for _, word := range set {
+			if !strings.Contains(padded, " "+word+" ") {
+				continue
+			}
+			for _, synonym := range set {
+				if synonym == word || len(variants) == maxQueryVariants {
+					continue
+				}
+				variant := strings.TrimSpace(strings.Replace(padded, " "+word+" ", " "+synonym+" ", 1))
+				if !slices.Contains(variants, variant) {
+					variants = append(variants, variant)
+					changed = true
+				}
+			}
+			break
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return variants
+}
+
+// queryVariants returns the queries to search instead of query in each of the organizations
+// whose vocabulary changes it
+func (s *service) queryVariants(ctx context.Context, query string, orgIDs []string) (map[string][]string, error) {
+	if s.vocabulary == nil || len(orgIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(orgIDs))
+	for _, id := range orgIDs {
+		if orgID, err := uuid.Parse(id); err == nil {
+			ids = append(ids, orgID)
+		}
+	}
+	vocabularies, err := s.vocabulary.Vocabularies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make(map[string][]string)
+	for orgID, vocabulary := range vocabularies {
+		if queries := vocabulary.queries(query); queries != nil {
+			variants[orgID.String()] = queries
+		}
+	}
+	return variants, nil
+}
+
+// organizationFilters returns the filters of the cards and boards, and of the projects, the
+// user may see in one organization
+func (a *searchAccess) organizationFilters(orgID string) (orgFilter, projectsFilter string) {
+	orgFilter = fmt.Sprintf("organization_id:=%s", orgID)
+	projectsFilter = orgFilter
+	if !a.isMemberOf(orgID) {
+		guestProjects := strings.Join(a.guestProjectIDs, ",")
+		orgFilter = fmt.Sprintf("%s && project_id:[%s]", orgFilter, guestProjects)
+		projectsFilter = fmt.Sprintf("%s && id:[%s]", projectsFilter, guestProjects)
+	}
+	return orgFilter, projectsFilter
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	memberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search/mocks"
+	"github.com/typesense/typesense-go/v2/typesense/api"
+	"go.uber.org/mock/gomock"
+)
+
+// staticVocabularies is a VocabularySource with fixed vocabularies
+type staticVocabularies map[uuid.UUID]*Vocabulary
+
+func (v staticVocabularies) Vocabularies(ctx context.Context, orgIDs []uuid.UUID) (map[uuid.UUID]*Vocabulary, error) {
+	return v, nil
+}
+
+func TestVocabularyQueries(t *testing.T) {
+	v := &Vocabulary{
+		Synonyms:  [][]string{{"bug", "defect", "issue"}, {"log in", "sign in"}},
+		StopWords: []string{"the", "a"},
+	}
+
+	assert.Nil(t, v.queries("Release notes"), "unchanged queries are searched as typed")
+	assert.Equal(t, []string{"release notes"}, v.queries("the release notes"))
+	assert.Nil(t, v.queries("The"), "a query of only stop words is searched as typed")
+	assert.Equal(t, []string{"bug on the board", "defect on the board", "issue on the board"}, (&Vocabulary{Synonyms: v.Synonyms}).queries("Bug on the board"))
+	assert.Equal(t, []string{"cannot log in", "cannot sign in"}, v.queries("cannot log in"))
+	assert.Nil(t, v.queries("debugging"), "synonyms match whole words")
+}
+
+func TestSearchWithVocabulary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockTypesenseClient(ctrl)
+	mockMemberRepo := memberMocks.NewMockRepository(ctrl)
+	userID := uuid.New()
+	plainOrgID := uuid.New()
+	customOrgID := uuid.New()
+	svc := NewService(mockClient, mockMemberRepo, staticVocabularies{
+		customOrgID: {Synonyms: [][]string{{"bug", "defect"}}},
+	}, nil)
+
+	mockMemberRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return([]*organization_member.OrganizationMember{
+		{OrganizationID: plainOrgID, UserID: userID},
+		{OrganizationID: customOrgID, UserID: userID},
+	}, nil)
+
+	card := func(id string, score int64) api.SearchResultHit {
+		return api.SearchResultHit{Document: &map[string]interface{}{"id": id, "title": id}, TextMatch: &score}
+	}
+	found := func(n int) *int { return &n }
+	mockClient.EXPECT().MultiSearch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, params *api.MultiSearchParams, body api.MultiSearchSearchesParameter) (*api.MultiSearchResult, error) {
+			// The 5 base searches, then cards, projects and boards once per variant
+			require.Len(t, body.Searches, 11)
+			assert.True(t, strings.HasSuffix(*body.Searches[0].FilterBy, "organization_id:!=["+customOrgID.String()+"]"))
+			assert.Equal(t, "bug", *body.Searches[5].Q)
+			assert.Equal(t, "organization_id:="+customOrgID.String(), *body.Searches[5].FilterBy)
+			assert.Equal(t, "defect", *body.Searches[8].Q)
+
+			results := make([]api.SearchResult, 11)
+			results[0] = api.SearchResult{Found: found(1), Hits: &[]api.SearchResultHit{card("plain", 10)}}
+			results[5] = api.SearchResult{Found: found(1), Hits: &[]api.SearchResultHit{card("custom", 5)}}
+			results[8] = api.SearchResult{Found: found(2), Hits: &[]api.SearchResultHit{card("custom", 7), card("defect", 6)}}
+			return &api.MultiSearchResult{Results: results}, nil
+		})
+
+	results, err := svc.Search(context.Background(), userID, "bug", nil, 10)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 3)
+	assert.Equal(t, "plain", results.Results[0].ID)
+	assert.Equal(t, "custom", results.Results[1].ID)
+	assert.Equal(t, float64(7), results.Results[1].Score, "a card found by several variants keeps its best score")
+	assert.Equal(t, "defect", results.Results[2].ID)
+	assert.Equal(t, 3, results.TotalCount)
+}
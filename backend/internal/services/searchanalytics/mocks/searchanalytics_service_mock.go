@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: searchanalytics_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=searchanalytics_service.go -destination=mocks/searchanalytics_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	organization "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	search "github.com/thatcatdev/kaimu/backend/internal/services/search"
+	searchanalytics "github.com/thatcatdev/kaimu/backend/internal/services/searchanalytics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAnalytics mocks base method.
+func (m *MockService) GetAnalytics(ctx context.Context, orgID uuid.UUID, since time.Time) (*searchanalytics.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", ctx, orgID, since)
+	ret0, _ := ret[0].(*searchanalytics.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockServiceMockRecorder) GetAnalytics(ctx, orgID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockService)(nil).GetAnalytics), ctx, orgID, since)
+}
+
+// Record mocks base method.
+func (m *MockService) Record(ctx context.Context, userID uuid.UUID, results *search.SearchResults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, userID, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockServiceMockRecorder) Record(ctx, userID, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockService)(nil).Record), ctx, userID, results)
+}
+
+// RecordAsync mocks base method.
+func (m *MockService) RecordAsync(ctx context.Context, userID uuid.UUID, results *search.SearchResults) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordAsync", ctx, userID, results)
+}
+
+// RecordAsync indicates an expected call of RecordAsync.
+func (mr *MockServiceMockRecorder) RecordAsync(ctx, userID, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAsync", reflect.TypeOf((*MockService)(nil).RecordAsync), ctx, userID, results)
+}
+
+// SetAnonymized mocks base method.
+func (m *MockService) SetAnonymized(ctx context.Context, orgID uuid.UUID, anonymized bool) (*organization.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAnonymized", ctx, orgID, anonymized)
+	ret0, _ := ret[0].(*organization.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAnonymized indicates an expected call of SetAnonymized.
+func (mr *MockServiceMockRecorder) SetAnonymized(ctx, orgID, anonymized any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAnonymized", reflect.TypeOf((*MockService)(nil).SetAnonymized), ctx, orgID, anonymized)
+}
@@ -0,0 +1,321 @@
+package searchanalytics
+
+//go:generate mockgen -source=searchanalytics_service.go -destination=mocks/searchanalytics_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPeriod is how far back analytics look when no start is given
+	DefaultPeriod = 30 * 24 * time.Hour
+	// minQueryLength is the shortest query recorded; shorter ones are typeahead noise
+	minQueryLength = 2
+	// maxQueryLength is how much of a query is recorded, in characters
+	maxQueryLength = 255
+	// typeaheadWindow is how soon a search that extends or shortens the user's previous one
+	// replaces it instead of being recorded as another search
+	typeaheadWindow = 5 * time.Second
+	// topQueries is how many popular and zero-result queries analytics list
+	topQueries = 20
+	// suggestionCandidates is how many popular queries zero-result terms are compared with
+	suggestionCandidates = 200
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// Analytics summarizes an organization's searches since a time
+type Analytics struct {
+	OrganizationID uuid.UUID
+	Since          time.Time
+	Totals         *search_query.Totals
+	Popular        []*search_query.QueryStats
+	ZeroResult     []*search_query.QueryStats
+	// SynonymSuggestions propose synonyms for zero-result terms
+	SynonymSuggestions []*SynonymSuggestion
+}
+
+// SynonymSuggestion proposes searching Synonym when Term is searched: Term found nothing,
+// and Synonym is a similarly spelled query that found results
+type SynonymSuggestion struct {
+	Term    string
+	Synonym string
+	// Searches is how often Term was searched
+	Searches int
+}
+
+type Service interface {
+	// Record stores a search in the analytics of each organization searched, with the number
+	// of results found in it. Throttled searches and very short queries aren't recorded, and a
+	// search that extends or shortens the user's previous one within seconds replaces it, so
+	// typeahead is recorded as the query the user settled on.
+	Record(ctx context.Context, userID uuid.UUID, results *search.SearchResults) error
+	// RecordAsync records a search in the background, logging failures
+	RecordAsync(ctx context.Context, userID uuid.UUID, results *search.SearchResults)
+	// GetAnalytics returns the organization's popular and zero-result queries since a time
+	GetAnalytics(ctx context.Context, orgID uuid.UUID, since time.Time) (*Analytics, error)
+	// SetAnonymized turns anonymized search analytics on or off. Turning it on also removes the
+	// users from the searches already recorded.
+	SetAnonymized(ctx context.Context, orgID uuid.UUID, anonymized bool) (*organization.Organization, error)
+}
+
+// recentSearch is a user's last recorded search
+type recentSearch struct {
+	query string
+	at    time.Time
+	// ids are the recorded rows by organization
+	ids map[uuid.UUID]uuid.UUID
+}
+
+type service struct {
+	queryRepo search_query.Repository
+	orgRepo   organization.Repository
+	now       func() time.Time
+
+	mu     sync.Mutex
+	recent map[uuid.UUID]*recentSearch
+}
+
+func NewService(queryRepo search_query.Repository, orgRepo organization.Repository) Service {
+	return &service{
+		queryRepo: queryRepo,
+		orgRepo:   orgRepo,
+		now:       time.Now,
+		recent:    make(map[uuid.UUID]*recentSearch),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "searchanalytics.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "searchanalytics"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Record(ctx context.Context, userID uuid.UUID, results *search.SearchResults) error {
+	ctx, span := s.startServiceSpan(ctx, "Record")
+	defer span.End()
+
+	query := normalizeQuery(results.Query)
+	if results.Throttled || utf8.RuneCountInString(query) < minQueryLength || len(results.OrganizationIDs) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int, len(results.OrganizationIDs))
+	for _, r := range results.Results {
+		counts[r.OrganizationID]++
+	}
+
+	orgs, err := s.orgRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	anonymized := make(map[uuid.UUID]bool, len(orgs))
+	for _, org := range orgs {
+		anonymized[org.ID] = org.SearchAnalyticsAnonymized
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	prev := s.recent[userID]
+	if prev == nil {
+		s.sweep(now.Add(-typeaheadWindow))
+	}
+	s.mu.Unlock()
+	typeahead := prev != nil && now.Sub(prev.at) <= typeaheadWindow &&
+		(strings.HasPrefix(query, prev.query) || strings.HasPrefix(prev.query, query))
+
+	recorded := &recentSearch{query: query, at: now, ids: make(map[uuid.UUID]uuid.UUID)}
+	for _, id := range results.OrganizationIDs {
+		orgID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		anonymous, ok := anonymized[orgID]
+		if !ok {
+			continue
+		}
+		if typeahead {
+			if rowID, ok := prev.ids[orgID]; ok {
+				if err := s.queryRepo.UpdateQuery(ctx, rowID, query, counts[id]); err != nil {
+					return err
+				}
+				recorded.ids[orgID] = rowID
+				continue
+			}
+		}
+
+		row := &search_query.SearchQuery{OrganizationID: orgID, Query: query, ResultCount: counts[id]}
+		if !anonymous {
+			row.UserID = &userID
+		}
+		if err := s.queryRepo.Create(ctx, row); err != nil {
+			return err
+		}
+		recorded.ids[orgID] = row.ID
+	}
+
+	s.mu.Lock()
+	s.recent[userID] = recorded
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *service) RecordAsync(ctx context.Context, userID uuid.UUID, results *search.SearchResults) {
+	// Recording outlives the request, but keeps its values (trace, logger)
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.Record(ctx, userID, results); err != nil {
+			log := logger.FromCtx(ctx)
+			log.Error().Err(err).Msg("Failed to record search")
+		}
+	}()
+}
+
+// sweep forgets users whose last search was before cutoff. Callers hold s.mu.
+func (s *service) sweep(cutoff time.Time) {
+	for userID, r := range s.recent {
+		if r.at.Before(cutoff) {
+			delete(s.recent, userID)
+		}
+	}
+}
+
+func (s *service) GetAnalytics(ctx context.Context, orgID uuid.UUID, since time.Time) (*Analytics, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetAnalytics")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	totals, err := s.queryRepo.GetTotals(ctx, orgID, since)
+	if err != nil {
+		return nil, err
+	}
+	popular, err := s.queryRepo.GetPopular(ctx, orgID, since, suggestionCandidates)
+	if err != nil {
+		return nil, err
+	}
+	zeroResult, err := s.queryRepo.GetZeroResult(ctx, orgID, since, topQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Analytics{
+		OrganizationID:     orgID,
+		Since:              since,
+		Totals:             totals,
+		Popular:            popular[:min(topQueries, len(popular))],
+		ZeroResult:         zeroResult,
+		SynonymSuggestions: suggestSynonyms(zeroResult, popular),
+	}, nil
+}
+
+func (s *service) SetAnonymized(ctx context.Context, orgID uuid.UUID, anonymized bool) (*organization.Organization, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetAnonymized")
+	span.SetAttributes(
+		attribute.String("org.id", orgID.String()),
+		attribute.Bool("anonymized", anonymized),
+	)
+	defer span.End()
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	org.SearchAnalyticsAnonymized = anonymized
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+	if anonymized {
+		if err := s.queryRepo.AnonymizeByOrgID(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+	return org, nil
+}
+
+// suggestSynonyms pairs each zero-result term with the most searched query that found
+// results and is within a typo of it
+func suggestSynonyms(zeroResult, popular []*search_query.QueryStats) []*SynonymSuggestion {
+	var suggestions []*SynonymSuggestion
+	for _, term := range zeroResult {
+		var best *search_query.QueryStats
+		bestDistance := maxTypoDistance(term.Query) + 1
+		for _, candidate := range popular {
+			if candidate.AvgResults == 0 || candidate.Query == term.Query {
+				continue
+			}
+			// popular is most searched first, so a tie keeps the more searched candidate
+			if d := editDistance(term.Query, candidate.Query); d < bestDistance {
+				best, bestDistance = candidate, d
+			}
+		}
+		if best != nil {
+			suggestions = append(suggestions, &SynonymSuggestion{Term: term.Query, Synonym: best.Query, Searches: term.Searches})
+		}
+	}
+	return suggestions
+}
+
+// maxTypoDistance is how many edits a query may be from another to be a misspelling of it
+func maxTypoDistance(query string) int {
+	if utf8.RuneCountInString(query) <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance is the Levenshtein distance between a and b, in characters
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// normalizeQuery lowercases a query, collapses its whitespace and truncates it
+func normalizeQuery(query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if runes := []rune(normalized); len(runes) > maxQueryLength {
+		normalized = string(runes[:maxQueryLength])
+	}
+	return normalized
+}
@@ -0,0 +1,183 @@
+package searchanalytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query"
+	queryMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_query/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+	anonOrgID := uuid.New()
+	orgs := []*organization.Organization{{ID: orgID}, {ID: anonOrgID, SearchAnalyticsAnonymized: true}}
+
+	resultsFor := func(query string, inOrg int) *search.SearchResults {
+		results := &search.SearchResults{Query: query, OrganizationIDs: []string{orgID.String(), anonOrgID.String()}}
+		for i := 0; i < inOrg; i++ {
+			results.Results = append(results.Results, &search.SearchResult{OrganizationID: orgID.String()})
+		}
+		return results
+	}
+
+	t.Run("success - records per organization, anonymized where configured", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		mockQueryRepo := queryMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockQueryRepo, mockOrgRepo).(*service)
+		svc.now = func() time.Time { return *&now }
+
+		mockOrgRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(orgs, nil)
+		var rows []*search_query.SearchQuery
+		mockQueryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, q *search_query.SearchQuery) error {
+				q.ID = uuid.New()
+				rows = append(rows, q)
+				return nil
+			})
+
+		require.NoError(t, svc.Record(ctx, userID, resultsFor("  Login  Bug", 3)))
+		require.Len(t, rows, 2)
+		assert.Equal(t, orgID, rows[0].OrganizationID)
+		assert.Equal(t, "login bug", rows[0].Query)
+		assert.Equal(t, 3, rows[0].ResultCount)
+		assert.Equal(t, &userID, rows[0].UserID)
+		assert.Equal(t, anonOrgID, rows[1].OrganizationID)
+		assert.Equal(t, 0, rows[1].ResultCount)
+		assert.Nil(t, rows[1].UserID)
+	})
+
+	t.Run("success - typeahead replaces the previous search", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		mockQueryRepo := queryMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockQueryRepo, mockOrgRepo).(*service)
+		svc.now = func() time.Time { return *&now }
+
+		mockOrgRepo.EXPECT().GetByUserID(gomock.Any(), userID).Return(orgs, nil).Times(3)
+		ids := map[uuid.UUID]uuid.UUID{}
+		mockQueryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, q *search_query.SearchQuery) error {
+				q.ID = uuid.New()
+				ids[q.OrganizationID] = q.ID
+				return nil
+			})
+		require.NoError(t, svc.Record(ctx, userID, resultsFor("log", 5)))
+
+		now = now.Add(time.Second)
+		mockQueryRepo.EXPECT().UpdateQuery(gomock.Any(), ids[orgID], "login", 2).Return(nil)
+		mockQueryRepo.EXPECT().UpdateQuery(gomock.Any(), ids[anonOrgID], "login", 0).Return(nil)
+		require.NoError(t, svc.Record(ctx, userID, resultsFor("login", 2)))
+
+		// The same query much later is another search
+		now = now.Add(typeaheadWindow + time.Second)
+		mockQueryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+		require.NoError(t, svc.Record(ctx, userID, resultsFor("login", 2)))
+	})
+
+	t.Run("success - skips throttled and short searches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		mockQueryRepo := queryMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockQueryRepo, mockOrgRepo).(*service)
+		svc.now = func() time.Time { return *&now }
+
+		throttled := resultsFor("login", 1)
+		throttled.Throttled = true
+		require.NoError(t, svc.Record(ctx, userID, throttled))
+		require.NoError(t, svc.Record(ctx, userID, resultsFor(" l ", 1)))
+	})
+}
+
+func TestGetAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	mockQueryRepo := queryMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockQueryRepo, mockOrgRepo).(*service)
+	svc.now = func() time.Time { return *&now }
+
+	orgID := uuid.New()
+	since := now.Add(-DefaultPeriod)
+
+	totals := &search_query.Totals{Searches: 40, ZeroResultSearches: 6, Searchers: 3}
+	popular := []*search_query.QueryStats{
+		{Query: "login", Searches: 20, AvgResults: 4},
+		{Query: "logo", Searches: 10, AvgResults: 1},
+		{Query: "authentication", Searches: 5, AvgResults: 2},
+		{Query: "lgin", Searches: 4},
+	}
+	zeroResult := []*search_query.QueryStats{
+		{Query: "lgin", Searches: 4},
+		{Query: "authentcation", Searches: 1},
+		{Query: "defect", Searches: 1},
+	}
+	mockQueryRepo.EXPECT().GetTotals(gomock.Any(), orgID, since).Return(totals, nil)
+	mockQueryRepo.EXPECT().GetPopular(gomock.Any(), orgID, since, suggestionCandidates).Return(popular, nil)
+	mockQueryRepo.EXPECT().GetZeroResult(gomock.Any(), orgID, since, topQueries).Return(zeroResult, nil)
+
+	analytics, err := svc.GetAnalytics(context.Background(), orgID, since)
+	require.NoError(t, err)
+	assert.Equal(t, totals, analytics.Totals)
+	assert.Len(t, analytics.Popular, 4)
+	assert.Equal(t, []*SynonymSuggestion{
+		{Term: "lgin", Synonym: "login", Searches: 4},
+		{Term: "authentcation", Synonym: "authentication", Searches: 1},
+	}, analytics.SynonymSuggestions)
+}
+
+func TestSetAnonymized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Now()
+
+	mockQueryRepo := queryMocks.NewMockRepository(ctrl)
+	mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockQueryRepo, mockOrgRepo).(*service)
+	svc.now = func() time.Time { return *&now }
+
+	orgID := uuid.New()
+
+	org := &organization.Organization{ID: orgID}
+	mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(org, nil)
+	mockOrgRepo.EXPECT().Update(gomock.Any(), org).Return(nil)
+	mockQueryRepo.EXPECT().AnonymizeByOrgID(gomock.Any(), orgID).Return(nil)
+
+	result, err := svc.SetAnonymized(context.Background(), orgID, true)
+	require.NoError(t, err)
+	assert.True(t, result.SearchAnalyticsAnonymized)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("bug", "bug"))
+	assert.Equal(t, 1, editDistance("lgin", "login"))
+	assert.Equal(t, 2, editDistance("défaut", "defaul"))
+	assert.Equal(t, 3, editDistance("", "bug"))
+}
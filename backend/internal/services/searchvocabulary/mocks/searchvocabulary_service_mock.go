@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: searchvocabulary_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=searchvocabulary_service.go -destination=mocks/searchvocabulary_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	search_synonym_set "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set"
+	search "github.com/thatcatdev/kaimu/backend/internal/services/search"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateSynonymSet mocks base method.
+func (m *MockService) CreateSynonymSet(ctx context.Context, orgID uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSynonymSet", ctx, orgID, words)
+	ret0, _ := ret[0].(*search_synonym_set.SearchSynonymSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSynonymSet indicates an expected call of CreateSynonymSet.
+func (mr *MockServiceMockRecorder) CreateSynonymSet(ctx, orgID, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSynonymSet", reflect.TypeOf((*MockService)(nil).CreateSynonymSet), ctx, orgID, words)
+}
+
+// DeleteSynonymSet mocks base method.
+func (m *MockService) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSynonymSet", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSynonymSet indicates an expected call of DeleteSynonymSet.
+func (mr *MockServiceMockRecorder) DeleteSynonymSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSynonymSet", reflect.TypeOf((*MockService)(nil).DeleteSynonymSet), ctx, id)
+}
+
+// GetStopWords mocks base method.
+func (m *MockService) GetStopWords(ctx context.Context, orgID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStopWords", ctx, orgID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStopWords indicates an expected call of GetStopWords.
+func (mr *MockServiceMockRecorder) GetStopWords(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStopWords", reflect.TypeOf((*MockService)(nil).GetStopWords), ctx, orgID)
+}
+
+// GetSynonymSet mocks base method.
+func (m *MockService) GetSynonymSet(ctx context.Context, id uuid.UUID) (*search_synonym_set.SearchSynonymSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSynonymSet", ctx, id)
+	ret0, _ := ret[0].(*search_synonym_set.SearchSynonymSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSynonymSet indicates an expected call of GetSynonymSet.
+func (mr *MockServiceMockRecorder) GetSynonymSet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSynonymSet", reflect.TypeOf((*MockService)(nil).GetSynonymSet), ctx, id)
+}
+
+// GetSynonymSets mocks base method.
+func (m *MockService) GetSynonymSets(ctx context.Context, orgID uuid.UUID) ([]*search_synonym_set.SearchSynonymSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSynonymSets", ctx, orgID)
+	ret0, _ := ret[0].([]*search_synonym_set.SearchSynonymSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSynonymSets indicates an expected call of GetSynonymSets.
+func (mr *MockServiceMockRecorder) GetSynonymSets(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSynonymSets", reflect.TypeOf((*MockService)(nil).GetSynonymSets), ctx, orgID)
+}
+
+// SetStopWords mocks base method.
+func (m *MockService) SetStopWords(ctx context.Context, orgID uuid.UUID, words []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStopWords", ctx, orgID, words)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStopWords indicates an expected call of SetStopWords.
+func (mr *MockServiceMockRecorder) SetStopWords(ctx, orgID, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStopWords", reflect.TypeOf((*MockService)(nil).SetStopWords), ctx, orgID, words)
+}
+
+// UpdateSynonymSet mocks base method.
+func (m *MockService) UpdateSynonymSet(ctx context.Context, id uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSynonymSet", ctx, id, words)
+	ret0, _ := ret[0].(*search_synonym_set.SearchSynonymSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSynonymSet indicates an expected call of UpdateSynonymSet.
+func (mr *MockServiceMockRecorder) UpdateSynonymSet(ctx, id, words any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSynonymSet", reflect.TypeOf((*MockService)(nil).UpdateSynonymSet), ctx, id, words)
+}
+
+// Vocabularies mocks base method.
+func (m *MockService) Vocabularies(ctx context.Context, orgIDs []uuid.UUID) (map[uuid.UUID]*search.Vocabulary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vocabularies", ctx, orgIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]*search.Vocabulary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vocabularies indicates an expected call of Vocabularies.
+func (mr *MockServiceMockRecorder) Vocabularies(ctx, orgIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vocabularies", reflect.TypeOf((*MockService)(nil).Vocabularies), ctx, orgIDs)
+}
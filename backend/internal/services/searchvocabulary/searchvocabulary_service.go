@@ -0,0 +1,272 @@
+package searchvocabulary
+
+//go:generate mockgen -source=searchvocabulary_service.go -destination=mocks/searchvocabulary_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_stop_word"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxSynonymSets is how many synonym sets an organization may have
+	MaxSynonymSets = 200
+	// MaxSynonyms is how many words or phrases a synonym set may have
+	MaxSynonyms = 16
+	// MaxStopWords is how many stop words an organization may have
+	MaxStopWords = 200
+	// maxWordLength is the longest synonym or stop word, in characters
+	maxWordLength = 64
+)
+
+var (
+	ErrSynonymSetNotFound = errors.New("synonym set not found")
+	ErrTooFewSynonyms     = errors.New("a synonym set needs at least two different words")
+	ErrTooManySynonyms    = errors.New("too many words in synonym set")
+	ErrTooManySynonymSets = errors.New("too many synonym sets")
+	ErrTooManyStopWords   = errors.New("too many stop words")
+	ErrInvalidWord        = errors.New("words must be 1 to 64 characters")
+	ErrStopWordNotSingle  = errors.New("a stop word must be a single word")
+)
+
+type Service interface {
+	search.VocabularySource
+
+	// GetSynonymSets returns the organization's synonym sets, oldest first
+	GetSynonymSets(ctx context.Context, orgID uuid.UUID) ([]*search_synonym_set.SearchSynonymSet, error)
+	GetSynonymSet(ctx context.Context, id uuid.UUID) (*search_synonym_set.SearchSynonymSet, error)
+	// CreateSynonymSet adds a set of words or phrases searched as one another. Words are
+	// lowercased and deduplicated.
+	CreateSynonymSet(ctx context.Context, orgID uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error)
+	UpdateSynonymSet(ctx context.Context, id uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error)
+	DeleteSynonymSet(ctx context.Context, id uuid.UUID) error
+	// GetStopWords returns the organization's stop words, alphabetically
+	GetStopWords(ctx context.Context, orgID uuid.UUID) ([]string, error)
+	// SetStopWords replaces the organization's stop words. Words are lowercased and
+	// deduplicated.
+	SetStopWords(ctx context.Context, orgID uuid.UUID, words []string) ([]string, error)
+}
+
+type service struct {
+	synonymRepo  search_synonym_set.Repository
+	stopWordRepo search_stop_word.Repository
+}
+
+func NewService(synonymRepo search_synonym_set.Repository, stopWordRepo search_stop_word.Repository) Service {
+	return &service{
+		synonymRepo:  synonymRepo,
+		stopWordRepo: stopWordRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "searchvocabulary.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "searchvocabulary"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Vocabularies(ctx context.Context, orgIDs []uuid.UUID) (map[uuid.UUID]*search.Vocabulary, error) {
+	ctx, span := s.startServiceSpan(ctx, "Vocabularies")
+	span.SetAttributes(attribute.Int("org.count", len(orgIDs)))
+	defer span.End()
+
+	sets, err := s.synonymRepo.GetByOrgIDs(ctx, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	stopWords, err := s.stopWordRepo.GetByOrgIDs(ctx, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	vocabularies := make(map[uuid.UUID]*search.Vocabulary)
+	vocabularyOf := func(orgID uuid.UUID) *search.Vocabulary {
+		v, ok := vocabularies[orgID]
+		if !ok {
+			v = &search.Vocabulary{}
+			vocabularies[orgID] = v
+		}
+		return v
+	}
+	for _, set := range sets {
+		v := vocabularyOf(set.OrganizationID)
+		v.Synonyms = append(v.Synonyms, set.Words)
+	}
+	for _, word := range stopWords {
+		v := vocabularyOf(word.OrganizationID)
+		v.StopWords = append(v.StopWords, word.Word)
+	}
+	return vocabularies, nil
+}
+
+func (s *service) GetSynonymSets(ctx context.Context, orgID uuid.UUID) ([]*search_synonym_set.SearchSynonymSet, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSynonymSets")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	return s.synonymRepo.GetByOrgIDs(ctx, []uuid.UUID{orgID})
+}
+
+func (s *service) GetSynonymSet(ctx context.Context, id uuid.UUID) (*search_synonym_set.SearchSynonymSet, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSynonymSet")
+	span.SetAttributes(attribute.String("synonym_set.id", id.String()))
+	defer span.End()
+
+	set, err := s.synonymRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSynonymSetNotFound
+		}
+		return nil, err
+	}
+	return set, nil
+}
+
+func (s *service) CreateSynonymSet(ctx context.Context, orgID uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateSynonymSet")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	normalized, err := normalizeSynonyms(words)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.synonymRepo.CountByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxSynonymSets {
+		return nil, ErrTooManySynonymSets
+	}
+
+	set := &search_synonym_set.SearchSynonymSet{OrganizationID: orgID, Words: normalized}
+	if err := s.synonymRepo.Create(ctx, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (s *service) UpdateSynonymSet(ctx context.Context, id uuid.UUID, words []string) (*search_synonym_set.SearchSynonymSet, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateSynonymSet")
+	span.SetAttributes(attribute.String("synonym_set.id", id.String()))
+	defer span.End()
+
+	normalized, err := normalizeSynonyms(words)
+	if err != nil {
+		return nil, err
+	}
+	set, err := s.GetSynonymSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	set.Words = normalized
+	if err := s.synonymRepo.Update(ctx, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (s *service) DeleteSynonymSet(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeleteSynonymSet")
+	span.SetAttributes(attribute.String("synonym_set.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetSynonymSet(ctx, id); err != nil {
+		return err
+	}
+	return s.synonymRepo.Delete(ctx, id)
+}
+
+func (s *service) GetStopWords(ctx context.Context, orgID uuid.UUID) ([]string, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetStopWords")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	rows, err := s.stopWordRepo.GetByOrgIDs(ctx, []uuid.UUID{orgID})
+	if err != nil {
+		return nil, err
+	}
+	words := make([]string, len(rows))
+	for i, row := range rows {
+		words[i] = row.Word
+	}
+	return words, nil
+}
+
+func (s *service) SetStopWords(ctx context.Context, orgID uuid.UUID, words []string) ([]string, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetStopWords")
+	span.SetAttributes(attribute.String("org.id", orgID.String()))
+	defer span.End()
+
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		w, err := normalizeWord(word)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(w, " ") {
+			return nil, ErrStopWordNotSingle
+		}
+		if !slices.Contains(normalized, w) {
+			normalized = append(normalized, w)
+		}
+	}
+	if len(normalized) > MaxStopWords {
+		return nil, ErrTooManyStopWords
+	}
+	slices.Sort(normalized)
+
+	if err := s.stopWordRepo.ReplaceByOrgID(ctx, orgID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// normalizeSynonyms lowercases and deduplicates the words of a synonym set
+func normalizeSynonyms(words []string) ([]string, error) {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		w, err := normalizeWord(word)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, w) {
+			normalized = append(normalized, w)
+		}
+	}
+	if len(normalized) < 2 {
+		return nil, ErrTooFewSynonyms
+	}
+	if len(normalized) > MaxSynonyms {
+		return nil, ErrTooManySynonyms
+	}
+	return normalized, nil
+}
+
+// normalizeWord lowercases a word or phrase and collapses its whitespace
+func normalizeWord(word string) (string, error) {
+	w := strings.Join(strings.Fields(strings.ToLower(word)), " ")
+	if w == "" || utf8.RuneCountInString(w) > maxWordLength {
+		return "", ErrInvalidWord
+	}
+	return w, nil
+}
@@ -0,0 +1,233 @@
+package searchvocabulary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_stop_word"
+	stopWordMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_stop_word/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set"
+	synonymMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/search_synonym_set/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/services/search"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestVocabularies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+	mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+	orgA, orgB, orgC := uuid.New(), uuid.New(), uuid.New()
+	orgIDs := []uuid.UUID{orgA, orgB, orgC}
+
+	mockSynonymRepo.EXPECT().GetByOrgIDs(gomock.Any(), orgIDs).Return([]*search_synonym_set.SearchSynonymSet{
+		{OrganizationID: orgA, Words: search_synonym_set.Words{"bug", "defect"}},
+		{OrganizationID: orgA, Words: search_synonym_set.Words{"log in", "sign in"}},
+	}, nil)
+	mockStopWordRepo.EXPECT().GetByOrgIDs(gomock.Any(), orgIDs).Return([]*search_stop_word.SearchStopWord{
+		{OrganizationID: orgB, Word: "the"},
+	}, nil)
+
+	vocabularies, err := svc.Vocabularies(context.Background(), orgIDs)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]*search.Vocabulary{
+		orgA: {Synonyms: [][]string{{"bug", "defect"}, {"log in", "sign in"}}},
+		orgB: {StopWords: []string{"the"}},
+	}, vocabularies)
+}
+
+func TestCreateSynonymSet(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("success - words are normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		mockSynonymRepo.EXPECT().CountByOrgID(gomock.Any(), orgID).Return(int64(3), nil)
+		mockSynonymRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		set, err := svc.CreateSynonymSet(ctx, orgID, []string{" Log  In", "sign in", "log in"})
+		require.NoError(t, err)
+		assert.Equal(t, orgID, set.OrganizationID)
+		assert.Equal(t, search_synonym_set.Words{"log in", "sign in"}, set.Words)
+	})
+
+	t.Run("error - invalid words", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		_, err := svc.CreateSynonymSet(ctx, orgID, []string{"bug", "BUG"})
+		assert.ErrorIs(t, err, ErrTooFewSynonyms)
+
+		_, err = svc.CreateSynonymSet(ctx, orgID, []string{"bug", "  "})
+		assert.ErrorIs(t, err, ErrInvalidWord)
+
+		_, err = svc.CreateSynonymSet(ctx, orgID, []string{"bug", strings.Repeat("x", maxWordLength+1)})
+		assert.ErrorIs(t, err, ErrInvalidWord)
+
+		words := make([]string, MaxSynonyms+1)
+		for i := range words {
+			words[i] = strings.Repeat("x", i+1)
+		}
+		_, err = svc.CreateSynonymSet(ctx, orgID, words)
+		assert.ErrorIs(t, err, ErrTooManySynonyms)
+	})
+
+	t.Run("error - too many sets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		mockSynonymRepo.EXPECT().CountByOrgID(gomock.Any(), orgID).Return(int64(MaxSynonymSets), nil)
+
+		_, err := svc.CreateSynonymSet(ctx, orgID, []string{"bug", "defect"})
+		assert.ErrorIs(t, err, ErrTooManySynonymSets)
+	})
+}
+
+func TestUpdateSynonymSet(t *testing.T) {
+	ctx := context.Background()
+	setID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		set := &search_synonym_set.SearchSynonymSet{ID: setID, Words: search_synonym_set.Words{"bug", "defect"}}
+		mockSynonymRepo.EXPECT().GetByID(gomock.Any(), setID).Return(set, nil)
+		mockSynonymRepo.EXPECT().Update(gomock.Any(), set).Return(nil)
+
+		updated, err := svc.UpdateSynonymSet(ctx, setID, []string{"bug", "defect", "Issue"})
+		require.NoError(t, err)
+		assert.Equal(t, search_synonym_set.Words{"bug", "defect", "issue"}, updated.Words)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		mockSynonymRepo.EXPECT().GetByID(gomock.Any(), setID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.UpdateSynonymSet(ctx, setID, []string{"bug", "defect"})
+		assert.ErrorIs(t, err, ErrSynonymSetNotFound)
+	})
+}
+
+func TestDeleteSynonymSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+	mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+	setID := uuid.New()
+
+	mockSynonymRepo.EXPECT().GetByID(gomock.Any(), setID).Return(&search_synonym_set.SearchSynonymSet{ID: setID}, nil)
+	mockSynonymRepo.EXPECT().Delete(gomock.Any(), setID).Return(nil)
+
+	require.NoError(t, svc.DeleteSynonymSet(context.Background(), setID))
+}
+
+func TestSetStopWords(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("success - words are normalized and sorted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		mockStopWordRepo.EXPECT().ReplaceByOrgID(gomock.Any(), orgID, []string{"a", "the"}).Return(nil)
+
+		words, err := svc.SetStopWords(ctx, orgID, []string{"The", " a ", "the"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "the"}, words)
+	})
+
+	t.Run("success - clears stop words", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		mockStopWordRepo.EXPECT().ReplaceByOrgID(gomock.Any(), orgID, []string{}).Return(nil)
+
+		words, err := svc.SetStopWords(ctx, orgID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, words)
+	})
+
+	t.Run("error - phrases are not stop words", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		_, err := svc.SetStopWords(ctx, orgID, []string{"of the"})
+		assert.ErrorIs(t, err, ErrStopWordNotSingle)
+	})
+
+	t.Run("error - too many", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSynonymRepo := synonymMocks.NewMockRepository(ctrl)
+		mockStopWordRepo := stopWordMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSynonymRepo, mockStopWordRepo)
+
+		words := make([]string, MaxStopWords+1)
+		for i := range words {
+			words[i] = fmt.Sprintf("word%d", i)
+		}
+		_, err := svc.SetStopWords(ctx, orgID, words)
+		assert.ErrorIs(t, err, ErrTooManyStopWords)
+	})
+}
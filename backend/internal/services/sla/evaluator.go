@@ -0,0 +1,44 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultEvaluationInterval is how often the evaluator checks policies for breaches
+const DefaultEvaluationInterval = time.Minute
+
+// Evaluator runs Service.Evaluate in the background
+type Evaluator struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewEvaluator(svc Service, interval time.Duration) *Evaluator {
+	return &Evaluator{svc: svc, interval: interval}
+}
+
+// Run evaluates SLA policies every interval until ctx is cancelled
+func (e *Evaluator) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		breached, err := e.svc.Evaluate(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to evaluate SLA policies")
+		} else if breached > 0 {
+			log.Info().Int("breached", breached).Msg("Flagged SLA breaches")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sla_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sla_service.go -destination=mocks/sla_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	sla_policy "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	sla "github.com/thatcatdev/kaimu/backend/internal/services/sla"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePolicy mocks base method.
+func (m *MockService) CreatePolicy(ctx context.Context, projectID uuid.UUID, input sla.PolicyInput) (*sla_policy.SLAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePolicy", ctx, projectID, input)
+	ret0, _ := ret[0].(*sla_policy.SLAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePolicy indicates an expected call of CreatePolicy.
+func (mr *MockServiceMockRecorder) CreatePolicy(ctx, projectID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePolicy", reflect.TypeOf((*MockService)(nil).CreatePolicy), ctx, projectID, input)
+}
+
+// DeletePolicy mocks base method.
+func (m *MockService) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockServiceMockRecorder) DeletePolicy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockService)(nil).DeletePolicy), ctx, id)
+}
+
+// Evaluate mocks base method.
+func (m *MockService) Evaluate(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockServiceMockRecorder) Evaluate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockService)(nil).Evaluate), ctx)
+}
+
+// GetPoliciesByProjectID mocks base method.
+func (m *MockService) GetPoliciesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*sla_policy.SLAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoliciesByProjectID", ctx, projectID)
+	ret0, _ := ret[0].([]*sla_policy.SLAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoliciesByProjectID indicates an expected call of GetPoliciesByProjectID.
+func (mr *MockServiceMockRecorder) GetPoliciesByProjectID(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoliciesByProjectID", reflect.TypeOf((*MockService)(nil).GetPoliciesByProjectID), ctx, projectID)
+}
+
+// GetPolicy mocks base method.
+func (m *MockService) GetPolicy(ctx context.Context, id uuid.UUID) (*sla_policy.SLAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, id)
+	ret0, _ := ret[0].(*sla_policy.SLAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockServiceMockRecorder) GetPolicy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockService)(nil).GetPolicy), ctx, id)
+}
+
+// GetSprintReport mocks base method.
+func (m *MockService) GetSprintReport(ctx context.Context, sprintID uuid.UUID) (*sla.SprintReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprintReport", ctx, sprintID)
+	ret0, _ := ret[0].(*sla.SprintReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprintReport indicates an expected call of GetSprintReport.
+func (mr *MockServiceMockRecorder) GetSprintReport(ctx, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintReport", reflect.TypeOf((*MockService)(nil).GetSprintReport), ctx, sprintID)
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockService) UpdatePolicy(ctx context.Context, id uuid.UUID, input sla.PolicyInput) (*sla_policy.SLAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", ctx, id, input)
+	ret0, _ := ret[0].(*sla_policy.SLAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockServiceMockRecorder) UpdatePolicy(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockService)(nil).UpdatePolicy), ctx, id, input)
+}
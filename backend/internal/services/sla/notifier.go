@@ -0,0 +1,147 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"gorm.io/gorm"
+)
+
+// BreachNotifier emails a breaching card's owner: its assignee, or its creator when the
+// card is unassigned
+type BreachNotifier struct {
+	breachRepo sla_breach.Repository
+	policyRepo sla_policy.Repository
+	cardRepo   card.Repository
+	userRepo   user.Repository
+	mailSvc    mail.MailService
+	localeSvc  locale.Service
+	now        func() time.Time
+}
+
+func NewBreachNotifier(breachRepo sla_breach.Repository, policyRepo sla_policy.Repository, cardRepo card.Repository, userRepo user.Repository, mailSvc mail.MailService, localeSvc locale.Service) *BreachNotifier {
+	return &BreachNotifier{
+		breachRepo: breachRepo,
+		policyRepo: policyRepo,
+		cardRepo:   cardRepo,
+		userRepo:   userRepo,
+		mailSvc:    mailSvc,
+		localeSvc:  localeSvc,
+		now:        time.Now,
+	}
+}
+
+// Subscribe registers the notification handler on the bus
+func (n *BreachNotifier) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardSLABreached, n.handleBreached)
+}
+
+// handleBreached sends the breach email once; redelivered events find the breach already
+// marked as notified
+func (n *BreachNotifier) handleBreached(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.SLABreachedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	breach, err := n.breachRepo.GetByID(ctx, payload.BreachID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if breach.NotifiedAt != nil {
+		return nil
+	}
+
+	c, err := n.cardRepo.GetByID(ctx, breach.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	policy, err := n.policyRepo.GetByID(ctx, breach.PolicyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	owner, err := n.owner(ctx, c)
+	if err != nil {
+		return err
+	}
+	if owner != nil && owner.Email != nil {
+		name := owner.Username
+		if owner.DisplayName != nil {
+			name = *owner.DisplayName
+		}
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, owner, policy.ProjectID))
+		subject := i18n.Tc(ctx, "email.sla_breach.subject", map[string]string{"card": c.Title})
+		err = n.mailSvc.SendMail(ctx, []string{*owner.Email}, subject, "sla_breach.mjml", map[string]string{
+			"name":         name,
+			"card_title":   c.Title,
+			"policy_name":  policy.Name,
+			"max_duration": formatDuration(ctx, policy.MaxDuration()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send SLA breach email: %w", err)
+		}
+	}
+
+	_, err = n.breachRepo.MarkNotified(ctx, breach.ID, n.now())
+	return err
+}
+
+// owner returns the user to notify about a card, or nil when it has neither assignee nor creator
+func (n *BreachNotifier) owner(ctx context.Context, c *card.Card) (*user.User, error) {
+	var ownerID *uuid.UUID
+	switch {
+	case c.AssigneeID != nil:
+		ownerID = c.AssigneeID
+	case c.CreatedBy != nil:
+		ownerID = c.CreatedBy
+	default:
+		return nil, nil
+	}
+
+	owner, err := n.userRepo.GetByID(ctx, *ownerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return owner, nil
+}
+
+// formatDuration renders a policy limit as whole hours where possible, e.g. "24 hours", in
+// the context's locale
+func formatDuration(ctx context.Context, d time.Duration) string {
+	if d%time.Hour == 0 {
+		if d == time.Hour {
+			return i18n.Tc(ctx, "duration.hour", nil)
+		}
+		return i18n.Tc(ctx, "duration.hours", map[string]string{"count": strconv.Itoa(int(d / time.Hour))})
+	}
+	minutes := int(d / time.Minute)
+	if minutes == 1 {
+		return i18n.Tc(ctx, "duration.minute", nil)
+	}
+	return i18n.Tc(ctx, "duration.minutes", map[string]string{"count": strconv.Itoa(minutes)})
+}
@@ -0,0 +1,94 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	breachMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	policyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBreachNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	breachRepo := breachMocks.NewMockRepository(ctrl)
+	policyRepo := policyMocks.NewMockRepository(ctrl)
+	cardRepo := cardMocks.NewMockRepository(ctrl)
+	userRepo := userMocks.NewMockRepository(ctrl)
+	localeSvc := localeMocks.NewMockService(ctrl)
+	mailSvc := fakes.NewMailService()
+
+	bus := events.NewSyncBus()
+	NewBreachNotifier(breachRepo, policyRepo, cardRepo, userRepo, mailSvc, localeSvc).Subscribe(bus)
+	ctx := context.Background()
+
+	email := "owner@example.com"
+	owner := &user.User{ID: uuid.New(), Username: "owner", Email: &email}
+	c := &card.Card{ID: uuid.New(), Title: "Fix login", AssigneeID: &owner.ID}
+	policy := &sla_policy.SLAPolicy{ID: uuid.New(), ProjectID: uuid.New(), Name: "Urgent leaves Todo", MaxDurationMinutes: 24 * 60}
+	breach := &sla_breach.SLABreach{ID: uuid.New(), PolicyID: policy.ID, CardID: c.ID}
+
+	publish := func() error {
+		return bus.Publish(ctx, events.New(ctx, events.CardSLABreached, events.SLABreachedPayload{
+			BreachID: breach.ID,
+			PolicyID: policy.ID,
+			CardID:   c.ID,
+		}))
+	}
+
+	t.Run("emails the assignee", func(t *testing.T) {
+		breachRepo.EXPECT().GetByID(gomock.Any(), breach.ID).Return(breach, nil)
+		cardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		policyRepo.EXPECT().GetByID(gomock.Any(), policy.ID).Return(policy, nil)
+		userRepo.EXPECT().GetByID(gomock.Any(), owner.ID).Return(owner, nil)
+		localeSvc.EXPECT().ForProject(gomock.Any(), owner, policy.ProjectID).Return("en")
+		breachRepo.EXPECT().MarkNotified(gomock.Any(), breach.ID, gomock.Any()).Return(true, nil)
+
+		require.NoError(t, publish())
+
+		require.Len(t, mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, mailSvc.Sent[0].To)
+		assert.Equal(t, "sla_breach.mjml", mailSvc.Sent[0].Template)
+		assert.Equal(t, "Fix login", mailSvc.Sent[0].Values["card_title"])
+		assert.Equal(t, "24 hours", mailSvc.Sent[0].Values["max_duration"])
+		assert.Equal(t, "SLA breached: Fix login", mailSvc.Sent[0].Subject)
+	})
+
+	t.Run("redelivery does not email again", func(t *testing.T) {
+		notifiedAt := time.Now()
+		notified := *breach
+		notified.NotifiedAt = &notifiedAt
+		breachRepo.EXPECT().GetByID(gomock.Any(), breach.ID).Return(&notified, nil)
+
+		require.NoError(t, publish())
+		assert.Len(t, mailSvc.Sent, 1)
+	})
+}
+
+func TestFormatDuration(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "1 hour", formatDuration(ctx, time.Hour))
+	assert.Equal(t, "48 hours", formatDuration(ctx, 48*time.Hour))
+	assert.Equal(t, "90 minutes", formatDuration(ctx, 90*time.Minute))
+	assert.Equal(t, "1 minute", formatDuration(ctx, time.Minute))
+
+	de := i18n.WithLocale(ctx, "de")
+	assert.Equal(t, "48 Stunden", formatDuration(de, 48*time.Hour))
+	assert.Equal(t, "1 Minute", formatDuration(de, time.Minute))
+}
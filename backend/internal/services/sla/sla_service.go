@@ -0,0 +1,510 @@
+package sla
+
+//go:generate mockgen -source=sla_service.go -destination=mocks/sla_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPolicyNotFound     = errors.New("SLA policy not found")
+	ErrProjectNotFound    = errors.New("project not found")
+	ErrColumnNotFound     = errors.New("column not found")
+	ErrColumnNotInProject = errors.New("column does not belong to the project")
+	ErrTagNotInProject    = errors.New("tag does not belong to the project")
+	ErrSprintNotFound     = errors.New("sprint not found")
+	ErrNameRequired       = errors.New("SLA policy name is required")
+	ErrInvalidDuration    = errors.New("SLA policy duration must be at least one minute")
+)
+
+// priorityRank orders priorities so escalation only ever raises a card's priority
+var priorityRank = map[card.CardPriority]int{
+	card.PriorityNone:   0,
+	card.PriorityLow:    1,
+	card.PriorityMedium: 2,
+	card.PriorityHigh:   3,
+	card.PriorityUrgent: 4,
+}
+
+// PolicyInput describes an SLA policy to create or replace
+type PolicyInput struct {
+	Name     string
+	ColumnID uuid.UUID
+	// Priority limits the policy to cards of one priority; nil covers all cards
+	Priority           *card.CardPriority
+	MaxDurationMinutes int
+	EscalateToPriority *card.CardPriority
+	BreachTagID        *uuid.UUID
+}
+
+// PolicyCompliance summarizes how a sprint's cards fared against one policy
+type PolicyCompliance struct {
+	Policy *sla_policy.SLAPolicy
+	// TrackedCards is the number of sprint cards the policy covers
+	TrackedCards  int
+	BreachedCards int
+	OpenBreaches  int
+}
+
+// ComplianceRate is the share of tracked cards that never breached the policy
+func (c PolicyCompliance) ComplianceRate() float64 {
+	return complianceRate(c.TrackedCards, c.BreachedCards)
+}
+
+// SprintReport summarizes SLA compliance of the cards in a sprint
+type SprintReport struct {
+	SprintID uuid.UUID
+	Policies []PolicyCompliance
+	// TrackedCards is the number of sprint cards covered by at least one policy
+	TrackedCards  int
+	BreachedCards int
+}
+
+// ComplianceRate is the share of tracked cards that never breached any policy
+func (r *SprintReport) ComplianceRate() float64 {
+	return complianceRate(r.TrackedCards, r.BreachedCards)
+}
+
+func complianceRate(tracked, breached int) float64 {
+	if tracked == 0 {
+		return 1
+	}
+	return float64(tracked-breached) / float64(tracked)
+}
+
+type Service interface {
+	CreatePolicy(ctx context.Context, projectID uuid.UUID, input PolicyInput) (*sla_policy.SLAPolicy, error)
+	UpdatePolicy(ctx context.Context, id uuid.UUID, input PolicyInput) (*sla_policy.SLAPolicy, error)
+	DeletePolicy(ctx context.Context, id uuid.UUID) error
+	GetPolicy(ctx context.Context, id uuid.UUID) (*sla_policy.SLAPolicy, error)
+	GetPoliciesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*sla_policy.SLAPolicy, error)
+	// Evaluate flags cards that have overstayed a policy's limit, escalating and tagging them
+	// as the policy says, and resolves breaches of cards that have since left the column.
+	// It returns the number of new breaches. Running it concurrently is safe.
+	Evaluate(ctx context.Context) (int, error)
+	GetSprintReport(ctx context.Context, sprintID uuid.UUID) (*SprintReport, error)
+}
+
+type service struct {
+	policyRepo  sla_policy.Repository
+	breachRepo  sla_breach.Repository
+	projectRepo project.Repository
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	cardRepo    card.Repository
+	cardTagRepo card_tag.Repository
+	tagRepo     tag.Repository
+	sprintRepo  sprint.Repository
+	txManager   transaction.Manager
+	bus         events.Bus
+	now         func() time.Time
+}
+
+func NewService(
+	policyRepo sla_policy.Repository,
+	breachRepo sla_breach.Repository,
+	projectRepo project.Repository,
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+	cardTagRepo card_tag.Repository,
+	tagRepo tag.Repository,
+	sprintRepo sprint.Repository,
+	txManager transaction.Manager,
+	bus events.Bus,
+) Service {
+	return &service{
+		policyRepo:  policyRepo,
+		breachRepo:  breachRepo,
+		projectRepo: projectRepo,
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		cardRepo:    cardRepo,
+		cardTagRepo: cardTagRepo,
+		tagRepo:     tagRepo,
+		sprintRepo:  sprintRepo,
+		txManager:   txManager,
+		bus:         bus,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "sla.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "sla"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreatePolicy(ctx context.Context, projectID uuid.UUID, input PolicyInput) (*sla_policy.SLAPolicy, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreatePolicy")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	policy := &sla_policy.SLAPolicy{
+		ProjectID: projectID,
+		CreatedBy: events.ActorFromContext(ctx),
+	}
+	if err := s.applyInput(ctx, policy, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.policyRepo.Create(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *service) UpdatePolicy(ctx context.Context, id uuid.UUID, input PolicyInput) (*sla_policy.SLAPolicy, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdatePolicy")
+	span.SetAttributes(attribute.String("sla_policy.id", id.String()))
+	defer span.End()
+
+	policy, err := s.GetPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyInput(ctx, policy, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.policyRepo.Update(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// applyInput validates input against the policy's project and copies it onto the policy
+func (s *service) applyInput(ctx context.Context, policy *sla_policy.SLAPolicy, input PolicyInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return ErrNameRequired
+	}
+	if input.MaxDurationMinutes < 1 {
+		return ErrInvalidDuration
+	}
+
+	col, err := s.columnRepo.GetByID(ctx, input.ColumnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrColumnNotFound
+		}
+		return err
+	}
+	b, err := s.boardRepo.GetByID(ctx, col.BoardID)
+	if err != nil {
+		return err
+	}
+	if b.ProjectID != policy.ProjectID {
+		return ErrColumnNotInProject
+	}
+
+	if input.BreachTagID != nil {
+		t, err := s.tagRepo.GetByID(ctx, *input.BreachTagID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if t == nil || t.ProjectID != policy.ProjectID {
+			return ErrTagNotInProject
+		}
+	}
+
+	policy.Name = name
+	policy.ColumnID = input.ColumnID
+	policy.Priority = input.Priority
+	policy.MaxDurationMinutes = input.MaxDurationMinutes
+	policy.EscalateToPriority = input.EscalateToPriority
+	policy.BreachTagID = input.BreachTagID
+	return nil
+}
+
+func (s *service) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeletePolicy")
+	span.SetAttributes(attribute.String("sla_policy.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetPolicy(ctx, id); err != nil {
+		return err
+	}
+	return s.policyRepo.Delete(ctx, id)
+}
+
+func (s *service) GetPolicy(ctx context.Context, id uuid.UUID) (*sla_policy.SLAPolicy, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetPolicy")
+	span.SetAttributes(attribute.String("sla_policy.id", id.String()))
+	defer span.End()
+
+	policy, err := s.policyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *service) GetPoliciesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*sla_policy.SLAPolicy, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetPoliciesByProjectID")
+	span.SetAttributes(attribute.String("project.id", projectID.String()))
+	defer span.End()
+
+	return s.policyRepo.GetByProjectID(ctx, projectID)
+}
+
+func (s *service) Evaluate(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Evaluate")
+	defer span.End()
+
+	policies, err := s.policyRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	breached := 0
+	for _, policy := range policies {
+		if err := s.resolveBreaches(ctx, policy, now); err != nil {
+			return breached, err
+		}
+		n, err := s.evaluatePolicy(ctx, policy, now)
+		breached += n
+		if err != nil {
+			return breached, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("sla.breached", breached))
+	return breached, nil
+}
+
+// resolveBreaches closes the policy's open breaches whose card has left the column
+func (s *service) resolveBreaches(ctx context.Context, policy *sla_policy.SLAPolicy, now time.Time) error {
+	open, err := s.breachRepo.GetOpenByPolicyID(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, breach := range open {
+		c, err := s.cardRepo.GetByID(ctx, breach.CardID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if c != nil && c.ColumnID == policy.ColumnID && c.ColumnEnteredAt.Equal(breach.ColumnEnteredAt) {
+			continue
+		}
+		if err := s.breachRepo.Resolve(ctx, breach.ID, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluatePolicy flags the cards that have been in the policy's column for too long
+func (s *service) evaluatePolicy(ctx context.Context, policy *sla_policy.SLAPolicy, now time.Time) (int, error) {
+	cards, err := s.cardRepo.GetByColumnEnteredBefore(ctx, policy.ColumnID, now.Add(-policy.MaxDuration()))
+	if err != nil {
+		return 0, err
+	}
+
+	breached := 0
+	for _, c := range cards {
+		if !policy.Applies(c.Priority) {
+			continue
+		}
+		created, err := s.breach(ctx, policy, c, now)
+		if err != nil {
+			return breached, err
+		}
+		if created {
+			breached++
+		}
+	}
+	return breached, nil
+}
+
+// breach records a breach and applies the policy's escalation. A card's stay in the column
+// breaches the policy once; later evaluations of the same stay report false.
+func (s *service) breach(ctx context.Context, policy *sla_policy.SLAPolicy, c *card.Card, now time.Time) (bool, error) {
+	var created bool
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		breach := &sla_breach.SLABreach{
+			PolicyID:        policy.ID,
+			CardID:          c.ID,
+			ColumnEnteredAt: c.ColumnEnteredAt,
+			BreachedAt:      now,
+		}
+		var err error
+		created, err = s.breachRepo.CreateIfAbsent(ctx, breach)
+		if err != nil || !created {
+			return err
+		}
+
+		changed := false
+		if policy.EscalateToPriority != nil && priorityRank[*policy.EscalateToPriority] > priorityRank[c.Priority] {
+			c.Priority = *policy.EscalateToPriority
+			if err := s.cardRepo.Update(ctx, c); err != nil {
+				return err
+			}
+			changed = true
+		}
+		if policy.BreachTagID != nil {
+			tagged, err := s.addTag(ctx, c.ID, *policy.BreachTagID)
+			if err != nil {
+				return err
+			}
+			changed = changed || tagged
+		}
+
+		if changed {
+			if err := s.bus.Publish(ctx, events.New(ctx, events.CardUpdated, events.CardPayload{
+				CardID:   c.ID,
+				BoardID:  c.BoardID,
+				ColumnID: c.ColumnID,
+			})); err != nil {
+				return err
+			}
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.CardSLABreached, events.SLABreachedPayload{
+			BreachID:  breach.ID,
+			PolicyID:  policy.ID,
+			CardID:    c.ID,
+			BoardID:   c.BoardID,
+			ProjectID: policy.ProjectID,
+		}))
+	})
+	return created, err
+}
+
+// addTag adds the tag to the card unless it already has it, reporting whether it was added
+func (s *service) addTag(ctx context.Context, cardID, tagID uuid.UUID) (bool, error) {
+	existing, err := s.cardTagRepo.GetByCardID(ctx, cardID)
+	if err != nil {
+		return false, err
+	}
+	for _, ct := range existing {
+		if ct.TagID == tagID {
+			return false, nil
+		}
+	}
+	return true, s.cardTagRepo.Create(ctx, &card_tag.CardTag{CardID: cardID, TagID: tagID})
+}
+
+func (s *service) GetSprintReport(ctx context.Context, sprintID uuid.UUID) (*SprintReport, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetSprintReport")
+	span.SetAttributes(attribute.String("sprint.id", sprintID.String()))
+	defer span.End()
+
+	sp, err := s.sprintRepo.GetByID(ctx, sprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSprintNotFound
+		}
+		return nil, err
+	}
+	b, err := s.boardRepo.GetByID(ctx, sp.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the project's policies on the sprint's board can apply to its cards
+	columns, err := s.columnRepo.GetByBoardID(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	onBoard := make(map[uuid.UUID]bool, len(columns))
+	for _, col := range columns {
+		onBoard[col.ID] = true
+	}
+	policies, err := s.policyRepo.GetByProjectID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardRepo.GetBySprintID(ctx, sprintID)
+	if err != nil {
+		return nil, err
+	}
+	breaches, err := s.breachRepo.GetBySprintID(ctx, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SprintReport{SprintID: sprintID}
+	tracked := make(map[uuid.UUID]bool)
+	breachedAny := make(map[uuid.UUID]bool)
+	for _, policy := range policies {
+		if !onBoard[policy.ColumnID] {
+			continue
+		}
+
+		policyTracked := make(map[uuid.UUID]bool)
+		for _, c := range cards {
+			if policy.Applies(c.Priority) {
+				policyTracked[c.ID] = true
+			}
+		}
+
+		compliance := PolicyCompliance{Policy: policy}
+		policyBreached := make(map[uuid.UUID]bool)
+		for _, breach := range breaches {
+			if breach.PolicyID != policy.ID {
+				continue
+			}
+			// Escalation may have moved a breached card out of the policy's priority
+			policyTracked[breach.CardID] = true
+			policyBreached[breach.CardID] = true
+			if breach.ResolvedAt == nil {
+				compliance.OpenBreaches++
+			}
+		}
+		compliance.TrackedCards = len(policyTracked)
+		compliance.BreachedCards = len(policyBreached)
+		report.Policies = append(report.Policies, compliance)
+
+		for id := range policyTracked {
+			tracked[id] = true
+		}
+		for id := range policyBreached {
+			breachedAny[id] = true
+		}
+	}
+
+	report.TrackedCards = len(tracked)
+	report.BreachedCards = len(breachedAny)
+	return report, nil
+}
@@ -0,0 +1,351 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	cardTagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach"
+	breachMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_breach/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy"
+	policyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sla_policy/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	tagMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// recordEvents subscribes to names on bus and returns the events published to them
+func recordEvents(bus events.Bus, names ...events.Name) *[]events.Event {
+	var published []events.Event
+	for _, name := range names {
+		bus.Subscribe(name, func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		})
+	}
+	return &published
+}
+
+func TestCreatePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPolicyRepo := policyMocks.NewMockRepository(ctrl)
+	mockBreachRepo := breachMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+	mockTagRepo := tagMocks.NewMockRepository(ctrl)
+	mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockPolicyRepo, mockBreachRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo, mockTagRepo, mockSprintRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+
+	projectID := uuid.New()
+	boardID := uuid.New()
+	columnID := uuid.New()
+	urgent := card.PriorityUrgent
+
+	expectProject := func() {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID}, nil)
+	}
+	expectColumn := func(boardProjectID uuid.UUID) {
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID, BoardID: boardID}, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: boardProjectID}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectProject()
+		expectColumn(projectID)
+		mockPolicyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		policy, err := svc.CreatePolicy(ctx, projectID, PolicyInput{
+			Name:               "  Urgent leaves Todo  ",
+			ColumnID:           columnID,
+			Priority:           &urgent,
+			MaxDurationMinutes: 24 * 60,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Urgent leaves Todo", policy.Name)
+		assert.Equal(t, projectID, policy.ProjectID)
+		assert.Equal(t, 24*time.Hour, policy.MaxDuration())
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreatePolicy(ctx, projectID, PolicyInput{Name: "p", ColumnID: columnID, MaxDurationMinutes: 60})
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		expectProject()
+
+		_, err := svc.CreatePolicy(ctx, projectID, PolicyInput{Name: " ", ColumnID: columnID, MaxDurationMinutes: 60})
+		assert.ErrorIs(t, err, ErrNameRequired)
+	})
+
+	t.Run("duration must be positive", func(t *testing.T) {
+		expectProject()
+
+		_, err := svc.CreatePolicy(ctx, projectID, PolicyInput{Name: "p", ColumnID: columnID})
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+	})
+
+	t.Run("column from another project", func(t *testing.T) {
+		expectProject()
+		expectColumn(uuid.New())
+
+		_, err := svc.CreatePolicy(ctx, projectID, PolicyInput{Name: "p", ColumnID: columnID, MaxDurationMinutes: 60})
+		assert.ErrorIs(t, err, ErrColumnNotInProject)
+	})
+
+	t.Run("tag from another project", func(t *testing.T) {
+		tagID := uuid.New()
+		expectProject()
+		expectColumn(projectID)
+		mockTagRepo.EXPECT().GetByID(gomock.Any(), tagID).Return(&tag.Tag{ID: tagID, ProjectID: uuid.New()}, nil)
+
+		_, err := svc.CreatePolicy(ctx, projectID, PolicyInput{Name: "p", ColumnID: columnID, MaxDurationMinutes: 60, BreachTagID: &tagID})
+		assert.ErrorIs(t, err, ErrTagNotInProject)
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	projectID := uuid.New()
+	boardID := uuid.New()
+	columnID := uuid.New()
+	tagID := uuid.New()
+	urgent := card.PriorityUrgent
+	high := card.PriorityHigh
+
+	policy := &sla_policy.SLAPolicy{
+		ID:                 uuid.New(),
+		ProjectID:          projectID,
+		Name:               "High leaves Todo",
+		ColumnID:           columnID,
+		Priority:           &high,
+		MaxDurationMinutes: 60,
+		EscalateToPriority: &urgent,
+		BreachTagID:        &tagID,
+	}
+
+	t.Run("flags, escalates and tags overdue cards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.CardUpdated, events.CardSLABreached)
+
+		mockPolicyRepo := policyMocks.NewMockRepository(ctrl)
+		mockBreachRepo := breachMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockPolicyRepo, mockBreachRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo, mockTagRepo, mockSprintRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		ctx := context.Background()
+
+		overdue := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: columnID, Priority: card.PriorityHigh, ColumnEnteredAt: now.Add(-2 * time.Hour)}
+		otherPriority := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: columnID, Priority: card.PriorityLow, ColumnEnteredAt: now.Add(-2 * time.Hour)}
+
+		mockPolicyRepo.EXPECT().GetAll(gomock.Any()).Return([]*sla_policy.SLAPolicy{policy}, nil)
+		mockBreachRepo.EXPECT().GetOpenByPolicyID(gomock.Any(), policy.ID).Return(nil, nil)
+		mockCardRepo.EXPECT().
+			GetByColumnEnteredBefore(gomock.Any(), columnID, now.Add(-time.Hour)).
+			Return([]*card.Card{overdue, otherPriority}, nil)
+		mockBreachRepo.EXPECT().
+			CreateIfAbsent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, b *sla_breach.SLABreach) (bool, error) {
+				assert.Equal(t, overdue.ID, b.CardID)
+				assert.Equal(t, overdue.ColumnEnteredAt, b.ColumnEnteredAt)
+				assert.Equal(t, now, b.BreachedAt)
+				b.ID = uuid.New()
+				return true, nil
+			})
+		mockCardRepo.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, c *card.Card) error {
+				assert.Equal(t, card.PriorityUrgent, c.Priority)
+				return nil
+			})
+		mockCardTagRepo.EXPECT().GetByCardID(gomock.Any(), overdue.ID).Return(nil, nil)
+		mockCardTagRepo.EXPECT().Create(gomock.Any(), &card_tag.CardTag{CardID: overdue.ID, TagID: tagID}).Return(nil)
+
+		breached, err := svc.Evaluate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, breached)
+
+		require.Len(t, *published, 2)
+		assert.Equal(t, events.CardUpdated, (*published)[0].Name)
+		assert.Equal(t, events.CardSLABreached, (*published)[1].Name)
+		payload := (*published)[1].Payload.(events.SLABreachedPayload)
+		assert.Equal(t, overdue.ID, payload.CardID)
+		assert.Equal(t, policy.ID, payload.PolicyID)
+		assert.Equal(t, projectID, payload.ProjectID)
+	})
+
+	t.Run("a stay breaches a policy only once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		bus := events.NewSyncBus()
+		published := recordEvents(bus, events.CardUpdated, events.CardSLABreached)
+
+		mockPolicyRepo := policyMocks.NewMockRepository(ctrl)
+		mockBreachRepo := breachMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockPolicyRepo, mockBreachRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo, mockTagRepo, mockSprintRepo, transaction.NewNoopManager(), bus).(*service)
+		svc.now = func() time.Time { return now }
+
+		ctx := context.Background()
+
+		overdue := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: columnID, Priority: card.PriorityHigh, ColumnEnteredAt: now.Add(-2 * time.Hour)}
+
+		mockPolicyRepo.EXPECT().GetAll(gomock.Any()).Return([]*sla_policy.SLAPolicy{policy}, nil)
+		mockBreachRepo.EXPECT().GetOpenByPolicyID(gomock.Any(), policy.ID).Return(nil, nil)
+		mockCardRepo.EXPECT().GetByColumnEnteredBefore(gomock.Any(), columnID, gomock.Any()).Return([]*card.Card{overdue}, nil)
+		mockBreachRepo.EXPECT().CreateIfAbsent(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		breached, err := svc.Evaluate(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, breached)
+		assert.Empty(t, *published)
+	})
+
+	t.Run("resolves breaches of cards that left the column", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockPolicyRepo := policyMocks.NewMockRepository(ctrl)
+		mockBreachRepo := breachMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+		mockTagRepo := tagMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockPolicyRepo, mockBreachRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo, mockTagRepo, mockSprintRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+		svc.now = func() time.Time { return now }
+
+		ctx := context.Background()
+
+		entered := now.Add(-3 * time.Hour)
+		moved := &card.Card{ID: uuid.New(), ColumnID: uuid.New(), ColumnEnteredAt: now.Add(-time.Minute)}
+		stillThere := &card.Card{ID: uuid.New(), ColumnID: columnID, ColumnEnteredAt: entered}
+		movedBreach := &sla_breach.SLABreach{ID: uuid.New(), PolicyID: policy.ID, CardID: moved.ID, ColumnEnteredAt: entered}
+		openBreach := &sla_breach.SLABreach{ID: uuid.New(), PolicyID: policy.ID, CardID: stillThere.ID, ColumnEnteredAt: entered}
+
+		mockPolicyRepo.EXPECT().GetAll(gomock.Any()).Return([]*sla_policy.SLAPolicy{policy}, nil)
+		mockBreachRepo.EXPECT().GetOpenByPolicyID(gomock.Any(), policy.ID).Return([]*sla_breach.SLABreach{movedBreach, openBreach}, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), moved.ID).Return(moved, nil)
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), stillThere.ID).Return(stillThere, nil)
+		mockBreachRepo.EXPECT().Resolve(gomock.Any(), movedBreach.ID, now).Return(nil)
+		mockCardRepo.EXPECT().GetByColumnEnteredBefore(gomock.Any(), columnID, gomock.Any()).Return(nil, nil)
+
+		_, err := svc.Evaluate(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func TestGetSprintReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPolicyRepo := policyMocks.NewMockRepository(ctrl)
+	mockBreachRepo := breachMocks.NewMockRepository(ctrl)
+	mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	mockCardRepo := cardMocks.NewMockRepository(ctrl)
+	mockCardTagRepo := cardTagMocks.NewMockRepository(ctrl)
+	mockTagRepo := tagMocks.NewMockRepository(ctrl)
+	mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockPolicyRepo, mockBreachRepo, mockProjectRepo, mockBoardRepo, mockColumnRepo, mockCardRepo, mockCardTagRepo, mockTagRepo, mockSprintRepo, transaction.NewNoopManager(), events.NewSyncBus()).(*service)
+	svc.now = func() time.Time { return time.Now() }
+
+	ctx := context.Background()
+
+	projectID := uuid.New()
+	boardID := uuid.New()
+	todoID := uuid.New()
+	sprintID := uuid.New()
+	urgent := card.PriorityUrgent
+
+	urgentPolicy := &sla_policy.SLAPolicy{ID: uuid.New(), ProjectID: projectID, ColumnID: todoID, Priority: &urgent, MaxDurationMinutes: 60}
+	anyPolicy := &sla_policy.SLAPolicy{ID: uuid.New(), ProjectID: projectID, ColumnID: todoID, MaxDurationMinutes: 600}
+	otherBoardPolicy := &sla_policy.SLAPolicy{ID: uuid.New(), ProjectID: projectID, ColumnID: uuid.New(), MaxDurationMinutes: 60}
+
+	urgentCard := &card.Card{ID: uuid.New(), Priority: card.PriorityUrgent}
+	lowCard := &card.Card{ID: uuid.New(), Priority: card.PriorityLow}
+	resolvedAt := time.Now()
+
+	mockSprintRepo.EXPECT().GetByID(gomock.Any(), sprintID).Return(&sprint.Sprint{ID: sprintID, BoardID: boardID}, nil)
+	mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+	mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{{ID: todoID, BoardID: boardID}}, nil)
+	mockPolicyRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return([]*sla_policy.SLAPolicy{urgentPolicy, anyPolicy, otherBoardPolicy}, nil)
+	mockCardRepo.EXPECT().GetBySprintID(gomock.Any(), sprintID).Return([]*card.Card{urgentCard, lowCard}, nil)
+	mockBreachRepo.EXPECT().GetBySprintID(gomock.Any(), sprintID).Return([]*sla_breach.SLABreach{
+		{PolicyID: urgentPolicy.ID, CardID: urgentCard.ID, ResolvedAt: &resolvedAt},
+		{PolicyID: anyPolicy.ID, CardID: lowCard.ID},
+	}, nil)
+
+	report, err := svc.GetSprintReport(ctx, sprintID)
+	require.NoError(t, err)
+
+	require.Len(t, report.Policies, 2)
+	assert.Equal(t, urgentPolicy.ID, report.Policies[0].Policy.ID)
+	assert.Equal(t, 1, report.Policies[0].TrackedCards)
+	assert.Equal(t, 1, report.Policies[0].BreachedCards)
+	assert.Equal(t, 0, report.Policies[0].OpenBreaches)
+	assert.Equal(t, 0.0, report.Policies[0].ComplianceRate())
+
+	assert.Equal(t, anyPolicy.ID, report.Policies[1].Policy.ID)
+	assert.Equal(t, 2, report.Policies[1].TrackedCards)
+	assert.Equal(t, 1, report.Policies[1].BreachedCards)
+	assert.Equal(t, 1, report.Policies[1].OpenBreaches)
+	assert.Equal(t, 0.5, report.Policies[1].ComplianceRate())
+
+	assert.Equal(t, 2, report.TrackedCards)
+	assert.Equal(t, 2, report.BreachedCards)
+	assert.Equal(t, 0.0, report.ComplianceRate())
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// Snapshots are set in the Go fonts, which are compiled in, so rendering needs no fonts
+// installed on the server. They cover Latin, Greek and Cyrillic; other characters come out
+// as boxes in PNGs, while SVG viewers fall back to their own fonts.
+var (
+	regularFont = mustParse(goregular.TTF)
+	boldFont    = mustParse(gobold.TTF)
+
+	facesMu sync.Mutex
+	faces   = map[faceKey]font.Face{}
+)
+
+type faceKey struct {
+	size float64
+	bold bool
+}
+
+func mustParse(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// face returns the font face for the size in pixels. Faces are cached, as there are only a
+// few sizes, but are not safe to use concurrently, so callers hold facesMu.
+func face(size float64, bold bool) font.Face {
+	key := faceKey{size: size, bold: bold}
+	if f, ok := faces[key]; ok {
+		return f
+	}
+	f := regularFont
+	if bold {
+		f = boldFont
+	}
+	ff, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		panic(err)
+	}
+	faces[key] = ff
+	return ff
+}
+
+// measure returns how wide text is in pixels
+func measure(text string, size float64, bold bool) float64 {
+	facesMu.Lock()
+	defer facesMu.Unlock()
+	return float64(font.MeasureString(face(size, bold), text)) / 64
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot_service.go -destination=mocks/snapshot_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	snapshot "github.com/thatcatdev/kaimu/backend/internal/services/snapshot"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GenerateBoardSnapshot mocks base method.
+func (m *MockService) GenerateBoardSnapshot(ctx context.Context, boardID uuid.UUID, format snapshot.Format) (*snapshot.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateBoardSnapshot", ctx, boardID, format)
+	ret0, _ := ret[0].(*snapshot.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateBoardSnapshot indicates an expected call of GenerateBoardSnapshot.
+func (mr *MockServiceMockRecorder) GenerateBoardSnapshot(ctx, boardID, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBoardSnapshot", reflect.TypeOf((*MockService)(nil).GenerateBoardSnapshot), ctx, boardID, format)
+}
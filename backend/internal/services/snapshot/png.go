@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// renderPNG rasterizes the scene
+func renderPNG(s *scene) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, s.width, s.height))
+	for _, r := range s.rects {
+		fillRect(img, r)
+	}
+
+	facesMu.Lock()
+	for _, l := range s.labels {
+		d := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(l.fill),
+			Face: face(l.size, l.bold),
+			Dot:  fixed.P(int(math.Round(l.x)), int(math.Round(l.y))),
+		}
+		d.DrawString(l.text)
+	}
+	facesMu.Unlock()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fillRect fills the rectangle, leaving out the pixels outside its rounded corners
+func fillRect(img *image.RGBA, r rect) {
+	bounds := image.Rect(int(math.Round(r.x)), int(math.Round(r.y)), int(math.Round(r.x+r.w)), int(math.Round(r.y+r.h)))
+	if r.radius <= 0 {
+		draw.Draw(img, bounds, image.NewUniform(r.fill), image.Point{}, draw.Src)
+		return
+	}
+	radius := math.Min(r.radius, math.Min(r.w, r.h)/2)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if outsideCorner(float64(x)+0.5-r.x, float64(y)+0.5-r.y, r.w, r.h, radius) {
+				continue
+			}
+			img.SetRGBA(x, y, r.fill)
+		}
+	}
+}
+
+// outsideCorner reports whether the point, relative to the rectangle's top left corner, lies
+// in a corner beyond the arc of the given radius
+func outsideCorner(px, py, w, h, radius float64) bool {
+	cx := math.Max(radius, math.Min(px, w-radius))
+	cy := math.Max(radius, math.Min(py, h-radius))
+	dx, dy := px-cx, py-cy
+	return dx*dx+dy*dy > radius*radius
+}
@@ -0,0 +1,240 @@
+package snapshot
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/textlayout"
+)
+
+// Board is what a snapshot shows
+type Board struct {
+	Title string
+	// Subtitle names the project and the active sprint, if any
+	Subtitle    string
+	Columns     []Column
+	GeneratedAt time.Time
+}
+
+// Column is a board column with its first cards
+type Column struct {
+	Name string
+	// Color is a hex color such as #6B7280; empty uses the default
+	Color    string
+	Count    int
+	Points   int
+	WipLimit *int
+	// Cards are the column's first cards in board order, at most MaxCardsPerColumn
+	Cards []Card
+}
+
+// Card is what a card in a snapshot shows
+type Card struct {
+	Key   string
+	Title string
+	// Assignee is the username of the assignee, empty for unassigned cards
+	Assignee    string
+	StoryPoints *int
+}
+
+const (
+	margin       = 24.0
+	columnWidth  = 248.0
+	columnGutter = 12.0
+	columnPad    = 10.0
+	headerHeight = 64.0
+	columnHead   = 40.0
+	cardGap      = 8.0
+	cardPad      = 10.0
+	cardRadius   = 6.0
+	footerHeight = 28.0
+
+	titleSize = 22.0
+	textSize  = 13.0
+	smallSize = 11.0
+	lineGap   = 4.0
+	// cardTitleLines caps how many lines of a card's title are shown
+	cardTitleLines = 2
+)
+
+var (
+	backgroundColor = rgb(0xF8FAFC)
+	columnColor     = rgb(0xEEF1F5)
+	cardColor       = rgb(0xFFFFFF)
+	borderColor     = rgb(0xD9DEE5)
+	textColor       = rgb(0x111827)
+	mutedColor      = rgb(0x6B7280)
+	overLimitColor  = rgb(0xDC2626)
+	defaultColumn   = rgb(0x6B7280)
+)
+
+// rect is a filled rectangle, with rounded corners when radius is above 0
+type rect struct {
+	x, y, w, h float64
+	radius     float64
+	fill       color.RGBA
+}
+
+// label is one line of text placed by its baseline
+type label struct {
+	x, y float64
+	size float64
+	bold bool
+	fill color.RGBA
+	text string
+}
+
+// scene is a laid out snapshot; rects are drawn first, in order, and labels over them
+type scene struct {
+	width, height int
+	rects         []rect
+	labels        []label
+}
+
+func (s *scene) rect(x, y, w, h, radius float64, fill color.RGBA) {
+	s.rects = append(s.rects, rect{x: x, y: y, w: w, h: h, radius: radius, fill: fill})
+}
+
+func (s *scene) text(x, y, size float64, bold bool, fill color.RGBA, text string) {
+	s.labels = append(s.labels, label{x: x, y: y, size: size, bold: bold, fill: fill, text: text})
+}
+
+// layout arranges the board's columns side by side, each as tall as its cards need, and
+// sizes the image to fit the tallest
+func layout(b Board) *scene {
+	s := &scene{}
+	columns := len(b.Columns)
+	if columns == 0 {
+		columns = 1
+	}
+	width := 2*margin + float64(columns)*columnWidth + float64(columns-1)*columnGutter
+	innerWidth := columnWidth - 2*columnPad
+	cardInner := innerWidth - 2*cardPad
+
+	tallest := columnHead
+	top := margin + headerHeight
+	for i, col := range b.Columns {
+		x := margin + float64(i)*(columnWidth+columnGutter)
+		y := top + columnHead
+
+		for _, c := range col.Cards {
+			titleLines := wrap(c.Title, textSize, true, cardInner, cardTitleLines)
+			h := 2*cardPad + smallSize + lineGap + float64(len(titleLines))*(textSize+lineGap)
+			details := cardDetails(c)
+			if details != "" {
+				h += smallSize + lineGap
+			}
+
+			// A one pixel border is a slightly larger rectangle behind the card
+			s.rect(x+columnPad-1, y-1, innerWidth+2, h+2, cardRadius+1, borderColor)
+			s.rect(x+columnPad, y, innerWidth, h, cardRadius, cardColor)
+			ty := y + cardPad + smallSize
+			s.text(x+columnPad+cardPad, ty, smallSize, false, mutedColor, truncate(c.Key, smallSize, false, cardInner))
+			for _, line := range titleLines {
+				ty += textSize + lineGap
+				s.text(x+columnPad+cardPad, ty, textSize, true, textColor, line)
+			}
+			if details != "" {
+				ty += smallSize + lineGap + 2
+				s.text(x+columnPad+cardPad, ty, smallSize, false, mutedColor, truncate(details, smallSize, false, cardInner))
+			}
+			y += h + cardGap
+		}
+		if more := col.Count - len(col.Cards); more > 0 {
+			s.text(x+columnPad, y+smallSize, smallSize, false, mutedColor, fmt.Sprintf("+%d more", more))
+			y += smallSize + cardGap
+		}
+		if h := y - top + columnPad - cardGap; h > tallest {
+			tallest = h
+		}
+	}
+
+	height := top + tallest + footerHeight + margin
+	s.width, s.height = int(width), int(height)
+
+	// The backgrounds go first so everything else is drawn over them
+	backgrounds := []rect{{w: width, h: height, fill: backgroundColor}}
+	for i, col := range b.Columns {
+		x := margin + float64(i)*(columnWidth+columnGutter)
+		backgrounds = append(backgrounds,
+			rect{x: x, y: top, w: columnWidth, h: tallest, radius: 8, fill: columnColor},
+			rect{x: x, y: top, w: columnWidth, h: 4, fill: parseColor(col.Color)},
+		)
+		s.columnHeader(col, x, top)
+	}
+	s.rects = append(backgrounds, s.rects...)
+
+	s.text(margin, margin+titleSize, titleSize, true, textColor, truncate(b.Title, titleSize, true, width-2*margin))
+	s.text(margin, margin+titleSize+smallSize+10, textSize, false, mutedColor, truncate(b.Subtitle, textSize, false, width-2*margin))
+	s.text(margin, height-margin, smallSize, false, mutedColor, "Generated "+b.GeneratedAt.UTC().Format("2006-01-02 15:04 MST"))
+	return s
+}
+
+// columnHeader names the column and counts its cards against its WIP limit
+func (s *scene) columnHeader(col Column, x, y float64) {
+	count := strconv.Itoa(col.Count)
+	countColor := mutedColor
+	if col.WipLimit != nil {
+		count += " / " + strconv.Itoa(*col.WipLimit)
+		if col.Count > *col.WipLimit {
+			countColor = overLimitColor
+		}
+	}
+	if col.Points > 0 {
+		count += " · " + strconv.Itoa(col.Points) + " pts"
+	}
+	countWidth := measure(count, smallSize, false)
+	baseline := y + 4 + columnHead/2 + textSize/2 - 2
+	s.text(x+columnWidth-columnPad-countWidth, baseline, smallSize, false, countColor, count)
+	s.text(x+columnPad, baseline, textSize, true, textColor, truncate(col.Name, textSize, true, columnWidth-2*columnPad-countWidth-8))
+}
+
+// cardDetails summarises a card's assignee and estimate on one line
+func cardDetails(c Card) string {
+	var parts []string
+	if c.Assignee != "" {
+		parts = append(parts, "@"+c.Assignee)
+	}
+	if c.StoryPoints != nil {
+		parts = append(parts, strconv.Itoa(*c.StoryPoints)+" pts")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// wrap breaks text into lines at most width wide, ending text cut short with an ellipsis
+func wrap(text string, size float64, bold bool, width float64, maxLines int) []string {
+	return textlayout.Wrap(text, measurer(size, bold), width, maxLines, "…")
+}
+
+// truncate shortens text to one line at most width wide
+func truncate(text string, size float64, bold bool, width float64) string {
+	return textlayout.Truncate(text, measurer(size, bold), width, "…")
+}
+
+func measurer(size float64, bold bool) textlayout.Measure {
+	return func(text string) float64 { return measure(text, size, bold) }
+}
+
+func rgb(v uint32) color.RGBA {
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xFF}
+}
+
+// parseColor reads a #RRGGBB color, falling back to the default column color
+func parseColor(hex string) color.RGBA {
+	if len(hex) != 7 || hex[0] != '#' {
+		return defaultColumn
+	}
+	v, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return defaultColumn
+	}
+	return rgb(uint32(v))
+}
+
+// hex formats a color as #RRGGBB
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
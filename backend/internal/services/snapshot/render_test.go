@@ -0,0 +1,104 @@
+package snapshot
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBoard() Board {
+	points, limit := 3, 1
+	return Board{
+		Title:       "Main <board>",
+		Subtitle:    "Kaimu · Sprint 4",
+		GeneratedAt: time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC),
+		Columns: []Column{
+			{Name: "To Do", Color: "#3B82F6", Count: 7, Points: 12, Cards: []Card{
+				{Key: "KAI-1", Title: "Fix login redirect loop when the session cookie expires during sign in with a provider", Assignee: "ana", StoryPoints: &points},
+				{Key: "KAI-2", Title: "Write docs"},
+			}},
+			{Name: "Doing", Color: "not a color", Count: 2, WipLimit: &limit},
+		},
+	}
+}
+
+func TestLayout(t *testing.T) {
+	s := layout(testBoard())
+	assert.Equal(t, int(2*margin+2*columnWidth+columnGutter), s.width)
+
+	var texts []string
+	for _, l := range s.labels {
+		texts = append(texts, l.text)
+		assert.LessOrEqual(t, l.x+measure(l.text, l.size, l.bold), float64(s.width)-margin+0.5, l.text)
+		assert.Less(t, l.y, float64(s.height), l.text)
+	}
+	assert.Contains(t, texts, "+5 more")
+	assert.Contains(t, texts, "@ana · 3 pts")
+	assert.Contains(t, texts, "7 · 12 pts")
+	assert.Contains(t, texts, "2 / 1")
+	assert.Contains(t, texts, "Generated 2026-05-01 09:30 UTC")
+
+	// Long titles wrap onto two lines, the second cut short
+	i := indexOf(texts, "KAI-1")
+	require.GreaterOrEqual(t, i, 0)
+	assert.True(t, strings.HasSuffix(texts[i+2], "…"), texts[i+2])
+
+	// Over its WIP limit, the count turns red; a bad color falls back to the default
+	for _, l := range s.labels {
+		if l.text == "2 / 1" {
+			assert.Equal(t, overLimitColor, l.fill)
+		}
+	}
+	var stripes int
+	for _, r := range s.rects {
+		if r.h == 4 && r.fill == defaultColumn {
+			stripes++
+		}
+	}
+	assert.Equal(t, 1, stripes)
+}
+
+func TestLayout_NoColumns(t *testing.T) {
+	s := layout(Board{Title: "Empty"})
+	assert.Equal(t, int(2*margin+columnWidth), s.width)
+	assert.Greater(t, s.height, 0)
+}
+
+func TestRenderPNG(t *testing.T) {
+	s := layout(testBoard())
+	data, err := renderPNG(s)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, s.width, img.Bounds().Dx())
+	assert.Equal(t, s.height, img.Bounds().Dy())
+
+	// The page background, and a column's bottom rounded corner left unpainted over it
+	bottom := s.height - int(footerHeight+margin) - 1
+	assert.Equal(t, backgroundColor, img.At(1, 1))
+	assert.Equal(t, backgroundColor, img.At(int(margin), bottom))
+	assert.Equal(t, columnColor, img.At(int(margin)+20, bottom))
+}
+
+func TestRenderSVG(t *testing.T) {
+	svg := string(renderSVG(layout(testBoard())))
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	assert.Contains(t, svg, `font-weight="bold" xml:space="preserve">Main &lt;board&gt;</text>`)
+	assert.Contains(t, svg, `fill="#3B82F6"`)
+	assert.Contains(t, svg, ` rx="6"`)
+}
+
+func indexOf(texts []string, text string) int {
+	for i, t := range texts {
+		if t == text {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,263 @@
+package snapshot
+
+//go:generate mockgen -source=snapshot_service.go -destination=mocks/snapshot_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/internal/services/storage"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrStorageDisabled = errors.New("snapshots need object storage, which is not enabled on this server")
+	ErrInvalidFormat   = errors.New("invalid image format")
+	ErrBoardNotFound   = errors.New("board not found")
+)
+
+const (
+	// MaxCardsPerColumn caps how many cards of a column a snapshot shows
+	MaxCardsPerColumn = 5
+	// ImageExpiry is how long the URL of a snapshot is valid; long enough for digest emails
+	// opened days later, and the longest a presigned URL may be
+	ImageExpiry = 7 * 24 * time.Hour
+	// KeyPrefix is where snapshots are stored; a lifecycle rule on it can remove old ones
+	KeyPrefix = "snapshots/"
+)
+
+// Format is the file format of a snapshot
+type Format string
+
+const (
+	// FormatPNG is for Slack and email, which don't show SVG
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// IsValid reports whether f is a known format
+func (f Format) IsValid() bool {
+	return f == FormatPNG || f == FormatSVG
+}
+
+func (f Format) contentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Image is a stored snapshot
+type Image struct {
+	URL       string
+	ExpiresAt time.Time
+	Format    Format
+	Width     int
+	Height    int
+}
+
+type Service interface {
+	// GenerateBoardSnapshot renders the board as it is now, stores the image and returns a
+	// URL to embed it with. The image shows the board's visible columns with their card
+	// counts and first cards. While a sprint is active it only counts the sprint's cards
+	// and leaves the backlog columns out, as the sprint board does.
+	GenerateBoardSnapshot(ctx context.Context, boardID uuid.UUID, format Format) (*Image, error)
+}
+
+type service struct {
+	boardRepo   board.Repository
+	columnRepo  board_column.Repository
+	cardRepo    card.Repository
+	projectRepo project.Repository
+	sprintRepo  sprint.Repository
+	userRepo    user.Repository
+	store       storage.Store
+	now         func() time.Time
+}
+
+// NewService returns the snapshot service; store is nil when object storage is not
+// configured
+func NewService(
+	boardRepo board.Repository,
+	columnRepo board_column.Repository,
+	cardRepo card.Repository,
+	projectRepo project.Repository,
+	sprintRepo sprint.Repository,
+	userRepo user.Repository,
+	store storage.Store,
+) Service {
+	return &service{
+		boardRepo:   boardRepo,
+		columnRepo:  columnRepo,
+		cardRepo:    cardRepo,
+		projectRepo: projectRepo,
+		sprintRepo:  sprintRepo,
+		userRepo:    userRepo,
+		store:       store,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "snapshot.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "snapshot"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GenerateBoardSnapshot(ctx context.Context, boardID uuid.UUID, format Format) (*Image, error) {
+	ctx, span := s.startServiceSpan(ctx, "GenerateBoardSnapshot")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("snapshot.format", string(format)),
+	)
+	defer span.End()
+
+	if !format.IsValid() {
+		return nil, ErrInvalidFormat
+	}
+	if s.store == nil {
+		return nil, ErrStorageDisabled
+	}
+
+	b, err := s.board(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	sc := layout(*b)
+	var body []byte
+	if format == FormatSVG {
+		body = renderSVG(sc)
+	} else if body, err = renderPNG(sc); err != nil {
+		return nil, err
+	}
+
+	filename := "board." + string(format)
+	key := KeyPrefix + uuid.NewString() + "/" + filename
+	if err := s.store.Put(ctx, key, format.contentType(), body); err != nil {
+		return nil, fmt.Errorf("storing snapshot: %w", err)
+	}
+	url, err := s.store.PresignGet(ctx, key, filename, ImageExpiry)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{
+		URL:       url,
+		ExpiresAt: b.GeneratedAt.Add(ImageExpiry),
+		Format:    format,
+		Width:     sc.width,
+		Height:    sc.height,
+	}, nil
+}
+
+// board gathers what the snapshot of the board shows
+func (s *service) board(ctx context.Context, boardID uuid.UUID) (*Board, error) {
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	p, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+
+	active, err := s.sprintRepo.GetActiveByBoardID(ctx, boardID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	var cards []*card.Card
+	subtitle := p.Name
+	if active != nil {
+		cards, err = s.cardRepo.GetBySprintID(ctx, active.ID)
+		subtitle += " · " + active.Name
+		if active.EndDate != nil {
+			subtitle += " · ends " + active.EndDate.Format("Jan 2")
+		}
+	} else {
+		cards, err = s.cardRepo.GetByBoardID(ctx, boardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byColumn := make(map[uuid.UUID][]*card.Card)
+	for _, c := range cards {
+		// Sprint cards include archived ones and may have moved to another board
+		if c.ArchivedAt != nil || c.BoardID != boardID {
+			continue
+		}
+		byColumn[c.ColumnID] = append(byColumn[c.ColumnID], c)
+	}
+
+	usernames := make(map[uuid.UUID]string)
+	snapshot := &Board{Title: b.Name, Subtitle: subtitle, GeneratedAt: s.now()}
+	for _, col := range columns {
+		if col.IsHidden || (active != nil && col.IsBacklog) {
+			continue
+		}
+		colCards := byColumn[col.ID]
+		sort.SliceStable(colCards, func(i, j int) bool { return colCards[i].Position < colCards[j].Position })
+
+		column := Column{Name: col.Name, Color: col.Color, Count: len(colCards), WipLimit: col.WipLimit}
+		for i, c := range colCards {
+			if c.StoryPoints != nil {
+				column.Points += *c.StoryPoints
+			}
+			if i >= MaxCardsPerColumn {
+				continue
+			}
+			shown := Card{Key: cardService.Key(p.Key, c.Number), Title: c.Title, StoryPoints: c.StoryPoints}
+			if c.AssigneeID != nil {
+				if shown.Assignee, err = s.username(ctx, *c.AssigneeID, usernames); err != nil {
+					return nil, err
+				}
+			}
+			column.Cards = append(column.Cards, shown)
+		}
+		snapshot.Columns = append(snapshot.Columns, column)
+	}
+	return snapshot, nil
+}
+
+// username looks a user's username up once per snapshot; deleted users have none
+func (s *service) username(ctx context.Context, id uuid.UUID, cache map[uuid.UUID]string) (string, error) {
+	if name, ok := cache[id]; ok {
+		return name, nil
+	}
+	u, err := s.userRepo.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if u != nil {
+		cache[id] = u.Username
+	}
+	return cache[id], nil
+}
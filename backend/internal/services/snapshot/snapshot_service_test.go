@@ -0,0 +1,185 @@
+package snapshot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	storageMocks "github.com/thatcatdev/kaimu/backend/internal/services/storage/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGenerateBoardSnapshot(t *testing.T) {
+	ctx := context.Background()
+	p := &project.Project{ID: uuid.New(), Name: "Kaimu", Key: "KAI"}
+	b := &board.Board{ID: uuid.New(), ProjectID: p.ID, Name: "Main"}
+	backlog := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Backlog", Position: 0, IsBacklog: true}
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "To Do", Position: 1}
+	hidden := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Hidden", Position: 2, IsHidden: true}
+	done := &board_column.BoardColumn{ID: uuid.New(), BoardID: b.ID, Name: "Done", Position: 3}
+	ana := &user.User{ID: uuid.New(), Username: "ana"}
+	points := 3
+
+	expectBoard := func(mockBoardRepo *boardMocks.MockRepository, mockColumnRepo *columnMocks.MockRepository, mockProjectRepo *projectMocks.MockRepository) {
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(b, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), p.ID).Return(p, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*board_column.BoardColumn{done, hidden, todo, backlog}, nil)
+	}
+
+	t.Run("shows the active sprint's cards", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockProjectRepo, mockSprintRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) }
+
+		expectBoard(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+		end := time.Date(2026, 5, 14, 0, 0, 0, 0, time.UTC)
+		active := &sprint.Sprint{ID: uuid.New(), BoardID: b.ID, Name: "Sprint 4", EndDate: &end}
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), b.ID).Return(active, nil)
+
+		archivedAt := time.Now()
+		var cards []*card.Card
+		for i := 1; i <= MaxCardsPerColumn+2; i++ {
+			cards = append(cards, &card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: todo.ID, Number: i, Title: "Card", Position: float64(10 - i), StoryPoints: &points})
+		}
+		// Both on cards the snapshot shows, looking ana up once
+		cards[len(cards)-1].AssigneeID = &ana.ID
+		cards[len(cards)-2].AssigneeID = &ana.ID
+		cards = append(cards,
+			&card.Card{ID: uuid.New(), BoardID: b.ID, ColumnID: done.ID, Number: 20, Title: "Archived", ArchivedAt: &archivedAt},
+			&card.Card{ID: uuid.New(), BoardID: uuid.New(), ColumnID: uuid.New(), Number: 21, Title: "Moved away"},
+		)
+		mockCardRepo.EXPECT().GetBySprintID(gomock.Any(), active.ID).Return(cards, nil)
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), ana.ID).Return(ana, nil).Times(1)
+
+		snap, err := svc.board(ctx, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Main", snap.Title)
+		assert.Equal(t, "Kaimu · Sprint 4 · ends May 14", snap.Subtitle)
+		require.Len(t, snap.Columns, 2)
+
+		col := snap.Columns[0]
+		assert.Equal(t, "To Do", col.Name)
+		assert.Equal(t, MaxCardsPerColumn+2, col.Count)
+		assert.Equal(t, 3*(MaxCardsPerColumn+2), col.Points)
+		require.Len(t, col.Cards, MaxCardsPerColumn)
+		// Board order, lowest position first
+		assert.Equal(t, "KAI-7", col.Cards[0].Key)
+		assert.Equal(t, "ana", col.Cards[1].Assignee)
+		assert.Empty(t, col.Cards[2].Assignee)
+
+		assert.Equal(t, "Done", snap.Columns[1].Name)
+		assert.Zero(t, snap.Columns[1].Count)
+	})
+
+	t.Run("stores a PNG of the whole board without a sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockProjectRepo, mockSprintRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) }
+
+		expectBoard(mockBoardRepo, mockColumnRepo, mockProjectRepo)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), b.ID).Return(nil, gorm.ErrRecordNotFound)
+		mockCardRepo.EXPECT().GetByBoardID(gomock.Any(), b.ID).Return([]*card.Card{
+			{ID: uuid.New(), BoardID: b.ID, ColumnID: backlog.ID, Number: 1, Title: "Idea"},
+		}, nil)
+
+		var key string
+		var body []byte
+		mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), "image/png", gomock.Any()).
+			DoAndReturn(func(_ context.Context, k, _ string, b []byte) error {
+				key, body = k, b
+				return nil
+			})
+		mockStore.EXPECT().PresignGet(gomock.Any(), gomock.Any(), "board.png", ImageExpiry).Return("https://files/board.png", nil)
+
+		img, err := svc.GenerateBoardSnapshot(ctx, b.ID, FormatPNG)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, KeyPrefix))
+		assert.True(t, strings.HasPrefix(string(body), "\x89PNG"))
+		assert.Equal(t, "https://files/board.png", img.URL)
+		assert.Equal(t, svc.now().Add(ImageExpiry), img.ExpiresAt)
+		// Backlog, To Do and Done
+		assert.Equal(t, int(2*margin+3*columnWidth+2*columnGutter), img.Width)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockProjectRepo, mockSprintRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) }
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), b.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GenerateBoardSnapshot(ctx, b.ID, FormatSVG)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockProjectRepo, mockSprintRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) }
+
+		_, err := svc.GenerateBoardSnapshot(ctx, b.ID, "gif")
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("storage disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+		mockStore := storageMocks.NewMockStore(ctrl)
+		svc := NewService(mockBoardRepo, mockColumnRepo, mockCardRepo, mockProjectRepo, mockSprintRepo, mockUserRepo, mockStore).(*service)
+		svc.now = func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) }
+
+		svc.store = nil
+		_, err := svc.GenerateBoardSnapshot(ctx, b.ID, FormatPNG)
+		assert.ErrorIs(t, err, ErrStorageDisabled)
+	})
+}
@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// svgFonts prefers the Go fonts the layout was measured with, falling back to similar ones
+const svgFonts = `'Go', 'Helvetica Neue', Helvetica, Arial, sans-serif`
+
+// renderSVG writes the scene as an SVG document
+func renderSVG(s *scene) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s">`+"\n",
+		s.width, s.height, s.width, s.height, svgFonts)
+	for _, r := range s.rects {
+		fmt.Fprintf(&buf, `<rect x="%s" y="%s" width="%s" height="%s"`, num(r.x), num(r.y), num(r.w), num(r.h))
+		if r.radius > 0 {
+			fmt.Fprintf(&buf, ` rx="%s"`, num(r.radius))
+		}
+		fmt.Fprintf(&buf, ` fill="%s"/>`+"\n", hex(r.fill))
+	}
+	for _, l := range s.labels {
+		fmt.Fprintf(&buf, `<text x="%s" y="%s" font-size="%s" fill="%s"`, num(l.x), num(l.y), num(l.size), hex(l.fill))
+		if l.bold {
+			buf.WriteString(` font-weight="bold"`)
+		}
+		buf.WriteString(` xml:space="preserve">`)
+		_ = xml.EscapeText(&buf, []byte(l.text))
+		buf.WriteString("</text>\n")
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+// num formats a coordinate as briefly as it can
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: split_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=split_service.go -destination=mocks/split_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	split "github.com/thatcatdev/kaimu/backend/internal/services/split"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// SplitCard mocks base method.
+func (m *MockService) SplitCard(ctx context.Context, input split.Input) (*split.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitCard", ctx, input)
+	ret0, _ := ret[0].(*split.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitCard indicates an expected call of SplitCard.
+func (mr *MockServiceMockRecorder) SplitCard(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitCard", reflect.TypeOf((*MockService)(nil).SplitCard), ctx, input)
+}
@@ -0,0 +1,186 @@
+package split
+
+//go:generate mockgen -source=split_service.go -destination=mocks/split_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MaxSplitCards caps how many cards one split can create
+const MaxSplitCards = 20
+
+var (
+	ErrNoTitles      = errors.New("a split needs at least one card title")
+	ErrTooManyTitles = fmt.Errorf("a card can be split into at most %d cards", MaxSplitCards)
+)
+
+// Input describes a split of a card into new cards
+type Input struct {
+	CardID uuid.UUID
+	// Titles are the titles of the new cards, one card each
+	Titles []string
+	// CopyTags gives every new card the card's tags
+	CopyTags bool
+	// CopyAssignee gives every new card the card's assignee
+	CopyAssignee bool
+	// SplitStoryPoints divides the card's story points among the new cards and clears
+	// them on the card, so the estimate of the work is kept
+	SplitStoryPoints bool
+	CreatedBy        uuid.UUID
+}
+
+// Result is a split card and the cards split off it, in title order
+type Result struct {
+	Card  *card.Card
+	Cards []*card.Card
+}
+
+type Service interface {
+	// SplitCard creates a card per title next to the card, in its column, and links each
+	// to it with a 'split_from' dependency. The card itself is kept.
+	SplitCard(ctx context.Context, input Input) (*Result, error)
+}
+
+type service struct {
+	dependencyRepo card_dependency.Repository
+	cardSvc        cardService.Service
+	txManager      transaction.Manager
+}
+
+func NewService(dependencyRepo card_dependency.Repository, cardSvc cardService.Service, txManager transaction.Manager) Service {
+	return &service{
+		dependencyRepo: dependencyRepo,
+		cardSvc:        cardSvc,
+		txManager:      txManager,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "split.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "split"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) SplitCard(ctx context.Context, input Input) (*Result, error) {
+	ctx, span := s.startServiceSpan(ctx, "SplitCard")
+	span.SetAttributes(attribute.String("card.id", input.CardID.String()))
+	defer span.End()
+
+	var titles []string
+	for _, title := range input.Titles {
+		if title = strings.TrimSpace(title); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	if len(titles) == 0 {
+		return nil, ErrNoTitles
+	}
+	if len(titles) > MaxSplitCards {
+		return nil, ErrTooManyTitles
+	}
+	span.SetAttributes(attribute.Int("split.count", len(titles)))
+
+	original, err := s.cardSvc.GetCard(ctx, input.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagIDs []uuid.UUID
+	if input.CopyTags {
+		tags, err := s.cardSvc.GetTagsForCard(ctx, original.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			tagIDs = append(tagIDs, t.ID)
+		}
+	}
+	var assigneeID *uuid.UUID
+	if input.CopyAssignee {
+		assigneeID = original.AssigneeID
+	}
+	var points []int
+	if input.SplitStoryPoints && original.StoryPoints != nil {
+		points = shareStoryPoints(*original.StoryPoints, len(titles))
+	}
+
+	result := &Result{Card: original, Cards: make([]*card.Card, 0, len(titles))}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, title := range titles {
+			cardInput := cardService.CreateCardInput{
+				ColumnID:   original.ColumnID,
+				Title:      title,
+				Priority:   original.Priority,
+				AssigneeID: assigneeID,
+				TagIDs:     tagIDs,
+				DueDate:    original.DueDate,
+				CreatedBy:  &input.CreatedBy,
+				// The new cards carry on the card's work rather than start from the column
+				SkipColumnDefaults: true,
+				SkipFloodCheck:     true,
+			}
+			if points != nil {
+				cardInput.StoryPoints = &points[i]
+			}
+			c, err := s.cardSvc.CreateCard(ctx, cardInput)
+			if err != nil {
+				return err
+			}
+			err = s.dependencyRepo.Create(ctx, &card_dependency.CardDependency{
+				FromCardID: c.ID,
+				ToCardID:   original.ID,
+				Kind:       card_dependency.KindSplitFrom,
+				CreatedBy:  &input.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			result.Cards = append(result.Cards, c)
+		}
+
+		if points != nil {
+			updated, err := s.cardSvc.UpdateCard(ctx, cardService.UpdateCardInput{ID: original.ID, ClearStoryPoints: true})
+			if err != nil {
+				return err
+			}
+			result.Card = updated
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// shareStoryPoints divides points into n shares as even as whole points allow, the
+// larger shares first
+func shareStoryPoints(points, n int) []int {
+	shares := make([]int, n)
+	for i := range shares {
+		shares[i] = points / n
+		if i < points%n {
+			shares[i]++
+		}
+	}
+	return shares
+}
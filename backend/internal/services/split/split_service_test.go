@@ -0,0 +1,181 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependencyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	cardServiceMocks "github.com/thatcatdev/kaimu/backend/internal/services/card/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSplitCard(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	assigneeID := uuid.New()
+	points := 5
+	original := &card.Card{
+		ID:          uuid.New(),
+		ColumnID:    uuid.New(),
+		Title:       "Checkout",
+		Priority:    card.PriorityHigh,
+		AssigneeID:  &assigneeID,
+		StoryPoints: &points,
+	}
+	tags := []*tag.Tag{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	t.Run("success - copies tags and assignee and splits story points", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), original.ID).Return(original, nil)
+		mockCardSvc.EXPECT().GetTagsForCard(gomock.Any(), original.ID).Return(tags, nil)
+
+		var inputs []cardService.CreateCardInput
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(ctx context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			inputs = append(inputs, input)
+			return &card.Card{ID: uuid.New(), ColumnID: input.ColumnID, Title: input.Title, StoryPoints: input.StoryPoints}, nil
+		})
+		var links []*card_dependency.CardDependency
+		mockDependencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(func(ctx context.Context, d *card_dependency.CardDependency) error {
+			links = append(links, d)
+			return nil
+		})
+		mockCardSvc.EXPECT().UpdateCard(gomock.Any(), cardService.UpdateCardInput{ID: original.ID, ClearStoryPoints: true}).
+			Return(&card.Card{ID: original.ID, Title: original.Title}, nil)
+
+		result, err := svc.SplitCard(ctx, Input{
+			CardID:           original.ID,
+			Titles:           []string{"Cart", "  ", "Payment", "Receipt"},
+			CopyTags:         true,
+			CopyAssignee:     true,
+			SplitStoryPoints: true,
+			CreatedBy:        userID,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Cards, 3)
+		assert.Nil(t, result.Card.StoryPoints)
+
+		for i, title := range []string{"Cart", "Payment", "Receipt"} {
+			assert.Equal(t, title, result.Cards[i].Title)
+			assert.Equal(t, original.ColumnID, inputs[i].ColumnID)
+			assert.Equal(t, card.PriorityHigh, inputs[i].Priority)
+			assert.Equal(t, &assigneeID, inputs[i].AssigneeID)
+			assert.Equal(t, []uuid.UUID{tags[0].ID, tags[1].ID}, inputs[i].TagIDs)
+			assert.True(t, inputs[i].SkipColumnDefaults)
+
+			assert.Equal(t, result.Cards[i].ID, links[i].FromCardID)
+			assert.Equal(t, original.ID, links[i].ToCardID)
+			assert.Equal(t, card_dependency.KindSplitFrom, links[i].Kind)
+		}
+		assert.Equal(t, 2, *inputs[0].StoryPoints)
+		assert.Equal(t, 2, *inputs[1].StoryPoints)
+		assert.Equal(t, 1, *inputs[2].StoryPoints)
+	})
+
+	t.Run("success - keeps story points and leaves tags and assignee", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), original.ID).Return(original, nil)
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input cardService.CreateCardInput) (*card.Card, error) {
+			assert.Nil(t, input.AssigneeID)
+			assert.Empty(t, input.TagIDs)
+			assert.Nil(t, input.StoryPoints)
+			return &card.Card{ID: uuid.New(), Title: input.Title}, nil
+		})
+		mockDependencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := svc.SplitCard(ctx, Input{CardID: original.ID, Titles: []string{"Cart"}, CreatedBy: userID})
+		require.NoError(t, err)
+		assert.Equal(t, original, result.Card)
+		assert.Len(t, result.Cards, 1)
+	})
+
+	t.Run("fail - no titles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		_, err := svc.SplitCard(ctx, Input{CardID: original.ID, Titles: []string{" ", ""}, CreatedBy: userID})
+		assert.ErrorIs(t, err, ErrNoTitles)
+	})
+
+	t.Run("fail - too many titles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		titles := make([]string, MaxSplitCards+1)
+		for i := range titles {
+			titles[i] = "Part"
+		}
+		_, err := svc.SplitCard(ctx, Input{CardID: original.ID, Titles: titles, CreatedBy: userID})
+		assert.ErrorIs(t, err, ErrTooManyTitles)
+	})
+
+	t.Run("fail - card not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), original.ID).Return(nil, cardService.ErrCardNotFound)
+
+		_, err := svc.SplitCard(ctx, Input{CardID: original.ID, Titles: []string{"Cart"}, CreatedBy: userID})
+		assert.ErrorIs(t, err, cardService.ErrCardNotFound)
+	})
+
+	t.Run("fail - create card error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockCardSvc := cardServiceMocks.NewMockService(ctrl)
+
+		svc := NewService(mockDependencyRepo, mockCardSvc, transaction.NewNoopManager())
+
+		mockCardSvc.EXPECT().GetCard(gomock.Any(), original.ID).Return(original, nil)
+		mockCardSvc.EXPECT().CreateCard(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := svc.SplitCard(ctx, Input{CardID: original.ID, Titles: []string{"Cart", "Payment"}, CreatedBy: userID})
+		assert.Error(t, err)
+	})
+}
+
+func TestShareStoryPoints(t *testing.T) {
+	assert.Equal(t, []int{2, 2, 1}, shareStoryPoints(5, 3))
+	assert.Equal(t, []int{1, 1, 0}, shareStoryPoints(2, 3))
+	assert.Equal(t, []int{4, 4}, shareStoryPoints(8, 2))
+	assert.Equal(t, []int{0, 0}, shareStoryPoints(0, 2))
+}
@@ -0,0 +1,30 @@
+package sprint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+)
+
+// MembershipSyncer applies the boards' backlog column policy whenever a card moves
+type MembershipSyncer struct {
+	sprintSvc Service
+}
+
+func NewMembershipSyncer(sprintSvc Service) *MembershipSyncer {
+	return &MembershipSyncer{sprintSvc: sprintSvc}
+}
+
+// Subscribe registers the sync handler on the bus
+func (s *MembershipSyncer) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardMoved, s.handleCardMoved)
+}
+
+func (s *MembershipSyncer) handleCardMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	return s.sprintSvc.ApplyColumnPolicy(ctx, payload.CardID, payload.FromColumnID, payload.ToColumnID)
+}
@@ -0,0 +1,406 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sprint_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sprint_service.go -destination=mocks/sprint_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	board "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	card "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	sprint "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprint0 "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AddCardToSprint mocks base method.
+func (m *MockService) AddCardToSprint(ctx context.Context, cardID, sprintID uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCardToSprint", ctx, cardID, sprintID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCardToSprint indicates an expected call of AddCardToSprint.
+func (mr *MockServiceMockRecorder) AddCardToSprint(ctx, cardID, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCardToSprint", reflect.TypeOf((*MockService)(nil).AddCardToSprint), ctx, cardID, sprintID)
+}
+
+// ApplyColumnPolicy mocks base method.
+func (m *MockService) ApplyColumnPolicy(ctx context.Context, cardID, fromColumnID, toColumnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyColumnPolicy", ctx, cardID, fromColumnID, toColumnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyColumnPolicy indicates an expected call of ApplyColumnPolicy.
+func (mr *MockServiceMockRecorder) ApplyColumnPolicy(ctx, cardID, fromColumnID, toColumnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyColumnPolicy", reflect.TypeOf((*MockService)(nil).ApplyColumnPolicy), ctx, cardID, fromColumnID, toColumnID)
+}
+
+// CompleteSprint mocks base method.
+func (m *MockService) CompleteSprint(ctx context.Context, id uuid.UUID, moveIncompleteToBacklog bool) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSprint", ctx, id, moveIncompleteToBacklog)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSprint indicates an expected call of CompleteSprint.
+func (mr *MockServiceMockRecorder) CompleteSprint(ctx, id, moveIncompleteToBacklog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSprint", reflect.TypeOf((*MockService)(nil).CompleteSprint), ctx, id, moveIncompleteToBacklog)
+}
+
+// CreateSprint mocks base method.
+func (m *MockService) CreateSprint(ctx context.Context, boardID uuid.UUID, name, goal string, startDate, endDate *time.Time, createdBy *uuid.UUID) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", ctx, boardID, name, goal, startDate, endDate, createdBy)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockServiceMockRecorder) CreateSprint(ctx, boardID, name, goal, startDate, endDate, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockService)(nil).CreateSprint), ctx, boardID, name, goal, startDate, endDate, createdBy)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockService) DeleteSprint(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockServiceMockRecorder) DeleteSprint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockService)(nil).DeleteSprint), ctx, id)
+}
+
+// GetActiveSprint mocks base method.
+func (m *MockService) GetActiveSprint(ctx context.Context, boardID uuid.UUID) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSprint", ctx, boardID)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSprint indicates an expected call of GetActiveSprint.
+func (mr *MockServiceMockRecorder) GetActiveSprint(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSprint", reflect.TypeOf((*MockService)(nil).GetActiveSprint), ctx, boardID)
+}
+
+// GetBacklogCards mocks base method.
+func (m *MockService) GetBacklogCards(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklogCards", ctx, boardID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklogCards indicates an expected call of GetBacklogCards.
+func (mr *MockServiceMockRecorder) GetBacklogCards(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklogCards", reflect.TypeOf((*MockService)(nil).GetBacklogCards), ctx, boardID)
+}
+
+// GetBoard mocks base method.
+func (m *MockService) GetBoard(ctx context.Context, sprintID uuid.UUID) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, sprintID)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockServiceMockRecorder) GetBoard(ctx, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockService)(nil).GetBoard), ctx, sprintID)
+}
+
+// GetBoardSprints mocks base method.
+func (m *MockService) GetBoardSprints(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSprints", ctx, boardID)
+	ret0, _ := ret[0].([]*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSprints indicates an expected call of GetBoardSprints.
+func (mr *MockServiceMockRecorder) GetBoardSprints(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSprints", reflect.TypeOf((*MockService)(nil).GetBoardSprints), ctx, boardID)
+}
+
+// GetCardByID mocks base method.
+func (m *MockService) GetCardByID(ctx context.Context, cardID uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardByID", ctx, cardID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardByID indicates an expected call of GetCardByID.
+func (mr *MockServiceMockRecorder) GetCardByID(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardByID", reflect.TypeOf((*MockService)(nil).GetCardByID), ctx, cardID)
+}
+
+// GetCardSprintIDs mocks base method.
+func (m *MockService) GetCardSprintIDs(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardSprintIDs", ctx, cardID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardSprintIDs indicates an expected call of GetCardSprintIDs.
+func (mr *MockServiceMockRecorder) GetCardSprintIDs(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardSprintIDs", reflect.TypeOf((*MockService)(nil).GetCardSprintIDs), ctx, cardID)
+}
+
+// GetClosedSprints mocks base method.
+func (m *MockService) GetClosedSprints(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedSprints", ctx, boardID)
+	ret0, _ := ret[0].([]*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosedSprints indicates an expected call of GetClosedSprints.
+func (mr *MockServiceMockRecorder) GetClosedSprints(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedSprints", reflect.TypeOf((*MockService)(nil).GetClosedSprints), ctx, boardID)
+}
+
+// GetClosedSprintsPaginated mocks base method.
+func (m *MockService) GetClosedSprintsPaginated(ctx context.Context, boardID uuid.UUID, limit, offset int) ([]*sprint.Sprint, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosedSprintsPaginated", ctx, boardID, limit, offset)
+	ret0, _ := ret[0].([]*sprint.Sprint)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetClosedSprintsPaginated indicates an expected call of GetClosedSprintsPaginated.
+func (mr *MockServiceMockRecorder) GetClosedSprintsPaginated(ctx, boardID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosedSprintsPaginated", reflect.TypeOf((*MockService)(nil).GetClosedSprintsPaginated), ctx, boardID, limit, offset)
+}
+
+// GetFutureSprints mocks base method.
+func (m *MockService) GetFutureSprints(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFutureSprints", ctx, boardID)
+	ret0, _ := ret[0].([]*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFutureSprints indicates an expected call of GetFutureSprints.
+func (mr *MockServiceMockRecorder) GetFutureSprints(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFutureSprints", reflect.TypeOf((*MockService)(nil).GetFutureSprints), ctx, boardID)
+}
+
+// GetMySprintWork mocks base method.
+func (m *MockService) GetMySprintWork(ctx context.Context, boardID, userID uuid.UUID) (*sprint0.MySprintWork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMySprintWork", ctx, boardID, userID)
+	ret0, _ := ret[0].(*sprint0.MySprintWork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMySprintWork indicates an expected call of GetMySprintWork.
+func (mr *MockServiceMockRecorder) GetMySprintWork(ctx, boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMySprintWork", reflect.TypeOf((*MockService)(nil).GetMySprintWork), ctx, boardID, userID)
+}
+
+// GetSprint mocks base method.
+func (m *MockService) GetSprint(ctx context.Context, id uuid.UUID) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprint", ctx, id)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprint indicates an expected call of GetSprint.
+func (mr *MockServiceMockRecorder) GetSprint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprint", reflect.TypeOf((*MockService)(nil).GetSprint), ctx, id)
+}
+
+// GetSprintCards mocks base method.
+func (m *MockService) GetSprintCards(ctx context.Context, sprintID uuid.UUID) ([]*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprintCards", ctx, sprintID)
+	ret0, _ := ret[0].([]*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprintCards indicates an expected call of GetSprintCards.
+func (mr *MockServiceMockRecorder) GetSprintCards(ctx, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprintCards", reflect.TypeOf((*MockService)(nil).GetSprintCards), ctx, sprintID)
+}
+
+// MoveCardToBacklog mocks base method.
+func (m *MockService) MoveCardToBacklog(ctx context.Context, cardID uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCardToBacklog", ctx, cardID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCardToBacklog indicates an expected call of MoveCardToBacklog.
+func (mr *MockServiceMockRecorder) MoveCardToBacklog(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCardToBacklog", reflect.TypeOf((*MockService)(nil).MoveCardToBacklog), ctx, cardID)
+}
+
+// RemoveCardFromSprint mocks base method.
+func (m *MockService) RemoveCardFromSprint(ctx context.Context, cardID, sprintID uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCardFromSprint", ctx, cardID, sprintID)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveCardFromSprint indicates an expected call of RemoveCardFromSprint.
+func (mr *MockServiceMockRecorder) RemoveCardFromSprint(ctx, cardID, sprintID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCardFromSprint", reflect.TypeOf((*MockService)(nil).RemoveCardFromSprint), ctx, cardID, sprintID)
+}
+
+// ReopenSprint mocks base method.
+func (m *MockService) ReopenSprint(ctx context.Context, id uuid.UUID) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenSprint", ctx, id)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenSprint indicates an expected call of ReopenSprint.
+func (mr *MockServiceMockRecorder) ReopenSprint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenSprint", reflect.TypeOf((*MockService)(nil).ReopenSprint), ctx, id)
+}
+
+// SetCardSprints mocks base method.
+func (m *MockService) SetCardSprints(ctx context.Context, cardID uuid.UUID, sprintIDs []uuid.UUID) (*card.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardSprints", ctx, cardID, sprintIDs)
+	ret0, _ := ret[0].(*card.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCardSprints indicates an expected call of SetCardSprints.
+func (mr *MockServiceMockRecorder) SetCardSprints(ctx, cardID, sprintIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardSprints", reflect.TypeOf((*MockService)(nil).SetCardSprints), ctx, cardID, sprintIDs)
+}
+
+// SetSprintAutoMembership mocks base method.
+func (m *MockService) SetSprintAutoMembership(ctx context.Context, boardID uuid.UUID, enabled bool) (*board.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSprintAutoMembership", ctx, boardID, enabled)
+	ret0, _ := ret[0].(*board.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSprintAutoMembership indicates an expected call of SetSprintAutoMembership.
+func (mr *MockServiceMockRecorder) SetSprintAutoMembership(ctx, boardID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSprintAutoMembership", reflect.TypeOf((*MockService)(nil).SetSprintAutoMembership), ctx, boardID, enabled)
+}
+
+// StartSprint mocks base method.
+func (m *MockService) StartSprint(ctx context.Context, id uuid.UUID) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSprint", ctx, id)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSprint indicates an expected call of StartSprint.
+func (mr *MockServiceMockRecorder) StartSprint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSprint", reflect.TypeOf((*MockService)(nil).StartSprint), ctx, id)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockService) UpdateSprint(ctx context.Context, id uuid.UUID, input sprint0.UpdateSprintInput) (*sprint.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", ctx, id, input)
+	ret0, _ := ret[0].(*sprint.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockServiceMockRecorder) UpdateSprint(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockService)(nil).UpdateSprint), ctx, id, input)
+}
@@ -10,6 +10,9 @@ import (
 	boardColumn "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/services/undo"
 	"github.com/thatcatdev/kaimu/backend/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -34,6 +37,30 @@ type UpdateSprintInput struct {
 	EndDate   *time.Time
 }
 
+// SprintWorkTotals are the aggregates of a set of sprint cards
+type SprintWorkTotals struct {
+	CardCount int
+	// StoryPoints is the sum over the estimated cards
+	StoryPoints     int
+	DoneCardCount   int
+	DoneStoryPoints int
+}
+
+// SprintWorkColumn is a board column with the user's sprint cards in it
+type SprintWorkColumn struct {
+	Column *boardColumn.BoardColumn
+	Cards  []*card.Card
+	Totals SprintWorkTotals
+}
+
+// MySprintWork is one user's share of a board's active sprint
+type MySprintWork struct {
+	Sprint *sprint.Sprint
+	// Columns follow the board's column order, leaving out columns without the user's cards
+	Columns []*SprintWorkColumn
+	Totals  SprintWorkTotals
+}
+
 type Service interface {
 	// Sprint CRUD operations
 	CreateSprint(ctx context.Context, boardID uuid.UUID, name, goal string, startDate, endDate *time.Time, createdBy *uuid.UUID) (*sprint.Sprint, error)
@@ -63,6 +90,18 @@ type Service interface {
 
 	// Get board for sprint
 	GetBoard(ctx context.Context, sprintID uuid.UUID) (*board.Board, error)
+
+	// GetMySprintWork returns the user's cards in the board's active sprint grouped by
+	// column; Sprint is nil when the board has no active sprint
+	GetMySprintWork(ctx context.Context, boardID, userID uuid.UUID) (*MySprintWork, error)
+
+	// SetSprintAutoMembership turns the board's backlog column policy on or off
+	SetSprintAutoMembership(ctx context.Context, boardID uuid.UUID, enabled bool) (*board.Board, error)
+	// ApplyColumnPolicy updates the sprint membership of a card that moved between the
+	// columns when its board has the policy on: leaving the backlog adds the card to the
+	// active sprint, returning to it removes the card from that sprint. Future sprints the
+	// card was planned into are left alone.
+	ApplyColumnPolicy(ctx context.Context, cardID, fromColumnID, toColumnID uuid.UUID) error
 }
 
 type service struct {
@@ -70,14 +109,20 @@ type service struct {
 	cardRepo        card.Repository
 	boardRepo       board.Repository
 	boardColumnRepo boardColumn.Repository
+	undoSvc         undo.Service
+	txManager       transaction.Manager
+	bus             events.Bus
 }
 
-func NewService(sprintRepo sprint.Repository, cardRepo card.Repository, boardRepo board.Repository, boardColumnRepo boardColumn.Repository) Service {
+func NewService(sprintRepo sprint.Repository, cardRepo card.Repository, boardRepo board.Repository, boardColumnRepo boardColumn.Repository, undoSvc undo.Service, txManager transaction.Manager, bus events.Bus) Service {
 	return &service{
 		sprintRepo:      sprintRepo,
 		cardRepo:        cardRepo,
 		boardRepo:       boardRepo,
 		boardColumnRepo: boardColumnRepo,
+		undoSvc:         undoSvc,
+		txManager:       txManager,
+		bus:             bus,
 	}
 }
 
@@ -250,21 +295,23 @@ func (s *service) DeleteSprint(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	// Remove all card-sprint associations for this sprint
-	// (cards will be removed from this sprint but may remain in other sprints)
-	cards, err := s.cardRepo.GetBySprintID(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	for _, c := range cards {
-		if err := s.cardRepo.RemoveCardFromSprint(ctx, c.ID, id); err != nil {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Remove all card-sprint associations for this sprint
+		// (cards will be removed from this sprint but may remain in other sprints)
+		cards, err := s.cardRepo.GetBySprintID(ctx, id)
+		if err != nil {
 			return err
 		}
-	}
 
-	// Delete sprint
-	return s.sprintRepo.Delete(ctx, sp.ID)
+		for _, c := range cards {
+			if err := s.cardRepo.RemoveCardFromSprint(ctx, c.ID, id); err != nil {
+				return err
+			}
+		}
+
+		// Delete sprint
+		return s.sprintRepo.Delete(ctx, sp.ID)
+	})
 }
 
 // Sprint lifecycle operations
@@ -308,7 +355,16 @@ func (s *service) StartSprint(ctx context.Context, id uuid.UUID) (*sprint.Sprint
 		sp.StartDate = &now
 	}
 
-	if err := s.sprintRepo.Update(ctx, sp); err != nil {
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sprintRepo.Update(ctx, sp); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.SprintStarted, events.SprintStartedPayload{
+			SprintID: sp.ID,
+			BoardID:  sp.BoardID,
+		}))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -338,53 +394,103 @@ func (s *service) CompleteSprint(ctx context.Context, id uuid.UUID, moveIncomple
 		return nil, ErrCannotCloseInactiveSprint
 	}
 
-	// Get all cards in this sprint
-	cards, err := s.cardRepo.GetBySprintID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+	var nextSprintID *uuid.UUID
 
-	// If moveIncompleteToNextSprint is true, move incomplete cards to next sprint
-	if moveIncompleteToNextSprint && len(cards) > 0 {
-		// Get the next future sprint (if any)
-		futureSprints, err := s.sprintRepo.GetFutureByBoardID(ctx, sp.BoardID)
-		if err != nil {
-			return nil, err
-		}
+	// Undoing reactivates the sprint as it was and takes carried-over cards back out of the
+	// next sprint
+	inverse := []undo.Step{{
+		Op:            undo.StepRestoreSprint,
+		SprintID:      &sp.ID,
+		SprintStatus:  sp.Status,
+		SprintEndDate: sp.EndDate,
+	}}
 
-		var nextSprint *sprint.Sprint
-		if len(futureSprints) > 0 {
-			nextSprint = futureSprints[0] // First future sprint (sorted by position)
+	// Carrying cards over and closing the sprint succeed or fail together
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Get all cards in this sprint
+		cards, err := s.cardRepo.GetBySprintID(ctx, id)
+		if err != nil {
+			return err
 		}
 
-		// For each card, check if it's in a "done" column
-		for _, c := range cards {
-			// Get the card's column to check if it's marked as done
-			col, err := s.boardColumnRepo.GetByID(ctx, c.ColumnID)
+		// If moveIncompleteToNextSprint is true, move incomplete cards to next sprint
+		if moveIncompleteToNextSprint && len(cards) > 0 {
+			// Get the next future sprint (if any)
+			futureSprints, err := s.sprintRepo.GetFutureByBoardID(ctx, sp.BoardID)
 			if err != nil {
-				// If we can't get the column, skip this card
-				continue
+				return err
 			}
 
-			// If the column is NOT a done column, add the card to the next sprint
-			if !col.IsDone && nextSprint != nil {
-				// Add card to next sprint (it stays in closed sprint for history)
-				if err := s.cardRepo.AddCardToSprint(ctx, c.ID, nextSprint.ID); err != nil {
-					// Log error but continue - don't fail the whole operation
-					continue
+			var nextSprint *sprint.Sprint
+			alreadyPlanned := make(map[uuid.UUID]bool)
+			if len(futureSprints) > 0 {
+				nextSprint = futureSprints[0] // First future sprint (sorted by position)
+				nextSprintID = &nextSprint.ID
+
+				// Cards already planned into the next sprint must stay there on undo
+				planned, err := s.cardRepo.GetBySprintID(ctx, nextSprint.ID)
+				if err != nil {
+					return err
+				}
+				for _, c := range planned {
+					alreadyPlanned[c.ID] = true
+				}
+			}
+
+			// For each card, check if it's in a "done" column
+			for _, c := range cards {
+				if nextSprint == nil {
+					break
+				}
+
+				// Get the card's column to check if it's marked as done
+				col, err := s.boardColumnRepo.GetByID(ctx, c.ColumnID)
+				if err != nil {
+					return err
+				}
+
+				// If the column is NOT a done column, add the card to the next sprint
+				if !col.IsDone && !alreadyPlanned[c.ID] {
+					// Add card to next sprint (it stays in closed sprint for history)
+					if err := s.cardRepo.AddCardToSprint(ctx, c.ID, nextSprint.ID); err != nil {
+						return err
+					}
+					inverse = append(inverse, undo.Step{
+						Op:       undo.StepRemoveCardFromSprint,
+						CardID:   &c.ID,
+						SprintID: &nextSprint.ID,
+					})
 				}
 			}
 		}
-	}
 
-	// Close the sprint (all cards remain in it for historical tracking)
-	sp.Status = sprint.SprintStatusClosed
-	if sp.EndDate == nil {
-		now := time.Now()
-		sp.EndDate = &now
-	}
+		// Close the sprint (all cards remain in it for historical tracking)
+		sp.Status = sprint.SprintStatusClosed
+		if sp.EndDate == nil {
+			now := time.Now()
+			sp.EndDate = &now
+		}
 
-	if err := s.sprintRepo.Update(ctx, sp); err != nil {
+		if err := s.sprintRepo.Update(ctx, sp); err != nil {
+			return err
+		}
+
+		if _, err := s.undoSvc.Record(ctx, undo.Operation{
+			Kind:     undo.KindCompleteSprint,
+			BoardID:  sp.BoardID,
+			EntityID: sp.ID,
+			Inverse:  inverse,
+		}); err != nil {
+			return err
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.SprintCompleted, events.SprintCompletedPayload{
+			SprintID:     sp.ID,
+			BoardID:      sp.BoardID,
+			NextSprintID: nextSprintID,
+		}))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -578,3 +684,176 @@ func (s *service) GetCardByID(ctx context.Context, cardID uuid.UUID) (*card.Card
 
 	return s.cardRepo.GetByID(ctx, cardID)
 }
+
+func (s *service) GetMySprintWork(ctx context.Context, boardID, userID uuid.UUID) (*MySprintWork, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMySprintWork")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("user.id", userID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	sp, err := s.GetActiveSprint(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	work := &MySprintWork{Sprint: sp, Columns: []*SprintWorkColumn{}}
+	if sp == nil {
+		return work, nil
+	}
+
+	cards, err := s.cardRepo.GetBySprintID(ctx, sp.ID)
+	if err != nil {
+		return nil, err
+	}
+	byColumn := make(map[uuid.UUID][]*card.Card)
+	for _, c := range cards {
+		if c.ArchivedAt != nil || c.AssigneeID == nil || *c.AssigneeID != userID {
+			continue
+		}
+		byColumn[c.ColumnID] = append(byColumn[c.ColumnID], c)
+	}
+	if len(byColumn) == 0 {
+		return work, nil
+	}
+
+	columns, err := s.boardColumnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range columns {
+		colCards, ok := byColumn[col.ID]
+		if !ok {
+			continue
+		}
+		wc := &SprintWorkColumn{Column: col, Cards: colCards}
+		for _, c := range colCards {
+			wc.Totals.add(c, col.IsDone)
+			work.Totals.add(c, col.IsDone)
+		}
+		work.Columns = append(work.Columns, wc)
+	}
+	return work, nil
+}
+
+func (t *SprintWorkTotals) add(c *card.Card, done bool) {
+	points := 0
+	if c.StoryPoints != nil {
+		points = *c.StoryPoints
+	}
+	t.CardCount++
+	t.StoryPoints += points
+	if done {
+		t.DoneCardCount++
+		t.DoneStoryPoints += points
+	}
+}
+
+func (s *service) SetSprintAutoMembership(ctx context.Context, boardID uuid.UUID, enabled bool) (*board.Board, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetSprintAutoMembership")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.Bool("board.sprint_auto_membership", enabled),
+	)
+	defer span.End()
+
+	b, err := s.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+	b.SprintAutoMembership = enabled
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.boardRepo.Update(ctx, b); err != nil {
+			return err
+		}
+		return s.bus.Publish(ctx, events.New(ctx, events.BoardUpdated, events.BoardPayload{
+			BoardID:   b.ID,
+			ProjectID: b.ProjectID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *service) ApplyColumnPolicy(ctx context.Context, cardID, fromColumnID, toColumnID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "ApplyColumnPolicy")
+	span.SetAttributes(
+		attribute.String("card.id", cardID.String()),
+		attribute.String("card.from_column_id", fromColumnID.String()),
+		attribute.String("card.to_column_id", toColumnID.String()),
+	)
+	defer span.End()
+
+	if fromColumnID == toColumnID {
+		return nil
+	}
+	// Cards, columns and boards deleted since the move leave nothing to do, and a card that
+	// has moved on is handled by its later move
+	c, err := s.cardRepo.GetByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if c.ColumnID != toColumnID {
+		return nil
+	}
+	to, err := s.boardColumnRepo.GetByID(ctx, toColumnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	b, err := s.boardRepo.GetByID(ctx, to.BoardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !b.SprintAutoMembership {
+		return nil
+	}
+
+	// A card arriving from another board counts as leaving a backlog, as it is new to this
+	// board's sprint
+	fromBacklog := true
+	from, err := s.boardColumnRepo.GetByID(ctx, fromColumnID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if from != nil && from.BoardID == to.BoardID {
+		fromBacklog = from.IsBacklog
+	}
+	if fromBacklog == to.IsBacklog {
+		return nil
+	}
+
+	active, err := s.sprintRepo.GetActiveByBoardID(ctx, b.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	span.SetAttributes(attribute.String("sprint.id", active.ID.String()))
+	if to.IsBacklog {
+		return s.cardRepo.RemoveCardFromSprint(ctx, cardID, active.ID)
+	}
+	return s.cardRepo.AddCardToSprint(ctx, cardID, active.ID)
+}
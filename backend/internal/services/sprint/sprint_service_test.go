@@ -0,0 +1,309 @@
+package sprint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	boardColumn "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	boardColumnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestStartSprint(t *testing.T) {
+	ctx := context.Background()
+	boardID := uuid.New()
+
+	setup := func(t *testing.T) (Service, *sprintMocks.MockRepository, *[]events.Event) {
+		ctrl := gomock.NewController(t)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		bus := events.NewSyncBus()
+		var started []events.Event
+		bus.Subscribe(events.SprintStarted, func(ctx context.Context, e events.Event) error {
+			started = append(started, e)
+			return nil
+		})
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, transaction.NewNoopManager(), bus)
+		return svc, mockSprintRepo, &started
+	}
+
+	t.Run("starts the sprint and publishes sprint.started", func(t *testing.T) {
+		svc, mockSprintRepo, started := setup(t)
+		sp := &sprint.Sprint{ID: uuid.New(), BoardID: boardID, Status: sprint.SprintStatusFuture}
+		mockSprintRepo.EXPECT().GetByID(gomock.Any(), sp.ID).Return(sp, nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+		mockSprintRepo.EXPECT().Update(gomock.Any(), sp).Return(nil)
+
+		result, err := svc.StartSprint(ctx, sp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sprint.SprintStatusActive, result.Status)
+		assert.NotNil(t, result.StartDate)
+		require.Len(t, *started, 1)
+		assert.Equal(t, events.SprintStartedPayload{SprintID: sp.ID, BoardID: boardID}, (*started)[0].Payload)
+	})
+
+	t.Run("another sprint is active", func(t *testing.T) {
+		svc, mockSprintRepo, started := setup(t)
+		sp := &sprint.Sprint{ID: uuid.New(), BoardID: boardID, Status: sprint.SprintStatusFuture}
+		mockSprintRepo.EXPECT().GetByID(gomock.Any(), sp.ID).Return(sp, nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(&sprint.Sprint{ID: uuid.New()}, nil)
+
+		_, err := svc.StartSprint(ctx, sp.ID)
+		assert.ErrorIs(t, err, ErrActiveSprintExists)
+		assert.Empty(t, *started)
+	})
+}
+
+func TestGetMySprintWork(t *testing.T) {
+	ctx := context.Background()
+	boardID := uuid.New()
+	userID := uuid.New()
+	otherUser := uuid.New()
+	points := func(n int) *int { return &n }
+
+	t.Run("groups the user's active sprint cards by column", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		sp := &sprint.Sprint{ID: uuid.New(), BoardID: boardID, Status: sprint.SprintStatusActive}
+		todo := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "To Do", Position: 0}
+		doing := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Doing", Position: 1}
+		done := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Done", Position: 2, IsDone: true}
+		archivedAt := time.Now()
+
+		mine1 := &card.Card{ID: uuid.New(), ColumnID: done.ID, AssigneeID: &userID, StoryPoints: points(3)}
+		mine2 := &card.Card{ID: uuid.New(), ColumnID: todo.ID, AssigneeID: &userID, StoryPoints: points(5)}
+		mine3 := &card.Card{ID: uuid.New(), ColumnID: todo.ID, AssigneeID: &userID}
+		theirs := &card.Card{ID: uuid.New(), ColumnID: doing.ID, AssigneeID: &otherUser, StoryPoints: points(8)}
+		unassigned := &card.Card{ID: uuid.New(), ColumnID: doing.ID}
+		archived := &card.Card{ID: uuid.New(), ColumnID: doing.ID, AssigneeID: &userID, ArchivedAt: &archivedAt}
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(sp, nil)
+		mockCardRepo.EXPECT().GetBySprintID(gomock.Any(), sp.ID).Return([]*card.Card{mine1, mine2, theirs, unassigned, archived, mine3}, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*boardColumn.BoardColumn{todo, doing, done}, nil)
+
+		work, err := svc.GetMySprintWork(ctx, boardID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, sp, work.Sprint)
+
+		// Doing only holds other people's and archived cards, so it is left out
+		require.Len(t, work.Columns, 2)
+		assert.Equal(t, todo, work.Columns[0].Column)
+		assert.Equal(t, []*card.Card{mine2, mine3}, work.Columns[0].Cards)
+		assert.Equal(t, SprintWorkTotals{CardCount: 2, StoryPoints: 5}, work.Columns[0].Totals)
+		assert.Equal(t, done, work.Columns[1].Column)
+		assert.Equal(t, SprintWorkTotals{CardCount: 1, StoryPoints: 3, DoneCardCount: 1, DoneStoryPoints: 3}, work.Columns[1].Totals)
+
+		assert.Equal(t, SprintWorkTotals{CardCount: 3, StoryPoints: 8, DoneCardCount: 1, DoneStoryPoints: 3}, work.Totals)
+	})
+
+	t.Run("returns no sprint when the board has no active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID}, nil)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		work, err := svc.GetMySprintWork(ctx, boardID, userID)
+		require.NoError(t, err)
+		assert.Nil(t, work.Sprint)
+		assert.Empty(t, work.Columns)
+		assert.Equal(t, SprintWorkTotals{}, work.Totals)
+	})
+
+	t.Run("returns ErrBoardNotFound for an unknown board", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetMySprintWork(ctx, boardID, userID)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+}
+
+func TestApplyColumnPolicy(t *testing.T) {
+	ctx := context.Background()
+	boardID := uuid.New()
+	backlog := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Backlog", IsBacklog: true}
+	todo := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "To Do"}
+	doing := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Doing"}
+	elsewhere := &boardColumn.BoardColumn{ID: uuid.New(), BoardID: uuid.New(), Name: "Backlog", IsBacklog: true}
+	active := &sprint.Sprint{ID: uuid.New(), BoardID: boardID, Status: sprint.SprintStatusActive}
+	cardID := uuid.New()
+
+	// expectMove sets up a card that moved into to, on a board with the policy set as given
+	expectMove := func(mockCardRepo *cardMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, mockColumnRepo *boardColumnMocks.MockRepository, from, to *boardColumn.BoardColumn, enabled bool) {
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(&card.Card{ID: cardID, BoardID: to.BoardID, ColumnID: to.ID}, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), to.ID).Return(to, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), to.BoardID).Return(&board.Board{ID: to.BoardID, SprintAutoMembership: enabled}, nil)
+		if enabled {
+			mockColumnRepo.EXPECT().GetByID(gomock.Any(), from.ID).Return(from, nil)
+		}
+	}
+
+	t.Run("leaving the backlog joins the active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, backlog, todo, true)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(active, nil)
+		mockCardRepo.EXPECT().AddCardToSprint(gomock.Any(), cardID, active.ID).Return(nil)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, backlog.ID, todo.ID))
+	})
+
+	t.Run("returning to the backlog leaves the active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, doing, backlog, true)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(active, nil)
+		mockCardRepo.EXPECT().RemoveCardFromSprint(gomock.Any(), cardID, active.ID).Return(nil)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, doing.ID, backlog.ID))
+	})
+
+	t.Run("arriving from another board joins the active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, elsewhere, todo, true)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(active, nil)
+		mockCardRepo.EXPECT().AddCardToSprint(gomock.Any(), cardID, active.ID).Return(nil)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, elsewhere.ID, todo.ID))
+	})
+
+	t.Run("moves between sprint columns change nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, todo, doing, true)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, todo.ID, doing.ID))
+	})
+
+	t.Run("without an active sprint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, backlog, todo, true)
+		mockSprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, backlog.ID, todo.ID))
+	})
+
+	t.Run("policy off", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		expectMove(mockCardRepo, mockBoardRepo, mockColumnRepo, backlog, todo, false)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, backlog.ID, todo.ID))
+	})
+
+	t.Run("card moved on since", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(&card.Card{ID: cardID, BoardID: boardID, ColumnID: doing.ID}, nil)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, backlog.ID, todo.ID))
+	})
+
+	t.Run("card deleted since", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := boardColumnMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockSprintRepo, mockCardRepo, mockBoardRepo, mockColumnRepo, nil, nil, nil)
+
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), cardID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, svc.ApplyColumnPolicy(ctx, cardID, backlog.ID, todo.ID))
+	})
+}
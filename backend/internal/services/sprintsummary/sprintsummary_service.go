@@ -0,0 +1,379 @@
+package sprintsummary
+
+//go:generate mockgen -source=sprintsummary_service.go -destination=mocks/sprintsummary_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/services/llm"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
+)
+
+const (
+	// SummariesPerHour is how many summaries a user may generate per hour after a burst of
+	// SummaryBurst
+	SummariesPerHour = 10
+	// SummaryBurst is how many summaries a user may generate at once
+	SummaryBurst = 3
+	// maxListedCards is how many cards of each group the model is given
+	maxListedCards = 50
+	// maxTitleLength is how much of a card title the model is given, in characters
+	maxTitleLength = 200
+	// maxSummaryLength is how much of the model's reply is kept, in characters
+	maxSummaryLength = 10000
+	// limiterIdleTTL is how long a user's limiter is kept after their last summary
+	limiterIdleTTL = 2 * time.Hour
+)
+
+var (
+	ErrSummaryUnavailable   = errors.New("AI sprint summaries are not configured")
+	ErrSummaryDisabled      = errors.New("AI features are not enabled for this organization")
+	ErrRateLimited          = errors.New("too many sprint summaries, try again later")
+	ErrEmptySprint          = errors.New("the sprint has no cards to summarize")
+	ErrSprintNotFound       = errors.New("sprint not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+)
+
+// systemPrompt asks the model for a plain-text stakeholder update
+const systemPrompt = `You write sprint status updates for stakeholders from the cards of a sprint.
+Reply with only the update, in plain text without markdown headings, tables or bold text:
+- Start with two or three sentences on the overall progress, towards the sprint goal when there is one.
+- Then list the work under the lines "Completed:", "In progress:" and "Blocked:" as short "- " bullet points; leave out a section without cards.
+- For blocked work, say what it is waiting on.
+- Group closely related cards into one bullet point instead of repeating every title, and don't mention work that isn't listed.
+Write in the language of the card titles.`
+
+// cardGroups are a sprint's cards by how far along they are
+type cardGroups struct {
+	completed  []*card.Card
+	inProgress []*card.Card
+	blocked    []*blockedCard
+}
+
+// blockedCard is an unfinished card with unfinished cards blocking it
+type blockedCard struct {
+	card     *card.Card
+	blockers []*card.Card
+}
+
+type Service interface {
+	// Generate has the configured language model summarize the sprint's completed,
+	// in-progress and blocked cards, and saves the summary on the sprint. The sprint's
+	// organization must have enabled AI features, and each user may generate SummariesPerHour
+	// summaries after a burst of SummaryBurst.
+	Generate(ctx context.Context, userID, sprintID uuid.UUID) (*sprint.Sprint, error)
+}
+
+// userLimiter is a user's summary rate limiter and when it was last used
+type userLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+type service struct {
+	provider       llm.Provider
+	sprintRepo     sprint.Repository
+	cardRepo       card.Repository
+	columnRepo     board_column.Repository
+	dependencyRepo card_dependency.Repository
+	boardRepo      board.Repository
+	projectRepo    project.Repository
+	orgRepo        organization.Repository
+	now            func() time.Time
+
+	mu       sync.Mutex
+	limiters map[uuid.UUID]*userLimiter
+}
+
+// NewService creates the sprint summary service. Without a provider, summaries are unavailable.
+func NewService(
+	provider llm.Provider,
+	sprintRepo sprint.Repository,
+	cardRepo card.Repository,
+	columnRepo board_column.Repository,
+	dependencyRepo card_dependency.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	orgRepo organization.Repository,
+) Service {
+	return &service{
+		provider:       provider,
+		sprintRepo:     sprintRepo,
+		cardRepo:       cardRepo,
+		columnRepo:     columnRepo,
+		dependencyRepo: dependencyRepo,
+		boardRepo:      boardRepo,
+		projectRepo:    projectRepo,
+		orgRepo:        orgRepo,
+		now:            time.Now,
+		limiters:       make(map[uuid.UUID]*userLimiter),
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "sprintsummary.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "sprintsummary"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Generate(ctx context.Context, userID, sprintID uuid.UUID) (*sprint.Sprint, error) {
+	ctx, span := s.startServiceSpan(ctx, "Generate")
+	span.SetAttributes(attribute.String("sprint.id", sprintID.String()))
+	defer span.End()
+
+	if s.provider == nil {
+		return nil, ErrSummaryUnavailable
+	}
+
+	sp, err := s.sprintRepo.GetByID(ctx, sprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSprintNotFound
+		}
+		return nil, err
+	}
+	b, err := s.boardRepo.GetByID(ctx, sp.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := s.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, proj.OrganizationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	if !org.AIDraftingEnabled {
+		return nil, ErrSummaryDisabled
+	}
+
+	groups, err := s.groupCards(ctx, sp, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups.completed)+len(groups.inProgress)+len(groups.blocked) == 0 {
+		return nil, ErrEmptySprint
+	}
+	if !s.allow(userID) {
+		return nil, ErrRateLimited
+	}
+
+	reply, err := s.provider.Complete(ctx, systemPrompt, composePrompt(sp, groups))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize sprint: %w", err)
+	}
+	summary := strings.TrimSpace(reply)
+	if runes := []rune(summary); len(runes) > maxSummaryLength {
+		summary = strings.TrimSpace(string(runes[:maxSummaryLength]))
+	}
+
+	generatedAt := s.now()
+	if err := s.sprintRepo.UpdateSummary(ctx, sp.ID, summary, generatedAt); err != nil {
+		return nil, err
+	}
+	sp.Summary = summary
+	sp.SummaryGeneratedAt = &generatedAt
+	return sp, nil
+}
+
+// groupCards sorts the sprint's cards into completed, in progress and blocked. Cards in a done
+// column are completed; an unfinished card is blocked while a card that blocks it isn't done.
+// Merged cards and cards archived unfinished are left out.
+func (s *service) groupCards(ctx context.Context, sp *sprint.Sprint, projectID uuid.UUID) (*cardGroups, error) {
+	cards, err := s.cardRepo.GetBySprintID(ctx, sp.ID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.columnRepo.GetByBoardID(ctx, sp.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := s.dependencyRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[uuid.UUID]bool, len(columns))
+	for _, col := range columns {
+		done[col.ID] = col.IsDone
+	}
+	// isDone looks up the columns of cards moved to other boards as it meets them
+	isDone := func(c *card.Card) (bool, error) {
+		if d, ok := done[c.ColumnID]; ok {
+			return d, nil
+		}
+		col, err := s.columnRepo.GetByID(ctx, c.ColumnID)
+		if err != nil {
+			return false, err
+		}
+		done[col.ID] = col.IsDone
+		return col.IsDone, nil
+	}
+
+	known := make(map[uuid.UUID]*card.Card, len(cards))
+	for _, c := range cards {
+		known[c.ID] = c
+	}
+	blockersOf := make(map[uuid.UUID][]uuid.UUID)
+	for _, dep := range dependencies {
+		if dep.Kind == card_dependency.KindBlocks {
+			blockersOf[dep.ToCardID] = append(blockersOf[dep.ToCardID], dep.FromCardID)
+		}
+	}
+
+	groups := &cardGroups{}
+	for _, c := range cards {
+		if c.MergedIntoID != nil {
+			continue
+		}
+		finished, err := isDone(c)
+		if err != nil {
+			return nil, err
+		}
+		if finished {
+			groups.completed = append(groups.completed, c)
+			continue
+		}
+		if c.ArchivedAt != nil {
+			continue
+		}
+
+		var blockers []*card.Card
+		for _, id := range blockersOf[c.ID] {
+			blocker, ok := known[id]
+			if !ok {
+				blocker, err = s.cardRepo.GetByID(ctx, id)
+				if err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						continue
+					}
+					return nil, err
+				}
+				known[id] = blocker
+			}
+			if blocker.ArchivedAt != nil {
+				continue
+			}
+			blockerDone, err := isDone(blocker)
+			if err != nil {
+				return nil, err
+			}
+			if !blockerDone {
+				blockers = append(blockers, blocker)
+			}
+		}
+		if len(blockers) > 0 {
+			groups.blocked = append(groups.blocked, &blockedCard{card: c, blockers: blockers})
+		} else {
+			groups.inProgress = append(groups.inProgress, c)
+		}
+	}
+	return groups, nil
+}
+
+// allow reports whether the user may generate a summary now
+func (s *service) allow(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	l, ok := s.limiters[userID]
+	if !ok {
+		for id, idle := range s.limiters {
+			if idle.lastUsed.Before(now.Add(-limiterIdleTTL)) {
+				delete(s.limiters, id)
+			}
+		}
+		l = &userLimiter{limiter: rate.NewLimiter(rate.Limit(SummariesPerHour/time.Hour.Seconds()), SummaryBurst)}
+		s.limiters[userID] = l
+	}
+	l.lastUsed = now
+	return l.limiter.AllowN(now, 1)
+}
+
+// composePrompt lists the sprint and its grouped cards for the model
+func composePrompt(sp *sprint.Sprint, groups *cardGroups) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sprint: %s (%s)\n", sp.Name, sp.Status)
+	if goal := strings.TrimSpace(sp.Goal); goal != "" {
+		fmt.Fprintf(&b, "Goal: %s\n", goal)
+	}
+	if sp.StartDate != nil && sp.EndDate != nil {
+		fmt.Fprintf(&b, "Dates: %s to %s\n", sp.StartDate.Format(time.DateOnly), sp.EndDate.Format(time.DateOnly))
+	}
+
+	writeGroup := func(name string, cards []*card.Card, detail func(i int) string) {
+		fmt.Fprintf(&b, "\n%s: %d cards, %d story points\n", name, len(cards), storyPoints(cards))
+		for i, c := range cards {
+			if i == maxListedCards {
+				fmt.Fprintf(&b, "- and %d more\n", len(cards)-maxListedCards)
+				break
+			}
+			fmt.Fprintf(&b, "- %s%s\n", cardTitle(c), detail(i))
+		}
+	}
+	none := func(int) string { return "" }
+	writeGroup("Completed", groups.completed, none)
+	writeGroup("In progress", groups.inProgress, none)
+
+	blocked := make([]*card.Card, len(groups.blocked))
+	for i, bc := range groups.blocked {
+		blocked[i] = bc.card
+	}
+	writeGroup("Blocked", blocked, func(i int) string {
+		titles := make([]string, len(groups.blocked[i].blockers))
+		for j, blocker := range groups.blocked[i].blockers {
+			titles[j] = cardTitle(blocker)
+		}
+		return " (waiting on: " + strings.Join(titles, "; ") + ")"
+	})
+	return b.String()
+}
+
+// cardTitle is the card's title on one line, truncated
+func cardTitle(c *card.Card) string {
+	title := strings.Join(strings.Fields(c.Title), " ")
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength]) + "…"
+	}
+	return title
+}
+
+// storyPoints sums the estimates of the cards
+func storyPoints(cards []*card.Card) int {
+	total := 0
+	for _, c := range cards {
+		if c.StoryPoints != nil {
+			total += *c.StoryPoints
+		}
+	}
+	return total
+}
@@ -0,0 +1,242 @@
+package sprintsummary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency"
+	dependencyMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_dependency/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	orgMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	llmMocks "github.com/thatcatdev/kaimu/backend/internal/services/llm/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	otherBoardColumnID := uuid.New()
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)
+	sp := &sprint.Sprint{ID: uuid.New(), BoardID: boardID, Name: "Sprint 4", Goal: "Ship login", Status: sprint.SprintStatusActive, StartDate: &start, EndDate: &end}
+
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "To Do"}
+	doneCol := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "Done", IsDone: true}
+	points := 3
+	archivedAt := start
+	mergedInto := uuid.New()
+	finished := &card.Card{ID: uuid.New(), ColumnID: doneCol.ID, Title: "Login  form", StoryPoints: &points}
+	working := &card.Card{ID: uuid.New(), ColumnID: todo.ID, Title: "Password reset"}
+	blocked := &card.Card{ID: uuid.New(), ColumnID: todo.ID, Title: "Remember me"}
+	unblocked := &card.Card{ID: uuid.New(), ColumnID: todo.ID, Title: "Logout"}
+	dropped := &card.Card{ID: uuid.New(), ColumnID: todo.ID, Title: "Dropped", ArchivedAt: &archivedAt}
+	merged := &card.Card{ID: uuid.New(), ColumnID: todo.ID, Title: "Duplicate", MergedIntoID: &mergedInto}
+	// The blocker is on another board of the project
+	blocker := &card.Card{ID: uuid.New(), ColumnID: otherBoardColumnID, Title: "Session API"}
+	dependencies := []*card_dependency.CardDependency{
+		{FromCardID: blocker.ID, ToCardID: blocked.ID, Kind: card_dependency.KindBlocks},
+		{FromCardID: finished.ID, ToCardID: unblocked.ID, Kind: card_dependency.KindBlocks},
+		{FromCardID: working.ID, ToCardID: unblocked.ID, Kind: card_dependency.KindRelates},
+	}
+
+	expectSprint := func(mockSprintRepo *sprintMocks.MockRepository, mockBoardRepo *boardMocks.MockRepository, mockProjectRepo *projectMocks.MockRepository, mockOrgRepo *orgMocks.MockRepository, enabled bool) {
+		mockSprintRepo.EXPECT().GetByID(gomock.Any(), sp.ID).Return(sp, nil)
+		mockBoardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		mockProjectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		mockOrgRepo.EXPECT().GetByID(gomock.Any(), orgID).Return(&organization.Organization{ID: orgID, AIDraftingEnabled: enabled}, nil)
+	}
+	expectCards := func(mockCardRepo *cardMocks.MockRepository, mockColumnRepo *columnMocks.MockRepository, mockDependencyRepo *dependencyMocks.MockRepository, cards []*card.Card) {
+		mockCardRepo.EXPECT().GetBySprintID(gomock.Any(), sp.ID).Return(cards, nil)
+		mockColumnRepo.EXPECT().GetByBoardID(gomock.Any(), boardID).Return([]*board_column.BoardColumn{todo, doneCol}, nil)
+		mockDependencyRepo.EXPECT().GetByProjectID(gomock.Any(), projectID).Return(dependencies, nil)
+	}
+
+	t.Run("success - groups the cards and saves the summary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectSprint(mockSprintRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, true)
+		expectCards(mockCardRepo, mockColumnRepo, mockDependencyRepo, []*card.Card{finished, working, blocked, unblocked, dropped, merged})
+		mockCardRepo.EXPECT().GetByID(gomock.Any(), blocker.ID).Return(blocker, nil)
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), otherBoardColumnID).Return(&board_column.BoardColumn{ID: otherBoardColumnID}, nil)
+		mockProvider.EXPECT().Complete(gomock.Any(), systemPrompt, "Sprint: Sprint 4 (active)\n"+
+			"Goal: Ship login\n"+
+			"Dates: 2026-03-02 to 2026-03-13\n"+
+			"\nCompleted: 1 cards, 3 story points\n"+
+			"- Login form\n"+
+			"\nIn progress: 2 cards, 0 story points\n"+
+			"- Password reset\n"+
+			"- Logout\n"+
+			"\nBlocked: 1 cards, 0 story points\n"+
+			"- Remember me (waiting on: Session API)\n").
+			Return("\n The login form shipped.\n", nil)
+		mockSprintRepo.EXPECT().UpdateSummary(gomock.Any(), sp.ID, "The login form shipped.", svc.now()).Return(nil)
+
+		result, err := svc.Generate(ctx, userID, sp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "The login form shipped.", result.Summary)
+		require.NotNil(t, result.SummaryGeneratedAt)
+		assert.Equal(t, svc.now(), *result.SummaryGeneratedAt)
+	})
+
+	t.Run("error - not configured", func(t *testing.T) {
+		svc := NewService(nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorIs(t, err, ErrSummaryUnavailable)
+	})
+
+	t.Run("error - sprint not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		mockSprintRepo.EXPECT().GetByID(gomock.Any(), sp.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorIs(t, err, ErrSprintNotFound)
+	})
+
+	t.Run("error - not enabled for the organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectSprint(mockSprintRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, false)
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorIs(t, err, ErrSummaryDisabled)
+	})
+
+	t.Run("error - nothing to summarize", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectSprint(mockSprintRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, true)
+		expectCards(mockCardRepo, mockColumnRepo, mockDependencyRepo, []*card.Card{dropped, merged})
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorIs(t, err, ErrEmptySprint)
+	})
+
+	t.Run("error - model failure keeps the cached summary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		expectSprint(mockSprintRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, true)
+		expectCards(mockCardRepo, mockColumnRepo, mockDependencyRepo, []*card.Card{finished})
+		mockProvider.EXPECT().Complete(gomock.Any(), systemPrompt, gomock.Any()).Return("", errors.New("timeout"))
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorContains(t, err, "timeout")
+	})
+
+	t.Run("error - rate limited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockProvider := llmMocks.NewMockProvider(ctrl)
+		mockSprintRepo := sprintMocks.NewMockRepository(ctrl)
+		mockCardRepo := cardMocks.NewMockRepository(ctrl)
+		mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+		mockDependencyRepo := dependencyMocks.NewMockRepository(ctrl)
+		mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+		mockProjectRepo := projectMocks.NewMockRepository(ctrl)
+		mockOrgRepo := orgMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockProvider, mockSprintRepo, mockCardRepo, mockColumnRepo, mockDependencyRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo).(*service)
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		for i := 0; i < SummaryBurst; i++ {
+			require.True(t, svc.allow(userID))
+		}
+		expectSprint(mockSprintRepo, mockBoardRepo, mockProjectRepo, mockOrgRepo, true)
+		expectCards(mockCardRepo, mockColumnRepo, mockDependencyRepo, []*card.Card{finished})
+
+		_, err := svc.Generate(ctx, userID, sp.ID)
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+}
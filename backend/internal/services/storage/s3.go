@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	amzDateFormat    = "20060102T150405Z"
+	// maxPresignExpiry is the longest validity Signature Version 4 allows
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// requestTimeout bounds one request the API itself makes to the store
+const requestTimeout = 30 * time.Second
+
+// s3Store talks to Amazon S3 or any store implementing its API, such as MinIO, signing
+// requests with AWS Signature Version 4
+type s3Store struct {
+	client          *http.Client
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+	now             func() time.Time
+}
+
+// NewS3Store returns a store for the bucket at the configured endpoint
+func NewS3Store(cfg config.StorageConfig) (Store, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", cfg.Endpoint)
+	}
+	return newS3Store(&http.Client{Timeout: requestTimeout}, endpoint, cfg), nil
+}
+
+func newS3Store(client *http.Client, endpoint *url.URL, cfg config.StorageConfig) *s3Store {
+	return &s3Store{
+		client:          client,
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		pathStyle:       cfg.PathStyle,
+		now:             time.Now,
+	}
+}
+
+func (s *s3Store) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (string, map[string]string, error) {
+	headers := map[string]string{
+		"content-length": strconv.FormatInt(size, 10),
+		"content-type":   contentType,
+	}
+	return s.presign(http.MethodPut, key, headers, url.Values{}, expiry), map[string]string{"Content-Type": contentType}, nil
+}
+
+func (s *s3Store) PresignGet(ctx context.Context, key, filename string, expiry time.Duration) (string, error) {
+	query := url.Values{}
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); disposition != "" {
+		query.Set("response-content-disposition", disposition)
+	}
+	return s.presign(http.MethodGet, key, nil, query, expiry), nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	hash := sha256.Sum256(body)
+	s.sign(req, hex.EncodeToString(hash[:]))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("object storage returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *s3Store) Size(ctx context.Context, key string) (int64, error) {
+	resp, err := s.do(ctx, http.MethodHead, key)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return 0, ErrObjectNotFound
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("object storage returned %s", resp.Status)
+	}
+	return resp.ContentLength, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("object storage returned %s", resp.Status)
+	}
+}
+
+// do sends a signed request without a body for the object
+func (s *s3Store) do(ctx context.Context, method, key string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, emptyPayloadHash)
+	return s.client.Do(req)
+}
+
+// objectURL returns the address of an object, with the bucket in the path or host name
+func (s *s3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	path := strings.TrimRight(u.Path, "/")
+	if s.pathStyle {
+		path += "/" + s.bucket
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = path + "/" + key
+	u.RawPath = uriEncode(u.Path, false)
+	return &u
+}
+
+// presign returns the object's URL with the request signed in its query, as for a
+// presigned request. headers lists headers, in lower case, the request must send.
+func (s *s3Store) presign(method, key string, headers map[string]string, query url.Values, expiry time.Duration) string {
+	if expiry > maxPresignExpiry {
+		expiry = maxPresignExpiry
+	}
+	if expiry < time.Second {
+		expiry = time.Second
+	}
+
+	t := s.now().UTC()
+	u := s.objectURL(key)
+	signed := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		signed[name] = value
+	}
+	names := sortedKeys(signed)
+
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+credentialScope(t, s.region))
+	query.Set("X-Amz-Date", t.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry/time.Second)))
+	query.Set("X-Amz-SignedHeaders", strings.Join(names, ";"))
+	canonicalQuery := canonicalQueryString(query)
+
+	signature := s.signature(t, canonicalRequest(method, u.RawPath, canonicalQuery, signed, names, unsignedPayload))
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+// sign adds an Authorization header covering the request's headers
+func (s *s3Store) sign(req *http.Request, payloadHash string) {
+	t := s.now().UTC()
+	req.Header.Set("X-Amz-Date", t.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		signed[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := sortedKeys(signed)
+
+	request := canonicalRequest(req.Method, req.URL.EscapedPath(), canonicalQueryString(req.URL.Query()), signed, names, payloadHash)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.accessKeyID, credentialScope(t, s.region), strings.Join(names, ";"), s.signature(t, request)))
+}
+
+// signature signs a canonical request with the key derived for the day and region
+func (s *s3Store) signature(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		credentialScope(t, s.region),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func credentialScope(t time.Time, region string) string {
+	return t.Format("20060102") + "/" + region + "/s3/aws4_request"
+}
+
+func canonicalRequest(method, uri, query string, headers map[string]string, names []string, payloadHash string) string {
+	var b strings.Builder
+	b.WriteString(method + "\n" + uri + "\n" + query + "\n")
+	for _, name := range names {
+		b.WriteString(name + ":" + headers[name] + "\n")
+	}
+	b.WriteString("\n" + strings.Join(names, ";") + "\n" + payloadHash)
+	return b.String()
+}
+
+// canonicalQueryString encodes the query sorted by name, as the signature covers it
+func canonicalQueryString(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for _, name := range sortedKeys(query) {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters, and slashes unless
+// encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,45 @@
+package storage
+
+//go:generate mockgen -source=store.go -destination=mocks/store_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+var (
+	ErrInvalidConfig  = errors.New("object storage needs a bucket and an access key")
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// Store keeps files in an object store. Clients upload and download with presigned URLs, so
+// uploaded file contents never pass through the API.
+type Store interface {
+	// PresignPut returns a URL the file can be PUT to until expiry. The upload must send the
+	// returned headers and exactly size bytes.
+	PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (string, map[string]string, error)
+	// PresignGet returns a URL the file can be downloaded from until expiry, as an
+	// attachment named filename
+	PresignGet(ctx context.Context, key, filename string, expiry time.Duration) (string, error)
+	// Put stores a file the API generated itself, such as an export, replacing any object
+	// at key
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	// Size returns the size of a stored object, or ErrObjectNotFound
+	Size(ctx context.Context, key string) (int64, error)
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStore returns the configured store, or nil when attachments are off
+func NewStore(cfg config.StorageConfig) (Store, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil
+	}
+	if cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, ErrInvalidConfig
+	}
+	return NewS3Store(cfg)
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: undo_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=undo_service.go -destination=mocks/undo_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	undo_operation "github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
+	undo "github.com/thatcatdev/kaimu/backend/internal/services/undo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOperation mocks base method.
+func (m *MockService) GetOperation(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperation", ctx, id)
+	ret0, _ := ret[0].(*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperation indicates an expected call of GetOperation.
+func (mr *MockServiceMockRecorder) GetOperation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperation", reflect.TypeOf((*MockService)(nil).GetOperation), ctx, id)
+}
+
+// GetUndoableOperations mocks base method.
+func (m *MockService) GetUndoableOperations(ctx context.Context, boardID uuid.UUID) ([]*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUndoableOperations", ctx, boardID)
+	ret0, _ := ret[0].([]*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUndoableOperations indicates an expected call of GetUndoableOperations.
+func (mr *MockServiceMockRecorder) GetUndoableOperations(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndoableOperations", reflect.TypeOf((*MockService)(nil).GetUndoableOperations), ctx, boardID)
+}
+
+// Record mocks base method.
+func (m *MockService) Record(ctx context.Context, op undo.Operation) (*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, op)
+	ret0, _ := ret[0].(*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockServiceMockRecorder) Record(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockService)(nil).Record), ctx, op)
+}
+
+// Undo mocks base method.
+func (m *MockService) Undo(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", ctx, id)
+	ret0, _ := ret[0].(*undo_operation.UndoOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockServiceMockRecorder) Undo(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockService)(nil).Undo), ctx, id)
+}
@@ -0,0 +1,255 @@
+package undo
+
+//go:generate mockgen -source=undo_service.go -destination=mocks/undo_service_mock.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOperationNotFound      = errors.New("operation not found")
+	ErrOperationAlreadyUndone = errors.New("operation has already been undone")
+	ErrOperationExpired       = errors.New("operation can no longer be undone")
+	ErrUndoConflict           = errors.New("operation cannot be undone because the board has changed since")
+)
+
+// Window is how long an operation stays undoable after it was recorded
+const Window = 30 * time.Minute
+
+// Kind identifies the operation an undo log entry reverts
+type Kind string
+
+const (
+	KindCompleteSprint Kind = "complete_sprint"
+)
+
+// StepOp is a primitive change applied when undoing an operation
+type StepOp string
+
+const (
+	// StepRestoreSprint puts a sprint back into the status and end date it had before
+	StepRestoreSprint StepOp = "restore_sprint"
+	// StepRemoveCardFromSprint takes a card back out of a sprint it was added to
+	StepRemoveCardFromSprint StepOp = "remove_card_from_sprint"
+)
+
+// Step is one inverse change. Only the fields used by Op are set.
+type Step struct {
+	Op            StepOp              `json:"op"`
+	SprintID      *uuid.UUID          `json:"sprint_id,omitempty"`
+	CardID        *uuid.UUID          `json:"card_id,omitempty"`
+	SprintStatus  sprint.SprintStatus `json:"sprint_status,omitempty"`
+	SprintEndDate *time.Time          `json:"sprint_end_date,omitempty"`
+}
+
+// Operation describes a bulk operation to record, with the steps that revert it in the
+// order they should be applied
+type Operation struct {
+	Kind     Kind
+	BoardID  uuid.UUID
+	EntityID uuid.UUID
+	Inverse  []Step
+}
+
+type Service interface {
+	// Record stores op in the undo log. Call it inside the operation's transaction so the
+	// log entry exists exactly when the operation was applied.
+	Record(ctx context.Context, op Operation) (*undo_operation.UndoOperation, error)
+	GetOperation(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error)
+	// GetUndoableOperations returns the board's operations still inside their undo window
+	GetUndoableOperations(ctx context.Context, boardID uuid.UUID) ([]*undo_operation.UndoOperation, error)
+	// Undo applies the operation's inverse steps. An operation can be undone only once.
+	Undo(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error)
+}
+
+type service struct {
+	undoRepo   undo_operation.Repository
+	sprintRepo sprint.Repository
+	cardRepo   card.Repository
+	txManager  transaction.Manager
+	bus        events.Bus
+	now        func() time.Time
+}
+
+func NewService(undoRepo undo_operation.Repository, sprintRepo sprint.Repository, cardRepo card.Repository, txManager transaction.Manager, bus events.Bus) Service {
+	return &service{
+		undoRepo:   undoRepo,
+		sprintRepo: sprintRepo,
+		cardRepo:   cardRepo,
+		txManager:  txManager,
+		bus:        bus,
+		now:        time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "undo.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "undo"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Record(ctx context.Context, op Operation) (*undo_operation.UndoOperation, error) {
+	ctx, span := s.startServiceSpan(ctx, "Record")
+	span.SetAttributes(
+		attribute.String("undo.kind", string(op.Kind)),
+		attribute.String("undo.board_id", op.BoardID.String()),
+	)
+	defer span.End()
+
+	inverse, err := json.Marshal(op.Inverse)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &undo_operation.UndoOperation{
+		Kind:      string(op.Kind),
+		BoardID:   op.BoardID,
+		EntityID:  op.EntityID,
+		ActorID:   events.ActorFromContext(ctx),
+		Inverse:   inverse,
+		ExpiresAt: s.now().Add(Window),
+	}
+	if err := s.undoRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *service) GetOperation(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetOperation")
+	span.SetAttributes(attribute.String("undo.id", id.String()))
+	defer span.End()
+
+	op, err := s.undoRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, err
+	}
+	return op, nil
+}
+
+func (s *service) GetUndoableOperations(ctx context.Context, boardID uuid.UUID) ([]*undo_operation.UndoOperation, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetUndoableOperations")
+	span.SetAttributes(attribute.String("undo.board_id", boardID.String()))
+	defer span.End()
+
+	return s.undoRepo.GetUndoableByBoardID(ctx, boardID, s.now())
+}
+
+func (s *service) Undo(ctx context.Context, id uuid.UUID) (*undo_operation.UndoOperation, error) {
+	ctx, span := s.startServiceSpan(ctx, "Undo")
+	span.SetAttributes(attribute.String("undo.id", id.String()))
+	defer span.End()
+
+	op, err := s.GetOperation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if op.UndoneAt != nil {
+		return nil, ErrOperationAlreadyUndone
+	}
+	now := s.now()
+	if !now.Before(op.ExpiresAt) {
+		return nil, ErrOperationExpired
+	}
+
+	var steps []Step
+	if err := json.Unmarshal(op.Inverse, &steps); err != nil {
+		return nil, fmt.Errorf("decode undo steps: %w", err)
+	}
+
+	undoneBy := events.ActorFromContext(ctx)
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Claiming first means a concurrent undo of the same operation finds nothing to do
+		claimed, err := s.undoRepo.MarkUndone(ctx, op.ID, undoneBy, now)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrOperationAlreadyUndone
+		}
+
+		for _, step := range steps {
+			if err := s.apply(ctx, op, step); err != nil {
+				return err
+			}
+		}
+
+		return s.bus.Publish(ctx, events.New(ctx, events.OperationUndone, events.OperationUndonePayload{
+			OperationID: op.ID,
+			Kind:        op.Kind,
+			BoardID:     op.BoardID,
+			EntityID:    op.EntityID,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	op.UndoneAt = &now
+	op.UndoneBy = undoneBy
+	return op, nil
+}
+
+// apply performs one inverse step, refusing steps that would overwrite changes made after
+// the operation
+func (s *service) apply(ctx context.Context, op *undo_operation.UndoOperation, step Step) error {
+	switch step.Op {
+	case StepRestoreSprint:
+		sp, err := s.sprintRepo.GetByID(ctx, *step.SprintID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUndoConflict
+			}
+			return err
+		}
+		// The sprint was reopened or otherwise changed since the operation
+		if sp.Status != sprint.SprintStatusClosed {
+			return ErrUndoConflict
+		}
+		if step.SprintStatus == sprint.SprintStatusActive {
+			// Another sprint has been started on the board in the meantime
+			active, err := s.sprintRepo.GetActiveByBoardID(ctx, op.BoardID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if active != nil {
+				return ErrUndoConflict
+			}
+		}
+
+		sp.Status = step.SprintStatus
+		sp.EndDate = step.SprintEndDate
+		return s.sprintRepo.Update(ctx, sp)
+
+	case StepRemoveCardFromSprint:
+		return s.cardRepo.RemoveCardFromSprint(ctx, *step.CardID, *step.SprintID)
+
+	default:
+		return fmt.Errorf("unknown undo step %q", step.Op)
+	}
+}
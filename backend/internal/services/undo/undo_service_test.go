@@ -0,0 +1,189 @@
+package undo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+	sprintMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
+	undoMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type testDeps struct {
+	undoRepo   *undoMocks.MockRepository
+	sprintRepo *sprintMocks.MockRepository
+	cardRepo   *cardMocks.MockRepository
+	bus        *events.InProcessBus
+	svc        *service
+}
+
+func setup(t *testing.T, now time.Time) *testDeps {
+	ctrl := gomock.NewController(t)
+	d := &testDeps{
+		undoRepo:   undoMocks.NewMockRepository(ctrl),
+		sprintRepo: sprintMocks.NewMockRepository(ctrl),
+		cardRepo:   cardMocks.NewMockRepository(ctrl),
+		bus:        events.NewSyncBus(),
+	}
+	d.svc = NewService(d.undoRepo, d.sprintRepo, d.cardRepo, transaction.NewNoopManager(), d.bus).(*service)
+	d.svc.now = func() time.Time { return now }
+	return d
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	d := setup(t, now)
+
+	userID := uuid.New()
+	ctx := events.WithActor(context.Background(), userID)
+	sprintID := uuid.New()
+	boardID := uuid.New()
+	inverse := []Step{{Op: StepRestoreSprint, SprintID: &sprintID, SprintStatus: sprint.SprintStatusActive}}
+
+	d.undoRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, op *undo_operation.UndoOperation) error {
+		assert.Equal(t, string(KindCompleteSprint), op.Kind)
+		assert.Equal(t, boardID, op.BoardID)
+		assert.Equal(t, sprintID, op.EntityID)
+		assert.Equal(t, &userID, op.ActorID)
+		assert.Equal(t, now.Add(Window), op.ExpiresAt)
+
+		var steps []Step
+		require.NoError(t, json.Unmarshal(op.Inverse, &steps))
+		assert.Equal(t, inverse, steps)
+		return nil
+	})
+
+	_, err := d.svc.Record(ctx, Operation{Kind: KindCompleteSprint, BoardID: boardID, EntityID: sprintID, Inverse: inverse})
+	require.NoError(t, err)
+}
+
+func TestUndo(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	sprintID := uuid.New()
+	nextSprintID := uuid.New()
+	cardID := uuid.New()
+	endDate := now.Add(24 * time.Hour)
+
+	newOperation := func(t *testing.T) *undo_operation.UndoOperation {
+		inverse, err := json.Marshal([]Step{
+			{Op: StepRestoreSprint, SprintID: &sprintID, SprintStatus: sprint.SprintStatusActive, SprintEndDate: &endDate},
+			{Op: StepRemoveCardFromSprint, CardID: &cardID, SprintID: &nextSprintID},
+		})
+		require.NoError(t, err)
+		return &undo_operation.UndoOperation{
+			ID:        uuid.New(),
+			Kind:      string(KindCompleteSprint),
+			BoardID:   boardID,
+			EntityID:  sprintID,
+			Inverse:   inverse,
+			ExpiresAt: now.Add(time.Minute),
+		}
+	}
+
+	t.Run("reactivates the sprint and takes carried cards back out", func(t *testing.T) {
+		d := setup(t, now)
+		op := newOperation(t)
+
+		var undone []events.Event
+		d.bus.Subscribe(events.OperationUndone, func(ctx context.Context, e events.Event) error {
+			undone = append(undone, e)
+			return nil
+		})
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+		d.undoRepo.EXPECT().MarkUndone(gomock.Any(), op.ID, nil, now).Return(true, nil)
+		d.sprintRepo.EXPECT().GetByID(gomock.Any(), sprintID).Return(&sprint.Sprint{ID: sprintID, BoardID: boardID, Status: sprint.SprintStatusClosed}, nil)
+		d.sprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+		d.sprintRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, sp *sprint.Sprint) error {
+			assert.Equal(t, sprint.SprintStatusActive, sp.Status)
+			assert.True(t, endDate.Equal(*sp.EndDate))
+			return nil
+		})
+		d.cardRepo.EXPECT().RemoveCardFromSprint(gomock.Any(), cardID, nextSprintID).Return(nil)
+
+		result, err := d.svc.Undo(ctx, op.ID)
+		require.NoError(t, err)
+		require.NotNil(t, result.UndoneAt)
+
+		require.Len(t, undone, 1)
+		assert.Equal(t, op.ID, undone[0].Payload.(events.OperationUndonePayload).OperationID)
+	})
+
+	t.Run("conflicts when another sprint has been started", func(t *testing.T) {
+		d := setup(t, now)
+		op := newOperation(t)
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+		d.undoRepo.EXPECT().MarkUndone(gomock.Any(), op.ID, nil, now).Return(true, nil)
+		d.sprintRepo.EXPECT().GetByID(gomock.Any(), sprintID).Return(&sprint.Sprint{ID: sprintID, BoardID: boardID, Status: sprint.SprintStatusClosed}, nil)
+		d.sprintRepo.EXPECT().GetActiveByBoardID(gomock.Any(), boardID).Return(&sprint.Sprint{ID: nextSprintID}, nil)
+
+		_, err := d.svc.Undo(ctx, op.ID)
+		assert.ErrorIs(t, err, ErrUndoConflict)
+	})
+
+	t.Run("conflicts when the sprint was reopened", func(t *testing.T) {
+		d := setup(t, now)
+		op := newOperation(t)
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+		d.undoRepo.EXPECT().MarkUndone(gomock.Any(), op.ID, nil, now).Return(true, nil)
+		d.sprintRepo.EXPECT().GetByID(gomock.Any(), sprintID).Return(&sprint.Sprint{ID: sprintID, BoardID: boardID, Status: sprint.SprintStatusFuture}, nil)
+
+		_, err := d.svc.Undo(ctx, op.ID)
+		assert.ErrorIs(t, err, ErrUndoConflict)
+	})
+
+	t.Run("expired operations cannot be undone", func(t *testing.T) {
+		d := setup(t, now)
+		op := newOperation(t)
+		op.ExpiresAt = now
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+
+		_, err := d.svc.Undo(ctx, op.ID)
+		assert.ErrorIs(t, err, ErrOperationExpired)
+	})
+
+	t.Run("an operation is undone only once", func(t *testing.T) {
+		d := setup(t, now)
+		op := newOperation(t)
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+		d.undoRepo.EXPECT().MarkUndone(gomock.Any(), op.ID, nil, now).Return(false, nil)
+
+		_, err := d.svc.Undo(ctx, op.ID)
+		assert.ErrorIs(t, err, ErrOperationAlreadyUndone)
+
+		undoneAt := now
+		op.UndoneAt = &undoneAt
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), op.ID).Return(op, nil)
+
+		_, err = d.svc.Undo(ctx, op.ID)
+		assert.ErrorIs(t, err, ErrOperationAlreadyUndone)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		d := setup(t, now)
+		id := uuid.New()
+
+		d.undoRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := d.svc.Undo(ctx, id)
+		assert.ErrorIs(t, err, ErrOperationNotFound)
+	})
+}
@@ -0,0 +1,45 @@
+package unread
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+)
+
+// Tracker marks cards viewed by the users who change them, so people's own edits don't
+// show up as unread activity. The view is taken at the event's time, so a late delivery
+// doesn't hide changes made by others since.
+type Tracker struct {
+	viewRepo card_view.Repository
+}
+
+func NewTracker(viewRepo card_view.Repository) *Tracker {
+	return &Tracker{viewRepo: viewRepo}
+}
+
+// Subscribe registers the tracker for the events that change a card
+func (t *Tracker) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardCreated, t.handleEvent)
+	bus.Subscribe(events.CardUpdated, t.handleEvent)
+	bus.Subscribe(events.CardMoved, t.handleEvent)
+}
+
+func (t *Tracker) handleEvent(ctx context.Context, event events.Event) error {
+	if event.ActorID == nil {
+		return nil
+	}
+
+	var cardID uuid.UUID
+	switch payload := event.Payload.(type) {
+	case events.CardPayload:
+		cardID = payload.CardID
+	case events.CardMovedPayload:
+		cardID = payload.CardID
+	default:
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	return t.viewRepo.MarkViewed(ctx, *event.ActorID, cardID, event.OccurredAt)
+}
@@ -0,0 +1,91 @@
+package unread
+
+//go:generate mockgen -source=unread_service.go -destination=mocks/unread_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Service tracks which cards changed since each user last viewed them. A card the user
+// never viewed counts as unread.
+type Service interface {
+	// MarkViewed records that the user viewed the card now
+	MarkViewed(ctx context.Context, userID, cardID uuid.UUID) error
+	// HasUnreadActivity reports whether a card last changed at updatedAt changed since the
+	// user last viewed it
+	HasUnreadActivity(ctx context.Context, userID, cardID uuid.UUID, updatedAt time.Time) (bool, error)
+	// CountUnread counts the board's cards with unread activity for the user
+	CountUnread(ctx context.Context, userID, boardID uuid.UUID) (int, error)
+}
+
+type service struct {
+	viewRepo card_view.Repository
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewService(viewRepo card_view.Repository) Service {
+	return &service{viewRepo: viewRepo, now: time.Now}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "unread.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "unread"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) MarkViewed(ctx context.Context, userID, cardID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "MarkViewed")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("card.id", cardID.String()),
+	)
+	defer span.End()
+
+	return s.viewRepo.MarkViewed(ctx, userID, cardID, s.now())
+}
+
+func (s *service) HasUnreadActivity(ctx context.Context, userID, cardID uuid.UUID, updatedAt time.Time) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "HasUnreadActivity")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("card.id", cardID.String()),
+	)
+	defer span.End()
+
+	view, err := s.viewRepo.GetByUserAndCard(ctx, userID, cardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return updatedAt.After(view.ViewedAt), nil
+}
+
+func (s *service) CountUnread(ctx context.Context, userID, boardID uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "CountUnread")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("board.id", boardID.String()),
+	)
+	defer span.End()
+
+	return s.viewRepo.CountUnreadByBoardID(ctx, userID, boardID)
+}
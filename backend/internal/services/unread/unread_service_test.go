@@ -0,0 +1,96 @@
+package unread
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view"
+	viewMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_view/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestHasUnreadActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViewRepo := viewMocks.NewMockRepository(ctrl)
+	svc := NewService(mockViewRepo)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	viewedAt := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("changed since the last view", func(t *testing.T) {
+		mockViewRepo.EXPECT().GetByUserAndCard(gomock.Any(), userID, cardID).Return(&card_view.CardView{UserID: userID, CardID: cardID, ViewedAt: viewedAt}, nil)
+
+		unread, err := svc.HasUnreadActivity(ctx, userID, cardID, viewedAt.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, unread)
+	})
+
+	t.Run("unchanged since the last view", func(t *testing.T) {
+		mockViewRepo.EXPECT().GetByUserAndCard(gomock.Any(), userID, cardID).Return(&card_view.CardView{UserID: userID, CardID: cardID, ViewedAt: viewedAt}, nil)
+
+		unread, err := svc.HasUnreadActivity(ctx, userID, cardID, viewedAt)
+		require.NoError(t, err)
+		assert.False(t, unread)
+	})
+
+	t.Run("never viewed", func(t *testing.T) {
+		mockViewRepo.EXPECT().GetByUserAndCard(gomock.Any(), userID, cardID).Return(nil, gorm.ErrRecordNotFound)
+
+		unread, err := svc.HasUnreadActivity(ctx, userID, cardID, viewedAt)
+		require.NoError(t, err)
+		assert.True(t, unread)
+	})
+}
+
+func TestMarkViewed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockViewRepo := viewMocks.NewMockRepository(ctrl)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	svc := &service{viewRepo: mockViewRepo, now: func() time.Time { return now }}
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	mockViewRepo.EXPECT().MarkViewed(gomock.Any(), userID, cardID, now).Return(nil)
+
+	require.NoError(t, svc.MarkViewed(context.Background(), userID, cardID))
+}
+
+func TestTracker(t *testing.T) {
+	cardID := uuid.New()
+	boardID := uuid.New()
+	actorID := uuid.New()
+
+	t.Run("marks the card viewed by the user who changed it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockViewRepo := viewMocks.NewMockRepository(ctrl)
+		bus := events.NewSyncBus()
+		NewTracker(mockViewRepo).Subscribe(bus)
+
+		ctx := events.WithActor(context.Background(), actorID)
+		event := events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: cardID, BoardID: boardID})
+		mockViewRepo.EXPECT().MarkViewed(gomock.Any(), actorID, cardID, event.OccurredAt).Return(nil)
+
+		require.NoError(t, bus.Publish(ctx, event))
+	})
+
+	t.Run("ignores system changes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		bus := events.NewSyncBus()
+		NewTracker(viewMocks.NewMockRepository(ctrl)).Subscribe(bus)
+
+		ctx := context.Background()
+		require.NoError(t, bus.Publish(ctx, events.New(ctx, events.CardUpdated, events.CardPayload{CardID: cardID, BoardID: boardID})))
+	})
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usermatch_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usermatch_service.go -destination=mocks/usermatch_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	user_match "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	usermatch "github.com/thatcatdev/kaimu/backend/internal/services/usermatch"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMatch mocks base method.
+func (m *MockService) GetMatch(ctx context.Context, id uuid.UUID) (*user_match.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatch", ctx, id)
+	ret0, _ := ret[0].(*user_match.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatch indicates an expected call of GetMatch.
+func (mr *MockServiceMockRecorder) GetMatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatch", reflect.TypeOf((*MockService)(nil).GetMatch), ctx, id)
+}
+
+// GetPendingMatches mocks base method.
+func (m *MockService) GetPendingMatches(ctx context.Context, orgID uuid.UUID) ([]*user_match.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingMatches", ctx, orgID)
+	ret0, _ := ret[0].([]*user_match.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingMatches indicates an expected call of GetPendingMatches.
+func (mr *MockServiceMockRecorder) GetPendingMatches(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingMatches", reflect.TypeOf((*MockService)(nil).GetPendingMatches), ctx, orgID)
+}
+
+// MatchUsers mocks base method.
+func (m *MockService) MatchUsers(ctx context.Context, orgID uuid.UUID, source string, users []usermatch.ExternalUser) ([]*user_match.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchUsers", ctx, orgID, source, users)
+	ret0, _ := ret[0].([]*user_match.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchUsers indicates an expected call of MatchUsers.
+func (mr *MockServiceMockRecorder) MatchUsers(ctx, orgID, source, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchUsers", reflect.TypeOf((*MockService)(nil).MatchUsers), ctx, orgID, source, users)
+}
+
+// ResolveMatch mocks base method.
+func (m *MockService) ResolveMatch(ctx context.Context, id uuid.UUID, userID *uuid.UUID, resolvedBy uuid.UUID) (*user_match.UserMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMatch", ctx, id, userID, resolvedBy)
+	ret0, _ := ret[0].(*user_match.UserMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveMatch indicates an expected call of ResolveMatch.
+func (mr *MockServiceMockRecorder) ResolveMatch(ctx, id, userID, resolvedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMatch", reflect.TypeOf((*MockService)(nil).ResolveMatch), ctx, id, userID, resolvedBy)
+}
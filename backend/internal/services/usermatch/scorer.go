@@ -0,0 +1,152 @@
+package usermatch
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+)
+
+// How sure each kind of evidence makes a match. Only the same email is certain enough to
+// match without asking; names are never unique.
+const (
+	emailConfidence           = 1.0
+	nameConfidence            = 0.9
+	reorderedNameConfidence   = 0.85
+	usernameConfidence        = 0.8
+	abbreviatedNameConfidence = 0.7
+	emailLocalPartConfidence  = 0.7
+	// similarNameConfidence is scaled by how similar the names are
+	similarNameConfidence = 0.8
+	// minNameSimilarity is how similar two names must be to count at all, 1 being equal
+	minNameSimilarity = 0.75
+)
+
+// score returns how likely the external user is the existing user, and why
+func score(ext ExternalUser, u *user.User) (float64, user_match.Reason) {
+	email := strings.TrimSpace(ext.Email)
+	if email != "" && u.Email != nil && strings.EqualFold(email, strings.TrimSpace(*u.Email)) {
+		return emailConfidence, user_match.ReasonEmail
+	}
+
+	var best float64
+	var reason user_match.Reason
+	consider := func(confidence float64, r user_match.Reason) {
+		if confidence > best {
+			best, reason = confidence, r
+		}
+	}
+
+	name := nameTokens(ext.DisplayName)
+	displayName := nameTokens(deref(u.DisplayName))
+	consider(nameScore(name, displayName))
+	consider(nameScore(name, nameTokens(u.Username)))
+
+	if local := emailLocalPart(email); local != "" {
+		if local == strings.Join(nameTokens(u.Username), "") {
+			consider(usernameConfidence, user_match.ReasonUsername)
+		}
+		// john.smith@example.com is most likely John Smith
+		if len(displayName) > 1 && local == strings.Join(displayName, "") {
+			consider(usernameConfidence, user_match.ReasonName)
+		}
+		if u.Email != nil && local == emailLocalPart(*u.Email) {
+			consider(emailLocalPartConfidence, user_match.ReasonEmailLocalPart)
+		}
+	}
+	return best, reason
+}
+
+// nameScore compares two names split into tokens
+func nameScore(a, b []string) (float64, user_match.Reason) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, ""
+	}
+	if slices.Equal(a, b) {
+		return nameConfidence, user_match.ReasonName
+	}
+	if len(a) == len(b) {
+		// "Smith, John" is John Smith
+		sortedA, sortedB := slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b))
+		if slices.Equal(sortedA, sortedB) {
+			return reorderedNameConfidence, user_match.ReasonName
+		}
+		// "J. Smith" is likely John Smith
+		if abbreviates(a, b) {
+			return abbreviatedNameConfidence, user_match.ReasonName
+		}
+	}
+
+	joinedA, joinedB := strings.Join(a, " "), strings.Join(b, " ")
+	similarity := 1 - float64(levenshtein(joinedA, joinedB))/float64(max(len([]rune(joinedA)), len([]rune(joinedB))))
+	if similarity < minNameSimilarity {
+		return 0, ""
+	}
+	return similarNameConfidence * similarity, user_match.ReasonSimilarName
+}
+
+// abbreviates reports whether the names are the same but for some tokens being initials,
+// with at least one token written out in both
+func abbreviates(a, b []string) bool {
+	spelledOut := false
+	for i := range a {
+		switch {
+		case a[i] == b[i]:
+			spelledOut = spelledOut || len([]rune(a[i])) > 1
+		case len([]rune(a[i])) == 1 && strings.HasPrefix(b[i], a[i]),
+			len([]rune(b[i])) == 1 && strings.HasPrefix(a[i], b[i]):
+		default:
+			return false
+		}
+	}
+	return spelledOut
+}
+
+// nameTokens splits a name into lowercase words, dropping punctuation
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// emailLocalPart returns the part of the email before the @, lowercase and without any
+// +tag or punctuation
+func emailLocalPart(email string) string {
+	local, _, found := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !found {
+		return ""
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return strings.Join(nameTokens(local), "")
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
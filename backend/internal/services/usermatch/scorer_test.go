@@ -0,0 +1,50 @@
+package usermatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+)
+
+func TestScore(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	johnSmith := &user.User{Username: "jsmith", DisplayName: strPtr("John Smith"), Email: strPtr("John.Smith@example.com")}
+
+	tests := []struct {
+		name       string
+		ext        ExternalUser
+		confidence float64
+		reason     user_match.Reason
+	}{
+		{"same email in another case", ExternalUser{Email: "john.smith@EXAMPLE.com"}, emailConfidence, user_match.ReasonEmail},
+		{"same name", ExternalUser{DisplayName: "john smith"}, nameConfidence, user_match.ReasonName},
+		{"reordered name", ExternalUser{DisplayName: "Smith, John"}, reorderedNameConfidence, user_match.ReasonName},
+		{"abbreviated name", ExternalUser{DisplayName: "J. Smith"}, abbreviatedNameConfidence, user_match.ReasonName},
+		{"email local part is the username", ExternalUser{Email: "jsmith+jira@other.org"}, usernameConfidence, user_match.ReasonUsername},
+		{"email local part is the name", ExternalUser{Email: "john_smith@other.org"}, usernameConfidence, user_match.ReasonName},
+		{"unrelated user", ExternalUser{DisplayName: "Jane Doe", Email: "jane@example.com"}, 0, ""},
+		{"initials alone", ExternalUser{DisplayName: "J. S."}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confidence, reason := score(tt.ext, johnSmith)
+			assert.Equal(t, tt.confidence, confidence)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	t.Run("a typo scores by similarity", func(t *testing.T) {
+		confidence, reason := score(ExternalUser{DisplayName: "Jon Smith"}, johnSmith)
+		assert.Equal(t, user_match.ReasonSimilarName, reason)
+		assert.InDelta(t, similarNameConfidence*0.9, confidence, 0.001)
+	})
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("smith", "smith"))
+	assert.Equal(t, 1, levenshtein("smith", "smyth"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 4, levenshtein("", "josé"))
+}
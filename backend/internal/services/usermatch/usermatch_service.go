@@ -0,0 +1,310 @@
+package usermatch
+
+//go:generate mockgen -source=usermatch_service.go -destination=mocks/usermatch_service_mock.go -package=mocks
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// AutoMatchConfidence is how sure a match must be to be made without asking. Only the
+	// same email reaches it.
+	AutoMatchConfidence = 0.95
+	// MinCandidateConfidence is how likely a user must be to be offered as a candidate
+	MinCandidateConfidence = 0.5
+	// MaxCandidates caps the candidates kept per match
+	MaxCandidates = 5
+	// MaxBatchSize caps how many external users are matched at once
+	MaxBatchSize = 500
+)
+
+var (
+	ErrSourceRequired   = errors.New("a source is required")
+	ErrIdentityRequired = errors.New("an external user needs an external ID or an email")
+	ErrBatchTooLarge    = fmt.Errorf("at most %d users can be matched at once", MaxBatchSize)
+	ErrMatchNotFound    = errors.New("user match not found")
+	ErrUserNotCandidate = errors.New("user is neither a candidate of the match nor a member of the organization")
+)
+
+// ExternalUser is a user as an external source knows them
+type ExternalUser struct {
+	// ExternalID identifies the user in the source; their email when empty
+	ExternalID  string
+	DisplayName string
+	Email       string
+}
+
+type Service interface {
+	// MatchUsers maps each external user to an existing user. Certain matches are made
+	// right away, uncertain ones wait in the queue for ResolveMatch, and users with no
+	// likely candidate are marked new. Matching the same user again scores them afresh
+	// unless their match was resolved by hand.
+	MatchUsers(ctx context.Context, orgID uuid.UUID, source string, users []ExternalUser) ([]*user_match.UserMatch, error)
+	GetMatch(ctx context.Context, id uuid.UUID) (*user_match.UserMatch, error)
+	// GetPendingMatches returns the organization's matches awaiting resolution
+	GetPendingMatches(ctx context.Context, orgID uuid.UUID) ([]*user_match.UserMatch, error)
+	// ResolveMatch settles a match by hand: to the given user, who must be a candidate or
+	// a member of the organization, or to a new user when userID is nil
+	ResolveMatch(ctx context.Context, id uuid.UUID, userID *uuid.UUID, resolvedBy uuid.UUID) (*user_match.UserMatch, error)
+}
+
+type service struct {
+	matchRepo     user_match.Repository
+	orgMemberRepo organization_member.Repository
+	userRepo      user.Repository
+	now           func() time.Time
+}
+
+func NewService(matchRepo user_match.Repository, orgMemberRepo organization_member.Repository, userRepo user.Repository) Service {
+	return &service{
+		matchRepo:     matchRepo,
+		orgMemberRepo: orgMemberRepo,
+		userRepo:      userRepo,
+		now:           time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "usermatch.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "usermatch"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) MatchUsers(ctx context.Context, orgID uuid.UUID, source string, users []ExternalUser) ([]*user_match.UserMatch, error) {
+	ctx, span := s.startServiceSpan(ctx, "MatchUsers")
+	span.SetAttributes(
+		attribute.String("organization.id", orgID.String()),
+		attribute.Int("users.count", len(users)),
+	)
+	defer span.End()
+
+	source = strings.ToLower(strings.TrimSpace(source))
+	if source == "" {
+		return nil, ErrSourceRequired
+	}
+	if len(users) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	externalIDs := make([]string, len(users))
+	for i, ext := range users {
+		externalIDs[i] = strings.TrimSpace(ext.ExternalID)
+		if externalIDs[i] == "" {
+			externalIDs[i] = strings.ToLower(strings.TrimSpace(ext.Email))
+		}
+		if externalIDs[i] == "" {
+			return nil, ErrIdentityRequired
+		}
+	}
+
+	members, err := s.getMemberUsers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*user_match.UserMatch, len(users))
+	for i, ext := range users {
+		match, err := s.matchRepo.GetByExternalID(ctx, orgID, source, externalIDs[i])
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if match != nil && match.ResolvedBy != nil {
+			matches[i] = match
+			continue
+		}
+
+		candidates, err := s.findCandidates(ctx, ext, members)
+		if err != nil {
+			return nil, err
+		}
+
+		isNew := match == nil
+		if isNew {
+			match = &user_match.UserMatch{
+				OrganizationID: orgID,
+				Source:         source,
+				ExternalID:     externalIDs[i],
+			}
+		}
+		match.DisplayName = optional(ext.DisplayName)
+		match.Email = optional(ext.Email)
+		s.applyCandidates(match, candidates)
+
+		if isNew {
+			err = s.matchRepo.Create(ctx, match)
+		} else {
+			err = s.matchRepo.Update(ctx, match)
+		}
+		if err != nil {
+			return nil, err
+		}
+		matches[i] = match
+	}
+	return matches, nil
+}
+
+func (s *service) GetMatch(ctx context.Context, id uuid.UUID) (*user_match.UserMatch, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetMatch")
+	defer span.End()
+
+	match, err := s.matchRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMatchNotFound
+	}
+	return match, err
+}
+
+func (s *service) GetPendingMatches(ctx context.Context, orgID uuid.UUID) ([]*user_match.UserMatch, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetPendingMatches")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	return s.matchRepo.GetPendingByOrgID(ctx, orgID)
+}
+
+func (s *service) ResolveMatch(ctx context.Context, id uuid.UUID, userID *uuid.UUID, resolvedBy uuid.UUID) (*user_match.UserMatch, error) {
+	ctx, span := s.startServiceSpan(ctx, "ResolveMatch")
+	defer span.End()
+
+	match, err := s.GetMatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	match.Status = user_match.StatusNew
+	match.UserID = nil
+	match.Confidence = 0
+	if userID != nil {
+		idx := slices.IndexFunc(match.Candidates, func(c user_match.Candidate) bool { return c.UserID == *userID })
+		if idx < 0 {
+			if _, err := s.orgMemberRepo.GetByOrgAndUser(ctx, match.OrganizationID, *userID); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, ErrUserNotCandidate
+				}
+				return nil, err
+			}
+		}
+		match.Status = user_match.StatusMatched
+		match.UserID = userID
+		// Someone vouched for the user, whatever the evidence said
+		match.Confidence = 1
+	}
+	now := s.now()
+	match.ResolvedBy = &resolvedBy
+	match.ResolvedAt = &now
+
+	if err := s.matchRepo.Update(ctx, match); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// getMemberUsers returns the users of the organization's members and guests
+func (s *service) getMemberUsers(ctx context.Context, orgID uuid.UUID) ([]*user.User, error) {
+	members, err := s.orgMemberRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*user.User, 0, len(members))
+	for _, m := range members {
+		u, err := s.userRepo.GetByID(ctx, m.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// findCandidates scores the organization's members against the external user, most likely
+// first. A user outside the organization is only a candidate when they have the same email,
+// so the importer can invite them rather than create a second account.
+func (s *service) findCandidates(ctx context.Context, ext ExternalUser, members []*user.User) (user_match.Candidates, error) {
+	var candidates user_match.Candidates
+	emailMatched := false
+	for _, u := range members {
+		confidence, reason := score(ext, u)
+		if confidence < MinCandidateConfidence {
+			continue
+		}
+		emailMatched = emailMatched || reason == user_match.ReasonEmail
+		candidates = append(candidates, user_match.Candidate{UserID: u.ID, Confidence: confidence, Reason: reason})
+	}
+
+	if email := strings.TrimSpace(ext.Email); email != "" && !emailMatched {
+		u, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if u != nil {
+			candidates = append(candidates, user_match.Candidate{UserID: u.ID, Confidence: emailConfidence, Reason: user_match.ReasonEmail})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b user_match.Candidate) int {
+		return cmp.Compare(b.Confidence, a.Confidence)
+	})
+	if len(candidates) > MaxCandidates {
+		candidates = candidates[:MaxCandidates]
+	}
+	return candidates, nil
+}
+
+// applyCandidates settles the match when its best candidate is certain and alone in being
+// so, queues it when there are only likely candidates, and marks it new when there are none
+func (s *service) applyCandidates(match *user_match.UserMatch, candidates user_match.Candidates) {
+	match.Candidates = candidates
+	match.UserID = nil
+	match.Confidence = 0
+	match.ResolvedAt = nil
+
+	switch {
+	case len(candidates) == 0:
+		match.Status = user_match.StatusNew
+	case candidates[0].Confidence >= AutoMatchConfidence &&
+		(len(candidates) == 1 || candidates[1].Confidence < AutoMatchConfidence):
+		match.Status = user_match.StatusMatched
+		match.UserID = &candidates[0].UserID
+		match.Confidence = candidates[0].Confidence
+	default:
+		match.Status = user_match.StatusPending
+		match.Confidence = candidates[0].Confidence
+	}
+	if match.Status != user_match.StatusPending {
+		now := s.now()
+		match.ResolvedAt = &now
+	}
+}
+
+// optional returns nil for an empty string
+func optional(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
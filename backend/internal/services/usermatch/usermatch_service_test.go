@@ -0,0 +1,273 @@
+package usermatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	orgMemberMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match"
+	matchMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user_match/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// expectMembers makes the users the organization's members
+func expectMembers(mockOrgMemberRepo *orgMemberMocks.MockRepository, mockUserRepo *userMocks.MockRepository, orgID uuid.UUID, users ...*user.User) {
+	members := make([]*organization_member.OrganizationMember, len(users))
+	for i, u := range users {
+		members[i] = &organization_member.OrganizationMember{OrganizationID: orgID, UserID: u.ID}
+		mockUserRepo.EXPECT().GetByID(gomock.Any(), u.ID).Return(u, nil)
+	}
+	mockOrgMemberRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(members, nil)
+}
+
+func TestMatchUsers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID := uuid.New()
+	strPtr := func(s string) *string { return &s }
+	john := &user.User{ID: uuid.New(), Username: "jsmith", DisplayName: strPtr("John Smith"), Email: strPtr("john@example.com")}
+	johnny := &user.User{ID: uuid.New(), Username: "johnny", DisplayName: strPtr("John Smith"), Email: strPtr("johnny@example.com")}
+
+	t.Run("success - same email matches, names queue and strangers are new", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		expectMembers(mockOrgMemberRepo, mockUserRepo, orgID, john, johnny)
+		mockMatchRepo.EXPECT().GetByExternalID(gomock.Any(), orgID, "jira", gomock.Any()).Return(nil, gorm.ErrRecordNotFound).Times(3)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "Jane@example.com").Return(nil, gorm.ErrRecordNotFound)
+		mockMatchRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+		matches, err := svc.MatchUsers(ctx, orgID, " Jira ", []ExternalUser{
+			{ExternalID: "acc-1", DisplayName: "Johnathan S", Email: "JOHN@example.com"},
+			{ExternalID: "acc-2", DisplayName: "John Smith"},
+			{DisplayName: "Jane Doe", Email: "Jane@example.com"},
+		})
+		require.NoError(t, err)
+		require.Len(t, matches, 3)
+
+		assert.Equal(t, user_match.StatusMatched, matches[0].Status)
+		assert.Equal(t, &john.ID, matches[0].UserID)
+		assert.Equal(t, emailConfidence, matches[0].Confidence)
+		assert.Equal(t, &now, matches[0].ResolvedAt)
+
+		assert.Equal(t, user_match.StatusPending, matches[1].Status)
+		assert.Nil(t, matches[1].UserID)
+		assert.Equal(t, nameConfidence, matches[1].Confidence)
+		assert.Len(t, matches[1].Candidates, 2)
+		assert.Nil(t, matches[1].ResolvedAt)
+
+		assert.Equal(t, user_match.StatusNew, matches[2].Status)
+		assert.Empty(t, matches[2].Candidates)
+		assert.Equal(t, "jane@example.com", matches[2].ExternalID)
+		assert.Equal(t, "jira", matches[2].Source)
+	})
+
+	t.Run("success - a user outside the organization with the same email is a candidate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		outsider := &user.User{ID: uuid.New(), Username: "ada", Email: strPtr("ada@example.com")}
+		expectMembers(mockOrgMemberRepo, mockUserRepo, orgID, john)
+		mockMatchRepo.EXPECT().GetByExternalID(gomock.Any(), orgID, "trello", "t-1").Return(nil, gorm.ErrRecordNotFound)
+		mockUserRepo.EXPECT().GetByEmail(gomock.Any(), "ada@example.com").Return(outsider, nil)
+		mockMatchRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		matches, err := svc.MatchUsers(ctx, orgID, "trello", []ExternalUser{{ExternalID: "t-1", Email: "ada@example.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, user_match.StatusMatched, matches[0].Status)
+		assert.Equal(t, &outsider.ID, matches[0].UserID)
+	})
+
+	t.Run("success - keeps matches resolved by hand and rescores the rest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		resolvedBy := uuid.New()
+		resolved := &user_match.UserMatch{ID: uuid.New(), Status: user_match.StatusMatched, UserID: &johnny.ID, ResolvedBy: &resolvedBy}
+		stale := &user_match.UserMatch{ID: uuid.New(), Status: user_match.StatusNew}
+		expectMembers(mockOrgMemberRepo, mockUserRepo, orgID, john, johnny)
+		mockMatchRepo.EXPECT().GetByExternalID(gomock.Any(), orgID, "jira", "acc-1").Return(resolved, nil)
+		mockMatchRepo.EXPECT().GetByExternalID(gomock.Any(), orgID, "jira", "acc-2").Return(stale, nil)
+		mockMatchRepo.EXPECT().Update(gomock.Any(), stale).Return(nil)
+
+		matches, err := svc.MatchUsers(ctx, orgID, "jira", []ExternalUser{
+			{ExternalID: "acc-1", DisplayName: "John Smith"},
+			{ExternalID: "acc-2", DisplayName: "Smith, John"},
+		})
+		require.NoError(t, err)
+		assert.Same(t, resolved, matches[0])
+		assert.Equal(t, user_match.StatusPending, matches[1].Status)
+		assert.Equal(t, reorderedNameConfidence, matches[1].Confidence)
+	})
+
+	t.Run("fail - invalid input", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.MatchUsers(ctx, orgID, " ", []ExternalUser{{ExternalID: "a"}})
+		assert.ErrorIs(t, err, ErrSourceRequired)
+
+		_, err = svc.MatchUsers(ctx, orgID, "jira", []ExternalUser{{ExternalID: "a"}, {DisplayName: "No Identity"}})
+		assert.ErrorIs(t, err, ErrIdentityRequired)
+
+		_, err = svc.MatchUsers(ctx, orgID, "jira", make([]ExternalUser, MaxBatchSize+1))
+		assert.ErrorIs(t, err, ErrBatchTooLarge)
+	})
+}
+
+func TestResolveMatch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	orgID := uuid.New()
+	resolvedBy := uuid.New()
+	pending := func(candidates ...uuid.UUID) *user_match.UserMatch {
+		match := &user_match.UserMatch{ID: uuid.New(), OrganizationID: orgID, Status: user_match.StatusPending, Confidence: 0.9}
+		for _, id := range candidates {
+			match.Candidates = append(match.Candidates, user_match.Candidate{UserID: id, Confidence: 0.9, Reason: user_match.ReasonName})
+		}
+		return match
+	}
+
+	t.Run("success - to a candidate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		candidate := uuid.New()
+		match := pending(candidate)
+		mockMatchRepo.EXPECT().GetByID(gomock.Any(), match.ID).Return(match, nil)
+		mockMatchRepo.EXPECT().Update(gomock.Any(), match).Return(nil)
+
+		resolved, err := svc.ResolveMatch(ctx, match.ID, &candidate, resolvedBy)
+		require.NoError(t, err)
+		assert.Equal(t, user_match.StatusMatched, resolved.Status)
+		assert.Equal(t, &candidate, resolved.UserID)
+		assert.Equal(t, float64(1), resolved.Confidence)
+		assert.Equal(t, &resolvedBy, resolved.ResolvedBy)
+		assert.Equal(t, &now, resolved.ResolvedAt)
+	})
+
+	t.Run("success - to a new user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		match := pending(uuid.New())
+		mockMatchRepo.EXPECT().GetByID(gomock.Any(), match.ID).Return(match, nil)
+		mockMatchRepo.EXPECT().Update(gomock.Any(), match).Return(nil)
+
+		resolved, err := svc.ResolveMatch(ctx, match.ID, nil, resolvedBy)
+		require.NoError(t, err)
+		assert.Equal(t, user_match.StatusNew, resolved.Status)
+		assert.Nil(t, resolved.UserID)
+		assert.Equal(t, float64(0), resolved.Confidence)
+	})
+
+	t.Run("success - to a member who wasn't a candidate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		member := uuid.New()
+		match := pending()
+		mockMatchRepo.EXPECT().GetByID(gomock.Any(), match.ID).Return(match, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, member).Return(&organization_member.OrganizationMember{}, nil)
+		mockMatchRepo.EXPECT().Update(gomock.Any(), match).Return(nil)
+
+		resolved, err := svc.ResolveMatch(ctx, match.ID, &member, resolvedBy)
+		require.NoError(t, err)
+		assert.Equal(t, &member, resolved.UserID)
+	})
+
+	t.Run("fail - user is neither a candidate nor a member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		stranger := uuid.New()
+		match := pending(uuid.New())
+		mockMatchRepo.EXPECT().GetByID(gomock.Any(), match.ID).Return(match, nil)
+		mockOrgMemberRepo.EXPECT().GetByOrgAndUser(gomock.Any(), orgID, stranger).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.ResolveMatch(ctx, match.ID, &stranger, resolvedBy)
+		assert.ErrorIs(t, err, ErrUserNotCandidate)
+	})
+
+	t.Run("fail - not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockMatchRepo := matchMocks.NewMockRepository(ctrl)
+		mockOrgMemberRepo := orgMemberMocks.NewMockRepository(ctrl)
+		mockUserRepo := userMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockMatchRepo, mockOrgMemberRepo, mockUserRepo).(*service)
+		svc.now = func() time.Time { return now }
+
+		id := uuid.New()
+		mockMatchRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.ResolveMatch(ctx, id, nil, resolvedBy)
+		assert.ErrorIs(t, err, ErrMatchNotFound)
+	})
+}
@@ -0,0 +1,152 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	bigQueryBaseURL       = "https://bigquery.googleapis.com/bigquery/v2"
+	bigQueryScope         = "https://www.googleapis.com/auth/bigquery.insertdata"
+	googleTokenURL        = "https://oauth2.googleapis.com/token"
+	serviceAccountKeyType = "service_account"
+)
+
+var ErrInvalidBigQueryConfig = errors.New("bigquery sync needs a project, dataset and service account credentials file")
+
+// serviceAccountKey is the part of a Google service account key file used to sign in
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type bigQuerySink struct {
+	client  *http.Client
+	baseURL string
+	project string
+	dataset string
+}
+
+// NewBigQuerySink streams rows into BigQuery with the tabledata.insertAll API, signed
+// in as the service account in the credentials file
+func NewBigQuerySink(cfg config.WarehouseConfig) (Sink, error) {
+	if cfg.BigQueryProject == "" || cfg.BigQueryDataset == "" || cfg.BigQueryCredentialsFile == "" {
+		return nil, ErrInvalidBigQueryConfig
+	}
+
+	data, err := os.ReadFile(cfg.BigQueryCredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bigquery credentials: %w", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse bigquery credentials: %w", err)
+	}
+	if key.Type != serviceAccountKeyType || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("%w: not a service account key", ErrInvalidBigQueryConfig)
+	}
+	tokenURL := key.TokenURI
+	if tokenURL == "" {
+		tokenURL = googleTokenURL
+	}
+
+	jwtConfig := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{bigQueryScope},
+		TokenURL:     tokenURL,
+	}
+	client := jwtConfig.Client(context.Background())
+	client.Timeout = sinkTimeout
+
+	return newBigQuerySink(client, bigQueryBaseURL, cfg.BigQueryProject, cfg.BigQueryDataset), nil
+}
+
+func newBigQuerySink(client *http.Client, baseURL, project, dataset string) *bigQuerySink {
+	return &bigQuerySink{client: client, baseURL: baseURL, project: project, dataset: dataset}
+}
+
+type bigQueryRow struct {
+	InsertID string                 `json:"insertId,omitempty"`
+	JSON     map[string]interface{} `json:"json"`
+}
+
+type bigQueryInsertRequest struct {
+	Rows []bigQueryRow `json:"rows"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+func (s *bigQuerySink) Insert(ctx context.Context, batch Batch) error {
+	request := bigQueryInsertRequest{Rows: make([]bigQueryRow, len(batch.Rows))}
+	for i, values := range batch.Rows {
+		row := bigQueryRow{JSON: make(map[string]interface{}, len(batch.Columns))}
+		for j, column := range batch.Columns {
+			row.JSON[column] = values[j]
+		}
+		if i < len(batch.RowIDs) {
+			row.InsertID = batch.RowIDs[i]
+		}
+		request.Rows[i] = row
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll",
+		s.baseURL, url.PathEscape(s.project), url.PathEscape(s.dataset), url.PathEscape(batch.Table))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to insert into bigquery: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to insert into bigquery: status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result bigQueryInsertResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to parse bigquery response: %w", err)
+	}
+	if len(result.InsertErrors) > 0 {
+		first := result.InsertErrors[0]
+		message := "unknown error"
+		if len(first.Errors) > 0 {
+			message = first.Errors[0].Reason + ": " + first.Errors[0].Message
+		}
+		return fmt.Errorf("bigquery rejected %d of %d rows, row %d: %s",
+			len(result.InsertErrors), len(batch.Rows), first.Index, message)
+	}
+	return nil
+}
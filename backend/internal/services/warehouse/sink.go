@@ -0,0 +1,59 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+const (
+	ProviderBigQuery  = "bigquery"
+	ProviderSnowflake = "snowflake"
+)
+
+// sinkTimeout bounds one insert request to the warehouse
+const sinkTimeout = 60 * time.Second
+
+var (
+	ErrUnknownProvider    = errors.New("unknown warehouse provider")
+	ErrInvalidTablePrefix = errors.New("warehouse table prefix may only contain letters, digits and underscores")
+)
+
+var tablePrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_]*$`)
+
+// Batch is a set of rows for one warehouse table. Each row holds a value per column,
+// either nil or a string, int or bool.
+type Batch struct {
+	Table   string
+	Columns []string
+	Rows    [][]interface{}
+	// RowIDs identify the rows, so providers that deduplicate can drop a retried insert
+	RowIDs []string
+}
+
+// Sink writes batches to a warehouse
+type Sink interface {
+	Insert(ctx context.Context, batch Batch) error
+}
+
+// NewSink returns the sink for the configured provider, or nil when the sync is off
+func NewSink(cfg config.WarehouseConfig) (Sink, error) {
+	if !tablePrefixPattern.MatchString(cfg.TablePrefix) {
+		return nil, ErrInvalidTablePrefix
+	}
+
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderBigQuery:
+		return NewBigQuerySink(cfg)
+	case ProviderSnowflake:
+		return NewSnowflakeSink(cfg)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
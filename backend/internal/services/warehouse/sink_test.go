@@ -0,0 +1,134 @@
+package warehouse
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+var testBatch = Batch{
+	Table:   "kaimu_cards",
+	Columns: []string{"id", "story_points", "done"},
+	Rows: [][]interface{}{
+		{"card-1", 3, true},
+		{"card-2", nil, false},
+	},
+	RowIDs: []string{"a", "b"},
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink(config.WarehouseConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+
+	_, err = NewSink(config.WarehouseConfig{Provider: "redshift"})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = NewSink(config.WarehouseConfig{Provider: ProviderBigQuery, TablePrefix: "kaimu;"})
+	assert.ErrorIs(t, err, ErrInvalidTablePrefix)
+
+	_, err = NewSink(config.WarehouseConfig{Provider: ProviderSnowflake})
+	assert.ErrorIs(t, err, ErrInvalidSnowflakeConfig)
+}
+
+func TestBigQuerySink(t *testing.T) {
+	t.Run("streams the rows with their insert ids", func(t *testing.T) {
+		var got bigQueryInsertRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/analytics/datasets/kaimu/tables/kaimu_cards/insertAll", r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"kind": "bigquery#tableDataInsertAllResponse"}`))
+		}))
+		defer server.Close()
+
+		sink := newBigQuerySink(server.Client(), server.URL, "analytics", "kaimu")
+		require.NoError(t, sink.Insert(context.Background(), testBatch))
+
+		require.Len(t, got.Rows, 2)
+		assert.Equal(t, "a", got.Rows[0].InsertID)
+		assert.Equal(t, map[string]interface{}{"id": "card-1", "story_points": float64(3), "done": true}, got.Rows[0].JSON)
+		assert.Nil(t, got.Rows[1].JSON["story_points"])
+	})
+
+	t.Run("fail - rows are rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"insertErrors": [{"index": 1, "errors": [{"reason": "invalid", "message": "no such field: done"}]}]}`))
+		}))
+		defer server.Close()
+
+		sink := newBigQuerySink(server.Client(), server.URL, "analytics", "kaimu")
+		err := sink.Insert(context.Background(), testBatch)
+		assert.ErrorContains(t, err, "no such field: done")
+	})
+}
+
+func TestSnowflakeSink(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	cfg := config.WarehouseConfig{
+		SnowflakeAccount:   "myorg-analytics.us-east-1",
+		SnowflakeUser:      "kaimu_sync",
+		SnowflakeDatabase:  "KAIMU",
+		SnowflakeSchema:    "PUBLIC",
+		SnowflakeWarehouse: "LOAD_WH",
+	}
+	now := time.Now()
+
+	t.Run("inserts the rows with text bindings and a key-pair token", func(t *testing.T) {
+		var got snowflakeStatement
+		var token string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v2/statements", r.URL.Path)
+			assert.Equal(t, "KEYPAIR_JWT", r.Header.Get("X-Snowflake-Authorization-Token-Type"))
+			token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		}))
+		defer server.Close()
+
+		sink, err := newSnowflakeSink(server.Client(), server.URL, cfg, key)
+		require.NoError(t, err)
+		sink.now = func() time.Time { return now }
+		require.NoError(t, sink.Insert(context.Background(), testBatch))
+
+		assert.Equal(t, "INSERT INTO kaimu_cards (id, story_points, done) VALUES (?, ?, ?), (?, ?, ?)", got.Statement)
+		assert.Equal(t, "LOAD_WH", got.Warehouse)
+		require.Len(t, got.Bindings, 6)
+		assert.Equal(t, "TEXT", got.Bindings["2"].Type)
+		assert.Equal(t, "3", *got.Bindings["2"].Value)
+		assert.Equal(t, "true", *got.Bindings["3"].Value)
+		assert.Nil(t, got.Bindings["5"].Value)
+
+		var claims jwt.RegisteredClaims
+		_, err = jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) { return &key.PublicKey, nil })
+		require.NoError(t, err)
+		fingerprint, err := publicKeyFingerprint(key)
+		require.NoError(t, err)
+		assert.Equal(t, "MYORG-ANALYTICS.KAIMU_SYNC", claims.Subject)
+		assert.Equal(t, "MYORG-ANALYTICS.KAIMU_SYNC."+fingerprint, claims.Issuer)
+		assert.WithinDuration(t, now.Add(snowflakeTokenLifetime), claims.ExpiresAt.Time, time.Second)
+	})
+
+	t.Run("fail - snowflake rejects the statement", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code": "002003", "message": "Table 'KAIMU_CARDS' does not exist"}`))
+		}))
+		defer server.Close()
+
+		sink, err := newSnowflakeSink(server.Client(), server.URL, cfg, key)
+		require.NoError(t, err)
+		err = sink.Insert(context.Background(), testBatch)
+		assert.ErrorContains(t, err, "does not exist")
+	})
+}
@@ -0,0 +1,220 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/thatcatdev/kaimu/backend/config"
+)
+
+// snowflakeTokenLifetime is how long a key-pair JWT is valid; Snowflake allows at most an hour
+const snowflakeTokenLifetime = 59 * time.Minute
+
+var (
+	ErrInvalidSnowflakeConfig = errors.New("snowflake sync needs an account, user, private key file, database and warehouse")
+	ErrInvalidPrivateKey      = errors.New("snowflake private key must be an RSA key in PEM form")
+)
+
+type snowflakeSink struct {
+	client    *http.Client
+	baseURL   string
+	account   string
+	user      string
+	key       *rsa.PrivateKey
+	keyID     string
+	database  string
+	schema    string
+	warehouse string
+	now       func() time.Time
+}
+
+// NewSnowflakeSink inserts rows into Snowflake through the SQL API, signed in with the
+// user's key pair
+func NewSnowflakeSink(cfg config.WarehouseConfig) (Sink, error) {
+	if cfg.SnowflakeAccount == "" || cfg.SnowflakeUser == "" || cfg.SnowflakePrivateKeyFile == "" ||
+		cfg.SnowflakeDatabase == "" || cfg.SnowflakeWarehouse == "" {
+		return nil, ErrInvalidSnowflakeConfig
+	}
+
+	data, err := os.ReadFile(cfg.SnowflakePrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snowflake private key: %w", err)
+	}
+	key, err := parseRSAPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := "https://" + strings.ToLower(cfg.SnowflakeAccount) + ".snowflakecomputing.com"
+	return newSnowflakeSink(&http.Client{Timeout: sinkTimeout}, baseURL, cfg, key)
+}
+
+func newSnowflakeSink(client *http.Client, baseURL string, cfg config.WarehouseConfig, key *rsa.PrivateKey) (*snowflakeSink, error) {
+	keyID, err := publicKeyFingerprint(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// The JWT names the account without any region or cloud suffix
+	account, _, _ := strings.Cut(cfg.SnowflakeAccount, ".")
+	return &snowflakeSink{
+		client:    client,
+		baseURL:   baseURL,
+		account:   strings.ToUpper(account),
+		user:      strings.ToUpper(cfg.SnowflakeUser),
+		key:       key,
+		keyID:     keyID,
+		database:  cfg.SnowflakeDatabase,
+		schema:    cfg.SnowflakeSchema,
+		warehouse: cfg.SnowflakeWarehouse,
+		now:       time.Now,
+	}, nil
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+	return key, nil
+}
+
+// publicKeyFingerprint is the SHA-256 fingerprint Snowflake shows for a user's public key
+func publicKeyFingerprint(key *rsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+// token signs a key-pair JWT for the user
+func (s *snowflakeSink) token() (string, error) {
+	qualifiedUser := s.account + "." + s.user
+	now := s.now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    qualifiedUser + "." + s.keyID,
+		Subject:   qualifiedUser,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(snowflakeTokenLifetime)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.key)
+}
+
+type snowflakeBinding struct {
+	Type  string  `json:"type"`
+	Value *string `json:"value"`
+}
+
+type snowflakeStatement struct {
+	Statement string                      `json:"statement"`
+	Bindings  map[string]snowflakeBinding `json:"bindings,omitempty"`
+	Database  string                      `json:"database"`
+	Schema    string                      `json:"schema"`
+	Warehouse string                      `json:"warehouse"`
+	Timeout   int                         `json:"timeout"`
+}
+
+type snowflakeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (s *snowflakeSink) Insert(ctx context.Context, batch Batch) error {
+	if len(batch.Rows) == 0 {
+		return nil
+	}
+
+	// Every value is bound as text; Snowflake casts it to the column's type
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "INSERT INTO %s (%s) VALUES ", batch.Table, strings.Join(batch.Columns, ", "))
+	bindings := make(map[string]snowflakeBinding, len(batch.Rows)*len(batch.Columns))
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(batch.Columns)), ", ")
+	for i, values := range batch.Rows {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString("(" + placeholders + ")")
+		for j, value := range values {
+			bindings[strconv.Itoa(i*len(batch.Columns)+j+1)] = snowflakeBinding{Type: "TEXT", Value: bindingValue(value)}
+		}
+	}
+
+	body, err := json.Marshal(snowflakeStatement{
+		Statement: sql.String(),
+		Bindings:  bindings,
+		Database:  s.database,
+		Schema:    s.schema,
+		Warehouse: s.warehouse,
+		Timeout:   int(sinkTimeout / time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	token, err := s.token()
+	if err != nil {
+		return fmt.Errorf("failed to sign snowflake token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/v2/statements", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", "KEYPAIR_JWT")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to insert into snowflake: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 202 means the statement is still running; it was accepted and completes on its own
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusAccepted {
+		return nil
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	var sfErr snowflakeError
+	if json.Unmarshal(respBody, &sfErr) == nil && sfErr.Message != "" {
+		return fmt.Errorf("failed to insert into snowflake: status %d: %s (%s)", resp.StatusCode, sfErr.Message, sfErr.Code)
+	}
+	return fmt.Errorf("failed to insert into snowflake: status %d", resp.StatusCode)
+}
+
+func bindingValue(value interface{}) *string {
+	if value == nil {
+		return nil
+	}
+	text := fmt.Sprint(value)
+	return &text
+}
@@ -0,0 +1,289 @@
+package warehouse
+
+//go:generate mockgen -source=warehouse_service.go -destination=mocks/warehouse_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/warehouse_sync"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// The streams synced, each to the table of the same name after the table prefix
+const (
+	StreamCards      = "cards"
+	StreamSprints    = "sprints"
+	StreamAuditDaily = "audit_daily"
+)
+
+// DefaultBatchSize is how many rows go in one insert when none is configured
+const DefaultBatchSize = 500
+
+var (
+	cardColumns = []string{
+		"id", "board_id", "project_id", "organization_id", "column_id", "column_name", "done",
+		"priority", "story_points", "original_story_points", "assignee_id", "epic_id",
+		"due_date", "started_at", "created_at", "updated_at", "synced_at",
+	}
+	sprintColumns = []string{
+		"id", "board_id", "project_id", "organization_id", "name", "status", "start_date",
+		"end_date", "committed_cards", "committed_points", "completed_cards", "completed_points",
+		"created_at", "updated_at", "synced_at",
+	}
+	auditDailyColumns = []string{
+		"day", "organization_id", "project_id", "action", "entity_type", "event_count", "synced_at",
+	}
+)
+
+type Service interface {
+	// Sync sends what changed since the last sync to the warehouse and returns how many
+	// rows were sent. Cards and sprints are sent again each time they change, so their
+	// tables are append-only and the latest row per id is current. Audit events are sent
+	// as daily counts once each UTC day is over.
+	Sync(ctx context.Context) (int, error)
+}
+
+type service struct {
+	syncRepo    warehouse_sync.Repository
+	sink        Sink
+	tablePrefix string
+	batchSize   int
+	now         func() time.Time
+}
+
+func NewService(syncRepo warehouse_sync.Repository, sink Sink, tablePrefix string, batchSize int) Service {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &service{
+		syncRepo:    syncRepo,
+		sink:        sink,
+		tablePrefix: tablePrefix,
+		batchSize:   batchSize,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "warehouse.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "warehouse"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) Sync(ctx context.Context) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "Sync")
+	defer span.End()
+
+	streams := []struct {
+		name string
+		sync func(ctx context.Context, syncedAt time.Time) (int, error)
+	}{
+		{StreamCards, s.syncCards},
+		{StreamSprints, s.syncSprints},
+		{StreamAuditDaily, s.syncAuditDaily},
+	}
+
+	// A failing stream keeps its cursor and is retried next time, without holding up the others
+	syncedAt := s.now().UTC()
+	total := 0
+	var errs []error
+	for _, stream := range streams {
+		sent, err := stream.sync(ctx, syncedAt)
+		total += sent
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", stream.name, err))
+		}
+	}
+	span.SetAttributes(attribute.Int("warehouse.rows", total))
+	return total, errors.Join(errs...)
+}
+
+// getCursor returns the stream's cursor, starting from the beginning before its first sync
+func (s *service) getCursor(ctx context.Context, stream string) (*warehouse_sync.Cursor, error) {
+	cursor, err := s.syncRepo.GetCursor(ctx, stream)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &warehouse_sync.Cursor{Stream: stream, CursorAt: time.Unix(0, 0).UTC()}, nil
+	}
+	return cursor, err
+}
+
+// syncRows pages through a stream by its (updated_at, id) cursor, saving the cursor
+// after each page the warehouse accepts
+func (s *service) syncRows(ctx context.Context, stream string, columns []string,
+	fetch func(after time.Time, afterID uuid.UUID) ([][]interface{}, []rowKey, error)) (int, error) {
+	cursor, err := s.getCursor(ctx, stream)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for {
+		afterID := uuid.Nil
+		if cursor.CursorID != nil {
+			afterID = *cursor.CursorID
+		}
+		rows, keys, err := fetch(cursor.CursorAt, afterID)
+		if err != nil {
+			return sent, err
+		}
+		if len(rows) == 0 {
+			return sent, nil
+		}
+
+		rowIDs := make([]string, len(keys))
+		for i, key := range keys {
+			rowIDs[i] = key.id.String() + "@" + key.updatedAt.UTC().Format(time.RFC3339Nano)
+		}
+		batch := Batch{Table: s.tablePrefix + stream, Columns: columns, Rows: rows, RowIDs: rowIDs}
+		if err := s.sink.Insert(ctx, batch); err != nil {
+			return sent, err
+		}
+		sent += len(rows)
+
+		last := keys[len(keys)-1]
+		cursor.CursorAt = last.updatedAt
+		cursor.CursorID = &last.id
+		if err := s.syncRepo.SaveCursor(ctx, cursor); err != nil {
+			return sent, err
+		}
+		if len(rows) < s.batchSize {
+			return sent, nil
+		}
+	}
+}
+
+// rowKey is the cursor position of a synced row
+type rowKey struct {
+	id        uuid.UUID
+	updatedAt time.Time
+}
+
+func (s *service) syncCards(ctx context.Context, syncedAt time.Time) (int, error) {
+	return s.syncRows(ctx, StreamCards, cardColumns, func(after time.Time, afterID uuid.UUID) ([][]interface{}, []rowKey, error) {
+		cards, err := s.syncRepo.GetCardsAfter(ctx, after, afterID, s.batchSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows := make([][]interface{}, len(cards))
+		keys := make([]rowKey, len(cards))
+		for i, c := range cards {
+			rows[i] = []interface{}{
+				c.ID.String(), c.BoardID.String(), c.ProjectID.String(), c.OrganizationID.String(),
+				c.ColumnID.String(), c.ColumnName, c.Done, c.Priority, intValue(c.StoryPoints),
+				intValue(c.OriginalStoryPoints), uuidValue(c.AssigneeID), uuidValue(c.EpicID),
+				timeValue(c.DueDate), timeValue(c.StartedAt), formatTime(c.CreatedAt),
+				formatTime(c.UpdatedAt), formatTime(syncedAt),
+			}
+			keys[i] = rowKey{id: c.ID, updatedAt: c.UpdatedAt}
+		}
+		return rows, keys, nil
+	})
+}
+
+func (s *service) syncSprints(ctx context.Context, syncedAt time.Time) (int, error) {
+	return s.syncRows(ctx, StreamSprints, sprintColumns, func(after time.Time, afterID uuid.UUID) ([][]interface{}, []rowKey, error) {
+		sprints, err := s.syncRepo.GetSprintsAfter(ctx, after, afterID, s.batchSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows := make([][]interface{}, len(sprints))
+		keys := make([]rowKey, len(sprints))
+		for i, sp := range sprints {
+			rows[i] = []interface{}{
+				sp.ID.String(), sp.BoardID.String(), sp.ProjectID.String(), sp.OrganizationID.String(),
+				sp.Name, sp.Status, timeValue(sp.StartDate), timeValue(sp.EndDate),
+				sp.CommittedCards, sp.CommittedPoints, sp.CompletedCards, sp.CompletedPoints,
+				formatTime(sp.CreatedAt), formatTime(sp.UpdatedAt), formatTime(syncedAt),
+			}
+			keys[i] = rowKey{id: sp.ID, updatedAt: sp.UpdatedAt}
+		}
+		return rows, keys, nil
+	})
+}
+
+// syncAuditDaily sends the counts of the days completed since the last sync. The
+// cursor moves to the end of the last day sent.
+func (s *service) syncAuditDaily(ctx context.Context, syncedAt time.Time) (int, error) {
+	cursor, err := s.getCursor(ctx, StreamAuditDaily)
+	if err != nil {
+		return 0, err
+	}
+	today := syncedAt.Truncate(24 * time.Hour)
+	if !cursor.CursorAt.Before(today) {
+		return 0, nil
+	}
+
+	counts, err := s.syncRepo.GetAuditDaily(ctx, cursor.CursorAt, today)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for start := 0; start < len(counts); start += s.batchSize {
+		page := counts[start:min(start+s.batchSize, len(counts))]
+		batch := Batch{
+			Table:   s.tablePrefix + StreamAuditDaily,
+			Columns: auditDailyColumns,
+			Rows:    make([][]interface{}, len(page)),
+			RowIDs:  make([]string, len(page)),
+		}
+		for i, c := range page {
+			day := c.Day.UTC().Format(time.DateOnly)
+			organizationID, projectID := uuidValue(c.OrganizationID), uuidValue(c.ProjectID)
+			batch.Rows[i] = []interface{}{
+				day, organizationID, projectID, c.Action, c.EntityType, c.EventCount, formatTime(syncedAt),
+			}
+			batch.RowIDs[i] = fmt.Sprintf("%s/%v/%v/%s/%s", day, organizationID, projectID, c.Action, c.EntityType)
+		}
+		if err := s.sink.Insert(ctx, batch); err != nil {
+			return sent, err
+		}
+		sent += len(page)
+	}
+
+	cursor.CursorAt = today
+	cursor.CursorID = nil
+	if err := s.syncRepo.SaveCursor(ctx, cursor); err != nil {
+		return sent, err
+	}
+	return sent, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
+
+func intValue(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+func uuidValue(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
@@ -0,0 +1,161 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/warehouse_sync"
+	syncMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/warehouse_sync/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type fakeSink struct {
+	batches []Batch
+	err     error
+}
+
+func (f *fakeSink) Insert(ctx context.Context, batch Batch) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.batches = append(f.batches, batch)
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	start := time.Unix(0, 0).UTC()
+
+	cardRow := func(updatedAt time.Time) *warehouse_sync.CardRow {
+		points := 3
+		return &warehouse_sync.CardRow{
+			ID:          uuid.New(),
+			BoardID:     uuid.New(),
+			ColumnName:  "Done",
+			Done:        true,
+			Priority:    "high",
+			StoryPoints: &points,
+			CreatedAt:   updatedAt.Add(-time.Hour),
+			UpdatedAt:   updatedAt,
+		}
+	}
+
+	t.Run("success - pages cards by cursor and sends completed audit days", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSyncRepo := syncMocks.NewMockRepository(ctrl)
+		sink := &fakeSink{}
+
+		svc := NewService(mockSyncRepo, sink, "kaimu_", 2).(*service)
+		svc.now = func() time.Time { return now }
+
+		first := []*warehouse_sync.CardRow{cardRow(now.Add(-3 * time.Hour)), cardRow(now.Add(-2 * time.Hour))}
+		second := []*warehouse_sync.CardRow{cardRow(now.Add(-time.Hour))}
+
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamCards).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetCardsAfter(gomock.Any(), start, uuid.Nil, 2).Return(first, nil)
+		mockSyncRepo.EXPECT().SaveCursor(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *warehouse_sync.Cursor) error {
+			assert.Equal(t, StreamCards, c.Stream)
+			assert.Equal(t, first[1].UpdatedAt, c.CursorAt)
+			assert.Equal(t, first[1].ID, *c.CursorID)
+			return nil
+		})
+		mockSyncRepo.EXPECT().GetCardsAfter(gomock.Any(), first[1].UpdatedAt, first[1].ID, 2).Return(second, nil)
+		mockSyncRepo.EXPECT().SaveCursor(gomock.Any(), gomock.Any()).Return(nil)
+
+		sprintCursor := &warehouse_sync.Cursor{Stream: StreamSprints, CursorAt: now.Add(-time.Hour)}
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamSprints).Return(sprintCursor, nil)
+		mockSyncRepo.EXPECT().GetSprintsAfter(gomock.Any(), sprintCursor.CursorAt, uuid.Nil, 2).Return(nil, nil)
+
+		projectID := uuid.New()
+		auditCursor := &warehouse_sync.Cursor{Stream: StreamAuditDaily, CursorAt: today.AddDate(0, 0, -2)}
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamAuditDaily).Return(auditCursor, nil)
+		mockSyncRepo.EXPECT().GetAuditDaily(gomock.Any(), auditCursor.CursorAt, today).Return([]*warehouse_sync.AuditDailyRow{
+			{Day: today.AddDate(0, 0, -2), ProjectID: &projectID, Action: "created", EntityType: "card", EventCount: 4},
+			{Day: today.AddDate(0, 0, -1), Action: "login", EntityType: "user", EventCount: 2},
+		}, nil)
+		mockSyncRepo.EXPECT().SaveCursor(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *warehouse_sync.Cursor) error {
+			assert.Equal(t, today, c.CursorAt)
+			assert.Nil(t, c.CursorID)
+			return nil
+		})
+
+		sent, err := svc.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 5, sent)
+
+		require.Len(t, sink.batches, 3)
+		assert.Equal(t, "kaimu_cards", sink.batches[0].Table)
+		assert.Len(t, sink.batches[0].Rows, 2)
+		assert.Len(t, sink.batches[1].Rows, 1)
+		row := sink.batches[0].Rows[0]
+		require.Len(t, row, len(cardColumns))
+		assert.Equal(t, first[0].ID.String(), row[0])
+		assert.Equal(t, true, row[6])
+		assert.Equal(t, 3, row[8])
+		assert.Nil(t, row[10])
+		assert.Equal(t, "2026-03-10T14:30:00Z", row[len(row)-1])
+
+		audit := sink.batches[2]
+		assert.Equal(t, "kaimu_audit_daily", audit.Table)
+		assert.Equal(t, []interface{}{"2026-03-08", nil, projectID.String(), "created", "card", 4, "2026-03-10T14:30:00Z"}, audit.Rows[0])
+		assert.Len(t, audit.RowIDs, 2)
+	})
+
+	t.Run("success - audit days already synced are skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSyncRepo := syncMocks.NewMockRepository(ctrl)
+		sink := &fakeSink{}
+
+		svc := NewService(mockSyncRepo, sink, "kaimu_", 10).(*service)
+		svc.now = func() time.Time { return now }
+
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamCards).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetCardsAfter(gomock.Any(), start, uuid.Nil, 10).Return(nil, nil)
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamSprints).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetSprintsAfter(gomock.Any(), start, uuid.Nil, 10).Return(nil, nil)
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamAuditDaily).Return(&warehouse_sync.Cursor{Stream: StreamAuditDaily, CursorAt: today}, nil)
+
+		sent, err := svc.Sync(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Empty(t, sink.batches)
+	})
+
+	t.Run("fail - a rejected insert keeps the cursor and the other streams still sync", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSyncRepo := syncMocks.NewMockRepository(ctrl)
+		sink := &fakeSink{}
+
+		svc := NewService(mockSyncRepo, sink, "kaimu_", 10).(*service)
+		svc.now = func() time.Time { return now }
+		sink.err = errors.New("warehouse down")
+
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamCards).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetCardsAfter(gomock.Any(), start, uuid.Nil, 10).Return([]*warehouse_sync.CardRow{cardRow(now)}, nil)
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamSprints).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetSprintsAfter(gomock.Any(), start, uuid.Nil, 10).Return(nil, nil)
+		mockSyncRepo.EXPECT().GetCursor(gomock.Any(), StreamAuditDaily).Return(nil, gorm.ErrRecordNotFound)
+		mockSyncRepo.EXPECT().GetAuditDaily(gomock.Any(), start, today).Return(nil, nil)
+		mockSyncRepo.EXPECT().SaveCursor(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, c *warehouse_sync.Cursor) error {
+			assert.Equal(t, StreamAuditDaily, c.Stream)
+			return nil
+		})
+
+		_, err := svc.Sync(ctx)
+		assert.ErrorContains(t, err, "cards: warehouse down")
+	})
+}
@@ -0,0 +1,47 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+)
+
+// DefaultSyncInterval is how often the worker syncs when no interval is configured
+const DefaultSyncInterval = time.Hour
+
+// Worker runs Service.Sync in the background
+type Worker struct {
+	svc      Service
+	interval time.Duration
+}
+
+func NewWorker(svc Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	return &Worker{svc: svc, interval: interval}
+}
+
+// Run syncs to the warehouse every interval until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := w.svc.Sync(ctx)
+		if err != nil {
+			log.Error().Err(err).Int("rows", sent).Msg("Failed to sync to the warehouse")
+		} else if sent > 0 {
+			log.Info().Int("rows", sent).Msg("Synced rows to the warehouse")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
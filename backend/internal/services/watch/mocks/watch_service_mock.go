@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watch_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=watch_service.go -destination=mocks/watch_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CountColumnWatchers mocks base method.
+func (m *MockService) CountColumnWatchers(ctx context.Context, columnID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountColumnWatchers", ctx, columnID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountColumnWatchers indicates an expected call of CountColumnWatchers.
+func (mr *MockServiceMockRecorder) CountColumnWatchers(ctx, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountColumnWatchers", reflect.TypeOf((*MockService)(nil).CountColumnWatchers), ctx, columnID)
+}
+
+// IsWatchingColumn mocks base method.
+func (m *MockService) IsWatchingColumn(ctx context.Context, userID, columnID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWatchingColumn", ctx, userID, columnID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsWatchingColumn indicates an expected call of IsWatchingColumn.
+func (mr *MockServiceMockRecorder) IsWatchingColumn(ctx, userID, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWatchingColumn", reflect.TypeOf((*MockService)(nil).IsWatchingColumn), ctx, userID, columnID)
+}
+
+// UnwatchColumn mocks base method.
+func (m *MockService) UnwatchColumn(ctx context.Context, userID, columnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwatchColumn", ctx, userID, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnwatchColumn indicates an expected call of UnwatchColumn.
+func (mr *MockServiceMockRecorder) UnwatchColumn(ctx, userID, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwatchColumn", reflect.TypeOf((*MockService)(nil).UnwatchColumn), ctx, userID, columnID)
+}
+
+// WatchColumn mocks base method.
+func (m *MockService) WatchColumn(ctx context.Context, userID, columnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchColumn", ctx, userID, columnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchColumn indicates an expected call of WatchColumn.
+func (mr *MockServiceMockRecorder) WatchColumn(ctx, userID, columnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchColumn", reflect.TypeOf((*MockService)(nil).WatchColumn), ctx, userID, columnID)
+}
@@ -0,0 +1,179 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/internal/i18n"
+	"github.com/thatcatdev/kaimu/backend/internal/services/locale"
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+	"github.com/thatcatdev/kaimu/backend/internal/services/rbac"
+	"gorm.io/gorm"
+)
+
+// watchTemplate is the email template used for column watch notifications
+const watchTemplate = "column_watch.mjml"
+
+// Notifier emails column watchers when a move takes a card into or out of their column.
+// Like notification rules it skips the acting user and users who can no longer view the
+// project, and it follows the project's email routing for card.moved.
+type Notifier struct {
+	watchRepo   column_watch.Repository
+	channelRepo notification_channel.Repository
+	columnRepo  board_column.Repository
+	boardRepo   board.Repository
+	projectRepo project.Repository
+	cardRepo    card.Repository
+	userRepo    user.Repository
+	rbacSvc     rbac.Service
+	mailSvc     mail.MailService
+	localeSvc   locale.Service
+}
+
+func NewNotifier(
+	watchRepo column_watch.Repository,
+	channelRepo notification_channel.Repository,
+	columnRepo board_column.Repository,
+	boardRepo board.Repository,
+	projectRepo project.Repository,
+	cardRepo card.Repository,
+	userRepo user.Repository,
+	rbacSvc rbac.Service,
+	mailSvc mail.MailService,
+	localeSvc locale.Service,
+) *Notifier {
+	return &Notifier{
+		watchRepo:   watchRepo,
+		channelRepo: channelRepo,
+		columnRepo:  columnRepo,
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		cardRepo:    cardRepo,
+		userRepo:    userRepo,
+		rbacSvc:     rbacSvc,
+		mailSvc:     mailSvc,
+		localeSvc:   localeSvc,
+	}
+}
+
+// Subscribe registers the notifier for card moves
+func (n *Notifier) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.CardMoved, n.handleMoved)
+}
+
+func (n *Notifier) handleMoved(ctx context.Context, event events.Event) error {
+	payload, ok := event.Payload.(events.CardMovedPayload)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+	// Reordering within a column neither enters nor leaves it
+	if payload.FromColumnID == payload.ToColumnID {
+		return nil
+	}
+
+	c, err := n.cardRepo.GetByID(ctx, payload.CardID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := n.notify(ctx, event, c, payload.FromColumnID, "notification.card_left_column"); err != nil {
+		return err
+	}
+	return n.notify(ctx, event, c, payload.ToColumnID, "notification.card_entered_column")
+}
+
+// notify emails the column's watchers the message with the given key, once per event
+func (n *Notifier) notify(ctx context.Context, event events.Event, c *card.Card, columnID uuid.UUID, messageKey string) error {
+	watches, err := n.watchRepo.GetByColumnID(ctx, columnID)
+	if err != nil || len(watches) == 0 {
+		return err
+	}
+
+	col, err := n.columnRepo.GetByID(ctx, columnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	b, err := n.boardRepo.GetByID(ctx, col.BoardID)
+	if err != nil {
+		return err
+	}
+	p, err := n.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	setting, err := n.channelRepo.GetEffective(ctx, p.ID, string(events.CardMoved))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if setting != nil && !setting.Email {
+		return nil
+	}
+
+	for _, watch := range watches {
+		if event.ActorID != nil && *event.ActorID == watch.UserID {
+			continue
+		}
+		if err := n.deliver(ctx, event, watch, c, col, p, messageKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver emails one watcher, unless they already got the event or lost access
+func (n *Notifier) deliver(ctx context.Context, event events.Event, watch *column_watch.ColumnWatch, c *card.Card, col *board_column.BoardColumn, p *project.Project, messageKey string) error {
+	delivered, err := n.watchRepo.IsDelivered(ctx, watch.UserID, watch.ColumnID, event.ID)
+	if err != nil || delivered {
+		return err
+	}
+
+	canView, err := n.rbacSvc.HasProjectPermission(ctx, watch.UserID, p.ID, "project:view")
+	if err != nil {
+		return err
+	}
+
+	watcher, err := n.userRepo.GetByID(ctx, watch.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if canView && watcher != nil && watcher.Email != nil {
+		ctx := i18n.WithLocale(ctx, n.localeSvc.ForProject(ctx, watcher, p.ID))
+		message := i18n.Tc(ctx, messageKey, map[string]string{
+			"card":    i18n.Tc(ctx, "notification.card", map[string]string{"title": c.Title}),
+			"column":  col.Name,
+			"project": p.Name,
+		})
+		name := watcher.Username
+		if watcher.DisplayName != nil {
+			name = *watcher.DisplayName
+		}
+		err := n.mailSvc.SendMail(ctx, []string{*watcher.Email}, message, watchTemplate, map[string]string{
+			"name":    name,
+			"column":  col.Name,
+			"message": message,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send column watch email: %w", err)
+		}
+	}
+
+	return n.watchRepo.MarkDelivered(ctx, watch.UserID, watch.ColumnID, event.ID)
+}
@@ -0,0 +1,152 @@
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	cardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
+	watchMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel"
+	channelMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/notification_channel/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	userMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	localeMocks "github.com/thatcatdev/kaimu/backend/internal/services/locale/mocks"
+	rbacMocks "github.com/thatcatdev/kaimu/backend/internal/services/rbac/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestNotifier(t *testing.T) {
+	projectID := uuid.New()
+	boardID := uuid.New()
+	todo := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "To Do"}
+	qa := &board_column.BoardColumn{ID: uuid.New(), BoardID: boardID, Name: "QA"}
+	email := "tester@example.com"
+	tester := &user.User{ID: uuid.New(), Username: "tester", Email: &email}
+	c := &card.Card{ID: uuid.New(), BoardID: boardID, ColumnID: qa.ID, Title: "Rotate keys"}
+	watch := &column_watch.ColumnWatch{UserID: tester.ID, ColumnID: qa.ID}
+
+	type deps struct {
+		watchRepo   *watchMocks.MockRepository
+		channelRepo *channelMocks.MockRepository
+		columnRepo  *columnMocks.MockRepository
+		boardRepo   *boardMocks.MockRepository
+		projectRepo *projectMocks.MockRepository
+		cardRepo    *cardMocks.MockRepository
+		userRepo    *userMocks.MockRepository
+		rbacSvc     *rbacMocks.MockService
+		mailSvc     *fakes.MailService
+		localeSvc   *localeMocks.MockService
+		bus         events.Bus
+	}
+	setup := func(t *testing.T) deps {
+		ctrl := gomock.NewController(t)
+		d := deps{
+			watchRepo:   watchMocks.NewMockRepository(ctrl),
+			channelRepo: channelMocks.NewMockRepository(ctrl),
+			columnRepo:  columnMocks.NewMockRepository(ctrl),
+			boardRepo:   boardMocks.NewMockRepository(ctrl),
+			projectRepo: projectMocks.NewMockRepository(ctrl),
+			cardRepo:    cardMocks.NewMockRepository(ctrl),
+			userRepo:    userMocks.NewMockRepository(ctrl),
+			rbacSvc:     rbacMocks.NewMockService(ctrl),
+			mailSvc:     fakes.NewMailService(),
+			localeSvc:   localeMocks.NewMockService(ctrl),
+			bus:         events.NewSyncBus(),
+		}
+		NewNotifier(d.watchRepo, d.channelRepo, d.columnRepo, d.boardRepo, d.projectRepo, d.cardRepo, d.userRepo, d.rbacSvc, d.mailSvc, d.localeSvc).Subscribe(d.bus)
+		return d
+	}
+	moved := func(ctx context.Context) events.Event {
+		return events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: c.ID, BoardID: boardID, FromColumnID: todo.ID, ToColumnID: qa.ID})
+	}
+	// expectQAWatched expects the lookups that lead up to delivering to the QA watcher
+	expectQAWatched := func(d deps, setting *notification_channel.Setting) {
+		d.cardRepo.EXPECT().GetByID(gomock.Any(), c.ID).Return(c, nil)
+		d.watchRepo.EXPECT().GetByColumnID(gomock.Any(), todo.ID).Return(nil, nil)
+		d.watchRepo.EXPECT().GetByColumnID(gomock.Any(), qa.ID).Return([]*column_watch.ColumnWatch{watch}, nil)
+		d.columnRepo.EXPECT().GetByID(gomock.Any(), qa.ID).Return(qa, nil)
+		d.boardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		d.projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, Name: "Platform"}, nil)
+		if setting == nil {
+			d.channelRepo.EXPECT().GetEffective(gomock.Any(), projectID, string(events.CardMoved)).Return(nil, gorm.ErrRecordNotFound)
+		} else {
+			d.channelRepo.EXPECT().GetEffective(gomock.Any(), projectID, string(events.CardMoved)).Return(setting, nil)
+		}
+	}
+
+	t.Run("emails watchers when a card enters their column", func(t *testing.T) {
+		d := setup(t)
+		ctx := context.Background()
+		event := moved(ctx)
+
+		expectQAWatched(d, nil)
+		d.watchRepo.EXPECT().IsDelivered(gomock.Any(), tester.ID, qa.ID, event.ID).Return(false, nil)
+		d.rbacSvc.EXPECT().HasProjectPermission(gomock.Any(), tester.ID, projectID, "project:view").Return(true, nil)
+		d.userRepo.EXPECT().GetByID(gomock.Any(), tester.ID).Return(tester, nil)
+		d.localeSvc.EXPECT().ForProject(gomock.Any(), tester, projectID).Return("en")
+		d.watchRepo.EXPECT().MarkDelivered(gomock.Any(), tester.ID, qa.ID, event.ID).Return(nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+
+		require.Len(t, d.mailSvc.Sent, 1)
+		assert.Equal(t, []string{email}, d.mailSvc.Sent[0].To)
+		assert.Equal(t, `Card "Rotate keys" entered QA in Platform`, d.mailSvc.Sent[0].Subject)
+		assert.Equal(t, "column_watch.mjml", d.mailSvc.Sent[0].Template)
+		assert.Equal(t, "QA", d.mailSvc.Sent[0].Values["column"])
+	})
+
+	t.Run("skips the watcher's own moves", func(t *testing.T) {
+		d := setup(t)
+		ctx := events.WithActor(context.Background(), tester.ID)
+
+		expectQAWatched(d, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, moved(ctx)))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("follows the project's email routing", func(t *testing.T) {
+		d := setup(t)
+		ctx := context.Background()
+
+		expectQAWatched(d, &notification_channel.Setting{Event: string(events.CardMoved), Email: false})
+
+		require.NoError(t, d.bus.Publish(ctx, moved(ctx)))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("does not notify twice for a redelivered event", func(t *testing.T) {
+		d := setup(t)
+		ctx := context.Background()
+		event := moved(ctx)
+
+		expectQAWatched(d, nil)
+		d.watchRepo.EXPECT().IsDelivered(gomock.Any(), tester.ID, qa.ID, event.ID).Return(true, nil)
+
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+
+	t.Run("ignores reordering within a column", func(t *testing.T) {
+		d := setup(t)
+		ctx := context.Background()
+
+		event := events.New(ctx, events.CardMoved, events.CardMovedPayload{CardID: c.ID, BoardID: boardID, FromColumnID: qa.ID, ToColumnID: qa.ID})
+		require.NoError(t, d.bus.Publish(ctx, event))
+		assert.Empty(t, d.mailSvc.Sent)
+	})
+}
@@ -0,0 +1,100 @@
+package watch
+
+//go:generate mockgen -source=watch_service.go -destination=mocks/watch_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var ErrColumnNotFound = errors.New("column not found")
+
+// Service manages users' column watches. Watchers are emailed by the Notifier when cards
+// enter or leave the column.
+type Service interface {
+	// WatchColumn subscribes the user to the column; watching it again is a no-op
+	WatchColumn(ctx context.Context, userID, columnID uuid.UUID) error
+	UnwatchColumn(ctx context.Context, userID, columnID uuid.UUID) error
+	IsWatchingColumn(ctx context.Context, userID, columnID uuid.UUID) (bool, error)
+	CountColumnWatchers(ctx context.Context, columnID uuid.UUID) (int, error)
+}
+
+type service struct {
+	watchRepo  column_watch.Repository
+	columnRepo board_column.Repository
+}
+
+func NewService(watchRepo column_watch.Repository, columnRepo board_column.Repository) Service {
+	return &service{
+		watchRepo:  watchRepo,
+		columnRepo: columnRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "watch.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "watch"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) WatchColumn(ctx context.Context, userID, columnID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "WatchColumn")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("column.id", columnID.String()),
+	)
+	defer span.End()
+
+	if _, err := s.columnRepo.GetByID(ctx, columnID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrColumnNotFound
+		}
+		return err
+	}
+	return s.watchRepo.Create(ctx, &column_watch.ColumnWatch{UserID: userID, ColumnID: columnID})
+}
+
+func (s *service) UnwatchColumn(ctx context.Context, userID, columnID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "UnwatchColumn")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("column.id", columnID.String()),
+	)
+	defer span.End()
+
+	return s.watchRepo.Delete(ctx, userID, columnID)
+}
+
+func (s *service) IsWatchingColumn(ctx context.Context, userID, columnID uuid.UUID) (bool, error) {
+	ctx, span := s.startServiceSpan(ctx, "IsWatchingColumn")
+	span.SetAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("column.id", columnID.String()),
+	)
+	defer span.End()
+
+	return s.watchRepo.Exists(ctx, userID, columnID)
+}
+
+func (s *service) CountColumnWatchers(ctx context.Context, columnID uuid.UUID) (int, error) {
+	ctx, span := s.startServiceSpan(ctx, "CountColumnWatchers")
+	span.SetAttributes(attribute.String("column.id", columnID.String()))
+	defer span.End()
+
+	return s.watchRepo.CountByColumnID(ctx, columnID)
+}
@@ -0,0 +1,42 @@
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch"
+	watchMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_watch/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestWatchColumn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWatchRepo := watchMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+	svc := NewService(mockWatchRepo, mockColumnRepo)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	columnID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(&board_column.BoardColumn{ID: columnID}, nil)
+		mockWatchRepo.EXPECT().Create(gomock.Any(), &column_watch.ColumnWatch{UserID: userID, ColumnID: columnID}).Return(nil)
+
+		require.NoError(t, svc.WatchColumn(ctx, userID, columnID))
+	})
+
+	t.Run("fail - column not found", func(t *testing.T) {
+		mockColumnRepo.EXPECT().GetByID(gomock.Any(), columnID).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.ErrorIs(t, svc.WatchColumn(ctx, userID, columnID), ErrColumnNotFound)
+	})
+}
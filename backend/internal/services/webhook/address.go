@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// ErrForbiddenAddress is returned when a webhook would be sent to an address that isn't
+// public, such as loopback, a private network or a cloud metadata endpoint
+var ErrForbiddenAddress = errors.New("webhooks can only be sent to public addresses")
+
+// nonPublicPrefixes are ranges net/netip doesn't classify but that are not the public internet
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach private IPv4 addresses
+}
+
+// publicAddress reports whether addr is on the public internet
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialControl refuses connections to addresses allow rejects. It runs after DNS resolution,
+// for every address dialed, so a host resolving differently between checks can't slip past.
+func dialControl(allow func(netip.Addr) bool) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, _ syscall.RawConn) error {
+		addrPort, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+		}
+		if !allow(addrPort.Addr()) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+		}
+		return nil
+	}
+}
+
+// literalPrivateHost reports whether a URL host is an address or name that can only be
+// private, to refuse it when the webhook is saved. Other hosts are checked when dialed.
+func literalPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return !publicAddress(addr)
+	}
+	return false
+}
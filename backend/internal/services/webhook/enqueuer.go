@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"gorm.io/gorm"
+)
+
+// Body is the JSON body posted to webhooks. ID is the event ID, the same for every webhook
+// and every attempt, so receivers can drop duplicates.
+type Body struct {
+	ID             uuid.UUID  `json:"id"`
+	Event          string     `json:"event"`
+	OccurredAt     time.Time  `json:"occurred_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	ActorID        *uuid.UUID `json:"actor_id,omitempty"`
+	// Data is the event's payload
+	Data any `json:"data"`
+}
+
+// Enqueuer queues a delivery of each supported event to every enabled webhook of the
+// event's organization that subscribes to it. Sending is left to the Sender, so a slow
+// endpoint never holds up event handling.
+type Enqueuer struct {
+	webhookRepo webhookrepo.Repository
+	boardRepo   board.Repository
+	projectRepo project.Repository
+	// notify wakes the sender, may be nil
+	notify func()
+}
+
+func NewEnqueuer(webhookRepo webhookrepo.Repository, boardRepo board.Repository, projectRepo project.Repository, notify func()) *Enqueuer {
+	return &Enqueuer{
+		webhookRepo: webhookRepo,
+		boardRepo:   boardRepo,
+		projectRepo: projectRepo,
+		notify:      notify,
+	}
+}
+
+// Subscribe registers the handler for every supported event
+func (e *Enqueuer) Subscribe(bus events.Bus) {
+	for name := range SupportedEvents {
+		bus.Subscribe(name, e.handleEvent)
+	}
+}
+
+// handleEvent queues the deliveries; a redelivered event finds them already queued
+func (e *Enqueuer) handleEvent(ctx context.Context, event events.Event) error {
+	orgID, err := e.organizationOf(ctx, event)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	webhooks, err := e.webhookRepo.GetEnabledByOrgAndEvent(ctx, orgID, string(event.Name))
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(Body{
+		ID:             event.ID,
+		Event:          string(event.Name),
+		OccurredAt:     event.OccurredAt.UTC(),
+		OrganizationID: orgID,
+		ActorID:        event.ActorID,
+		Data:           event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		err := e.webhookRepo.CreateDelivery(ctx, &webhookrepo.Delivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			EventName:     string(event.Name),
+			Body:          body,
+			Status:        webhookrepo.DeliveryPending,
+			NextAttemptAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if e.notify != nil {
+		e.notify()
+	}
+	return nil
+}
+
+// organizationOf finds the organization an event happened in, through the board for card
+// and sprint events
+func (e *Enqueuer) organizationOf(ctx context.Context, event events.Event) (uuid.UUID, error) {
+	var boardID uuid.UUID
+	switch payload := event.Payload.(type) {
+	case events.CardPayload:
+		boardID = payload.BoardID
+	case events.CardMovedPayload:
+		boardID = payload.BoardID
+	case events.SprintCompletedPayload:
+		boardID = payload.BoardID
+	case events.MemberAddedPayload:
+		return payload.OrganizationID, nil
+	case events.MemberInvitedPayload:
+		return payload.OrganizationID, nil
+	default:
+		return uuid.Nil, fmt.Errorf("unexpected payload %T for %s", event.Payload, event.Name)
+	}
+
+	b, err := e.boardRepo.GetByID(ctx, boardID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	p, err := e.projectRepo.GetByID(ctx, b.ProjectID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return p.OrganizationID, nil
+}
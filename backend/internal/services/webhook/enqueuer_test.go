@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project/mocks"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	webhookMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestEnqueuer(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	projectID := uuid.New()
+	boardID := uuid.New()
+	actorID := uuid.New()
+
+	moved := events.Event{
+		ID:         uuid.New(),
+		Name:       events.CardMoved,
+		OccurredAt: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+		ActorID:    &actorID,
+		Payload:    events.CardMovedPayload{CardID: uuid.New(), BoardID: boardID, FromColumnID: uuid.New(), ToColumnID: uuid.New()},
+	}
+
+	t.Run("queues a delivery per subscribed webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		webhookRepo := webhookMocks.NewMockRepository(ctrl)
+		boardRepo := boardMocks.NewMockRepository(ctrl)
+		projectRepo := projectMocks.NewMockRepository(ctrl)
+		notified := 0
+		enqueuer := NewEnqueuer(webhookRepo, boardRepo, projectRepo, func() { notified++ })
+
+		first, second := &webhookrepo.Webhook{ID: uuid.New()}, &webhookrepo.Webhook{ID: uuid.New()}
+		boardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(&board.Board{ID: boardID, ProjectID: projectID}, nil)
+		projectRepo.EXPECT().GetByID(gomock.Any(), projectID).Return(&project.Project{ID: projectID, OrganizationID: orgID}, nil)
+		webhookRepo.EXPECT().GetEnabledByOrgAndEvent(gomock.Any(), orgID, "card.moved").Return([]*webhookrepo.Webhook{first, second}, nil)
+
+		var deliveries []*webhookrepo.Delivery
+		webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, d *webhookrepo.Delivery) error {
+			deliveries = append(deliveries, d)
+			return nil
+		})
+
+		require.NoError(t, enqueuer.handleEvent(ctx, moved))
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, first.ID, deliveries[0].WebhookID)
+		assert.Equal(t, second.ID, deliveries[1].WebhookID)
+		assert.Equal(t, moved.ID, deliveries[0].EventID)
+		assert.Equal(t, webhookrepo.DeliveryPending, deliveries[0].Status)
+		assert.Equal(t, 1, notified)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(deliveries[0].Body, &body))
+		assert.Equal(t, moved.ID.String(), body["id"])
+		assert.Equal(t, "card.moved", body["event"])
+		assert.Equal(t, "2026-03-10T12:00:00Z", body["occurred_at"])
+		assert.Equal(t, orgID.String(), body["organization_id"])
+		assert.Equal(t, actorID.String(), body["actor_id"])
+		assert.Equal(t, boardID.String(), body["data"].(map[string]any)["board_id"])
+	})
+
+	t.Run("member events carry the organization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		webhookRepo := webhookMocks.NewMockRepository(ctrl)
+		enqueuer := NewEnqueuer(webhookRepo, boardMocks.NewMockRepository(ctrl), projectMocks.NewMockRepository(ctrl), nil)
+
+		webhookRepo.EXPECT().GetEnabledByOrgAndEvent(gomock.Any(), orgID, "member.invited").Return(nil, nil)
+
+		err := enqueuer.handleEvent(ctx, events.Event{
+			ID:      uuid.New(),
+			Name:    events.MemberInvited,
+			Payload: events.MemberInvitedPayload{InvitationID: uuid.New(), OrganizationID: orgID, Email: "ana@example.com"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("deleted board", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		boardRepo := boardMocks.NewMockRepository(ctrl)
+		enqueuer := NewEnqueuer(webhookMocks.NewMockRepository(ctrl), boardRepo, projectMocks.NewMockRepository(ctrl), nil)
+
+		boardRepo.EXPECT().GetByID(gomock.Any(), boardID).Return(nil, gorm.ErrRecordNotFound)
+
+		require.NoError(t, enqueuer.handleEvent(ctx, moved))
+	})
+}
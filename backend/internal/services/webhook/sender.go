@@ -0,0 +1,255 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/logger"
+	"gorm.io/gorm"
+)
+
+// Request headers sent with every delivery
+const (
+	HeaderEvent     = "X-Kaimu-Event"
+	HeaderDelivery  = "X-Kaimu-Delivery"
+	HeaderTimestamp = "X-Kaimu-Timestamp"
+	HeaderSignature = "X-Kaimu-Signature"
+)
+
+// Sign returns the signature of a request: the hex HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the webhook's secret, prefixed with "sha256=". Receivers recompute it and
+// should reject old timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type SenderConfig struct {
+	// PollInterval is how often the sender looks for due deliveries when not notified
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease is how long a claimed delivery stays hidden from other senders; it must cover
+	// sending a whole batch
+	Lease time.Duration
+	// Timeout bounds one request
+	Timeout time.Duration
+	// MaxAttempts after which a delivery is marked failed
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry, doubled for each further attempt
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Retention is how long finished deliveries are kept in the log
+	Retention time.Duration
+}
+
+func DefaultSenderConfig() SenderConfig {
+	return SenderConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		Lease:        5 * time.Minute,
+		Timeout:      10 * time.Second,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		Retention:    30 * 24 * time.Hour,
+	}
+}
+
+// cleanupInterval is how often deliveries past their retention are deleted
+const cleanupInterval = time.Hour
+
+// Sender posts queued deliveries to their webhooks, retrying failures with exponential
+// backoff. Responses other than 2xx count as failures, redirects included.
+//
+// Webhook URLs are chosen by organization managers, so the sender only connects to public
+// addresses, checked on every dial, doesn't follow redirects, and logs only the status of
+// responses: otherwise it could be used to read internal services.
+type Sender struct {
+	webhookRepo webhookrepo.Repository
+	client      *http.Client
+	cfg         SenderConfig
+	wake        chan struct{}
+	now         func() time.Time
+	// allowAddress decides which resolved addresses may be connected to
+	allowAddress func(netip.Addr) bool
+}
+
+func NewSender(webhookRepo webhookrepo.Repository, cfg SenderConfig) *Sender {
+	s := &Sender{
+		webhookRepo:  webhookRepo,
+		cfg:          cfg,
+		wake:         make(chan struct{}, 1),
+		now:          time.Now,
+		allowAddress: publicAddress,
+	}
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: dialControl(func(addr netip.Addr) bool { return s.allowAddress(addr) }),
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the webhook, bypassing the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	s.client = &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return s
+}
+
+// Notify wakes the sender early. It never blocks.
+func (s *Sender) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends deliveries until ctx is cancelled
+func (s *Sender) Run(ctx context.Context) {
+	log := logger.FromCtx(ctx)
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		// Keep claiming while full batches come back so a backlog drains quickly
+		for {
+			n, err := s.SendPending(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to send webhook deliveries")
+				break
+			}
+			if n < s.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		case <-cleanup.C:
+			if _, err := s.webhookRepo.DeleteDeliveriesBefore(ctx, s.now().Add(-s.cfg.Retention)); err != nil {
+				log.Error().Err(err).Msg("Failed to clean up webhook deliveries")
+			}
+		}
+	}
+}
+
+// SendPending sends one batch of due deliveries and returns how many were claimed
+func (s *Sender) SendPending(ctx context.Context) (int, error) {
+	claimed, err := s.webhookRepo.ClaimDueDeliveries(ctx, s.cfg.BatchSize, s.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range claimed {
+		if err := s.send(ctx, delivery); err != nil {
+			return len(claimed), err
+		}
+	}
+	return len(claimed), nil
+}
+
+// send attempts one delivery and records the outcome. Only failures to record it are
+// returned.
+func (s *Sender) send(ctx context.Context, delivery *webhookrepo.Delivery) error {
+	result := webhookrepo.AttemptResult{AttemptedAt: s.now()}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil
+	case err != nil:
+		return err
+	case !webhook.Enabled:
+		// Disabled webhooks keep their log but nothing more is sent
+		message := "webhook is disabled"
+		result.Error = &message
+	default:
+		status, err := s.post(ctx, webhook, delivery)
+		if status != 0 {
+			result.ResponseStatus = &status
+		}
+		if err != nil {
+			message := err.Error()
+			result.Error = &message
+			if delivery.Attempts < s.cfg.MaxAttempts {
+				retryAt := result.AttemptedAt.Add(s.backoff(delivery.Attempts))
+				result.RetryAt = &retryAt
+			}
+		}
+	}
+
+	if result.Error != nil {
+		log := logger.FromCtx(ctx)
+		log.Warn().
+			Str("webhook_id", delivery.WebhookID.String()).
+			Str("delivery_id", delivery.ID.String()).
+			Str("event", delivery.EventName).
+			Int("attempts", delivery.Attempts).
+			Str("error", *result.Error).
+			Msg("Webhook delivery failed")
+	}
+	return s.webhookRepo.RecordAttempt(ctx, delivery.ID, result)
+}
+
+// post sends the request and returns the response status, 0 when no response came back.
+// The response body is discarded unread.
+func (s *Sender) post(ctx context.Context, webhook *webhookrepo.Webhook, delivery *webhookrepo.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Kaimu-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventName)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before retrying a delivery that has failed attempts times
+func (s *Sender) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.cfg.MaxBackoff {
+			return s.cfg.MaxBackoff
+		}
+	}
+	return delay
+}
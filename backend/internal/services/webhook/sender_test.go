@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	webhookMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSign(t *testing.T) {
+	// Computed with: printf '1700000000.{"id":1}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t, "sha256=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8", Sign("whsec_test", 1700000000, []byte(`{"id":1}`)))
+}
+
+func TestSender(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultSenderConfig()
+
+	// Test servers listen on loopback, which only these senders may connect to
+	newSender := func(ctrl *gomock.Controller) (*Sender, *webhookMocks.MockRepository) {
+		webhookRepo := webhookMocks.NewMockRepository(ctrl)
+		sender := NewSender(webhookRepo, cfg)
+		sender.now = func() time.Time { return now }
+		sender.allowAddress = func(netip.Addr) bool { return true }
+		return sender, webhookRepo
+	}
+
+	t.Run("posts signed deliveries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sender, webhookRepo := newSender(ctrl)
+
+		var got *http.Request
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			gotBody, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Enabled: true}
+		delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, EventName: "card.created", Body: []byte(`{"id":1}`), Attempts: 1}
+		webhookRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), cfg.BatchSize, cfg.Lease).Return([]*webhookrepo.Delivery{delivery}, nil)
+		webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		status := 200
+		webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, webhookrepo.AttemptResult{
+			AttemptedAt:    now,
+			ResponseStatus: &status,
+		}).Return(nil)
+
+		n, err := sender.SendPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		require.NotNil(t, got)
+		assert.Equal(t, `{"id":1}`, string(gotBody))
+		assert.Equal(t, "card.created", got.Header.Get(HeaderEvent))
+		assert.Equal(t, delivery.ID.String(), got.Header.Get(HeaderDelivery))
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), got.Header.Get(HeaderTimestamp))
+		assert.Equal(t, Sign("whsec_test", now.Unix(), gotBody), got.Header.Get(HeaderSignature))
+	})
+
+	t.Run("retries failed deliveries with backoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sender, webhookRepo := newSender(ctrl)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Enabled: true}
+		delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, Body: []byte(`{}`), Attempts: 3}
+		webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, result webhookrepo.AttemptResult) error {
+			require.NotNil(t, result.Error)
+			assert.Equal(t, "endpoint responded with status 502", *result.Error)
+			assert.Equal(t, 502, *result.ResponseStatus)
+			require.NotNil(t, result.RetryAt)
+			assert.Equal(t, now.Add(4*cfg.BaseBackoff), *result.RetryAt)
+			return nil
+		})
+
+		require.NoError(t, sender.send(ctx, delivery))
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sender, webhookRepo := newSender(ctrl)
+
+		// Nothing listens on a closed server's URL
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Enabled: true}
+		delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, Body: []byte(`{}`), Attempts: cfg.MaxAttempts}
+		webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, result webhookrepo.AttemptResult) error {
+			require.NotNil(t, result.Error)
+			assert.Nil(t, result.ResponseStatus)
+			assert.Nil(t, result.RetryAt)
+			return nil
+		})
+
+		require.NoError(t, sender.send(ctx, delivery))
+	})
+
+	t.Run("refuses private addresses", func(t *testing.T) {
+		var hit bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hit = true
+		}))
+		defer server.Close()
+
+		for _, url := range []string{server.URL, "http://169.254.169.254/latest/meta-data/", "http://[::1]:1/hooks"} {
+			t.Run(url, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+				webhookRepo := webhookMocks.NewMockRepository(ctrl)
+				sender := NewSender(webhookRepo, cfg)
+				sender.now = func() time.Time { return now }
+
+				webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: url, Secret: "whsec_test", Enabled: true}
+				delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, Body: []byte(`{}`), Attempts: 1}
+				webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+				webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, result webhookrepo.AttemptResult) error {
+					require.NotNil(t, result.Error)
+					assert.Contains(t, *result.Error, ErrForbiddenAddress.Error())
+					assert.Nil(t, result.ResponseStatus)
+					return nil
+				})
+
+				require.NoError(t, sender.send(ctx, delivery))
+			})
+		}
+		assert.False(t, hit)
+	})
+
+	t.Run("doesn't follow redirects", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sender, webhookRepo := newSender(ctrl)
+
+		var redirected bool
+		internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected = true
+		}))
+		defer internal.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Enabled: true}
+		delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, Body: []byte(`{}`), Attempts: 1}
+		webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, result webhookrepo.AttemptResult) error {
+			require.NotNil(t, result.Error)
+			assert.Equal(t, "endpoint responded with status 307", *result.Error)
+			assert.Equal(t, http.StatusTemporaryRedirect, *result.ResponseStatus)
+			return nil
+		})
+
+		require.NoError(t, sender.send(ctx, delivery))
+		assert.False(t, redirected)
+	})
+
+	t.Run("fails deliveries of disabled webhooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		sender, webhookRepo := newSender(ctrl)
+
+		webhook := &webhookrepo.Webhook{ID: uuid.New(), URL: "http://127.0.0.1:1", Enabled: false}
+		delivery := &webhookrepo.Delivery{ID: uuid.New(), WebhookID: webhook.ID, Attempts: 1}
+		webhookRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		webhookRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, result webhookrepo.AttemptResult) error {
+			require.NotNil(t, result.Error)
+			assert.Equal(t, "webhook is disabled", *result.Error)
+			assert.Nil(t, result.RetryAt)
+			return nil
+		})
+
+		require.NoError(t, sender.send(ctx, delivery))
+	})
+}
+
+func TestPublicAddress(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::1":   true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"fd00:ec2::254":        false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::ffff:127.0.0.1":     false,
+		"64:ff9b::a00:1":       false,
+		"224.0.0.1":            false,
+		"::ffff:93.184.216.34": true,
+	} {
+		assert.Equal(t, public, publicAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	sender := NewSender(nil, SenderConfig{BaseBackoff: time.Minute, MaxBackoff: 10 * time.Minute})
+	assert.Equal(t, time.Minute, sender.backoff(1))
+	assert.Equal(t, 4*time.Minute, sender.backoff(3))
+	assert.Equal(t, 10*time.Minute, sender.backoff(5))
+}
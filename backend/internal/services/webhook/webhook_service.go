@@ -0,0 +1,344 @@
+package webhook
+
+//go:generate mockgen -source=webhook_service.go -destination=mocks/webhook_service_mock.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxWebhooksPerOrganization caps how many webhooks one organization can register
+	MaxWebhooksPerOrganization = 20
+	// secretPrefix marks webhook secrets, so they are recognisable in configuration
+	secretPrefix = "whsec_"
+
+	maxDescriptionLength = 255
+	maxURLLength         = 2000
+)
+
+// SupportedEvents are the events webhooks can subscribe to
+var SupportedEvents = map[events.Name]bool{
+	events.CardCreated:     true,
+	events.CardUpdated:     true,
+	events.CardMoved:       true,
+	events.CardDeleted:     true,
+	events.CardArchived:    true,
+	events.SprintCompleted: true,
+	events.MemberInvited:   true,
+	events.MemberAdded:     true,
+}
+
+var (
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrDeliveryNotFound   = errors.New("webhook delivery not found")
+	ErrInvalidURL         = errors.New("webhook URL must be an absolute http or https URL")
+	ErrPrivateURL         = errors.New("webhook URL must point to a public address")
+	ErrNoEvents           = errors.New("a webhook must subscribe to at least one event")
+	ErrUnknownEvent       = errors.New("webhooks cannot subscribe to this event")
+	ErrDescriptionTooLong = fmt.Errorf("webhook description is longer than %d characters", maxDescriptionLength)
+	ErrTooManyWebhooks    = fmt.Errorf("an organization can have at most %d webhooks", MaxWebhooksPerOrganization)
+)
+
+// EventNames returns the supported event names, sorted
+func EventNames() []string {
+	names := make([]string, 0, len(SupportedEvents))
+	for name := range SupportedEvents {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CreateInput is a new webhook
+type CreateInput struct {
+	URL         string
+	Description string
+	Events      []string
+	CreatedBy   *uuid.UUID
+}
+
+// UpdateInput changes the non-nil fields of a webhook
+type UpdateInput struct {
+	URL         *string
+	Description *string
+	Events      []string
+	Enabled     *bool
+}
+
+type Service interface {
+	// CreateWebhook registers a webhook with a new secret
+	CreateWebhook(ctx context.Context, orgID uuid.UUID, input CreateInput) (*webhookrepo.Webhook, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, input UpdateInput) (*webhookrepo.Webhook, error)
+	// DeleteWebhook deletes the webhook with its deliveries
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	// RotateSecret replaces the webhook's secret; requests still queued are signed with the
+	// new one
+	RotateSecret(ctx context.Context, id uuid.UUID) (*webhookrepo.Webhook, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (*webhookrepo.Webhook, error)
+	GetWebhooks(ctx context.Context, orgID uuid.UUID) ([]*webhookrepo.Webhook, error)
+	// GetDeliveries returns one page of the webhook's deliveries, newest first, and the total
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*webhookrepo.Delivery, int64, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*webhookrepo.Delivery, error)
+	// Redeliver queues a delivery to be sent again right away, whatever its outcome so far
+	Redeliver(ctx context.Context, id uuid.UUID) (*webhookrepo.Delivery, error)
+}
+
+type service struct {
+	webhookRepo webhookrepo.Repository
+	// notify wakes the sender, may be nil
+	notify func()
+	now    func() time.Time
+}
+
+// NewService creates the webhook service; notify, when not nil, is called when a delivery
+// is queued for redelivery so it goes out without waiting for the sender's next poll
+func NewService(webhookRepo webhookrepo.Repository, notify func()) Service {
+	return &service{
+		webhookRepo: webhookRepo,
+		notify:      notify,
+		now:         time.Now,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "webhook.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "webhook"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) CreateWebhook(ctx context.Context, orgID uuid.UUID, input CreateInput) (*webhookrepo.Webhook, error) {
+	ctx, span := s.startServiceSpan(ctx, "CreateWebhook")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	webhookURL, err := validateURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventNames, err := validateEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+	description := strings.TrimSpace(input.Description)
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return nil, ErrDescriptionTooLong
+	}
+
+	existing, err := s.webhookRepo.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxWebhooksPerOrganization {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook := &webhookrepo.Webhook{
+		OrganizationID: orgID,
+		URL:            webhookURL,
+		Description:    description,
+		Events:         eventNames,
+		Secret:         secret,
+		Enabled:        true,
+		CreatedBy:      input.CreatedBy,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, id uuid.UUID, input UpdateInput) (*webhookrepo.Webhook, error) {
+	ctx, span := s.startServiceSpan(ctx, "UpdateWebhook")
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+	defer span.End()
+
+	webhook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.URL != nil {
+		if webhook.URL, err = validateURL(*input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		description := strings.TrimSpace(*input.Description)
+		if utf8.RuneCountInString(description) > maxDescriptionLength {
+			return nil, ErrDescriptionTooLong
+		}
+		webhook.Description = description
+	}
+	if input.Events != nil {
+		if webhook.Events, err = validateEvents(input.Events); err != nil {
+			return nil, err
+		}
+	}
+	if input.Enabled != nil {
+		webhook.Enabled = *input.Enabled
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "DeleteWebhook")
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+	defer span.End()
+
+	if _, err := s.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+func (s *service) RotateSecret(ctx context.Context, id uuid.UUID) (*webhookrepo.Webhook, error) {
+	ctx, span := s.startServiceSpan(ctx, "RotateSecret")
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+	defer span.End()
+
+	webhook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.Secret, err = generateSecret(); err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *service) GetWebhook(ctx context.Context, id uuid.UUID) (*webhookrepo.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *service) GetWebhooks(ctx context.Context, orgID uuid.UUID) ([]*webhookrepo.Webhook, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetWebhooks")
+	span.SetAttributes(attribute.String("organization.id", orgID.String()))
+	defer span.End()
+
+	return s.webhookRepo.GetByOrgID(ctx, orgID)
+}
+
+func (s *service) GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*webhookrepo.Delivery, int64, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetDeliveries")
+	span.SetAttributes(attribute.String("webhook.id", webhookID.String()))
+	defer span.End()
+
+	return s.webhookRepo.GetDeliveries(ctx, webhookID, limit, offset)
+}
+
+func (s *service) GetDelivery(ctx context.Context, id uuid.UUID) (*webhookrepo.Delivery, error) {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *service) Redeliver(ctx context.Context, id uuid.UUID) (*webhookrepo.Delivery, error) {
+	ctx, span := s.startServiceSpan(ctx, "Redeliver")
+	span.SetAttributes(attribute.String("webhook_delivery.id", id.String()))
+	defer span.End()
+
+	delivery, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if err := s.webhookRepo.Requeue(ctx, id, now); err != nil {
+		return nil, err
+	}
+	delivery.Status = webhookrepo.DeliveryPending
+	delivery.NextAttemptAt = now
+	if s.notify != nil {
+		s.notify()
+	}
+	return delivery, nil
+}
+
+func validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) > maxURLLength {
+		return "", ErrInvalidURL
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidURL
+	}
+	// Names resolving to private addresses are refused by the sender when it dials them
+	if literalPrivateHost(u.Hostname()) {
+		return "", ErrPrivateURL
+	}
+	return raw, nil
+}
+
+// validateEvents checks the names are supported and drops duplicates
+func validateEvents(names []string) (webhookrepo.EventNames, error) {
+	var result webhookrepo.EventNames
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !SupportedEvents[events.Name(name)] {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrNoEvents
+	}
+	return result, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	webhookrepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook"
+	webhookMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/webhook/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockWebhookRepo, nil).(*service)
+
+		mockWebhookRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(nil, nil)
+		mockWebhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		webhook, err := svc.CreateWebhook(ctx, orgID, CreateInput{
+			URL:         " https://example.com/hooks ",
+			Description: "CI",
+			Events:      []string{"card.moved", "card.created", "card.moved"},
+			CreatedBy:   &userID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/hooks", webhook.URL)
+		assert.Equal(t, webhookrepo.EventNames{"card.moved", "card.created"}, webhook.Events)
+		assert.True(t, webhook.Enabled)
+		assert.True(t, strings.HasPrefix(webhook.Secret, secretPrefix))
+		assert.Len(t, webhook.Secret, len(secretPrefix)+48)
+		assert.Equal(t, &userID, webhook.CreatedBy)
+	})
+
+	tests := []struct {
+		name  string
+		input CreateInput
+		err   error
+	}{
+		{"relative URL", CreateInput{URL: "/hooks", Events: []string{"card.moved"}}, ErrInvalidURL},
+		{"other scheme", CreateInput{URL: "ftp://example.com", Events: []string{"card.moved"}}, ErrInvalidURL},
+		{"loopback", CreateInput{URL: "http://localhost:8080/hooks", Events: []string{"card.moved"}}, ErrPrivateURL},
+		{"private network", CreateInput{URL: "http://10.0.0.7/hooks", Events: []string{"card.moved"}}, ErrPrivateURL},
+		{"metadata endpoint", CreateInput{URL: "http://169.254.169.254/latest/meta-data/", Events: []string{"card.moved"}}, ErrPrivateURL},
+		{"IPv6 unique local", CreateInput{URL: "http://[fd00::1]/hooks", Events: []string{"card.moved"}}, ErrPrivateURL},
+		{"no events", CreateInput{URL: "https://example.com"}, ErrNoEvents},
+		{"unknown event", CreateInput{URL: "https://example.com", Events: []string{"board.created"}}, ErrUnknownEvent},
+		{"long description", CreateInput{URL: "https://example.com", Events: []string{"card.moved"}, Description: strings.Repeat("x", 256)}, ErrDescriptionTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewService(webhookMocks.NewMockRepository(ctrl), nil).(*service)
+
+			_, err := svc.CreateWebhook(ctx, orgID, tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("too many webhooks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockWebhookRepo, nil).(*service)
+
+		mockWebhookRepo.EXPECT().GetByOrgID(gomock.Any(), orgID).Return(make([]*webhookrepo.Webhook, MaxWebhooksPerOrganization), nil)
+
+		_, err := svc.CreateWebhook(ctx, orgID, CreateInput{URL: "https://example.com", Events: []string{"card.moved"}})
+		assert.ErrorIs(t, err, ErrTooManyWebhooks)
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("changes the given fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockWebhookRepo, nil).(*service)
+
+		mockWebhookRepo.EXPECT().GetByID(gomock.Any(), id).Return(&webhookrepo.Webhook{
+			ID: id, URL: "https://example.com", Description: "CI", Events: webhookrepo.EventNames{"card.moved"}, Enabled: true,
+		}, nil)
+		mockWebhookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		enabled := false
+		webhook, err := svc.UpdateWebhook(ctx, id, UpdateInput{Events: []string{"sprint.completed"}, Enabled: &enabled})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", webhook.URL)
+		assert.Equal(t, "CI", webhook.Description)
+		assert.Equal(t, webhookrepo.EventNames{"sprint.completed"}, webhook.Events)
+		assert.False(t, webhook.Enabled)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+
+		svc := NewService(mockWebhookRepo, nil).(*service)
+
+		mockWebhookRepo.EXPECT().GetByID(gomock.Any(), id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.UpdateWebhook(ctx, id, UpdateInput{})
+		assert.ErrorIs(t, err, ErrWebhookNotFound)
+	})
+}
+
+func TestRotateSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockWebhookRepo, nil).(*service)
+
+	id := uuid.New()
+	mockWebhookRepo.EXPECT().GetByID(gomock.Any(), id).Return(&webhookrepo.Webhook{ID: id, Secret: "whsec_old"}, nil)
+	mockWebhookRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	webhook, err := svc.RotateSecret(context.Background(), id)
+	require.NoError(t, err)
+	assert.NotEqual(t, "whsec_old", webhook.Secret)
+	assert.True(t, strings.HasPrefix(webhook.Secret, secretPrefix))
+}
+
+func TestRedeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := webhookMocks.NewMockRepository(ctrl)
+	notified := false
+	svc := NewService(mockWebhookRepo, func() { notified = true }).(*service)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	id := uuid.New()
+	mockWebhookRepo.EXPECT().GetDeliveryByID(gomock.Any(), id).Return(&webhookrepo.Delivery{ID: id, Status: webhookrepo.DeliveryFailed}, nil)
+	mockWebhookRepo.EXPECT().Requeue(gomock.Any(), id, now).Return(nil)
+
+	delivery, err := svc.Redeliver(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, webhookrepo.DeliveryPending, delivery.Status)
+	assert.Equal(t, now, delivery.NextAttemptAt)
+	assert.True(t, notified)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workflow_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=workflow_service.go -destination=mocks/workflow_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	column_transition "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	workflow "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckTransition mocks base method.
+func (m *MockService) CheckTransition(ctx context.Context, boardID, fromColumnID, toColumnID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTransition", ctx, boardID, fromColumnID, toColumnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckTransition indicates an expected call of CheckTransition.
+func (mr *MockServiceMockRecorder) CheckTransition(ctx, boardID, fromColumnID, toColumnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTransition", reflect.TypeOf((*MockService)(nil).CheckTransition), ctx, boardID, fromColumnID, toColumnID)
+}
+
+// GetTransitions mocks base method.
+func (m *MockService) GetTransitions(ctx context.Context, boardID uuid.UUID) ([]*column_transition.ColumnTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions", ctx, boardID)
+	ret0, _ := ret[0].([]*column_transition.ColumnTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitions indicates an expected call of GetTransitions.
+func (mr *MockServiceMockRecorder) GetTransitions(ctx, boardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockService)(nil).GetTransitions), ctx, boardID)
+}
+
+// SetTransitions mocks base method.
+func (m *MockService) SetTransitions(ctx context.Context, boardID uuid.UUID, transitions []workflow.Transition) ([]*column_transition.ColumnTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransitions", ctx, boardID, transitions)
+	ret0, _ := ret[0].([]*column_transition.ColumnTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTransitions indicates an expected call of SetTransitions.
+func (mr *MockServiceMockRecorder) SetTransitions(ctx, boardID, transitions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransitions", reflect.TypeOf((*MockService)(nil).SetTransitions), ctx, boardID, transitions)
+}
@@ -0,0 +1,171 @@
+package workflow
+
+//go:generate mockgen -source=workflow_service.go -destination=mocks/workflow_service_mock.go -package=mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	"github.com/thatcatdev/kaimu/backend/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBoardNotFound       = errors.New("board not found")
+	ErrColumnNotOnBoard    = errors.New("column does not belong to the board")
+	ErrSameColumn          = errors.New("a transition must connect two different columns")
+	ErrInvalidTransition   = errors.New("the board workflow does not allow moving cards between these columns")
+	ErrDuplicateTransition = errors.New("transition is listed more than once")
+)
+
+// InvalidTransitionError reports a move the board workflow does not allow. It matches
+// ErrInvalidTransition with errors.Is.
+type InvalidTransitionError struct {
+	FromColumnID uuid.UUID
+	ToColumnID   uuid.UUID
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return ErrInvalidTransition.Error()
+}
+
+func (e *InvalidTransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// Transition is an allowed move from one column to another
+type Transition struct {
+	FromColumnID uuid.UUID
+	ToColumnID   uuid.UUID
+}
+
+type Service interface {
+	GetTransitions(ctx context.Context, boardID uuid.UUID) ([]*column_transition.ColumnTransition, error)
+	// SetTransitions replaces the board's workflow. An empty list lifts all restrictions.
+	SetTransitions(ctx context.Context, boardID uuid.UUID, transitions []Transition) ([]*column_transition.ColumnTransition, error)
+	// CheckTransition returns an *InvalidTransitionError when the board's workflow does not
+	// allow moving a card between the two columns. Boards without a workflow allow every
+	// move, as does reordering within one column.
+	CheckTransition(ctx context.Context, boardID, fromColumnID, toColumnID uuid.UUID) error
+}
+
+type service struct {
+	transitionRepo column_transition.Repository
+	boardRepo      board.Repository
+	columnRepo     board_column.Repository
+}
+
+func NewService(transitionRepo column_transition.Repository, boardRepo board.Repository, columnRepo board_column.Repository) Service {
+	return &service{
+		transitionRepo: transitionRepo,
+		boardRepo:      boardRepo,
+		columnRepo:     columnRepo,
+	}
+}
+
+func (s *service) startServiceSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	tracer := tracing.GetTracer(ctx)
+	return tracer.Start(ctx, "workflow.service."+operationName,
+		trace.WithAttributes(
+			attribute.String("service", "workflow"),
+			attribute.String("type", "service"),
+			attribute.String("method", operationName),
+		),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		tracing.GetEnvironmentAttribute(),
+	)
+}
+
+func (s *service) GetTransitions(ctx context.Context, boardID uuid.UUID) ([]*column_transition.ColumnTransition, error) {
+	ctx, span := s.startServiceSpan(ctx, "GetTransitions")
+	span.SetAttributes(attribute.String("board.id", boardID.String()))
+	defer span.End()
+
+	return s.transitionRepo.GetByBoardID(ctx, boardID)
+}
+
+func (s *service) SetTransitions(ctx context.Context, boardID uuid.UUID, transitions []Transition) ([]*column_transition.ColumnTransition, error) {
+	ctx, span := s.startServiceSpan(ctx, "SetTransitions")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.Int("workflow.transition_count", len(transitions)),
+	)
+	defer span.End()
+
+	if _, err := s.boardRepo.GetByID(ctx, boardID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBoardNotFound
+		}
+		return nil, err
+	}
+
+	columns, err := s.columnRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	onBoard := make(map[uuid.UUID]bool, len(columns))
+	for _, col := range columns {
+		onBoard[col.ID] = true
+	}
+
+	seen := make(map[Transition]bool, len(transitions))
+	rows := make([]*column_transition.ColumnTransition, 0, len(transitions))
+	for _, t := range transitions {
+		if !onBoard[t.FromColumnID] || !onBoard[t.ToColumnID] {
+			return nil, ErrColumnNotOnBoard
+		}
+		if t.FromColumnID == t.ToColumnID {
+			return nil, ErrSameColumn
+		}
+		if seen[t] {
+			return nil, ErrDuplicateTransition
+		}
+		seen[t] = true
+
+		rows = append(rows, &column_transition.ColumnTransition{
+			BoardID:      boardID,
+			FromColumnID: t.FromColumnID,
+			ToColumnID:   t.ToColumnID,
+		})
+	}
+
+	if err := s.transitionRepo.ReplaceForBoard(ctx, boardID, rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (s *service) CheckTransition(ctx context.Context, boardID, fromColumnID, toColumnID uuid.UUID) error {
+	ctx, span := s.startServiceSpan(ctx, "CheckTransition")
+	span.SetAttributes(
+		attribute.String("board.id", boardID.String()),
+		attribute.String("workflow.from_column_id", fromColumnID.String()),
+		attribute.String("workflow.to_column_id", toColumnID.String()),
+	)
+	defer span.End()
+
+	if fromColumnID == toColumnID {
+		return nil
+	}
+
+	transitions, err := s.transitionRepo.GetByBoardID(ctx, boardID)
+	if err != nil {
+		return err
+	}
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	for _, t := range transitions {
+		if t.FromColumnID == fromColumnID && t.ToColumnID == toColumnID {
+			return nil
+		}
+	}
+	return &InvalidTransitionError{FromColumnID: fromColumnID, ToColumnID: toColumnID}
+}
@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	boardMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	columnMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column/mocks"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	transitionMocks "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition/mocks"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestSetTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+	mockBoardRepo := boardMocks.NewMockRepository(ctrl)
+	mockColumnRepo := columnMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockTransitionRepo, mockBoardRepo, mockColumnRepo)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	todoID := uuid.New()
+	doneID := uuid.New()
+
+	expectBoard := func() {
+		mockBoardRepo.EXPECT().
+			GetByID(gomock.Any(), boardID).
+			Return(&board.Board{ID: boardID}, nil)
+		mockColumnRepo.EXPECT().
+			GetByBoardID(gomock.Any(), boardID).
+			Return([]*board_column.BoardColumn{{ID: todoID, BoardID: boardID}, {ID: doneID, BoardID: boardID}}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectBoard()
+		mockTransitionRepo.EXPECT().
+			ReplaceForBoard(gomock.Any(), boardID, gomock.Len(1)).
+			Return(nil)
+
+		result, err := svc.SetTransitions(ctx, boardID, []Transition{{FromColumnID: todoID, ToColumnID: doneID}})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, todoID, result[0].FromColumnID)
+		assert.Equal(t, doneID, result[0].ToColumnID)
+	})
+
+	t.Run("empty list clears the workflow", func(t *testing.T) {
+		expectBoard()
+		mockTransitionRepo.EXPECT().
+			ReplaceForBoard(gomock.Any(), boardID, gomock.Len(0)).
+			Return(nil)
+
+		result, err := svc.SetTransitions(ctx, boardID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		mockBoardRepo.EXPECT().
+			GetByID(gomock.Any(), boardID).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetTransitions(ctx, boardID, nil)
+		assert.ErrorIs(t, err, ErrBoardNotFound)
+	})
+
+	t.Run("column from another board", func(t *testing.T) {
+		expectBoard()
+
+		_, err := svc.SetTransitions(ctx, boardID, []Transition{{FromColumnID: todoID, ToColumnID: uuid.New()}})
+		assert.ErrorIs(t, err, ErrColumnNotOnBoard)
+	})
+
+	t.Run("self transition", func(t *testing.T) {
+		expectBoard()
+
+		_, err := svc.SetTransitions(ctx, boardID, []Transition{{FromColumnID: todoID, ToColumnID: todoID}})
+		assert.ErrorIs(t, err, ErrSameColumn)
+	})
+
+	t.Run("duplicate transition", func(t *testing.T) {
+		expectBoard()
+
+		_, err := svc.SetTransitions(ctx, boardID, []Transition{
+			{FromColumnID: todoID, ToColumnID: doneID},
+			{FromColumnID: todoID, ToColumnID: doneID},
+		})
+		assert.ErrorIs(t, err, ErrDuplicateTransition)
+	})
+}
+
+func TestCheckTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransitionRepo := transitionMocks.NewMockRepository(ctrl)
+
+	svc := NewService(mockTransitionRepo, boardMocks.NewMockRepository(ctrl), columnMocks.NewMockRepository(ctrl))
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	todoID := uuid.New()
+	doingID := uuid.New()
+	doneID := uuid.New()
+
+	rules := []*column_transition.ColumnTransition{
+		{BoardID: boardID, FromColumnID: todoID, ToColumnID: doingID},
+		{BoardID: boardID, FromColumnID: doingID, ToColumnID: doneID},
+	}
+
+	t.Run("no workflow allows every move", func(t *testing.T) {
+		mockTransitionRepo.EXPECT().
+			GetByBoardID(gomock.Any(), boardID).
+			Return(nil, nil)
+
+		assert.NoError(t, svc.CheckTransition(ctx, boardID, todoID, doneID))
+	})
+
+	t.Run("listed transition is allowed", func(t *testing.T) {
+		mockTransitionRepo.EXPECT().
+			GetByBoardID(gomock.Any(), boardID).
+			Return(rules, nil)
+
+		assert.NoError(t, svc.CheckTransition(ctx, boardID, todoID, doingID))
+	})
+
+	t.Run("unlisted transition is rejected", func(t *testing.T) {
+		mockTransitionRepo.EXPECT().
+			GetByBoardID(gomock.Any(), boardID).
+			Return(rules, nil)
+
+		err := svc.CheckTransition(ctx, boardID, todoID, doneID)
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+
+		var invalid *InvalidTransitionError
+		require.True(t, errors.As(err, &invalid))
+		assert.Equal(t, todoID, invalid.FromColumnID)
+		assert.Equal(t, doneID, invalid.ToColumnID)
+	})
+
+	t.Run("reordering within a column is always allowed", func(t *testing.T) {
+		assert.NoError(t, svc.CheckTransition(ctx, boardID, doneID, doneID))
+	})
+}
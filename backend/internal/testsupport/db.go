@@ -0,0 +1,164 @@
+// Package testsupport provides shared helpers for tests that need a real database.
+//
+// The schema is created by running the application migrations, so tests always exercise
+// the same tables, enums and seed data (permissions, system roles) as production.
+// Factories live in testsupport/fixtures and in-memory repositories in testsupport/fakes.
+package testsupport
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/thatcatdev/kaimu/backend/db"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+// preservedTables hold migration seed data and are never wiped by ResetDB
+var preservedTables = map[string]bool{
+	"schema_migrations": true,
+	"permissions":       true,
+}
+
+// partiallyPreservedTables only lose rows that were created by tests
+var partiallyPreservedTables = map[string]string{
+	"roles":            "is_system = false",
+	"role_permissions": "role_id IN (SELECT id FROM roles WHERE is_system = false)",
+}
+
+// DSN builds the test database connection string from TEST_DB_* environment variables
+func DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		getEnv("TEST_DB_HOST", "localhost"),
+		getEnv("TEST_DB_USER", "pulse"),
+		getEnv("TEST_DB_PASSWORD", "mysecretpassword"),
+		DatabaseName(),
+		getEnv("TEST_DB_PORT", "5432"),
+	)
+}
+
+// DatabaseName returns the name of the test database
+func DatabaseName() string {
+	return getEnv("TEST_DB_NAME", "pulse_test")
+}
+
+// NewTestDB connects to the test database, applies all migrations and removes data left
+// behind by previous tests. The test is skipped when the database is unavailable.
+func NewTestDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	testDB, err := gorm.Open(postgres.Open(DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("Skipping test: could not connect to test database: %v", err)
+	}
+
+	sqlDB, err := testDB.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	migrateOnce.Do(func() {
+		migrateErr = db.MigrateUpWithDB(sqlDB, DatabaseName())
+	})
+	if migrateErr != nil {
+		t.Fatalf("failed to migrate test database: %v", migrateErr)
+	}
+
+	ResetDB(t, testDB)
+
+	return testDB
+}
+
+// ResetDB deletes all rows created by tests, keeping the seed data inserted by migrations.
+// Tables are emptied children first so foreign keys never block a delete.
+func ResetDB(t testing.TB, testDB *gorm.DB) {
+	t.Helper()
+
+	tables, err := tablesInDeleteOrder(testDB)
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+
+	for _, table := range tables {
+		if preservedTables[table] {
+			continue
+		}
+		query := fmt.Sprintf("DELETE FROM %q", table)
+		if where, ok := partiallyPreservedTables[table]; ok {
+			query += " WHERE " + where
+		}
+		if err := testDB.Exec(query).Error; err != nil {
+			t.Fatalf("failed to clean table %s: %v", table, err)
+		}
+	}
+}
+
+// tablesInDeleteOrder returns the public tables ordered so that referencing tables come
+// before the tables they reference
+func tablesInDeleteOrder(testDB *gorm.DB) ([]string, error) {
+	var tables []string
+	err := testDB.Raw(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`).Scan(&tables).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []struct {
+		Child  string
+		Parent string
+	}
+	err = testDB.Raw(`
+		SELECT c.conrelid::regclass::text AS child, c.confrelid::regclass::text AS parent
+		FROM pg_constraint c
+		JOIN pg_namespace n ON n.oid = c.connamespace
+		WHERE c.contype = 'f' AND n.nspname = 'public' AND c.conrelid <> c.confrelid
+	`).Scan(&refs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]string)
+	for _, ref := range refs {
+		children[ref.Parent] = append(children[ref.Parent], ref.Child)
+	}
+
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool)
+	var visit func(table string)
+	visit = func(table string) {
+		if visited[table] {
+			return
+		}
+		visited[table] = true
+		for _, child := range children[table] {
+			visit(child)
+		}
+		ordered = append(ordered, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+
+	return ordered, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,161 @@
+package fakes
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+)
+
+var (
+	_ board.Repository        = (*BoardRepository)(nil)
+	_ board_column.Repository = (*BoardColumnRepository)(nil)
+)
+
+// BoardRepository is an in-memory board.Repository
+type BoardRepository struct {
+	boards *table[board.Board]
+}
+
+func NewBoardRepository() *BoardRepository {
+	return &BoardRepository{boards: newTable[board.Board]()}
+}
+
+func (r *BoardRepository) Create(ctx context.Context, b *board.Board) error {
+	ensureID(&b.ID)
+	now := time.Now()
+	b.CreatedAt, b.UpdatedAt = now, now
+	r.boards.put(b.ID, b)
+	return nil
+}
+
+func (r *BoardRepository) GetByID(ctx context.Context, id uuid.UUID) (*board.Board, error) {
+	return r.boards.get(id)
+}
+
+func (r *BoardRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*board.Board, error) {
+	return r.boards.filter(func(b *board.Board) bool { return b.ProjectID == projectID }), nil
+}
+
+func (r *BoardRepository) GetDefaultByProjectID(ctx context.Context, projectID uuid.UUID) (*board.Board, error) {
+	return r.boards.first(func(b *board.Board) bool { return b.ProjectID == projectID && b.IsDefault })
+}
+
+func (r *BoardRepository) GetAll(ctx context.Context) ([]*board.Board, error) {
+	return r.boards.filter(nil), nil
+}
+
+func (r *BoardRepository) GetAutoArchiving(ctx context.Context) ([]*board.Board, error) {
+	return r.boards.filter(func(b *board.Board) bool { return b.AutoArchiveDays != nil }), nil
+}
+
+func (r *BoardRepository) Update(ctx context.Context, b *board.Board) error {
+	b.UpdatedAt = time.Now()
+	r.boards.put(b.ID, b)
+	return nil
+}
+
+func (r *BoardRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.boards.delete(id)
+	return nil
+}
+
+// BoardColumnRepository is an in-memory board_column.Repository
+type BoardColumnRepository struct {
+	columns *table[board_column.BoardColumn]
+	cards   *CardRepository
+}
+
+// NewBoardColumnRepository creates the repository; column stats are computed over cards
+func NewBoardColumnRepository(cards *CardRepository) *BoardColumnRepository {
+	return &BoardColumnRepository{columns: newTable[board_column.BoardColumn](), cards: cards}
+}
+
+func (r *BoardColumnRepository) Create(ctx context.Context, column *board_column.BoardColumn) error {
+	ensureID(&column.ID)
+	now := time.Now()
+	column.CreatedAt, column.UpdatedAt = now, now
+	r.columns.put(column.ID, column)
+	return nil
+}
+
+func (r *BoardColumnRepository) GetByID(ctx context.Context, id uuid.UUID) (*board_column.BoardColumn, error) {
+	return r.columns.get(id)
+}
+
+func (r *BoardColumnRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error) {
+	return sortColumns(r.columns.filter(func(c *board_column.BoardColumn) bool { return c.BoardID == boardID })), nil
+}
+
+func (r *BoardColumnRepository) GetVisibleByBoardID(ctx context.Context, boardID uuid.UUID) ([]*board_column.BoardColumn, error) {
+	return sortColumns(r.columns.filter(func(c *board_column.BoardColumn) bool {
+		return c.BoardID == boardID && !c.IsHidden
+	})), nil
+}
+
+func (r *BoardColumnRepository) GetStatsByBoardID(ctx context.Context, boardID uuid.UUID, now time.Time) ([]*board_column.ColumnStats, error) {
+	columns, _ := r.GetByBoardID(ctx, boardID)
+	stats := make([]*board_column.ColumnStats, len(columns))
+	for i, col := range columns {
+		s := &board_column.ColumnStats{ColumnID: col.ID, WipLimit: col.WipLimit}
+		cards, _ := r.cards.GetByColumnID(ctx, col.ID)
+		var age, inColumn float64
+		for _, c := range cards {
+			s.CardCount++
+			if c.StoryPoints != nil {
+				s.StoryPoints += *c.StoryPoints
+				s.EstimatedCardCount++
+			}
+			age += now.Sub(c.CreatedAt).Seconds()
+			inColumn += now.Sub(c.ColumnEnteredAt).Seconds()
+		}
+		if s.CardCount > 0 {
+			age /= float64(s.CardCount)
+			inColumn /= float64(s.CardCount)
+			s.AverageAgeSeconds, s.AverageTimeInColumnSeconds = &age, &inColumn
+		}
+		stats[i] = s
+	}
+	return stats, nil
+}
+
+func (r *BoardColumnRepository) GetMaxPosition(ctx context.Context, boardID uuid.UUID) (int, error) {
+	maxPos := -1
+	for _, c := range r.columns.filter(func(c *board_column.BoardColumn) bool { return c.BoardID == boardID }) {
+		if c.Position > maxPos {
+			maxPos = c.Position
+		}
+	}
+	return maxPos, nil
+}
+
+func (r *BoardColumnRepository) Update(ctx context.Context, column *board_column.BoardColumn) error {
+	column.UpdatedAt = time.Now()
+	r.columns.put(column.ID, column)
+	return nil
+}
+
+func (r *BoardColumnRepository) UpdatePositions(ctx context.Context, columns []*board_column.BoardColumn) error {
+	for _, col := range columns {
+		stored, err := r.columns.get(col.ID)
+		if err != nil {
+			continue
+		}
+		stored.Position = col.Position
+		r.columns.put(stored.ID, stored)
+	}
+	return nil
+}
+
+func (r *BoardColumnRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.columns.delete(id)
+	return nil
+}
+
+func sortColumns(columns []*board_column.BoardColumn) []*board_column.BoardColumn {
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Position < columns[j].Position })
+	return columns
+}
@@ -0,0 +1,347 @@
+package fakes
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"gorm.io/gorm"
+)
+
+var _ card.Repository = (*CardRepository)(nil)
+
+// CardRepository is an in-memory card.Repository, including the card_sprints relationship.
+// Cards are numbered per project of the boards repository, or per board for boards it lacks.
+type CardRepository struct {
+	cards       *table[card.Card]
+	cardSprints *table[card.CardSprint]
+	boards      *BoardRepository
+	moveMu      sync.Mutex
+	numberMu    sync.Mutex
+	lastNumbers map[uuid.UUID]int
+}
+
+func NewCardRepository(boards *BoardRepository) *CardRepository {
+	return &CardRepository{
+		cards:       newTable[card.Card](),
+		cardSprints: newTable[card.CardSprint](),
+		boards:      boards,
+		lastNumbers: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *CardRepository) Create(ctx context.Context, c *card.Card) error {
+	ensureID(&c.ID)
+	if c.Number == 0 {
+		c.Number, _ = r.ReserveNumbers(ctx, c.BoardID, 1)
+	}
+	if c.Priority == "" {
+		c.Priority = card.PriorityNone
+	}
+	now := time.Now()
+	c.CreatedAt, c.UpdatedAt = now, now
+	if c.ColumnEnteredAt.IsZero() {
+		c.ColumnEnteredAt = now
+	}
+	r.cards.put(c.ID, c)
+	return nil
+}
+
+func (r *CardRepository) ReserveNumbers(ctx context.Context, boardID uuid.UUID, count int) (int, error) {
+	r.numberMu.Lock()
+	defer r.numberMu.Unlock()
+
+	projectID := r.projectOf(boardID)
+	r.lastNumbers[projectID] += count
+	return r.lastNumbers[projectID] - count + 1, nil
+}
+
+// projectOf returns the project of the board, or the board's own ID when it is unknown
+func (r *CardRepository) projectOf(boardID uuid.UUID) uuid.UUID {
+	if r.boards != nil {
+		if b, err := r.boards.GetByID(context.Background(), boardID); err == nil {
+			return b.ProjectID
+		}
+	}
+	return boardID
+}
+
+func (r *CardRepository) GetByID(ctx context.Context, id uuid.UUID) (*card.Card, error) {
+	return r.cards.get(id)
+}
+
+func (r *CardRepository) GetByProjectNumber(ctx context.Context, projectID uuid.UUID, number int) (*card.Card, error) {
+	cards := r.cards.filter(func(c *card.Card) bool { return c.Number == number && r.projectOf(c.BoardID) == projectID })
+	if len(cards) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cards[0], nil
+}
+
+func (r *CardRepository) GetByColumnID(ctx context.Context, columnID uuid.UUID) ([]*card.Card, error) {
+	return sortCardsByPosition(r.cards.filter(func(c *card.Card) bool { return c.ColumnID == columnID && c.ArchivedAt == nil })), nil
+}
+
+func (r *CardRepository) GetByColumnEnteredBefore(ctx context.Context, columnID uuid.UUID, before time.Time) ([]*card.Card, error) {
+	cards := r.cards.filter(func(c *card.Card) bool {
+		return c.ColumnID == columnID && c.ColumnEnteredAt.Before(before) && c.ArchivedAt == nil
+	})
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].ColumnEnteredAt.Before(cards[j].ColumnEnteredAt) })
+	return cards, nil
+}
+
+func (r *CardRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	return sortCardsByPosition(r.cards.filter(func(c *card.Card) bool { return c.BoardID == boardID && c.ArchivedAt == nil })), nil
+}
+
+func (r *CardRepository) GetByAssigneeID(ctx context.Context, assigneeID uuid.UUID) ([]*card.Card, error) {
+	cards := r.cards.filter(func(c *card.Card) bool {
+		return c.AssigneeID != nil && *c.AssigneeID == assigneeID && c.ArchivedAt == nil
+	})
+	// Due date ascending with nulls last, then newest first
+	sort.SliceStable(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		switch {
+		case a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+			return a.DueDate.Before(*b.DueDate)
+		case a.DueDate != nil && b.DueDate == nil:
+			return true
+		case a.DueDate == nil && b.DueDate != nil:
+			return false
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return cards, nil
+}
+
+func (r *CardRepository) GetBySprintID(ctx context.Context, sprintID uuid.UUID) ([]*card.Card, error) {
+	inSprint := make(map[uuid.UUID]bool)
+	for _, cs := range r.cardSprints.filter(func(cs *card.CardSprint) bool { return cs.SprintID == sprintID }) {
+		inSprint[cs.CardID] = true
+	}
+	return sortCardsByPosition(r.cards.filter(func(c *card.Card) bool { return inSprint[c.ID] })), nil
+}
+
+func (r *CardRepository) GetBacklogByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	inAnySprint := make(map[uuid.UUID]bool)
+	for _, cs := range r.cardSprints.filter(nil) {
+		inAnySprint[cs.CardID] = true
+	}
+	return sortCardsByPosition(r.cards.filter(func(c *card.Card) bool {
+		return c.BoardID == boardID && c.ArchivedAt == nil && !inAnySprint[c.ID]
+	})), nil
+}
+
+func (r *CardRepository) GetArchivedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*card.Card, error) {
+	cards := sortCardsByPosition(r.cards.filter(func(c *card.Card) bool { return c.BoardID == boardID && c.ArchivedAt != nil }))
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].ArchivedAt.After(*cards[j].ArchivedAt) })
+	return cards, nil
+}
+
+func (r *CardRepository) Archive(ctx context.Context, ids []uuid.UUID, at time.Time) (int64, error) {
+	var archived int64
+	for _, id := range ids {
+		c, err := r.cards.get(id)
+		if err != nil || c.ArchivedAt != nil {
+			continue
+		}
+		c.ArchivedAt = &at
+		r.cards.put(id, c)
+		archived++
+	}
+	return archived, nil
+}
+
+func (r *CardRepository) Merge(ctx context.Context, ids []uuid.UUID, intoID uuid.UUID, at time.Time) error {
+	for _, id := range ids {
+		c, err := r.cards.get(id)
+		if err != nil {
+			continue
+		}
+		c.MergedIntoID = &intoID
+		if c.ArchivedAt == nil {
+			c.ArchivedAt = &at
+		}
+		r.cards.put(id, c)
+	}
+	return nil
+}
+
+func (r *CardRepository) Unarchive(ctx context.Context, id uuid.UUID, columnEnteredAt time.Time) error {
+	c, err := r.cards.get(id)
+	if err != nil {
+		return nil
+	}
+	c.ArchivedAt = nil
+	c.ColumnEnteredAt = columnEnteredAt
+	r.cards.put(id, c)
+	return nil
+}
+
+func (r *CardRepository) GetAll(ctx context.Context) ([]*card.Card, error) {
+	return r.cards.filter(nil), nil
+}
+
+func (r *CardRepository) GetMaxPosition(ctx context.Context, columnID uuid.UUID) (float64, error) {
+	maxPos := 0.0
+	for _, c := range r.cards.filter(func(c *card.Card) bool { return c.ColumnID == columnID }) {
+		maxPos = math.Max(maxPos, c.Position)
+	}
+	return maxPos, nil
+}
+
+func (r *CardRepository) GetPositionBetween(ctx context.Context, columnID uuid.UUID, afterCardID *uuid.UUID) (float64, error) {
+	cards, _ := r.GetByColumnID(ctx, columnID)
+
+	if afterCardID == nil {
+		if len(cards) == 0 || cards[0].Position >= 1000 {
+			return 500, nil
+		}
+		return cards[0].Position / 2, nil
+	}
+
+	afterCard, err := r.cards.get(*afterCardID)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range cards {
+		if c.Position > afterCard.Position {
+			return (afterCard.Position + c.Position) / 2, nil
+		}
+	}
+	return afterCard.Position + 1000, nil
+}
+
+func (r *CardRepository) GetStoryPointsByColumnID(ctx context.Context, columnID uuid.UUID) (int, error) {
+	points := 0
+	for _, c := range r.cards.filter(func(c *card.Card) bool { return c.ColumnID == columnID && c.ArchivedAt == nil }) {
+		if c.StoryPoints != nil {
+			points += *c.StoryPoints
+		}
+	}
+	return points, nil
+}
+
+// LockColumnStoryPoints sums the column's story points; the fake has no transactions to
+// hold a lock for
+func (r *CardRepository) LockColumnStoryPoints(ctx context.Context, columnID uuid.UUID) (int, error) {
+	return r.GetStoryPointsByColumnID(ctx, columnID)
+}
+
+// MoveCard computes the position and moves the card while holding the move lock,
+// mirroring the column lock taken by the real repository
+func (r *CardRepository) MoveCard(ctx context.Context, cardID, targetColumnID, targetBoardID uuid.UUID, afterCardID *uuid.UUID) (*card.Card, error) {
+	r.moveMu.Lock()
+	defer r.moveMu.Unlock()
+
+	c, err := r.cards.get(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if c.ColumnID != targetColumnID {
+		c.ColumnEnteredAt = time.Now()
+		if c.StartedAt == nil {
+			c.StartedAt = &c.ColumnEnteredAt
+		}
+	}
+
+	// Take the card out of its column so it is never its own neighbour
+	c.ColumnID = uuid.Nil
+	r.cards.put(c.ID, c)
+
+	var position float64
+	if afterCard, err := r.cardAfter(afterCardID); err == nil && afterCard.ColumnID != targetColumnID {
+		// The anchor left the column in a concurrent move; append to the end instead
+		maxPos, _ := r.GetMaxPosition(ctx, targetColumnID)
+		position = maxPos + 1000
+	} else if position, err = r.GetPositionBetween(ctx, targetColumnID, afterCardID); err != nil {
+		return nil, err
+	}
+
+	if r.projectOf(c.BoardID) != r.projectOf(targetBoardID) {
+		c.Number, _ = r.ReserveNumbers(ctx, targetBoardID, 1)
+	}
+	c.ColumnID = targetColumnID
+	c.BoardID = targetBoardID
+	c.Position = position
+	return c, r.Update(ctx, c)
+}
+
+func (r *CardRepository) cardAfter(afterCardID *uuid.UUID) (*card.Card, error) {
+	if afterCardID == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.cards.get(*afterCardID)
+}
+
+func (r *CardRepository) Update(ctx context.Context, c *card.Card) error {
+	c.UpdatedAt = time.Now()
+	r.cards.put(c.ID, c)
+	return nil
+}
+
+func (r *CardRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.cards.delete(id)
+	return r.RemoveCardFromAllSprints(ctx, id)
+}
+
+// cardSprintKey derives a stable table key from the card/sprint pair
+func cardSprintKey(cardID, sprintID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(cardID, sprintID[:])
+}
+
+func (r *CardRepository) AddCardToSprint(ctx context.Context, cardID, sprintID uuid.UUID) error {
+	key := cardSprintKey(cardID, sprintID)
+	if r.cardSprints.exists(key) {
+		return nil
+	}
+	r.cardSprints.put(key, &card.CardSprint{
+		ID:       uuid.New(),
+		CardID:   cardID,
+		SprintID: sprintID,
+		AddedAt:  time.Now(),
+	})
+	return nil
+}
+
+func (r *CardRepository) RemoveCardFromSprint(ctx context.Context, cardID, sprintID uuid.UUID) error {
+	r.cardSprints.delete(cardSprintKey(cardID, sprintID))
+	return nil
+}
+
+func (r *CardRepository) GetSprintIDsForCard(ctx context.Context, cardID uuid.UUID) ([]uuid.UUID, error) {
+	cardSprints := r.cardSprints.filter(func(cs *card.CardSprint) bool { return cs.CardID == cardID })
+	sprintIDs := make([]uuid.UUID, len(cardSprints))
+	for i, cs := range cardSprints {
+		sprintIDs[i] = cs.SprintID
+	}
+	return sprintIDs, nil
+}
+
+func (r *CardRepository) SetCardSprints(ctx context.Context, cardID uuid.UUID, sprintIDs []uuid.UUID) error {
+	if err := r.RemoveCardFromAllSprints(ctx, cardID); err != nil {
+		return err
+	}
+	for _, sprintID := range sprintIDs {
+		if err := r.AddCardToSprint(ctx, cardID, sprintID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CardRepository) RemoveCardFromAllSprints(ctx context.Context, cardID uuid.UUID) error {
+	for _, cs := range r.cardSprints.filter(func(cs *card.CardSprint) bool { return cs.CardID == cardID }) {
+		r.cardSprints.delete(cardSprintKey(cs.CardID, cs.SprintID))
+	}
+	return nil
+}
+
+func sortCardsByPosition(cards []*card.Card) []*card.Card {
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Position < cards[j].Position })
+	return cards
+}
@@ -0,0 +1,36 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+)
+
+var _ column_transition.Repository = (*ColumnTransitionRepository)(nil)
+
+// ColumnTransitionRepository is an in-memory column_transition.Repository
+type ColumnTransitionRepository struct {
+	transitions *table[column_transition.ColumnTransition]
+}
+
+func NewColumnTransitionRepository() *ColumnTransitionRepository {
+	return &ColumnTransitionRepository{transitions: newTable[column_transition.ColumnTransition]()}
+}
+
+func (r *ColumnTransitionRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*column_transition.ColumnTransition, error) {
+	return r.transitions.filter(func(t *column_transition.ColumnTransition) bool { return t.BoardID == boardID }), nil
+}
+
+func (r *ColumnTransitionRepository) ReplaceForBoard(ctx context.Context, boardID uuid.UUID, transitions []*column_transition.ColumnTransition) error {
+	for _, t := range r.transitions.filter(func(t *column_transition.ColumnTransition) bool { return t.BoardID == boardID }) {
+		r.transitions.delete(t.ID)
+	}
+	for _, t := range transitions {
+		ensureID(&t.ID)
+		t.CreatedAt = time.Now()
+		r.transitions.put(t.ID, t)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/thatcatdev/kaimu/backend/internal/services/mail"
+)
+
+var _ mail.MailService = (*MailService)(nil)
+
+// SentMail is one mail a MailService was asked to send
+type SentMail struct {
+	To       []string
+	Subject  string
+	Template string
+	Values   map[string]string
+	// Branding is the branding set on the context the mail was sent with, if any
+	Branding *mail.Branding
+}
+
+// MailService is a mail.MailService that records mail instead of sending it
+type MailService struct {
+	mu   sync.Mutex
+	Sent []SentMail
+}
+
+func NewMailService() *MailService {
+	return &MailService{}
+}
+
+func (s *MailService) SendMail(ctx context.Context, to []string, subject string, template string, values map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Sent = append(s.Sent, SentMail{To: to, Subject: subject, Template: template, Values: values, Branding: mail.BrandingFromContext(ctx)})
+	return nil
+}
@@ -0,0 +1,218 @@
+package fakes
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"gorm.io/gorm"
+)
+
+var (
+	_ organization.Repository        = (*OrganizationRepository)(nil)
+	_ organization_member.Repository = (*OrganizationMemberRepository)(nil)
+)
+
+// OrganizationRepository is an in-memory organization.Repository. Membership lookups are
+// answered from the member repository it was created with.
+type OrganizationRepository struct {
+	orgs    *table[organization.Organization]
+	members *OrganizationMemberRepository
+}
+
+func NewOrganizationRepository(members *OrganizationMemberRepository) *OrganizationRepository {
+	return &OrganizationRepository{
+		orgs:    newTable[organization.Organization](),
+		members: members,
+	}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, org *organization.Organization) error {
+	if _, err := r.GetBySlug(ctx, org.Slug); err == nil {
+		return gorm.ErrDuplicatedKey
+	}
+	ensureID(&org.ID)
+	now := time.Now()
+	org.CreatedAt, org.UpdatedAt = now, now
+	r.orgs.put(org.ID, org)
+	return nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	return r.orgs.get(id)
+}
+
+func (r *OrganizationRepository) GetBySlug(ctx context.Context, slug string) (*organization.Organization, error) {
+	return r.orgs.first(func(o *organization.Organization) bool { return o.Slug == slug })
+}
+
+func (r *OrganizationRepository) GetByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*organization.Organization, error) {
+	return r.orgs.filter(func(o *organization.Organization) bool { return o.OwnerID == ownerID }), nil
+}
+
+func (r *OrganizationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*organization.Organization, error) {
+	memberOf := make(map[uuid.UUID]bool)
+	if r.members != nil {
+		memberships, _ := r.members.GetByUserID(ctx, userID)
+		for _, m := range memberships {
+			memberOf[m.OrganizationID] = true
+		}
+	}
+	return r.orgs.filter(func(o *organization.Organization) bool {
+		return o.OwnerID == userID || memberOf[o.ID]
+	}), nil
+}
+
+func (r *OrganizationRepository) GetAll(ctx context.Context) ([]*organization.Organization, error) {
+	return r.orgs.filter(nil), nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, org *organization.Organization) error {
+	org.UpdatedAt = time.Now()
+	r.orgs.put(org.ID, org)
+	return nil
+}
+
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.orgs.delete(id)
+	return nil
+}
+
+// OrganizationMemberRepository is an in-memory organization_member.Repository
+type OrganizationMemberRepository struct {
+	members *table[organization_member.OrganizationMember]
+	users   *UserRepository
+}
+
+// NewOrganizationMemberRepository creates a repository that reads member names from users,
+// for the directory
+func NewOrganizationMemberRepository(users *UserRepository) *OrganizationMemberRepository {
+	return &OrganizationMemberRepository{members: newTable[organization_member.OrganizationMember](), users: users}
+}
+
+func (r *OrganizationMemberRepository) Create(ctx context.Context, member *organization_member.OrganizationMember) error {
+	if _, err := r.GetByOrgAndUser(ctx, member.OrganizationID, member.UserID); err == nil {
+		return gorm.ErrDuplicatedKey
+	}
+	ensureID(&member.ID)
+	if member.Role == "" {
+		member.Role = "member"
+	}
+	member.CreatedAt = time.Now()
+	r.members.put(member.ID, member)
+	return nil
+}
+
+func (r *OrganizationMemberRepository) GetByID(ctx context.Context, id uuid.UUID) (*organization_member.OrganizationMember, error) {
+	return r.members.get(id)
+}
+
+func (r *OrganizationMemberRepository) GetByOrgAndUser(ctx context.Context, orgID, userID uuid.UUID) (*organization_member.OrganizationMember, error) {
+	return r.members.first(func(m *organization_member.OrganizationMember) bool {
+		return m.OrganizationID == orgID && m.UserID == userID
+	})
+}
+
+func (r *OrganizationMemberRepository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	return r.members.filter(func(m *organization_member.OrganizationMember) bool { return m.OrganizationID == orgID }), nil
+}
+
+func (r *OrganizationMemberRepository) GetMembersByOrgID(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	return r.members.filter(func(m *organization_member.OrganizationMember) bool { return m.OrganizationID == orgID && !m.IsGuest }), nil
+}
+
+func (r *OrganizationMemberRepository) GetGuestsByOrgID(ctx context.Context, orgID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	return r.members.filter(func(m *organization_member.OrganizationMember) bool { return m.OrganizationID == orgID && m.IsGuest }), nil
+}
+
+// GetDirectory pages the members like the real repository. The fakes hold no sessions, so
+// no member was ever active.
+func (r *OrganizationMemberRepository) GetDirectory(ctx context.Context, orgID uuid.UUID, query organization_member.DirectoryQuery) ([]*organization_member.DirectoryEntry, int64, error) {
+	search := strings.ToLower(strings.TrimSpace(query.Search))
+	names := make(map[uuid.UUID]string)
+
+	var entries []*organization_member.DirectoryEntry
+	for _, m := range r.members.filter(func(m *organization_member.OrganizationMember) bool {
+		return m.OrganizationID == orgID && !m.IsGuest && (query.RoleID == nil || (m.RoleID != nil && *m.RoleID == *query.RoleID))
+	}) {
+		u, err := r.users.GetByID(ctx, m.UserID)
+		if err != nil {
+			continue
+		}
+		name, fields := u.Username, []string{u.Username}
+		if u.DisplayName != nil && *u.DisplayName != "" {
+			name = *u.DisplayName
+			fields = append(fields, *u.DisplayName)
+		}
+		if query.SearchEmail && u.Email != nil {
+			fields = append(fields, *u.Email)
+		}
+		if search != "" && !slices.ContainsFunc(fields, func(f string) bool { return strings.Contains(strings.ToLower(f), search) }) {
+			continue
+		}
+		names[m.ID] = strings.ToLower(name)
+		entries = append(entries, &organization_member.DirectoryEntry{OrganizationMember: *m})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if query.Descending {
+			a, b = b, a
+		}
+		switch query.Sort {
+		case organization_member.DirectorySortJoinedAt, organization_member.DirectorySortLastActiveAt:
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			return names[a.ID] < names[b.ID]
+		}
+	})
+
+	total := int64(len(entries))
+	start := min(query.Offset, len(entries))
+	end := len(entries)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(entries))
+	}
+	return entries[start:end], total, nil
+}
+
+// GetGuestProjectIDs grants no projects: the fakes hold no project memberships
+func (r *OrganizationMemberRepository) GetGuestProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *OrganizationMemberRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*organization_member.OrganizationMember, error) {
+	return r.members.filter(func(m *organization_member.OrganizationMember) bool { return m.UserID == userID }), nil
+}
+
+func (r *OrganizationMemberRepository) Update(ctx context.Context, member *organization_member.OrganizationMember) error {
+	r.members.put(member.ID, member)
+	return nil
+}
+
+func (r *OrganizationMemberRepository) UpdateRoles(ctx context.Context, orgID uuid.UUID, userIDs []uuid.UUID, roleID uuid.UUID) (int64, error) {
+	var count int64
+	for _, userID := range userIDs {
+		m, err := r.GetByOrgAndUser(ctx, orgID, userID)
+		if err != nil {
+			continue
+		}
+		m.RoleID = &roleID
+		m.Role = ""
+		r.members.put(m.ID, m)
+		count++
+	}
+	return count, nil
+}
+
+func (r *OrganizationMemberRepository) Delete(ctx context.Context, orgID, userID uuid.UUID) error {
+	if m, err := r.GetByOrgAndUser(ctx, orgID, userID); err == nil {
+		r.members.delete(m.ID)
+	}
+	return nil
+}
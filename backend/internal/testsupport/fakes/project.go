@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+)
+
+var _ project.Repository = (*ProjectRepository)(nil)
+
+// ProjectRepository is an in-memory project.Repository
+type ProjectRepository struct {
+	projects *table[project.Project]
+}
+
+func NewProjectRepository() *ProjectRepository {
+	return &ProjectRepository{projects: newTable[project.Project]()}
+}
+
+func (r *ProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	ensureID(&p.ID)
+	now := time.Now()
+	p.CreatedAt, p.UpdatedAt = now, now
+	r.projects.put(p.ID, p)
+	return nil
+}
+
+func (r *ProjectRepository) GetByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	return r.projects.get(id)
+}
+
+func (r *ProjectRepository) GetByOrgID(ctx context.Context, orgID uuid.UUID) ([]*project.Project, error) {
+	return r.projects.filter(func(p *project.Project) bool { return p.OrganizationID == orgID }), nil
+}
+
+func (r *ProjectRepository) GetByKey(ctx context.Context, orgID uuid.UUID, key string) (*project.Project, error) {
+	return r.projects.first(func(p *project.Project) bool { return p.OrganizationID == orgID && p.Key == key })
+}
+
+func (r *ProjectRepository) GetAll(ctx context.Context) ([]*project.Project, error) {
+	return r.projects.filter(nil), nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, p *project.Project) error {
+	p.UpdatedAt = time.Now()
+	r.projects.put(p.ID, p)
+	return nil
+}
+
+func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.projects.delete(id)
+	return nil
+}
@@ -0,0 +1,37 @@
+package fakes
+
+// Repositories bundles a consistent set of in-memory repositories
+type Repositories struct {
+	Users       *UserRepository
+	Orgs        *OrganizationRepository
+	Members     *OrganizationMemberRepository
+	Projects    *ProjectRepository
+	Boards      *BoardRepository
+	Columns     *BoardColumnRepository
+	Transitions *ColumnTransitionRepository
+	Cards       *CardRepository
+	Tags        *TagRepository
+	CardTags    *CardTagRepository
+	Sprints     *SprintRepository
+}
+
+// NewRepositories creates an empty set of in-memory repositories
+func NewRepositories() *Repositories {
+	users := NewUserRepository()
+	members := NewOrganizationMemberRepository(users)
+	boards := NewBoardRepository()
+	cards := NewCardRepository(boards)
+	return &Repositories{
+		Users:       users,
+		Orgs:        NewOrganizationRepository(members),
+		Members:     members,
+		Projects:    NewProjectRepository(),
+		Boards:      boards,
+		Columns:     NewBoardColumnRepository(cards),
+		Transitions: NewColumnTransitionRepository(),
+		Cards:       cards,
+		Tags:        NewTagRepository(),
+		CardTags:    NewCardTagRepository(),
+		Sprints:     NewSprintRepository(),
+	}
+}
@@ -0,0 +1,127 @@
+package fakes
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
+)
+
+var _ sprint.Repository = (*SprintRepository)(nil)
+
+// SprintRepository is an in-memory sprint.Repository
+type SprintRepository struct {
+	sprints *table[sprint.Sprint]
+}
+
+func NewSprintRepository() *SprintRepository {
+	return &SprintRepository{sprints: newTable[sprint.Sprint]()}
+}
+
+func (r *SprintRepository) Create(ctx context.Context, sp *sprint.Sprint) error {
+	ensureID(&sp.ID)
+	if sp.Status == "" {
+		sp.Status = sprint.SprintStatusFuture
+	}
+	now := time.Now()
+	sp.CreatedAt, sp.UpdatedAt = now, now
+	r.sprints.put(sp.ID, sp)
+	return nil
+}
+
+func (r *SprintRepository) GetByID(ctx context.Context, id uuid.UUID) (*sprint.Sprint, error) {
+	return r.sprints.get(id)
+}
+
+func (r *SprintRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	return sortSprintsByPosition(r.byBoardAndStatus(boardID, "")), nil
+}
+
+func (r *SprintRepository) GetActiveByBoardID(ctx context.Context, boardID uuid.UUID) (*sprint.Sprint, error) {
+	return r.sprints.first(func(sp *sprint.Sprint) bool {
+		return sp.BoardID == boardID && sp.Status == sprint.SprintStatusActive
+	})
+}
+
+func (r *SprintRepository) GetFutureByBoardID(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	return sortSprintsByPosition(r.byBoardAndStatus(boardID, sprint.SprintStatusFuture)), nil
+}
+
+func (r *SprintRepository) GetClosedByBoardID(ctx context.Context, boardID uuid.UUID) ([]*sprint.Sprint, error) {
+	return sortSprintsByEndDateDesc(r.byBoardAndStatus(boardID, sprint.SprintStatusClosed)), nil
+}
+
+func (r *SprintRepository) GetClosedByBoardIDPaginated(ctx context.Context, boardID uuid.UUID, limit, offset int) ([]*sprint.Sprint, int, error) {
+	closed, _ := r.GetClosedByBoardID(ctx, boardID)
+	total := len(closed)
+	if offset >= total {
+		return []*sprint.Sprint{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return closed[offset:end], total, nil
+}
+
+func (r *SprintRepository) Update(ctx context.Context, sp *sprint.Sprint) error {
+	sp.UpdatedAt = time.Now()
+	r.sprints.put(sp.ID, sp)
+	return nil
+}
+
+func (r *SprintRepository) UpdateSummary(ctx context.Context, id uuid.UUID, summary string, generatedAt time.Time) error {
+	sp, err := r.sprints.get(id)
+	if err != nil {
+		// Like the SQL update, updating a missing sprint changes nothing
+		return nil
+	}
+	sp.Summary, sp.SummaryGeneratedAt = summary, &generatedAt
+	r.sprints.put(sp.ID, sp)
+	return nil
+}
+
+func (r *SprintRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.sprints.delete(id)
+	return nil
+}
+
+func (r *SprintRepository) GetNextPosition(ctx context.Context, boardID uuid.UUID) (int, error) {
+	maxPos := -1
+	for _, sp := range r.byBoardAndStatus(boardID, "") {
+		if sp.Position > maxPos {
+			maxPos = sp.Position
+		}
+	}
+	return maxPos + 1, nil
+}
+
+func (r *SprintRepository) byBoardAndStatus(boardID uuid.UUID, status sprint.SprintStatus) []*sprint.Sprint {
+	return r.sprints.filter(func(sp *sprint.Sprint) bool {
+		return sp.BoardID == boardID && (status == "" || sp.Status == status)
+	})
+}
+
+func sortSprintsByPosition(sprints []*sprint.Sprint) []*sprint.Sprint {
+	sort.SliceStable(sprints, func(i, j int) bool { return sprints[i].Position < sprints[j].Position })
+	return sprints
+}
+
+// sortSprintsByEndDateDesc orders like Postgres "end_date DESC", where NULLs sort first
+func sortSprintsByEndDateDesc(sprints []*sprint.Sprint) []*sprint.Sprint {
+	sort.SliceStable(sprints, func(i, j int) bool {
+		a, b := sprints[i].EndDate, sprints[j].EndDate
+		switch {
+		case a == nil && b == nil:
+			return sprints[i].CreatedAt.After(sprints[j].CreatedAt)
+		case a == nil:
+			return true
+		case b == nil:
+			return false
+		}
+		return a.After(*b)
+	})
+	return sprints
+}
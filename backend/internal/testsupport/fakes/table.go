@@ -0,0 +1,105 @@
+// Package fakes provides in-memory implementations of the repository interfaces so service
+// tests can run against realistic state without Postgres or hand-written mock expectations.
+//
+// Fakes return copies of stored entities, mirroring how GORM hands out fresh structs, and
+// report missing rows with gorm.ErrRecordNotFound just like the real repositories.
+package fakes
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// table is a minimal in-memory table keyed by UUID that preserves insertion order
+type table[T any] struct {
+	mu    sync.RWMutex
+	rows  map[uuid.UUID]*T
+	order map[uuid.UUID]int
+	next  int
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{
+		rows:  make(map[uuid.UUID]*T),
+		order: make(map[uuid.UUID]int),
+	}
+}
+
+func (t *table[T]) put(id uuid.UUID, row *T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cp := *row
+	if _, exists := t.rows[id]; !exists {
+		t.order[id] = t.next
+		t.next++
+	}
+	t.rows[id] = &cp
+}
+
+func (t *table[T]) get(id uuid.UUID) (*T, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	row, ok := t.rows[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *row
+	return &cp, nil
+}
+
+func (t *table[T]) exists(id uuid.UUID) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	_, ok := t.rows[id]
+	return ok
+}
+
+func (t *table[T]) delete(id uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.rows, id)
+	delete(t.order, id)
+}
+
+// filter returns copies of all rows matching keep, in insertion order
+func (t *table[T]) filter(keep func(*T) bool) []*T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(t.rows))
+	for id, row := range t.rows {
+		if keep == nil || keep(row) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return t.order[ids[i]] < t.order[ids[j]] })
+
+	result := make([]*T, len(ids))
+	for i, id := range ids {
+		cp := *t.rows[id]
+		result[i] = &cp
+	}
+	return result
+}
+
+// first returns a copy of the first row matching keep
+func (t *table[T]) first(keep func(*T) bool) (*T, error) {
+	rows := t.filter(keep)
+	if len(rows) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return rows[0], nil
+}
+
+func ensureID(id *uuid.UUID) {
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+}
@@ -0,0 +1,129 @@
+package fakes
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	"gorm.io/gorm"
+)
+
+var (
+	_ tag.Repository      = (*TagRepository)(nil)
+	_ card_tag.Repository = (*CardTagRepository)(nil)
+)
+
+// TagRepository is an in-memory tag.Repository
+type TagRepository struct {
+	tags *table[tag.Tag]
+}
+
+func NewTagRepository() *TagRepository {
+	return &TagRepository{tags: newTable[tag.Tag]()}
+}
+
+func (r *TagRepository) Create(ctx context.Context, t *tag.Tag) error {
+	ensureID(&t.ID)
+	if t.Color == "" {
+		t.Color = "#6B7280"
+	}
+	t.CreatedAt = time.Now()
+	r.tags.put(t.ID, t)
+	return nil
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id uuid.UUID) (*tag.Tag, error) {
+	return r.tags.get(id)
+}
+
+func (r *TagRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*tag.Tag, error) {
+	tags := r.tags.filter(func(t *tag.Tag) bool { return t.ProjectID == projectID })
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *TagRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*tag.Tag, error) {
+	wanted := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.tags.filter(func(t *tag.Tag) bool { return wanted[t.ID] }), nil
+}
+
+func (r *TagRepository) GetByName(ctx context.Context, projectID uuid.UUID, name string) (*tag.Tag, error) {
+	return r.tags.first(func(t *tag.Tag) bool { return t.ProjectID == projectID && t.Name == name })
+}
+
+func (r *TagRepository) Update(ctx context.Context, t *tag.Tag) error {
+	r.tags.put(t.ID, t)
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.tags.delete(id)
+	return nil
+}
+
+// CardTagRepository is an in-memory card_tag.Repository
+type CardTagRepository struct {
+	cardTags *table[card_tag.CardTag]
+}
+
+func NewCardTagRepository() *CardTagRepository {
+	return &CardTagRepository{cardTags: newTable[card_tag.CardTag]()}
+}
+
+// cardTagKey derives a stable table key from the composite primary key
+func cardTagKey(cardID, tagID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(cardID, tagID[:])
+}
+
+func (r *CardTagRepository) Create(ctx context.Context, cardTag *card_tag.CardTag) error {
+	key := cardTagKey(cardTag.CardID, cardTag.TagID)
+	if r.cardTags.exists(key) {
+		return gorm.ErrDuplicatedKey
+	}
+	cardTag.CreatedAt = time.Now()
+	r.cardTags.put(key, cardTag)
+	return nil
+}
+
+func (r *CardTagRepository) GetByCardID(ctx context.Context, cardID uuid.UUID) ([]*card_tag.CardTag, error) {
+	return r.cardTags.filter(func(ct *card_tag.CardTag) bool { return ct.CardID == cardID }), nil
+}
+
+func (r *CardTagRepository) GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]*card_tag.CardTag, error) {
+	return r.cardTags.filter(func(ct *card_tag.CardTag) bool { return slices.Contains(cardIDs, ct.CardID) }), nil
+}
+
+func (r *CardTagRepository) GetByTagID(ctx context.Context, tagID uuid.UUID) ([]*card_tag.CardTag, error) {
+	return r.cardTags.filter(func(ct *card_tag.CardTag) bool { return ct.TagID == tagID }), nil
+}
+
+func (r *CardTagRepository) DeleteByCardID(ctx context.Context, cardID uuid.UUID) error {
+	for _, ct := range r.cardTags.filter(func(ct *card_tag.CardTag) bool { return ct.CardID == cardID }) {
+		r.cardTags.delete(cardTagKey(ct.CardID, ct.TagID))
+	}
+	return nil
+}
+
+func (r *CardTagRepository) DeleteByCardAndTag(ctx context.Context, cardID, tagID uuid.UUID) error {
+	r.cardTags.delete(cardTagKey(cardID, tagID))
+	return nil
+}
+
+func (r *CardTagRepository) SetTagsForCard(ctx context.Context, cardID uuid.UUID, tagIDs []uuid.UUID) error {
+	if err := r.DeleteByCardID(ctx, cardID); err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if err := r.Create(ctx, &card_tag.CardTag{CardID: cardID, TagID: tagID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"gorm.io/gorm"
+)
+
+var _ user.Repository = (*UserRepository)(nil)
+
+// UserRepository is an in-memory user.Repository
+type UserRepository struct {
+	users *table[user.User]
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: newTable[user.User]()}
+}
+
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	if _, err := r.GetByUsername(ctx, u.Username); err == nil {
+		return gorm.ErrDuplicatedKey
+	}
+	ensureID(&u.ID)
+	now := time.Now()
+	u.CreatedAt, u.UpdatedAt = now, now
+	r.users.put(u.ID, u)
+	return nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*user.User, error) {
+	return r.users.first(func(u *user.User) bool { return u.Username == username })
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	return r.users.get(id)
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.users.first(func(u *user.User) bool { return u.Email != nil && *u.Email == email })
+}
+
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	u.UpdatedAt = time.Now()
+	r.users.put(u.ID, u)
+	return nil
+}
+
+func (r *UserRepository) GetAll(ctx context.Context) ([]*user.User, error) {
+	return r.users.filter(nil), nil
+}
@@ -0,0 +1,233 @@
+// Package fixtures provides factory functions that build common entity graphs for tests.
+//
+// Factories write through repository interfaces, so the same fixture works against
+// Postgres (DBRepositories) and against the in-memory fakes (FakeRepositories).
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"gorm.io/gorm"
+)
+
+// sequence makes generated usernames, slugs and keys unique within a test binary
+var sequence atomic.Int64
+
+func next() int64 {
+	return sequence.Add(1)
+}
+
+// Repositories is the set of repositories the factories write through
+type Repositories struct {
+	Users    user.Repository
+	Orgs     organization.Repository
+	Members  organization_member.Repository
+	Projects project.Repository
+	Boards   board.Repository
+	Columns  board_column.Repository
+	Cards    card.Repository
+}
+
+// DBRepositories returns repositories backed by the given database
+func DBRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Users:    user.NewRepository(db),
+		Orgs:     organization.NewRepository(db),
+		Members:  organization_member.NewRepository(db),
+		Projects: project.NewRepository(db),
+		Boards:   board.NewRepository(db),
+		Columns:  board_column.NewRepository(db),
+		Cards:    card.NewRepository(db),
+	}
+}
+
+// FakeRepositories returns factory repositories backed by in-memory fakes
+func FakeRepositories(f *fakes.Repositories) Repositories {
+	return Repositories{
+		Users:    f.Users,
+		Orgs:     f.Orgs,
+		Members:  f.Members,
+		Projects: f.Projects,
+		Boards:   f.Boards,
+		Columns:  f.Columns,
+		Cards:    f.Cards,
+	}
+}
+
+// TestOrg is an organization together with its owner
+type TestOrg struct {
+	Organization *organization.Organization
+	Owner        *user.User
+}
+
+// TestBoard is a board with the default columns and the cards created on it
+type TestBoard struct {
+	Board   *board.Board
+	Backlog *board_column.BoardColumn
+	Todo    *board_column.BoardColumn
+	Doing   *board_column.BoardColumn
+	Done    *board_column.BoardColumn
+	Cards   []*card.Card
+}
+
+// Columns returns the board columns in position order
+func (b *TestBoard) Columns() []*board_column.BoardColumn {
+	return []*board_column.BoardColumn{b.Backlog, b.Todo, b.Doing, b.Done}
+}
+
+// NewTestUser creates a user with a unique username and email
+func NewTestUser(t testing.TB, repos Repositories) *user.User {
+	t.Helper()
+
+	n := next()
+	passwordHash := "hashedpassword"
+	email := fmt.Sprintf("user%d@example.com", n)
+	u := &user.User{
+		Username:      fmt.Sprintf("user%d", n),
+		PasswordHash:  &passwordHash,
+		Email:         &email,
+		EmailVerified: true,
+	}
+	if err := repos.Users.Create(context.Background(), u); err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	return u
+}
+
+// NewTestOrg creates an organization owned by a new user, who is also its owner member
+func NewTestOrg(t testing.TB, repos Repositories) *TestOrg {
+	t.Helper()
+	ctx := context.Background()
+
+	owner := NewTestUser(t, repos)
+
+	n := next()
+	org := &organization.Organization{
+		Name:    fmt.Sprintf("Test Org %d", n),
+		Slug:    fmt.Sprintf("test-org-%d", n),
+		OwnerID: owner.ID,
+	}
+	if err := repos.Orgs.Create(ctx, org); err != nil {
+		t.Fatalf("failed to create test organization: %v", err)
+	}
+
+	AddTestMember(t, repos, org.ID, owner.ID, role.OwnerRoleID)
+
+	return &TestOrg{Organization: org, Owner: owner}
+}
+
+// AddTestMember adds a user to an organization with the given system role
+func AddTestMember(t testing.TB, repos Repositories, orgID, userID, roleID uuid.UUID) *organization_member.OrganizationMember {
+	t.Helper()
+
+	legacyRole := "member"
+	switch roleID {
+	case role.OwnerRoleID:
+		legacyRole = "owner"
+	case role.AdminRoleID:
+		legacyRole = "admin"
+	}
+
+	member := &organization_member.OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           legacyRole,
+		RoleID:         &roleID,
+	}
+	if err := repos.Members.Create(context.Background(), member); err != nil {
+		t.Fatalf("failed to create test organization member: %v", err)
+	}
+	return member
+}
+
+// NewTestProject creates a project with a unique key in the organization
+func NewTestProject(t testing.TB, repos Repositories, orgID uuid.UUID) *project.Project {
+	t.Helper()
+
+	n := next()
+	p := &project.Project{
+		OrganizationID: orgID,
+		Name:           fmt.Sprintf("Test Project %d", n),
+		Key:            fmt.Sprintf("T%d", n%100000),
+	}
+	if err := repos.Projects.Create(context.Background(), p); err != nil {
+		t.Fatalf("failed to create test project: %v", err)
+	}
+	return p
+}
+
+// NewTestBoardWithCards creates a default-style board (Backlog, Todo, In Progress, Done)
+// with cardCount cards in the Todo column
+func NewTestBoardWithCards(t testing.TB, repos Repositories, projectID uuid.UUID, cardCount int) *TestBoard {
+	t.Helper()
+	ctx := context.Background()
+
+	b := &board.Board{
+		ProjectID: projectID,
+		Name:      fmt.Sprintf("Test Board %d", next()),
+		IsDefault: true,
+	}
+	if err := repos.Boards.Create(ctx, b); err != nil {
+		t.Fatalf("failed to create test board: %v", err)
+	}
+
+	newColumn := func(name string, position int, isBacklog, isHidden, isDone bool) *board_column.BoardColumn {
+		col := &board_column.BoardColumn{
+			BoardID:   b.ID,
+			Name:      name,
+			Position:  position,
+			IsBacklog: isBacklog,
+			IsHidden:  isHidden,
+			IsDone:    isDone,
+			Color:     "#6B7280",
+		}
+		if err := repos.Columns.Create(ctx, col); err != nil {
+			t.Fatalf("failed to create test column: %v", err)
+		}
+		return col
+	}
+
+	tb := &TestBoard{
+		Board:   b,
+		Backlog: newColumn("Backlog", 0, true, true, false),
+		Todo:    newColumn("Todo", 1, false, false, false),
+		Doing:   newColumn("In Progress", 2, false, false, false),
+		Done:    newColumn("Done", 3, false, false, true),
+	}
+
+	for i := 0; i < cardCount; i++ {
+		tb.Cards = append(tb.Cards, NewTestCard(t, repos, tb.Todo, float64(i+1)*1000))
+	}
+
+	return tb
+}
+
+// NewTestCard creates a card in the column at the given position
+func NewTestCard(t testing.TB, repos Repositories, column *board_column.BoardColumn, position float64) *card.Card {
+	t.Helper()
+
+	c := &card.Card{
+		ColumnID: column.ID,
+		BoardID:  column.BoardID,
+		Title:    fmt.Sprintf("Test Card %d", next()),
+		Position: position,
+		Priority: card.PriorityNone,
+	}
+	if err := repos.Cards.Create(context.Background(), c); err != nil {
+		t.Fatalf("failed to create test card: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,107 @@
+package fixtures_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
+	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fakes"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport/fixtures"
+)
+
+func TestFixturesWithFakes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NewTestOrg creates owner membership", func(t *testing.T) {
+		f := fakes.NewRepositories()
+		repos := fixtures.FakeRepositories(f)
+
+		org := fixtures.NewTestOrg(t, repos)
+
+		member, err := f.Members.GetByOrgAndUser(ctx, org.Organization.ID, org.Owner.ID)
+		require.NoError(t, err)
+		require.NotNil(t, member.RoleID)
+		assert.Equal(t, role.OwnerRoleID, *member.RoleID)
+
+		orgs, err := f.Orgs.GetByUserID(ctx, org.Owner.ID)
+		require.NoError(t, err)
+		assert.Len(t, orgs, 1)
+	})
+
+	t.Run("NewTestBoardWithCards places cards in Todo", func(t *testing.T) {
+		f := fakes.NewRepositories()
+		repos := fixtures.FakeRepositories(f)
+
+		org := fixtures.NewTestOrg(t, repos)
+		proj := fixtures.NewTestProject(t, repos, org.Organization.ID)
+		tb := fixtures.NewTestBoardWithCards(t, repos, proj.ID, 3)
+
+		columns, err := f.Columns.GetByBoardID(ctx, tb.Board.ID)
+		require.NoError(t, err)
+		assert.Len(t, columns, 4)
+
+		cards, err := f.Cards.GetByColumnID(ctx, tb.Todo.ID)
+		require.NoError(t, err)
+		require.Len(t, cards, 3)
+		assert.Equal(t, tb.Cards[0].ID, cards[0].ID)
+	})
+
+	t.Run("card service runs against fakes", func(t *testing.T) {
+		f := fakes.NewRepositories()
+		repos := fixtures.FakeRepositories(f)
+
+		org := fixtures.NewTestOrg(t, repos)
+		proj := fixtures.NewTestProject(t, repos, org.Organization.ID)
+		tb := fixtures.NewTestBoardWithCards(t, repos, proj.ID, 2)
+
+		workflowSvc := workflowService.NewService(f.Transitions, f.Boards, f.Columns)
+		svc := cardService.NewService(f.Cards, f.Columns, f.Boards, f.Tags, f.CardTags, nil, workflowSvc, contentService.NewService(f.Boards, f.Projects, f.Orgs, contentService.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		moved, err := svc.MoveCard(ctx, tb.Cards[0].ID, tb.Done.ID, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, tb.Done.ID, moved.ColumnID)
+
+		todo, err := f.Cards.GetByColumnID(ctx, tb.Todo.ID)
+		require.NoError(t, err)
+		assert.Len(t, todo, 1)
+
+		done, err := f.Cards.GetByColumnID(ctx, tb.Done.ID)
+		require.NoError(t, err)
+		assert.Len(t, done, 1)
+	})
+	t.Run("card service enforces the board workflow", func(t *testing.T) {
+		f := fakes.NewRepositories()
+		repos := fixtures.FakeRepositories(f)
+
+		org := fixtures.NewTestOrg(t, repos)
+		proj := fixtures.NewTestProject(t, repos, org.Organization.ID)
+		tb := fixtures.NewTestBoardWithCards(t, repos, proj.ID, 1)
+
+		workflowSvc := workflowService.NewService(f.Transitions, f.Boards, f.Columns)
+		svc := cardService.NewService(f.Cards, f.Columns, f.Boards, f.Tags, f.CardTags, nil, workflowSvc, contentService.NewService(f.Boards, f.Projects, f.Orgs, contentService.Limits{}), nil, transaction.NewNoopManager(), events.NewSyncBus())
+
+		_, err := workflowSvc.SetTransitions(ctx, tb.Board.ID, []workflowService.Transition{
+			{FromColumnID: tb.Todo.ID, ToColumnID: tb.Doing.ID},
+			{FromColumnID: tb.Doing.ID, ToColumnID: tb.Done.ID},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.MoveCard(ctx, tb.Cards[0].ID, tb.Done.ID, nil, false)
+		assert.ErrorIs(t, err, workflowService.ErrInvalidTransition)
+
+		moved, err := svc.MoveCard(ctx, tb.Cards[0].ID, tb.Doing.ID, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, tb.Doing.ID, moved.ColumnID)
+
+		moved, err = svc.MoveCard(ctx, tb.Cards[0].ID, tb.Backlog.ID, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, tb.Backlog.ID, moved.ColumnID)
+	})
+}
@@ -0,0 +1,75 @@
+// Package textlayout breaks text into lines for renderers that draw text themselves, such as
+// the PDF exports and board snapshots
+package textlayout
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Measure returns how wide text is drawn, in the renderer's units
+type Measure func(text string) float64
+
+// Wrap breaks text into lines at most width wide, at spaces where it can. Text needing more
+// than maxLines lines is cut short, with ellipsis ending the last line.
+func Wrap(text string, measure Measure, width float64, maxLines int, ellipsis string) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if measure(candidate) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		// A word wider than a whole line is broken wherever it has to be
+		for measure(word) > width {
+			n := fitting(word, measure, width)
+			lines = append(lines, word[:n])
+			word = word[n:]
+		}
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	if len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+	last := lines[maxLines-1]
+	for last != "" && measure(last+ellipsis) > width {
+		_, n := utf8.DecodeLastRuneInString(last)
+		last = strings.TrimRight(last[:len(last)-n], " ")
+	}
+	lines[maxLines-1] = last + ellipsis
+	return lines
+}
+
+// Truncate shortens text to one line at most width wide, ending it with ellipsis when cut short
+func Truncate(text string, measure Measure, width float64, ellipsis string) string {
+	lines := Wrap(text, measure, width, 1, ellipsis)
+	if len(lines) == 0 {
+		return ""
+	}
+	return lines[0]
+}
+
+// fitting returns how many bytes of word, at least one rune, fit in width
+func fitting(word string, measure Measure, width float64) int {
+	n := 0
+	for i, r := range word {
+		end := i + utf8.RuneLen(r)
+		if n > 0 && measure(word[:end]) > width {
+			break
+		}
+		n = end
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+package textlayout
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runes measures text as one unit per rune
+func runes(text string) float64 {
+	return float64(utf8.RuneCountInString(text))
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("breaks lines at spaces", func(t *testing.T) {
+		assert.Equal(t, []string{"alpha beta", "gamma"}, Wrap("alpha  beta\ngamma", runes, 10, 5, "…"))
+	})
+
+	t.Run("breaks words wider than a line", func(t *testing.T) {
+		assert.Equal(t, []string{"abcd", "abcd", "ab"}, Wrap("abcdabcdab", runes, 4, 5, "…"))
+	})
+
+	t.Run("breaks multi-byte words between runes", func(t *testing.T) {
+		assert.Equal(t, []string{"äöü", "ß"}, Wrap("äöüß", runes, 3, 5, "…"))
+	})
+
+	t.Run("ends text cut short with the ellipsis", func(t *testing.T) {
+		assert.Equal(t, []string{"one two", "three…"}, Wrap("one two three four", runes, 7, 2, "…"))
+		assert.Equal(t, []string{"one t..."}, Wrap("one two three four", runes, 8, 1, "..."))
+	})
+
+	t.Run("empty text has no lines", func(t *testing.T) {
+		assert.Empty(t, Wrap("  ", runes, 100, 3, "…"))
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", runes, 10, "…"))
+	assert.Equal(t, "a longe…", Truncate("a longer title", runes, 8, "…"))
+	assert.Equal(t, "", Truncate("", runes, 10, "…"))
+}
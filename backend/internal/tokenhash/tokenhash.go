@@ -0,0 +1,14 @@
+// Package tokenhash hashes secrets such as refresh, embed and API tokens for storage, so a
+// leaked database doesn't leak usable tokens
+package tokenhash
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Hash returns the SHA-256 hash of the token, URL-safe base64 encoded
+func Hash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return base64.URLEncoding.EncodeToString(hash[:])
+}
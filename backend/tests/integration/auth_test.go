@@ -3,10 +3,8 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
@@ -21,7 +19,7 @@ import (
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
-	"gorm.io/driver/postgres"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -44,39 +42,7 @@ type TestServer struct {
 }
 
 func setupTestServer(t *testing.T) *TestServer {
-	// Use test database config from environment or defaults
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test
-	testDB.Exec("DELETE FROM refresh_tokens")
-	testDB.Exec("DELETE FROM users")
+	testDB := testsupport.NewTestDB(t)
 
 	// Create services
 	userRepository := userRepo.NewRepository(testDB)
@@ -97,12 +63,12 @@ func setupTestServer(t *testing.T) *TestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(nil, nil),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authService)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authService, nil)(srv)
 
 	return &TestServer{
 		handler:     wrappedHandler,
@@ -112,7 +78,7 @@ func setupTestServer(t *testing.T) *TestServer {
 }
 
 func (ts *TestServer) cleanup(t *testing.T) {
-	ts.db.Exec("DELETE FROM users")
+	testsupport.ResetDB(t, ts.db)
 }
 
 func (ts *TestServer) executeGraphQL(t *testing.T, query string, cookies []*http.Cookie) (*GraphQLResponse, []*http.Cookie) {
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"sync"
 	"testing"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -20,25 +20,33 @@ import (
 	columnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	memberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	permissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
 	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
-	"gorm.io/driver/postgres"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -48,46 +56,7 @@ type BoardTestServer struct {
 }
 
 func setupBoardTestServer(t *testing.T) *BoardTestServer {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test (order matters due to foreign keys)
-	testDB.Exec("DELETE FROM card_tags")
-	testDB.Exec("DELETE FROM cards")
-	testDB.Exec("DELETE FROM tags")
-	testDB.Exec("DELETE FROM board_columns")
-	testDB.Exec("DELETE FROM boards")
-	testDB.Exec("DELETE FROM projects")
-	testDB.Exec("DELETE FROM organization_members")
-	testDB.Exec("DELETE FROM organizations")
-	testDB.Exec("DELETE FROM refresh_tokens")
-	testDB.Exec("DELETE FROM users")
+	testDB := testsupport.NewTestDB(t)
 
 	// Create repositories
 	userRepository := userRepo.NewRepository(testDB)
@@ -100,6 +69,7 @@ func setupBoardTestServer(t *testing.T) *BoardTestServer {
 	cardRepository := cardRepo.NewRepository(testDB)
 	tagRepository := tagRepo.NewRepository(testDB)
 	cardTagRepository := cardTagRepo.NewRepository(testDB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(testDB)
 	permissionRepository := permissionRepo.NewRepository(testDB)
 	roleRepository := roleRepo.NewRepository(testDB)
 	rolePermissionRepository := rolePermissionRepo.NewRepository(testDB)
@@ -107,10 +77,13 @@ func setupBoardTestServer(t *testing.T) *BoardTestServer {
 	// Create services
 	refreshRepository := refreshTokenRepo.NewRepository(testDB)
 	authSvc := auth.NewService(userRepository, refreshRepository, "test-jwt-secret", 15, 7)
-	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository)
-	projSvc := projectService.NewService(projectRepository, orgRepository)
-	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository)
-	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository)
+	txManager := transaction.NewManager(testDB)
+	eventBus := events.NewSyncBus()
+	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository, txManager, eventBus)
+	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository, txManager, eventBus)
+	projSvc := projectService.NewService(projectRepository, orgRepository, boardSvc, txManager, eventBus)
+	workflowSvc := workflowService.NewService(columnTransitionRepository, boardRepository, columnRepository)
+	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository, columnDefaultsRepo.NewRepository(testDB), workflowSvc, contentService.NewService(boardRepository, projectRepository, orgRepository, contentService.Limits{}), calendarService.NewService(projectCalendarRepo.NewRepository(testDB), projectRepository), txManager, eventBus)
 	tagSvc := tagService.NewService(tagRepository, projectRepository)
 	rbacSvc := rbacService.NewService(
 		permissionRepository,
@@ -136,6 +109,7 @@ func setupBoardTestServer(t *testing.T) *BoardTestServer {
 		ProjectService:      projSvc,
 		BoardService:        boardSvc,
 		CardService:         cardSvc,
+		WorkflowService:     workflowSvc,
 		TagService:          tagSvc,
 		RBACService:         rbacSvc,
 	}
@@ -143,12 +117,12 @@ func setupBoardTestServer(t *testing.T) *BoardTestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(rbacSvc, nil),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authSvc)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authSvc, nil)(srv)
 
 	return &BoardTestServer{
 		handler: wrappedHandler,
@@ -563,6 +537,231 @@ func TestMoveCard(t *testing.T) {
 	assert.Equal(t, "In Progress", moveData.MoveCard.Column.Name)
 }
 
+func TestColumnTransitions(t *testing.T) {
+	server := setupBoardTestServer(t)
+	defer server.cleanup()
+
+	token, err := server.registerUser("workflowuser", "password123")
+	require.NoError(t, err)
+
+	orgResp := server.executeQuery(`mutation { createOrganization(input: { name: "Workflow Org" }) { id } }`, token)
+	var orgData struct {
+		CreateOrganization struct {
+			ID string `json:"id"`
+		} `json:"createOrganization"`
+	}
+	json.Unmarshal(orgResp.Data, &orgData)
+
+	projResp := server.executeQuery(fmt.Sprintf(`mutation {
+		createProject(input: { organizationId: "%s", name: "Workflow", key: "WFL" }) {
+			defaultBoard { id columns { id name } }
+		}
+	}`, orgData.CreateOrganization.ID), token)
+
+	var projData struct {
+		CreateProject struct {
+			DefaultBoard struct {
+				ID      string `json:"id"`
+				Columns []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"columns"`
+			} `json:"defaultBoard"`
+		} `json:"createProject"`
+	}
+	json.Unmarshal(projResp.Data, &projData)
+	boardID := projData.CreateProject.DefaultBoard.ID
+
+	columnIDs := make(map[string]string)
+	for _, col := range projData.CreateProject.DefaultBoard.Columns {
+		columnIDs[col.Name] = col.ID
+	}
+	todoColID, inProgressColID := columnIDs["Todo"], columnIDs["In Progress"]
+	require.NotEmpty(t, todoColID)
+	require.NotEmpty(t, inProgressColID)
+
+	// Only allow Todo -> In Progress
+	setResp := server.executeQuery(fmt.Sprintf(`mutation {
+		setColumnTransitions(boardId: "%s", transitions: [{ fromColumnId: "%s", toColumnId: "%s" }]) {
+			fromColumnId
+			toColumnId
+		}
+	}`, boardID, todoColID, inProgressColID), token)
+	require.Empty(t, setResp.Errors, "Set transitions errors: %v", setResp.Errors)
+
+	boardResp := server.executeQuery(fmt.Sprintf(`query {
+		board(id: "%s") { columnTransitions { fromColumnId toColumnId } }
+	}`, boardID), token)
+	require.Empty(t, boardResp.Errors)
+
+	var boardData struct {
+		Board struct {
+			ColumnTransitions []struct {
+				FromColumnID string `json:"fromColumnId"`
+				ToColumnID   string `json:"toColumnId"`
+			} `json:"columnTransitions"`
+		} `json:"board"`
+	}
+	json.Unmarshal(boardResp.Data, &boardData)
+	require.Len(t, boardData.Board.ColumnTransitions, 1)
+	assert.Equal(t, todoColID, boardData.Board.ColumnTransitions[0].FromColumnID)
+	assert.Equal(t, inProgressColID, boardData.Board.ColumnTransitions[0].ToColumnID)
+
+	t.Run("self transitions are rejected", func(t *testing.T) {
+		resp := server.executeQuery(fmt.Sprintf(`mutation {
+			setColumnTransitions(boardId: "%s", transitions: [{ fromColumnId: "%s", toColumnId: "%s" }]) {
+				fromColumnId
+			}
+		}`, boardID, todoColID, todoColID), token)
+		assert.NotEmpty(t, resp.Errors)
+	})
+
+	t.Run("owners bypass the workflow", func(t *testing.T) {
+		cardResp := server.executeQuery(fmt.Sprintf(`mutation {
+			createCard(input: { columnId: "%s", title: "Workflow Card" }) { id }
+		}`, inProgressColID), token)
+		var cardData struct {
+			CreateCard struct {
+				ID string `json:"id"`
+			} `json:"createCard"`
+		}
+		json.Unmarshal(cardResp.Data, &cardData)
+
+		moveResp := server.executeQuery(fmt.Sprintf(`mutation {
+			moveCard(input: { cardId: "%s", targetColumnId: "%s" }) { column { id } }
+		}`, cardData.CreateCard.ID, todoColID), token)
+		assert.Empty(t, moveResp.Errors, "Move card errors: %v", moveResp.Errors)
+	})
+}
+
+func TestConcurrentCardMoves(t *testing.T) {
+	server := setupBoardTestServer(t)
+	defer server.cleanup()
+
+	token, err := server.registerUser("concurrentmover", "password123")
+	require.NoError(t, err)
+
+	orgResp := server.executeQuery(`mutation { createOrganization(input: { name: "Concurrent Move Org" }) { id } }`, token)
+	require.Empty(t, orgResp.Errors)
+	var orgData struct {
+		CreateOrganization struct {
+			ID string `json:"id"`
+		} `json:"createOrganization"`
+	}
+	require.NoError(t, json.Unmarshal(orgResp.Data, &orgData))
+
+	projResp := server.executeQuery(fmt.Sprintf(`mutation {
+		createProject(input: { organizationId: "%s", name: "Concurrent Move", key: "CMV" }) {
+			defaultBoard { columns { id name } }
+		}
+	}`, orgData.CreateOrganization.ID), token)
+	require.Empty(t, projResp.Errors)
+	var projData struct {
+		CreateProject struct {
+			DefaultBoard struct {
+				Columns []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"columns"`
+			} `json:"defaultBoard"`
+		} `json:"createProject"`
+	}
+	require.NoError(t, json.Unmarshal(projResp.Data, &projData))
+
+	var todoColID, inProgressColID string
+	for _, col := range projData.CreateProject.DefaultBoard.Columns {
+		switch col.Name {
+		case "Todo":
+			todoColID = col.ID
+		case "In Progress":
+			inProgressColID = col.ID
+		}
+	}
+
+	createCard := func(columnID, title string) string {
+		resp := server.executeQuery(fmt.Sprintf(`mutation {
+			createCard(input: { columnId: "%s", title: "%s" }) { id }
+		}`, columnID, title), token)
+		require.Empty(t, resp.Errors)
+		var data struct {
+			CreateCard struct {
+				ID string `json:"id"`
+			} `json:"createCard"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		return data.CreateCard.ID
+	}
+
+	moveCard := func(cardID, afterCardID string) *graphQLResponse {
+		after := ""
+		if afterCardID != "" {
+			after = fmt.Sprintf(`afterCardId: "%s"`, afterCardID)
+		}
+		return server.executeQuery(fmt.Sprintf(`mutation {
+			moveCard(input: { cardId: "%s", targetColumnId: "%s" %s }) { id }
+		}`, cardID, inProgressColID, after), token)
+	}
+
+	assertDistinctOrderedPositions := func(t *testing.T, expected int) {
+		var positions []float64
+		require.NoError(t, server.db.Raw(
+			"SELECT position FROM cards WHERE column_id = ? ORDER BY position ASC", inProgressColID,
+		).Scan(&positions).Error)
+		require.Len(t, positions, expected)
+		for i := 1; i < len(positions); i++ {
+			assert.Less(t, positions[i-1], positions[i], "positions must be unique and strictly ordered")
+		}
+	}
+
+	anchorID := createCard(inProgressColID, "Anchor")
+	lastID := createCard(inProgressColID, "Last")
+
+	const movers = 20
+	cardIDs := make([]string, movers)
+	for i := range cardIDs {
+		cardIDs[i] = createCard(todoColID, fmt.Sprintf("Dragged %d", i))
+	}
+
+	t.Run("concurrent drags between the same neighbours get distinct positions", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan string, movers)
+		for _, id := range cardIDs {
+			wg.Add(1)
+			go func(cardID string) {
+				defer wg.Done()
+				if resp := moveCard(cardID, anchorID); len(resp.Errors) > 0 {
+					errs <- fmt.Sprint(resp.Errors)
+				}
+			}(id)
+		}
+		wg.Wait()
+		close(errs)
+		for e := range errs {
+			t.Errorf("move failed: %s", e)
+		}
+
+		assertDistinctOrderedPositions(t, movers+2)
+
+		// Every dragged card landed between the anchor and the last card
+		var order []string
+		require.NoError(t, server.db.Raw(
+			"SELECT id FROM cards WHERE column_id = ? ORDER BY position ASC", inProgressColID,
+		).Scan(&order).Error)
+		assert.Equal(t, anchorID, order[0])
+		assert.Equal(t, lastID, order[len(order)-1])
+	})
+
+	t.Run("exhausted gaps renumber the column", func(t *testing.T) {
+		// Repeatedly dropping at the top halves the leading gap until it must be renumbered
+		for i := 0; i < 60; i++ {
+			resp := moveCard(cardIDs[i%movers], "")
+			require.Empty(t, resp.Errors)
+		}
+
+		assertDistinctOrderedPositions(t, movers+2)
+	})
+}
+
 func TestTagCRUD(t *testing.T) {
 	server := setupBoardTestServer(t)
 	defer server.cleanup()
@@ -2,10 +2,8 @@ package integration
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -18,7 +16,7 @@ import (
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	"github.com/thatcatdev/kaimu/backend/internal/services/oidc"
-	"gorm.io/driver/postgres"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -30,40 +28,7 @@ type OIDCTestServer struct {
 }
 
 func setupOIDCTestServer(t *testing.T) *OIDCTestServer {
-	// Use test database config from environment or defaults
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test
-	testDB.Exec("DELETE FROM oidc_identities")
-	testDB.Exec("DELETE FROM refresh_tokens")
-	testDB.Exec("DELETE FROM users")
+	testDB := testsupport.NewTestDB(t)
 
 	// Create test providers
 	providers := []config.OIDCProvider{
@@ -126,8 +91,7 @@ func setupOIDCTestServer(t *testing.T) *OIDCTestServer {
 }
 
 func (ts *OIDCTestServer) cleanup(t *testing.T) {
-	ts.db.Exec("DELETE FROM oidc_identities")
-	ts.db.Exec("DELETE FROM users")
+	testsupport.ResetDB(t, ts.db)
 }
 
 func TestOIDC_ListProviders(t *testing.T) {
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -20,25 +19,33 @@ import (
 	columnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	memberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	permissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
 	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
-	"gorm.io/driver/postgres"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -48,46 +55,7 @@ type OrgProjectTestServer struct {
 }
 
 func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test
-	testDB.Exec("DELETE FROM card_tags")
-	testDB.Exec("DELETE FROM cards")
-	testDB.Exec("DELETE FROM tags")
-	testDB.Exec("DELETE FROM board_columns")
-	testDB.Exec("DELETE FROM boards")
-	testDB.Exec("DELETE FROM projects")
-	testDB.Exec("DELETE FROM organization_members")
-	testDB.Exec("DELETE FROM organizations")
-	testDB.Exec("DELETE FROM refresh_tokens")
-	testDB.Exec("DELETE FROM users")
+	testDB := testsupport.NewTestDB(t)
 
 	// Create repositories
 	userRepository := userRepo.NewRepository(testDB)
@@ -99,6 +67,7 @@ func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
 	columnRepository := columnRepo.NewRepository(testDB)
 	cardRepository := cardRepo.NewRepository(testDB)
 	cardTagRepository := cardTagRepo.NewRepository(testDB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(testDB)
 	tagRepository := tagRepo.NewRepository(testDB)
 	permissionRepository := permissionRepo.NewRepository(testDB)
 	roleRepository := roleRepo.NewRepository(testDB)
@@ -107,10 +76,13 @@ func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
 	// Create services
 	refreshRepository := refreshTokenRepo.NewRepository(testDB)
 	authSvc := auth.NewService(userRepository, refreshRepository, "test-jwt-secret", 15, 7)
-	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository)
-	projSvc := projectService.NewService(projectRepository, orgRepository)
-	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository)
-	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository)
+	txManager := transaction.NewManager(testDB)
+	eventBus := events.NewSyncBus()
+	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository, txManager, eventBus)
+	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository, txManager, eventBus)
+	projSvc := projectService.NewService(projectRepository, orgRepository, boardSvc, txManager, eventBus)
+	workflowSvc := workflowService.NewService(columnTransitionRepository, boardRepository, columnRepository)
+	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository, columnDefaultsRepo.NewRepository(testDB), workflowSvc, contentService.NewService(boardRepository, projectRepository, orgRepository, contentService.Limits{}), calendarService.NewService(projectCalendarRepo.NewRepository(testDB), projectRepository), txManager, eventBus)
 	tagSvc := tagService.NewService(tagRepository, projectRepository)
 	rbacSvc := rbacService.NewService(
 		permissionRepository,
@@ -136,6 +108,7 @@ func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
 		ProjectService:      projSvc,
 		BoardService:        boardSvc,
 		CardService:         cardSvc,
+		WorkflowService:     workflowSvc,
 		TagService:          tagSvc,
 		RBACService:         rbacSvc,
 	}
@@ -143,12 +116,12 @@ func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(rbacSvc, nil),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authSvc)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authSvc, nil)(srv)
 
 	return &OrgProjectTestServer{
 		handler: wrappedHandler,
@@ -157,15 +130,7 @@ func setupOrgProjectTestServer(t *testing.T) *OrgProjectTestServer {
 }
 
 func (ts *OrgProjectTestServer) cleanup(t *testing.T) {
-	ts.db.Exec("DELETE FROM card_tags")
-	ts.db.Exec("DELETE FROM cards")
-	ts.db.Exec("DELETE FROM tags")
-	ts.db.Exec("DELETE FROM board_columns")
-	ts.db.Exec("DELETE FROM boards")
-	ts.db.Exec("DELETE FROM projects")
-	ts.db.Exec("DELETE FROM organization_members")
-	ts.db.Exec("DELETE FROM organizations")
-	ts.db.Exec("DELETE FROM users")
+	testsupport.ResetDB(t, ts.db)
 }
 
 func (ts *OrgProjectTestServer) executeGraphQL(t *testing.T, query string, cookies []*http.Cookie) (*GraphQLResponse, []*http.Cookie) {
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -20,27 +19,35 @@ import (
 	columnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
 	invRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/invitation"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	memberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	permRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
-	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
+	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
 	invitationSvc "github.com/thatcatdev/kaimu/backend/internal/services/invitation"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacSvc "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
-	"gorm.io/driver/postgres"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -50,40 +57,7 @@ type RBACTestServer struct {
 }
 
 func setupRBACTestServer(t *testing.T) *RBACTestServer {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up any existing tables first to ensure clean schema
-	cleanupRBACTables(testDB)
-
-	// Seed permissions and system roles
-	seedRBACData(t, testDB)
+	testDB := testsupport.NewTestDB(t)
 
 	// Create repositories
 	userRepository := userRepo.NewRepository(testDB)
@@ -100,14 +74,18 @@ func setupRBACTestServer(t *testing.T) *RBACTestServer {
 	cardRepository := cardRepo.NewRepository(testDB)
 	tagRepository := tagRepo.NewRepository(testDB)
 	cardTagRepository := cardTagRepo.NewRepository(testDB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(testDB)
 	refreshRepository := refreshTokenRepo.NewRepository(testDB)
 
 	// Create services
 	authSvc := auth.NewService(userRepository, refreshRepository, "test-jwt-secret", 15, 7)
-	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository)
-	projSvc := projectService.NewService(projectRepository, orgRepository)
-	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository)
-	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository)
+	txManager := transaction.NewManager(testDB)
+	eventBus := events.NewSyncBus()
+	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository, txManager, eventBus)
+	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository, txManager, eventBus)
+	projSvc := projectService.NewService(projectRepository, orgRepository, boardSvc, txManager, eventBus)
+	workflowSvc := workflowService.NewService(columnTransitionRepository, boardRepository, columnRepository)
+	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository, columnDefaultsRepo.NewRepository(testDB), workflowSvc, contentService.NewService(boardRepository, projectRepository, orgRepository, contentService.Limits{}), calendarService.NewService(projectCalendarRepo.NewRepository(testDB), projectRepository), txManager, eventBus)
 	tagSvc := tagService.NewService(tagRepository, projectRepository)
 	rbacService := rbacSvc.NewService(
 		permRepository,
@@ -125,8 +103,14 @@ func setupRBACTestServer(t *testing.T) *RBACTestServer {
 		memberRepository,
 		userRepository,
 		roleRepository,
+		projectRepository,
+		projectMemberRepository,
 		nil, // mail service not needed for tests
+		nil,
 		config.EmailConfig{},
+		config.MembershipConfig{MaxGuestsPerOrg: 1},
+		txManager,
+		eventBus,
 	)
 
 	// Create resolver
@@ -142,6 +126,7 @@ func setupRBACTestServer(t *testing.T) *RBACTestServer {
 		ProjectService:      projSvc,
 		BoardService:        boardSvc,
 		CardService:         cardSvc,
+		WorkflowService:     workflowSvc,
 		TagService:          tagSvc,
 		RBACService:         rbacService,
 		InvitationService:   invSvc,
@@ -150,12 +135,12 @@ func setupRBACTestServer(t *testing.T) *RBACTestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(rbacService, invSvc),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authSvc)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authSvc, nil)(srv)
 
 	return &RBACTestServer{
 		handler: wrappedHandler,
@@ -163,109 +148,8 @@ func setupRBACTestServer(t *testing.T) *RBACTestServer {
 	}
 }
 
-func cleanupRBACTables(db *gorm.DB) {
-	// Clean up data from tables (in correct order for foreign key constraints)
-	db.Exec("DELETE FROM card_tags")
-	db.Exec("DELETE FROM cards")
-	db.Exec("DELETE FROM tags")
-	db.Exec("DELETE FROM board_columns")
-	db.Exec("DELETE FROM boards")
-	db.Exec("DELETE FROM invitations")
-	db.Exec("DELETE FROM project_members")
-	// Only delete role_permissions for non-system roles (keep seed data for system roles)
-	db.Exec("DELETE FROM role_permissions WHERE role_id NOT IN ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000004')")
-	db.Exec("DELETE FROM organization_members")
-	db.Exec("DELETE FROM projects")
-	db.Exec("DELETE FROM roles WHERE is_system = false")
-	// Don't delete permissions - this is seed data needed by all tests
-	db.Exec("DELETE FROM organizations")
-	db.Exec("DELETE FROM refresh_tokens")
-	db.Exec("DELETE FROM users")
-}
-
-func seedRBACData(t *testing.T, db *gorm.DB) {
-	// Insert permissions if not exists
-	err := db.Exec(`
-		INSERT INTO permissions (code, name, description, resource_type) VALUES
-		('org:view', 'View Organization', 'Can view organization details', 'organization'),
-		('org:manage', 'Manage Organization', 'Can edit organization settings', 'organization'),
-		('org:delete', 'Delete Organization', 'Can delete the organization', 'organization'),
-		('org:invite', 'Invite Members', 'Can invite new members to organization', 'organization'),
-		('org:remove_members', 'Remove Members', 'Can remove members from organization', 'organization'),
-		('org:manage_roles', 'Manage Roles', 'Can create and edit custom roles', 'organization'),
-		('project:view', 'View Project', 'Can view project details', 'project'),
-		('project:create', 'Create Project', 'Can create new projects', 'project'),
-		('project:manage', 'Manage Project', 'Can edit project settings', 'project'),
-		('project:delete', 'Delete Project', 'Can delete projects', 'project'),
-		('project:manage_members', 'Manage Project Members', 'Can add/remove project members', 'project'),
-		('board:view', 'View Board', 'Can view board and columns', 'board'),
-		('board:create', 'Create Board', 'Can create new boards', 'board'),
-		('board:manage', 'Manage Board', 'Can edit board settings and columns', 'board'),
-		('board:delete', 'Delete Board', 'Can delete boards', 'board'),
-		('card:view', 'View Cards', 'Can view cards on boards', 'card'),
-		('card:create', 'Create Cards', 'Can create new cards', 'card'),
-		('card:edit', 'Edit Cards', 'Can edit card details', 'card'),
-		('card:move', 'Move Cards', 'Can move cards between columns', 'card'),
-		('card:delete', 'Delete Cards', 'Can delete cards', 'card'),
-		('card:assign', 'Assign Cards', 'Can assign cards to users', 'card')
-		ON CONFLICT (code) DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed permissions")
-
-	// Insert system roles if not exists
-	err = db.Exec(`
-		INSERT INTO roles (id, organization_id, name, description, is_system, scope) VALUES
-		('00000000-0000-0000-0000-000000000001', NULL, 'Owner', 'Full access to everything', TRUE, 'organization'),
-		('00000000-0000-0000-0000-000000000002', NULL, 'Admin', 'Administrative access', TRUE, 'organization'),
-		('00000000-0000-0000-0000-000000000003', NULL, 'Member', 'Standard member', TRUE, 'organization'),
-		('00000000-0000-0000-0000-000000000004', NULL, 'Viewer', 'Read-only access', TRUE, 'organization')
-		ON CONFLICT DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed roles")
-
-	// Owner gets all permissions
-	err = db.Exec(`
-		INSERT INTO role_permissions (role_id, permission_id)
-		SELECT '00000000-0000-0000-0000-000000000001', id FROM permissions
-		ON CONFLICT DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed owner permissions")
-
-	// Admin gets all except org:delete and org:manage_roles
-	err = db.Exec(`
-		INSERT INTO role_permissions (role_id, permission_id)
-		SELECT '00000000-0000-0000-0000-000000000002', id FROM permissions
-		WHERE code NOT IN ('org:delete', 'org:manage_roles')
-		ON CONFLICT DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed admin permissions")
-
-	// Member gets view + create + edit
-	err = db.Exec(`
-		INSERT INTO role_permissions (role_id, permission_id)
-		SELECT '00000000-0000-0000-0000-000000000003', id FROM permissions
-		WHERE code IN (
-			'org:view',
-			'project:view', 'project:create',
-			'board:view', 'board:create',
-			'card:view', 'card:create', 'card:edit', 'card:move', 'card:assign'
-		)
-		ON CONFLICT DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed member permissions")
-
-	// Viewer gets read-only
-	err = db.Exec(`
-		INSERT INTO role_permissions (role_id, permission_id)
-		SELECT '00000000-0000-0000-0000-000000000004', id FROM permissions
-		WHERE code IN ('org:view', 'project:view', 'board:view', 'card:view')
-		ON CONFLICT DO NOTHING
-	`).Error
-	require.NoError(t, err, "Failed to seed viewer permissions")
-}
-
 func (ts *RBACTestServer) cleanup(t *testing.T) {
-	cleanupRBACTables(ts.db)
+	testsupport.ResetDB(t, ts.db)
 }
 
 func (ts *RBACTestServer) executeGraphQL(t *testing.T, query string, cookies []*http.Cookie) (*GraphQLResponse, []*http.Cookie) {
@@ -938,6 +822,82 @@ func TestRBAC_OrganizationMembers_Query(t *testing.T) {
 	assert.Equal(t, "Owner", data.OrganizationMembers[0].Role.Name)
 }
 
+func TestRBAC_OrganizationDirectory_Query(t *testing.T) {
+	ts := setupRBACTestServer(t)
+	defer ts.cleanup(t)
+
+	ownerCookies := ts.registerUser(t, "dirowner", "password123")
+	orgID := ts.createOrganization(t, ownerCookies, "Directory Org")
+	aliceCookies := ts.registerUser(t, "diralice", "password123")
+	ts.inviteAndAccept(t, ownerCookies, aliceCookies, orgID, "diralice@test.com", "00000000-0000-0000-0000-000000000003")
+	bobCookies := ts.registerUser(t, "dirbob", "password123")
+	ts.inviteAndAccept(t, ownerCookies, bobCookies, orgID, "dirbob@test.com", "00000000-0000-0000-0000-000000000004")
+
+	type directory struct {
+		OrganizationDirectory struct {
+			Edges []struct {
+				Node struct {
+					User struct {
+						Username string `json:"username"`
+					} `json:"user"`
+					LastActiveAt *string `json:"lastActiveAt"`
+				} `json:"node"`
+				Cursor string `json:"cursor"`
+			} `json:"edges"`
+			PageInfo struct {
+				HasNextPage bool `json:"hasNextPage"`
+				TotalCount  int  `json:"totalCount"`
+			} `json:"pageInfo"`
+		} `json:"organizationDirectory"`
+	}
+	queryDirectoryAs := func(cookies []*http.Cookie, args string) directory {
+		query := fmt.Sprintf(`query {
+			organizationDirectory(organizationId: "%s"%s) {
+				edges { node { user { username } lastActiveAt } cursor }
+				pageInfo { hasNextPage totalCount }
+			}
+		}`, orgID, args)
+		resp, _ := ts.executeGraphQL(t, query, cookies)
+		require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+		var data directory
+		json.Unmarshal(resp.Data, &data)
+		return data
+	}
+	queryDirectory := func(args string) directory {
+		return queryDirectoryAs(ownerCookies, args)
+	}
+	usernames := func(d directory) []string {
+		var names []string
+		for _, e := range d.OrganizationDirectory.Edges {
+			names = append(names, e.Node.User.Username)
+		}
+		return names
+	}
+
+	// Sorted by name, one page at a time
+	page := queryDirectory(`, first: 2`)
+	assert.Equal(t, []string{"diralice", "dirbob"}, usernames(page))
+	assert.True(t, page.OrganizationDirectory.PageInfo.HasNextPage)
+	assert.Equal(t, 3, page.OrganizationDirectory.PageInfo.TotalCount)
+	assert.NotNil(t, page.OrganizationDirectory.Edges[0].Node.LastActiveAt, "registering starts a session")
+
+	next := queryDirectory(fmt.Sprintf(`, first: 2, after: "%s"`, page.OrganizationDirectory.Edges[1].Cursor))
+	assert.Equal(t, []string{"dirowner"}, usernames(next))
+	assert.False(t, next.OrganizationDirectory.PageInfo.HasNextPage)
+
+	assert.Equal(t, []string{"dirowner", "dirbob", "diralice"}, usernames(queryDirectory(`, descending: true`)))
+
+	// Search and role filter
+	assert.Equal(t, []string{"dirbob"}, usernames(queryDirectory(`, filter: { search: "BOB" }`)))
+	assert.Equal(t, []string{"diralice"}, usernames(queryDirectory(`, filter: { roleId: "00000000-0000-0000-0000-000000000003" }`)))
+	assert.Empty(t, usernames(queryDirectory(`, filter: { search: "%" }`)))
+
+	// Only those who manage members find them by email
+	assert.Equal(t, []string{"diralice"}, usernames(queryDirectory(`, filter: { search: "DIRALICE@TEST" }`)))
+	assert.Empty(t, usernames(queryDirectoryAs(bobCookies, `, filter: { search: "diralice@test" }`)))
+	assert.Equal(t, []string{"diralice"}, usernames(queryDirectoryAs(bobCookies, `, filter: { search: "diralice" }`)))
+}
+
 func TestRBAC_ChangeMemberRole_Success(t *testing.T) {
 	ts := setupRBACTestServer(t)
 	defer ts.cleanup(t)
@@ -1434,6 +1394,247 @@ func TestRBAC_AcceptInvitation_Success(t *testing.T) {
 	assert.Contains(t, usernames, "acceptuser")
 }
 
+func TestRBAC_AcceptProjectInvitation(t *testing.T) {
+	ts := setupRBACTestServer(t)
+	defer ts.cleanup(t)
+
+	ownerCookies := ts.registerUser(t, "projinviteowner", "password123")
+	orgID := ts.createOrganization(t, ownerCookies, "Project Invite Org")
+	projectID := ts.createProject(t, ownerCookies, orgID, "Shared Project", "SHP")
+
+	// Invite straight into the project as Member, with the default Viewer org role
+	inviteQuery := fmt.Sprintf(`mutation {
+		inviteMember(input: {
+			organizationId: "%s"
+			email: "projectguest@test.com"
+			projectId: "%s"
+			projectRoleId: "00000000-0000-0000-0000-000000000003"
+		}) {
+			token
+			role { name }
+			project { id }
+			projectRole { name }
+		}
+	}`, orgID, projectID)
+
+	resp, _ := ts.executeGraphQL(t, inviteQuery, ownerCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+
+	var inviteData struct {
+		InviteMember struct {
+			Token string `json:"token"`
+			Role  struct {
+				Name string `json:"name"`
+			} `json:"role"`
+			Project struct {
+				ID string `json:"id"`
+			} `json:"project"`
+			ProjectRole struct {
+				Name string `json:"name"`
+			} `json:"projectRole"`
+		} `json:"inviteMember"`
+	}
+	json.Unmarshal(resp.Data, &inviteData)
+	assert.Equal(t, "Viewer", inviteData.InviteMember.Role.Name)
+	assert.Equal(t, projectID, inviteData.InviteMember.Project.ID)
+	assert.Equal(t, "Member", inviteData.InviteMember.ProjectRole.Name)
+
+	guestCookies := ts.registerUser(t, "projectguest", "password123")
+	acceptQuery := fmt.Sprintf(`mutation { acceptInvitation(token: "%s") { id } }`, inviteData.InviteMember.Token)
+	resp, _ = ts.executeGraphQL(t, acceptQuery, guestCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+
+	// The project membership was created together with the org membership
+	membersQuery := fmt.Sprintf(`query {
+		projectMembers(projectId: "%s") {
+			user { username }
+			role { name }
+		}
+	}`, projectID)
+
+	resp, _ = ts.executeGraphQL(t, membersQuery, ownerCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+	var membersData struct {
+		ProjectMembers []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+			Role *struct {
+				Name string `json:"name"`
+			} `json:"role"`
+		} `json:"projectMembers"`
+	}
+	json.Unmarshal(resp.Data, &membersData)
+
+	var found bool
+	for _, m := range membersData.ProjectMembers {
+		if m.User.Username == "projectguest" {
+			found = true
+			require.NotNil(t, m.Role)
+			assert.Equal(t, "Member", m.Role.Name)
+		}
+	}
+	assert.True(t, found, "guest should be a project member")
+}
+
+func TestRBAC_InviteMember_ProjectOfOtherOrg(t *testing.T) {
+	ts := setupRBACTestServer(t)
+	defer ts.cleanup(t)
+
+	ownerCookies := ts.registerUser(t, "otherorgowner", "password123")
+	orgID := ts.createOrganization(t, ownerCookies, "Inviting Org")
+	otherOrgID := ts.createOrganization(t, ownerCookies, "Other Org")
+	otherProjectID := ts.createProject(t, ownerCookies, otherOrgID, "Other Project", "OTH")
+
+	inviteQuery := fmt.Sprintf(`mutation {
+		inviteMember(input: {
+			organizationId: "%s"
+			email: "someone@test.com"
+			projectId: "%s"
+		}) {
+			id
+		}
+	}`, orgID, otherProjectID)
+
+	resp, _ := ts.executeGraphQL(t, inviteQuery, ownerCookies)
+	assert.NotEmpty(t, resp.Errors, "Inviting into another organization's project should fail")
+}
+
+func TestRBAC_GuestOnlyReachesGrantedProject(t *testing.T) {
+	ts := setupRBACTestServer(t)
+	defer ts.cleanup(t)
+
+	ownerCookies := ts.registerUser(t, "guestorgowner", "password123")
+	orgID := ts.createOrganization(t, ownerCookies, "Guest Org")
+	sharedProjectID := ts.createProject(t, ownerCookies, orgID, "Shared Project", "SHR")
+	privateProjectID := ts.createProject(t, ownerCookies, orgID, "Private Project", "PRV")
+	_, privateColumnID := ts.getBoard(t, ownerCookies, privateProjectID)
+	privateCardID := ts.createCard(t, ownerCookies, privateColumnID, "Internal Card")
+
+	inviteQuery := fmt.Sprintf(`mutation {
+		inviteMember(input: {
+			organizationId: "%s"
+			email: "contractor@test.com"
+			roleId: "00000000-0000-0000-0000-000000000003"
+			projectId: "%s"
+			guest: true
+		}) {
+			token
+			isGuest
+		}
+	}`, orgID, sharedProjectID)
+
+	resp, _ := ts.executeGraphQL(t, inviteQuery, ownerCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+	var inviteData struct {
+		InviteMember struct {
+			Token   string `json:"token"`
+			IsGuest bool   `json:"isGuest"`
+		} `json:"inviteMember"`
+	}
+	json.Unmarshal(resp.Data, &inviteData)
+	assert.True(t, inviteData.InviteMember.IsGuest)
+
+	guestCookies := ts.registerUser(t, "contractor", "password123")
+	acceptQuery := fmt.Sprintf(`mutation { acceptInvitation(token: "%s") { id } }`, inviteData.InviteMember.Token)
+	resp, _ = ts.executeGraphQL(t, acceptQuery, guestCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+
+	// The guest only sees the project they were added to
+	orgQuery := fmt.Sprintf(`query { organization(id: "%s") { projects { id } } }`, orgID)
+	resp, _ = ts.executeGraphQL(t, orgQuery, guestCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+	var orgData struct {
+		Organization struct {
+			Projects []struct {
+				ID string `json:"id"`
+			} `json:"projects"`
+		} `json:"organization"`
+	}
+	json.Unmarshal(resp.Data, &orgData)
+	require.Len(t, orgData.Organization.Projects, 1)
+	assert.Equal(t, sharedProjectID, orgData.Organization.Projects[0].ID)
+
+	// and cannot reach the other projects' cards, though members of the org can
+	viewCardQuery := fmt.Sprintf(`query { card(id: "%s") { id } }`, privateCardID)
+	resp, _ = ts.executeGraphQL(t, viewCardQuery, guestCookies)
+	assert.NotEmpty(t, resp.Errors, "Guest should not be able to view cards of other projects")
+
+	// Guests are listed apart from the members
+	membersQuery := fmt.Sprintf(`query {
+		organizationMembers(organizationId: "%s") { user { username } }
+		organizationGuests(organizationId: "%s") { user { username } isGuest }
+	}`, orgID, orgID)
+	resp, _ = ts.executeGraphQL(t, membersQuery, ownerCookies)
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+	var membersData struct {
+		OrganizationMembers []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"organizationMembers"`
+		OrganizationGuests []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+			IsGuest bool `json:"isGuest"`
+		} `json:"organizationGuests"`
+	}
+	json.Unmarshal(resp.Data, &membersData)
+	for _, m := range membersData.OrganizationMembers {
+		assert.NotEqual(t, "contractor", m.User.Username, "guests should not be listed as members")
+	}
+	require.Len(t, membersData.OrganizationGuests, 1)
+	assert.Equal(t, "contractor", membersData.OrganizationGuests[0].User.Username)
+	assert.True(t, membersData.OrganizationGuests[0].IsGuest)
+
+	// Guests cannot invite
+	guestInviteQuery := fmt.Sprintf(`mutation {
+		inviteMember(input: { organizationId: "%s", email: "friend@test.com" }) { id }
+	}`, orgID)
+	resp, _ = ts.executeGraphQL(t, guestInviteQuery, guestCookies)
+	assert.NotEmpty(t, resp.Errors, "Guests should not be able to invite")
+}
+
+func TestRBAC_InviteGuest_LimitReached(t *testing.T) {
+	ts := setupRBACTestServer(t)
+	defer ts.cleanup(t)
+
+	ownerCookies := ts.registerUser(t, "guestlimitowner", "password123")
+	orgID := ts.createOrganization(t, ownerCookies, "Guest Limit Org")
+	projectID := ts.createProject(t, ownerCookies, orgID, "Limited Project", "LIM")
+
+	inviteGuest := func(email string) *GraphQLResponse {
+		query := fmt.Sprintf(`mutation {
+			inviteMember(input: {
+				organizationId: "%s"
+				email: "%s"
+				projectId: "%s"
+				guest: true
+			}) {
+				id
+			}
+		}`, orgID, email, projectID)
+		resp, _ := ts.executeGraphQL(t, query, ownerCookies)
+		return resp
+	}
+
+	// The test server allows one guest, and a pending invitation holds the place
+	resp := inviteGuest("firstguest@test.com")
+	require.Empty(t, resp.Errors, "Expected no errors, got: %v", resp.Errors)
+
+	resp = inviteGuest("secondguest@test.com")
+	require.NotEmpty(t, resp.Errors, "Expected the guest limit to be enforced")
+	assert.Contains(t, resp.Errors[0].Message, "guest limit")
+
+	// Guest invitations need a project
+	query := fmt.Sprintf(`mutation {
+		inviteMember(input: { organizationId: "%s", email: "noproject@test.com", guest: true }) { id }
+	}`, orgID)
+	resp, _ = ts.executeGraphQL(t, query, ownerCookies)
+	assert.NotEmpty(t, resp.Errors, "Expected a guest invitation without project to fail")
+}
+
 func TestRBAC_AcceptInvitation_InvalidToken(t *testing.T) {
 	ts := setupRBACTestServer(t)
 	defer ts.cleanup(t)
@@ -22,28 +22,36 @@ import (
 	columnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	memberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	permissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
 	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	"github.com/thatcatdev/kaimu/backend/internal/services/search"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"github.com/typesense/typesense-go/v2/typesense"
 	"github.com/typesense/typesense-go/v2/typesense/api"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -83,38 +91,7 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 		t.Skipf("Skipping integration test: could not connect to Typesense at %s:%s: %v", tsHost, tsPort, err)
 	}
 
-	// Database setup (same as other integration tests)
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test
-	cleanupTestData(testDB)
+	testDB := testsupport.NewTestDB(t)
 
 	// Create repositories
 	userRepository := userRepo.NewRepository(testDB)
@@ -127,6 +104,7 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 	cardRepository := cardRepo.NewRepository(testDB)
 	tagRepository := tagRepo.NewRepository(testDB)
 	cardTagRepository := cardTagRepo.NewRepository(testDB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(testDB)
 	refreshRepository := refreshTokenRepo.NewRepository(testDB)
 	permissionRepository := permissionRepo.NewRepository(testDB)
 	roleRepository := roleRepo.NewRepository(testDB)
@@ -136,7 +114,7 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 	tsClientInterface := search.NewTypesenseClientFromRaw(tsClient)
 
 	// Create search service
-	searchSvc := search.NewService(tsClientInterface, memberRepository)
+	searchSvc := search.NewService(tsClientInterface, memberRepository, nil, nil)
 
 	// Initialize search collections
 	err = searchSvc.InitializeCollections(context.Background())
@@ -146,10 +124,13 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 
 	// Create services
 	authSvc := auth.NewService(userRepository, refreshRepository, "test-jwt-secret", 15, 7)
-	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository)
-	projSvc := projectService.NewService(projectRepository, orgRepository)
-	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository)
-	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository)
+	txManager := transaction.NewManager(testDB)
+	eventBus := events.NewSyncBus()
+	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository, txManager, eventBus)
+	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository, txManager, eventBus)
+	projSvc := projectService.NewService(projectRepository, orgRepository, boardSvc, txManager, eventBus)
+	workflowSvc := workflowService.NewService(columnTransitionRepository, boardRepository, columnRepository)
+	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository, columnDefaultsRepo.NewRepository(testDB), workflowSvc, contentService.NewService(boardRepository, projectRepository, orgRepository, contentService.Limits{}), calendarService.NewService(projectCalendarRepo.NewRepository(testDB), projectRepository), txManager, eventBus)
 	tagSvc := tagService.NewService(tagRepository, projectRepository)
 	rbacSvc := rbacService.NewService(
 		permissionRepository,
@@ -175,6 +156,7 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 		ProjectService:      projSvc,
 		BoardService:        boardSvc,
 		CardService:         cardSvc,
+		WorkflowService:     workflowSvc,
 		TagService:          tagSvc,
 		SearchService:       searchSvc,
 		RBACService:         rbacSvc,
@@ -183,12 +165,12 @@ func setupSearchTestServer(t *testing.T) *SearchTestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(rbacSvc, nil),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authSvc)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authSvc, nil)(srv)
 
 	return &SearchTestServer{
 		handler:       wrappedHandler,
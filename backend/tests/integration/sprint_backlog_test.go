@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
 	"time"
 
@@ -17,34 +16,46 @@ import (
 	"github.com/thatcatdev/kaimu/backend/graph"
 	"github.com/thatcatdev/kaimu/backend/graph/generated"
 	"github.com/thatcatdev/kaimu/backend/http/middleware"
+	auditRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
 	boardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board"
 	columnRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/board_column"
 	cardRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card"
 	cardTagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/card_tag"
-	auditRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/audit"
+	checklistRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/checklist"
+	columnDefaultsRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_defaults"
+	columnTransitionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/column_transition"
+	epicRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/epic"
 	metricsHistoryRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/metrics_history"
 	orgRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization"
 	memberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/organization_member"
 	permissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/permission"
 	projectRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project"
+	projectCalendarRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_calendar"
 	projectMemberRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/project_member"
 	refreshTokenRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/refreshtoken"
 	roleRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role"
 	rolePermissionRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/role_permission"
 	sprintRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/sprint"
 	tagRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/tag"
+	undoOperationRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/undo_operation"
 	userRepo "github.com/thatcatdev/kaimu/backend/internal/db/repositories/user"
+	"github.com/thatcatdev/kaimu/backend/internal/db/transaction"
 	"github.com/thatcatdev/kaimu/backend/internal/directives"
+	"github.com/thatcatdev/kaimu/backend/internal/events"
 	"github.com/thatcatdev/kaimu/backend/internal/services/auth"
 	boardService "github.com/thatcatdev/kaimu/backend/internal/services/board"
+	calendarService "github.com/thatcatdev/kaimu/backend/internal/services/calendar"
 	cardService "github.com/thatcatdev/kaimu/backend/internal/services/card"
+	contentService "github.com/thatcatdev/kaimu/backend/internal/services/content"
 	metricsService "github.com/thatcatdev/kaimu/backend/internal/services/metrics"
 	orgService "github.com/thatcatdev/kaimu/backend/internal/services/organization"
 	projectService "github.com/thatcatdev/kaimu/backend/internal/services/project"
 	rbacService "github.com/thatcatdev/kaimu/backend/internal/services/rbac"
 	sprintService "github.com/thatcatdev/kaimu/backend/internal/services/sprint"
 	tagService "github.com/thatcatdev/kaimu/backend/internal/services/tag"
-	"gorm.io/driver/postgres"
+	undoService "github.com/thatcatdev/kaimu/backend/internal/services/undo"
+	workflowService "github.com/thatcatdev/kaimu/backend/internal/services/workflow"
+	"github.com/thatcatdev/kaimu/backend/internal/testsupport"
 	"gorm.io/gorm"
 )
 
@@ -54,50 +65,7 @@ type SprintTestServer struct {
 }
 
 func setupSprintTestServer(t *testing.T) *SprintTestServer {
-	dbHost := os.Getenv("TEST_DB_HOST")
-	if dbHost == "" {
-		dbHost = "localhost"
-	}
-	dbPort := os.Getenv("TEST_DB_PORT")
-	if dbPort == "" {
-		dbPort = "5432"
-	}
-	dbUser := os.Getenv("TEST_DB_USER")
-	if dbUser == "" {
-		dbUser = "pulse"
-	}
-	dbPassword := os.Getenv("TEST_DB_PASSWORD")
-	if dbPassword == "" {
-		dbPassword = "mysecretpassword"
-	}
-	dbName := os.Getenv("TEST_DB_NAME")
-	if dbName == "" {
-		dbName = "pulse_test"
-	}
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
-
-	testDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		t.Skipf("Skipping integration test: could not connect to test database: %v", err)
-	}
-
-	// Clean up tables before test (order matters due to foreign keys)
-	testDB.Exec("DELETE FROM audit_log")
-	testDB.Exec("DELETE FROM metrics_history")
-	testDB.Exec("DELETE FROM card_sprints")
-	testDB.Exec("DELETE FROM sprints")
-	testDB.Exec("DELETE FROM card_tags")
-	testDB.Exec("DELETE FROM cards")
-	testDB.Exec("DELETE FROM tags")
-	testDB.Exec("DELETE FROM board_columns")
-	testDB.Exec("DELETE FROM boards")
-	testDB.Exec("DELETE FROM projects")
-	testDB.Exec("DELETE FROM organization_members")
-	testDB.Exec("DELETE FROM organizations")
-	testDB.Exec("DELETE FROM refresh_tokens")
-	testDB.Exec("DELETE FROM users")
+	testDB := testsupport.NewTestDB(t)
 
 	// Create repositories
 	userRepository := userRepo.NewRepository(testDB)
@@ -110,7 +78,9 @@ func setupSprintTestServer(t *testing.T) *SprintTestServer {
 	cardRepository := cardRepo.NewRepository(testDB)
 	tagRepository := tagRepo.NewRepository(testDB)
 	cardTagRepository := cardTagRepo.NewRepository(testDB)
+	columnTransitionRepository := columnTransitionRepo.NewRepository(testDB)
 	sprintRepository := sprintRepo.NewRepository(testDB)
+	undoOperationRepository := undoOperationRepo.NewRepository(testDB)
 	metricsHistoryRepository := metricsHistoryRepo.NewRepository(testDB)
 	refreshRepository := refreshTokenRepo.NewRepository(testDB)
 	auditRepository := auditRepo.NewRepository(testDB)
@@ -120,13 +90,18 @@ func setupSprintTestServer(t *testing.T) *SprintTestServer {
 
 	// Create services
 	authSvc := auth.NewService(userRepository, refreshRepository, "test-jwt-secret", 15, 7)
-	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository)
-	projSvc := projectService.NewService(projectRepository, orgRepository)
-	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository)
-	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository)
+	txManager := transaction.NewManager(testDB)
+	eventBus := events.NewSyncBus()
+	orgSvc := orgService.NewService(orgRepository, memberRepository, userRepository, txManager, eventBus)
+	boardSvc := boardService.NewService(boardRepository, columnRepository, projectRepository, txManager, eventBus)
+	projSvc := projectService.NewService(projectRepository, orgRepository, boardSvc, txManager, eventBus)
+	workflowSvc := workflowService.NewService(columnTransitionRepository, boardRepository, columnRepository)
+	cardSvc := cardService.NewService(cardRepository, columnRepository, boardRepository, tagRepository, cardTagRepository, columnDefaultsRepo.NewRepository(testDB), workflowSvc, contentService.NewService(boardRepository, projectRepository, orgRepository, contentService.Limits{}), calendarService.NewService(projectCalendarRepo.NewRepository(testDB), projectRepository), txManager, eventBus)
 	tagSvc := tagService.NewService(tagRepository, projectRepository)
-	sprintSvc := sprintService.NewService(sprintRepository, cardRepository, boardRepository, columnRepository)
-	metricsSvc := metricsService.NewService(sprintRepository, cardRepository, columnRepository, metricsHistoryRepository, auditRepository)
+	undoSvc := undoService.NewService(undoOperationRepository, sprintRepository, cardRepository, txManager, eventBus)
+	sprintSvc := sprintService.NewService(sprintRepository, cardRepository, boardRepository, columnRepository, undoSvc, txManager, eventBus)
+	sprintService.NewMembershipSyncer(sprintSvc).Subscribe(eventBus)
+	metricsSvc := metricsService.NewService(sprintRepository, cardRepository, columnRepository, metricsHistoryRepository, auditRepository, checklistRepo.NewRepository(testDB), epicRepo.NewRepository(testDB))
 	rbacSvc := rbacService.NewService(
 		permissionRepository,
 		roleRepository,
@@ -151,8 +126,10 @@ func setupSprintTestServer(t *testing.T) *SprintTestServer {
 		ProjectService:      projSvc,
 		BoardService:        boardSvc,
 		CardService:         cardSvc,
+		WorkflowService:     workflowSvc,
 		TagService:          tagSvc,
 		SprintService:       sprintSvc,
+		UndoService:         undoSvc,
 		MetricsService:      metricsSvc,
 		RBACService:         rbacSvc,
 	}
@@ -160,12 +137,12 @@ func setupSprintTestServer(t *testing.T) *SprintTestServer {
 	// Create GraphQL handler
 	gqlConfig := generated.Config{
 		Resolvers:  resolver,
-		Directives: directives.GetDirectives(),
+		Directives: directives.GetDirectives(rbacSvc, nil),
 	}
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(gqlConfig))
 
 	// Wrap with auth middleware
-	wrappedHandler := middleware.AuthMiddleware(authSvc)(srv)
+	wrappedHandler := middleware.AuthMiddleware(authSvc, nil)(srv)
 
 	return &SprintTestServer{
 		handler: wrappedHandler,
@@ -174,6 +151,7 @@ func setupSprintTestServer(t *testing.T) *SprintTestServer {
 }
 
 func (s *SprintTestServer) cleanup() {
+	s.db.Exec("DELETE FROM undo_operations")
 	s.db.Exec("DELETE FROM metrics_history")
 	s.db.Exec("DELETE FROM card_sprints")
 	s.db.Exec("DELETE FROM sprints")
@@ -532,6 +510,89 @@ func TestMoveCardToBacklogRemovesFromSprint(t *testing.T) {
 	assert.Equal(t, 0, len(moveData.MoveCardToBacklog.Sprints))
 }
 
+func TestSprintAutoMembershipPolicy(t *testing.T) {
+	server := setupSprintTestServer(t)
+	defer server.cleanup()
+
+	token, err := server.registerUser("policyuser", "password123")
+	require.NoError(t, err)
+
+	_, boardID, columns := server.setupProject(t, token, "Policy Test", "POL")
+
+	policyResp := server.executeQuery(fmt.Sprintf(`mutation {
+		setBoardSprintAutoMembership(boardId: "%s", enabled: true) { id sprintAutoMembership }
+	}`, boardID), token)
+	require.Empty(t, policyResp.Errors, "Set policy errors: %v", policyResp.Errors)
+	var policyData struct {
+		SetBoardSprintAutoMembership struct {
+			SprintAutoMembership bool `json:"sprintAutoMembership"`
+		} `json:"setBoardSprintAutoMembership"`
+	}
+	json.Unmarshal(policyResp.Data, &policyData)
+	assert.True(t, policyData.SetBoardSprintAutoMembership.SprintAutoMembership)
+
+	// Create and start a sprint
+	sprintResp := server.executeQuery(fmt.Sprintf(`mutation {
+		createSprint(input: { boardId: "%s", name: "Policy Sprint" }) { id }
+	}`, boardID), token)
+	var sprintData struct {
+		CreateSprint struct {
+			ID string `json:"id"`
+		} `json:"createSprint"`
+	}
+	json.Unmarshal(sprintResp.Data, &sprintData)
+	sprintID := sprintData.CreateSprint.ID
+	server.executeQuery(fmt.Sprintf(`mutation { startSprint(id: "%s") { id } }`, sprintID), token)
+
+	// Create a card in the backlog
+	cardResp := server.executeQuery(fmt.Sprintf(`mutation {
+		createCard(input: { columnId: "%s", title: "Planned work" }) { id }
+	}`, columns["Backlog"]), token)
+	var cardData struct {
+		CreateCard struct {
+			ID string `json:"id"`
+		} `json:"createCard"`
+	}
+	json.Unmarshal(cardResp.Data, &cardData)
+	cardID := cardData.CreateCard.ID
+
+	cardSprints := func() []string {
+		resp := server.executeQuery(fmt.Sprintf(`query { card(id: "%s") { sprints { id } } }`, cardID), token)
+		require.Empty(t, resp.Errors)
+		var data struct {
+			Card struct {
+				Sprints []struct {
+					ID string `json:"id"`
+				} `json:"sprints"`
+			} `json:"card"`
+		}
+		json.Unmarshal(resp.Data, &data)
+		ids := []string{}
+		for _, sp := range data.Card.Sprints {
+			ids = append(ids, sp.ID)
+		}
+		return ids
+	}
+	moveCard := func(columnID string) {
+		resp := server.executeQuery(fmt.Sprintf(`mutation {
+			moveCard(input: { cardId: "%s", targetColumnId: "%s" }) { id }
+		}`, cardID, columnID), token)
+		require.Empty(t, resp.Errors, "Move card errors: %v", resp.Errors)
+	}
+
+	// Moving the card out of the backlog adds it to the active sprint
+	moveCard(columns["Todo"])
+	assert.Equal(t, []string{sprintID}, cardSprints())
+
+	// Moves between sprint columns leave it there
+	moveCard(columns["In Progress"])
+	assert.Equal(t, []string{sprintID}, cardSprints())
+
+	// Moving it back to the backlog removes it again
+	moveCard(columns["Backlog"])
+	assert.Empty(t, cardSprints())
+}
+
 func TestGetBacklogCards(t *testing.T) {
 	server := setupSprintTestServer(t)
 	defer server.cleanup()
@@ -843,3 +904,112 @@ func TestCompleteSprint(t *testing.T) {
 	}
 	assert.False(t, inSprint, "Card should not be in completed sprint after moveIncompleteToBacklog=true")
 }
+
+func TestUndoCompleteSprint(t *testing.T) {
+	server := setupSprintTestServer(t)
+	defer server.cleanup()
+
+	token, err := server.registerUser("undouser", "password123")
+	require.NoError(t, err)
+
+	_, boardID, columns := server.setupProject(t, token, "Undo Sprint Test", "UST")
+
+	createSprint := func(name string) string {
+		resp := server.executeQuery(fmt.Sprintf(`mutation {
+			createSprint(input: { boardId: "%s", name: "%s" }) { id }
+		}`, boardID, name), token)
+		require.Empty(t, resp.Errors)
+
+		var data struct {
+			CreateSprint struct {
+				ID string `json:"id"`
+			} `json:"createSprint"`
+		}
+		json.Unmarshal(resp.Data, &data)
+		return data.CreateSprint.ID
+	}
+
+	sprintID := createSprint("Current Sprint")
+	nextSprintID := createSprint("Next Sprint")
+	server.executeQuery(fmt.Sprintf(`mutation { startSprint(id: "%s") { id } }`, sprintID), token)
+
+	cardResp := server.executeQuery(fmt.Sprintf(`mutation {
+		createCard(input: { columnId: "%s", title: "Unfinished Card" }) { id }
+	}`, columns["Todo"]), token)
+	var cardData struct {
+		CreateCard struct {
+			ID string `json:"id"`
+		} `json:"createCard"`
+	}
+	json.Unmarshal(cardResp.Data, &cardData)
+	cardID := cardData.CreateCard.ID
+
+	server.executeQuery(fmt.Sprintf(`mutation {
+		addCardToSprint(input: { cardId: "%s", sprintId: "%s" }) { id }
+	}`, cardID, sprintID), token)
+
+	completeResp := server.executeQuery(fmt.Sprintf(`mutation {
+		completeSprint(id: "%s", moveIncompleteToNextSprint: true) { id status }
+	}`, sprintID), token)
+	require.Empty(t, completeResp.Errors)
+
+	cardSprintIDs := func() []string {
+		resp := server.executeQuery(fmt.Sprintf(`query { card(id: "%s") { sprints { id } } }`, cardID), token)
+		var data struct {
+			Card struct {
+				Sprints []struct {
+					ID string `json:"id"`
+				} `json:"sprints"`
+			} `json:"card"`
+		}
+		json.Unmarshal(resp.Data, &data)
+
+		var ids []string
+		for _, s := range data.Card.Sprints {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+	require.ElementsMatch(t, []string{sprintID, nextSprintID}, cardSprintIDs())
+
+	// The completion is listed as undoable
+	listQuery := fmt.Sprintf(`query { undoableOperations(boardId: "%s") { id kind entityId } }`, boardID)
+	listResp := server.executeQuery(listQuery, token)
+	require.Empty(t, listResp.Errors)
+
+	var listData struct {
+		UndoableOperations []struct {
+			ID       string `json:"id"`
+			Kind     string `json:"kind"`
+			EntityID string `json:"entityId"`
+		} `json:"undoableOperations"`
+	}
+	json.Unmarshal(listResp.Data, &listData)
+	require.Len(t, listData.UndoableOperations, 1)
+	assert.Equal(t, "COMPLETE_SPRINT", listData.UndoableOperations[0].Kind)
+	assert.Equal(t, sprintID, listData.UndoableOperations[0].EntityID)
+	operationID := listData.UndoableOperations[0].ID
+
+	// Undo reactivates the sprint and takes the carried card back out of the next sprint
+	undoQuery := fmt.Sprintf(`mutation { undoOperation(operationId: "%s") { id undoneAt } }`, operationID)
+	undoResp := server.executeQuery(undoQuery, token)
+	require.Empty(t, undoResp.Errors, "Undo errors: %v", undoResp.Errors)
+
+	sprintResp := server.executeQuery(fmt.Sprintf(`query { sprint(id: "%s") { status } }`, sprintID), token)
+	var sprintData struct {
+		Sprint struct {
+			Status string `json:"status"`
+		} `json:"sprint"`
+	}
+	json.Unmarshal(sprintResp.Data, &sprintData)
+	assert.Equal(t, "ACTIVE", sprintData.Sprint.Status)
+	assert.Equal(t, []string{sprintID}, cardSprintIDs())
+
+	// An operation can only be undone once
+	againResp := server.executeQuery(undoQuery, token)
+	assert.NotEmpty(t, againResp.Errors)
+
+	listResp = server.executeQuery(listQuery, token)
+	json.Unmarshal(listResp.Data, &listData)
+	assert.Empty(t, listData.UndoableOperations)
+}